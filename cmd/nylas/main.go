@@ -2,24 +2,32 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
+	"github.com/spf13/cobra"
+
 	"github.com/nylas/cli/internal/cli"
 	"github.com/nylas/cli/internal/cli/admin"
 	"github.com/nylas/cli/internal/cli/agent"
 	"github.com/nylas/cli/internal/cli/ai"
 	"github.com/nylas/cli/internal/cli/audit"
 	"github.com/nylas/cli/internal/cli/auth"
+	"github.com/nylas/cli/internal/cli/cache"
 	"github.com/nylas/cli/internal/cli/calendar"
-	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/cli/changes"
 	"github.com/nylas/cli/internal/cli/config"
 	"github.com/nylas/cli/internal/cli/contacts"
 	"github.com/nylas/cli/internal/cli/dashboard"
 	"github.com/nylas/cli/internal/cli/demo"
+	"github.com/nylas/cli/internal/cli/dev"
 	"github.com/nylas/cli/internal/cli/email"
+	"github.com/nylas/cli/internal/cli/gpg"
+	"github.com/nylas/cli/internal/cli/graph"
+	"github.com/nylas/cli/internal/cli/mailauth"
 	"github.com/nylas/cli/internal/cli/mcp"
+	"github.com/nylas/cli/internal/cli/migrate"
 	"github.com/nylas/cli/internal/cli/notetaker"
+	"github.com/nylas/cli/internal/cli/notify"
 	"github.com/nylas/cli/internal/cli/otp"
 	"github.com/nylas/cli/internal/cli/rpc"
 	"github.com/nylas/cli/internal/cli/scheduler"
@@ -33,39 +41,49 @@ import (
 )
 
 func main() {
-	// Add subcommands
-	rootCmd := cli.GetRootCmd()
-
-	// Enable command typo suggestions (e.g., "Did you mean 'email'?")
-	rootCmd.SuggestionsMinimumDistance = 2
-	rootCmd.AddCommand(ai.NewAICmd())
-	rootCmd.AddCommand(agent.NewAgentCmd())
-	rootCmd.AddCommand(audit.NewAuditCmd())
-	rootCmd.AddCommand(auth.NewAuthCmd())
-	rootCmd.AddCommand(config.NewConfigCmd())
-	rootCmd.AddCommand(otp.NewOTPCmd())
-	rootCmd.AddCommand(email.NewEmailCmd())
-	rootCmd.AddCommand(calendar.NewCalendarCmd())
-	rootCmd.AddCommand(contacts.NewContactsCmd())
-	rootCmd.AddCommand(dashboard.NewDashboardCmd())
-	rootCmd.AddCommand(setup.NewSetupCmd())
-	rootCmd.AddCommand(scheduler.NewSchedulerCmd())
-	rootCmd.AddCommand(admin.NewAdminCmd())
-	rootCmd.AddCommand(webhook.NewWebhookCmd())
-	rootCmd.AddCommand(notetaker.NewNotetakerCmd())
-	rootCmd.AddCommand(timezone.NewTimezoneCmd())
-	rootCmd.AddCommand(mcp.NewMCPCmd())
-	rootCmd.AddCommand(rpc.NewRPCCmd())
-	rootCmd.AddCommand(templatecmd.NewTemplateCmd())
-	rootCmd.AddCommand(demo.NewDemoCmd())
-	rootCmd.AddCommand(cli.NewTUICmd())
-	rootCmd.AddCommand(update.NewUpdateCmd())
-	rootCmd.AddCommand(workflow.NewWorkflowCmd())
-	rootCmd.AddCommand(workspace.NewWorkspaceCmd())
+	cli.SetCommands(commands)
 
 	if err := cli.Execute(); err != nil {
 		cli.LogAuditError(err)
-		fmt.Fprint(os.Stderr, common.FormatError(err))
-		os.Exit(1)
+		os.Exit(cli.ReportError(err))
+	}
+}
+
+// commands builds the top-level commands.
+func commands() []*cobra.Command {
+	return []*cobra.Command{
+		ai.NewAICmd(),
+		agent.NewAgentCmd(),
+		audit.NewAuditCmd(),
+		auth.NewAuthCmd(),
+		config.NewConfigCmd(),
+		otp.NewOTPCmd(),
+		email.NewEmailCmd(),
+		gpg.NewGPGCmd(),
+		mailauth.NewIMAPCmd(),
+		mailauth.NewSMTPCmd(),
+		migrate.NewMigrateCmd(),
+		cache.NewCacheCmd(),
+		calendar.NewCalendarCmd(),
+		contacts.NewContactsCmd(),
+		changes.NewChangesCmd(),
+		graph.NewGraphCmd(),
+		dashboard.NewDashboardCmd(),
+		setup.NewSetupCmd(),
+		scheduler.NewSchedulerCmd(),
+		admin.NewAdminCmd(),
+		webhook.NewWebhookCmd(),
+		notetaker.NewNotetakerCmd(),
+		notify.NewNotifyCmd(),
+		timezone.NewTimezoneCmd(),
+		mcp.NewMCPCmd(),
+		rpc.NewRPCCmd(),
+		templatecmd.NewTemplateCmd(),
+		demo.NewDemoCmd(),
+		dev.NewDevCmd(),
+		cli.NewTUICmd(),
+		update.NewUpdateCmd(),
+		workflow.NewWorkflowCmd(),
+		workspace.NewWorkspaceCmd(),
 	}
 }
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// structuredOutputCalls are the common helpers that write --json output.
+var structuredOutputCalls = map[string]bool{
+	"GetOutputWriter":          true,
+	"GetOutputWriterTo":        true,
+	"GetStreamWriter":          true,
+	"IsJSON":                   true,
+	"IsStructuredOutput":       true,
+	"WriteListWithColumns":     true,
+	"WriteListWithWideColumns": true,
+	"WritePage":                true,
+	"PrintCount":               true,
+	"PrintJSON":                true,
+}
+
+// nylasClientCalls are the common helpers that hand out an API client.
+var nylasClientCalls = map[string]bool{
+	"GetNylasClient":    true,
+	"WithClient":        true,
+	"WithClientNoGrant": true,
+}
+
+func commonCall(calls map[string]bool) func(pkg, name string) bool {
+	return func(pkg, name string) bool {
+		return (pkg == "" || pkg == "common") && calls[name]
+	}
+}
+
+// usesGPG matches the constructors of the gpg adapter.
+func usesGPG(pkg, name string) bool {
+	if pkg == "" || pkg == "common" {
+		return name == "NewGPGBackend"
+	}
+	return (pkg == "gpg" || pkg == "gpgAdapter") && strings.HasPrefix(name, "New")
+}
+
+var setCommandsOnce sync.Once
+
+// walkCommands calls fn for every runnable command of the full tree.
+func walkCommands(fn func(c *cobra.Command)) {
+	setCommandsOnce.Do(func() { cli.SetCommands(commands) })
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		if c.Name() == "demo" {
+			return // The real commands, checked on their own
+		}
+		if c.Runnable() {
+			fn(c)
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(cli.GetRootCmd())
+}
+
+// TestCommandsDeclareOutput fails for a command that writes --json output
+// without declaring its shape, which 'nylas schema' then can't describe.
+// A command writes structured output when the function that builds it, or
+// a function of the same package it calls, uses one of the output helpers.
+func TestCommandsDeclareOutput(t *testing.T) {
+	src := newSourceIndex()
+	writesOutput := commonCall(structuredOutputCalls)
+
+	walkCommands(func(c *cobra.Command) {
+		if common.OutputSchemaID(c) == "" && src.reaches(c, writesOutput) {
+			t.Errorf("%s writes --json output but declares no schema; wrap it in common.DeclareOutput", c.CommandPath())
+		}
+	})
+}
+
+// TestCommandsDeclarePermissions fails for a command that calls the API
+// without recording a scope, or runs gpg without recording the capability,
+// which 'nylas permissions' would then report as needing nothing. Commands
+// that do neither run locally and need no metadata.
+func TestCommandsDeclarePermissions(t *testing.T) {
+	src := newSourceIndex()
+	callsAPI := commonCall(nylasClientCalls)
+
+	walkCommands(func(c *cobra.Command) {
+		scopes, caps := common.CommandPermissions(c)
+		if len(scopes) == 0 && src.reaches(c, callsAPI) {
+			t.Errorf("%s calls the Nylas API but records no scope; wrap it in common.RequireScopes", c.CommandPath())
+		}
+		if !slices.Contains(caps, domain.CapabilityGPG) && src.reaches(c, usesGPG) {
+			t.Errorf("%s runs gpg but doesn't record it; wrap it in common.RequireCapabilities", c.CommandPath())
+		}
+	})
+}
+
+// sourceIndex parses the packages that define commands, on demand.
+type sourceIndex struct {
+	fset    *token.FileSet
+	files   map[string]*ast.File
+	funcs   map[string]map[string][]ast.Node // dir -> name -> functions and methods
+	imports map[string]map[string]bool       // dir -> imported package names
+}
+
+func newSourceIndex() *sourceIndex {
+	return &sourceIndex{
+		fset:    token.NewFileSet(),
+		files:   map[string]*ast.File{},
+		funcs:   map[string]map[string][]ast.Node{},
+		imports: map[string]map[string]bool{},
+	}
+}
+
+func (s *sourceIndex) load(dir string) {
+	if s.funcs[dir] != nil {
+		return
+	}
+	funcs, imports := map[string][]ast.Node{}, map[string]bool{}
+	s.funcs[dir], s.imports[dir] = funcs, imports
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(s.fset, path, nil, 0)
+		if err != nil {
+			continue
+		}
+		s.files[path] = f
+		for _, imp := range f.Imports {
+			name := pathpkg.Base(strings.Trim(imp.Path.Value, `"`))
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			imports[name] = true
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				funcs[decl.Name.Name] = append(funcs[decl.Name.Name], decl)
+			case *ast.GenDecl:
+				// Test seams: var getClient = func() ...
+				for _, spec := range decl.Specs {
+					vs, ok := spec.(*ast.ValueSpec)
+					if !ok {
+						continue
+					}
+					for i, value := range vs.Values {
+						if lit, ok := value.(*ast.FuncLit); ok && i < len(vs.Names) {
+							funcs[vs.Names[i].Name] = append(funcs[vs.Names[i].Name], lit)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// reaches reports whether the function that runs c calls a function match
+// accepts, directly or through functions of its own package.
+func (s *sourceIndex) reaches(c *cobra.Command, match func(pkg, name string) bool) bool {
+	var run any = c.RunE
+	if c.RunE == nil {
+		run = c.Run
+	}
+	fn := runtime.FuncForPC(reflect.ValueOf(run).Pointer())
+	if fn == nil {
+		return false
+	}
+	path, line := fn.FileLine(fn.Entry())
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+	dir := filepath.Dir(path)
+	s.load(dir)
+	f := s.files[path]
+	if f == nil {
+		return false
+	}
+	for _, decl := range f.Decls {
+		if s.fset.Position(decl.Pos()).Line <= line && line <= s.fset.Position(decl.End()).Line {
+			return s.calls(dir, decl, match, map[ast.Node]bool{})
+		}
+	}
+	return false
+}
+
+// calls reports whether fn, or a same-package function it calls, calls a
+// function match accepts. Other command constructors aren't followed, so a
+// group's own RunE isn't blamed for what its subcommands do.
+func (s *sourceIndex) calls(dir string, fn ast.Node, match func(pkg, name string) bool, seen map[ast.Node]bool) bool {
+	if seen[fn] {
+		return false
+	}
+	seen[fn] = true
+	found := false
+	ast.Inspect(fn, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || found {
+			return !found
+		}
+		fun := call.Fun
+		if idx, ok := fun.(*ast.IndexExpr); ok {
+			fun = idx.X
+		}
+		var pkg, name string
+		switch fun := fun.(type) {
+		case *ast.Ident:
+			name = fun.Name
+		case *ast.SelectorExpr:
+			if x, ok := fun.X.(*ast.Ident); ok {
+				pkg = x.Name
+			}
+			name = fun.Sel.Name
+		}
+		if match(pkg, name) {
+			found = true
+			return false
+		}
+		if s.imports[dir][pkg] || strings.HasSuffix(name, "Cmd") {
+			return true
+		}
+		for _, callee := range s.funcs[dir][name] {
+			if s.calls(dir, callee, match, seen) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
@@ -11,6 +11,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
 	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/httputil"
 )
@@ -130,9 +132,28 @@ func (b *BaseClient) DoJSONRequestAndDecode(ctx context.Context, method, endpoin
 	return b.ReadJSONResponse(resp, result)
 }
 
-// ExpandEnvVar expands environment variables in the format ${VAR_NAME}.
+// lookupSecret reads a ${secret:key} config value from the secret store.
+// Replaced in tests.
+var lookupSecret = func(key string) (string, error) {
+	store, err := keyring.NewSecretStore(config.DefaultConfigDir())
+	if err != nil {
+		return "", err
+	}
+	return store.Get(key)
+}
+
+// ExpandEnvVar expands environment variables in the format ${VAR_NAME}, and
+// secret store references in the format ${secret:key}. A reference that
+// cannot be read expands to the empty string.
 // This is a utility function used by all AI clients.
 func ExpandEnvVar(value string) string {
+	if key, ok := domain.ParseSecretRef(value); ok {
+		secret, err := lookupSecret(key)
+		if err != nil {
+			return ""
+		}
+		return secret
+	}
 	if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
 		envVar := value[2 : len(value)-1]
 		return os.Getenv(envVar)
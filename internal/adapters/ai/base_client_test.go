@@ -527,3 +527,26 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestExpandEnvVar_SecretRef(t *testing.T) {
+	orig := lookupSecret
+	t.Cleanup(func() { lookupSecret = orig })
+	lookupSecret = func(key string) (string, error) {
+		if key == "config.ai.claude.api_key" {
+			return "sk-ant-from-keychain", nil
+		}
+		return "", domain.ErrSecretNotFound
+	}
+
+	if got := ExpandEnvVar("${secret:config.ai.claude.api_key}"); got != "sk-ant-from-keychain" {
+		t.Errorf("ExpandEnvVar() = %q, want secret store value", got)
+	}
+	if got := ExpandEnvVar("${secret:config.ai.groq.api_key}"); got != "" {
+		t.Errorf("ExpandEnvVar() = %q, want empty for a missing secret", got)
+	}
+
+	t.Setenv("GROQ_API_KEY", "gsk-env")
+	if got := GetAPIKeyFromEnv("${secret:config.ai.groq.api_key}", "GROQ_API_KEY"); got != "gsk-env" {
+		t.Errorf("GetAPIKeyFromEnv() = %q, want env fallback", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/nylas/cli/internal/adapters/replyparser"
 	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
 )
@@ -113,8 +114,9 @@ func (a *EmailAnalyzer) buildThreadContext(thread *domain.Thread, messages []dom
 
 		_, _ = fmt.Fprintf(&builder, "\n[%s] %s:\n", timestamp, sender)
 
-		// Add message body (truncate if too long)
-		body := msg.Body
+		// Add the new content of the message, without quoted history or
+		// signature (truncate if too long)
+		body := replyparser.ReplyText(msg.Body)
 		if len(body) > 500 {
 			body = body[:500] + "..."
 		}
@@ -123,6 +123,22 @@ func TestEmailAnalyzer_BuildThreadContext(t *testing.T) {
 	}
 }
 
+func TestEmailAnalyzer_BuildThreadContext_StripsQuotes(t *testing.T) {
+	analyzer := &EmailAnalyzer{}
+
+	result := analyzer.buildThreadContext(&domain.Thread{Subject: "Lunch"}, []domain.Message{
+		{
+			From: []domain.EmailParticipant{{Name: "Bob"}},
+			Body: `<div>Tuesday works.</div><div class="gmail_quote">On Mon, Alice wrote:<blockquote>How about lunch?</blockquote></div>`,
+			Date: time.Now(),
+		},
+	})
+
+	assert.Contains(t, result, "Tuesday works.")
+	assert.NotContains(t, result, "How about lunch?")
+	assert.NotContains(t, result, "<div>")
+}
+
 func TestEmailAnalyzer_AnalyzeParticipants(t *testing.T) {
 	analyzer := &EmailAnalyzer{}
 
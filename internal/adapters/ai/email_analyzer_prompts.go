@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/nylas/cli/internal/adapters/replyparser"
 	"github.com/nylas/cli/internal/domain"
 )
 
@@ -190,8 +191,8 @@ func (a *EmailAnalyzer) analyzeParticipants(thread *domain.Thread, messages []do
 			}
 		}
 
-		// Count mentions in message body
-		body := strings.ToLower(msg.Body)
+		// Count mentions in the new content, not in quoted history
+		body := strings.ToLower(replyparser.ReplyText(msg.Body))
 		for email, p := range participantMap {
 			if strings.Contains(body, strings.ToLower(email)) {
 				p.MentionCount++
@@ -432,7 +433,7 @@ func (a *EmailAnalyzer) detectUrgencyIndicators(messages []domain.Message) []str
 
 	// Check for urgent keywords
 	for _, msg := range messages {
-		bodyLower := strings.ToLower(msg.Body)
+		bodyLower := strings.ToLower(replyparser.ReplyText(msg.Body))
 		subjectLower := strings.ToLower(msg.Subject)
 
 		for _, keyword := range urgentKeywords {
@@ -0,0 +1,159 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/replyparser"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const (
+	// maxSummaryMessageChars bounds each message of a conversation in the
+	// prompt; digests give each message less room.
+	maxSummaryMessageChars = 4000
+	maxDigestMessageChars  = 600
+	// maxSummaryPromptChars bounds the whole prompt.
+	maxSummaryPromptChars = 60000
+)
+
+// EmailSummaryRequest is the input for SummarizeEmail.
+type EmailSummaryRequest struct {
+	Subject      string
+	Messages     []domain.Message // oldest first
+	Digest       bool             // unrelated recent messages rather than one conversation
+	ProviderName string           // empty for the default provider
+}
+
+// SummarizeEmail asks the model to summarize a message or conversation, or
+// with req.Digest a batch of recent messages, and list its action items.
+func SummarizeEmail(ctx context.Context, router ports.LLMRouter, req *EmailSummaryRequest) (*domain.EmailSummary, error) {
+	if len(req.Messages) == 0 {
+		return nil, fmt.Errorf("no messages to summarize")
+	}
+
+	system := emailSummarySystemPrompt
+	if req.Digest {
+		system = emailDigestSystemPrompt
+	}
+	chatReq := &domain.ChatRequest{
+		Messages: []domain.ChatMessage{
+			{Role: "system", Content: system},
+			{Role: "user", Content: buildEmailSummaryPrompt(req)},
+		},
+		Temperature: 0.2,
+	}
+
+	var (
+		resp *domain.ChatResponse
+		err  error
+	)
+	if req.ProviderName != "" {
+		resp, err = router.ChatWithProvider(ctx, req.ProviderName, chatReq)
+	} else {
+		resp, err = router.Chat(ctx, chatReq)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := parseEmailSummary(resp.Content)
+	if err != nil {
+		// Models sometimes answer in prose; keep it rather than fail.
+		summary = &domain.EmailSummary{Summary: strings.TrimSpace(resp.Content)}
+	}
+	summary.Subject = req.Subject
+	summary.MessageCount = len(req.Messages)
+	summary.Provider = resp.Provider
+	summary.TokensUsed = resp.Usage.TotalTokens
+	if summary.ActionItems == nil {
+		summary.ActionItems = []domain.EmailActionItem{}
+	}
+	return summary, nil
+}
+
+const emailSummarySystemPrompt = `You summarize email conversations for a busy reader.
+Respond with JSON only, in this form:
+{"summary":"...","key_points":["..."],"action_items":[{"task":"...","from":"...","due":"..."}]}
+- summary: 2-4 sentences on what the conversation is about and where it stands
+- key_points: up to 5 facts, decisions or numbers worth remembering; [] if none
+- action_items: what the reader is asked or expected to do; [] if nothing
+- task: a short imperative sentence; from: who asked; due: the deadline as stated, empty if none
+Do not invent details that are not in the emails.`
+
+const emailDigestSystemPrompt = `You write a digest of recent emails for a busy reader.
+Respond with JSON only, in this form:
+{"summary":"...","key_points":["..."],"action_items":[{"task":"...","from":"...","due":"...","subject":"..."}]}
+- summary: 3-5 sentences on what arrived and what matters most
+- key_points: up to 8 notable items, most important first; skip newsletters and promotions unless they matter
+- action_items: what the reader is asked or expected to do; [] if nothing
+- task: a short imperative sentence; from: the sender; due: the deadline as stated, empty if none; subject: the email it comes from
+Do not invent details that are not in the emails.`
+
+// buildEmailSummaryPrompt lists the messages with their new content only,
+// leaving out quoted history and signatures.
+func buildEmailSummaryPrompt(req *EmailSummaryRequest) string {
+	perMessage := maxSummaryMessageChars
+	if req.Digest {
+		perMessage = maxDigestMessageChars
+	}
+
+	var sb strings.Builder
+	if req.Subject != "" {
+		fmt.Fprintf(&sb, "Subject: %s\n", req.Subject)
+	}
+	fmt.Fprintf(&sb, "%d email(s):\n", len(req.Messages))
+	for i := range req.Messages {
+		msg := &req.Messages[i]
+		var entry strings.Builder
+		fmt.Fprintf(&entry, "\n--- Email %d ---\nFrom: %s\nDate: %s\n", i+1, formatInboxParticipants(msg.From), msg.Date.Format(time.RFC1123))
+		if req.Digest || req.Subject == "" {
+			fmt.Fprintf(&entry, "Subject: %s\n", msg.Subject)
+		}
+		body := strings.TrimSpace(replyparser.ReplyText(msg.Body))
+		if body == "" {
+			body = msg.Snippet
+		}
+		entry.WriteString(truncateStr(body, perMessage))
+		entry.WriteString("\n")
+
+		if sb.Len()+entry.Len() > maxSummaryPromptChars {
+			fmt.Fprintf(&sb, "\n[%d more email(s) left out]\n", len(req.Messages)-i)
+			break
+		}
+		sb.WriteString(entry.String())
+	}
+	return sb.String()
+}
+
+func parseEmailSummary(content string) (*domain.EmailSummary, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start == -1 || end <= start {
+		return nil, fmt.Errorf("no JSON found in response")
+	}
+
+	var summary domain.EmailSummary
+	if err := json.Unmarshal([]byte(content[start:end+1]), &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	summary.Summary = strings.TrimSpace(summary.Summary)
+
+	items := summary.ActionItems[:0]
+	for _, item := range summary.ActionItems {
+		item.Task = strings.TrimSpace(item.Task)
+		if item.Task == "" {
+			continue
+		}
+		item.From = strings.TrimSpace(item.From)
+		item.Due = strings.TrimSpace(item.Due)
+		item.Subject = strings.TrimSpace(item.Subject)
+		items = append(items, item)
+	}
+	summary.ActionItems = items
+	return &summary, nil
+}
@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func summaryMessages() []domain.Message {
+	return []domain.Message{
+		{
+			Subject: "Q3 budget",
+			From:    []domain.EmailParticipant{{Name: "Ana", Email: "ana@example.com"}},
+			Date:    time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+			Body:    "<p>Can you send the Q3 numbers by Friday?</p>",
+		},
+		{
+			Subject: "Re: Q3 budget",
+			From:    []domain.EmailParticipant{{Email: "bob@example.com"}},
+			Date:    time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC),
+			Snippet: "Sure, working on it",
+		},
+	}
+}
+
+func TestSummarizeEmail(t *testing.T) {
+	router := &fakeRouter{reply: "```json\n" + `{"summary":" Ana needs Q3 numbers. ","key_points":["Due Friday"],` +
+		`"action_items":[{"task":"Send the Q3 numbers","from":"Ana","due":"Friday"},{"task":"  "}]}` + "\n```"}
+
+	summary, err := SummarizeEmail(context.Background(), router, &EmailSummaryRequest{
+		Subject:      "Q3 budget",
+		Messages:     summaryMessages(),
+		ProviderName: "ollama",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ollama", router.provider)
+	assert.Equal(t, emailSummarySystemPrompt, router.req.Messages[0].Content)
+	prompt := router.req.Messages[1].Content
+	assert.Contains(t, prompt, "Subject: Q3 budget")
+	assert.Contains(t, prompt, "From: Ana <ana@example.com>")
+	assert.Contains(t, prompt, "Can you send the Q3 numbers by Friday?")
+	assert.NotContains(t, prompt, "<p>")
+	assert.Contains(t, prompt, "Sure, working on it", "snippet stands in for an empty body")
+
+	assert.Equal(t, "Ana needs Q3 numbers.", summary.Summary)
+	assert.Equal(t, 2, summary.MessageCount)
+	assert.Equal(t, []string{"Due Friday"}, summary.KeyPoints)
+	assert.Equal(t, []domain.EmailActionItem{{Task: "Send the Q3 numbers", From: "Ana", Due: "Friday"}}, summary.ActionItems)
+}
+
+func TestSummarizeEmail_Digest(t *testing.T) {
+	router := &fakeRouter{reply: "Nothing important arrived."}
+
+	summary, err := SummarizeEmail(context.Background(), router, &EmailSummaryRequest{Messages: summaryMessages(), Digest: true})
+	require.NoError(t, err)
+
+	assert.Empty(t, router.provider, "the default provider is used")
+	assert.Equal(t, emailDigestSystemPrompt, router.req.Messages[0].Content)
+	assert.Contains(t, router.req.Messages[1].Content, "Subject: Re: Q3 budget")
+	assert.Equal(t, "Nothing important arrived.", summary.Summary, "prose replies are kept")
+	assert.NotNil(t, summary.ActionItems)
+}
+
+func TestSummarizeEmail_Errors(t *testing.T) {
+	_, err := SummarizeEmail(context.Background(), &fakeRouter{}, &EmailSummaryRequest{})
+	assert.Error(t, err)
+
+	_, err = SummarizeEmail(context.Background(), &fakeRouter{err: errors.New("offline")}, &EmailSummaryRequest{Messages: summaryMessages()})
+	assert.EqualError(t, err, "offline")
+}
+
+func TestBuildEmailSummaryPrompt_Bounded(t *testing.T) {
+	msgs := make([]domain.Message, 200)
+	for i := range msgs {
+		msgs[i] = domain.Message{Subject: "Report", Body: strings.Repeat("x", 1000)}
+	}
+
+	prompt := buildEmailSummaryPrompt(&EmailSummaryRequest{Messages: msgs, Digest: true})
+
+	assert.LessOrEqual(t, len(prompt), maxSummaryPromptChars+100)
+	assert.Contains(t, prompt, "more email(s) left out")
+}
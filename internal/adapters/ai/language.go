@@ -0,0 +1,192 @@
+package ai
+
+import (
+	"strings"
+	"unicode"
+)
+
+// languageNames maps ISO 639-1 codes to English language names.
+var languageNames = map[string]string{
+	"ar": "Arabic",
+	"cs": "Czech",
+	"da": "Danish",
+	"de": "German",
+	"el": "Greek",
+	"en": "English",
+	"es": "Spanish",
+	"fi": "Finnish",
+	"fr": "French",
+	"he": "Hebrew",
+	"hi": "Hindi",
+	"hu": "Hungarian",
+	"id": "Indonesian",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"nl": "Dutch",
+	"no": "Norwegian",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"ro": "Romanian",
+	"ru": "Russian",
+	"sv": "Swedish",
+	"th": "Thai",
+	"tr": "Turkish",
+	"uk": "Ukrainian",
+	"vi": "Vietnamese",
+	"zh": "Chinese",
+}
+
+// NormalizeLanguage turns a language code or English name ("en", "EN",
+// "pt-BR", "german") into an ISO 639-1 code. ok is false for unknown input.
+func NormalizeLanguage(s string) (code string, ok bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "-_"); i > 0 {
+		s = s[:i]
+	}
+	if _, ok := languageNames[s]; ok {
+		return s, true
+	}
+	for code, name := range languageNames {
+		if strings.ToLower(name) == s {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// LanguageName returns the English name for an ISO 639-1 code, or the code
+// itself when it is unknown.
+func LanguageName(code string) string {
+	if name, ok := languageNames[strings.ToLower(code)]; ok {
+		return name
+	}
+	return code
+}
+
+// FormatLanguage renders a code as "German (de)".
+func FormatLanguage(code string) string {
+	if code == "" {
+		return "unknown"
+	}
+	name := LanguageName(code)
+	if name == code {
+		return code
+	}
+	return name + " (" + code + ")"
+}
+
+// stopwords are frequent function words that tell Latin-script languages
+// apart. Words shared by several languages still count for each.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "you", "that", "have", "for", "with", "this", "are", "not", "will", "would", "your", "from", "please", "thanks", "is", "it", "we"},
+	"de": {"der", "die", "das", "und", "ich", "nicht", "sie", "mit", "ist", "auf", "für", "wir", "ein", "eine", "bitte", "danke", "auch", "sind", "zu"},
+	"fr": {"le", "la", "les", "et", "je", "vous", "nous", "est", "pas", "pour", "une", "des", "avec", "merci", "que", "dans", "sur", "du", "ce"},
+	"es": {"el", "la", "los", "las", "y", "que", "de", "para", "con", "por", "una", "usted", "gracias", "es", "muy", "pero", "del", "nos", "está"},
+	"it": {"il", "di", "che", "non", "per", "una", "sono", "con", "della", "grazie", "anche", "questo", "mi", "ti", "gli", "ciao", "è", "del", "ho"},
+	"pt": {"o", "os", "que", "não", "para", "com", "uma", "você", "obrigado", "obrigada", "está", "muito", "mas", "do", "da", "em", "são", "isso", "nós"},
+	"nl": {"de", "het", "een", "en", "ik", "niet", "je", "van", "voor", "met", "zijn", "wij", "dank", "bedankt", "ook", "maar", "dat", "is", "op"},
+	"sv": {"och", "att", "det", "som", "jag", "inte", "för", "med", "är", "på", "vi", "tack", "har", "den", "ett", "till", "av", "du", "kan"},
+	"pl": {"i", "nie", "się", "na", "jest", "że", "to", "w", "z", "do", "dziękuję", "proszę", "jak", "ale", "czy", "tak", "pan", "pani", "mnie"},
+	"tr": {"ve", "bir", "bu", "için", "ile", "çok", "teşekkürler", "ama", "değil", "ne", "var", "olarak", "daha", "gibi", "sizin", "ben", "biz", "de", "da"},
+}
+
+// stopwordIndex maps each stopword to the languages it belongs to.
+var stopwordIndex = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopwords {
+		for _, w := range words {
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}()
+
+// DetectLanguage guesses the ISO 639-1 code of plain text without any
+// network calls. Non-Latin scripts are identified by their characters and
+// Latin-script languages by their most frequent words. It returns "" when the
+// text is too short or ambiguous to call.
+func DetectLanguage(text string) string {
+	if lang := detectScript(text); lang != "" {
+		return lang
+	}
+
+	scores := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, w := range words {
+		for _, lang := range stopwordIndex[w] {
+			scores[lang]++
+		}
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, runnerUp = lang, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	// Require a few hits and a clear lead over the next language.
+	if bestScore < 3 || bestScore == runnerUp {
+		return ""
+	}
+	return best
+}
+
+// detectScript identifies languages by writing system when most letters are
+// non-Latin.
+func detectScript(text string) string {
+	counts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			counts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case strings.ContainsRune("іїєґІЇЄҐ", r):
+			counts["uk"]++
+			counts["ru"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	// Japanese mixes kana with Han characters; any kana means Japanese.
+	if counts["ja"] > 0 && counts["ja"]+counts["zh"] > letters/2 {
+		return "ja"
+	}
+	// Ukrainian-only letters distinguish it from Russian.
+	if counts["uk"] > 0 && counts["ru"] > letters/2 {
+		return "uk"
+	}
+	for _, lang := range []string{"zh", "ko", "ru", "ar", "he", "el", "hi", "th"} {
+		if counts[lang] > letters/2 {
+			return lang
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Thanks for the update. We will have the report ready for you this week.", "en"},
+		{"german", "Vielen Dank für die Nachricht. Ich bin nicht sicher, ob wir das bis Freitag schaffen.", "de"},
+		{"french", "Merci pour votre message. Nous avons besoin de plus de temps pour le projet.", "fr"},
+		{"spanish", "Gracias por el correo. Los documentos están listos para la reunión del lunes.", "es"},
+		{"italian", "Grazie per il messaggio, sono d'accordo che non è il momento per questo.", "it"},
+		{"portuguese", "Muito obrigado, você está certo e não há problema com isso.", "pt"},
+		{"dutch", "Bedankt voor je bericht, ik kan niet op het overleg zijn maar ook morgen is goed.", "nl"},
+		{"japanese", "会議の時間を変更してもよろしいでしょうか。", "ja"},
+		{"chinese", "我们明天下午开会讨论这个项目。", "zh"},
+		{"korean", "내일 회의에 참석할 수 있습니까?", "ko"},
+		{"russian", "Спасибо за письмо, встреча переносится на завтра.", "ru"},
+		{"ukrainian", "Дякую за лист, зустріч переноситься на п'ятницю.", "uk"},
+		{"too short", "OK", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectLanguage(tt.text))
+		})
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"en", "en", true},
+		{"EN", "en", true},
+		{"pt-BR", "pt", true},
+		{"zh_TW", "zh", true},
+		{"German", "de", true},
+		{" japanese ", "ja", true},
+		{"klingon", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := NormalizeLanguage(tt.in)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestFormatLanguage(t *testing.T) {
+	assert.Equal(t, "German (de)", FormatLanguage("de"))
+	assert.Equal(t, "xq", FormatLanguage("xq"))
+	assert.Equal(t, "unknown", FormatLanguage(""))
+}
@@ -0,0 +1,136 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// maxTranscriptPromptChars bounds the transcript text sent to the model.
+const maxTranscriptPromptChars = 60000
+
+// MeetingActionsRequest is the input for action item extraction.
+type MeetingActionsRequest struct {
+	Title        string
+	Transcript   *domain.NotetakerTranscript
+	Participants []domain.Person // known attendees, used to name owners
+	ProviderName string          // empty for the default provider
+}
+
+// ExtractActionItems asks the model for the action items agreed in a meeting.
+func ExtractActionItems(ctx context.Context, router ports.LLMRouter, req *MeetingActionsRequest) ([]domain.MeetingActionItem, error) {
+	text := FormatTranscript(req.Transcript)
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("transcript is empty")
+	}
+	if len(text) > maxTranscriptPromptChars {
+		text = text[:maxTranscriptPromptChars] + "\n[transcript truncated]"
+	}
+
+	chatReq := &domain.ChatRequest{
+		Messages: []domain.ChatMessage{
+			{Role: "system", Content: meetingActionsSystemPrompt},
+			{Role: "user", Content: buildMeetingActionsPrompt(req.Title, text, req.Participants)},
+		},
+		Temperature: 0.2,
+	}
+
+	var (
+		resp *domain.ChatResponse
+		err  error
+	)
+	if req.ProviderName != "" {
+		resp, err = router.ChatWithProvider(ctx, req.ProviderName, chatReq)
+	} else {
+		resp, err = router.Chat(ctx, chatReq)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseMeetingActionItems(resp.Content)
+}
+
+const meetingActionsSystemPrompt = `You extract action items from meeting transcripts.
+An action item is a concrete task someone agreed to do or was asked to do.
+Skip general discussion, opinions, and tasks that were completed during the meeting.
+Respond with JSON only, in this form:
+{"action_items":[{"task":"...","owner":"...","owner_email":"...","due":"..."}]}
+- task: a short imperative sentence
+- owner: the person responsible, as named in the transcript; empty if nobody was named
+- owner_email: the owner's email, only if the owner is in the attendee list
+- due: the deadline as stated (e.g. "Friday", "next week"); empty if none
+Return {"action_items":[]} when there are none.`
+
+func buildMeetingActionsPrompt(title, transcript string, participants []domain.Person) string {
+	var sb strings.Builder
+	if title != "" {
+		fmt.Fprintf(&sb, "Meeting: %s\n", title)
+	}
+	if len(participants) > 0 {
+		sb.WriteString("Attendees:\n")
+		for _, p := range participants {
+			if p.Name != "" {
+				fmt.Fprintf(&sb, "- %s <%s>\n", p.Name, p.Email)
+			} else {
+				fmt.Fprintf(&sb, "- %s\n", p.Email)
+			}
+		}
+	}
+	sb.WriteString("\nTranscript:\n")
+	sb.WriteString(transcript)
+	return sb.String()
+}
+
+// FormatTranscript renders a transcript as "[mm:ss] Speaker: text" lines.
+func FormatTranscript(t *domain.NotetakerTranscript) string {
+	if t == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, s := range t.Segments {
+		text := strings.TrimSpace(s.Text)
+		if text == "" {
+			continue
+		}
+		if s.Speaker == "" {
+			sb.WriteString(text)
+		} else {
+			secs := s.Start / 1000
+			fmt.Fprintf(&sb, "[%02d:%02d] %s: %s", secs/60, secs%60, s.Speaker, text)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func parseMeetingActionItems(content string) ([]domain.MeetingActionItem, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start == -1 || end <= start {
+		return nil, fmt.Errorf("no JSON found in response")
+	}
+
+	var result struct {
+		ActionItems []domain.MeetingActionItem `json:"action_items"`
+	}
+	if err := json.Unmarshal([]byte(content[start:end+1]), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	items := result.ActionItems[:0]
+	for _, item := range result.ActionItems {
+		item.Task = strings.TrimSpace(item.Task)
+		if item.Task == "" {
+			continue
+		}
+		item.Owner = strings.TrimSpace(item.Owner)
+		item.OwnerEmail = strings.TrimSpace(item.OwnerEmail)
+		item.Due = strings.TrimSpace(item.Due)
+		items = append(items, item)
+	}
+	return items, nil
+}
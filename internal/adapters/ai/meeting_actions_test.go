@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestFormatTranscript(t *testing.T) {
+	transcript := &domain.NotetakerTranscript{Segments: []domain.TranscriptSegment{
+		{Speaker: "Ann", Start: 0, Text: "Hello."},
+		{Speaker: "Bo", Start: 75_500, Text: " I'll send it. "},
+		{Speaker: "Bo", Start: 80_000, Text: "  "},
+	}}
+	assert.Equal(t, "[00:00] Ann: Hello.\n[01:15] Bo: I'll send it.\n", FormatTranscript(transcript))
+
+	raw := &domain.NotetakerTranscript{Segments: []domain.TranscriptSegment{{Text: "Just text"}}}
+	assert.Equal(t, "Just text\n", FormatTranscript(raw))
+	assert.Empty(t, FormatTranscript(nil))
+}
+
+func TestExtractActionItems(t *testing.T) {
+	router := &fakeRouter{reply: "Here you go:\n```json\n" +
+		`{"action_items":[{"task":" Send the proposal ","owner":"Bo","owner_email":"bo@example.com","due":"Friday"},{"task":"","owner":"Ann"}]}` +
+		"\n```"}
+	req := &MeetingActionsRequest{
+		Title: "Weekly sync",
+		Transcript: &domain.NotetakerTranscript{Segments: []domain.TranscriptSegment{
+			{Speaker: "Bo", Text: "I'll send the proposal by Friday."},
+		}},
+		Participants: []domain.Person{{Name: "Bo", Email: "bo@example.com"}, {Email: "ann@example.com"}},
+		ProviderName: "claude",
+	}
+
+	items, err := ExtractActionItems(context.Background(), router, req)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.MeetingActionItem{
+		{Task: "Send the proposal", Owner: "Bo", OwnerEmail: "bo@example.com", Due: "Friday"},
+	}, items, "items without a task are dropped")
+
+	assert.Equal(t, "claude", router.provider)
+	prompt := router.req.Messages[1].Content
+	assert.Contains(t, prompt, "Meeting: Weekly sync")
+	assert.Contains(t, prompt, "- Bo <bo@example.com>")
+	assert.Contains(t, prompt, "- ann@example.com")
+	assert.Contains(t, prompt, "Bo: I'll send the proposal by Friday.")
+}
+
+func TestExtractActionItems_Errors(t *testing.T) {
+	transcript := &domain.NotetakerTranscript{Segments: []domain.TranscriptSegment{{Text: "Hi"}}}
+
+	_, err := ExtractActionItems(context.Background(), &fakeRouter{}, &MeetingActionsRequest{Transcript: &domain.NotetakerTranscript{}})
+	assert.ErrorContains(t, err, "empty")
+
+	_, err = ExtractActionItems(context.Background(), &fakeRouter{reply: "no items"}, &MeetingActionsRequest{Transcript: transcript})
+	assert.ErrorContains(t, err, "no JSON")
+
+	items, err := ExtractActionItems(context.Background(), &fakeRouter{reply: `{"action_items":[]}`}, &MeetingActionsRequest{Transcript: transcript})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
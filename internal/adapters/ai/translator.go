@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// NewTranslator returns the translation backend selected by
+// ai.translation.backend: the configured LLM provider (the default) or a
+// LibreTranslate server.
+func NewTranslator(config *domain.AIConfig) (ports.Translator, error) {
+	var tc domain.TranslationConfig
+	if config != nil && config.Translation != nil {
+		tc = *config.Translation
+	}
+
+	switch strings.ToLower(tc.Backend) {
+	case "", domain.TranslationBackendLLM:
+		if !config.IsConfigured() {
+			return nil, fmt.Errorf("no AI provider configured for translation")
+		}
+		return NewLLMTranslator(NewRouter(config), tc.Provider), nil
+	case domain.TranslationBackendLibreTranslate:
+		return NewLibreTranslateClient(tc.URL, ExpandEnvVar(tc.APIKey)), nil
+	default:
+		return nil, fmt.Errorf("unknown translation backend: %s", tc.Backend)
+	}
+}
+
+// LLMTranslator translates with an LLM provider, so a local Ollama model
+// keeps message content on the machine.
+type LLMTranslator struct {
+	router   ports.LLMRouter
+	provider string
+}
+
+var _ ports.Translator = (*LLMTranslator)(nil)
+
+// NewLLMTranslator creates a translator using provider, or the router's
+// default provider when empty.
+func NewLLMTranslator(router ports.LLMRouter, provider string) *LLMTranslator {
+	return &LLMTranslator{router: router, provider: provider}
+}
+
+// Name returns the backend name.
+func (t *LLMTranslator) Name() string {
+	if t.provider != "" {
+		return domain.TranslationBackendLLM + ":" + t.provider
+	}
+	return domain.TranslationBackendLLM
+}
+
+// sourceLanguagePrefix starts the line in which the model reports the
+// detected source language.
+const sourceLanguagePrefix = "SOURCE_LANGUAGE:"
+
+// Translate translates req.Text. The model reports the source language on
+// its first line; when it doesn't, the language is detected locally.
+func (t *LLMTranslator) Translate(ctx context.Context, req *domain.TranslationRequest) (*domain.TranslationResult, error) {
+	target := LanguageName(req.TargetLanguage)
+	system := fmt.Sprintf(`You are a professional translator. Translate the user's email text into %s.
+Preserve the meaning, tone, line breaks, names, email addresses, URLs and numbers.
+Do not add explanations or notes.
+Reply in exactly this format:
+%s <ISO 639-1 code of the original language>
+---
+<translated text>`, target, sourceLanguagePrefix)
+
+	chatReq := &domain.ChatRequest{
+		Messages: []domain.ChatMessage{
+			{Role: "system", Content: system},
+			{Role: "user", Content: req.Text},
+		},
+		Temperature: 0.1,
+	}
+
+	var (
+		resp *domain.ChatResponse
+		err  error
+	)
+	if t.provider != "" {
+		resp, err = t.router.ChatWithProvider(ctx, t.provider, chatReq)
+	} else {
+		resp, err = t.router.Chat(ctx, chatReq)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("translation failed: %w", err)
+	}
+
+	text, source := parseTranslationResponse(resp.Content)
+	if source == "" {
+		source = req.SourceLanguage
+	}
+	if source == "" {
+		source = DetectLanguage(req.Text)
+	}
+
+	return &domain.TranslationResult{
+		Text:           text,
+		SourceLanguage: source,
+		TargetLanguage: req.TargetLanguage,
+		Backend:        t.Name(),
+	}, nil
+}
+
+// parseTranslationResponse splits the model reply into the translated text
+// and the reported source language code. Replies without the header are
+// treated as bare translations.
+func parseTranslationResponse(content string) (text, source string) {
+	content = strings.TrimSpace(content)
+	first, rest, found := strings.Cut(content, "\n")
+	if !found || !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(first)), sourceLanguagePrefix) {
+		return content, ""
+	}
+
+	reported := strings.TrimSpace(strings.TrimSpace(first)[len(sourceLanguagePrefix):])
+	source, _ = NormalizeLanguage(strings.Trim(reported, "<>\"'`"))
+
+	rest = strings.TrimLeft(rest, "\r\n")
+	if after, ok := strings.CutPrefix(rest, "---"); ok {
+		rest = after
+	}
+	return strings.TrimSpace(rest), source
+}
@@ -0,0 +1,119 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/httputil"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// DefaultLibreTranslateURL is a LibreTranslate server on this machine, e.g.
+// started with `docker run -p 5000:5000 libretranslate/libretranslate`.
+const DefaultLibreTranslateURL = "http://localhost:5000"
+
+// LibreTranslateClient translates with the LibreTranslate API.
+type LibreTranslateClient struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+var _ ports.Translator = (*LibreTranslateClient)(nil)
+
+// NewLibreTranslateClient creates a client for the server at baseURL, or
+// DefaultLibreTranslateURL when empty.
+func NewLibreTranslateClient(baseURL, apiKey string) *LibreTranslateClient {
+	if baseURL == "" {
+		baseURL = DefaultLibreTranslateURL
+	}
+	return &LibreTranslateClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		http:    httputil.DefaultClient,
+	}
+}
+
+// Name returns the backend name.
+func (c *LibreTranslateClient) Name() string {
+	return domain.TranslationBackendLibreTranslate
+}
+
+type libreTranslateRequest struct {
+	Q      string `json:"q"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Format string `json:"format"`
+	APIKey string `json:"api_key,omitempty"`
+}
+
+type libreTranslateResponse struct {
+	TranslatedText   string `json:"translatedText"`
+	DetectedLanguage *struct {
+		Language   string  `json:"language"`
+		Confidence float64 `json:"confidence"`
+	} `json:"detectedLanguage"`
+	Error string `json:"error"`
+}
+
+// Translate calls POST /translate, letting the server detect the source
+// language unless req.SourceLanguage is set.
+func (c *LibreTranslateClient) Translate(ctx context.Context, req *domain.TranslationRequest) (*domain.TranslationResult, error) {
+	source := req.SourceLanguage
+	if source == "" {
+		source = "auto"
+	}
+	payload, err := json.Marshal(libreTranslateRequest{
+		Q:      req.Text,
+		Source: source,
+		Target: req.TargetLanguage,
+		Format: "text",
+		APIKey: c.apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/translate", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("libretranslate request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var out libreTranslateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("decode libretranslate response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if out.Error != "" {
+			return nil, fmt.Errorf("libretranslate returned HTTP %d: %s", resp.StatusCode, out.Error)
+		}
+		return nil, fmt.Errorf("libretranslate returned HTTP %d", resp.StatusCode)
+	}
+
+	result := &domain.TranslationResult{
+		Text:           out.TranslatedText,
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		Backend:        c.Name(),
+	}
+	if out.DetectedLanguage != nil && out.DetectedLanguage.Language != "" {
+		result.SourceLanguage = out.DetectedLanguage.Language
+	}
+	if result.SourceLanguage == "" {
+		result.SourceLanguage = DetectLanguage(req.Text)
+	}
+	return result, nil
+}
@@ -0,0 +1,154 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// fakeRouter returns a canned reply and records the request.
+type fakeRouter struct {
+	reply    string
+	err      error
+	provider string
+	req      *domain.ChatRequest
+}
+
+func (r *fakeRouter) GetProvider(name string) (ports.LLMProvider, error) { return nil, nil }
+func (r *fakeRouter) ListProviders() []string                            { return nil }
+
+func (r *fakeRouter) Chat(ctx context.Context, req *domain.ChatRequest) (*domain.ChatResponse, error) {
+	return r.ChatWithProvider(ctx, "", req)
+}
+
+func (r *fakeRouter) ChatWithProvider(_ context.Context, provider string, req *domain.ChatRequest) (*domain.ChatResponse, error) {
+	r.provider, r.req = provider, req
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &domain.ChatResponse{Content: r.reply}, nil
+}
+
+func TestParseTranslationResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantText   string
+		wantSource string
+	}{
+		{"with header", "SOURCE_LANGUAGE: de\n---\nHello there", "Hello there", "de"},
+		{"lowercase header and name", "source_language: German\n\nHello", "Hello", "de"},
+		{"bracketed code", "SOURCE_LANGUAGE: <fr>\n---\nHi", "Hi", "fr"},
+		{"bare translation", "Just the translation", "Just the translation", ""},
+		{"multi-line body", "SOURCE_LANGUAGE: es\n---\nLine one\n\nLine two", "Line one\n\nLine two", "es"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, source := parseTranslationResponse(tt.content)
+			assert.Equal(t, tt.wantText, text)
+			assert.Equal(t, tt.wantSource, source)
+		})
+	}
+}
+
+func TestLLMTranslator_Translate(t *testing.T) {
+	t.Run("uses reported source language", func(t *testing.T) {
+		router := &fakeRouter{reply: "SOURCE_LANGUAGE: de\n---\nSee you tomorrow"}
+		tr := NewLLMTranslator(router, "ollama")
+
+		result, err := tr.Translate(context.Background(), &domain.TranslationRequest{Text: "Bis morgen", TargetLanguage: "en"})
+		require.NoError(t, err)
+		assert.Equal(t, "See you tomorrow", result.Text)
+		assert.Equal(t, "de", result.SourceLanguage)
+		assert.Equal(t, "en", result.TargetLanguage)
+		assert.Equal(t, "llm:ollama", result.Backend)
+		assert.Equal(t, "ollama", router.provider)
+		assert.Contains(t, router.req.Messages[0].Content, "English")
+		assert.Equal(t, "Bis morgen", router.req.Messages[1].Content)
+	})
+
+	t.Run("falls back to local detection", func(t *testing.T) {
+		router := &fakeRouter{reply: "Thank you for the invitation, we are happy to come."}
+		tr := NewLLMTranslator(router, "")
+
+		result, err := tr.Translate(context.Background(), &domain.TranslationRequest{
+			Text:           "Vielen Dank für die Einladung, wir kommen gerne und sind auch pünktlich.",
+			TargetLanguage: "en",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "de", result.SourceLanguage)
+		assert.Equal(t, "llm", result.Backend)
+	})
+
+	t.Run("wraps provider errors", func(t *testing.T) {
+		tr := NewLLMTranslator(&fakeRouter{err: errors.New("connection refused")}, "")
+		_, err := tr.Translate(context.Background(), &domain.TranslationRequest{Text: "Hola", TargetLanguage: "en"})
+		assert.ErrorContains(t, err, "translation failed")
+	})
+}
+
+func TestLibreTranslateClient_Translate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var got libreTranslateRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/translate", r.URL.Path)
+			assert.Equal(t, http.MethodPost, r.Method)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			_, _ = w.Write([]byte(`{"translatedText":"Good morning","detectedLanguage":{"language":"fr","confidence":92}}`))
+		}))
+		defer server.Close()
+
+		client := NewLibreTranslateClient(server.URL+"/", "secret")
+		result, err := client.Translate(context.Background(), &domain.TranslationRequest{Text: "Bonjour", TargetLanguage: "en"})
+		require.NoError(t, err)
+		assert.Equal(t, "Good morning", result.Text)
+		assert.Equal(t, "fr", result.SourceLanguage)
+		assert.Equal(t, "libretranslate", result.Backend)
+		assert.Equal(t, libreTranslateRequest{Q: "Bonjour", Source: "auto", Target: "en", Format: "text", APIKey: "secret"}, got)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"xx is not supported"}`))
+		}))
+		defer server.Close()
+
+		_, err := NewLibreTranslateClient(server.URL, "").Translate(context.Background(), &domain.TranslationRequest{Text: "Hi", TargetLanguage: "xx"})
+		assert.ErrorContains(t, err, "HTTP 400: xx is not supported")
+	})
+}
+
+func TestNewTranslator(t *testing.T) {
+	t.Run("llm backend by default", func(t *testing.T) {
+		tr, err := NewTranslator(&domain.AIConfig{DefaultProvider: "ollama", Ollama: &domain.OllamaConfig{Model: "mistral"}})
+		require.NoError(t, err)
+		assert.Equal(t, "llm", tr.Name())
+	})
+
+	t.Run("llm backend needs a provider", func(t *testing.T) {
+		_, err := NewTranslator(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("libretranslate needs no AI provider", func(t *testing.T) {
+		tr, err := NewTranslator(&domain.AIConfig{Translation: &domain.TranslationConfig{Backend: "libretranslate"}})
+		require.NoError(t, err)
+		assert.Equal(t, "libretranslate", tr.Name())
+		assert.Equal(t, DefaultLibreTranslateURL, tr.(*LibreTranslateClient).baseURL)
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := NewTranslator(&domain.AIConfig{Translation: &domain.TranslationConfig{Backend: "babelfish"}})
+		assert.ErrorContains(t, err, "unknown translation backend")
+	})
+}
@@ -0,0 +1,132 @@
+// Package asciicast records terminal sessions in the asciicast v2 format
+// played back by asciinema.
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Event types.
+const (
+	EventOutput = "o"
+	EventResize = "r"
+)
+
+// Header is the first line of a recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Writer appends timestamped events to a recording. It is safe for
+// concurrent use; as an io.Writer it records terminal output.
+type Writer struct {
+	mu      sync.Mutex
+	w       io.Writer
+	start   time.Time
+	pending []byte // Incomplete UTF-8 sequence held for the next write
+	now     func() time.Time
+}
+
+// NewWriter writes h to w and returns a Writer for the session's events.
+// Version, Timestamp and missing dimensions are filled in.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	return newWriter(w, h, time.Now)
+}
+
+func newWriter(w io.Writer, h Header, now func() time.Time) (*Writer, error) {
+	start := now()
+	h.Version = 2
+	if h.Timestamp == 0 {
+		h.Timestamp = start.Unix()
+	}
+	if h.Width <= 0 {
+		h.Width = 80
+	}
+	if h.Height <= 0 {
+		h.Height = 24
+	}
+	line, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, start: start, now: now}, nil
+}
+
+// Write records p as terminal output. A multi-byte character split across
+// writes is recorded whole with the later write.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := append(w.pending, p...)
+	cut := completeUTF8(data)
+	w.pending = append([]byte(nil), data[cut:]...)
+	if cut == 0 {
+		return len(p), nil
+	}
+	if err := w.event(EventOutput, string(data[:cut])); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Resize records a change of terminal size.
+func (w *Writer) Resize(width, height int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.event(EventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Flush records any output held back by Write, replacing a truncated
+// character.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) == 0 {
+		return nil
+	}
+	data := strings.ToValidUTF8(string(w.pending), "\uFFFD")
+	w.pending = nil
+	return w.event(EventOutput, data)
+}
+
+func (w *Writer) event(kind, data string) error {
+	elapsed := w.now().Sub(w.start).Seconds()
+	line, err := json.Marshal([]any{json.Number(fmt.Sprintf("%.6f", elapsed)), kind, data})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.w, "%s\n", line)
+	return err
+}
+
+// completeUTF8 returns the length of the longest prefix of p that doesn't
+// end in the middle of a UTF-8 sequence.
+func completeUTF8(p []byte) int {
+	for i := 1; i < utf8.UTFMax && i <= len(p); i++ {
+		start := len(p) - i
+		if !utf8.RuneStart(p[start]) {
+			continue
+		}
+		if utf8.FullRune(p[start:]) {
+			return len(p)
+		}
+		return start
+	}
+	return len(p)
+}
@@ -0,0 +1,87 @@
+package asciicast
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances by a second on every reading.
+func fakeClock() func() time.Time {
+	t := time.Unix(1767225600, 0)
+	return func() time.Time {
+		now := t
+		t = t.Add(time.Second)
+		return now
+	}
+}
+
+func decodeLines(t *testing.T, data string) (Header, [][]any) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+	var h Header
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &h))
+	events := make([][]any, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		var e []any
+		require.NoError(t, json.Unmarshal([]byte(line), &e), line)
+		events = append(events, e)
+	}
+	return h, events
+}
+
+func TestWriter_HeaderAndEvents(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newWriter(&buf, Header{Width: 120, Command: "nylas email list", Env: map[string]string{"TERM": "xterm"}}, fakeClock())
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("hello\r\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Resize(100, 30))
+
+	h, events := decodeLines(t, buf.String())
+	assert.Equal(t, 2, h.Version)
+	assert.Equal(t, 120, h.Width)
+	assert.Equal(t, 24, h.Height, "missing height defaults")
+	assert.Equal(t, int64(1767225600), h.Timestamp)
+	assert.Equal(t, "nylas email list", h.Command)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, []any{1.0, "o", "hello\r\n"}, events[0])
+	assert.Equal(t, []any{2.0, "r", "100x30"}, events[1])
+}
+
+func TestWriter_SplitUTF8(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newWriter(&buf, Header{}, fakeClock())
+	require.NoError(t, err)
+
+	check := []byte("✓ done")
+	n, err := w.Write(check[:2])
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	_, err = w.Write(check[2:])
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte{0xe2, 0x9c})
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	_, events := decodeLines(t, buf.String())
+	require.Len(t, events, 2)
+	assert.Equal(t, "✓ done", events[0][2])
+	assert.Equal(t, "�", events[1][2], "a truncated character is replaced")
+}
+
+func TestCompleteUTF8(t *testing.T) {
+	assert.Equal(t, 3, completeUTF8([]byte("abc")))
+	assert.Equal(t, 1, completeUTF8([]byte{'a', 0xe2, 0x9c}))
+	assert.Equal(t, 4, completeUTF8([]byte("a✓")))
+	assert.Equal(t, 0, completeUTF8([]byte{0xf0, 0x9f, 0x98}))
+	assert.Equal(t, 0, completeUTF8(nil))
+}
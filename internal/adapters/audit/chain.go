@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Entries form a hash chain: each records the previous entry's hash, and
+// chain.json holds the head, so editing, removing, reordering or cutting off
+// entries is detectable. Entries that retention or clear removed on purpose
+// are summarized by the anchor, the last entry removed.
+const (
+	chainFileName = "chain.json"
+	chainLockName = "chain.lock"
+
+	// chainLockWait bounds how long Log waits for another process.
+	chainLockWait = 2 * time.Second
+	// chainLockStale is when a lock left by a crashed process is taken over.
+	chainLockStale = 10 * time.Second
+)
+
+// chainState is the persisted head and anchor of the hash chain.
+type chainState struct {
+	Seq        int64  `json:"seq"`
+	Hash       string `json:"hash"`
+	AnchorSeq  int64  `json:"anchor_seq,omitempty"`
+	AnchorHash string `json:"anchor_hash,omitempty"`
+}
+
+// EntryHash returns the SHA-256 of entry with its Hash field empty.
+func EntryHash(entry domain.AuditEntry) string {
+	entry.Hash = ""
+	data, _ := json.Marshal(entry)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *FileStore) loadChain() (chainState, error) {
+	var state chainState
+	data, err := os.ReadFile(filepath.Join(s.basePath, chainFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("read audit chain: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("audit chain %s is corrupt: %w", chainFileName, err)
+	}
+	return state, nil
+}
+
+func (s *FileStore) saveChain(state chainState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal audit chain: %w", err)
+	}
+	tmp := filepath.Join(s.basePath, chainFileName+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("write audit chain: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.basePath, chainFileName)); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("write audit chain: %w", err)
+	}
+	return nil
+}
+
+// lockChain takes the cross-process chain lock, so concurrent CLI
+// processes cannot fork the chain. The returned func releases it.
+func (s *FileStore) lockChain() (func(), error) {
+	path := filepath.Join(s.basePath, chainLockName)
+	deadline := time.Now().Add(chainLockWait)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("lock audit chain: %w", err)
+		}
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > chainLockStale {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.New("lock audit chain: held by another process")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// advanceAnchor records removed entries as intentionally gone. Called with
+// s.mu and the chain lock held.
+func (s *FileStore) advanceAnchor(removed []domain.AuditEntry) error {
+	state, err := s.loadChain()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, entry := range removed {
+		if entry.Seq > state.AnchorSeq {
+			state.AnchorSeq, state.AnchorHash = entry.Seq, entry.Hash
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.saveChain(state)
+}
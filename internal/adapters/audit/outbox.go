@@ -0,0 +1,341 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Entries bound for remote sinks wait in per-sink outbox files until a
+// batch is due. A flush claims the files by renaming them, so concurrent
+// CLI processes never send the same entry twice, and puts back whatever
+// could not be delivered.
+const (
+	outboxDirName = "outbox"
+	outboxExt     = ".jsonl"
+	sendingExt    = ".sending"
+	retryExt      = ".retry"
+
+	// staleClaimAge is when a claim left by a crashed process is retaken.
+	staleClaimAge = 5 * time.Minute
+
+	// Automatic flushes back off after a failure, doubling from
+	// minSinkBackoff up to maxSinkBackoff, so a collector outage does not
+	// slow down every command.
+	minSinkBackoff = time.Minute
+	maxSinkBackoff = time.Hour
+
+	// maxQueuedRecords caps each sink's queue while its collector is down;
+	// the oldest records are dropped first.
+	maxQueuedRecords = 10000
+)
+
+// SinkResult is the outcome of flushing one sink.
+type SinkResult struct {
+	Sink    string `json:"sink"`
+	Sent    int    `json:"sent"`
+	Pending int    `json:"pending"`
+	Dropped int    `json:"dropped,omitempty"`
+	Err     error  `json:"-"`
+}
+
+func (s *FileStore) outboxDir() string {
+	return filepath.Join(s.basePath, outboxDirName)
+}
+
+// enqueueForSinks queues entry for every configured sink. Called with s.mu
+// held.
+func (s *FileStore) enqueueForSinks(entry *domain.AuditEntry) error {
+	if len(s.config.Sinks) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(s.outboxDir(), 0700); err != nil {
+		return fmt.Errorf("create outbox: %w", err)
+	}
+	line, err := json.Marshal(SinkRecord{AuditEntry: *entry, Host: hostname()})
+	if err != nil {
+		return fmt.Errorf("marshal entry: %w", err)
+	}
+	for _, sink := range s.config.Sinks {
+		path := filepath.Join(s.outboxDir(), sink.Name+outboxExt)
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("open outbox for %s: %w", sink.Name, err)
+		}
+		_, err = f.Write(append(line, '\n'))
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("queue entry for %s: %w", sink.Name, err)
+		}
+	}
+	return nil
+}
+
+// FlushSinks sends queued entries to each sink whose batch is full or whose
+// oldest entry has waited domain.AuditSinkMaxAge. force sends everything
+// queued. token returns the bearer token for a sink.
+func (s *FileStore) FlushSinks(ctx context.Context, force bool, token func(name string) string) []SinkResult {
+	s.mu.RLock()
+	sinks := append([]domain.AuditSink(nil), s.config.Sinks...)
+	s.mu.RUnlock()
+
+	results := make([]SinkResult, 0, len(sinks))
+	for _, cfg := range sinks {
+		results = append(results, s.flushSink(ctx, cfg, force, token))
+	}
+	return results
+}
+
+func (s *FileStore) flushSink(ctx context.Context, cfg domain.AuditSink, force bool, token func(string) string) SinkResult {
+	result := SinkResult{Sink: cfg.Name}
+
+	files := s.queuedFiles(cfg.Name)
+	records, err := readRecords(files)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Pending = len(records)
+	if len(records) == 0 {
+		return result
+	}
+	due := force || len(records) >= cfg.EffectiveBatchSize() ||
+		time.Since(records[0].Timestamp) >= domain.AuditSinkMaxAge
+	backoff := s.readBackoff(cfg.Name)
+	if !due || (!force && time.Now().Before(backoff.Until)) {
+		return result
+	}
+
+	claimed := claimFiles(files)
+	if records, err = readRecords(claimed); err != nil {
+		result.Err = err
+		return result
+	}
+
+	secret := ""
+	if cfg.Type == domain.AuditSinkHTTPS {
+		secret = token(cfg.Name)
+	}
+	sink, err := NewSink(cfg, secret)
+	if err == nil {
+		batch := cfg.EffectiveBatchSize()
+		for result.Sent < len(records) {
+			end := min(result.Sent+batch, len(records))
+			if err = sendWithRetry(ctx, sink, records[result.Sent:end]); err != nil {
+				break
+			}
+			result.Sent = end
+		}
+	}
+	result.Err = err
+	if err != nil {
+		s.writeBackoff(cfg.Name, backoff.next())
+	} else {
+		_ = os.Remove(s.backoffPath(cfg.Name))
+	}
+
+	remaining := records[result.Sent:]
+	if len(remaining) > maxQueuedRecords {
+		result.Dropped = len(remaining) - maxQueuedRecords
+		remaining = remaining[result.Dropped:]
+	}
+	if len(remaining) > 0 {
+		if werr := s.writeRetry(cfg.Name, remaining); werr != nil {
+			// Keep the claimed files so nothing is lost; they are retaken
+			// once the claim goes stale.
+			result.Err = errors.Join(result.Err, werr)
+			return result
+		}
+	}
+	for _, path := range claimed {
+		_ = os.Remove(path)
+	}
+	result.Pending = len(remaining)
+	return result
+}
+
+// sinkBackoff is when automatic flushes of a failing sink resume.
+type sinkBackoff struct {
+	Until time.Time     `json:"until"`
+	Delay time.Duration `json:"delay"`
+}
+
+func (b sinkBackoff) next() sinkBackoff {
+	delay := min(max(b.Delay*2, minSinkBackoff), maxSinkBackoff)
+	return sinkBackoff{Until: time.Now().Add(delay), Delay: delay}
+}
+
+func (s *FileStore) backoffPath(name string) string {
+	return filepath.Join(s.outboxDir(), name+".backoff")
+}
+
+func (s *FileStore) readBackoff(name string) sinkBackoff {
+	var b sinkBackoff
+	if data, err := os.ReadFile(s.backoffPath(name)); err == nil {
+		_ = json.Unmarshal(data, &b)
+	}
+	return b
+}
+
+func (s *FileStore) writeBackoff(name string, b sinkBackoff) {
+	if data, err := json.Marshal(b); err == nil {
+		_ = os.WriteFile(s.backoffPath(name), data, 0600)
+	}
+}
+
+// queuedFiles lists a sink's outbox, retry files, and stale claims.
+func (s *FileStore) queuedFiles(name string) []string {
+	dir := s.outboxDir()
+	var files []string
+	if matches, _ := filepath.Glob(filepath.Join(dir, name+".*"+retryExt)); len(matches) > 0 {
+		files = append(files, matches...)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, name+".*"+sendingExt)); len(matches) > 0 {
+		for _, path := range matches {
+			if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleClaimAge {
+				files = append(files, path)
+			}
+		}
+	}
+	sort.Strings(files)
+	main := filepath.Join(dir, name+outboxExt)
+	if _, err := os.Stat(main); err == nil {
+		files = append(files, main)
+	}
+	return files
+}
+
+// claimFiles renames files to claim names. Files another process claimed
+// first are skipped.
+func claimFiles(files []string) []string {
+	claimed := make([]string, 0, len(files))
+	for _, path := range files {
+		base := filepath.Base(path)
+		name := base[:strings.Index(base, ".")]
+		claim := filepath.Join(filepath.Dir(path), name+"."+uuid.New().String()+sendingExt)
+		if err := os.Rename(path, claim); err == nil {
+			// Touch the claim so it is not mistaken for a stale one.
+			now := time.Now()
+			_ = os.Chtimes(claim, now, now)
+			claimed = append(claimed, claim)
+		}
+	}
+	return claimed
+}
+
+// writeRetry atomically writes records to a new retry file.
+func (s *FileStore) writeRetry(name string, records []SinkRecord) error {
+	dir := s.outboxDir()
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create retry file: %w", err)
+	}
+	w := bufio.NewWriter(tmp)
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+			return fmt.Errorf("marshal record: %w", err)
+		}
+		_, _ = w.Write(append(line, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write retry file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write retry file: %w", err)
+	}
+	// Retry files sort by creation time, keeping the queue in order.
+	final := filepath.Join(dir, fmt.Sprintf("%s.%d-%s%s", name, time.Now().UnixNano(), uuid.New().String()[:8], retryExt))
+	if err := os.Rename(tmp.Name(), final); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write retry file: %w", err)
+	}
+	return nil
+}
+
+// readRecords reads queued records in order, skipping unreadable lines.
+func readRecords(files []string) ([]SinkRecord, error) {
+	var records []SinkRecord
+	for _, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("read outbox: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var record SinkRecord
+			if json.Unmarshal(scanner.Bytes(), &record) == nil {
+				records = append(records, record)
+			}
+		}
+		_ = f.Close()
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// TestSink sends a single test record to cfg, without retries.
+func TestSink(ctx context.Context, cfg domain.AuditSink, token string) error {
+	sink, err := NewSink(cfg, token)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, sinkTimeout)
+	defer cancel()
+	return sink.Send(ctx, []SinkRecord{{
+		AuditEntry: domain.AuditEntry{
+			ID:        uuid.New().String(),
+			Timestamp: time.Now(),
+			Command:   "audit test-sink",
+			Status:    domain.AuditStatusSuccess,
+			Details:   map[string]string{"test": "true", "sink": cfg.Name},
+		},
+		Host: hostname(),
+	}})
+}
+
+// SinkStatus reports how many entries are queued for each sink.
+func (s *FileStore) SinkStatus() []SinkResult {
+	s.mu.RLock()
+	sinks := append([]domain.AuditSink(nil), s.config.Sinks...)
+	s.mu.RUnlock()
+
+	results := make([]SinkResult, 0, len(sinks))
+	for _, cfg := range sinks {
+		records, err := readRecords(s.queuedFiles(cfg.Name))
+		results = append(results, SinkResult{Sink: cfg.Name, Pending: len(records), Err: err})
+	}
+	return results
+}
+
+// ClearOutbox removes everything queued for the named sink.
+func (s *FileStore) ClearOutbox(name string) error {
+	matches, _ := filepath.Glob(filepath.Join(s.outboxDir(), name+".*"))
+	for _, path := range matches {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("clear outbox: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// collector is an HTTPS sink endpoint that can be switched to failing.
+type collector struct {
+	mu       sync.Mutex
+	failing  bool
+	received []SinkRecord
+	server   *httptest.Server
+}
+
+func newCollector(t *testing.T) *collector {
+	c := &collector{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.failing {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var batch []SinkRecord
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &batch)
+		c.received = append(c.received, batch...)
+	}))
+	t.Cleanup(c.server.Close)
+	return c
+}
+
+func (c *collector) setFailing(failing bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failing = failing
+}
+
+func (c *collector) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.received)
+}
+
+func newSinkStore(t *testing.T, sinks ...domain.AuditSink) *FileStore {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := domain.DefaultAuditConfig()
+	cfg.Enabled, cfg.Initialized, cfg.Path = true, true, dir
+	cfg.Sinks = sinks
+	if err := store.SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func logEntries(t *testing.T, store *FileStore, n int) {
+	t.Helper()
+	for range n {
+		if err := store.Log(&domain.AuditEntry{Command: "email list", Status: domain.AuditStatusSuccess}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func noToken(string) string { return "" }
+
+func TestFlushSinks_Batching(t *testing.T) {
+	fastRetries(t)
+	c := newCollector(t)
+	store := newSinkStore(t, domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: c.server.URL, BatchSize: 3})
+
+	logEntries(t, store, 2)
+	results := store.FlushSinks(context.Background(), false, noToken)
+	if results[0].Sent != 0 || results[0].Pending != 2 || c.count() != 0 {
+		t.Fatalf("sent before the batch filled: %+v", results[0])
+	}
+
+	logEntries(t, store, 1)
+	results = store.FlushSinks(context.Background(), false, noToken)
+	if results[0].Err != nil || results[0].Sent != 3 || results[0].Pending != 0 || c.count() != 3 {
+		t.Fatalf("full batch: %+v, collector has %d", results[0], c.count())
+	}
+
+	// A forced flush sends a partial batch.
+	logEntries(t, store, 1)
+	results = store.FlushSinks(context.Background(), true, noToken)
+	if results[0].Sent != 1 || c.count() != 4 {
+		t.Fatalf("forced flush: %+v", results[0])
+	}
+	if c.received[0].Host != hostname() {
+		t.Errorf("host = %q, want %q", c.received[0].Host, hostname())
+	}
+}
+
+func TestFlushSinks_OldEntriesAreDue(t *testing.T) {
+	fastRetries(t)
+	c := newCollector(t)
+	store := newSinkStore(t, domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: c.server.URL})
+
+	if err := store.Log(&domain.AuditEntry{Command: "email list", Timestamp: time.Now().Add(-domain.AuditSinkMaxAge - time.Second)}); err != nil {
+		t.Fatal(err)
+	}
+	results := store.FlushSinks(context.Background(), false, noToken)
+	if results[0].Sent != 1 {
+		t.Fatalf("an entry older than the max age should be sent: %+v", results[0])
+	}
+}
+
+func TestFlushSinks_FailureKeepsEntriesAndBacksOff(t *testing.T) {
+	fastRetries(t)
+	c := newCollector(t)
+	c.setFailing(true)
+	store := newSinkStore(t, domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: c.server.URL, BatchSize: 2})
+
+	logEntries(t, store, 2)
+	results := store.FlushSinks(context.Background(), false, noToken)
+	if results[0].Err == nil || results[0].Pending != 2 {
+		t.Fatalf("failed flush: %+v", results[0])
+	}
+	if matches, _ := filepath.Glob(filepath.Join(store.Path(), "outbox", "c.*.sending")); len(matches) != 0 {
+		t.Errorf("claims left behind: %v", matches)
+	}
+
+	// The collector recovers, but automatic flushes wait out the backoff.
+	c.setFailing(false)
+	logEntries(t, store, 1)
+	results = store.FlushSinks(context.Background(), false, noToken)
+	if results[0].Sent != 0 || results[0].Pending != 3 {
+		t.Fatalf("flushed during backoff: %+v", results[0])
+	}
+
+	// A forced flush ignores the backoff and sends everything in order.
+	results = store.FlushSinks(context.Background(), true, noToken)
+	if results[0].Err != nil || results[0].Sent != 3 || c.count() != 3 {
+		t.Fatalf("forced flush: %+v", results[0])
+	}
+	if _, err := os.Stat(filepath.Join(store.Path(), "outbox", "c.backoff")); !os.IsNotExist(err) {
+		t.Error("backoff should be cleared after a delivery")
+	}
+	if status := store.SinkStatus(); status[0].Pending != 0 {
+		t.Errorf("queue not empty: %+v", status)
+	}
+}
+
+func TestFlushSinks_SinksAreIndependent(t *testing.T) {
+	fastRetries(t)
+	up, down := newCollector(t), newCollector(t)
+	down.setFailing(true)
+	store := newSinkStore(t,
+		domain.AuditSink{Name: "up", Type: domain.AuditSinkHTTPS, URL: up.server.URL},
+		domain.AuditSink{Name: "down", Type: domain.AuditSinkHTTPS, URL: down.server.URL},
+	)
+
+	logEntries(t, store, 2)
+	results := store.FlushSinks(context.Background(), true, noToken)
+	if results[0].Sent != 2 || results[1].Err == nil || results[1].Pending != 2 {
+		t.Fatalf("results: %+v", results)
+	}
+
+	if err := store.ClearOutbox("down"); err != nil {
+		t.Fatal(err)
+	}
+	if status := store.SinkStatus(); status[1].Pending != 0 {
+		t.Errorf("cleared queue: %+v", status[1])
+	}
+}
+
+func TestLog_NoSinksNoOutbox(t *testing.T) {
+	store := newSinkStore(t)
+	logEntries(t, store, 1)
+	if _, err := os.Stat(filepath.Join(store.Path(), "outbox")); !os.IsNotExist(err) {
+		t.Error("outbox created without sinks")
+	}
+}
@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/httputil"
+)
+
+// sinkTimeout bounds a single delivery attempt.
+const sinkTimeout = 10 * time.Second
+
+// sinkRetryDelays are the waits between delivery attempts.
+var sinkRetryDelays = []time.Duration{500 * time.Millisecond, 2 * time.Second}
+
+// Sink ships a batch of audit records to a remote collector.
+type Sink interface {
+	Send(ctx context.Context, records []SinkRecord) error
+}
+
+// SinkRecord is an audit entry as shipped: the entry plus the machine that
+// recorded it, so a central collector can tell hosts apart.
+type SinkRecord struct {
+	domain.AuditEntry
+	Host string `json:"host,omitempty"`
+}
+
+// permanentError marks a delivery failure that retrying cannot fix, such as
+// a rejected token.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// NewSink creates the sink described by cfg. token is the bearer token for
+// https sinks; s3 sinks read the standard AWS_* environment variables.
+func NewSink(cfg domain.AuditSink, token string) (Sink, error) {
+	if err := ValidateSink(cfg); err != nil {
+		return nil, err
+	}
+	switch cfg.Type {
+	case domain.AuditSinkSyslog:
+		return newSyslogSink(cfg.URL)
+	case domain.AuditSinkHTTPS:
+		return &httpsSink{url: cfg.URL, token: token, client: httputil.NewClient(sinkTimeout)}, nil
+	default:
+		return newS3Sink(cfg)
+	}
+}
+
+// ValidateSink checks a sink's name, type and URL.
+func ValidateSink(cfg domain.AuditSink) error {
+	if cfg.Name == "" || strings.ContainsAny(cfg.Name, `/\. `) {
+		return fmt.Errorf("sink name %q must be non-empty without spaces, dots or slashes", cfg.Name)
+	}
+	if cfg.BatchSize < 0 {
+		return errors.New("batch size must not be negative")
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid sink URL %q", cfg.URL)
+	}
+
+	switch cfg.Type {
+	case domain.AuditSinkSyslog:
+		if u.Scheme != "udp" && u.Scheme != "tcp" && u.Scheme != "tls" {
+			return fmt.Errorf("syslog URL must start with udp://, tcp:// or tls://")
+		}
+		if u.Port() == "" {
+			return fmt.Errorf("syslog URL %q needs a port", cfg.URL)
+		}
+	case domain.AuditSinkHTTPS:
+		if u.Scheme != "https" && !(u.Scheme == "http" && isLoopback(u.Hostname())) {
+			return fmt.Errorf("collector URL must use https")
+		}
+	case domain.AuditSinkS3:
+		if u.Scheme != "s3" {
+			return fmt.Errorf("s3 URL must look like s3://bucket/prefix")
+		}
+		if cfg.Endpoint != "" {
+			if e, err := url.Parse(cfg.Endpoint); err != nil || e.Host == "" || (e.Scheme != "https" && e.Scheme != "http") {
+				return fmt.Errorf("invalid s3 endpoint %q", cfg.Endpoint)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown sink type %q (use syslog, https or s3)", cfg.Type)
+	}
+	return nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// sendWithRetry delivers records, retrying transient failures.
+func sendWithRetry(ctx context.Context, sink Sink, records []SinkRecord) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, sinkTimeout)
+		err = sink.Send(attemptCtx, records)
+		cancel()
+
+		var perm permanentError
+		if err == nil || errors.As(err, &perm) || attempt >= len(sinkRetryDelays) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(sinkRetryDelays[attempt]):
+		}
+	}
+}
+
+// httpsSink POSTs each batch as a JSON array.
+type httpsSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func (s *httpsSink) Send(ctx context.Context, records []SinkRecord) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("marshal records: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post to collector: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return checkSinkResponse("collector", resp)
+}
+
+// checkSinkResponse turns a non-2xx response into an error; 4xx other than
+// 408 and 429 are permanent.
+func checkSinkResponse(what string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err := fmt.Errorf("%s returned %d: %s", what, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return permanentError{err}
+	}
+	return err
+}
+
+// hostname is recorded with every shipped entry.
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return name
+}
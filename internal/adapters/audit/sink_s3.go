@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/httputil"
+)
+
+// s3Sink writes each batch as a JSON Lines object under
+// prefix/YYYY/MM/DD/, signed with AWS Signature Version 4. Credentials come
+// from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+type s3Sink struct {
+	bucket   string
+	prefix   string
+	region   string
+	endpoint string // empty for AWS; path-style when set
+	client   *http.Client
+	now      func() time.Time
+}
+
+func newS3Sink(cfg domain.AuditSink) (*s3Sink, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 URL: %w", err)
+	}
+	region := cfg.Region
+	for _, env := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if region == "" {
+			region = os.Getenv(env)
+		}
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &s3Sink{
+		bucket:   u.Host,
+		prefix:   strings.Trim(u.Path, "/"),
+		region:   region,
+		endpoint: strings.TrimRight(cfg.Endpoint, "/"),
+		client:   httputil.NewClient(sinkTimeout),
+		now:      time.Now,
+	}, nil
+}
+
+func (s *s3Sink) Send(ctx context.Context, records []SinkRecord) error {
+	accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return permanentError{errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for s3 sinks")}
+	}
+
+	var body bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("marshal record: %w", err)
+		}
+		body.Write(append(line, '\n'))
+	}
+
+	now := s.now().UTC()
+	key := now.Format("2006/01/02/150405") + "-" + uuid.New().String() + ".jsonl"
+	if s.prefix != "" {
+		key = s.prefix + "/" + key
+	}
+
+	base, path := "https://"+s.bucket+".s3."+s.region+".amazonaws.com", "/"+key
+	if s.endpoint != "" {
+		base, path = s.endpoint, "/"+s.bucket+"/"+key
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, base+awsURIEncode(path), bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	signV4(req, body.Bytes(), accessKey, secretKey, os.Getenv("AWS_SESSION_TOKEN"), s.region, now)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("put to s3: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return checkSinkResponse("s3", resp)
+}
+
+// signV4 adds AWS Signature Version 4 headers for the s3 service.
+func signV4(req *http.Request, body []byte, accessKey, secretKey, sessionToken, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	signed := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+		signed = append(signed, "x-amz-security-token")
+	}
+
+	var headers strings.Builder
+	for _, name := range signed {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		headers.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonical))
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	for _, part := range []string{region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// awsURIEncode percent-encodes everything but unreserved characters and
+// slashes, as SigV4 canonical paths require.
+func awsURIEncode(path string) string {
+	var sb strings.Builder
+	for _, b := range []byte(path) {
+		switch {
+		case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/':
+			sb.WriteByte(b)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", b)
+		}
+	}
+	return sb.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Syslog priorities use the local0 facility.
+const (
+	syslogFacilityLocal0 = 16
+	syslogSeverityError  = 3
+	syslogSeverityInfo   = 6
+)
+
+// syslogSink sends each record as an RFC 5424 message whose body is the
+// record's JSON. Stream transports use octet-counting framing (RFC 6587).
+type syslogSink struct {
+	network string // udp, tcp or tls
+	addr    string
+}
+
+func newSyslogSink(rawURL string) (*syslogSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog URL: %w", err)
+	}
+	return &syslogSink{network: u.Scheme, addr: u.Host}, nil
+}
+
+func (s *syslogSink) Send(ctx context.Context, records []SinkRecord) error {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("connect to syslog %s: %w", s.addr, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	for _, record := range records {
+		msg, err := formatSyslog(record)
+		if err != nil {
+			return err
+		}
+		if s.network != "udp" {
+			msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+		}
+		if _, err := conn.Write(msg); err != nil {
+			return fmt.Errorf("write to syslog %s: %w", s.addr, err)
+		}
+	}
+	return nil
+}
+
+func (s *syslogSink) dial(ctx context.Context) (net.Conn, error) {
+	if s.network == "tls" {
+		dialer := &tls.Dialer{Config: &tls.Config{MinVersion: tls.VersionTLS12}}
+		return dialer.DialContext(ctx, "tcp", s.addr)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, s.network, s.addr)
+}
+
+// formatSyslog renders record as an RFC 5424 message.
+func formatSyslog(record SinkRecord) ([]byte, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("marshal record: %w", err)
+	}
+	severity := syslogSeverityInfo
+	if record.Status == domain.AuditStatusError {
+		severity = syslogSeverityError
+	}
+	host := record.Host
+	if host == "" {
+		host = "-"
+	}
+	header := fmt.Sprintf("<%d>1 %s %s nylas-cli %d audit - ",
+		syslogFacilityLocal0*8+severity,
+		record.Timestamp.UTC().Format(time.RFC3339Nano),
+		host, os.Getpid())
+	return append([]byte(header), body...), nil
+}
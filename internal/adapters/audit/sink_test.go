@@ -0,0 +1,245 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func testRecords(n int) []SinkRecord {
+	records := make([]SinkRecord, n)
+	for i := range records {
+		records[i] = SinkRecord{
+			AuditEntry: domain.AuditEntry{
+				ID:        "entry-" + string(rune('a'+i)),
+				Timestamp: time.Date(2026, 10, 18, 9, 0, i, 0, time.UTC),
+				Command:   "email list",
+				Status:    domain.AuditStatusSuccess,
+			},
+			Host: "laptop-1",
+		}
+	}
+	return records
+}
+
+func fastRetries(t *testing.T) {
+	t.Helper()
+	orig := sinkRetryDelays
+	sinkRetryDelays = []time.Duration{time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { sinkRetryDelays = orig })
+}
+
+func TestValidateSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		sink    domain.AuditSink
+		wantErr string
+	}{
+		{"syslog tls", domain.AuditSink{Name: "siem", Type: domain.AuditSinkSyslog, URL: "tls://logs.example.com:6514"}, ""},
+		{"syslog without port", domain.AuditSink{Name: "siem", Type: domain.AuditSinkSyslog, URL: "udp://logs.example.com"}, "needs a port"},
+		{"syslog bad scheme", domain.AuditSink{Name: "siem", Type: domain.AuditSinkSyslog, URL: "https://logs.example.com:514"}, "udp://"},
+		{"https", domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: "https://audit.example.com/ingest"}, ""},
+		{"plain http refused", domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: "http://audit.example.com/ingest"}, "must use https"},
+		{"plain http on loopback", domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: "http://127.0.0.1:8080/"}, ""},
+		{"s3", domain.AuditSink{Name: "archive", Type: domain.AuditSinkS3, URL: "s3://bucket/prefix"}, ""},
+		{"s3 bad endpoint", domain.AuditSink{Name: "archive", Type: domain.AuditSinkS3, URL: "s3://bucket", Endpoint: "minio:9000"}, "invalid s3 endpoint"},
+		{"unknown type", domain.AuditSink{Name: "x", Type: "kafka", URL: "kafka://broker:9092"}, "unknown sink type"},
+		{"bad name", domain.AuditSink{Name: "a.b", Type: domain.AuditSinkHTTPS, URL: "https://audit.example.com"}, "sink name"},
+		{"negative batch", domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: "https://audit.example.com", BatchSize: -1}, "batch size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSink(tt.sink)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPSSink(t *testing.T) {
+	fastRetries(t)
+
+	var calls atomic.Int32
+	var got []SinkRecord
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: server.URL}, "tok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sendWithRetry(context.Background(), sink, testRecords(3)); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want a retry after the 503", calls.Load())
+	}
+	if auth != "Bearer tok" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if len(got) != 3 || got[0].Host != "laptop-1" || got[2].Command != "email list" {
+		t.Errorf("collector got %+v", got)
+	}
+}
+
+func TestHTTPSSink_RejectedTokenIsNotRetried(t *testing.T) {
+	fastRetries(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "bad token", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	sink, _ := NewSink(domain.AuditSink{Name: "c", Type: domain.AuditSinkHTTPS, URL: server.URL}, "wrong")
+	err := sendWithRetry(context.Background(), sink, testRecords(1))
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("err = %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestSyslogSink_TCPFraming(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ln.Close() }()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		r := bufio.NewReader(conn)
+		var msgs []string
+		for range 2 {
+			var n int
+			if _, err := fmt.Fscan(r, &n); err != nil {
+				break
+			}
+			_, _ = r.ReadByte() // the space after the length
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				break
+			}
+			msgs = append(msgs, string(buf))
+		}
+		received <- msgs
+	}()
+
+	records := testRecords(2)
+	records[1].Status = domain.AuditStatusError
+	sink, err := NewSink(domain.AuditSink{Name: "siem", Type: domain.AuditSinkSyslog, URL: "tcp://" + ln.Addr().String()}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Send(context.Background(), records); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	msgs := <-received
+	if len(msgs) != 2 {
+		t.Fatalf("got %d messages", len(msgs))
+	}
+	if !strings.HasPrefix(msgs[0], "<134>1 2026-10-18T09:00:00Z laptop-1 nylas-cli ") {
+		t.Errorf("info message header: %q", msgs[0])
+	}
+	if !strings.HasPrefix(msgs[1], "<131>1 ") {
+		t.Errorf("error message priority: %q", msgs[1])
+	}
+	body := msgs[0][strings.Index(msgs[0], "{"):]
+	var record SinkRecord
+	if err := json.Unmarshal([]byte(body), &record); err != nil || record.ID != "entry-a" {
+		t.Errorf("body = %q (%v)", body, err)
+	}
+}
+
+func TestS3Sink(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	var method, path, auth, payloadHash string
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		auth = r.Header.Get("Authorization")
+		payloadHash = r.Header.Get("X-Amz-Content-Sha256")
+		body, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSpace(string(body)), "\n")
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(domain.AuditSink{
+		Name: "archive", Type: domain.AuditSinkS3, URL: "s3://acme-audit/cli/",
+		Region: "eu-west-1", Endpoint: server.URL,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3 := sink.(*s3Sink)
+	s3.now = func() time.Time { return time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC) }
+
+	if err := sink.Send(context.Background(), testRecords(2)); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if method != http.MethodPut || !strings.HasPrefix(path, "/acme-audit/cli/2026/10/18/093000-") || !strings.HasSuffix(path, ".jsonl") {
+		t.Errorf("PUT %s %s", method, path)
+	}
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20261018/eu-west-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if len(payloadHash) != 64 || len(lines) != 2 {
+		t.Errorf("hash %q, %d lines", payloadHash, len(lines))
+	}
+}
+
+func TestS3Sink_MissingCredentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	sink, err := NewSink(domain.AuditSink{Name: "archive", Type: domain.AuditSinkS3, URL: "s3://bucket"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sink.Send(context.Background(), testRecords(1))
+	var perm permanentError
+	if err == nil || !errors.As(err, &perm) {
+		t.Fatalf("err = %v, want a permanent error", err)
+	}
+}
@@ -128,6 +128,21 @@ func (s *FileStore) Log(entry *domain.AuditEntry) error {
 		logPath = filepath.Join(s.basePath, "audit"+logFileExt)
 	}
 
+	// Link the entry into the hash chain
+	unlock, err := s.lockChain()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := s.loadChain()
+	if err != nil {
+		return err
+	}
+	entry.Seq = state.Seq + 1
+	entry.PrevHash = state.Hash
+	entry.Hash = EntryHash(*entry)
+
 	// Open file for appending
 	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
 	if err != nil {
@@ -145,7 +160,12 @@ func (s *FileStore) Log(entry *domain.AuditEntry) error {
 		return fmt.Errorf("write entry: %w", err)
 	}
 
-	return nil
+	state.Seq, state.Hash = entry.Seq, entry.Hash
+	if err := s.saveChain(state); err != nil {
+		return err
+	}
+
+	return s.enqueueForSinks(entry)
 }
 
 // Path returns the audit log directory path.
@@ -309,7 +329,8 @@ func (s *FileStore) readLogFile(path string) ([]domain.AuditEntry, error) {
 	return entries, scanner.Err()
 }
 
-// Clear removes all audit logs.
+// Clear removes all audit logs. The hash chain continues from the last
+// removed entry.
 func (s *FileStore) Clear(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -318,6 +339,23 @@ func (s *FileStore) Clear(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	unlock, err := s.lockChain()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := s.loadChain()
+	if err != nil {
+		return err
+	}
+	if err := s.advanceAnchor([]domain.AuditEntry{{Seq: state.Seq, Hash: state.Hash}}); err != nil {
+		return err
+	}
 
 	for _, file := range files {
 		select {
@@ -382,6 +420,12 @@ func (s *FileStore) Cleanup(ctx context.Context) error {
 		return err
 	}
 
+	unlock, err := s.lockChain()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	for _, file := range files {
 		select {
 		case <-ctx.Done():
@@ -398,6 +442,14 @@ func (s *FileStore) Cleanup(ctx context.Context) error {
 
 		if fileDate.Before(cutoff) {
 			path := filepath.Join(s.basePath, file)
+			// Record the removed entries as pruned, not missing.
+			removed, err := s.readLogFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("read %s: %w", file, err)
+			}
+			if err := s.advanceAnchor(removed); err != nil {
+				return err
+			}
 			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
 				return fmt.Errorf("remove %s: %w", file, err)
 			}
@@ -0,0 +1,225 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Kinds of chain problems found by Verify.
+const (
+	ProblemUnreadable = "unreadable" // a line is not a valid entry
+	ProblemModified   = "modified"   // an entry's content does not match its hash
+	ProblemLink       = "link"       // an entry does not follow the one before it
+	ProblemMissing    = "missing"    // entries are absent from the middle
+	ProblemDuplicate  = "duplicate"  // two entries share a sequence number
+	ProblemTruncated  = "truncated"  // entries are absent from the end
+	ProblemHead       = "head"       // the chain head disagrees with the entries
+	ProblemUnchained  = "unchained"  // an entry without a hash among chained ones
+)
+
+// VerifyProblem is one break in the audit chain.
+type VerifyProblem struct {
+	Kind   string `json:"kind"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Seq    int64  `json:"seq,omitempty"`
+	Detail string `json:"detail"`
+}
+
+// VerifyReport is the result of checking the audit chain.
+type VerifyReport struct {
+	Entries       int             `json:"entries"`   // chained entries checked
+	Unchained     int             `json:"unchained"` // entries written before chaining
+	FirstSeq      int64           `json:"first_seq,omitempty"`
+	LastSeq       int64           `json:"last_seq,omitempty"`
+	HeadSeq       int64           `json:"head_seq"`
+	PrunedThrough int64           `json:"pruned_through,omitempty"` // last entry removed by retention or clear
+	Problems      []VerifyProblem `json:"problems"`
+}
+
+// OK reports whether the chain is intact.
+func (r *VerifyReport) OK() bool { return len(r.Problems) == 0 }
+
+// locatedEntry is an entry with where it was read from.
+type locatedEntry struct {
+	domain.AuditEntry
+	file string
+	line int
+}
+
+// Verify checks every entry against its hash and its predecessor, and the
+// newest entry against the chain head.
+func (s *FileStore) Verify(ctx context.Context) (*VerifyReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report := &VerifyReport{Problems: []VerifyProblem{}}
+	state, err := s.loadChain()
+	if err != nil {
+		return nil, err
+	}
+	report.HeadSeq, report.PrunedThrough = state.Seq, state.AnchorSeq
+
+	files, err := s.getLogFiles()
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+
+	var chained []locatedEntry
+	var legacy []locatedEntry
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		entries, problems, err := readChainFile(filepath.Join(s.basePath, file), file)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+		report.Problems = append(report.Problems, problems...)
+		for _, e := range entries {
+			if e.Seq == 0 && e.Hash == "" {
+				legacy = append(legacy, e)
+				continue
+			}
+			if EntryHash(e.AuditEntry) != e.Hash {
+				report.Problems = append(report.Problems, VerifyProblem{
+					Kind: ProblemModified, File: e.file, Line: e.line, Seq: e.Seq,
+					Detail: "content does not match its hash",
+				})
+			}
+			chained = append(chained, e)
+		}
+	}
+	report.Entries, report.Unchained = len(chained), len(legacy)
+
+	sort.SliceStable(chained, func(i, j int) bool { return chained[i].Seq < chained[j].Seq })
+	report.Problems = append(report.Problems, checkLinks(chained, state)...)
+
+	// Unhashed entries are expected only from before chaining began.
+	if len(chained) > 0 {
+		first := earliest(chained)
+		for _, e := range legacy {
+			if e.Timestamp.After(first) {
+				report.Problems = append(report.Problems, VerifyProblem{
+					Kind: ProblemUnchained, File: e.file, Line: e.line,
+					Detail: "entry without a hash written after chaining began",
+				})
+			}
+		}
+		report.FirstSeq, report.LastSeq = chained[0].Seq, chained[len(chained)-1].Seq
+	}
+
+	return report, nil
+}
+
+// checkLinks walks entries in sequence order from the anchor to the head.
+func checkLinks(chained []locatedEntry, state chainState) []VerifyProblem {
+	var problems []VerifyProblem
+	nextSeq, prevHash := state.AnchorSeq+1, state.AnchorHash
+	var last *locatedEntry
+
+	for i := range chained {
+		e := &chained[i]
+		switch {
+		case last != nil && e.Seq == last.Seq:
+			problems = append(problems, VerifyProblem{
+				Kind: ProblemDuplicate, File: e.file, Line: e.line, Seq: e.Seq,
+				Detail: fmt.Sprintf("sequence number also used at %s:%d", last.file, last.line),
+			})
+			continue
+		case e.Seq < nextSeq:
+			// Older than the anchor: retention has not removed it yet.
+			last = e
+			continue
+		case e.Seq > nextSeq:
+			problems = append(problems, VerifyProblem{
+				Kind: ProblemMissing, File: e.file, Line: e.line, Seq: e.Seq,
+				Detail: describeGap(nextSeq, e.Seq-1),
+			})
+		case e.PrevHash != prevHash:
+			problems = append(problems, VerifyProblem{
+				Kind: ProblemLink, File: e.file, Line: e.line, Seq: e.Seq,
+				Detail: "does not follow the previous entry",
+			})
+		}
+		nextSeq, prevHash, last = e.Seq+1, e.Hash, e
+	}
+
+	switch {
+	case last == nil || last.Seq < state.Seq:
+		from := state.AnchorSeq + 1
+		if last != nil {
+			from = last.Seq + 1
+		}
+		if from <= state.Seq {
+			problems = append(problems, VerifyProblem{
+				Kind: ProblemTruncated, Seq: state.Seq,
+				Detail: describeGap(from, state.Seq) + " at the end",
+			})
+		}
+	case last.Seq > state.Seq:
+		problems = append(problems, VerifyProblem{
+			Kind: ProblemHead, File: last.file, Line: last.line, Seq: last.Seq,
+			Detail: fmt.Sprintf("entries after the recorded head (seq %d)", state.Seq),
+		})
+	case last.Hash != state.Hash:
+		problems = append(problems, VerifyProblem{
+			Kind: ProblemHead, File: last.file, Line: last.line, Seq: last.Seq,
+			Detail: "newest entry does not match the recorded head",
+		})
+	}
+	return problems
+}
+
+func describeGap(from, to int64) string {
+	if from == to {
+		return fmt.Sprintf("entry %d is missing", from)
+	}
+	return fmt.Sprintf("entries %d-%d are missing", from, to)
+}
+
+func earliest(entries []locatedEntry) time.Time {
+	first := entries[0].Timestamp
+	for _, e := range entries[1:] {
+		if e.Timestamp.Before(first) {
+			first = e.Timestamp
+		}
+	}
+	return first
+}
+
+// readChainFile reads a log file, reporting lines that do not parse.
+func readChainFile(path, name string) ([]locatedEntry, []VerifyProblem, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []locatedEntry
+	var problems []VerifyProblem
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry domain.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			problems = append(problems, VerifyProblem{Kind: ProblemUnreadable, File: name, Line: line, Detail: "not a valid entry"})
+			continue
+		}
+		entries = append(entries, locatedEntry{AuditEntry: entry, file: name, line: line})
+	}
+	return entries, problems, scanner.Err()
+}
@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// newChainStore returns a store with n chained entries in a single log file.
+func newChainStore(t *testing.T, n int) (*FileStore, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	store, err := NewFileStore(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	cfg := &domain.AuditConfig{Enabled: true, Initialized: true, Path: tmpDir, RetentionDays: 7}
+	if err := store.SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	for i := range n {
+		entry := &domain.AuditEntry{Command: "email list", Status: domain.AuditStatusSuccess, Details: map[string]string{"n": string(rune('a' + i))}}
+		if err := store.Log(entry); err != nil {
+			t.Fatalf("Log failed: %v", err)
+		}
+	}
+	return store, filepath.Join(tmpDir, "audit.jsonl")
+}
+
+func verifyKinds(t *testing.T, store *FileStore) []string {
+	t.Helper()
+	report, err := store.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	var kinds []string
+	for _, p := range report.Problems {
+		kinds = append(kinds, p.Kind)
+	}
+	return kinds
+}
+
+func rewriteLines(t *testing.T, path string, edit func([]string) []string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	lines = edit(lines)
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		t.Fatalf("write log: %v", err)
+	}
+}
+
+func TestFileStore_LogChainsEntries(t *testing.T) {
+	store, _ := newChainStore(t, 3)
+
+	entries, err := store.List(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+
+	bySeq := map[int64]domain.AuditEntry{}
+	for _, e := range entries {
+		bySeq[e.Seq] = e
+	}
+	if bySeq[1].PrevHash != "" {
+		t.Errorf("first entry PrevHash = %q, want empty", bySeq[1].PrevHash)
+	}
+	for seq := int64(2); seq <= 3; seq++ {
+		if bySeq[seq].PrevHash != bySeq[seq-1].Hash {
+			t.Errorf("entry %d does not link to entry %d", seq, seq-1)
+		}
+	}
+}
+
+func TestFileStore_Verify(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func([]string) []string
+		want []string
+	}{
+		{
+			name: "intact",
+			edit: func(lines []string) []string { return lines },
+		},
+		{
+			name: "modified entry",
+			edit: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], "email list", "email send", 1)
+				return lines
+			},
+			want: []string{ProblemModified},
+		},
+		{
+			name: "removed from the middle",
+			edit: func(lines []string) []string { return append(lines[:1], lines[2:]...) },
+			want: []string{ProblemMissing},
+		},
+		{
+			name: "truncated",
+			edit: func(lines []string) []string { return lines[:2] },
+			want: []string{ProblemTruncated},
+		},
+		{
+			name: "garbage line",
+			edit: func(lines []string) []string { return append(lines, "not json") },
+			want: []string{ProblemUnreadable},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, logPath := newChainStore(t, 4)
+			rewriteLines(t, logPath, tt.edit)
+
+			got := verifyKinds(t, store)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("problems = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileStore_VerifyAfterRetention(t *testing.T) {
+	store, logPath := newChainStore(t, 2)
+
+	// Age the file past retention, then keep logging into a fresh one.
+	oldPath := filepath.Join(store.Path(), time.Now().AddDate(0, 0, -10).Format(dateFormat)+logFileExt)
+	if err := os.Rename(logPath, oldPath); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if err := store.Cleanup(context.Background()); err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	if err := store.Log(&domain.AuditEntry{Command: "auth status"}); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+
+	report, err := store.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if !report.OK() {
+		t.Errorf("problems = %+v, want none", report.Problems)
+	}
+	if report.PrunedThrough != 2 || report.FirstSeq != 3 {
+		t.Errorf("PrunedThrough = %d, FirstSeq = %d, want 2 and 3", report.PrunedThrough, report.FirstSeq)
+	}
+}
+
+func TestFileStore_VerifyAfterClear(t *testing.T) {
+	store, _ := newChainStore(t, 2)
+
+	if err := store.Clear(context.Background()); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if got := verifyKinds(t, store); len(got) != 0 {
+		t.Errorf("problems after clear = %v, want none", got)
+	}
+
+	if err := store.Log(&domain.AuditEntry{Command: "auth status"}); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if got := verifyKinds(t, store); len(got) != 0 {
+		t.Errorf("problems after logging = %v, want none", got)
+	}
+}
@@ -0,0 +1,88 @@
+// Package autonotetaker stores the notetakers `notetaker sync` created in a
+// local JSON file.
+package autonotetaker
+
+import (
+	"github.com/nylas/cli/internal/adapters/jsonfile"
+	"github.com/nylas/cli/internal/domain"
+)
+
+const fileVersion = 1
+
+// Store implements ports.AutoNotetakerStore.
+type Store struct {
+	file *jsonfile.Store[fileShape]
+}
+
+type fileShape struct {
+	jsonfile.Versioned
+	Notetakers []domain.AutoNotetaker `json:"notetakers"`
+}
+
+// New creates a store backed by the file at path.
+func New(path string) *Store {
+	return &Store{file: jsonfile.New[fileShape](path, "auto-join notetakers", fileVersion)}
+}
+
+// Path returns the file path.
+func (s *Store) Path() string {
+	return s.file.Path()
+}
+
+// List returns the records for grantID in the order they were saved. A
+// corrupt file is an error, so sync never loses track of notetakers it has
+// to clean up.
+func (s *Store) List(grantID string) ([]domain.AutoNotetaker, error) {
+	shape, err := s.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	var records []domain.AutoNotetaker
+	for _, r := range shape.Notetakers {
+		if r.GrantID == grantID {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// Save adds records, replacing any existing one with the same notetaker ID
+// in place.
+func (s *Store) Save(records ...domain.AutoNotetaker) error {
+	return s.file.Update(func(shape *fileShape) error {
+		index := make(map[string]int, len(shape.Notetakers))
+		for i, r := range shape.Notetakers {
+			index[r.NotetakerID] = i
+		}
+		for _, r := range records {
+			if r.NotetakerID == "" || r.GrantID == "" {
+				return domain.ErrInvalidInput
+			}
+			if i, ok := index[r.NotetakerID]; ok {
+				shape.Notetakers[i] = r
+				continue
+			}
+			index[r.NotetakerID] = len(shape.Notetakers)
+			shape.Notetakers = append(shape.Notetakers, r)
+		}
+		return nil
+	})
+}
+
+// Delete removes the records with the given notetaker IDs.
+func (s *Store) Delete(notetakerIDs ...string) error {
+	return s.file.Update(func(shape *fileShape) error {
+		drop := make(map[string]bool, len(notetakerIDs))
+		for _, id := range notetakerIDs {
+			drop[id] = true
+		}
+		kept := shape.Notetakers[:0]
+		for _, r := range shape.Notetakers {
+			if !drop[r.NotetakerID] {
+				kept = append(kept, r)
+			}
+		}
+		shape.Notetakers = kept
+		return nil
+	})
+}
@@ -0,0 +1,60 @@
+package autonotetaker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestStore_SaveListDelete(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "notetaker-sync.json"))
+
+	records, err := s.List("grant-1")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	join := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+	require.NoError(t, s.Save(
+		domain.AutoNotetaker{NotetakerID: "nt-1", GrantID: "grant-1", EventID: "evt-1", JoinTime: join},
+		domain.AutoNotetaker{NotetakerID: "nt-2", GrantID: "grant-2", EventID: "evt-2", JoinTime: join},
+		domain.AutoNotetaker{NotetakerID: "nt-3", GrantID: "grant-1", EventID: "evt-3", JoinTime: join},
+	))
+
+	// Saving the same notetaker again replaces it in place.
+	require.NoError(t, s.Save(domain.AutoNotetaker{NotetakerID: "nt-1", GrantID: "grant-1", EventID: "evt-1", JoinTime: join.Add(time.Hour)}))
+
+	records, err = s.List("grant-1")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, join.Add(time.Hour), records[0].JoinTime)
+	assert.Equal(t, "nt-3", records[1].NotetakerID)
+
+	require.NoError(t, s.Delete("nt-1", "missing"))
+	records, err = s.List("grant-1")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "nt-3", records[0].NotetakerID)
+
+	info, err := os.Stat(s.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestStore_SaveRequiresIDs(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "notetaker-sync.json"))
+	assert.ErrorIs(t, s.Save(domain.AutoNotetaker{GrantID: "grant-1"}), domain.ErrInvalidInput)
+	assert.ErrorIs(t, s.Save(domain.AutoNotetaker{NotetakerID: "nt-1"}), domain.ErrInvalidInput)
+}
+
+func TestStore_CorruptFileIsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notetaker-sync.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err := New(path).List("grant-1")
+	assert.Error(t, err)
+}
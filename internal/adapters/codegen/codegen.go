@@ -0,0 +1,115 @@
+// Package codegen renders Nylas API requests as equivalent cURL, Go,
+// Python and Node.js code.
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Supported languages.
+const (
+	LangCurl   = "curl"
+	LangGo     = "go"
+	LangPython = "python"
+	LangNode   = "node"
+)
+
+// Languages lists the supported languages.
+var Languages = []string{LangCurl, LangGo, LangPython, LangNode}
+
+// Credentials are read from these environment variables in the rendered
+// code; the real values are never printed.
+const (
+	APIKeyEnv      = "NYLAS_API_KEY"
+	AccessTokenEnv = "NYLAS_ACCESS_TOKEN"
+)
+
+// maxBody is the largest body rendered inline; larger and binary bodies
+// are replaced with a placeholder.
+const maxBody = 16 * 1024
+
+// bodyFile is the placeholder file for bodies that aren't rendered.
+const bodyFile = "body.bin"
+
+// Render returns req as code in lang.
+func Render(lang string, req domain.APIRequest) (string, error) {
+	switch lang {
+	case LangCurl:
+		return renderCurl(req), nil
+	case LangGo:
+		return renderGo(req), nil
+	case LangPython:
+		return renderPython(req), nil
+	case LangNode:
+		return renderNode(req), nil
+	}
+	return "", fmt.Errorf("unsupported language %q (use %s)", lang, strings.Join(Languages, ", "))
+}
+
+// header is a request header in rendering order.
+type header struct {
+	name, value string
+}
+
+// headers returns the non-credential headers of req sorted by name.
+func headers(req domain.APIRequest) []header {
+	out := make([]header, 0, len(req.Header))
+	for name, value := range req.Header {
+		out = append(out, header{name, value})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].name < out[j].name })
+	return out
+}
+
+// tokenEnv returns the environment variable holding the request's
+// bearer credential, or "" for unauthenticated requests.
+func tokenEnv(req domain.APIRequest) string {
+	switch req.Auth {
+	case domain.APIAuthAPIKey:
+		return APIKeyEnv
+	case domain.APIAuthToken:
+		return AccessTokenEnv
+	}
+	return ""
+}
+
+// body returns the request body as text to render inline, pretty-printing
+// JSON. ok is false when the body is too large or not text; it is then
+// read from bodyFile instead.
+func body(req domain.APIRequest) (text string, isJSON, ok bool) {
+	if len(req.Body) == 0 {
+		return "", false, true
+	}
+	if len(req.Body) > maxBody || !utf8.Valid(req.Body) {
+		return "", false, false
+	}
+	if json.Valid(req.Body) {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, req.Body, "", "  "); err == nil {
+			return buf.String(), true, true
+		}
+	}
+	return string(req.Body), false, true
+}
+
+// indent prefixes every line of s but the first with prefix.
+func indent(s, prefix string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
+
+// jsonString quotes s as a JSON string, which is also a valid JavaScript
+// and Python string literal.
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
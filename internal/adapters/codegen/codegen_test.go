@@ -0,0 +1,99 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func postRequest() domain.APIRequest {
+	return domain.APIRequest{
+		Method: "POST",
+		URL:    "https://api.us.nylas.com/v3/grants/g1/folders",
+		Auth:   domain.APIAuthAPIKey,
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body:   []byte(`{"name":"It's done"}`),
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := map[string][]string{
+		LangCurl: {
+			"curl --request POST",
+			"--url 'https://api.us.nylas.com/v3/grants/g1/folders'",
+			`--header "Authorization: Bearer $NYLAS_API_KEY"`,
+			"--header 'Content-Type: application/json'",
+			`"name": "It'\''s done"`,
+		},
+		LangGo: {
+			"body := strings.NewReader(`{\n  \"name\": \"It's done\"\n}`)",
+			`http.NewRequest("POST", "https://api.us.nylas.com/v3/grants/g1/folders", body)`,
+			`req.Header.Set("Authorization", "Bearer "+os.Getenv("NYLAS_API_KEY"))`,
+			`req.Header.Set("Content-Type", "application/json")`,
+		},
+		LangPython: {
+			"response = requests.post(",
+			`"Authorization": f"Bearer {os.environ['NYLAS_API_KEY']}",`,
+			`"Content-Type": "application/json",`,
+			"data=r'''{\n  \"name\": \"It's done\"\n}''',",
+		},
+		LangNode: {
+			`const response = await fetch("https://api.us.nylas.com/v3/grants/g1/folders", {`,
+			`method: "POST",`,
+			"Authorization: `Bearer ${process.env.NYLAS_API_KEY}`,",
+			"body: JSON.stringify({\n    \"name\": \"It's done\"\n  }),",
+		},
+	}
+	for lang, want := range tests {
+		t.Run(lang, func(t *testing.T) {
+			code, err := Render(lang, postRequest())
+			require.NoError(t, err)
+			for _, w := range want {
+				assert.Contains(t, code, w)
+			}
+		})
+	}
+}
+
+func TestRender_GetWithoutBody(t *testing.T) {
+	req := domain.APIRequest{Method: "GET", URL: "https://api.us.nylas.com/v3/grants/g1/messages?limit=5", Auth: domain.APIAuthToken}
+
+	for _, lang := range Languages {
+		code, err := Render(lang, req)
+		require.NoError(t, err)
+		assert.Contains(t, code, AccessTokenEnv, lang)
+		assert.NotContains(t, code, "body", lang)
+	}
+	code, _ := Render(LangGo, req)
+	assert.Contains(t, code, `"https://api.us.nylas.com/v3/grants/g1/messages?limit=5", nil)`)
+}
+
+func TestRender_BinaryBody(t *testing.T) {
+	req := postRequest()
+	req.Body = []byte{0xff, 0xfe, 0x00}
+
+	code, err := Render(LangCurl, req)
+	require.NoError(t, err)
+	assert.Contains(t, code, "--data-binary @body.bin")
+
+	req.Body = []byte(strings.Repeat("a", maxBody+1))
+	code, err = Render(LangPython, req)
+	require.NoError(t, err)
+	assert.Contains(t, code, `open("body.bin", "rb")`)
+	assert.Contains(t, code, "data=body,")
+}
+
+func TestRender_Unauthenticated(t *testing.T) {
+	code, err := Render(LangCurl, domain.APIRequest{Method: "GET", URL: "https://example.com/x"})
+	require.NoError(t, err)
+	assert.NotContains(t, code, "Authorization")
+}
+
+func TestRender_UnknownLanguage(t *testing.T) {
+	_, err := Render("ruby", postRequest())
+	assert.ErrorContains(t, err, "unsupported language")
+}
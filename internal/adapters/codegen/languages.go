@@ -0,0 +1,140 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func renderCurl(req domain.APIRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "curl --request %s \\\n  --url %s", req.Method, shellQuote(req.URL))
+	if env := tokenEnv(req); env != "" {
+		fmt.Fprintf(&b, " \\\n  --header \"Authorization: Bearer $%s\"", env)
+	}
+	for _, h := range headers(req) {
+		fmt.Fprintf(&b, " \\\n  --header %s", shellQuote(h.name+": "+h.value))
+	}
+	text, _, ok := body(req)
+	switch {
+	case !ok:
+		fmt.Fprintf(&b, " \\\n  --data-binary @%s", bodyFile)
+	case text != "":
+		fmt.Fprintf(&b, " \\\n  --data %s", shellQuote(text))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// shellQuote single-quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func renderGo(req domain.APIRequest) string {
+	var b strings.Builder
+	text, _, ok := body(req)
+	reader := "nil"
+	switch {
+	case !ok:
+		fmt.Fprintf(&b, "body, err := os.Open(%q)\nif err != nil {\n\tlog.Fatal(err)\n}\ndefer body.Close()\n\n", bodyFile)
+		reader = "body"
+	case text != "":
+		fmt.Fprintf(&b, "body := strings.NewReader(%s)\n", goString(text))
+		reader = "body"
+	}
+	fmt.Fprintf(&b, "req, err := http.NewRequest(%q, %q, %s)\nif err != nil {\n\tlog.Fatal(err)\n}\n", req.Method, req.URL, reader)
+	if env := tokenEnv(req); env != "" {
+		fmt.Fprintf(&b, "req.Header.Set(\"Authorization\", \"Bearer \"+os.Getenv(%q))\n", env)
+	}
+	for _, h := range headers(req) {
+		fmt.Fprintf(&b, "req.Header.Set(%q, %q)\n", h.name, h.value)
+	}
+	b.WriteString("\nresp, err := http.DefaultClient.Do(req)\nif err != nil {\n\tlog.Fatal(err)\n}\ndefer resp.Body.Close()\n")
+	b.WriteString("if _, err := io.Copy(os.Stdout, resp.Body); err != nil {\n\tlog.Fatal(err)\n}\n")
+	return b.String()
+}
+
+// goString quotes s as a Go string literal, preferring a raw string.
+func goString(s string) string {
+	if !strings.Contains(s, "`") && !strings.Contains(s, "\r") {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
+
+func renderPython(req domain.APIRequest) string {
+	var b strings.Builder
+	b.WriteString("import os\n\nimport requests\n\n")
+	text, _, ok := body(req)
+	if !ok {
+		fmt.Fprintf(&b, "with open(%q, \"rb\") as f:\n    body = f.read()\n\n", bodyFile)
+	}
+
+	fn := strings.ToLower(req.Method)
+	switch req.Method {
+	case "GET", "POST", "PUT", "PATCH", "DELETE":
+		fmt.Fprintf(&b, "response = requests.%s(\n    %s,\n", fn, jsonString(req.URL))
+	default:
+		fmt.Fprintf(&b, "response = requests.request(\n    %s,\n    %s,\n", jsonString(req.Method), jsonString(req.URL))
+	}
+	if env := tokenEnv(req); env != "" || len(req.Header) > 0 {
+		b.WriteString("    headers={\n")
+		if env != "" {
+			fmt.Fprintf(&b, "        \"Authorization\": f\"Bearer {os.environ['%s']}\",\n", env)
+		}
+		for _, h := range headers(req) {
+			fmt.Fprintf(&b, "        %s: %s,\n", jsonString(h.name), jsonString(h.value))
+		}
+		b.WriteString("    },\n")
+	}
+	switch {
+	case !ok:
+		b.WriteString("    data=body,\n")
+	case text != "":
+		fmt.Fprintf(&b, "    data=%s,\n", pythonString(text))
+	}
+	b.WriteString(")\nresponse.raise_for_status()\nprint(response.text)\n")
+	return b.String()
+}
+
+// pythonString quotes s as a Python string literal, preferring a raw
+// triple-quoted string for multi-line text.
+func pythonString(s string) string {
+	if strings.Contains(s, "\n") && !strings.Contains(s, "'''") && !strings.HasSuffix(s, `\`) && !strings.Contains(s, "\r") {
+		return "r'''" + s + "'''"
+	}
+	return jsonString(s)
+}
+
+func renderNode(req domain.APIRequest) string {
+	var b strings.Builder
+	text, isJSON, ok := body(req)
+	if !ok {
+		fmt.Fprintf(&b, "import { readFile } from \"node:fs/promises\";\n\nconst body = await readFile(%s);\n\n", jsonString(bodyFile))
+	}
+	fmt.Fprintf(&b, "const response = await fetch(%s, {\n  method: %s,\n", jsonString(req.URL), jsonString(req.Method))
+	if env := tokenEnv(req); env != "" || len(req.Header) > 0 {
+		b.WriteString("  headers: {\n")
+		if env != "" {
+			fmt.Fprintf(&b, "    Authorization: `Bearer ${process.env.%s}`,\n", env)
+		}
+		for _, h := range headers(req) {
+			fmt.Fprintf(&b, "    %s: %s,\n", jsonString(h.name), jsonString(h.value))
+		}
+		b.WriteString("  },\n")
+	}
+	switch {
+	case !ok:
+		b.WriteString("  body,\n")
+	case isJSON:
+		// JSON is a JavaScript expression, so the body is kept readable.
+		fmt.Fprintf(&b, "  body: JSON.stringify(%s),\n", indent(text, "  "))
+	case text != "":
+		fmt.Fprintf(&b, "  body: %s,\n", jsonString(text))
+	}
+	b.WriteString("});\nif (!response.ok) {\n  throw new Error(`${response.status} ${await response.text()}`);\n}\nconsole.log(await response.text());\n")
+	return b.String()
+}
@@ -40,7 +40,7 @@ func DefaultConfigDir() string {
 
 // Load loads the configuration from the file.
 func (f *FileStore) Load() (*domain.Config, error) {
-	data, err := os.ReadFile(f.path)
+	data, err := ReadFile(f.path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return domain.DefaultConfig(), nil
@@ -67,20 +67,15 @@ func (f *FileStore) Load() (*domain.Config, error) {
 	return &config, nil
 }
 
-// Save saves the configuration to the file.
+// Save saves the configuration to the file. An encrypted file stays
+// encrypted.
 func (f *FileStore) Save(config *domain.Config) error {
-	// Ensure directory exists
-	dir := filepath.Dir(f.path)
-	if err := os.MkdirAll(dir, 0700); err != nil {
-		return err
-	}
-
 	data, err := yaml.Marshal(config)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(f.path, data, 0600)
+	return WriteFile(f.path, data)
 }
 
 // Path returns the path to the config file.
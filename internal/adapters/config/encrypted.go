@@ -0,0 +1,218 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/nylas/cli/internal/adapters/keyring"
+)
+
+// PassphraseEnv holds the passphrase for an encrypted config file.
+const PassphraseEnv = "NYLAS_CONFIG_PASSPHRASE"
+
+// encryptedHeader starts a config file sealed with `nylas config encrypt`.
+// The rest of the file is keyring.SealWithPassphrase output.
+const encryptedHeader = "nylas-config/v1+encrypted\n"
+
+// ErrPassphraseRequired is returned when an encrypted config file is read
+// and no passphrase is available.
+var ErrPassphraseRequired = errors.New("config file is encrypted; set " + PassphraseEnv)
+
+// PassphraseFunc returns the passphrase for an encrypted config file.
+type PassphraseFunc func() (string, error)
+
+var (
+	passphraseMu     sync.Mutex
+	passphraseSource PassphraseFunc = envPassphrase
+	passphraseCache  string
+)
+
+// SetPassphraseSource sets how the passphrase for an encrypted config file
+// is obtained, e.g. a terminal prompt. The source is asked at most once per
+// process; a passphrase that opens the file is remembered. Passing nil
+// restores the default, which reads NYLAS_CONFIG_PASSPHRASE.
+func SetPassphraseSource(fn PassphraseFunc) {
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+	if fn == nil {
+		fn = envPassphrase
+	}
+	passphraseSource = fn
+	passphraseCache = ""
+}
+
+func envPassphrase() (string, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return p, nil
+	}
+	return "", ErrPassphraseRequired
+}
+
+// openSealed decrypts an encrypted config file body, asking the passphrase
+// source when no passphrase has been remembered yet.
+func openSealed(sealed []byte) ([]byte, error) {
+	passphraseMu.Lock()
+	defer passphraseMu.Unlock()
+
+	passphrase := passphraseCache
+	if passphrase == "" {
+		p, err := passphraseSource()
+		if err != nil {
+			return nil, err
+		}
+		passphrase = p
+	}
+	plain, err := keyring.OpenWithPassphrase([]byte(passphrase), sealed)
+	if err != nil {
+		passphraseCache = ""
+		return nil, err
+	}
+	passphraseCache = passphrase
+	return plain, nil
+}
+
+// reseal encrypts plain to replace the encrypted file body current. When
+// no passphrase has been remembered yet, current is opened first so the
+// file is never re-sealed under a passphrase that did not open it.
+func reseal(current, plain []byte) ([]byte, error) {
+	passphraseMu.Lock()
+	passphrase := passphraseCache
+	passphraseMu.Unlock()
+	if passphrase == "" {
+		if _, err := openSealed(current[len(encryptedHeader):]); err != nil {
+			return nil, err
+		}
+		passphraseMu.Lock()
+		passphrase = passphraseCache
+		passphraseMu.Unlock()
+	}
+	return sealWith(passphrase, plain)
+}
+
+func sealWith(passphrase string, plain []byte) ([]byte, error) {
+	sealed, err := keyring.SealWithPassphrase([]byte(passphrase), plain)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(encryptedHeader), append(sealed, '\n')...), nil
+}
+
+// isEncrypted reports whether data is an encrypted config file.
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedHeader))
+}
+
+// IsEncrypted reports whether the config file is encrypted.
+func (f *FileStore) IsEncrypted() bool {
+	data, err := os.ReadFile(f.path)
+	return err == nil && isEncrypted(data)
+}
+
+// Encrypt seals the config file with passphrase. Later reads and writes in
+// this process use the same passphrase.
+func (f *FileStore) Encrypt(passphrase string) error {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	if isEncrypted(data) {
+		return errors.New("config file is already encrypted")
+	}
+	sealed, err := sealWith(passphrase, data)
+	if err != nil {
+		return err
+	}
+	if err := writeAtomic(f.path, sealed); err != nil {
+		return err
+	}
+
+	passphraseMu.Lock()
+	passphraseCache = passphrase
+	passphraseMu.Unlock()
+	return nil
+}
+
+// Decrypt rewrites an encrypted config file as plain YAML.
+func (f *FileStore) Decrypt() error {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	if !isEncrypted(data) {
+		return errors.New("config file is not encrypted")
+	}
+	plain, err := openSealed(data[len(encryptedHeader):])
+	if err != nil {
+		return fmt.Errorf("decrypt %s: %w", f.path, err)
+	}
+	return writeAtomic(f.path, plain)
+}
+
+// ReadFile returns the contents of the config file at path, decrypting it
+// when it is encrypted.
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the CLI config file
+	if err != nil {
+		return nil, err
+	}
+	if !isEncrypted(data) {
+		return data, nil
+	}
+	plain, err := openSealed(data[len(encryptedHeader):])
+	if err != nil {
+		return nil, fmt.Errorf("decrypt %s: %w", path, err)
+	}
+	return plain, nil
+}
+
+// WriteFile replaces the config file at path with data. A file that is
+// encrypted stays encrypted under the same passphrase.
+func WriteFile(path string, data []byte) error {
+	if current, err := os.ReadFile(path); err == nil && isEncrypted(current) { // #nosec G304 -- path is the CLI config file
+		if data, err = reseal(current, data); err != nil {
+			return fmt.Errorf("encrypt %s: %w", path, err)
+		}
+	}
+	return writeAtomic(path, data)
+}
+
+// writeAtomic replaces path atomically, so an interrupted write never
+// leaves a truncated config behind.
+func writeAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".config.yaml.tmp.*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	if err := tmp.Chmod(0600); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nylas/cli/internal/adapters/keyring"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+func newEncryptedTestStore(t *testing.T) *FileStore {
+	t.Helper()
+	t.Cleanup(func() { SetPassphraseSource(nil) })
+	SetPassphraseSource(nil)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("region: eu\n# keep me\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return NewFileStore(path)
+}
+
+func TestFileStore_EncryptRoundTrip(t *testing.T) {
+	store := newEncryptedTestStore(t)
+
+	if err := store.Encrypt(testPassphrase); err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !store.IsEncrypted() {
+		t.Fatal("IsEncrypted() = false after Encrypt()")
+	}
+	data, _ := os.ReadFile(store.Path())
+	if strings.Contains(string(data), "region") {
+		t.Error("encrypted file contains plaintext")
+	}
+	if err := store.Encrypt(testPassphrase); err == nil {
+		t.Error("Encrypt() of an encrypted file should fail")
+	}
+
+	// A later process supplies the passphrase through its source.
+	SetPassphraseSource(func() (string, error) { return testPassphrase, nil })
+	cfg, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Region != "eu" {
+		t.Errorf("Region = %q, want eu", cfg.Region)
+	}
+
+	cfg.Region = "us"
+	if err := store.Save(cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if !store.IsEncrypted() {
+		t.Error("Save() wrote an encrypted config in plaintext")
+	}
+
+	if err := store.Decrypt(); err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	data, _ = os.ReadFile(store.Path())
+	if !strings.Contains(string(data), "region: us") {
+		t.Errorf("decrypted config = %q", data)
+	}
+}
+
+func TestFileStore_EncryptedPassphraseErrors(t *testing.T) {
+	store := newEncryptedTestStore(t)
+	if err := store.Encrypt(testPassphrase); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(PassphraseEnv, "")
+	SetPassphraseSource(nil)
+	if _, err := store.Load(); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("Load() without passphrase error = %v, want ErrPassphraseRequired", err)
+	}
+
+	SetPassphraseSource(func() (string, error) { return "wrong passphrase!", nil })
+	if _, err := store.Load(); !errors.Is(err, keyring.ErrWrongPassphrase) {
+		t.Errorf("Load() with wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+	if err := store.Save(nil); !errors.Is(err, keyring.ErrWrongPassphrase) {
+		t.Errorf("Save() with wrong passphrase error = %v, want ErrWrongPassphrase", err)
+	}
+
+	t.Setenv(PassphraseEnv, testPassphrase)
+	SetPassphraseSource(nil)
+	if _, err := store.Load(); err != nil {
+		t.Errorf("Load() with %s error = %v", PassphraseEnv, err)
+	}
+}
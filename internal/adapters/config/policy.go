@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nylas/cli/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyFileName is the invoker policy file in the config directory.
+const PolicyFileName = "policy.yaml"
+
+// DefaultPolicyPath returns the default invoker policy file path.
+func DefaultPolicyPath() string {
+	return filepath.Join(DefaultConfigDir(), PolicyFileName)
+}
+
+// LoadInvokerPolicy reads and validates the invoker policy at path. A
+// missing file means no policy and returns nil.
+func LoadInvokerPolicy(path string) (*domain.InvokerPolicy, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var policy domain.InvokerPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &policy, nil
+}
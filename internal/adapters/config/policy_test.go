@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadInvokerPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	policy, err := LoadInvokerPolicy(filepath.Join(tmpDir, PolicyFileName))
+	if err != nil || policy != nil {
+		t.Fatalf("LoadInvokerPolicy(missing) = %v, %v; want nil, nil", policy, err)
+	}
+
+	path := filepath.Join(tmpDir, PolicyFileName)
+	content := `rules:
+  - sources: [claude-code, github-copilot]
+    deny: ["email send", "* delete"]
+    reason: Agents may draft but not send or delete
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	policy, err = LoadInvokerPolicy(path)
+	if err != nil {
+		t.Fatalf("LoadInvokerPolicy failed: %v", err)
+	}
+	if match := policy.Denied("claude-code", "contacts delete"); match == nil || match.Pattern != "* delete" {
+		t.Errorf("Denied(claude-code, contacts delete) = %+v, want match on \"* delete\"", match)
+	}
+
+	if err := os.WriteFile(path, []byte("rules:\n  - deny: [\"email send\"]\n"), 0600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	if _, err := LoadInvokerPolicy(path); err == nil || !strings.Contains(err.Error(), "sources is empty") {
+		t.Errorf("LoadInvokerPolicy(no sources) error = %v, want sources is empty", err)
+	}
+}
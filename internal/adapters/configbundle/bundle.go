@@ -0,0 +1,383 @@
+// Package configbundle moves a CLI setup between machines as one file.
+package configbundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	adapterconfig "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Version is the bundle format version written by Marshal.
+const Version = 1
+
+// encryptedHeader starts every encrypted bundle.
+const encryptedHeader = "nylas-config-bundle/v1+encrypted\n"
+
+// ErrPassphraseRequired is returned when reading an encrypted bundle
+// without a passphrase.
+var ErrPassphraseRequired = errors.New("bundle is encrypted; a passphrase is required")
+
+// ErrPlaintextSecrets is returned when an unencrypted bundle would carry
+// API keys written literally in config.yaml.
+var ErrPlaintextSecrets = errors.New("config.yaml holds plaintext secrets")
+
+// Bundle is a portable snapshot of a CLI setup: config files relative to
+// the config directory, the local grant list, and — only in encrypted
+// bundles — the credentials from the secret store.
+type Bundle struct {
+	Version      int                `json:"version"`
+	ExportedAt   time.Time          `json:"exported_at"`
+	Files        map[string]string  `json:"files"`
+	Grants       []domain.GrantInfo `json:"grants,omitempty"`
+	DefaultGrant string             `json:"default_grant,omitempty"`
+	Secrets      map[string]string  `json:"secrets,omitempty"`
+}
+
+// configFiles are the files a bundle carries, relative to the config
+// directory. Data files (audit logs, invitations, waitlists) and key
+// material (openpgp, smime) stay behind.
+var configFiles = []string{"config.yaml", "templates.json", "audit/config.json"}
+
+// readBundledFile reads the bundled file name at path. config.yaml is decrypted
+// when it is encrypted, so bundles always carry plain YAML.
+func readBundledFile(path, name string) ([]byte, error) {
+	if name == "config.yaml" {
+		return adapterconfig.ReadFile(path)
+	}
+	return os.ReadFile(path) // #nosec G304 -- path is inside the config directory
+}
+
+// writeBundledFile writes the bundled file name to path, keeping an encrypted
+// config.yaml encrypted.
+func writeBundledFile(path, name string, data []byte) error {
+	if name == "config.yaml" {
+		return adapterconfig.WriteFile(path, data)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// themesDir holds custom TUI themes, one YAML file each.
+const themesDir = "themes"
+
+// portableSecrets are the secret store keys carried by encrypted bundles.
+// Dashboard sessions and the grant token signing key are bound to this
+// machine and are never exported.
+var portableSecrets = []string{
+	ports.KeyAPIKey,
+	ports.KeyAPIKeyID,
+	ports.KeyClientID,
+	ports.KeyClientSecret,
+	ports.KeyOrgID,
+	ports.KeySMTPSecret,
+	ports.KeyNotifySlackWebhook,
+}
+
+// Collect reads the setup in dir. Secrets are read from secrets when it is
+// non-nil; pass nil for a bundle without credentials.
+func Collect(dir string, grants ports.GrantStore, secrets ports.SecretStore) (*Bundle, error) {
+	b := &Bundle{Version: Version, ExportedAt: time.Now().UTC().Truncate(time.Second), Files: map[string]string{}}
+
+	names := append([]string{}, configFiles...)
+	themes, err := filepath.Glob(filepath.Join(dir, themesDir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, theme := range themes {
+		names = append(names, path.Join(themesDir, filepath.Base(theme)))
+	}
+	for _, name := range names {
+		data, err := readBundledFile(filepath.Join(dir, filepath.FromSlash(name)), name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		b.Files[name] = string(data)
+	}
+
+	if grants != nil {
+		list, err := grants.ListGrants()
+		if err != nil {
+			return nil, fmt.Errorf("list grants: %w", err)
+		}
+		b.Grants = list
+		if id, err := grants.GetDefaultGrant(); err == nil {
+			b.DefaultGrant = id
+		}
+	}
+
+	if secrets != nil {
+		b.Secrets = map[string]string{}
+		for _, key := range secretKeys(b.Files["config.yaml"]) {
+			value, err := secrets.Get(key)
+			if errors.Is(err, domain.ErrSecretNotFound) || value == "" {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read secret %s: %w", key, err)
+			}
+			b.Secrets[key] = value
+		}
+	}
+	return b, nil
+}
+
+// secretKeys returns the portable secret keys, including each environment
+// profile's API key and each ${secret:...} reference named in configYAML.
+func secretKeys(configYAML string) []string {
+	keys := append([]string{}, portableSecrets...)
+	var cfg struct {
+		Environments map[string]any   `yaml:"environments"`
+		AI           *domain.AIConfig `yaml:"ai"`
+	}
+	if err := yaml.Unmarshal([]byte(configYAML), &cfg); err == nil {
+		names := make([]string, 0, len(cfg.Environments))
+		for name := range cfg.Environments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			keys = append(keys,
+				ports.EnvironmentSecretKey(name, ports.KeyAPIKey),
+				ports.EnvironmentSecretKey(name, ports.KeyAPIKeyID))
+		}
+		for _, field := range cfg.AI.SecretFields() {
+			if key, ok := domain.ParseSecretRef(*field.Value); ok && portableSecret(key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// plaintextSecrets returns the config paths in configYAML that hold a
+// literal secret rather than a reference.
+func plaintextSecrets(configYAML string) []string {
+	var cfg struct {
+		AI *domain.AIConfig `yaml:"ai"`
+	}
+	if err := yaml.Unmarshal([]byte(configYAML), &cfg); err != nil {
+		return nil
+	}
+	var paths []string
+	for _, field := range cfg.AI.SecretFields() {
+		if domain.IsPlaintextSecret(*field.Value) {
+			paths = append(paths, field.Path)
+		}
+	}
+	return paths
+}
+
+// Marshal encodes b, encrypting it when passphrase is non-empty. Bundles
+// carrying secrets, from the secret store or written literally in
+// config.yaml, must be encrypted.
+func Marshal(b *Bundle, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		if len(b.Secrets) > 0 {
+			return nil, errors.New("bundles with secrets must be encrypted")
+		}
+		if paths := plaintextSecrets(b.Files["config.yaml"]); len(paths) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrPlaintextSecrets, strings.Join(paths, ", "))
+		}
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return append(data, '\n'), nil
+	}
+	sealed, err := keyring.SealWithPassphrase([]byte(passphrase), data)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte(encryptedHeader), sealed...), '\n'), nil
+}
+
+// IsEncrypted reports whether data is an encrypted bundle.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedHeader))
+}
+
+// Unmarshal decodes a bundle written by Marshal and validates its paths.
+func Unmarshal(data []byte, passphrase string) (*Bundle, error) {
+	if IsEncrypted(data) {
+		if passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		plain, err := keyring.OpenWithPassphrase([]byte(passphrase), data[len(encryptedHeader):])
+		if err != nil {
+			return nil, err
+		}
+		data = plain
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("not a config bundle: %w", err)
+	}
+	if b.Version < 1 || b.Version > Version {
+		return nil, fmt.Errorf("unsupported config bundle version %d", b.Version)
+	}
+	for name := range b.Files {
+		if !portablePath(name) {
+			return nil, fmt.Errorf("config bundle contains an unexpected file %q", name)
+		}
+	}
+	for key := range b.Secrets {
+		if !portableSecret(key) {
+			return nil, fmt.Errorf("config bundle contains an unexpected secret %q", key)
+		}
+	}
+	return &b, nil
+}
+
+func portablePath(name string) bool {
+	for _, f := range configFiles {
+		if name == f {
+			return true
+		}
+	}
+	dir, file := path.Split(name)
+	return dir == themesDir+"/" && strings.HasSuffix(file, ".yaml") && path.Clean(name) == name
+}
+
+func portableSecret(key string) bool {
+	for _, k := range portableSecrets {
+		if key == k {
+			return true
+		}
+	}
+	if field, ok := strings.CutPrefix(key, ports.ConfigSecretKey("ai.")); ok {
+		return strings.HasSuffix(field, "."+ports.KeyAPIKey) && !strings.ContainsAny(field, "/\\")
+	}
+	env, ok := strings.CutPrefix(key, "env.")
+	return ok && strings.HasSuffix(env, "."+ports.KeyAPIKey) && !strings.ContainsAny(env, "/\\")
+}
+
+// Change describes one item Apply writes.
+type Change struct {
+	Kind   string `json:"kind"` // file, grant, secret
+	Name   string `json:"name"`
+	Action string `json:"action"` // create, replace, unchanged, skip
+}
+
+// ApplyOptions controls Apply.
+type ApplyOptions struct {
+	// Overwrite replaces files and secrets that already exist with
+	// different contents. Without it they are skipped.
+	Overwrite bool
+	// DryRun reports the changes without writing anything.
+	DryRun bool
+}
+
+// Apply writes b into dir and the given stores. It returns every change,
+// including skipped ones.
+func Apply(b *Bundle, dir string, grants ports.GrantStore, secrets ports.SecretStore, opts ApplyOptions) ([]Change, error) {
+	var changes []Change
+
+	names := make([]string, 0, len(b.Files))
+	for name := range b.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		current, err := readBundledFile(target, name)
+		exists := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return changes, fmt.Errorf("read %s: %w", name, err)
+		}
+		change := Change{Kind: "file", Name: name, Action: action(exists, string(current) == b.Files[name], opts.Overwrite)}
+		changes = append(changes, change)
+		if opts.DryRun || (change.Action != "create" && change.Action != "replace") {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return changes, err
+		}
+		if err := writeBundledFile(target, name, []byte(b.Files[name])); err != nil {
+			return changes, fmt.Errorf("write %s: %w", name, err)
+		}
+	}
+
+	if grants != nil {
+		for _, g := range b.Grants {
+			_, err := grants.GetGrant(g.ID)
+			change := Change{Kind: "grant", Name: grantName(g), Action: "create"}
+			if err == nil {
+				change.Action = "unchanged"
+			}
+			changes = append(changes, change)
+			if !opts.DryRun && change.Action == "create" {
+				if err := grants.SaveGrant(g); err != nil {
+					return changes, fmt.Errorf("save grant %s: %w", g.ID, err)
+				}
+			}
+		}
+		if b.DefaultGrant != "" && !opts.DryRun {
+			if _, err := grants.GetDefaultGrant(); errors.Is(err, domain.ErrNoDefaultGrant) || opts.Overwrite {
+				if err := grants.SetDefaultGrant(b.DefaultGrant); err != nil {
+					return changes, fmt.Errorf("set default grant: %w", err)
+				}
+			}
+		}
+	}
+
+	if secrets != nil {
+		keys := make([]string, 0, len(b.Secrets))
+		for key := range b.Secrets {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			current, err := secrets.Get(key)
+			exists := err == nil && current != ""
+			change := Change{Kind: "secret", Name: key, Action: action(exists, current == b.Secrets[key], opts.Overwrite)}
+			changes = append(changes, change)
+			if opts.DryRun || (change.Action != "create" && change.Action != "replace") {
+				continue
+			}
+			if err := secrets.Set(key, b.Secrets[key]); err != nil {
+				return changes, fmt.Errorf("store secret %s: %w", key, err)
+			}
+		}
+	}
+	return changes, nil
+}
+
+func action(exists, same, overwrite bool) string {
+	switch {
+	case !exists:
+		return "create"
+	case same:
+		return "unchanged"
+	case overwrite:
+		return "replace"
+	default:
+		return "skip"
+	}
+}
+
+func grantName(g domain.GrantInfo) string {
+	if g.Email != "" {
+		return g.Email
+	}
+	return g.ID
+}
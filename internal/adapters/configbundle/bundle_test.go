@@ -0,0 +1,192 @@
+package configbundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adapterconfig "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/grantcache"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const testConfig = "region: us\nenvironments:\n  staging:\n    region: eu\nai:\n  claude:\n    api_key: ${secret:config.ai.claude.api_key}\n"
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func sourceSetup(t *testing.T) (string, ports.GrantStore, *keyring.MockSecretStore) {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, dir, "config.yaml", testConfig)
+	writeFile(t, dir, "templates.json", `{"templates":[]}`)
+	writeFile(t, dir, "themes/dark.yaml", "name: dark\n")
+	writeFile(t, dir, "audit/2026-10-18.jsonl", "{}\n")
+	writeFile(t, dir, "invitations.json", "[]")
+
+	grants := grantcache.New(filepath.Join(t.TempDir(), "grants.json"))
+	require.NoError(t, grants.SaveGrant(domain.GrantInfo{ID: "grant-1", Email: "a@example.com", Provider: domain.ProviderGoogle}))
+	require.NoError(t, grants.SetDefaultGrant("grant-1"))
+
+	secrets := keyring.NewMockSecretStore()
+	require.NoError(t, secrets.Set(ports.KeyAPIKey, "nyk_main"))
+	require.NoError(t, secrets.Set(ports.EnvironmentSecretKey("staging", ports.KeyAPIKey), "nyk_staging"))
+	require.NoError(t, secrets.Set(ports.ConfigSecretKey("ai.claude.api_key"), "sk-ant"))
+	require.NoError(t, secrets.Set(ports.KeyDashboardUserToken, "session"))
+	require.NoError(t, secrets.Set(ports.KeyGrantTokenSigningKey, "signing"))
+	return dir, grants, secrets
+}
+
+func TestCollect(t *testing.T) {
+	dir, grants, secrets := sourceSetup(t)
+
+	b, err := Collect(dir, grants, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"config.yaml":      testConfig,
+		"templates.json":   `{"templates":[]}`,
+		"themes/dark.yaml": "name: dark\n",
+	}, b.Files)
+	assert.Len(t, b.Grants, 1)
+	assert.Equal(t, "grant-1", b.DefaultGrant)
+	assert.Nil(t, b.Secrets)
+
+	b, err = Collect(dir, grants, secrets)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		ports.KeyAPIKey:            "nyk_main",
+		"env.staging.api_key":      "nyk_staging",
+		"config.ai.claude.api_key": "sk-ant",
+	}, b.Secrets, "machine-bound secrets stay behind")
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	dir, grants, secrets := sourceSetup(t)
+	b, err := Collect(dir, grants, secrets)
+	require.NoError(t, err)
+
+	_, err = Marshal(b, "")
+	assert.Error(t, err, "secrets require encryption")
+
+	data, err := Marshal(b, "correct horse battery")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(data))
+	assert.NotContains(t, string(data), "nyk_main")
+
+	_, err = Unmarshal(data, "")
+	assert.ErrorIs(t, err, ErrPassphraseRequired)
+	_, err = Unmarshal(data, "wrong passphrase!")
+	assert.ErrorIs(t, err, keyring.ErrWrongPassphrase)
+
+	got, err := Unmarshal(data, "correct horse battery")
+	require.NoError(t, err)
+	assert.Equal(t, b, got)
+
+	b.Secrets = nil
+	plain, err := Marshal(b, "")
+	require.NoError(t, err)
+	assert.False(t, IsEncrypted(plain))
+	got, err = Unmarshal(plain, "")
+	require.NoError(t, err)
+	assert.Equal(t, b.Files, got.Files)
+}
+
+func TestMarshalRefusesPlaintextSecrets(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "config.yaml", "ai:\n  claude:\n    api_key: sk-ant-literal\n  openai:\n    api_key: ${OPENAI_API_KEY}\n  translation:\n    api_key: lt-literal\n")
+	b, err := Collect(dir, nil, nil)
+	require.NoError(t, err)
+
+	_, err = Marshal(b, "")
+	require.ErrorIs(t, err, ErrPlaintextSecrets)
+	assert.Contains(t, err.Error(), "ai.claude.api_key, ai.translation.api_key")
+	assert.NotContains(t, err.Error(), "ai.openai.api_key", "references aren't secrets")
+
+	data, err := Marshal(b, "correct horse battery")
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "sk-ant-literal")
+}
+
+func TestUnmarshalRejectsUnexpectedEntries(t *testing.T) {
+	for _, data := range []string{
+		`{"version":1,"files":{"../.bashrc":"x"}}`,
+		`{"version":1,"files":{"themes/../../x.yaml":"x"}}`,
+		`{"version":1,"files":{".secrets.enc":"x"}}`,
+		`{"version":1,"secrets":{"dashboard_user_token":"x"}}`,
+		`{"version":2,"files":{}}`,
+		`not json`,
+	} {
+		_, err := Unmarshal([]byte(data), "")
+		assert.Error(t, err, data)
+	}
+}
+
+func TestApply(t *testing.T) {
+	src, srcGrants, srcSecrets := sourceSetup(t)
+	b, err := Collect(src, srcGrants, srcSecrets)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeFile(t, dir, "config.yaml", "region: eu\n")
+	grants := grantcache.New(filepath.Join(t.TempDir(), "grants.json"))
+	secrets := keyring.NewMockSecretStore()
+
+	changes, err := Apply(b, dir, grants, secrets, ApplyOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Contains(t, changes, Change{Kind: "file", Name: "config.yaml", Action: "skip"})
+	assert.Contains(t, changes, Change{Kind: "file", Name: "themes/dark.yaml", Action: "create"})
+	assert.Contains(t, changes, Change{Kind: "grant", Name: "a@example.com", Action: "create"})
+	assert.Contains(t, changes, Change{Kind: "secret", Name: ports.KeyAPIKey, Action: "create"})
+	assert.NoFileExists(t, filepath.Join(dir, "themes", "dark.yaml"))
+	assert.Empty(t, secrets.GetAll())
+
+	_, err = Apply(b, dir, grants, secrets, ApplyOptions{})
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "region: eu\n", string(data), "existing files are kept without Overwrite")
+	assert.FileExists(t, filepath.Join(dir, "themes", "dark.yaml"))
+	got, err := grants.GetDefaultGrant()
+	require.NoError(t, err)
+	assert.Equal(t, "grant-1", got)
+	key, err := secrets.Get("env.staging.api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "nyk_staging", key)
+
+	changes, err = Apply(b, dir, grants, secrets, ApplyOptions{Overwrite: true})
+	require.NoError(t, err)
+	assert.Contains(t, changes, Change{Kind: "file", Name: "config.yaml", Action: "replace"})
+	assert.Contains(t, changes, Change{Kind: "file", Name: "templates.json", Action: "unchanged"})
+	data, err = os.ReadFile(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, testConfig, string(data))
+}
+
+func TestEncryptedConfigFile(t *testing.T) {
+	t.Cleanup(func() { adapterconfig.SetPassphraseSource(nil) })
+	dir := t.TempDir()
+	writeFile(t, dir, "config.yaml", testConfig)
+	store := adapterconfig.NewFileStore(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, store.Encrypt("config passphrase"))
+
+	b, err := Collect(dir, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, testConfig, b.Files["config.yaml"], "bundles carry plain YAML")
+
+	b.Files["config.yaml"] = "region: eu\n"
+	_, err = Apply(b, dir, nil, nil, ApplyOptions{Overwrite: true})
+	require.NoError(t, err)
+	assert.True(t, store.IsEncrypted(), "import keeps the config encrypted")
+	cfg, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "eu", cfg.Region)
+}
@@ -0,0 +1,117 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// dialTimeout bounds how long a client waits for the daemon to answer. The
+// socket is local, so anything slower means no daemon is serving it.
+const dialTimeout = 200 * time.Millisecond
+
+// ErrNotRunning is returned when no daemon listens on the socket.
+var ErrNotRunning = errors.New("daemon is not running")
+
+// DeclinedError is returned when the daemon refuses a request before
+// running anything. The command can run locally instead.
+type DeclinedError struct {
+	Reason string
+}
+
+func (e *DeclinedError) Error() string {
+	return "daemon declined the request: " + e.Reason
+}
+
+// Client talks to a daemon over its socket.
+type Client struct {
+	socket string
+}
+
+// NewClient creates a client for the daemon listening on socket.
+func NewClient(socket string) *Client {
+	return &Client{socket: socket}
+}
+
+// Run runs a command in the daemon with stdio as its stdin, stdout and
+// stderr, and returns the command's exit code. Errors that wrap
+// ErrNotRunning or are a *DeclinedError mean nothing ran.
+func (c *Client) Run(req *Request, stdio *Stdio) (int, error) {
+	req.Op = OpRun
+	conn, dec, err := c.open(req, stdio)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := accepted(dec); err != nil {
+		return 0, err
+	}
+	var frame Frame
+	if err := dec.Decode(&frame); err != nil {
+		return 0, fmt.Errorf("lost connection to daemon: %w", err)
+	}
+	if frame.Exit == nil {
+		return 0, errors.New("daemon sent no exit code")
+	}
+	return *frame.Exit, nil
+}
+
+// Status returns the status of the running daemon.
+func (c *Client) Status() (*Status, error) {
+	conn, dec, err := c.open(&Request{Op: OpStatus}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	var frame Frame
+	if err := dec.Decode(&frame); err != nil {
+		return nil, fmt.Errorf("read daemon status: %w", err)
+	}
+	if frame.Status == nil {
+		return nil, &DeclinedError{Reason: frame.Declined}
+	}
+	return frame.Status, nil
+}
+
+// Stop asks the daemon to exit once running commands finish.
+func (c *Client) Stop() error {
+	conn, dec, err := c.open(&Request{Op: OpStop}, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	return accepted(dec)
+}
+
+func (c *Client) open(req *Request, stdio *Stdio) (*net.UnixConn, *json.Decoder, error) {
+	nc, err := net.DialTimeout("unix", c.socket, dialTimeout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrNotRunning, err)
+	}
+	conn := nc.(*net.UnixConn)
+	if err := sendStdio(conn, stdio); err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("%w: %v", ErrNotRunning, err)
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("%w: %v", ErrNotRunning, err)
+	}
+	return conn, json.NewDecoder(conn), nil
+}
+
+// accepted reads the daemon's answer to a request.
+func accepted(dec *json.Decoder) error {
+	var frame Frame
+	if err := dec.Decode(&frame); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotRunning, err)
+	}
+	if !frame.Accepted {
+		return &DeclinedError{Reason: frame.Declined}
+	}
+	return nil
+}
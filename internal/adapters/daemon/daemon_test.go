@@ -0,0 +1,134 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer runs a daemon on a temporary socket until the test ends.
+func startServer(t *testing.T, run Runner) (*Server, *Client) {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "d.sock")
+	server := NewServer(socket, "1.2.3", run)
+	require.NoError(t, server.Listen())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+	return server, NewClient(socket)
+}
+
+// tempStdio returns files standing in for a client's stdin, stdout and
+// stderr.
+func tempStdio(t *testing.T, stdin string) *Stdio {
+	t.Helper()
+	dir := t.TempDir()
+	open := func(name, content string) *os.File {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		f, err := os.OpenFile(path, os.O_RDWR, 0)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = f.Close() })
+		return f
+	}
+	return &Stdio{Stdin: open("stdin", stdin), Stdout: open("stdout", ""), Stderr: open("stderr", "")}
+}
+
+func readFile(t *testing.T, f *os.File) string {
+	t.Helper()
+	data, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRunUsesClientFiles(t *testing.T) {
+	_, client := startServer(t, func(ctx context.Context, req *Request, stdio *Stdio) int {
+		in, _ := io.ReadAll(stdio.Stdin)
+		_, _ = io.WriteString(stdio.Stdout, strings.Join(req.Args, " ")+":"+string(in))
+		_, _ = io.WriteString(stdio.Stderr, req.Dir)
+		return 3
+	})
+
+	stdio := tempStdio(t, "piped")
+	code, err := client.Run(&Request{Version: "1.2.3", Args: []string{"email", "list"}, Dir: "/work"}, stdio)
+	require.NoError(t, err)
+	assert.Equal(t, 3, code)
+	assert.Equal(t, "email list:piped", readFile(t, stdio.Stdout))
+	assert.Equal(t, "/work", readFile(t, stdio.Stderr))
+}
+
+func TestRunDeclinesOtherVersions(t *testing.T) {
+	ran := false
+	_, client := startServer(t, func(context.Context, *Request, *Stdio) int {
+		ran = true
+		return 0
+	})
+
+	_, err := client.Run(&Request{Version: "9.9.9"}, tempStdio(t, ""))
+	var declined *DeclinedError
+	require.ErrorAs(t, err, &declined)
+	assert.Contains(t, declined.Reason, "1.2.3")
+	assert.False(t, ran)
+}
+
+func TestStatusAndStop(t *testing.T) {
+	server, client := startServer(t, func(context.Context, *Request, *Stdio) int { return 0 })
+	server.AddService("webhook listener")
+
+	_, err := client.Run(&Request{Version: "1.2.3"}, tempStdio(t, ""))
+	require.NoError(t, err)
+
+	status, err := client.Status()
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), status.PID)
+	assert.Equal(t, "1.2.3", status.Version)
+	assert.Equal(t, int64(1), status.Served)
+	assert.Equal(t, []string{"webhook listener"}, status.Services)
+
+	require.NoError(t, client.Stop())
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(server.Socket())
+		return errors.Is(err, os.ErrNotExist)
+	}, time.Second, 10*time.Millisecond, "socket removed on stop")
+}
+
+func TestClientWithoutDaemon(t *testing.T) {
+	client := NewClient(filepath.Join(t.TempDir(), "missing.sock"))
+	_, err := client.Run(&Request{}, tempStdio(t, ""))
+	assert.ErrorIs(t, err, ErrNotRunning)
+}
+
+func TestListen(t *testing.T) {
+	t.Run("refuses a second daemon", func(t *testing.T) {
+		server, _ := startServer(t, nil)
+		assert.ErrorIs(t, NewServer(server.Socket(), "1.2.3", nil).Listen(), ErrAlreadyRunning)
+	})
+
+	t.Run("replaces a stale socket", func(t *testing.T) {
+		socket := filepath.Join(t.TempDir(), "d.sock")
+		require.NoError(t, os.WriteFile(socket, nil, 0o600))
+		server := NewServer(socket, "1.2.3", nil)
+		require.NoError(t, server.Listen())
+		t.Cleanup(func() { _ = server.listener.Close() })
+
+		info, err := os.Stat(socket)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		assert.NotZero(t, info.Mode()&os.ModeSocket)
+	})
+}
@@ -0,0 +1,81 @@
+// Package daemon runs CLI commands in a long-lived process that keeps
+// credentials, caches and connections warm. Clients reach it over a unix
+// socket and hand it their stdin, stdout and stderr, so a command run by
+// the daemon reads and writes the client's files directly.
+//
+// A connection starts with one byte carrying the client's files as socket
+// control data, followed by a JSON Request. The daemon answers with JSON
+// Frames: for a run, an accepted or declined frame and then the exit code.
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/config"
+)
+
+// SocketEnv overrides the daemon socket path.
+const SocketEnv = "NYLAS_DAEMON_SOCKET"
+
+// Operations a client can request.
+const (
+	OpRun    = "run"
+	OpStatus = "status"
+	OpStop   = "stop"
+)
+
+// Request describes what the client wants.
+type Request struct {
+	Op string `json:"op"`
+
+	// Version is the client's CLI version. The daemon declines to run
+	// commands for a different version.
+	Version string `json:"version"`
+
+	// Args, Dir and Env describe the command to run for OpRun: the
+	// arguments after "nylas", the working directory and the environment.
+	Args []string `json:"args,omitempty"`
+	Dir  string   `json:"dir,omitempty"`
+	Env  []string `json:"env,omitempty"`
+}
+
+// Frame is a message from the daemon.
+type Frame struct {
+	// Accepted is the first frame of a run the daemon started, and the
+	// answer to a stop request.
+	Accepted bool `json:"accepted,omitempty"`
+
+	// Declined is the reason the daemon would not run the request.
+	Declined string `json:"declined,omitempty"`
+
+	Exit   *int    `json:"exit,omitempty"`
+	Status *Status `json:"status,omitempty"`
+}
+
+// Status describes a running daemon.
+type Status struct {
+	PID       int       `json:"pid"`
+	Version   string    `json:"version"`
+	Socket    string    `json:"socket"`
+	StartedAt time.Time `json:"started_at"`
+	Served    int64     `json:"commands_served"`
+	Services  []string  `json:"services,omitempty"`
+}
+
+// Stdio holds the files a command runs with.
+type Stdio struct {
+	Stdin  *os.File
+	Stdout *os.File
+	Stderr *os.File
+}
+
+// DefaultSocketPath returns NYLAS_DAEMON_SOCKET, or daemon.sock in the
+// config directory.
+func DefaultSocketPath() string {
+	if path := os.Getenv(SocketEnv); path != "" {
+		return path
+	}
+	return filepath.Join(config.DefaultConfigDir(), "daemon.sock")
+}
@@ -0,0 +1,236 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrAlreadyRunning is returned by Listen when another daemon answers
+	// on the socket.
+	ErrAlreadyRunning = errors.New("daemon is already running")
+
+	// ErrUnsupported is returned on platforms that cannot pass files over
+	// a unix socket.
+	ErrUnsupported = errors.New("the daemon is not supported on this platform")
+)
+
+// Runner runs one command for a client with the client's files and returns
+// its exit code. ctx is cancelled when the client disconnects.
+type Runner func(ctx context.Context, req *Request, stdio *Stdio) int
+
+// Server accepts client connections on a unix socket. Commands run one at
+// a time, since a Runner may change process-wide state.
+type Server struct {
+	socket  string
+	version string
+	run     Runner
+
+	listener  net.Listener
+	startedAt time.Time
+	served    atomic.Int64
+
+	runMu sync.Mutex
+
+	mu       sync.Mutex
+	services []string
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewServer creates a server for the given socket path. version is the CLI
+// version; requests from other versions are declined.
+func NewServer(socket, version string, run Runner) *Server {
+	return &Server{
+		socket:  socket,
+		version: version,
+		run:     run,
+		stopped: make(chan struct{}),
+	}
+}
+
+// AddService records a background service the daemon runs, e.g. a webhook
+// listener, so status can report it.
+func (s *Server) AddService(description string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.services = append(s.services, description)
+}
+
+// Socket returns the socket path.
+func (s *Server) Socket() string {
+	return s.socket
+}
+
+// Listen creates the socket. The directory is created private to the user
+// and the socket is only accessible by them. A socket left behind by a
+// daemon that exited is replaced.
+func (s *Server) Listen() error {
+	if !supported {
+		return ErrUnsupported
+	}
+	if err := os.MkdirAll(filepath.Dir(s.socket), 0o700); err != nil {
+		return fmt.Errorf("create socket directory: %w", err)
+	}
+	if _, err := os.Stat(s.socket); err == nil {
+		if conn, err := net.DialTimeout("unix", s.socket, dialTimeout); err == nil {
+			_ = conn.Close()
+			return ErrAlreadyRunning
+		}
+		if err := os.Remove(s.socket); err != nil {
+			return fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", s.socket)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", s.socket, err)
+	}
+	if err := os.Chmod(s.socket, 0o600); err != nil {
+		_ = listener.Close()
+		return fmt.Errorf("restrict socket permissions: %w", err)
+	}
+	s.listener = listener
+	s.startedAt = time.Now()
+	return nil
+}
+
+// Serve accepts connections until ctx is done or a client asks the daemon
+// to stop, then waits for running commands. The socket is removed on
+// return.
+func (s *Server) Serve(ctx context.Context) error {
+	if s.listener == nil {
+		if err := s.Listen(); err != nil {
+			return err
+		}
+	}
+	defer func() { _ = os.Remove(s.socket) }()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.stopped:
+		}
+		_ = s.listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-s.stopped:
+				return nil
+			default:
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("accept: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handle(ctx, conn.(*net.UnixConn))
+		}()
+	}
+}
+
+// Stop makes Serve return. Safe to call more than once.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() { close(s.stopped) })
+}
+
+// Status returns the daemon's current status.
+func (s *Server) Status() *Status {
+	s.mu.Lock()
+	services := append([]string(nil), s.services...)
+	s.mu.Unlock()
+	return &Status{
+		PID:       os.Getpid(),
+		Version:   s.version,
+		Socket:    s.socket,
+		StartedAt: s.startedAt,
+		Served:    s.served.Load(),
+		Services:  services,
+	}
+}
+
+func (s *Server) handle(ctx context.Context, conn *net.UnixConn) {
+	defer func() { _ = conn.Close() }()
+
+	stdio, err := receiveStdio(conn)
+	if err != nil {
+		return
+	}
+	if stdio != nil {
+		defer stdio.close()
+	}
+
+	dec := json.NewDecoder(conn)
+	enc := json.NewEncoder(conn)
+	var req Request
+	if err := dec.Decode(&req); err != nil {
+		return
+	}
+
+	switch req.Op {
+	case OpStatus:
+		_ = enc.Encode(Frame{Status: s.Status()})
+	case OpStop:
+		_ = enc.Encode(Frame{Accepted: true})
+		s.Stop()
+	case OpRun:
+		if stdio == nil {
+			_ = enc.Encode(Frame{Declined: "no files passed"})
+			return
+		}
+		s.handleRun(ctx, &req, stdio, conn, enc)
+	default:
+		_ = enc.Encode(Frame{Declined: fmt.Sprintf("unknown operation %q", req.Op)})
+	}
+}
+
+func (s *Server) handleRun(ctx context.Context, req *Request, stdio *Stdio, conn io.Reader, enc *json.Encoder) {
+	if req.Version != s.version {
+		_ = enc.Encode(Frame{Declined: fmt.Sprintf("daemon runs version %s, client is %s", s.version, req.Version)})
+		return
+	}
+
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	if err := enc.Encode(Frame{Accepted: true}); err != nil {
+		return
+	}
+
+	// The client sends nothing more; the read returns when it goes away.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		_, _ = io.Copy(io.Discard, conn)
+		cancel()
+	}()
+
+	code := s.run(ctx, req, stdio)
+	s.served.Add(1)
+	_ = enc.Encode(Frame{Exit: &code})
+}
+
+func (s *Stdio) close() {
+	_ = s.Stdin.Close()
+	_ = s.Stdout.Close()
+	_ = s.Stderr.Close()
+}
@@ -0,0 +1,61 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// supported reports whether the daemon can run on this platform.
+const supported = true
+
+// sendStdio writes the handshake byte, with stdio attached when given.
+func sendStdio(conn *net.UnixConn, stdio *Stdio) error {
+	var oob []byte
+	if stdio != nil {
+		oob = syscall.UnixRights(int(stdio.Stdin.Fd()), int(stdio.Stdout.Fd()), int(stdio.Stderr.Fd())) // #nosec G115 -- file descriptors fit in int
+	}
+	_, _, err := conn.WriteMsgUnix([]byte{0}, oob, nil)
+	return err
+}
+
+// receiveStdio reads the handshake byte and the files attached to it. It
+// returns nil when the client sent none.
+func receiveStdio(conn *net.UnixConn) (*Stdio, error) {
+	buf := make([]byte, 1)
+	oob := make([]byte, syscall.CmsgSpace(3*4))
+	_, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, err
+	}
+	if oobn == 0 {
+		return nil, nil
+	}
+
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, fmt.Errorf("parse control message: %w", err)
+	}
+	var fds []int
+	for i := range msgs {
+		rights, err := syscall.ParseUnixRights(&msgs[i])
+		if err != nil {
+			continue
+		}
+		fds = append(fds, rights...)
+	}
+	if len(fds) != 3 {
+		for _, fd := range fds {
+			_ = syscall.Close(fd)
+		}
+		return nil, fmt.Errorf("expected 3 files, got %d", len(fds))
+	}
+	return &Stdio{
+		Stdin:  os.NewFile(uintptr(fds[0]), "/dev/stdin"),  // #nosec G115 -- descriptors are non-negative
+		Stdout: os.NewFile(uintptr(fds[1]), "/dev/stdout"), // #nosec G115 -- descriptors are non-negative
+		Stderr: os.NewFile(uintptr(fds[2]), "/dev/stderr"), // #nosec G115 -- descriptors are non-negative
+	}, nil
+}
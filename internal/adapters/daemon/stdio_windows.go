@@ -0,0 +1,17 @@
+//go:build windows
+
+package daemon
+
+import "net"
+
+// supported reports whether the daemon can run on this platform. Windows
+// cannot pass files over a socket.
+const supported = false
+
+func sendStdio(*net.UnixConn, *Stdio) error {
+	return ErrUnsupported
+}
+
+func receiveStdio(*net.UnixConn) (*Stdio, error) {
+	return nil, ErrUnsupported
+}
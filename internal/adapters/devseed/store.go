@@ -0,0 +1,87 @@
+// Package devseed stores the objects `dev seed` created in a local JSON
+// file.
+package devseed
+
+import (
+	"github.com/nylas/cli/internal/adapters/jsonfile"
+	"github.com/nylas/cli/internal/domain"
+)
+
+const fileVersion = 1
+
+// Store implements ports.SeedStore.
+type Store struct {
+	file *jsonfile.Store[fileShape]
+}
+
+type fileShape struct {
+	jsonfile.Versioned
+	Items []domain.SeededItem `json:"items"`
+}
+
+// New creates a store backed by the file at path.
+func New(path string) *Store {
+	return &Store{file: jsonfile.New[fileShape](path, "seeded items", fileVersion)}
+}
+
+// Path returns the file path.
+func (s *Store) Path() string {
+	return s.file.Path()
+}
+
+// List returns the items seeded into grantID in the order they were saved.
+// A corrupt file is an error, so clean never loses track of seeded data.
+func (s *Store) List(grantID string) ([]domain.SeededItem, error) {
+	shape, err := s.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	var items []domain.SeededItem
+	for _, item := range shape.Items {
+		if item.GrantID == grantID {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// Save adds items. Several messages of one thread share the thread ID, so
+// an item already recorded for the same grant is skipped.
+func (s *Store) Save(items ...domain.SeededItem) error {
+	return s.file.Update(func(shape *fileShape) error {
+		seen := make(map[string]bool, len(shape.Items))
+		for _, item := range shape.Items {
+			seen[item.GrantID+"\x00"+item.ID] = true
+		}
+		for _, item := range items {
+			if item.ID == "" || item.GrantID == "" || item.Kind == "" {
+				return domain.ErrInvalidInput
+			}
+			key := item.GrantID + "\x00" + item.ID
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			shape.Items = append(shape.Items, item)
+		}
+		return nil
+	})
+}
+
+// Delete removes the items of grantID with the given IDs.
+func (s *Store) Delete(grantID string, ids ...string) error {
+	return s.file.Update(func(shape *fileShape) error {
+		drop := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			drop[id] = true
+		}
+		kept := shape.Items[:0]
+		for _, item := range shape.Items {
+			if item.GrantID != grantID || !drop[item.ID] {
+				kept = append(kept, item)
+			}
+		}
+		shape.Items = kept
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+package devseed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestStore_SaveListDelete(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "dev-seed.json"))
+
+	items, err := s.List("grant-1")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	require.NoError(t, s.Save(
+		domain.SeededItem{GrantID: "grant-1", Kind: domain.SeedThread, ID: "thread-1"},
+		domain.SeededItem{GrantID: "grant-2", Kind: domain.SeedContact, ID: "contact-1"},
+		domain.SeededItem{GrantID: "grant-1", Kind: domain.SeedEvent, ID: "event-1", CalendarID: "cal-1"},
+	))
+	// A reply in the same thread is recorded once.
+	require.NoError(t, s.Save(domain.SeededItem{GrantID: "grant-1", Kind: domain.SeedThread, ID: "thread-1"}))
+
+	items, err = s.List("grant-1")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "thread-1", items[0].ID)
+	assert.Equal(t, "cal-1", items[1].CalendarID)
+
+	require.NoError(t, s.Delete("grant-1", "thread-1", "contact-1"))
+	items, err = s.List("grant-1")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "event-1", items[0].ID)
+
+	items, err = s.List("grant-2")
+	require.NoError(t, err)
+	assert.Len(t, items, 1, "delete only touches the given grant")
+
+	info, err := os.Stat(s.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestStore_SaveRequiresFields(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "dev-seed.json"))
+
+	err := s.Save(domain.SeededItem{GrantID: "grant-1", ID: "thread-1"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestStore_CorruptFileIsAnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev-seed.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+
+	_, err := New(path).List("grant-1")
+	assert.ErrorContains(t, err, "read seeded items")
+}
@@ -0,0 +1,64 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+// kafkaSink produces records through the Confluent Kafka REST Proxy (v2 API),
+// keyed by event ID so redeliveries land on the same partition.
+type kafkaSink struct {
+	endpoint string
+	user     *url.Userinfo
+	display  string
+	client   *http.Client
+}
+
+func newKafkaSink(u *url.URL, topic string) *kafkaSink {
+	scheme := "http"
+	if u.Scheme == "kafka+https" {
+		scheme = "https"
+	}
+	endpoint := url.URL{Scheme: scheme, Host: u.Host, Path: "/topics/" + url.PathEscape(topic)}
+	return &kafkaSink{
+		endpoint: endpoint.String(),
+		user:     u.User,
+		display:  displayName(u),
+		client:   &http.Client{},
+	}
+}
+
+func (s *kafkaSink) Name() string { return s.display }
+
+func (s *kafkaSink) Publish(ctx context.Context, event *ports.WebhookEvent) error {
+	data, err := payload(event)
+	if err != nil {
+		return err
+	}
+
+	record := map[string]any{"value": json.RawMessage(data)}
+	if event.ID != "" {
+		record["key"] = event.ID
+	}
+	body, err := json.Marshal(map[string]any{"records": []any{record}})
+	if err != nil {
+		return err
+	}
+
+	req, err := newJSONRequest(http.MethodPost, s.endpoint, "application/vnd.kafka.json.v2+json", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if s.user != nil {
+		pass, _ := s.user.Password()
+		req.SetBasicAuth(s.user.Username(), pass)
+	}
+	return postJSON(ctx, s.client, req)
+}
+
+func (s *kafkaSink) Close() error { return nil }
@@ -0,0 +1,164 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+const natsDefaultPort = "4222"
+
+// natsSink publishes with the NATS core text protocol. A PING follows each
+// PUB so server-side errors (permissions, payload size) surface per event.
+type natsSink struct {
+	addr    string
+	subject string
+	useTLS  bool
+	user    string
+	pass    string
+	display string
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newNATSSink(u *url.URL, subject string) *natsSink {
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+	s := &natsSink{
+		addr:    host,
+		subject: subject,
+		useTLS:  u.Scheme == "nats+tls",
+		display: displayName(u),
+	}
+	if u.User != nil {
+		s.user = u.User.Username()
+		s.pass, _ = u.User.Password()
+	}
+	return s
+}
+
+func (s *natsSink) Name() string { return s.display }
+
+func (s *natsSink) Publish(ctx context.Context, event *ports.WebhookEvent) error {
+	data, err := payload(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// One reconnect attempt covers a connection the server closed while idle.
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			if err := s.connect(ctx); err != nil {
+				return err
+			}
+		}
+		err = s.publish(ctx, data)
+		if err == nil {
+			return nil
+		}
+		s.closeLocked()
+		if attempt == 1 || strings.HasPrefix(err.Error(), "nats: -ERR") {
+			return err
+		}
+	}
+}
+
+func (s *natsSink) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: publishTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("nats: connect %s: %w", s.addr, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(publishTimeout))
+
+	r := bufio.NewReader(conn)
+	info, err := r.ReadString('\n')
+	if err != nil || !strings.HasPrefix(info, "INFO ") {
+		_ = conn.Close()
+		return fmt.Errorf("nats: unexpected greeting from %s", s.addr)
+	}
+
+	if s.useTLS {
+		host, _, _ := net.SplitHostPort(s.addr)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return fmt.Errorf("nats: TLS handshake: %w", err)
+		}
+		conn = tlsConn
+		r = bufio.NewReader(conn)
+	}
+
+	opts := map[string]any{"verbose": false, "pedantic": false, "name": "nylas-cli", "lang": "go"}
+	switch {
+	case s.user != "" && s.pass != "":
+		opts["user"], opts["pass"] = s.user, s.pass
+	case s.user != "":
+		opts["auth_token"] = s.user
+	}
+	connect, _ := json.Marshal(opts)
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\n", connect); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("nats: %w", err)
+	}
+
+	s.conn, s.r = conn, r
+	return nil
+}
+
+func (s *natsSink) publish(ctx context.Context, data []byte) error {
+	deadline := time.Now().Add(publishTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = s.conn.SetDeadline(deadline)
+
+	if _, err := fmt.Fprintf(s.conn, "PUB %s %d\r\n%s\r\nPING\r\n", s.subject, len(data), data); err != nil {
+		return fmt.Errorf("nats: %w", err)
+	}
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("nats: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			_, _ = fmt.Fprint(s.conn, "PONG\r\n")
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: %s", line)
+		}
+	}
+}
+
+func (s *natsSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+	return nil
+}
+
+func (s *natsSink) closeLocked() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn, s.r = nil, nil
+	}
+}
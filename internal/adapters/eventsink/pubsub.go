@@ -0,0 +1,71 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+// pubSubTokenEnv holds an OAuth access token for the Pub/Sub REST API, e.g.
+// from `gcloud auth print-access-token`.
+const pubSubTokenEnv = "GOOGLE_OAUTH_ACCESS_TOKEN"
+
+// pubSubSink publishes through the Pub/Sub REST API. When
+// PUBSUB_EMULATOR_HOST is set, it targets the emulator without auth.
+type pubSubSink struct {
+	project string
+	topic   string
+	client  *http.Client
+}
+
+func newPubSubSink(project, topic string) *pubSubSink {
+	return &pubSubSink{project: project, topic: topic, client: &http.Client{}}
+}
+
+func (s *pubSubSink) Name() string {
+	return fmt.Sprintf("pubsub://%s/%s", s.project, s.topic)
+}
+
+func (s *pubSubSink) Publish(ctx context.Context, event *ports.WebhookEvent) error {
+	base, token := "https://pubsub.googleapis.com", os.Getenv(pubSubTokenEnv)
+	if emulator := os.Getenv("PUBSUB_EMULATOR_HOST"); emulator != "" {
+		base, token = "http://"+emulator, ""
+	} else if token == "" {
+		return fmt.Errorf("pubsub: set %s (for example from `gcloud auth print-access-token`)", pubSubTokenEnv)
+	}
+
+	data, err := payload(event)
+	if err != nil {
+		return err
+	}
+	message := map[string]any{"data": base64.StdEncoding.EncodeToString(data)}
+	if attrs := attributes(event); len(attrs) > 0 {
+		message["attributes"] = attrs
+	}
+	body, err := json.Marshal(map[string]any{"messages": []any{message}})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/topics/%s:publish", base, url.PathEscape(s.project), url.PathEscape(s.topic))
+	req, err := newJSONRequest(http.MethodPost, endpoint, "application/json", body)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if err := postJSON(ctx, s.client, req); err != nil {
+		return fmt.Errorf("pubsub: %w", err)
+	}
+	return nil
+}
+
+func (s *pubSubSink) Close() error { return nil }
@@ -0,0 +1,126 @@
+// Package eventsink forwards webhook events to message queues (NATS, Kafka
+// via the REST Proxy, Amazon SQS, and Google Cloud Pub/Sub) using their wire
+// or HTTP APIs directly, without vendor SDKs.
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+// publishTimeout bounds a single HTTP publish.
+const publishTimeout = 10 * time.Second
+
+// New creates a sink from a URL:
+//
+//	nats://[user:pass@]host:4222/subject     (nats+tls:// for TLS)
+//	kafka://host:8082/topic                  (Kafka REST Proxy; kafka+https:// for TLS)
+//	sqs://sqs.us-east-1.amazonaws.com/123456789012/queue  (sqs+http://...?region= for LocalStack)
+//	pubsub://project/topic
+func New(rawURL string) (ports.EventSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// url.Error repeats the input, which may carry a password.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return nil, fmt.Errorf("invalid sink URL: %w", err)
+	}
+	target := strings.Trim(u.Path, "/")
+
+	switch u.Scheme {
+	case "nats", "nats+tls":
+		if target == "" {
+			return nil, fmt.Errorf("nats sink needs a subject: nats://host:4222/<subject>")
+		}
+		return newNATSSink(u, target), nil
+	case "kafka", "kafka+http", "kafka+https":
+		if target == "" {
+			return nil, fmt.Errorf("kafka sink needs a topic: kafka://rest-proxy:8082/<topic>")
+		}
+		return newKafkaSink(u, target), nil
+	case "sqs", "sqs+http":
+		return newSQSSink(u)
+	case "pubsub":
+		if u.Host == "" || target == "" || strings.Contains(target, "/") {
+			return nil, fmt.Errorf("pubsub sink must look like pubsub://<project>/<topic>")
+		}
+		return newPubSubSink(u.Host, target), nil
+	default:
+		return nil, fmt.Errorf("unsupported sink scheme %q (use nats, kafka, sqs, or pubsub)", u.Scheme)
+	}
+}
+
+// displayName returns u without its userinfo for logs and status lines.
+// url.Redacted only masks a password, so a token passed as the user name
+// would still show.
+func displayName(u *url.URL) string {
+	shown := *u
+	shown.User = nil
+	return shown.String()
+}
+
+// payload returns the bytes forwarded for an event: the original request
+// body when available, so consumers see exactly what Nylas sent.
+func payload(event *ports.WebhookEvent) ([]byte, error) {
+	if len(event.RawBody) > 0 {
+		return event.RawBody, nil
+	}
+	return json.Marshal(event.Body)
+}
+
+// attributes returns the event metadata sent alongside the payload on
+// queues that support it.
+func attributes(event *ports.WebhookEvent) map[string]string {
+	attrs := map[string]string{}
+	if event.ID != "" {
+		attrs["id"] = event.ID
+	}
+	if event.Type != "" {
+		attrs["type"] = event.Type
+	}
+	if event.GrantID != "" {
+		attrs["grant_id"] = event.GrantID
+	}
+	return attrs
+}
+
+// postJSON sends body and fails on a non-2xx response, including the
+// response text in the error.
+func postJSON(ctx context.Context, client *http.Client, req *http.Request) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func newJSONRequest(method, endpoint, contentType string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
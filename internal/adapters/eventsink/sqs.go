@@ -0,0 +1,124 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+// sqsSink sends messages with the SQS JSON protocol, signed with SigV4 using
+// credentials from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and
+// AWS_SESSION_TOKEN.
+type sqsSink struct {
+	endpoint string
+	queueURL string
+	region   string
+	fifo     bool
+	display  string
+	client   *http.Client
+	now      func() time.Time
+}
+
+func newSQSSink(u *url.URL) (*sqsSink, error) {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.Host == "" || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("sqs sink must look like sqs://sqs.<region>.amazonaws.com/<account-id>/<queue>")
+	}
+
+	region := u.Query().Get("region")
+	if region == "" {
+		// sqs.<region>.amazonaws.com
+		if labels := strings.Split(u.Hostname(), "."); len(labels) >= 3 && labels[0] == "sqs" {
+			region = labels[1]
+		}
+	}
+	if region == "" {
+		return nil, fmt.Errorf("cannot infer the AWS region from %q; add ?region=<region>", u.Host)
+	}
+
+	scheme := "https"
+	if u.Scheme == "sqs+http" {
+		scheme = "http"
+	}
+	base := url.URL{Scheme: scheme, Host: u.Host, Path: "/"}
+	queue := url.URL{Scheme: scheme, Host: u.Host, Path: "/" + parts[0] + "/" + parts[1]}
+
+	return &sqsSink{
+		endpoint: base.String(),
+		queueURL: queue.String(),
+		region:   region,
+		fifo:     strings.HasSuffix(parts[1], ".fifo"),
+		display:  displayName(u),
+		client:   &http.Client{},
+		now:      time.Now,
+	}, nil
+}
+
+func (s *sqsSink) Name() string { return s.display }
+
+func (s *sqsSink) Publish(ctx context.Context, event *ports.WebhookEvent) error {
+	creds := awsCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return fmt.Errorf("sqs: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+
+	data, err := payload(event)
+	if err != nil {
+		return err
+	}
+
+	msg := map[string]any{
+		"QueueUrl":    s.queueURL,
+		"MessageBody": string(data),
+	}
+	if attrs := attributes(event); len(attrs) > 0 {
+		msgAttrs := make(map[string]any, len(attrs))
+		for k, v := range attrs {
+			msgAttrs[k] = map[string]string{"DataType": "String", "StringValue": v}
+		}
+		msg["MessageAttributes"] = msgAttrs
+	}
+	if s.fifo {
+		// Group by grant so each mailbox's events stay ordered; dedupe on the
+		// event ID so Nylas retries aren't enqueued twice.
+		group := event.GrantID
+		if group == "" {
+			group = "nylas"
+		}
+		msg["MessageGroupId"] = group
+		if event.ID != "" {
+			msg["MessageDeduplicationId"] = event.ID
+		} else {
+			msg["MessageDeduplicationId"] = sha256Hex(data)
+		}
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := newJSONRequest(http.MethodPost, s.endpoint, "application/x-amz-json-1.0", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Amz-Target", "AmazonSQS.SendMessage")
+	signV4(req, body, creds, s.region, "sqs", s.now())
+
+	if err := postJSON(ctx, s.client, req); err != nil {
+		return fmt.Errorf("sqs: %w", err)
+	}
+	return nil
+}
+
+func (s *sqsSink) Close() error { return nil }
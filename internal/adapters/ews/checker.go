@@ -0,0 +1,159 @@
+// Package ews checks connectivity to Exchange Web Services endpoints before
+// an on-premises Exchange account is connected.
+package ews
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// checkTimeout bounds each request to the Exchange server.
+const checkTimeout = 15 * time.Second
+
+// getInboxRequest is a minimal EWS call: it only succeeds for an
+// authenticated mailbox and returns the server version in the SOAP header.
+const getInboxRequest = `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"
+  xmlns:t="http://schemas.microsoft.com/exchange/services/2006/types"
+  xmlns:m="http://schemas.microsoft.com/exchange/services/2006/messages">
+  <soap:Header><t:RequestServerVersion Version="Exchange2010_SP2"/></soap:Header>
+  <soap:Body>
+    <m:GetFolder>
+      <m:FolderShape><t:BaseShape>IdOnly</t:BaseShape></m:FolderShape>
+      <m:FolderIds><t:DistinguishedFolderId Id="inbox"/></m:FolderIds>
+    </m:GetFolder>
+  </soap:Body>
+</soap:Envelope>`
+
+// Checker implements ports.EWSChecker.
+type Checker struct {
+	client func(insecure bool) *http.Client
+}
+
+var _ ports.EWSChecker = (*Checker)(nil)
+
+// NewChecker creates a checker that talks to the server directly.
+func NewChecker() *Checker {
+	return &Checker{client: func(insecure bool) *http.Client {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: insecure, // #nosec G402 -- opt-in for self-signed on-prem servers
+		}
+		return &http.Client{Transport: transport, Timeout: checkTimeout}
+	}}
+}
+
+// Check probes the endpoint without credentials to learn the offered auth
+// schemes, then tries the credentials if Basic is offered. Connection and
+// TLS failures are returned as errors.
+func (c *Checker) Check(ctx context.Context, opts domain.EWSCheckOptions) (*domain.EWSCheckResult, error) {
+	result := &domain.EWSCheckResult{Endpoint: opts.Endpoint}
+	client := c.client(opts.InsecureSkipVerify)
+
+	resp, err := c.post(ctx, client, opts.Endpoint, nil)
+	if err != nil {
+		return result, err
+	}
+	result.StatusCode = resp.StatusCode
+	result.AuthSchemes = authSchemes(resp.Header)
+	if resp.StatusCode == http.StatusNotFound {
+		return result, fmt.Errorf("no EWS endpoint at %s (HTTP 404)", opts.Endpoint)
+	}
+
+	if opts.Username == "" || !hasScheme(result.AuthSchemes, "Basic") {
+		return result, nil
+	}
+
+	result.AuthTested = true
+	resp, err = c.post(ctx, client, opts.Endpoint, &opts)
+	if err != nil {
+		return result, err
+	}
+	result.StatusCode = resp.StatusCode
+	if resp.StatusCode == http.StatusOK {
+		result.Authenticated = true
+		result.ServerVersion = resp.serverVersion
+	}
+	return result, nil
+}
+
+type checkResponse struct {
+	StatusCode    int
+	Header        http.Header
+	serverVersion string
+}
+
+func (c *Checker) post(ctx context.Context, client *http.Client, endpoint string, creds *domain.EWSCheckOptions) (*checkResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBufferString(getInboxRequest))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	if creds != nil {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach %s: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	out := &checkResponse{StatusCode: resp.StatusCode, Header: resp.Header}
+	if resp.StatusCode == http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		out.serverVersion = parseServerVersion(body)
+	}
+	return out, nil
+}
+
+// authSchemes lists the schemes named in WWW-Authenticate headers.
+func authSchemes(h http.Header) []string {
+	var schemes []string
+	for _, v := range h.Values("WWW-Authenticate") {
+		scheme, _, _ := strings.Cut(strings.TrimSpace(v), " ")
+		if scheme != "" && !hasScheme(schemes, scheme) {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes
+}
+
+func hasScheme(schemes []string, want string) bool {
+	for _, s := range schemes {
+		if strings.EqualFold(s, want) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseServerVersion reads ServerVersionInfo from a SOAP response header,
+// e.g. "15.1.2507".
+func parseServerVersion(body []byte) string {
+	var env struct {
+		Header struct {
+			Version struct {
+				Major string `xml:"MajorVersion,attr"`
+				Minor string `xml:"MinorVersion,attr"`
+				Build string `xml:"MajorBuildNumber,attr"`
+			} `xml:"ServerVersionInfo"`
+		} `xml:"Header"`
+	}
+	if xml.Unmarshal(body, &env) != nil || env.Header.Version.Major == "" {
+		return ""
+	}
+	v := env.Header.Version
+	return v.Major + "." + v.Minor + "." + v.Build
+}
@@ -0,0 +1,87 @@
+package ews
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+const getFolderResponse = `<?xml version="1.0" encoding="utf-8"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">
+  <s:Header>
+    <h:ServerVersionInfo MajorVersion="15" MinorVersion="1" MajorBuildNumber="2507"
+      xmlns:h="http://schemas.microsoft.com/exchange/services/2006/types"/>
+  </s:Header>
+  <s:Body/>
+</s:Envelope>`
+
+// exchangeServer answers like Exchange: 401 with the given schemes unless
+// the Basic credentials are me/secret.
+func exchangeServer(t *testing.T, schemes ...string) (*httptest.Server, *Checker) {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != domain.EWSDefaultPath {
+			http.NotFound(w, r)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); ok && user == "me" && pass == "secret" {
+			_, _ = w.Write([]byte(getFolderResponse))
+			return
+		}
+		for _, s := range schemes {
+			w.Header().Add("WWW-Authenticate", s)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &Checker{client: func(bool) *http.Client { return srv.Client() }}
+}
+
+func TestCheck_BasicAuth(t *testing.T) {
+	srv, checker := exchangeServer(t, "Negotiate", "NTLM", `Basic realm="mail.example.com"`)
+	endpoint := srv.URL + domain.EWSDefaultPath
+
+	result, err := checker.Check(context.Background(), domain.EWSCheckOptions{Endpoint: endpoint})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Negotiate", "NTLM", "Basic"}, result.AuthSchemes)
+	assert.False(t, result.AuthTested)
+
+	result, err = checker.Check(context.Background(), domain.EWSCheckOptions{Endpoint: endpoint, Username: "me", Password: "secret"})
+	require.NoError(t, err)
+	assert.True(t, result.AuthTested)
+	assert.True(t, result.Authenticated)
+	assert.Equal(t, "15.1.2507", result.ServerVersion)
+
+	result, err = checker.Check(context.Background(), domain.EWSCheckOptions{Endpoint: endpoint, Username: "me", Password: "wrong"})
+	require.NoError(t, err)
+	assert.True(t, result.AuthTested)
+	assert.False(t, result.Authenticated)
+	assert.Equal(t, http.StatusUnauthorized, result.StatusCode)
+}
+
+func TestCheck_NTLMOnlySkipsCredentials(t *testing.T) {
+	srv, checker := exchangeServer(t, "NTLM")
+	result, err := checker.Check(context.Background(), domain.EWSCheckOptions{
+		Endpoint: srv.URL + domain.EWSDefaultPath, Username: "me", Password: "secret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NTLM"}, result.AuthSchemes)
+	assert.False(t, result.AuthTested)
+}
+
+func TestCheck_Errors(t *testing.T) {
+	srv, checker := exchangeServer(t, "NTLM")
+
+	_, err := checker.Check(context.Background(), domain.EWSCheckOptions{Endpoint: srv.URL + "/owa"})
+	assert.ErrorContains(t, err, "no EWS endpoint")
+
+	srv.Close()
+	_, err = checker.Check(context.Background(), domain.EWSCheckOptions{Endpoint: srv.URL + domain.EWSDefaultPath})
+	assert.ErrorContains(t, err, "could not reach")
+}
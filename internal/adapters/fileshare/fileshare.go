@@ -0,0 +1,67 @@
+// Package fileshare uploads files to an HTTP file-sharing endpoint that
+// accepts PUT {url}/{filename} and responds with a download link in the
+// body, such as transfer.sh or a presigned-upload gateway.
+package fileshare
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/nylas/cli/internal/httputil"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// maxResponseSize bounds the link response read from the server.
+const maxResponseSize = 64 << 10
+
+// Client uploads files to a PUT endpoint.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+var _ ports.AttachmentUploader = (*Client)(nil)
+
+// New returns a client for the upload endpoint at baseURL.
+func New(baseURL string) *Client {
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), http: httputil.DefaultClient}
+}
+
+// Upload PUTs content to {baseURL}/{filename} and returns the link from the
+// response body.
+func (c *Client) Upload(ctx context.Context, filename, contentType string, content []byte) (string, error) {
+	target := c.baseURL + "/" + url.PathEscape(filename)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.ContentLength = int64(len(content))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("upload %s: %w", filename, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("upload %s: %w", filename, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("upload %s: server returned HTTP %d", filename, resp.StatusCode)
+	}
+
+	link := strings.TrimSpace(string(body))
+	if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("upload %s: server did not return a link", filename)
+	}
+	return link, nil
+}
@@ -0,0 +1,52 @@
+package fileshare
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Upload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/up/big%20report.pdf", r.URL.EscapedPath())
+		assert.Equal(t, "application/pdf", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "data", string(body))
+		_, _ = w.Write([]byte("https://files.example.com/abc/big%20report.pdf\n"))
+	}))
+	defer server.Close()
+
+	link, err := New(server.URL+"/up/").Upload(context.Background(), "big report.pdf", "application/pdf", []byte("data"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://files.example.com/abc/big%20report.pdf", link)
+}
+
+func TestClient_Upload_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "http error", status: http.StatusRequestEntityTooLarge, wantErr: "HTTP 413"},
+		{name: "no link", status: http.StatusOK, body: "stored", wantErr: "did not return a link"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := New(server.URL).Upload(context.Background(), "a.txt", "", []byte("x"))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
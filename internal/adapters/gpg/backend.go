@@ -0,0 +1,51 @@
+package gpg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// PassphraseEnv holds the secret key passphrase for non-interactive use.
+// The native backend has no agent or pinentry, so it is the only way to
+// unlock a protected key there.
+const PassphraseEnv = "NYLAS_GPG_PASSPHRASE"
+
+// Backend is the set of OpenPGP operations the CLI uses. Service shells out
+// to the gpg binary; NativeService is a pure-Go implementation for
+// containers and CI where gnupg isn't installed.
+type Backend interface {
+	CheckGPGAvailable(ctx context.Context) error
+	ListSigningKeys(ctx context.Context) ([]KeyInfo, error)
+	ListPublicKeys(ctx context.Context) ([]KeyInfo, error)
+	GetDefaultSigningKey(ctx context.Context) (*KeyInfo, error)
+	FindKeyByEmail(ctx context.Context, email string) (*KeyInfo, error)
+	FindPublicKeyByEmail(ctx context.Context, email string) (*KeyInfo, error)
+	SignData(ctx context.Context, keyID string, data []byte, senderEmail string) (*SignResult, error)
+	VerifyDetachedSignature(ctx context.Context, data []byte, signature []byte) (*VerifyResult, error)
+	EncryptData(ctx context.Context, recipientKeyIDs []string, data []byte) (*EncryptResult, error)
+	SignAndEncryptData(ctx context.Context, signerKeyID string, recipientKeyIDs []string, data []byte, senderEmail string) (*EncryptResult, error)
+	DecryptData(ctx context.Context, ciphertext []byte) (*DecryptResult, error)
+	GenerateKey(ctx context.Context, opts GenerateKeyOptions) (*KeyInfo, error)
+	ImportKeys(ctx context.Context, data []byte) (*ImportResult, error)
+	ExportPublicKey(ctx context.Context, keyID string) ([]byte, error)
+}
+
+var (
+	_ Backend = (*Service)(nil)
+	_ Backend = (*NativeService)(nil)
+)
+
+// NewBackend returns the backend for kind. An empty kind selects the system
+// gpg binary; keyringDir is where the native backend keeps its keys.
+func NewBackend(kind domain.GPGBackend, keyringDir string) (Backend, error) {
+	switch kind {
+	case "", domain.GPGBackendSystem:
+		return NewService(), nil
+	case domain.GPGBackendNative:
+		return NewNativeService(keyringDir), nil
+	default:
+		return nil, fmt.Errorf("unknown gpg backend %q (use %s or %s)", kind, domain.GPGBackendSystem, domain.GPGBackendNative)
+	}
+}
@@ -0,0 +1,242 @@
+package gpg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/mail"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Key generation defaults.
+const (
+	DefaultKeyAlgorithm = "ed25519"
+	DefaultKeyExpire    = "2y"
+)
+
+// keyAlgorithms maps supported --algo values to gpg --quick-generate-key
+// algorithm strings. "future-default" is ed25519 signing with a cv25519
+// encryption subkey.
+var keyAlgorithms = map[string]string{
+	"ed25519": "future-default",
+	"rsa4096": "rsa4096",
+}
+
+// KeyAlgorithms returns the supported key generation algorithms.
+func KeyAlgorithms() []string {
+	return []string{"ed25519", "rsa4096"}
+}
+
+// GenerateKey creates a new key pair with a signing primary key and an
+// encryption subkey, and returns the new secret key.
+func (s *Service) GenerateKey(ctx context.Context, opts GenerateKeyOptions) (*KeyInfo, error) {
+	name, email, algoName, expire, err := normalizeGenerateOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	algo := keyAlgorithms[algoName]
+
+	args := []string{"--batch", "--status-fd", "1", "--pinentry-mode", "loopback"}
+	if opts.Passphrase == "" {
+		args = append(args, "--passphrase", "")
+	} else {
+		args = append(args, "--passphrase-fd", "0")
+	}
+	userID := fmt.Sprintf("%s <%s>", name, email)
+	args = append(args, "--quick-generate-key", userID, algo, "default", expire)
+
+	// #nosec G204 - name, email, algorithm and expiry are validated above
+	cmd := exec.CommandContext(ctx, "gpg", args...)
+	if opts.Passphrase != "" {
+		cmd.Stdin = strings.NewReader(opts.Passphrase + "\n")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("gpg key generation failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	fpr := parseKeyCreated(stdout.String())
+	if fpr == "" {
+		return nil, fmt.Errorf("gpg did not report the new key")
+	}
+
+	keys, err := s.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range keys {
+		if keys[i].Fingerprint == fpr {
+			return &keys[i], nil
+		}
+	}
+	return &KeyInfo{KeyID: fpr[len(fpr)-16:], Fingerprint: fpr, UIDs: []string{userID}}, nil
+}
+
+// normalizeGenerateOptions validates opts and fills in the default
+// algorithm and expiry.
+func normalizeGenerateOptions(opts GenerateKeyOptions) (name, email, algo, expire string, err error) {
+	name = strings.TrimSpace(opts.Name)
+	if name == "" || strings.ContainsAny(name, "<>\n\r") {
+		return "", "", "", "", fmt.Errorf("invalid key name: %q", opts.Name)
+	}
+	addr, err := mail.ParseAddress(strings.TrimSpace(opts.Email))
+	if err != nil || addr.Name != "" {
+		return "", "", "", "", fmt.Errorf("invalid email format: %q", opts.Email)
+	}
+
+	algo = strings.ToLower(opts.Algorithm)
+	if algo == "" {
+		algo = DefaultKeyAlgorithm
+	}
+	if _, ok := keyAlgorithms[algo]; !ok {
+		return "", "", "", "", fmt.Errorf("unsupported key algorithm %q (use %s)", opts.Algorithm, strings.Join(KeyAlgorithms(), " or "))
+	}
+
+	expire = strings.ToLower(strings.TrimSpace(opts.Expire))
+	if expire == "" {
+		expire = DefaultKeyExpire
+	}
+	if !isValidExpire(expire) {
+		return "", "", "", "", fmt.Errorf("invalid expiry %q (use e.g. 1y, 6m, 90d or never)", opts.Expire)
+	}
+	return name, addr.Address, algo, expire, nil
+}
+
+// ImportKeys imports ASCII-armored or binary keys into the keyring.
+func (s *Service) ImportKeys(ctx context.Context, data []byte) (*ImportResult, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("no key data to import")
+	}
+
+	cmd := exec.CommandContext(ctx, "gpg", "--batch", "--status-fd", "1", "--import")
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	result := parseImportStatus(stdout.String())
+	if result == nil || len(result.Fingerprints) == 0 {
+		if runErr != nil {
+			return nil, fmt.Errorf("gpg import failed: %s", strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("no valid OpenPGP keys found")
+	}
+	return result, nil
+}
+
+// ExportPublicKey returns the ASCII-armored public key for keyID, minimized
+// to the self-signatures so it stays small enough to attach to email.
+func (s *Service) ExportPublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	if !isValidGPGKeyID(keyID) {
+		return nil, fmt.Errorf("invalid GPG key ID format: %q", keyID)
+	}
+
+	// #nosec G204 - keyID is validated by isValidGPGKeyID above
+	cmd := exec.CommandContext(ctx, "gpg", "--armor", "--export-options", "export-minimal", "--export", keyID)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("gpg export failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("no public key found for %s", keyID)
+	}
+	return stdout.Bytes(), nil
+}
+
+// isValidExpire accepts "never", "0", or a number with an optional d/w/m/y
+// suffix.
+func isValidExpire(expire string) bool {
+	if expire == "never" || expire == "0" {
+		return true
+	}
+	n := strings.TrimRight(expire, "dwmy")
+	if len(expire)-len(n) > 1 {
+		return false
+	}
+	v, err := strconv.Atoi(n)
+	return err == nil && v > 0
+}
+
+// expireDuration converts a valid expiry to a duration, zero meaning never.
+// Like gpg, a bare number is days and a month is 30 days.
+func expireDuration(expire string) time.Duration {
+	if expire == "never" || expire == "0" {
+		return 0
+	}
+	unit := 24 * time.Hour
+	n := expire
+	switch expire[len(expire)-1] {
+	case 'd':
+		n = expire[:len(expire)-1]
+	case 'w':
+		n, unit = expire[:len(expire)-1], 7*24*time.Hour
+	case 'm':
+		n, unit = expire[:len(expire)-1], 30*24*time.Hour
+	case 'y':
+		n, unit = expire[:len(expire)-1], 365*24*time.Hour
+	}
+	v, _ := strconv.Atoi(n)
+	return time.Duration(v) * unit
+}
+
+// parseKeyCreated returns the fingerprint from a "KEY_CREATED" status line.
+func parseKeyCreated(status string) string {
+	for _, line := range strings.Split(status, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 4 && fields[0] == "[GNUPG:]" && fields[1] == "KEY_CREATED" {
+			return fields[3]
+		}
+	}
+	return ""
+}
+
+// parseImportStatus reads IMPORT_OK and IMPORT_RES status lines. It returns
+// nil when gpg reported no import summary.
+func parseImportStatus(status string) *ImportResult {
+	var result *ImportResult
+	var fprs []string
+	for _, line := range strings.Split(status, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "[GNUPG:]" {
+			continue
+		}
+		switch fields[1] {
+		case "IMPORT_OK":
+			if len(fields) >= 4 && !slices.Contains(fprs, fields[3]) {
+				fprs = append(fprs, fields[3])
+			}
+		case "IMPORT_RES":
+			// count no_user_id imported imported_rsa unchanged n_uids n_subk
+			// n_sigs n_revoc sec_read sec_imported sec_dups skipped_new_keys
+			// not_imported ...
+			counts := fields[2:]
+			result = &ImportResult{
+				Imported:    statusInt(counts, 2),
+				Unchanged:   statusInt(counts, 4),
+				SecretKeys:  statusInt(counts, 10),
+				NotImported: statusInt(counts, 13),
+			}
+		}
+	}
+	if result != nil {
+		result.Fingerprints = fprs
+	}
+	return result
+}
+
+func statusInt(fields []string, i int) int {
+	if i >= len(fields) {
+		return 0
+	}
+	n, _ := strconv.Atoi(fields[i])
+	return n
+}
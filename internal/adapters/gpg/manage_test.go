@@ -0,0 +1,144 @@
+package gpg
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// useTempKeyring points gpg at an empty keyring for the duration of the test.
+func useTempKeyring(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("GPG not installed, skipping test")
+	}
+	t.Setenv("GNUPGHOME", t.TempDir())
+}
+
+func TestGenerateKey_Validation(t *testing.T) {
+	svc := NewService()
+	tests := []struct {
+		name    string
+		opts    GenerateKeyOptions
+		wantErr string
+	}{
+		{"missing name", GenerateKeyOptions{Email: "a@example.com"}, "invalid key name"},
+		{"name with brackets", GenerateKeyOptions{Name: "A <b>", Email: "a@example.com"}, "invalid key name"},
+		{"bad email", GenerateKeyOptions{Name: "A", Email: "nope"}, "invalid email format"},
+		{"email with name", GenerateKeyOptions{Name: "A", Email: "A <a@example.com>"}, "invalid email format"},
+		{"bad algorithm", GenerateKeyOptions{Name: "A", Email: "a@example.com", Algorithm: "dsa"}, "unsupported key algorithm"},
+		{"bad expiry", GenerateKeyOptions{Name: "A", Email: "a@example.com", Expire: "2 years"}, "invalid expiry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GenerateKey(context.Background(), tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("GenerateKey() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsValidExpire(t *testing.T) {
+	for _, v := range []string{"never", "0", "2y", "6m", "90d", "3w", "365"} {
+		if !isValidExpire(v) {
+			t.Errorf("isValidExpire(%q) = false, want true", v)
+		}
+	}
+	for _, v := range []string{"", "y", "-1y", "2yy", "1.5y", "2 years"} {
+		if isValidExpire(v) {
+			t.Errorf("isValidExpire(%q) = true, want false", v)
+		}
+	}
+}
+
+func TestParseImportStatus(t *testing.T) {
+	status := `[GNUPG:] IMPORTED 0123456789ABCDEF Alice <alice@example.com>
+[GNUPG:] IMPORT_OK 1 AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA
+[GNUPG:] IMPORT_OK 0 BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB
+[GNUPG:] IMPORT_OK 1 AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA
+[GNUPG:] IMPORT_RES 3 0 1 0 1 0 0 0 0 1 1 0 0 1 0 0 0 0
+`
+	got := parseImportStatus(status)
+	if got == nil {
+		t.Fatal("parseImportStatus() = nil")
+	}
+	if len(got.Fingerprints) != 2 {
+		t.Errorf("Fingerprints = %v, want 2 entries", got.Fingerprints)
+	}
+	if got.Imported != 1 || got.Unchanged != 1 || got.SecretKeys != 1 || got.NotImported != 1 {
+		t.Errorf("counts = %+v", got)
+	}
+	if parseImportStatus("[GNUPG:] NODATA 1\n") != nil {
+		t.Error("parseImportStatus() without IMPORT_RES should be nil")
+	}
+}
+
+func TestParseKeyCreated(t *testing.T) {
+	status := "[GNUPG:] KEY_CONSIDERED X 0\n[GNUPG:] KEY_CREATED B 5F0C8A1E2D3B4C5D6E7F8091A2B3C4D5E6F70819\n"
+	if got := parseKeyCreated(status); got != "5F0C8A1E2D3B4C5D6E7F8091A2B3C4D5E6F70819" {
+		t.Errorf("parseKeyCreated() = %q", got)
+	}
+	if got := parseKeyCreated(""); got != "" {
+		t.Errorf("parseKeyCreated(\"\") = %q", got)
+	}
+}
+
+func TestKeyLifecycle_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+	useTempKeyring(t)
+	ctx := context.Background()
+	svc := NewService()
+
+	key, err := svc.GenerateKey(ctx, GenerateKeyOptions{Name: "Alice Test", Email: "alice@example.com"})
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	if len(key.Fingerprint) != 40 || key.Expires == nil {
+		t.Errorf("GenerateKey() = %+v, want fingerprint and expiry", key)
+	}
+
+	armored, err := svc.ExportPublicKey(ctx, key.Fingerprint)
+	if err != nil {
+		t.Fatalf("ExportPublicKey() error = %v", err)
+	}
+	if !strings.HasPrefix(string(armored), "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		t.Errorf("ExportPublicKey() is not armored: %.40s", armored)
+	}
+
+	if _, err := svc.ExportPublicKey(ctx, "nobody@example.com"); err == nil {
+		t.Error("ExportPublicKey() for unknown key should fail")
+	}
+
+	// Import into a fresh keyring.
+	t.Setenv("GNUPGHOME", t.TempDir())
+	result, err := svc.ImportKeys(ctx, armored)
+	if err != nil {
+		t.Fatalf("ImportKeys() error = %v", err)
+	}
+	if result.Imported != 1 || len(result.Fingerprints) != 1 || result.Fingerprints[0] != key.Fingerprint {
+		t.Errorf("ImportKeys() = %+v", result)
+	}
+	if _, err := svc.ImportKeys(ctx, []byte("not a key")); err == nil {
+		t.Error("ImportKeys() with garbage should fail")
+	}
+}
+
+func TestParseSecretKeys_SubkeyFingerprint(t *testing.T) {
+	output := `sec:u:255:22:C446DB4C8D0B2AD2:1700000000:1763072000::u:::scESC:::+::ed25519:::0:
+fpr:::::::::68DBE29384F336F72C10834EC446DB4C8D0B2AD2:
+uid:u::::1700000000::HASH::Alice <alice@example.com>::::::::::0:
+ssb:u:255:18:1111222233334444:1700000000:1763072000:::::e:::+::cv25519::
+fpr:::::::::99998888777766665555444433332222111122223333:
+`
+	keys, err := parseSecretKeys(output)
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("parseSecretKeys() = %v, %v", keys, err)
+	}
+	if keys[0].Fingerprint != "68DBE29384F336F72C10834EC446DB4C8D0B2AD2" {
+		t.Errorf("Fingerprint = %q, want primary key fingerprint", keys[0].Fingerprint)
+	}
+}
@@ -0,0 +1,490 @@
+package gpg
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+
+	"github.com/nylas/cli/internal/httputil"
+)
+
+// Native keyring files, relative to the keyring directory. Both hold a
+// single ASCII-armored block so they can be inspected or copied by hand.
+const (
+	nativePublicKeyring = "pubring.asc"
+	nativeSecretKeyring = "secring.asc"
+)
+
+// maxFetchedKey caps keyserver responses.
+const maxFetchedKey = 1 << 20
+
+// NativeService implements Backend in pure Go (ProtonMail go-crypto) with
+// its own keyring directory, so it works where gnupg isn't installed.
+// Secret keys are stored as exported, still protected by their passphrase.
+type NativeService struct {
+	dir       string
+	keyserver string
+	client    *http.Client
+}
+
+// NewNativeService creates a native backend with its keyring in dir.
+func NewNativeService(dir string) *NativeService {
+	return &NativeService{dir: dir, keyserver: DefaultPublishServer, client: httputil.DefaultClient}
+}
+
+// Dir returns the keyring directory.
+func (s *NativeService) Dir() string {
+	return s.dir
+}
+
+// CheckGPGAvailable always succeeds; the native backend needs no binary.
+func (s *NativeService) CheckGPGAvailable(_ context.Context) error {
+	return nil
+}
+
+// ListSigningKeys lists the secret keys in the keyring.
+func (s *NativeService) ListSigningKeys(_ context.Context) ([]KeyInfo, error) {
+	secret, err := s.readKeyring(nativeSecretKeyring)
+	if err != nil {
+		return nil, err
+	}
+	return entityKeyInfos(secret, true), nil
+}
+
+// ListPublicKeys lists all public keys in the keyring.
+func (s *NativeService) ListPublicKeys(_ context.Context) ([]KeyInfo, error) {
+	public, err := s.readKeyring(nativePublicKeyring)
+	if err != nil {
+		return nil, err
+	}
+	return entityKeyInfos(public, false), nil
+}
+
+// GetDefaultSigningKey resolves git's user.signingkey in the keyring.
+func (s *NativeService) GetDefaultSigningKey(ctx context.Context) (*KeyInfo, error) {
+	keyID, err := gitSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.readKeyring(nativeSecretKeyring)
+	if err != nil {
+		return nil, err
+	}
+	if e := findEntity(secret, keyID); e != nil {
+		info := entityKeyInfo(e, true)
+		return &info, nil
+	}
+	return nil, fmt.Errorf("git signing key %s not found in keyring %s", keyID, s.dir)
+}
+
+// FindKeyByEmail finds a secret key with email in its UIDs.
+func (s *NativeService) FindKeyByEmail(ctx context.Context, email string) (*KeyInfo, error) {
+	keys, err := s.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	for i := range keys {
+		if keyMatchesEmail(&keys[i], email) {
+			return &keys[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no GPG key found for email %s", email)
+}
+
+// FindPublicKeyByEmail finds a public key by email, fetching it from the
+// keyserver when it is not in the keyring.
+func (s *NativeService) FindPublicKeyByEmail(ctx context.Context, email string) (*KeyInfo, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	find := func() (*KeyInfo, error) {
+		keys, err := s.ListPublicKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range keys {
+			if keyMatchesEmail(&keys[i], email) && (keys[i].Expires == nil || keys[i].Expires.After(time.Now())) {
+				return &keys[i], nil
+			}
+		}
+		return nil, nil
+	}
+
+	if key, err := find(); key != nil || err != nil {
+		return key, err
+	}
+	if isReservedLookupEmail(email) {
+		return nil, fmt.Errorf("no public key found for %s (checked local keyring only; skipped remote lookup for reserved domain)", email)
+	}
+	if err := s.fetchKey(ctx, "/vks/v1/by-email/"+url.PathEscape(email)); err != nil {
+		return nil, fmt.Errorf("no public key found for %s (checked local keyring and %s): %w", email, s.keyserver, err)
+	}
+	if key, err := find(); key != nil || err != nil {
+		return key, err
+	}
+	return nil, fmt.Errorf("key fetched but not found for %s", email)
+}
+
+// GenerateKey creates a key pair with a signing primary key and an
+// encryption subkey and adds it to the keyring.
+func (s *NativeService) GenerateKey(_ context.Context, opts GenerateKeyOptions) (*KeyInfo, error) {
+	name, email, algo, expire, err := normalizeGenerateOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &packet.Config{
+		KeyLifetimeSecs: uint32(expireDuration(expire) / time.Second),
+	}
+	switch algo {
+	case "rsa4096":
+		cfg.Algorithm = packet.PubKeyAlgoRSA
+		cfg.RSABits = 4096
+	default:
+		cfg.Algorithm = packet.PubKeyAlgoEdDSA
+		cfg.Curve = packet.Curve25519
+	}
+
+	entity, err := openpgp.NewEntity(name, "", email, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("key generation failed: %w", err)
+	}
+	if opts.Passphrase != "" {
+		if err := entity.EncryptPrivateKeys([]byte(opts.Passphrase), nil); err != nil {
+			return nil, fmt.Errorf("protecting new key: %w", err)
+		}
+	}
+
+	if _, err := s.addEntities(openpgp.EntityList{entity}); err != nil {
+		return nil, err
+	}
+	info := entityKeyInfo(entity, true)
+	return &info, nil
+}
+
+// ImportKeys adds ASCII-armored or binary keys to the keyring.
+func (s *NativeService) ImportKeys(_ context.Context, data []byte) (*ImportResult, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("no key data to import")
+	}
+	entities, err := readKeys(data)
+	if err != nil || len(entities) == 0 {
+		return nil, fmt.Errorf("no valid OpenPGP keys found")
+	}
+	return s.addEntities(entities)
+}
+
+// ExportPublicKey returns the ASCII-armored public key for keyID.
+func (s *NativeService) ExportPublicKey(_ context.Context, keyID string) ([]byte, error) {
+	if !isValidGPGKeyID(keyID) {
+		return nil, fmt.Errorf("invalid GPG key ID format: %q", keyID)
+	}
+	public, err := s.readKeyring(nativePublicKeyring)
+	if err != nil {
+		return nil, err
+	}
+	e := findEntity(public, keyID)
+	if e == nil {
+		return nil, fmt.Errorf("no public key found for %s", keyID)
+	}
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.Serialize(w); err != nil {
+		return nil, fmt.Errorf("exporting key: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// addEntities merges entities into the keyring. Public parts go to the
+// public keyring; entities carrying private keys also go to the secret one.
+func (s *NativeService) addEntities(entities openpgp.EntityList) (*ImportResult, error) {
+	public, err := s.readKeyring(nativePublicKeyring)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.readKeyring(nativeSecretKeyring)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Fingerprints: []string{}}
+	secretChanged := false
+	for _, e := range entities {
+		fpr := fingerprint(e)
+		var replaced bool
+		public, replaced = replaceEntity(public, e)
+		if replaced {
+			result.Unchanged++
+		} else {
+			result.Imported++
+		}
+		if e.PrivateKey != nil {
+			secret, _ = replaceEntity(secret, e)
+			result.SecretKeys++
+			secretChanged = true
+		}
+		result.Fingerprints = append(result.Fingerprints, fpr)
+	}
+
+	if err := s.writeKeyring(nativePublicKeyring, public, false); err != nil {
+		return nil, err
+	}
+	if secretChanged {
+		if err := s.writeKeyring(nativeSecretKeyring, secret, true); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// fetchKey imports the key served at path on the keyserver.
+func (s *NativeService) fetchKey(ctx context.Context, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, keyserverFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.keyserver+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("keyserver request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("no key on %s", s.keyserver)
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("keyserver returned HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchedKey))
+	if err != nil {
+		return fmt.Errorf("reading keyserver response: %w", err)
+	}
+	entities, err := readKeys(data)
+	if err != nil || len(entities) == 0 {
+		return fmt.Errorf("keyserver returned no usable key")
+	}
+	// Never take secret material from a keyserver.
+	for _, e := range entities {
+		e.PrivateKey = nil
+		for i := range e.Subkeys {
+			e.Subkeys[i].PrivateKey = nil
+		}
+	}
+	_, err = s.addEntities(entities)
+	return err
+}
+
+func (s *NativeService) readKeyring(name string) (openpgp.EntityList, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading keyring: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("reading keyring %s: %w", filepath.Join(s.dir, name), err)
+	}
+	return entities, nil
+}
+
+func (s *NativeService) writeKeyring(name string, entities openpgp.EntityList, secret bool) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("creating keyring directory: %w", err)
+	}
+
+	blockType := openpgp.PublicKeyType
+	if secret {
+		blockType = openpgp.PrivateKeyType
+	}
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, blockType, nil)
+	if err != nil {
+		return err
+	}
+	for _, e := range entities {
+		if secret {
+			err = e.SerializePrivateWithoutSigning(w, nil)
+		} else {
+			err = e.Serialize(w)
+		}
+		if err != nil {
+			return fmt.Errorf("writing key %s: %w", fingerprint(e), err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+
+	path := filepath.Join(s.dir, name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("writing keyring: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// readKeys parses one or more armored key blocks, or a binary keyring.
+func readKeys(data []byte) (openpgp.EntityList, error) {
+	if !bytes.Contains(data, []byte("-----BEGIN PGP")) {
+		return openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+	var entities openpgp.EntityList
+	r := bytes.NewReader(data)
+	for {
+		block, err := armor.Decode(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if len(entities) > 0 {
+				break
+			}
+			return nil, err
+		}
+		list, err := openpgp.ReadKeyRing(block.Body)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, list...)
+	}
+	return entities, nil
+}
+
+// replaceEntity replaces the entity with e's fingerprint, or appends e.
+func replaceEntity(list openpgp.EntityList, e *openpgp.Entity) (openpgp.EntityList, bool) {
+	fpr := fingerprint(e)
+	for i := range list {
+		if fingerprint(list[i]) == fpr {
+			list[i] = e
+			return list, true
+		}
+	}
+	return append(list, e), false
+}
+
+// findEntity returns the first entity matching ref: a key ID or
+// fingerprint (of the primary key or a subkey), or an email address.
+func findEntity(list openpgp.EntityList, ref string) *openpgp.Entity {
+	ref = strings.TrimSpace(ref)
+	hexRef := strings.TrimPrefix(strings.ToUpper(ref), "0X")
+	isHex := gpgKeyIDPattern.MatchString(hexRef)
+	email := strings.ToLower(ref)
+	if start, end := strings.LastIndex(email, "<"), strings.LastIndex(email, ">"); start >= 0 && end > start {
+		email = email[start+1 : end]
+	}
+
+	for _, e := range list {
+		if isHex {
+			if strings.HasSuffix(fingerprint(e), hexRef) {
+				return e
+			}
+			for _, sub := range e.Subkeys {
+				if strings.HasSuffix(strings.ToUpper(hex.EncodeToString(sub.PublicKey.Fingerprint)), hexRef) {
+					return e
+				}
+			}
+			continue
+		}
+		for _, id := range e.Identities {
+			if strings.EqualFold(id.UserId.Email, email) {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+func fingerprint(e *openpgp.Entity) string {
+	return strings.ToUpper(hex.EncodeToString(e.PrimaryKey.Fingerprint))
+}
+
+func entityKeyInfos(list openpgp.EntityList, secret bool) []KeyInfo {
+	keys := make([]KeyInfo, 0, len(list))
+	for _, e := range list {
+		keys = append(keys, entityKeyInfo(e, secret))
+	}
+	return keys
+}
+
+// entityKeyInfo describes e the way gpg --with-colons does.
+func entityKeyInfo(e *openpgp.Entity, secret bool) KeyInfo {
+	pk := e.PrimaryKey
+	info := KeyInfo{
+		KeyID:       pk.KeyIdString(),
+		Fingerprint: fingerprint(e),
+		Trust:       "-",
+		Type:        keyType(pk.PubKeyAlgo),
+		Created:     pk.CreationTime,
+	}
+	if secret {
+		info.Trust = "u"
+	}
+	if bits, err := pk.BitLength(); err == nil {
+		info.Length = int(bits)
+	}
+
+	var primary string
+	if id := e.PrimaryIdentity(); id != nil {
+		primary = id.Name
+		info.UIDs = append(info.UIDs, primary)
+	}
+	var others []string
+	for name := range e.Identities {
+		if name != primary {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	info.UIDs = append(info.UIDs, others...)
+
+	if sig, _ := e.PrimarySelfSignature(); sig != nil && sig.KeyLifetimeSecs != nil && *sig.KeyLifetimeSecs > 0 {
+		expires := pk.CreationTime.Add(time.Duration(*sig.KeyLifetimeSecs) * time.Second)
+		info.Expires = &expires
+	}
+	return info
+}
+
+func keyType(algo packet.PublicKeyAlgorithm) string {
+	switch algo {
+	case packet.PubKeyAlgoRSA, packet.PubKeyAlgoRSASignOnly, packet.PubKeyAlgoRSAEncryptOnly:
+		return "RSA"
+	case packet.PubKeyAlgoDSA:
+		return "DSA"
+	case packet.PubKeyAlgoECDSA:
+		return "ECDSA"
+	case packet.PubKeyAlgoEdDSA, packet.PubKeyAlgoEd25519, packet.PubKeyAlgoEd448:
+		return "EdDSA"
+	default:
+		return fmt.Sprintf("algo%d", algo)
+	}
+}
@@ -0,0 +1,328 @@
+package gpg
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// nativeConfig matches gpg's defaults closely enough for PGP/MIME.
+var nativeConfig = &packet.Config{DefaultHash: crypto.SHA256}
+
+// SignData creates an ASCII-armored detached signature for data.
+// senderEmail is validated for parity with the system backend.
+func (s *NativeService) SignData(_ context.Context, keyID string, data []byte, senderEmail string) (*SignResult, error) {
+	if !isValidGPGKeyID(keyID) {
+		return nil, fmt.Errorf("invalid GPG key ID format: %q", keyID)
+	}
+	if senderEmail != "" {
+		if _, err := mail.ParseAddress(senderEmail); err != nil {
+			return nil, fmt.Errorf("invalid sender email format: %q", senderEmail)
+		}
+	}
+
+	signer, err := s.unlockedSigner(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, signer, bytes.NewReader(data), nativeConfig); err != nil {
+		return nil, fmt.Errorf("signing failed: %w", err)
+	}
+	buf.WriteByte('\n')
+
+	return &SignResult{
+		Signature: buf.Bytes(),
+		KeyID:     keyID,
+		SignedAt:  time.Now(),
+		HashAlgo:  "SHA256",
+	}, nil
+}
+
+// VerifyDetachedSignature verifies a detached signature against data,
+// fetching the signer's key from the keyserver when it isn't in the keyring.
+func (s *NativeService) VerifyDetachedSignature(ctx context.Context, data []byte, signature []byte) (*VerifyResult, error) {
+	rawSig, err := dearmor(signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	pkt, err := packet.Read(bytes.NewReader(rawSig))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	sig, ok := pkt.(*packet.Signature)
+	if !ok {
+		return nil, fmt.Errorf("invalid signature: expected a signature packet")
+	}
+
+	result := &VerifyResult{SignedAt: sig.CreationTime, TrustLevel: "unknown"}
+	if sig.IssuerKeyId != nil {
+		result.SignerKeyID = fmt.Sprintf("%016X", *sig.IssuerKeyId)
+	}
+
+	public, err := s.readKeyring(nativePublicKeyring)
+	if err != nil {
+		return nil, err
+	}
+	if sig.IssuerKeyId != nil && len(public.KeysById(*sig.IssuerKeyId)) == 0 {
+		if s.fetchKey(ctx, "/vks/v1/by-keyid/"+result.SignerKeyID) == nil {
+			if public, err = s.readKeyring(nativePublicKeyring); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	_, signer, err := openpgp.VerifyDetachedSignature(public, bytes.NewReader(data), bytes.NewReader(rawSig), nativeConfig)
+	if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+		return nil, fmt.Errorf("public key not found (tried %s). Import it with: nylas gpg keys import <file>", s.keyserver)
+	}
+	if err != nil {
+		return result, nil
+	}
+
+	result.Valid = true
+	result.Fingerprint = fingerprint(signer)
+	if id := signer.PrimaryIdentity(); id != nil {
+		result.SignerUID = id.Name
+	}
+	if secret, err := s.readKeyring(nativeSecretKeyring); err == nil && findEntity(secret, result.Fingerprint) != nil {
+		result.TrustLevel = "ultimate"
+	}
+	return result, nil
+}
+
+// EncryptData encrypts data for one or more recipients.
+func (s *NativeService) EncryptData(_ context.Context, recipientKeyIDs []string, data []byte) (*EncryptResult, error) {
+	if len(recipientKeyIDs) == 0 {
+		return nil, fmt.Errorf("at least one recipient key ID is required")
+	}
+	to, err := s.recipients(recipientKeyIDs)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := encryptArmored(to, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptResult{Ciphertext: ciphertext, RecipientKeys: recipientKeyIDs}, nil
+}
+
+// SignAndEncryptData signs data with the sender's key and encrypts it for
+// recipients.
+func (s *NativeService) SignAndEncryptData(_ context.Context, signerKeyID string, recipientKeyIDs []string, data []byte, senderEmail string) (*EncryptResult, error) {
+	if signerKeyID == "" {
+		return nil, fmt.Errorf("signer key ID is required for sign+encrypt")
+	}
+	if len(recipientKeyIDs) == 0 {
+		return nil, fmt.Errorf("at least one recipient key ID is required")
+	}
+	if !isValidGPGKeyID(signerKeyID) {
+		return nil, fmt.Errorf("invalid signer GPG key ID format: %q", signerKeyID)
+	}
+	if senderEmail != "" {
+		if _, err := mail.ParseAddress(senderEmail); err != nil {
+			return nil, fmt.Errorf("invalid sender email format: %q", senderEmail)
+		}
+	}
+
+	to, err := s.recipients(recipientKeyIDs)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := s.unlockedSigner(signerKeyID)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := encryptArmored(to, signer, data)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptResult{Ciphertext: ciphertext, RecipientKeys: recipientKeyIDs}, nil
+}
+
+// DecryptData decrypts an OpenPGP message with a secret key from the
+// keyring and reports any embedded signature.
+func (s *NativeService) DecryptData(_ context.Context, ciphertext []byte) (*DecryptResult, error) {
+	if len(ciphertext) == 0 {
+		return nil, fmt.Errorf("ciphertext is empty")
+	}
+	body, err := dearmor(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decryption failed: %w", err)
+	}
+
+	secret, err := s.readKeyring(nativeSecretKeyring)
+	if err != nil {
+		return nil, err
+	}
+	public, err := s.readKeyring(nativePublicKeyring)
+	if err != nil {
+		return nil, err
+	}
+	keyring := append(openpgp.EntityList{}, secret...)
+	for _, e := range public {
+		if findEntity(secret, fingerprint(e)) == nil {
+			keyring = append(keyring, e)
+		}
+	}
+
+	md, err := openpgp.ReadMessage(bytes.NewReader(body), keyring, unlockPrompt(), nativeConfig)
+	if err != nil {
+		if errors.Is(err, pgperrors.ErrKeyIncorrect) {
+			return nil, fmt.Errorf("no secret key available to decrypt this message. The message was encrypted for a different recipient")
+		}
+		return nil, fmt.Errorf("decryption failed: %w", err)
+	}
+
+	plaintext, err := io.ReadAll(md.UnverifiedBody)
+	if err != nil && (md.SignatureError == nil || !md.IsSigned) {
+		return nil, fmt.Errorf("decryption failed: %w", err)
+	}
+	if len(plaintext) == 0 {
+		return nil, fmt.Errorf("decryption produced empty plaintext")
+	}
+
+	result := &DecryptResult{Plaintext: plaintext}
+	if md.DecryptedWith.PublicKey != nil {
+		result.DecryptKeyID = md.DecryptedWith.PublicKey.KeyIdString()
+	}
+	if md.IsSigned {
+		result.SignerKeyID = fmt.Sprintf("%016X", md.SignedByKeyId)
+		// Like gpg, only report a verdict when the signer's key is known.
+		if md.SignedBy != nil {
+			result.WasSigned = true
+			result.SignatureOK = md.SignatureError == nil
+			if id := md.SignedBy.Entity.PrimaryIdentity(); id != nil {
+				result.SignerUID = id.Name
+			}
+		}
+	}
+	return result, nil
+}
+
+// unlockedSigner returns the secret key for keyID with its private keys
+// decrypted.
+func (s *NativeService) unlockedSigner(keyID string) (*openpgp.Entity, error) {
+	secret, err := s.readKeyring(nativeSecretKeyring)
+	if err != nil {
+		return nil, err
+	}
+	signer := findEntity(secret, keyID)
+	if signer == nil {
+		return nil, fmt.Errorf("GPG key %s not found or not usable for signing", keyID)
+	}
+	if err := unlockEntity(signer); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// recipients resolves key IDs to public keys that can encrypt.
+func (s *NativeService) recipients(keyIDs []string) ([]*openpgp.Entity, error) {
+	public, err := s.readKeyring(nativePublicKeyring)
+	if err != nil {
+		return nil, err
+	}
+	to := make([]*openpgp.Entity, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		if !isValidGPGKeyID(keyID) {
+			return nil, fmt.Errorf("invalid GPG key ID format: %q", keyID)
+		}
+		e := findEntity(public, keyID)
+		if e == nil {
+			return nil, fmt.Errorf("public key not found for one or more recipients")
+		}
+		if _, ok := e.EncryptionKey(time.Now()); !ok {
+			return nil, fmt.Errorf("one or more recipient keys are unusable (expired, revoked, or invalid)")
+		}
+		to = append(to, e)
+	}
+	return to, nil
+}
+
+// unlockEntity decrypts e's private keys with the passphrase from
+// PassphraseEnv.
+func unlockEntity(e *openpgp.Entity) error {
+	if e.PrivateKey == nil || !e.PrivateKey.Encrypted {
+		return nil
+	}
+	passphrase := os.Getenv(PassphraseEnv)
+	if passphrase == "" {
+		return fmt.Errorf("GPG key %s is passphrase-protected; set %s", e.PrimaryKey.KeyIdString(), PassphraseEnv)
+	}
+	if err := e.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+		return fmt.Errorf("wrong passphrase for GPG key %s (from %s)", e.PrimaryKey.KeyIdString(), PassphraseEnv)
+	}
+	return nil
+}
+
+// unlockPrompt decrypts the offered keys with the passphrase from
+// PassphraseEnv. ReadMessage calls it again after a wrong passphrase, so
+// it gives up on the second call.
+func unlockPrompt() openpgp.PromptFunction {
+	tried := false
+	return func(keys []openpgp.Key, _ bool) ([]byte, error) {
+		passphrase := os.Getenv(PassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("the secret key is passphrase-protected; set %s", PassphraseEnv)
+		}
+		if tried {
+			return nil, fmt.Errorf("wrong passphrase (from %s)", PassphraseEnv)
+		}
+		tried = true
+		for _, k := range keys {
+			if k.PrivateKey != nil && k.PrivateKey.Encrypted {
+				_ = k.PrivateKey.Decrypt([]byte(passphrase))
+			}
+		}
+		return nil, nil
+	}
+}
+
+func encryptArmored(to []*openpgp.Entity, signer *openpgp.Entity, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	aw, err := armor.Encode(&buf, "PGP MESSAGE", nil)
+	if err != nil {
+		return nil, err
+	}
+	pw, err := openpgp.Encrypt(aw, to, signer, nil, nativeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("encryption failed: %w", err)
+	}
+	if _, err := pw.Write(data); err != nil {
+		return nil, fmt.Errorf("encryption failed: %w", err)
+	}
+	if err := pw.Close(); err != nil {
+		return nil, fmt.Errorf("encryption failed: %w", err)
+	}
+	if err := aw.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// dearmor returns the binary packets from armored or binary input.
+func dearmor(data []byte) ([]byte, error) {
+	if !strings.HasPrefix(strings.TrimSpace(string(data)), "-----BEGIN PGP") {
+		return data, nil
+	}
+	block, err := armor.Decode(bytes.NewReader(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(block.Body)
+}
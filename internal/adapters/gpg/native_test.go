@@ -0,0 +1,352 @@
+package gpg
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// newNativeWithKey returns a native backend holding one fresh ed25519 key.
+func newNativeWithKey(t *testing.T, name, email, passphrase string) (*NativeService, *KeyInfo) {
+	t.Helper()
+	svc := NewNativeService(t.TempDir())
+	key, err := svc.GenerateKey(context.Background(), GenerateKeyOptions{
+		Name: name, Email: email, Passphrase: passphrase,
+	})
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return svc, key
+}
+
+func TestNewBackend(t *testing.T) {
+	for kind, want := range map[domain.GPGBackend]string{
+		"":                      "*gpg.Service",
+		domain.GPGBackendSystem: "*gpg.Service",
+		domain.GPGBackendNative: "*gpg.NativeService",
+	} {
+		b, err := NewBackend(kind, t.TempDir())
+		if err != nil {
+			t.Fatalf("NewBackend(%q) error = %v", kind, err)
+		}
+		if got := typeName(b); got != want {
+			t.Errorf("NewBackend(%q) = %s, want %s", kind, got, want)
+		}
+	}
+	if _, err := NewBackend("openssl", ""); err == nil || !strings.Contains(err.Error(), "unknown gpg backend") {
+		t.Errorf("NewBackend(openssl) error = %v", err)
+	}
+}
+
+func typeName(b Backend) string {
+	switch b.(type) {
+	case *Service:
+		return "*gpg.Service"
+	case *NativeService:
+		return "*gpg.NativeService"
+	}
+	return "?"
+}
+
+func TestNativeService_GenerateAndList(t *testing.T) {
+	ctx := context.Background()
+	svc, key := newNativeWithKey(t, "Ada Lovelace", "ada@example.com", "")
+
+	if len(key.Fingerprint) != 40 || !strings.HasSuffix(key.Fingerprint, key.KeyID) {
+		t.Errorf("key = %+v, want 40-char fingerprint ending in key ID", key)
+	}
+	if key.Type != "EdDSA" || key.Expires == nil {
+		t.Errorf("key type = %q, expires = %v; want EdDSA with the default expiry", key.Type, key.Expires)
+	}
+	if len(key.UIDs) != 1 || key.UIDs[0] != "Ada Lovelace <ada@example.com>" {
+		t.Errorf("UIDs = %v", key.UIDs)
+	}
+
+	secret, err := svc.ListSigningKeys(ctx)
+	if err != nil || len(secret) != 1 || secret[0].Fingerprint != key.Fingerprint {
+		t.Fatalf("ListSigningKeys() = %v, %v", secret, err)
+	}
+	public, err := svc.ListPublicKeys(ctx)
+	if err != nil || len(public) != 1 {
+		t.Fatalf("ListPublicKeys() = %v, %v", public, err)
+	}
+	found, err := svc.FindKeyByEmail(ctx, "ADA@example.com")
+	if err != nil || found.Fingerprint != key.Fingerprint {
+		t.Errorf("FindKeyByEmail() = %v, %v", found, err)
+	}
+}
+
+func TestNativeService_SignVerifyAcrossKeyrings(t *testing.T) {
+	ctx := context.Background()
+	alice, key := newNativeWithKey(t, "Alice", "alice@example.com", "")
+	bob := NewNativeService(t.TempDir())
+
+	armored, err := alice.ExportPublicKey(ctx, "alice@example.com")
+	if err != nil || !bytes.Contains(armored, []byte("BEGIN PGP PUBLIC KEY BLOCK")) {
+		t.Fatalf("ExportPublicKey() = %q, %v", armored, err)
+	}
+	imported, err := bob.ImportKeys(ctx, armored)
+	if err != nil || imported.Imported != 1 || imported.SecretKeys != 0 {
+		t.Fatalf("ImportKeys() = %+v, %v", imported, err)
+	}
+
+	data := []byte("Content-Type: text/plain\r\n\r\nhello\r\n")
+	signed, err := alice.SignData(ctx, key.KeyID, data, "alice@example.com")
+	if err != nil {
+		t.Fatalf("SignData() error = %v", err)
+	}
+
+	result, err := bob.VerifyDetachedSignature(ctx, data, signed.Signature)
+	if err != nil {
+		t.Fatalf("VerifyDetachedSignature() error = %v", err)
+	}
+	if !result.Valid || result.Fingerprint != key.Fingerprint || result.SignerUID != "Alice <alice@example.com>" {
+		t.Errorf("result = %+v", result)
+	}
+	if result.TrustLevel != "unknown" {
+		t.Errorf("TrustLevel = %q, want unknown for a foreign key", result.TrustLevel)
+	}
+
+	result, err = bob.VerifyDetachedSignature(ctx, []byte("tampered"), signed.Signature)
+	if err != nil || result.Valid {
+		t.Errorf("tampered: result = %+v, err = %v; want invalid", result, err)
+	}
+}
+
+func TestNativeService_VerifyFetchesUnknownKey(t *testing.T) {
+	ctx := context.Background()
+	alice, key := newNativeWithKey(t, "Alice", "alice@example.com", "")
+	armored, err := alice.ExportPublicKey(ctx, key.KeyID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		_, _ = w.Write(armored)
+	}))
+	defer server.Close()
+
+	bob := NewNativeService(t.TempDir())
+	bob.keyserver = server.URL
+
+	data := []byte("hello")
+	signed, err := alice.SignData(ctx, key.KeyID, data, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := bob.VerifyDetachedSignature(ctx, data, signed.Signature)
+	if err != nil || !result.Valid {
+		t.Fatalf("VerifyDetachedSignature() = %+v, %v", result, err)
+	}
+	if requested != "/vks/v1/by-keyid/"+key.KeyID {
+		t.Errorf("keyserver path = %q", requested)
+	}
+	if keys, _ := bob.ListPublicKeys(ctx); len(keys) != 1 {
+		t.Errorf("fetched key not stored: %v", keys)
+	}
+}
+
+func TestNativeService_VerifyUnknownKeyNotOnServer(t *testing.T) {
+	ctx := context.Background()
+	alice, key := newNativeWithKey(t, "Alice", "alice@example.com", "")
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	bob := NewNativeService(t.TempDir())
+	bob.keyserver = server.URL
+
+	signed, err := alice.SignData(ctx, key.KeyID, []byte("hello"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bob.VerifyDetachedSignature(ctx, []byte("hello"), signed.Signature); err == nil || !strings.Contains(err.Error(), "public key not found") {
+		t.Errorf("error = %v, want public key not found", err)
+	}
+}
+
+func TestNativeService_EncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	alice, aliceKey := newNativeWithKey(t, "Alice", "alice@example.com", "")
+	bob, bobKey := newNativeWithKey(t, "Bob", "bob@example.com", "")
+
+	exchange := func(from, to *NativeService, ref string) {
+		armored, err := from.ExportPublicKey(ctx, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := to.ImportKeys(ctx, armored); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exchange(bob, alice, bobKey.KeyID)
+	exchange(alice, bob, aliceKey.KeyID)
+
+	plaintext := []byte("the eagle lands at noon")
+
+	enc, err := alice.EncryptData(ctx, []string{"bob@example.com"}, plaintext)
+	if err != nil || !bytes.Contains(enc.Ciphertext, []byte("BEGIN PGP MESSAGE")) {
+		t.Fatalf("EncryptData() = %v, %v", enc, err)
+	}
+	dec, err := bob.DecryptData(ctx, enc.Ciphertext)
+	if err != nil || !bytes.Equal(dec.Plaintext, plaintext) || dec.WasSigned {
+		t.Fatalf("DecryptData() = %+v, %v", dec, err)
+	}
+	if _, err := alice.DecryptData(ctx, enc.Ciphertext); err == nil || !strings.Contains(err.Error(), "no secret key") {
+		t.Errorf("sender DecryptData() error = %v, want no secret key", err)
+	}
+
+	enc, err = alice.SignAndEncryptData(ctx, aliceKey.KeyID, []string{bobKey.Fingerprint}, plaintext, "alice@example.com")
+	if err != nil {
+		t.Fatalf("SignAndEncryptData() error = %v", err)
+	}
+	dec, err = bob.DecryptData(ctx, enc.Ciphertext)
+	if err != nil {
+		t.Fatalf("DecryptData() error = %v", err)
+	}
+	if !dec.WasSigned || !dec.SignatureOK || dec.SignerUID != "Alice <alice@example.com>" {
+		t.Errorf("signature = %+v", dec)
+	}
+
+	if _, err := alice.EncryptData(ctx, []string{"carol@example.com"}, plaintext); err == nil || !strings.Contains(err.Error(), "public key not found") {
+		t.Errorf("unknown recipient error = %v", err)
+	}
+}
+
+func TestNativeService_Passphrase(t *testing.T) {
+	ctx := context.Background()
+	svc, key := newNativeWithKey(t, "Ada", "ada@example.com", "correct horse")
+
+	t.Setenv(PassphraseEnv, "")
+	if _, err := svc.SignData(ctx, key.KeyID, []byte("x"), ""); err == nil || !strings.Contains(err.Error(), PassphraseEnv) {
+		t.Errorf("no passphrase: error = %v, want hint about %s", err, PassphraseEnv)
+	}
+
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := svc.SignData(ctx, key.KeyID, []byte("x"), ""); err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("wrong passphrase: error = %v", err)
+	}
+
+	t.Setenv(PassphraseEnv, "correct horse")
+	if _, err := svc.SignData(ctx, key.KeyID, []byte("x"), ""); err != nil {
+		t.Errorf("SignData() error = %v", err)
+	}
+
+	enc, err := svc.EncryptData(ctx, []string{key.KeyID}, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dec, err := svc.DecryptData(ctx, enc.Ciphertext); err != nil || string(dec.Plaintext) != "secret" {
+		t.Errorf("DecryptData() = %v, %v", dec, err)
+	}
+	t.Setenv(PassphraseEnv, "wrong")
+	if _, err := svc.DecryptData(ctx, enc.Ciphertext); err == nil {
+		t.Error("DecryptData() with wrong passphrase succeeded")
+	}
+}
+
+func TestNativeService_ImportSecretKeyAndRejectGarbage(t *testing.T) {
+	ctx := context.Background()
+	svc := NewNativeService(t.TempDir())
+	if _, err := svc.ImportKeys(ctx, []byte("not a key")); err == nil {
+		t.Error("ImportKeys(garbage) succeeded")
+	}
+	if _, err := svc.ImportKeys(ctx, nil); err == nil {
+		t.Error("ImportKeys(nil) succeeded")
+	}
+
+	src, key := newNativeWithKey(t, "Ada", "ada@example.com", "")
+	secret, err := src.readKeyring(nativeSecretKeyring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	for _, e := range secret {
+		if err := e.SerializePrivateWithoutSigning(&buf, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := svc.ImportKeys(ctx, buf.Bytes())
+	if err != nil || result.SecretKeys != 1 || result.Fingerprints[0] != key.Fingerprint {
+		t.Fatalf("ImportKeys(binary secret) = %+v, %v", result, err)
+	}
+	result, err = svc.ImportKeys(ctx, buf.Bytes())
+	if err != nil || result.Unchanged != 1 || result.Imported != 0 {
+		t.Errorf("re-import = %+v, %v", result, err)
+	}
+	if _, err := svc.SignData(ctx, key.KeyID, []byte("x"), ""); err != nil {
+		t.Errorf("SignData() with imported key error = %v", err)
+	}
+}
+
+func TestNativeService_FindPublicKeyByEmail_ReservedDomain(t *testing.T) {
+	svc := NewNativeService(t.TempDir())
+	svc.keyserver = "http://127.0.0.1:0"
+	_, err := svc.FindPublicKeyByEmail(context.Background(), "nobody@mail.test")
+	if err == nil || !strings.Contains(err.Error(), "reserved domain") {
+		t.Errorf("error = %v, want reserved domain skip", err)
+	}
+}
+
+func TestFindEntity(t *testing.T) {
+	svc, key := newNativeWithKey(t, "Ada", "ada@example.com", "")
+	list, err := svc.readKeyring(nativePublicKeyring)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{key.KeyID, strings.ToLower(key.Fingerprint), "0x" + key.KeyID[8:], "ADA@example.com", "Ada <ada@example.com>"} {
+		if findEntity(list, ref) == nil {
+			t.Errorf("findEntity(%q) = nil", ref)
+		}
+	}
+	for _, ref := range []string{"bob@example.com", "DEADBEEF"} {
+		if findEntity(list, ref) != nil {
+			t.Errorf("findEntity(%q) matched", ref)
+		}
+	}
+}
+
+func TestExpireDuration(t *testing.T) {
+	day := int64(24 * 60 * 60)
+	for in, want := range map[string]int64{"never": 0, "0": 0, "90": 90 * day, "90d": 90 * day, "2w": 14 * day, "6m": 180 * day, "2y": 730 * day} {
+		if got := int64(expireDuration(in).Seconds()); got != want {
+			t.Errorf("expireDuration(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+// TestNativeService_GPGInterop checks that a native signature verifies
+// with the gpg binary.
+func TestNativeService_GPGInterop(t *testing.T) {
+	useTempKeyring(t)
+	ctx := context.Background()
+	native, key := newNativeWithKey(t, "Ada", "ada@example.com", "")
+
+	armored, err := native.ExportPublicKey(ctx, key.KeyID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	system := NewService()
+	if _, err := system.ImportKeys(ctx, armored); err != nil {
+		t.Fatalf("gpg import error = %v", err)
+	}
+
+	data := []byte("interop\r\n")
+	signed, err := native.SignData(ctx, key.KeyID, data, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := system.VerifyDetachedSignature(ctx, data, signed.Signature)
+	if err != nil || !result.Valid {
+		out, _ := exec.Command("gpg", "--version").Output()
+		t.Fatalf("gpg verify = %+v, %v (gpg %s)", result, err, strings.SplitN(string(out), "\n", 2)[0])
+	}
+}
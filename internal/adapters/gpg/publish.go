@@ -0,0 +1,146 @@
+package gpg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/nylas/cli/internal/httputil"
+)
+
+// DefaultPublishServer is the keyserver used by PublishKey. It only serves
+// email addresses whose owner confirmed them, so uploads trigger a
+// verification email per address.
+const DefaultPublishServer = "https://keys.openpgp.org"
+
+// maxPublishResponse caps keyserver response bodies.
+const maxPublishResponse = 1 << 20
+
+// Address states reported by the VKS API.
+const (
+	AddressPublished   = "published"
+	AddressUnpublished = "unpublished"
+	AddressPending     = "pending"
+	AddressRevoked     = "revoked"
+)
+
+// PublishResult describes a key upload to a VKS keyserver.
+type PublishResult struct {
+	Server      string            `json:"server"`              // Keyserver base URL
+	Fingerprint string            `json:"fingerprint"`         // Uploaded key fingerprint
+	Addresses   map[string]string `json:"addresses"`           // Address -> state after upload
+	Requested   []string          `json:"requested,omitempty"` // Addresses sent a verification email
+}
+
+// KeyPublisher uploads public keys to a keyserver speaking the VKS API
+// (https://keys.openpgp.org/about/api).
+type KeyPublisher struct {
+	server string
+	client *http.Client
+}
+
+// NewKeyPublisher creates a publisher for server, or DefaultPublishServer
+// when empty. Bare host names get an https:// scheme.
+func NewKeyPublisher(server string) *KeyPublisher {
+	server = strings.TrimRight(strings.TrimSpace(server), "/")
+	if server == "" {
+		server = DefaultPublishServer
+	}
+	if !strings.Contains(server, "://") {
+		server = "https://" + server
+	}
+	return &KeyPublisher{server: server, client: httputil.DefaultClient}
+}
+
+// Server returns the keyserver base URL.
+func (p *KeyPublisher) Server() string {
+	return p.server
+}
+
+type vksUploadResponse struct {
+	KeyFpr string            `json:"key_fpr"`
+	Status map[string]string `json:"status"`
+	Token  string            `json:"token"`
+}
+
+// Publish uploads an ASCII-armored public key and requests verification
+// for every address that is not yet published.
+func (p *KeyPublisher) Publish(ctx context.Context, armoredKey []byte) (*PublishResult, error) {
+	var upload vksUploadResponse
+	if err := p.post(ctx, "/vks/v1/upload", map[string]any{"keytext": string(armoredKey)}, &upload); err != nil {
+		return nil, err
+	}
+
+	result := &PublishResult{
+		Server:      p.server,
+		Fingerprint: upload.KeyFpr,
+		Addresses:   upload.Status,
+	}
+	if result.Addresses == nil {
+		result.Addresses = map[string]string{}
+	}
+
+	var pending []string
+	for addr, state := range upload.Status {
+		if state == AddressUnpublished {
+			pending = append(pending, addr)
+		}
+	}
+	if len(pending) == 0 || upload.Token == "" {
+		return result, nil
+	}
+	sort.Strings(pending)
+
+	var verify vksUploadResponse
+	body := map[string]any{"token": upload.Token, "addresses": pending}
+	if err := p.post(ctx, "/vks/v1/request-verify", body, &verify); err != nil {
+		return result, fmt.Errorf("key uploaded but requesting verification failed: %w", err)
+	}
+	for addr, state := range verify.Status {
+		result.Addresses[addr] = state
+	}
+	result.Requested = pending
+	return result, nil
+}
+
+func (p *KeyPublisher) post(ctx context.Context, path string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.server+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("keyserver request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPublishResponse))
+	if err != nil {
+		return fmt.Errorf("reading keyserver response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("keyserver rejected the request: %s", apiErr.Error)
+		}
+		return fmt.Errorf("keyserver returned HTTP %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid keyserver response: %w", err)
+	}
+	return nil
+}
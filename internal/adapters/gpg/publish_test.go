@@ -0,0 +1,70 @@
+package gpg
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewKeyPublisher_Server(t *testing.T) {
+	tests := map[string]string{
+		"":                          DefaultPublishServer,
+		"keys.example.org":          "https://keys.example.org",
+		"http://localhost:8080/":    "http://localhost:8080",
+		" https://keys.openpgp.org": "https://keys.openpgp.org",
+	}
+	for in, want := range tests {
+		if got := NewKeyPublisher(in).Server(); got != want {
+			t.Errorf("NewKeyPublisher(%q).Server() = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestKeyPublisher_Publish(t *testing.T) {
+	var verifyBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/vks/v1/upload":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if !strings.Contains(body["keytext"], "BEGIN PGP") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"no key"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"key_fpr":"ABCD","token":"tok","status":{"a@example.com":"unpublished","b@example.com":"published"}}`))
+		case "/vks/v1/request-verify":
+			_ = json.NewDecoder(r.Body).Decode(&verifyBody)
+			_, _ = w.Write([]byte(`{"key_fpr":"ABCD","token":"tok","status":{"a@example.com":"pending"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p := NewKeyPublisher(srv.URL)
+	result, err := p.Publish(context.Background(), []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----"))
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if result.Fingerprint != "ABCD" {
+		t.Errorf("Fingerprint = %q", result.Fingerprint)
+	}
+	if result.Addresses["a@example.com"] != AddressPending || result.Addresses["b@example.com"] != AddressPublished {
+		t.Errorf("Addresses = %v", result.Addresses)
+	}
+	if len(result.Requested) != 1 || result.Requested[0] != "a@example.com" {
+		t.Errorf("Requested = %v", result.Requested)
+	}
+	if verifyBody["token"] != "tok" {
+		t.Errorf("request-verify token = %v", verifyBody["token"])
+	}
+
+	_, err = p.Publish(context.Background(), []byte("garbage"))
+	if err == nil || !strings.Contains(err.Error(), "no key") {
+		t.Errorf("Publish(garbage) error = %v, want keyserver error", err)
+	}
+}
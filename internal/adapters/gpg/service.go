@@ -59,7 +59,8 @@ func NewService() *Service {
 func (s *Service) CheckGPGAvailable(ctx context.Context) error {
 	cmd := exec.CommandContext(ctx, "gpg", "--version")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("GPG not found. Install with: sudo apt install gnupg (Linux) or brew install gnupg (macOS)")
+		return fmt.Errorf("GPG not found. Install with: sudo apt install gnupg (Linux) or brew install gnupg (macOS), " +
+			"or use the built-in backend: nylas config set gpg.backend native")
 	}
 	return nil
 }
@@ -81,16 +82,9 @@ func (s *Service) ListSigningKeys(ctx context.Context) ([]KeyInfo, error) {
 
 // GetDefaultSigningKey retrieves the default signing key from git config.
 func (s *Service) GetDefaultSigningKey(ctx context.Context) (*KeyInfo, error) {
-	// Try to get key from git config
-	cmd := exec.CommandContext(ctx, "git", "config", "--get", "user.signingkey")
-	output, err := cmd.Output()
+	keyID, err := gitSigningKey(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("no default GPG key configured. Set with: git config --global user.signingkey <KEY_ID>")
-	}
-
-	keyID := strings.TrimSpace(string(output))
-	if keyID == "" {
-		return nil, fmt.Errorf("git user.signingkey is empty")
+		return nil, err
 	}
 
 	// Get full key info
@@ -109,6 +103,21 @@ func (s *Service) GetDefaultSigningKey(ctx context.Context) (*KeyInfo, error) {
 	return nil, fmt.Errorf("git signing key %s not found in GPG keyring", keyID)
 }
 
+// gitSigningKey returns git's user.signingkey.
+func gitSigningKey(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "config", "--get", "user.signingkey")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("no default GPG key configured. Set with: git config --global user.signingkey <KEY_ID>")
+	}
+
+	keyID := strings.TrimSpace(string(output))
+	if keyID == "" {
+		return "", fmt.Errorf("git user.signingkey is empty")
+	}
+	return keyID, nil
+}
+
 // FindKeyByEmail finds a signing key that contains the given email in its UIDs.
 // Returns the KeyInfo with the actual key ID for use with --local-user.
 // This is important because GPG's --sender option only works correctly when
@@ -480,8 +489,8 @@ func parseKeys(output string, recordPrefix string) ([]KeyInfo, error) {
 				}
 			}
 
-		case "fpr": // Fingerprint
-			if currentKey != nil && len(fields) > 9 {
+		case "fpr": // Fingerprint (the first one follows the primary key; later ones belong to subkeys)
+			if currentKey != nil && currentKey.Fingerprint == "" && len(fields) > 9 {
 				currentKey.Fingerprint = fields[9]
 			}
 
@@ -47,3 +47,21 @@ type DecryptResult struct {
 	SignerUID    string // UID of signer (e.g., "Name <email>")
 	DecryptKeyID string // Key ID used for decryption
 }
+
+// GenerateKeyOptions configures a new key pair.
+type GenerateKeyOptions struct {
+	Name       string // Real name for the user ID
+	Email      string // Email address for the user ID
+	Algorithm  string // "ed25519" (default) or "rsa4096"
+	Expire     string // Expiry in gpg syntax, e.g. "2y", "6m", "never" (default "2y")
+	Passphrase string // Empty creates an unprotected key
+}
+
+// ImportResult summarizes a key import.
+type ImportResult struct {
+	Fingerprints []string `json:"fingerprints"` // Keys that were new or updated
+	Imported     int      `json:"imported"`     // New public keys
+	Unchanged    int      `json:"unchanged"`    // Public keys already in the keyring
+	SecretKeys   int      `json:"secret_keys"`  // Secret keys imported
+	NotImported  int      `json:"not_imported"` // Keys gpg rejected
+}
@@ -0,0 +1,82 @@
+package granttoken
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+type memStore map[string]string
+
+func (m memStore) Set(key, value string) error { m[key] = value; return nil }
+func (m memStore) Get(key string) (string, error) {
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	return "", domain.ErrSecretNotFound
+}
+func (m memStore) Delete(key string) error { delete(m, key); return nil }
+func (m memStore) IsAvailable() bool       { return true }
+func (m memStore) Name() string            { return "memory" }
+
+func TestSigningKey_CreatesOnceAndReuses(t *testing.T) {
+	store := memStore{}
+	first, err := SigningKey(store)
+	require.NoError(t, err)
+	assert.Len(t, first, signingKeySize)
+	assert.NotEmpty(t, store[ports.KeyGrantTokenSigningKey])
+
+	second, err := SigningKey(store)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	store[ports.KeyGrantTokenSigningKey] = "short"
+	_, err = SigningKey(store)
+	assert.Error(t, err)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	key := []byte(strings.Repeat("k", signingKeySize))
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	issued := New("grant-1", []domain.Scope{domain.ScopeEmailRead}, time.Hour, now)
+
+	token, err := Sign(key, issued)
+	require.NoError(t, err)
+	assert.True(t, IsToken(token))
+
+	got, err := Verify(key, token, now.Add(59*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, issued, got)
+
+	t.Run("expired", func(t *testing.T) {
+		got, err := Verify(key, token, now.Add(time.Hour))
+		assert.ErrorIs(t, err, domain.ErrGrantTokenExpired)
+		require.NotNil(t, got, "claims are returned for reporting")
+		assert.Equal(t, issued.ID, got.ID)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := Verify([]byte(strings.Repeat("x", signingKeySize)), token, now)
+		assert.ErrorIs(t, err, domain.ErrGrantTokenInvalid)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		other, err := Sign(key, New("grant-2", issued.Scopes, time.Hour, now))
+		require.NoError(t, err)
+		otherBody, _, _ := strings.Cut(strings.TrimPrefix(other, Prefix), ".")
+		_, sig, _ := strings.Cut(token, ".")
+		_, err = Verify(key, Prefix+otherBody+"."+sig, now)
+		assert.ErrorIs(t, err, domain.ErrGrantTokenInvalid)
+	})
+
+	for _, bad := range []string{"", "nyk_abc", Prefix + "nodot", Prefix + "a.b"} {
+		_, err := Verify(key, bad, now)
+		assert.ErrorIs(t, err, domain.ErrGrantTokenInvalid, bad)
+	}
+}
@@ -0,0 +1,165 @@
+// Package httpcache stores API responses on disk for the response cache.
+package httpcache
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Store implements ports.ResponseCache with one JSON file per response,
+// in a directory per resource. Files are readable only by the owner since
+// responses can contain contact details.
+type Store struct {
+	dir string
+}
+
+// ResourceStats summarizes the cached responses for one resource.
+type ResourceStats struct {
+	Resource string `json:"resource"`
+	Entries  int    `json:"entries"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// New creates a store rooted at dir.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the cache directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Get returns the cached response for key, or nil if there is none.
+// Unreadable entries are treated as misses.
+func (s *Store) Get(resource, key string) (*domain.CachedResponse, error) {
+	path, err := s.path(resource, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var r domain.CachedResponse
+	if err := json.Unmarshal(data, &r); err != nil {
+		_ = os.Remove(path)
+		return nil, nil
+	}
+	return &r, nil
+}
+
+// Put stores a response under key, replacing it atomically.
+func (s *Store) Put(resource, key string, r *domain.CachedResponse) error {
+	path, err := s.path(resource, key)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// Invalidate drops every cached response for resource.
+func (s *Store) Invalidate(resource string) error {
+	if !validResource(resource) {
+		return domain.ErrInvalidInput
+	}
+	return os.RemoveAll(filepath.Join(s.dir, resource))
+}
+
+// Clear removes all cached responses and returns how many were removed.
+func (s *Store) Clear() (int, error) {
+	stats, err := s.Stats()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, st := range stats {
+		if err := s.Invalidate(st.Resource); err != nil {
+			return removed, err
+		}
+		removed += st.Entries
+	}
+	return removed, nil
+}
+
+// Stats returns per-resource entry counts and sizes, sorted by resource.
+func (s *Store) Stats() ([]ResourceStats, error) {
+	dirs, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var stats []ResourceStats
+	for _, d := range dirs {
+		if !d.IsDir() || !validResource(d.Name()) {
+			continue
+		}
+		st := ResourceStats{Resource: d.Name()}
+		entries, err := os.ReadDir(filepath.Join(s.dir, d.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !strings.HasSuffix(e.Name(), ".json") {
+				continue
+			}
+			if info, err := e.Info(); err == nil {
+				st.Entries++
+				st.Bytes += info.Size()
+			}
+		}
+		stats = append(stats, st)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Resource < stats[j].Resource })
+	return stats, nil
+}
+
+func (s *Store) path(resource, key string) (string, error) {
+	if !validResource(resource) || key == "" || strings.ContainsAny(key, `/\.`) {
+		return "", domain.ErrInvalidInput
+	}
+	return filepath.Join(s.dir, resource, key+".json"), nil
+}
+
+func validResource(resource string) bool {
+	_, ok := domain.DefaultCacheTTLs[resource]
+	return ok
+}
@@ -0,0 +1,77 @@
+package httpcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestStore_PutGet(t *testing.T) {
+	s := New(t.TempDir())
+
+	got, err := s.Get("folders", "abc")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	want := &domain.CachedResponse{Body: []byte(`{"data":[]}`), ETag: `"v1"`, StoredAt: time.Now().UTC().Truncate(time.Second)}
+	require.NoError(t, s.Put("folders", "abc", want))
+
+	got, err = s.Get("folders", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	info, err := os.Stat(filepath.Join(s.Dir(), "folders", "abc.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestStore_RejectsBadKeys(t *testing.T) {
+	s := New(t.TempDir())
+	assert.ErrorIs(t, s.Put("messages", "abc", &domain.CachedResponse{}), domain.ErrInvalidInput)
+	assert.ErrorIs(t, s.Put("folders", "../x", &domain.CachedResponse{}), domain.ErrInvalidInput)
+	assert.ErrorIs(t, s.Invalidate("../"), domain.ErrInvalidInput)
+}
+
+func TestStore_CorruptEntryIsMiss(t *testing.T) {
+	s := New(t.TempDir())
+	require.NoError(t, os.MkdirAll(filepath.Join(s.Dir(), "grants"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(s.Dir(), "grants", "k.json"), []byte("{"), 0o600))
+
+	got, err := s.Get("grants", "k")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestStore_InvalidateClearStats(t *testing.T) {
+	s := New(t.TempDir())
+	stats, err := s.Stats()
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+
+	for _, key := range []string{"a", "b"} {
+		require.NoError(t, s.Put("contacts", key, &domain.CachedResponse{Body: []byte(`{}`)}))
+	}
+	require.NoError(t, s.Put("calendars", "c", &domain.CachedResponse{Body: []byte(`{}`)}))
+
+	stats, err = s.Stats()
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "calendars", stats[0].Resource)
+	assert.Equal(t, 2, stats[1].Entries)
+	assert.Positive(t, stats[1].Bytes)
+
+	require.NoError(t, s.Invalidate("contacts"))
+	got, err := s.Get("contacts", "a")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	removed, err := s.Clear()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
@@ -0,0 +1,66 @@
+// Package invitations stores bulk hosted-auth invitations in a local JSON file.
+package invitations
+
+import (
+	"strings"
+
+	"github.com/nylas/cli/internal/adapters/jsonfile"
+	"github.com/nylas/cli/internal/domain"
+)
+
+const fileVersion = 1
+
+// Store implements ports.InvitationStore.
+type Store struct {
+	file *jsonfile.Store[fileShape]
+}
+
+type fileShape struct {
+	jsonfile.Versioned
+	Invitations []domain.Invitation `json:"invitations"`
+}
+
+// New creates an invitation store backed by the file at path.
+func New(path string) *Store {
+	return &Store{file: jsonfile.New[fileShape](path, "invitations", fileVersion)}
+}
+
+// Path returns the invitations file path.
+func (s *Store) Path() string {
+	return s.file.Path()
+}
+
+// List returns every invitation in the order they were first sent. A
+// corrupt file is an error, so sent invitations are never silently
+// forgotten and re-sent.
+func (s *Store) List() ([]domain.Invitation, error) {
+	shape, err := s.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	return shape.Invitations, nil
+}
+
+// Save adds invitations, replacing any existing one with the same email.
+// A replaced invitation keeps its place in the list.
+func (s *Store) Save(invitations ...domain.Invitation) error {
+	return s.file.Update(func(shape *fileShape) error {
+		index := make(map[string]int, len(shape.Invitations))
+		for i, inv := range shape.Invitations {
+			index[strings.ToLower(inv.Email)] = i
+		}
+		for _, inv := range invitations {
+			if inv.Email == "" {
+				return domain.ErrInvalidInput
+			}
+			key := strings.ToLower(inv.Email)
+			if i, ok := index[key]; ok {
+				shape.Invitations[i] = inv
+				continue
+			}
+			index[key] = len(shape.Invitations)
+			shape.Invitations = append(shape.Invitations, inv)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,52 @@
+package invitations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestStore_SaveAndList(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "invitations.json"))
+
+	invs, err := s.List()
+	require.NoError(t, err)
+	assert.Empty(t, invs)
+
+	sent := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, s.Save(
+		domain.Invitation{Email: "ana@acme.com", Provider: "google", State: "s1", SentAt: sent},
+		domain.Invitation{Email: "bob@acme.com", Provider: "google", State: "s2", SentAt: sent},
+	))
+
+	// Re-sending replaces the entry in place.
+	require.NoError(t, s.Save(domain.Invitation{Email: "ANA@acme.com", Provider: "google", State: "s3", SentAt: sent.Add(time.Hour)}))
+
+	invs, err = s.List()
+	require.NoError(t, err)
+	require.Len(t, invs, 2)
+	assert.Equal(t, "s3", invs[0].State)
+	assert.Equal(t, "bob@acme.com", invs[1].Email)
+
+	info, err := os.Stat(s.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestStore_SaveRequiresEmail(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "invitations.json"))
+	assert.ErrorIs(t, s.Save(domain.Invitation{State: "s1"}), domain.ErrInvalidInput)
+}
+
+func TestStore_CorruptFileIsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invitations.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err := New(path).List()
+	assert.Error(t, err)
+}
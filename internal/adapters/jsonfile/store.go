@@ -0,0 +1,135 @@
+// Package jsonfile keeps a versioned JSON document in a local file. It backs
+// the stores that track what the CLI created or sent, such as invitations,
+// seeded test data and auto-join notetakers.
+package jsonfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Versioned is embedded in a document to record its file format version as
+// the top-level "version" field.
+type Versioned struct {
+	Version int `json:"version"`
+}
+
+func (v *Versioned) setVersion(n int) {
+	v.Version = n
+}
+
+type versioned interface {
+	setVersion(n int)
+}
+
+// Store keeps a document of type T, which embeds Versioned, in a JSON file.
+// Loads and updates are serialized, and an update replaces the file
+// atomically with owner-only permissions.
+type Store[T any] struct {
+	path    string
+	name    string // What the file holds, for errors
+	version int
+	mu      sync.Mutex
+}
+
+// New creates a store backed by the file at path. name describes the
+// content in errors, for example "invitations".
+func New[T any](path, name string, version int) *Store[T] {
+	return &Store[T]{path: path, name: name, version: version}
+}
+
+// Path returns the file path.
+func (s *Store[T]) Path() string {
+	return s.path
+}
+
+// Load returns the document, or an empty one when the file doesn't exist.
+func (s *Store[T]) Load() (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.read()
+}
+
+// Update loads the document, applies fn and writes the result back. Nothing
+// is written when fn fails.
+func (s *Store[T]) Update(fn func(doc *T) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, err := s.read()
+	if err != nil {
+		return err
+	}
+	if err := fn(doc); err != nil {
+		return err
+	}
+	return s.write(doc)
+}
+
+// read loads the file. A corrupt file is an error rather than an empty
+// document: the stores track things that can't be rebuilt from the API, and
+// starting over would lose them.
+func (s *Store[T]) read() (*T, error) {
+	doc := new(T)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.stamp(doc)
+		return doc, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("read %s %s: %w", s.name, s.path, err)
+	}
+	return doc, nil
+}
+
+func (s *Store[T]) write(doc *T) error {
+	s.stamp(doc)
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(s.path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+func (s *Store[T]) stamp(doc *T) {
+	if v, ok := any(doc).(versioned); ok {
+		v.setVersion(s.version)
+	}
+}
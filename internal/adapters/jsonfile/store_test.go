@@ -0,0 +1,68 @@
+package jsonfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDoc struct {
+	Versioned
+	Items []string `json:"items"`
+}
+
+func TestStore_LoadMissingFile(t *testing.T) {
+	s := New[testDoc](filepath.Join(t.TempDir(), "items.json"), "items", 3)
+
+	doc, err := s.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 3, doc.Version)
+	assert.Empty(t, doc.Items)
+}
+
+func TestStore_UpdateWritesVersionedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "items.json")
+	s := New[testDoc](path, "items", 2)
+
+	require.NoError(t, s.Update(func(doc *testDoc) error {
+		doc.Items = append(doc.Items, "a", "b")
+		return nil
+	}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "items": ["a", "b"]}`, string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
+
+func TestStore_FailedUpdateWritesNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+	s := New[testDoc](path, "items", 1)
+
+	errStop := errors.New("stop")
+	err := s.Update(func(doc *testDoc) error {
+		doc.Items = append(doc.Items, "a")
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.NoFileExists(t, path)
+}
+
+func TestStore_CorruptFileIsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := New[testDoc](path, "items", 1).Load()
+	assert.ErrorContains(t, err, "read items")
+}
@@ -0,0 +1,98 @@
+package keyring
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// CachedStore keeps secrets read from another store in memory for a while,
+// so a long-running process doesn't reach the keychain for every command.
+// Writes go straight through and update the cache. Changes made by other
+// processes show up once the cached value expires.
+type CachedStore struct {
+	store ports.SecretStore
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	value   string
+	found   bool
+	expires time.Time
+}
+
+// NewCachedStore wraps store, remembering each secret read for ttl.
+func NewCachedStore(store ports.SecretStore, ttl time.Duration) *CachedStore {
+	return &CachedStore{
+		store:   store,
+		ttl:     ttl,
+		entries: make(map[string]cachedSecret),
+	}
+}
+
+// Set stores a secret value for the given key.
+func (c *CachedStore) Set(key, value string) error {
+	if err := c.store.Set(key, value); err != nil {
+		c.forget(key)
+		return err
+	}
+	c.remember(key, value, true)
+	return nil
+}
+
+// Get retrieves a secret value for the given key. Missing secrets are
+// cached too.
+func (c *CachedStore) Get(key string) (string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		if !entry.found {
+			return "", domain.ErrSecretNotFound
+		}
+		return entry.value, nil
+	}
+
+	value, err := c.store.Get(key)
+	switch {
+	case err == nil:
+		c.remember(key, value, true)
+	case errors.Is(err, domain.ErrSecretNotFound):
+		c.remember(key, "", false)
+	}
+	return value, err
+}
+
+// Delete removes a secret for the given key.
+func (c *CachedStore) Delete(key string) error {
+	c.forget(key)
+	return c.store.Delete(key)
+}
+
+// IsAvailable checks if the underlying store is available.
+func (c *CachedStore) IsAvailable() bool {
+	return c.store.IsAvailable()
+}
+
+// Name returns the name of the underlying store.
+func (c *CachedStore) Name() string {
+	return c.store.Name()
+}
+
+func (c *CachedStore) remember(key, value string, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedSecret{value: value, found: found, expires: time.Now().Add(c.ttl)}
+}
+
+func (c *CachedStore) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
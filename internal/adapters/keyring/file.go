@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
@@ -159,6 +160,27 @@ func (f *EncryptedFileStore) Delete(key string) error {
 	return nil
 }
 
+// Keys returns the keys of all stored secrets, sorted.
+func (f *EncryptedFileStore) Keys() ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	secrets, err := f.loadSecrets()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrSecretStoreFailed, err)
+	}
+
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
 // IsAvailable always returns true for file-based storage.
 func (f *EncryptedFileStore) IsAvailable() bool {
 	return true
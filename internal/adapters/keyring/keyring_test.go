@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/nylas/cli/internal/adapters/keyring"
 	"github.com/nylas/cli/internal/domain"
@@ -106,6 +107,59 @@ func TestMockSecretStore(t *testing.T) {
 	})
 }
 
+func TestCachedStore(t *testing.T) {
+	t.Run("reads hit the store once", func(t *testing.T) {
+		mock := keyring.NewMockSecretStore()
+		require.NoError(t, mock.Set(ports.KeyAPIKey, "nyk_1"))
+		reads := 0
+		mock.GetFunc = func(key string) (string, error) {
+			reads++
+			if key == ports.KeyAPIKey {
+				return "nyk_1", nil
+			}
+			return "", domain.ErrSecretNotFound
+		}
+		store := keyring.NewCachedStore(mock, time.Hour)
+
+		for range 3 {
+			value, err := store.Get(ports.KeyAPIKey)
+			require.NoError(t, err)
+			assert.Equal(t, "nyk_1", value)
+			_, err = store.Get(ports.KeyClientID)
+			assert.ErrorIs(t, err, domain.ErrSecretNotFound)
+		}
+		assert.Equal(t, 2, reads)
+		assert.Equal(t, "mock", store.Name())
+	})
+
+	t.Run("writes update the cache", func(t *testing.T) {
+		mock := keyring.NewMockSecretStore()
+		store := keyring.NewCachedStore(mock, time.Hour)
+
+		_, err := store.Get(ports.KeyAPIKey)
+		assert.ErrorIs(t, err, domain.ErrSecretNotFound)
+		require.NoError(t, store.Set(ports.KeyAPIKey, "nyk_2"))
+		value, err := store.Get(ports.KeyAPIKey)
+		require.NoError(t, err)
+		assert.Equal(t, "nyk_2", value)
+
+		require.NoError(t, store.Delete(ports.KeyAPIKey))
+		_, err = store.Get(ports.KeyAPIKey)
+		assert.ErrorIs(t, err, domain.ErrSecretNotFound)
+	})
+
+	t.Run("expired values are read again", func(t *testing.T) {
+		mock := keyring.NewMockSecretStore()
+		store := keyring.NewCachedStore(mock, 0)
+		require.NoError(t, store.Set(ports.KeyAPIKey, "nyk_old"))
+		require.NoError(t, mock.Set(ports.KeyAPIKey, "nyk_new"))
+
+		value, err := store.Get(ports.KeyAPIKey)
+		require.NoError(t, err)
+		assert.Equal(t, "nyk_new", value)
+	})
+}
+
 func TestEncryptedFileStore(t *testing.T) {
 	tmpDir := t.TempDir()
 	setFileStorePassphrase(t)
@@ -169,6 +223,12 @@ func TestEncryptedFileStore(t *testing.T) {
 		assert.Equal(t, "my-api-key-456", apiKey)
 	})
 
+	t.Run("keys lists stored secrets", func(t *testing.T) {
+		keys, err := store.Keys()
+		require.NoError(t, err)
+		assert.Equal(t, []string{ports.KeyAPIKey, ports.KeyClientID, "test-key"}, keys)
+	})
+
 	t.Run("file is created with correct permissions", func(t *testing.T) {
 		secretsPath := filepath.Join(tmpDir, ".secrets.enc")
 		info, err := os.Stat(secretsPath)
@@ -1,6 +1,7 @@
 package keyring
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/nylas/cli/internal/domain"
@@ -84,6 +85,18 @@ func (m *MockSecretStore) Reset() {
 	clear(m.secrets)
 }
 
+// Keys returns the keys of all stored secrets, sorted.
+func (m *MockSecretStore) Keys() ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := make([]string, 0, len(m.secrets))
+	for k := range m.secrets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
 // GetAll returns all stored secrets.
 func (m *MockSecretStore) GetAll() map[string]string {
 	m.mu.RLock()
@@ -0,0 +1,61 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MinPassphraseLen is the shortest passphrase SealWithPassphrase accepts.
+const MinPassphraseLen = minPassphraseLen
+
+// ErrWrongPassphrase is returned when sealed data can't be opened with the
+// given passphrase, or has been modified.
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted data")
+
+// SealWithPassphrase encrypts plaintext with AES-256-GCM under an Argon2id
+// key derived from passphrase and a fresh salt. The result is
+// "<base64 salt>.<base64 nonce+ciphertext>", safe to store as text.
+func SealWithPassphrase(passphrase, plaintext []byte) ([]byte, error) {
+	if len(passphrase) < MinPassphraseLen {
+		return nil, fmt.Errorf("passphrase must be at least %d characters", MinPassphraseLen)
+	}
+
+	salt := make([]byte, fileStoreSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	key := derivePassphraseKey(passphrase, salt)
+	defer zeroBytes(key)
+
+	sealed, err := encryptWithKey(key, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	out := []byte(base64.StdEncoding.EncodeToString(salt))
+	out = append(out, '.')
+	return append(out, sealed...), nil
+}
+
+// OpenWithPassphrase decrypts data produced by SealWithPassphrase.
+func OpenWithPassphrase(passphrase, data []byte) ([]byte, error) {
+	encodedSalt, sealed, ok := bytes.Cut(bytes.TrimSpace(data), []byte("."))
+	if !ok {
+		return nil, ErrWrongPassphrase
+	}
+	salt, err := base64.StdEncoding.DecodeString(string(encodedSalt))
+	if err != nil || len(salt) != fileStoreSaltSize {
+		return nil, ErrWrongPassphrase
+	}
+	key := derivePassphraseKey(passphrase, salt)
+	defer zeroBytes(key)
+
+	plaintext, err := decryptWithKey(key, sealed)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,364 @@
+// Package mailarchive indexes and searches exported mail archives (mbox
+// files and .eml messages) so mail deleted from the server stays searchable.
+package mailarchive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// indexVersion is bumped when the entry format changes, forcing a rebuild.
+const indexVersion = 1
+
+// archiveExtensions are the file extensions picked up when walking a
+// directory. Files named "mbox" (Apple Mail exports) are included too.
+var archiveExtensions = []string{".mbox", ".mbx", ".mbs", ".eml"}
+
+// entry is the indexed form of one archived message.
+type entry struct {
+	Offset        int64                     `json:"offset"`
+	Length        int64                     `json:"length"`
+	MessageID     string                    `json:"message_id,omitempty"`
+	Subject       string                    `json:"subject"`
+	From          []domain.EmailParticipant `json:"from,omitempty"`
+	To            []domain.EmailParticipant `json:"to,omitempty"`
+	Cc            []domain.EmailParticipant `json:"cc,omitempty"`
+	Date          time.Time                 `json:"date"`
+	Snippet       string                    `json:"snippet"`
+	Text          string                    `json:"text"` // Lowercased plain text, truncated
+	Attachments   []domain.Attachment       `json:"attachments,omitempty"`
+	HasAttachment bool                      `json:"has_attachment,omitempty"`
+}
+
+// sourceIndex is the cached index of one archive file.
+type sourceIndex struct {
+	Version int       `json:"version"`
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Entries []entry   `json:"entries"`
+}
+
+// Stats summarizes an index refresh.
+type Stats struct {
+	Sources   int `json:"sources"`
+	Reindexed int `json:"reindexed"`
+	Messages  int `json:"messages"`
+}
+
+// Index is an on-disk index of archive files, rebuilt per file whenever the
+// file's size or modification time changes.
+type Index struct {
+	dir string
+}
+
+// NewIndex creates an index stored in dir.
+func NewIndex(dir string) *Index {
+	return &Index{dir: dir}
+}
+
+// Sources expands archive paths into the archive files they contain.
+// Directories are walked recursively; a leading ~ is expanded.
+func Sources(paths []string) ([]string, error) {
+	var files []string
+	seen := map[string]bool{}
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, p := range paths {
+		p = expandHome(strings.TrimSpace(p))
+		if p == "" {
+			continue
+		}
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(abs)
+		if err != nil {
+			return nil, fmt.Errorf("archive path %s: %w", p, err)
+		}
+		if !info.IsDir() {
+			add(abs)
+			continue
+		}
+		err = filepath.WalkDir(abs, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isArchiveFile(path) {
+				add(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("archive path %s: %w", p, err)
+		}
+	}
+	return files, nil
+}
+
+func isArchiveFile(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	if base == "mbox" {
+		return true
+	}
+	ext := filepath.Ext(base)
+	for _, e := range archiveExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, p[1:])
+		}
+	}
+	return p
+}
+
+// Refresh brings the index up to date for the archives under paths.
+func (x *Index) Refresh(ctx context.Context, paths []string) (*Stats, error) {
+	_, stats, err := x.load(ctx, paths)
+	return stats, err
+}
+
+// Search returns archived messages matching q, newest first.
+func (x *Index) Search(ctx context.Context, paths []string, q domain.ArchiveQuery) ([]domain.Message, error) {
+	sources, _, err := x.load(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+	q = q.Normalized()
+
+	type hit struct {
+		src *sourceIndex
+		e   *entry
+	}
+	var hits []hit
+	for _, src := range sources {
+		for i := range src.Entries {
+			if matches(&src.Entries[i], q) {
+				hits = append(hits, hit{src, &src.Entries[i]})
+			}
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].e.Date.After(hits[j].e.Date) })
+	if q.Limit > 0 && len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+
+	messages := make([]domain.Message, 0, len(hits))
+	for _, h := range hits {
+		messages = append(messages, toMessage(h.src.Path, h.e, readBody(h.src.Path, h.e)))
+	}
+	return messages, nil
+}
+
+// load returns the index of every source, reindexing stale ones.
+func (x *Index) load(ctx context.Context, paths []string) ([]*sourceIndex, *Stats, error) {
+	files, err := Sources(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats := &Stats{Sources: len(files)}
+	sources := make([]*sourceIndex, 0, len(files))
+	for _, path := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("archive %s: %w", path, err)
+		}
+		src := x.cached(path, info)
+		if src == nil {
+			if src, err = buildSource(path, info); err != nil {
+				return nil, nil, fmt.Errorf("indexing %s: %w", path, err)
+			}
+			stats.Reindexed++
+			// A failed write only costs a rebuild next time.
+			_ = x.save(src)
+		}
+		stats.Messages += len(src.Entries)
+		sources = append(sources, src)
+	}
+	return sources, stats, nil
+}
+
+func (x *Index) indexFile(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(x.dir, hex.EncodeToString(sum[:8])+".json")
+}
+
+// cached returns the stored index for path if it is still current.
+func (x *Index) cached(path string, info os.FileInfo) *sourceIndex {
+	data, err := os.ReadFile(x.indexFile(path))
+	if err != nil {
+		return nil
+	}
+	var src sourceIndex
+	if json.Unmarshal(data, &src) != nil {
+		return nil
+	}
+	if src.Version != indexVersion || src.Path != path || src.Size != info.Size() || !src.ModTime.Equal(info.ModTime()) {
+		return nil
+	}
+	return &src
+}
+
+func (x *Index) save(src *sourceIndex) error {
+	if err := os.MkdirAll(x.dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	tmp := x.indexFile(src.Path) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, x.indexFile(src.Path))
+}
+
+func buildSource(path string, info os.FileInfo) (*sourceIndex, error) {
+	f, err := os.Open(path) // #nosec G304 -- archive paths come from the user's config
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	src := &sourceIndex{Version: indexVersion, Path: path, Size: info.Size(), ModTime: info.ModTime(), Entries: []entry{}}
+	err = splitMessages(f, func(offset, size int64, raw []byte) error {
+		msg, err := parseMessage(raw)
+		if err != nil {
+			return nil // Skip unparseable messages rather than the whole archive
+		}
+		text := msg.plainText()
+		src.Entries = append(src.Entries, entry{
+			Offset:        offset,
+			Length:        size,
+			MessageID:     msg.MessageID,
+			Subject:       msg.Subject,
+			From:          msg.From,
+			To:            msg.To,
+			Cc:            msg.Cc,
+			Date:          msg.Date,
+			Snippet:       truncateRunes(text, snippetLength),
+			Text:          strings.ToLower(truncateRunes(text, maxIndexedText)),
+			Attachments:   msg.Attachments,
+			HasAttachment: msg.HasAttachment,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// matches applies a normalized query to an entry.
+func matches(e *entry, q domain.ArchiveQuery) bool {
+	if !q.After.IsZero() && e.Date.Before(q.After) {
+		return false
+	}
+	if !q.Before.IsZero() && !e.Date.Before(q.Before) {
+		return false
+	}
+	if q.HasAttachment != nil && e.HasAttachment != *q.HasAttachment {
+		return false
+	}
+	subject := strings.ToLower(e.Subject)
+	if q.Subject != "" && !strings.Contains(subject, q.Subject) {
+		return false
+	}
+	from := participantText(e.From)
+	if q.From != "" && !strings.Contains(from, q.From) {
+		return false
+	}
+	to := participantText(e.To) + " " + participantText(e.Cc)
+	if q.To != "" && !strings.Contains(to, q.To) {
+		return false
+	}
+	if q.Text != "" {
+		return strings.Contains(subject, q.Text) || strings.Contains(from, q.Text) ||
+			strings.Contains(to, q.Text) || strings.Contains(e.Text, q.Text)
+	}
+	return true
+}
+
+func participantText(ps []domain.EmailParticipant) string {
+	parts := make([]string, 0, len(ps))
+	for _, p := range ps {
+		parts = append(parts, p.Name+" <"+p.Email+">")
+	}
+	return strings.ToLower(strings.Join(parts, ", "))
+}
+
+// readBody re-reads a message from its archive to return the full body.
+// It returns "" when the archive changed since indexing.
+func readBody(path string, e *entry) string {
+	f, err := os.Open(path) // #nosec G304 -- archive paths come from the user's config
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	var body string
+	r := io.NewSectionReader(f, e.Offset, e.Length)
+	_ = splitMessages(r, func(_, _ int64, raw []byte) error {
+		if msg, err := parseMessage(raw); err == nil && body == "" {
+			body = msg.body()
+		}
+		return nil
+	})
+	return body
+}
+
+// MessageID returns the ID used for an archived message: stable for a given
+// archive file and position.
+func MessageID(path string, offset int64) string {
+	sum := sha256.Sum256([]byte(path))
+	return fmt.Sprintf("archive:%s:%d", hex.EncodeToString(sum[:6]), offset)
+}
+
+func toMessage(path string, e *entry, body string) domain.Message {
+	if body == "" {
+		body = e.Snippet
+	}
+	return domain.Message{
+		ID:          MessageID(path, e.Offset),
+		Subject:     e.Subject,
+		From:        e.From,
+		To:          e.To,
+		Cc:          e.Cc,
+		Body:        body,
+		Snippet:     e.Snippet,
+		Date:        e.Date,
+		Folders:     []string{domain.ArchiveFolder},
+		Attachments: e.Attachments,
+		Metadata:    map[string]string{domain.ArchivePathMetadataKey: path},
+		Object:      domain.ArchiveMessageObject,
+	}
+}
@@ -0,0 +1,194 @@
+package mailarchive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+const testMbox = `From alice@example.com Mon Jan  6 10:00:00 2020
+From: Alice <alice@example.com>
+To: Bob <bob@example.com>
+Subject: Quarterly invoice
+Date: Mon, 6 Jan 2020 10:00:00 +0000
+Message-ID: <one@example.com>
+
+Please find the invoice details below.
+>From the accounting team.
+
+From carol@example.com Tue Feb  4 09:30:00 2020
+From: =?UTF-8?Q?Carol_M=C3=BCller?= <carol@example.com>
+To: bob@example.com
+Cc: dave@example.com
+Subject: =?UTF-8?B?UmU6IFBpY25pYw==?=
+Date: Tue, 4 Feb 2020 09:30:00 +0000
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="b1"
+
+--b1
+Content-Type: text/html; charset=utf-8
+Content-Transfer-Encoding: quoted-printable
+
+<p>See you at the <b>park</b> on Saturday=21</p>
+--b1
+Content-Type: application/pdf; name="map.pdf"
+Content-Disposition: attachment; filename="map.pdf"
+Content-Transfer-Encoding: base64
+
+JVBERi0xLjQK
+--b1--
+`
+
+const testEML = "From: Erin <erin@example.com>\r\nTo: bob@example.com\r\nSubject: Old contract\r\n" +
+	"Date: Wed, 1 Mar 2017 12:00:00 +0000\r\nContent-Type: text/plain; charset=iso-8859-1\r\n" +
+	"Content-Transfer-Encoding: base64\r\n\r\nU2lnbmVkIGNvbnRyYWN0IGF0dGFjaGVkLg==\r\n"
+
+func writeArchive(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestSplitMessages(t *testing.T) {
+	var offsets []int64
+	var bodies []string
+	err := splitMessages(strings.NewReader(testMbox), func(offset, size int64, raw []byte) error {
+		offsets = append(offsets, offset)
+		bodies = append(bodies, string(raw))
+		assert.True(t, strings.HasPrefix(testMbox[offset:offset+size], "From "))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, bodies, 2)
+	assert.Equal(t, int64(0), offsets[0])
+	assert.Contains(t, bodies[0], "\nFrom the accounting team.", "mboxrd quoting is undone")
+	assert.True(t, strings.HasPrefix(bodies[1], "From: =?UTF-8?Q?Carol"))
+
+	var count int
+	require.NoError(t, splitMessages(strings.NewReader(testEML), func(offset, size int64, raw []byte) error {
+		count++
+		assert.Equal(t, int64(len(testEML)), size)
+		return nil
+	}))
+	assert.Equal(t, 1, count)
+}
+
+func TestParseMessage(t *testing.T) {
+	var raws [][]byte
+	require.NoError(t, splitMessages(strings.NewReader(testMbox), func(_, _ int64, raw []byte) error {
+		raws = append(raws, raw)
+		return nil
+	}))
+
+	msg, err := parseMessage(raws[1])
+	require.NoError(t, err)
+	assert.Equal(t, "Re: Picnic", msg.Subject)
+	assert.Equal(t, []domain.EmailParticipant{{Name: "Carol Müller", Email: "carol@example.com"}}, msg.From)
+	assert.Equal(t, "dave@example.com", msg.Cc[0].Email)
+	assert.True(t, msg.HasAttachment)
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "map.pdf", msg.Attachments[0].Filename)
+	assert.Equal(t, "See you at the park on Saturday!", msg.plainText())
+
+	msg, err = parseMessage([]byte(testEML))
+	require.NoError(t, err)
+	assert.Equal(t, "Signed contract attached.", msg.plainText())
+	assert.Equal(t, 2017, msg.Date.Year())
+}
+
+func TestSources(t *testing.T) {
+	dir := t.TempDir()
+	mbox := writeArchive(t, dir, "export/2020.mbox", testMbox)
+	eml := writeArchive(t, dir, "export/old/contract.eml", testEML)
+	apple := writeArchive(t, dir, "export/Inbox.mbox/mbox", testMbox)
+	writeArchive(t, dir, "export/notes.txt", "not mail")
+
+	files, err := Sources([]string{filepath.Join(dir, "export"), mbox})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{mbox, eml, apple}, files)
+
+	_, err = Sources([]string{filepath.Join(dir, "missing.mbox")})
+	assert.Error(t, err)
+}
+
+func TestIndexSearch(t *testing.T) {
+	dir := t.TempDir()
+	archives := filepath.Join(dir, "archives")
+	mbox := writeArchive(t, archives, "mail.mbox", testMbox)
+	writeArchive(t, archives, "contract.eml", testEML)
+	idx := NewIndex(filepath.Join(dir, "index"))
+	ctx := context.Background()
+	paths := []string{archives}
+
+	all, err := idx.Search(ctx, paths, domain.ArchiveQuery{Text: "*"})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "Re: Picnic", all[0].Subject, "newest first")
+	assert.True(t, domain.IsArchivedMessage(&all[0]))
+	assert.Equal(t, mbox, domain.ArchivePath(&all[0]))
+	assert.Equal(t, []string{domain.ArchiveFolder}, all[0].Folders)
+	assert.Contains(t, all[0].Body, "<b>park</b>")
+
+	tests := []struct {
+		name  string
+		query domain.ArchiveQuery
+		want  []string
+	}{
+		{"body text", domain.ArchiveQuery{Text: "ACCOUNTING"}, []string{"Quarterly invoice"}},
+		{"html body", domain.ArchiveQuery{Text: "saturday"}, []string{"Re: Picnic"}},
+		{"sender name", domain.ArchiveQuery{Text: "müller"}, []string{"Re: Picnic"}},
+		{"from", domain.ArchiveQuery{From: "erin@"}, []string{"Old contract"}},
+		{"cc counts as to", domain.ArchiveQuery{To: "dave"}, []string{"Re: Picnic"}},
+		{"subject", domain.ArchiveQuery{Subject: "invoice"}, []string{"Quarterly invoice"}},
+		{"date range", domain.ArchiveQuery{
+			After:  time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			Before: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		}, []string{"Quarterly invoice"}},
+		{"attachments", domain.ArchiveQuery{HasAttachment: boolPtr(true)}, []string{"Re: Picnic"}},
+		{"limit", domain.ArchiveQuery{Limit: 1}, []string{"Re: Picnic"}},
+		{"no match", domain.ArchiveQuery{Text: "zebra"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msgs, err := idx.Search(ctx, paths, tt.query)
+			require.NoError(t, err)
+			var got []string
+			for _, m := range msgs {
+				got = append(got, m.Subject)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIndexRefresh_ReindexesChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	mbox := writeArchive(t, dir, "mail.mbox", testMbox)
+	idx := NewIndex(filepath.Join(dir, "index"))
+	ctx := context.Background()
+
+	stats, err := idx.Refresh(ctx, []string{mbox})
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Sources: 1, Reindexed: 1, Messages: 2}, *stats)
+
+	stats, err = idx.Refresh(ctx, []string{mbox})
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Reindexed, "unchanged file uses the stored index")
+
+	require.NoError(t, os.WriteFile(mbox, []byte(testMbox+"\nFrom x@example.com Sat Jan  1 00:00:00 2022\nSubject: New\n\nhi\n"), 0o600))
+	stats, err = idx.Refresh(ctx, []string{mbox})
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Sources: 1, Reindexed: 1, Messages: 3}, *stats)
+}
+
+func boolPtr(b bool) *bool { return &b }
@@ -0,0 +1,284 @@
+package mailarchive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Limits on what the index keeps per message.
+const (
+	maxIndexedText = 16 << 10
+	snippetLength  = 200
+	maxPartDepth   = 10
+)
+
+var (
+	tagRe        = regexp.MustCompile(`(?s)<(?:style|script|head)[^>]*>.*?</(?:style|script|head)>|<[^>]*>`)
+	spaceRe      = regexp.MustCompile(`\s+`)
+	wordDecoder  = &mime.WordDecoder{CharsetReader: charsetReader}
+	addrParser   = &mail.AddressParser{WordDecoder: wordDecoder}
+	mboxFromLine = []byte("From ")
+)
+
+// splitMessages calls fn with each message in r and the offset and length
+// of the bytes it was read from. A file
+// starting with an mbox "From " line is split mbox-style (mboxrd ">From "
+// quoting is undone); anything else is a single RFC 822 message.
+func splitMessages(r io.Reader, fn func(offset, size int64, raw []byte) error) error {
+	br := bufio.NewReaderSize(r, 64<<10)
+	head, _ := br.Peek(len(mboxFromLine))
+	if !bytes.Equal(head, mboxFromLine) {
+		raw, err := io.ReadAll(br)
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(raw)) == 0 {
+			return nil
+		}
+		return fn(0, int64(len(raw)), raw)
+	}
+
+	var (
+		pos, start int64
+		msg        bytes.Buffer
+		inMessage  bool
+		prevBlank  = true
+	)
+	flush := func() error {
+		if !inMessage {
+			return nil
+		}
+		raw := bytes.TrimRight(msg.Bytes(), "\r\n")
+		msg.Reset()
+		return fn(start, pos-start, append(raw, '\n'))
+	}
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if prevBlank && bytes.HasPrefix(line, mboxFromLine) {
+				if ferr := flush(); ferr != nil {
+					return ferr
+				}
+				inMessage, start = true, pos
+			} else if inMessage {
+				msg.Write(unquoteFromLine(line))
+			}
+			prevBlank = len(bytes.TrimRight(line, "\r\n")) == 0
+			pos += int64(len(line))
+		}
+		if err == io.EOF {
+			return flush()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// unquoteFromLine removes one ">" from mboxrd-quoted ">From " lines.
+func unquoteFromLine(line []byte) []byte {
+	trimmed := bytes.TrimLeft(line, ">")
+	if len(trimmed) < len(line) && bytes.HasPrefix(trimmed, mboxFromLine) {
+		return line[1:]
+	}
+	return line
+}
+
+// parsedMessage is a message read from an archive.
+type parsedMessage struct {
+	MessageID     string
+	Subject       string
+	From          []domain.EmailParticipant
+	To            []domain.EmailParticipant
+	Cc            []domain.EmailParticipant
+	Date          time.Time
+	TextBody      string
+	HTMLBody      string
+	Attachments   []domain.Attachment
+	HasAttachment bool
+}
+
+// parseMessage parses a raw RFC 822 message. Malformed bodies are kept
+// as far as they could be read.
+func parseMessage(raw []byte) (*parsedMessage, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	msg := &parsedMessage{
+		MessageID: strings.Trim(strings.TrimSpace(m.Header.Get("Message-Id")), "<>"),
+		Subject:   decodeHeader(m.Header.Get("Subject")),
+		From:      parseAddresses(m.Header.Get("From")),
+		To:        parseAddresses(m.Header.Get("To")),
+		Cc:        parseAddresses(m.Header.Get("Cc")),
+	}
+	if date, err := m.Header.Date(); err == nil {
+		msg.Date = date
+	}
+	walkPart(msg, m.Header, m.Body, 0)
+	return msg, nil
+}
+
+// partHeader is satisfied by mail.Header and textproto.MIMEHeader.
+type partHeader interface{ Get(string) string }
+
+// walkPart collects text and HTML bodies and attachment metadata.
+func walkPart(msg *parsedMessage, h partHeader, body io.Reader, depth int) {
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") && depth < maxPartDepth {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err != nil {
+				return
+			}
+			walkPart(msg, part.Header, part, depth+1)
+		}
+	}
+
+	disposition, dparams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := decodeHeader(dparams["filename"])
+	if filename == "" {
+		filename = decodeHeader(params["name"])
+	}
+	if disposition == "attachment" || (filename != "" && !strings.HasPrefix(mediaType, "text/")) {
+		msg.HasAttachment = true
+		msg.Attachments = append(msg.Attachments, domain.Attachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			IsInline:    disposition == "inline",
+		})
+		return
+	}
+
+	switch mediaType {
+	case "text/plain", "text/html":
+		data, _ := io.ReadAll(io.LimitReader(decodeTransfer(h.Get("Content-Transfer-Encoding"), body), 4<<20))
+		text := toUTF8(data, params["charset"])
+		if mediaType == "text/html" {
+			msg.HTMLBody += text
+		} else {
+			msg.TextBody += text
+		}
+	}
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &newlineStripper{r: r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+	return r
+}
+
+// newlineStripper drops CR and LF so base64 line breaks don't break decoding.
+type newlineStripper struct{ r io.Reader }
+
+func (n *newlineStripper) Read(p []byte) (int, error) {
+	for {
+		c, err := n.r.Read(p)
+		j := 0
+		for _, b := range p[:c] {
+			if b != '\r' && b != '\n' {
+				p[j] = b
+				j++
+			}
+		}
+		if j > 0 || err != nil {
+			return j, err
+		}
+	}
+}
+
+// toUTF8 converts ISO-8859-1 bodies and drops invalid bytes from others.
+func toUTF8(data []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252", "us-ascii":
+		if !utf8.Valid(data) {
+			runes := make([]rune, len(data))
+			for i, b := range data {
+				runes[i] = rune(b)
+			}
+			return string(runes)
+		}
+	}
+	return strings.ToValidUTF8(string(data), "")
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(toUTF8(data, charset)), nil
+}
+
+func decodeHeader(s string) string {
+	if decoded, err := wordDecoder.DecodeHeader(s); err == nil {
+		return strings.TrimSpace(decoded)
+	}
+	return strings.TrimSpace(s)
+}
+
+func parseAddresses(s string) []domain.EmailParticipant {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	addrs, err := addrParser.ParseList(s)
+	if err != nil {
+		return []domain.EmailParticipant{{Email: decodeHeader(s)}}
+	}
+	out := make([]domain.EmailParticipant, 0, len(addrs))
+	for _, a := range addrs {
+		out = append(out, domain.EmailParticipant{Name: a.Name, Email: a.Address})
+	}
+	return out
+}
+
+// plainText returns the message's searchable text: the text body, or the
+// HTML body with tags removed.
+func (m *parsedMessage) plainText() string {
+	text := m.TextBody
+	if strings.TrimSpace(text) == "" {
+		text = html.UnescapeString(tagRe.ReplaceAllString(m.HTMLBody, " "))
+	}
+	return strings.TrimSpace(spaceRe.ReplaceAllString(text, " "))
+}
+
+// body returns the HTML body when present, as the Nylas API does.
+func (m *parsedMessage) body() string {
+	if m.HTMLBody != "" {
+		return m.HTMLBody
+	}
+	return m.TextBody
+}
+
+func truncateRunes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[:n]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
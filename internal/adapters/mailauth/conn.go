@@ -0,0 +1,104 @@
+package mailauth
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Tester implements ports.MailAuthTester over plain TCP/TLS connections.
+type Tester struct {
+	dialer *net.Dialer
+}
+
+// NewTester creates a tester with a conservative connect timeout.
+func NewTester() *Tester {
+	return &Tester{dialer: &net.Dialer{Timeout: 15 * time.Second}}
+}
+
+var _ ports.MailAuthTester = (*Tester)(nil)
+
+// conn is a line-oriented protocol connection that records a transcript.
+type conn struct {
+	net.Conn
+	r      *bufio.Reader
+	result *domain.MailAuthResult
+}
+
+func (t *Tester) dial(ctx context.Context, opts domain.MailAuthOptions, result *domain.MailAuthResult) (*conn, error) {
+	addr := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+
+	raw, err := t.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = raw.SetDeadline(deadline)
+	}
+
+	c := &conn{Conn: raw, r: bufio.NewReader(raw), result: result}
+	if opts.TLSMode == domain.MailTLSImplicit {
+		if err := c.upgradeTLS(opts); err != nil {
+			_ = raw.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *conn) upgradeTLS(opts domain.MailAuthOptions) error {
+	tlsConn := tls.Client(c.Conn, &tls.Config{
+		ServerName:         opts.Host,
+		InsecureSkipVerify: opts.InsecureSkipVerify, // #nosec G402 -- opt-in for self-signed test servers
+		MinVersion:         tls.VersionTLS12,
+	})
+	if err := tlsConn.Handshake(); err != nil {
+		return fmt.Errorf("TLS handshake with %s failed: %w", opts.Host, err)
+	}
+	c.Conn = tlsConn
+	c.r = bufio.NewReader(tlsConn)
+	return nil
+}
+
+// send writes a line, recording display (which may hide secrets) rather than
+// the wire text.
+func (c *conn) send(line, display, decoded string) error {
+	c.result.Transcript = append(c.result.Transcript, domain.SASLExchangeLine{
+		Direction: "C",
+		Line:      display,
+		Decoded:   decoded,
+	})
+	_, err := fmt.Fprintf(c.Conn, "%s\r\n", line)
+	return err
+}
+
+func (c *conn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("connection closed while reading response: %w", err)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	c.result.Transcript = append(c.result.Transcript, domain.SASLExchangeLine{Direction: "S", Line: line})
+	return line, nil
+}
+
+// annotateLast attaches a decoded payload to the most recent server line.
+func (c *conn) annotateLast(decoded string) {
+	if n := len(c.result.Transcript); n > 0 && decoded != "" {
+		c.result.Transcript[n-1].Decoded = decoded
+	}
+}
+
+// authDisplay returns the transcript form of an AUTH line with the base64
+// payload replaced, since it embeds the bearer token.
+func authDisplay(prefix string) string {
+	return prefix + " " + redacted
+}
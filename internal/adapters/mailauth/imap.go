@@ -0,0 +1,181 @@
+package mailauth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// TestIMAP authenticates to an IMAP server with a bearer token.
+func (t *Tester) TestIMAP(ctx context.Context, opts domain.MailAuthOptions) (*domain.MailAuthResult, error) {
+	result := &domain.MailAuthResult{
+		Protocol:  "imap",
+		Server:    net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)),
+		Mechanism: opts.Mechanism,
+	}
+
+	c, err := t.dial(ctx, opts, result)
+	if err != nil {
+		return result, err
+	}
+	defer func() { _ = c.Close() }()
+
+	if err := imapLogin(c, opts); err != nil {
+		return result, err
+	}
+
+	if result.Authenticated {
+		_ = c.send("a9 LOGOUT", "a9 LOGOUT", "")
+		_, _ = c.readLine()
+	}
+	return result, nil
+}
+
+// imapLogin reads the greeting, negotiates STARTTLS when requested, and
+// authenticates. A rejected login is recorded on c.result, not returned.
+func imapLogin(c *conn, opts domain.MailAuthOptions) error {
+	greeting, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(greeting, "* OK") {
+		return fmt.Errorf("unexpected IMAP greeting: %s", greeting)
+	}
+
+	caps, err := imapCapabilities(c, "a1")
+	if err != nil {
+		return err
+	}
+
+	if opts.TLSMode == domain.MailTLSStartTLS {
+		if !slices.Contains(caps, "STARTTLS") {
+			return fmt.Errorf("server does not advertise STARTTLS")
+		}
+		if _, err := imapCommand(c, "a2", "STARTTLS"); err != nil {
+			return err
+		}
+		if err := c.upgradeTLS(opts); err != nil {
+			return err
+		}
+		// Capabilities must be re-read after TLS; AUTH= often only appears now.
+		if caps, err = imapCapabilities(c, "a3"); err != nil {
+			return err
+		}
+	}
+	c.result.Capabilities = caps
+
+	if !slices.Contains(caps, "AUTH="+string(opts.Mechanism)) {
+		return fmt.Errorf("server does not advertise AUTH=%s", opts.Mechanism)
+	}
+
+	return imapAuthenticate(c, opts, slices.Contains(caps, "SASL-IR"))
+}
+
+// imapAuthenticate runs AUTHENTICATE and records success or the decoded
+// error challenge on c.result.
+func imapAuthenticate(c *conn, opts domain.MailAuthOptions, saslIR bool) error {
+	payload, err := initialResponse(opts)
+	if err != nil {
+		return err
+	}
+
+	const tag = "a5"
+	cmd := tag + " AUTHENTICATE " + string(opts.Mechanism)
+	if saslIR {
+		if err := c.send(cmd+" "+payload, authDisplay(cmd), describeInitialResponse(opts)); err != nil {
+			return err
+		}
+	} else {
+		if err := c.send(cmd, cmd, ""); err != nil {
+			return err
+		}
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(line, "+") {
+			return imapStatus(c.result, tag, line)
+		}
+		if err := c.send(payload, redacted, describeInitialResponse(opts)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(line, "+") {
+			// Error challenge: decode it, then let the server finish with NO.
+			decoded := decodeChallenge(strings.TrimPrefix(line, "+"))
+			c.annotateLast(decoded)
+			c.result.ServerError = decoded
+			abort := abortResponse(opts.Mechanism)
+			if err := c.send(abort, abort, ""); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasPrefix(line, tag+" ") {
+			return imapStatus(c.result, tag, line)
+		}
+	}
+}
+
+// imapStatus interprets a tagged completion line.
+func imapStatus(result *domain.MailAuthResult, tag, line string) error {
+	status := strings.TrimPrefix(line, tag+" ")
+	if strings.HasPrefix(status, "OK") {
+		result.Authenticated = true
+		return nil
+	}
+	if result.ServerError == "" {
+		result.ServerError = status
+	}
+	return nil
+}
+
+// imapCapabilities issues CAPABILITY and returns the advertised list.
+func imapCapabilities(c *conn, tag string) ([]string, error) {
+	lines, err := imapCommand(c, tag, "CAPABILITY")
+	if err != nil {
+		return nil, err
+	}
+	var caps []string
+	for _, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "* CAPABILITY "); ok {
+			for _, cap := range strings.Fields(rest) {
+				caps = append(caps, strings.ToUpper(cap))
+			}
+		}
+	}
+	return caps, nil
+}
+
+// imapCommand sends a tagged command and returns untagged lines until the
+// tagged completion, failing on NO/BAD.
+func imapCommand(c *conn, tag, command string) ([]string, error) {
+	if err := c.send(tag+" "+command, tag+" "+command, ""); err != nil {
+		return nil, err
+	}
+	var untagged []string
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if rest, ok := strings.CutPrefix(line, tag+" "); ok {
+			if !strings.HasPrefix(rest, "OK") {
+				return nil, fmt.Errorf("%s failed: %s", command, rest)
+			}
+			return untagged, nil
+		}
+		untagged = append(untagged, line)
+	}
+}
@@ -0,0 +1,204 @@
+package mailauth
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialResponse(t *testing.T) {
+	opts := domain.MailAuthOptions{Host: "imap.example.com", Port: 993, Username: "me@example.com", Token: "tok"}
+
+	opts.Mechanism = domain.SASLXOAuth2
+	got, err := initialResponse(opts)
+	require.NoError(t, err)
+	decoded, _ := base64.StdEncoding.DecodeString(got)
+	assert.Equal(t, "user=me@example.com\x01auth=Bearer tok\x01\x01", string(decoded))
+
+	opts.Mechanism = domain.SASLOAuthBearer
+	got, err = initialResponse(opts)
+	require.NoError(t, err)
+	decoded, _ = base64.StdEncoding.DecodeString(got)
+	assert.Equal(t, "n,a=me@example.com,\x01host=imap.example.com\x01port=993\x01auth=Bearer tok\x01\x01", string(decoded))
+
+	opts.Mechanism = domain.SASLPlain
+	got, err = initialResponse(opts)
+	require.NoError(t, err)
+	decoded, _ = base64.StdEncoding.DecodeString(got)
+	assert.Equal(t, "\x00me@example.com\x00tok", string(decoded))
+	assert.Equal(t, "^@me@example.com^@<redacted>", describeInitialResponse(opts))
+
+	opts.Mechanism = "CRAM-MD5"
+	_, err = initialResponse(opts)
+	assert.Error(t, err)
+}
+
+// The transcript is meant to be pasted into bug reports, so the token must
+// never appear in it.
+func TestDescribeInitialResponse_RedactsToken(t *testing.T) {
+	opts := domain.MailAuthOptions{Username: "me@example.com", Token: "secret-token", Mechanism: domain.SASLXOAuth2}
+	desc := describeInitialResponse(opts)
+
+	assert.NotContains(t, desc, "secret-token")
+	assert.Equal(t, "user=me@example.com^Aauth=Bearer <redacted>^A^A", desc)
+}
+
+func TestDecodeChallenge(t *testing.T) {
+	challenge := base64.StdEncoding.EncodeToString([]byte(`{"status":"401","schemes":"Bearer"}`))
+	assert.Equal(t, `{"schemes":"Bearer","status":"401"}`, decodeChallenge(challenge))
+	assert.Equal(t, "", decodeChallenge("not base64!"))
+}
+
+// fakeServer accepts one connection and answers each client line with the
+// scripted replies in order. Replies keyed by "" are sent on connect.
+func fakeServer(t *testing.T, script func(line string) []string, greeting string) (string, int) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		_, _ = conn.Write([]byte(greeting + "\r\n"))
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			for _, reply := range script(strings.TrimRight(line, "\r\n")) {
+				_, _ = conn.Write([]byte(reply + "\r\n"))
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p
+}
+
+func testOpts(host string, port int, mech domain.SASLMechanism) domain.MailAuthOptions {
+	return domain.MailAuthOptions{
+		Host: host, Port: port, Username: "me@example.com", Token: "secret-token",
+		Mechanism: mech, TLSMode: domain.MailTLSNone,
+	}
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestTestIMAP_Success(t *testing.T) {
+	host, port := fakeServer(t, func(line string) []string {
+		switch {
+		case strings.HasPrefix(line, "a1 CAPABILITY"):
+			return []string{"* CAPABILITY IMAP4rev1 SASL-IR AUTH=XOAUTH2", "a1 OK done"}
+		case strings.HasPrefix(line, "a5 AUTHENTICATE XOAUTH2 "):
+			return []string{"a5 OK authenticated"}
+		case strings.HasPrefix(line, "a9 LOGOUT"):
+			return []string{"* BYE", "a9 OK"}
+		}
+		return []string{"* BAD"}
+	}, "* OK ready")
+
+	result, err := NewTester().TestIMAP(testContext(t), testOpts(host, port, domain.SASLXOAuth2))
+	require.NoError(t, err)
+
+	assert.True(t, result.Authenticated)
+	assert.Contains(t, result.Capabilities, "AUTH=XOAUTH2")
+	for _, l := range result.Transcript {
+		assert.NotContains(t, l.Line, "secret-token")
+		assert.NotContains(t, l.Line, base64.StdEncoding.EncodeToString([]byte("user=me@example.com")))
+	}
+}
+
+func TestTestIMAP_DecodesErrorChallenge(t *testing.T) {
+	errJSON := base64.StdEncoding.EncodeToString([]byte(`{"status":"400"}`))
+	host, port := fakeServer(t, func(line string) []string {
+		switch {
+		case strings.HasPrefix(line, "a1 CAPABILITY"):
+			return []string{"* CAPABILITY IMAP4rev1 AUTH=OAUTHBEARER", "a1 OK"}
+		case line == "a5 AUTHENTICATE OAUTHBEARER":
+			return []string{"+ "}
+		case line == "AQ==":
+			return []string{"a5 NO [AUTHENTICATIONFAILED] invalid"}
+		case strings.HasPrefix(line, "a5") || line == "":
+			return nil
+		}
+		// The initial response (no SASL-IR) is answered with an error challenge.
+		return []string{"+ " + errJSON}
+	}, "* OK ready")
+
+	result, err := NewTester().TestIMAP(testContext(t), testOpts(host, port, domain.SASLOAuthBearer))
+	require.NoError(t, err)
+
+	assert.False(t, result.Authenticated)
+	assert.Equal(t, `{"status":"400"}`, result.ServerError)
+}
+
+func TestTestIMAP_MissingMechanism(t *testing.T) {
+	host, port := fakeServer(t, func(line string) []string {
+		return []string{"* CAPABILITY IMAP4rev1 AUTH=PLAIN", "a1 OK"}
+	}, "* OK ready")
+
+	_, err := NewTester().TestIMAP(testContext(t), testOpts(host, port, domain.SASLXOAuth2))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AUTH=XOAUTH2")
+}
+
+func TestTestSMTP_Success(t *testing.T) {
+	host, port := fakeServer(t, func(line string) []string {
+		switch {
+		case strings.HasPrefix(line, "EHLO"):
+			return []string{"250-smtp.example.com", "250-SIZE 1000", "250 AUTH LOGIN XOAUTH2"}
+		case strings.HasPrefix(line, "AUTH XOAUTH2 "):
+			return []string{"235 2.7.0 Accepted"}
+		case line == "QUIT":
+			return []string{"221 bye"}
+		}
+		return []string{"500 what"}
+	}, "220 smtp.example.com ESMTP")
+
+	result, err := NewTester().TestSMTP(testContext(t), testOpts(host, port, domain.SASLXOAuth2))
+	require.NoError(t, err)
+
+	assert.True(t, result.Authenticated)
+	assert.Equal(t, []string{"SIZE 1000", "AUTH LOGIN XOAUTH2"}, result.Capabilities)
+}
+
+func TestTestSMTP_ErrorChallenge(t *testing.T) {
+	errJSON := base64.StdEncoding.EncodeToString([]byte(`{"status":"401"}`))
+	host, port := fakeServer(t, func(line string) []string {
+		switch {
+		case strings.HasPrefix(line, "EHLO"):
+			return []string{"250-smtp.example.com", "250 AUTH XOAUTH2"}
+		case strings.HasPrefix(line, "AUTH XOAUTH2 "):
+			return []string{"334 " + errJSON}
+		case line == "":
+			return []string{"535 5.7.8 Username and Password not accepted"}
+		}
+		return []string{"500 what"}
+	}, "220 smtp.example.com ESMTP")
+
+	result, err := NewTester().TestSMTP(testContext(t), testOpts(host, port, domain.SASLXOAuth2))
+	require.NoError(t, err)
+
+	assert.False(t, result.Authenticated)
+	assert.Equal(t, `{"status":"401"}`, result.ServerError)
+}
@@ -0,0 +1,92 @@
+// Package mailauth implements SASL probes against IMAP and SMTP servers for
+// debugging OAuth provider configuration and checking password logins.
+package mailauth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+const redacted = "<redacted>"
+
+// initialResponse builds the client's first SASL message for the mechanism,
+// base64-encoded as sent on the wire.
+func initialResponse(opts domain.MailAuthOptions) (string, error) {
+	raw, err := rawInitialResponse(opts, opts.Token)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString([]byte(raw)), nil
+}
+
+// rawInitialResponse builds the unencoded SASL message with the given token.
+//
+// XOAUTH2:     user={user}^Aauth=Bearer {token}^A^A
+// OAUTHBEARER: n,a={user},^Ahost={host}^Aport={port}^Aauth=Bearer {token}^A^A (RFC 7628)
+// PLAIN:       ^@{user}^@{password} (RFC 4616)
+func rawInitialResponse(opts domain.MailAuthOptions, token string) (string, error) {
+	switch opts.Mechanism {
+	case domain.SASLXOAuth2:
+		return "user=" + opts.Username + "\x01auth=Bearer " + token + "\x01\x01", nil
+	case domain.SASLOAuthBearer:
+		return "n,a=" + saslName(opts.Username) + ",\x01host=" + opts.Host +
+			"\x01port=" + strconv.Itoa(opts.Port) +
+			"\x01auth=Bearer " + token + "\x01\x01", nil
+	case domain.SASLPlain:
+		return "\x00" + opts.Username + "\x00" + token, nil
+	default:
+		return "", fmt.Errorf("unsupported SASL mechanism: %s", opts.Mechanism)
+	}
+}
+
+// describeInitialResponse renders the initial response for the transcript
+// with the token redacted and control-A and NUL separators made visible.
+func describeInitialResponse(opts domain.MailAuthOptions) string {
+	raw, err := rawInitialResponse(opts, redacted)
+	if err != nil {
+		return ""
+	}
+	return strings.NewReplacer("\x01", "^A", "\x00", "^@").Replace(raw)
+}
+
+// decodeChallenge decodes a base64 server challenge. Providers send a JSON
+// error document here when a bearer token is rejected.
+func decodeChallenge(challenge string) string {
+	challenge = strings.TrimSpace(challenge)
+	if challenge == "" {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(challenge)
+	if err != nil {
+		return ""
+	}
+
+	var doc map[string]any
+	if json.Unmarshal(decoded, &doc) == nil {
+		if pretty, err := json.Marshal(doc); err == nil {
+			return string(pretty)
+		}
+	}
+	return string(decoded)
+}
+
+// abortResponse is what the client sends after an error challenge to let the
+// server finish the exchange. RFC 7628 requires a single ^A for OAUTHBEARER;
+// XOAUTH2 expects an empty response.
+func abortResponse(mech domain.SASLMechanism) string {
+	if mech == domain.SASLOAuthBearer {
+		return base64.StdEncoding.EncodeToString([]byte("\x01"))
+	}
+	return ""
+}
+
+// saslName escapes ',' and '=' in the authzid per RFC 5801.
+func saslName(s string) string {
+	s = strings.ReplaceAll(s, "=", "=3D")
+	return strings.ReplaceAll(s, ",", "=2C")
+}
@@ -0,0 +1,168 @@
+package mailauth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// TestSMTP authenticates to an SMTP server with a bearer token.
+func (t *Tester) TestSMTP(ctx context.Context, opts domain.MailAuthOptions) (*domain.MailAuthResult, error) {
+	result := &domain.MailAuthResult{
+		Protocol:  "smtp",
+		Server:    net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)),
+		Mechanism: opts.Mechanism,
+	}
+
+	c, err := t.dial(ctx, opts, result)
+	if err != nil {
+		return result, err
+	}
+	defer func() { _ = c.Close() }()
+
+	if code, _, err := smtpReply(c); err != nil {
+		return result, err
+	} else if code != 220 {
+		return result, fmt.Errorf("unexpected SMTP greeting code %d", code)
+	}
+
+	exts, err := smtpEHLO(c)
+	if err != nil {
+		return result, err
+	}
+
+	if opts.TLSMode == domain.MailTLSStartTLS {
+		if !slices.Contains(exts, "STARTTLS") {
+			return result, fmt.Errorf("server does not advertise STARTTLS")
+		}
+		if err := smtpExpect(c, "STARTTLS", 220); err != nil {
+			return result, err
+		}
+		if err := c.upgradeTLS(opts); err != nil {
+			return result, err
+		}
+		if exts, err = smtpEHLO(c); err != nil {
+			return result, err
+		}
+	}
+	result.Capabilities = exts
+
+	if !smtpSupportsAuth(exts, opts.Mechanism) {
+		return result, fmt.Errorf("server does not advertise AUTH %s", opts.Mechanism)
+	}
+
+	payload, err := initialResponse(opts)
+	if err != nil {
+		return result, err
+	}
+	cmd := "AUTH " + string(opts.Mechanism)
+	if err := c.send(cmd+" "+payload, authDisplay(cmd), describeInitialResponse(opts)); err != nil {
+		return result, err
+	}
+
+	code, text, err := smtpReply(c)
+	if err != nil {
+		return result, err
+	}
+	if code == 334 {
+		// Error challenge: decode it, then let the server finish with 5xx.
+		decoded := decodeChallenge(text)
+		c.annotateLast(decoded)
+		result.ServerError = decoded
+		abort := abortResponse(opts.Mechanism)
+		if err := c.send(abort, abort, ""); err != nil {
+			return result, err
+		}
+		if code, text, err = smtpReply(c); err != nil {
+			return result, err
+		}
+	}
+
+	if code == 235 {
+		result.Authenticated = true
+		_ = c.send("QUIT", "QUIT", "")
+		_, _, _ = smtpReply(c)
+	} else if result.ServerError == "" {
+		result.ServerError = fmt.Sprintf("%d %s", code, text)
+	}
+	return result, nil
+}
+
+// smtpEHLO sends EHLO and returns the advertised extensions (upper-cased).
+func smtpEHLO(c *conn) ([]string, error) {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		name = "localhost"
+	}
+	if err := c.send("EHLO "+name, "EHLO "+name, ""); err != nil {
+		return nil, err
+	}
+
+	var exts []string
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if len(line) < 4 || !strings.HasPrefix(line, "250") {
+			return nil, fmt.Errorf("EHLO failed: %s", line)
+		}
+		exts = append(exts, strings.ToUpper(line[4:]))
+		if line[3] == ' ' {
+			// The first line is the server greeting, not an extension.
+			return exts[1:], nil
+		}
+	}
+}
+
+func smtpSupportsAuth(exts []string, mech domain.SASLMechanism) bool {
+	for _, ext := range exts {
+		if rest, ok := strings.CutPrefix(ext, "AUTH "); ok {
+			if slices.Contains(strings.Fields(rest), string(mech)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func smtpExpect(c *conn, command string, want int) error {
+	if err := c.send(command, command, ""); err != nil {
+		return err
+	}
+	code, text, err := smtpReply(c)
+	if err != nil {
+		return err
+	}
+	if code != want {
+		return fmt.Errorf("%s failed: %d %s", command, code, text)
+	}
+	return nil
+}
+
+// smtpReply reads a (possibly multi-line) reply and returns its code and the
+// text of the final line.
+func smtpReply(c *conn) (int, string, error) {
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return 0, "", err
+		}
+		if len(line) < 3 {
+			return 0, "", fmt.Errorf("malformed SMTP reply: %q", line)
+		}
+		code, err := strconv.Atoi(line[:3])
+		if err != nil {
+			return 0, "", fmt.Errorf("malformed SMTP reply: %q", line)
+		}
+		if len(line) == 3 || line[3] == ' ' {
+			return code, strings.TrimSpace(line[min(4, len(line)):]), nil
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package mailauth
+
+import (
+	"encoding/base64"
+	"strings"
+	"unicode/utf16"
+)
+
+// IMAP mailbox names use modified UTF-7 (RFC 3501 section 5.1.3): printable
+// ASCII stands for itself, "&" is written "&-", and everything else is
+// UTF-16BE in base64 (with "," for "/") between "&" and "-".
+var mutf7 = base64.NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+,").WithPadding(base64.NoPadding)
+
+// encodeMailboxName converts a UTF-8 mailbox name to modified UTF-7.
+func encodeMailboxName(name string) string {
+	var b strings.Builder
+	var pending []rune
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		units := utf16.Encode(pending)
+		buf := make([]byte, 0, len(units)*2)
+		for _, u := range units {
+			buf = append(buf, byte(u>>8), byte(u))
+		}
+		b.WriteByte('&')
+		b.WriteString(mutf7.EncodeToString(buf))
+		b.WriteByte('-')
+		pending = pending[:0]
+	}
+	for _, r := range name {
+		if r >= 0x20 && r <= 0x7e {
+			flush()
+			if r == '&' {
+				b.WriteString("&-")
+			} else {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		pending = append(pending, r)
+	}
+	flush()
+	return b.String()
+}
+
+// decodeMailboxName converts a modified UTF-7 mailbox name to UTF-8.
+// Malformed shifts are kept verbatim.
+func decodeMailboxName(name string) string {
+	if !strings.Contains(name, "&") {
+		return name
+	}
+	var b strings.Builder
+	for {
+		before, after, found := strings.Cut(name, "&")
+		b.WriteString(before)
+		if !found {
+			return b.String()
+		}
+		encoded, rest, closed := strings.Cut(after, "-")
+		if !closed {
+			b.WriteString("&" + after)
+			return b.String()
+		}
+		name = rest
+		if encoded == "" {
+			b.WriteByte('&')
+			continue
+		}
+		buf, err := mutf7.DecodeString(encoded)
+		if err != nil || len(buf)%2 != 0 {
+			b.WriteString("&" + encoded + "-")
+			continue
+		}
+		units := make([]uint16, len(buf)/2)
+		for i := range units {
+			units[i] = uint16(buf[2*i])<<8 | uint16(buf[2*i+1])
+		}
+		b.WriteString(string(utf16.Decode(units)))
+	}
+}
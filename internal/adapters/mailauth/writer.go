@@ -0,0 +1,207 @@
+package mailauth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// imapCommandTimeout bounds each command of a writer session, which has no
+// overall deadline.
+const imapCommandTimeout = 2 * time.Minute
+
+// IMAPWriter appends messages to mailboxes over an authenticated IMAP
+// connection.
+type IMAPWriter struct {
+	c   *conn
+	seq int
+}
+
+var _ ports.MailboxWriter = (*IMAPWriter)(nil)
+
+// OpenMailboxWriter connects and authenticates to an IMAP server for
+// writing. The session transcript is not kept.
+func (t *Tester) OpenMailboxWriter(ctx context.Context, opts domain.MailAuthOptions) (*IMAPWriter, error) {
+	result := &domain.MailAuthResult{
+		Protocol:  "imap",
+		Server:    net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port)),
+		Mechanism: opts.Mechanism,
+	}
+	c, err := t.dial(ctx, opts, result)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.SetDeadline(time.Now().Add(imapCommandTimeout))
+
+	if err := imapLogin(c, opts); err != nil {
+		_ = c.Close()
+		return nil, err
+	}
+	if !result.Authenticated {
+		_ = c.Close()
+		return nil, fmt.Errorf("IMAP authentication to %s failed: %s", result.Server, result.ServerError)
+	}
+	return &IMAPWriter{c: c}, nil
+}
+
+// Mailboxes lists all mailboxes with LIST "" "*".
+func (w *IMAPWriter) Mailboxes(ctx context.Context) ([]domain.IMAPMailbox, error) {
+	lines, err := w.command(ctx, `LIST "" "*"`)
+	if err != nil {
+		return nil, err
+	}
+	return parseListResponse(lines), nil
+}
+
+// CreateMailbox creates name, treating "already exists" as success.
+func (w *IMAPWriter) CreateMailbox(ctx context.Context, name string) error {
+	_, err := w.command(ctx, "CREATE "+imapQuote(encodeMailboxName(name)))
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "exists") {
+		return nil
+	}
+	return err
+}
+
+// Append stores msg in mailbox with a synchronizing literal. Bare LF line
+// endings are converted to CRLF as IMAP requires.
+func (w *IMAPWriter) Append(ctx context.Context, mailbox string, msg domain.AppendMessage) error {
+	if err := w.begin(ctx); err != nil {
+		return err
+	}
+	raw := bytes.ReplaceAll(bytes.ReplaceAll(msg.Raw, []byte("\r\n"), []byte("\n")), []byte("\n"), []byte("\r\n"))
+
+	tag := w.nextTag()
+	command := "APPEND " + imapQuote(encodeMailboxName(mailbox))
+	if len(msg.Flags) > 0 {
+		command += " (" + strings.Join(msg.Flags, " ") + ")"
+	}
+	if !msg.Date.IsZero() {
+		command += ` "` + msg.Date.Format("02-Jan-2006 15:04:05 -0700") + `"`
+	}
+	command += fmt.Sprintf(" {%d}", len(raw))
+
+	if err := w.c.send(tag+" "+command, tag+" "+command, ""); err != nil {
+		return err
+	}
+	line, err := w.c.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "+") {
+		return fmt.Errorf("APPEND to %s failed: %s", mailbox, strings.TrimPrefix(line, tag+" "))
+	}
+	if _, err := w.c.Write(append(raw, '\r', '\n')); err != nil {
+		return err
+	}
+	for {
+		line, err := w.c.readLine()
+		if err != nil {
+			return err
+		}
+		if rest, ok := strings.CutPrefix(line, tag+" "); ok {
+			if !strings.HasPrefix(rest, "OK") {
+				return fmt.Errorf("APPEND to %s failed: %s", mailbox, rest)
+			}
+			return nil
+		}
+	}
+}
+
+// Close logs out and closes the connection.
+func (w *IMAPWriter) Close() error {
+	_ = w.c.SetDeadline(time.Now().Add(10 * time.Second))
+	_ = w.c.send(w.nextTag()+" LOGOUT", "LOGOUT", "")
+	return w.c.Close()
+}
+
+func (w *IMAPWriter) nextTag() string {
+	w.seq++
+	return "w" + strconv.Itoa(w.seq)
+}
+
+// begin prepares the connection for the next command.
+func (w *IMAPWriter) begin(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w.c.result.Transcript = w.c.result.Transcript[:0]
+	return w.c.SetDeadline(time.Now().Add(imapCommandTimeout))
+}
+
+func (w *IMAPWriter) command(ctx context.Context, command string) ([]string, error) {
+	if err := w.begin(ctx); err != nil {
+		return nil, err
+	}
+	return imapCommand(w.c, w.nextTag(), command)
+}
+
+// parseListResponse parses untagged LIST lines. A name sent as a literal
+// arrives on the following line.
+func parseListResponse(lines []string) []domain.IMAPMailbox {
+	var boxes []domain.IMAPMailbox
+	for i := 0; i < len(lines); i++ {
+		rest, ok := strings.CutPrefix(lines[i], "* LIST ")
+		if !ok || !strings.HasPrefix(rest, "(") {
+			continue
+		}
+		end := strings.Index(rest, ")")
+		if end < 0 {
+			continue
+		}
+		box := domain.IMAPMailbox{Attributes: strings.Fields(rest[1:end])}
+
+		rest = strings.TrimSpace(rest[end+1:])
+		box.Delimiter, rest = imapString(rest)
+		rest = strings.TrimSpace(rest)
+
+		name := ""
+		if strings.HasPrefix(rest, "{") && i+1 < len(lines) {
+			i++
+			name = lines[i]
+		} else {
+			name, _ = imapString(rest)
+		}
+		box.Name = decodeMailboxName(name)
+		boxes = append(boxes, box)
+	}
+	return boxes
+}
+
+// imapString reads a quoted string or atom (NIL is "") from s and returns
+// it with the remainder.
+func imapString(s string) (string, string) {
+	if !strings.HasPrefix(s, `"`) {
+		atom, rest, _ := strings.Cut(s, " ")
+		if strings.EqualFold(atom, "NIL") {
+			atom = ""
+		}
+		return atom, rest
+	}
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:]
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), ""
+}
+
+// imapQuote returns s as an IMAP quoted string.
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
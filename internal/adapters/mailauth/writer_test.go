@@ -0,0 +1,128 @@
+package mailauth
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailboxNameUTF7(t *testing.T) {
+	tests := []struct{ utf8, mutf7 string }{
+		{"INBOX", "INBOX"},
+		{"Tom & Jerry", "Tom &- Jerry"},
+		{"Entwürfe", "Entw&APw-rfe"},
+		{"台北", "&U,BTFw-"},
+		{"[Gmail]/Gesendet", "[Gmail]/Gesendet"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.mutf7, encodeMailboxName(tt.utf8))
+		assert.Equal(t, tt.utf8, decodeMailboxName(tt.mutf7))
+	}
+	assert.Equal(t, "odd &name", decodeMailboxName("odd &name"))
+}
+
+func TestParseListResponse(t *testing.T) {
+	boxes := parseListResponse([]string{
+		`* LIST (\HasNoChildren) "/" "INBOX"`,
+		`* LIST (\HasNoChildren \Sent) "/" "[Gmail]/Sent Mail"`,
+		`* LIST (\Noselect) NIL Archive`,
+		`* LIST () "." {9}`,
+		`Entw&APw-rfe`,
+	})
+	require.Len(t, boxes, 4)
+	assert.Equal(t, "INBOX", boxes[0].Name)
+	assert.Equal(t, "[Gmail]/Sent Mail", boxes[1].Name)
+	assert.True(t, boxes[1].HasAttribute(`\sent`))
+	assert.Equal(t, "", boxes[2].Delimiter)
+	assert.Equal(t, "Archive", boxes[2].Name)
+	assert.Equal(t, ".", boxes[3].Delimiter)
+	assert.Equal(t, "Entwürfe", boxes[3].Name)
+}
+
+func TestIMAPWriter(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		appended []string
+		literal  int
+		body     strings.Builder
+	)
+	host, port := fakeServer(t, func(line string) []string {
+		mu.Lock()
+		defer mu.Unlock()
+		if literal > 0 {
+			body.WriteString(line + "\r\n")
+			literal -= len(line) + 2
+			if literal <= 0 {
+				literal = 0
+				appended = append(appended, body.String())
+				body.Reset()
+				return []string{"w3 OK [APPENDUID 1 1] done"}
+			}
+			return nil
+		}
+		switch {
+		case strings.HasPrefix(line, "a1 CAPABILITY"):
+			return []string{"* CAPABILITY IMAP4rev1 SASL-IR AUTH=XOAUTH2", "a1 OK"}
+		case strings.HasPrefix(line, "a5 AUTHENTICATE"):
+			return []string{"a5 OK"}
+		case line == `w1 LIST "" "*"`:
+			return []string{`* LIST (\HasNoChildren) "/" "INBOX"`, `* LIST (\Sent) "/" "Sent"`, "w1 OK"}
+		case line == `w2 CREATE "Entw&APw-rfe"`:
+			return []string{"w2 NO [ALREADYEXISTS] Mailbox already exists"}
+		case strings.HasPrefix(line, `w3 APPEND "Sent" (\Seen) "02-Jan-2026 15:04:05 +0000" {`):
+			n, _ := strconv.Atoi(strings.TrimSuffix(line[strings.Index(line, "{")+1:], "}"))
+			literal = n + 2 // The literal is followed by the command's CRLF
+			return []string{"+ Ready"}
+		case strings.HasPrefix(line, "w4 CREATE"):
+			return []string{"w4 NO [CANNOT] invalid name"}
+		}
+		return []string{"* BAD " + line}
+	}, "* OK ready")
+
+	w, err := NewTester().OpenMailboxWriter(testContext(t), testOpts(host, port, domain.SASLXOAuth2))
+	require.NoError(t, err)
+	defer func() { _ = w.Close() }()
+
+	boxes, err := w.Mailboxes(testContext(t))
+	require.NoError(t, err)
+	require.Len(t, boxes, 2)
+	assert.Equal(t, "Sent", boxes[1].Name)
+
+	require.NoError(t, w.CreateMailbox(testContext(t), "Entwürfe"))
+
+	err = w.Append(testContext(t), "Sent", domain.AppendMessage{
+		Raw:   []byte("Subject: hi\n\nbody\n"),
+		Flags: []string{`\Seen`},
+		Date:  time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	assert.Error(t, w.CreateMailbox(testContext(t), "bad"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, appended, 1)
+	assert.Equal(t, "Subject: hi\r\n\r\nbody\r\n\r\n", appended[0])
+}
+
+func TestOpenMailboxWriter_AuthFailure(t *testing.T) {
+	host, port := fakeServer(t, func(line string) []string {
+		switch {
+		case strings.HasPrefix(line, "a1 CAPABILITY"):
+			return []string{"* CAPABILITY IMAP4rev1 SASL-IR AUTH=XOAUTH2", "a1 OK"}
+		case strings.HasPrefix(line, "a5 AUTHENTICATE"):
+			return []string{"a5 NO [AUTHENTICATIONFAILED] bad token"}
+		}
+		return nil
+	}, "* OK ready")
+
+	_, err := NewTester().OpenMailboxWriter(testContext(t), testOpts(host, port, domain.SASLXOAuth2))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad token")
+}
@@ -0,0 +1,229 @@
+// Package mailconfig discovers the IMAP and SMTP servers for an email domain
+// using Thunderbird-style autoconfig, Microsoft autodiscover, and finally
+// connection probes against common host names.
+package mailconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/httputil"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// lookupTimeout bounds each autoconfig request and connection probe, so an
+// unreachable host doesn't stall the wizard.
+const lookupTimeout = 5 * time.Second
+
+// maxConfigSize caps the size of a configuration document.
+const maxConfigSize = 1 << 20
+
+// lookup is a configuration URL template. {domain} and {email} are replaced
+// with the query-escaped domain and address.
+type lookup struct {
+	source string
+	url    string
+}
+
+// defaultAutoconfig are tried in order: the domain's own autoconfig, then
+// the Thunderbird ISP database.
+var defaultAutoconfig = []lookup{
+	{source: "autoconfig", url: "https://autoconfig.{domain}/mail/config-v1.1.xml?emailaddress={email}"},
+	{source: "autoconfig", url: "https://{domain}/.well-known/autoconfig/mail/config-v1.1.xml?emailaddress={email}"},
+	{source: "ispdb", url: "https://autoconfig.thunderbird.net/v1.1/{domain}"},
+}
+
+var defaultAutodiscover = []lookup{
+	{source: "autodiscover", url: "https://autodiscover.{domain}/autodiscover/autodiscover.xml"},
+	{source: "autodiscover", url: "https://{domain}/autodiscover/autodiscover.xml"},
+}
+
+// Discoverer implements ports.MailConfigDiscoverer.
+type Discoverer struct {
+	http         *http.Client
+	autoconfig   []lookup
+	autodiscover []lookup
+	probe        func(ctx context.Context, server domain.MailServer) bool
+}
+
+var _ ports.MailConfigDiscoverer = (*Discoverer)(nil)
+
+// New returns a discoverer that uses the public autoconfig locations.
+func New() *Discoverer {
+	return &Discoverer{
+		http:         httputil.NewClient(lookupTimeout),
+		autoconfig:   defaultAutoconfig,
+		autodiscover: defaultAutodiscover,
+		probe:        probe,
+	}
+}
+
+// Discover returns the mail servers for email. Servers that no configuration
+// document lists are found by probing; an error is returned only when no
+// IMAP server is found.
+func (d *Discoverer) Discover(ctx context.Context, email string) (*domain.MailServerConfig, error) {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return nil, fmt.Errorf("invalid email address: %q", email)
+	}
+	domainName := strings.ToLower(email[at+1:])
+
+	cfg := d.lookupAutoconfig(ctx, domainName, email)
+	if cfg == nil {
+		cfg = d.lookupAutodiscover(ctx, domainName, email)
+	}
+	if cfg == nil {
+		cfg = &domain.MailServerConfig{Source: "probe"}
+	}
+	cfg.Domain = domainName
+
+	if cfg.IMAP == nil {
+		cfg.IMAP = d.probeFirst(ctx, imapCandidates(domainName))
+	}
+	if cfg.SMTP == nil {
+		cfg.SMTP = d.probeFirst(ctx, smtpCandidates(domainName))
+	}
+	if cfg.IMAP == nil {
+		return cfg, fmt.Errorf("no IMAP server found for %s", domainName)
+	}
+	return cfg, nil
+}
+
+func (d *Discoverer) lookupAutoconfig(ctx context.Context, domainName, email string) *domain.MailServerConfig {
+	for _, l := range d.autoconfig {
+		body, err := d.fetch(ctx, http.MethodGet, l.expand(domainName, email), nil)
+		if err != nil {
+			continue
+		}
+		if cfg := parseAutoconfig(body, domainName, email); cfg != nil {
+			cfg.Source = l.source
+			return cfg
+		}
+	}
+	return nil
+}
+
+func (d *Discoverer) lookupAutodiscover(ctx context.Context, domainName, email string) *domain.MailServerConfig {
+	for _, l := range d.autodiscover {
+		body, err := d.fetch(ctx, http.MethodPost, l.expand(domainName, email), autodiscoverRequest(email))
+		if err != nil {
+			continue
+		}
+		if cfg := parseAutodiscover(body); cfg != nil {
+			cfg.Source = l.source
+			return cfg
+		}
+	}
+	return nil
+}
+
+func (d *Discoverer) fetch(ctx context.Context, method, rawURL string, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	var reader io.Reader
+	if body != nil {
+		reader = strings.NewReader(string(body))
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/xml")
+	}
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP %d", rawURL, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxConfigSize))
+}
+
+func (l lookup) expand(domainName, email string) string {
+	return strings.NewReplacer(
+		"{domain}", url.PathEscape(domainName),
+		"{email}", url.QueryEscape(email),
+	).Replace(l.url)
+}
+
+// probeFirst probes all candidates at once and returns the first, in
+// preference order, that accepted a connection.
+func (d *Discoverer) probeFirst(ctx context.Context, candidates []domain.MailServer) *domain.MailServer {
+	ok := make([]bool, len(candidates))
+	var wg sync.WaitGroup
+	for i, c := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok[i] = d.probe(ctx, c)
+		}()
+	}
+	wg.Wait()
+	for i, c := range candidates {
+		if ok[i] {
+			return &c
+		}
+	}
+	return nil
+}
+
+func imapCandidates(domainName string) []domain.MailServer {
+	return []domain.MailServer{
+		{Host: "imap." + domainName, Port: 993, TLSMode: domain.MailTLSImplicit},
+		{Host: "mail." + domainName, Port: 993, TLSMode: domain.MailTLSImplicit},
+		{Host: domainName, Port: 993, TLSMode: domain.MailTLSImplicit},
+		{Host: "imap." + domainName, Port: 143, TLSMode: domain.MailTLSStartTLS},
+		{Host: "mail." + domainName, Port: 143, TLSMode: domain.MailTLSStartTLS},
+	}
+}
+
+func smtpCandidates(domainName string) []domain.MailServer {
+	return []domain.MailServer{
+		{Host: "smtp." + domainName, Port: 465, TLSMode: domain.MailTLSImplicit},
+		{Host: "smtp." + domainName, Port: 587, TLSMode: domain.MailTLSStartTLS},
+		{Host: "mail." + domainName, Port: 465, TLSMode: domain.MailTLSImplicit},
+		{Host: "mail." + domainName, Port: 587, TLSMode: domain.MailTLSStartTLS},
+		{Host: domainName, Port: 587, TLSMode: domain.MailTLSStartTLS},
+	}
+}
+
+// probe reports whether the server accepts a connection, completing the TLS
+// handshake for implicit TLS so a host with a mismatched certificate isn't
+// offered.
+func probe(ctx context.Context, server domain.MailServer) bool {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(server.Host, strconv.Itoa(server.Port))
+	var (
+		conn net.Conn
+		err  error
+	)
+	if server.TLSMode == domain.MailTLSImplicit {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: server.Host, MinVersion: tls.VersionTLS12}}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
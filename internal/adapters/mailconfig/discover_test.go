@@ -0,0 +1,131 @@
+package mailconfig
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const autoconfigXML = `<?xml version="1.0"?>
+<clientConfig version="1.1">
+  <emailProvider id="example.com">
+    <incomingServer type="pop3">
+      <hostname>pop.example.com</hostname><port>995</port><socketType>SSL</socketType>
+    </incomingServer>
+    <incomingServer type="imap">
+      <hostname>imap.%EMAILDOMAIN%</hostname><port>143</port><socketType>plain</socketType>
+    </incomingServer>
+    <incomingServer type="imap">
+      <hostname>imap.%EMAILDOMAIN%</hostname><port>993</port><socketType>SSL</socketType>
+    </incomingServer>
+    <outgoingServer type="smtp">
+      <hostname>smtp.example.com</hostname><port>587</port><socketType>STARTTLS</socketType>
+    </outgoingServer>
+  </emailProvider>
+</clientConfig>`
+
+const autodiscoverXML = `<?xml version="1.0" encoding="utf-8"?>
+<Autodiscover xmlns="http://schemas.microsoft.com/exchange/autodiscover/responseschema/2006">
+  <Response xmlns="http://schemas.microsoft.com/exchange/autodiscover/outlook/responseschema/2006a">
+    <Account>
+      <Protocol><Type>IMAP</Type><Server>Mail.Example.com</Server><Port>993</Port><SSL>on</SSL></Protocol>
+      <Protocol><Type>SMTP</Type><Server>mail.example.com</Server><Port>587</Port><Encryption>TLS</Encryption></Protocol>
+    </Account>
+  </Response>
+</Autodiscover>`
+
+func TestParseAutoconfig(t *testing.T) {
+	cfg := parseAutoconfig([]byte(autoconfigXML), "example.com", "me@example.com")
+	require.NotNil(t, cfg)
+	assert.Equal(t, &domain.MailServer{Host: "imap.example.com", Port: 993, TLSMode: domain.MailTLSImplicit}, cfg.IMAP)
+	assert.Equal(t, &domain.MailServer{Host: "smtp.example.com", Port: 587, TLSMode: domain.MailTLSStartTLS}, cfg.SMTP)
+
+	assert.Nil(t, parseAutoconfig([]byte(`<clientConfig/>`), "example.com", "me@example.com"))
+	assert.Nil(t, parseAutoconfig([]byte(`not xml`), "example.com", "me@example.com"))
+}
+
+func TestParseAutodiscover(t *testing.T) {
+	cfg := parseAutodiscover([]byte(autodiscoverXML))
+	require.NotNil(t, cfg)
+	assert.Equal(t, &domain.MailServer{Host: "mail.example.com", Port: 993, TLSMode: domain.MailTLSImplicit}, cfg.IMAP)
+	assert.Equal(t, &domain.MailServer{Host: "mail.example.com", Port: 587, TLSMode: domain.MailTLSStartTLS}, cfg.SMTP)
+
+	assert.Equal(t, domain.MailTLSNone, autodiscoverTLSMode("off", "", 143))
+	assert.Equal(t, domain.MailTLSStartTLS, autodiscoverTLSMode("on", "", 143))
+}
+
+func newTestDiscoverer(srv *httptest.Server, reachable ...string) *Discoverer {
+	return &Discoverer{
+		http: srv.Client(),
+		autoconfig: []lookup{
+			{source: "autoconfig", url: srv.URL + "/autoconfig/{domain}?emailaddress={email}"},
+			{source: "ispdb", url: srv.URL + "/ispdb/{domain}"},
+		},
+		autodiscover: []lookup{{source: "autodiscover", url: srv.URL + "/autodiscover/{domain}"}},
+		probe: func(_ context.Context, s domain.MailServer) bool {
+			for _, r := range reachable {
+				if r == s.Host {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+func TestDiscover_FallsThroughSources(t *testing.T) {
+	var gotEmail, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ispdb/isp.test":
+			gotEmail = r.URL.Query().Get("emailaddress")
+			_, _ = io.WriteString(w, autoconfigXML)
+		case "/autodiscover/corp.test":
+			b, _ := io.ReadAll(r.Body)
+			gotBody = string(b)
+			_, _ = io.WriteString(w, autodiscoverXML)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	d := newTestDiscoverer(srv)
+
+	cfg, err := d.Discover(context.Background(), "me@isp.test")
+	require.NoError(t, err)
+	assert.Equal(t, "ispdb", cfg.Source)
+	assert.Equal(t, "isp.test", cfg.Domain)
+	assert.Equal(t, "imap.isp.test", cfg.IMAP.Host)
+	// The ISP database is public and must not be sent the address.
+	assert.Empty(t, gotEmail)
+
+	cfg, err = d.Discover(context.Background(), "me@corp.test")
+	require.NoError(t, err)
+	assert.Equal(t, "autodiscover", cfg.Source)
+	assert.Equal(t, "mail.example.com", cfg.IMAP.Host)
+	assert.Contains(t, gotBody, "<EMailAddress>me@corp.test</EMailAddress>")
+}
+
+func TestDiscover_Probes(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	d := newTestDiscoverer(srv, "mail.small.test")
+	cfg, err := d.Discover(context.Background(), "me@small.test")
+	require.NoError(t, err)
+	assert.Equal(t, "probe", cfg.Source)
+	assert.Equal(t, &domain.MailServer{Host: "mail.small.test", Port: 993, TLSMode: domain.MailTLSImplicit}, cfg.IMAP)
+	assert.Equal(t, &domain.MailServer{Host: "mail.small.test", Port: 465, TLSMode: domain.MailTLSImplicit}, cfg.SMTP)
+
+	_, err = newTestDiscoverer(srv).Discover(context.Background(), "me@nowhere.test")
+	assert.ErrorContains(t, err, "no IMAP server found for nowhere.test")
+
+	_, err = d.Discover(context.Background(), "not-an-email")
+	assert.Error(t, err)
+}
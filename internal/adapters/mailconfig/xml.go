@@ -0,0 +1,164 @@
+package mailconfig
+
+import (
+	"encoding/xml"
+	"html"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// clientConfig is a Thunderbird autoconfig document (config-v1.1.xml).
+type clientConfig struct {
+	Providers []struct {
+		Incoming []configServer `xml:"incomingServer"`
+		Outgoing []configServer `xml:"outgoingServer"`
+	} `xml:"emailProvider"`
+}
+
+type configServer struct {
+	Type       string `xml:"type,attr"`
+	Hostname   string `xml:"hostname"`
+	Port       int    `xml:"port"`
+	SocketType string `xml:"socketType"`
+}
+
+// parseAutoconfig returns the first IMAP and SMTP servers of an autoconfig
+// document, preferring encrypted ones, or nil if it lists no IMAP server.
+func parseAutoconfig(body []byte, domainName, email string) *domain.MailServerConfig {
+	var doc clientConfig
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+	localPart, _, _ := strings.Cut(email, "@")
+	placeholders := strings.NewReplacer(
+		"%EMAILDOMAIN%", domainName,
+		"%EMAILADDRESS%", email,
+		"%EMAILLOCALPART%", localPart,
+	)
+
+	var imap, smtp []domain.MailServer
+	for _, p := range doc.Providers {
+		for _, s := range p.Incoming {
+			if strings.EqualFold(s.Type, "imap") {
+				imap = append(imap, s.toDomain(placeholders))
+			}
+		}
+		for _, s := range p.Outgoing {
+			if strings.EqualFold(s.Type, "smtp") {
+				smtp = append(smtp, s.toDomain(placeholders))
+			}
+		}
+	}
+	if len(imap) == 0 {
+		return nil
+	}
+	return &domain.MailServerConfig{IMAP: preferTLS(imap), SMTP: preferTLS(smtp)}
+}
+
+func (s configServer) toDomain(placeholders *strings.Replacer) domain.MailServer {
+	mode := domain.MailTLSNone
+	switch strings.ToUpper(strings.TrimSpace(s.SocketType)) {
+	case "SSL":
+		mode = domain.MailTLSImplicit
+	case "STARTTLS":
+		mode = domain.MailTLSStartTLS
+	}
+	return domain.MailServer{
+		Host:    strings.ToLower(placeholders.Replace(strings.TrimSpace(s.Hostname))),
+		Port:    s.Port,
+		TLSMode: mode,
+	}
+}
+
+// preferTLS returns the first encrypted server, or the first server if none
+// is encrypted.
+func preferTLS(servers []domain.MailServer) *domain.MailServer {
+	for _, s := range servers {
+		if s.TLSMode != domain.MailTLSNone {
+			return &s
+		}
+	}
+	if len(servers) > 0 {
+		return &servers[0]
+	}
+	return nil
+}
+
+// autodiscoverResponse is a POX autodiscover response using the Outlook
+// 2006a response schema.
+type autodiscoverResponse struct {
+	Response struct {
+		Account struct {
+			Protocols []struct {
+				Type       string `xml:"Type"`
+				Server     string `xml:"Server"`
+				Port       int    `xml:"Port"`
+				SSL        string `xml:"SSL"`
+				Encryption string `xml:"Encryption"`
+			} `xml:"Protocol"`
+		} `xml:"Account"`
+	} `xml:"Response"`
+}
+
+func autodiscoverRequest(email string) []byte {
+	return []byte(`<?xml version="1.0" encoding="utf-8"?>
+<Autodiscover xmlns="http://schemas.microsoft.com/exchange/autodiscover/outlook/requestschema/2006">
+  <Request>
+    <EMailAddress>` + html.EscapeString(email) + `</EMailAddress>
+    <AcceptableResponseSchema>http://schemas.microsoft.com/exchange/autodiscover/outlook/responseschema/2006a</AcceptableResponseSchema>
+  </Request>
+</Autodiscover>`)
+}
+
+// parseAutodiscover returns the IMAP and SMTP servers of an autodiscover
+// response, or nil if it lists no IMAP server.
+func parseAutodiscover(body []byte) *domain.MailServerConfig {
+	var doc autodiscoverResponse
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil
+	}
+
+	cfg := &domain.MailServerConfig{}
+	for _, p := range doc.Response.Account.Protocols {
+		server := &domain.MailServer{
+			Host:    strings.ToLower(strings.TrimSpace(p.Server)),
+			Port:    p.Port,
+			TLSMode: autodiscoverTLSMode(p.SSL, p.Encryption, p.Port),
+		}
+		switch strings.ToUpper(p.Type) {
+		case "IMAP":
+			if cfg.IMAP == nil {
+				cfg.IMAP = server
+			}
+		case "SMTP":
+			if cfg.SMTP == nil {
+				cfg.SMTP = server
+			}
+		}
+	}
+	if cfg.IMAP == nil || cfg.IMAP.Host == "" {
+		return nil
+	}
+	return cfg
+}
+
+// autodiscoverTLSMode maps Encryption (SSL, TLS, None, Auto) or, when it is
+// absent, the SSL on/off flag and the port to a TLS mode.
+func autodiscoverTLSMode(ssl, encryption string, port int) domain.MailTLSMode {
+	switch strings.ToUpper(encryption) {
+	case "SSL":
+		return domain.MailTLSImplicit
+	case "TLS":
+		return domain.MailTLSStartTLS
+	case "NONE":
+		return domain.MailTLSNone
+	}
+	if strings.EqualFold(ssl, "off") {
+		return domain.MailTLSNone
+	}
+	if port == 993 || port == 465 {
+		return domain.MailTLSImplicit
+	}
+	return domain.MailTLSStartTLS
+}
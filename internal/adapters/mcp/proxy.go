@@ -57,6 +57,7 @@ type Proxy struct {
 	httpClient   *http.Client
 	sessionID    string
 	grantTools   map[string]bool // Dynamically discovered tools that accept grant_id
+	toolGuard    ToolGuard       // Permission check before tool calls
 	mu           sync.RWMutex
 }
 
@@ -132,6 +133,15 @@ func (p *Proxy) Run(ctx context.Context) error {
 			continue
 		}
 
+		// Refuse tool calls the guard does not allow
+		if refused, denied := p.checkToolCall(ctx, &req); denied {
+			if _, err := writer.Write(append(refused, '\n')); err != nil {
+				return fmt.Errorf("writing refusal: %w", err)
+			}
+			_ = writer.Flush()
+			continue
+		}
+
 		// Try to handle locally first (for get_grant without email)
 		if localResponse, handled := p.handleLocalToolCall(&req); handled {
 			if len(localResponse) > 0 {
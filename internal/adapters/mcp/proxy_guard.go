@@ -0,0 +1,34 @@
+package mcp
+
+import "context"
+
+// ToolGuard decides whether a tool call may run. A non-nil error refuses the
+// call and is returned to the assistant as the tool result.
+type ToolGuard func(ctx context.Context, tool string, args map[string]any) error
+
+// SetToolGuard sets the guard consulted before every tools/call request.
+func (p *Proxy) SetToolGuard(guard ToolGuard) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.toolGuard = guard
+}
+
+// checkToolCall runs the tool guard for tools/call requests. It returns the
+// error response and true when the call is refused.
+func (p *Proxy) checkToolCall(ctx context.Context, req *rpcRequest) ([]byte, bool) {
+	if req.Method != "tools/call" {
+		return nil, false
+	}
+
+	p.mu.RLock()
+	guard := p.toolGuard
+	p.mu.RUnlock()
+
+	if guard == nil {
+		return nil, false
+	}
+	if err := guard(ctx, req.Params.Name, req.Params.Arguments); err != nil {
+		return p.createToolErrorResponse(req.ID, err.Error()), true
+	}
+	return nil, false
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestProxy_checkToolCall(t *testing.T) {
+	t.Parallel()
+
+	proxy := NewProxy("key", "us")
+
+	call := &rpcRequest{ID: 7, Method: "tools/call"}
+	call.Params.Name = "send_message"
+	call.Params.Arguments = map[string]any{"subject": "hi"}
+
+	if _, denied := proxy.checkToolCall(context.Background(), call); denied {
+		t.Fatal("calls must pass when no guard is set")
+	}
+
+	var gotTool string
+	var gotArgs map[string]any
+	proxy.SetToolGuard(func(_ context.Context, tool string, args map[string]any) error {
+		gotTool, gotArgs = tool, args
+		if tool == "send_message" {
+			return errors.New("send_message is denied")
+		}
+		return nil
+	})
+
+	if _, denied := proxy.checkToolCall(context.Background(), &rpcRequest{ID: 1, Method: "tools/list"}); denied {
+		t.Fatal("only tools/call requests are guarded")
+	}
+	if gotTool != "" {
+		t.Fatalf("guard ran for tools/list: %q", gotTool)
+	}
+
+	resp, denied := proxy.checkToolCall(context.Background(), call)
+	if !denied {
+		t.Fatal("expected send_message to be refused")
+	}
+	if gotTool != "send_message" || gotArgs["subject"] != "hi" {
+		t.Errorf("guard got %q %v", gotTool, gotArgs)
+	}
+
+	var parsed struct {
+		ID     int `json:"id"`
+		Result struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+			IsError bool `json:"isError"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &parsed); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if parsed.ID != 7 || !parsed.Result.IsError || parsed.Result.Content[0].Text != "send_message is denied" {
+		t.Errorf("unexpected refusal: %s", resp)
+	}
+
+	call.Params.Name = "list_messages"
+	if _, denied := proxy.checkToolCall(context.Background(), call); denied {
+		t.Error("list_messages should be allowed")
+	}
+}
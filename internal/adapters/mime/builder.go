@@ -262,9 +262,14 @@ func (b *Builder) writeAttachmentPart(buf *bytes.Buffer, att *domain.Attachment)
 
 	buf.WriteString("\r\n")
 
-	// Encode content as base64
-	encoded := base64.StdEncoding.EncodeToString(att.Content)
-	// Split into 76-character lines per RFC 2045
+	writeBase64Lines(buf, att.Content)
+
+	return nil
+}
+
+// writeBase64Lines writes data base64-encoded in 76-character lines per RFC 2045.
+func writeBase64Lines(buf *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
 	for i := 0; i < len(encoded); i += 76 {
 		end := i + 76
 		if end > len(encoded) {
@@ -272,8 +277,6 @@ func (b *Builder) writeAttachmentPart(buf *bytes.Buffer, att *domain.Attachment)
 		}
 		buf.WriteString(encoded[i:end] + "\r\n")
 	}
-
-	return nil
 }
 
 // validateBaseRequest validates the common fields of a message request.
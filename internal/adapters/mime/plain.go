@@ -0,0 +1,64 @@
+package mime
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// MessageRequest contains all data needed to build an ordinary (unsigned,
+// unencrypted) email, e.g. for delivery over SMTP.
+type MessageRequest struct {
+	// Standard email fields
+	From        []domain.EmailParticipant
+	To          []domain.EmailParticipant
+	Cc          []domain.EmailParticipant
+	Bcc         []domain.EmailParticipant
+	ReplyTo     []domain.EmailParticipant
+	Subject     string
+	Body        string
+	ContentType string // "text/plain" or "text/html"
+
+	// Optional
+	Attachments []domain.Attachment
+	Headers     map[string]string
+	MessageID   string
+	Date        time.Time
+}
+
+// Implement messageRequest interface for MessageRequest.
+func (r *MessageRequest) getFrom() []domain.EmailParticipant    { return r.From }
+func (r *MessageRequest) getTo() []domain.EmailParticipant      { return r.To }
+func (r *MessageRequest) getCc() []domain.EmailParticipant      { return r.Cc }
+func (r *MessageRequest) getReplyTo() []domain.EmailParticipant { return r.ReplyTo }
+func (r *MessageRequest) getSubject() string                    { return r.Subject }
+func (r *MessageRequest) getHeaders() map[string]string         { return r.Headers }
+func (r *MessageRequest) getMessageID() string                  { return r.MessageID }
+func (r *MessageRequest) getDate() time.Time                    { return r.Date }
+
+// BuildMessage constructs an RFC 5322 message: a single quoted-printable
+// body part, or multipart/mixed when there are attachments. Bcc recipients
+// are not written to the headers; pass them to the transport's envelope.
+func (b *Builder) BuildMessage(req *MessageRequest) ([]byte, error) {
+	if err := validateBaseRequest(req); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeCommonHeaders(&buf, req)
+
+	// The content part is built exactly as for signed messages.
+	content := &SignedMessageRequest{
+		Body:        req.Body,
+		ContentType: req.ContentType,
+		Attachments: req.Attachments,
+	}
+	if err := b.writeContentPart(&buf, content); err != nil {
+		return nil, fmt.Errorf("failed to write message content: %w", err)
+	}
+	buf.WriteString("\r\n")
+
+	return buf.Bytes(), nil
+}
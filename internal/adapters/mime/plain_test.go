@@ -0,0 +1,101 @@
+package mime
+
+import (
+	"bytes"
+	"io"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestBuildMessage_Simple(t *testing.T) {
+	builder := NewBuilder()
+
+	req := &MessageRequest{
+		From:        []domain.EmailParticipant{{Name: "Alice", Email: "alice@example.com"}},
+		To:          []domain.EmailParticipant{{Name: "Bob", Email: "bob@example.com"}},
+		Bcc:         []domain.EmailParticipant{{Email: "secret@example.com"}},
+		Subject:     "Hello",
+		Body:        "Line one\nLine two",
+		ContentType: "text/plain",
+		MessageID:   "abc@example.com",
+		Date:        time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	result, err := builder.BuildMessage(req)
+	if err != nil {
+		t.Fatalf("BuildMessage() error = %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(result))
+	if err != nil {
+		t.Fatalf("result is not a valid RFC 5322 message: %v", err)
+	}
+
+	if got := msg.Header.Get("Message-ID"); got != "<abc@example.com>" {
+		t.Errorf("Message-ID = %q", got)
+	}
+	if got := msg.Header.Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if strings.Contains(string(result), "secret@example.com") {
+		t.Error("Bcc recipient must not appear in headers")
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if got := strings.TrimRight(string(body), "\r\n"); got != "Line one\r\nLine two" {
+		t.Errorf("body = %q", got)
+	}
+}
+
+func TestBuildMessage_WithAttachment(t *testing.T) {
+	builder := NewBuilder()
+
+	req := &MessageRequest{
+		To:          []domain.EmailParticipant{{Email: "bob@example.com"}},
+		Subject:     "Report",
+		Body:        "<p>See attached</p>",
+		ContentType: "text/html",
+		Attachments: []domain.Attachment{
+			{Filename: "report.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+		},
+	}
+
+	result, err := builder.BuildMessage(req)
+	if err != nil {
+		t.Fatalf("BuildMessage() error = %v", err)
+	}
+
+	resultStr := string(result)
+	for _, want := range []string{
+		"Content-Type: multipart/mixed",
+		"Content-Type: text/html; charset=utf-8",
+		"filename=\"report.pdf\"",
+		"JVBERi0xLjQ=",
+	} {
+		if !strings.Contains(resultStr, want) {
+			t.Errorf("missing %q in output", want)
+		}
+	}
+	if !strings.HasSuffix(resultStr, "--\r\n") {
+		t.Error("message should end with the closing boundary and CRLF")
+	}
+}
+
+func TestBuildMessage_Validation(t *testing.T) {
+	builder := NewBuilder()
+
+	if _, err := builder.BuildMessage(&MessageRequest{Subject: "x"}); err == nil {
+		t.Error("expected error for missing recipient")
+	}
+	if _, err := builder.BuildMessage(&MessageRequest{To: []domain.EmailParticipant{{Email: "a@b.c"}}}); err == nil {
+		t.Error("expected error for missing subject")
+	}
+}
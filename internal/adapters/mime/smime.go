@@ -0,0 +1,120 @@
+package mime
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// SMIMEMessageRequest contains all data needed to build an S/MIME email.
+type SMIMEMessageRequest struct {
+	// Standard email fields
+	From    []domain.EmailParticipant
+	To      []domain.EmailParticipant
+	Cc      []domain.EmailParticipant
+	Bcc     []domain.EmailParticipant
+	ReplyTo []domain.EmailParticipant
+	Subject string
+
+	// Entity is the signed and/or enveloped MIME entity, including its
+	// Content-Type header, from BuildSMIMESignedEntity or
+	// BuildSMIMEEnvelopedEntity.
+	Entity []byte
+
+	// Optional
+	Headers   map[string]string
+	MessageID string
+	Date      time.Time
+}
+
+// Implement messageRequest interface for SMIMEMessageRequest.
+func (r *SMIMEMessageRequest) getFrom() []domain.EmailParticipant    { return r.From }
+func (r *SMIMEMessageRequest) getTo() []domain.EmailParticipant      { return r.To }
+func (r *SMIMEMessageRequest) getCc() []domain.EmailParticipant      { return r.Cc }
+func (r *SMIMEMessageRequest) getReplyTo() []domain.EmailParticipant { return r.ReplyTo }
+func (r *SMIMEMessageRequest) getSubject() string                    { return r.Subject }
+func (r *SMIMEMessageRequest) getHeaders() map[string]string         { return r.Headers }
+func (r *SMIMEMessageRequest) getMessageID() string                  { return r.MessageID }
+func (r *SMIMEMessageRequest) getDate() time.Time                    { return r.Date }
+
+// BuildSMIMESignedEntity wraps content and its detached DER signature in a
+// multipart/signed entity per RFC 8551 Section 3.5.3. content must be the
+// exact bytes that were signed, e.g. from PrepareContentToSign.
+// Structure:
+//
+//	Content-Type: multipart/signed; protocol="application/pkcs7-signature";
+//	    micalg=sha-256; boundary="..."
+//
+//	--boundary
+//	[Signed content]
+//	--boundary
+//	Content-Type: application/pkcs7-signature; name="smime.p7s"
+//	Content-Transfer-Encoding: base64
+//
+//	[Base64 signature]
+//	--boundary--
+func (b *Builder) BuildSMIMESignedEntity(content, signature []byte) ([]byte, error) {
+	if len(content) == 0 {
+		return nil, fmt.Errorf("signed content is required")
+	}
+	if len(signature) == 0 {
+		return nil, fmt.Errorf("signature is required")
+	}
+
+	var buf bytes.Buffer
+	boundary := generateBoundary("smime-signed")
+	buf.WriteString("Content-Type: multipart/signed; protocol=\"application/pkcs7-signature\";\r\n")
+	_, _ = fmt.Fprintf(&buf, "\tmicalg=sha-256; boundary=\"%s\"\r\n", boundary)
+	buf.WriteString("\r\n")
+	buf.WriteString("This is an S/MIME signed message\r\n")
+
+	buf.WriteString("\r\n--" + boundary + "\r\n")
+	buf.Write(content)
+
+	buf.WriteString("\r\n--" + boundary + "\r\n")
+	buf.WriteString("Content-Type: application/pkcs7-signature; name=\"smime.p7s\"\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("Content-Disposition: attachment; filename=\"smime.p7s\"\r\n")
+	buf.WriteString("Content-Description: S/MIME Cryptographic Signature\r\n")
+	buf.WriteString("\r\n")
+	writeBase64Lines(&buf, signature)
+	buf.WriteString("\r\n--" + boundary + "--\r\n")
+
+	return buf.Bytes(), nil
+}
+
+// BuildSMIMEEnvelopedEntity wraps DER-encoded CMS EnvelopedData in an
+// application/pkcs7-mime entity per RFC 8551 Section 3.3.
+func (b *Builder) BuildSMIMEEnvelopedEntity(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) == 0 {
+		return nil, fmt.Errorf("ciphertext is required")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("Content-Type: application/pkcs7-mime; smime-type=enveloped-data;\r\n")
+	buf.WriteString("\tname=\"smime.p7m\"\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("Content-Disposition: attachment; filename=\"smime.p7m\"\r\n")
+	buf.WriteString("Content-Description: S/MIME Encrypted Message\r\n")
+	buf.WriteString("\r\n")
+	writeBase64Lines(&buf, ciphertext)
+
+	return buf.Bytes(), nil
+}
+
+// BuildSMIMEMessage writes the message headers followed by the S/MIME entity.
+func (b *Builder) BuildSMIMEMessage(req *SMIMEMessageRequest) ([]byte, error) {
+	if err := validateBaseRequest(req); err != nil {
+		return nil, err
+	}
+	if len(req.Entity) == 0 {
+		return nil, fmt.Errorf("S/MIME entity is required")
+	}
+
+	var buf bytes.Buffer
+	writeCommonHeaders(&buf, req)
+	buf.Write(req.Entity)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,127 @@
+package mime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	stdmime "mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestBuildSMIMEMessage_Signed(t *testing.T) {
+	builder := NewBuilder()
+	content, err := builder.PrepareContentToSign("Hello, world!", "text/plain", nil)
+	if err != nil {
+		t.Fatalf("PrepareContentToSign() error = %v", err)
+	}
+	signature := bytes.Repeat([]byte{0x30, 0x82, 0x01}, 40)
+
+	entity, err := builder.BuildSMIMESignedEntity(content, signature)
+	if err != nil {
+		t.Fatalf("BuildSMIMESignedEntity() error = %v", err)
+	}
+	raw, err := builder.BuildSMIMEMessage(&SMIMEMessageRequest{
+		From:    []domain.EmailParticipant{{Email: "alice@example.com"}},
+		To:      []domain.EmailParticipant{{Email: "bob@example.com"}},
+		Subject: "Signed",
+		Entity:  entity,
+		Date:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("BuildSMIMEMessage() error = %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	if got := msg.Header.Get("Subject"); got != "Signed" {
+		t.Errorf("Subject = %q", got)
+	}
+	mediaType, params, err := stdmime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("ParseMediaType() error = %v", err)
+	}
+	if mediaType != "multipart/signed" || params["protocol"] != "application/pkcs7-signature" || params["micalg"] != "sha-256" {
+		t.Errorf("Content-Type = %s %v", mediaType, params)
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	part, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("first part: %v", err)
+	}
+	if !strings.HasPrefix(part.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("first part Content-Type = %q", part.Header.Get("Content-Type"))
+	}
+	part, err = mr.NextPart()
+	if err != nil {
+		t.Fatalf("signature part: %v", err)
+	}
+	if !strings.HasPrefix(part.Header.Get("Content-Type"), "application/pkcs7-signature") {
+		t.Errorf("signature Content-Type = %q", part.Header.Get("Content-Type"))
+	}
+	encoded, _ := io.ReadAll(part)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	if err != nil || !bytes.Equal(decoded, signature) {
+		t.Errorf("signature round trip failed: %v", err)
+	}
+	for _, line := range strings.Split(string(encoded), "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("base64 line longer than 76 characters: %d", len(line))
+		}
+	}
+}
+
+func TestBuildSMIMEMessage_Enveloped(t *testing.T) {
+	builder := NewBuilder()
+	entity, err := builder.BuildSMIMEEnvelopedEntity([]byte("ciphertext"))
+	if err != nil {
+		t.Fatalf("BuildSMIMEEnvelopedEntity() error = %v", err)
+	}
+	raw, err := builder.BuildSMIMEMessage(&SMIMEMessageRequest{
+		To:      []domain.EmailParticipant{{Email: "bob@example.com"}},
+		Subject: "Encrypted",
+		Entity:  entity,
+	})
+	if err != nil {
+		t.Fatalf("BuildSMIMEMessage() error = %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	mediaType, params, _ := stdmime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if mediaType != "application/pkcs7-mime" || params["smime-type"] != "enveloped-data" {
+		t.Errorf("Content-Type = %s %v", mediaType, params)
+	}
+	body, _ := io.ReadAll(msg.Body)
+	if strings.TrimSpace(string(body)) != base64.StdEncoding.EncodeToString([]byte("ciphertext")) {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestBuildSMIME_Validation(t *testing.T) {
+	builder := NewBuilder()
+	if _, err := builder.BuildSMIMESignedEntity(nil, []byte("sig")); err == nil {
+		t.Error("expected error for empty content")
+	}
+	if _, err := builder.BuildSMIMESignedEntity([]byte("content"), nil); err == nil {
+		t.Error("expected error for empty signature")
+	}
+	if _, err := builder.BuildSMIMEEnvelopedEntity(nil); err == nil {
+		t.Error("expected error for empty ciphertext")
+	}
+	if _, err := builder.BuildSMIMEMessage(&SMIMEMessageRequest{
+		To: []domain.EmailParticipant{{Email: "bob@example.com"}}, Subject: "x",
+	}); err == nil {
+		t.Error("expected error for missing entity")
+	}
+}
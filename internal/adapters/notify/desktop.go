@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Desktop shows notifications with the operating system's notifier:
+// notify-send on Linux, osascript on macOS and PowerShell on Windows.
+type Desktop struct {
+	goos string
+}
+
+// NewDesktop creates a desktop notifier for the current OS.
+func NewDesktop() *Desktop {
+	return &Desktop{goos: runtime.GOOS}
+}
+
+// Notify shows n.
+func (d *Desktop) Notify(ctx context.Context, n domain.Notification) error {
+	name, args := desktopCommand(d.goos, n)
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput() // #nosec G204 -- fixed program, text passed as arguments
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// desktopCommand returns the program and arguments that show n on goos.
+func desktopCommand(goos string, n domain.Notification) (string, []string) {
+	body := n.Body
+	if n.URL != "" {
+		body = strings.TrimSpace(body + "\n" + n.URL)
+	}
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(n.Title))
+		return "osascript", []string{"-e", script}
+	case "windows":
+		script := "Add-Type -AssemblyName System.Windows.Forms; " +
+			"$n = New-Object System.Windows.Forms.NotifyIcon; " +
+			"$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; " +
+			fmt.Sprintf("$n.ShowBalloonTip(10000, %s, %s, 'Info'); Start-Sleep -Seconds 10; $n.Dispose()",
+				powerShellString(n.Title), powerShellString(body))
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}
+	default:
+		return "notify-send", []string{"--app-name=Nylas", "--", n.Title, body}
+	}
+}
+
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
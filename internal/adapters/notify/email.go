@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Email sends notifications as messages from a grant's mailbox.
+type Email struct {
+	client  ports.MessageClient
+	grantID string
+	to      string
+}
+
+// NewEmail creates a notifier that mails to from grantID.
+func NewEmail(client ports.MessageClient, grantID, to string) *Email {
+	return &Email{client: client, grantID: grantID, to: to}
+}
+
+// Notify sends n with its title as the subject.
+func (e *Email) Notify(ctx context.Context, n domain.Notification) error {
+	body := html.EscapeString(n.Body)
+	if n.URL != "" {
+		body += `<p><a href="` + html.EscapeString(n.URL) + `">` + html.EscapeString(n.URL) + `</a></p>`
+	}
+	_, err := e.client.SendMessage(ctx, e.grantID, &domain.SendMessageRequest{
+		Subject: "[Nylas] " + n.Title,
+		Body:    strings.ReplaceAll(body, "\n", "<br>"),
+		To:      []domain.EmailParticipant{{Email: e.to}},
+	})
+	return err
+}
@@ -0,0 +1,43 @@
+// Package notify delivers notifications to the desktop, a Slack incoming
+// webhook, or email, routed per category by the notify config.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Router sends each notification to the channels configured for its
+// category.
+type Router struct {
+	cfg      *domain.NotifyConfig
+	channels map[string]ports.Notifier
+}
+
+// NewRouter creates a router. channels maps a channel name (domain.NotifyDesktop
+// and so on) to its notifier; a routed channel missing from it is an error
+// when a notification is sent.
+func NewRouter(cfg *domain.NotifyConfig, channels map[string]ports.Notifier) *Router {
+	return &Router{cfg: cfg, channels: channels}
+}
+
+// Notify sends n to every channel routed for its category. A failing channel
+// doesn't stop the others; their errors are joined.
+func (r *Router) Notify(ctx context.Context, n domain.Notification) error {
+	var errs []error
+	for _, name := range r.cfg.ChannelsFor(n.Category) {
+		ch, ok := r.channels[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: channel is not set up", name))
+			continue
+		}
+		if err := ch.Notify(ctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+type recordingNotifier struct {
+	got []domain.Notification
+	err error
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, n domain.Notification) error {
+	r.got = append(r.got, n)
+	return r.err
+}
+
+func TestRouter_RoutesByCategory(t *testing.T) {
+	desktop, slack := &recordingNotifier{}, &recordingNotifier{err: errors.New("HTTP 500")}
+	router := NewRouter(&domain.NotifyConfig{Routes: map[string][]string{
+		domain.NotifyVIPEmail:       {domain.NotifyDesktop, domain.NotifySlack, domain.NotifyEmail},
+		domain.NotifyBookingCreated: {},
+	}}, map[string]ports.Notifier{domain.NotifyDesktop: desktop, domain.NotifySlack: slack})
+
+	err := router.Notify(context.Background(), domain.Notification{Category: domain.NotifyVIPEmail, Title: "hi"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slack: HTTP 500")
+	assert.Contains(t, err.Error(), "email: channel is not set up")
+	assert.Len(t, desktop.got, 1, "a failing channel doesn't stop the others")
+	assert.Len(t, slack.got, 1)
+
+	require.NoError(t, router.Notify(context.Background(), domain.Notification{Category: domain.NotifyBookingCreated}))
+	require.NoError(t, router.Notify(context.Background(), domain.Notification{Category: domain.NotifyMeetingSoon}))
+	assert.Len(t, desktop.got, 2, "unrouted categories go to the desktop")
+}
+
+func TestDesktopCommand(t *testing.T) {
+	n := domain.Notification{Title: `Say "hi"`, Body: "It's 5", URL: "https://example.com"}
+
+	name, args := desktopCommand("linux", n)
+	assert.Equal(t, "notify-send", name)
+	assert.Equal(t, []string{"--app-name=Nylas", "--", `Say "hi"`, "It's 5\nhttps://example.com"}, args)
+
+	name, args = desktopCommand("darwin", n)
+	assert.Equal(t, "osascript", name)
+	assert.Equal(t, `display notification "It's 5`+"\n"+`https://example.com" with title "Say \"hi\""`, args[1])
+
+	name, args = desktopCommand("windows", n)
+	assert.Equal(t, "powershell", name)
+	assert.Contains(t, args[len(args)-1], `'It''s 5`)
+}
+
+func TestSlack_Notify(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	err := NewSlack(srv.URL).Notify(context.Background(), domain.Notification{Title: "Email from Ana", Body: "Renewal", URL: "https://x"})
+	require.NoError(t, err)
+	assert.Equal(t, "*Email from Ana*\nRenewal\n<https://x>", got["text"])
+}
+
+func TestSlack_NotifyError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	err := NewSlack(srv.URL+"/services/SECRET").Notify(context.Background(), domain.Notification{Title: "x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 403: invalid_token")
+	assert.NotContains(t, err.Error(), "SECRET")
+}
+
+func TestEmail_Notify(t *testing.T) {
+	client := nylas.NewMockClient()
+	var got *domain.SendMessageRequest
+	client.SendMessageFunc = func(_ context.Context, grantID string, req *domain.SendMessageRequest) (*domain.Message, error) {
+		assert.Equal(t, "grant-1", grantID)
+		got = req
+		return &domain.Message{ID: "msg-1"}, nil
+	}
+
+	err := NewEmail(client, "grant-1", "me@example.com").Notify(context.Background(), domain.Notification{Title: "Booked", Body: "a<b\nc"})
+	require.NoError(t, err)
+	assert.Equal(t, "[Nylas] Booked", got.Subject)
+	assert.Equal(t, "a&lt;b<br>c", got.Body)
+	assert.Equal(t, "me@example.com", got.To[0].Email)
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/httputil"
+)
+
+// slackTimeout bounds a single webhook post.
+const slackTimeout = 10 * time.Second
+
+// Slack posts notifications to a Slack incoming webhook.
+type Slack struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlack creates a notifier for the incoming webhook at webhookURL.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{url: webhookURL, client: httputil.NewClient(slackTimeout)}
+}
+
+// Notify posts n as a message.
+func (s *Slack) Notify(ctx context.Context, n domain.Notification) error {
+	text := "*" + n.Title + "*"
+	if n.Body != "" {
+		text += "\n" + n.Body
+	}
+	if n.URL != "" {
+		text += "\n<" + n.URL + ">"
+	}
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		// The webhook URL is a credential; don't echo it.
+		return fmt.Errorf("invalid Slack webhook URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post to Slack: %w", redactURL(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// redactURL drops the request URL url.Error adds, since it holds the
+// webhook secret.
+func redactURL(err error) error {
+	var uerr *url.Error
+	if errors.As(err, &uerr) {
+		return uerr.Err
+	}
+	return err
+}
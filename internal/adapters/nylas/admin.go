@@ -505,28 +505,5 @@ func (c *HTTPClient) GetGrantStats(ctx context.Context) (*domain.GrantStats, err
 		return nil, err
 	}
 
-	// Calculate statistics
-	stats := &domain.GrantStats{
-		Total:      len(grants),
-		ByProvider: make(map[string]int),
-		ByStatus:   make(map[string]int),
-	}
-
-	for _, grant := range grants {
-		// Count by provider
-		stats.ByProvider[string(grant.Provider)]++
-
-		// Count by status
-		if grant.GrantStatus != "" {
-			stats.ByStatus[grant.GrantStatus]++
-			switch grant.GrantStatus {
-			case "valid":
-				stats.Valid++
-			case "invalid":
-				stats.Invalid++
-			}
-		}
-	}
-
-	return stats, nil
+	return domain.NewGrantStats(grants), nil
 }
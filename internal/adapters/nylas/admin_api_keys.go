@@ -0,0 +1,67 @@
+package nylas
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Application API key operations (/v3/admin/applications/{id}/api-keys).
+
+func (c *HTTPClient) apiKeysURL(appID string) string {
+	return fmt.Sprintf("%s/v3/admin/applications/%s/api-keys", c.baseURL, url.PathEscape(appID))
+}
+
+// ListAPIKeys retrieves the API keys of an application.
+func (c *HTTPClient) ListAPIKeys(ctx context.Context, appID string) ([]domain.APIKey, error) {
+	if err := validateRequired("application ID", appID); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data []domain.APIKey `json:"data"`
+	}
+	if err := c.doGet(ctx, c.apiKeysURL(appID), &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// CreateAPIKey creates an API key for an application.
+func (c *HTTPClient) CreateAPIKey(ctx context.Context, appID string, req *domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error) {
+	if err := validateRequired("application ID", appID); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, fmt.Errorf("create API key request is required")
+	}
+
+	resp, err := c.doJSONRequest(ctx, "POST", c.apiKeysURL(appID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data domain.CreatedAPIKey `json:"data"`
+	}
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	if result.Data.Key == "" {
+		return nil, fmt.Errorf("API key response did not include the key value")
+	}
+	return &result.Data, nil
+}
+
+// RevokeAPIKey revokes an API key.
+func (c *HTTPClient) RevokeAPIKey(ctx context.Context, appID, keyID string) error {
+	if err := validateRequired("application ID", appID); err != nil {
+		return err
+	}
+	if err := validateRequired("API key ID", keyID); err != nil {
+		return err
+	}
+	return c.doDelete(ctx, c.apiKeysURL(appID)+"/"+url.PathEscape(keyID))
+}
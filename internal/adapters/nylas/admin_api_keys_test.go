@@ -0,0 +1,89 @@
+package nylas_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPIKeyTestClient(t *testing.T, handler http.HandlerFunc) *nylas.HTTPClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := nylas.NewHTTPClient()
+	client.SetCredentials("client-id", "secret", "api-key")
+	client.SetBaseURL(server.URL)
+	return client
+}
+
+func TestHTTPClient_ListAPIKeys(t *testing.T) {
+	client := newAPIKeyTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/admin/applications/app-1/api-keys", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{
+				{"id": "key-1", "name": "Production", "status": "active", "created_at": 1767225600},
+			},
+		})
+	})
+
+	keys, err := client.ListAPIKeys(context.Background(), "app-1")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "key-1", keys[0].ID)
+	assert.Equal(t, "active", keys[0].Status)
+	assert.Equal(t, int64(1767225600), keys[0].CreatedAt.Unix())
+}
+
+func TestHTTPClient_CreateAPIKey(t *testing.T) {
+	client := newAPIKeyTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/admin/applications/app-1/api-keys", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "ci", body["name"])
+		assert.Equal(t, float64(30), body["expires_in"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"id": "key-2", "name": "ci", "status": "active", "api_key": "nyk_v0_secret"},
+		})
+	})
+
+	key, err := client.CreateAPIKey(context.Background(), "app-1", &domain.CreateAPIKeyRequest{Name: "ci", ExpiresIn: 30})
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", key.ID)
+	assert.Equal(t, "nyk_v0_secret", key.Key)
+}
+
+func TestHTTPClient_CreateAPIKey_MissingValue(t *testing.T) {
+	client := newAPIKeyTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"key-2"}}`))
+	})
+
+	_, err := client.CreateAPIKey(context.Background(), "app-1", &domain.CreateAPIKeyRequest{Name: "ci"})
+	assert.ErrorContains(t, err, "did not include the key value")
+}
+
+func TestHTTPClient_RevokeAPIKey(t *testing.T) {
+	client := newAPIKeyTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/admin/applications/app-1/api-keys/key-1", r.URL.Path)
+		assert.Equal(t, "DELETE", r.Method)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	require.NoError(t, client.RevokeAPIKey(context.Background(), "app-1", "key-1"))
+	assert.Error(t, client.RevokeAPIKey(context.Background(), "app-1", ""))
+}
@@ -41,6 +41,30 @@ func TestParseError_ParsesTopLevelMessage(t *testing.T) {
 	assert.Equal(t, "extra fields not permitted: app_password", apiErr.Message)
 }
 
+func TestParseError_KeepsProviderError(t *testing.T) {
+	client := NewHTTPClient()
+
+	resp := &http.Response{
+		StatusCode: http.StatusForbidden,
+		Body: io.NopCloser(strings.NewReader(`{
+			"request_id": "req-1",
+			"error": {
+				"type": "provider_error",
+				"message": "Rate Limit Exceeded",
+				"provider_error": {"error": {"code": 403, "errors": [{"reason": "rateLimitExceeded"}]}}
+			}
+		}`)),
+	}
+
+	err := client.parseError(resp)
+
+	var apiErr *domain.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "provider_error", apiErr.Type)
+	assert.Contains(t, apiErr.ProviderError, "rateLimitExceeded")
+	assert.NotContains(t, apiErr.Error(), "rateLimitExceeded")
+}
+
 func TestParseError_ParsesOAuthErrorFormat(t *testing.T) {
 	client := NewHTTPClient()
 
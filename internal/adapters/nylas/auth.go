@@ -6,12 +6,19 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/nylas/cli/internal/domain"
 )
 
 // BuildAuthURL builds the OAuth authorization URL.
 func (c *HTTPClient) BuildAuthURL(provider domain.Provider, redirectURI, state, codeChallenge string) string {
+	return c.BuildAuthURLWithOptions(provider, redirectURI, state, codeChallenge, domain.AuthURLOptions{})
+}
+
+// BuildAuthURLWithOptions builds the OAuth authorization URL with requested
+// scopes and a login hint.
+func (c *HTTPClient) BuildAuthURLWithOptions(provider domain.Provider, redirectURI, state, codeChallenge string, opts domain.AuthURLOptions) string {
 	baseURL := fmt.Sprintf("%s/v3/connect/auth", c.baseURL)
 	query := NewQueryBuilder().
 		Add("client_id", c.clientID).
@@ -19,7 +26,9 @@ func (c *HTTPClient) BuildAuthURL(provider domain.Provider, redirectURI, state,
 		Add("response_type", "code").
 		Add("provider", string(provider)).
 		Add("access_type", "offline").
-		Add("state", state)
+		Add("state", state).
+		Add("login_hint", opts.LoginHint).
+		Add("scope", strings.Join(opts.Scopes, " "))
 
 	if codeChallenge != "" {
 		query.Add("code_challenge", codeChallenge).
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -78,6 +79,25 @@ func TestHTTPClient_BuildAuthURL(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_BuildAuthURLWithOptions(t *testing.T) {
+	client := newTestClient("test-api-key", "test-client-id", "test-client-secret")
+
+	raw := client.BuildAuthURLWithOptions(domain.ProviderMicrosoft, "http://localhost:8080/callback", "test-state", "", domain.AuthURLOptions{
+		Scopes:    []string{"Mail.Read", "Calendars.ReadWrite"},
+		LoginHint: "user@example.com",
+	})
+	parsed, err := url.Parse(raw)
+	require.NoError(t, err)
+	query := parsed.Query()
+	assert.Equal(t, "Mail.Read Calendars.ReadWrite", query.Get("scope"))
+	assert.Equal(t, "user@example.com", query.Get("login_hint"))
+	assert.Equal(t, "microsoft", query.Get("provider"))
+
+	plain := client.BuildAuthURL(domain.ProviderGoogle, "http://localhost:8080/callback", "test-state", "")
+	assert.NotContains(t, plain, "scope=")
+	assert.NotContains(t, plain, "login_hint=")
+}
+
 func TestHTTPClient_ExchangeCode(t *testing.T) {
 	tests := []struct {
 		name           string
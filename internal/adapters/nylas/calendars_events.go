@@ -55,6 +55,7 @@ func (c *HTTPClient) GetEventsWithCursor(ctx context.Context, grantID, calendarI
 		AddBoolPtr("busy", params.Busy).
 		Add("order_by", params.OrderBy).
 		Add("ical_uid", params.ICalUID).
+		Add("select", params.Select).
 		BuildURL(baseURL)
 
 	var result struct {
@@ -102,7 +103,10 @@ func (c *HTTPClient) GetEvent(ctx context.Context, grantID, calendarID, eventID
 // CreateEvent creates a new event.
 func (c *HTTPClient) CreateEvent(ctx context.Context, grantID, calendarID string, req *domain.CreateEventRequest) (*domain.Event, error) {
 	baseURL := fmt.Sprintf("%s/v3/grants/%s/events", c.baseURL, url.PathEscape(grantID))
-	queryURL := NewQueryBuilder().Add("calendar_id", calendarID).BuildURL(baseURL)
+	queryURL := NewQueryBuilder().
+		Add("calendar_id", calendarID).
+		AddBoolPtr("notify_participants", req.NotifyParticipants).
+		BuildURL(baseURL)
 
 	payload := map[string]any{
 		"title": req.Title,
@@ -154,7 +158,10 @@ func (c *HTTPClient) CreateEvent(ctx context.Context, grantID, calendarID string
 // UpdateEvent updates an existing event.
 func (c *HTTPClient) UpdateEvent(ctx context.Context, grantID, calendarID, eventID string, req *domain.UpdateEventRequest) (*domain.Event, error) {
 	baseURL := fmt.Sprintf("%s/v3/grants/%s/events/%s", c.baseURL, url.PathEscape(grantID), url.PathEscape(eventID))
-	queryURL := NewQueryBuilder().Add("calendar_id", calendarID).BuildURL(baseURL)
+	queryURL := NewQueryBuilder().
+		Add("calendar_id", calendarID).
+		AddBoolPtr("notify_participants", req.NotifyParticipants).
+		BuildURL(baseURL)
 
 	payload := make(map[string]any)
 	if req.Title != nil {
@@ -652,3 +652,30 @@ func TestHTTPClient_DeleteEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPClient_CreateEvent_NotifyParticipants(t *testing.T) {
+	notify := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "false", r.URL.Query().Get("notify_participants"))
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.NotContains(t, body, "notify_participants")
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"id": "evt-1"}})
+	}))
+	defer server.Close()
+
+	client := nylas.NewHTTPClient()
+	client.SetCredentials("client-id", "secret", "api-key")
+	client.SetBaseURL(server.URL)
+
+	_, err := client.CreateEvent(context.Background(), "grant-123", "cal-123", &domain.CreateEventRequest{
+		Title:              "Copied",
+		When:               domain.EventWhen{StartTime: 1704067200, EndTime: 1704070800},
+		Participants:       []domain.Participant{{Person: domain.Person{Email: "bob@example.com"}}},
+		NotifyParticipants: &notify,
+	})
+	require.NoError(t, err)
+}
@@ -51,6 +51,10 @@ type HTTPClient struct {
 	requestTimeout time.Duration
 	maxRetries     int
 	retryDelay     time.Duration
+	cache          ports.ResponseCache
+	cacheConfig    *domain.CacheConfig
+	serveStale     bool
+	observer       func(domain.APIRequest)
 }
 
 // NewHTTPClient creates a new Nylas HTTP client with rate limiting and retry logic.
@@ -157,8 +161,9 @@ func (c *HTTPClient) parseError(resp *http.Response) error {
 		Type      string `json:"type"`
 		RequestID string `json:"request_id"`
 		Error     struct {
-			Message string `json:"message"`
-			Type    string `json:"type"`
+			Message       string          `json:"message"`
+			Type          string          `json:"type"`
+			ProviderError json.RawMessage `json:"provider_error"`
 		} `json:"error"`
 	}
 	if err := json.Unmarshal(body, &errResp); err == nil {
@@ -173,12 +178,17 @@ func (c *HTTPClient) parseError(resp *http.Response) error {
 		if bodyReqID := strings.TrimSpace(errResp.RequestID); bodyReqID != "" {
 			requestID = bodyReqID
 		}
+		providerErr := strings.TrimSpace(string(errResp.Error.ProviderError))
+		if providerErr == "null" {
+			providerErr = ""
+		}
 		if message != "" || errType != "" {
 			return &domain.APIError{
-				StatusCode: resp.StatusCode,
-				Type:       errType,
-				Message:    message,
-				RequestID:  requestID,
+				StatusCode:    resp.StatusCode,
+				Type:          errType,
+				Message:       message,
+				RequestID:     requestID,
+				ProviderError: providerErr,
 			}
 		}
 	}
@@ -264,6 +274,8 @@ func cloneRequestForAttempt(req *http.Request, ctx context.Context, attempt int)
 func (c *HTTPClient) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
 	// Set User-Agent header for all requests
 	req.Header.Set("User-Agent", version.UserAgent())
+	c.invalidateCacheFor(req)
+	c.observeRequest(req)
 
 	var lastErr error
 
@@ -295,10 +307,10 @@ func (c *HTTPClient) doRequest(ctx context.Context, req *http.Request) (*http.Re
 
 			// Don't retry context timeout/cancellation errors - they'll just timeout again
 			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
-				return nil, fmt.Errorf("%w: %v", domain.ErrNetworkError, err)
+				return nil, fmt.Errorf("%w: %w", domain.ErrNetworkError, err)
 			}
 
-			lastErr = fmt.Errorf("%w: %v", domain.ErrNetworkError, err)
+			lastErr = fmt.Errorf("%w: %w", domain.ErrNetworkError, err)
 
 			// Only retry transient network errors (connection refused, DNS, etc.)
 			if attempt < c.maxRetries {
@@ -340,6 +352,8 @@ func (c *HTTPClient) doRequest(ctx context.Context, req *http.Request) (*http.Re
 
 func (c *HTTPClient) doRequestNoRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
 	req.Header.Set("User-Agent", version.UserAgent())
+	c.invalidateCacheFor(req)
+	c.observeRequest(req)
 
 	// Apply rate limiting - wait for permission to proceed
 	if err := c.rateLimiter.Wait(ctx); err != nil {
@@ -359,7 +373,7 @@ func (c *HTTPClient) doRequestNoRetry(ctx context.Context, req *http.Request) (*
 			return nil, ctx.Err()
 		}
 
-		return nil, fmt.Errorf("%w: %v", domain.ErrNetworkError, err)
+		return nil, fmt.Errorf("%w: %w", domain.ErrNetworkError, err)
 	}
 
 	resp.Body = &cancelOnCloseBody{
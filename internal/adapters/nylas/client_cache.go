@@ -0,0 +1,129 @@
+package nylas
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// cacheNow is the clock used for cache freshness. Replaced in tests.
+var cacheNow = time.Now
+
+// SetResponseCache enables caching of read-only folder, calendar, contact,
+// and grant responses. A nil cache disables it.
+func (c *HTTPClient) SetResponseCache(cache ports.ResponseCache, cfg *domain.CacheConfig) {
+	c.cache = cache
+	c.cacheConfig = cfg
+}
+
+// SetServeStale makes GETs fall back to a cached response, however old,
+// when the API can't be reached in time (e.g. for shell completion).
+func (c *HTTPClient) SetServeStale(enabled bool) {
+	c.serveStale = enabled
+}
+
+// staleFallback decodes a stale cached entry into result when serving
+// stale responses is enabled. It reports whether result was filled.
+func (c *HTTPClient) staleFallback(entry *domain.CachedResponse, result any) bool {
+	if !c.serveStale || entry == nil {
+		return false
+	}
+	return json.Unmarshal(entry.Body, result) == nil
+}
+
+// cacheResource returns the cacheable resource a request URL belongs to,
+// or "" if responses from it are never cached.
+//
+//	/v3/grants                        grants
+//	/v3/grants/{id}                   grants
+//	/v3/grants/{id}/folders[/...]     folders (also calendars, contacts)
+func cacheResource(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "v3" || parts[1] != "grants" {
+		return ""
+	}
+	if len(parts) <= 3 {
+		return "grants"
+	}
+	if _, ok := domain.DefaultCacheTTLs[parts[3]]; ok {
+		return parts[3]
+	}
+	return ""
+}
+
+// cacheKey identifies a response by URL and API key, so different keys
+// (and environments) never share entries.
+func (c *HTTPClient) cacheKey(rawURL string) string {
+	sum := sha256.Sum256([]byte(c.apiKey + "\n" + rawURL))
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedResponse looks up a cached response for a GET. fresh reports whether
+// it can be used without asking the API.
+func (c *HTTPClient) cachedResponse(rawURL string) (resource string, entry *domain.CachedResponse, fresh bool) {
+	if c.cache == nil {
+		return "", nil, false
+	}
+	resource = cacheResource(rawURL)
+	if resource == "" || c.cacheConfig.TTL(resource) <= 0 {
+		return "", nil, false
+	}
+	entry, err := c.cache.Get(resource, c.cacheKey(rawURL))
+	if err != nil || entry == nil {
+		return resource, nil, false
+	}
+	return resource, entry, entry.Fresh(c.cacheConfig.TTL(resource), cacheNow())
+}
+
+// addValidators makes a stale entry revalidatable with a conditional GET.
+func addValidators(req *http.Request, entry *domain.CachedResponse) {
+	if entry == nil {
+		return
+	}
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// storeResponse caches a successful response body. Cache write failures
+// are ignored; the response is still returned to the caller.
+func (c *HTTPClient) storeResponse(resource, rawURL string, resp *http.Response, body []byte) {
+	if c.cache == nil || resource == "" {
+		return
+	}
+	_ = c.cache.Put(resource, c.cacheKey(rawURL), &domain.CachedResponse{
+		Body:         body,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		StoredAt:     cacheNow(),
+	})
+}
+
+// invalidateCacheFor drops cached responses for the resource a write
+// request changes.
+func (c *HTTPClient) invalidateCacheFor(req *http.Request) {
+	if c.cache == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return
+	}
+	// free-busy is a read-only POST
+	if strings.HasSuffix(req.URL.Path, "/free-busy") {
+		return
+	}
+	if resource := cacheResource(req.URL.String()); resource != "" {
+		_ = c.cache.Invalidate(resource)
+	}
+}
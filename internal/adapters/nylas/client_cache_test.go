@@ -0,0 +1,183 @@
+//go:build !integration
+
+package nylas
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+type memCache struct {
+	entries     map[string]*domain.CachedResponse
+	invalidated []string
+}
+
+func newMemCache() *memCache {
+	return &memCache{entries: map[string]*domain.CachedResponse{}}
+}
+
+func (m *memCache) Get(resource, key string) (*domain.CachedResponse, error) {
+	return m.entries[resource+"/"+key], nil
+}
+
+func (m *memCache) Put(resource, key string, r *domain.CachedResponse) error {
+	m.entries[resource+"/"+key] = r
+	return nil
+}
+
+func (m *memCache) Invalidate(resource string) error {
+	m.invalidated = append(m.invalidated, resource)
+	for k := range m.entries {
+		if len(k) > len(resource) && k[:len(resource)+1] == resource+"/" {
+			delete(m.entries, k)
+		}
+	}
+	return nil
+}
+
+func TestCacheResource(t *testing.T) {
+	tests := map[string]string{
+		"https://api.us.nylas.com/v3/grants":                          "grants",
+		"https://api.us.nylas.com/v3/grants/g1":                       "grants",
+		"https://api.us.nylas.com/v3/grants/g1/folders":               "folders",
+		"https://api.us.nylas.com/v3/grants/g1/calendars/primary":     "calendars",
+		"https://api.us.nylas.com/v3/grants/g1/contacts?limit=5":      "contacts",
+		"https://api.us.nylas.com/v3/grants/g1/messages":              "",
+		"https://api.us.nylas.com/v3/applications":                    "",
+		"https://api.us.nylas.com/v3/grants/g1/calendars/x/free-busy": "calendars",
+	}
+	for url, want := range tests {
+		assert.Equal(t, want, cacheResource(url), url)
+	}
+}
+
+func TestHTTPClient_ResponseCache(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	origNow := cacheNow
+	cacheNow = func() time.Time { return now }
+	t.Cleanup(func() { cacheNow = origNow })
+
+	var requests int
+	var lastIfNoneMatch string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":{"id":"new","name":"New"}}`))
+			return
+		}
+		requests++
+		lastIfNoneMatch = r.Header.Get("If-None-Match")
+		if lastIfNoneMatch == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"inbox","name":"INBOX"}]}`))
+	}))
+	defer server.Close()
+
+	cache := newMemCache()
+	client := NewHTTPClient()
+	client.SetCredentials("", "", "api-key")
+	client.SetBaseURL(server.URL)
+	client.SetResponseCache(cache, &domain.CacheConfig{FoldersTTL: "10m"})
+	ctx := context.Background()
+
+	folders, err := client.GetFolders(ctx, "g1")
+	require.NoError(t, err)
+	require.Len(t, folders, 1)
+	assert.Equal(t, 1, requests)
+
+	// Fresh: served from cache without a request.
+	folders, err = client.GetFolders(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "inbox", folders[0].ID)
+	assert.Equal(t, 1, requests)
+
+	// Stale: revalidated with If-None-Match; 304 reuses the body.
+	now = now.Add(11 * time.Minute)
+	folders, err = client.GetFolders(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, "inbox", folders[0].ID)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, `"v1"`, lastIfNoneMatch)
+
+	// Writes drop the resource's entries.
+	_, err = client.CreateFolder(ctx, "g1", &domain.CreateFolderRequest{Name: "New"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"folders"}, cache.invalidated)
+	_, err = client.GetFolders(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, requests)
+	assert.Empty(t, lastIfNoneMatch)
+
+	// A different API key never sees these entries.
+	client.SetCredentials("", "", "other-key")
+	_, err = client.GetFolders(ctx, "g1")
+	require.NoError(t, err)
+	assert.Equal(t, 4, requests)
+}
+
+func TestHTTPClient_ResponseCacheSkipsUncachedResources(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	cache := newMemCache()
+	client := NewHTTPClient()
+	client.SetBaseURL(server.URL)
+	client.SetResponseCache(cache, &domain.CacheConfig{CalendarsTTL: "0"})
+
+	for range 2 {
+		_, err := client.GetCalendars(context.Background(), "g1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, requests)
+	assert.Empty(t, cache.entries)
+}
+
+func TestHTTPClient_ServeStale(t *testing.T) {
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	origNow := cacheNow
+	cacheNow = func() time.Time { return now }
+	t.Cleanup(func() { cacheNow = origNow })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"inbox","name":"INBOX"}]}`))
+	}))
+
+	client := NewHTTPClient()
+	client.SetCredentials("", "", "api-key")
+	client.SetBaseURL(server.URL)
+	client.SetMaxRetries(0)
+	client.SetResponseCache(newMemCache(), &domain.CacheConfig{FoldersTTL: "10m"})
+	ctx := context.Background()
+
+	_, err := client.GetFolders(ctx, "g1")
+	require.NoError(t, err)
+	server.Close()
+	now = now.Add(time.Hour)
+
+	_, err = client.GetFolders(ctx, "g1")
+	assert.Error(t, err, "stale entries aren't used by default")
+
+	client.SetServeStale(true)
+	folders, err := client.GetFolders(ctx, "g1")
+	require.NoError(t, err)
+	require.Len(t, folders, 1)
+	assert.Equal(t, "inbox", folders[0].ID)
+}
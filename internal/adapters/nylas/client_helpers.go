@@ -51,35 +51,7 @@ type ListResponse[T any] struct {
 //	    return nil, err
 //	}
 func (c *HTTPClient) doGet(ctx context.Context, url string, result any) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return err
-	}
-	c.setAuthHeader(req)
-
-	resp, err := c.doRequest(ctx, req)
-	if err != nil {
-		return fmt.Errorf("%w: %v", domain.ErrNetworkError, err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		trackAuditError(resp.StatusCode)
-		return c.parseError(resp)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
-
-	trackAuditRequest(body, resp.StatusCode)
-
-	if err := json.Unmarshal(body, result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return nil
+	return c.doGetWithNotFoundAuth(ctx, url, "", result, nil)
 }
 
 // doGetWithNotFound performs a GET request with special handling for 404.
@@ -99,20 +71,50 @@ func (c *HTTPClient) doGetWithNotFound(ctx context.Context, url string, result a
 
 // doGetWithNotFoundAuth is doGetWithNotFound with an explicit bearer token
 // (e.g. a Scheduler session token); an empty token falls back to the API key.
+// A nil notFoundErr treats 404 like any other API error.
+//
+// API-key requests for cacheable resources go through the response cache
+// when one is set: fresh entries are returned without a request, and stale
+// ones are revalidated with If-None-Match / If-Modified-Since.
 func (c *HTTPClient) doGetWithNotFoundAuth(ctx context.Context, url, token string, result any, notFoundErr error) error {
+	var resource string
+	var cached *domain.CachedResponse
+	if token == "" {
+		var fresh bool
+		resource, cached, fresh = c.cachedResponse(url)
+		if fresh {
+			if err := json.Unmarshal(cached.Body, result); err == nil {
+				return nil
+			}
+			cached = nil
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return err
 	}
 	c.setAuth(req, token)
+	addValidators(req, cached)
 
 	resp, err := c.doRequest(ctx, req)
 	if err != nil {
+		if c.staleFallback(cached, result) {
+			return nil
+		}
 		return fmt.Errorf("%w: %v", domain.ErrNetworkError, err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode == http.StatusNotFound {
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		trackAuditRequest(nil, resp.StatusCode)
+		c.storeResponse(resource, url, resp, cached.Body)
+		if err := json.Unmarshal(cached.Body, result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound && notFoundErr != nil {
 		trackAuditError(resp.StatusCode)
 		return notFoundErr
 	}
@@ -132,6 +134,7 @@ func (c *HTTPClient) doGetWithNotFoundAuth(ctx context.Context, url, token strin
 		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	c.storeResponse(resource, url, resp, body)
 	return nil
 }
 
@@ -0,0 +1,58 @@
+package nylas
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// SetRequestObserver registers fn to be called with every request the
+// client sends, before it is sent. Credentials are not passed to fn.
+func (c *HTTPClient) SetRequestObserver(fn func(domain.APIRequest)) {
+	c.observer = fn
+}
+
+// observedHeaders are left out of observed requests: credentials, and
+// headers the client adds to every request.
+var observedHeaders = map[string]bool{
+	"Authorization":     true,
+	"User-Agent":        true,
+	"If-None-Match":     true,
+	"If-Modified-Since": true,
+}
+
+func (c *HTTPClient) observeRequest(req *http.Request) {
+	if c.observer == nil {
+		return
+	}
+	r := domain.APIRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Auth:   domain.APIAuthNone,
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		r.Auth = domain.APIAuthToken
+		if c.apiKey != "" && auth == "Bearer "+c.apiKey {
+			r.Auth = domain.APIAuthAPIKey
+		}
+	}
+	for name, values := range req.Header {
+		if observedHeaders[name] || len(values) == 0 {
+			continue
+		}
+		if r.Header == nil {
+			r.Header = make(map[string]string)
+		}
+		r.Header[name] = strings.Join(values, ", ")
+	}
+	// Bodies that can't be replayed are left out rather than consumed.
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			r.Body, _ = io.ReadAll(body)
+			_ = body.Close()
+		}
+	}
+	c.observer(r)
+}
@@ -0,0 +1,47 @@
+//go:build !integration
+
+package nylas
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestRequestObserver(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"id": "f1", "name": "Receipts"}})
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+	client.SetBaseURL(server.URL)
+	client.SetCredentials("", "", "secret-key")
+	var observed []domain.APIRequest
+	client.SetRequestObserver(func(r domain.APIRequest) { observed = append(observed, r) })
+
+	_, err := client.CreateFolder(context.Background(), "grant-1", &domain.CreateFolderRequest{Name: "Receipts"})
+	require.NoError(t, err)
+
+	require.Len(t, observed, 1)
+	r := observed[0]
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, server.URL+"/v3/grants/grant-1/folders", r.URL)
+	assert.Equal(t, domain.APIAuthAPIKey, r.Auth)
+	assert.Equal(t, "application/json", r.Header["Content-Type"])
+	assert.NotContains(t, r.Header, "Authorization")
+	assert.NotContains(t, r.Header, "User-Agent")
+	assert.JSONEq(t, `{"name":"Receipts"}`, string(r.Body))
+	assert.JSONEq(t, string(r.Body), string(received), "the body is still sent")
+}
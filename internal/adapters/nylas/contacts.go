@@ -68,7 +68,8 @@ func (c *HTTPClient) GetContactsWithCursor(ctx context.Context, grantID string,
 			Add("source", params.Source).
 			Add("group", params.Group).
 			AddBool("recurse", params.Recurse).
-			AddBool("profile_picture", params.ProfilePicture)
+			AddBool("profile_picture", params.ProfilePicture).
+			Add("select", params.Select)
 	}
 	queryURL := qb.BuildURL(baseURL)
 
@@ -26,6 +26,11 @@ func (d *Client) BuildAuthURL(provider domain.Provider, redirectURI, state, code
 	return "https://demo.nylas.com/auth"
 }
 
+// BuildAuthURLWithOptions returns a mock auth URL.
+func (d *Client) BuildAuthURLWithOptions(provider domain.Provider, redirectURI, state, codeChallenge string, opts domain.AuthURLOptions) string {
+	return d.BuildAuthURL(provider, redirectURI, state, codeChallenge)
+}
+
 // ExchangeCode returns a mock grant.
 func (d *Client) ExchangeCode(ctx context.Context, code, redirectURI, codeVerifier string) (*domain.Grant, error) {
 	return &domain.Grant{
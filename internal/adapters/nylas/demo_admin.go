@@ -64,6 +64,17 @@ func (d *DemoClient) GetSchedulerSession(ctx context.Context, sessionID string)
 	}, nil
 }
 
+func (d *DemoClient) CreateBooking(ctx context.Context, configurationID string, req *domain.CreateBookingRequest) (*domain.Booking, error) {
+	return &domain.Booking{
+		BookingID:    "booking-demo-new",
+		Title:        "Demo Meeting",
+		Status:       "confirmed",
+		StartTime:    time.Unix(req.StartTime, 0),
+		EndTime:      time.Unix(req.EndTime, 0),
+		Participants: []domain.Participant{{Person: domain.Person{Name: req.Guest.Name, Email: req.Guest.Email}}},
+	}, nil
+}
+
 func (d *DemoClient) GetBooking(ctx context.Context, configurationID, bookingID string) (*domain.Booking, error) {
 	return &domain.Booking{
 		BookingID: bookingID,
@@ -291,6 +302,24 @@ func (d *DemoClient) DeleteWorkspace(ctx context.Context, workspaceID string) er
 	return nil
 }
 
+func (d *DemoClient) ListAPIKeys(ctx context.Context, appID string) ([]domain.APIKey, error) {
+	return []domain.APIKey{
+		{ID: "key-demo-1", Name: "Demo Production Key", Status: "active"},
+		{ID: "key-demo-2", Name: "Demo CI Key", Status: "revoked"},
+	}, nil
+}
+
+func (d *DemoClient) CreateAPIKey(ctx context.Context, appID string, req *domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error) {
+	return &domain.CreatedAPIKey{
+		APIKey: domain.APIKey{ID: "key-demo-new", Name: req.Name, Status: "active"},
+		Key:    "nyk_demo_key",
+	}, nil
+}
+
+func (d *DemoClient) RevokeAPIKey(ctx context.Context, appID, keyID string) error {
+	return nil
+}
+
 func (d *DemoClient) ListCredentials(ctx context.Context, connectorID string) ([]domain.ConnectorCredential, error) {
 	return []domain.ConnectorCredential{
 		{ID: "cred-demo-1", Name: "Connector Demo Credential"},
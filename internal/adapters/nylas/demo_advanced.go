@@ -25,12 +25,18 @@ func (d *DemoClient) ListAllGrants(ctx context.Context, params *domain.GrantsQue
 }
 
 func (d *DemoClient) GetGrantStats(ctx context.Context) (*domain.GrantStats, error) {
+	day := func(daysAgo int) string { return time.Now().UTC().AddDate(0, 0, -daysAgo).Format(time.DateOnly) }
 	return &domain.GrantStats{
 		Total:      10,
 		Valid:      8,
 		Invalid:    2,
 		ByProvider: map[string]int{"google": 6, "microsoft": 4},
 		ByStatus:   map[string]int{"valid": 8, "invalid": 2},
+		ByProviderStatus: map[string]map[string]int{
+			"google":    {"valid": 5, "invalid": 1},
+			"microsoft": {"valid": 3, "invalid": 1},
+		},
+		CreatedByDay: map[string]int{day(2): 2, day(20): 1, day(45): 3, day(80): 1, day(150): 3},
 	}, nil
 }
 
@@ -8,13 +8,25 @@ import (
 
 // DemoClient is a client that returns realistic demo data for screenshots and demos.
 // It implements the ports.NylasClient interface without requiring any credentials.
-type DemoClient struct{}
+type DemoClient struct {
+	// dataset replaces the built-in samples when the client is seeded.
+	dataset *demoDataset
+}
 
 // NewDemoClient creates a new DemoClient for demo mode.
 func NewDemoClient() *DemoClient {
 	return &DemoClient{}
 }
 
+// demoFirst returns up to limit items, or all of them when limit is not
+// positive, as the API does for a page.
+func demoFirst[T any](items []T, limit int) []T {
+	if limit > 0 && limit < len(items) {
+		return items[:limit]
+	}
+	return items
+}
+
 // SetRegion is a no-op for demo client.
 func (d *DemoClient) SetRegion(region string) {}
 
@@ -26,6 +38,11 @@ func (d *DemoClient) BuildAuthURL(provider domain.Provider, redirectURI, state,
 	return "https://demo.nylas.com/auth"
 }
 
+// BuildAuthURLWithOptions returns a mock auth URL.
+func (d *DemoClient) BuildAuthURLWithOptions(provider domain.Provider, redirectURI, state, codeChallenge string, opts domain.AuthURLOptions) string {
+	return d.BuildAuthURL(provider, redirectURI, state, codeChallenge)
+}
+
 // ExchangeCode returns a mock grant.
 func (d *DemoClient) ExchangeCode(ctx context.Context, code, redirectURI, codeVerifier string) (*domain.Grant, error) {
 	return &domain.Grant{
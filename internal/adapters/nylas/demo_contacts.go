@@ -8,15 +8,25 @@ import (
 
 // GetContacts returns demo contacts.
 func (d *DemoClient) GetContacts(ctx context.Context, grantID string, params *domain.ContactQueryParams) ([]domain.Contact, error) {
-	return d.getDemoContacts(), nil
+	return demoFirst(d.getDemoContacts(), contactLimit(params)), nil
 }
 
 // GetContactsWithCursor returns demo contacts with pagination.
 func (d *DemoClient) GetContactsWithCursor(ctx context.Context, grantID string, params *domain.ContactQueryParams) (*domain.ContactListResponse, error) {
-	return &domain.ContactListResponse{Data: d.getDemoContacts()}, nil
+	return &domain.ContactListResponse{Data: demoFirst(d.getDemoContacts(), contactLimit(params))}, nil
+}
+
+func contactLimit(params *domain.ContactQueryParams) int {
+	if params == nil {
+		return 0
+	}
+	return params.Limit
 }
 
 func (d *DemoClient) getDemoContacts() []domain.Contact {
+	if d.dataset != nil {
+		return d.dataset.contacts
+	}
 	return []domain.Contact{
 		{
 			ID:           "contact-001",
@@ -0,0 +1,250 @@
+package nylas
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// DemoSize selects how much data a seeded DemoClient generates.
+type DemoSize string
+
+// Demo dataset sizes.
+const (
+	DemoSizeSmall  DemoSize = "small"
+	DemoSizeMedium DemoSize = "medium"
+	DemoSizeLarge  DemoSize = "large"
+)
+
+// demoSizeCounts is how many messages, events and contacts each size has.
+var demoSizeCounts = map[DemoSize]struct{ messages, events, contacts int }{
+	DemoSizeSmall:  {messages: 25, events: 15, contacts: 20},
+	DemoSizeMedium: {messages: 250, events: 120, contacts: 150},
+	DemoSizeLarge:  {messages: 5000, events: 1500, contacts: 2000},
+}
+
+// ParseDemoSize validates a --size value.
+func ParseDemoSize(s string) (DemoSize, error) {
+	size := DemoSize(strings.ToLower(s))
+	if _, ok := demoSizeCounts[size]; !ok {
+		return "", fmt.Errorf("invalid size %q (use small, medium or large)", s)
+	}
+	return size, nil
+}
+
+// demoDataset is data generated from a seed.
+type demoDataset struct {
+	messages []domain.Message
+	threads  []domain.Thread
+	events   []domain.Event
+	contacts []domain.Contact
+}
+
+// NewSeededDemoClient creates a DemoClient whose mailbox, calendar and
+// contacts are generated from seed. The same seed and size always produce
+// the same data; dates are placed around the start of the current UTC day,
+// so they only move when the day changes.
+func NewSeededDemoClient(seed int64, size DemoSize) *DemoClient {
+	counts, ok := demoSizeCounts[size]
+	if !ok {
+		counts = demoSizeCounts[DemoSizeSmall]
+	}
+	g := &demoGenerator{
+		rng:    rand.New(rand.NewPCG(uint64(seed), 0x6e796c6173)),
+		anchor: time.Now().UTC().Truncate(24 * time.Hour),
+	}
+
+	people := g.people(counts.contacts)
+	data := &demoDataset{contacts: g.contacts(people)}
+	data.messages, data.threads = g.mailbox(counts.messages, people)
+	data.events = g.events(counts.events, people)
+	return &DemoClient{dataset: data}
+}
+
+// demoGenerator builds a dataset from a single random stream. Every value
+// is drawn in a fixed order, so changing the order changes every seed's
+// output.
+type demoGenerator struct {
+	rng    *rand.Rand
+	anchor time.Time
+}
+
+type demoPerson struct {
+	given, surname, email, company, title string
+}
+
+func (g *demoGenerator) pick(options []string) string {
+	return options[g.rng.IntN(len(options))]
+}
+
+func (g *demoGenerator) people(n int) []demoPerson {
+	people := make([]demoPerson, n)
+	for i := range people {
+		given, surname := g.pick(demoGivenNames), g.pick(demoSurnames)
+		company := g.pick(demoCompanies)
+		people[i] = demoPerson{
+			given:   given,
+			surname: surname,
+			email: fmt.Sprintf("%s.%s%d@%s.example.com",
+				strings.ToLower(given), strings.ToLower(surname), i+1, strings.ToLower(strings.ReplaceAll(company, " ", ""))),
+			company: company,
+			title:   g.pick(demoJobTitles),
+		}
+	}
+	return people
+}
+
+// fill picks a template and, when it has a %s, a topic to put in it.
+func (g *demoGenerator) fill(templates []string) string {
+	t := g.pick(templates)
+	if !strings.Contains(t, "%s") {
+		return t
+	}
+	return fmt.Sprintf(t, g.pick(demoTopics))
+}
+
+func (p demoPerson) name() string { return p.given + " " + p.surname }
+
+func (g *demoGenerator) contacts(people []demoPerson) []domain.Contact {
+	contacts := make([]domain.Contact, len(people))
+	for i, p := range people {
+		contacts[i] = domain.Contact{
+			ID:           fmt.Sprintf("contact-%03d", i+1),
+			GivenName:    p.given,
+			Surname:      p.surname,
+			Emails:       []domain.ContactEmail{{Email: p.email, Type: "work"}},
+			PhoneNumbers: []domain.ContactPhone{{Number: fmt.Sprintf("+1-555-%04d", g.rng.IntN(10000)), Type: "mobile"}},
+			CompanyName:  p.company,
+			JobTitle:     p.title,
+		}
+	}
+	return contacts
+}
+
+// mailbox generates messages newest first, grouped into threads of one to
+// four messages.
+func (g *demoGenerator) mailbox(n int, people []demoPerson) ([]domain.Message, []domain.Thread) {
+	me := domain.EmailParticipant{Name: "Demo User", Email: "demo@example.com"}
+	messages := make([]domain.Message, 0, n)
+	var threads []domain.Thread
+
+	at := g.anchor
+	for len(messages) < n {
+		sender := people[g.rng.IntN(len(people))]
+		from := domain.EmailParticipant{Name: sender.name(), Email: sender.email}
+		subject := g.fill(demoSubjects)
+		threadID := fmt.Sprintf("thread-%03d", len(threads)+1)
+		thread := domain.Thread{
+			ID:           threadID,
+			Subject:      subject,
+			Participants: []domain.EmailParticipant{from, me},
+		}
+
+		replies := min(1+g.rng.IntN(4), n-len(messages))
+		for r := range replies {
+			at = at.Add(-time.Duration(5+g.rng.IntN(240)) * time.Minute)
+			// Walking back in time, so the last message is the original.
+			k := replies - 1 - r
+			msgSubject, msgFrom, msgTo := subject, from, me
+			if k > 0 {
+				msgSubject = "Re: " + subject
+			}
+			if k%2 == 1 {
+				msgFrom, msgTo = me, from
+			}
+			body := fmt.Sprintf("Hi %s,\n\n%s\n\n%s", strings.Fields(msgTo.Name)[0], g.pick(demoBodies), strings.Fields(msgFrom.Name)[0])
+			msg := domain.Message{
+				ID:       fmt.Sprintf("msg-%03d", len(messages)+1),
+				ThreadID: threadID,
+				Subject:  msgSubject,
+				From:     []domain.EmailParticipant{msgFrom},
+				To:       []domain.EmailParticipant{msgTo},
+				Date:     at,
+				Unread:   g.rng.IntN(3) == 0 && msgFrom != me,
+				Starred:  g.rng.IntN(8) == 0,
+				Snippet:  demoSnippet(body),
+				Body:     body,
+			}
+			messages = append(messages, msg)
+
+			thread.MessageIDs = append(thread.MessageIDs, msg.ID)
+			thread.Unread = thread.Unread || msg.Unread
+			thread.Starred = thread.Starred || msg.Starred
+			if r == 0 {
+				thread.LatestMessageRecvDate, thread.Snippet = msg.Date, msg.Snippet
+			}
+			thread.EarliestMessageDate = msg.Date
+		}
+		threads = append(threads, thread)
+	}
+	return messages, threads
+}
+
+// events spreads events over working hours, mostly after the anchor day.
+func (g *demoGenerator) events(n int, people []demoPerson) []domain.Event {
+	calendars := []string{"primary", "work", "family"}
+	days := max(7, n/3)
+
+	events := make([]domain.Event, n)
+	for i := range events {
+		day := g.anchor.AddDate(0, 0, g.rng.IntN(days)-days/4)
+		begin := day.Add(time.Duration(8*60+15*g.rng.IntN(36)) * time.Minute)
+		duration := time.Duration(15*(1+g.rng.IntN(8))) * time.Minute
+
+		participants := []domain.Participant{{Person: domain.Person{Name: "Demo User", Email: "demo@example.com"}, Status: "yes"}}
+		for range g.rng.IntN(4) {
+			p := people[g.rng.IntN(len(people))]
+			participants = append(participants, domain.Participant{
+				Person: domain.Person{Name: p.name(), Email: p.email},
+				Status: g.pick([]string{"yes", "no", "maybe", "noreply"}),
+			})
+		}
+
+		events[i] = domain.Event{
+			CalendarID:   calendars[g.rng.IntN(len(calendars))],
+			Title:        g.fill(demoEventTitles),
+			When:         domain.EventWhen{StartTime: begin.Unix(), EndTime: begin.Add(duration).Unix()},
+			Status:       "confirmed",
+			Location:     g.pick(demoLocations),
+			Participants: participants,
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b domain.Event) int { return cmp.Compare(a.When.StartTime, b.When.StartTime) })
+	for i := range events {
+		events[i].ID = fmt.Sprintf("event-%03d", i+1)
+	}
+	return events
+}
+
+func demoSnippet(body string) string {
+	s := strings.Join(strings.Fields(body), " ")
+	if len(s) > 90 {
+		s = s[:87] + "..."
+	}
+	return s
+}
+
+var (
+	demoGivenNames = []string{"Sarah", "Mike", "Emily", "David", "Priya", "James", "Aisha", "Carlos", "Mei", "Olivia", "Noah", "Fatima", "Lucas", "Hana", "Ethan", "Sofia", "Omar", "Grace", "Leo", "Zoe"}
+	demoSurnames   = []string{"Chen", "Johnson", "Williams", "Patel", "Garcia", "Kim", "Nguyen", "Okafor", "Rossi", "Schmidt", "Silva", "Tanaka", "Brown", "Cohen", "Dubois", "Singh"}
+	demoCompanies  = []string{"Acme Corp", "Globex", "Initech", "Umbrella Labs", "Stark Industries", "Wayne Enterprises", "Hooli", "Vandelay"}
+	demoJobTitles  = []string{"Engineering Manager", "Product Designer", "Account Executive", "Software Engineer", "Head of Sales", "Recruiter", "Data Scientist", "Customer Success Lead", "CFO", "Marketing Director"}
+	demoTopics     = []string{"Q3 roadmap", "onboarding", "the launch", "budget review", "hiring plan", "API migration", "customer feedback", "the offsite", "security audit", "pricing update"}
+	demoSubjects   = []string{"Quick question about %s", "Notes from %s", "Follow-up: %s", "Action items for %s", "%s - draft for review", "Can we move %s?", "Update on %s"}
+	demoBodies     = []string{
+		"Thanks for the update. I've added my comments to the doc; the main open question is timing.",
+		"Could you take a look before Thursday? I want to share it with the wider team next week.",
+		"Attaching the latest numbers. Overall we're tracking ahead of plan, with a couple of risks to discuss.",
+		"Let's sync for fifteen minutes tomorrow. I have a few ideas on how to simplify the rollout.",
+		"Circling back on this. Are we still good for the date we agreed on?",
+		"Great work on this so far. Two small suggestions inline, otherwise ready to go.",
+	}
+	demoEventTitles = []string{"Standup", "1:1", "Review: %s", "Planning: %s", "Sync on %s", "Interview", "Lunch", "Demo: %s", "Retro"}
+	demoLocations   = []string{"Conference Room A", "Conference Room B", "Google Meet", "Zoom", "Cafe downstairs", ""}
+)
@@ -0,0 +1,76 @@
+//go:build !integration
+// +build !integration
+
+package nylas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseDemoSize(t *testing.T) {
+	for _, s := range []string{"small", "medium", "LARGE"} {
+		if _, err := ParseDemoSize(s); err != nil {
+			t.Errorf("ParseDemoSize(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseDemoSize("huge"); err == nil {
+		t.Error("ParseDemoSize(huge) error = nil, want error")
+	}
+}
+
+func TestSeededDemoClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("same_seed_same_data", func(t *testing.T) {
+		a, b := NewSeededDemoClient(42, DemoSizeSmall), NewSeededDemoClient(42, DemoSizeSmall)
+		if !reflect.DeepEqual(a.dataset, b.dataset) {
+			t.Error("Expected identical datasets for the same seed")
+		}
+
+		c := NewSeededDemoClient(43, DemoSizeSmall)
+		if reflect.DeepEqual(a.dataset.messages, c.dataset.messages) {
+			t.Error("Expected different messages for a different seed")
+		}
+	})
+
+	t.Run("size_sets_counts", func(t *testing.T) {
+		client := NewSeededDemoClient(1, DemoSizeMedium)
+		messages, _ := client.GetMessages(ctx, "demo-grant", 0)
+		events, _ := client.GetEvents(ctx, "demo-grant", "primary", nil)
+		contacts, _ := client.GetContacts(ctx, "demo-grant", nil)
+
+		want := demoSizeCounts[DemoSizeMedium]
+		if len(messages) != want.messages || len(events) != want.events || len(contacts) != want.contacts {
+			t.Errorf("got %d messages, %d events, %d contacts; want %d, %d, %d",
+				len(messages), len(events), len(contacts), want.messages, want.events, want.contacts)
+		}
+	})
+
+	t.Run("threads_cover_messages", func(t *testing.T) {
+		client := NewSeededDemoClient(7, DemoSizeSmall)
+		messages, _ := client.GetMessages(ctx, "demo-grant", 0)
+		threads, _ := client.GetThreads(ctx, "demo-grant", nil)
+
+		inThread := map[string]string{}
+		for _, th := range threads {
+			for _, id := range th.MessageIDs {
+				inThread[id] = th.ID
+			}
+		}
+		for i, msg := range messages {
+			if inThread[msg.ID] != msg.ThreadID {
+				t.Errorf("message %s has thread %q, listed under %q", msg.ID, msg.ThreadID, inThread[msg.ID])
+			}
+			if i > 0 && msg.Date.After(messages[i-1].Date) {
+				t.Errorf("message %s is newer than the one before it", msg.ID)
+			}
+		}
+
+		msg, err := client.GetMessage(ctx, "demo-grant", messages[3].ID)
+		if err != nil || msg.ID != messages[3].ID {
+			t.Errorf("GetMessage(%s) = %v, %v", messages[3].ID, msg, err)
+		}
+	})
+}
@@ -9,12 +9,12 @@ import (
 
 // GetEvents returns demo events.
 func (d *DemoClient) GetEvents(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) ([]domain.Event, error) {
-	return d.getDemoEvents(), nil
+	return demoFirst(d.getDemoEvents(), eventLimit(params)), nil
 }
 
 // GetEventsWithCursor returns demo events with pagination.
 func (d *DemoClient) GetEventsWithCursor(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
-	return &domain.EventListResponse{Data: d.getDemoEvents()}, nil
+	return &domain.EventListResponse{Data: demoFirst(d.getDemoEvents(), eventLimit(params))}, nil
 }
 
 // ImportEvents returns demo events for bulk import/export.
@@ -22,7 +22,17 @@ func (d *DemoClient) ImportEvents(ctx context.Context, grantID string, params *d
 	return d.getDemoEvents(), nil
 }
 
+func eventLimit(params *domain.EventQueryParams) int {
+	if params == nil {
+		return 0
+	}
+	return params.Limit
+}
+
 func (d *DemoClient) getDemoEvents() []domain.Event {
+	if d.dataset != nil {
+		return d.dataset.events
+	}
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
@@ -9,22 +9,32 @@ import (
 
 // GetMessages returns demo messages.
 func (d *DemoClient) GetMessages(ctx context.Context, grantID string, limit int) ([]domain.Message, error) {
-	return d.getDemoMessages(), nil
+	return demoFirst(d.getDemoMessages(), limit), nil
 }
 
 // GetMessagesWithParams returns demo messages.
 func (d *DemoClient) GetMessagesWithParams(ctx context.Context, grantID string, params *domain.MessageQueryParams) ([]domain.Message, error) {
-	return d.getDemoMessages(), nil
+	return demoFirst(d.getDemoMessages(), messageLimit(params)), nil
 }
 
 // GetMessagesWithCursor returns demo messages with pagination.
 func (d *DemoClient) GetMessagesWithCursor(ctx context.Context, grantID string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
 	return &domain.MessageListResponse{
-		Data: d.getDemoMessages(),
+		Data: demoFirst(d.getDemoMessages(), messageLimit(params)),
 	}, nil
 }
 
+func messageLimit(params *domain.MessageQueryParams) int {
+	if params == nil {
+		return 0
+	}
+	return params.Limit
+}
+
 func (d *DemoClient) getDemoMessages() []domain.Message {
+	if d.dataset != nil {
+		return d.dataset.messages
+	}
 	now := time.Now()
 	return []domain.Message{
 		{
@@ -201,3 +201,16 @@ func (d *DemoClient) GetNotetakerMedia(ctx context.Context, grantID, notetakerID
 		},
 	}, nil
 }
+
+// GetNotetakerTranscript returns a demo transcript.
+func (d *DemoClient) GetNotetakerTranscript(ctx context.Context, grantID, notetakerID string) (*domain.NotetakerTranscript, error) {
+	return &domain.NotetakerTranscript{
+		Type: "speaker_labelled",
+		Segments: []domain.TranscriptSegment{
+			{Speaker: "Alice Johnson", Start: 0, End: 6200, Text: "Let's review where the Q4 launch stands."},
+			{Speaker: "Bob Smith", Start: 6200, End: 14800, Text: "The API docs still need an update. I'll have that done by Friday."},
+			{Speaker: "Alice Johnson", Start: 14800, End: 21500, Text: "Great. Carol, can you schedule the design review for next week?"},
+			{Speaker: "Carol White", Start: 21500, End: 25100, Text: "Sure, I'll send the invite today."},
+		},
+	}, nil
+}
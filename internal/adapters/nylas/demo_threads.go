@@ -9,17 +9,27 @@ import (
 
 // GetThreads returns demo threads.
 func (d *DemoClient) GetThreads(ctx context.Context, grantID string, params *domain.ThreadQueryParams) ([]domain.Thread, error) {
-	return d.getDemoThreads(), nil
+	return demoFirst(d.getDemoThreads(), threadLimit(params)), nil
 }
 
 // GetThreadsWithCursor returns demo threads with pagination.
 func (d *DemoClient) GetThreadsWithCursor(ctx context.Context, grantID string, params *domain.ThreadQueryParams) (*domain.ThreadListResponse, error) {
 	return &domain.ThreadListResponse{
-		Data: d.getDemoThreads(),
+		Data: demoFirst(d.getDemoThreads(), threadLimit(params)),
 	}, nil
 }
 
+func threadLimit(params *domain.ThreadQueryParams) int {
+	if params == nil {
+		return 0
+	}
+	return params.Limit
+}
+
 func (d *DemoClient) getDemoThreads() []domain.Thread {
+	if d.dataset != nil {
+		return d.dataset.threads
+	}
 	now := time.Now()
 	return []domain.Thread{
 		{
@@ -153,8 +153,8 @@ func (c *HTTPClient) createDraftWithJSON(ctx context.Context, grantID string, re
 	return &draft, nil
 }
 
-// doMultipartDraft sends a multipart/form-data draft request and decodes the
-// response into out. Used by both createDraftWithMultipart and updateDraftWithMultipart.
+// doMultipartDraft sends a multipart/form-data message or draft request and decodes the
+// response into out. Used by the draft create/update paths and SendMessage.
 func (c *HTTPClient) doMultipartDraft(ctx context.Context, method, url string, payload map[string]any, attachments []domain.Attachment, out any, acceptedStatuses ...int) error {
 	// Create multipart form
 	var buf bytes.Buffer
@@ -175,9 +175,14 @@ func (c *HTTPClient) doMultipartDraft(ctx context.Context, method, url string, p
 			continue // Skip attachments without content
 		}
 
-		// Create form file with proper headers
+		// Inline parts are named after their content ID, which is how the
+		// API links them to cid: references in the HTML body.
+		name := fmt.Sprintf("file%d", i)
+		if att.IsInline && att.ContentID != "" {
+			name = att.ContentID
+		}
 		h := make(textproto.MIMEHeader)
-		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file%d"; filename="%s"`, i, att.Filename))
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, name, att.Filename))
 		if att.ContentType != "" {
 			h.Set("Content-Type", att.ContentType)
 		} else {
@@ -206,7 +211,7 @@ func (c *HTTPClient) doMultipartDraft(ctx context.Context, method, url string, p
 
 	resp, err := c.doRequest(ctx, httpReq)
 	if err != nil {
-		return fmt.Errorf("%w: %v", domain.ErrNetworkError, err)
+		return fmt.Errorf("%w: %w", domain.ErrNetworkError, err)
 	}
 
 	if !slices.Contains(acceptedStatuses, resp.StatusCode) {
@@ -539,3 +539,37 @@ func TestHTTPClient_UpdateDraft_ErrorResponse(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, draft)
 }
+
+func TestHTTPClient_UpdateDraft_InlineAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/v3/grants/grant-123/drafts/draft-1", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(10<<20))
+
+		require.Contains(t, r.MultipartForm.File, "logo")
+		assert.Equal(t, "logo.png", r.MultipartForm.File["logo"][0].Filename)
+		require.Contains(t, r.MultipartForm.File, "file1")
+		assert.Equal(t, "report.pdf", r.MultipartForm.File["file1"][0].Filename)
+
+		var msg map[string]any
+		require.NoError(t, json.Unmarshal([]byte(r.FormValue("message")), &msg))
+		assert.Contains(t, msg["body"], `src="cid:logo"`)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"id": "draft-1"}})
+	}))
+	defer server.Close()
+
+	client := nylas.NewHTTPClient()
+	client.SetCredentials("client-id", "secret", "api-key")
+	client.SetBaseURL(server.URL)
+
+	_, err := client.UpdateDraft(context.Background(), "grant-123", "draft-1", &domain.CreateDraftRequest{
+		Body: `<p>Hi</p><img src="cid:logo">`,
+		Attachments: []domain.Attachment{
+			{Filename: "logo.png", ContentType: "image/png", ContentID: "logo", IsInline: true, Content: []byte("PNG")},
+			{Filename: "report.pdf", ContentType: "application/pdf", Content: []byte("PDF")},
+		},
+	})
+	require.NoError(t, err)
+}
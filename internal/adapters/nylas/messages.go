@@ -110,9 +110,11 @@ func (c *HTTPClient) GetMessagesWithCursor(ctx context.Context, grantID string,
 		AddInt64("received_before", params.ReceivedBefore).
 		AddInt64("received_after", params.ReceivedAfter).
 		Add("q", params.SearchQuery).
+		Add("search_query_native", params.NativeQuery).
 		AddSlice("in", params.In).
 		Add("fields", params.Fields).
 		Add("metadata_pair", params.MetadataPair).
+		Add("select", params.Select).
 		BuildURL(baseURL)
 
 	var result struct {
@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/util"
@@ -56,6 +57,19 @@ func buildSendMessagePayload(req *domain.SendMessageRequest, includeSignature bo
 func (c *HTTPClient) SendMessage(ctx context.Context, grantID string, req *domain.SendMessageRequest) (*domain.Message, error) {
 	queryURL := fmt.Sprintf("%s/v3/grants/%s/messages/send", c.baseURL, url.PathEscape(grantID))
 
+	// Attachments with content are uploaded as multipart form files.
+	if slices.ContainsFunc(req.Attachments, func(a domain.Attachment) bool { return len(a.Content) > 0 }) {
+		var result struct {
+			Data messageResponse `json:"data"`
+		}
+		if err := c.doMultipartDraft(ctx, "POST", queryURL, buildSendMessagePayload(req, true), req.Attachments, &result,
+			http.StatusOK, http.StatusCreated, http.StatusAccepted); err != nil {
+			return nil, err
+		}
+		msg := convertMessage(result.Data)
+		return &msg, nil
+	}
+
 	resp, err := c.doJSONRequest(ctx, "POST", queryURL, buildSendMessagePayload(req, true), http.StatusOK, http.StatusCreated, http.StatusAccepted)
 	if err != nil {
 		return nil, err
@@ -188,6 +188,43 @@ func TestHTTPClient_SendMessage(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_SendMessage_WithAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Content-Type"), "multipart/form-data")
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+
+		var message map[string]any
+		require.NoError(t, json.Unmarshal([]byte(r.FormValue("message")), &message))
+		assert.Equal(t, "Fwd: Report", message["subject"])
+
+		file, header, err := r.FormFile("file0")
+		require.NoError(t, err)
+		defer func() { _ = file.Close() }()
+		assert.Equal(t, "report.pdf", header.Filename)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"id": "sent-with-file", "subject": "Fwd: Report"},
+		})
+	}))
+	defer server.Close()
+
+	client := nylas.NewHTTPClient()
+	client.SetCredentials("client-id", "secret", "api-key")
+	client.SetBaseURL(server.URL)
+
+	msg, err := client.SendMessage(context.Background(), "grant-123", &domain.SendMessageRequest{
+		Subject: "Fwd: Report",
+		Body:    "See attached",
+		To:      []domain.EmailParticipant{{Email: "to@example.com"}},
+		Attachments: []domain.Attachment{
+			{Filename: "report.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sent-with-file", msg.ID)
+}
+
 func TestHTTPClient_SendMessage_ErrorHandling(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -174,6 +174,26 @@ func TestHTTPClient_GetMessagesWithParams(t *testing.T) {
 				"q": "meeting notes",
 			},
 		},
+		{
+			name: "includes native search query",
+			params: &domain.MessageQueryParams{
+				Limit:       10,
+				NativeQuery: "from:alice has:attachment",
+			},
+			wantQuery: map[string]string{
+				"search_query_native": "from:alice has:attachment",
+			},
+		},
+		{
+			name: "includes selected fields",
+			params: &domain.MessageQueryParams{
+				Limit:  200,
+				Select: "id",
+			},
+			wantQuery: map[string]string{
+				"select": "id",
+			},
+		},
 		{
 			name: "includes folder filter",
 			params: &domain.MessageQueryParams{
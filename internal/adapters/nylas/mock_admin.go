@@ -172,6 +172,32 @@ func (m *MockClient) DeleteWorkspace(ctx context.Context, workspaceID string) er
 	return nil
 }
 
+func (m *MockClient) ListAPIKeys(ctx context.Context, appID string) ([]domain.APIKey, error) {
+	if m.ListAPIKeysFunc != nil {
+		return m.ListAPIKeysFunc(ctx, appID)
+	}
+	return []domain.APIKey{
+		{ID: "key-1", Name: "Production", Status: "active"},
+	}, nil
+}
+
+func (m *MockClient) CreateAPIKey(ctx context.Context, appID string, req *domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error) {
+	if m.CreateAPIKeyFunc != nil {
+		return m.CreateAPIKeyFunc(ctx, appID, req)
+	}
+	return &domain.CreatedAPIKey{
+		APIKey: domain.APIKey{ID: "new-key", Name: req.Name, Status: "active"},
+		Key:    "nyk_mock_new_key",
+	}, nil
+}
+
+func (m *MockClient) RevokeAPIKey(ctx context.Context, appID, keyID string) error {
+	if m.RevokeAPIKeyFunc != nil {
+		return m.RevokeAPIKeyFunc(ctx, appID, keyID)
+	}
+	return nil
+}
+
 func (m *MockClient) ListCredentials(ctx context.Context, connectorID string) ([]domain.ConnectorCredential, error) {
 	return []domain.ConnectorCredential{
 		{ID: "cred-1", Name: "Connector Credential"},
@@ -78,6 +78,7 @@ type MockClient struct {
 	LastRedirectURI             string
 	LastAuthState               string
 	LastCodeChallenge           string
+	LastAuthURLOptions          domain.AuthURLOptions
 	LastCodeVerifier            string
 	LastMessageID               string
 	LastSignatureID             string
@@ -169,6 +170,17 @@ type MockClient struct {
 
 	// Contact functions
 	GetContactsFunc func(ctx context.Context, grantID string, params *domain.ContactQueryParams) ([]domain.Contact, error)
+
+	// API key functions
+	ListAPIKeysFunc  func(ctx context.Context, appID string) ([]domain.APIKey, error)
+	CreateAPIKeyFunc func(ctx context.Context, appID string, req *domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error)
+	RevokeAPIKeyFunc func(ctx context.Context, appID, keyID string) error
+
+	// Scheduler functions
+	CreateBookingFunc func(ctx context.Context, configurationID string, req *domain.CreateBookingRequest) (*domain.Booking, error)
+
+	// Notetaker functions
+	GetNotetakerTranscriptFunc func(ctx context.Context, grantID, notetakerID string) (*domain.NotetakerTranscript, error)
 }
 
 // NewMockClient creates a new MockClient.
@@ -199,3 +211,9 @@ func (m *MockClient) BuildAuthURL(provider domain.Provider, redirectURI, state,
 	}
 	return "https://mock.nylas.com/auth"
 }
+
+// BuildAuthURLWithOptions records the options and returns a mock auth URL.
+func (m *MockClient) BuildAuthURLWithOptions(provider domain.Provider, redirectURI, state, codeChallenge string, opts domain.AuthURLOptions) string {
+	m.LastAuthURLOptions = opts
+	return m.BuildAuthURL(provider, redirectURI, state, codeChallenge)
+}
@@ -78,6 +78,9 @@ func (m *MockClient) UpdateContact(ctx context.Context, grantID, contactID strin
 		contact.Surname = *req.Surname
 	}
 	contact.Emails = req.Emails
+	if req.Groups != nil {
+		contact.Groups = *req.Groups
+	}
 	return contact, nil
 }
 
@@ -80,4 +80,18 @@ func (m *MockClient) GetNotetakerMedia(ctx context.Context, grantID, notetakerID
 	}, nil
 }
 
+// GetNotetakerTranscript retrieves a notetaker's transcript.
+func (m *MockClient) GetNotetakerTranscript(ctx context.Context, grantID, notetakerID string) (*domain.NotetakerTranscript, error) {
+	if m.GetNotetakerTranscriptFunc != nil {
+		return m.GetNotetakerTranscriptFunc(ctx, grantID, notetakerID)
+	}
+	return &domain.NotetakerTranscript{
+		Type: "speaker_labelled",
+		Segments: []domain.TranscriptSegment{
+			{Speaker: "Alice", Start: 0, End: 4000, Text: "Thanks for joining."},
+			{Speaker: "Bob", Start: 4000, End: 9000, Text: "I'll send the proposal by Friday."},
+		},
+	}, nil
+}
+
 // Scheduler Mock Implementations
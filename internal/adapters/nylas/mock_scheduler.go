@@ -62,6 +62,19 @@ func (m *MockClient) GetSchedulerSession(ctx context.Context, sessionID string)
 	}, nil
 }
 
+func (m *MockClient) CreateBooking(ctx context.Context, configurationID string, req *domain.CreateBookingRequest) (*domain.Booking, error) {
+	if m.CreateBookingFunc != nil {
+		return m.CreateBookingFunc(ctx, configurationID, req)
+	}
+	return &domain.Booking{
+		BookingID:    "booking-new",
+		Status:       "confirmed",
+		StartTime:    time.Unix(req.StartTime, 0),
+		EndTime:      time.Unix(req.EndTime, 0),
+		Participants: []domain.Participant{{Person: domain.Person{Name: req.Guest.Name, Email: req.Guest.Email}}},
+	}, nil
+}
+
 func (m *MockClient) GetBooking(ctx context.Context, configurationID, bookingID string) (*domain.Booking, error) {
 	return &domain.Booking{
 		BookingID: bookingID,
@@ -0,0 +1,81 @@
+package nylas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// maxTranscriptSize caps how much of a transcript file is read.
+const maxTranscriptSize = 32 << 20
+
+// GetNotetakerTranscript downloads and parses a notetaker's transcript.
+// The media URL is pre-signed, so the download carries no API key.
+func (c *HTTPClient) GetNotetakerTranscript(ctx context.Context, grantID, notetakerID string) (*domain.NotetakerTranscript, error) {
+	media, err := c.GetNotetakerMedia(ctx, grantID, notetakerID)
+	if err != nil {
+		return nil, err
+	}
+	if media.Transcript == nil || media.Transcript.URL == "" {
+		return nil, domain.ErrTranscriptNotReady
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", media.Transcript.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doRequest(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNetworkError, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseError(resp)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTranscriptSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading transcript: %w", err)
+	}
+	return parseNotetakerTranscript(body)
+}
+
+// parseNotetakerTranscript accepts the JSON transcript format, whose
+// "transcript" field is a list of speaker-labelled segments or, for raw
+// transcripts, a single string. Anything else is treated as plain text.
+func parseNotetakerTranscript(body []byte) (*domain.NotetakerTranscript, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, domain.ErrTranscriptNotReady
+	}
+	if trimmed[0] != '{' {
+		return &domain.NotetakerTranscript{Type: "raw", Segments: []domain.TranscriptSegment{{Text: string(trimmed)}}}, nil
+	}
+
+	var raw struct {
+		Type       string          `json:"type"`
+		Transcript json.RawMessage `json:"transcript"`
+	}
+	if err := json.Unmarshal(trimmed, &raw); err != nil {
+		return nil, fmt.Errorf("decoding transcript: %w", err)
+	}
+
+	transcript := &domain.NotetakerTranscript{Type: raw.Type}
+	var text string
+	if err := json.Unmarshal(raw.Transcript, &text); err == nil {
+		if strings.TrimSpace(text) != "" {
+			transcript.Segments = []domain.TranscriptSegment{{Text: text}}
+		}
+		return transcript, nil
+	}
+	if err := json.Unmarshal(raw.Transcript, &transcript.Segments); err != nil {
+		return nil, fmt.Errorf("decoding transcript: %w", err)
+	}
+	return transcript, nil
+}
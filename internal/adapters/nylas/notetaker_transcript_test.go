@@ -0,0 +1,97 @@
+//go:build !integration
+// +build !integration
+
+package nylas_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTranscriptServer(t *testing.T, transcript string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/grants/grant-1/notetakers/nt-1/media":
+			data := map[string]any{}
+			if transcript != "" {
+				data["transcript"] = map[string]any{"url": server.URL + "/files/transcript", "content_type": "application/json"}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"request_id": "req-1", "data": data})
+		case "/files/transcript":
+			// Pre-signed media URLs must not receive the API key.
+			assert.Empty(t, r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(transcript))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPClient_GetNotetakerTranscript(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantType   string
+		wantResult []domain.TranscriptSegment
+	}{
+		{
+			name:     "speaker labelled",
+			body:     `{"object":"transcript","type":"speaker_labelled","transcript":[{"speaker":"Ann","start":0,"end":1500,"text":"Hello."},{"speaker":"Bo","start":1500,"end":3000,"text":"Hi."}]}`,
+			wantType: "speaker_labelled",
+			wantResult: []domain.TranscriptSegment{
+				{Speaker: "Ann", Start: 0, End: 1500, Text: "Hello."},
+				{Speaker: "Bo", Start: 1500, End: 3000, Text: "Hi."},
+			},
+		},
+		{
+			name:       "raw json",
+			body:       `{"object":"transcript","type":"raw","transcript":"Hello. Hi."}`,
+			wantType:   "raw",
+			wantResult: []domain.TranscriptSegment{{Text: "Hello. Hi."}},
+		},
+		{
+			name:       "plain text",
+			body:       "Hello. Hi.\n",
+			wantType:   "raw",
+			wantResult: []domain.TranscriptSegment{{Text: "Hello. Hi."}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTranscriptServer(t, tt.body)
+			client := nylas.NewHTTPClient()
+			client.SetCredentials("client-id", "secret", "api-key")
+			client.SetBaseURL(server.URL)
+
+			transcript, err := client.GetNotetakerTranscript(context.Background(), "grant-1", "nt-1")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, transcript.Type)
+			assert.Equal(t, tt.wantResult, transcript.Segments)
+		})
+	}
+}
+
+func TestHTTPClient_GetNotetakerTranscript_NotReady(t *testing.T) {
+	server := newTranscriptServer(t, "")
+	client := nylas.NewHTTPClient()
+	client.SetCredentials("client-id", "secret", "api-key")
+	client.SetBaseURL(server.URL)
+
+	_, err := client.GetNotetakerTranscript(context.Background(), "grant-1", "nt-1")
+	assert.ErrorIs(t, err, domain.ErrTranscriptNotReady)
+}
@@ -209,6 +209,35 @@ func (c *HTTPClient) getBookingWithToken(ctx context.Context, token, bookingID s
 	return &result.Data, nil
 }
 
+// CreateBooking books a slot on a configuration.
+func (c *HTTPClient) CreateBooking(ctx context.Context, configurationID string, req *domain.CreateBookingRequest) (*domain.Booking, error) {
+	if req == nil {
+		return nil, fmt.Errorf("create booking request is required")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	token, err := c.bookingSessionToken(ctx, configurationID)
+	if err != nil {
+		return nil, err
+	}
+
+	queryURL := fmt.Sprintf("%s/v3/scheduling/bookings", c.baseURL)
+
+	resp, err := c.doJSONRequestWithToken(ctx, "POST", queryURL, token, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data domain.Booking `json:"data"`
+	}
+	if err := c.decodeJSONResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result.Data, nil
+}
+
 // GetBooking retrieves a specific booking.
 func (c *HTTPClient) GetBooking(ctx context.Context, configurationID, bookingID string) (*domain.Booking, error) {
 	if err := validateRequired("booking ID", bookingID); err != nil {
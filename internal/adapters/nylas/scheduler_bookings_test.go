@@ -135,7 +135,38 @@ func TestHTTPClient_GetBooking(t *testing.T) {
 	assert.Equal(t, "confirmed", booking.Status)
 }
 
-// Note: Bookings are created through the scheduler session flow, not directly
+func TestHTTPClient_CreateBooking(t *testing.T) {
+	server := bookingTestServer(t, "session-abc", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/scheduling/bookings", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(1767261600), body["start_time"])
+		assert.Equal(t, map[string]any{"name": "Ana", "email": "ana@example.com"}, body["guest"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"booking_id": "booking-new", "status": "confirmed"},
+		})
+	})
+	defer server.Close()
+
+	client := nylas.NewHTTPClient()
+	client.SetCredentials("client-id", "secret", "api-key")
+	client.SetBaseURL(server.URL)
+
+	booking, err := client.CreateBooking(context.Background(), "config-1", &domain.CreateBookingRequest{
+		StartTime: 1767261600,
+		EndTime:   1767263400,
+		Guest:     domain.BookingGuest{Name: "Ana", Email: "ana@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "booking-new", booking.BookingID)
+
+	_, err = client.CreateBooking(context.Background(), "config-1", &domain.CreateBookingRequest{StartTime: 10, EndTime: 5, Guest: domain.BookingGuest{Email: "a@b.c"}})
+	assert.ErrorContains(t, err, "end time must be after start time")
+}
 
 func TestHTTPClient_ConfirmBooking(t *testing.T) {
 	server := bookingTestServer(t, "session-abc", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,138 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const (
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	microsoftTokenURL = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+
+	// validationCode is never a real authorization code; providers reject it
+	// with invalid_grant once the client itself has been accepted.
+	validationCode = "nylas-cli-client-check"
+)
+
+// clientErrors are the OAuth error codes that mean the client, not the
+// code, was rejected.
+var clientErrors = map[string]bool{
+	"invalid_client":        true,
+	"unauthorized_client":   true,
+	"redirect_uri_mismatch": true,
+}
+
+// microsoftClientErrors are Entra ID error codes, found in
+// error_description, that also blame the client.
+var microsoftClientErrors = []string{
+	"AADSTS700016",  // application not found in the directory
+	"AADSTS7000215", // invalid client secret
+	"AADSTS7000222", // client secret expired
+	"AADSTS90002",   // tenant not found
+	"AADSTS50011",   // redirect URI not registered
+}
+
+// ClientValidator implements ports.OAuthClientValidator against the Google
+// and Microsoft token endpoints.
+type ClientValidator struct {
+	httpClient *http.Client
+	tokenURLs  map[domain.Provider]string
+}
+
+var _ ports.OAuthClientValidator = (*ClientValidator)(nil)
+
+// NewClientValidator creates a validator using the providers' public token
+// endpoints.
+func NewClientValidator() *ClientValidator {
+	return &ClientValidator{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		tokenURLs: map[domain.Provider]string{
+			domain.ProviderGoogle:    googleTokenURL,
+			domain.ProviderMicrosoft: microsoftTokenURL,
+		},
+	}
+}
+
+// ValidateClient implements ports.OAuthClientValidator.
+func (v *ClientValidator) ValidateClient(ctx context.Context, check domain.OAuthClientCheck) error {
+	tokenURL, ok := v.tokenURLs[check.Provider]
+	if !ok {
+		return fmt.Errorf("%s OAuth clients can't be validated", check.Provider.DisplayName())
+	}
+	if check.Provider == domain.ProviderMicrosoft {
+		tenant := check.Tenant
+		if tenant == "" {
+			tenant = "common"
+		}
+		tokenURL = fmt.Sprintf(tokenURL, url.PathEscape(tenant))
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {validationCode},
+		"client_id":     {check.ClientID},
+		"client_secret": {check.ClientSecret},
+		"redirect_uri":  {check.RedirectURI},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s token endpoint unreachable: %w", check.Provider.DisplayName(), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var body struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Error == "" {
+		return fmt.Errorf("%s token endpoint returned %s", check.Provider.DisplayName(), resp.Status)
+	}
+
+	if clientErrors[body.Error] || (check.Provider == domain.ProviderMicrosoft && containsAny(body.Description, microsoftClientErrors)) {
+		detail := body.Error
+		if body.Description != "" {
+			detail += ": " + firstLine(body.Description)
+		}
+		return fmt.Errorf("%w: %s", domain.ErrOAuthClientRejected, detail)
+	}
+	return nil
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstLine trims Entra ID descriptions, which append trace and correlation
+// IDs on later lines.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(strings.TrimSuffix(line, "\r"))
+}
@@ -0,0 +1,131 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestClientValidator_ValidateClient(t *testing.T) {
+	tests := []struct {
+		name         string
+		provider     domain.Provider
+		status       int
+		body         string
+		wantRejected bool
+		wantErr      bool
+	}{
+		{
+			name:     "google client accepted",
+			provider: domain.ProviderGoogle,
+			status:   http.StatusBadRequest,
+			body:     `{"error":"invalid_grant","error_description":"Malformed auth code."}`,
+		},
+		{
+			name:         "google client unknown",
+			provider:     domain.ProviderGoogle,
+			status:       http.StatusUnauthorized,
+			body:         `{"error":"invalid_client","error_description":"The OAuth client was not found."}`,
+			wantRejected: true,
+		},
+		{
+			name:         "google redirect mismatch",
+			provider:     domain.ProviderGoogle,
+			status:       http.StatusBadRequest,
+			body:         `{"error":"redirect_uri_mismatch","error_description":"Bad Request"}`,
+			wantRejected: true,
+		},
+		{
+			name:     "microsoft client accepted",
+			provider: domain.ProviderMicrosoft,
+			status:   http.StatusBadRequest,
+			body:     `{"error":"invalid_grant","error_description":"AADSTS9002313: Invalid request.\r\nTrace ID: x"}`,
+		},
+		{
+			name:         "microsoft bad secret",
+			provider:     domain.ProviderMicrosoft,
+			status:       http.StatusUnauthorized,
+			body:         `{"error":"invalid_client","error_description":"AADSTS7000215: Invalid client secret provided.\r\nTrace ID: x"}`,
+			wantRejected: true,
+		},
+		{
+			name:         "microsoft unknown app",
+			provider:     domain.ProviderMicrosoft,
+			status:       http.StatusBadRequest,
+			body:         `{"error":"unauthorized_client","error_description":"AADSTS700016: Application not found."}`,
+			wantRejected: true,
+		},
+		{
+			name:     "not an OAuth error",
+			provider: domain.ProviderGoogle,
+			status:   http.StatusBadGateway,
+			body:     `<html>bad gateway</html>`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			var gotForm map[string][]string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				_ = r.ParseForm()
+				gotForm = r.PostForm
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			v := NewClientValidator()
+			v.tokenURLs = map[domain.Provider]string{
+				domain.ProviderGoogle:    srv.URL + "/token",
+				domain.ProviderMicrosoft: srv.URL + "/%s/oauth2/v2.0/token",
+			}
+
+			err := v.ValidateClient(context.Background(), domain.OAuthClientCheck{
+				Provider:     tt.provider,
+				ClientID:     "client-id",
+				ClientSecret: "secret",
+				RedirectURI:  "https://api.us.nylas.com/v3/connect/callback",
+			})
+
+			switch {
+			case tt.wantRejected:
+				if !errors.Is(err, domain.ErrOAuthClientRejected) {
+					t.Fatalf("ValidateClient() = %v, want ErrOAuthClientRejected", err)
+				}
+				if strings.Contains(err.Error(), "Trace ID") {
+					t.Errorf("error keeps trace lines: %q", err)
+				}
+			case tt.wantErr:
+				if err == nil || errors.Is(err, domain.ErrOAuthClientRejected) {
+					t.Fatalf("ValidateClient() = %v, want a non-rejection error", err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("ValidateClient() = %v, want nil", err)
+				}
+			}
+
+			if tt.provider == domain.ProviderMicrosoft && gotPath != "/common/oauth2/v2.0/token" {
+				t.Errorf("path = %q, want the common tenant", gotPath)
+			}
+			if got := gotForm["client_id"]; len(got) != 1 || got[0] != "client-id" {
+				t.Errorf("client_id = %v", got)
+			}
+		})
+	}
+}
+
+func TestClientValidator_UnsupportedProvider(t *testing.T) {
+	err := NewClientValidator().ValidateClient(context.Background(), domain.OAuthClientCheck{Provider: domain.ProviderIMAP})
+	if err == nil || errors.Is(err, domain.ErrOAuthClientRejected) {
+		t.Fatalf("ValidateClient() = %v, want an error", err)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +20,7 @@ import (
 // CallbackServer implements the OAuth callback server.
 type CallbackServer struct {
 	port      int
+	opts      CallbackOptions
 	server    *http.Server
 	listener  net.Listener
 	listeners []net.Listener
@@ -28,10 +31,29 @@ type CallbackServer struct {
 	state     string
 }
 
+// CallbackOptions change where the callback server listens and the
+// redirect URI it advertises.
+type CallbackOptions struct {
+	// Host is the address to listen on. Empty or "localhost" listens on the
+	// IPv4 and IPv6 loopback addresses.
+	Host string
+
+	// RedirectURI replaces http://<host>:<port>/callback, for example with
+	// a tunnel that forwards to the server. Its path is the one served.
+	RedirectURI string
+}
+
 // NewCallbackServer creates a new callback server.
 func NewCallbackServer(port int) *CallbackServer {
+	return NewCallbackServerWithOptions(port, CallbackOptions{})
+}
+
+// NewCallbackServerWithOptions creates a callback server with a custom
+// listen host or redirect URI.
+func NewCallbackServerWithOptions(port int, opts CallbackOptions) *CallbackServer {
 	return &CallbackServer{
 		port:     port,
+		opts:     opts,
 		codeChan: make(chan string, 1),
 		errChan:  make(chan error, 1),
 	}
@@ -45,7 +67,7 @@ func (s *CallbackServer) Start() error {
 	s.setExpectedState("")
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/callback", s.handleCallback)
+	mux.HandleFunc(s.callbackPath(), s.handleCallback)
 
 	s.server = &http.Server{
 		Handler:           mux,
@@ -58,8 +80,9 @@ func (s *CallbackServer) Start() error {
 	// Bind to loopback only. The browser follows the advertised localhost
 	// redirect URI, which can resolve to either IPv4 or IPv6 loopback
 	// depending on host configuration. Listen on both loopback families when
-	// available without accepting LAN traffic.
-	listeners, port, err := listenLoopback(s.port)
+	// available without accepting LAN traffic, unless another host was asked
+	// for (e.g. 0.0.0.0 inside a container).
+	listeners, port, err := s.listen()
 	if err != nil {
 		return fmt.Errorf("failed to start callback server: %w", err)
 	}
@@ -74,6 +97,23 @@ func (s *CallbackServer) Start() error {
 	return nil
 }
 
+func (s *CallbackServer) listen() ([]net.Listener, int, error) {
+	if s.opts.Host == "" || s.opts.Host == "localhost" {
+		return listenLoopback(s.port)
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.opts.Host, strconv.Itoa(s.port)))
+	if err != nil {
+		return nil, 0, err
+	}
+	tcpAddr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		_ = listener.Close()
+		return nil, 0, fmt.Errorf("unexpected listener address type %T", listener.Addr())
+	}
+	return []net.Listener{listener}, tcpAddr.Port, nil
+}
+
 func listenLoopback(port int) ([]net.Listener, int, error) {
 	ipv4, err := net.Listen("tcp4", fmt.Sprintf("127.0.0.1:%d", port))
 	if err != nil {
@@ -146,7 +186,28 @@ func (s *CallbackServer) WaitForCallback(ctx context.Context, expectedState stri
 
 // GetRedirectURI returns the redirect URI for OAuth.
 func (s *CallbackServer) GetRedirectURI() string {
-	return fmt.Sprintf("http://localhost:%d/callback", s.port)
+	if s.opts.RedirectURI != "" {
+		return s.opts.RedirectURI
+	}
+	return LocalRedirectURI(s.opts.Host, s.port)
+}
+
+// LocalRedirectURI returns the redirect URI of a callback server listening
+// on host and port. Unspecified hosts such as 0.0.0.0 are reached through
+// localhost.
+func LocalRedirectURI(host string, port int) string {
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return fmt.Sprintf("http://%s/callback", net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// callbackPath returns the path the redirect URI points at.
+func (s *CallbackServer) callbackPath() string {
+	if u, err := url.Parse(s.opts.RedirectURI); err == nil && u.Path != "" {
+		return u.Path
+	}
+	return "/callback"
 }
 
 func (s *CallbackServer) handleCallback(w http.ResponseWriter, r *http.Request) {
@@ -36,6 +36,7 @@ func TestCallbackServer_GetRedirectURI(t *testing.T) {
 	tests := []struct {
 		name string
 		port int
+		opts CallbackOptions
 		want string
 	}{
 		{
@@ -48,11 +49,35 @@ func TestCallbackServer_GetRedirectURI(t *testing.T) {
 			port: 9000,
 			want: "http://localhost:9000/callback",
 		},
+		{
+			name: "custom host",
+			port: 9191,
+			opts: CallbackOptions{Host: "127.0.0.1"},
+			want: "http://127.0.0.1:9191/callback",
+		},
+		{
+			name: "IPv6 host",
+			port: 9191,
+			opts: CallbackOptions{Host: "::1"},
+			want: "http://[::1]:9191/callback",
+		},
+		{
+			name: "all interfaces",
+			port: 9191,
+			opts: CallbackOptions{Host: "0.0.0.0"},
+			want: "http://localhost:9191/callback",
+		},
+		{
+			name: "redirect URI",
+			port: 9191,
+			opts: CallbackOptions{RedirectURI: "https://mytunnel.example/callback"},
+			want: "https://mytunnel.example/callback",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			server := NewCallbackServer(tt.port)
+			server := NewCallbackServerWithOptions(tt.port, tt.opts)
 			got := server.GetRedirectURI()
 			if got != tt.want {
 				t.Errorf("GetRedirectURI() = %q, want %q", got, tt.want)
@@ -92,6 +117,39 @@ func TestCallbackServer_StartAcceptsIPv6LoopbackForAdvertisedLocalhost(t *testin
 	}
 }
 
+func TestCallbackServer_StartServesRedirectURIPathOnHost(t *testing.T) {
+	server := NewCallbackServerWithOptions(0, CallbackOptions{
+		Host:        "127.0.0.1",
+		RedirectURI: "https://mytunnel.example/oauth/done",
+	})
+	if err := server.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer func() { _ = server.Stop() }()
+	server.setExpectedState("test-state")
+
+	client := &http.Client{Timeout: time.Second, Transport: &http.Transport{Proxy: nil}}
+	base := "http://127.0.0.1:" + strconv.Itoa(server.port)
+
+	resp, err := client.Get(base + "/callback?code=test-code&state=test-state")
+	if err != nil {
+		t.Fatalf("default path request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("default path status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	resp, err = client.Get(base + "/oauth/done?code=test-code&state=test-state")
+	if err != nil {
+		t.Fatalf("redirect URI path request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("redirect URI path status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
 func TestCallbackServer_handleCallback_Success(t *testing.T) {
 	server := NewCallbackServer(8080)
 	server.setExpectedState("test-state-123")
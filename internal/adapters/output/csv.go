@@ -0,0 +1,201 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+// CSVWriter outputs data as RFC 4180 CSV for spreadsheets.
+type CSVWriter struct {
+	w io.Writer
+}
+
+// NewCSVWriter creates a new CSV writer.
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: w}
+}
+
+// Write outputs a list (one row per item) or a single object (one row).
+// Columns are the JSON field names; nested values are written as compact
+// JSON so no data is lost.
+func (cw *CSVWriter) Write(data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode CSV: %w", err)
+	}
+
+	var rows []map[string]any
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Errorf("failed to encode CSV: %w", err)
+	}
+	switch v := decoded.(type) {
+	case []any:
+		for _, item := range v {
+			row, ok := item.(map[string]any)
+			if !ok {
+				row = map[string]any{"value": item}
+			}
+			rows = append(rows, row)
+		}
+	case map[string]any:
+		rows = []map[string]any{v}
+	case nil:
+		return nil
+	default:
+		rows = []map[string]any{{"value": v}}
+	}
+
+	headers := csvHeaders(reflect.TypeOf(data), rows)
+	w := csv.NewWriter(cw.w)
+	_ = w.Write(headers)
+	for _, row := range rows {
+		record := make([]string, len(headers))
+		for i, h := range headers {
+			record[i] = csvJSONValue(row[h])
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteList outputs the given columns of each item.
+func (cw *CSVWriter) WriteList(data any, columns []ports.Column) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return cw.Write(data)
+	}
+
+	w := csv.NewWriter(cw.w)
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.Header
+	}
+	_ = w.Write(headers)
+	for i := range v.Len() {
+		record := make([]string, len(columns))
+		for j, col := range columns {
+			record[j] = csvValue(getFieldValue(v.Index(i), col.Field))
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// WriteError outputs an error as a single-cell CSV.
+func (cw *CSVWriter) WriteError(err error) error {
+	w := csv.NewWriter(cw.w)
+	_ = w.Write([]string{"error"})
+	_ = w.Write([]string{err.Error()})
+	w.Flush()
+	return w.Error()
+}
+
+// csvHeaders orders columns by the struct's JSON field order when t is a
+// struct (or a slice of them), followed by any remaining keys sorted.
+func csvHeaders(t reflect.Type, rows []map[string]any) []string {
+	present := make(map[string]bool)
+	for _, row := range rows {
+		for k := range row {
+			present[k] = true
+		}
+	}
+
+	var headers []string
+	for _, name := range jsonFieldNames(t) {
+		if present[name] {
+			headers = append(headers, name)
+			delete(present, name)
+		}
+	}
+	rest := make([]string, 0, len(present))
+	for k := range present {
+		rest = append(rest, k)
+	}
+	slices.Sort(rest)
+	return append(headers, rest...)
+}
+
+// jsonFieldNames lists the JSON names of a struct's fields in declaration
+// order, flattening embedded structs the way encoding/json does.
+func jsonFieldNames(t reflect.Type) []string {
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			names = append(names, jsonFieldNames(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// csvJSONValue renders a decoded JSON value as a cell.
+func csvJSONValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		raw, _ := json.Marshal(val)
+		return string(raw)
+	}
+}
+
+// csvValue renders a struct field as a cell. Unlike the table writer, times
+// are absolute and nothing is truncated.
+func csvValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case time.Time:
+		if val.IsZero() {
+			return ""
+		}
+		return val.Format(time.RFC3339)
+	case bool:
+		return strconv.FormatBool(val)
+	case []string:
+		return strings.Join(val, "; ")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+type csvTestItem struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name,omitempty"`
+	Active bool              `json:"active"`
+	Count  int               `json:"count"`
+	Tags   []string          `json:"tags,omitempty"`
+	Meta   map[string]string `json:"meta,omitempty"`
+}
+
+func TestCSVWriter_Write(t *testing.T) {
+	var buf bytes.Buffer
+	items := []csvTestItem{
+		{ID: "1", Name: "Smith, Jane", Active: true, Count: 3, Tags: []string{"a", "b"}},
+		{ID: "2", Meta: map[string]string{"k": "v"}},
+	}
+
+	require.NoError(t, NewCSVWriter(&buf).Write(items))
+
+	assert.Equal(t, "id,name,active,count,tags,meta\n"+
+		"1,\"Smith, Jane\",true,3,\"[\"\"a\"\",\"\"b\"\"]\",\n"+
+		"2,,false,0,,\"{\"\"k\"\":\"\"v\"\"}\"\n", buf.String())
+}
+
+func TestCSVWriter_WriteSingleObject(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewCSVWriter(&buf).Write(&csvTestItem{ID: "1", Count: 2}))
+	assert.Equal(t, "id,active,count\n1,false,2\n", buf.String())
+}
+
+func TestCSVWriter_WriteList(t *testing.T) {
+	type row struct {
+		ID      string
+		Created time.Time
+		Tags    []string
+	}
+	var buf bytes.Buffer
+	created := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	data := []row{{ID: "a-very-long-identifier-that-a-table-would-truncate-for-display", Created: created, Tags: []string{"x", "y"}}}
+
+	err := NewCSVWriter(&buf).WriteList(data, []ports.Column{
+		{Header: "ID", Field: "ID"},
+		{Header: "Created", Field: "Created"},
+		{Header: "Tags", Field: "Tags"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ID,Created,Tags\n"+
+		"a-very-long-identifier-that-a-table-would-truncate-for-display,2024-05-01T09:00:00Z,x; y\n", buf.String())
+}
+
+func TestCSVWriter_WriteError(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewCSVWriter(&buf).WriteError(errors.New("boom")))
+	assert.Equal(t, "error\nboom\n", buf.String())
+}
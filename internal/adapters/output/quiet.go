@@ -18,8 +18,17 @@ func NewQuietWriter(w io.Writer) *QuietWriter {
 	return &QuietWriter{w: w}
 }
 
-// Write outputs the ID or quiet field of a single object.
+// Write outputs the ID or quiet field of a single object, or of each item
+// of a list.
 func (qw *QuietWriter) Write(data any) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice {
+		return qw.WriteList(data, nil)
+	}
+
 	id := extractQuietField(data)
 	if id != "" {
 		_, _ = fmt.Fprintln(qw.w, id)
@@ -35,7 +44,11 @@ func (qw *QuietWriter) WriteList(data any, _ []ports.Column) error {
 	}
 
 	if v.Kind() != reflect.Slice {
-		return qw.Write(data)
+		id := extractQuietField(data)
+		if id != "" {
+			_, _ = fmt.Fprintln(qw.w, id)
+		}
+		return nil
 	}
 
 	for i := range v.Len() {
@@ -39,6 +39,16 @@ func TestQuietWriter_Write(t *testing.T) {
 			data:     quietItem{ID: "456", Name: "Test"},
 			expected: "456\n",
 		},
+		{
+			name:     "list prints one ID per line",
+			data:     []testItem{{ID: "1"}, {ID: "2"}},
+			expected: "1\n2\n",
+		},
+		{
+			name:     "pointer to list",
+			data:     &[]quietItem{{ID: "3"}},
+			expected: "3\n",
+		},
 	}
 
 	for _, tt := range tests {
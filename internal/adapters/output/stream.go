@@ -0,0 +1,69 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+// NewStreamWriter returns a writer that outputs a list item by item, or
+// false when the format needs the whole list (table, YAML, CSV, templates).
+func NewStreamWriter(w io.Writer, opts ports.OutputOptions) (ports.StreamWriter, bool) {
+	switch opts.Format {
+	case ports.FormatJSON:
+		return &JSONStreamWriter{w: w}, true
+	case ports.FormatQuiet:
+		return &quietStreamWriter{w: w}, true
+	default:
+		return nil, false
+	}
+}
+
+// JSONStreamWriter writes a JSON array one element at a time, formatted
+// exactly as JSONWriter formats the whole list.
+type JSONStreamWriter struct {
+	w     io.Writer
+	count int
+}
+
+// WriteItem appends item to the array.
+func (jw *JSONStreamWriter) WriteItem(item any) error {
+	data, err := json.MarshalIndent(item, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	sep := ",\n  "
+	if jw.count == 0 {
+		sep = "[\n  "
+	}
+	jw.count++
+	_, err = fmt.Fprintf(jw.w, "%s%s", sep, data)
+	return err
+}
+
+// Close ends the array.
+func (jw *JSONStreamWriter) Close() error {
+	end := "\n]\n"
+	if jw.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(jw.w, end)
+	return err
+}
+
+// quietStreamWriter writes the ID of each item on its own line.
+type quietStreamWriter struct {
+	w io.Writer
+}
+
+func (qw *quietStreamWriter) WriteItem(item any) error {
+	if id := extractQuietField(item); id != "" {
+		_, err := fmt.Fprintln(qw.w, id)
+		return err
+	}
+	return nil
+}
+
+func (qw *quietStreamWriter) Close() error { return nil }
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONStreamWriter_MatchesJSONWriter(t *testing.T) {
+	type item struct {
+		ID   string   `json:"id"`
+		Tags []string `json:"tags"`
+	}
+	for _, items := range [][]item{
+		nil,
+		{{ID: "a", Tags: []string{"x"}}},
+		{{ID: "a"}, {ID: "b <c>", Tags: []string{"y", "z"}}},
+	} {
+		var want, got bytes.Buffer
+		require.NoError(t, NewJSONWriter(&want).Write(append([]item{}, items...)))
+
+		sw, ok := NewStreamWriter(&got, ports.OutputOptions{Format: ports.FormatJSON})
+		require.True(t, ok)
+		for _, it := range items {
+			require.NoError(t, sw.WriteItem(it))
+		}
+		require.NoError(t, sw.Close())
+		assert.Equal(t, want.String(), got.String())
+	}
+}
+
+func TestQuietStreamWriter(t *testing.T) {
+	var buf bytes.Buffer
+	sw, ok := NewStreamWriter(&buf, ports.OutputOptions{Format: ports.FormatQuiet})
+	require.True(t, ok)
+	require.NoError(t, sw.WriteItem(struct{ ID string }{"msg-1"}))
+	require.NoError(t, sw.WriteItem(struct{ ID string }{"msg-2"}))
+	require.NoError(t, sw.Close())
+	assert.Equal(t, "msg-1\nmsg-2\n", buf.String())
+}
+
+func TestNewStreamWriter_BufferedFormats(t *testing.T) {
+	for _, format := range []ports.OutputFormat{ports.FormatTable, ports.FormatYAML, ports.FormatCSV, ports.FormatTemplate} {
+		_, ok := NewStreamWriter(&bytes.Buffer{}, ports.OutputOptions{Format: format})
+		assert.False(t, ok, format)
+	}
+}
@@ -0,0 +1,121 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+// TemplateWriter renders data with a user-supplied Go template. Lists are
+// rendered once per item, each followed by a newline, so
+// `{{.ID}} {{.Subject}}` prints one line per message.
+type TemplateWriter struct {
+	w    io.Writer
+	text string
+}
+
+// NewTemplateWriter creates a writer for the template text.
+func NewTemplateWriter(w io.Writer, text string) *TemplateWriter {
+	return &TemplateWriter{w: w, text: text}
+}
+
+// templateFuncs are available to --output templates.
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join": func(sep string, v any) string {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return fmt.Sprint(v)
+		}
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = fmt.Sprint(rv.Index(i).Interface())
+		}
+		return strings.Join(parts, sep)
+	},
+	"truncate": func(n int, s string) string {
+		return truncate(s, n)
+	},
+	"date": func(layout string, v any) string {
+		switch t := v.(type) {
+		case time.Time:
+			return t.Local().Format(layout)
+		case *time.Time:
+			if t == nil {
+				return ""
+			}
+			return t.Local().Format(layout)
+		case int64:
+			return time.Unix(t, 0).Local().Format(layout)
+		case int:
+			return time.Unix(int64(t), 0).Local().Format(layout)
+		}
+		return fmt.Sprint(v)
+	},
+}
+
+// ParseTemplate compiles template text with the output template functions.
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Write renders a single object, or each item of a list.
+func (tw *TemplateWriter) Write(data any) error {
+	tmpl, err := ParseTemplate(tw.text)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Slice {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return tw.render(tmpl, data)
+	}
+	for i := range v.Len() {
+		if err := tw.render(tmpl, v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteList renders each item (columns are ignored).
+func (tw *TemplateWriter) WriteList(data any, _ []ports.Column) error {
+	return tw.Write(data)
+}
+
+// WriteError writes the error message as a line.
+func (tw *TemplateWriter) WriteError(err error) error {
+	_, werr := fmt.Fprintln(tw.w, "Error:", err)
+	return werr
+}
+
+func (tw *TemplateWriter) render(tmpl *template.Template, item any) error {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, item); err != nil {
+		return fmt.Errorf("render output template: %w", err)
+	}
+	out := b.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err := io.WriteString(tw.w, out)
+	return err
+}
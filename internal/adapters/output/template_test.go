@@ -0,0 +1,51 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type templateItem struct {
+	ID      string
+	Subject string
+	Tags    []string
+	Sent    time.Time
+}
+
+func TestTemplateWriter_List(t *testing.T) {
+	var buf bytes.Buffer
+	items := []templateItem{
+		{ID: "m1", Subject: "Hello", Tags: []string{"a", "b"}},
+		{ID: "m2", Subject: "World"},
+	}
+
+	require.NoError(t, NewTemplateWriter(&buf, "{{.ID}} {{.Subject}} [{{join \",\" .Tags}}]").WriteList(items, nil))
+	assert.Equal(t, "m1 Hello [a,b]\nm2 World []\n", buf.String())
+}
+
+func TestTemplateWriter_SingleAndFuncs(t *testing.T) {
+	var buf bytes.Buffer
+	item := &templateItem{ID: "m1", Subject: "a very long subject", Sent: time.Date(2026, 1, 5, 10, 0, 0, 0, time.Local)}
+
+	require.NoError(t, NewTemplateWriter(&buf, `{{upper .ID}} {{truncate 6 .Subject}} {{date "2006-01-02" .Sent}} {{json .Tags}}`).Write(item))
+	assert.Equal(t, "M1 a v... 2026-01-05 null\n", buf.String())
+}
+
+func TestTemplateWriter_Maps(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewTemplateWriter(&buf, "{{.id}}={{.missing}}\n").Write(map[string]any{"id": "x"}))
+	assert.Equal(t, "x=<no value>\n", buf.String())
+}
+
+func TestTemplateWriter_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewTemplateWriter(&buf, "{{.ID").Write(templateItem{})
+	assert.ErrorContains(t, err, "invalid output template")
+
+	err = NewTemplateWriter(&buf, "{{.Nope}}").Write(templateItem{})
+	assert.ErrorContains(t, err, "render output template")
+}
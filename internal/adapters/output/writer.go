@@ -16,6 +16,10 @@ func NewWriter(w io.Writer, opts ports.OutputOptions) ports.OutputWriter {
 		return NewYAMLWriter(w)
 	case ports.FormatQuiet:
 		return NewQuietWriter(w)
+	case ports.FormatCSV:
+		return NewCSVWriter(w)
+	case ports.FormatTemplate:
+		return NewTemplateWriter(w, opts.Template)
 	default:
 		return NewTableWriter(w, !opts.NoColor)
 	}
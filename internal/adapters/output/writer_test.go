@@ -20,6 +20,8 @@ func TestNewWriter(t *testing.T) {
 		{"json format", ports.FormatJSON, "*output.JSONWriter"},
 		{"yaml format", ports.FormatYAML, "*output.YAMLWriter"},
 		{"quiet format", ports.FormatQuiet, "*output.QuietWriter"},
+		{"csv format", ports.FormatCSV, "*output.CSVWriter"},
+		{"template format", ports.FormatTemplate, "*output.TemplateWriter"},
 		{"empty format defaults to table", "", "*output.TableWriter"},
 	}
 
@@ -0,0 +1,306 @@
+// Package recipients keeps a local index of the addresses a grant writes to,
+// built from its contacts and recent mail, for completing recipients.
+package recipients
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+const fileVersion = 1
+
+// Store is the recipient index file. It only holds data that can be rebuilt
+// from the API, so a lost concurrent update is harmless and no lock file is
+// taken.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+type fileShape struct {
+	Version int                    `json:"version"`
+	Grants  map[string]*grantIndex `json:"grants"`
+}
+
+type grantIndex struct {
+	BuiltAt    time.Time          `json:"built_at"`
+	Recipients []domain.Recipient `json:"recipients"`
+}
+
+// New creates a recipient index backed by the file at path.
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the index file path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Replace stores a freshly built index for a grant.
+func (s *Store) Replace(grantID string, recipients []domain.Recipient) error {
+	return s.mutate(func(shape *fileShape) bool {
+		shape.Grants[grantID] = &grantIndex{BuiltAt: time.Now().UTC(), Recipients: recipients}
+		return true
+	})
+}
+
+// Record counts addresses that were just written to. It only updates a grant
+// that already has an index, so sending mail never creates one.
+func (s *Store) Record(grantID string, addrs []domain.EmailParticipant, at time.Time) error {
+	if len(addrs) == 0 {
+		return nil
+	}
+	return s.mutate(func(shape *fileShape) bool {
+		idx := shape.Grants[grantID]
+		if idx == nil {
+			return false
+		}
+		b := newBuilder(nil)
+		b.recipients = idx.Recipients
+		for i, r := range idx.Recipients {
+			b.byEmail[strings.ToLower(r.Email)] = i
+		}
+		for _, p := range addrs {
+			b.see(p, at)
+		}
+		idx.Recipients = b.recipients
+		return true
+	})
+}
+
+// List returns a grant's indexed recipients and when the index was built.
+// A grant without an index returns a zero time.
+func (s *Store) List(grantID string) ([]domain.Recipient, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shape, err := s.read()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	idx := shape.Grants[grantID]
+	if idx == nil {
+		return nil, time.Time{}, nil
+	}
+	return idx.Recipients, idx.BuiltAt, nil
+}
+
+// Search returns up to limit of a grant's recipients matching query, best
+// first. See Match.
+func (s *Store) Search(grantID, query string, limit int) ([]domain.Recipient, error) {
+	recipients, _, err := s.List(grantID)
+	if err != nil {
+		return nil, err
+	}
+	return Match(recipients, query, limit), nil
+}
+
+// Match returns up to limit recipients whose address, or a word of whose
+// name, starts with query, ignoring case. Contacts and addresses seen more
+// often, then more recently, come first. limit <= 0 returns every match.
+func Match(recipients []domain.Recipient, query string, limit int) []domain.Recipient {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var out []domain.Recipient
+	for _, r := range recipients {
+		if matches(r, query) {
+			out = append(out, r)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b domain.Recipient) int {
+		if a.Contact != b.Contact {
+			if a.Contact {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(
+			cmp.Compare(b.Count, a.Count),
+			b.LastSeen.Compare(a.LastSeen),
+			cmp.Compare(a.Email, b.Email),
+		)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func matches(r domain.Recipient, query string) bool {
+	if query == "" || strings.HasPrefix(strings.ToLower(r.Email), query) {
+		return true
+	}
+	name := strings.ToLower(r.Name)
+	if strings.HasPrefix(name, query) {
+		return true
+	}
+	for _, word := range strings.Fields(name) {
+		if strings.HasPrefix(word, query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Build indexes a grant's contacts and the participants of its recent mail,
+// leaving out the grant's own addresses and automated senders that aren't
+// contacts.
+func Build(contacts []domain.Contact, messages []domain.Message, self []string) []domain.Recipient {
+	b := newBuilder(self)
+	for _, c := range contacts {
+		for _, e := range c.Emails {
+			b.addContact(e.Email, c.DisplayName())
+		}
+	}
+	for i := range messages {
+		msg := &messages[i]
+		for _, list := range [][]domain.EmailParticipant{msg.From, msg.To, msg.Cc, msg.Bcc} {
+			for _, p := range list {
+				if !isAutomated(p.Email) {
+					b.see(p, msg.Date)
+				}
+			}
+		}
+	}
+	return b.recipients
+}
+
+type builder struct {
+	recipients []domain.Recipient
+	byEmail    map[string]int
+	self       map[string]bool
+}
+
+func newBuilder(self []string) *builder {
+	b := &builder{byEmail: make(map[string]int), self: make(map[string]bool, len(self))}
+	for _, e := range self {
+		b.self[strings.ToLower(e)] = true
+	}
+	return b
+}
+
+// entry returns the recipient for email, adding it when new. It returns nil
+// for empty and own addresses.
+func (b *builder) entry(email string) *domain.Recipient {
+	email = strings.TrimSpace(email)
+	key := strings.ToLower(email)
+	if !strings.Contains(key, "@") || b.self[key] {
+		return nil
+	}
+	if i, ok := b.byEmail[key]; ok {
+		return &b.recipients[i]
+	}
+	b.byEmail[key] = len(b.recipients)
+	b.recipients = append(b.recipients, domain.Recipient{Email: email})
+	return &b.recipients[len(b.recipients)-1]
+}
+
+func (b *builder) addContact(email, name string) {
+	r := b.entry(email)
+	if r == nil {
+		return
+	}
+	r.Contact = true
+	if name != "" && name != email {
+		r.Name = name
+	}
+}
+
+func (b *builder) see(p domain.EmailParticipant, at time.Time) {
+	r := b.entry(p.Email)
+	if r == nil {
+		return
+	}
+	r.Count++
+	if at.After(r.LastSeen) {
+		r.LastSeen = at.UTC()
+	}
+	if r.Name == "" && p.Name != "" && p.Name != p.Email {
+		r.Name = p.Name
+	}
+}
+
+// isAutomated reports whether an address looks like one nobody writes to.
+func isAutomated(email string) bool {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	for _, marker := range []string{"noreply", "no-reply", "no_reply", "donotreply", "do-not-reply", "mailer-daemon", "bounce"} {
+		if strings.Contains(local, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// mutate applies fn to the index and writes it back when fn reports a
+// change.
+func (s *Store) mutate(fn func(*fileShape) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shape, err := s.read()
+	if err != nil {
+		return err
+	}
+	if !fn(shape) {
+		return nil
+	}
+	return s.write(shape)
+}
+
+func (s *Store) read() (*fileShape, error) {
+	empty := &fileShape{Version: fileVersion, Grants: map[string]*grantIndex{}}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return empty, nil
+		}
+		return nil, err
+	}
+
+	var shape fileShape
+	if err := json.Unmarshal(data, &shape); err != nil || shape.Version != fileVersion {
+		// A damaged or older index is rebuilt rather than migrated.
+		return empty, nil
+	}
+	if shape.Grants == nil {
+		shape.Grants = map[string]*grantIndex{}
+	}
+	return &shape, nil
+}
+
+func (s *Store) write(shape *fileShape) error {
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(shape)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".recipients-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
@@ -0,0 +1,106 @@
+package recipients
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestBuild(t *testing.T) {
+	day1 := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	contacts := []domain.Contact{
+		{GivenName: "Ana", Surname: "Silva", Emails: []domain.ContactEmail{{Email: "ana@example.com"}, {Email: "ana.silva@home.example"}}},
+		{Emails: []domain.ContactEmail{{Email: "noreply@bank.example"}}},
+	}
+	messages := []domain.Message{
+		{
+			Date: day1,
+			From: []domain.EmailParticipant{{Name: "Bob", Email: "bob@example.com"}},
+			To:   []domain.EmailParticipant{{Email: "me@example.com"}, {Email: "ANA@example.com"}},
+		},
+		{
+			Date: day2,
+			From: []domain.EmailParticipant{{Email: "no-reply@shop.example"}, {Email: "Me@Example.com"}},
+			To:   []domain.EmailParticipant{{Email: "bob@example.com"}},
+			Cc:   []domain.EmailParticipant{{Email: "not an address"}},
+		},
+	}
+
+	got := Build(contacts, messages, []string{"me@example.com"})
+
+	assert.Equal(t, []domain.Recipient{
+		{Email: "ana@example.com", Name: "Ana Silva", Contact: true, Count: 1, LastSeen: day1},
+		{Email: "ana.silva@home.example", Name: "Ana Silva", Contact: true},
+		{Email: "noreply@bank.example", Contact: true},
+		{Email: "bob@example.com", Name: "Bob", Count: 2, LastSeen: day2},
+	}, got)
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Now()
+	recipients := []domain.Recipient{
+		{Email: "bob@example.com", Name: "Bob Stone", Count: 2},
+		{Email: "ana@example.com", Name: "Ana Silva", Count: 1, LastSeen: now},
+		{Email: "ann@example.com", Count: 5},
+		{Email: "sam@example.com", Name: "Sam Anders", Contact: true},
+	}
+
+	emails := func(rs []domain.Recipient) []string {
+		out := make([]string, len(rs))
+		for i, r := range rs {
+			out[i] = r.Email
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"sam@example.com", "ann@example.com", "ana@example.com"}, emails(Match(recipients, "AN", 0)),
+		"contacts first, then the most written to; name words match")
+	assert.Equal(t, []string{"bob@example.com"}, emails(Match(recipients, "stone", 0)))
+	assert.Len(t, Match(recipients, "", 2), 2)
+	assert.Empty(t, Match(recipients, "zed", 0))
+}
+
+func TestStore(t *testing.T) {
+	store := New(filepath.Join(t.TempDir(), "nylas", "recipients.json"))
+	at := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.Record("grant-1", []domain.EmailParticipant{{Email: "ana@example.com"}}, at))
+	got, built, err := store.List("grant-1")
+	require.NoError(t, err)
+	assert.Empty(t, got, "recording doesn't create an index")
+	assert.True(t, built.IsZero())
+
+	require.NoError(t, store.Replace("grant-1", []domain.Recipient{{Email: "ana@example.com", Count: 1}}))
+	require.NoError(t, store.Record("grant-1", []domain.EmailParticipant{{Email: "Ana@example.com", Name: "Ana"}, {Email: "bob@example.com"}}, at))
+
+	got, built, err = store.List("grant-1")
+	require.NoError(t, err)
+	assert.False(t, built.IsZero())
+	assert.Equal(t, []domain.Recipient{
+		{Email: "ana@example.com", Name: "Ana", Count: 2, LastSeen: at},
+		{Email: "bob@example.com", Count: 1, LastSeen: at},
+	}, got)
+
+	found, err := store.Search("grant-1", "bo", 10)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "bob@example.com", found[0].Email)
+
+	other, _, err := store.List("grant-2")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestStore_RecordWithoutIndexWritesNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recipients.json")
+
+	require.NoError(t, New(path).Record("grant-1", []domain.EmailParticipant{{Email: "ana@example.com"}}, time.Now()))
+
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,146 @@
+package replyparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// quoteElementPattern matches the opening tag of elements that wrap quoted
+	// history: Gmail, Apple Mail/Thunderbird (<blockquote type="cite">), Yahoo,
+	// and this CLI's own replies.
+	quoteElementPattern = regexp.MustCompile(`(?i)<(div|blockquote)\b[^>]*(\bclass\s*=\s*["'][^"']*\b(gmail_quote|gmail_quote_container|yahoo_quoted|nylas_quote|moz-cite-prefix)\b|\bid\s*=\s*["']yahoo_quoted|\btype\s*=\s*["']cite["'])[^>]*>`)
+
+	// historyStartPattern matches markers after which everything is history:
+	// Outlook's reply header (divRplyFwdMsg, appendonsend, the stopSpelling
+	// rule) and its bordered "From:/Sent:" block.
+	historyStartPattern = regexp.MustCompile(`(?i)<(div|hr)\b[^>]*\bid\s*=\s*["'](divRplyFwdMsg|appendonsend|stopSpelling)["'][^>]*>|<div\b[^>]*style\s*=\s*["'][^"']*border-top\s*:\s*solid\s+#(E1E1E1|B5C4DF)[^"']*["'][^>]*>`)
+
+	// textSplitterPattern finds text separators inside HTML.
+	textSplitterPattern = regexp.MustCompile(`(?i)-{2,}\s*(original message|ursprüngliche nachricht|message d'origine|mensaje original)\s*-{2,}`)
+
+	// signatureElementPattern matches signature containers.
+	signatureElementPattern = regexp.MustCompile(`(?i)<(div|span|table)\b[^>]*(\bclass\s*=\s*["'][^"']*\b(gmail_signature|moz-signature)\b|\bid\s*=\s*["'](Signature|ms-outlook-mobile-signature)["']|\bdata-smartmail\s*=\s*["']gmail_signature["'])[^>]*>`)
+
+	mobileSigHTMLPattern = regexp.MustCompile(`(?i)(sent from my \w+|get outlook for (ios|android))`)
+)
+
+// parseHTML splits an HTML body. Quote and signature containers are removed
+// whole; Outlook-style history and text separators cut the rest of the body.
+func parseHTML(body string) Parts {
+	parts := Parts{HTML: true}
+
+	reply, quoted := cutHistory(body)
+	reply, moreQuoted := removeElements(reply, quoteElementPattern)
+	reply, signature := removeElements(reply, signatureElementPattern)
+
+	if loc := mobileSigHTMLPattern.FindStringIndex(reply); loc != nil && textTail(reply[loc[0]:]) {
+		cut := openingTagsStart(reply, loc[0])
+		signature = append(signature, reply[cut:])
+		reply = reply[:cut]
+	}
+
+	parts.Reply = strings.TrimSpace(reply)
+	parts.Quoted = strings.TrimSpace(strings.Join(append(moreQuoted, quoted), "\n"))
+	parts.Signature = strings.TrimSpace(strings.Join(signature, "\n"))
+	return parts
+}
+
+// cutHistory splits body at the first Outlook history marker or text
+// separator.
+func cutHistory(body string) (string, string) {
+	cut := len(body)
+	if loc := historyStartPattern.FindStringIndex(body); loc != nil {
+		cut = loc[0]
+	}
+	if loc := textSplitterPattern.FindStringIndex(body[:cut]); loc != nil {
+		// Start at the tag holding the separator so no half-open tag remains.
+		cut = max(0, strings.LastIndex(body[:loc[0]], "<"))
+		if cut == 0 && !strings.HasPrefix(body, "<") {
+			cut = loc[0]
+		}
+	}
+	return body[:cut], body[cut:]
+}
+
+// removeElements removes every element whose opening tag matches pattern,
+// with its content, returning the remaining HTML and the removed elements.
+func removeElements(body string, pattern *regexp.Regexp) (string, []string) {
+	var (
+		b       strings.Builder
+		removed []string
+	)
+	for {
+		loc := pattern.FindStringSubmatchIndex(body)
+		if loc == nil {
+			b.WriteString(body)
+			return b.String(), removed
+		}
+		name := strings.ToLower(body[loc[2]:loc[3]])
+		end := elementEnd(body, name, loc[1])
+		b.WriteString(body[:loc[0]])
+		removed = append(removed, body[loc[0]:end])
+		body = body[end:]
+	}
+}
+
+// elementEnd returns the offset just past the closing tag that matches an
+// element named name opened before offset from, or len(s) if it is unclosed.
+func elementEnd(s, name string, from int) int {
+	lower := strings.ToLower(s)
+	open, closing := "<"+name, "</"+name
+	depth := 1
+	for i := from; i < len(lower); {
+		next := strings.IndexByte(lower[i:], '<')
+		if next < 0 {
+			break
+		}
+		i += next
+		switch {
+		case strings.HasPrefix(lower[i:], closing) && tagNameEnds(lower, i+len(closing)):
+			depth--
+			if depth == 0 {
+				if gt := strings.IndexByte(lower[i:], '>'); gt >= 0 {
+					return i + gt + 1
+				}
+				return len(s)
+			}
+		case strings.HasPrefix(lower[i:], open) && tagNameEnds(lower, i+len(open)):
+			depth++
+		}
+		i++
+	}
+	return len(s)
+}
+
+// tagNameEnds reports whether the tag name ends at s[i], so that <div does
+// not match <divider.
+func tagNameEnds(s string, i int) bool {
+	if i >= len(s) {
+		return true
+	}
+	switch s[i] {
+	case ' ', '>', '/', '\t', '\n', '\r':
+		return true
+	}
+	return false
+}
+
+// openingTagsStart walks back from i over the opening tags that directly
+// precede it, so cutting there doesn't leave an unclosed element behind.
+func openingTagsStart(s string, i int) int {
+	for i > 0 && s[i-1] == '>' {
+		lt := strings.LastIndexByte(s[:i], '<')
+		if lt < 0 || strings.HasPrefix(s[lt:], "</") || strings.ContainsRune(s[lt+1:i-1], '>') {
+			break
+		}
+		i = lt
+	}
+	return i
+}
+
+// textTail reports whether s has little text left, so a mobile signature
+// marker near the end isn't mistaken for one quoted mid-message.
+func textTail(s string) bool {
+	return len(textContent(s)) <= 80
+}
@@ -0,0 +1,82 @@
+// Package replyparser separates the new content of an email from its quoted
+// reply history and signature, in the spirit of Mailgun's talon. It works on
+// both plain-text and HTML bodies without any network calls, so it can run on
+// every message shown or fed to an AI provider.
+package replyparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Parts is a message body split into its components. For HTML bodies each
+// part is HTML; for plain-text bodies each part is text.
+type Parts struct {
+	// Reply is the new content written by the sender.
+	Reply string `json:"reply"`
+	// Quoted is the reply/forward history that was removed.
+	Quoted string `json:"quoted,omitempty"`
+	// Signature is the trailing signature that was removed.
+	Signature string `json:"signature,omitempty"`
+	// HTML reports whether the parts are HTML.
+	HTML bool `json:"html"`
+}
+
+// htmlPattern detects HTML bodies.
+var htmlPattern = regexp.MustCompile(`(?i)<(html|body|div|p|br|span|table|blockquote)\b`)
+
+// Parse splits body into reply, quoted history and signature. When nothing
+// would remain of the reply (e.g. a bare forward), the whole body is kept as
+// the reply.
+func Parse(body string) Parts {
+	var parts Parts
+	if htmlPattern.MatchString(body) {
+		parts = parseHTML(body)
+	} else {
+		parts = parseText(body)
+	}
+	if strings.TrimSpace(textContent(parts.Reply)) == "" {
+		return Parts{Reply: body, HTML: parts.HTML}
+	}
+	return parts
+}
+
+// StripQuotes returns only the new content of body, in its original format.
+func StripQuotes(body string) string {
+	return Parse(body).Reply
+}
+
+// ReplyText returns the new content of body as plain text, for prompts and
+// other places that can't render HTML.
+func ReplyText(body string) string {
+	parts := Parse(body)
+	if !parts.HTML {
+		return parts.Reply
+	}
+	return textContent(parts.Reply)
+}
+
+var (
+	tagPattern       = regexp.MustCompile(`(?s)<[^>]*>`)
+	blockTagPattern  = regexp.MustCompile(`(?i)</?(?:br|p|div|tr|li|h[1-6]|blockquote)(?:\s[^>]*)?/?>`)
+	dropTagPattern   = regexp.MustCompile(`(?is)<(style|script|head)\b.*?</(style|script|head)>`)
+	blankRunsPattern = regexp.MustCompile(`\n{3,}`)
+	spaceRunsPattern = regexp.MustCompile(`[ \t\x{00a0}]+`)
+)
+
+// textContent converts HTML to readable plain text. Plain text passes through
+// with only whitespace tidied.
+func textContent(s string) string {
+	if htmlPattern.MatchString(s) {
+		s = dropTagPattern.ReplaceAllString(s, "")
+		s = blockTagPattern.ReplaceAllString(s, "\n")
+		s = tagPattern.ReplaceAllString(s, "")
+		s = unescapeHTML(s)
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaceRunsPattern.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(blankRunsPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
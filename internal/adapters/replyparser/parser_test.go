@@ -0,0 +1,211 @@
+package replyparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_Text(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantReply     string
+		wantQuoted    string
+		wantSignature string
+	}{
+		{
+			name:      "no history",
+			body:      "Sounds good, see you then.",
+			wantReply: "Sounds good, see you then.",
+		},
+		{
+			name:       "gmail attribution",
+			body:       "Works for me.\n\nOn Mon, Jan 6, 2025 at 9:00 AM Alice <alice@example.com> wrote:\n> Can we meet Tuesday?\n> Alice",
+			wantReply:  "Works for me.",
+			wantQuoted: "On Mon, Jan 6, 2025 at 9:00 AM Alice <alice@example.com> wrote:\n> Can we meet Tuesday?\n> Alice",
+		},
+		{
+			name:       "wrapped attribution",
+			body:       "Yes.\n\nOn Mon, Jan 6, 2025 at 9:00 AM Alice Example\n<alice@example.com> wrote:\n> Ready?",
+			wantReply:  "Yes.",
+			wantQuoted: "On Mon, Jan 6, 2025 at 9:00 AM Alice Example\n<alice@example.com> wrote:\n> Ready?",
+		},
+		{
+			name:       "german attribution",
+			body:       "Passt.\n\nAm 06.01.2025 um 09:00 schrieb Alice <alice@example.com>:\n> Dienstag?",
+			wantReply:  "Passt.",
+			wantQuoted: "Am 06.01.2025 um 09:00 schrieb Alice <alice@example.com>:\n> Dienstag?",
+		},
+		{
+			name:       "original message splitter",
+			body:       "Approved.\n\n-----Original Message-----\nFrom: Bob\nPlease approve.",
+			wantReply:  "Approved.",
+			wantQuoted: "-----Original Message-----\nFrom: Bob\nPlease approve.",
+		},
+		{
+			name:       "outlook header block",
+			body:       "Done.\n\nFrom: Bob <bob@example.com>\nSent: Monday, January 6, 2025 9:00 AM\nTo: Alice\nSubject: Task\n\nPlease do it.",
+			wantReply:  "Done.",
+			wantQuoted: "From: Bob <bob@example.com>\nSent: Monday, January 6, 2025 9:00 AM\nTo: Alice\nSubject: Task\n\nPlease do it.",
+		},
+		{
+			name:       "trailing quote without attribution",
+			body:       "Agreed.\n\n> We should ship Friday.\n> Thoughts?",
+			wantReply:  "Agreed.",
+			wantQuoted: "> We should ship Friday.\n> Thoughts?",
+		},
+		{
+			name:      "interleaved reply is kept whole",
+			body:      "On Mon, Alice wrote:\n> Tuesday?\nYes, Tuesday works.\n> 10am?\nMake it 11.",
+			wantReply: "On Mon, Alice wrote:\n> Tuesday?\nYes, Tuesday works.\n> 10am?\nMake it 11.",
+		},
+		{
+			name:          "signature delimiter",
+			body:          "See attached.\n\n-- \nBob Smith\nAcme Corp",
+			wantReply:     "See attached.",
+			wantSignature: "-- \nBob Smith\nAcme Corp",
+		},
+		{
+			name:          "mobile signature",
+			body:          "On my way.\n\nSent from my iPhone",
+			wantReply:     "On my way.",
+			wantSignature: "Sent from my iPhone",
+		},
+		{
+			name:          "sign-off keeps closing line",
+			body:          "The report is ready.\n\nThanks,\nBob Smith\nHead of Ops",
+			wantReply:     "The report is ready.\n\nThanks,",
+			wantSignature: "Bob Smith\nHead of Ops",
+		},
+		{
+			name:      "sign-off followed by prose is not a signature",
+			body:      "Thanks,\nI looked into the issue and the root cause is the retry loop in the sync worker.",
+			wantReply: "Thanks,\nI looked into the issue and the root cause is the retry loop in the sync worker.",
+		},
+		{
+			name:          "signature and quote",
+			body:          "Confirmed.\n--\nBob\n\nOn Mon, Alice wrote:\n> Confirm?",
+			wantReply:     "Confirmed.",
+			wantQuoted:    "On Mon, Alice wrote:\n> Confirm?",
+			wantSignature: "--\nBob",
+		},
+		{
+			name:      "bare forward keeps body",
+			body:      "---------- Forwarded message ---------\nFrom: Alice\nFYI",
+			wantReply: "---------- Forwarded message ---------\nFrom: Alice\nFYI",
+		},
+		{
+			name:       "crlf line endings",
+			body:       "Ok.\r\n\r\nOn Mon, Alice wrote:\r\n> Ok?",
+			wantReply:  "Ok.",
+			wantQuoted: "On Mon, Alice wrote:\n> Ok?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := Parse(tt.body)
+			assert.False(t, parts.HTML)
+			assert.Equal(t, tt.wantReply, parts.Reply)
+			assert.Equal(t, tt.wantQuoted, parts.Quoted)
+			assert.Equal(t, tt.wantSignature, parts.Signature)
+		})
+	}
+}
+
+func TestParse_HTML(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantReply     string
+		wantQuoted    []string
+		wantSignature []string
+	}{
+		{
+			name:       "gmail quote",
+			body:       `<div dir="ltr">Works for me.</div><br><div class="gmail_quote"><div class="gmail_attr">On Mon, Alice wrote:</div><blockquote class="gmail_quote"><div>Tuesday?</div></blockquote></div>`,
+			wantReply:  `<div dir="ltr">Works for me.</div><br>`,
+			wantQuoted: []string{"gmail_attr", "Tuesday?"},
+		},
+		{
+			name:       "apple mail cite",
+			body:       `<html><body><div>Sure.</div><div><br><blockquote type="cite"><div>Lunch?</div></blockquote></div></body></html>`,
+			wantReply:  `<html><body><div>Sure.</div><div><br></div></body></html>`,
+			wantQuoted: []string{"Lunch?"},
+		},
+		{
+			name:       "outlook reply header",
+			body:       `<div>Approved.</div><hr style="display:inline-block"><div id="divRplyFwdMsg"><b>From:</b> Bob</div><div>Please approve.</div>`,
+			wantReply:  `<div>Approved.</div><hr style="display:inline-block">`,
+			wantQuoted: []string{"divRplyFwdMsg", "Please approve."},
+		},
+		{
+			name:       "original message separator",
+			body:       `<p>Done.</p><p>-----Original Message-----<br>From: Bob</p>`,
+			wantReply:  `<p>Done.</p>`,
+			wantQuoted: []string{"Original Message"},
+		},
+		{
+			name:          "gmail signature",
+			body:          `<div>See attached.</div><div><div dir="ltr" class="gmail_signature" data-smartmail="gmail_signature"><div>Bob</div></div></div>`,
+			wantReply:     `<div>See attached.</div><div></div>`,
+			wantSignature: []string{"Bob"},
+		},
+		{
+			name:          "mobile signature",
+			body:          `<div>On my way.</div><div>Sent from my iPhone</div>`,
+			wantReply:     `<div>On my way.</div>`,
+			wantSignature: []string{"Sent from my iPhone"},
+		},
+		{
+			name:       "nested quotes are removed whole",
+			body:       `<p>Yes.</p><blockquote type="cite"><p>Really?</p><blockquote type="cite"><p>Ship it.</p></blockquote><p>Tail</p></blockquote>`,
+			wantReply:  `<p>Yes.</p>`,
+			wantQuoted: []string{"Really?", "Ship it.", "Tail"},
+		},
+		{
+			name:      "bare forward keeps body",
+			body:      `<div class="nylas_forward"><p>---------- Forwarded message ----------</p><p>FYI</p></div>`,
+			wantReply: `<div class="nylas_forward"><p>---------- Forwarded message ----------</p><p>FYI</p></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := Parse(tt.body)
+			assert.True(t, parts.HTML)
+			assert.Equal(t, tt.wantReply, parts.Reply)
+			for _, want := range tt.wantQuoted {
+				assert.Contains(t, parts.Quoted, want)
+			}
+			for _, want := range tt.wantSignature {
+				assert.Contains(t, parts.Signature, want)
+			}
+		})
+	}
+}
+
+func TestStripQuotes(t *testing.T) {
+	assert.Equal(t, "Yes.", StripQuotes("Yes.\n\nOn Mon, Alice wrote:\n> Ok?"))
+	assert.Equal(t, "", StripQuotes(""))
+}
+
+func TestReplyText(t *testing.T) {
+	t.Run("html is converted to text", func(t *testing.T) {
+		body := `<div>Works&nbsp;for me.</div><div>See you <b>Tuesday</b>.</div><div class="gmail_quote">On Mon, Alice wrote:<blockquote>Tuesday?</blockquote></div>`
+		assert.Equal(t, "Works for me.\n\nSee you Tuesday.", ReplyText(body))
+	})
+
+	t.Run("text passes through", func(t *testing.T) {
+		assert.Equal(t, "Agreed.", ReplyText("Agreed.\n\n> Ship Friday?"))
+	})
+}
+
+func TestElementEnd(t *testing.T) {
+	s := `<div a><divider></divider><div>x</div></div>rest`
+	end := elementEnd(s, "div", len(`<div a>`))
+	assert.Equal(t, "rest", s[end:])
+
+	assert.Equal(t, len("<div>open"), elementEnd("<div>open", "div", len("<div>")))
+}
@@ -0,0 +1,179 @@
+package replyparser
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// attributionPattern matches "On <date>, <sender> wrote:" in the languages
+	// mail clients commonly localize it to.
+	attributionPattern = regexp.MustCompile(`(?i)^\s*(on|am|le|el|il|op|em|den|w dniu)\s.*(wrote|schrieb|a écrit|escribió|ha scritto|schreef|escreveu|skrev|napisał)(\s*:?|\s.*:)\s*$`)
+	// attributionStartPattern matches the first line of an attribution that
+	// the client wrapped over several lines.
+	attributionStartPattern = regexp.MustCompile(`(?i)^\s*(on|am|le|el|il|op|em|den|w dniu)\s`)
+	attributionEndPattern   = regexp.MustCompile(`(?i)(wrote|schrieb|a écrit|escribió|ha scritto|schreef|escreveu|skrev|napisał)(\s.*)?:\s*$`)
+
+	// splitterPattern matches separators after which everything is history.
+	splitterPattern = regexp.MustCompile(`(?i)^\s*(-{2,}\s*(original message|ursprüngliche nachricht|message d'origine|mensaje original|forwarded message|weitergeleitete nachricht)\s*-{2,}|begin forwarded message:|_{20,})\s*$`)
+	// headerFromPattern and headerSentPattern detect Outlook-style header
+	// blocks ("From: ... / Sent: ...") that start an unprefixed quote.
+	headerFromPattern = regexp.MustCompile(`(?i)^\s*\*?(from|von|de|van)\s*:\*?\s+\S`)
+	headerSentPattern = regexp.MustCompile(`(?i)^\s*\*?(sent|date|gesendet|datum|envoyé|enviado|verzonden)\s*:\*?\s+\S`)
+
+	// Signature markers.
+	sigDelimiterPattern = regexp.MustCompile(`^--\s?$`)
+	mobileSigPattern    = regexp.MustCompile(`(?i)^\s*(sent from (my |mail for |yahoo mail|outlook)|get outlook for |sent via )`)
+	signOffPattern      = regexp.MustCompile(`(?i)^\s*(best|best regards|kind regards|warm regards|regards|many thanks|thanks|thank you|thanks again|cheers|sincerely|br|thx|ta|all the best|viele grüße|mit freundlichen grüßen|cordialement)\s*[,.!]?\s*$`)
+)
+
+const (
+	// sigMaxLines bounds how far from the end a signature may start.
+	sigMaxLines = 12
+	// signOffMaxLines bounds the name/title lines after a sign-off.
+	signOffMaxLines = 4
+	// signOffMaxLineLen rejects sign-off blocks followed by prose.
+	signOffMaxLineLen = 60
+)
+
+// parseText splits a plain-text body.
+func parseText(body string) Parts {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	cut := quoteStart(lines)
+	reply, quoted := lines[:cut], lines[cut:]
+
+	sig := signatureStart(reply)
+	reply, signature := reply[:sig], reply[sig:]
+
+	return Parts{
+		Reply:     strings.TrimSpace(strings.Join(reply, "\n")),
+		Quoted:    strings.TrimSpace(strings.Join(quoted, "\n")),
+		Signature: strings.TrimSpace(strings.Join(signature, "\n")),
+	}
+}
+
+// quoteStart returns the index of the first line of quoted history, or
+// len(lines) when there is none.
+//
+// Splitters ("-----Original Message-----", Outlook header blocks) always
+// start history. An attribution line starts history unless text written by
+// the sender follows among the quoted lines, which means the reply is
+// interleaved with the quote and should be kept whole. Without either, a
+// trailing block of ">" lines is history.
+func quoteStart(lines []string) int {
+	for i, line := range lines {
+		switch {
+		case splitterPattern.MatchString(line), isHeaderBlock(lines, i):
+			return i
+		case isAttribution(lines, i):
+			if interleaved(lines[i+1:]) {
+				return len(lines)
+			}
+			return i
+		}
+	}
+
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	start := end
+	for start > 0 && (isQuoted(lines[start-1]) || (strings.TrimSpace(lines[start-1]) == "" && start < end)) {
+		start--
+	}
+	if start == end {
+		return len(lines)
+	}
+	return start
+}
+
+func isQuoted(line string) bool {
+	return strings.HasPrefix(strings.TrimLeft(line, " \t"), ">")
+}
+
+// isAttribution reports whether an attribution starts at lines[i], allowing
+// it to wrap over up to three lines.
+func isAttribution(lines []string, i int) bool {
+	if attributionPattern.MatchString(lines[i]) {
+		return true
+	}
+	if !attributionStartPattern.MatchString(lines[i]) {
+		return false
+	}
+	for j := i + 1; j < len(lines) && j <= i+2; j++ {
+		if strings.TrimSpace(lines[j]) == "" {
+			return false
+		}
+		if attributionEndPattern.MatchString(lines[j]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHeaderBlock reports whether lines[i] starts a "From:/Sent:" header block.
+func isHeaderBlock(lines []string, i int) bool {
+	if !headerFromPattern.MatchString(lines[i]) {
+		return false
+	}
+	for j := i + 1; j < len(lines) && j <= i+4; j++ {
+		if headerSentPattern.MatchString(lines[j]) {
+			return true
+		}
+	}
+	return false
+}
+
+// interleaved reports whether unquoted text is mixed in with ">" lines, as in
+// an inline reply.
+func interleaved(lines []string) bool {
+	sawQuote := false
+	for _, line := range lines {
+		switch {
+		case isQuoted(line):
+			sawQuote = true
+		case strings.TrimSpace(line) == "":
+		case sawQuote:
+			return true
+		}
+	}
+	return false
+}
+
+// signatureStart returns the index of the first signature line, or
+// len(lines) when none is found near the end.
+func signatureStart(lines []string) int {
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	first := max(0, end-sigMaxLines)
+
+	for i := first; i < end; i++ {
+		if sigDelimiterPattern.MatchString(lines[i]) || mobileSigPattern.MatchString(lines[i]) {
+			return i
+		}
+	}
+
+	// A sign-off ("Thanks,") followed by a few short lines: keep the sign-off
+	// with the reply and treat the name/title lines as the signature.
+	for i := end - 1; i >= first && i >= end-signOffMaxLines-1; i-- {
+		if !signOffPattern.MatchString(lines[i]) {
+			continue
+		}
+		for _, line := range lines[i+1 : end] {
+			if len(strings.TrimSpace(line)) > signOffMaxLineLen {
+				return len(lines)
+			}
+		}
+		return i + 1
+	}
+	return len(lines)
+}
+
+// unescapeHTML decodes entities, mapping non-breaking spaces to spaces.
+func unescapeHTML(s string) string {
+	return strings.ReplaceAll(html.UnescapeString(s), "\u00a0", " ")
+}
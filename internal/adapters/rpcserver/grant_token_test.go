@@ -0,0 +1,239 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func testGrantToken(scopes ...domain.Scope) *domain.GrantToken {
+	return &domain.GrantToken{ID: "tok-1", GrantID: "grant-1", Scopes: scopes, ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+func TestMethodScope(t *testing.T) {
+	tests := []struct {
+		method string
+		want   domain.Scope
+		ok     bool
+	}{
+		{"email.list", domain.ScopeEmailRead, true},
+		{"email.attachment.download", domain.ScopeEmailRead, true},
+		{"email.update", domain.ScopeEmailModify, true},
+		{"email.send", domain.ScopeEmailSend, true},
+		{"draft.send", domain.ScopeEmailSend, true},
+		{"draft.create", domain.ScopeEmailModify, true},
+		{"thread.get", domain.ScopeEmailRead, true},
+		{"event.list", domain.ScopeCalendarRead, true},
+		{"calendar.freeBusy", domain.ScopeCalendarRead, true},
+		{"event.rsvp", domain.ScopeCalendarWrite, true},
+		{"event.import", domain.ScopeCalendarRead, true},
+		{"scheduler.config.list", domain.ScopeCalendarRead, true},
+		{"scheduler.groupEvent.delete", domain.ScopeCalendarWrite, true},
+		{"scheduler.booking.cancel", "", false},
+		{"scheduler.session.create", "", false},
+		{"scheduler.groupEvent.import", "", false},
+		{"calendar.virtual.delete", "", false},
+		{"contact.getWithPicture", domain.ScopeContactsRead, true},
+		{"contact.delete", domain.ScopeContactsWrite, true},
+		{"admin.app.list", "", false},
+		{"audit.list", "", false},
+		{"config.read", "", false},
+		{"auth.grant.revoke", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := methodScope(tt.method)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("methodScope(%q) = %q, %v; want %q, %v", tt.method, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestAuthorizeGrantToken(t *testing.T) {
+	token := testGrantToken(domain.ScopeEmailRead)
+	now := time.Now()
+
+	params, rpcErr := authorizeGrantToken(token, "email.list", json.RawMessage(`{"limit":5}`), now)
+	if rpcErr != nil {
+		t.Fatalf("authorizeGrantToken() error = %v", rpcErr)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(params, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["grant_id"] != "grant-1" || got["limit"] != float64(5) {
+		t.Errorf("params = %v, want grant pinned and limit kept", got)
+	}
+
+	if params, rpcErr := authorizeGrantToken(token, "email.get", nil, now); rpcErr != nil || string(params) != `{"grant_id":"grant-1"}` {
+		t.Errorf("empty params = %s, %v; want grant only", params, rpcErr)
+	}
+
+	denied := []struct {
+		name   string
+		method string
+		params string
+		at     time.Time
+		code   int
+	}{
+		{"missing scope", "email.send", `{}`, now, Unauthorized},
+		{"app-level method", "admin.app.list", `{}`, now, Unauthorized},
+		{"other grant", "email.list", `{"grant_id":"grant-2"}`, now, Unauthorized},
+		{"expired", "email.list", `{}`, token.ExpiresAt, Unauthorized},
+		{"non-object params", "email.list", `[1]`, now, InvalidParams},
+	}
+	for _, tt := range denied {
+		if _, rpcErr := authorizeGrantToken(token, tt.method, json.RawMessage(tt.params), tt.at); rpcErr == nil || rpcErr.Code != tt.code {
+			t.Errorf("%s: error = %v, want code %d", tt.name, rpcErr, tt.code)
+		}
+	}
+
+	// Booking and import calls aren't keyed by grant, so even a token with
+	// every calendar scope can't reach another grant's bookings through them.
+	calendarToken := testGrantToken(domain.ScopeCalendarRead, domain.ScopeCalendarWrite)
+	for _, method := range []string{"scheduler.booking.get", "scheduler.booking.cancel", "scheduler.session.create", "scheduler.groupEvent.import"} {
+		params := `{"configuration_id":"cfg-of-grant-2","booking_id":"b-1","config_id":"cfg-of-grant-2"}`
+		if _, rpcErr := authorizeGrantToken(calendarToken, method, json.RawMessage(params), now); rpcErr == nil || rpcErr.Code != Unauthorized {
+			t.Errorf("%s: error = %v, want Unauthorized", method, rpcErr)
+		}
+	}
+	readToken := testGrantToken(domain.ScopeCalendarRead)
+	if _, rpcErr := authorizeGrantToken(readToken, "scheduler.groupEvent.import", json.RawMessage(`{"config_id":"cfg-1"}`), now); rpcErr == nil || rpcErr.Code != Unauthorized {
+		t.Errorf("read-only groupEvent.import: error = %v, want Unauthorized", rpcErr)
+	}
+	if _, rpcErr := authorizeGrantToken(readToken, "event.import", json.RawMessage(`{"calendar_id":"primary"}`), now); rpcErr != nil {
+		t.Errorf("read-only event.import: error = %v", rpcErr)
+	}
+
+	if _, rpcErr := authorizeGrantToken(token, "email.list", json.RawMessage(`{"grant_id":"grant-1"}`), now); rpcErr != nil {
+		t.Errorf("same grant: error = %v", rpcErr)
+	}
+	if _, rpcErr := authorizeGrantToken(token, "client.focus", json.RawMessage(`{"focused":true}`), now); rpcErr != nil {
+		t.Errorf("client.focus: error = %v", rpcErr)
+	}
+}
+
+func TestReceives(t *testing.T) {
+	now := time.Now()
+	token := testGrantToken(domain.ScopeEmailRead)
+
+	if !receives(nil, "contact.updated", "grant-1", now) {
+		t.Error("session connections receive every notification")
+	}
+	if !receives(token, "message.received", "grant-1", now) {
+		t.Error("token with email.read should receive message.received")
+	}
+	if receives(token, "event.updated", "grant-1", now) {
+		t.Error("token without calendar.read received event.updated")
+	}
+	if receives(token, "message.received", "grant-2", now) {
+		t.Error("token received another grant's notification")
+	}
+	if receives(token, "message.received", "grant-1", token.ExpiresAt) {
+		t.Error("expired token received a notification")
+	}
+}
+
+func TestServer_GrantTokenConnection(t *testing.T) {
+	d := NewDispatcher()
+	d.Register("email.list", func(_ context.Context, params json.RawMessage) (any, error) {
+		var p struct {
+			GrantID string `json:"grant_id"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return map[string]string{"grant_id": p.GrantID}, nil
+	})
+	d.Register("email.send", func(context.Context, json.RawMessage) (any, error) {
+		t.Error("email.send reached its handler")
+		return nil, nil
+	})
+
+	srv := NewServer(Config{
+		Token:         "secret-token",
+		NotifyGrantID: "grant-1",
+		VerifyGrantToken: func(token string) (*domain.GrantToken, error) {
+			if token != "nylt_valid" {
+				return nil, domain.ErrGrantTokenInvalid
+			}
+			return testGrantToken(domain.ScopeEmailRead), nil
+		},
+	}, d)
+	httpSrv := newHTTPTestServer(t, srv.handler())
+	t.Cleanup(httpSrv.Close)
+	wsURL := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+
+	if _, resp, err := websocket.DefaultDialer.Dial(wsURL, authHeader("nylt_forged")); err == nil {
+		t.Fatal("Dial() with an invalid grant token succeeded")
+	} else if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL+"?token=nylt_valid", nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	if resp != nil {
+		defer func() { _ = resp.Body.Close() }()
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	var response struct {
+		Result map[string]string `json:"result"`
+		Error  *RPCError         `json:"error"`
+	}
+	send := func(msg string) {
+		t.Helper()
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+			t.Fatalf("WriteMessage() error = %v", err)
+		}
+		response.Result, response.Error = nil, nil
+		readJSON(t, conn, &response)
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"email.list","params":{}}`)
+	if response.Error != nil || response.Result["grant_id"] != "grant-1" {
+		t.Fatalf("email.list response = %+v, want the token's grant", response)
+	}
+	send(`{"jsonrpc":"2.0","id":2,"method":"email.send","params":{}}`)
+	if response.Error == nil || response.Error.Code != Unauthorized {
+		t.Fatalf("email.send response = %+v, want unauthorized", response)
+	}
+
+	// Only notifications the token may see are delivered.
+	if err := srv.Broadcast("event.updated", map[string]string{"id": "evt-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Broadcast("message.received", map[string]string{"id": "msg-1"}); err != nil {
+		t.Fatal(err)
+	}
+	var notification struct {
+		Method string `json:"method"`
+	}
+	readJSON(t, conn, &notification)
+	if notification.Method != "message.received" {
+		t.Fatalf("notification = %q, want message.received", notification.Method)
+	}
+}
+
+func TestServer_GrantTokensDisabledWithoutVerifier(t *testing.T) {
+	srv := NewServer(Config{Token: "secret-token"}, NewDispatcher())
+	httpSrv := newHTTPTestServer(t, srv.handler())
+	t.Cleanup(httpSrv.Close)
+	wsURL := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, authHeader("nylt_valid"))
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err == nil || !errors.Is(err, websocket.ErrBadHandshake) {
+		t.Fatalf("Dial() error = %v, want bad handshake", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 )
 
 const (
@@ -68,6 +69,17 @@ func (d *Dispatcher) Dispatch(ctx context.Context, raw []byte) []byte {
 		})
 	}
 
+	if token := grantTokenFrom(ctx); token != nil {
+		params, rpcErr := authorizeGrantToken(token, req.Method, req.Params, time.Now())
+		if rpcErr != nil {
+			if req.ID == nil {
+				return nil
+			}
+			return marshalResponse(errorResponse(req.ID, rpcErr))
+		}
+		req.Params = params
+	}
+
 	if req.ID == nil {
 		if h, ok := d.handlers[req.Method]; ok {
 			if _, err := h(ctx, req.Params); err != nil {
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/nylas/cli/internal/domain"
 )
 
 const shutdownTimeout = 5 * time.Second
@@ -18,6 +20,12 @@ type Config struct {
 	Addr           string
 	Token          string
 	AllowedOrigins []string
+
+	// VerifyGrantToken, when set, also admits connections bearing a grant
+	// token; their calls are limited to the token's grant and scopes.
+	VerifyGrantToken func(token string) (*domain.GrantToken, error)
+	// NotifyGrantID is the grant whose changes are broadcast.
+	NotifyGrantID string
 }
 
 type Server struct {
@@ -32,6 +40,7 @@ type Server struct {
 
 type clientConn struct {
 	conn    *websocket.Conn
+	token   *domain.GrantToken // nil for session-token connections
 	writeMu sync.Mutex
 }
 
@@ -57,8 +66,11 @@ func (s *Server) Broadcast(method string, params any) error {
 
 	s.mu.Lock()
 	conns := make([]*clientConn, 0, len(s.conns))
+	now := time.Now()
 	for _, c := range s.conns {
-		conns = append(conns, c)
+		if receives(c.token, method, s.cfg.NotifyGrantID, now) {
+			conns = append(conns, c)
+		}
 	}
 	s.mu.Unlock()
 
@@ -118,7 +130,8 @@ func (s *Server) handler() http.Handler {
 }
 
 func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
-	if !s.authorized(r) {
+	token, ok := s.authorized(r)
+	if !ok {
 		http.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
@@ -132,7 +145,7 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c := &clientConn{conn: conn}
+	c := &clientConn{conn: conn, token: token}
 	s.register(c)
 	defer s.unregister(c)
 
@@ -142,7 +155,7 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	if baseCtx == nil {
 		baseCtx = context.Background()
 	}
-	connCtx, cancel := context.WithCancel(baseCtx)
+	connCtx, cancel := context.WithCancel(withGrantToken(baseCtx, token))
 	defer cancel()
 
 	for {
@@ -164,11 +177,27 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) authorized(r *http.Request) bool {
-	if token := bearerToken(r.Header.Get("Authorization")); ValidateToken(s.cfg.Token, token) {
-		return true
+// authorized checks the request's token. A session token yields a nil grant
+// token; a valid grant token is returned for per-call checks.
+func (s *Server) authorized(r *http.Request) (*domain.GrantToken, bool) {
+	candidates := []string{bearerToken(r.Header.Get("Authorization")), r.URL.Query().Get("token")}
+	for _, provided := range candidates {
+		if ValidateToken(s.cfg.Token, provided) {
+			return nil, true
+		}
+	}
+	if s.cfg.VerifyGrantToken == nil {
+		return nil, false
+	}
+	for _, provided := range candidates {
+		if provided == "" {
+			continue
+		}
+		if token, err := s.cfg.VerifyGrantToken(provided); err == nil {
+			return token, true
+		}
 	}
-	return ValidateToken(s.cfg.Token, r.URL.Query().Get("token"))
+	return nil, false
 }
 
 func bearerToken(header string) string {
@@ -0,0 +1,496 @@
+package smime
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// passwordEnv carries the PKCS#12 password to openssl so it never appears in
+// the process list.
+const passwordEnv = "NYLAS_SMIME_P12_PASSWORD"
+
+// ErrNoCertificate is returned when no usable certificate matches an address.
+var ErrNoCertificate = errors.New("no S/MIME certificate found")
+
+// Service provides S/MIME signing, verification, and encryption using the
+// system openssl command. Identities are PKCS#12 (.p12/.pfx) files in the
+// service directory; recipient certificates are PEM or DER files there or,
+// on macOS, certificates in the login keychain.
+type Service struct {
+	dir string
+
+	// run executes openssl; replaced in tests.
+	run func(ctx context.Context, env []string, args ...string) ([]byte, error)
+	// keychain returns PEM certificates for an email from the OS keychain,
+	// or nil where there is no supported keychain.
+	keychain func(ctx context.Context, email string) ([]byte, error)
+	now      func() time.Time
+}
+
+// NewService creates an S/MIME service that looks for identities and
+// certificates in dir.
+func NewService(dir string) *Service {
+	s := &Service{dir: dir, run: runOpenSSL, now: time.Now}
+	if runtime.GOOS == "darwin" {
+		s.keychain = macKeychainCertificates
+	}
+	return s
+}
+
+// Dir returns the directory searched for identities and certificates.
+func (s *Service) Dir() string {
+	return s.dir
+}
+
+// CheckOpenSSLAvailable verifies openssl is installed.
+func (s *Service) CheckOpenSSLAvailable(ctx context.Context) error {
+	if _, err := s.run(ctx, nil, "version"); err != nil {
+		return fmt.Errorf("openssl not found. Install with: sudo apt install openssl (Linux) or brew install openssl (macOS)")
+	}
+	return nil
+}
+
+// LoadIdentity reads a PKCS#12 file and returns its signing identity.
+func (s *Service) LoadIdentity(ctx context.Context, path, password string) (*Identity, error) {
+	env := []string{passwordEnv + "=" + password}
+	args := []string{"pkcs12", "-in", path, "-passin", "env:" + passwordEnv, "-nodes"}
+	out, err := s.run(ctx, env, args...)
+	if err != nil && strings.Contains(err.Error(), "unsupported") {
+		// Files exported by older tools use RC2/3DES, which OpenSSL 3 only
+		// reads with the legacy provider.
+		out, err = s.run(ctx, env, append(args, "-legacy")...)
+	}
+	if err != nil {
+		if strings.Contains(err.Error(), "mac verify failure") || strings.Contains(err.Error(), "invalid password") {
+			return nil, fmt.Errorf("wrong password for %s", filepath.Base(path))
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+
+	id, err := parseIdentityPEM(out)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	id.Source = path
+	return id, nil
+}
+
+// FindIdentity returns the first valid identity for email among the PKCS#12
+// files in the service directory. All files are tried with password.
+func (s *Service) FindIdentity(ctx context.Context, email, password string) (*Identity, error) {
+	files, err := s.listFiles(".p12", ".pfx")
+	if err != nil {
+		return nil, err
+	}
+	var lastErr error
+	for _, path := range files {
+		id, err := s.LoadIdentity(ctx, path, password)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if matchesEmail(id.Certificate, email) && usableAt(id.Certificate, s.now()) {
+			return id, nil
+		}
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("no S/MIME identity for %s in %s (last error: %w)", email, s.dir, lastErr)
+	}
+	return nil, fmt.Errorf("no S/MIME identity for %s in %s", email, s.dir)
+}
+
+// FindCertificate returns a valid certificate issued to email from the
+// service directory or the OS keychain. It returns ErrNoCertificate when
+// none is found.
+func (s *Service) FindCertificate(ctx context.Context, email string) (*x509.Certificate, string, error) {
+	files, err := s.listFiles(".pem", ".crt", ".cer")
+	if err != nil {
+		return nil, "", err
+	}
+	for _, path := range files {
+		data, err := os.ReadFile(path) // #nosec G304 -- files in the S/MIME directory
+		if err != nil {
+			continue
+		}
+		if cert := s.pickCertificate(parseCertificates(data), email); cert != nil {
+			return cert, path, nil
+		}
+	}
+
+	if s.keychain != nil {
+		data, err := s.keychain(ctx, email)
+		if err == nil {
+			if cert := s.pickCertificate(parseCertificates(data), email); cert != nil {
+				return cert, "keychain", nil
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("%w for %s", ErrNoCertificate, email)
+}
+
+// ListCertificates returns the certificates in the service directory,
+// including those inside PKCS#12 files when password opens them.
+func (s *Service) ListCertificates(ctx context.Context, password string) ([]CertificateInfo, error) {
+	var infos []CertificateInfo
+	files, err := s.listFiles(".pem", ".crt", ".cer")
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range files {
+		data, err := os.ReadFile(path) // #nosec G304 -- files in the S/MIME directory
+		if err != nil {
+			continue
+		}
+		for _, cert := range parseCertificates(data) {
+			infos = append(infos, Info(cert, path))
+		}
+	}
+
+	identities, err := s.listFiles(".p12", ".pfx")
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range identities {
+		id, err := s.LoadIdentity(ctx, path, password)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, Info(id.Certificate, path))
+	}
+	return infos, nil
+}
+
+// Sign creates a detached DER-encoded CMS signature over data.
+func (s *Service) Sign(ctx context.Context, id *Identity, data []byte) ([]byte, error) {
+	dir, cleanup, err := tempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	dataFile, err := writeTemp(dir, "data", data)
+	if err != nil {
+		return nil, err
+	}
+	certFile, err := writeTemp(dir, "signer.pem", encodeCertificates(id.Certificate))
+	if err != nil {
+		return nil, err
+	}
+	keyFile, err := writeTemp(dir, "key.pem", id.keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"cms", "-sign", "-binary", "-md", "sha256", "-outform", "DER",
+		"-in", dataFile, "-signer", certFile, "-inkey", keyFile}
+	if len(id.Chain) > 0 {
+		chainFile, err := writeTemp(dir, "chain.pem", encodeCertificates(id.Chain...))
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-certfile", chainFile)
+	}
+	sig, err := s.run(ctx, nil, args...)
+	if err != nil {
+		return nil, fmt.Errorf("S/MIME signing failed: %w", err)
+	}
+	return sig, nil
+}
+
+// Encrypt encrypts data for the given certificates as DER-encoded CMS
+// EnvelopedData using AES-256-CBC.
+func (s *Service) Encrypt(ctx context.Context, certs []*x509.Certificate, data []byte) (*EncryptResult, error) {
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("at least one recipient certificate is required")
+	}
+	dir, cleanup, err := tempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	dataFile, err := writeTemp(dir, "data", data)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"cms", "-encrypt", "-binary", "-aes-256-cbc", "-outform", "DER", "-in", dataFile}
+	result := &EncryptResult{}
+	for i, cert := range certs {
+		certFile, err := writeTemp(dir, fmt.Sprintf("recipient-%d.pem", i), encodeCertificates(cert))
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, certFile)
+		result.Recipients = append(result.Recipients, certificateEmails(cert)...)
+	}
+
+	result.Ciphertext, err = s.run(ctx, nil, args...)
+	if err != nil {
+		return nil, fmt.Errorf("S/MIME encryption failed: %w", err)
+	}
+	return result, nil
+}
+
+// Verify checks a DER-encoded CMS signature. With content, the signature is
+// detached (multipart/signed); without, it is opaque signed-data. A
+// signature that matches but whose signer doesn't chain to a trusted CA is
+// Valid but not Trusted.
+func (s *Service) Verify(ctx context.Context, content, signature []byte) (*VerifyResult, error) {
+	dir, cleanup, err := tempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	sigFile, err := writeTemp(dir, "signature.p7s", signature)
+	if err != nil {
+		return nil, err
+	}
+	signerFile := filepath.Join(dir, "signer.pem")
+	args := []string{"cms", "-verify", "-binary", "-inform", "DER", "-in", sigFile,
+		"-signer", signerFile, "-out", filepath.Join(dir, "content")}
+	if content != nil {
+		contentFile, err := writeTemp(dir, "content.in", content)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-content", contentFile)
+	}
+
+	result := &VerifyResult{}
+	if _, err := s.run(ctx, nil, append(args, "-noverify")...); err != nil {
+		return result, nil
+	}
+	result.Valid = true
+
+	if data, err := os.ReadFile(signerFile); err == nil { // #nosec G304 -- our temp file
+		if certs := parseCertificates(data); len(certs) > 0 {
+			info := Info(certs[0], "")
+			result.SignerSubject = info.Subject
+			result.Issuer = info.Issuer
+			result.Fingerprint = info.Fingerprint
+			result.NotAfter = info.NotAfter
+			if len(info.Emails) > 0 {
+				result.SignerEmail = info.Emails[0]
+			}
+		}
+	}
+
+	if _, err := s.run(ctx, nil, args...); err != nil {
+		result.TrustError = err.Error()
+	} else {
+		result.Trusted = true
+	}
+	return result, nil
+}
+
+// LoadCertificates reads the PEM or DER certificates in a file.
+func LoadCertificates(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- user-supplied certificate file
+	if err != nil {
+		return nil, err
+	}
+	certs := parseCertificates(data)
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found in %s", filepath.Base(path))
+	}
+	return certs, nil
+}
+
+// pickCertificate returns the first certificate for email that is valid now.
+func (s *Service) pickCertificate(certs []*x509.Certificate, email string) *x509.Certificate {
+	now := s.now()
+	for _, cert := range certs {
+		if matchesEmail(cert, email) && usableAt(cert, now) {
+			return cert
+		}
+	}
+	return nil
+}
+
+// listFiles returns files in the service directory with the given
+// extensions. A missing directory has no files.
+func (s *Service) listFiles(exts ...string) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", s.dir, err)
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		for _, want := range exts {
+			if ext == want {
+				files = append(files, filepath.Join(s.dir, e.Name()))
+				break
+			}
+		}
+	}
+	return files, nil
+}
+
+// parseIdentityPEM extracts the private key and the certificate matching it
+// from openssl pkcs12 output. Other certificates become the chain.
+func parseIdentityPEM(data []byte) (*Identity, error) {
+	var keyPEM []byte
+	var signer crypto.Signer
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		switch block.Type {
+		case "CERTIFICATE":
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				certs = append(certs, cert)
+			}
+		case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+			key, err := parsePrivateKey(block)
+			if err != nil {
+				return nil, err
+			}
+			signer = key
+			keyPEM = pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: block.Bytes})
+		}
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("no private key found")
+	}
+
+	id := &Identity{keyPEM: keyPEM}
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	for _, cert := range certs {
+		if id.Certificate == nil && ok && pub.Equal(cert.PublicKey) {
+			id.Certificate = cert
+			continue
+		}
+		id.Chain = append(id.Chain, cert)
+	}
+	if id.Certificate == nil {
+		return nil, fmt.Errorf("no certificate matches the private key")
+	}
+	return id, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// parseCertificates reads PEM certificates, or a single DER certificate.
+func parseCertificates(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+	if len(certs) == 0 {
+		if cert, err := x509.ParseCertificate(data); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+	return certs
+}
+
+func encodeCertificates(certs ...*x509.Certificate) []byte {
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.Bytes()
+}
+
+// tempDir creates a private working directory for one openssl operation.
+func tempDir() (string, func(), error) {
+	dir, err := os.MkdirTemp("", "nylas-smime-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }, nil
+}
+
+func writeTemp(dir, name string, data []byte) (string, error) {
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return path, nil
+}
+
+// runOpenSSL runs openssl with extra environment variables and returns its
+// stdout. Errors carry openssl's stderr.
+func runOpenSSL(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "openssl", args...) // #nosec G204 -- fixed binary, arguments built by this package
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s", firstLines(msg, 3))
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// macKeychainCertificates returns certificates for email from the macOS
+// keychains as PEM.
+func macKeychainCertificates(ctx context.Context, email string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "security", "find-certificate", "-a", "-e", email, "-p") // #nosec G204 -- fixed binary
+	return cmd.Output()
+}
+
+func firstLines(s string, n int) string {
+	lines := strings.SplitN(s, "\n", n+1)
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return strings.Join(lines, "; ")
+}
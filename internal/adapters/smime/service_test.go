@@ -0,0 +1,207 @@
+package smime
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func skipWithoutOpenSSL(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("openssl"); err != nil {
+		t.Skip("openssl not available")
+	}
+}
+
+// testIdentity writes a self-signed certificate for email to dir as
+// <name>.pem and <name>.p12 (password "secret") and returns the key and cert.
+func testIdentity(t *testing.T, dir, name, email string, notAfter time.Time) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(time.Now().UnixNano()),
+		Subject:        pkix.Name{CommonName: name},
+		EmailAddresses: []string{email},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       notAfter,
+		KeyUsage:       x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, name+".pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	keyPath := filepath.Join(t.TempDir(), name+".key")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+
+	out, err := exec.Command("openssl", "pkcs12", "-export", "-in", certPath, "-inkey", keyPath,
+		"-passout", "pass:secret", "-out", filepath.Join(dir, name+".p12")).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return key, cert
+}
+
+func TestLoadIdentity(t *testing.T) {
+	skipWithoutOpenSSL(t)
+	dir := t.TempDir()
+	_, cert := testIdentity(t, dir, "alice", "alice@example.com", time.Now().Add(24*time.Hour))
+	svc := NewService(dir)
+
+	id, err := svc.LoadIdentity(context.Background(), filepath.Join(dir, "alice.p12"), "secret")
+	require.NoError(t, err)
+	assert.Equal(t, cert.Raw, id.Certificate.Raw)
+	assert.NotEmpty(t, id.keyPEM)
+
+	_, err = svc.LoadIdentity(context.Background(), filepath.Join(dir, "alice.p12"), "wrong")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong password")
+}
+
+func TestFindIdentityAndCertificate(t *testing.T) {
+	skipWithoutOpenSSL(t)
+	dir := t.TempDir()
+	testIdentity(t, dir, "alice", "alice@example.com", time.Now().Add(24*time.Hour))
+	testIdentity(t, dir, "bob-expired", "bob@example.com", time.Now().Add(-time.Minute))
+	svc := NewService(dir)
+	svc.keychain = nil
+	ctx := context.Background()
+
+	id, err := svc.FindIdentity(ctx, "ALICE@example.com", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com"}, id.Certificate.EmailAddresses)
+
+	_, err = svc.FindIdentity(ctx, "bob@example.com", "secret")
+	assert.Error(t, err, "expired identity must not be used")
+
+	cert, source, err := svc.FindCertificate(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", cert.Subject.CommonName)
+	assert.Equal(t, filepath.Join(dir, "alice.pem"), source)
+
+	_, _, err = svc.FindCertificate(ctx, "bob@example.com")
+	assert.ErrorIs(t, err, ErrNoCertificate)
+}
+
+func TestFindCertificate_Keychain(t *testing.T) {
+	_, cert := testSelfSigned(t, "carol@example.com")
+	svc := NewService(t.TempDir())
+	svc.keychain = func(_ context.Context, email string) ([]byte, error) {
+		assert.Equal(t, "carol@example.com", email)
+		return encodeCertificates(cert), nil
+	}
+
+	got, source, err := svc.FindCertificate(context.Background(), "carol@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, cert.Raw, got.Raw)
+	assert.Equal(t, "keychain", source)
+}
+
+func TestSignVerify(t *testing.T) {
+	skipWithoutOpenSSL(t)
+	dir := t.TempDir()
+	testIdentity(t, dir, "alice", "alice@example.com", time.Now().Add(24*time.Hour))
+	svc := NewService(dir)
+	ctx := context.Background()
+
+	id, err := svc.LoadIdentity(ctx, filepath.Join(dir, "alice.p12"), "secret")
+	require.NoError(t, err)
+	content := []byte("Content-Type: text/plain\r\n\r\nhello\r\n")
+	sig, err := svc.Sign(ctx, id, content)
+	require.NoError(t, err)
+
+	result, err := svc.Verify(ctx, content, sig)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.False(t, result.Trusted, "self-signed certificate isn't in the trust store")
+	assert.NotEmpty(t, result.TrustError)
+	assert.Equal(t, "alice@example.com", result.SignerEmail)
+	assert.Equal(t, Fingerprint(id.Certificate), result.Fingerprint)
+
+	tampered, err := svc.Verify(ctx, []byte("Content-Type: text/plain\r\n\r\nhullo\r\n"), sig)
+	require.NoError(t, err)
+	assert.False(t, tampered.Valid)
+}
+
+func TestEncrypt(t *testing.T) {
+	skipWithoutOpenSSL(t)
+	dir := t.TempDir()
+	testIdentity(t, dir, "bob", "bob@example.com", time.Now().Add(24*time.Hour))
+	svc := NewService(dir)
+	ctx := context.Background()
+
+	cert, _, err := svc.FindCertificate(ctx, "bob@example.com")
+	require.NoError(t, err)
+	result, err := svc.Encrypt(ctx, []*x509.Certificate{cert}, []byte("secret message"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob@example.com"}, result.Recipients)
+
+	encPath := filepath.Join(t.TempDir(), "msg.p7m")
+	require.NoError(t, os.WriteFile(encPath, result.Ciphertext, 0o600))
+	out, err := exec.Command("openssl", "cms", "-decrypt", "-binary", "-inform", "DER", "-in", encPath,
+		"-recip", filepath.Join(dir, "bob.p12"), "-passin", "pass:secret").Output()
+	if err != nil {
+		// Older openssl can't take a PKCS#12 recipient; decrypt with the key instead.
+		id, loadErr := svc.LoadIdentity(ctx, filepath.Join(dir, "bob.p12"), "secret")
+		require.NoError(t, loadErr)
+		keyPath := filepath.Join(t.TempDir(), "bob.key")
+		require.NoError(t, os.WriteFile(keyPath, id.keyPEM, 0o600))
+		out, err = exec.Command("openssl", "cms", "-decrypt", "-binary", "-inform", "DER", "-in", encPath,
+			"-recip", filepath.Join(dir, "bob.pem"), "-inkey", keyPath).Output()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "secret message", string(out))
+
+	_, err = svc.Encrypt(ctx, nil, []byte("x"))
+	assert.Error(t, err)
+}
+
+func TestParseIdentityPEM_Errors(t *testing.T) {
+	_, cert := testSelfSigned(t, "dave@example.com")
+	_, err := parseIdentityPEM(encodeCertificates(cert))
+	assert.ErrorContains(t, err, "no private key")
+
+	other, _ := testSelfSigned(t, "erin@example.com")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(other)})
+	_, err = parseIdentityPEM(append(encodeCertificates(cert), keyPEM...))
+	assert.ErrorContains(t, err, "no certificate matches")
+}
+
+func TestCertificateEmails_SubjectFallback(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{Names: []pkix.AttributeTypeAndValue{
+		{Type: []int{1, 2, 840, 113549, 1, 9, 1}, Value: "legacy@example.com"},
+	}}}
+	assert.True(t, matchesEmail(cert, "Legacy@example.com"))
+}
+
+func testSelfSigned(t *testing.T, email string) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: email},
+		EmailAddresses: []string{email},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, cert
+}
@@ -0,0 +1,107 @@
+package smime
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Identity is a signing certificate together with its private key, loaded
+// from a PKCS#12 file.
+type Identity struct {
+	Certificate *x509.Certificate   // Signer certificate
+	Chain       []*x509.Certificate // Intermediate certificates shipped with the signature
+	Source      string              // File the identity was loaded from
+
+	keyPEM []byte // PEM-encoded private key; never written to disk longer than a command runs
+}
+
+// CertificateInfo describes a certificate for display.
+type CertificateInfo struct {
+	Subject     string    // Subject common name or full DN
+	Emails      []string  // Email addresses from the SAN extension (or subject)
+	Issuer      string    // Issuer common name or full DN
+	Fingerprint string    // SHA-256 fingerprint, hex
+	NotBefore   time.Time // Start of validity
+	NotAfter    time.Time // End of validity
+	Source      string    // Where the certificate was found
+}
+
+// VerifyResult contains the result of an S/MIME signature verification.
+type VerifyResult struct {
+	Valid         bool      // Signature matches the content
+	Trusted       bool      // Signer chains to a CA in the system trust store
+	TrustError    string    // Why the chain isn't trusted (if !Trusted)
+	SignerEmail   string    // First email address of the signer
+	SignerSubject string    // Signer subject
+	Issuer        string    // Signer certificate issuer
+	Fingerprint   string    // SHA-256 fingerprint of the signer certificate
+	NotAfter      time.Time // Signer certificate expiry
+}
+
+// EncryptResult contains the result of an S/MIME encryption.
+type EncryptResult struct {
+	Ciphertext []byte   // DER-encoded CMS EnvelopedData
+	Recipients []string // Emails of the recipient certificates
+}
+
+// Info summarizes a certificate.
+func Info(cert *x509.Certificate, source string) CertificateInfo {
+	return CertificateInfo{
+		Subject:     displayName(cert.Subject.CommonName, cert.Subject.String()),
+		Emails:      certificateEmails(cert),
+		Issuer:      displayName(cert.Issuer.CommonName, cert.Issuer.String()),
+		Fingerprint: Fingerprint(cert),
+		NotBefore:   cert.NotBefore,
+		NotAfter:    cert.NotAfter,
+		Source:      source,
+	}
+}
+
+// Fingerprint returns the certificate's SHA-256 fingerprint in hex.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// certificateEmails returns the SAN email addresses, falling back to the
+// legacy emailAddress attribute in the subject.
+func certificateEmails(cert *x509.Certificate) []string {
+	if len(cert.EmailAddresses) > 0 {
+		return cert.EmailAddresses
+	}
+	var emails []string
+	for _, name := range cert.Subject.Names {
+		// 1.2.840.113549.1.9.1 is PKCS#9 emailAddress.
+		if name.Type.String() == "1.2.840.113549.1.9.1" {
+			if s, ok := name.Value.(string); ok {
+				emails = append(emails, s)
+			}
+		}
+	}
+	return emails
+}
+
+// matchesEmail reports whether the certificate is issued to email.
+func matchesEmail(cert *x509.Certificate, email string) bool {
+	for _, e := range certificateEmails(cert) {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// usableAt reports whether the certificate is within its validity period.
+func usableAt(cert *x509.Certificate, now time.Time) bool {
+	return !now.Before(cert.NotBefore) && !now.After(cert.NotAfter)
+}
+
+func displayName(cn, dn string) string {
+	if cn != "" {
+		return cn
+	}
+	return dn
+}
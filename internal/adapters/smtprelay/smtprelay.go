@@ -0,0 +1,169 @@
+// Package smtprelay sends mail through a user-configured SMTP relay, used as
+// a fallback when the Nylas send endpoint fails or is rate limited.
+package smtprelay
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// defaultTimeout bounds a whole SMTP session when ctx has no deadline.
+const defaultTimeout = 60 * time.Second
+
+// Client sends through one SMTP relay.
+type Client struct {
+	cfg    domain.SMTPConfig
+	secret string
+	dialer *net.Dialer
+	// tlsConfig is used for implicit TLS and STARTTLS; tests replace it.
+	tlsConfig *tls.Config
+}
+
+var _ ports.MailTransport = (*Client)(nil)
+
+// New returns a client for cfg, authenticating with secret (a password, or
+// an OAuth access token for XOAUTH2). Port, TLS mode and auth method get
+// their defaults when unset.
+func New(cfg domain.SMTPConfig, secret string) *Client {
+	if cfg.TLS == "" {
+		cfg.TLS = domain.MailTLSStartTLS
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+		if cfg.TLS == domain.MailTLSImplicit {
+			cfg.Port = 465
+		}
+	}
+	if cfg.Auth == "" {
+		cfg.Auth = domain.SMTPAuthPlain
+	}
+	return &Client{
+		cfg:       cfg,
+		secret:    secret,
+		dialer:    &net.Dialer{Timeout: 30 * time.Second},
+		tlsConfig: &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12},
+	}
+}
+
+// Name returns host:port.
+func (c *Client) Name() string {
+	return net.JoinHostPort(c.cfg.Host, strconv.Itoa(c.cfg.Port))
+}
+
+// Verify connects, negotiates TLS and authenticates, then quits.
+func (c *Client) Verify(ctx context.Context) error {
+	client, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	return client.Quit()
+}
+
+// Send delivers raw to recipients.
+func (c *Client) Send(ctx context.Context, from string, recipients []string, raw []byte) error {
+	if len(recipients) == 0 {
+		return errors.New("no recipients")
+	}
+
+	client, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("MAIL FROM rejected: %w", err)
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("RCPT TO %s rejected: %w", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA rejected: %w", err)
+	}
+	if _, err := w.Write(raw); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// connect dials, secures and authenticates a session.
+func (c *Client) connect(ctx context.Context) (*smtp.Client, error) {
+	addr := c.Name()
+	conn, err := c.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	if c.cfg.TLS == domain.MailTLSImplicit {
+		tlsConn := tls.Client(conn, c.tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("TLS handshake with %s: %w", addr, err)
+		}
+		conn = tlsConn
+	}
+
+	client, err := smtp.NewClient(conn, c.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("SMTP greeting from %s: %w", addr, err)
+	}
+
+	if c.cfg.TLS == domain.MailTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			_ = client.Close()
+			return nil, fmt.Errorf("%s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(c.tlsConfig); err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("STARTTLS with %s: %w", addr, err)
+		}
+	}
+
+	if c.secret != "" {
+		auth, err := c.auth()
+		if err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+		if err := client.Auth(auth); err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+	return client, nil
+}
+
+func (c *Client) auth() (smtp.Auth, error) {
+	switch c.cfg.Auth {
+	case domain.SMTPAuthPlain:
+		return smtp.PlainAuth("", c.cfg.Username, c.secret, c.cfg.Host), nil
+	case domain.SMTPAuthLogin:
+		return &loginAuth{username: c.cfg.Username, password: c.secret, host: c.cfg.Host}, nil
+	case domain.SMTPAuthXOAuth2:
+		return &xoauth2Auth{username: c.cfg.Username, token: c.secret, host: c.cfg.Host}, nil
+	default:
+		return nil, fmt.Errorf("unsupported SMTP auth method: %s", c.cfg.Auth)
+	}
+}
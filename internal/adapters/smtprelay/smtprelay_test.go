@@ -0,0 +1,223 @@
+package smtprelay
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// fakeServer is a minimal plaintext SMTP server that records a session.
+type fakeServer struct {
+	ln       net.Listener
+	mu       sync.Mutex
+	commands []string
+	authLine string
+	data     string
+	// rejectRcpt makes RCPT TO fail for this address.
+	rejectRcpt string
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{ln: ln}
+	t.Cleanup(func() { _ = ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	r := bufio.NewReader(conn)
+	write := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	write("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.ToUpper(strings.Fields(line + " x")[0]))
+		s.mu.Unlock()
+
+		upper := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(upper, "EHLO"):
+			write("250-fake")
+			write("250 AUTH PLAIN LOGIN XOAUTH2")
+		case strings.HasPrefix(upper, "AUTH LOGIN"):
+			write("334 " + base64.StdEncoding.EncodeToString([]byte("Username:")))
+			user, _ := r.ReadString('\n')
+			write("334 " + base64.StdEncoding.EncodeToString([]byte("Password:")))
+			pass, _ := r.ReadString('\n')
+			s.recordAuth("LOGIN " + decode(user) + ":" + decode(pass))
+			write("235 ok")
+		case strings.HasPrefix(upper, "AUTH "):
+			fields := strings.Fields(line)
+			s.recordAuth(fields[1] + " " + decode(fields[len(fields)-1]))
+			write("235 ok")
+		case strings.HasPrefix(upper, "MAIL FROM"):
+			write("250 ok")
+		case strings.HasPrefix(upper, "RCPT TO"):
+			if s.rejectRcpt != "" && strings.Contains(line, s.rejectRcpt) {
+				write("550 no such user")
+				continue
+			}
+			write("250 ok")
+		case upper == "DATA":
+			write("354 go ahead")
+			var body strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				body.WriteString(l)
+			}
+			s.mu.Lock()
+			s.data = body.String()
+			s.mu.Unlock()
+			write("250 queued")
+		case upper == "QUIT":
+			write("221 bye")
+			return
+		default:
+			write("250 ok")
+		}
+	}
+}
+
+func (s *fakeServer) recordAuth(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authLine = line
+}
+
+func decode(s string) string {
+	b, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	return string(b)
+}
+
+func (s *fakeServer) client(auth domain.SMTPAuthMethod, secret string) *Client {
+	return New(domain.SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     s.port(),
+		Username: "me@example.com",
+		Auth:     auth,
+		TLS:      domain.MailTLSNone,
+	}, secret)
+}
+
+func TestNew_Defaults(t *testing.T) {
+	c := New(domain.SMTPConfig{Host: "smtp.example.com"}, "")
+	assert.Equal(t, "smtp.example.com:587", c.Name())
+	assert.Equal(t, domain.MailTLSStartTLS, c.cfg.TLS)
+	assert.Equal(t, domain.SMTPAuthPlain, c.cfg.Auth)
+
+	c = New(domain.SMTPConfig{Host: "smtp.example.com", TLS: domain.MailTLSImplicit}, "")
+	assert.Equal(t, "smtp.example.com:465", c.Name())
+}
+
+func TestClient_Send(t *testing.T) {
+	s := newFakeServer(t)
+	raw := []byte("Subject: Hi\r\n\r\nHello\r\n")
+
+	err := s.client(domain.SMTPAuthPlain, "hunter2").Send(context.Background(), "me@example.com", []string{"a@example.com", "b@example.com"}, raw)
+	require.NoError(t, err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Equal(t, "PLAIN \x00me@example.com\x00hunter2", s.authLine)
+	assert.Equal(t, "Subject: Hi\r\n\r\nHello\r\n", s.data)
+	assert.Equal(t, []string{"EHLO", "AUTH", "MAIL", "RCPT", "RCPT", "DATA", "QUIT"}, s.commands)
+}
+
+func TestClient_Auth(t *testing.T) {
+	tests := []struct {
+		auth domain.SMTPAuthMethod
+		want string
+	}{
+		{domain.SMTPAuthLogin, "LOGIN me@example.com:hunter2"},
+		{domain.SMTPAuthXOAuth2, "XOAUTH2 user=me@example.com\x01auth=Bearer hunter2\x01\x01"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.auth), func(t *testing.T) {
+			s := newFakeServer(t)
+			require.NoError(t, s.client(tt.auth, "hunter2").Verify(context.Background()))
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			assert.Equal(t, tt.want, s.authLine)
+		})
+	}
+}
+
+func TestClient_SendErrors(t *testing.T) {
+	t.Run("rejected recipient", func(t *testing.T) {
+		s := newFakeServer(t)
+		s.rejectRcpt = "bad@example.com"
+		err := s.client(domain.SMTPAuthPlain, "").Send(context.Background(), "me@example.com", []string{"bad@example.com"}, []byte("x"))
+		assert.ErrorContains(t, err, "RCPT TO bad@example.com rejected")
+	})
+
+	t.Run("no recipients", func(t *testing.T) {
+		err := New(domain.SMTPConfig{Host: "127.0.0.1"}, "").Send(context.Background(), "me@example.com", nil, []byte("x"))
+		assert.ErrorContains(t, err, "no recipients")
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := ln.Addr().(*net.TCPAddr).Port
+		_ = ln.Close()
+
+		c := New(domain.SMTPConfig{Host: "127.0.0.1", Port: port, TLS: domain.MailTLSNone}, "")
+		err = c.Verify(context.Background())
+		assert.ErrorContains(t, err, "connect to 127.0.0.1:"+strconv.Itoa(port))
+	})
+
+	t.Run("starttls unsupported", func(t *testing.T) {
+		s := newFakeServer(t)
+		c := New(domain.SMTPConfig{Host: "127.0.0.1", Port: s.port(), TLS: domain.MailTLSStartTLS}, "")
+		assert.ErrorContains(t, c.Verify(context.Background()), "does not support STARTTLS")
+	})
+
+	t.Run("unsupported auth method", func(t *testing.T) {
+		s := newFakeServer(t)
+		assert.ErrorContains(t, s.client("cram-md5", "x").Verify(context.Background()), "unsupported SMTP auth method")
+	})
+}
+
+func TestRequireTLS(t *testing.T) {
+	assert.NoError(t, requireTLS(&smtp.ServerInfo{Name: "smtp.example.com", TLS: true}, "smtp.example.com"))
+	assert.NoError(t, requireTLS(&smtp.ServerInfo{Name: "localhost"}, "localhost"))
+	assert.Error(t, requireTLS(&smtp.ServerInfo{Name: "smtp.example.com"}, "smtp.example.com"))
+	assert.Error(t, requireTLS(&smtp.ServerInfo{Name: "evil.example.com", TLS: true}, "smtp.example.com"))
+}
@@ -0,0 +1,155 @@
+// Package statuspage reads the public Nylas status page, which is served by
+// Atlassian Statuspage (summary.json, API v2).
+package statuspage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/httputil"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// DefaultURL is the Nylas status page.
+const DefaultURL = "https://status.nylas.com"
+
+// URLEnv overrides the status page URL, e.g. for a mirror or tests.
+const URLEnv = "NYLAS_STATUS_PAGE_URL"
+
+// Client fetches the status page summary.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+var _ ports.StatusPage = (*Client)(nil)
+
+// New returns a client for NYLAS_STATUS_PAGE_URL or the default status page.
+func New() *Client {
+	baseURL := DefaultURL
+	if v := strings.TrimSpace(os.Getenv(URLEnv)); v != "" {
+		baseURL = v
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), http: httputil.DefaultClient}
+}
+
+type summaryResponse struct {
+	Page struct {
+		URL       string    `json:"url"`
+		UpdatedAt time.Time `json:"updated_at"`
+	} `json:"page"`
+	Status struct {
+		Indicator   string `json:"indicator"`
+		Description string `json:"description"`
+	} `json:"status"`
+	Components []struct {
+		ID        string    `json:"id"`
+		Name      string    `json:"name"`
+		Status    string    `json:"status"`
+		UpdatedAt time.Time `json:"updated_at"`
+		Group     bool      `json:"group"`
+	} `json:"components"`
+	Incidents             []incident `json:"incidents"`
+	ScheduledMaintenances []incident `json:"scheduled_maintenances"`
+}
+
+type incident struct {
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Impact     string    `json:"impact"`
+	CreatedAt  time.Time `json:"created_at"`
+	StartedAt  time.Time `json:"started_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Shortlink  string    `json:"shortlink"`
+	Components []struct {
+		ID string `json:"id"`
+	} `json:"components"`
+	IncidentUpdates []struct {
+		Body string `json:"body"`
+	} `json:"incident_updates"`
+}
+
+// Summary fetches /api/v2/summary.json.
+func (c *Client) Summary(ctx context.Context) (*domain.ServiceStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v2/summary.json", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch status page: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status page returned HTTP %d", resp.StatusCode)
+	}
+
+	var raw summaryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode status page: %w", err)
+	}
+	return raw.toDomain(c.baseURL), nil
+}
+
+func (r *summaryResponse) toDomain(baseURL string) *domain.ServiceStatus {
+	status := &domain.ServiceStatus{
+		Indicator:   r.Status.Indicator,
+		Description: r.Status.Description,
+		PageURL:     r.Page.URL,
+		UpdatedAt:   r.Page.UpdatedAt,
+	}
+	if status.PageURL == "" {
+		status.PageURL = baseURL
+	}
+
+	for _, c := range r.Components {
+		// Groups only aggregate their children.
+		if c.Group {
+			continue
+		}
+		status.Components = append(status.Components, domain.StatusComponent{
+			ID:        c.ID,
+			Name:      c.Name,
+			Status:    c.Status,
+			UpdatedAt: c.UpdatedAt,
+		})
+	}
+	for _, inc := range r.Incidents {
+		status.Incidents = append(status.Incidents, inc.toDomain())
+	}
+	for _, inc := range r.ScheduledMaintenances {
+		status.Maintenance = append(status.Maintenance, inc.toDomain())
+	}
+	return status
+}
+
+func (i incident) toDomain() domain.StatusIncident {
+	out := domain.StatusIncident{
+		Name:      i.Name,
+		Status:    i.Status,
+		Impact:    i.Impact,
+		StartedAt: i.StartedAt,
+		UpdatedAt: i.UpdatedAt,
+		Link:      i.Shortlink,
+	}
+	if out.StartedAt.IsZero() {
+		out.StartedAt = i.CreatedAt
+	}
+	for _, c := range i.Components {
+		out.Components = append(out.Components, c.ID)
+	}
+	// Updates are newest first.
+	if len(i.IncidentUpdates) > 0 {
+		out.LatestUpdate = i.IncidentUpdates[0].Body
+	}
+	return out
+}
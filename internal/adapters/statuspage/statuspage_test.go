@@ -0,0 +1,70 @@
+package statuspage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const summaryFixture = `{
+  "page": {"url": "https://status.nylas.com", "updated_at": "2026-10-17T10:00:00Z"},
+  "status": {"indicator": "minor", "description": "Partially Degraded Service"},
+  "components": [
+    {"id": "grp", "name": "Providers", "status": "degraded_performance", "group": true},
+    {"id": "g1", "name": "Google", "status": "degraded_performance", "updated_at": "2026-10-17T09:40:00Z"},
+    {"id": "m1", "name": "Microsoft", "status": "operational", "updated_at": "2026-10-01T00:00:00Z"}
+  ],
+  "incidents": [
+    {
+      "name": "Delayed Google sync",
+      "status": "investigating",
+      "impact": "minor",
+      "created_at": "2026-10-17T09:20:00Z",
+      "updated_at": "2026-10-17T09:45:00Z",
+      "shortlink": "https://stspg.io/abc",
+      "components": [{"id": "g1"}],
+      "incident_updates": [{"body": "We are investigating."}, {"body": "Older update"}]
+    }
+  ],
+  "scheduled_maintenances": []
+}`
+
+func TestClient_Summary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/summary.json", r.URL.Path)
+		_, _ = w.Write([]byte(summaryFixture))
+	}))
+	defer server.Close()
+	t.Setenv(URLEnv, server.URL+"/")
+
+	status, err := New().Summary(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "minor", status.Indicator)
+	assert.Equal(t, "https://status.nylas.com", status.PageURL)
+	require.Len(t, status.Components, 2, "group components are skipped")
+	assert.Equal(t, "Google", status.Components[0].Name)
+
+	require.Len(t, status.Incidents, 1)
+	inc := status.Incidents[0]
+	assert.Equal(t, time.Date(2026, 10, 17, 9, 20, 0, 0, time.UTC), inc.StartedAt.UTC(), "falls back to created_at")
+	assert.Equal(t, []string{"g1"}, inc.Components)
+	assert.Equal(t, "We are investigating.", inc.LatestUpdate)
+	assert.Equal(t, "https://stspg.io/abc", inc.Link)
+}
+
+func TestClient_Summary_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	t.Setenv(URLEnv, server.URL)
+
+	_, err := New().Summary(context.Background())
+	assert.ErrorContains(t, err, "HTTP 503")
+}
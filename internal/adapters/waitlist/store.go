@@ -0,0 +1,167 @@
+// Package waitlist stores scheduler waitlist entries in a local JSON file.
+package waitlist
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/jsonfile"
+	"github.com/nylas/cli/internal/domain"
+)
+
+const (
+	fileVersion  = 1
+	lockWait     = 10 * time.Second
+	staleLockAge = 2 * time.Minute
+)
+
+// Store implements ports.WaitlistStore. The file is shared by the
+// long-running `waitlist serve` process and one-off commands such as
+// `waitlist add`, so every change takes a lock file as well as the mutex.
+type Store struct {
+	file *jsonfile.Store[fileShape]
+	mu   sync.Mutex // Serializes taking the lock file within the process
+}
+
+type fileShape struct {
+	jsonfile.Versioned
+	SigningKey string                 `json:"signing_key,omitempty"`
+	Entries    []domain.WaitlistEntry `json:"entries"`
+}
+
+// New creates a waitlist store backed by the file at path.
+func New(path string) *Store {
+	return &Store{file: jsonfile.New[fileShape](path, "waitlist", fileVersion)}
+}
+
+// Path returns the waitlist file path.
+func (s *Store) Path() string {
+	return s.file.Path()
+}
+
+// List returns every entry in the order they were queued. Unlike the grant
+// cache, a corrupt file is an error: the waitlist is not something that can
+// be rebuilt from the API.
+func (s *Store) List() ([]domain.WaitlistEntry, error) {
+	shape, err := s.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	return shape.Entries, nil
+}
+
+// Add queues an entry, assigning its ID, and returns it.
+func (s *Store) Add(entry domain.WaitlistEntry) (domain.WaitlistEntry, error) {
+	if entry.ConfigurationID == "" || entry.Email == "" {
+		return entry, domain.ErrInvalidInput
+	}
+	id, err := randomHex(6)
+	if err != nil {
+		return entry, err
+	}
+	entry.ID = "wl_" + id
+	if entry.Status == "" {
+		entry.Status = domain.WaitlistWaiting
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	err = s.mutate(func(shape *fileShape) error {
+		shape.Entries = append(shape.Entries, entry)
+		return nil
+	})
+	return entry, err
+}
+
+// Update applies fn to all entries and saves the result.
+func (s *Store) Update(fn func(entries []domain.WaitlistEntry) error) error {
+	return s.mutate(func(shape *fileShape) error {
+		return fn(shape.Entries)
+	})
+}
+
+// Remove deletes an entry.
+func (s *Store) Remove(id string) error {
+	return s.mutate(func(shape *fileShape) error {
+		for i := range shape.Entries {
+			if shape.Entries[i].ID == id {
+				shape.Entries = append(shape.Entries[:i], shape.Entries[i+1:]...)
+				return nil
+			}
+		}
+		return domain.ErrWaitlistEntryNotFound
+	})
+}
+
+// SigningKey returns the key that signs offer accept links, creating it on
+// first use.
+func (s *Store) SigningKey() ([]byte, error) {
+	var key string
+	err := s.mutate(func(shape *fileShape) error {
+		if shape.SigningKey == "" {
+			k, err := randomHex(32)
+			if err != nil {
+				return err
+			}
+			shape.SigningKey = k
+		}
+		key = shape.SigningKey
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(key)
+}
+
+func (s *Store) mutate(fn func(*fileShape) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.withFileLock(func() error {
+		return s.file.Update(fn)
+	})
+}
+
+func (s *Store) withFileLock(fn func() error) error {
+	path := s.file.Path()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockWait)
+	for {
+		err := os.Mkdir(lockPath, 0o700)
+		if err == nil {
+			defer func() { _ = os.Remove(lockPath) }()
+			return fn()
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+		if info, serr := os.Stat(lockPath); serr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.RemoveAll(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for waitlist lock: %s", lockPath)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
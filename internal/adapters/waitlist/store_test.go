@@ -0,0 +1,87 @@
+package waitlist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestStore_AddListRemove(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "waitlist.json"))
+
+	entries, err := s.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	first, err := s.Add(domain.WaitlistEntry{ConfigurationID: "cfg-1", Email: "ana@example.com"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.ID)
+	assert.Equal(t, domain.WaitlistWaiting, first.Status)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	second, err := s.Add(domain.WaitlistEntry{ConfigurationID: "cfg-1", Email: "bob@example.com"})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	entries, err = s.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "ana@example.com", entries[0].Email)
+
+	info, err := os.Stat(s.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, s.Remove(first.ID))
+	assert.ErrorIs(t, s.Remove(first.ID), domain.ErrWaitlistEntryNotFound)
+	entries, err = s.List()
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestStore_AddValidates(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "waitlist.json"))
+	_, err := s.Add(domain.WaitlistEntry{Email: "ana@example.com"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestStore_Update(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "waitlist.json"))
+	entry, err := s.Add(domain.WaitlistEntry{ConfigurationID: "cfg-1", Email: "ana@example.com"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Update(func(entries []domain.WaitlistEntry) error {
+		entries[0].Status = domain.WaitlistBooked
+		entries[0].BookingID = "bk-1"
+		return nil
+	}))
+
+	entries, err := s.List()
+	require.NoError(t, err)
+	assert.Equal(t, entry.ID, entries[0].ID)
+	assert.Equal(t, domain.WaitlistBooked, entries[0].Status)
+	assert.Equal(t, "bk-1", entries[0].BookingID)
+}
+
+func TestStore_SigningKeyIsStable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waitlist.json")
+	key, err := New(path).SigningKey()
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	again, err := New(path).SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, key, again)
+}
+
+func TestStore_CorruptFileIsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waitlist.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err := New(path).List()
+	assert.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -80,6 +81,7 @@ type Server struct {
 	tunnel         ports.Tunnel
 	events         chan *ports.WebhookEvent
 	handlers       []ports.WebhookEventHandler
+	routes         map[string]http.Handler
 	handlerSlots   chan struct{}
 	seenSignatures map[string]time.Time
 	stats          ports.WebhookServerStats
@@ -157,6 +159,11 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc(s.config.Path, s.handleWebhook)
 	mux.HandleFunc("/health", s.handleHealth)
 	mux.HandleFunc("/", s.handleRoot)
+	s.mu.RLock()
+	for pattern, handler := range s.routes {
+		mux.Handle(pattern, handler)
+	}
+	s.mu.RUnlock()
 
 	s.server = &http.Server{
 		Handler:           mux,
@@ -275,6 +282,28 @@ func (s *Server) OnEvent(handler ports.WebhookEventHandler) {
 	s.handlers = append(s.handlers, handler)
 }
 
+// Handle mounts an extra route next to the webhook endpoint, such as a link
+// target that must be reachable through the same tunnel. Call before Start.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.routes == nil {
+		s.routes = make(map[string]http.Handler)
+	}
+	s.routes[pattern] = handler
+}
+
+// BaseURL returns the public (tunnel) base URL without the webhook path,
+// falling back to the local base URL.
+func (s *Server) BaseURL() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.stats.PublicURL != "" {
+		return strings.TrimSuffix(s.stats.PublicURL, s.config.Path)
+	}
+	return LocalBaseURL(s.config.Port)
+}
+
 // Events returns a channel for receiving webhook events.
 func (s *Server) Events() <-chan *ports.WebhookEvent {
 	return s.events
@@ -599,3 +599,22 @@ func TestServer_StartBindsLoopbackOnly(t *testing.T) {
 	require.NotNil(t, ip, "could not parse listener host as IP: %s", host)
 	assert.True(t, ip.IsLoopback(), "listener bound to non-loopback address: %s", addr)
 }
+
+func TestServer_HandleMountsExtraRoutes(t *testing.T) {
+	port := reserveTCPPort(t)
+	server := NewServer(ports.WebhookServerConfig{Port: port, Path: "/webhook"})
+	server.Handle("/extra", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("extra"))
+	}))
+	require.NoError(t, server.Start(context.Background()))
+	defer func() { _ = server.Stop() }()
+
+	assert.Equal(t, LocalBaseURL(port), server.BaseURL())
+
+	resp, err := http.Get(server.BaseURL() + "/extra")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(resp.Body)
+	assert.Equal(t, "extra", buf.String())
+}
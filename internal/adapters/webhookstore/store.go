@@ -0,0 +1,234 @@
+// Package webhookstore keeps received webhook events in daily JSON Lines
+// files.
+package webhookstore
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const (
+	fileExt    = ".jsonl"
+	dateFormat = "2006-01-02"
+)
+
+// FileStore implements ports.WebhookEventStore with one file per day.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+var _ ports.WebhookEventStore = (*FileStore)(nil)
+
+// NewFileStore creates a store in dir. If dir is empty, DefaultPath is used.
+func NewFileStore(dir string) *FileStore {
+	if dir == "" {
+		dir = DefaultPath()
+	}
+	return &FileStore{dir: dir}
+}
+
+// DefaultPath returns the default event directory in the user cache.
+func DefaultPath() string {
+	root, err := os.UserCacheDir()
+	if err != nil {
+		root = os.TempDir()
+	}
+	return filepath.Join(root, "nylas", "webhook-events")
+}
+
+// Path returns the event directory.
+func (s *FileStore) Path() string {
+	return s.dir
+}
+
+// Append writes record to the file for the day it was received.
+func (s *FileStore) Append(record *domain.WebhookEventRecord) error {
+	if record.ReceivedAt.IsZero() {
+		record.ReceivedAt = time.Now()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("create event directory: %w", err)
+	}
+	path := filepath.Join(s.dir, record.ReceivedAt.Local().Format(dateFormat)+fileExt)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) // #nosec G304 -- path built from the store dir and a date
+	if err != nil {
+		return fmt.Errorf("open event file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+	return nil
+}
+
+// Query returns matching events, newest first. Files from days entirely
+// outside the query's time range are not read.
+func (s *FileStore) Query(ctx context.Context, query *domain.WebhookEventQuery) ([]domain.WebhookEventRecord, error) {
+	if query == nil {
+		query = &domain.WebhookEventQuery{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := s.files()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.WebhookEventRecord
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !query.Since.IsZero() && f.day.AddDate(0, 0, 1).Before(query.Since) {
+			break // Files are newest first, so the rest are older still
+		}
+		if !query.Until.IsZero() && f.day.After(query.Until) {
+			continue
+		}
+		fileRecords, err := readFile(filepath.Join(s.dir, f.name))
+		if err != nil {
+			continue // Skip unreadable files
+		}
+		for i := range fileRecords {
+			if query.Matches(&fileRecords[i]) {
+				records = append(records, fileRecords[i])
+			}
+		}
+	}
+
+	slices.SortStableFunc(records, func(a, b domain.WebhookEventRecord) int {
+		return b.ReceivedAt.Compare(a.ReceivedAt)
+	})
+	if query.Limit > 0 && len(records) > query.Limit {
+		records = records[:query.Limit]
+	}
+	return records, nil
+}
+
+// Get returns the most recently received event with id.
+func (s *FileStore) Get(ctx context.Context, id string) (*domain.WebhookEventRecord, error) {
+	records, err := s.Query(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].ID == id {
+			return &records[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", domain.ErrWebhookEventNotFound, id)
+}
+
+// Prune removes the files of days that ended before cutoff.
+func (s *FileStore) Prune(cutoff time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := s.files()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, f := range files {
+		if !f.day.AddDate(0, 0, 1).Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, f.name)); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("remove %s: %w", f.name, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// Clear removes all event files.
+func (s *FileStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := s.files()
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.Remove(filepath.Join(s.dir, f.name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+type dayFile struct {
+	name string
+	day  time.Time
+}
+
+// files returns the dated event files, newest first.
+func (s *FileStore) files() ([]dayFile, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var files []dayFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+		day, err := time.ParseInLocation(dateFormat, strings.TrimSuffix(name, fileExt), time.Local)
+		if err != nil {
+			continue
+		}
+		files = append(files, dayFile{name: name, day: day})
+	}
+	slices.SortFunc(files, func(a, b dayFile) int { return cmp.Compare(b.name, a.name) })
+	return files, nil
+}
+
+func readFile(path string) ([]domain.WebhookEventRecord, error) {
+	f, err := os.Open(path) // #nosec G304 -- path from the store dir listing
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var records []domain.WebhookEventRecord
+	scanner := bufio.NewScanner(f)
+	// Payloads can be large (message.created with bodies).
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var r domain.WebhookEventRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue // Skip partial or corrupt lines
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
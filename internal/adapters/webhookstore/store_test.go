@@ -0,0 +1,99 @@
+package webhookstore
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func record(id, typ, grant string, at time.Time) *domain.WebhookEventRecord {
+	return &domain.WebhookEventRecord{
+		ID: id, Type: typ, GrantID: grant, ReceivedAt: at, Verified: true,
+		Payload: json.RawMessage(`{"id":"` + id + `"}`),
+	}
+}
+
+func TestFileStore_AppendAndQuery(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, store.Append(record("e1", "message.created", "g1", now.Add(-3*time.Hour))))
+	require.NoError(t, store.Append(record("e2", "event.updated", "g2", now.Add(-30*time.Minute))))
+	require.NoError(t, store.Append(record("e3", "message.updated", "g1", now.Add(-10*time.Minute))))
+	require.NoError(t, store.Append(record("old", "message.created", "g1", now.AddDate(0, 0, -3))))
+
+	all, err := store.Query(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.Equal(t, "e3", all[0].ID, "newest first")
+	assert.JSONEq(t, `{"id":"e3"}`, string(all[0].Payload))
+
+	got, err := store.Query(ctx, &domain.WebhookEventQuery{Triggers: []string{"message.*"}, Since: now.Add(-time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "e3", got[0].ID)
+
+	got, err = store.Query(ctx, &domain.WebhookEventQuery{GrantID: "g1", Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e3", "e1"}, []string{got[0].ID, got[1].ID})
+
+	e, err := store.Get(ctx, "e2")
+	require.NoError(t, err)
+	assert.Equal(t, "event.updated", e.Type)
+
+	_, err = store.Get(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrWebhookEventNotFound)
+}
+
+func TestFileStore_SkipsCorruptLines(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(dir)
+	require.NoError(t, store.Append(record("e1", "message.created", "", time.Now())))
+
+	path := filepath.Join(dir, time.Now().Format(dateFormat)+fileExt)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, _ = f.WriteString("{truncated\n")
+	require.NoError(t, f.Close())
+
+	got, err := store.Query(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+}
+
+func TestFileStore_PruneAndClear(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+	now := time.Now()
+	require.NoError(t, store.Append(record("old", "message.created", "", now.AddDate(0, 0, -40))))
+	require.NoError(t, store.Append(record("new", "message.created", "", now)))
+
+	removed, err := store.Prune(now.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	got, err := store.Query(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "new", got[0].ID)
+
+	require.NoError(t, store.Clear())
+	got, err = store.Query(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestFileStore_MissingDir(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "none"))
+	got, err := store.Query(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
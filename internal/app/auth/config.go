@@ -171,12 +171,65 @@ func (s *ConfigService) GetOrgID() (string, error) {
 	return s.secrets.Get(ports.KeyOrgID)
 }
 
+// RotateAPIKey replaces the API key stored for env and its ID; env "" is
+// the top-level key. Unlike SetupConfig it leaves grants alone, since a new
+// key for the same application sees the same grants. Both values are
+// restored if either write fails.
+func (s *ConfigService) RotateAPIKey(env, apiKey, keyID string) (err error) {
+	keyName, idName := apiKeySecretKeys(env)
+	snapshots := []secretSnapshot{
+		newSecretSnapshot(s.secrets, keyName),
+		newSecretSnapshot(s.secrets, idName),
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if restoreErr := restoreSecretSnapshots(s.secrets, snapshots); restoreErr != nil {
+			err = errors.Join(err, restoreErr)
+		}
+	}()
+
+	if err = s.secrets.Set(keyName, apiKey); err != nil {
+		return err
+	}
+	if keyID == "" {
+		return s.secrets.Delete(idName)
+	}
+	return s.secrets.Set(idName, keyID)
+}
+
+// GetAPIKeyID retrieves the ID of the API key stored for env, if the CLI
+// knows it; env "" is the top-level key.
+func (s *ConfigService) GetAPIKeyID(env string) (string, error) {
+	_, idName := apiKeySecretKeys(env)
+	return s.secrets.Get(idName)
+}
+
+// apiKeySecretKeys returns the secret store keys holding env's API key and
+// its ID.
+func apiKeySecretKeys(env string) (keyName, idName string) {
+	if env == "" {
+		return ports.KeyAPIKey, ports.KeyAPIKeyID
+	}
+	return ports.EnvironmentSecretKey(env, ports.KeyAPIKey), ports.EnvironmentSecretKey(env, ports.KeyAPIKeyID)
+}
+
 // ResetConfig clears all configuration and secrets.
 func (s *ConfigService) ResetConfig() error {
+	// Delete environment profile keys before the config that lists them
+	if cfg, err := s.config.Load(); err == nil {
+		for name := range cfg.Environments {
+			_ = s.secrets.Delete(ports.EnvironmentSecretKey(name, ports.KeyAPIKey))
+			_ = s.secrets.Delete(ports.EnvironmentSecretKey(name, ports.KeyAPIKeyID))
+		}
+	}
+
 	// Delete all secrets
 	_ = s.secrets.Delete(ports.KeyClientID)
 	_ = s.secrets.Delete(ports.KeyClientSecret)
 	_ = s.secrets.Delete(ports.KeyAPIKey)
+	_ = s.secrets.Delete(ports.KeyAPIKeyID)
 	_ = s.secrets.Delete(ports.KeyOrgID)
 	_ = clearStoredGrantState(s.secrets)
 
@@ -72,6 +72,18 @@ func TestConfigService_ResetConfig(t *testing.T) {
 		assert.Equal(t, "user-token", secrets.data[ports.KeyDashboardUserToken])
 		assert.Equal(t, "app-id", secrets.data[ports.KeyDashboardAppID])
 	})
+
+	t.Run("clears environment profile keys", func(t *testing.T) {
+		secrets := newMockSecretStore()
+		configStore := newMockConfigStore()
+		configStore.config = &domain.Config{
+			Environments: map[string]*domain.EnvironmentProfile{"sandbox": {Region: "us"}},
+		}
+		secrets.data[ports.EnvironmentSecretKey("sandbox", ports.KeyAPIKey)] = "nyk_sandbox"
+
+		require.NoError(t, NewConfigService(configStore, secrets).ResetConfig())
+		assert.Empty(t, secrets.data)
+	})
 }
 
 func TestConfigService_GetStatusIgnoresConfigGrantList(t *testing.T) {
@@ -340,3 +352,63 @@ func (m *failingSetupConfigStore) Path() string {
 func (m *failingSetupConfigStore) Exists() bool {
 	return m.config != nil
 }
+
+type failingSetSecretStore struct {
+	*mockSecretStore
+	failSetKey string
+}
+
+func (s *failingSetSecretStore) Set(key, value string) error {
+	if key == s.failSetKey {
+		return errors.New("set failed")
+	}
+	return s.mockSecretStore.Set(key, value)
+}
+
+func TestConfigService_RotateAPIKey(t *testing.T) {
+	t.Run("replaces key and keeps grants", func(t *testing.T) {
+		secrets := newMockSecretStore()
+		secrets.data[ports.KeyAPIKey] = "old-api-key"
+		secrets.data[ports.KeyAPIKeyID] = "key-old"
+		secrets.data[storedDefaultGrantKey] = "grant-123"
+
+		svc := NewConfigService(newMockConfigStore(), secrets)
+		require.NoError(t, svc.RotateAPIKey("", "new-api-key", "key-new"))
+
+		assert.Equal(t, "new-api-key", secrets.data[ports.KeyAPIKey])
+		assert.Equal(t, "key-new", secrets.data[ports.KeyAPIKeyID])
+		assert.Equal(t, "grant-123", secrets.data[storedDefaultGrantKey])
+	})
+
+	t.Run("restores the old key when a write fails", func(t *testing.T) {
+		base := newMockSecretStore()
+		base.data[ports.KeyAPIKey] = "old-api-key"
+		base.data[ports.KeyAPIKeyID] = "key-old"
+		secrets := &failingSetSecretStore{mockSecretStore: base, failSetKey: ports.KeyAPIKeyID}
+
+		svc := NewConfigService(newMockConfigStore(), secrets)
+		err := svc.RotateAPIKey("", "new-api-key", "key-new")
+		require.Error(t, err)
+
+		assert.Equal(t, "old-api-key", base.data[ports.KeyAPIKey])
+	})
+
+	t.Run("environment profile leaves the top-level key alone", func(t *testing.T) {
+		secrets := newMockSecretStore()
+		secrets.data[ports.KeyAPIKey] = "prod-api-key"
+		secrets.data[ports.KeyAPIKeyID] = "key-prod"
+		secrets.data[ports.EnvironmentSecretKey("staging", ports.KeyAPIKeyID)] = "key-staging-old"
+
+		svc := NewConfigService(newMockConfigStore(), secrets)
+		id, err := svc.GetAPIKeyID("staging")
+		require.NoError(t, err)
+		assert.Equal(t, "key-staging-old", id)
+
+		require.NoError(t, svc.RotateAPIKey("staging", "staging-api-key", "key-staging"))
+
+		assert.Equal(t, "staging-api-key", secrets.data[ports.EnvironmentSecretKey("staging", ports.KeyAPIKey)])
+		assert.Equal(t, "key-staging", secrets.data[ports.EnvironmentSecretKey("staging", ports.KeyAPIKeyID)])
+		assert.Equal(t, "prod-api-key", secrets.data[ports.KeyAPIKey])
+		assert.Equal(t, "key-prod", secrets.data[ports.KeyAPIKeyID])
+	})
+}
@@ -40,6 +40,13 @@ func NewService(
 
 // Login performs OAuth login with the specified provider.
 func (s *Service) Login(ctx context.Context, provider domain.Provider) (*domain.Grant, error) {
+	return s.LoginWithOptions(ctx, provider, domain.AuthURLOptions{})
+}
+
+// LoginWithOptions performs OAuth login requesting the scopes and account in
+// opts. Signing in to an account that already has a grant re-authenticates
+// that grant.
+func (s *Service) LoginWithOptions(ctx context.Context, provider domain.Provider, opts domain.AuthURLOptions) (*domain.Grant, error) {
 	// Start callback server
 	if err := s.server.Start(); err != nil {
 		return nil, err
@@ -66,7 +73,7 @@ func (s *Service) Login(ctx context.Context, provider domain.Provider) (*domain.
 	}()
 
 	// Build auth URL and open browser
-	authURL := s.client.BuildAuthURL(provider, redirectURI, state, codeChallenge)
+	authURL := s.client.BuildAuthURLWithOptions(provider, redirectURI, state, codeChallenge, opts)
 	if err := s.browser.Open(authURL); err != nil {
 		return nil, err
 	}
@@ -528,6 +528,26 @@ func TestService_Login(t *testing.T) {
 	})
 }
 
+func TestService_LoginWithOptions(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.ExchangeCodeFunc = func(ctx context.Context, code, redirectURI, codeVerifier string) (*domain.Grant, error) {
+		return &domain.Grant{ID: "grant-123", Email: "user@example.com", Provider: domain.ProviderGoogle}, nil
+	}
+	server := &mockOAuthServer{redirectURI: "http://localhost:8080/callback", code: "auth-code-123"}
+	svc := NewService(client, newMockGrantStore(), newMockConfigStore(), server, &mockBrowser{})
+
+	opts := domain.AuthURLOptions{
+		Scopes:    []string{"https://www.googleapis.com/auth/calendar"},
+		LoginHint: "user@example.com",
+	}
+	grant, err := svc.LoginWithOptions(context.Background(), domain.ProviderGoogle, opts)
+
+	require.NoError(t, err)
+	assert.Equal(t, "grant-123", grant.ID)
+	assert.True(t, client.BuildAuthURLCalled)
+	assert.Equal(t, opts, client.LastAuthURLOptions)
+}
+
 func TestService_Logout(t *testing.T) {
 	t.Run("successful logout revokes and deletes grant", func(t *testing.T) {
 		client := nylas.NewMockClient()
@@ -3,6 +3,9 @@ package admin
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 )
 
 // NewAdminCmd creates the admin command group.
@@ -18,11 +21,16 @@ the Nylas platform at an organizational level.
 API reference: https://developer.nylas.com/docs/reference/api/applications/`,
 	}
 
+	common.RequireScopes(cmd, domain.ScopeApplicationAPI)
+
+	cmd.AddCommand(newAPIKeysCmd())
 	cmd.AddCommand(newApplicationsCmd())
 	cmd.AddCommand(newCallbackURIsCmd())
 	cmd.AddCommand(newConnectorsCmd())
 	cmd.AddCommand(newCredentialsCmd())
 	cmd.AddCommand(newGrantsCmd())
+	cmd.AddCommand(common.DeclareOutput(newInviteCmd(), inviteResult{}, []onboardingUser{}))
+	cmd.AddCommand(newOnboardingCmd())
 
 	return cmd
 }
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
 )
 
 func TestNewAdminCmd(t *testing.T) {
@@ -262,6 +265,27 @@ func TestConnectorCreateCmd(t *testing.T) {
 		smtpPort := cmd.Flags().Lookup("smtp-port")
 		assert.Equal(t, "587", smtpPort.DefValue)
 	})
+
+	t.Run("name_required_without_interactive", func(t *testing.T) {
+		cmd := newConnectorCreateCmd()
+		cmd.SetArgs([]string{"--provider", "google"})
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		assert.ErrorContains(t, cmd.Execute(), "--name flag is required")
+	})
+
+	t.Run("interactive_rejects_settings_flags", func(t *testing.T) {
+		cmd := newConnectorCreateCmd()
+		cmd.SetArgs([]string{"--interactive", "--client-id", "abc"})
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		assert.ErrorContains(t, cmd.Execute(), "--interactive asks for the connector settings")
+	})
+
+	t.Run("interactive_rejects_other_providers", func(t *testing.T) {
+		cmd := newConnectorCreateCmd()
+		cmd.SetArgs([]string{"--interactive", "--provider", "ews"})
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		assert.ErrorContains(t, cmd.Execute(), "doesn't support ews connectors")
+	})
 }
 
 func TestConnectorUpdateCmd(t *testing.T) {
@@ -473,3 +497,35 @@ func TestGrantStatsCmd(t *testing.T) {
 		assert.NotEmpty(t, cmd.Short)
 	})
 }
+
+func TestEWSConnectorScopes(t *testing.T) {
+	scopes, err := ewsConnectorScopes(nil)
+	require.NoError(t, err)
+	assert.Equal(t, domain.EWSScopes, scopes)
+
+	scopes, err = ewsConnectorScopes([]string{"ews.messages"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ews.messages"}, scopes)
+
+	_, err = ewsConnectorScopes([]string{"ews.messages", "https://www.googleapis.com/auth/gmail.readonly"})
+	assert.ErrorContains(t, err, "invalid EWS scope")
+}
+
+func TestGrantPageTokens(t *testing.T) {
+	offset, err := parseGrantPageToken("")
+	require.NoError(t, err)
+	assert.Equal(t, 0, offset)
+
+	offset, err = parseGrantPageToken("150")
+	require.NoError(t, err)
+	assert.Equal(t, 150, offset)
+
+	_, err = parseGrantPageToken("abc")
+	assert.Error(t, err)
+	_, err = parseGrantPageToken("-5")
+	assert.Error(t, err)
+
+	params := &domain.GrantsQueryParams{Limit: 50, Offset: 100}
+	assert.Equal(t, "150", nextGrantPageToken(params, 50), "a full page may have a next one")
+	assert.Empty(t, nextGrantPageToken(params, 20), "a short page is the last")
+}
@@ -0,0 +1,457 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	authapp "github.com/nylas/cli/internal/app/auth"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// apiKeyStore is the part of the auth config service that stores the CLI's
+// own API key.
+type apiKeyStore interface {
+	RotateAPIKey(env, apiKey, keyID string) error
+	GetAPIKeyID(env string) (string, error)
+}
+
+// openAPIKeyStore and newKeyClient are replaced in tests.
+var (
+	openAPIKeyStore = func() (apiKeyStore, error) {
+		secrets, err := keyring.NewSecretStore(config.DefaultConfigDir())
+		if err != nil {
+			return nil, err
+		}
+		return authapp.NewConfigService(config.NewDefaultFileStore(), secrets), nil
+	}
+	newKeyClient = common.NewNylasClientWithAPIKey
+)
+
+func newAPIKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "api-keys",
+		Aliases: []string{"apikeys", "keys"},
+		Short:   "Manage application API keys",
+		Long: `List, create, revoke, and rotate the API keys of your Nylas application.
+
+'rotate' creates a new key, checks that it works, stores it as the key this
+CLI uses, and then revokes the old one, so scripts that call the CLI keep
+working without config edits.
+
+API reference: https://developer.nylas.com/docs/reference/api/`,
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newAPIKeyListCmd(), []domain.APIKey{}))
+	cmd.AddCommand(newAPIKeyCreateCmd())
+	cmd.AddCommand(newAPIKeyRevokeCmd())
+	cmd.AddCommand(common.DeclareOutput(newAPIKeyRotateCmd(), rotateResult{}))
+
+	return cmd
+}
+
+func newAPIKeyListCmd() *cobra.Command {
+	var appID string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List API keys",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
+				app, err := resolveApplicationID(ctx, client, appID)
+				if err != nil {
+					return struct{}{}, err
+				}
+				keys, err := client.ListAPIKeys(ctx, app)
+				if err != nil {
+					return struct{}{}, common.WrapListError("API keys", err)
+				}
+
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(keys)
+				}
+				if len(keys) == 0 {
+					common.PrintEmptyStateWithHint("API keys", "Create one with: nylas admin api-keys create --name <name> --activate")
+					return struct{}{}, nil
+				}
+
+				current := storedAPIKeyID(activeKeyEnvironment())
+				table := common.NewTable("ID", "NAME", "STATUS", "EXPIRES", "CREATED", "")
+				for _, k := range keys {
+					marker := ""
+					if k.ID == current {
+						marker = common.Green.Sprint("← this CLI")
+					}
+					table.AddRow(k.ID, k.Name, k.Status, formatUnixTime(&k.ExpiresAt), formatUnixTime(&k.CreatedAt), marker)
+				}
+				table.Render()
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&appID, "app", "", "Application ID (default: the application of the current API key)")
+
+	return cmd
+}
+
+func newAPIKeyCreateCmd() *cobra.Command {
+	var (
+		appID     string
+		name      string
+		expiresIn int
+		activate  bool
+		keyFile   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API key",
+		Long: `Create an API key. The key value is shown by the API only once, and this
+command never prints it: pass --activate to store it as the key this CLI
+uses, or --key-file to write it to a new file readable only by you.`,
+		Example: `  # Create a key for CI and save it to a file
+  nylas admin api-keys create --name ci --expires 90 --key-file ./ci-key.txt
+
+  # Create a key and switch this CLI to it
+  nylas admin api-keys create --name laptop --activate`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !activate && keyFile == "" {
+				return common.NewUserError("choose where the new key goes",
+					"Pass --activate to store it for this CLI, or --key-file <path> to save it")
+			}
+			if expiresIn < 0 {
+				return common.NewInputError("--expires must be zero or a positive number of days")
+			}
+			if name == "" {
+				name = defaultAPIKeyName()
+			}
+
+			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
+				app, err := resolveApplicationID(ctx, client, appID)
+				if err != nil {
+					return struct{}{}, err
+				}
+				key, err := client.CreateAPIKey(ctx, app, &domain.CreateAPIKeyRequest{Name: name, ExpiresIn: expiresIn})
+				if err != nil {
+					return struct{}{}, common.WrapCreateError("API key", err)
+				}
+				common.RecordAuditDetail("api_key_id", key.ID)
+				common.PrintSuccess("Created API key %s (%s)", key.ID, key.Name)
+
+				if keyFile != "" {
+					if err := writeKeyFile(keyFile, key.Key); err != nil {
+						return struct{}{}, err
+					}
+					fmt.Printf("  Key saved to %s\n", keyFile)
+				}
+				if activate {
+					store, err := openAPIKeyStore()
+					if err != nil {
+						return struct{}{}, common.WrapLoadError("secret store", err)
+					}
+					if err := store.RotateAPIKey(activeKeyEnvironment(), key.Key, key.ID); err != nil {
+						return struct{}{}, common.WrapSaveError("API key", err)
+					}
+					common.ResetCachedClient()
+					fmt.Println("  Activated for this CLI")
+					warnEnvAPIKey()
+				}
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&appID, "app", "", "Application ID (default: the application of the current API key)")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Key name (default: CLI-<timestamp>)")
+	cmd.Flags().IntVar(&expiresIn, "expires", 0, "Expire after this many days (default: never)")
+	cmd.Flags().BoolVar(&activate, "activate", false, "Store the new key as the one this CLI uses")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "Write the new key to this file (must not exist)")
+
+	return cmd
+}
+
+func newAPIKeyRevokeCmd() *cobra.Command {
+	var (
+		appID string
+		yes   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "revoke <key-id>",
+		Aliases: []string{"delete"},
+		Short:   "Revoke an API key",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyID := args[0]
+			prompt := fmt.Sprintf("Revoke API key %s? Anything using it stops working.", keyID)
+			if keyID == storedAPIKeyID(activeKeyEnvironment()) {
+				prompt = fmt.Sprintf("API key %s is the one this CLI uses; revoking it logs the CLI out. Revoke it?", keyID)
+			}
+			if !yes && !common.Confirm(prompt, false) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+
+			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
+				app, err := resolveApplicationID(ctx, client, appID)
+				if err != nil {
+					return struct{}{}, err
+				}
+				if err := client.RevokeAPIKey(ctx, app, keyID); err != nil {
+					return struct{}{}, common.WrapDeleteError("API key", err)
+				}
+				common.RecordAuditDetail("api_key_id", keyID)
+				common.PrintSuccess("Revoked API key %s", keyID)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&appID, "app", "", "Application ID (default: the application of the current API key)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// rotateOptions configures rotateAPIKey.
+type rotateOptions struct {
+	AppID    string
+	Env      string // environment profile whose key is rotated; "" for the top-level key
+	OldKeyID string // key to revoke; "" when unknown
+	Name     string
+	Expires  int
+	KeepOld  bool
+}
+
+// rotateResult describes a finished rotation.
+type rotateResult struct {
+	NewKeyID   string `json:"new_key_id"`
+	OldKeyID   string `json:"old_key_id,omitempty"`
+	OldRevoked bool   `json:"old_revoked"`
+	RevokeErr  string `json:"revoke_error,omitempty"`
+}
+
+func newAPIKeyRotateCmd() *cobra.Command {
+	var (
+		opts rotateOptions
+		yes  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate [old-key-id]",
+		Short: "Replace the CLI's API key with a new one",
+		Long: `Rotate the API key this CLI uses:
+
+  1. create a new key
+  2. check that the new key can reach the API
+  3. store it in place of the current key (restored if storing fails)
+  4. revoke the old key, unless --keep-old
+
+If anything fails before step 3, the new key is revoked and nothing changes.
+The old key is the one given as an argument, or the one the CLI created
+last; if neither is known, it is left active and you are told to revoke it.`,
+		Example: `  # Rotate, revoking the key the CLI created earlier
+  nylas admin api-keys rotate
+
+  # Rotate away from a key created in the dashboard
+  nylas admin api-keys rotate <old-key-id>
+
+  # Keep the old key active for a grace period
+  nylas admin api-keys rotate --keep-old`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Env = activeKeyEnvironment()
+			if len(args) > 0 {
+				opts.OldKeyID = args[0]
+			} else {
+				opts.OldKeyID = storedAPIKeyID(opts.Env)
+			}
+			if opts.Expires < 0 {
+				return common.NewInputError("--expires must be zero or a positive number of days")
+			}
+			if opts.Name == "" {
+				opts.Name = defaultAPIKeyName()
+			}
+			if !yes && !opts.KeepOld && opts.OldKeyID != "" {
+				if !common.Confirm(fmt.Sprintf("Rotate the CLI's API key and revoke %s?", opts.OldKeyID), true) {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
+
+			result, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (*rotateResult, error) {
+				store, err := openAPIKeyStore()
+				if err != nil {
+					return nil, common.WrapLoadError("secret store", err)
+				}
+				return rotateAPIKey(ctx, client, store, opts)
+			})
+			if err != nil {
+				return err
+			}
+			common.RecordAuditDetail("api_key_id", result.NewKeyID)
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(result)
+			}
+			printRotateResult(result, opts)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.AppID, "app", "", "Application ID (default: the application of the current API key)")
+	cmd.Flags().StringVarP(&opts.Name, "name", "n", "", "Name of the new key (default: CLI-<timestamp>)")
+	cmd.Flags().IntVar(&opts.Expires, "expires", 0, "New key expires after this many days (default: never)")
+	cmd.Flags().BoolVar(&opts.KeepOld, "keep-old", false, "Leave the old key active")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// rotateAPIKey creates a new key, verifies it, swaps it into store, and
+// revokes the old key. The stored key only changes once the new key works.
+func rotateAPIKey(ctx context.Context, client ports.NylasClient, store apiKeyStore, opts rotateOptions) (*rotateResult, error) {
+	app, err := resolveApplicationID(ctx, client, opts.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := client.CreateAPIKey(ctx, app, &domain.CreateAPIKeyRequest{Name: opts.Name, ExpiresIn: opts.Expires})
+	if err != nil {
+		return nil, common.WrapCreateError("API key", err)
+	}
+
+	// Undo the new key if it can't be put into use.
+	abandon := func(cause error) error {
+		if revokeErr := client.RevokeAPIKey(ctx, app, key.ID); revokeErr != nil {
+			return errors.Join(cause, fmt.Errorf("also failed to revoke new key %s: %w", key.ID, revokeErr))
+		}
+		return cause
+	}
+
+	newClient := newKeyClient(key.Key)
+	if _, err := newClient.ListApplications(ctx); err != nil {
+		return nil, abandon(common.NewUserError(
+			fmt.Sprintf("new API key %s did not work: %v", key.ID, err),
+			"Your stored key is unchanged; try again or check the key's permissions"))
+	}
+	if err := store.RotateAPIKey(opts.Env, key.Key, key.ID); err != nil {
+		return nil, abandon(common.WrapSaveError("API key", err))
+	}
+	common.ResetCachedClient()
+
+	result := &rotateResult{NewKeyID: key.ID, OldKeyID: opts.OldKeyID}
+	if opts.KeepOld || opts.OldKeyID == "" || opts.OldKeyID == key.ID {
+		return result, nil
+	}
+	// Revoke with the new key: the old one may already be on its way out.
+	if err := newClient.RevokeAPIKey(ctx, app, opts.OldKeyID); err != nil {
+		result.RevokeErr = err.Error()
+		return result, nil
+	}
+	result.OldRevoked = true
+	return result, nil
+}
+
+func printRotateResult(result *rotateResult, opts rotateOptions) {
+	if opts.Env != "" {
+		common.PrintSuccess("API key for environment %q rotated; the CLI now uses %s", opts.Env, result.NewKeyID)
+	} else {
+		common.PrintSuccess("API key rotated; the CLI now uses %s", result.NewKeyID)
+	}
+	switch {
+	case result.OldRevoked:
+		fmt.Printf("  Revoked old key %s\n", result.OldKeyID)
+	case result.RevokeErr != "":
+		common.PrintWarningStderr("could not revoke old key %s: %s", result.OldKeyID, result.RevokeErr)
+		fmt.Printf("  Revoke it with: nylas admin api-keys revoke %s\n", result.OldKeyID)
+	case opts.KeepOld && result.OldKeyID != "":
+		fmt.Printf("  Old key %s is still active\n", result.OldKeyID)
+	default:
+		fmt.Println("  The old key's ID is unknown, so it is still active.")
+		fmt.Println("  Find it with 'nylas admin api-keys list' and revoke it.")
+	}
+	warnEnvAPIKey()
+}
+
+// resolveApplicationID returns explicit, or the application the current API
+// key belongs to.
+func resolveApplicationID(ctx context.Context, client ports.NylasClient, explicit string) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	apps, err := client.ListApplications(ctx)
+	if err != nil {
+		return "", common.WrapGetError("application", err)
+	}
+	for _, app := range apps {
+		if app.ApplicationID != "" {
+			return app.ApplicationID, nil
+		}
+		if app.ID != "" {
+			return app.ID, nil
+		}
+	}
+	return "", common.NewUserError("could not determine the application", "Pass --app <application-id>")
+}
+
+// storedAPIKeyID returns the ID of the API key the CLI stores for env, or ""
+// when unknown.
+func storedAPIKeyID(env string) string {
+	store, err := openAPIKeyStore()
+	if err != nil {
+		return ""
+	}
+	id, _ := store.GetAPIKeyID(env)
+	return id
+}
+
+// activeKeyEnvironment returns the environment profile whose API key the CLI
+// is using, or "" for the top-level key.
+func activeKeyEnvironment() string {
+	cfg, _ := config.NewDefaultFileStore().Load()
+	return common.ActiveEnvironment(cfg)
+}
+
+func defaultAPIKeyName() string {
+	return "CLI-" + time.Now().Format("20060102-150405")
+}
+
+// writeKeyFile writes key to a new file readable only by the owner.
+func writeKeyFile(path, key string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return common.NewUserError(fmt.Sprintf("%s already exists", path), "Choose a new file for --key-file")
+		}
+		return common.WrapWriteError("key file", err)
+	}
+	if _, err := f.WriteString(key + "\n"); err != nil {
+		_ = f.Close()
+		return common.WrapWriteError("key file", err)
+	}
+	return f.Close()
+}
+
+// warnEnvAPIKey reminds the user that NYLAS_API_KEY overrides the stored key.
+func warnEnvAPIKey() {
+	if strings.TrimSpace(os.Getenv("NYLAS_API_KEY")) != "" {
+		common.PrintWarningStderr("NYLAS_API_KEY is set and overrides the stored key; update it too")
+	}
+}
@@ -0,0 +1,195 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+type fakeAPIKeyStore struct {
+	env, key, keyID string
+	err             error
+}
+
+func (s *fakeAPIKeyStore) RotateAPIKey(env, apiKey, keyID string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.env, s.key, s.keyID = env, apiKey, keyID
+	return nil
+}
+
+func (s *fakeAPIKeyStore) GetAPIKeyID(string) (string, error) { return s.keyID, nil }
+
+// useKeyClient makes newKeyClient return client and records the key it was
+// built with.
+func useKeyClient(t *testing.T, client ports.NylasClient) *string {
+	t.Helper()
+	var gotKey string
+	orig := newKeyClient
+	newKeyClient = func(apiKey string) ports.NylasClient {
+		gotKey = apiKey
+		return client
+	}
+	t.Cleanup(func() { newKeyClient = orig })
+	return &gotKey
+}
+
+func TestNewAPIKeysCmd(t *testing.T) {
+	cmd := newAPIKeysCmd()
+	assert.Equal(t, "api-keys", cmd.Use)
+	assert.Contains(t, cmd.Aliases, "apikeys")
+
+	cmdMap := make(map[string]bool)
+	for _, sub := range cmd.Commands() {
+		cmdMap[sub.Name()] = true
+	}
+	for _, expected := range []string{"list", "create", "revoke", "rotate"} {
+		assert.True(t, cmdMap[expected], "Missing expected subcommand: %s", expected)
+	}
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores new key and revokes old with it", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		newClient := nylas.NewMockClient()
+		var revokedWithNew string
+		newClient.RevokeAPIKeyFunc = func(_ context.Context, appID, keyID string) error {
+			assert.Equal(t, "app-id-1", appID)
+			revokedWithNew = keyID
+			return nil
+		}
+		gotKey := useKeyClient(t, newClient)
+		store := &fakeAPIKeyStore{key: "old", keyID: "key-old"}
+
+		result, err := rotateAPIKey(ctx, client, store, rotateOptions{OldKeyID: "key-old", Name: "rotated"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "nyk_mock_new_key", *gotKey)
+		assert.Equal(t, "nyk_mock_new_key", store.key)
+		assert.Equal(t, "new-key", store.keyID)
+		assert.Equal(t, "key-old", revokedWithNew)
+		assert.True(t, result.OldRevoked)
+	})
+
+	t.Run("new key that fails verification is revoked and not stored", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		var revoked string
+		client.RevokeAPIKeyFunc = func(_ context.Context, _, keyID string) error {
+			revoked = keyID
+			return nil
+		}
+		useKeyClient(t, failingListClient{nylas.NewMockClient()})
+		store := &fakeAPIKeyStore{key: "old", keyID: "key-old"}
+
+		_, err := rotateAPIKey(ctx, client, store, rotateOptions{OldKeyID: "key-old"})
+		require.Error(t, err)
+		assert.Equal(t, "new-key", revoked)
+		assert.Equal(t, "old", store.key)
+	})
+
+	t.Run("store failure revokes the new key", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		var revoked string
+		client.RevokeAPIKeyFunc = func(_ context.Context, _, keyID string) error {
+			revoked = keyID
+			return nil
+		}
+		useKeyClient(t, nylas.NewMockClient())
+
+		_, err := rotateAPIKey(ctx, client, &fakeAPIKeyStore{err: errors.New("keyring locked")}, rotateOptions{OldKeyID: "key-old"})
+		require.Error(t, err)
+		assert.Equal(t, "new-key", revoked)
+	})
+
+	t.Run("keep old or unknown old key", func(t *testing.T) {
+		newClient := nylas.NewMockClient()
+		newClient.RevokeAPIKeyFunc = func(context.Context, string, string) error {
+			t.Fatal("old key must not be revoked")
+			return nil
+		}
+		useKeyClient(t, newClient)
+
+		result, err := rotateAPIKey(ctx, nylas.NewMockClient(), &fakeAPIKeyStore{}, rotateOptions{OldKeyID: "key-old", KeepOld: true})
+		require.NoError(t, err)
+		assert.False(t, result.OldRevoked)
+
+		result, err = rotateAPIKey(ctx, nylas.NewMockClient(), &fakeAPIKeyStore{}, rotateOptions{})
+		require.NoError(t, err)
+		assert.False(t, result.OldRevoked)
+	})
+
+	t.Run("failed revoke of old key is reported, not fatal", func(t *testing.T) {
+		newClient := nylas.NewMockClient()
+		newClient.RevokeAPIKeyFunc = func(context.Context, string, string) error { return domain.ErrAPIKeyNotFound }
+		useKeyClient(t, newClient)
+		store := &fakeAPIKeyStore{}
+
+		result, err := rotateAPIKey(ctx, nylas.NewMockClient(), store, rotateOptions{OldKeyID: "key-old"})
+		require.NoError(t, err)
+		assert.Equal(t, "new-key", store.keyID)
+		assert.Contains(t, result.RevokeErr, "not found")
+	})
+
+	t.Run("active environment profile rotates that profile's key", func(t *testing.T) {
+		common.SetActiveEnvironment("staging")
+		t.Cleanup(func() { common.SetActiveEnvironment("") })
+		useKeyClient(t, nylas.NewMockClient())
+		store := &fakeAPIKeyStore{}
+
+		env := activeKeyEnvironment()
+		require.Equal(t, "staging", env)
+		_, err := rotateAPIKey(ctx, nylas.NewMockClient(), store, rotateOptions{Env: env})
+		require.NoError(t, err)
+		assert.Equal(t, "staging", store.env)
+		assert.Equal(t, "nyk_mock_new_key", store.key)
+	})
+}
+
+type failingListClient struct {
+	*nylas.MockClient
+}
+
+func (failingListClient) ListApplications(context.Context) ([]domain.Application, error) {
+	return nil, domain.ErrAuthFailed
+}
+
+func TestResolveApplicationID(t *testing.T) {
+	id, err := resolveApplicationID(context.Background(), nylas.NewMockClient(), "explicit")
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", id)
+
+	id, err = resolveApplicationID(context.Background(), nylas.NewMockClient(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "app-id-1", id)
+}
+
+func TestWriteKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.txt")
+	require.NoError(t, writeKeyFile(path, "nyk_secret"))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	assert.ErrorContains(t, writeKeyFile(path, "other"), "already exists")
+}
+
+func TestAPIKeyCreateCmd_RequiresDestination(t *testing.T) {
+	cmd := newAPIKeyCreateCmd()
+	cmd.SetArgs([]string{"--name", "ci"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	assert.ErrorContains(t, cmd.Execute(), "choose where the new key goes")
+}
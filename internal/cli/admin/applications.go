@@ -2,7 +2,6 @@ package admin
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/nylas/cli/internal/cli/common"
@@ -21,8 +20,8 @@ func newApplicationsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/applications/`,
 	}
 
-	cmd.AddCommand(newAppListCmd())
-	cmd.AddCommand(newAppShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newAppListCmd(), []domain.Application{}))
+	cmd.AddCommand(common.DeclareOutput(newAppShowCmd(), domain.Application{}))
 	cmd.AddCommand(newAppCreateCmd())
 	cmd.AddCommand(newAppUpdateCmd())
 	cmd.AddCommand(newAppDeleteCmd())
@@ -43,8 +42,8 @@ func newAppListCmd() *cobra.Command {
 					return struct{}{}, common.WrapListError("applications", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(apps)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(apps)
 				}
 
 				if len(apps) == 0 {
@@ -91,8 +90,8 @@ func newAppShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("application", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(app)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(app)
 				}
 
 				_, _ = common.Bold.Println("Application Details")
@@ -2,7 +2,6 @@ package admin
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/nylas/cli/internal/cli/common"
@@ -19,8 +18,8 @@ func newCallbackURIsCmd() *cobra.Command {
 		Long:    "Manage OAuth callback URIs for your Nylas application.",
 	}
 
-	cmd.AddCommand(newCallbackURIListCmd())
-	cmd.AddCommand(newCallbackURIShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newCallbackURIListCmd(), []domain.CallbackURI{}))
+	cmd.AddCommand(common.DeclareOutput(newCallbackURIShowCmd(), domain.CallbackURI{}))
 	cmd.AddCommand(newCallbackURICreateCmd())
 	cmd.AddCommand(newCallbackURIUpdateCmd())
 	cmd.AddCommand(newCallbackURIDeleteCmd())
@@ -41,8 +40,8 @@ func newCallbackURIListCmd() *cobra.Command {
 					return struct{}{}, common.WrapListError("callback URIs", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(uris)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(uris)
 				}
 
 				if len(uris) == 0 {
@@ -85,8 +84,8 @@ func newCallbackURIShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("callback URI", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(uri)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(uri)
 				}
 
 				_, _ = common.Bold.Println("Callback URI Details")
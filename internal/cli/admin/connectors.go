@@ -2,8 +2,9 @@ package admin
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/domain"
@@ -21,9 +22,9 @@ func newConnectorsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/connectors-integrations/`,
 	}
 
-	cmd.AddCommand(newConnectorListCmd())
-	cmd.AddCommand(newConnectorShowCmd())
-	cmd.AddCommand(newConnectorCreateCmd())
+	cmd.AddCommand(common.DeclareOutput(newConnectorListCmd(), []domain.Connector{}))
+	cmd.AddCommand(common.DeclareOutput(newConnectorShowCmd(), domain.Connector{}))
+	cmd.AddCommand(common.DeclareOutput(newConnectorCreateCmd(), imapWizardResult{}, domain.Connector{}))
 	cmd.AddCommand(newConnectorUpdateCmd())
 	cmd.AddCommand(newConnectorDeleteCmd())
 
@@ -44,8 +45,8 @@ func newConnectorListCmd() *cobra.Command {
 				}
 				connectors = common.FilterVisibleConnectors(connectors)
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(connectors)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(connectors)
 				}
 
 				if len(connectors) == 0 {
@@ -88,8 +89,8 @@ func newConnectorShowCmd() *cobra.Command {
 					return struct{}{}, common.NewUserError("connector not found", "The inbox connector is no longer supported")
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(connector)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(connector)
 				}
 
 				// #nosec G104 -- color output errors are non-critical, best-effort display
@@ -139,16 +140,51 @@ func newConnectorCreateCmd() *cobra.Command {
 		imapPort     int
 		smtpHost     string
 		smtpPort     int
+		interactive  bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a connector",
-		Long:  "Create a new email provider connector.",
+		Long: `Create a new email provider connector.
+
+--interactive walks through creating a Google, Microsoft or IMAP connector.
+For Google and Microsoft it prints the redirect URI to register with the
+OAuth app, asks for the client ID and secret, and tests them with a token
+exchange before creating the connector. For IMAP it runs the same wizard as
+'nylas admin connectors create imap --wizard'.`,
+		Example: `  # Guided setup
+  nylas admin connectors create --interactive
+
+  # Create a Google connector with flags
+  nylas admin connectors create --name Google --provider google --client-id <id> --client-secret <secret>`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := common.ValidateSupportedConnectorProvider(provider); err != nil {
 				return err
 			}
+			if interactive {
+				if clientID != "" || clientSecret != "" || imapHost != "" || smtpHost != "" {
+					return common.NewUserError("--interactive asks for the connector settings",
+						"Remove --client-id, --client-secret and the --imap/--smtp flags")
+				}
+				return runConnectorWizard(cmd, name, provider, scopes)
+			}
+			if err := common.ValidateRequiredFlag("--name", name); err != nil {
+				return err
+			}
+			if err := common.ValidateRequiredFlag("--provider", provider); err != nil {
+				return err
+			}
+			if provider == string(domain.ProviderEWS) {
+				if clientID != "" || clientSecret != "" || imapHost != "" || smtpHost != "" {
+					return common.NewUserError("EWS connectors take no OAuth or IMAP settings",
+						"The Exchange server is set per grant: nylas auth login --provider ews --ews-host <host>")
+				}
+				var err error
+				if scopes, err = ewsConnectorScopes(scopes); err != nil {
+					return err
+				}
+			}
 
 			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
 				req := &domain.CreateConnectorRequest{
@@ -187,22 +223,37 @@ func newConnectorCreateCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&name, "name", "", "Connector name (required)")
-	cmd.Flags().StringVar(&provider, "provider", "", "Provider (google, microsoft, imap, etc.) (required)")
+	cmd.Flags().StringVar(&name, "name", "", "Connector name (required without --interactive)")
+	cmd.Flags().StringVar(&provider, "provider", "", "Provider (google, microsoft, ews, imap, etc.) (required without --interactive)")
 	cmd.Flags().StringVar(&clientID, "client-id", "", "OAuth client ID")
 	cmd.Flags().StringVar(&clientSecret, "client-secret", "", "OAuth client secret")
-	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{}, "OAuth scopes (comma-separated)")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{}, "OAuth scopes (comma-separated); EWS defaults to ews.messages,ews.calendars,ews.contacts")
 	cmd.Flags().StringVar(&imapHost, "imap-host", "", "IMAP host (for IMAP provider)")
 	cmd.Flags().IntVar(&imapPort, "imap-port", 993, "IMAP port")
 	cmd.Flags().StringVar(&smtpHost, "smtp-host", "", "SMTP host (for IMAP provider)")
 	cmd.Flags().IntVar(&smtpPort, "smtp-port", 587, "SMTP port")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Walk through the setup and test OAuth credentials before creating")
 
-	_ = cmd.MarkFlagRequired("name")
-	_ = cmd.MarkFlagRequired("provider")
+	cmd.AddCommand(common.DeclareOutput(newConnectorCreateIMAPCmd(), imapWizardResult{}, domain.Connector{}))
 
 	return cmd
 }
 
+// ewsConnectorScopes defaults an EWS connector to all EWS scopes and rejects
+// scopes Exchange doesn't have.
+func ewsConnectorScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return domain.EWSScopes, nil
+	}
+	for _, s := range scopes {
+		if !slices.Contains(domain.EWSScopes, s) {
+			return nil, common.NewUserError(fmt.Sprintf("invalid EWS scope: %s", s),
+				"Use "+strings.Join(domain.EWSScopes, ", "))
+		}
+	}
+	return scopes, nil
+}
+
 func newConnectorUpdateCmd() *cobra.Command {
 	var (
 		name   string
@@ -241,7 +292,7 @@ func newConnectorUpdateCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Connector name")
-	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{}, "OAuth scopes (comma-separated)")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{}, "OAuth scopes (comma-separated); EWS defaults to ews.messages,ews.calendars,ews.contacts")
 
 	return cmd
 }
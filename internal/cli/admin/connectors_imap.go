@@ -0,0 +1,406 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/mailauth"
+	"github.com/nylas/cli/internal/adapters/mailconfig"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Replaced in tests.
+var (
+	newMailConfigDiscoverer = func() ports.MailConfigDiscoverer { return mailconfig.New() }
+	newMailAuthTester       = func() ports.MailAuthTester { return mailauth.NewTester() }
+)
+
+// maxLoginAttempts is how often the wizard asks for the password again after
+// the server rejects it.
+const maxLoginAttempts = 3
+
+// imapAccount is a mailbox the wizard has verified and will connect.
+type imapAccount struct {
+	Username string
+	Password string
+	IMAP     domain.MailServer
+	SMTP     *domain.MailServer
+}
+
+// imapWizardResult is printed with --json.
+type imapWizardResult struct {
+	Connector        *domain.Connector        `json:"connector"`
+	ConnectorCreated bool                     `json:"connector_created"`
+	Grant            *domain.Grant            `json:"grant"`
+	Settings         *domain.MailServerConfig `json:"settings"`
+}
+
+// imapWizardOptions are the flags of the IMAP connector command.
+type imapWizardOptions struct {
+	name     string
+	email    string
+	username string
+	imapHost string
+	imapPort int
+	smtpHost string
+	smtpPort int
+}
+
+func newConnectorCreateIMAPCmd() *cobra.Command {
+	var (
+		opts   imapWizardOptions
+		wizard bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "imap",
+		Short: "Create an IMAP connector, optionally with a setup wizard",
+		Long: `Create an IMAP connector.
+
+With --wizard, the connector and a first grant are set up in one go:
+
+  1. The IMAP and SMTP servers for the email address are looked up through
+     autoconfig (the domain's own, then the Thunderbird ISP database) and
+     autodiscover, falling back to probing imap.<domain>, mail.<domain>
+     and smtp.<domain>.
+  2. You confirm or correct the servers, then enter the password. The login
+     is tested against the IMAP server (and SMTP, if found) before anything
+     is created.
+  3. The IMAP connector is created unless the application already has one,
+     and a grant is created for the mailbox.
+
+--imap-host skips discovery. The wizard needs an interactive terminal.`,
+		Example: `  # Discover settings, test the login and connect the mailbox
+  nylas admin connectors create imap --wizard --email me@example.com
+
+  # Create the connector only, with known servers
+  nylas admin connectors create imap --imap-host imap.example.com --smtp-host smtp.example.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !wizard {
+				if err := common.ValidateRequiredFlag("--imap-host", opts.imapHost); err != nil {
+					return err
+				}
+				_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
+					connector, err := client.CreateConnector(ctx, imapConnectorRequest(opts.name, &domain.MailServerConfig{
+						IMAP: &domain.MailServer{Host: opts.imapHost, Port: opts.imapPort, TLSMode: tlsModeForPort(opts.imapPort)},
+						SMTP: optionalServer(opts.smtpHost, opts.smtpPort),
+					}))
+					if err != nil {
+						return struct{}{}, common.WrapCreateError("connector", err)
+					}
+					if common.IsStructuredOutput(cmd) {
+						return struct{}{}, common.GetOutputWriter(cmd).Write(connector)
+					}
+					common.PrintSuccess("Created connector: %s", connector.Name)
+					fmt.Printf("  ID: %s\n", common.Cyan.Sprint(connector.ID))
+					return struct{}{}, nil
+				})
+				return err
+			}
+
+			return runIMAPWizard(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "IMAP", "Connector name")
+	cmd.Flags().BoolVar(&wizard, "wizard", false, "Discover settings, test the login, and create a first grant")
+	cmd.Flags().StringVar(&opts.email, "email", "", "Email address of the first mailbox (wizard)")
+	cmd.Flags().StringVar(&opts.username, "username", "", "IMAP username if it isn't the email address (wizard)")
+	cmd.Flags().StringVar(&opts.imapHost, "imap-host", "", "IMAP host; skips discovery in the wizard")
+	cmd.Flags().IntVar(&opts.imapPort, "imap-port", 993, "IMAP port")
+	cmd.Flags().StringVar(&opts.smtpHost, "smtp-host", "", "SMTP host")
+	cmd.Flags().IntVar(&opts.smtpPort, "smtp-port", 587, "SMTP port")
+
+	return cmd
+}
+
+// runIMAPWizard discovers the mail servers for an address, tests the login
+// and connects the mailbox, creating the IMAP connector if needed.
+func runIMAPWizard(cmd *cobra.Command, opts *imapWizardOptions) error {
+	if opts.email == "" {
+		var err error
+		if opts.email, err = common.InputPrompt("Email address to connect", ""); err != nil {
+			return err
+		}
+	}
+	opts.email = strings.TrimSpace(opts.email)
+	if err := common.ValidateEmail("email", opts.email); err != nil {
+		return err
+	}
+
+	var cfg *domain.MailServerConfig
+	if opts.imapHost != "" {
+		cfg = &domain.MailServerConfig{
+			Source: "flags",
+			IMAP:   &domain.MailServer{Host: opts.imapHost, Port: opts.imapPort, TLSMode: tlsModeForPort(opts.imapPort)},
+			SMTP:   optionalServer(opts.smtpHost, opts.smtpPort),
+		}
+	} else {
+		var err error
+		cfg, err = common.RunWithSpinnerResult("Looking up mail servers for "+opts.email+"...", func() (*domain.MailServerConfig, error) {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+			return newMailConfigDiscoverer().Discover(ctx, opts.email)
+		})
+		if err != nil {
+			common.PrintWarning("%v", err)
+		}
+		if cfg == nil {
+			cfg = &domain.MailServerConfig{}
+		}
+	}
+	if err := confirmMailServers(cfg); err != nil {
+		return err
+	}
+
+	if opts.username == "" {
+		opts.username = opts.email
+	}
+	account, err := promptIMAPLogin(cfg, opts.username)
+	if err != nil {
+		return err
+	}
+
+	client, err := common.GetNylasClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := common.CreateContext()
+	defer cancel()
+	result, err := connectIMAPAccount(ctx, client, opts.name, account)
+	if err != nil {
+		return err
+	}
+	result.Settings = cfg
+
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(result)
+	}
+	if result.ConnectorCreated {
+		common.PrintSuccess("Created connector: %s (%s)", result.Connector.Name, result.Connector.ID)
+	} else {
+		fmt.Printf("Using existing IMAP connector %s\n", result.Connector.ID)
+	}
+	common.PrintSuccess("Connected %s", result.Grant.Email)
+	fmt.Printf("  Grant ID: %s\n", common.Cyan.Sprint(result.Grant.ID))
+	return nil
+}
+
+// confirmMailServers shows the discovered servers and lets the user accept
+// or correct them.
+func confirmMailServers(cfg *domain.MailServerConfig) error {
+	if cfg.IMAP != nil {
+		fmt.Printf("\nMail servers (%s):\n", cfg.Source)
+		fmt.Printf("  IMAP: %s\n", describeMailServer(cfg.IMAP))
+		smtp := "not found"
+		if cfg.SMTP != nil {
+			smtp = describeMailServer(cfg.SMTP)
+		}
+		fmt.Printf("  SMTP: %s\n\n", smtp)
+
+		ok, err := common.ConfirmPrompt("Use these servers?", true)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	imap, err := promptMailServer("IMAP", cfg.IMAP, 993)
+	if err != nil {
+		return err
+	}
+	if imap == nil {
+		return common.NewUserError("an IMAP host is required", "Pass --imap-host or enter the host when asked")
+	}
+	cfg.IMAP = imap
+	if cfg.SMTP, err = promptMailServer("SMTP", cfg.SMTP, 587); err != nil {
+		return err
+	}
+	cfg.Source = "manual"
+	return nil
+}
+
+// promptMailServer asks for a host and port, defaulting to current. An empty
+// host returns nil.
+func promptMailServer(label string, current *domain.MailServer, defaultPort int) (*domain.MailServer, error) {
+	host, port := "", defaultPort
+	if current != nil {
+		host, port = current.Host, current.Port
+	}
+	host, err := common.InputPrompt(label+" host", host)
+	if err != nil {
+		return nil, err
+	}
+	if host = strings.TrimSpace(host); host == "" {
+		return nil, nil
+	}
+	rawPort, err := common.InputPrompt(label+" port", strconv.Itoa(port))
+	if err != nil {
+		return nil, err
+	}
+	if port, err = strconv.Atoi(strings.TrimSpace(rawPort)); err != nil || port <= 0 || port > 65535 {
+		return nil, common.NewInputError(fmt.Sprintf("invalid %s port %q", label, rawPort))
+	}
+	return &domain.MailServer{Host: host, Port: port, TLSMode: tlsModeForPort(port)}, nil
+}
+
+// promptIMAPLogin asks for the password until the IMAP server accepts it,
+// then checks SMTP with the same credentials.
+func promptIMAPLogin(cfg *domain.MailServerConfig, username string) (*imapAccount, error) {
+	tester := newMailAuthTester()
+	account := &imapAccount{Username: username, IMAP: *cfg.IMAP, SMTP: cfg.SMTP}
+
+	for attempt := 1; ; attempt++ {
+		password, err := common.PasswordPrompt("Password for " + username)
+		if err != nil {
+			return nil, err
+		}
+		if password == "" {
+			return nil, common.NewUserError("a password is required",
+				"Run the wizard in an interactive terminal; some providers require an app password")
+		}
+		account.Password = password
+
+		err = common.RunWithSpinner("Testing IMAP login...", func() error {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+			return testMailLogin(ctx, tester.TestIMAP, account.IMAP, account)
+		})
+		if err == nil {
+			common.PrintSuccess("IMAP login succeeded")
+			break
+		}
+		if attempt == maxLoginAttempts {
+			return nil, common.NewUserError(err.Error(),
+				"Check the username and password; providers like Yahoo and iCloud require an app password")
+		}
+		common.PrintError("%v", err)
+	}
+
+	if account.SMTP != nil {
+		err := common.RunWithSpinner("Testing SMTP login...", func() error {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+			return testMailLogin(ctx, tester.TestSMTP, *account.SMTP, account)
+		})
+		if err != nil {
+			common.PrintWarning("SMTP check failed, sending may not work: %v", err)
+		} else {
+			common.PrintSuccess("SMTP login succeeded")
+		}
+	}
+	return account, nil
+}
+
+// testMailLogin runs a password (SASL PLAIN) login against server.
+func testMailLogin(
+	ctx context.Context,
+	run func(context.Context, domain.MailAuthOptions) (*domain.MailAuthResult, error),
+	server domain.MailServer,
+	account *imapAccount,
+) error {
+	result, err := run(ctx, domain.MailAuthOptions{
+		Host:      server.Host,
+		Port:      server.Port,
+		Username:  account.Username,
+		Token:     account.Password,
+		Mechanism: domain.SASLPlain,
+		TLSMode:   server.TLSMode,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", describeMailServer(&server), err)
+	}
+	if !result.Authenticated {
+		return fmt.Errorf("%s rejected the login: %s", describeMailServer(&server), result.ServerError)
+	}
+	return nil
+}
+
+// connectIMAPAccount creates the IMAP connector if the application doesn't
+// have one yet, then a grant for the verified account.
+func connectIMAPAccount(ctx context.Context, client ports.NylasClient, name string, account *imapAccount) (*imapWizardResult, error) {
+	result := &imapWizardResult{}
+
+	connectors, err := client.ListConnectors(ctx)
+	if err != nil {
+		return nil, common.WrapListError("connectors", err)
+	}
+	for _, c := range connectors {
+		if c.Provider == string(domain.ProviderIMAP) {
+			result.Connector = &c
+			break
+		}
+	}
+	if result.Connector == nil {
+		cfg := &domain.MailServerConfig{IMAP: &account.IMAP, SMTP: account.SMTP}
+		if result.Connector, err = client.CreateConnector(ctx, imapConnectorRequest(name, cfg)); err != nil {
+			return nil, common.WrapCreateError("connector", err)
+		}
+		result.ConnectorCreated = true
+	}
+
+	settings := map[string]any{
+		"imap_username": account.Username,
+		"imap_password": account.Password,
+		"imap_host":     account.IMAP.Host,
+		"imap_port":     account.IMAP.Port,
+	}
+	if account.SMTP != nil {
+		settings["smtp_host"] = account.SMTP.Host
+		settings["smtp_port"] = account.SMTP.Port
+	}
+	if result.Grant, err = client.CreateCustomGrant(ctx, string(domain.ProviderIMAP), settings); err != nil {
+		return nil, common.WrapCreateError("grant", err)
+	}
+	return result, nil
+}
+
+func imapConnectorRequest(name string, cfg *domain.MailServerConfig) *domain.CreateConnectorRequest {
+	settings := &domain.ConnectorSettings{
+		IMAPHost:     cfg.IMAP.Host,
+		IMAPPort:     cfg.IMAP.Port,
+		IMAPSecurity: connectorSecurity(cfg.IMAP.TLSMode),
+	}
+	if cfg.SMTP != nil {
+		settings.SMTPHost = cfg.SMTP.Host
+		settings.SMTPPort = cfg.SMTP.Port
+		settings.SMTPSecurity = connectorSecurity(cfg.SMTP.TLSMode)
+	}
+	return &domain.CreateConnectorRequest{Name: name, Provider: string(domain.ProviderIMAP), Settings: settings}
+}
+
+// connectorSecurity maps a TLS mode to the connector's security setting.
+func connectorSecurity(mode domain.MailTLSMode) string {
+	if mode == domain.MailTLSImplicit {
+		return "ssl"
+	}
+	return string(mode)
+}
+
+// tlsModeForPort guesses the TLS mode of a manually entered port.
+func tlsModeForPort(port int) domain.MailTLSMode {
+	if port == 993 || port == 465 {
+		return domain.MailTLSImplicit
+	}
+	return domain.MailTLSStartTLS
+}
+
+func optionalServer(host string, port int) *domain.MailServer {
+	if host == "" {
+		return nil
+	}
+	return &domain.MailServer{Host: host, Port: port, TLSMode: tlsModeForPort(port)}
+}
+
+func describeMailServer(s *domain.MailServer) string {
+	return fmt.Sprintf("%s:%d (%s)", s.Host, s.Port, s.TLSMode)
+}
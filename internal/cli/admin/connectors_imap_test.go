@@ -0,0 +1,121 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// connectorsClient overrides the mock's fixed connector list.
+type connectorsClient struct {
+	*nylas.MockClient
+	connectors []domain.Connector
+	created    *domain.CreateConnectorRequest
+}
+
+func (c *connectorsClient) ListConnectors(context.Context) ([]domain.Connector, error) {
+	return c.connectors, nil
+}
+
+func (c *connectorsClient) CreateConnector(ctx context.Context, req *domain.CreateConnectorRequest) (*domain.Connector, error) {
+	c.created = req
+	return c.MockClient.CreateConnector(ctx, req)
+}
+
+func testIMAPAccount() *imapAccount {
+	return &imapAccount{
+		Username: "me@example.com",
+		Password: "hunter2",
+		IMAP:     domain.MailServer{Host: "imap.example.com", Port: 993, TLSMode: domain.MailTLSImplicit},
+		SMTP:     &domain.MailServer{Host: "smtp.example.com", Port: 587, TLSMode: domain.MailTLSStartTLS},
+	}
+}
+
+func TestConnectorCreateIMAPCmd_Routing(t *testing.T) {
+	cmd, _, err := newConnectorCreateCmd().Find([]string{"imap", "--wizard"})
+	require.NoError(t, err)
+	assert.Equal(t, "imap", cmd.Name())
+	assert.NotNil(t, cmd.Flags().Lookup("wizard"))
+	assert.NotNil(t, cmd.Flags().Lookup("email"))
+}
+
+func TestConnectIMAPAccount_CreatesConnectorAndGrant(t *testing.T) {
+	mock := nylas.NewMockClient()
+	var gotProvider string
+	var gotSettings map[string]any
+	mock.CreateCustomGrantFunc = func(_ context.Context, provider string, settings map[string]any) (*domain.Grant, error) {
+		gotProvider, gotSettings = provider, settings
+		return &domain.Grant{ID: "grant-1", Email: "me@example.com"}, nil
+	}
+	client := &connectorsClient{MockClient: mock}
+
+	result, err := connectIMAPAccount(context.Background(), client, "IMAP", testIMAPAccount())
+	require.NoError(t, err)
+
+	assert.True(t, result.ConnectorCreated)
+	require.NotNil(t, client.created)
+	assert.Equal(t, "imap", client.created.Provider)
+	assert.Equal(t, "ssl", client.created.Settings.IMAPSecurity)
+	assert.Equal(t, "starttls", client.created.Settings.SMTPSecurity)
+
+	assert.Equal(t, "imap", gotProvider)
+	assert.Equal(t, map[string]any{
+		"imap_username": "me@example.com",
+		"imap_password": "hunter2",
+		"imap_host":     "imap.example.com",
+		"imap_port":     993,
+		"smtp_host":     "smtp.example.com",
+		"smtp_port":     587,
+	}, gotSettings)
+	assert.Equal(t, "grant-1", result.Grant.ID)
+}
+
+func TestConnectIMAPAccount_ReusesConnector(t *testing.T) {
+	client := &connectorsClient{
+		MockClient: nylas.NewMockClient(),
+		connectors: []domain.Connector{{ID: "conn-imap", Provider: "imap"}},
+	}
+
+	result, err := connectIMAPAccount(context.Background(), client, "IMAP", testIMAPAccount())
+	require.NoError(t, err)
+	assert.False(t, result.ConnectorCreated)
+	assert.Nil(t, client.created)
+	assert.Equal(t, "conn-imap", result.Connector.ID)
+}
+
+func TestTestMailLogin(t *testing.T) {
+	account := testIMAPAccount()
+	var got domain.MailAuthOptions
+	run := func(_ context.Context, opts domain.MailAuthOptions) (*domain.MailAuthResult, error) {
+		got = opts
+		return &domain.MailAuthResult{Authenticated: opts.Token == "hunter2", ServerError: "NO [AUTHENTICATIONFAILED]"}, nil
+	}
+
+	require.NoError(t, testMailLogin(context.Background(), run, account.IMAP, account))
+	assert.Equal(t, domain.SASLPlain, got.Mechanism)
+	assert.Equal(t, domain.MailTLSImplicit, got.TLSMode)
+	assert.Equal(t, "me@example.com", got.Username)
+
+	account.Password = "wrong"
+	err := testMailLogin(context.Background(), run, account.IMAP, account)
+	assert.ErrorContains(t, err, "imap.example.com:993 (tls) rejected the login: NO [AUTHENTICATIONFAILED]")
+
+	failing := func(context.Context, domain.MailAuthOptions) (*domain.MailAuthResult, error) {
+		return &domain.MailAuthResult{}, errors.New("server does not advertise AUTH=PLAIN")
+	}
+	assert.ErrorContains(t, testMailLogin(context.Background(), failing, account.IMAP, account), "AUTH=PLAIN")
+}
+
+func TestTLSModeForPort(t *testing.T) {
+	assert.Equal(t, domain.MailTLSImplicit, tlsModeForPort(993))
+	assert.Equal(t, domain.MailTLSImplicit, tlsModeForPort(465))
+	assert.Equal(t, domain.MailTLSStartTLS, tlsModeForPort(587))
+	assert.Equal(t, "ssl", connectorSecurity(domain.MailTLSImplicit))
+	assert.Equal(t, "none", connectorSecurity(domain.MailTLSNone))
+}
@@ -0,0 +1,168 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/oauth"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newOAuthClientValidator creates the validator the wizard tests clients with.
+var newOAuthClientValidator = func() ports.OAuthClientValidator { return oauth.NewClientValidator() }
+
+// oauthAppConsoles says where each provider's redirect URIs are registered.
+var oauthAppConsoles = map[domain.Provider]string{
+	domain.ProviderGoogle:    "Google Cloud Console → APIs & Services → Credentials → your OAuth client → Authorized redirect URIs",
+	domain.ProviderMicrosoft: "Microsoft Entra admin center → App registrations → your app → Authentication → Web → Redirect URIs",
+}
+
+// runConnectorWizard walks through creating a Google, Microsoft or IMAP
+// connector. OAuth credentials are tested with the provider before the
+// connector is created.
+func runConnectorWizard(cmd *cobra.Command, name, provider string, scopes []string) error {
+	p := domain.Provider(strings.ToLower(provider))
+	if p == "" {
+		var err error
+		p, err = common.Select("Connector type", []common.SelectOption[domain.Provider]{
+			{Label: "Google (Gmail, Google Workspace)", Value: domain.ProviderGoogle},
+			{Label: "Microsoft (Outlook, Microsoft 365)", Value: domain.ProviderMicrosoft},
+			{Label: "IMAP (any other mail server)", Value: domain.ProviderIMAP},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	switch p {
+	case domain.ProviderIMAP:
+		if name == "" {
+			name = "IMAP"
+		}
+		return runIMAPWizard(cmd, &imapWizardOptions{name: name, imapPort: 993, smtpPort: 587})
+	case domain.ProviderGoogle, domain.ProviderMicrosoft:
+	default:
+		return common.NewUserError(fmt.Sprintf("--interactive doesn't support %s connectors", provider),
+			"Use --provider google, microsoft or imap, or create it with flags")
+	}
+
+	cfg, err := config.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil {
+		cfg = domain.DefaultConfig()
+	}
+	redirectURI := cfg.ConnectorRedirectURI()
+	fmt.Printf("\nRegister this redirect URI with your %s OAuth app:\n\n", p.DisplayName())
+	fmt.Printf("  %s\n\n", common.Cyan.Sprint(redirectURI))
+	_, _ = common.Dim.Printf("  %s\n\n", oauthAppConsoles[p])
+
+	check, err := promptOAuthClient(p, redirectURI)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = p.DisplayName()
+	}
+	req := &domain.CreateConnectorRequest{
+		Name:     name,
+		Provider: string(p),
+		Settings: &domain.ConnectorSettings{
+			ClientID:     check.ClientID,
+			ClientSecret: check.ClientSecret,
+			Tenant:       check.Tenant,
+		},
+		Scopes: scopes,
+	}
+
+	connector, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (*domain.Connector, error) {
+		connector, err := client.CreateConnector(ctx, req)
+		if err != nil {
+			return nil, common.WrapCreateError("connector", err)
+		}
+		return connector, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(connector)
+	}
+	common.PrintSuccess("Created connector: %s", connector.Name)
+	fmt.Printf("  ID:           %s\n", common.Cyan.Sprint(connector.ID))
+	fmt.Printf("  Provider:     %s\n", connector.Provider)
+	fmt.Printf("  Redirect URI: %s\n", redirectURI)
+	fmt.Printf("\nConnect an account with: nylas auth login --provider %s\n", p)
+	return nil
+}
+
+// promptOAuthClient asks for the OAuth client until the provider accepts
+// it. When the provider can't be reached, the user decides whether to go on
+// without the check.
+func promptOAuthClient(p domain.Provider, redirectURI string) (domain.OAuthClientCheck, error) {
+	validator := newOAuthClientValidator()
+	check := domain.OAuthClientCheck{Provider: p, RedirectURI: redirectURI}
+
+	for attempt := 1; ; attempt++ {
+		var err error
+		if check.ClientID, err = common.InputPrompt("Client ID", check.ClientID); err != nil {
+			return check, err
+		}
+		if check.ClientSecret, err = common.PasswordPrompt("Client secret"); err != nil {
+			return check, err
+		}
+		check.ClientID, check.ClientSecret = strings.TrimSpace(check.ClientID), strings.TrimSpace(check.ClientSecret)
+		if check.ClientID == "" || check.ClientSecret == "" {
+			return check, common.NewUserError("a client ID and secret are required",
+				"Create an OAuth client in the provider's console and run the wizard in an interactive terminal")
+		}
+		if p == domain.ProviderMicrosoft {
+			tenant := check.Tenant
+			if tenant == "" {
+				tenant = "common"
+			}
+			if tenant, err = common.InputPrompt("Tenant (common, organizations, or your tenant ID)", tenant); err != nil {
+				return check, err
+			}
+			check.Tenant = strings.TrimSpace(tenant)
+		}
+
+		err = common.RunWithSpinner("Testing the client with "+p.DisplayName()+"...", func() error {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+			return validator.ValidateClient(ctx, check)
+		})
+		if err == nil {
+			common.PrintSuccess("%s accepted the client", p.DisplayName())
+			return check, nil
+		}
+
+		if !errors.Is(err, domain.ErrOAuthClientRejected) {
+			common.PrintWarning("Could not test the client: %v", err)
+			ok, promptErr := common.ConfirmPrompt("Create the connector without the check?", false)
+			if promptErr != nil {
+				return check, promptErr
+			}
+			if !ok {
+				return check, common.NewUserError("connector not created", "Run the wizard again once the provider is reachable")
+			}
+			return check, nil
+		}
+
+		common.PrintError("%v", err)
+		if attempt == maxLoginAttempts {
+			return check, common.NewUserError(
+				fmt.Sprintf("%s rejected the client %d times", p.DisplayName(), attempt),
+				"Check the client ID and secret, and that "+redirectURI+" is registered with the app",
+			)
+		}
+		fmt.Println("Enter the credentials again.")
+	}
+}
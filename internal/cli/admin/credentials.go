@@ -2,7 +2,6 @@ package admin
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -70,8 +69,8 @@ func newCredentialsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/connector-credentials/`,
 	}
 
-	cmd.AddCommand(newCredentialListCmd())
-	cmd.AddCommand(newCredentialShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newCredentialListCmd(), []domain.ConnectorCredential{}))
+	cmd.AddCommand(common.DeclareOutput(newCredentialShowCmd(), domain.ConnectorCredential{}))
 	cmd.AddCommand(newCredentialCreateCmd())
 	cmd.AddCommand(newCredentialUpdateCmd())
 	cmd.AddCommand(newCredentialDeleteCmd())
@@ -101,8 +100,8 @@ func newCredentialListCmd() *cobra.Command {
 					return struct{}{}, common.WrapListError("credentials", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(credentials)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(credentials)
 				}
 
 				if len(credentials) == 0 {
@@ -146,8 +145,8 @@ func newCredentialShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("credential", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(credential)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(credential)
 				}
 
 				_, _ = common.Bold.Printf("Credential: %s\n", credential.Name)
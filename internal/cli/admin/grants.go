@@ -2,8 +2,8 @@ package admin
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/domain"
@@ -21,8 +21,9 @@ func newGrantsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/manage-grants/`,
 	}
 
-	cmd.AddCommand(newGrantListCmd())
-	cmd.AddCommand(newGrantStatsCmd())
+	cmd.AddCommand(common.DeclareOutput(newGrantListCmd(), []domain.Grant{}, common.Page[domain.Grant]{}))
+	cmd.AddCommand(common.RunLocally(common.DeclareOutput(newGrantStatsCmd(), domain.GrantStats{}), "watch"))
+	cmd.AddCommand(common.DeclareOutput(newGrantProvisionCmd(), []provisionResult{}))
 
 	return cmd
 }
@@ -33,29 +34,54 @@ func newGrantListCmd() *cobra.Command {
 		offset      int
 		connectorID string
 		status      string
+		pages       *common.PageSelection
 	)
 
 	cmd := &cobra.Command{
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List grants",
-		Long:    "List all grants with optional filters.",
+		Long: `List all grants with optional filters.
+
+Use --all to fetch every grant, up to --max (10000 by default; 0 for no cap).
+Use --page-token to fetch one page at a time: an empty token starts at the
+first page, and the token of the next page is printed after the list, or
+returned as next_cursor with --json.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
-				params := &domain.GrantsQueryParams{
-					Limit:       limit,
-					Offset:      offset,
-					ConnectorID: connectorID,
-					Status:      status,
+			params := &domain.GrantsQueryParams{
+				Limit:       limit,
+				Offset:      offset,
+				ConnectorID: connectorID,
+				Status:      status,
+			}
+			if pages.All {
+				params.Limit = pages.Max
+			}
+			if pages.Cursor() {
+				pageOffset, err := parseGrantPageToken(pages.Token)
+				if err != nil {
+					return err
 				}
+				params.Offset = pageOffset
+			}
 
+			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
 				grants, err := client.ListAllGrants(ctx, params)
 				if err != nil {
 					return struct{}{}, common.WrapListError("grants", err)
 				}
+				pages.WarnCapped(len(grants))
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(grants)
+				next := ""
+				if pages.Cursor() {
+					next = nextGrantPageToken(params, len(grants))
+				}
+
+				if pages.Cursor() && common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.WritePage(cmd, grants, next)
+				}
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(grants)
 				}
 
 				if len(grants) == 0 {
@@ -85,6 +111,7 @@ func newGrantListCmd() *cobra.Command {
 					table.AddRow(common.Cyan.Sprint(email), grant.ID, string(grant.Provider), status)
 				}
 				table.Render()
+				common.PrintNextPage(cmd, next)
 
 				return struct{}{}, nil
 			})
@@ -96,61 +123,30 @@ func newGrantListCmd() *cobra.Command {
 	cmd.Flags().IntVar(&offset, "offset", 0, "Offset for pagination")
 	cmd.Flags().StringVar(&connectorID, "connector-id", "", "Filter by connector ID")
 	cmd.Flags().StringVar(&status, "status", "", "Filter by status (valid, invalid)")
+	pages = common.AddPageSelectionFlags(cmd, "grants")
+	cmd.MarkFlagsMutuallyExclusive("offset", "page-token")
 
 	return cmd
 }
 
-func newGrantStatsCmd() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "stats",
-		Short: "Show grant statistics",
-		Long:  "Show statistics about all grants in the organization.",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
-				stats, err := client.GetGrantStats(ctx)
-				if err != nil {
-					return struct{}{}, common.WrapGetError("grant stats", err)
-				}
-
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(stats)
-				}
-
-				_, _ = common.Bold.Println("Grant Statistics")
-				fmt.Printf("  Total Grants: %s\n", common.Cyan.Sprintf("%d", stats.Total))
-				fmt.Printf("  Valid: %s\n", common.Green.Sprintf("%d", stats.Valid))
-				fmt.Printf("  Invalid: %s\n", common.Red.Sprintf("%d", stats.Invalid))
-
-				if len(stats.ByProvider) > 0 {
-					fmt.Printf("\nBy Provider:\n")
-					table := common.NewTable("PROVIDER", "COUNT")
-					for provider, count := range stats.ByProvider {
-						table.AddRow(common.Green.Sprint(provider), fmt.Sprintf("%d", count))
-					}
-					table.Render()
-				}
-
-				if len(stats.ByStatus) > 0 {
-					fmt.Printf("\nBy Status:\n")
-					table := common.NewTable("STATUS", "COUNT")
-					for status, count := range stats.ByStatus {
-						statusColor := common.Yellow
-						switch status {
-						case "valid":
-							statusColor = common.Green
-						case "invalid":
-							statusColor = common.Red
-						}
-						table.AddRow(statusColor.Sprint(status), fmt.Sprintf("%d", count))
-					}
-					table.Render()
-				}
-
-				return struct{}{}, nil
-			})
-			return err
-		},
+// parseGrantPageToken returns the offset of a grants page token. The grants
+// endpoint pages by offset, so the token is the offset of the page.
+func parseGrantPageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(token)
+	if err != nil || offset < 0 {
+		return 0, common.NewUserError("invalid page token: "+token, "Use the token printed by the previous 'nylas admin grants list'")
 	}
+	return offset, nil
+}
 
-	return cmd
+// nextGrantPageToken returns the token of the page after one of n grants, or
+// "" when the page was not full and so was the last.
+func nextGrantPageToken(params *domain.GrantsQueryParams, n int) string {
+	if params.Limit <= 0 || n < params.Limit {
+		return ""
+	}
+	return strconv.Itoa(params.Offset + n)
 }
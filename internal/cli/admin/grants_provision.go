@@ -0,0 +1,331 @@
+package admin
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/invitations"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// openProvisioningStore opens the local log of provisioned users. Replaced
+// in tests.
+var openProvisioningStore = func() (ports.InvitationStore, error) {
+	return invitations.New(filepath.Join(config.DefaultConfigDir(), "provisioning.json")), nil
+}
+
+// Provisioning outcomes for one user.
+const (
+	provisionPending   = "pending"   // hosted-auth link created, waiting for the user
+	provisionConnected = "connected" // a valid grant already existed
+	provisionCreated   = "created"   // grant created through custom auth
+	provisionFailed    = "failed"
+)
+
+// provisionResult is one row of a provisioning run, as exported with
+// --output csv or --json.
+type provisionResult struct {
+	Email    string `json:"email"`
+	Name     string `json:"name,omitempty"`
+	Provider string `json:"provider"`
+	Status   string `json:"status"`
+	AuthURL  string `json:"auth_url,omitempty"`
+	GrantID  string `json:"grant_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+	state    string
+}
+
+// provisionOptions say how users without a grant are connected: a
+// hosted-auth link returning to redirectURI, or a custom-auth grant using
+// the service account or admin consent behind credentialID.
+type provisionOptions struct {
+	provider     domain.Provider
+	redirectURI  string
+	credentialID string
+}
+
+func newGrantProvisionCmd() *cobra.Command {
+	var (
+		filePath     string
+		connector    string
+		redirectURI  string
+		credentialID string
+		mappingPath  string
+		yes          bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "provision",
+		Short: "Connect a CSV of users to one connector",
+		Long: `Read a CSV of users (an email column and an optional name column) and
+connect each of them through --connector.
+
+By default every user gets a hosted-auth link with their email as the login
+hint; --redirect-uri must be one of the application's callback URIs. With
+--credential-id, grants are created directly through custom auth instead,
+using a Google service account or Microsoft admin consent credential (see
+'nylas admin credentials list'); no user action is needed.
+
+Users who already have a valid grant are reported as connected and skipped.
+Every user is recorded locally in provisioning.json in the config directory;
+track who completed auth with 'nylas admin grants provision status'. Use
+--output csv or --json to export the results.
+
+To pick a connector per user from their domain's MX records, see
+'nylas admin onboarding plan'.`,
+		Example: `  # Create hosted-auth links and export them
+  nylas admin grants provision --file users.csv --connector google \
+    --redirect-uri https://app.example.com/callback --output csv > links.csv
+
+  # Create grants for a Google Workspace service account
+  nylas admin grants provision --file users.csv --connector google --credential-id <credential-id>
+
+  # Check completion
+  nylas admin grants provision status`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateRequiredFlag("--file", filePath); err != nil {
+				return err
+			}
+			opts, err := parseProvisionOptions(connector, redirectURI, credentialID)
+			if err != nil {
+				return err
+			}
+
+			records, err := common.ReadCSVFile(filePath, mappingPath, onboardingFields)
+			if err != nil {
+				return err
+			}
+			if len(records) == 0 {
+				return common.NewUserError("no users found in "+filePath, "The file needs an email column with one user per row")
+			}
+			store, err := openProvisioningStore()
+			if err != nil {
+				return err
+			}
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContext()
+			grants, err := client.ListGrants(ctx)
+			cancel()
+			if err != nil {
+				return common.WrapListError("grants", err)
+			}
+			results := planProvisioning(records, grants, opts.provider)
+
+			pending := countProvisionStatus(results, provisionPending)
+			if opts.credentialID != "" && pending > 0 && !yes &&
+				!common.Confirm(fmt.Sprintf("Create %d %s grant(s)?", pending, opts.provider.DisplayName()), false) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+			provisionUsers(client, opts, results)
+
+			if err := recordProvisioning(store, results); err != nil {
+				return err
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				if err := common.GetOutputWriter(cmd).Write(results); err != nil {
+					return err
+				}
+			} else {
+				printProvisionResults(results)
+			}
+			if failed := countProvisionStatus(results, provisionFailed); failed == len(results) {
+				return common.NewUserError("no users were provisioned", "Check the errors above and the connector settings")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&filePath, "file", "f", "", "CSV of users with an email column and optional name column (required)")
+	cmd.Flags().StringVar(&connector, "connector", "", "Connector to connect the users through: google, microsoft, ews, imap, icloud or yahoo (required)")
+	cmd.Flags().StringVar(&redirectURI, "redirect-uri", "", "Callback URI the hosted-auth links return to")
+	cmd.Flags().StringVar(&credentialID, "credential-id", "", "Create grants through custom auth with this connector credential instead of links")
+	cmd.Flags().StringVar(&mappingPath, "mapping", "", "YAML file mapping CSV headers to email/name")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
+
+	cmd.AddCommand(common.RunLocally(common.DeclareOutput(newCompletionStatusCmd(openProvisioningStore, "nylas admin grants provision",
+		"Create some with: nylas admin grants provision --file users.csv ..."), inviteStatus{}), "watch"))
+
+	return cmd
+}
+
+func parseProvisionOptions(connector, redirectURI, credentialID string) (provisionOptions, error) {
+	if err := common.ValidateRequiredFlag("--connector", connector); err != nil {
+		return provisionOptions{}, err
+	}
+	provider, err := domain.ParseProvider(strings.ToLower(connector))
+	if err != nil || provider == domain.ProviderVirtual || provider == domain.ProviderNylas {
+		return provisionOptions{}, common.NewInputError(fmt.Sprintf("invalid --connector %q: use google, microsoft, ews, imap, icloud or yahoo", connector))
+	}
+	opts := provisionOptions{provider: provider, redirectURI: redirectURI, credentialID: credentialID}
+
+	if credentialID != "" {
+		if redirectURI != "" {
+			return opts, common.NewMutuallyExclusiveError("credential-id", "redirect-uri")
+		}
+		if provider != domain.ProviderGoogle && provider != domain.ProviderMicrosoft {
+			return opts, common.NewUserError(
+				fmt.Sprintf("%s grants can't be created from a credential", provider.DisplayName()),
+				"Use --credential-id with --connector google or microsoft, or send hosted-auth links with --redirect-uri",
+			)
+		}
+		return opts, nil
+	}
+
+	if redirectURI == "" {
+		return opts, common.NewUserError("choose how to connect the users",
+			"Use --redirect-uri to create hosted-auth links, or --credential-id to create grants through custom auth")
+	}
+	if u, err := url.Parse(redirectURI); err != nil || u.Scheme == "" || u.Host == "" {
+		return opts, common.NewInputError(fmt.Sprintf("invalid --redirect-uri %q: must be an absolute URL", redirectURI))
+	}
+	return opts, nil
+}
+
+// planProvisioning turns CSV rows into results: users with a valid grant are
+// connected, the rest are pending. Invalid rows fail and duplicate emails are
+// dropped.
+func planProvisioning(records []common.CSVRecord, grants []domain.Grant, provider domain.Provider) []provisionResult {
+	seen := make(map[string]bool)
+	results := make([]provisionResult, 0, len(records))
+	var users []domain.Invitation
+
+	for _, rec := range records {
+		email := strings.ToLower(strings.TrimSpace(rec.Fields["email"]))
+		if seen[email] && email != "" {
+			continue
+		}
+		seen[email] = true
+		result := provisionResult{Email: email, Name: rec.Fields["name"], Provider: string(provider), Status: provisionPending}
+		if err := common.ValidateEmail("email", email); err != nil {
+			result.Status = provisionFailed
+			result.Error = fmt.Sprintf("line %d: invalid email %q", rec.Line, email)
+		}
+		results = append(results, result)
+		users = append(users, domain.Invitation{Email: email})
+	}
+
+	domain.MatchGrants(users, grants)
+	for i := range results {
+		if results[i].Status == provisionPending && users[i].Completed() {
+			results[i].Status = provisionConnected
+			results[i].GrantID = users[i].GrantID
+		}
+	}
+	return results
+}
+
+// provisionUsers connects each pending user: a hosted-auth link, or a
+// custom-auth grant when opts has a credential.
+func provisionUsers(client ports.NylasClient, opts provisionOptions, results []provisionResult) {
+	for i := range results {
+		r := &results[i]
+		if r.Status != provisionPending {
+			continue
+		}
+
+		if opts.credentialID == "" {
+			r.state = newOnboardingState()
+			r.AuthURL = client.BuildAuthURLWithOptions(opts.provider, opts.redirectURI, r.state, "",
+				domain.AuthURLOptions{LoginHint: r.Email})
+			continue
+		}
+
+		ctx, cancel := common.CreateContext()
+		grant, err := client.CreateCustomGrant(ctx, string(opts.provider), map[string]any{
+			"credential_id": opts.credentialID,
+			"email_address": r.Email,
+		})
+		cancel()
+		if err != nil {
+			r.Status = provisionFailed
+			r.Error = err.Error()
+			continue
+		}
+		r.Status = provisionCreated
+		r.GrantID = grant.ID
+	}
+}
+
+// recordProvisioning saves every provisioned user so 'provision status' can
+// track them.
+func recordProvisioning(store ports.InvitationStore, results []provisionResult) error {
+	now := time.Now()
+	records := make([]domain.Invitation, 0, len(results))
+	for _, r := range results {
+		if r.Status == provisionFailed {
+			continue
+		}
+		inv := domain.Invitation{
+			Email:    r.Email,
+			Name:     r.Name,
+			Provider: r.Provider,
+			State:    r.state,
+			SentAt:   now,
+			GrantID:  r.GrantID,
+		}
+		if inv.Completed() {
+			inv.CompletedAt = now
+		}
+		records = append(records, inv)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := store.Save(records...); err != nil {
+		return common.WrapSaveError("provisioning log", err)
+	}
+	return nil
+}
+
+func countProvisionStatus(results []provisionResult, status string) int {
+	n := 0
+	for _, r := range results {
+		if r.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func printProvisionResults(results []provisionResult) {
+	table := common.NewTable("EMAIL", "STATUS", "AUTH LINK / GRANT")
+	for _, r := range results {
+		switch r.Status {
+		case provisionPending:
+			table.AddRow(r.Email, common.Yellow.Sprint(r.Status), r.AuthURL)
+		case provisionFailed:
+			table.AddRow(r.Email, common.Red.Sprint(r.Status), r.Error)
+		default:
+			table.AddRow(r.Email, common.Green.Sprint(r.Status), r.GrantID)
+		}
+	}
+	table.Render()
+
+	fmt.Println()
+	fmt.Printf("%d link(s), %d grant(s) created, %d already connected",
+		countProvisionStatus(results, provisionPending),
+		countProvisionStatus(results, provisionCreated),
+		countProvisionStatus(results, provisionConnected))
+	if failed := countProvisionStatus(results, provisionFailed); failed > 0 {
+		fmt.Printf(", %d failed", failed)
+	}
+	fmt.Println()
+	if countProvisionStatus(results, provisionPending) > 0 {
+		fmt.Println("\nTrack completion with: nylas admin grants provision status")
+	}
+}
@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestParseProvisionOptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		connector    string
+		redirectURI  string
+		credentialID string
+		wantErr      string
+	}{
+		{name: "links", connector: "google", redirectURI: "https://app.example.com/cb"},
+		{name: "service account", connector: "Google", credentialID: "cred-1"},
+		{name: "admin consent", connector: "microsoft", credentialID: "cred-1"},
+		{name: "no connector", redirectURI: "https://app.example.com/cb", wantErr: "--connector"},
+		{name: "unknown connector", connector: "aol", redirectURI: "https://app.example.com/cb", wantErr: "invalid --connector"},
+		{name: "no mode", connector: "google", wantErr: "choose how to connect"},
+		{name: "both modes", connector: "google", redirectURI: "https://app.example.com/cb", credentialID: "cred-1", wantErr: "cannot specify both"},
+		{name: "credential for imap", connector: "imap", credentialID: "cred-1", wantErr: "can't be created from a credential"},
+		{name: "relative redirect", connector: "google", redirectURI: "/cb", wantErr: "must be an absolute URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseProvisionOptions(tt.connector, tt.redirectURI, tt.credentialID)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPlanProvisioning(t *testing.T) {
+	records := []common.CSVRecord{
+		{Line: 2, Fields: map[string]string{"email": "Ana@Acme.com", "name": "Ana"}},
+		{Line: 3, Fields: map[string]string{"email": "bob@acme.com"}},
+		{Line: 4, Fields: map[string]string{"email": "ana@acme.com"}},
+		{Line: 5, Fields: map[string]string{"email": "nope"}},
+	}
+	grants := []domain.Grant{{ID: "g-ana", Email: "ana@acme.com", GrantStatus: "valid"}}
+
+	results := planProvisioning(records, grants, domain.ProviderGoogle)
+
+	require.Len(t, results, 3, "duplicate emails are dropped")
+	assert.Equal(t, provisionResult{Email: "ana@acme.com", Name: "Ana", Provider: "google", Status: provisionConnected, GrantID: "g-ana"}, results[0])
+	assert.Equal(t, provisionPending, results[1].Status)
+	assert.Equal(t, provisionFailed, results[2].Status)
+	assert.Contains(t, results[2].Error, "line 5")
+}
+
+func TestProvisionUsers_Links(t *testing.T) {
+	client := nylas.NewMockClient()
+	results := []provisionResult{
+		{Email: "ana@acme.com", Status: provisionPending},
+		{Email: "bob@acme.com", Status: provisionConnected, GrantID: "g-bob"},
+	}
+
+	provisionUsers(client, provisionOptions{provider: domain.ProviderGoogle, redirectURI: "https://app.example.com/cb"}, results)
+
+	assert.Equal(t, "https://mock.nylas.com/auth", results[0].AuthURL)
+	assert.NotEmpty(t, results[0].state)
+	assert.Equal(t, "https://app.example.com/cb", client.LastRedirectURI)
+	assert.Equal(t, domain.AuthURLOptions{LoginHint: "ana@acme.com"}, client.LastAuthURLOptions)
+	assert.Empty(t, results[1].AuthURL, "connected users get no link")
+}
+
+func TestProvisionUsers_CustomAuth(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.CreateCustomGrantFunc = func(_ context.Context, provider string, settings map[string]any) (*domain.Grant, error) {
+		assert.Equal(t, "google", provider)
+		assert.Equal(t, "cred-1", settings["credential_id"])
+		if settings["email_address"] == "bob@acme.com" {
+			return nil, errors.New("user not in domain")
+		}
+		return &domain.Grant{ID: "g-" + settings["email_address"].(string)}, nil
+	}
+	results := []provisionResult{
+		{Email: "ana@acme.com", Status: provisionPending},
+		{Email: "bob@acme.com", Status: provisionPending},
+	}
+
+	provisionUsers(client, provisionOptions{provider: domain.ProviderGoogle, credentialID: "cred-1"}, results)
+
+	assert.Equal(t, provisionCreated, results[0].Status)
+	assert.Equal(t, "g-ana@acme.com", results[0].GrantID)
+	assert.Equal(t, provisionFailed, results[1].Status)
+	assert.Equal(t, "user not in domain", results[1].Error)
+}
+
+func TestRecordProvisioning(t *testing.T) {
+	store := newTestInvitationStore(t)
+
+	require.NoError(t, recordProvisioning(store, []provisionResult{
+		{Email: "ana@acme.com", Provider: "google", Status: provisionPending, state: "s1"},
+		{Email: "bob@acme.com", Provider: "google", Status: provisionCreated, GrantID: "g-bob"},
+		{Email: "cy@acme.com", Provider: "google", Status: provisionFailed, Error: "boom"},
+	}))
+
+	recorded, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, recorded, 2, "failed users are not recorded")
+	assert.Equal(t, "s1", recorded[0].State)
+	assert.False(t, recorded[0].Completed())
+	assert.True(t, recorded[1].Completed())
+	assert.False(t, recorded[1].CompletedAt.IsZero())
+}
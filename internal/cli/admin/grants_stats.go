@@ -0,0 +1,268 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// grantPeriod is the number of grants created in one day, week or month.
+type grantPeriod struct {
+	Start string
+	Count int
+}
+
+func newGrantStatsCmd() *cobra.Command {
+	var (
+		by      string
+		periods int
+		watch   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show grant statistics",
+		Long: `Show statistics about all grants in the organization: totals, a breakdown
+by provider and status, and how many grants were created per day, week or
+month (--by), drawn as a sparkline over the last --periods periods.
+
+--watch refreshes the statistics on an interval until you press Ctrl+C.`,
+		Example: `  nylas admin grants stats
+  nylas admin grants stats --by week --periods 26
+  nylas admin grants stats --watch 5m
+  nylas admin grants stats --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if by != "day" && by != "week" && by != "month" {
+				return common.NewInputError(fmt.Sprintf("invalid --by %q: use day, week or month", by))
+			}
+			if periods <= 0 {
+				return common.NewInputError("--periods must be positive")
+			}
+
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+			show := func() error {
+				ctx, cancel := common.CreateContext()
+				defer cancel()
+				stats, err := client.GetGrantStats(ctx)
+				if err != nil {
+					return common.WrapGetError("grant stats", err)
+				}
+				if common.IsStructuredOutput(cmd) {
+					return common.GetOutputWriter(cmd).Write(stats)
+				}
+				printGrantStats(stats, groupGrantsCreated(stats.CreatedByDay, by, periods, time.Now().UTC()), by)
+				return nil
+			}
+
+			if err := show(); err != nil {
+				return err
+			}
+			if watch <= 0 {
+				return nil
+			}
+			return watchGrantStats(cmd, watch, show)
+		},
+	}
+
+	cmd.Flags().StringVar(&by, "by", "month", "Group grant creation by day, week or month")
+	cmd.Flags().IntVar(&periods, "periods", 12, "Number of creation periods to show")
+	cmd.Flags().DurationVar(&watch, "watch", 0, "Refresh on this interval until interrupted")
+
+	return cmd
+}
+
+// watchGrantStats calls show every interval until interrupted, clearing the
+// screen first when the statistics go to a terminal.
+func watchGrantStats(cmd *cobra.Command, interval time.Duration, show func() error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	redraw := !common.IsStructuredOutput(cmd) && !common.IsQuiet()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if redraw {
+			fmt.Print("\033[H\033[2J")
+			_, _ = common.Dim.Printf("Updated %s, every %s (Ctrl+C to stop)\n\n", time.Now().Format(time.TimeOnly), interval)
+		}
+		if err := show(); err != nil {
+			common.PrintWarningStderr("Refresh failed: %v", err)
+		}
+	}
+}
+
+// groupGrantsCreated sums daily creation counts into the last n days, weeks
+// (starting Monday) or months, ending with the one containing now. Empty
+// periods are included so the sparkline keeps its time scale.
+func groupGrantsCreated(byDay map[string]int, by string, n int, now time.Time) []grantPeriod {
+	start := periodStart(now, by)
+	out := make([]grantPeriod, n)
+	index := make(map[string]int, n)
+	for i := n - 1; i >= 0; i-- {
+		key := start.Format(periodLayout(by))
+		out[i] = grantPeriod{Start: key}
+		index[key] = i
+		start = previousPeriod(start, by)
+	}
+
+	for day, count := range byDay {
+		t, err := time.Parse(time.DateOnly, day)
+		if err != nil {
+			continue
+		}
+		if i, ok := index[periodStart(t, by).Format(periodLayout(by))]; ok {
+			out[i].Count += count
+		}
+	}
+	return out
+}
+
+func periodStart(t time.Time, by string) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch by {
+	case "week":
+		return t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+	case "month":
+		return t.AddDate(0, 0, 1-t.Day())
+	default:
+		return t
+	}
+}
+
+func previousPeriod(t time.Time, by string) time.Time {
+	switch by {
+	case "week":
+		return t.AddDate(0, 0, -7)
+	case "month":
+		return t.AddDate(0, -1, 0)
+	default:
+		return t.AddDate(0, 0, -1)
+	}
+}
+
+func periodLayout(by string) string {
+	if by == "month" {
+		return "2006-01"
+	}
+	return time.DateOnly
+}
+
+// sparkline draws values as block characters scaled to the largest value.
+func sparkline(values []int) string {
+	const blocks = "▁▂▃▄▅▆▇█"
+	levels := []rune(blocks)
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if peak > 0 {
+			level = v * (len(levels) - 1) / peak
+		}
+		b.WriteRune(levels[level])
+	}
+	return b.String()
+}
+
+// sortedCounts returns the keys of counts, largest count first.
+func sortedCounts(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func grantStatusColor(status string) string {
+	switch status {
+	case "valid":
+		return common.Green.Sprint(status)
+	case "invalid":
+		return common.Red.Sprint(status)
+	default:
+		return common.Yellow.Sprint(status)
+	}
+}
+
+func printGrantStats(stats *domain.GrantStats, created []grantPeriod, by string) {
+	_, _ = common.Bold.Println("Grant Statistics")
+	fmt.Printf("  Total Grants: %s\n", common.Cyan.Sprintf("%d", stats.Total))
+	fmt.Printf("  Valid: %s\n", common.Green.Sprintf("%d", stats.Valid))
+	fmt.Printf("  Invalid: %s\n", common.Red.Sprintf("%d", stats.Invalid))
+
+	if len(stats.ByProvider) > 0 {
+		fmt.Printf("\nBy Provider:\n")
+		statuses := sortedCounts(stats.ByStatus)
+		headers := []string{"PROVIDER", "COUNT"}
+		if len(stats.ByProviderStatus) > 0 {
+			for _, status := range statuses {
+				headers = append(headers, strings.ToUpper(status))
+			}
+		}
+		headers = append(headers, "SHARE")
+		table := common.NewTable(headers...)
+		for _, provider := range sortedCounts(stats.ByProvider) {
+			count := stats.ByProvider[provider]
+			row := []string{common.Green.Sprint(provider), fmt.Sprintf("%d", count)}
+			if len(stats.ByProviderStatus) > 0 {
+				for _, status := range statuses {
+					row = append(row, fmt.Sprintf("%d", stats.ByProviderStatus[provider][status]))
+				}
+			}
+			row = append(row, fmt.Sprintf("%3d%% %s", count*100/max(stats.Total, 1), progressBar(count, stats.Total, 20)))
+			table.AddRow(row...)
+		}
+		table.Render()
+	}
+
+	if len(stats.ByStatus) > 0 {
+		fmt.Printf("\nBy Status:\n")
+		table := common.NewTable("STATUS", "COUNT")
+		for _, status := range sortedCounts(stats.ByStatus) {
+			table.AddRow(grantStatusColor(status), fmt.Sprintf("%d", stats.ByStatus[status]))
+		}
+		table.Render()
+	}
+
+	if len(stats.CreatedByDay) == 0 || len(created) == 0 {
+		return
+	}
+	values := make([]int, len(created))
+	total := 0
+	for i, p := range created {
+		values[i] = p.Count
+		total += p.Count
+	}
+	fmt.Printf("\nCreated per %s (last %d): %s  %d total\n", by, len(created), common.Cyan.Sprint(sparkline(values)), total)
+	table := common.NewTable(strings.ToUpper(by), "CREATED")
+	for _, p := range created {
+		table.AddRow(p.Start, fmt.Sprintf("%d", p.Count))
+	}
+	table.Render()
+}
@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupGrantsCreated(t *testing.T) {
+	byDay := map[string]int{
+		"2026-01-15": 2,
+		"2026-03-02": 1, // Monday
+		"2026-03-04": 3,
+		"2026-03-08": 1, // Sunday
+		"2025-01-01": 9, // out of range
+	}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []grantPeriod{
+		{Start: "2026-01", Count: 2},
+		{Start: "2026-02"},
+		{Start: "2026-03", Count: 5},
+	}, groupGrantsCreated(byDay, "month", 3, now))
+
+	assert.Equal(t, []grantPeriod{
+		{Start: "2026-03-02", Count: 5},
+		{Start: "2026-03-09"},
+	}, groupGrantsCreated(byDay, "week", 2, now))
+
+	assert.Equal(t, []grantPeriod{
+		{Start: "2026-03-08", Count: 1},
+		{Start: "2026-03-09"},
+		{Start: "2026-03-10"},
+	}, groupGrantsCreated(byDay, "day", 3, now))
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▄█", sparkline([]int{0, 4, 8}))
+	assert.Equal(t, "▁▁", sparkline([]int{0, 0}))
+}
+
+func TestSortedCounts(t *testing.T) {
+	assert.Equal(t, []string{"google", "imap", "microsoft"},
+		sortedCounts(map[string]int{"microsoft": 1, "google": 5, "imap": 1}))
+}
@@ -0,0 +1,356 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/invitations"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// openInvitationStore opens the local invitation log. Replaced in tests.
+var openInvitationStore = func() (ports.InvitationStore, error) {
+	return invitations.New(filepath.Join(config.DefaultConfigDir(), "invitations.json")), nil
+}
+
+const (
+	defaultInviteSubject  = "Connect your {{.ProviderName}} account"
+	defaultInviteTemplate = `<p>Hi{{with .Name}} {{.}}{{end}},</p>
+<p>Please connect your {{.ProviderName}} account ({{.Email}}) so we can get you set up.</p>
+<p><a href="{{.AuthURL}}">Connect your account</a></p>
+<p>The link signs you in with your provider; we never see your password.</p>
+`
+)
+
+// inviteData is what invitation subject and body templates can use.
+type inviteData struct {
+	Name         string
+	Email        string
+	Provider     string
+	ProviderName string
+	AuthURL      string
+}
+
+// inviteSender renders and sends invitations, either through a transactional
+// sending domain or from a connected mailbox.
+type inviteSender struct {
+	client  ports.NylasClient
+	domain  string // transactional sending domain
+	from    string // sender address on domain
+	grantID string // or: mailbox to send from
+	subject *template.Template
+	body    *htmltemplate.Template
+}
+
+func newInviteSender(client ports.NylasClient, domainName, from, grantID, subject, body string) (*inviteSender, error) {
+	subjectTmpl, err := template.New("subject").Option("missingkey=error").Parse(subject)
+	if err != nil {
+		return nil, common.NewInputError(fmt.Sprintf("invalid --subject template: %v", err))
+	}
+	bodyTmpl, err := htmltemplate.New("body").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, common.NewUserError(fmt.Sprintf("invalid invitation template: %v", err),
+			"Template fields: {{.Name}} {{.Email}} {{.Provider}} {{.ProviderName}} {{.AuthURL}}")
+	}
+	return &inviteSender{client: client, domain: domainName, from: from, grantID: grantID, subject: subjectTmpl, body: bodyTmpl}, nil
+}
+
+// render builds the invitation email for a planned user.
+func (s *inviteSender) render(u onboardingUser) (*domain.SendMessageRequest, error) {
+	data := inviteData{
+		Name:         u.Name,
+		Email:        u.Email,
+		Provider:     u.Provider,
+		ProviderName: domain.Provider(u.Provider).DisplayName(),
+		AuthURL:      u.AuthURL,
+	}
+	var subject, body strings.Builder
+	if err := s.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("render subject: %w", err)
+	}
+	if err := s.body.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("render body: %w", err)
+	}
+	req := &domain.SendMessageRequest{
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+		To:      []domain.EmailParticipant{{Name: u.Name, Email: u.Email}},
+	}
+	if s.from != "" {
+		req.From = []domain.EmailParticipant{{Email: s.from}}
+	}
+	return req, nil
+}
+
+// send emails one invitation and returns the sent message ID.
+func (s *inviteSender) send(ctx context.Context, u onboardingUser) (string, error) {
+	req, err := s.render(u)
+	if err != nil {
+		return "", err
+	}
+	var msg *domain.Message
+	if s.grantID != "" {
+		msg, err = s.client.SendMessage(ctx, s.grantID, req)
+	} else {
+		msg, err = s.client.SendTransactionalMessage(ctx, s.domain, req)
+	}
+	if err != nil {
+		return "", err
+	}
+	return msg.ID, nil
+}
+
+// inviteResult summarizes a send run.
+type inviteResult struct {
+	Sent    []domain.Invitation `json:"sent"`
+	Skipped []string            `json:"skipped,omitempty"`
+	Failed  []inviteFailure     `json:"failed,omitempty"`
+}
+
+type inviteFailure struct {
+	Email string `json:"email"`
+	Error string `json:"error"`
+}
+
+// selectInvitees drops users who can't be invited (no link) and, unless
+// resend is set, users who were already invited.
+func selectInvitees(users []onboardingUser, existing []domain.Invitation, resend bool) (invitees []onboardingUser, result inviteResult) {
+	invited := make(map[string]domain.Invitation, len(existing))
+	for _, inv := range existing {
+		invited[strings.ToLower(inv.Email)] = inv
+	}
+	for _, u := range users {
+		if u.Error != "" {
+			result.Failed = append(result.Failed, inviteFailure{Email: u.Email, Error: u.Error})
+			continue
+		}
+		if inv, ok := invited[u.Email]; ok && (inv.Completed() || !resend) {
+			result.Skipped = append(result.Skipped, u.Email)
+			continue
+		}
+		invitees = append(invitees, u)
+	}
+	return invitees, result
+}
+
+// sendInvitations sends each invitation and records it as soon as it's sent,
+// so an interrupted run can be resumed without emailing anyone twice.
+func sendInvitations(sender *inviteSender, store ports.InvitationStore, invitees []onboardingUser, result inviteResult) inviteResult {
+	for _, u := range invitees {
+		ctx, cancel := common.CreateContext()
+		messageID, err := sender.send(ctx, u)
+		cancel()
+		if err != nil {
+			result.Failed = append(result.Failed, inviteFailure{Email: u.Email, Error: err.Error()})
+			continue
+		}
+		inv := domain.Invitation{
+			Email:     u.Email,
+			Name:      u.Name,
+			Provider:  u.Provider,
+			State:     u.State,
+			MessageID: messageID,
+			SentAt:    time.Now(),
+		}
+		if err := store.Save(inv); err != nil {
+			result.Failed = append(result.Failed, inviteFailure{Email: u.Email, Error: "sent, but not recorded: " + err.Error()})
+			continue
+		}
+		result.Sent = append(result.Sent, inv)
+	}
+	return result
+}
+
+func newInviteCmd() *cobra.Command {
+	var (
+		csvPath      string
+		templatePath string
+		subject      string
+		redirectURI  string
+		sendDomain   string
+		from         string
+		grantID      string
+		mappingPath  string
+		resend       bool
+		dryRun       bool
+		yes          bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "invite",
+		Short: "Email hosted-auth invitations to a CSV of users",
+		Long: `Email each user in a CSV a personalized hosted-auth link and track who
+completes it.
+
+Users are planned as in 'admin onboarding plan': each domain's MX records pick
+the connector, and each link carries the user's email as a login hint.
+Invitations are sent through a transactional sending domain (--domain and
+--from) or from a connected mailbox (--grant), and recorded locally in
+invitations.json in the config directory. Users already invited are skipped
+unless --resend is given; users who completed auth are always skipped.
+
+--template is an HTML file using Go template syntax with the fields
+{{.Name}}, {{.Email}}, {{.Provider}}, {{.ProviderName}} and {{.AuthURL}}.
+--subject is a template with the same fields.
+
+Track progress with 'nylas admin invite status'.`,
+		Example: `  # Preview who would be invited
+  nylas admin invite --csv users.csv --redirect-uri https://app.example.com/callback \
+    --domain mail.example.com --from onboarding@mail.example.com --dry-run
+
+  # Send with a custom template
+  nylas admin invite --csv users.csv --template invite.html \
+    --redirect-uri https://app.example.com/callback \
+    --domain mail.example.com --from onboarding@mail.example.com
+
+  # Check completion
+  nylas admin invite status`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateRequiredFlag("--csv", csvPath); err != nil {
+				return err
+			}
+			if err := common.ValidateRequiredFlag("--redirect-uri", redirectURI); err != nil {
+				return err
+			}
+			if grantID == "" && (sendDomain == "" || from == "") {
+				return common.NewUserError("choose how to send the invitations",
+					"Use --domain and --from to send through a transactional domain, or --grant to send from a mailbox")
+			}
+			if grantID != "" && sendDomain != "" {
+				return common.NewInputError("--grant and --domain cannot be used together")
+			}
+
+			body := defaultInviteTemplate
+			if templatePath != "" {
+				data, err := os.ReadFile(templatePath) // #nosec G304 -- user-supplied template file
+				if err != nil {
+					return common.WrapError(fmt.Errorf("failed to read template %s: %w", templatePath, err))
+				}
+				body = string(data)
+			}
+
+			records, err := common.ReadCSVFile(csvPath, mappingPath, onboardingFields)
+			if err != nil {
+				return err
+			}
+			if len(records) == 0 {
+				return common.NewUserError("no users found in "+csvPath, "The file needs an email column with one user per row")
+			}
+			store, err := openInvitationStore()
+			if err != nil {
+				return err
+			}
+			existing, err := store.List()
+			if err != nil {
+				return common.WrapListError("invitations", err)
+			}
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+			sender, err := newInviteSender(client, sendDomain, from, grantID, subject, body)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContext()
+			users := planOnboarding(ctx, client, records, redirectURI)
+			cancel()
+			invitees, result := selectInvitees(users, existing, resend)
+
+			if dryRun {
+				for _, u := range invitees {
+					if _, err := sender.render(u); err != nil {
+						return common.NewUserError(fmt.Sprintf("template failed for %s: %v", u.Email, err),
+							"Template fields: {{.Name}} {{.Email}} {{.Provider}} {{.ProviderName}} {{.AuthURL}}")
+					}
+				}
+				if common.IsStructuredOutput(cmd) {
+					return common.GetOutputWriter(cmd).Write(invitees)
+				}
+				printInvitePreview(invitees, result)
+				return nil
+			}
+
+			if len(invitees) > 0 && !yes && !common.Confirm(fmt.Sprintf("Send %d invitation(s)?", len(invitees)), false) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+			result = sendInvitations(sender, store, invitees, result)
+
+			if common.IsStructuredOutput(cmd) {
+				if err := common.GetOutputWriter(cmd).Write(result); err != nil {
+					return err
+				}
+			} else {
+				printInviteResult(result)
+			}
+			if len(invitees) > 0 && len(result.Sent) == 0 {
+				return common.NewUserError("no invitations were sent", "Check the errors above and the sender settings")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&csvPath, "csv", "", "CSV of users with an email column and optional name column (required)")
+	cmd.Flags().StringVar(&templatePath, "template", "", "HTML template file for the invitation body")
+	cmd.Flags().StringVar(&subject, "subject", defaultInviteSubject, "Subject template")
+	cmd.Flags().StringVar(&redirectURI, "redirect-uri", "", "Callback URI the hosted-auth links return to (required)")
+	cmd.Flags().StringVar(&sendDomain, "domain", "", "Transactional sending domain")
+	cmd.Flags().StringVar(&from, "from", "", "Sender address (required with --domain)")
+	cmd.Flags().StringVar(&grantID, "grant", "", "Send from this connected mailbox instead of a transactional domain")
+	cmd.Flags().StringVar(&mappingPath, "mapping", "", "YAML file mapping CSV headers to email/name")
+	cmd.Flags().BoolVar(&resend, "resend", false, "Email users who were already invited but haven't completed auth")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show who would be invited without sending")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
+
+	cmd.AddCommand(common.RunLocally(common.DeclareOutput(newInviteStatusCmd(), inviteStatus{}), "watch"))
+
+	return cmd
+}
+
+func printInvitePreview(invitees []onboardingUser, result inviteResult) {
+	if len(invitees) > 0 {
+		table := common.NewTable("EMAIL", "NAME", "CONNECTOR")
+		for _, u := range invitees {
+			table.AddRow(u.Email, u.Name, u.Provider)
+		}
+		table.Render()
+		fmt.Println()
+	}
+	fmt.Printf("Would send %d invitation(s)", len(invitees))
+	if len(result.Skipped) > 0 {
+		fmt.Printf(", skip %d already invited", len(result.Skipped))
+	}
+	fmt.Println()
+	for _, f := range result.Failed {
+		common.PrintWarning("%s: %s", f.Email, f.Error)
+	}
+}
+
+func printInviteResult(result inviteResult) {
+	if len(result.Sent) > 0 {
+		common.PrintSuccess("Sent %d invitation(s)", len(result.Sent))
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("Skipped %d already invited (use --resend to email them again)\n", len(result.Skipped))
+	}
+	for _, f := range result.Failed {
+		common.PrintError("%s: %s", f.Email, f.Error)
+	}
+	if len(result.Sent) > 0 {
+		fmt.Println("\nTrack completion with: nylas admin invite status")
+	}
+}
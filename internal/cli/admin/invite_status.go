@@ -0,0 +1,199 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// inviteStatus is the completion dashboard for sent invitations.
+type inviteStatus struct {
+	Invited     int                 `json:"invited"`
+	Completed   int                 `json:"completed"`
+	Pending     int                 `json:"pending"`
+	ByProvider  []inviteProvider    `json:"by_provider"`
+	Invitations []domain.Invitation `json:"invitations"`
+}
+
+type inviteProvider struct {
+	Provider  string `json:"provider"`
+	Invited   int    `json:"invited"`
+	Completed int    `json:"completed"`
+}
+
+// refreshInviteStatus matches invitations to the application's grants,
+// records newly completed ones, and summarizes progress.
+func refreshInviteStatus(ctx context.Context, client ports.NylasClient, store ports.InvitationStore) (*inviteStatus, error) {
+	invs, err := store.List()
+	if err != nil {
+		return nil, common.WrapListError("invitations", err)
+	}
+	if len(invs) > 0 {
+		grants, err := client.ListGrants(ctx)
+		if err != nil {
+			return nil, common.WrapListError("grants", err)
+		}
+		if domain.MatchGrants(invs, grants) > 0 {
+			if err := store.Save(invs...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return summarizeInvitations(invs), nil
+}
+
+func summarizeInvitations(invs []domain.Invitation) *inviteStatus {
+	status := &inviteStatus{Invited: len(invs), Invitations: invs}
+	providers := make(map[string]*inviteProvider)
+	for _, inv := range invs {
+		p, ok := providers[inv.Provider]
+		if !ok {
+			p = &inviteProvider{Provider: inv.Provider}
+			providers[inv.Provider] = p
+		}
+		p.Invited++
+		if inv.Completed() {
+			p.Completed++
+			status.Completed++
+		}
+	}
+	status.Pending = status.Invited - status.Completed
+	for _, p := range providers {
+		status.ByProvider = append(status.ByProvider, *p)
+	}
+	sort.Slice(status.ByProvider, func(i, j int) bool {
+		return status.ByProvider[i].Provider < status.ByProvider[j].Provider
+	})
+	return status
+}
+
+func newInviteStatusCmd() *cobra.Command {
+	return newCompletionStatusCmd(openInvitationStore, "nylas admin invite", "Send some with: nylas admin invite --csv users.csv ...")
+}
+
+// newCompletionStatusCmd builds the status command of a bulk onboarding
+// command (parent) whose links are recorded in openStore.
+func newCompletionStatusCmd(openStore func() (ports.InvitationStore, error), parent, emptyHint string) *cobra.Command {
+	var (
+		watch       time.Duration
+		showPending bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show which users completed auth",
+		Long: `Show an onboarding completion dashboard. A user has completed auth once a
+valid grant with their email exists on the application.
+
+--watch re-checks on an interval until every user has completed auth or you
+press Ctrl+C.`,
+		Example: `  ` + parent + ` status
+  ` + parent + ` status --pending
+  ` + parent + ` status --watch 1m`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openStore()
+			if err != nil {
+				return err
+			}
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+
+			check := func() (*inviteStatus, error) {
+				ctx, cancel := common.CreateContext()
+				defer cancel()
+				return refreshInviteStatus(ctx, client, store)
+			}
+			status, err := check()
+			if err != nil {
+				return err
+			}
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(status)
+			}
+			printInviteStatus(status, showPending, emptyHint)
+			if watch <= 0 || status.Invited == 0 {
+				return nil
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			ticker := time.NewTicker(watch)
+			defer ticker.Stop()
+			for status.Pending > 0 {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+				before := status.Completed
+				if status, err = check(); err != nil {
+					common.PrintWarningStderr("Check failed: %v", err)
+					continue
+				}
+				if status.Completed != before {
+					fmt.Println()
+					printInviteStatus(status, showPending, emptyHint)
+				}
+			}
+			common.PrintSuccess("All users have connected their accounts")
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&watch, "watch", 0, "Re-check on this interval until everyone has completed auth")
+	cmd.Flags().BoolVar(&showPending, "pending", false, "Only list invitations that are not completed")
+
+	return cmd
+}
+
+func printInviteStatus(status *inviteStatus, pendingOnly bool, emptyHint string) {
+	if status.Invited == 0 {
+		common.PrintEmptyStateWithHint("invitations", emptyHint)
+		return
+	}
+
+	fmt.Printf("Onboarding: %d/%d completed (%d%%)  %s\n\n",
+		status.Completed, status.Invited, status.Completed*100/status.Invited, progressBar(status.Completed, status.Invited, 30))
+
+	byProvider := common.NewTable("CONNECTOR", "INVITED", "COMPLETED", "PENDING")
+	for _, p := range status.ByProvider {
+		byProvider.AddRow(p.Provider, fmt.Sprint(p.Invited), fmt.Sprint(p.Completed), fmt.Sprint(p.Invited-p.Completed))
+	}
+	byProvider.Render()
+	fmt.Println()
+
+	table := common.NewTable("EMAIL", "CONNECTOR", "INVITED", "STATUS")
+	for _, inv := range status.Invitations {
+		if pendingOnly && inv.Completed() {
+			continue
+		}
+		state := common.Yellow.Sprint("pending")
+		if inv.Completed() {
+			state = common.Green.Sprint("completed " + common.FormatTimeAgo(inv.CompletedAt))
+		}
+		table.AddRow(inv.Email, inv.Provider, common.FormatTimeAgo(inv.SentAt), state)
+	}
+	table.Render()
+}
+
+// progressBar draws done/total as a bar of the given width.
+func progressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
@@ -0,0 +1,143 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/invitations"
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func newTestInvitationStore(t *testing.T) *invitations.Store {
+	t.Helper()
+	return invitations.New(filepath.Join(t.TempDir(), "invitations.json"))
+}
+
+func TestInviteSender_Render(t *testing.T) {
+	sender, err := newInviteSender(nylas.NewMockClient(), "mail.example.com", "onboarding@mail.example.com", "",
+		defaultInviteSubject, defaultInviteTemplate)
+	require.NoError(t, err)
+
+	req, err := sender.render(onboardingUser{
+		Email: "ana@acme.com", Name: "Ana <Admin>", Provider: "google",
+		AuthURL: "https://api.us.nylas.com/v3/connect/auth?a=1&login_hint=ana%40acme.com",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Connect your Google account", req.Subject)
+	assert.Equal(t, []domain.EmailParticipant{{Name: "Ana <Admin>", Email: "ana@acme.com"}}, req.To)
+	assert.Equal(t, "onboarding@mail.example.com", req.From[0].Email)
+	assert.Contains(t, req.Body, "Hi Ana &lt;Admin&gt;,", "names are HTML-escaped")
+	assert.Contains(t, req.Body, `href="https://api.us.nylas.com/v3/connect/auth?a=1&amp;login_hint=ana%40acme.com"`)
+}
+
+func TestNewInviteSender_BadTemplate(t *testing.T) {
+	_, err := newInviteSender(nylas.NewMockClient(), "d", "f", "", defaultInviteSubject, "{{.Nope")
+	assert.Error(t, err)
+
+	sender, err := newInviteSender(nylas.NewMockClient(), "d", "f", "", defaultInviteSubject, "{{.Unknown}}")
+	require.NoError(t, err)
+	_, err = sender.render(onboardingUser{Email: "ana@acme.com"})
+	assert.Error(t, err, "unknown fields fail instead of rendering blank")
+}
+
+func TestSelectInvitees(t *testing.T) {
+	users := []onboardingUser{
+		{Email: "ana@acme.com", AuthURL: "u1"},
+		{Email: "bob@acme.com", AuthURL: "u2"},
+		{Email: "cy@acme.com", AuthURL: "u3"},
+		{Email: "bad", Error: "line 5: invalid email"},
+	}
+	existing := []domain.Invitation{
+		{Email: "bob@acme.com"},
+		{Email: "cy@acme.com", GrantID: "g-cy"},
+	}
+
+	invitees, result := selectInvitees(users, existing, false)
+	require.Len(t, invitees, 1)
+	assert.Equal(t, "ana@acme.com", invitees[0].Email)
+	assert.Equal(t, []string{"bob@acme.com", "cy@acme.com"}, result.Skipped)
+	require.Len(t, result.Failed, 1)
+
+	invitees, result = selectInvitees(users, existing, true)
+	require.Len(t, invitees, 2, "--resend includes pending invitations but not completed ones")
+	assert.Equal(t, []string{"cy@acme.com"}, result.Skipped)
+}
+
+func TestSendInvitations(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.SendMessageFunc = func(_ context.Context, grantID string, req *domain.SendMessageRequest) (*domain.Message, error) {
+		assert.Equal(t, "grant-sender", grantID)
+		if req.To[0].Email == "bob@acme.com" {
+			return nil, errors.New("mailbox full")
+		}
+		return &domain.Message{ID: "msg-" + req.To[0].Email}, nil
+	}
+	sender, err := newInviteSender(client, "", "", "grant-sender", defaultInviteSubject, defaultInviteTemplate)
+	require.NoError(t, err)
+	store := newTestInvitationStore(t)
+
+	result := sendInvitations(sender, store, []onboardingUser{
+		{Email: "ana@acme.com", Provider: "google", State: "s1", AuthURL: "https://x"},
+		{Email: "bob@acme.com", Provider: "google", State: "s2", AuthURL: "https://y"},
+	}, inviteResult{})
+
+	require.Len(t, result.Sent, 1)
+	assert.Equal(t, "msg-ana@acme.com", result.Sent[0].MessageID)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, "bob@acme.com", result.Failed[0].Email)
+
+	recorded, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, recorded, 1, "only sent invitations are recorded")
+	assert.Equal(t, "s1", recorded[0].State)
+}
+
+func TestRefreshInviteStatus(t *testing.T) {
+	store := newTestInvitationStore(t)
+	sent := time.Now().Add(-time.Hour)
+	require.NoError(t, store.Save(
+		domain.Invitation{Email: "ana@acme.com", Provider: "google", SentAt: sent},
+		domain.Invitation{Email: "bob@acme.com", Provider: "google", SentAt: sent},
+		domain.Invitation{Email: "cy@small.org", Provider: "imap", SentAt: sent},
+	))
+	client := nylas.NewMockClient()
+	client.ListGrantsFunc = func(context.Context) ([]domain.Grant, error) {
+		return []domain.Grant{{ID: "g-ana", Email: "ana@acme.com", GrantStatus: "valid"}}, nil
+	}
+
+	status, err := refreshInviteStatus(context.Background(), client, store)
+	require.NoError(t, err)
+	assert.Equal(t, 3, status.Invited)
+	assert.Equal(t, 1, status.Completed)
+	assert.Equal(t, 2, status.Pending)
+	assert.Equal(t, []inviteProvider{
+		{Provider: "google", Invited: 2, Completed: 1},
+		{Provider: "imap", Invited: 1},
+	}, status.ByProvider)
+
+	recorded, err := store.List()
+	require.NoError(t, err)
+	assert.Equal(t, "g-ana", recorded[0].GrantID, "completion is saved")
+}
+
+func TestInviteCmd_RequiresSender(t *testing.T) {
+	cmd := newInviteCmd()
+	cmd.SetArgs([]string{"--csv", "users.csv", "--redirect-uri", "https://app.example.com/cb"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "choose how to send")
+}
+
+func TestProgressBar(t *testing.T) {
+	assert.Equal(t, "[██░░]", progressBar(1, 2, 4))
+	assert.Equal(t, "[░░░░]", progressBar(0, 0, 4))
+}
@@ -0,0 +1,292 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// lookupMX resolves a domain's MX records. Replaced in tests.
+var lookupMX = net.DefaultResolver.LookupMX
+
+// onboardingFields are the CSV columns plan reads.
+var onboardingFields = []string{"email", "name"}
+
+// onboardingUser is one planned user: the connector their mail domain needs
+// and the hosted-auth link that connects them.
+type onboardingUser struct {
+	Email    string `json:"email"`
+	Name     string `json:"name,omitempty"`
+	Domain   string `json:"domain"`
+	MX       string `json:"mx,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	State    string `json:"state,omitempty"`
+	AuthURL  string `json:"auth_url,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// onboardingConnector counts the users that need one connector type.
+type onboardingConnector struct {
+	Provider   string `json:"provider"`
+	Users      int    `json:"users"`
+	Configured bool   `json:"configured"`
+}
+
+func newOnboardingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "onboarding",
+		Short: "Plan bulk onboarding of users",
+		Long:  "Plan connecting many users at once: which connectors they need and the links that connect them.",
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newOnboardingPlanCmd(), []onboardingUser{}))
+
+	return cmd
+}
+
+func newOnboardingPlanCmd() *cobra.Command {
+	var (
+		redirectURI string
+		mappingPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "plan <users.csv>",
+		Short: "Recommend connectors and create hosted-auth links for a CSV of users",
+		Long: `Read a CSV of users (an email column and an optional name column), look up
+the MX records of each user's mail domain, and recommend a connector:
+
+  MX at Google (aspmx.l.google.com, ...)          → google
+  MX at Microsoft (*.mail.protection.outlook.com) → microsoft
+  anything else                                   → imap
+
+Each user gets a hosted-auth link with their email as the login hint and a
+unique state value, ready to send in an onboarding campaign. --redirect-uri
+must be one of the application's callback URIs.
+
+The plan also reports which recommended connectors the application does not
+have yet. Use --output csv or --json to export the per-user links.`,
+		Example: `  # Review the plan
+  nylas admin onboarding plan users.csv --redirect-uri https://app.example.com/callback
+
+  # Export the links for a mail merge
+  nylas admin onboarding plan users.csv --redirect-uri https://app.example.com/callback --output csv > links.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateRequiredFlag("--redirect-uri", redirectURI); err != nil {
+				return err
+			}
+			if u, err := url.Parse(redirectURI); err != nil || u.Scheme == "" || u.Host == "" {
+				return common.NewInputError(fmt.Sprintf("invalid --redirect-uri %q: must be an absolute URL", redirectURI))
+			}
+			records, err := common.ReadCSVFile(args[0], mappingPath, onboardingFields)
+			if err != nil {
+				return err
+			}
+			if len(records) == 0 {
+				return common.NewUserError("no users found in "+args[0], "The file needs an email column with one user per row")
+			}
+
+			_, err = common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
+				users := planOnboarding(ctx, client, records, redirectURI)
+				connectors, err := onboardingConnectors(ctx, client, users)
+				if err != nil {
+					common.PrintWarningStderr("Could not list connectors: %v", err)
+				}
+
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(users)
+				}
+				printOnboardingPlan(users, connectors)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&redirectURI, "redirect-uri", "", "Callback URI the hosted-auth links return to (required)")
+	cmd.Flags().StringVar(&mappingPath, "mapping", "", "YAML file mapping CSV headers to email/name")
+
+	return cmd
+}
+
+// planOnboarding resolves each user's connector and builds their hosted-auth
+// link. Rows that can't be planned keep an Error and no link; duplicate
+// emails are dropped. MX lookups are done once per domain.
+func planOnboarding(ctx context.Context, client ports.NylasClient, records []common.CSVRecord, redirectURI string) []onboardingUser {
+	type mxResult struct {
+		host string
+		err  error
+	}
+	lookups := make(map[string]mxResult)
+	seen := make(map[string]bool)
+	users := make([]onboardingUser, 0, len(records))
+
+	for _, rec := range records {
+		email := strings.ToLower(strings.TrimSpace(rec.Fields["email"]))
+		if seen[email] && email != "" {
+			continue
+		}
+		seen[email] = true
+		user := onboardingUser{Email: email, Name: rec.Fields["name"]}
+
+		if err := common.ValidateEmail("email", email); err != nil {
+			user.Error = fmt.Sprintf("line %d: invalid email %q", rec.Line, email)
+			users = append(users, user)
+			continue
+		}
+		user.Domain = email[strings.LastIndex(email, "@")+1:]
+
+		res, ok := lookups[user.Domain]
+		if !ok {
+			res.host, res.err = primaryMX(ctx, user.Domain)
+			lookups[user.Domain] = res
+		}
+		if res.err != nil {
+			user.Error = res.err.Error()
+			users = append(users, user)
+			continue
+		}
+		user.MX = res.host
+		provider := providerForMX(res.host)
+		user.Provider = string(provider)
+		user.State = newOnboardingState()
+		user.AuthURL = withLoginHint(client.BuildAuthURL(provider, redirectURI, user.State, ""), email)
+		users = append(users, user)
+	}
+	return users
+}
+
+// primaryMX returns the most preferred MX host of a domain.
+func primaryMX(ctx context.Context, domainName string) (string, error) {
+	records, err := lookupMX(ctx, domainName)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return "", fmt.Errorf("no MX records for %s", domainName)
+		}
+		return "", fmt.Errorf("MX lookup for %s failed: %w", domainName, err)
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("no MX records for %s", domainName)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Pref < records[j].Pref })
+	return strings.TrimSuffix(strings.ToLower(records[0].Host), "."), nil
+}
+
+// providerForMX maps an MX host to the connector that serves it.
+func providerForMX(host string) domain.Provider {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, suffix := range []string{".google.com", ".googlemail.com"} {
+		if strings.HasSuffix(host, suffix) {
+			return domain.ProviderGoogle
+		}
+	}
+	for _, suffix := range []string{".protection.outlook.com", ".outlook.com", ".hotmail.com"} {
+		if strings.HasSuffix(host, suffix) {
+			return domain.ProviderMicrosoft
+		}
+	}
+	return domain.ProviderIMAP
+}
+
+// withLoginHint adds the user's email as login_hint so the provider's sign-in
+// page is prefilled.
+func withLoginHint(authURL, email string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return authURL
+	}
+	q := u.Query()
+	q.Set("login_hint", email)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// newOnboardingState returns a random state value that ties a completed
+// hosted-auth flow back to its row in the plan.
+func newOnboardingState() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// onboardingConnectors counts users per recommended connector and marks the
+// ones the application already has.
+func onboardingConnectors(ctx context.Context, client ports.NylasClient, users []onboardingUser) ([]onboardingConnector, error) {
+	counts := make(map[string]int)
+	for _, u := range users {
+		if u.Provider != "" {
+			counts[u.Provider]++
+		}
+	}
+	needed := make([]onboardingConnector, 0, len(counts))
+	for provider, n := range counts {
+		needed = append(needed, onboardingConnector{Provider: provider, Users: n})
+	}
+	sort.Slice(needed, func(i, j int) bool {
+		if needed[i].Users != needed[j].Users {
+			return needed[i].Users > needed[j].Users
+		}
+		return needed[i].Provider < needed[j].Provider
+	})
+
+	existing, err := client.ListConnectors(ctx)
+	if err != nil {
+		return needed, err
+	}
+	for i := range needed {
+		for _, c := range existing {
+			if c.Provider == needed[i].Provider {
+				needed[i].Configured = true
+				break
+			}
+		}
+	}
+	return needed, nil
+}
+
+func printOnboardingPlan(users []onboardingUser, connectors []onboardingConnector) {
+	table := common.NewTable("EMAIL", "DOMAIN", "MX", "CONNECTOR", "AUTH LINK")
+	failed := 0
+	for _, u := range users {
+		if u.Error != "" {
+			failed++
+			table.AddRow(u.Email, u.Domain, common.Red.Sprint(u.Error), "-", "-")
+			continue
+		}
+		table.AddRow(u.Email, u.Domain, u.MX, common.Green.Sprint(u.Provider), u.AuthURL)
+	}
+	table.Render()
+
+	fmt.Println()
+	fmt.Printf("Planned %d user(s)", len(users)-failed)
+	if failed > 0 {
+		fmt.Printf(", %d could not be planned", failed)
+	}
+	fmt.Println()
+
+	if len(connectors) == 0 {
+		return
+	}
+	fmt.Println("\nConnectors needed:")
+	for _, c := range connectors {
+		status := common.Green.Sprint("configured")
+		if !c.Configured {
+			status = common.Yellow.Sprintf("missing – create with: nylas admin connectors create --provider %s", c.Provider)
+		}
+		fmt.Printf("  %-10s %3d user(s)  %s\n", c.Provider, c.Users, status)
+	}
+}
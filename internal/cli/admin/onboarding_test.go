@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// useMXRecords makes lookupMX answer from records and counts lookups.
+func useMXRecords(t *testing.T, records map[string][]*net.MX) *int {
+	t.Helper()
+	calls := 0
+	orig := lookupMX
+	lookupMX = func(_ context.Context, name string) ([]*net.MX, error) {
+		calls++
+		mx, ok := records[name]
+		if !ok {
+			return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+		}
+		return mx, nil
+	}
+	t.Cleanup(func() { lookupMX = orig })
+	return &calls
+}
+
+func TestProviderForMX(t *testing.T) {
+	tests := map[string]domain.Provider{
+		"aspmx.l.google.com.":                   domain.ProviderGoogle,
+		"alt1.aspmx.l.googlemail.com":           domain.ProviderGoogle,
+		"acme-com.mail.protection.outlook.com.": domain.ProviderMicrosoft,
+		"mx1.hotmail.com":                       domain.ProviderMicrosoft,
+		"mx.fastmail.com":                       domain.ProviderIMAP,
+		"mail.notgoogle.com":                    domain.ProviderIMAP,
+	}
+	for host, want := range tests {
+		assert.Equal(t, want, providerForMX(host), host)
+	}
+}
+
+func TestPlanOnboarding(t *testing.T) {
+	calls := useMXRecords(t, map[string][]*net.MX{
+		"acme.com":    {{Host: "alt1.aspmx.l.google.com.", Pref: 5}, {Host: "aspmx.l.google.com.", Pref: 1}},
+		"contoso.com": {{Host: "contoso-com.mail.protection.outlook.com.", Pref: 0}},
+		"small.org":   {{Host: "mx.small.org.", Pref: 10}},
+	})
+	client := nylas.NewMockClient()
+	client.BuildAuthURLFunc = func(provider domain.Provider, redirectURI, state, _ string) string {
+		return "https://api.us.nylas.com/v3/connect/auth?provider=" + string(provider) + "&redirect_uri=" + url.QueryEscape(redirectURI) + "&state=" + state
+	}
+
+	records := []common.CSVRecord{
+		{Line: 2, Fields: map[string]string{"email": "Ana@Acme.com", "name": "Ana"}},
+		{Line: 3, Fields: map[string]string{"email": "bob@acme.com"}},
+		{Line: 4, Fields: map[string]string{"email": "cy@contoso.com"}},
+		{Line: 5, Fields: map[string]string{"email": "dee@small.org"}},
+		{Line: 6, Fields: map[string]string{"email": "ana@acme.com"}},
+		{Line: 7, Fields: map[string]string{"email": "not-an-email"}},
+		{Line: 8, Fields: map[string]string{"email": "eve@gone.invalid"}},
+	}
+	users := planOnboarding(context.Background(), client, records, "https://app.example.com/callback")
+
+	require.Len(t, users, 6, "duplicate ana@acme.com is dropped")
+	assert.Equal(t, 4, *calls, "one lookup per domain")
+
+	ana := users[0]
+	assert.Equal(t, "ana@acme.com", ana.Email)
+	assert.Equal(t, "Ana", ana.Name)
+	assert.Equal(t, "aspmx.l.google.com", ana.MX, "lowest preference wins")
+	assert.Equal(t, "google", ana.Provider)
+	assert.NotEmpty(t, ana.State)
+	link, err := url.Parse(ana.AuthURL)
+	require.NoError(t, err)
+	assert.Equal(t, "ana@acme.com", link.Query().Get("login_hint"))
+	assert.Equal(t, ana.State, link.Query().Get("state"))
+	assert.Equal(t, "https://app.example.com/callback", link.Query().Get("redirect_uri"))
+
+	assert.NotEqual(t, ana.State, users[1].State)
+	assert.Equal(t, "microsoft", users[2].Provider)
+	assert.Equal(t, "imap", users[3].Provider)
+
+	assert.Contains(t, users[4].Error, "line 7")
+	assert.Empty(t, users[4].AuthURL)
+	assert.Equal(t, "no MX records for gone.invalid", users[5].Error)
+	assert.Empty(t, users[5].AuthURL)
+}
+
+func TestPlanOnboarding_LookupFailure(t *testing.T) {
+	orig := lookupMX
+	lookupMX = func(context.Context, string) ([]*net.MX, error) { return nil, errors.New("timeout") }
+	t.Cleanup(func() { lookupMX = orig })
+
+	users := planOnboarding(context.Background(), nylas.NewMockClient(),
+		[]common.CSVRecord{{Line: 2, Fields: map[string]string{"email": "ana@acme.com"}}}, "https://app.example.com/cb")
+	require.Len(t, users, 1)
+	assert.Contains(t, users[0].Error, "MX lookup for acme.com failed")
+}
+
+func TestOnboardingConnectors(t *testing.T) {
+	client := nylas.NewMockClient() // has google and microsoft connectors
+	users := []onboardingUser{
+		{Provider: "google"}, {Provider: "google"}, {Provider: "imap"}, {Error: "bad"},
+	}
+
+	needed, err := onboardingConnectors(context.Background(), client, users)
+	require.NoError(t, err)
+	assert.Equal(t, []onboardingConnector{
+		{Provider: "google", Users: 2, Configured: true},
+		{Provider: "imap", Users: 1},
+	}, needed)
+}
+
+func TestOnboardingPlanCmd_RequiresRedirectURI(t *testing.T) {
+	cmd := newOnboardingPlanCmd()
+	cmd.SetArgs([]string{"users.csv"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--redirect-uri")
+}
@@ -1,6 +1,11 @@
 package agent
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+
+	"github.com/spf13/cobra"
+)
 
 func newAccountCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -34,10 +39,10 @@ API reference: https://developer.nylas.com/docs/v3/agent-accounts/provisioning/
   nylas agent account delete <agent-id|email>`,
 	}
 
-	cmd.AddCommand(newCreateCmd())
-	cmd.AddCommand(newUpdateCmd())
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(common.DeclareOutput(newCreateCmd(), domain.AgentAccount{}))
+	cmd.AddCommand(common.DeclareOutput(newUpdateCmd(), domain.AgentAccount{}))
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.AgentAccount{}))
+	cmd.AddCommand(common.DeclareOutput(newGetCmd(), domain.AgentAccount{}))
 	cmd.AddCommand(newMoveCmd())
 	cmd.AddCommand(newDeleteCmd())
 
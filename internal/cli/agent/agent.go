@@ -1,6 +1,12 @@
 package agent
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/nylas/cli/internal/agentgraph"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+
+	"github.com/spf13/cobra"
+)
 
 // NewAgentCmd creates the agent command group.
 func NewAgentCmd() *cobra.Command {
@@ -43,12 +49,14 @@ API reference: https://developer.nylas.com/docs/v3/agent-accounts/
 		},
 	}
 
+	common.RequireScopes(cmd, domain.ScopeApplicationAPI)
+
 	cmd.AddCommand(newAccountCmd())
 	cmd.AddCommand(newPolicyCmd())
 	cmd.AddCommand(newRuleCmd())
 	cmd.AddCommand(newAgentListCmd())
-	cmd.AddCommand(newOverviewCmd())
-	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(common.DeclareOutput(newOverviewCmd(), agentgraph.Overview{}))
+	cmd.AddCommand(common.DeclareOutput(newStatusCmd(), statusResult{}))
 
 	return cmd
 }
@@ -35,14 +35,14 @@ Examples:
   nylas agent list delete <list-id> --yes`,
 	}
 
-	cmd.AddCommand(newAgentListListCmd())
-	cmd.AddCommand(newAgentListGetCmd())
-	cmd.AddCommand(newAgentListCreateCmd())
-	cmd.AddCommand(newAgentListUpdateCmd())
+	cmd.AddCommand(common.DeclareOutput(newAgentListListCmd(), []domain.AgentList{}))
+	cmd.AddCommand(common.DeclareOutput(newAgentListGetCmd(), agentListDetails{}))
+	cmd.AddCommand(common.DeclareOutput(newAgentListCreateCmd(), domain.AgentList{}))
+	cmd.AddCommand(common.DeclareOutput(newAgentListUpdateCmd(), domain.AgentList{}))
 	cmd.AddCommand(newAgentListDeleteCmd())
-	cmd.AddCommand(newAgentListItemsCmd())
-	cmd.AddCommand(newAgentListAddCmd())
-	cmd.AddCommand(newAgentListRemoveCmd())
+	cmd.AddCommand(common.DeclareOutput(newAgentListItemsCmd(), []string{}))
+	cmd.AddCommand(common.DeclareOutput(newAgentListAddCmd(), domain.AgentList{}))
+	cmd.AddCommand(common.DeclareOutput(newAgentListRemoveCmd(), domain.AgentList{}))
 
 	return cmd
 }
@@ -104,6 +104,12 @@ Examples:
 	}
 }
 
+// agentListDetails is a list with its items, as list get writes it.
+type agentListDetails struct {
+	List  *domain.AgentList `json:"list"`
+	Items []string          `json:"items"`
+}
+
 func runAgentListGet(listID string, jsonOutput bool) error {
 	_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
 		list, err := client.GetList(ctx, listID)
@@ -117,7 +123,7 @@ func runAgentListGet(listID string, jsonOutput bool) error {
 		}
 
 		if jsonOutput {
-			return struct{}{}, common.PrintJSON(map[string]any{"list": list, "items": items})
+			return struct{}{}, common.PrintJSON(agentListDetails{List: list, Items: items})
 		}
 
 		printAgentListDetails(*list, items)
@@ -43,11 +43,11 @@ Examples:
   nylas agent policy delete <policy-id> --yes`,
 	}
 
-	cmd.AddCommand(newPolicyListCmd())
-	cmd.AddCommand(newPolicyGetCmd())
-	cmd.AddCommand(newPolicyReadCmd())
-	cmd.AddCommand(newPolicyCreateCmd())
-	cmd.AddCommand(newPolicyUpdateCmd())
+	cmd.AddCommand(common.DeclareOutput(newPolicyListCmd(), []domain.Policy{}))
+	cmd.AddCommand(common.DeclareOutput(newPolicyGetCmd(), domain.Policy{}))
+	cmd.AddCommand(common.DeclareOutput(newPolicyReadCmd(), domain.Policy{}))
+	cmd.AddCommand(common.DeclareOutput(newPolicyCreateCmd(), domain.Policy{}))
+	cmd.AddCommand(common.DeclareOutput(newPolicyUpdateCmd(), domain.Policy{}))
 	cmd.AddCommand(newPolicyDeleteCmd())
 
 	return cmd
@@ -32,11 +32,11 @@ Examples:
   nylas agent rule delete <rule-id> --yes`,
 	}
 
-	cmd.AddCommand(newRuleListCmd())
-	cmd.AddCommand(newRuleGetCmd())
-	cmd.AddCommand(newRuleReadCmd())
-	cmd.AddCommand(newRuleCreateCmd())
-	cmd.AddCommand(newRuleUpdateCmd())
+	cmd.AddCommand(common.DeclareOutput(newRuleListCmd(), []domain.Rule{}))
+	cmd.AddCommand(common.DeclareOutput(newRuleGetCmd(), domain.Rule{}))
+	cmd.AddCommand(common.DeclareOutput(newRuleReadCmd(), domain.Rule{}))
+	cmd.AddCommand(common.DeclareOutput(newRuleCreateCmd(), domain.Rule{}))
+	cmd.AddCommand(common.DeclareOutput(newRuleUpdateCmd(), domain.Rule{}))
 	cmd.AddCommand(newRuleDeleteCmd())
 
 	return cmd
@@ -1,6 +1,8 @@
 package ai
 
 import (
+	"github.com/nylas/cli/internal/cli/common"
+
 	"github.com/spf13/cobra"
 )
 
@@ -38,9 +40,9 @@ Examples:
 	// Add subcommands
 	cmd.AddCommand(newConfigCmd())
 	cmd.AddCommand(newClearDataCmd())
-	cmd.AddCommand(newUsageCmd())
+	cmd.AddCommand(common.DeclareOutput(newUsageCmd(), UsageStats{}))
 	cmd.AddCommand(newSetBudgetCmd())
-	cmd.AddCommand(newShowBudgetCmd())
+	cmd.AddCommand(common.DeclareOutput(newShowBudgetCmd(), BudgetConfig{}))
 
 	return cmd
 }
@@ -5,10 +5,14 @@ package ai
 import (
 	"testing"
 
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
 	"github.com/nylas/cli/internal/cli/testutil"
 	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // executeCommand executes a command and captures its output.
@@ -137,6 +141,16 @@ func TestMaskAPIKey(t *testing.T) {
 			input:    "sk-proj-ABCDEFGHIJKLMNOPQRSTUVWXYZ12345678901234567890",
 			expected: "sk-proj-***...***7890",
 		},
+		{
+			name:     "env var reference",
+			input:    "${ANTHROPIC_API_KEY}",
+			expected: "${ANTHROPIC_API_KEY}",
+		},
+		{
+			name:     "secret store reference",
+			input:    "${secret:config.ai.claude.api_key}",
+			expected: "${secret:config.ai.claude.api_key}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -405,6 +419,31 @@ func TestSetConfigValue(t *testing.T) {
 			expectErr: true,
 			validate:  nil,
 		},
+		{
+			name:      "set translation.backend",
+			key:       "translation.backend",
+			value:     "libretranslate",
+			expectErr: false,
+			validate: func(t *testing.T, ai *domain.AIConfig) {
+				assert.Equal(t, "libretranslate", ai.Translation.Backend)
+			},
+		},
+		{
+			name:      "set invalid translation.backend",
+			key:       "translation.backend",
+			value:     "babelfish",
+			expectErr: true,
+			validate:  nil,
+		},
+		{
+			name:      "set translation.url",
+			key:       "translation.url",
+			value:     "http://localhost:5000",
+			expectErr: false,
+			validate: func(t *testing.T, ai *domain.AIConfig) {
+				assert.Equal(t, "http://localhost:5000", ai.Translation.URL)
+			},
+		},
 		{
 			name:      "invalid key format",
 			key:       "ollama",
@@ -454,3 +493,28 @@ func TestSetConfigValueAllProviders(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigSetStoresAPIKeyInSecretStore(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	secrets := keyring.NewMockSecretStore()
+	original := openSecretStore
+	openSecretStore = func() (ports.SecretStore, error) { return secrets, nil }
+	t.Cleanup(func() { openSecretStore = original })
+
+	stdout, _, err := testutil.ExecuteSubCommand(newConfigSetCmd(), "claude.api_key", "sk-ant-api03-plaintext")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "stored in mock")
+	assert.NotContains(t, stdout, "sk-ant-api03-plaintext")
+
+	cfg, err := config.NewDefaultFileStore().Load()
+	require.NoError(t, err)
+	assert.Equal(t, "${secret:config.ai.claude.api_key}", cfg.AI.Claude.APIKey)
+	assert.Equal(t, "sk-ant-api03-plaintext", secrets.GetAll()["config.ai.claude.api_key"])
+
+	_, _, err = testutil.ExecuteSubCommand(newConfigSetCmd(), "openai.api_key", "${OPENAI_API_KEY}")
+	require.NoError(t, err)
+	cfg, err = config.NewDefaultFileStore().Load()
+	require.NoError(t, err)
+	assert.Equal(t, "${OPENAI_API_KEY}", cfg.AI.OpenAI.APIKey, "env references stay in config")
+	assert.Len(t, secrets.GetAll(), 1)
+}
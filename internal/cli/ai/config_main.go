@@ -173,6 +173,21 @@ func newConfigListCmd() *cobra.Command {
 				fmt.Printf("    email_context_analysis: %v\n", cfg.AI.Features.EmailContextAnalysis)
 			}
 
+			// Translation
+			if cfg.AI.Translation != nil {
+				fmt.Printf("\n  Translation:\n")
+				fmt.Printf("    backend: %s\n", cfg.AI.Translation.Backend)
+				if cfg.AI.Translation.Provider != "" {
+					fmt.Printf("    provider: %s\n", cfg.AI.Translation.Provider)
+				}
+				if cfg.AI.Translation.URL != "" {
+					fmt.Printf("    url: %s\n", cfg.AI.Translation.URL)
+				}
+				if cfg.AI.Translation.APIKey != "" {
+					fmt.Printf("    api_key: %s\n", maskAPIKey(cfg.AI.Translation.APIKey))
+				}
+			}
+
 			return nil
 		},
 	}
@@ -206,6 +221,10 @@ Supported keys:
   - features.focus_time_protection
   - features.conflict_resolution
   - features.email_context_analysis
+  - translation.backend
+  - translation.provider
+  - translation.url
+  - translation.api_key
 
 Examples:
   nylas ai config get default_provider
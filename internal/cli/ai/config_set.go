@@ -4,11 +4,20 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
 	"github.com/spf13/cobra"
 )
 
+// openSecretStore opens the store API keys set with `ai config set` are
+// written to. Replaced in tests.
+var openSecretStore = func() (ports.SecretStore, error) {
+	return keyring.NewSecretStore(config.DefaultConfigDir())
+}
+
 func newConfigSetCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "set <key> <value>",
@@ -37,6 +46,14 @@ Supported keys:
   - features.focus_time_protection (true, false)
   - features.conflict_resolution (true, false)
   - features.email_context_analysis (true, false)
+  - translation.backend (llm, libretranslate)
+  - translation.provider (AI provider for the llm backend, e.g., ollama)
+  - translation.url (LibreTranslate URL, e.g., http://localhost:5000)
+  - translation.api_key (LibreTranslate API key)
+
+API keys given literally are stored in the OS keychain (or the encrypted file
+store) and config.yaml keeps only a ${secret:...} reference. Values that use
+${ENV_VAR} are written to config.yaml as-is.
 
 Examples:
   # Set Ollama as default provider
@@ -61,7 +78,11 @@ Examples:
 
   # Configure feature toggles
   nylas ai config set features.natural_language_scheduling true
-  nylas ai config set features.focus_time_protection true`,
+  nylas ai config set features.focus_time_protection true
+
+  # Translate messages with a self-hosted LibreTranslate server
+  nylas ai config set translation.backend libretranslate
+  nylas ai config set translation.url http://localhost:5000`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := args[0]
@@ -78,6 +99,19 @@ Examples:
 				cfg.AI = &domain.AIConfig{}
 			}
 
+			storedIn := ""
+			if strings.HasSuffix(key, ".api_key") && domain.IsPlaintextSecret(value) {
+				secrets, err := openSecretStore()
+				if err != nil {
+					return fmt.Errorf("access secret store: %w", err)
+				}
+				secretKey := ports.ConfigSecretKey("ai." + key)
+				if err := secrets.Set(secretKey, value); err != nil {
+					return fmt.Errorf("store %s: %w", key, err)
+				}
+				value, storedIn = domain.SecretRef(secretKey), secrets.Name()
+			}
+
 			if err := setConfigValue(cfg.AI, key, value); err != nil {
 				return err
 			}
@@ -86,7 +120,11 @@ Examples:
 				return common.WrapSaveError("config", err)
 			}
 
-			fmt.Printf("✓ Set %s = %s\n", key, value)
+			if storedIn != "" {
+				fmt.Printf("✓ Set %s (stored in %s)\n", key, storedIn)
+			} else {
+				fmt.Printf("✓ Set %s = %s\n", key, value)
+			}
 			fmt.Printf("\nConfiguration saved to: %s\n", store.Path())
 			return nil
 		},
@@ -95,7 +133,11 @@ Examples:
 
 // maskAPIKey masks an API key for display, showing first 8 and last 4 characters.
 // Example: "sk-proj-abcdefghijklmnop" -> "sk-proj-***...***mnop"
+// ${ENV_VAR} and ${secret:...} references are not secret and are shown as-is.
 func maskAPIKey(key string) string {
+	if key != "" && !domain.IsPlaintextSecret(key) {
+		return key
+	}
 	if len(key) <= 12 {
 		// Too short to mask meaningfully
 		return "***"
@@ -247,6 +289,26 @@ func getConfigValue(ai *domain.AIConfig, key string) (string, error) {
 			return "", fmt.Errorf("unknown features key: %s", parts[1])
 		}
 
+	case "translation":
+		if ai.Translation == nil {
+			return "", fmt.Errorf("translation not configured")
+		}
+		if len(parts) < 2 {
+			return "", common.NewInputError(fmt.Sprintf("invalid key: %s", key))
+		}
+		switch parts[1] {
+		case "backend":
+			return ai.Translation.Backend, nil
+		case "provider":
+			return ai.Translation.Provider, nil
+		case "url":
+			return ai.Translation.URL, nil
+		case "api_key":
+			return ai.Translation.APIKey, nil
+		default:
+			return "", fmt.Errorf("unknown translation key: %s", parts[1])
+		}
+
 	default:
 		return "", fmt.Errorf("unknown config key: %s", key)
 	}
@@ -413,6 +475,29 @@ func setConfigValue(ai *domain.AIConfig, key, value string) error {
 			return fmt.Errorf("unknown features key: %s", parts[1])
 		}
 
+	case "translation":
+		if ai.Translation == nil {
+			ai.Translation = &domain.TranslationConfig{}
+		}
+		if len(parts) < 2 {
+			return common.NewInputError(fmt.Sprintf("invalid key: %s", key))
+		}
+		switch parts[1] {
+		case "backend":
+			if err := common.ValidateOneOf("translation backend", value, []string{domain.TranslationBackendLLM, domain.TranslationBackendLibreTranslate}); err != nil {
+				return err
+			}
+			ai.Translation.Backend = value
+		case "provider":
+			ai.Translation.Provider = value
+		case "url":
+			ai.Translation.URL = value
+		case "api_key":
+			ai.Translation.APIKey = value
+		default:
+			return fmt.Errorf("unknown translation key: %s", parts[1])
+		}
+
 	default:
 		return fmt.Errorf("unknown config key: %s", key)
 	}
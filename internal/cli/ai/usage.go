@@ -57,13 +57,8 @@ Examples:
 				}
 			}
 
-			if common.IsJSON(cmd) {
-				data, err := json.MarshalIndent(stats, "", "  ")
-				if err != nil {
-					return common.WrapMarshalError("usage stats", err)
-				}
-				fmt.Println(string(data))
-				return nil
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(stats)
 			}
 
 			// Display formatted output
@@ -2,6 +2,9 @@
 package audit
 
 import (
+	"github.com/nylas/cli/internal/adapters/audit"
+	"github.com/nylas/cli/internal/cli/common"
+
 	"github.com/spf13/cobra"
 )
 
@@ -34,13 +37,23 @@ for API traceability.`,
   nylas audit logs summary --days 7
 
   # Export logs
-  nylas audit export --output audit.json`,
+  nylas audit export --output audit.json
+
+  # Check the log for tampering
+  nylas audit verify
+
+  # Ship entries to a central collector
+  nylas audit sink add siem --type syslog --url tls://logs.example.com:6514
+  nylas audit test-sink siem`,
 	}
 
 	cmd.AddCommand(newInitCmd())
 	cmd.AddCommand(newLogsCmd())
 	cmd.AddCommand(newConfigCmd())
 	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(newSinkCmd())
+	cmd.AddCommand(newTestSinkCmd())
+	cmd.AddCommand(common.DeclareOutput(newVerifyCmd(), audit.VerifyReport{}))
 
 	return cmd
 }
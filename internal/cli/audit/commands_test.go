@@ -40,7 +40,7 @@ func TestNewAuditCmd_Subcommands(t *testing.T) {
 		subNames[sub.Use] = true
 	}
 
-	expected := []string{"init", "logs", "config", "export"}
+	expected := []string{"init", "logs", "config", "export", "sink", "test-sink [name]", "verify"}
 	for _, name := range expected {
 		assert.True(t, subNames[name], "expected subcommand %q to exist", name)
 	}
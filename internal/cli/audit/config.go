@@ -53,6 +53,9 @@ func newConfigShowCmd() *cobra.Command {
 			fmt.Printf("  Compress Old:    %s\n", yesNo(cfg.CompressOld))
 			fmt.Printf("  Log Request ID:  %s\n", yesNo(cfg.LogRequestID))
 			fmt.Printf("  Log API Details: %s\n", yesNo(cfg.LogAPIDetails))
+			if len(cfg.Sinks) > 0 {
+				fmt.Printf("  Remote Sinks:    %d (nylas audit sink list)\n", len(cfg.Sinks))
+			}
 
 			// Storage stats
 			fileCount, totalSize, oldestEntry, err := store.Stats()
@@ -20,7 +20,7 @@ func newLogsCmd() *cobra.Command {
 	cmd.AddCommand(newEnableCmd())
 	cmd.AddCommand(newDisableCmd())
 	cmd.AddCommand(newStatusCmd())
-	cmd.AddCommand(newShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newShowCmd(), []entryRow{}))
 	cmd.AddCommand(newSummaryCmd())
 	cmd.AddCommand(newClearCmd())
 
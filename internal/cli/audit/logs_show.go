@@ -3,6 +3,7 @@ package audit
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -129,6 +130,24 @@ When filtering by request ID, shows detailed information about that specific ent
 	return cmd
 }
 
+// entryRow is one audit entry as the logs table and --json show it.
+type entryRow struct {
+	Timestamp  string `json:"timestamp"`
+	Command    string `json:"command"`
+	Grant      string `json:"grant,omitempty"`
+	Invoker    string `json:"invoker"`
+	Source     string `json:"source"`
+	Status     string `json:"status"`
+	Duration   string `json:"duration"`
+	RequestID  string `json:"request_id,omitempty"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+
+	// Table display fields (not in JSON)
+	GrantDisplay   string `json:"-"`
+	InvokerDisplay string `json:"-"`
+	SourceDisplay  string `json:"-"`
+}
+
 func showEntryTable(cmd *cobra.Command, entries []domain.AuditEntry) error {
 	columns := []ports.Column{
 		{Header: "TIMESTAMP", Field: "Timestamp", Width: 19},
@@ -140,26 +159,9 @@ func showEntryTable(cmd *cobra.Command, entries []domain.AuditEntry) error {
 		{Header: "DURATION", Field: "Duration", Width: 10},
 	}
 
-	type row struct {
-		Timestamp  string `json:"timestamp"`
-		Command    string `json:"command"`
-		Grant      string `json:"grant,omitempty"`
-		Invoker    string `json:"invoker"`
-		Source     string `json:"source"`
-		Status     string `json:"status"`
-		Duration   string `json:"duration"`
-		RequestID  string `json:"request_id,omitempty"`
-		HTTPStatus int    `json:"http_status,omitempty"`
-
-		// Table display fields (not in JSON)
-		GrantDisplay   string `json:"-"`
-		InvokerDisplay string `json:"-"`
-		SourceDisplay  string `json:"-"`
-	}
-
-	rows := make([]row, len(entries))
+	rows := make([]entryRow, len(entries))
 	for i, e := range entries {
-		rows[i] = row{
+		rows[i] = entryRow{
 			Timestamp:      e.Timestamp.Format("2006-01-02 15:04:05"),
 			Command:        e.Command,
 			Grant:          orDash(e.GrantID),
@@ -226,6 +228,19 @@ func showDetailedEntry(entry domain.AuditEntry) error {
 		}
 	}
 
+	if len(entry.Details) > 0 {
+		fmt.Println()
+		fmt.Println("  Details:")
+		keys := make([]string, 0, len(entry.Details))
+		for k := range entry.Details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("    %-12s %s\n", k+":", entry.Details[k])
+		}
+	}
+
 	return nil
 }
 
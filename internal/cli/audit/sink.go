@@ -0,0 +1,346 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nylas/cli/internal/adapters/audit"
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// openSinkSecrets opens the store holding sink bearer tokens. Replaced in
+// tests.
+var openSinkSecrets = func() (ports.SecretStore, error) {
+	return keyring.NewSecretStore(configAdapter.DefaultConfigDir())
+}
+
+// SinkToken returns the stored bearer token for the named sink, or "".
+func SinkToken(name string) string {
+	secrets, err := openSinkSecrets()
+	if err != nil {
+		return ""
+	}
+	token, _ := secrets.Get(ports.AuditSinkSecretKey(name))
+	return token
+}
+
+func newSinkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sink",
+		Short: "Ship audit entries to remote collectors",
+		Long: `Ship a copy of every audit entry to syslog, an HTTPS collector, or an S3 bucket.
+
+Entries are still written locally. Each sink queues them in the audit
+directory and sends them in batches once a batch fills or its oldest entry
+has waited five minutes. Failed deliveries are retried, stay queued, and are
+sent after the collector recovers.
+
+Sink types:
+  syslog  RFC 5424 over udp://, tcp:// or tls://host:port; the message body
+          is the entry as JSON
+  https   each batch POSTed as a JSON array, with an optional bearer token
+  s3      each batch written as a JSON Lines object under
+          s3://bucket/prefix/YYYY/MM/DD/, using AWS_ACCESS_KEY_ID,
+          AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN`,
+	}
+
+	cmd.AddCommand(newSinkAddCmd())
+	cmd.AddCommand(common.DeclareOutput(newSinkListCmd(), []sinkView{}))
+	cmd.AddCommand(newSinkRemoveCmd())
+	cmd.AddCommand(newSinkFlushCmd())
+
+	return cmd
+}
+
+func newSinkAddCmd() *cobra.Command {
+	var (
+		sink  domain.AuditSink
+		token string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add or replace a remote sink",
+		Example: `  # Central syslog over TLS
+  nylas audit sink add siem --type syslog --url tls://logs.example.com:6514
+
+  # HTTPS collector with a bearer token
+  nylas audit sink add collector --type https --url https://audit.example.com/ingest --token $TOKEN
+
+  # S3 bucket, 100 entries per object
+  nylas audit sink add archive --type s3 --url s3://acme-audit/nylas-cli --region us-west-2 --batch-size 100
+
+  # Check delivery
+  nylas audit test-sink collector`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sink.Name = args[0]
+			if err := audit.ValidateSink(sink); err != nil {
+				return common.NewUserError(err.Error(), "Run 'nylas audit sink --help' for the URL of each type")
+			}
+			if token != "" && sink.Type != domain.AuditSinkHTTPS {
+				return common.NewUserError("--token applies only to https sinks", "")
+			}
+
+			store, err := audit.NewFileStore("")
+			if err != nil {
+				return fmt.Errorf("open audit store: %w", err)
+			}
+			cfg, err := store.GetConfig()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+
+			if token != "" {
+				secrets, err := openSinkSecrets()
+				if err != nil {
+					return common.WrapGetError("secret store", err)
+				}
+				if err := secrets.Set(ports.AuditSinkSecretKey(sink.Name), token); err != nil {
+					return common.WrapSaveError("sink token", err)
+				}
+			}
+
+			if existing := cfg.Sink(sink.Name); existing != nil {
+				*existing = sink
+			} else {
+				cfg.Sinks = append(cfg.Sinks, sink)
+			}
+			if err := store.SaveConfig(cfg); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+
+			common.PrintSuccess("Added %s sink %s", sink.Type, sink.Name)
+			if !cfg.Enabled {
+				fmt.Println(common.Dim.Sprintf("Audit logging is disabled; enable it with: nylas audit logs enable"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar((*string)(&sink.Type), "type", "", "Sink type: syslog, https or s3 (required)")
+	cmd.Flags().StringVar(&sink.URL, "url", "", "Sink address (required; see 'nylas audit sink --help')")
+	cmd.Flags().StringVar(&sink.Region, "region", "", "AWS region for s3 (default: AWS_REGION or us-east-1)")
+	cmd.Flags().StringVar(&sink.Endpoint, "endpoint", "", "S3-compatible endpoint URL, e.g. for MinIO")
+	cmd.Flags().IntVar(&sink.BatchSize, "batch-size", 0, fmt.Sprintf("Entries per delivery (default %d)", domain.DefaultAuditSinkBatchSize))
+	cmd.Flags().StringVar(&token, "token", "", "Bearer token for https sinks, kept in the secret store")
+	_ = cmd.MarkFlagRequired("type")
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+// sinkView is a configured sink with the number of entries queued for it.
+type sinkView struct {
+	domain.AuditSink
+	Pending int `json:"pending"`
+}
+
+func newSinkListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List remote sinks and their queues",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store, err := audit.NewFileStore("")
+			if err != nil {
+				return fmt.Errorf("open audit store: %w", err)
+			}
+			cfg, err := store.GetConfig()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+
+			pending := make(map[string]int, len(cfg.Sinks))
+			for _, status := range store.SinkStatus() {
+				pending[status.Sink] = status.Pending
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				views := make([]sinkView, 0, len(cfg.Sinks))
+				for _, sink := range cfg.Sinks {
+					views = append(views, sinkView{AuditSink: sink, Pending: pending[sink.Name]})
+				}
+				return common.GetOutputWriter(cmd).Write(views)
+			}
+
+			if len(cfg.Sinks) == 0 {
+				common.PrintEmptyStateWithHint("audit sinks", "Add one with: nylas audit sink add <name> --type https --url <url>")
+				return nil
+			}
+			table := common.NewTable("NAME", "TYPE", "URL", "BATCH", "QUEUED")
+			for _, sink := range cfg.Sinks {
+				table.AddRow(sink.Name, string(sink.Type), sink.URL,
+					fmt.Sprint(sink.EffectiveBatchSize()), fmt.Sprint(pending[sink.Name]))
+			}
+			table.Render()
+			return nil
+		},
+	}
+}
+
+func newSinkRemoveCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a remote sink and discard its queue",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			store, err := audit.NewFileStore("")
+			if err != nil {
+				return fmt.Errorf("open audit store: %w", err)
+			}
+			cfg, err := store.GetConfig()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if cfg.Sink(name) == nil {
+				return common.NewUserError(fmt.Sprintf("no audit sink named %q", name), "List sinks with: nylas audit sink list")
+			}
+
+			queued := 0
+			for _, status := range store.SinkStatus() {
+				if status.Sink == name {
+					queued = status.Pending
+				}
+			}
+			if queued > 0 && !yes && !common.Confirm(fmt.Sprintf("Discard %d undelivered entries for %s?", queued, name), false) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+
+			cfg.Sinks = removeSink(cfg.Sinks, name)
+			if err := store.SaveConfig(cfg); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			if err := store.ClearOutbox(name); err != nil {
+				return err
+			}
+			if secrets, err := openSinkSecrets(); err == nil {
+				_ = secrets.Delete(ports.AuditSinkSecretKey(name))
+			}
+
+			common.PrintSuccess("Removed sink %s", name)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Discard undelivered entries without asking")
+
+	return cmd
+}
+
+func newSinkFlushCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "flush",
+		Short: "Send every queued entry now",
+		Long: `Send every queued entry to its sink now, ignoring batch size and the
+retry backoff. Entries are otherwise sent as commands run.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store, err := audit.NewFileStore("")
+			if err != nil {
+				return fmt.Errorf("open audit store: %w", err)
+			}
+
+			results := store.FlushSinks(context.Background(), true, SinkToken)
+			if len(results) == 0 {
+				common.PrintEmptyStateWithHint("audit sinks", "Add one with: nylas audit sink add <name> --type https --url <url>")
+				return nil
+			}
+
+			var failed []string
+			for _, result := range results {
+				if result.Err != nil {
+					failed = append(failed, result.Sink)
+					_, _ = common.Red.Printf("✗ %s: sent %d, %d still queued: %v\n", result.Sink, result.Sent, result.Pending, result.Err)
+					continue
+				}
+				common.PrintSuccess("%s: sent %d", result.Sink, result.Sent)
+				if result.Dropped > 0 {
+					common.PrintWarningStderr("%s: dropped %d oldest entries over the queue limit", result.Sink, result.Dropped)
+				}
+			}
+			if len(failed) > 0 {
+				return common.NewUserError("delivery failed for "+strings.Join(failed, ", "),
+					"Check the sink with: nylas audit test-sink "+failed[0])
+			}
+			return nil
+		},
+	}
+}
+
+func newTestSinkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "test-sink [name]",
+		Short: "Send a test entry to remote sinks",
+		Long: `Send one test entry, marked with details.test=true, to the named sink or to
+every sink, and report whether each delivery succeeded. Queued entries are
+not touched.`,
+		Example: `  # Test every sink
+  nylas audit test-sink
+
+  # Test one sink
+  nylas audit test-sink collector`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := audit.NewFileStore("")
+			if err != nil {
+				return fmt.Errorf("open audit store: %w", err)
+			}
+			cfg, err := store.GetConfig()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+
+			sinks := cfg.Sinks
+			if len(args) == 1 {
+				sink := cfg.Sink(args[0])
+				if sink == nil {
+					return common.NewUserError(fmt.Sprintf("no audit sink named %q", args[0]), "List sinks with: nylas audit sink list")
+				}
+				sinks = []domain.AuditSink{*sink}
+			}
+			if len(sinks) == 0 {
+				common.PrintEmptyStateWithHint("audit sinks", "Add one with: nylas audit sink add <name> --type https --url <url>")
+				return nil
+			}
+
+			var failed []string
+			for _, sink := range sinks {
+				token := ""
+				if sink.Type == domain.AuditSinkHTTPS {
+					token = SinkToken(sink.Name)
+				}
+				if err := audit.TestSink(cmd.Context(), sink, token); err != nil {
+					failed = append(failed, sink.Name)
+					_, _ = common.Red.Printf("✗ %s (%s): %v\n", sink.Name, sink.Type, err)
+					continue
+				}
+				common.PrintSuccess("%s (%s): delivered", sink.Name, sink.Type)
+			}
+			if len(failed) > 0 {
+				return common.NewUserError("test delivery failed for "+strings.Join(failed, ", "), "Check the sink URL and credentials")
+			}
+			return nil
+		},
+	}
+}
+
+func removeSink(sinks []domain.AuditSink, name string) []domain.AuditSink {
+	kept := sinks[:0]
+	for _, sink := range sinks {
+		if sink.Name != name {
+			kept = append(kept, sink)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nylas/cli/internal/adapters/audit"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSinkTest(t *testing.T) ports.SecretStore {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	secrets := keyring.NewMockSecretStore()
+	orig := openSinkSecrets
+	openSinkSecrets = func() (ports.SecretStore, error) { return secrets, nil }
+	t.Cleanup(func() { openSinkSecrets = orig })
+	return secrets
+}
+
+func runAuditCmd(t *testing.T, args ...string) error {
+	t.Helper()
+	cmd := NewAuditCmd()
+	cmd.SetArgs(args)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
+
+func loadAuditConfig(t *testing.T) *domain.AuditConfig {
+	t.Helper()
+	store, err := audit.NewFileStore("")
+	require.NoError(t, err)
+	cfg, err := store.GetConfig()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestSinkAddListRemove(t *testing.T) {
+	secrets := setupSinkTest(t)
+
+	require.NoError(t, runAuditCmd(t, "sink", "add", "collector", "--type", "https",
+		"--url", "https://audit.example.com/ingest", "--token", "tok", "--batch-size", "50"))
+	require.NoError(t, runAuditCmd(t, "sink", "add", "siem", "--type", "syslog", "--url", "tls://logs.example.com:6514"))
+
+	cfg := loadAuditConfig(t)
+	require.Len(t, cfg.Sinks, 2)
+	assert.Equal(t, 50, cfg.Sinks[0].BatchSize)
+	token, err := secrets.Get(ports.AuditSinkSecretKey("collector"))
+	require.NoError(t, err)
+	assert.Equal(t, "tok", token)
+
+	// Adding an existing name replaces it.
+	require.NoError(t, runAuditCmd(t, "sink", "add", "siem", "--type", "syslog", "--url", "udp://logs.example.com:514"))
+	cfg = loadAuditConfig(t)
+	require.Len(t, cfg.Sinks, 2)
+	assert.Equal(t, "udp://logs.example.com:514", cfg.Sink("siem").URL)
+
+	require.NoError(t, runAuditCmd(t, "sink", "list"))
+
+	require.NoError(t, runAuditCmd(t, "sink", "remove", "collector", "--yes"))
+	cfg = loadAuditConfig(t)
+	require.Len(t, cfg.Sinks, 1)
+	_, err = secrets.Get(ports.AuditSinkSecretKey("collector"))
+	assert.Error(t, err, "token should be deleted with the sink")
+
+	assert.Error(t, runAuditCmd(t, "sink", "remove", "collector", "--yes"))
+}
+
+func TestSinkAdd_Validation(t *testing.T) {
+	setupSinkTest(t)
+
+	assert.ErrorContains(t, runAuditCmd(t, "sink", "add", "c", "--type", "https", "--url", "http://audit.example.com"), "https")
+	assert.ErrorContains(t, runAuditCmd(t, "sink", "add", "c", "--type", "kafka", "--url", "kafka://b:9092"), "unknown sink type")
+	assert.ErrorContains(t, runAuditCmd(t, "sink", "add", "c", "--type", "syslog", "--url", "udp://h:514", "--token", "x"), "--token")
+	assert.Empty(t, loadAuditConfig(t).Sinks)
+}
+
+func TestTestSink(t *testing.T) {
+	secrets := setupSinkTest(t)
+	require.NoError(t, secrets.Set(ports.AuditSinkSecretKey("collector"), "tok"))
+
+	var got []audit.SinkRecord
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer server.Close()
+
+	require.NoError(t, runAuditCmd(t, "sink", "add", "collector", "--type", "https", "--url", server.URL))
+	require.NoError(t, runAuditCmd(t, "test-sink", "collector"))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "audit test-sink", got[0].Command)
+	assert.Equal(t, "true", got[0].Details["test"])
+	assert.Equal(t, "Bearer tok", auth)
+
+	server.Close()
+	assert.ErrorContains(t, runAuditCmd(t, "test-sink"), "test delivery failed for collector")
+	assert.ErrorContains(t, runAuditCmd(t, "test-sink", "missing"), "no audit sink")
+}
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/nylas/cli/internal/adapters/audit"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/spf13/cobra"
+)
+
+func newVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Check the audit log for tampering",
+		Long: `Check that the audit log has not been modified, reordered or cut short.
+
+Each entry stores the SHA-256 of the entry before it, and the newest hash is
+kept alongside the logs. verify recomputes every hash and walks the chain,
+reporting edited entries, missing entries, and entries removed from the end.
+Entries removed by retention or 'audit logs clear' are expected and not
+reported. Entries written before chaining existed are counted but cannot be
+checked.
+
+Someone who can rewrite the whole audit directory can rebuild a consistent
+chain, so also ship entries off the machine with 'nylas audit sink add'.
+
+Exits with an error when a problem is found.`,
+		Example: `  # Verify the audit log
+  nylas audit verify
+
+  # Machine-readable report
+  nylas audit verify --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store, err := audit.NewFileStore("")
+			if err != nil {
+				return fmt.Errorf("open audit store: %w", err)
+			}
+			cfg, err := store.GetConfig()
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if !cfg.Initialized {
+				return fmt.Errorf("audit logging not initialized. Run: nylas audit init")
+			}
+
+			report, err := store.Verify(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("verify audit log: %w", err)
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				if err := common.GetOutputWriter(cmd).Write(report); err != nil {
+					return err
+				}
+			} else {
+				printVerifyReport(report)
+			}
+
+			if !report.OK() {
+				return common.NewUserError(
+					fmt.Sprintf("audit log failed verification: %d problems", len(report.Problems)),
+					"Compare with a copy shipped to a remote sink, or 'nylas audit export' from a backup",
+				)
+			}
+			return nil
+		},
+	}
+}
+
+func printVerifyReport(report *audit.VerifyReport) {
+	if report.Entries == 0 && report.OK() {
+		fmt.Println("No chained entries to verify.")
+	}
+	if report.Entries > 0 {
+		fmt.Printf("Checked %d entries (seq %d-%d)\n", report.Entries, report.FirstSeq, report.LastSeq)
+	}
+	if report.PrunedThrough > 0 {
+		fmt.Println(common.Dim.Sprintf("Entries up to seq %d were removed by retention or clear", report.PrunedThrough))
+	}
+	if report.Unchained > 0 {
+		fmt.Println(common.Dim.Sprintf("%d entries predate hash chaining and were not checked", report.Unchained))
+	}
+
+	if report.OK() {
+		common.PrintSuccess("Audit log intact")
+		return
+	}
+
+	fmt.Println()
+	table := common.NewTable("PROBLEM", "SEQ", "LOCATION", "DETAIL")
+	for _, p := range report.Problems {
+		seq, location := "", ""
+		if p.Seq > 0 {
+			seq = fmt.Sprint(p.Seq)
+		}
+		if p.File != "" {
+			location = fmt.Sprintf("%s:%d", p.File, p.Line)
+		}
+		table.AddRow(p.Kind, seq, location, p.Detail)
+	}
+	table.Render()
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"os"
 	"os/user"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/nylas/cli/internal/adapters/audit"
+	auditcli "github.com/nylas/cli/internal/cli/audit"
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
@@ -15,6 +17,10 @@ import (
 	"golang.org/x/term"
 )
 
+// auditSinkFlushTimeout bounds how long a command waits on remote audit
+// sinks after it finishes.
+const auditSinkFlushTimeout = 5 * time.Second
+
 // AuditContext holds audit state during command execution.
 type AuditContext struct {
 	StartTime  time.Time
@@ -28,6 +34,9 @@ type AuditContext struct {
 	// Invoker tracking
 	Invoker       string // Username: "alice", "dependabot[bot]"
 	InvokerSource string // Source: "claude-code", "github-actions", "terminal"
+
+	// Details are command-specific facts recorded via common.RecordAuditDetail.
+	Details map[string]string
 }
 
 var (
@@ -56,6 +65,18 @@ func SetAuditGrantInfo(grantID, grantEmail string) {
 	}
 }
 
+// SetAuditDetail records a command-specific detail for the current audit entry.
+func SetAuditDetail(key, value string) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	if currentAudit != nil {
+		if currentAudit.Details == nil {
+			currentAudit.Details = make(map[string]string)
+		}
+		currentAudit.Details[key] = value
+	}
+}
+
 // initAuditHooks sets up the audit logging hooks on the root command.
 func initAuditHooks(rootCmd *cobra.Command) {
 	rootCmd.PersistentPreRunE = auditPreRun
@@ -66,32 +87,54 @@ func initAuditHooks(rootCmd *cobra.Command) {
 		SetAuditGrantInfo(grantID, "")
 	}
 
+	// Set up command detail hook
+	common.AuditDetailHook = SetAuditDetail
+
 	// Set up request tracking hook
 	ports.AuditRequestHook = SetAuditRequestInfo
 }
 
 // auditPreRun is called before every command execution.
 func auditPreRun(cmd *cobra.Command, args []string) error {
+	if err := common.ValidateOutputFlag(cmd); err != nil {
+		return err
+	}
+
 	// Apply the global --quiet flag process-wide so decorative output
-	// (success messages, tables, spinners, progress) is suppressed. Structured
-	// data is handled separately by the OutputWriter.
+	// (success messages, tables, spinners, progress, status symbols, colors)
+	// is suppressed. --ids implies it, so only the IDs reach stdout.
+	// Structured data is handled separately by the OutputWriter.
 	quiet, _ := cmd.Flags().GetBool("quiet")
+	quiet = quiet || common.IsIDsOnly(cmd)
 	common.SetQuiet(quiet)
+	if noColor, _ := cmd.Flags().GetBool("no-color"); noColor || quiet {
+		common.DisableColor()
+	}
+
+	// Select the environment profile before any command builds a client.
+	env, _ := cmd.Flags().GetString("env")
+	common.SetActiveEnvironment(env)
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	common.SetNoCache(noCache)
+	emitCode, _ := cmd.Flags().GetString("emit-code")
+	if err := common.SetEmitCode(emitCode); err != nil {
+		return err
+	}
 
 	// Don't audit help, version, or completion commands
 	if isExcludedCommand(cmd) {
 		return nil
 	}
 
-	// Don't audit audit commands (avoid recursion)
+	// Detect invoker identity
 	commandPath := getCommandPath(cmd)
+	invoker, invokerSource := getInvokerIdentity()
+
+	// Don't audit audit commands (avoid recursion)
 	if strings.HasPrefix(commandPath, "audit") {
-		return nil
+		return enforceInvokerPolicy(cmd, commandPath, invokerSource)
 	}
 
-	// Detect invoker identity
-	invoker, invokerSource := getInvokerIdentity()
-
 	auditMu.Lock()
 	currentAudit = &AuditContext{
 		StartTime:     time.Now(),
@@ -100,9 +143,13 @@ func auditPreRun(cmd *cobra.Command, args []string) error {
 		Invoker:       invoker,
 		InvokerSource: invokerSource,
 	}
+	if transcript := os.Getenv(transcriptEnv); transcript != "" {
+		currentAudit.Details = map[string]string{"transcript": transcript}
+	}
 	auditMu.Unlock()
 
-	return nil
+	// Checked after the audit context exists so refusals are logged.
+	return enforceInvokerPolicy(cmd, commandPath, invokerSource)
 }
 
 // auditPostRun is called after every command execution.
@@ -157,6 +204,7 @@ func logAuditEntry(ctx *AuditContext, status domain.AuditStatus, errMsg string)
 		Error:         errMsg,
 		Invoker:       ctx.Invoker,
 		InvokerSource: ctx.InvokerSource,
+		Details:       ctx.Details,
 	}
 
 	if cfg.LogRequestID && ctx.RequestID != "" {
@@ -166,7 +214,15 @@ func logAuditEntry(ctx *AuditContext, status domain.AuditStatus, errMsg string)
 		entry.HTTPStatus = ctx.HTTPStatus
 	}
 
-	_ = store.Log(entry)
+	if err := store.Log(entry); err != nil || len(cfg.Sinks) == 0 {
+		return
+	}
+
+	// Ship due batches to remote sinks. Failures stay queued and back off;
+	// 'nylas audit sink flush' reports them.
+	flushCtx, cancel := context.WithTimeout(context.Background(), auditSinkFlushTimeout)
+	defer cancel()
+	store.FlushSinks(flushCtx, false, auditcli.SinkToken)
 }
 
 // getCommandPath returns the full command path (e.g., "email list").
@@ -234,8 +290,8 @@ func sanitizeArgs(args []string) []string {
 			}
 		}
 
-		// Check for API key patterns
-		if strings.HasPrefix(arg, "nyk_") || isLongBase64(arg) {
+		// Check for API key and grant token patterns
+		if strings.HasPrefix(arg, "nyk_") || strings.HasPrefix(arg, "nylt_") || isLongBase64(arg) {
 			result[i] = "[REDACTED]"
 			continue
 		}
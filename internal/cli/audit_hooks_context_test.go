@@ -123,3 +123,34 @@ func TestSetAuditGrantInfo(t *testing.T) {
 	currentAudit = nil
 	auditMu.Unlock()
 }
+
+func TestSetAuditDetail(t *testing.T) {
+	// No-op without an audit context
+	auditMu.Lock()
+	currentAudit = nil
+	auditMu.Unlock()
+	SetAuditDetail("transport", "smtp")
+
+	auditMu.Lock()
+	currentAudit = &AuditContext{}
+	auditMu.Unlock()
+
+	SetAuditDetail("transport", "nylas")
+	SetAuditDetail("transport", "smtp")
+	SetAuditDetail("fallback_reason", "rate limited")
+
+	auditMu.Lock()
+	got := currentAudit.Details
+	currentAudit = nil
+	auditMu.Unlock()
+
+	want := map[string]string{"transport": "smtp", "fallback_reason": "rate limited"}
+	if len(got) != len(want) {
+		t.Fatalf("Details = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Details[%q] = %q, want %q", k, got[k], v)
+		}
+	}
+}
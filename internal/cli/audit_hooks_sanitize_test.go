@@ -166,6 +166,11 @@ func TestSanitizeArgs(t *testing.T) {
 			args: []string{"nyk_abcdef123456789012345678901234567890"},
 			want: []string{"[REDACTED]"},
 		},
+		{
+			name: "redacts nylt_ grant tokens",
+			args: []string{"auth", "token", "verify", "nylt_eyJqdGkiOiJ0b2sifQ.c2ln"},
+			want: []string{"auth", "token", "verify", "[REDACTED]"},
+		},
 		{
 			name: "redacts long base64 strings",
 			args: []string{"YWJjZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXoxMjM0NTY3ODkw"},
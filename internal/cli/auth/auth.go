@@ -3,6 +3,9 @@ package auth
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 )
 
 // NewAuthCmd creates the auth command group.
@@ -32,21 +35,23 @@ Commands:
 API reference: https://developer.nylas.com/docs/v3/auth/`,
 	}
 
-	cmd.AddCommand(newLoginCmd())
+	common.RequireCapabilities(cmd, domain.CapabilityKeychain)
+
+	cmd.AddCommand(common.RunLocally(common.RequireCapabilities(common.RequireScopes(common.DeclareOutput(newLoginCmd(), domain.EWSCheckResult{}), domain.ScopeApplicationAPI), domain.CapabilityBrowser, domain.CapabilityNetwork)))
 	cmd.AddCommand(newLogoutCmd())
-	cmd.AddCommand(newStatusCmd())
-	cmd.AddCommand(newWhoamiCmd())
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newStatusCmd(), map[string]any{}))
+	cmd.AddCommand(common.DeclareOutput(newWhoamiCmd(), map[string]string{}))
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.GrantStatus{}))
+	cmd.AddCommand(common.DeclareOutput(newShowCmd(), domain.Grant{}))
 	cmd.AddCommand(newSwitchCmd())
 	cmd.AddCommand(newAddCmd())
 	cmd.AddCommand(newRemoveCmd())
 	cmd.AddCommand(newConfigCmd())
 	cmd.AddCommand(newRevokeCmd())
-	cmd.AddCommand(newTokenCmd())
-	cmd.AddCommand(newProvidersCmd())
-	cmd.AddCommand(newDetectCmd())
-	cmd.AddCommand(newScopesCmd())
+	cmd.AddCommand(common.DeclareOutput(newTokenCmd(), map[string]string{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newProvidersCmd(), []domain.Connector{}), domain.ScopeApplicationAPI))
+	cmd.AddCommand(common.DeclareOutput(newDetectCmd(), detectResult{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newScopesCmd(), scopesResult{}), domain.ScopeApplicationAPI))
 	cmd.AddCommand(newMigrateCmd())
 
 	return cmd
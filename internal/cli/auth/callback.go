@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/oauth"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// callbackFlags move the OAuth callback server off its default
+// http://localhost:<callback_port>/callback, e.g. when that port is taken or
+// the browser reaches the CLI through a container port or a tunnel.
+type callbackFlags struct {
+	port        int
+	host        string
+	redirectURI string
+}
+
+func (f *callbackFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.port, "callback-port", 0, "Port for the OAuth callback server (default: callback_port from config)")
+	cmd.Flags().StringVar(&f.host, "callback-host", "", "Address for the OAuth callback server to listen on (default: loopback)")
+	cmd.Flags().StringVar(&f.redirectURI, "redirect-uri", "", "Redirect URI to send to the provider, e.g. a tunnel to the callback server")
+}
+
+// used reports whether any callback flag was given.
+func (f *callbackFlags) used(cmd *cobra.Command) bool {
+	for _, name := range []string{"callback-port", "callback-host", "redirect-uri"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the flag values without touching the network.
+func (f *callbackFlags) validate() error {
+	if f.port < 0 || f.port > 65535 {
+		return common.NewInputError(fmt.Sprintf("invalid --callback-port %d: must be between 1 and 65535", f.port))
+	}
+	if f.host != "" && f.host != "localhost" && net.ParseIP(f.host) == nil {
+		return common.NewUserError(
+			fmt.Sprintf("invalid --callback-host %q: must be an IP address or localhost", f.host),
+			"Use 127.0.0.1 for loopback only, or 0.0.0.0 to listen on all interfaces (e.g. in a container)",
+		)
+	}
+	if f.redirectURI == "" {
+		return nil
+	}
+
+	u, err := url.Parse(f.redirectURI)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return common.NewInputError(fmt.Sprintf("invalid --redirect-uri %q: must be an absolute http or https URL", f.redirectURI))
+	}
+	if u.Scheme == "http" && !isLoopbackHost(u.Hostname()) {
+		return common.NewUserError(
+			fmt.Sprintf("invalid --redirect-uri %q: only localhost redirect URIs may use http", f.redirectURI),
+			"Use the https URL of your tunnel",
+		)
+	}
+	return nil
+}
+
+// options returns the callback server's port and options, taking the port
+// from cfg when --callback-port isn't set.
+func (f *callbackFlags) options(cfg *domain.Config) (int, oauth.CallbackOptions) {
+	port := f.port
+	if port == 0 && cfg != nil {
+		port = cfg.CallbackPort
+	}
+	return port, oauth.CallbackOptions{Host: f.host, RedirectURI: f.redirectURI}
+}
+
+// checkRedirectURI makes sure the redirect URI the callback flags lead to is
+// registered for the application, since the provider flow fails only after
+// sign-in otherwise. A failed lookup is reported but doesn't stop login.
+func checkRedirectURI(f *callbackFlags) error {
+	configStore, _, _, err := createDependencies()
+	if err != nil {
+		return err
+	}
+	cfg, _ := configStore.Load()
+	redirectURI := f.redirectURI
+	if redirectURI == "" {
+		port, opts := f.options(cfg)
+		redirectURI = oauth.LocalRedirectURI(opts.Host, port)
+	}
+
+	client, err := common.GetNylasClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := common.CreateContext()
+	registered, err := client.ListCallbackURIs(ctx)
+	cancel()
+	if err != nil {
+		_, _ = common.Yellow.Printf("⚠ Could not check the application's callback URIs: %v\n", err)
+		return nil
+	}
+
+	return redirectURIRegistered(redirectURI, registered)
+}
+
+// redirectURIRegistered returns an error naming the registered URIs unless
+// redirectURI is one of them.
+func redirectURIRegistered(redirectURI string, registered []domain.CallbackURI) error {
+	urls := make([]string, 0, len(registered))
+	for _, cb := range registered {
+		if cb.URL == redirectURI {
+			return nil
+		}
+		urls = append(urls, cb.URL)
+	}
+
+	registeredList := "none"
+	if len(urls) > 0 {
+		registeredList = strings.Join(urls, ", ")
+	}
+	return common.NewUserErrorWithSuggestions(
+		fmt.Sprintf("redirect URI %s is not registered for this application (registered: %s)", redirectURI, registeredList),
+		"Add it with: nylas admin callback-uris create --url "+redirectURI,
+		"Or pass --redirect-uri with one of the registered URIs",
+	)
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestCallbackFlagsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   callbackFlags
+		wantErr string
+	}{
+		{name: "defaults", flags: callbackFlags{}},
+		{name: "port and host", flags: callbackFlags{port: 9191, host: "127.0.0.1"}},
+		{name: "all interfaces", flags: callbackFlags{host: "0.0.0.0"}},
+		{name: "tunnel", flags: callbackFlags{redirectURI: "https://mytunnel.example/callback"}},
+		{name: "localhost over http", flags: callbackFlags{redirectURI: "http://127.0.0.1:9191/callback"}},
+		{name: "port out of range", flags: callbackFlags{port: 70000}, wantErr: "--callback-port"},
+		{name: "host name", flags: callbackFlags{host: "example.com"}, wantErr: "--callback-host"},
+		{name: "relative URI", flags: callbackFlags{redirectURI: "/callback"}, wantErr: "absolute http or https"},
+		{name: "remote http", flags: callbackFlags{redirectURI: "http://mytunnel.example/callback"}, wantErr: "only localhost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.flags.validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCallbackFlagsOptionsUsesConfigPort(t *testing.T) {
+	cfg := &domain.Config{CallbackPort: 9007}
+
+	port, _ := (&callbackFlags{}).options(cfg)
+	assert.Equal(t, 9007, port)
+
+	port, opts := (&callbackFlags{port: 9191, host: "127.0.0.1"}).options(cfg)
+	assert.Equal(t, 9191, port)
+	assert.Equal(t, "127.0.0.1", opts.Host)
+}
+
+func TestRedirectURIRegistered(t *testing.T) {
+	registered := []domain.CallbackURI{
+		{URL: "http://localhost:9007/callback"},
+		{URL: "https://mytunnel.example/callback"},
+	}
+
+	assert.NoError(t, redirectURIRegistered("https://mytunnel.example/callback", registered))
+
+	err := redirectURIRegistered("http://localhost:9191/callback", registered)
+	assert.ErrorContains(t, err, "http://localhost:9191/callback is not registered")
+	assert.ErrorContains(t, err, "registered: http://localhost:9007/callback, https://mytunnel.example/callback")
+
+	assert.ErrorContains(t, redirectURIRegistered("http://localhost:9191/callback", nil), "registered: none")
+}
+
+func TestLoginCallbackFlagsNeedBrowserSignIn(t *testing.T) {
+	cmd := newLoginCmd()
+	cmd.SetArgs([]string{"--provider", "icloud", "--callback-port", "9191"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "only apply to browser sign-in")
+}
@@ -1,7 +1,6 @@
 package auth
 
 import (
-	"encoding/json"
 	"fmt"
 	"slices"
 	"strings"
@@ -11,6 +10,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// detectResult is the provider guessed for an email address.
+type detectResult struct {
+	Email    string `json:"email"`
+	Domain   string `json:"domain"`
+	Provider string `json:"provider"`
+	Note     string `json:"note,omitempty"`
+}
+
 func newDetectCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "detect <email>",
@@ -52,12 +59,7 @@ custom domains, verify the provider with your IT administrator.`,
 			domainPart := parts[1]
 			provider := detectProvider(domainPart)
 
-			result := struct {
-				Email    string `json:"email"`
-				Domain   string `json:"domain"`
-				Provider string `json:"provider"`
-				Note     string `json:"note,omitempty"`
-			}{
+			result := detectResult{
 				Email:    email,
 				Domain:   domainPart,
 				Provider: string(provider),
@@ -77,10 +79,8 @@ custom domains, verify the provider with your IT administrator.`,
 				result.Note = "Use IMAP for generic email providers. Configure IMAP/SMTP settings during authentication."
 			}
 
-			if common.IsJSON(cmd) {
-				enc := json.NewEncoder(cmd.OutOrStdout())
-				enc.SetIndent("", "  ")
-				return enc.Encode(result)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(result)
 			}
 
 			// Display as formatted text
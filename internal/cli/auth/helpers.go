@@ -10,6 +10,7 @@ import (
 	"github.com/nylas/cli/internal/adapters/oauth"
 	authapp "github.com/nylas/cli/internal/app/auth"
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
 )
 
@@ -89,6 +90,12 @@ func createGrantStore() (ports.GrantStore, error) {
 
 // createAuthService creates the auth service.
 func createAuthService() (*authapp.Service, *authapp.ConfigService, error) {
+	return createOAuthService(&callbackFlags{})
+}
+
+// createOAuthService creates the auth service with its OAuth callback server
+// set up from cb.
+func createOAuthService(cb *callbackFlags) (*authapp.Service, *authapp.ConfigService, error) {
 	configStore, secretStore, grantStore, err := createDependencies()
 	if err != nil {
 		return nil, nil, err
@@ -99,7 +106,11 @@ func createAuthService() (*authapp.Service, *authapp.ConfigService, error) {
 
 	// Create OAuth server
 	cfg, _ := configStore.Load()
-	oauthServer := oauth.NewCallbackServer(cfg.CallbackPort)
+	if cfg == nil {
+		cfg = domain.DefaultConfig()
+	}
+	port, opts := cb.options(cfg)
+	oauthServer := oauth.NewCallbackServerWithOptions(port, opts)
 
 	// Create browser
 	browserAdapter := browser.NewDefaultBrowser()
@@ -18,7 +18,13 @@ var oauthProviders = map[domain.Provider]bool{
 }
 
 func newLoginCmd() *cobra.Command {
-	var provider string
+	var (
+		provider  string
+		ewsFlags  ewsLoginFlags
+		reauth    string
+		addScopes []string
+		callback  callbackFlags
+	)
 
 	cmd := &cobra.Command{
 		Use:   "login",
@@ -33,7 +39,26 @@ OAuth providers (opens browser):
 Credential providers (prompts for credentials):
   icloud     iCloud (requires app-specific password)
   yahoo      Yahoo (requires app password)
-  imap       Generic IMAP server`,
+  imap       Generic IMAP server
+
+Exchange on-premises accounts can also be connected without a browser: pass
+--ews-host with the Exchange server. The EWS endpoint is checked first (TLS,
+offered auth schemes and, when Basic auth is offered, the credentials), then
+the grant is created with the username and password.
+
+--reauth signs an existing Google, Microsoft or Exchange grant in again, for
+example after its access was revoked. Add --add-scopes to request more
+access at the same time: the grant keeps its current scopes and gains the
+provider permissions behind the named scopes (email, email.read,
+email.modify, email.send, calendar, calendar.read, contacts,
+contacts.read). See what a grant is missing with 'nylas auth scopes'.
+
+The browser is sent back to http://localhost:<callback_port>/callback
+(callback_port defaults to 9007). When that port is taken, or the browser
+can't reach the CLI directly (containers, remote machines), change it with
+--callback-port and --callback-host, or pass --redirect-uri with a tunnel
+that forwards to the callback server. The redirect URI must be registered
+for the application; login checks this before opening the browser.`,
 		Example: `  # Login with Google (default)
   nylas auth login
 
@@ -43,6 +68,12 @@ Credential providers (prompts for credentials):
   # Login with Exchange on-premises
   nylas auth login --provider ews
 
+  # Connect Exchange on-premises with a username and password
+  nylas auth login --provider ews --ews-host mail.example.com --username user@example.com
+
+  # Only check that the EWS endpoint is reachable
+  nylas auth login --provider ews --ews-host mail.example.com --check-only
+
   # Login with iCloud
   nylas auth login --provider icloud
 
@@ -50,13 +81,47 @@ Credential providers (prompts for credentials):
   nylas auth login --provider yahoo
 
   # Login with a generic IMAP server
-  nylas auth login --provider imap`,
+  nylas auth login --provider imap
+
+  # Add calendar and contacts access to an existing grant
+  nylas auth login --reauth <grant-id> --add-scopes calendar,contacts
+
+  # Receive the callback through a tunnel on another port
+  nylas auth login --callback-port 9191 --callback-host 127.0.0.1 --redirect-uri https://mytunnel.example/callback`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			p, err := parseLoginProvider(provider)
 			if err != nil {
 				return err
 			}
 
+			if cmd.Flags().Changed("add-scopes") && reauth == "" {
+				return common.NewUserError("--add-scopes needs the grant to add them to",
+					"Use: nylas auth login --reauth <grant-id> --add-scopes "+strings.Join(addScopes, ","))
+			}
+			if reauth != "" && (cmd.Flags().Changed("provider") || ewsFlags.used(cmd)) {
+				return common.NewUserError("--reauth uses the grant's own provider",
+					"Remove --provider and the --ews-* flags")
+			}
+
+			if callback.used(cmd) {
+				if ewsFlags.used(cmd) || (reauth == "" && !oauthProviders[p]) {
+					return common.NewUserError("--callback-port, --callback-host and --redirect-uri only apply to browser sign-in",
+						"Use them with --provider google, microsoft or ews, or with --reauth")
+				}
+				if err := callback.validate(); err != nil {
+					return err
+				}
+			}
+			if ewsFlags.used(cmd) {
+				if p != domain.ProviderEWS {
+					return common.NewUserError("--ews-host and related flags only apply to Exchange",
+						"Use them with --provider ews")
+				}
+				if err := common.ValidateRequiredFlag("--ews-host", ewsFlags.host); err != nil {
+					return err
+				}
+			}
+
 			configSvc, _, _, err := createConfigService()
 			if err != nil {
 				return err
@@ -66,20 +131,36 @@ Credential providers (prompts for credentials):
 				return fmt.Errorf("nylas not configured - run 'nylas auth config' first")
 			}
 
+			if callback.used(cmd) {
+				if err := checkRedirectURI(&callback); err != nil {
+					return err
+				}
+			}
+
+			if reauth != "" {
+				return loginReauth(reauth, addScopes, &callback)
+			}
+			if ewsFlags.used(cmd) {
+				return loginEWS(cmd, &ewsFlags)
+			}
 			if oauthProviders[p] {
-				return loginOAuth(p)
+				return loginOAuth(p, &callback)
 			}
 			return loginCredentials(p)
 		},
 	}
 
 	cmd.Flags().StringVarP(&provider, "provider", "p", "google", "Email provider (google, microsoft, ews, icloud, yahoo, imap)")
+	ewsFlags.addFlags(cmd)
+	cmd.Flags().StringVar(&reauth, "reauth", "", "Re-authenticate an existing grant (ID or email)")
+	cmd.Flags().StringSliceVar(&addScopes, "add-scopes", nil, "With --reauth, scopes to add (e.g. calendar,contacts)")
+	callback.addFlags(cmd)
 
 	return cmd
 }
 
-func loginOAuth(provider domain.Provider) error {
-	authSvc, _, err := createAuthService()
+func loginOAuth(provider domain.Provider, callback *callbackFlags) error {
+	authSvc, _, err := createOAuthService(callback)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/ews"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newEWSChecker is replaced in tests.
+var newEWSChecker = func() ports.EWSChecker { return ews.NewChecker() }
+
+// ewsLoginFlags connect an on-premises Exchange account with credentials
+// instead of the browser.
+type ewsLoginFlags struct {
+	host      string
+	username  string
+	skipCheck bool
+	checkOnly bool
+	insecure  bool
+}
+
+func (f *ewsLoginFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.host, "ews-host", "", "Exchange server host or EWS URL; connects with username and password instead of the browser")
+	cmd.Flags().StringVar(&f.username, "username", "", "Exchange username, e.g. user@example.com or DOMAIN\\user (with --ews-host)")
+	cmd.Flags().BoolVar(&f.skipCheck, "skip-check", false, "Skip the EWS connectivity check (with --ews-host)")
+	cmd.Flags().BoolVar(&f.checkOnly, "check-only", false, "Only run the EWS connectivity check (with --ews-host)")
+	cmd.Flags().BoolVar(&f.insecure, "insecure", false, "Accept a self-signed certificate during the check (with --ews-host)")
+}
+
+// used reports whether any credential-login flag was given.
+func (f *ewsLoginFlags) used(cmd *cobra.Command) bool {
+	for _, name := range []string{"ews-host", "username", "skip-check", "check-only", "insecure"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// loginEWS connects an Exchange account through custom auth after checking
+// that the EWS endpoint is reachable and, if the server allows Basic auth,
+// that it accepts the credentials.
+func loginEWS(cmd *cobra.Command, f *ewsLoginFlags) error {
+	endpoint, err := domain.EWSEndpoint(f.host)
+	if err != nil {
+		return common.NewInputError(err.Error())
+	}
+	if f.skipCheck && f.checkOnly {
+		return common.NewMutuallyExclusiveError("skip-check", "check-only")
+	}
+
+	username := strings.TrimSpace(f.username)
+	if username == "" {
+		if username, err = common.InputPrompt("Exchange username (email or DOMAIN\\user)", ""); err != nil {
+			return err
+		}
+		username = strings.TrimSpace(username)
+	}
+	if username == "" {
+		return common.NewUserError("an Exchange username is required", "Pass --username or run in an interactive terminal")
+	}
+	password, err := common.PasswordPrompt("Exchange password")
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return common.NewUserError("an Exchange password is required", "Run the login in an interactive terminal")
+	}
+
+	if !f.skipCheck {
+		opts := domain.EWSCheckOptions{Endpoint: endpoint, Username: username, Password: password, InsecureSkipVerify: f.insecure}
+		result, err := common.RunWithSpinnerResult("Checking "+endpoint+"...", func() (*domain.EWSCheckResult, error) {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+			return newEWSChecker().Check(ctx, opts)
+		})
+		if f.checkOnly && err == nil && common.IsStructuredOutput(cmd) {
+			return common.GetOutputWriter(cmd).Write(result)
+		}
+		if err := reportEWSCheck(result, err); err != nil {
+			return err
+		}
+		if f.checkOnly {
+			return nil
+		}
+	}
+
+	authSvc, _, err := createAuthService()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := common.CreateLongContext()
+	defer cancel()
+
+	var grant *domain.Grant
+	err = common.RunWithSpinner("Authenticating...", func() error {
+		grant, err = authSvc.LoginWithCredentials(ctx, string(domain.ProviderEWS), ewsGrantSettings(endpoint, username, password))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	printLoginSuccess(grant)
+	return nil
+}
+
+// ewsGrantSettings are the custom-auth settings for an Exchange grant. The
+// host is sent without the default EWS path.
+func ewsGrantSettings(endpoint, username, password string) map[string]any {
+	host := strings.TrimPrefix(endpoint, "https://")
+	host = strings.TrimSuffix(host, domain.EWSDefaultPath)
+	return map[string]any{
+		"username":             username,
+		"password":             password,
+		"exchange_server_host": host,
+	}
+}
+
+// reportEWSCheck prints the pre-check outcome and turns a failed check into
+// an actionable error.
+func reportEWSCheck(result *domain.EWSCheckResult, err error) error {
+	if err != nil {
+		return common.NewUserError(err.Error(),
+			"Check the host name and that EWS is published to this network; use --insecure for a self-signed certificate")
+	}
+
+	common.PrintSuccess("EWS endpoint reachable: %s", result.Endpoint)
+	if len(result.AuthSchemes) > 0 {
+		fmt.Printf("  Auth schemes: %s\n", strings.Join(result.AuthSchemes, ", "))
+	}
+	switch {
+	case result.Authenticated:
+		version := ""
+		if result.ServerVersion != "" {
+			version = " (Exchange " + result.ServerVersion + ")"
+		}
+		common.PrintSuccess("Credentials accepted%s", version)
+	case result.AuthTested:
+		return common.NewUserError("Exchange rejected the username or password",
+			"Try the DOMAIN\\user form of the username, or check that the account isn't locked")
+	default:
+		fmt.Println("  Credentials not tested: the server doesn't offer Basic authentication.")
+	}
+	return nil
+}
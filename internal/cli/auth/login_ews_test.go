@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+func TestEWSGrantSettings(t *testing.T) {
+	settings := ewsGrantSettings("https://mail.example.com/EWS/Exchange.asmx", `CORP\me`, "secret")
+	assert.Equal(t, map[string]any{
+		"username":             `CORP\me`,
+		"password":             "secret",
+		"exchange_server_host": "mail.example.com",
+	}, settings)
+
+	settings = ewsGrantSettings("https://mail.example.com:8443/custom/ews.asmx", "me", "secret")
+	assert.Equal(t, "mail.example.com:8443/custom/ews.asmx", settings["exchange_server_host"])
+}
+
+func TestReportEWSCheck(t *testing.T) {
+	assert.NoError(t, reportEWSCheck(&domain.EWSCheckResult{Endpoint: "https://x", AuthTested: true, Authenticated: true}, nil))
+	assert.NoError(t, reportEWSCheck(&domain.EWSCheckResult{Endpoint: "https://x", AuthSchemes: []string{"NTLM"}}, nil))
+
+	err := reportEWSCheck(&domain.EWSCheckResult{Endpoint: "https://x", AuthTested: true}, nil)
+	assert.ErrorContains(t, err, "rejected the username or password")
+
+	err = reportEWSCheck(&domain.EWSCheckResult{}, errors.New("could not reach https://x"))
+	assert.ErrorContains(t, err, "could not reach")
+}
+
+type stubEWSChecker struct{ opts domain.EWSCheckOptions }
+
+func (s *stubEWSChecker) Check(_ context.Context, opts domain.EWSCheckOptions) (*domain.EWSCheckResult, error) {
+	s.opts = opts
+	return &domain.EWSCheckResult{Endpoint: opts.Endpoint}, nil
+}
+
+func TestLoginCmd_EWSFlags(t *testing.T) {
+	t.Run("rejected for other providers", func(t *testing.T) {
+		cmd := newLoginCmd()
+		cmd.SetArgs([]string{"--provider", "google", "--ews-host", "mail.example.com"})
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		assert.ErrorContains(t, cmd.Execute(), "only apply to Exchange")
+	})
+
+	t.Run("requires ews-host", func(t *testing.T) {
+		cmd := newLoginCmd()
+		cmd.SetArgs([]string{"--provider", "ews", "--username", "me@example.com"})
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		assert.ErrorContains(t, cmd.Execute(), "--ews-host flag is required")
+	})
+
+	t.Run("check-only and skip-check conflict", func(t *testing.T) {
+		cmd := newLoginCmd()
+		require.NoError(t, cmd.ParseFlags([]string{"--ews-host", "mail.example.com", "--check-only", "--skip-check"}))
+		f := &ewsLoginFlags{host: "mail.example.com", checkOnly: true, skipCheck: true}
+		assert.True(t, f.used(cmd))
+		assert.Error(t, loginEWS(cmd, f))
+	})
+
+	t.Run("invalid host", func(t *testing.T) {
+		checker := &stubEWSChecker{}
+		orig := newEWSChecker
+		newEWSChecker = func() ports.EWSChecker { return checker }
+		t.Cleanup(func() { newEWSChecker = orig })
+
+		err := loginEWS(newLoginCmd(), &ewsLoginFlags{host: "http://mail.example.com"})
+		assert.ErrorContains(t, err, "https")
+		assert.Empty(t, checker.opts.Endpoint)
+	})
+}
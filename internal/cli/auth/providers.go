@@ -1,7 +1,6 @@
 package auth
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -46,10 +45,8 @@ This command shows connectors configured for your Nylas application.`,
 			}
 			connectors = common.FilterVisibleConnectors(connectors)
 
-			if common.IsJSON(cmd) {
-				enc := json.NewEncoder(cmd.OutOrStdout())
-				enc.SetIndent("", "  ")
-				return enc.Encode(connectors)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(connectors)
 			}
 
 			renderProviders(cmd.OutOrStdout(), connectors)
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// loginReauth re-authenticates an existing grant through its provider's
+// consent screen, requesting its current scopes plus addScopes.
+func loginReauth(identifier string, addScopes []string, callback *callbackFlags) error {
+	grantID, err := common.ResolveGrantIdentifier(identifier)
+	if err != nil {
+		return err
+	}
+
+	client, err := common.GetNylasClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := common.CreateContext()
+	grant, err := client.GetGrant(ctx, grantID)
+	cancel()
+	if err != nil {
+		return common.WrapGetError("grant", err)
+	}
+
+	opts, added, err := reauthOptions(grant, addScopes)
+	if err != nil {
+		return err
+	}
+	if len(addScopes) > 0 && len(added) == 0 {
+		fmt.Printf("Grant %s already has the requested scopes.\n", grant.ID)
+		return nil
+	}
+
+	authSvc, _, err := createOAuthService(callback)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Opening browser to re-authenticate %s...\n", grant.Email)
+	fmt.Println("Sign in with the same account and approve the requested access.")
+
+	longCtx, longCancel := common.CreateLongContext()
+	defer longCancel()
+
+	newGrant, err := authSvc.LoginWithOptions(longCtx, grant.Provider, opts)
+	if err != nil {
+		return err
+	}
+
+	printLoginSuccess(newGrant)
+	if newGrant.ID != grant.ID {
+		_, _ = common.Yellow.Printf("\n⚠ Signed in as a different account, so a new grant was created; %s is unchanged.\n", grant.ID)
+		return nil
+	}
+	if len(added) > 0 {
+		fmt.Printf("  Added:    %s\n", strings.Join(added, ", "))
+	}
+	return nil
+}
+
+// reauthOptions builds the authentication URL options to re-authenticate
+// grant. With scope names, the grant's current provider scopes are requested
+// together with the ones backing those scopes, since requested scopes
+// replace the connector's defaults. added lists the provider scopes the
+// grant doesn't have yet.
+func reauthOptions(grant *domain.Grant, scopeNames []string) (opts domain.AuthURLOptions, added []string, err error) {
+	if !oauthProviders[grant.Provider] {
+		return opts, nil, common.NewUserError(
+			fmt.Sprintf("grant %s uses %s, which doesn't sign in through a browser", grant.ID, grant.Provider.DisplayName()),
+			"Remove it with 'nylas auth remove' and log in again with 'nylas auth login'",
+		)
+	}
+
+	opts.LoginHint = grant.Email
+	if len(scopeNames) == 0 {
+		return opts, nil, nil
+	}
+
+	scopes, err := domain.ParseGrantTokenScopes(scopeNames)
+	if err != nil {
+		return opts, nil, err
+	}
+	if len(grant.Scope) == 0 {
+		return opts, nil, common.NewUserError(
+			fmt.Sprintf("grant %s has no recorded scopes, so they can't be kept while adding more", grant.ID),
+			fmt.Sprintf("Run 'nylas auth login --reauth %s' without --add-scopes, then check 'nylas auth scopes %s'", grant.ID, grant.ID),
+		)
+	}
+
+	opts.Scopes = slices.Clone(grant.Scope)
+	for _, scope := range scopes {
+		perms := scope.ProviderPermissions(grant.Provider)
+		if len(perms) == 0 {
+			return opts, nil, common.NewUserError(
+				fmt.Sprintf("%s grants have no OAuth scope for %s", grant.Provider.DisplayName(), scope),
+				"Scopes can be added to Google and Microsoft grants",
+			)
+		}
+		if ok, _ := scope.GrantedBy(grant.Provider, grant.Scope); ok {
+			continue
+		}
+		for _, perm := range perms {
+			if !slices.Contains(opts.Scopes, perm) {
+				opts.Scopes = append(opts.Scopes, perm)
+				added = append(added, perm)
+			}
+		}
+	}
+	return opts, added, nil
+}
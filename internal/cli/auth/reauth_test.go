@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestReauthOptions(t *testing.T) {
+	grant := &domain.Grant{
+		ID:       "grant-1",
+		Email:    "user@example.com",
+		Provider: domain.ProviderGoogle,
+		Scope: []string{
+			"https://www.googleapis.com/auth/gmail.modify",
+			"https://www.googleapis.com/auth/calendar.readonly",
+		},
+	}
+
+	t.Run("reauth keeps connector scopes", func(t *testing.T) {
+		opts, added, err := reauthOptions(grant, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", opts.LoginHint)
+		assert.Empty(t, opts.Scopes)
+		assert.Empty(t, added)
+	})
+
+	t.Run("adds missing scopes to the current ones", func(t *testing.T) {
+		opts, added, err := reauthOptions(grant, []string{"calendar", "contacts", "email.read"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"https://www.googleapis.com/auth/gmail.modify",
+			"https://www.googleapis.com/auth/calendar.readonly",
+			"https://www.googleapis.com/auth/calendar",
+			"https://www.googleapis.com/auth/contacts",
+		}, opts.Scopes)
+		assert.Equal(t, opts.Scopes[2:], added)
+	})
+
+	t.Run("rejects unknown scopes", func(t *testing.T) {
+		_, _, err := reauthOptions(grant, []string{"drive"})
+		assert.ErrorContains(t, err, `unknown scope "drive"`)
+	})
+
+	t.Run("rejects credential providers", func(t *testing.T) {
+		_, _, err := reauthOptions(&domain.Grant{ID: "g", Provider: domain.ProviderIMAP}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("needs recorded scopes to add more", func(t *testing.T) {
+		_, _, err := reauthOptions(&domain.Grant{ID: "g", Provider: domain.ProviderMicrosoft}, []string{"calendar"})
+		assert.ErrorContains(t, err, "no recorded scopes")
+	})
+}
+
+func TestFeatureScopeReport(t *testing.T) {
+	root := &cobra.Command{Use: "nylas"}
+	calendar := common.RequireScopes(&cobra.Command{Use: "calendar"}, domain.ScopeCalendarRead)
+	calendar.AddCommand(common.RequireScopes(&cobra.Command{Use: "create"}, domain.ScopeCalendarWrite))
+	root.AddCommand(
+		calendar,
+		common.RequireScopes(&cobra.Command{Use: "admin"}, domain.ScopeApplicationAPI),
+		&cobra.Command{Use: "version"},
+	)
+
+	grant := &domain.Grant{Provider: domain.ProviderMicrosoft, Scope: []string{"Calendars.Read"}}
+	features := featureScopeReport(root, grant)
+	assert.Equal(t, []featureScopes{{
+		Feature: "calendar",
+		Scopes: []scopeStatus{
+			{Scope: domain.ScopeCalendarRead, Status: scopeGranted},
+			{Scope: domain.ScopeCalendarWrite, Status: scopeMissing},
+		},
+	}}, features)
+	assert.Equal(t, []domain.Scope{domain.ScopeCalendarWrite}, missingScopes(features))
+
+	imap := featureScopeReport(root, &domain.Grant{Provider: domain.ProviderIMAP})
+	assert.Equal(t, scopeNotApplicable, imap[0].Scopes[0].Status)
+	assert.Empty(t, missingScopes(imap))
+}
+
+func TestLoginAddScopesNeedsReauth(t *testing.T) {
+	cmd := newLoginCmd()
+	cmd.SetArgs([]string{"--add-scopes", "calendar"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "--add-scopes needs the grant")
+}
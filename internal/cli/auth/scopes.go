@@ -1,15 +1,47 @@
 package auth
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 )
 
+// Scope statuses in `auth scopes` feature reports.
+const (
+	scopeGranted       = "granted"
+	scopeMissing       = "missing"
+	scopeNotApplicable = "not_applicable" // the provider has no OAuth scopes
+)
+
+// scopesResult is the output of `auth scopes`.
+type scopesResult struct {
+	GrantID  string          `json:"grant_id"`
+	Email    string          `json:"email"`
+	Provider string          `json:"provider"`
+	Status   string          `json:"status"`
+	Scopes   []string        `json:"scopes"`
+	Features []featureScopes `json:"features"`
+	Missing  []domain.Scope  `json:"missing"`
+}
+
+// featureScopes lists the scopes a top-level command's subcommands need,
+// and whether the grant has them.
+type featureScopes struct {
+	Feature string        `json:"feature"`
+	Scopes  []scopeStatus `json:"scopes"`
+}
+
+type scopeStatus struct {
+	Scope  domain.Scope `json:"scope"`
+	Status string       `json:"status"`
+}
+
 func newScopesCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "scopes [grant-id]",
@@ -21,6 +53,10 @@ Scopes define what data and operations are permitted for a grant:
 - Calendar scopes: Read events, create/update events
 - Contacts scopes: Read/write contact information
 
+For each feature (email, calendar, contacts, ...), the scopes its commands
+need are compared with the grant's, and missing ones are listed with the
+command that adds them.
+
 If no grant ID is provided, shows scopes for the currently active grant.`,
 		Example: `  # Show scopes for current grant
   nylas auth scopes
@@ -57,24 +93,19 @@ If no grant ID is provided, shows scopes for the currently active grant.`,
 				return common.WrapGetError("grant scopes", err)
 			}
 
-			result := struct {
-				GrantID  string   `json:"grant_id"`
-				Email    string   `json:"email"`
-				Provider string   `json:"provider"`
-				Status   string   `json:"status"`
-				Scopes   []string `json:"scopes"`
-			}{
+			features := featureScopeReport(cmd.Root(), grant)
+			result := scopesResult{
 				GrantID:  grant.ID,
 				Email:    grant.Email,
 				Provider: string(grant.Provider),
 				Status:   grant.GrantStatus,
 				Scopes:   grant.Scope,
+				Features: features,
+				Missing:  missingScopes(features),
 			}
 
-			if common.IsJSON(cmd) {
-				enc := json.NewEncoder(cmd.OutOrStdout())
-				enc.SetIndent("", "  ")
-				return enc.Encode(result)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(result)
 			}
 
 			// Display as formatted text
@@ -86,17 +117,17 @@ If no grant ID is provided, shows scopes for the currently active grant.`,
 
 			if len(result.Scopes) == 0 {
 				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No scopes configured.")
-				return nil
-			}
-
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "OAuth Scopes (%d):\n", len(result.Scopes))
-			for i, scope := range result.Scopes {
-				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %d. %s\n", i+1, scope)
-				if description := describeScopeCategory(scope); description != "" {
-					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "     %s\n", description)
+			} else {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "OAuth Scopes (%d):\n", len(result.Scopes))
+				for i, scope := range result.Scopes {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "  %d. %s\n", i+1, scope)
+					if description := describeScopeCategory(scope); description != "" {
+						_, _ = fmt.Fprintf(cmd.OutOrStdout(), "     %s\n", description)
+					}
 				}
 			}
 
+			printFeatureScopes(cmd.OutOrStdout(), result)
 			return nil
 		},
 	}
@@ -168,3 +199,95 @@ func describeScopeCategory(scope string) string {
 
 	return ""
 }
+
+// featureScopeReport groups the scopes commands need by top-level command
+// and checks each against the grant. The application scope is left out: it
+// belongs to the API key, not the grant.
+func featureScopeReport(root *cobra.Command, grant *domain.Grant) []featureScopes {
+	order := domain.GrantTokenScopes()
+	var features []featureScopes
+	for _, top := range root.Commands() {
+		var needed []domain.Scope
+		walkCommands(top, func(c *cobra.Command) {
+			scopes, _ := common.CommandPermissions(c)
+			for _, scope := range scopes {
+				if slices.Contains(order, scope) && !slices.Contains(needed, scope) {
+					needed = append(needed, scope)
+				}
+			}
+		})
+		if len(needed) == 0 {
+			continue
+		}
+		slices.SortFunc(needed, func(a, b domain.Scope) int {
+			return slices.Index(order, a) - slices.Index(order, b)
+		})
+
+		feature := featureScopes{Feature: top.Name()}
+		for _, scope := range needed {
+			status := scopeMissing
+			if ok, known := scope.GrantedBy(grant.Provider, grant.Scope); !known {
+				status = scopeNotApplicable
+			} else if ok {
+				status = scopeGranted
+			}
+			feature.Scopes = append(feature.Scopes, scopeStatus{Scope: scope, Status: status})
+		}
+		features = append(features, feature)
+	}
+	return features
+}
+
+func walkCommands(cmd *cobra.Command, fn func(*cobra.Command)) {
+	fn(cmd)
+	for _, sub := range cmd.Commands() {
+		walkCommands(sub, fn)
+	}
+}
+
+// missingScopes lists the missing scopes across features, in scope order.
+func missingScopes(features []featureScopes) []domain.Scope {
+	missing := []domain.Scope{}
+	for _, scope := range domain.GrantTokenScopes() {
+		for _, feature := range features {
+			if slices.Contains(feature.Scopes, scopeStatus{Scope: scope, Status: scopeMissing}) && !slices.Contains(missing, scope) {
+				missing = append(missing, scope)
+			}
+		}
+	}
+	return missing
+}
+
+func printFeatureScopes(w io.Writer, result scopesResult) {
+	if len(result.Features) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Scopes by feature:")
+	for _, feature := range result.Features {
+		marks := make([]string, 0, len(feature.Scopes))
+		for _, scope := range feature.Scopes {
+			switch scope.Status {
+			case scopeGranted:
+				marks = append(marks, common.Green.Sprint("✓ ")+string(scope.Scope))
+			case scopeMissing:
+				marks = append(marks, common.Red.Sprint("✗ ")+string(scope.Scope))
+			default:
+				marks = append(marks, common.Dim.Sprint("– "+string(scope.Scope)))
+			}
+		}
+		_, _ = fmt.Fprintf(w, "  %-12s %s\n", feature.Feature, strings.Join(marks, "  "))
+	}
+
+	if len(result.Missing) == 0 {
+		return
+	}
+	names := make([]string, len(result.Missing))
+	for i, scope := range result.Missing {
+		names[i] = string(scope)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "Missing scopes: %s\n", strings.Join(names, ", "))
+	_, _ = fmt.Fprintf(w, "  Add them with: nylas auth login --reauth %s --add-scopes %s\n", result.GrantID, strings.Join(names, ","))
+}
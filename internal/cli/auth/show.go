@@ -88,8 +88,8 @@ Information includes:
 				return common.WrapGetError("grant details", err)
 			}
 
-			if common.IsJSON(cmd) {
-				return common.PrintJSON(grant)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(grant)
 			}
 
 			// Display grant information
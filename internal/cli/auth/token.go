@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
 )
 
@@ -47,5 +48,8 @@ func newTokenCmd() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&copyToClipboard, "copy", "c", false, "Copy to clipboard")
 
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newTokenIssueCmd(), issuedGrantToken{}), domain.ScopeApplicationAPI))
+	cmd.AddCommand(common.DeclareOutput(newTokenVerifyCmd(), issuedGrantToken{}))
+
 	return cmd
 }
@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/audit"
+	"github.com/nylas/cli/internal/adapters/granttoken"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// maxGrantTokenTTL caps how long an issued grant token stays valid.
+const maxGrantTokenTTL = 30 * 24 * time.Hour
+
+// issuedGrantToken is the structured output of token issue and verify.
+type issuedGrantToken struct {
+	Token     string         `json:"token,omitempty" yaml:"token,omitempty"`
+	ID        string         `json:"id" yaml:"id"`
+	GrantID   string         `json:"grant_id" yaml:"grant_id"`
+	Scopes    []domain.Scope `json:"scopes" yaml:"scopes"`
+	IssuedAt  time.Time      `json:"issued_at" yaml:"issued_at"`
+	ExpiresAt time.Time      `json:"expires_at" yaml:"expires_at"`
+}
+
+// QuietField prints the token itself in quiet mode.
+func (t issuedGrantToken) QuietField() string {
+	if t.Token != "" {
+		return t.Token
+	}
+	return t.ID
+}
+
+func newGrantTokenOutput(token string, t *domain.GrantToken) issuedGrantToken {
+	return issuedGrantToken{
+		Token:     token,
+		ID:        t.ID,
+		GrantID:   t.GrantID,
+		Scopes:    t.Scopes,
+		IssuedAt:  t.IssuedAt,
+		ExpiresAt: t.ExpiresAt,
+	}
+}
+
+func newTokenIssueCmd() *cobra.Command {
+	var (
+		grant           string
+		ttl             string
+		scopes          []string
+		copyToClipboard bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue a scoped, time-limited grant token for local tools",
+		Long: `Issue a token that lets another local tool use one grant through
+'nylas rpc serve' without the API key.
+
+The token is signed with a key kept in the CLI's secret store. It only works
+for the given grant, only for the listed scopes, and only until it expires.
+Each issuance is recorded in the audit log.
+
+Scopes: email.read, email.modify, email.send, calendar.read, calendar,
+contacts.read, contacts. Aliases such as messages.read, messages.send,
+events.read and calendar.write are accepted.`,
+		Example: `  # Read-only mail access for one hour
+  nylas auth token issue --grant user@example.com --ttl 1h --scopes messages.read
+
+  # Calendar access for a day, as JSON
+  nylas auth token issue --ttl 24h --scopes calendar.read,calendar --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateRequiredFlag("--scopes", strings.Join(scopes, "")); err != nil {
+				return err
+			}
+			parsed, err := domain.ParseGrantTokenScopes(scopes)
+			if err != nil {
+				return common.NewUserError(err.Error(), "Valid scopes: "+scopeList(domain.GrantTokenScopes()))
+			}
+			lifetime, err := parseGrantTokenTTL(ttl)
+			if err != nil {
+				return err
+			}
+
+			var grantArgs []string
+			if grant != "" {
+				grantArgs = []string{grant}
+			}
+			issued, err := common.WithClient(grantArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (*domain.GrantToken, error) {
+				if _, err := client.GetGrant(ctx, grantID); err != nil {
+					return nil, common.WrapGetError("grant", err)
+				}
+				return granttoken.New(grantID, parsed, lifetime, time.Now()), nil
+			})
+			if err != nil {
+				return err
+			}
+
+			_, secretStore, _, err := createDependencies()
+			if err != nil {
+				return err
+			}
+			key, err := granttoken.SigningKey(secretStore)
+			if err != nil {
+				return err
+			}
+			token, err := granttoken.Sign(key, issued)
+			if err != nil {
+				return err
+			}
+
+			common.RecordAuditDetail("token_id", issued.ID)
+			common.RecordAuditDetail("token_scopes", scopeList(issued.Scopes))
+			common.RecordAuditDetail("token_expires_at", issued.ExpiresAt.Format(time.RFC3339))
+			if !auditEnabled() {
+				common.PrintWarningStderr("Audit logging is disabled, so this issuance is not recorded. Enable it with: nylas audit init --enable")
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(newGrantTokenOutput(token, issued))
+			}
+			if copyToClipboard {
+				if err := common.CopyToClipboard(token); err != nil {
+					return common.WrapWriteError("clipboard", err)
+				}
+				_, _ = common.Green.Println("✓ Grant token copied to clipboard")
+			} else {
+				fmt.Println(token)
+			}
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), common.Dim.Sprintf("Grant %s · %s · expires %s",
+				issued.GrantID, scopeList(issued.Scopes), issued.ExpiresAt.Local().Format(time.RFC1123)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&grant, "grant", "", "Grant ID or email (defaults to the default grant)")
+	cmd.Flags().StringVar(&ttl, "ttl", "1h", "How long the token stays valid (e.g. 30m, 8h, 7d; max 30d)")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", nil, "Comma-separated scopes the token allows (required)")
+	cmd.Flags().BoolVarP(&copyToClipboard, "copy", "c", false, "Copy the token to the clipboard instead of printing it")
+
+	return cmd
+}
+
+func newTokenVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify <token>",
+		Short: "Show the grant, scopes and expiry of a grant token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, secretStore, _, err := createDependencies()
+			if err != nil {
+				return err
+			}
+			key, err := granttoken.SigningKey(secretStore)
+			if err != nil {
+				return err
+			}
+
+			claims, err := granttoken.Verify(key, args[0], time.Now())
+			switch {
+			case errors.Is(err, domain.ErrGrantTokenExpired):
+				return common.NewUserError(
+					fmt.Sprintf("grant token %s expired at %s", claims.ID, claims.ExpiresAt.Local().Format(time.RFC1123)),
+					"Issue a new one with: nylas auth token issue --scopes <scopes>",
+				)
+			case err != nil:
+				return common.NewUserError("grant token is invalid",
+					"Grant tokens only verify on the machine that issued them")
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(newGrantTokenOutput("", claims))
+			}
+			common.PrintSuccess("Valid grant token %s", claims.ID)
+			fmt.Printf("  Grant:   %s\n", claims.GrantID)
+			fmt.Printf("  Scopes:  %s\n", scopeList(claims.Scopes))
+			fmt.Printf("  Expires: %s (in %s)\n", claims.ExpiresAt.Local().Format(time.RFC1123),
+				time.Until(claims.ExpiresAt).Round(time.Second))
+			return nil
+		},
+	}
+}
+
+// parseGrantTokenTTL parses --ttl and enforces the allowed range.
+func parseGrantTokenTTL(s string) (time.Duration, error) {
+	ttl, err := common.ParseDuration(s)
+	if err != nil || ttl <= 0 {
+		return 0, common.NewUserError(fmt.Sprintf("invalid --ttl %q", s), "Use a duration such as 30m, 8h or 7d")
+	}
+	if ttl > maxGrantTokenTTL {
+		return 0, common.NewUserError(fmt.Sprintf("--ttl %s is longer than the 30d maximum", s), "Issue a shorter-lived token and re-issue it when it expires")
+	}
+	return ttl, nil
+}
+
+func scopeList(scopes []domain.Scope) string {
+	names := make([]string, len(scopes))
+	for i, s := range scopes {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+// auditEnabled reports whether audit entries are currently being written.
+func auditEnabled() bool {
+	store, err := audit.NewFileStore("")
+	if err != nil {
+		return false
+	}
+	cfg, _ := store.GetConfig()
+	return cfg != nil && cfg.Enabled
+}
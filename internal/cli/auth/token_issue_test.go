@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestTokenSubcommands(t *testing.T) {
+	cmd := newTokenCmd()
+
+	for _, name := range []string{"issue", "verify"} {
+		sub, _, err := cmd.Find([]string{name})
+		if err != nil || sub.Name() != name {
+			t.Errorf("token %s subcommand not found", name)
+		}
+	}
+
+	issue := newTokenIssueCmd()
+	for _, flag := range []string{"grant", "ttl", "scopes", "copy"} {
+		if issue.Flags().Lookup(flag) == nil {
+			t.Errorf("Expected --%s flag on token issue", flag)
+		}
+	}
+	if got := issue.Flags().Lookup("ttl").DefValue; got != "1h" {
+		t.Errorf("--ttl default = %q, want 1h", got)
+	}
+}
+
+func TestTokenIssueValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"missing scopes", []string{}, "--scopes flag is required"},
+		{"unknown scope", []string{"--scopes", "messages.delete"}, "messages.delete"},
+		{"application scope", []string{"--scopes", "application"}, "application"},
+		{"bad ttl", []string{"--scopes", "messages.read", "--ttl", "soon"}, "invalid --ttl"},
+		{"ttl too long", []string{"--scopes", "messages.read", "--ttl", "31d"}, "30d maximum"},
+		{"unexpected argument", []string{"extra", "--scopes", "messages.read"}, "unknown command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newTokenIssueCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseGrantTokenTTL(t *testing.T) {
+	for input, want := range map[string]time.Duration{"30m": 30 * time.Minute, "8h": 8 * time.Hour, "30d": maxGrantTokenTTL} {
+		got, err := parseGrantTokenTTL(input)
+		if err != nil || got != want {
+			t.Errorf("parseGrantTokenTTL(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "0s", "-1h", "5y"} {
+		if _, err := parseGrantTokenTTL(input); err == nil {
+			t.Errorf("parseGrantTokenTTL(%q) expected error", input)
+		}
+	}
+}
+
+func TestIssuedGrantTokenQuietField(t *testing.T) {
+	claims := &domain.GrantToken{ID: "tok-1", GrantID: "grant-1", Scopes: []domain.Scope{domain.ScopeEmailRead, domain.ScopeEmailSend}}
+
+	if got := newGrantTokenOutput("nylt_abc.def", claims).QuietField(); got != "nylt_abc.def" {
+		t.Errorf("QuietField() = %q, want the token", got)
+	}
+	if got := newGrantTokenOutput("", claims).QuietField(); got != "tok-1" {
+		t.Errorf("QuietField() = %q, want the token ID", got)
+	}
+	if got := scopeList(claims.Scopes); got != "email.read, email.send" {
+		t.Errorf("scopeList() = %q", got)
+	}
+}
@@ -0,0 +1,174 @@
+// Package cache provides commands for the on-disk API response cache.
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/httpcache"
+	"github.com/nylas/cli/internal/cli/common"
+)
+
+// openStore opens the response cache. Replaced in tests.
+var openStore = common.NewResponseCache
+
+// NewCacheCmd creates the cache command.
+func NewCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the API response cache",
+		Long: `Manage the on-disk cache of API responses.
+
+When enabled, read-only folder, calendar, contact, and grant requests are
+served from disk while fresh. Stale entries are revalidated with the API using
+ETag / If-Modified-Since, and any change made through the CLI drops the
+cached responses for that resource.
+
+The cache is off by default. Enable it with:
+  nylas config set cache.enabled true      (or NYLAS_CACHE=true)
+
+Tune how long responses stay fresh (defaults: folders 10m, calendars 10m,
+contacts 5m, grants 1m; "0" disables one resource):
+  nylas config set cache.contacts_ttl 15m
+
+Bypass it for one command with the global --no-cache flag.
+
+Shell completion of --calendar and --folder values always uses the cache,
+whatever cache.enabled says, so pressing Tab doesn't wait on the API: fresh
+entries are used directly, and stale ones when the API takes more than a
+couple of seconds.`,
+		Example: `  # Show what is cached
+  nylas cache status
+
+  # Clear everything, or one resource
+  nylas cache clear
+  nylas cache clear contacts`,
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newStatusCmd(), cacheStatus{}))
+	cmd.AddCommand(newClearCmd())
+
+	return cmd
+}
+
+// cacheStatus is the structured output of `cache status`.
+type cacheStatus struct {
+	Enabled   bool                      `json:"enabled"`
+	Directory string                    `json:"directory"`
+	TTLs      map[string]string         `json:"ttls"`
+	Resources []httpcache.ResourceStats `json:"resources"`
+}
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show cache settings and contents",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.NewDefaultFileStore().Load()
+			if err != nil {
+				return common.WrapLoadError("configuration", err)
+			}
+			store, err := openStore()
+			if err != nil {
+				return err
+			}
+			stats, err := store.Stats()
+			if err != nil {
+				return fmt.Errorf("read cache: %w", err)
+			}
+
+			status := cacheStatus{
+				Enabled:   cfg.CacheEnabled(),
+				Directory: store.Dir(),
+				TTLs:      map[string]string{},
+				Resources: stats,
+			}
+			for _, resource := range cacheResources() {
+				status.TTLs[resource] = formatTTL(cfg.Cache.TTL(resource))
+			}
+			if status.Resources == nil {
+				status.Resources = []httpcache.ResourceStats{}
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(status)
+			}
+
+			state := common.Yellow.Sprint("disabled")
+			if status.Enabled {
+				state = common.Green.Sprint("enabled")
+			}
+			fmt.Printf("Cache:       %s\n", state)
+			fmt.Printf("Completions: %s\n", common.Green.Sprint("always cached"))
+			fmt.Printf("Directory:   %s\n\n", status.Directory)
+
+			counts := map[string]httpcache.ResourceStats{}
+			for _, st := range stats {
+				counts[st.Resource] = st
+			}
+			table := common.NewTable("RESOURCE", "TTL", "ENTRIES", "SIZE")
+			for _, resource := range cacheResources() {
+				st := counts[resource]
+				table.AddRow(resource, status.TTLs[resource], fmt.Sprintf("%d", st.Entries), common.FormatSize(st.Bytes))
+			}
+			table.Render()
+
+			if !status.Enabled {
+				fmt.Println()
+				fmt.Println("Enable for all commands with: nylas config set cache.enabled true")
+			}
+			return nil
+		},
+	}
+}
+
+func newClearCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear [resource]",
+		Short: "Remove cached responses",
+		Long:  "Remove all cached responses, or only those for one resource: " + strings.Join(cacheResources(), ", ") + ".",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openStore()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				resource := args[0]
+				if err := common.ValidateOneOf("resource", resource, cacheResources()); err != nil {
+					return err
+				}
+				if err := store.Invalidate(resource); err != nil {
+					return fmt.Errorf("clear cache: %w", err)
+				}
+				common.PrintSuccess("Cleared cached %s", resource)
+				return nil
+			}
+
+			removed, err := store.Clear()
+			if err != nil {
+				return fmt.Errorf("clear cache: %w", err)
+			}
+			common.PrintSuccess("Cleared %d cached response(s)", removed)
+			return nil
+		},
+	}
+}
+
+// cacheResources returns the cacheable resources in display order.
+func cacheResources() []string {
+	return []string{"calendars", "contacts", "folders", "grants"}
+}
+
+func formatTTL(d time.Duration) string {
+	if d <= 0 {
+		return "off"
+	}
+	return d.String()
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/httpcache"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func useStore(t *testing.T) *httpcache.Store {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(t.TempDir(), "config"))
+	store := httpcache.New(t.TempDir())
+	orig := openStore
+	openStore = func() (*httpcache.Store, error) { return store, nil }
+	t.Cleanup(func() { openStore = orig })
+	return store
+}
+
+func execute(t *testing.T, args ...string) error {
+	t.Helper()
+	cmd := NewCacheCmd()
+	cmd.SetArgs(args)
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	return cmd.Execute()
+}
+
+func TestCacheClear(t *testing.T) {
+	store := useStore(t)
+	for _, resource := range []string{"contacts", "folders"} {
+		require.NoError(t, store.Put(resource, "k", &domain.CachedResponse{Body: []byte(`{}`)}))
+	}
+
+	require.NoError(t, execute(t, "clear", "contacts"))
+	stats, err := store.Stats()
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "folders", stats[0].Resource)
+
+	assert.ErrorContains(t, execute(t, "clear", "messages"), "invalid resource")
+
+	require.NoError(t, execute(t, "clear"))
+	stats, err = store.Stats()
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+}
+
+func TestCacheStatus(t *testing.T) {
+	useStore(t)
+	require.NoError(t, execute(t, "status"))
+}
@@ -0,0 +1,366 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// agendaRanges are the accepted range arguments, in help order.
+var agendaRanges = []string{"today", "tomorrow", "week", "month"}
+
+// agendaMaxEventsPerCalendar caps how many events one calendar contributes.
+const agendaMaxEventsPerCalendar = 1000
+
+// agendaItem is one event as shown in the agenda.
+type agendaItem struct {
+	ID              string    `json:"id"`
+	CalendarID      string    `json:"calendar_id"`
+	Calendar        string    `json:"calendar"`
+	Title           string    `json:"title"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	AllDay          bool      `json:"all_day"`
+	Location        string    `json:"location,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	ConferencingURL string    `json:"conferencing_url,omitempty"`
+	event           domain.Event
+}
+
+// agendaDay groups the items that fall on one local day.
+type agendaDay struct {
+	Date   string       `json:"date"`
+	Events []agendaItem `json:"events"`
+}
+
+func newAgendaCmd() *cobra.Command {
+	var (
+		calendars []string
+		targetTZ  string
+		ics       bool
+		showAll   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "agenda [today|tomorrow|week|month] [grant-id]",
+		Short: "Show events from all calendars grouped by day",
+		Long: `Show an agenda of events from every calendar of a grant, merged and grouped
+by day in your local timezone, with conferencing links.
+
+Ranges start at the beginning of today:
+  today      today only (default)
+  tomorrow   tomorrow only
+  week       the next 7 days
+  month      the next month
+
+Use --ics to write the agenda as an iCalendar file instead, e.g. to import
+it elsewhere.`,
+		Example: `  # Today's agenda
+  nylas calendar agenda
+
+  # The next 7 days in another timezone
+  nylas calendar agenda week --timezone Europe/Berlin
+
+  # Only some calendars
+  nylas calendar agenda week --calendar primary --calendar team-cal-id
+
+  # Export the month as iCalendar
+  nylas calendar agenda month --ics > agenda.ics`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rangeName := "today"
+			if len(args) > 0 && slices.Contains(agendaRanges, args[0]) {
+				rangeName, args = args[0], args[1:]
+			}
+			if len(args) > 1 {
+				return common.NewUserError(
+					fmt.Sprintf("unknown agenda range %q", args[0]),
+					"Use one of: "+strings.Join(agendaRanges, ", "),
+				)
+			}
+
+			loc := time.Local
+			if targetTZ != "" {
+				if err := validateTimeZone(targetTZ); err != nil {
+					return err
+				}
+				loc, _ = time.LoadLocation(targetTZ)
+			}
+			start, end := agendaWindow(rangeName, time.Now().In(loc))
+
+			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				var warnings []string
+				items, err := common.RunWithSpinnerResult("Loading agenda...", func() ([]agendaItem, error) {
+					var items []agendaItem
+					var err error
+					items, warnings, err = collectAgenda(ctx, client, grantID, calendars, start, end, showAll)
+					return items, err
+				})
+				if err != nil {
+					return struct{}{}, err
+				}
+				// Printed after the spinner so they don't interleave with it.
+				for _, w := range warnings {
+					common.PrintWarningStderr("%s", w)
+				}
+
+				if ics {
+					return struct{}{}, writeAgendaICS(cmd.OutOrStdout(), items, time.Now())
+				}
+
+				days := groupAgendaByDay(items, start, end, loc)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(days)
+				}
+				if len(days) == 0 {
+					common.PrintEmptyStateWithHint("events", "Try a longer range: nylas calendar agenda week")
+					return struct{}{}, nil
+				}
+				tzName := targetTZ
+				if tzName == "" {
+					tzName = getLocalTimeZone()
+				}
+				printAgenda(cmd.OutOrStdout(), days, loc, tzName)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&calendars, "calendar", "c", nil, "Only include these calendar IDs (repeatable; default all)")
+	cmd.Flags().StringVar(&targetTZ, "timezone", "", "IANA timezone to group and display in (defaults to system timezone)")
+	cmd.Flags().BoolVar(&ics, "ics", false, "Write the agenda as iCalendar (.ics) to stdout")
+	cmd.Flags().BoolVar(&showAll, "show-cancelled", false, "Include cancelled events")
+
+	return cmd
+}
+
+// agendaWindow returns the [start, end) window for a range name.
+func agendaWindow(rangeName string, now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch rangeName {
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+	case "week":
+		return today, today.AddDate(0, 0, 7)
+	case "month":
+		return today, today.AddDate(0, 1, 0)
+	default:
+		return today, today.AddDate(0, 0, 1)
+	}
+}
+
+// collectAgenda fetches expanded events in [start, end) from every selected
+// calendar ("primary" selects the primary calendar). Calendars that fail to
+// load are skipped and reported as warnings rather than failing the agenda.
+func collectAgenda(ctx context.Context, client ports.NylasClient, grantID string, only []string,
+	start, end time.Time, showCancelled bool) ([]agendaItem, []string, error) {
+	calendars, err := client.GetCalendars(ctx, grantID)
+	if err != nil {
+		return nil, nil, common.WrapListError("calendars", err)
+	}
+
+	selected, err := selectAgendaCalendars(calendars, only)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		items    []agendaItem
+		warnings []string
+		seen     = make(map[string]bool)
+	)
+	for _, cal := range selected {
+		params := &domain.EventQueryParams{
+			Limit:           common.NormalizePageSize(0),
+			Start:           start.Unix(),
+			End:             end.Unix(),
+			ExpandRecurring: true,
+			ShowCancelled:   showCancelled,
+		}
+		events, err := fetchEvents(ctx, client, grantID, cal.ID, params, agendaMaxEventsPerCalendar)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Skipped calendar %s: %s", cal.Name, common.WrapError(err).Message))
+			continue
+		}
+		for _, e := range events {
+			if seen[e.ID] || (!showCancelled && e.Status == "cancelled") {
+				continue
+			}
+			seen[e.ID] = true
+			items = append(items, newAgendaItem(e, cal, start.Location()))
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b agendaItem) int {
+		if c := a.Start.Compare(b.Start); c != 0 {
+			return c
+		}
+		if a.AllDay != b.AllDay {
+			if a.AllDay {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Title, b.Title)
+	})
+	return items, warnings, nil
+}
+
+func selectAgendaCalendars(calendars []domain.Calendar, only []string) ([]domain.Calendar, error) {
+	if len(only) == 0 {
+		return calendars, nil
+	}
+	var selected []domain.Calendar
+	for _, id := range only {
+		idx := slices.IndexFunc(calendars, func(c domain.Calendar) bool {
+			return c.ID == id || (id == "primary" && c.IsPrimary)
+		})
+		if idx < 0 {
+			return nil, common.NewUserError(
+				fmt.Sprintf("calendar not found: %s", id),
+				"List calendars with: nylas calendar list",
+			)
+		}
+		selected = append(selected, calendars[idx])
+	}
+	return selected, nil
+}
+
+// newAgendaItem converts an event to loc. All-day events keep their dates:
+// they start at local midnight and end at local midnight after the last day
+// (end_date is exclusive, as in iCalendar).
+func newAgendaItem(e domain.Event, cal domain.Calendar, loc *time.Location) agendaItem {
+	item := agendaItem{
+		ID:         e.ID,
+		CalendarID: cal.ID,
+		Calendar:   cal.Name,
+		Title:      e.Title,
+		AllDay:     e.When.IsAllDay(),
+		Location:   e.Location,
+		Status:     e.Status,
+		event:      e,
+	}
+	if e.Conferencing != nil && e.Conferencing.Details != nil {
+		item.ConferencingURL = e.Conferencing.Details.URL
+	}
+
+	if !item.AllDay {
+		item.Start = e.When.StartDateTime().In(loc)
+		item.End = e.When.EndDateTime().In(loc)
+		return item
+	}
+
+	startDate := e.When.Date
+	if startDate == "" {
+		startDate = e.When.StartDate
+	}
+	item.Start, _ = time.ParseInLocation(time.DateOnly, startDate, loc)
+	item.End = item.Start.AddDate(0, 0, 1)
+	if e.When.EndDate != "" {
+		if end, err := time.ParseInLocation(time.DateOnly, e.When.EndDate, loc); err == nil && end.After(item.Start) {
+			item.End = end
+		}
+	}
+	return item
+}
+
+// groupAgendaByDay buckets items into local days within [start, end). Timed
+// events appear on the day they start (or the first day, if already under
+// way); all-day events appear on every day they cover.
+func groupAgendaByDay(items []agendaItem, start, end time.Time, loc *time.Location) []agendaDay {
+	byDate := make(map[string]*agendaDay)
+	var order []string
+	add := func(day time.Time, item agendaItem) {
+		key := day.Format(time.DateOnly)
+		d, ok := byDate[key]
+		if !ok {
+			d = &agendaDay{Date: key}
+			byDate[key] = d
+			order = append(order, key)
+		}
+		d.Events = append(d.Events, item)
+	}
+	midnight := func(t time.Time) time.Time {
+		t = t.In(loc)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+
+	for _, item := range items {
+		first := midnight(item.Start)
+		if first.Before(start) {
+			first = start
+		}
+		if !item.AllDay {
+			if first.Before(end) {
+				add(first, item)
+			}
+			continue
+		}
+		for day := first; day.Before(item.End) && day.Before(end); day = day.AddDate(0, 0, 1) {
+			add(day, item)
+		}
+	}
+
+	slices.Sort(order)
+	days := make([]agendaDay, 0, len(order))
+	for _, key := range order {
+		d := byDate[key]
+		// All-day entries lead each day.
+		slices.SortStableFunc(d.Events, func(a, b agendaItem) int {
+			switch {
+			case a.AllDay == b.AllDay:
+				return 0
+			case a.AllDay:
+				return -1
+			default:
+				return 1
+			}
+		})
+		days = append(days, *d)
+	}
+	return days
+}
+
+func printAgenda(w io.Writer, days []agendaDay, loc *time.Location, tzName string) {
+	for i, day := range days {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		date, _ := time.ParseInLocation(time.DateOnly, day.Date, loc)
+		_, _ = common.Bold.Fprintln(w, date.Format("Monday, Jan 2"))
+
+		for _, item := range day.Events {
+			when := "All day"
+			if !item.AllDay {
+				when = item.Start.Format("3:04 PM") + " - " + item.End.Format("3:04 PM")
+			}
+			_, _ = fmt.Fprintf(w, "  %s %s", common.Cyan.Sprint(padRight(when, 19)), item.Title)
+			if item.Calendar != "" {
+				_, _ = common.Dim.Fprintf(w, "  · %s", item.Calendar)
+			}
+			if item.Status == "cancelled" {
+				_, _ = common.Red.Fprint(w, "  (cancelled)")
+			}
+			_, _ = fmt.Fprintln(w)
+
+			indent := strings.Repeat(" ", 22)
+			if item.Location != "" {
+				_, _ = common.Dim.Fprintf(w, "%s%s\n", indent, item.Location)
+			}
+			if item.ConferencingURL != "" {
+				_, _ = fmt.Fprintf(w, "%s🔗 %s\n", indent, item.ConferencingURL)
+			}
+		}
+	}
+	_, _ = common.Dim.Fprintf(w, "\nTimes in %s\n", tzName)
+}
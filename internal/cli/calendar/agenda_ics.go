@@ -0,0 +1,108 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// icsTimestamp is the iCalendar UTC date-time form.
+const icsTimestamp = "20060102T150405Z"
+
+// writeAgendaICS writes items as an iCalendar (RFC 5545) calendar. Each
+// expanded occurrence becomes its own VEVENT keyed by its Nylas event ID, so
+// the file imports as plain events rather than a recurring series.
+func writeAgendaICS(w io.Writer, items []agendaItem, now time.Time) error {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(foldICSLine(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Nylas//Nylas CLI//EN")
+	line("CALSCALE:GREGORIAN")
+	for _, item := range items {
+		line("BEGIN:VEVENT")
+		line("UID:" + item.ID + "@nylas")
+		line("DTSTAMP:" + now.UTC().Format(icsTimestamp))
+		if item.AllDay {
+			line("DTSTART;VALUE=DATE:" + item.Start.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + item.End.Format("20060102"))
+		} else {
+			line("DTSTART:" + item.Start.UTC().Format(icsTimestamp))
+			line("DTEND:" + item.End.UTC().Format(icsTimestamp))
+		}
+		line("SUMMARY:" + escapeICSText(item.Title))
+		if item.Location != "" {
+			line("LOCATION:" + escapeICSText(item.Location))
+		}
+		if desc := item.event.Description; desc != "" {
+			line("DESCRIPTION:" + escapeICSText(desc))
+		}
+		if item.ConferencingURL != "" {
+			line("URL:" + item.ConferencingURL)
+		}
+		if status := icsStatus(item.Status); status != "" {
+			line("STATUS:" + status)
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return fmt.Errorf("write iCalendar: %w", err)
+	}
+	return nil
+}
+
+func icsStatus(status string) string {
+	switch status {
+	case "confirmed", "tentative", "cancelled":
+		return strings.ToUpper(status)
+	default:
+		return ""
+	}
+}
+
+// escapeICSText escapes a TEXT value (RFC 5545 section 3.3.11).
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// foldICSLine folds a content line at 75 octets without splitting a UTF-8
+// sequence (RFC 5545 section 3.1).
+func foldICSLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !isUTF8Start(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		width = limit - 1
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,160 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestAgendaWindow(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	now := time.Date(2026, 3, 4, 15, 30, 0, 0, loc)
+	today := time.Date(2026, 3, 4, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		rangeName  string
+		start, end time.Time
+	}{
+		{"today", today, today.AddDate(0, 0, 1)},
+		{"tomorrow", today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)},
+		{"week", today, today.AddDate(0, 0, 7)},
+		{"month", today, today.AddDate(0, 1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rangeName, func(t *testing.T) {
+			start, end := agendaWindow(tt.rangeName, now)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.end, end)
+		})
+	}
+}
+
+func agendaTestClient(failCalendar string) *nylas.MockClient {
+	at := func(day, hour int) int64 {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC).Unix()
+	}
+	events := map[string][]domain.Event{
+		"work": {
+			{ID: "standup", Title: "Standup", When: domain.EventWhen{StartTime: at(4, 9), EndTime: at(4, 10)},
+				Conferencing: &domain.Conferencing{Details: &domain.ConferencingDetails{URL: "https://meet.example.com/abc"}}},
+			{ID: "cancelled", Title: "Old sync", Status: "cancelled", When: domain.EventWhen{StartTime: at(4, 11), EndTime: at(4, 12)}},
+		},
+		"team": {
+			{ID: "offsite", Title: "Offsite", When: domain.EventWhen{StartDate: "2026-03-04", EndDate: "2026-03-06", Object: "datespan"}},
+			{ID: "standup", Title: "Standup", When: domain.EventWhen{StartTime: at(4, 9), EndTime: at(4, 10)}},
+			{ID: "review", Title: "Review", When: domain.EventWhen{StartTime: at(5, 14), EndTime: at(5, 15)}},
+		},
+	}
+
+	client := nylas.NewMockClient()
+	client.GetCalendarsFunc = func(context.Context, string) ([]domain.Calendar, error) {
+		return []domain.Calendar{
+			{ID: "work", Name: "Work", IsPrimary: true},
+			{ID: "team", Name: "Team"},
+			{ID: "holidays", Name: "Holidays"},
+		}, nil
+	}
+	client.GetEventsWithCursorFunc = func(_ context.Context, _, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
+		if calendarID == failCalendar {
+			return nil, errors.New("forbidden")
+		}
+		if !params.ExpandRecurring {
+			return nil, errors.New("agenda must expand recurring events")
+		}
+		return &domain.EventListResponse{Data: events[calendarID]}, nil
+	}
+	return client
+}
+
+func TestCollectAgenda(t *testing.T) {
+	start := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+
+	t.Run("merges calendars and groups by day", func(t *testing.T) {
+		items, warnings, err := collectAgenda(context.Background(), agendaTestClient("holidays"), "grant", nil, start, end, false)
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "Holidays")
+
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		assert.Equal(t, []string{"offsite", "standup", "review"}, ids, "deduplicated, cancelled dropped, sorted")
+		assert.Equal(t, "https://meet.example.com/abc", items[1].ConferencingURL)
+
+		days := groupAgendaByDay(items, start, end, time.UTC)
+		require.Len(t, days, 2)
+		assert.Equal(t, "2026-03-04", days[0].Date)
+		assert.Equal(t, []string{"offsite", "standup"}, []string{days[0].Events[0].ID, days[0].Events[1].ID})
+		assert.Equal(t, "2026-03-05", days[1].Date)
+		assert.Equal(t, []string{"offsite", "review"}, []string{days[1].Events[0].ID, days[1].Events[1].ID},
+			"end_date is exclusive and all-day events lead the day")
+	})
+
+	t.Run("calendar filter", func(t *testing.T) {
+		items, _, err := collectAgenda(context.Background(), agendaTestClient(""), "grant", []string{"primary"}, start, end, true)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, "Work", items[0].Calendar)
+
+		_, _, err = collectAgenda(context.Background(), agendaTestClient(""), "grant", []string{"nope"}, start, end, false)
+		assert.ErrorContains(t, err, "calendar not found")
+	})
+}
+
+func TestGroupAgendaByDay_Timezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	start := time.Date(2026, 3, 4, 0, 0, 0, 0, tokyo)
+	end := start.AddDate(0, 0, 2)
+
+	// 20:00 UTC on Mar 4 is 05:00 on Mar 5 in Tokyo.
+	event := domain.Event{ID: "late", When: domain.EventWhen{
+		StartTime: time.Date(2026, 3, 4, 20, 0, 0, 0, time.UTC).Unix(),
+		EndTime:   time.Date(2026, 3, 4, 21, 0, 0, 0, time.UTC).Unix(),
+	}}
+	days := groupAgendaByDay([]agendaItem{newAgendaItem(event, domain.Calendar{}, tokyo)}, start, end, tokyo)
+	require.Len(t, days, 1)
+	assert.Equal(t, "2026-03-05", days[0].Date)
+	assert.Equal(t, 5, days[0].Events[0].Start.Hour())
+}
+
+func TestWriteAgendaICS(t *testing.T) {
+	start := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	items := []agendaItem{
+		{ID: "e1", Title: "Plan; review, ship", Start: start, End: start.Add(time.Hour), Status: "confirmed",
+			ConferencingURL: "https://meet.example.com/abc",
+			event:           domain.Event{Description: "Line one\nLine two"}},
+		{ID: "e2", Title: "Holiday", AllDay: true, Start: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{ID: "e3", Title: strings.Repeat("é", 60), Start: start, End: start},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeAgendaICS(&buf, items, start))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "UID:e1@nylas\r\n")
+	assert.Contains(t, out, "DTSTART:20260304T090000Z\r\nDTEND:20260304T100000Z\r\n")
+	assert.Contains(t, out, `SUMMARY:Plan\; review\, ship`)
+	assert.Contains(t, out, `DESCRIPTION:Line one\nLine two`)
+	assert.Contains(t, out, "URL:https://meet.example.com/abc\r\n")
+	assert.Contains(t, out, "STATUS:CONFIRMED\r\n")
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20260305\r\nDTEND;VALUE=DATE:20260306\r\n")
+
+	for _, line := range strings.Split(out, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line not folded: %q", line)
+	}
+}
@@ -1,6 +1,8 @@
 package calendar
 
 import (
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -38,11 +40,11 @@ Use machine learning to analyze your calendar patterns and optimize your schedul
 	}
 
 	// Add AI subcommands
-	cmd.AddCommand(newAnalyzeThreadCmd())
-	cmd.AddCommand(newAnalyzeCmd())
+	cmd.AddCommand(common.RequireCapabilities(common.DeclareOutput(newAnalyzeThreadCmd(), domain.EmailThreadAnalysis{}), domain.CapabilityAI))
+	cmd.AddCommand(common.DeclareOutput(newAnalyzeCmd(), domain.MeetingAnalysis{}, domain.MeetingScore{}))
 	cmd.AddCommand(newConflictsCmd())
 	cmd.AddCommand(newRescheduleCmd())
-	cmd.AddCommand(newFocusTimeCmd())
+	cmd.AddCommand(common.DeclareOutput(newFocusTimeCmd(), domain.FocusTimeAnalysis{}, []domain.ProtectedBlock{}))
 	cmd.AddCommand(newAdaptCmd())
 
 	return cmd
@@ -2,7 +2,6 @@ package calendar
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -95,10 +94,8 @@ This command uses AI to:
 				}
 
 				// Output results
-				if common.IsJSON(cmd) {
-					enc := json.NewEncoder(cmd.OutOrStdout())
-					enc.SetIndent("", "  ")
-					return struct{}{}, enc.Encode(analysis)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(analysis)
 				}
 
 				displayThreadAnalysis(analysis)
@@ -22,11 +22,19 @@ func newRescheduleCmd() *cobra.Command {
 Analyzes your calendar patterns to suggest optimal alternative times that work for all participants.`,
 	}
 
-	cmd.AddCommand(newAIRescheduleCmd())
+	cmd.AddCommand(common.DeclareOutput(newAIRescheduleCmd(), rescheduleOutput{}))
 
 	return cmd
 }
 
+// rescheduleOutput is the structured output of reschedule ai.
+type rescheduleOutput struct {
+	Event       *domain.Event             `json:"event"`
+	Suggestions []domain.RescheduleOption `json:"suggestions"`
+	// Result is set when --auto-select applied the best suggestion.
+	Result *domain.RescheduleResult `json:"result,omitempty"`
+}
+
 func newAIRescheduleCmd() *cobra.Command {
 	var (
 		reason         string
@@ -61,22 +69,29 @@ func newAIRescheduleCmd() *cobra.Command {
 			eventID := args[0]
 
 			_, err := common.WithClient([]string{}, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				structured := common.IsStructuredOutput(cmd)
+
 				// Fetch the event to reschedule
-				fmt.Printf("📅 Fetching event %s...\n", eventID)
+				if !structured {
+					fmt.Printf("📅 Fetching event %s...\n", eventID)
+				}
 				event, err := fetchEventByID(ctx, client, grantID, eventID)
 				if err != nil {
 					return struct{}{}, common.WrapFetchError("event", err)
 				}
 
-				fmt.Printf("✓ Found: %s\n", event.Title)
-				fmt.Printf("  Current time: %s\n",
-					time.Unix(event.When.StartTime, 0).Format(common.DisplayWeekdayDateTime))
+				if !structured {
+					fmt.Printf("✓ Found: %s\n", event.Title)
+					fmt.Printf("  Current time: %s\n",
+						time.Unix(event.When.StartTime, 0).Format(common.DisplayWeekdayDateTime))
+
+					fmt.Println("\n🔍 Analyzing your calendar patterns...")
+				}
 
 				// Analyze patterns
-				fmt.Println("\n🔍 Analyzing your calendar patterns...")
 				learner := analytics.NewPatternLearner(client)
 				analysis, err := learner.AnalyzeHistory(ctx, grantID, 90)
-				if err != nil {
+				if err != nil && !structured {
 					fmt.Printf("⚠️  Could not analyze patterns: %v\n", err)
 				}
 
@@ -110,12 +125,25 @@ func newAIRescheduleCmd() *cobra.Command {
 				}
 
 				// Get reschedule suggestions
-				fmt.Println("\n⚙️  Finding optimal alternative times...")
+				if !structured {
+					fmt.Println("\n⚙️  Finding optimal alternative times...")
+				}
 				suggestions, err := findRescheduleSuggestions(ctx, client, resolver, grantID, event, request, patterns)
 				if err != nil {
 					return struct{}{}, common.WrapGetError("reschedule suggestions", err)
 				}
 
+				if structured {
+					out := rescheduleOutput{Event: event, Suggestions: suggestions}
+					if autoSelect && len(suggestions) > 0 {
+						out.Result, err = applyReschedule(ctx, client, grantID, event, suggestions[0], notify, reason)
+						if err != nil {
+							return struct{}{}, common.WrapUpdateError("reschedule", err)
+						}
+					}
+					return struct{}{}, common.GetOutputWriter(cmd).Write(out)
+				}
+
 				// Display suggestions
 				displayRescheduleSuggestions(event, suggestions, reason)
 
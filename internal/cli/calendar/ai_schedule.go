@@ -84,8 +84,11 @@ Examples:
 				privacyLabel = " (Privacy Mode)"
 			}
 
-			fmt.Printf("\n🤖 AI Scheduling Assistant%s\n", privacyLabel)
-			fmt.Printf("Provider: %s\n\n", providerDisplay)
+			structured := common.IsStructuredOutput(cmd)
+			if !structured {
+				fmt.Printf("\n🤖 AI Scheduling Assistant%s\n", privacyLabel)
+				fmt.Printf("Provider: %s\n\n", providerDisplay)
+			}
 
 			// Create AI router
 			router := ai.NewRouter(cfg.AI)
@@ -106,7 +109,9 @@ Examples:
 				}
 
 				// Show processing message
-				fmt.Printf("Processing your request: \"%s\"\n\n", query)
+				if !structured {
+					fmt.Printf("Processing your request: \"%s\"\n\n", query)
+				}
 
 				// Call AI scheduler
 				response, err := scheduler.Schedule(ctx, scheduleReq)
@@ -114,6 +119,18 @@ Examples:
 					return struct{}{}, common.WrapError(err)
 				}
 
+				// Structured output never prompts; --yes still creates the
+				// first option and includes the event.
+				if structured {
+					out := scheduleOutput{ScheduleResponse: response}
+					if autoConfirm && len(response.Options) > 0 {
+						if out.Event, err = createMeetingFromOption(cmd, response.Options[0], grantID, client); err != nil {
+							return struct{}{}, err
+						}
+					}
+					return struct{}{}, common.GetOutputWriter(cmd).Write(out)
+				}
+
 				// Display results
 				if err := displayScheduleOptions(response, userTimezone); err != nil {
 					return struct{}{}, err
@@ -135,7 +152,8 @@ Examples:
 					_, _ = fmt.Scanln(&choice) // User input, validated by selectScheduleOption
 				}
 				if idx := selectScheduleOption(autoConfirm, len(response.Options), choice); idx >= 0 {
-					return struct{}{}, createMeetingFromOption(cmd, response.Options[idx], grantID, client)
+					_, err := createMeetingFromOption(cmd, response.Options[idx], grantID, client)
+					return struct{}{}, err
 				}
 
 				return struct{}{}, nil
@@ -154,6 +172,13 @@ Examples:
 	return cmd
 }
 
+// scheduleOutput is the structured output of schedule ai.
+type scheduleOutput struct {
+	*ai.ScheduleResponse
+	// Event is the meeting created from the first option with --yes.
+	Event *domain.Event `json:"event,omitempty"`
+}
+
 // scheduleGrantArgs builds the grant-resolution args for the AI scheduler.
 // The positional args hold the natural-language query, so the grant comes only
 // from --grant; an empty result makes common.WithClient fall back to the
@@ -242,15 +267,19 @@ func displayScheduleOptions(response *ai.ScheduleResponse, _ string) error {
 }
 
 // createMeetingFromOption creates a calendar event from a selected option.
-func createMeetingFromOption(cmd *cobra.Command, option ai.ScheduleOption, grantID string, client ports.NylasClient) error {
+// It prints the event unless cmd asks for structured output.
+func createMeetingFromOption(cmd *cobra.Command, option ai.ScheduleOption, grantID string, client ports.NylasClient) (*domain.Event, error) {
 	if client == nil {
-		return fmt.Errorf("calendar client is not configured")
+		return nil, fmt.Errorf("calendar client is not configured")
 	}
 	if grantID == "" {
-		return fmt.Errorf("grant ID is required")
+		return nil, fmt.Errorf("grant ID is required")
 	}
 
-	fmt.Println("\nCreating event...")
+	structured := cmd != nil && common.IsStructuredOutput(cmd)
+	if !structured {
+		fmt.Println("\nCreating event...")
+	}
 
 	title := "Meeting"
 	participants := sortedParticipantEmails(option.Participants)
@@ -294,12 +323,15 @@ func createMeetingFromOption(cmd *cobra.Command, option ai.ScheduleOption, grant
 
 	calendarID, err := GetDefaultCalendarID(ctx, client, grantID, "", true)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	event, err := client.CreateEvent(ctx, grantID, calendarID, createReq)
 	if err != nil {
-		return common.WrapCreateError("event", err)
+		return nil, common.WrapCreateError("event", err)
+	}
+	if structured {
+		return event, nil
 	}
 
 	fmt.Printf("✓ Event created\n")
@@ -311,7 +343,7 @@ func createMeetingFromOption(cmd *cobra.Command, option ai.ScheduleOption, grant
 		fmt.Printf("  Participants: %s\n", strings.Join(participants, ", "))
 	}
 
-	return nil
+	return event, nil
 }
 
 func sortedParticipantEmails(participants map[string]ai.ParticipantTime) []string {
@@ -42,7 +42,7 @@ func TestCreateMeetingFromOptionCreatesRealEvent(t *testing.T) {
 		},
 	}
 
-	if err := createMeetingFromOption(nil, option, "grant-123", client); err != nil {
+	if _, err := createMeetingFromOption(nil, option, "grant-123", client); err != nil {
 		t.Fatalf("createMeetingFromOption() error = %v", err)
 	}
 
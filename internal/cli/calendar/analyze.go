@@ -64,17 +64,27 @@ It provides actionable AI recommendations for optimizing your calendar.`,
 
 				// If scoring a specific time
 				if scoreTime != "" {
-					return struct{}{}, scoreSpecificTime(ctx, learner, client, grantID, scoreTime, participants, duration)
+					return struct{}{}, scoreSpecificTime(ctx, cmd, learner, grantID, scoreTime, participants, duration)
 				}
 
+				structured := common.IsStructuredOutput(cmd)
+
 				// Analyze historical patterns
-				fmt.Printf("🔍 Analyzing %d days of meeting history...\n\n", days)
+				if !structured {
+					fmt.Printf("🔍 Analyzing %d days of meeting history...\n\n", days)
+				}
 
 				analysis, err := learner.AnalyzeHistory(ctx, grantID, days)
 				if err != nil {
 					return struct{}{}, common.WrapGetError("meeting analysis", err)
 				}
 
+				// Recommendations are part of the analysis, so --apply has
+				// nothing to add to structured output.
+				if structured {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(analysis)
+				}
+
 				// Display results
 				displayAnalysis(analysis, workingHours)
 
@@ -237,15 +247,19 @@ func displayAnalysis(analysis *domain.MeetingAnalysis, workingHours *domain.DayS
 	}
 }
 
-func scoreSpecificTime(ctx context.Context, learner *analytics.PatternLearner, _ ports.NylasClient, grantID, timeStr string, participants []string, duration int) error {
+func scoreSpecificTime(ctx context.Context, cmd *cobra.Command, learner *analytics.PatternLearner, grantID, timeStr string, participants []string, duration int) error {
 	// Parse the time
 	proposedTime, err := time.Parse(time.RFC3339, timeStr)
 	if err != nil {
 		return common.NewUserError("invalid time format", "use RFC3339 format")
 	}
 
+	structured := common.IsStructuredOutput(cmd)
+
 	// Analyze history to get patterns
-	fmt.Println("🔍 Analyzing historical patterns...")
+	if !structured {
+		fmt.Println("🔍 Analyzing historical patterns...")
+	}
 	analysis, err := learner.AnalyzeHistory(ctx, grantID, 90)
 	if err != nil {
 		return common.WrapGetError("meeting analysis", err)
@@ -259,6 +273,10 @@ func scoreSpecificTime(ctx context.Context, learner *analytics.PatternLearner, _
 	scorer := analytics.NewMeetingScorer(analysis.Patterns)
 	score := scorer.ScoreMeetingTime(proposedTime, participants, duration)
 
+	if structured {
+		return common.GetOutputWriter(cmd).Write(score)
+	}
+
 	// Display score
 	fmt.Printf("\n🎯 Meeting Score for %s\n", proposedTime.Format("Monday, Jan 2, 2006 at 3:04 PM MST"))
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -15,7 +15,7 @@ import (
 func newAvailabilityCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "availability",
-		Aliases: []string{"avail", "freebusy"},
+		Aliases: []string{"avail"},
 		Short:   "Check calendar availability",
 		Long: `Check calendar availability and find free meeting times.
 
@@ -25,8 +25,8 @@ Use 'nylas calendar availability find' to find available meeting slots.
 API reference: https://developer.nylas.com/docs/reference/api/availability/`,
 	}
 
-	cmd.AddCommand(newFreeBusyCmd())
-	cmd.AddCommand(newFindSlotsCmd())
+	cmd.AddCommand(common.DeclareOutput(newFreeBusyCmd(), domain.FreeBusyResponse{}))
+	cmd.AddCommand(common.DeclareOutput(newFindSlotsCmd(), domain.AvailabilityResponse{}))
 
 	return cmd
 }
@@ -6,6 +6,8 @@ import (
 
 	"github.com/nylas/cli/internal/adapters/ai"
 	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
 	"github.com/spf13/cobra"
 )
@@ -25,17 +27,21 @@ View calendars, list events, create new events, and more.
 API reference: https://developer.nylas.com/docs/v3/calendar/`,
 	}
 
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newShowCmd())
-	cmd.AddCommand(newCreateCmd())
-	cmd.AddCommand(newUpdateCmd())
-	cmd.AddCommand(newDeleteCmd())
+	common.RequireScopes(cmd, domain.ScopeCalendarRead)
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.Calendar{}))
+	cmd.AddCommand(common.DeclareOutput(newShowCmd(), domain.Calendar{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newCreateCmd(), domain.Calendar{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newUpdateCmd(), domain.Calendar{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newDeleteCmd(), common.DeleteResult{}), domain.ScopeCalendarWrite))
 	cmd.AddCommand(newEventsCmd())
 	cmd.AddCommand(newAvailabilityCmd())
-	cmd.AddCommand(newResourcesCmd())
+	cmd.AddCommand(common.DeclareOutput(newFreeBusyGridCmd(), freeBusyGrid{}))
+	cmd.AddCommand(common.DeclareOutput(newResourcesCmd(), []domain.RoomResource{}))
 	cmd.AddCommand(newVirtualCmd())
 	cmd.AddCommand(newRecurringCmd())
-	cmd.AddCommand(newFindTimeCmd())
+	cmd.AddCommand(common.DeclareOutput(newAgendaCmd(), []agendaDay{}))
+	cmd.AddCommand(common.DeclareOutput(newFindTimeCmd(), findTimeOutput{}, domain.Event{}))
 	cmd.AddCommand(newScheduleCmd())
 	cmd.AddCommand(newAICmd()) // AI command group includes: analyze, conflicts, reschedule, focus-time, adapt
 
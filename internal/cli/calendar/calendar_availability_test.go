@@ -16,7 +16,8 @@ func TestAvailabilityCmd(t *testing.T) {
 
 	t.Run("has_aliases", func(t *testing.T) {
 		assert.Contains(t, cmd.Aliases, "avail")
-		assert.Contains(t, cmd.Aliases, "freebusy")
+		// Removed when freebusy became the free/busy grid command.
+		assert.NotContains(t, cmd.Aliases, "freebusy")
 	})
 
 	t.Run("has_subcommands", func(t *testing.T) {
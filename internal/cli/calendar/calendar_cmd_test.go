@@ -28,7 +28,7 @@ func TestNewCalendarCmd(t *testing.T) {
 	})
 
 	t.Run("has_required_subcommands", func(t *testing.T) {
-		expectedCmds := []string{"list", "show", "create", "update", "delete", "events", "availability"}
+		expectedCmds := []string{"list", "show", "create", "update", "delete", "events", "availability", "freebusy", "agenda"}
 
 		cmdMap := make(map[string]bool)
 		for _, sub := range cmd.Commands() {
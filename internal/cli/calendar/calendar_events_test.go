@@ -240,7 +240,7 @@ func TestEventsRSVPCmd(t *testing.T) {
 	cmd := newEventsRSVPCmd()
 
 	t.Run("command_name", func(t *testing.T) {
-		assert.Equal(t, "rsvp <event-id> <status> [grant-id]", cmd.Use)
+		assert.Equal(t, "rsvp [event-id] [grant-id]", cmd.Use)
 	})
 
 	t.Run("has_short_description", func(t *testing.T) {
@@ -26,7 +26,7 @@ Detects:
 - Suggests alternative times with scoring`,
 	}
 
-	cmd.AddCommand(newCheckConflictsCmd())
+	cmd.AddCommand(common.DeclareOutput(newCheckConflictsCmd(), domain.ConflictAnalysis{}))
 
 	return cmd
 }
@@ -92,11 +92,15 @@ func newCheckConflictsCmd() *cobra.Command {
 			}
 
 			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				structured := common.IsStructuredOutput(cmd)
+
 				// Analyze patterns first
-				fmt.Println("🔍 Analyzing your calendar patterns...")
+				if !structured {
+					fmt.Println("🔍 Analyzing your calendar patterns...")
+				}
 				learner := analytics.NewPatternLearner(client)
 				analysis, err := learner.AnalyzeHistory(ctx, grantID, 90)
-				if err != nil {
+				if err != nil && !structured {
 					fmt.Printf("⚠️  Could not analyze patterns: %v\n", err)
 				}
 
@@ -109,12 +113,20 @@ func newCheckConflictsCmd() *cobra.Command {
 				resolver := analytics.NewConflictResolver(client, patterns)
 
 				// Detect conflicts
-				fmt.Println("\n⚙️  Detecting conflicts...")
+				if !structured {
+					fmt.Println("\n⚙️  Detecting conflicts...")
+				}
 				conflicts, err := resolver.DetectConflicts(ctx, grantID, proposedEvent, patterns)
 				if err != nil {
 					return struct{}{}, common.WrapGetError("conflicts", err)
 				}
 
+				// Alternatives are ranked best first, so --auto-resolve adds
+				// nothing to structured output.
+				if structured {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(conflicts)
+				}
+
 				// Display results
 				displayConflicts(conflicts)
 
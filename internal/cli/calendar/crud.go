@@ -25,6 +25,10 @@ func newShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("calendar", err)
 				}
 
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(cal)
+				}
+
 				fmt.Println("════════════════════════════════════════════════════════════")
 				_, _ = common.BoldWhite.Printf("Calendar: %s\n", cal.Name)
 				fmt.Println("════════════════════════════════════════════════════════════")
@@ -86,6 +90,10 @@ func newCreateCmd() *cobra.Command {
 					return struct{}{}, common.WrapCreateError("calendar", err)
 				}
 
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(cal)
+				}
+
 				_, _ = common.Green.Printf("✓ Created calendar '%s' (ID: %s)\n", cal.Name, cal.ID)
 				return struct{}{}, nil
 			})
@@ -135,6 +143,10 @@ func newUpdateCmd() *cobra.Command {
 					return struct{}{}, common.WrapUpdateError("calendar", err)
 				}
 
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(cal)
+				}
+
 				_, _ = common.Green.Printf("✓ Updated calendar '%s'\n", cal.Name)
 				return struct{}{}, nil
 			})
@@ -186,6 +198,10 @@ func newDeleteCmd() *cobra.Command {
 					return struct{}{}, common.WrapDeleteError("calendar", err)
 				}
 
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(common.DeleteResult{Resource: "calendar", ID: calendarID, Deleted: true})
+				}
+
 				_, _ = common.Green.Printf("✓ Calendar deleted\n")
 				return struct{}{}, nil
 			})
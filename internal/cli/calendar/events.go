@@ -2,6 +2,9 @@ package calendar
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 )
 
 func newEventsCmd() *cobra.Command {
@@ -14,13 +17,15 @@ func newEventsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/events/`,
 	}
 
-	cmd.AddCommand(newEventsListCmd())
-	cmd.AddCommand(newEventsShowCmd())
-	cmd.AddCommand(newEventsCreateCmd())
-	cmd.AddCommand(newEventsUpdateCmd())
-	cmd.AddCommand(newEventsDeleteCmd())
-	cmd.AddCommand(newEventsRSVPCmd())
-	cmd.AddCommand(newEventsImportCmd())
+	cmd.AddCommand(common.DeclareOutput(newEventsListCmd(), []domain.Event{}, common.Page[domain.Event]{}))
+	cmd.AddCommand(common.DeclareOutput(newEventsShowCmd(), domain.Event{}))
+	cmd.AddCommand(common.DeclareOutput(newEventsCountCmd(), common.CountResult{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newEventsCreateCmd(), domain.Event{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newEventsUpdateCmd(), domain.Event{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newEventsDeleteCmd(), common.DeleteResult{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newEventsCancelCmd(), []cancelOutcome{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newEventsRSVPCmd(), []rsvpOutcome{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newEventsImportCmd(), []domain.Event{}, common.CSVImportResult{}, []*domain.CreateEventRequest{}), domain.ScopeCalendarWrite))
 
 	return cmd
 }
@@ -0,0 +1,298 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/templates"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const (
+	defaultCancelSubject = "Cancelled: {{title}}"
+	defaultCancelBody    = "{{title}} ({{when}}) has been cancelled.\n\n{{message}}"
+)
+
+type cancelOptions struct {
+	calendarID string
+	query      string
+	days       int
+	limit      int
+	notify     string
+	subject    string
+	dryRun     bool
+	yes        bool
+}
+
+// cancelOutcome is the result for one matched event.
+type cancelOutcome struct {
+	EventID   string   `json:"event_id"`
+	Title     string   `json:"title"`
+	When      string   `json:"when"`
+	Cancelled bool     `json:"cancelled"`
+	Notified  []string `json:"notified,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func newEventsCancelCmd() *cobra.Command {
+	opts := cancelOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "cancel [grant-id]",
+		Short: "Cancel every event matching a query",
+		Long: `Cancel upcoming events that match a query and email their participants a
+note. Matching events are always listed first; use --dry-run to stop there.
+
+Queries compare event fields, joined with "and":
+  title~'Q3 review'                 title contains "Q3 review"
+  location='Room 4'                 location equals "Room 4"
+  participant~@acme.com             any participant email or name contains it
+  organizer=me@example.com          organizer email or name equals it
+  title!~standup and status!=tentative
+
+--notify sends each event's participants (except you) a note. The message and
+--subject can use {{title}}, {{when}}, and {{location}}.`,
+		Example: `  # See what would be cancelled
+  nylas calendar events cancel --query "title~'Q3 review'" --dry-run
+
+  # Cancel and tell attendees why
+  nylas calendar events cancel --query "title~'Q3 review'" --notify "Rescheduling to Q4"
+
+  # Look further ahead, with a custom subject
+  nylas calendar events cancel --query "location='Room 4'" --days 90 \
+    --notify "Room 4 is closed for renovation" --subject "Moved: {{title}}"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateRequiredFlag("--query", opts.query); err != nil {
+				return err
+			}
+			filter, err := domain.ParseEventFilter(opts.query)
+			if err != nil {
+				return common.NewUserError(err.Error(), `Example: --query "title~'Q3 review'"`)
+			}
+			if opts.days <= 0 {
+				return common.NewInputError("--days must be positive")
+			}
+
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				return struct{}{}, runEventsCancel(ctx, cmd, client, grantID, filter, opts)
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.calendarID, "calendar", "c", "", "Calendar ID (defaults to primary)")
+	cmd.Flags().StringVar(&opts.query, "query", "", "Events to cancel, e.g. \"title~'Q3 review'\" (required)")
+	cmd.Flags().IntVar(&opts.days, "days", 30, "How many days ahead to search")
+	cmd.Flags().IntVar(&opts.limit, "limit", 500, "Maximum events to search")
+	cmd.Flags().StringVar(&opts.notify, "notify", "", "Email participants this message")
+	cmd.Flags().StringVar(&opts.subject, "subject", defaultCancelSubject, "Subject of the --notify email")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "List matching events without cancelling them")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runEventsCancel(ctx context.Context, cmd *cobra.Command, client ports.NylasClient, grantID string, filter *domain.EventFilter, opts cancelOptions) error {
+	calID, err := GetDefaultCalendarID(ctx, client, grantID, opts.calendarID, false)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	events, err := fetchEvents(ctx, client, grantID, calID, &domain.EventQueryParams{
+		Limit:   opts.limit,
+		OrderBy: "start",
+		Start:   now.Unix(),
+		End:     now.AddDate(0, 0, opts.days).Unix(),
+	}, opts.limit)
+	if err != nil {
+		return common.WrapListError("events", err)
+	}
+
+	var matches []domain.Event
+	skippedReadOnly := 0
+	for i := range events {
+		e := &events[i]
+		if e.Status == "cancelled" || !filter.Match(e) {
+			continue
+		}
+		if e.ReadOnly {
+			skippedReadOnly++
+			continue
+		}
+		matches = append(matches, *e)
+	}
+
+	structured := common.IsStructuredOutput(cmd)
+	if len(matches) == 0 {
+		if structured {
+			return common.GetOutputWriter(cmd).Write([]cancelOutcome{})
+		}
+		common.PrintEmptyStateWithHint("matching events", fmt.Sprintf("Nothing in the next %d days matches; widen the search with --days", opts.days))
+		return nil
+	}
+
+	self := grantEmail(ctx, client, grantID)
+	if !structured {
+		printCancelPlan(matches, self, opts)
+		if skippedReadOnly > 0 {
+			common.PrintWarningStderr("skipping %d matching event(s) you can't modify (organized by someone else)", skippedReadOnly)
+		}
+	}
+	if opts.dryRun {
+		if structured {
+			return common.GetOutputWriter(cmd).Write(planOutcomes(matches))
+		}
+		fmt.Println("Dry run: nothing was cancelled.")
+		return nil
+	}
+	if !opts.yes && !common.Confirm(fmt.Sprintf("Cancel %d event(s)?", len(matches)), false) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	outcomes := make([]cancelOutcome, 0, len(matches))
+	progress := common.NewProgressBar("Cancelling events", len(matches))
+	for i := range matches {
+		outcomes = append(outcomes, cancelEvent(ctx, client, grantID, calID, &matches[i], self, opts))
+		progress.Increment()
+	}
+	progress.Finish()
+
+	if structured {
+		if err := common.GetOutputWriter(cmd).Write(outcomes); err != nil {
+			return err
+		}
+	}
+	return summarizeCancel(outcomes, structured)
+}
+
+// cancelEvent deletes the event, then emails its participants when --notify
+// is set. A failed note does not undo the cancellation.
+func cancelEvent(ctx context.Context, client ports.NylasClient, grantID, calID string, e *domain.Event, self string, opts cancelOptions) cancelOutcome {
+	out := cancelOutcome{EventID: e.ID, Title: e.Title, When: formatEventTime(e.When)}
+	if err := client.DeleteEvent(ctx, grantID, calID, e.ID); err != nil {
+		out.Error = err.Error()
+		return out
+	}
+	out.Cancelled = true
+
+	recipients := noteRecipients(e, self)
+	if opts.notify == "" || len(recipients) == 0 {
+		return out
+	}
+	subject, body := renderCancelNote(e, opts.subject, opts.notify)
+	_, err := client.SendMessage(ctx, grantID, &domain.SendMessageRequest{
+		Subject: subject,
+		Body:    body,
+		To:      recipients,
+	})
+	if err != nil {
+		out.Error = "cancelled, but the note failed: " + err.Error()
+		return out
+	}
+	for _, r := range recipients {
+		out.Notified = append(out.Notified, r.Email)
+	}
+	return out
+}
+
+// noteRecipients returns the participants to notify: everyone with an
+// email address except the grant itself and people who declined.
+func noteRecipients(e *domain.Event, self string) []domain.EmailParticipant {
+	var out []domain.EmailParticipant
+	seen := map[string]bool{}
+	for _, p := range e.Participants {
+		email := strings.ToLower(strings.TrimSpace(p.Email))
+		if email == "" || email == self || p.Status == "no" || seen[email] {
+			continue
+		}
+		seen[email] = true
+		out = append(out, domain.EmailParticipant{Name: p.Name, Email: p.Email})
+	}
+	return out
+}
+
+// renderCancelNote fills the subject and note templates for one event.
+func renderCancelNote(e *domain.Event, subjectTmpl, message string) (string, string) {
+	vars := map[string]string{
+		"title":    e.Title,
+		"when":     formatEventTime(e.When),
+		"location": e.Location,
+	}
+	vars["message"], _ = templates.ExpandVariables(message, vars)
+	subject, _ := templates.ExpandVariables(subjectTmpl, vars)
+	body, _ := templates.ExpandVariables(defaultCancelBody, vars)
+	return subject, strings.TrimSpace(body)
+}
+
+// grantEmail returns the grant's lower-cased email, or "" if unknown.
+func grantEmail(ctx context.Context, client ports.NylasClient, grantID string) string {
+	grant, err := client.GetGrant(ctx, grantID)
+	if err != nil || grant == nil {
+		return ""
+	}
+	return strings.ToLower(grant.Email)
+}
+
+func printCancelPlan(matches []domain.Event, self string, opts cancelOptions) {
+	fmt.Printf("%d event(s) match %s:\n\n", len(matches), common.Cyan.Sprint(opts.query))
+	table := common.NewTable("TITLE", "WHEN", "GUESTS", "ID")
+	for i := range matches {
+		e := &matches[i]
+		title := e.Title
+		if len(e.Recurrence) > 0 {
+			title += " (whole series)"
+		}
+		table.AddRow(common.Truncate(title, 40), formatEventTime(e.When), fmt.Sprintf("%d", len(noteRecipients(e, self))), e.ID)
+	}
+	table.Render()
+	fmt.Println()
+
+	if opts.notify != "" {
+		subject, body := renderCancelNote(&matches[0], opts.subject, opts.notify)
+		fmt.Printf("%s\n  Subject: %s\n", common.Dim.Sprint("Participants will get this note (first event shown):"), subject)
+		for _, line := range strings.Split(body, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+		fmt.Println()
+	}
+}
+
+func planOutcomes(matches []domain.Event) []cancelOutcome {
+	out := make([]cancelOutcome, len(matches))
+	for i := range matches {
+		out[i] = cancelOutcome{EventID: matches[i].ID, Title: matches[i].Title, When: formatEventTime(matches[i].When)}
+	}
+	return out
+}
+
+func summarizeCancel(outcomes []cancelOutcome, structured bool) error {
+	cancelled, notified, failed := 0, 0, 0
+	for _, o := range outcomes {
+		if o.Cancelled {
+			cancelled++
+		}
+		notified += len(o.Notified)
+		if o.Error != "" {
+			failed++
+			if !structured {
+				common.PrintWarningStderr("%s (%s): %s", o.Title, o.EventID, o.Error)
+			}
+		}
+	}
+	if !structured {
+		common.PrintSuccess("Cancelled %d of %d event(s); notified %d participant(s)", cancelled, len(outcomes), notified)
+	}
+	if failed > 0 {
+		return common.NewUserError(fmt.Sprintf("%d event(s) had errors", failed), "Re-run with the same --query to retry the rest")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func cancelTestEvents() []domain.Event {
+	return []domain.Event{
+		{
+			ID:    "evt-1",
+			Title: "Q3 Review",
+			When:  domain.EventWhen{StartTime: 1767261600, EndTime: 1767265200},
+			Participants: []domain.Participant{
+				{Person: domain.Person{Name: "Me", Email: "Me@example.com"}},
+				{Person: domain.Person{Name: "Ana", Email: "ana@example.com"}, Status: "yes"},
+				{Person: domain.Person{Email: "bob@example.com"}, Status: "no"},
+			},
+		},
+		{ID: "evt-2", Title: "Q3 review prep", Status: "cancelled"},
+		{ID: "evt-3", Title: "Q3 Review (theirs)", ReadOnly: true},
+		{ID: "evt-4", Title: "Standup"},
+	}
+}
+
+func newCancelTestClient(events []domain.Event) (*testCalendarClient, *[]string, *[]*domain.SendMessageRequest) {
+	var deleted []string
+	var sent []*domain.SendMessageRequest
+	client := &testCalendarClient{
+		MockClient: nylas.NewMockClient(),
+		getEventsWithCursorFunc: func(context.Context, string, string, *domain.EventQueryParams) (*domain.EventListResponse, error) {
+			return &domain.EventListResponse{Data: events}, nil
+		},
+	}
+	client.GetGrantFunc = func(context.Context, string) (*domain.Grant, error) {
+		return &domain.Grant{ID: "grant-1", Email: "me@example.com"}, nil
+	}
+	client.DeleteEventFunc = func(_ context.Context, _, _, eventID string) error {
+		deleted = append(deleted, eventID)
+		return nil
+	}
+	client.SendMessageFunc = func(_ context.Context, _ string, req *domain.SendMessageRequest) (*domain.Message, error) {
+		sent = append(sent, req)
+		return &domain.Message{ID: "msg-1"}, nil
+	}
+	return client, &deleted, &sent
+}
+
+func TestRunEventsCancel(t *testing.T) {
+	filter, err := domain.ParseEventFilter("title~'q3 review'")
+	require.NoError(t, err)
+	opts := cancelOptions{calendarID: "cal-1", days: 30, limit: 100, subject: defaultCancelSubject}
+
+	t.Run("dry run cancels nothing", func(t *testing.T) {
+		client, deleted, sent := newCancelTestClient(cancelTestEvents())
+		dry := opts
+		dry.dryRun = true
+		dry.notify = "Moving to Q4"
+
+		require.NoError(t, runEventsCancel(context.Background(), &cobra.Command{}, client, "grant-1", filter, dry))
+		assert.Empty(t, *deleted)
+		assert.Empty(t, *sent)
+	})
+
+	t.Run("cancels matches and notifies participants", func(t *testing.T) {
+		client, deleted, sent := newCancelTestClient(cancelTestEvents())
+		run := opts
+		run.yes = true
+		run.notify = "Moving {{title}} to Q4"
+
+		require.NoError(t, runEventsCancel(context.Background(), &cobra.Command{}, client, "grant-1", filter, run))
+		assert.Equal(t, []string{"evt-1"}, *deleted)
+		require.Len(t, *sent, 1)
+		assert.Equal(t, "Cancelled: Q3 Review", (*sent)[0].Subject)
+		assert.Contains(t, (*sent)[0].Body, "Moving Q3 Review to Q4")
+		assert.Equal(t, []domain.EmailParticipant{{Name: "Ana", Email: "ana@example.com"}}, (*sent)[0].To)
+	})
+
+	t.Run("delete failure is reported", func(t *testing.T) {
+		client, _, sent := newCancelTestClient(cancelTestEvents())
+		client.DeleteEventFunc = func(context.Context, string, string, string) error {
+			return errors.New("boom")
+		}
+		run := opts
+		run.yes = true
+		run.notify = "x"
+
+		err := runEventsCancel(context.Background(), &cobra.Command{}, client, "grant-1", filter, run)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 event(s) had errors")
+		assert.Empty(t, *sent)
+	})
+}
+
+func TestCancelEvent_NoteFailureKeepsCancellation(t *testing.T) {
+	client, _, _ := newCancelTestClient(nil)
+	client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+		return nil, errors.New("smtp down")
+	}
+	event := cancelTestEvents()[0]
+
+	out := cancelEvent(context.Background(), client, "grant-1", "cal-1", &event, "me@example.com", cancelOptions{notify: "sorry", subject: defaultCancelSubject})
+	assert.True(t, out.Cancelled)
+	assert.Contains(t, out.Error, "note failed")
+	assert.Empty(t, out.Notified)
+}
+
+func TestRenderCancelNote(t *testing.T) {
+	event := &domain.Event{Title: "Planning", Location: "Room 4"}
+	subject, body := renderCancelNote(event, "Moved: {{title}}", "We lost {{location}}.")
+	assert.Equal(t, "Moved: Planning", subject)
+	assert.Contains(t, body, "Planning")
+	assert.Contains(t, body, "has been cancelled.")
+	assert.Contains(t, body, "We lost Room 4.")
+}
+
+func TestEventsCancelCmd_Validation(t *testing.T) {
+	cmd := newEventsCancelCmd()
+	cmd.SetArgs([]string{})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	assert.ErrorContains(t, cmd.Execute(), "--query")
+
+	cmd = newEventsCancelCmd()
+	cmd.SetArgs([]string{"--query", "when~today"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	assert.ErrorContains(t, cmd.Execute(), "unknown field")
+}
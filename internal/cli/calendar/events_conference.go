@@ -0,0 +1,141 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nylas/cli/internal/adapters/templates"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// conferenceOptions are the --conference flags of `events create`.
+type conferenceOptions struct {
+	provider   string // zoom, meet, or teams
+	autocreate bool
+}
+
+// conferenceResult describes how the conference link was attached.
+type conferenceResult struct {
+	autocreated bool
+	fallback    string // why autocreate was skipped, when a static URL was used
+}
+
+// validate checks the flags before any API call.
+func (o conferenceOptions) validate() error {
+	if o.provider == "" {
+		if o.autocreate {
+			return common.NewUserError("--autocreate requires --conference", "Example: --conference meet --autocreate")
+		}
+		return nil
+	}
+	return common.ValidateOneOf("conference", o.provider, domain.ConferenceProviderNames())
+}
+
+// staticConferencing builds conferencing from the configured join URL.
+func staticConferencing(provider, title string, cfg *domain.ConferencingConfig) (*domain.Conferencing, bool) {
+	tmpl := cfg.JoinURL(provider)
+	if tmpl == "" {
+		return nil, false
+	}
+	joinURL, _ := templates.ExpandVariables(tmpl, map[string]string{"title": title})
+	return &domain.Conferencing{
+		Provider: domain.ConferenceProviders[provider],
+		Details:  &domain.ConferencingDetails{URL: joinURL},
+	}, true
+}
+
+// missingJoinURLError explains how to configure a static join URL.
+func missingJoinURLError(provider, reason string) error {
+	return common.NewUserErrorWithSuggestions(
+		fmt.Sprintf("%s; no static %s join URL is configured", reason, provider),
+		fmt.Sprintf("Set one with: nylas config set conferencing.%s_url <join-url>", provider),
+		"Or try --autocreate to have the provider create the meeting",
+	)
+}
+
+// createEventWithConference creates the event with a conference link. With
+// autocreate, the provider provisions the meeting; if it can't (unsupported
+// provider for this account, no Zoom grant), the configured static join URL
+// is used instead.
+func createEventWithConference(ctx context.Context, client ports.NylasClient, grantID, calendarID string, req *domain.CreateEventRequest, opts conferenceOptions, cfg *domain.ConferencingConfig) (*domain.Event, conferenceResult, error) {
+	var result conferenceResult
+	if opts.provider == "" {
+		event, err := client.CreateEvent(ctx, grantID, calendarID, req)
+		return event, result, err
+	}
+
+	static, hasStatic := staticConferencing(opts.provider, req.Title, cfg)
+	if !opts.autocreate {
+		if !hasStatic {
+			return nil, result, missingJoinURLError(opts.provider, "--conference without --autocreate needs a join URL")
+		}
+		req.Conferencing = static
+		event, err := client.CreateEvent(ctx, grantID, calendarID, req)
+		return event, result, err
+	}
+
+	autocreate := &domain.ConferencingAutocreate{}
+	if opts.provider == "zoom" {
+		if cfg == nil || cfg.ZoomGrantID == "" {
+			result.fallback = "Zoom autocreate needs conferencing.zoom_grant_id"
+		} else {
+			autocreate.ConfGrantID = cfg.ZoomGrantID
+		}
+	}
+
+	if result.fallback == "" {
+		req.Conferencing = &domain.Conferencing{
+			Provider:   domain.ConferenceProviders[opts.provider],
+			Autocreate: autocreate,
+		}
+		event, err := client.CreateEvent(ctx, grantID, calendarID, req)
+		if err == nil || !isAutocreateRejected(err) {
+			result.autocreated = err == nil
+			return event, result, err
+		}
+		result.fallback = "the provider rejected autocreate: " + err.Error()
+	}
+
+	if !hasStatic {
+		return nil, result, missingJoinURLError(opts.provider, "couldn't autocreate a meeting ("+result.fallback+")")
+	}
+	req.Conferencing = static
+	event, err := client.CreateEvent(ctx, grantID, calendarID, req)
+	return event, result, err
+}
+
+// isAutocreateRejected reports whether the API refused the conferencing
+// request itself, as opposed to failing for auth or availability reasons.
+func isAutocreateRejected(err error) bool {
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
+
+// printConference prints the conference line of a created event.
+func printConference(event *domain.Event, result conferenceResult) {
+	if result.fallback != "" {
+		common.PrintWarningStderr("used the static join URL: %s", result.fallback)
+	}
+	if event.Conferencing == nil {
+		if result.autocreated {
+			fmt.Println("Conference: provisioning (run 'nylas calendar events show' shortly for the link)")
+		}
+		return
+	}
+	if d := event.Conferencing.Details; d != nil && d.URL != "" {
+		fmt.Printf("Conference: %s (%s)\n", d.URL, event.Conferencing.Provider)
+	} else if result.autocreated {
+		fmt.Printf("Conference: %s meeting provisioning (run 'nylas calendar events show' shortly for the link)\n", event.Conferencing.Provider)
+	}
+}
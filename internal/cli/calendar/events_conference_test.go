@@ -0,0 +1,101 @@
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// recordCreates makes CreateEvent record each conferencing request and
+// fail with rejectErr while autocreate is requested.
+func recordCreates(client *nylas.MockClient, rejectErr error) *[]*domain.Conferencing {
+	var sent []*domain.Conferencing
+	client.CreateEventFunc = func(_ context.Context, _, _ string, req *domain.CreateEventRequest) (*domain.Event, error) {
+		sent = append(sent, req.Conferencing)
+		if rejectErr != nil && req.Conferencing != nil && req.Conferencing.Autocreate != nil {
+			return nil, rejectErr
+		}
+		return &domain.Event{ID: "evt-1", Title: req.Title, Conferencing: req.Conferencing}, nil
+	}
+	return &sent
+}
+
+func TestCreateEventWithConference(t *testing.T) {
+	ctx := context.Background()
+	cfg := &domain.ConferencingConfig{ZoomURL: "https://zoom.us/j/123?t={{title}}", MeetURL: "https://meet.google.com/abc-defg-hij"}
+
+	t.Run("autocreate sends provider and autocreate", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		sent := recordCreates(client, nil)
+
+		_, result, err := createEventWithConference(ctx, client, "grant", "cal", &domain.CreateEventRequest{Title: "Sync"}, conferenceOptions{provider: "meet", autocreate: true}, cfg)
+		require.NoError(t, err)
+		assert.True(t, result.autocreated)
+		require.Len(t, *sent, 1)
+		assert.Equal(t, "Google Meet", (*sent)[0].Provider)
+		assert.NotNil(t, (*sent)[0].Autocreate)
+	})
+
+	t.Run("rejected autocreate falls back to static URL", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		sent := recordCreates(client, &domain.APIError{StatusCode: http.StatusBadRequest, Message: "conferencing not supported"})
+
+		event, result, err := createEventWithConference(ctx, client, "grant", "cal", &domain.CreateEventRequest{Title: "Sync"}, conferenceOptions{provider: "meet", autocreate: true}, cfg)
+		require.NoError(t, err)
+		assert.False(t, result.autocreated)
+		assert.Contains(t, result.fallback, "rejected autocreate")
+		require.Len(t, *sent, 2)
+		assert.Equal(t, "https://meet.google.com/abc-defg-hij", event.Conferencing.Details.URL)
+		assert.Nil(t, event.Conferencing.Autocreate)
+	})
+
+	t.Run("other API errors are returned", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		recordCreates(client, &domain.APIError{StatusCode: http.StatusInternalServerError})
+
+		_, _, err := createEventWithConference(ctx, client, "grant", "cal", &domain.CreateEventRequest{Title: "Sync"}, conferenceOptions{provider: "meet", autocreate: true}, cfg)
+		assert.Error(t, err)
+	})
+
+	t.Run("zoom without grant uses static URL without trying", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		sent := recordCreates(client, nil)
+
+		event, result, err := createEventWithConference(ctx, client, "grant", "cal", &domain.CreateEventRequest{Title: "Sync"}, conferenceOptions{provider: "zoom", autocreate: true}, cfg)
+		require.NoError(t, err)
+		assert.Contains(t, result.fallback, "zoom_grant_id")
+		require.Len(t, *sent, 1)
+		assert.Equal(t, "https://zoom.us/j/123?t=Sync", event.Conferencing.Details.URL)
+		assert.Equal(t, "Zoom Meeting", event.Conferencing.Provider)
+	})
+
+	t.Run("zoom autocreate passes the zoom grant", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		sent := recordCreates(client, nil)
+
+		_, _, err := createEventWithConference(ctx, client, "grant", "cal", &domain.CreateEventRequest{Title: "Sync"}, conferenceOptions{provider: "zoom", autocreate: true}, &domain.ConferencingConfig{ZoomGrantID: "zoom-grant"})
+		require.NoError(t, err)
+		assert.Equal(t, "zoom-grant", (*sent)[0].Autocreate.ConfGrantID)
+	})
+
+	t.Run("no static URL configured", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		recordCreates(client, nil)
+
+		_, _, err := createEventWithConference(ctx, client, "grant", "cal", &domain.CreateEventRequest{Title: "Sync"}, conferenceOptions{provider: "teams"}, cfg)
+		assert.ErrorContains(t, err, "no static teams join URL")
+	})
+}
+
+func TestConferenceOptionsValidate(t *testing.T) {
+	assert.NoError(t, conferenceOptions{}.validate())
+	assert.NoError(t, conferenceOptions{provider: "teams", autocreate: true}.validate())
+	assert.ErrorContains(t, conferenceOptions{provider: "webex"}.validate(), "invalid conference")
+	assert.ErrorContains(t, conferenceOptions{autocreate: true}.validate(), "requires --conference")
+}
@@ -0,0 +1,81 @@
+package calendar
+
+import (
+	"context"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+func newEventsCountCmd() *cobra.Command {
+	var (
+		calendarID    string
+		days          int
+		title         string
+		showCancelled bool
+		maxItems      int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "count [grant-id]",
+		Short: "Count events",
+		Long: `Count events in a calendar without downloading them.
+
+Count walks every page with only event IDs selected, one request per 200
+events. The output is the bare number, with a trailing "+" when --max was
+reached. Use --json for the count, whether it is complete, and the number of
+pages fetched.
+
+Like 'events list', count covers the next 7 days of the primary calendar by
+default. Use --days 0 to count every event.`,
+		Example: `  # Events in the next week
+  nylas calendar events count
+
+  # Events in the next 30 days of a specific calendar
+  nylas calendar events count --calendar <calendar-id> --days 30
+
+  # All events titled "1:1"
+  nylas calendar events count --days 0 --title "1:1" --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (common.CountResult, error) {
+				calID, err := GetDefaultCalendarID(ctx, client, grantID, calendarID, false)
+				if err != nil {
+					return common.CountResult{}, err
+				}
+				params := eventListParams(common.MaxAPILimit, days, showCancelled)
+				params.OrderBy = ""
+				params.Title = title
+				params.Select = "id"
+				return countEvents(ctx, client, grantID, calID, &params, maxItems)
+			})
+			if err != nil {
+				return common.WrapListError("events", err)
+			}
+			return common.PrintCount(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&calendarID, "calendar", "c", "", "Calendar ID (defaults to primary)")
+	cmd.Flags().IntVarP(&days, "days", "d", 7, "Count events in the next N days (0 for no limit)")
+	cmd.Flags().StringVar(&title, "title", "", "Count events whose title contains this text")
+	cmd.Flags().BoolVar(&showCancelled, "show-cancelled", false, "Include cancelled events")
+	cmd.Flags().IntVar(&maxItems, "max", 0, "Stop counting at this many events (0=unlimited)")
+
+	return cmd
+}
+
+// countEvents counts the events matching params page by page.
+func countEvents(ctx context.Context, client ports.NylasClient, grantID, calendarID string, params *domain.EventQueryParams, maxItems int) (common.CountResult, error) {
+	return common.CountPages(ctx, "events", maxItems, func(ctx context.Context, cursor string) (common.PageResult[domain.Event], error) {
+		page := *params
+		page.PageToken = cursor
+		resp, err := client.GetEventsWithCursor(ctx, grantID, calendarID, &page)
+		if err != nil {
+			return common.PageResult[domain.Event]{}, err
+		}
+		return common.PageResult[domain.Event]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+}
@@ -28,6 +28,7 @@ func newEventsCreateCmd() *cobra.Command {
 		ignoreWorkingHours bool
 		lockTimezone       bool
 		eventTimezone      string
+		conference         conferenceOptions
 	)
 
 	cmd := &cobra.Command{
@@ -44,9 +45,21 @@ Examples:
 
   # Create event with participants
   nylas calendar events create --title "Team Sync" --start "2024-01-15 10:00" --end "2024-01-15 11:00" \
-    --participant "alice@example.com" --participant "bob@example.com"`,
+    --participant "alice@example.com" --participant "bob@example.com"
+
+  # Create event with an auto-provisioned Google Meet link
+  nylas calendar events create --title "Standup" --start "2024-01-15 09:00" --conference meet --autocreate
+
+Conference links:
+  --conference zoom|meet|teams attaches a meeting. With --autocreate the
+  provider creates it (Zoom also needs conferencing.zoom_grant_id); if that
+  isn't possible, the static URL from conferencing.<provider>_url is used.
+  Without --autocreate the static URL is always used.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := conference.validate(); err != nil {
+				return err
+			}
 			if title == "" {
 				return common.NewUserError(
 					"title is required",
@@ -153,15 +166,26 @@ Examples:
 					req.Metadata["timezone_locked"] = "true"
 				}
 
+				var conferencing *domain.ConferencingConfig
+				if conference.provider != "" {
+					if cfg, err := config.NewDefaultFileStore().Load(); err == nil {
+						conferencing = cfg.Conferencing
+					}
+				}
+
+				var confResult conferenceResult
 				event, err := common.RunWithSpinnerResult("Creating event...", func() (*domain.Event, error) {
-					return client.CreateEvent(ctx, grantID, calID, req)
+					var event *domain.Event
+					var err error
+					event, confResult, err = createEventWithConference(ctx, client, grantID, calID, req, conference, conferencing)
+					return event, err
 				})
 				if err != nil {
 					return struct{}{}, common.WrapCreateError("event", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(event)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(event)
 				}
 
 				fmt.Printf("%s Event created successfully!\n\n", common.Green.Sprint("✓"))
@@ -171,6 +195,9 @@ Examples:
 					fmt.Printf("%s %s\n", common.Cyan.Sprint("🔒 Timezone locked:"), when.StartTimezone)
 					fmt.Println("     This event will always display in this timezone, regardless of viewer's location.")
 				}
+				if conference.provider != "" {
+					printConference(event, confResult)
+				}
 				fmt.Printf("ID: %s\n", event.ID)
 
 				return struct{}{}, nil
@@ -193,6 +220,8 @@ Examples:
 	cmd.Flags().BoolVar(&ignoreWorkingHours, "ignore-working-hours", false, "Skip working hours validation")
 	cmd.Flags().BoolVar(&lockTimezone, "lock-timezone", false, "Lock event to its timezone (always display in this timezone)")
 	cmd.Flags().StringVar(&eventTimezone, "timezone", "", "IANA timezone for start/end times (e.g., America/Los_Angeles). Defaults to system timezone.")
+	cmd.Flags().StringVar(&conference.provider, "conference", "", "Attach a meeting link: zoom, meet, or teams")
+	cmd.Flags().BoolVar(&conference.autocreate, "autocreate", false, "Have the conferencing provider create the meeting (with --conference)")
 
 	_ = cmd.MarkFlagRequired("title")
 	_ = cmd.MarkFlagRequired("start")
@@ -245,6 +274,7 @@ func newEventsDeleteCmd() *cobra.Command {
 				GrantID:      resourceArgs.GrantID,
 				Force:        force,
 				DeleteFunc:   deleteFunc,
+				Cmd:          cmd,
 			})
 		},
 	}
@@ -271,6 +301,9 @@ func newEventsUpdateCmd() *cobra.Command {
 		lockTimezone   bool
 		unlockTimezone bool
 		eventTimezone  string
+		occurrence     string
+		following      bool
+		allOccurrences bool
 	)
 
 	cmd := &cobra.Command{
@@ -286,12 +319,38 @@ Examples:
   nylas calendar events update <event-id> --start "2024-01-15 14:00" --end "2024-01-15 15:00"
 
   # Update location and description
-  nylas calendar events update <event-id> --location "Conference Room A" --description "Weekly sync"`,
+  nylas calendar events update <event-id> --location "Conference Room A" --description "Weekly sync"
+
+Recurring events:
+  --occurrence <date>                     Change only the occurrence on that date
+  --occurrence <date> --this-and-following
+                                          Change that occurrence and all later ones;
+                                          the original series ends before it and a
+                                          new series continues with the changes
+  --all                                   Change the master event (every occurrence)
+
+  The event ID may be the master event or any of its instances. When a new
+  start moves a weekly series to another weekday, a single-day BYDAY moves
+  with it.
+
+  # Move one occurrence
+  nylas calendar events update <event-id> --occurrence 2024-03-04 --start "2024-03-04 15:00" --end "2024-03-04 16:00"
+
+  # Rename the series from March 11 on
+  nylas calendar events update <event-id> --occurrence 2024-03-11 --this-and-following --title "Team Sync v2"
+
+  # Change the location of every occurrence
+  nylas calendar events update <event-id> --all --location "Room 4"`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			eventID := args[0]
 			grantArgs := args[1:]
 
+			scope, err := resolveRecurringEditScope(occurrence, following, allOccurrences)
+			if err != nil {
+				return err
+			}
+
 			// The timezone is only applied while parsing new times, so alone
 			// it would silently do nothing.
 			if cmd.Flags().Changed("timezone") && !cmd.Flags().Changed("start") {
@@ -301,7 +360,7 @@ Examples:
 				)
 			}
 
-			_, err := common.WithClient(grantArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+			_, err = common.WithClient(grantArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				// Get calendar ID if not specified
 				calID, err := GetDefaultCalendarID(ctx, client, grantID, calendarID, false)
 				if err != nil {
@@ -376,28 +435,26 @@ Examples:
 					}
 				}
 
+				var note string
+				if scope != editSingleEvent {
+					event, err := common.RunWithSpinnerResult("Updating recurring event...", func() (*domain.Event, error) {
+						event, n, err := updateRecurringEvent(ctx, client, grantID, calID, eventID, scope, occurrence, req)
+						note = n
+						return event, err
+					})
+					if err != nil {
+						return struct{}{}, err
+					}
+					return struct{}{}, printUpdatedEvent(cmd, event, note, lockTimezone, unlockTimezone)
+				}
+
 				event, err := common.RunWithSpinnerResult("Updating event...", func() (*domain.Event, error) {
 					return client.UpdateEvent(ctx, grantID, calID, eventID, req)
 				})
 				if err != nil {
 					return struct{}{}, common.WrapUpdateError("event", err)
 				}
-
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(event)
-				}
-
-				fmt.Printf("%s Event updated successfully!\n\n", common.Green.Sprint("✓"))
-				fmt.Printf("Title: %s\n", event.Title)
-				fmt.Printf("When: %s\n", formatEventTime(event.When))
-				if lockTimezone {
-					fmt.Printf("%s Timezone is now locked\n", common.Cyan.Sprint("🔒"))
-				} else if unlockTimezone {
-					fmt.Printf("%s Timezone lock removed\n", common.Cyan.Sprint("🔓"))
-				}
-				fmt.Printf("ID: %s\n", event.ID)
-
-				return struct{}{}, nil
+				return struct{}{}, printUpdatedEvent(cmd, event, note, lockTimezone, unlockTimezone)
 			})
 			return err
 		},
@@ -417,6 +474,29 @@ Examples:
 	cmd.Flags().BoolVar(&lockTimezone, "lock-timezone", false, "Lock event to its timezone")
 	cmd.Flags().BoolVar(&unlockTimezone, "unlock-timezone", false, "Remove timezone lock from event")
 	cmd.Flags().StringVar(&eventTimezone, "timezone", "", "IANA timezone for start/end times (e.g., America/Los_Angeles). Defaults to system timezone.")
+	cmd.Flags().StringVar(&occurrence, "occurrence", "", "Recurring events: update only the occurrence on this date (YYYY-MM-DD)")
+	cmd.Flags().BoolVar(&following, "this-and-following", false, "Recurring events: with --occurrence, also update all later occurrences")
+	cmd.Flags().BoolVar(&allOccurrences, "all", false, "Recurring events: update every occurrence (the master event)")
 
 	return cmd
 }
+
+func printUpdatedEvent(cmd *cobra.Command, event *domain.Event, note string, lockTimezone, unlockTimezone bool) error {
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(event)
+	}
+
+	fmt.Printf("%s Event updated successfully!\n\n", common.Green.Sprint("✓"))
+	fmt.Printf("Title: %s\n", event.Title)
+	fmt.Printf("When: %s\n", formatEventTime(event.When))
+	if lockTimezone {
+		fmt.Printf("%s Timezone is now locked\n", common.Cyan.Sprint("🔒"))
+	} else if unlockTimezone {
+		fmt.Printf("%s Timezone lock removed\n", common.Cyan.Sprint("🔓"))
+	}
+	fmt.Printf("ID: %s\n", event.ID)
+	if note != "" {
+		fmt.Println(note)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// eventCSVFields are the fields a CSV import can set. The export uses the
+// same names, so `events list --format csv` output imports unchanged.
+var eventCSVFields = []string{
+	"title", "description", "location", "start", "end", "all_day", "timezone",
+	"participants", "busy", "visibility",
+}
+
+// eventCSVTimeLayout is how timed events are exported; parseEventTime reads
+// it back in the row's timezone.
+const eventCSVTimeLayout = "2006-01-02 15:04"
+
+// eventCSVColumns are the columns of `events list --format csv`. Timed
+// events are written as wall-clock times in their own timezone.
+var eventCSVColumns = []common.CSVColumn[domain.Event]{
+	{Name: "id", Value: func(e domain.Event) string { return e.ID }},
+	{Name: "calendar_id", Value: func(e domain.Event) string { return e.CalendarID }},
+	{Name: "title", Value: func(e domain.Event) string { return e.Title }},
+	{Name: "description", Value: func(e domain.Event) string { return e.Description }},
+	{Name: "location", Value: func(e domain.Event) string { return e.Location }},
+	{Name: "start", Value: func(e domain.Event) string { start, _, _ := eventCSVTimes(e.When); return start }},
+	{Name: "end", Value: func(e domain.Event) string { _, end, _ := eventCSVTimes(e.When); return end }},
+	{Name: "all_day", Value: func(e domain.Event) string { return strconv.FormatBool(e.When.IsAllDay()) }},
+	{Name: "timezone", Value: func(e domain.Event) string { _, _, tz := eventCSVTimes(e.When); return tz }},
+	{Name: "participants", Value: func(e domain.Event) string {
+		emails := make([]string, len(e.Participants))
+		for i, p := range e.Participants {
+			emails[i] = p.Email
+		}
+		return strings.Join(emails, "; ")
+	}},
+	{Name: "busy", Value: func(e domain.Event) string { return strconv.FormatBool(e.Busy) }},
+	{Name: "visibility", Value: func(e domain.Event) string { return e.Visibility }},
+	{Name: "status", Value: func(e domain.Event) string { return e.Status }},
+}
+
+// eventCSVTimes returns the start, end and timezone cells for when.
+func eventCSVTimes(when domain.EventWhen) (string, string, string) {
+	if when.IsAllDay() {
+		start := when.Date
+		if start == "" {
+			start = when.StartDate
+		}
+		end := when.EndDate
+		if end == "" {
+			end = start
+		}
+		return start, end, ""
+	}
+
+	tz := when.StartTimezone
+	loc, err := time.LoadLocation(tz)
+	if tz == "" || err != nil {
+		tz, loc = "UTC", time.UTC
+	}
+	return time.Unix(when.StartTime, 0).In(loc).Format(eventCSVTimeLayout),
+		time.Unix(when.EndTime, 0).In(loc).Format(eventCSVTimeLayout), tz
+}
+
+// eventFromCSV builds a create request from an imported row. Rows without a
+// timezone use defaultTZ (the system timezone when empty).
+func eventFromCSV(fields map[string]string, defaultTZ string) (*domain.CreateEventRequest, error) {
+	if fields["title"] == "" {
+		return nil, fmt.Errorf("row has no title")
+	}
+	if fields["start"] == "" {
+		return nil, fmt.Errorf("row has no start")
+	}
+
+	allDay, err := parseCSVBool(fields["all_day"], false)
+	if err != nil {
+		return nil, fmt.Errorf("all_day: %w", err)
+	}
+	busy, err := parseCSVBool(fields["busy"], true)
+	if err != nil {
+		return nil, fmt.Errorf("busy: %w", err)
+	}
+
+	tz := fields["timezone"]
+	if tz == "" {
+		tz = defaultTZ
+	}
+	when, err := parseEventTime(fields["start"], fields["end"], allDay, tz)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &domain.CreateEventRequest{
+		Title:       fields["title"],
+		Description: fields["description"],
+		Location:    fields["location"],
+		When:        *when,
+		Busy:        busy,
+		Visibility:  fields["visibility"],
+	}
+	for _, email := range common.SplitCSVList(fields["participants"]) {
+		req.Participants = append(req.Participants, domain.Participant{Person: domain.Person{Email: email}})
+	}
+	return req, nil
+}
+
+// parseCSVBool accepts the spellings spreadsheets commonly use.
+func parseCSVBool(value string, def bool) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return def, nil
+	case "true", "yes", "y", "1", "x":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected true or false, got %q", value)
+	}
+}
+
+// runEventsCSVImport creates one event per CSV row in the calendar (the
+// primary writable calendar by default). With dryRun it only validates the
+// rows and shows what would be created.
+func runEventsCSVImport(cmd *cobra.Command, args []string, path, mappingPath, calendarID, tz string, dryRun bool) error {
+	if tz != "" {
+		if err := validateTimeZone(tz); err != nil {
+			return err
+		}
+	}
+
+	records, err := common.ReadCSVFile(path, mappingPath, eventCSVFields)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		common.PrintEmptyStateWithHint("rows", "Check that the file has data rows below the header")
+		return nil
+	}
+	build := func(fields map[string]string) (*domain.CreateEventRequest, error) {
+		return eventFromCSV(fields, tz)
+	}
+
+	if dryRun {
+		return printEventImportPreview(cmd, records, build)
+	}
+
+	_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+		calID, err := GetDefaultCalendarID(ctx, client, grantID, calendarID, true)
+		if err != nil {
+			return struct{}{}, err
+		}
+
+		result := common.ImportCSVRecords(records, build, func(req *domain.CreateEventRequest) (string, error) {
+			event, err := client.CreateEvent(ctx, grantID, calID, req)
+			if err != nil {
+				return "", common.WrapCreateError("event", err)
+			}
+			return event.ID, nil
+		})
+
+		if common.IsStructuredOutput(cmd) && !common.IsCSV(cmd) {
+			return struct{}{}, common.GetOutputWriter(cmd).Write(result)
+		}
+		common.PrintCSVImportResult(cmd.OutOrStdout(), result, "event(s) into "+calID)
+		if len(result.Failed) > 0 {
+			return struct{}{}, common.NewUserError(
+				fmt.Sprintf("%d of %d rows failed to import", len(result.Failed), len(records)),
+				"Fix the listed rows and import them again",
+			)
+		}
+		return struct{}{}, nil
+	})
+	return err
+}
+
+func printEventImportPreview(cmd *cobra.Command, records []common.CSVRecord,
+	build func(map[string]string) (*domain.CreateEventRequest, error)) error {
+	reqs, failed := common.BuildCSVRecords(records, build)
+	if common.IsStructuredOutput(cmd) && !common.IsCSV(cmd) {
+		return common.GetOutputWriter(cmd).Write(reqs)
+	}
+
+	w := cmd.OutOrStdout()
+	table := common.NewTable("TITLE", "WHEN", "PARTICIPANTS").SetWriter(w)
+	for _, req := range reqs {
+		table.AddRow(req.Title, formatEventTime(req.When), strconv.Itoa(len(req.Participants)))
+	}
+	table.Render()
+	common.PrintCSVDryRun(w, len(reqs), "event(s)", failed)
+	return nil
+}
@@ -0,0 +1,93 @@
+package calendar
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestEventCSVRoundTrip(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	start := time.Date(2024, 6, 3, 9, 30, 0, 0, berlin)
+
+	events := []domain.Event{
+		{
+			ID:    "evt-1",
+			Title: "Planning",
+			When: domain.EventWhen{
+				Object:        "timespan",
+				StartTime:     start.Unix(),
+				EndTime:       start.Add(90 * time.Minute).Unix(),
+				StartTimezone: "Europe/Berlin",
+			},
+			Participants: []domain.Participant{{Person: domain.Person{Email: "a@x.com"}}, {Person: domain.Person{Email: "b@x.com"}}},
+			Busy:         true,
+		},
+		{
+			ID:    "evt-2",
+			Title: "Offsite",
+			When:  domain.EventWhen{Object: "datespan", StartDate: "2024-06-10", EndDate: "2024-06-12"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, common.WriteCSV(&buf, events, eventCSVColumns))
+	assert.Contains(t, buf.String(), "2024-06-03 09:30,2024-06-03 11:00,false,Europe/Berlin")
+
+	records, _, err := common.ReadCSVRecords(&buf, nil, eventCSVFields)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	timed, err := eventFromCSV(records[0].Fields, "UTC")
+	require.NoError(t, err)
+	assert.Equal(t, "Planning", timed.Title)
+	assert.Equal(t, events[0].When.StartTime, timed.When.StartTime)
+	assert.Equal(t, events[0].When.EndTime, timed.When.EndTime)
+	assert.Equal(t, "Europe/Berlin", timed.When.StartTimezone)
+	assert.True(t, timed.Busy)
+	assert.Len(t, timed.Participants, 2)
+
+	allDay, err := eventFromCSV(records[1].Fields, "UTC")
+	require.NoError(t, err)
+	assert.Equal(t, "datespan", allDay.When.Object)
+	assert.Equal(t, "2024-06-10", allDay.When.StartDate)
+	assert.Equal(t, "2024-06-12", allDay.When.EndDate)
+	assert.False(t, allDay.Busy)
+}
+
+func TestEventFromCSV(t *testing.T) {
+	t.Run("default timezone and one hour end", func(t *testing.T) {
+		req, err := eventFromCSV(map[string]string{"title": "Call", "start": "2024-06-03 15:00"}, "America/New_York")
+		require.NoError(t, err)
+		ny, _ := time.LoadLocation("America/New_York")
+		assert.Equal(t, time.Date(2024, 6, 3, 15, 0, 0, 0, ny).Unix(), req.When.StartTime)
+		assert.Equal(t, req.When.StartTime+3600, req.When.EndTime)
+		assert.True(t, req.Busy, "busy defaults to true like events create")
+	})
+
+	t.Run("spreadsheet booleans", func(t *testing.T) {
+		req, err := eventFromCSV(map[string]string{"title": "Holiday", "start": "2024-12-25", "all_day": "Yes", "busy": "no"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "2024-12-25", req.When.Date)
+		assert.False(t, req.Busy)
+	})
+
+	for name, fields := range map[string]map[string]string{
+		"missing title": {"start": "2024-06-03 15:00"},
+		"missing start": {"title": "x"},
+		"bad boolean":   {"title": "x", "start": "2024-06-03", "busy": "maybe"},
+		"bad start":     {"title": "x", "start": "next tuesday"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := eventFromCSV(fields, "UTC")
+			assert.Error(t, err)
+		})
+	}
+}
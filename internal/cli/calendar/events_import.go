@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nylas/cli/internal/cli/common"
@@ -18,6 +19,11 @@ func newEventsImportCmd() *cobra.Command {
 		startStr   string
 		endStr     string
 		limit      int
+
+		csvFile     string
+		mappingPath string
+		csvTZ       string
+		dryRun      bool
 	)
 
 	cmd := &cobra.Command{
@@ -33,15 +39,50 @@ defaults to now through one month ahead.
 Use --json to capture the full event data for export or syncing. A single call
 returns up to --limit events (max 500); raise --limit to export more.
 
+With --file, import goes the other way: one event is created in the calendar
+per row of a CSV file. Headers matching a field name are used directly (so
+"events list --format csv" output imports as is); map other headers with a
+YAML --mapping file:
+
+  columns:
+    Subject: title
+    "Start Date": start
+    "End Date": end
+    Attendees: participants
+  defaults:
+    timezone: Europe/London
+
+Fields: ` + strings.Join(eventCSVFields, ", ") + `
+
+start/end take 'YYYY-MM-DD HH:MM' (in the row's timezone, else --timezone,
+else the system timezone) or YYYY-MM-DD for all-day events. A missing end
+means one hour. Participants are emails separated by ";" or ",".
+
 API reference: https://developer.nylas.com/docs/v3/calendar/`,
 		Example: `  # Export a year of events from the primary calendar as JSON
   nylas calendar events import --calendar primary \
     --start 2026-01-01 --end 2026-12-31 --json
 
   # Export from a specific calendar
-  nylas calendar events import --calendar <calendar-id> --limit 200`,
+  nylas calendar events import --calendar <calendar-id> --limit 200
+
+  # Create events from a spreadsheet
+  nylas calendar events import --file events.csv --mapping mapping.yaml --dry-run
+  nylas calendar events import --file events.csv --mapping mapping.yaml`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if csvFile != "" {
+				for _, name := range []string{"start", "end", "limit"} {
+					if cmd.Flags().Changed(name) {
+						return common.NewUserError(
+							fmt.Sprintf("--%s cannot be used with --file", name),
+							"--start, --end and --limit select events to export; --file creates events",
+						)
+					}
+				}
+				return runEventsCSVImport(cmd, args, csvFile, mappingPath, calendarID, csvTZ, dryRun)
+			}
+
 			start, err := parseImportTime("start", startStr)
 			if err != nil {
 				return err
@@ -99,6 +140,10 @@ API reference: https://developer.nylas.com/docs/v3/calendar/`,
 	cmd.Flags().StringVar(&startStr, "start", "", "Start of the window (YYYY-MM-DD, 'YYYY-MM-DD HH:MM', or Unix). Defaults to now.")
 	cmd.Flags().StringVar(&endStr, "end", "", "End of the window (YYYY-MM-DD, 'YYYY-MM-DD HH:MM', or Unix). Defaults to +1 month.")
 	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "Maximum number of events to import (max 500)")
+	cmd.Flags().StringVarP(&csvFile, "file", "f", "", "Create events from this CSV file (- for stdin)")
+	cmd.Flags().StringVarP(&mappingPath, "mapping", "m", "", "YAML file mapping CSV headers to event fields (with --file)")
+	cmd.Flags().StringVar(&csvTZ, "timezone", "", "Timezone for rows without one (with --file; defaults to system timezone)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the events --file would create without creating them")
 
 	return cmd
 }
@@ -19,6 +19,8 @@ func newEventsListCmd() *cobra.Command {
 		showAll    bool
 		targetTZ   string
 		showTZ     bool
+		grants     *common.GrantSelection
+		pages      *common.PageSelection
 	)
 
 	cmd := &cobra.Command{
@@ -27,6 +29,11 @@ func newEventsListCmd() *cobra.Command {
 		Short:   "List calendar events",
 		Long: `List events from the specified calendar or primary calendar.
 
+Use --all to fetch every event in the range, up to --max (10000 by default;
+0 for no cap). Use --page-token to fetch one page at a time: an empty token
+starts at the first page, and the token of the next page is printed after
+the list, or returned as next_cursor with --json.
+
 Examples:
   # List events in your local timezone
   nylas calendar events list
@@ -35,9 +42,29 @@ Examples:
   nylas calendar events list --timezone America/Los_Angeles
 
   # List events with timezone abbreviations shown
-  nylas calendar events list --show-tz`,
+  nylas calendar events list --show-tz
+
+  # Export the next 30 days for a spreadsheet (re-import with events import --file)
+  nylas calendar events list --days 30 --limit 500 --format csv > events.csv
+
+  # Work and personal calendars together (--limit applies per account)
+  nylas calendar events list --grants me@work.com,me@home.com
+
+  # Page through the next 90 days as JSON, 50 events at a time
+  nylas calendar events list --days 90 --limit 50 --json --page-token ""`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			args, err := grants.Args(args)
+			if err != nil {
+				return err
+			}
+			if err := pages.Check(grants); err != nil {
+				return err
+			}
+			if grants.Multi() && calendarID != "" {
+				return common.NewUserError("--calendar can't be combined with --grant all or --grants", "Calendar IDs belong to a single account; omit --calendar to use each account's primary calendar")
+			}
+
 			// Auto-detect timezone if not specified.
 			// An explicit --timezone="" disables conversion.
 			if targetTZ == "" && !cmd.Flags().Changed("timezone") {
@@ -51,42 +78,35 @@ Examples:
 				}
 			}
 
-			pag := common.SetupPagination(limit, false, 0)
+			pag := pages.Limits(limit)
 			limit = pag.Limit
-			maxItems := 0
-			if pag.Mode == common.PaginateWithCap {
-				maxItems = pag.MaxItems
+			maxItems := pag.FetchCap()
+
+			if grants.Multi() {
+				return runEventsListAccounts(cmd, grants, eventListParams(limit, days, showAll), maxItems, targetTZ, showTZ)
 			}
 
-			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				// If no calendar specified, try to get the primary calendar
 				calID, err := GetDefaultCalendarID(ctx, client, grantID, calendarID, false)
 				if err != nil {
 					return struct{}{}, err
 				}
 
-				params := &domain.EventQueryParams{
-					Limit:   limit,
-					OrderBy: "start", // Sort by start time ascending
-				}
-
-				// Set time range if days specified
-				if days > 0 {
-					now := time.Now()
-					params.Start = now.Unix()
-					params.End = now.AddDate(0, 0, days).Unix()
-				}
-
-				if showAll {
-					params.ShowCancelled = true
-				}
-
-				events, err := fetchEvents(ctx, client, grantID, calID, params, maxItems)
+				params := eventListParams(limit, days, showAll)
+				events, next, err := listEvents(ctx, client, grantID, calID, &params, maxItems, pages)
 				if err != nil {
 					return struct{}{}, common.WrapListError("events", err)
 				}
+				pages.WarnCapped(len(events))
 
-				// JSON output (including empty array)
+				// Structured output (including empty array)
+				if common.IsCSV(cmd) {
+					return struct{}{}, common.WriteCSV(cmd.OutOrStdout(), events, eventCSVColumns)
+				}
+				if pages.Cursor() && common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.WritePage(cmd, events, next)
+				}
 				if common.IsStructuredOutput(cmd) {
 					out := common.GetOutputWriter(cmd)
 					return struct{}{}, out.Write(events)
@@ -99,94 +119,11 @@ Examples:
 
 				fmt.Printf("Found %d event(s):\n\n", len(events))
 
-				// Resolve the local timezone name at most once for the whole
-				// list: getLocalTimeZone reads env vars and resolves symlinks
-				// on every call, which is wasteful per event. Not memoized at
-				// package level so t.Setenv-based tests keep working.
-				var cachedLocalTZ string
-				localTZ := func() string {
-					if cachedLocalTZ == "" {
-						cachedLocalTZ = getLocalTimeZone()
-					}
-					return cachedLocalTZ
-				}
-
+				localTZ := cachedLocalTimeZone()
 				for _, event := range events {
-					// Title with timezone badge (if showing timezone info)
-					fmt.Printf("%s", common.Cyan.Sprint(event.Title))
-					if showTZ && !event.When.IsAllDay() {
-						// Get event's original timezone
-						start := event.When.StartDateTime()
-						originalTZ := start.Location().String()
-						if originalTZ == "Local" {
-							originalTZ = localTZ()
-						}
-
-						// Add colored timezone badge
-						badge := formatTimezoneBadge(originalTZ, true) // Use abbreviation
-						fmt.Printf(" %s", common.Blue.Sprint(badge))
-					}
-					fmt.Println()
-
-					// Time (with timezone conversion if requested)
-					timeDisplay, err := formatEventTimeWithTZ(&event, targetTZ)
-					if err != nil {
-						fmt.Printf("  %s %s (timezone conversion error: %v)\n",
-							common.Dim.Sprint("When:"),
-							formatEventTime(event.When),
-							err)
-					} else {
-						if timeDisplay.ShowConversion {
-							// Show converted time prominently
-							fmt.Printf("  %s %s", common.Dim.Sprint("When:"), timeDisplay.ConvertedTime)
-							if showTZ {
-								fmt.Printf(" %s", common.BoldBlue.Sprint(timeDisplay.ConvertedTimezone))
-							}
-							fmt.Println()
-							// Show original time as reference
-							fmt.Printf("       %s %s",
-								common.Dim.Sprint("(Original:"),
-								common.Dim.Sprint(timeDisplay.OriginalTime))
-							if showTZ {
-								fmt.Printf(" %s", common.Dim.Sprint(timeDisplay.OriginalTimezone))
-							}
-							fmt.Printf("%s\n", common.Dim.Sprint(")"))
-						} else {
-							// No conversion - show original time
-							fmt.Printf("  %s %s", common.Dim.Sprint("When:"), timeDisplay.OriginalTime)
-							if showTZ && timeDisplay.OriginalTimezone != "" {
-								fmt.Printf(" %s", common.BoldBlue.Sprint(timeDisplay.OriginalTimezone))
-							}
-							fmt.Println()
-						}
-					}
-
-					// Location
-					if event.Location != "" {
-						fmt.Printf("  %s %s\n", common.Dim.Sprint("Location:"), event.Location)
-					}
-
-					// Status
-					statusColor := common.Green
-					switch event.Status {
-					case "cancelled":
-						statusColor = common.Red
-					case "tentative":
-						statusColor = common.Yellow
-					}
-					if event.Status != "" {
-						fmt.Printf("  %s %s\n", common.Dim.Sprint("Status:"), statusColor.Sprint(event.Status))
-					}
-
-					// Participants count
-					if len(event.Participants) > 0 {
-						fmt.Printf("  %s %d participant(s)\n", common.Dim.Sprint("Guests:"), len(event.Participants))
-					}
-
-					// ID
-					fmt.Printf("  %s %s\n", common.Dim.Sprint("ID:"), common.Dim.Sprint(event.ID))
-					fmt.Println()
+					printEventListItem(event, "", targetTZ, showTZ, localTZ)
 				}
+				common.PrintNextPage(cmd, next)
 
 				return struct{}{}, nil
 			})
@@ -200,10 +137,142 @@ Examples:
 	cmd.Flags().BoolVar(&showAll, "show-cancelled", false, "Include cancelled events")
 	cmd.Flags().StringVar(&targetTZ, "timezone", "", "Display times in this timezone (e.g., America/Los_Angeles). Defaults to local timezone.")
 	cmd.Flags().BoolVar(&showTZ, "show-tz", false, "Show timezone abbreviations (e.g., PST, EST)")
+	grants = common.AddGrantSelectionFlags(cmd)
+	pages = common.AddPageSelectionFlags(cmd, "events")
 
 	return cmd
 }
 
+// eventListParams returns the query of `events list`: events in the next
+// days days (0 for no limit), ordered by start time.
+func eventListParams(limit, days int, showCancelled bool) domain.EventQueryParams {
+	params := domain.EventQueryParams{
+		Limit:         limit,
+		OrderBy:       "start",
+		ShowCancelled: showCancelled,
+	}
+	if days > 0 {
+		now := time.Now()
+		params.Start = now.Unix()
+		params.End = now.AddDate(0, 0, days).Unix()
+	}
+	return params
+}
+
+// cachedLocalTimeZone resolves the local timezone name at most once for a
+// whole list: getLocalTimeZone reads env vars and resolves symlinks on every
+// call, which is wasteful per event. Not memoized at package level so
+// t.Setenv-based tests keep working.
+func cachedLocalTimeZone() func() string {
+	var cached string
+	return func() string {
+		if cached == "" {
+			cached = getLocalTimeZone()
+		}
+		return cached
+	}
+}
+
+// printEventListItem prints one event of `events list`. account is shown
+// when the list covers several grants.
+func printEventListItem(event domain.Event, account, targetTZ string, showTZ bool, localTZ func() string) {
+	// Title with timezone badge (if showing timezone info)
+	fmt.Printf("%s", common.Cyan.Sprint(event.Title))
+	if showTZ && !event.When.IsAllDay() {
+		// Get event's original timezone
+		start := event.When.StartDateTime()
+		originalTZ := start.Location().String()
+		if originalTZ == "Local" {
+			originalTZ = localTZ()
+		}
+
+		// Add colored timezone badge
+		badge := formatTimezoneBadge(originalTZ, true) // Use abbreviation
+		fmt.Printf(" %s", common.Blue.Sprint(badge))
+	}
+	fmt.Println()
+
+	// Time (with timezone conversion if requested)
+	timeDisplay, err := formatEventTimeWithTZ(&event, targetTZ)
+	if err != nil {
+		fmt.Printf("  %s %s (timezone conversion error: %v)\n",
+			common.Dim.Sprint("When:"),
+			formatEventTime(event.When),
+			err)
+	} else {
+		if timeDisplay.ShowConversion {
+			// Show converted time prominently
+			fmt.Printf("  %s %s", common.Dim.Sprint("When:"), timeDisplay.ConvertedTime)
+			if showTZ {
+				fmt.Printf(" %s", common.BoldBlue.Sprint(timeDisplay.ConvertedTimezone))
+			}
+			fmt.Println()
+			// Show original time as reference
+			fmt.Printf("       %s %s",
+				common.Dim.Sprint("(Original:"),
+				common.Dim.Sprint(timeDisplay.OriginalTime))
+			if showTZ {
+				fmt.Printf(" %s", common.Dim.Sprint(timeDisplay.OriginalTimezone))
+			}
+			fmt.Printf("%s\n", common.Dim.Sprint(")"))
+		} else {
+			// No conversion - show original time
+			fmt.Printf("  %s %s", common.Dim.Sprint("When:"), timeDisplay.OriginalTime)
+			if showTZ && timeDisplay.OriginalTimezone != "" {
+				fmt.Printf(" %s", common.BoldBlue.Sprint(timeDisplay.OriginalTimezone))
+			}
+			fmt.Println()
+		}
+	}
+
+	// Location
+	if event.Location != "" {
+		fmt.Printf("  %s %s\n", common.Dim.Sprint("Location:"), event.Location)
+	}
+
+	// Status
+	statusColor := common.Green
+	switch event.Status {
+	case "cancelled":
+		statusColor = common.Red
+	case "tentative":
+		statusColor = common.Yellow
+	}
+	if event.Status != "" {
+		fmt.Printf("  %s %s\n", common.Dim.Sprint("Status:"), statusColor.Sprint(event.Status))
+	}
+
+	if account != "" {
+		fmt.Printf("  %s %s\n", common.Dim.Sprint("Account:"), common.Blue.Sprint(account))
+	}
+
+	// Participants count
+	if len(event.Participants) > 0 {
+		fmt.Printf("  %s %d participant(s)\n", common.Dim.Sprint("Guests:"), len(event.Participants))
+	}
+
+	// ID
+	fmt.Printf("  %s %s\n", common.Dim.Sprint("ID:"), common.Dim.Sprint(event.ID))
+	fmt.Println()
+}
+
+// listEvents fetches the events of `events list`: one page from --page-token
+// with the cursor of the next page, or up to maxItems.
+func listEvents(ctx context.Context, client ports.NylasClient, grantID, calendarID string, params *domain.EventQueryParams, maxItems int, pages *common.PageSelection) ([]domain.Event, string, error) {
+	if !pages.Cursor() {
+		events, err := fetchEvents(ctx, client, grantID, calendarID, params, maxItems)
+		return events, "", err
+	}
+
+	params.Limit = common.NormalizePageSize(params.Limit)
+	params.PageToken = pages.Token
+	resp, err := client.GetEventsWithCursor(ctx, grantID, calendarID, params)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Data, resp.Pagination.NextCursor, nil
+}
+
 func fetchEvents(ctx context.Context, client ports.NylasClient, grantID, calendarID string, params *domain.EventQueryParams, maxItems int) ([]domain.Event, error) {
 	if maxItems <= 0 {
 		return client.GetEvents(ctx, grantID, calendarID, params)
@@ -0,0 +1,77 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// accountEvent is an event tagged with the account it came from.
+type accountEvent struct {
+	Account      string `json:"account" yaml:"account"`
+	domain.Event `yaml:",inline"`
+}
+
+// accountEventCSVColumns are eventCSVColumns after an account column.
+func accountEventCSVColumns() []common.CSVColumn[accountEvent] {
+	columns := []common.CSVColumn[accountEvent]{
+		{Name: "account", Value: func(e accountEvent) string { return e.Account }},
+	}
+	for _, col := range eventCSVColumns {
+		columns = append(columns, common.CSVColumn[accountEvent]{
+			Name:  col.Name,
+			Value: func(e accountEvent) string { return col.Value(e.Event) },
+		})
+	}
+	return columns
+}
+
+// runEventsListAccounts lists the primary-calendar events of several grants,
+// merged by start time.
+func runEventsListAccounts(cmd *cobra.Command, sel *common.GrantSelection, params domain.EventQueryParams, maxItems int, targetTZ string, showTZ bool) error {
+	results, err := common.WithGrants(sel, func(ctx context.Context, client ports.NylasClient, grantID string) ([]domain.Event, error) {
+		calID, err := GetDefaultCalendarID(ctx, client, grantID, "", false)
+		if err != nil {
+			return nil, err
+		}
+		p := params
+		return fetchEvents(ctx, client, grantID, calID, &p, maxItems)
+	})
+	if err != nil {
+		return common.WrapListError("events", err)
+	}
+	events := mergeAccountEvents(results)
+
+	if common.IsCSV(cmd) {
+		return common.WriteCSV(cmd.OutOrStdout(), events, accountEventCSVColumns())
+	}
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(events)
+	}
+	if len(events) == 0 {
+		common.PrintEmptyState("events")
+		return nil
+	}
+
+	fmt.Printf("Found %d event(s) across %d accounts:\n\n", len(events), len(results))
+	localTZ := cachedLocalTimeZone()
+	for _, e := range events {
+		printEventListItem(e.Event, e.Account, targetTZ, showTZ, localTZ)
+	}
+	return nil
+}
+
+func mergeAccountEvents(results []common.GrantResult[domain.Event]) []accountEvent {
+	events := common.MergeGrantResults(results, func(account string, e domain.Event) accountEvent {
+		return accountEvent{Account: account, Event: e}
+	})
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].When.StartDateTime().Before(events[j].When.StartDateTime())
+	})
+	return events
+}
@@ -1,12 +1,18 @@
 package calendar
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
 	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,6 +29,29 @@ func (c *testCalendarClient) GetEventsWithCursor(ctx context.Context, grantID, c
 	return c.MockClient.GetEventsWithCursor(ctx, grantID, calendarID, params)
 }
 
+func TestListEvents_Cursor(t *testing.T) {
+	client := &testCalendarClient{
+		MockClient: nylas.NewMockClient(),
+		getEventsWithCursorFunc: func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
+			assert.Empty(t, params.PageToken)
+			assert.Equal(t, 25, params.Limit)
+			return &domain.EventListResponse{
+				Data:       []domain.Event{{ID: "event-1"}},
+				Pagination: domain.Pagination{NextCursor: "page-2"},
+			}, nil
+		},
+	}
+	cmd := &cobra.Command{Use: "list"}
+	pages := common.AddPageSelectionFlags(cmd, "events")
+	require.NoError(t, cmd.ParseFlags([]string{"--page-token="}))
+
+	events, next, err := listEvents(context.Background(), client, "grant-123", "cal-123", &domain.EventQueryParams{Limit: 25}, 0, pages)
+
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "page-2", next)
+}
+
 func TestFetchEvents(t *testing.T) {
 	t.Run("uses direct fetch when maxItems is zero", func(t *testing.T) {
 		client := &testCalendarClient{MockClient: nylas.NewMockClient()}
@@ -82,3 +111,52 @@ func TestFetchEvents(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to fetch page 1")
 	})
 }
+
+func TestMergeAccountEvents(t *testing.T) {
+	at := func(hour int) domain.EventWhen {
+		return domain.EventWhen{StartTime: time.Date(2026, 3, 2, hour, 0, 0, 0, time.UTC).Unix()}
+	}
+	results := []common.GrantResult[domain.Event]{
+		{Grant: domain.GrantInfo{ID: "g1", Email: "me@work.com"}, Items: []domain.Event{{ID: "standup", When: at(9)}, {ID: "review", When: at(15)}}},
+		{Grant: domain.GrantInfo{ID: "g2", Email: "me@home.com"}, Items: []domain.Event{{ID: "dentist", When: at(11)}}},
+	}
+
+	merged := mergeAccountEvents(results)
+
+	require.Len(t, merged, 3)
+	assert.Equal(t, []string{"standup", "dentist", "review"}, []string{merged[0].ID, merged[1].ID, merged[2].ID})
+	assert.Equal(t, "me@home.com", merged[1].Account)
+
+	var buf bytes.Buffer
+	require.NoError(t, common.WriteCSV(&buf, merged, accountEventCSVColumns()))
+	assert.True(t, strings.HasPrefix(buf.String(), "account,id,"), buf.String())
+}
+
+func TestEventsListCmd_CalendarWithManyGrants(t *testing.T) {
+	_, _, err := clitestutil.ExecuteSubCommand(newEventsListCmd(), "--grants", "a,b", "--calendar", "cal-1")
+	assert.ErrorContains(t, err, "--calendar")
+}
+
+func TestCountEvents(t *testing.T) {
+	client := &testCalendarClient{
+		MockClient: nylas.NewMockClient(),
+		getEventsWithCursorFunc: func(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
+			assert.Equal(t, "cal-123", calendarID)
+			assert.Equal(t, "id", params.Select)
+			if params.PageToken == "" {
+				return &domain.EventListResponse{
+					Data:       []domain.Event{{ID: "event-1"}, {ID: "event-2"}},
+					Pagination: domain.Pagination{NextCursor: "next"},
+				}, nil
+			}
+			return &domain.EventListResponse{Data: []domain.Event{{ID: "event-3"}}}, nil
+		},
+	}
+
+	result, err := countEvents(context.Background(), client, "grant-123", "cal-123", &domain.EventQueryParams{Select: "id"}, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Count)
+	assert.False(t, result.Complete)
+	assert.Equal(t, 1, result.Pages)
+}
@@ -2,7 +2,9 @@ package calendar
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,14 +14,32 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// rsvpStatuses are the accepted RSVP responses.
+var rsvpStatuses = []string{"yes", "no", "maybe"}
+
+var rsvpStatusText = map[string]string{
+	"yes":   "accepted",
+	"no":    "declined",
+	"maybe": "tentatively accepted",
+}
+
+type rsvpOptions struct {
+	calendarID      string
+	status          string
+	comment         string
+	query           string
+	days            int
+	limit           int
+	includeAnswered bool
+	dryRun          bool
+	yes             bool
+}
+
 func newEventsRSVPCmd() *cobra.Command {
-	var (
-		calendarID string
-		comment    string
-	)
+	opts := rsvpOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "rsvp <event-id> <status> [grant-id]",
+		Use:   "rsvp [event-id] [grant-id]",
 		Short: "RSVP to an event invitation",
 		Long: `Respond to an event invitation with your RSVP status.
 
@@ -28,39 +48,60 @@ Status options:
   - no     Decline the invitation
   - maybe  Tentatively accept
 
+The event's calendar is found automatically; pass --calendar to skip the
+lookup. The older form 'rsvp <event-id> <status> [grant-id]' still works.
+
+With --query instead of an event ID, every upcoming invitation on the
+calendar that matches is answered at once (see 'nylas calendar events cancel
+--help' for the query syntax). Only invitations you haven't answered yet are
+included unless --include-answered is set. Matches are listed and confirmed
+before anything is sent; use --dry-run to stop there.
+
 Examples:
   # Accept an event invitation
-  nylas calendar events rsvp <event-id> yes
+  nylas calendar events rsvp <event-id> --status yes
 
   # Decline with a comment
-  nylas calendar events rsvp <event-id> no --comment "I have a conflict"
+  nylas calendar events rsvp <event-id> --status no --comment "I have a conflict"
 
   # Tentatively accept
-  nylas calendar events rsvp <event-id> maybe`,
-		Args: cobra.RangeArgs(2, 3),
+  nylas calendar events rsvp <event-id> --status maybe
+
+  # Accept every pending invite from your manager in the next two weeks
+  nylas calendar events rsvp --query "organizer=boss@example.com" --status yes --days 14
+
+  # Preview which invitations would be declined
+  nylas calendar events rsvp --query "title~'all hands'" --status no --dry-run`,
+		Args: cobra.MaximumNArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			eventID := args[0]
-			status := strings.ToLower(args[1])
-			grantArgs := args[2:]
-
-			// Validate status
-			if status != "yes" && status != "no" && status != "maybe" {
-				return common.NewUserError(
-					"invalid RSVP status",
-					"Status must be 'yes', 'no', or 'maybe'",
-				)
+			eventID, grantArgs, err := parseRSVPArgs(args, &opts)
+			if err != nil {
+				return err
 			}
 
-			_, err := common.WithClient(grantArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
-				// Get calendar ID if not specified
-				calID, err := GetDefaultCalendarID(ctx, client, grantID, calendarID, false)
+			if opts.query != "" {
+				filter, err := domain.ParseEventFilter(opts.query)
+				if err != nil {
+					return common.NewUserError(err.Error(), `Example: --query "organizer=boss@example.com"`)
+				}
+				if opts.days <= 0 {
+					return common.NewInputError("--days must be positive")
+				}
+				_, err = common.WithClient(grantArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+					return struct{}{}, runBulkRSVP(ctx, cmd, client, grantID, filter, opts)
+				})
+				return err
+			}
+
+			_, err = common.WithClient(grantArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				calID, err := resolveEventCalendar(ctx, client, grantID, eventID, opts.calendarID)
 				if err != nil {
 					return struct{}{}, err
 				}
 
 				req := &domain.SendRSVPRequest{
-					Status:  status,
-					Comment: comment,
+					Status:  opts.status,
+					Comment: opts.comment,
 				}
 
 				err = common.RunWithSpinner("Sending RSVP...", func() error {
@@ -70,12 +111,12 @@ Examples:
 					return struct{}{}, common.WrapSendError("RSVP", err)
 				}
 
-				statusText := map[string]string{
-					"yes":   "accepted",
-					"no":    "declined",
-					"maybe": "tentatively accepted",
+				// Same shape as --query output, so scripts handle one schema.
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write([]rsvpOutcome{{EventID: eventID, Status: opts.status, Sent: true}})
 				}
-				fmt.Printf("%s RSVP sent! You have %s the invitation.\n", common.Green.Sprint("✓"), statusText[status])
+
+				fmt.Printf("%s RSVP sent! You have %s the invitation.\n", common.Green.Sprint("✓"), rsvpStatusText[opts.status])
 
 				return struct{}{}, nil
 			})
@@ -83,12 +124,98 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVarP(&calendarID, "calendar", "c", "", "Calendar ID (defaults to primary)")
-	cmd.Flags().StringVar(&comment, "comment", "", "Optional comment with your RSVP")
+	cmd.Flags().StringVarP(&opts.calendarID, "calendar", "c", "", "Calendar ID (found from the event when omitted)")
+	cmd.Flags().StringVar(&opts.status, "status", "", "Response: yes, no, or maybe")
+	cmd.Flags().StringVar(&opts.comment, "comment", "", "Optional comment with your RSVP")
+	cmd.Flags().StringVar(&opts.query, "query", "", "Answer every invitation matching this query instead of one event")
+	cmd.Flags().IntVar(&opts.days, "days", 30, "How many days ahead --query searches")
+	cmd.Flags().IntVar(&opts.limit, "limit", 500, "Maximum events --query searches")
+	cmd.Flags().BoolVar(&opts.includeAnswered, "include-answered", false, "With --query, also change invitations you already answered")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "With --query, list matching invitations without answering them")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation prompt")
 
 	return cmd
 }
 
+// parseRSVPArgs sorts the positional arguments into the event ID and grant,
+// accepting the status either from --status or, in the older form, as the
+// second argument. It validates and normalizes opts.status.
+func parseRSVPArgs(args []string, opts *rsvpOptions) (string, []string, error) {
+	var eventID string
+	if opts.query == "" {
+		if len(args) == 0 {
+			return "", nil, common.NewUserError("event ID is required", "Pass an event ID, or --query to answer several invitations")
+		}
+		eventID, args = args[0], args[1:]
+		if opts.status == "" && len(args) > 0 {
+			opts.status, args = args[0], args[1:]
+		}
+	}
+	if len(args) > 1 {
+		return "", nil, common.NewUserError("too many arguments", "Usage: nylas calendar events rsvp [event-id] [grant-id] --status yes|no|maybe")
+	}
+
+	opts.status = strings.ToLower(strings.TrimSpace(opts.status))
+	if opts.status == "" {
+		return "", nil, common.NewUserError("--status is required", "Status must be 'yes', 'no', or 'maybe'")
+	}
+	if !slices.Contains(rsvpStatuses, opts.status) {
+		return "", nil, common.NewUserError(
+			"invalid RSVP status",
+			"Status must be 'yes', 'no', or 'maybe'",
+		)
+	}
+	return eventID, args, nil
+}
+
+// resolveEventCalendar returns calendarID when set. Otherwise it looks the
+// event up in each of the grant's calendars, primary first, and returns the
+// one that has it.
+func resolveEventCalendar(ctx context.Context, client ports.NylasClient, grantID, eventID, calendarID string) (string, error) {
+	if calendarID != "" {
+		return calendarID, nil
+	}
+
+	calendars, err := client.GetCalendars(ctx, grantID)
+	if err != nil {
+		return "", common.WrapListError("calendars", err)
+	}
+	slices.SortStableFunc(calendars, func(a, b domain.Calendar) int {
+		switch {
+		case a.IsPrimary == b.IsPrimary:
+			return 0
+		case a.IsPrimary:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	// A calendar that fails for another reason (e.g. no access) is skipped,
+	// but reported if the event isn't found anywhere else.
+	var lookupErr error
+	for _, cal := range calendars {
+		event, err := client.GetEvent(ctx, grantID, cal.ID, eventID)
+		if err != nil {
+			if lookupErr == nil && !errors.Is(err, domain.ErrEventNotFound) {
+				lookupErr = err
+			}
+			continue
+		}
+		if event.CalendarID != "" {
+			return event.CalendarID, nil
+		}
+		return cal.ID, nil
+	}
+	if lookupErr != nil {
+		return "", common.WrapGetError("event", lookupErr)
+	}
+	return "", common.NewUserError(
+		fmt.Sprintf("event %s not found in any calendar", eventID),
+		"Check the event ID, or pass --calendar",
+	)
+}
+
 // Helper functions
 
 func formatEventTime(when domain.EventWhen) string {
@@ -0,0 +1,184 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// rsvpOutcome is the result for one matched invitation.
+type rsvpOutcome struct {
+	EventID  string `json:"event_id"`
+	Title    string `json:"title"`
+	When     string `json:"when"`
+	Previous string `json:"previous_status"`
+	Status   string `json:"status"`
+	Sent     bool   `json:"sent"`
+	Error    string `json:"error,omitempty"`
+}
+
+// rsvpMatch is an invitation selected for a bulk RSVP with the grant's
+// current response.
+type rsvpMatch struct {
+	event    domain.Event
+	previous string
+}
+
+func runBulkRSVP(ctx context.Context, cmd *cobra.Command, client ports.NylasClient, grantID string, filter *domain.EventFilter, opts rsvpOptions) error {
+	calID, err := GetDefaultCalendarID(ctx, client, grantID, opts.calendarID, false)
+	if err != nil {
+		return err
+	}
+	self := grantEmail(ctx, client, grantID)
+	if self == "" {
+		return common.NewUserError("could not determine the grant's email address", "Your email is needed to find your invitations; check the grant with: nylas auth status")
+	}
+
+	now := time.Now()
+	events, err := fetchEvents(ctx, client, grantID, calID, &domain.EventQueryParams{
+		Limit:   opts.limit,
+		OrderBy: "start",
+		Start:   now.Unix(),
+		End:     now.AddDate(0, 0, opts.days).Unix(),
+	}, opts.limit)
+	if err != nil {
+		return common.WrapListError("events", err)
+	}
+	matches := selectRSVPMatches(events, filter, self, opts)
+
+	structured := common.IsStructuredOutput(cmd)
+	if len(matches) == 0 {
+		if structured {
+			return common.GetOutputWriter(cmd).Write([]rsvpOutcome{})
+		}
+		hint := fmt.Sprintf("Nothing in the next %d days matches; widen the search with --days", opts.days)
+		if !opts.includeAnswered {
+			hint += " or add --include-answered"
+		}
+		common.PrintEmptyStateWithHint("matching invitations", hint)
+		return nil
+	}
+
+	if !structured {
+		printRSVPPlan(matches, opts)
+	}
+	if opts.dryRun {
+		if structured {
+			return common.GetOutputWriter(cmd).Write(rsvpPlanOutcomes(matches, opts.status))
+		}
+		fmt.Println("Dry run: no responses were sent.")
+		return nil
+	}
+	if !opts.yes && !common.Confirm(fmt.Sprintf("Reply %q to %d invitation(s)?", opts.status, len(matches)), false) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	req := &domain.SendRSVPRequest{Status: opts.status, Comment: opts.comment}
+	outcomes := rsvpPlanOutcomes(matches, opts.status)
+	progress := common.NewProgressBar("Sending RSVPs", len(matches))
+	for i, m := range matches {
+		if err := client.SendRSVP(ctx, grantID, calID, m.event.ID, req); err != nil {
+			outcomes[i].Error = err.Error()
+		} else {
+			outcomes[i].Sent = true
+		}
+		progress.Increment()
+	}
+	progress.Finish()
+
+	if structured {
+		if err := common.GetOutputWriter(cmd).Write(outcomes); err != nil {
+			return err
+		}
+	}
+	return summarizeRSVP(outcomes, structured)
+}
+
+// selectRSVPMatches keeps the upcoming events matching filter that invite
+// self, excluding events self organizes and those already answered with
+// the requested status. Answered invitations are only kept with
+// --include-answered.
+func selectRSVPMatches(events []domain.Event, filter *domain.EventFilter, self string, opts rsvpOptions) []rsvpMatch {
+	var matches []rsvpMatch
+	for i := range events {
+		e := &events[i]
+		if e.Status == "cancelled" || !filter.Match(e) {
+			continue
+		}
+		if e.Organizer != nil && strings.EqualFold(e.Organizer.Email, self) {
+			continue
+		}
+		previous, invited := participantStatus(e, self)
+		if !invited || previous == opts.status {
+			continue
+		}
+		if !opts.includeAnswered && previous != "" && previous != "noreply" {
+			continue
+		}
+		matches = append(matches, rsvpMatch{event: *e, previous: previous})
+	}
+	return matches
+}
+
+// participantStatus returns the RSVP status of the participant with the
+// given email, and whether they are a participant at all.
+func participantStatus(e *domain.Event, email string) (string, bool) {
+	for _, p := range e.Participants {
+		if strings.EqualFold(strings.TrimSpace(p.Email), email) {
+			return p.Status, true
+		}
+	}
+	return "", false
+}
+
+func printRSVPPlan(matches []rsvpMatch, opts rsvpOptions) {
+	fmt.Printf("%d invitation(s) match %s:\n\n", len(matches), common.Cyan.Sprint(opts.query))
+	table := common.NewTable("TITLE", "WHEN", "CURRENT", "ID")
+	for _, m := range matches {
+		current := formatParticipantStatus(m.previous)
+		if current == "" {
+			current = common.Dim.Sprint("pending")
+		}
+		table.AddRow(common.Truncate(m.event.Title, 40), formatEventTime(m.event.When), current, m.event.ID)
+	}
+	table.Render()
+	fmt.Println()
+}
+
+func rsvpPlanOutcomes(matches []rsvpMatch, status string) []rsvpOutcome {
+	out := make([]rsvpOutcome, len(matches))
+	for i, m := range matches {
+		out[i] = rsvpOutcome{EventID: m.event.ID, Title: m.event.Title, When: formatEventTime(m.event.When), Previous: m.previous, Status: status}
+	}
+	return out
+}
+
+func summarizeRSVP(outcomes []rsvpOutcome, structured bool) error {
+	sent, failed := 0, 0
+	for _, o := range outcomes {
+		if o.Sent {
+			sent++
+		}
+		if o.Error != "" {
+			failed++
+			if !structured {
+				common.PrintWarningStderr("%s (%s): %s", o.Title, o.EventID, o.Error)
+			}
+		}
+	}
+	if !structured && len(outcomes) > 0 {
+		common.PrintSuccess("Sent %d of %d RSVP(s) (%s)", sent, len(outcomes), rsvpStatusText[outcomes[0].Status])
+	}
+	if failed > 0 {
+		return common.NewUserError(fmt.Sprintf("%d RSVP(s) failed", failed), "Re-run with the same --query to retry the rest")
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestParseRSVPArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		opts       rsvpOptions
+		wantEvent  string
+		wantGrant  []string
+		wantStatus string
+		wantErr    bool
+	}{
+		{name: "status flag", args: []string{"evt-1"}, opts: rsvpOptions{status: "YES"}, wantEvent: "evt-1", wantStatus: "yes"},
+		{name: "status flag with grant", args: []string{"evt-1", "grant-1"}, opts: rsvpOptions{status: "no"}, wantEvent: "evt-1", wantGrant: []string{"grant-1"}, wantStatus: "no"},
+		{name: "positional status", args: []string{"evt-1", "maybe", "grant-1"}, wantEvent: "evt-1", wantGrant: []string{"grant-1"}, wantStatus: "maybe"},
+		{name: "query with grant", args: []string{"grant-1"}, opts: rsvpOptions{status: "yes", query: "title~x"}, wantGrant: []string{"grant-1"}, wantStatus: "yes"},
+		{name: "missing event", opts: rsvpOptions{status: "yes"}, wantErr: true},
+		{name: "missing status", args: []string{"evt-1"}, wantErr: true},
+		{name: "invalid status", args: []string{"evt-1"}, opts: rsvpOptions{status: "sure"}, wantErr: true},
+		{name: "too many arguments", args: []string{"evt-1", "grant-1", "extra"}, opts: rsvpOptions{status: "yes"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			eventID, grantArgs, err := parseRSVPArgs(tt.args, &opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEvent, eventID)
+			assert.ElementsMatch(t, tt.wantGrant, grantArgs)
+			assert.Equal(t, tt.wantStatus, opts.status)
+		})
+	}
+}
+
+func TestResolveEventCalendar(t *testing.T) {
+	newClient := func(holder string, otherErr error) *nylas.MockClient {
+		client := nylas.NewMockClient()
+		client.GetCalendarsFunc = func(context.Context, string) ([]domain.Calendar, error) {
+			return []domain.Calendar{{ID: "cal-shared"}, {ID: "cal-team"}, {ID: "cal-primary", IsPrimary: true}}, nil
+		}
+		client.GetEventFunc = func(_ context.Context, _, calendarID, eventID string) (*domain.Event, error) {
+			switch {
+			case calendarID == holder:
+				return &domain.Event{ID: eventID}, nil
+			case calendarID == "cal-shared" && otherErr != nil:
+				return nil, otherErr
+			}
+			return nil, domain.ErrEventNotFound
+		}
+		return client
+	}
+
+	t.Run("explicit calendar skips the lookup", func(t *testing.T) {
+		calID, err := resolveEventCalendar(context.Background(), nylas.NewMockClient(), "grant-1", "evt-1", "cal-given")
+		require.NoError(t, err)
+		assert.Equal(t, "cal-given", calID)
+	})
+
+	t.Run("found in a secondary calendar", func(t *testing.T) {
+		calID, err := resolveEventCalendar(context.Background(), newClient("cal-team", nil), "grant-1", "evt-1", "")
+		require.NoError(t, err)
+		assert.Equal(t, "cal-team", calID)
+	})
+
+	t.Run("not found anywhere", func(t *testing.T) {
+		_, err := resolveEventCalendar(context.Background(), newClient("", nil), "grant-1", "evt-1", "")
+		assert.ErrorContains(t, err, "not found in any calendar")
+	})
+
+	t.Run("other lookup errors are reported", func(t *testing.T) {
+		_, err := resolveEventCalendar(context.Background(), newClient("", errors.New("forbidden")), "grant-1", "evt-1", "")
+		assert.ErrorContains(t, err, "forbidden")
+	})
+}
+
+func rsvpTestEvents() []domain.Event {
+	invite := func(id, title, status string) domain.Event {
+		return domain.Event{
+			ID:        id,
+			Title:     title,
+			Organizer: &domain.Participant{Person: domain.Person{Email: "boss@example.com"}},
+			Participants: []domain.Participant{
+				{Person: domain.Person{Email: "boss@example.com"}, Status: "yes"},
+				{Person: domain.Person{Email: "Me@Example.com"}, Status: status},
+			},
+		}
+	}
+	mine := invite("evt-mine", "Planning sync", "yes")
+	mine.Organizer = &domain.Participant{Person: domain.Person{Email: "me@example.com"}}
+	cancelled := invite("evt-cancelled", "Planning sync", "noreply")
+	cancelled.Status = "cancelled"
+
+	return []domain.Event{
+		invite("evt-pending", "Planning sync", "noreply"),
+		invite("evt-answered", "Planning review", "no"),
+		invite("evt-same", "Planning retro", "yes"),
+		invite("evt-other", "Lunch", "noreply"),
+		{ID: "evt-uninvited", Title: "Planning offsite"},
+		mine,
+		cancelled,
+	}
+}
+
+func TestSelectRSVPMatches(t *testing.T) {
+	filter, err := domain.ParseEventFilter("title~planning")
+	require.NoError(t, err)
+
+	ids := func(matches []rsvpMatch) []string {
+		var out []string
+		for _, m := range matches {
+			out = append(out, m.event.ID)
+		}
+		return out
+	}
+
+	pending := selectRSVPMatches(rsvpTestEvents(), filter, "me@example.com", rsvpOptions{status: "yes"})
+	assert.Equal(t, []string{"evt-pending"}, ids(pending))
+	assert.Equal(t, "noreply", pending[0].previous)
+
+	all := selectRSVPMatches(rsvpTestEvents(), filter, "me@example.com", rsvpOptions{status: "yes", includeAnswered: true})
+	assert.Equal(t, []string{"evt-pending", "evt-answered"}, ids(all))
+}
+
+func TestRunBulkRSVP(t *testing.T) {
+	filter, err := domain.ParseEventFilter("title~planning")
+	require.NoError(t, err)
+
+	newClient := func(sent *[]string) *testCalendarClient {
+		client := &testCalendarClient{
+			MockClient: nylas.NewMockClient(),
+			getEventsWithCursorFunc: func(context.Context, string, string, *domain.EventQueryParams) (*domain.EventListResponse, error) {
+				return &domain.EventListResponse{Data: rsvpTestEvents()}, nil
+			},
+		}
+		client.GetGrantFunc = func(context.Context, string) (*domain.Grant, error) {
+			return &domain.Grant{ID: "grant-1", Email: "me@example.com"}, nil
+		}
+		client.SendRSVPFunc = func(_ context.Context, _, calendarID, eventID string, req *domain.SendRSVPRequest) error {
+			assert.Equal(t, "cal-1", calendarID)
+			assert.Equal(t, "Running late", req.Comment)
+			*sent = append(*sent, eventID+":"+req.Status)
+			return nil
+		}
+		return client
+	}
+	opts := rsvpOptions{calendarID: "cal-1", status: "yes", comment: "Running late", query: "title~planning", days: 30, limit: 100}
+
+	t.Run("dry run sends nothing", func(t *testing.T) {
+		var sent []string
+		dry := opts
+		dry.dryRun = true
+		require.NoError(t, runBulkRSVP(context.Background(), &cobra.Command{}, newClient(&sent), "grant-1", filter, dry))
+		assert.Empty(t, sent)
+	})
+
+	t.Run("answers pending invitations", func(t *testing.T) {
+		var sent []string
+		run := opts
+		run.yes = true
+		require.NoError(t, runBulkRSVP(context.Background(), &cobra.Command{}, newClient(&sent), "grant-1", filter, run))
+		assert.Equal(t, []string{"evt-pending:yes"}, sent)
+	})
+
+	t.Run("failures are reported", func(t *testing.T) {
+		var sent []string
+		client := newClient(&sent)
+		client.SendRSVPFunc = func(context.Context, string, string, string, *domain.SendRSVPRequest) error {
+			return errors.New("boom")
+		}
+		run := opts
+		run.yes = true
+		err := runBulkRSVP(context.Background(), &cobra.Command{}, client, "grant-1", filter, run)
+		assert.ErrorContains(t, err, "1 RSVP(s) failed")
+	})
+}
@@ -60,8 +60,8 @@ Examples:
 					return struct{}{}, common.WrapGetError("event", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(event)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(event)
 				}
 
 				// Title
@@ -0,0 +1,361 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// recurringEditScope selects which part of a recurring series an update touches.
+type recurringEditScope int
+
+const (
+	editSingleEvent recurringEditScope = iota
+	editOccurrence
+	editThisAndFollowing
+	editAllOccurrences
+)
+
+// resolveRecurringEditScope validates the --occurrence, --this-and-following
+// and --all flags of `events update`.
+func resolveRecurringEditScope(occurrence string, following, all bool) (recurringEditScope, error) {
+	if occurrence != "" {
+		if _, err := time.Parse(time.DateOnly, occurrence); err != nil {
+			return editSingleEvent, common.NewUserError(
+				fmt.Sprintf("invalid --occurrence date: %s", occurrence),
+				"Use the occurrence's date as YYYY-MM-DD",
+			)
+		}
+	}
+
+	switch {
+	case all && (occurrence != "" || following):
+		return editSingleEvent, common.NewUserError(
+			"--all cannot be combined with --occurrence or --this-and-following",
+			"Use --all to edit the whole series, or --occurrence <date> for part of it",
+		)
+	case following && occurrence == "":
+		return editSingleEvent, common.NewUserError(
+			"--this-and-following requires --occurrence",
+			"Pass the first occurrence to change, e.g. --occurrence 2024-03-04 --this-and-following",
+		)
+	case all:
+		return editAllOccurrences, nil
+	case following:
+		return editThisAndFollowing, nil
+	case occurrence != "":
+		return editOccurrence, nil
+	default:
+		return editSingleEvent, nil
+	}
+}
+
+// updateRecurringEvent applies req to one occurrence, this and following
+// occurrences, or the whole series of the recurring event eventID, which may
+// be the master event or any of its instances. The returned note describes
+// what happened to the series, if anything beyond a plain update.
+func updateRecurringEvent(ctx context.Context, client ports.NylasClient, grantID, calendarID, eventID string,
+	scope recurringEditScope, occurrence string, req *domain.UpdateEventRequest) (*domain.Event, string, error) {
+	master, err := client.GetEvent(ctx, grantID, calendarID, eventID)
+	if err != nil {
+		return nil, "", common.WrapFetchError("event", err)
+	}
+	if master.MasterEventID != "" {
+		master, err = client.GetEvent(ctx, grantID, calendarID, master.MasterEventID)
+		if err != nil {
+			return nil, "", common.WrapFetchError("master event", err)
+		}
+	}
+	if len(master.Recurrence) == 0 {
+		return nil, "", common.NewUserError(
+			fmt.Sprintf("event %s is not recurring", master.ID),
+			"Omit --occurrence, --this-and-following and --all to update a single event",
+		)
+	}
+
+	if scope == editAllOccurrences {
+		event, err := updateSeriesMaster(ctx, client, grantID, calendarID, master, req)
+		return event, "", err
+	}
+
+	instance, err := findOccurrence(ctx, client, grantID, calendarID, master, occurrence)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if scope == editOccurrence {
+		event, err := client.UpdateRecurringEventInstance(ctx, grantID, calendarID, instance.ID, req)
+		if err != nil {
+			return nil, "", common.WrapUpdateError("recurring event instance", err)
+		}
+		return event, "", nil
+	}
+
+	// Editing from the first occurrence on is the whole series.
+	occStart := instance.When.StartDateTime()
+	if !occStart.After(master.When.StartDateTime()) {
+		event, err := updateSeriesMaster(ctx, client, grantID, calendarID, master, req)
+		return event, "", err
+	}
+	return splitRecurringSeries(ctx, client, grantID, calendarID, master, instance, req)
+}
+
+// updateSeriesMaster updates the master event, moving a single-day BYDAY
+// along with the series when the new start falls on another weekday.
+func updateSeriesMaster(ctx context.Context, client ports.NylasClient, grantID, calendarID string,
+	master *domain.Event, req *domain.UpdateEventRequest) (*domain.Event, error) {
+	if req.When != nil {
+		if rules := shiftRecurrenceWeekday(master.Recurrence, master.When.StartDateTime(), req.When.StartDateTime()); rules != nil {
+			req.Recurrence = rules
+		}
+	}
+	event, err := client.UpdateEvent(ctx, grantID, calendarID, master.ID, req)
+	if err != nil {
+		return nil, common.WrapUpdateError("event", err)
+	}
+	return event, nil
+}
+
+// findOccurrence returns the instance of master that starts on date
+// (YYYY-MM-DD) in the series' timezone.
+func findOccurrence(ctx context.Context, client ports.NylasClient, grantID, calendarID string,
+	master *domain.Event, date string) (*domain.Event, error) {
+	loc := time.Local
+	if tz := master.When.StartTimezone; tz != "" {
+		if l, err := time.LoadLocation(tz); err == nil {
+			loc = l
+		}
+	}
+	day, _ := time.ParseInLocation(time.DateOnly, date, loc)
+
+	// Pad the window by a day on each side; all-day instances and provider
+	// timezones can put an occurrence just outside the local day.
+	instances, err := client.GetRecurringEventInstances(ctx, grantID, calendarID, master.ID, &domain.EventQueryParams{
+		Limit:           50,
+		ExpandRecurring: true,
+		Start:           day.AddDate(0, 0, -1).Unix(),
+		End:             day.AddDate(0, 0, 2).Unix(),
+	})
+	if err != nil {
+		return nil, common.WrapFetchError("recurring event instances", err)
+	}
+	for i := range instances {
+		start := instances[i].When.StartDateTime()
+		if !instances[i].When.IsAllDay() {
+			start = start.In(loc)
+		}
+		if start.Format(time.DateOnly) == date {
+			return &instances[i], nil
+		}
+	}
+	return nil, common.NewUserError(
+		fmt.Sprintf("no occurrence of %s on %s", master.ID, date),
+		fmt.Sprintf("List occurrences with: nylas calendar recurring list %s --calendar %s", master.ID, calendarID),
+	)
+}
+
+// splitRecurringSeries ends the original series before instance and starts a
+// new series at instance with req applied. The new series is created first so
+// a failure never drops occurrences; it is removed again if the original
+// series cannot be shortened.
+func splitRecurringSeries(ctx context.Context, client ports.NylasClient, grantID, calendarID string,
+	master, instance *domain.Event, req *domain.UpdateEventRequest) (*domain.Event, string, error) {
+	occStart := instance.When.StartDateTime()
+
+	consumed := 0
+	if _, ok := recurrenceCount(master.Recurrence); ok {
+		earlier, err := client.GetRecurringEventInstances(ctx, grantID, calendarID, master.ID, &domain.EventQueryParams{
+			Limit:           1000,
+			ExpandRecurring: true,
+			Start:           master.When.StartDateTime().Unix(),
+			End:             occStart.Unix(),
+		})
+		if err != nil {
+			return nil, "", common.WrapFetchError("recurring event instances", err)
+		}
+		for _, e := range earlier {
+			if e.When.StartDateTime().Before(occStart) {
+				consumed++
+			}
+		}
+	}
+
+	create := seriesCreateRequest(master, instance, req, calendarID)
+	create.Recurrence = continueRecurrence(master.Recurrence, consumed)
+	if req.When != nil {
+		if rules := shiftRecurrenceWeekday(create.Recurrence, occStart, req.When.StartDateTime()); rules != nil {
+			create.Recurrence = rules
+		}
+	}
+
+	created, err := client.CreateEvent(ctx, grantID, calendarID, create)
+	if err != nil {
+		return nil, "", common.WrapCreateError("event series", err)
+	}
+
+	truncate := &domain.UpdateEventRequest{Recurrence: endRecurrence(master.Recurrence, instance.When)}
+	if _, err := client.UpdateEvent(ctx, grantID, calendarID, master.ID, truncate); err != nil {
+		if delErr := client.DeleteEvent(ctx, grantID, calendarID, created.ID); delErr != nil {
+			return nil, "", common.NewUserError(
+				fmt.Sprintf("failed to end the original series: %v", err),
+				fmt.Sprintf("Delete the duplicate series with: nylas calendar events delete %s", created.ID),
+			)
+		}
+		return nil, "", common.WrapUpdateError("event", err)
+	}
+
+	note := fmt.Sprintf("Series split: occurrences before %s keep the original event (%s)",
+		occStart.Format(time.DateOnly), master.ID)
+	return created, note, nil
+}
+
+// seriesCreateRequest copies master into a create request, taking the time
+// from instance and overriding anything set in req.
+func seriesCreateRequest(master, instance *domain.Event, req *domain.UpdateEventRequest, calendarID string) *domain.CreateEventRequest {
+	create := &domain.CreateEventRequest{
+		Title:        master.Title,
+		Description:  master.Description,
+		Location:     master.Location,
+		When:         instance.When,
+		Participants: master.Participants,
+		Busy:         master.Busy,
+		Visibility:   master.Visibility,
+		Conferencing: master.Conferencing,
+		Reminders:    master.Reminders,
+		CalendarID:   calendarID,
+		Metadata:     master.Metadata,
+	}
+	if req.Title != nil {
+		create.Title = *req.Title
+	}
+	if req.Description != nil {
+		create.Description = *req.Description
+	}
+	if req.Location != nil {
+		create.Location = *req.Location
+	}
+	if req.When != nil {
+		create.When = *req.When
+	}
+	if len(req.Participants) > 0 {
+		create.Participants = req.Participants
+	}
+	if req.Busy != nil {
+		create.Busy = *req.Busy
+	}
+	if req.Visibility != nil {
+		create.Visibility = *req.Visibility
+	}
+	if req.Metadata != nil {
+		create.Metadata = req.Metadata
+	}
+	return create
+}
+
+// rewriteRRule applies edit to the parts of every RRULE line, leaving
+// EXDATE/RDATE lines untouched.
+func rewriteRRule(rules []string, edit func(parts []string) []string) []string {
+	out := make([]string, 0, len(rules))
+	for _, line := range rules {
+		body, ok := strings.CutPrefix(line, "RRULE:")
+		if !ok {
+			out = append(out, line)
+			continue
+		}
+		out = append(out, "RRULE:"+strings.Join(edit(strings.Split(body, ";")), ";"))
+	}
+	return out
+}
+
+// withoutRRulePart drops the NAME=value part from an RRULE.
+func withoutRRulePart(parts []string, name string) []string {
+	out := parts[:0:0]
+	for _, p := range parts {
+		if !strings.HasPrefix(p, name+"=") {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// recurrenceCount returns the COUNT of the series' RRULE, if it has one.
+func recurrenceCount(rules []string) (int, bool) {
+	for _, line := range rules {
+		body, ok := strings.CutPrefix(line, "RRULE:")
+		if !ok {
+			continue
+		}
+		for _, p := range strings.Split(body, ";") {
+			if v, ok := strings.CutPrefix(p, "COUNT="); ok {
+				n, err := strconv.Atoi(v)
+				return n, err == nil
+			}
+		}
+	}
+	return 0, false
+}
+
+// endRecurrence ends the series just before the occurrence at when: UNTIL
+// replaces any COUNT or UNTIL. All-day series use a date UNTIL.
+func endRecurrence(rules []string, when domain.EventWhen) []string {
+	start := when.StartDateTime()
+	until := start.Add(-time.Second).UTC().Format("20060102T150405Z")
+	if when.IsAllDay() {
+		until = start.AddDate(0, 0, -1).Format("20060102")
+	}
+	return rewriteRRule(rules, func(parts []string) []string {
+		parts = withoutRRulePart(withoutRRulePart(parts, "COUNT"), "UNTIL")
+		return append(parts, "UNTIL="+until)
+	})
+}
+
+// continueRecurrence returns the rules for the remainder of a series after
+// consumed occurrences: COUNT is reduced, everything else is kept.
+func continueRecurrence(rules []string, consumed int) []string {
+	return rewriteRRule(rules, func(parts []string) []string {
+		for i, p := range parts {
+			v, ok := strings.CutPrefix(p, "COUNT=")
+			if !ok {
+				continue
+			}
+			if n, err := strconv.Atoi(v); err == nil {
+				parts[i] = "COUNT=" + strconv.Itoa(max(n-consumed, 1))
+			}
+		}
+		return parts
+	})
+}
+
+// shiftRecurrenceWeekday moves a single-day BYDAY from the weekday of from to
+// the weekday of to. It returns nil when nothing needs rewriting, including
+// multi-day BYDAY lists, which cannot be shifted unambiguously.
+func shiftRecurrenceWeekday(rules []string, from, to time.Time) []string {
+	if from.IsZero() || to.IsZero() || from.Weekday() == to.Weekday() {
+		return nil
+	}
+	oldDay, newDay := rruleWeekday(from.Weekday()), rruleWeekday(to.Weekday())
+	changed := false
+	out := rewriteRRule(rules, func(parts []string) []string {
+		for i, p := range parts {
+			if p == "BYDAY="+oldDay {
+				parts[i] = "BYDAY=" + newDay
+				changed = true
+			}
+		}
+		return parts
+	})
+	if !changed {
+		return nil
+	}
+	return out
+}
+
+func rruleWeekday(d time.Weekday) string {
+	return strings.ToUpper(d.String()[:2])
+}
@@ -0,0 +1,245 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestResolveRecurringEditScope(t *testing.T) {
+	tests := []struct {
+		name       string
+		occurrence string
+		following  bool
+		all        bool
+		want       recurringEditScope
+		wantErr    bool
+	}{
+		{name: "single event", want: editSingleEvent},
+		{name: "occurrence", occurrence: "2024-03-04", want: editOccurrence},
+		{name: "this and following", occurrence: "2024-03-04", following: true, want: editThisAndFollowing},
+		{name: "all", all: true, want: editAllOccurrences},
+		{name: "following without occurrence", following: true, wantErr: true},
+		{name: "all with occurrence", occurrence: "2024-03-04", all: true, wantErr: true},
+		{name: "bad date", occurrence: "March 4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveRecurringEditScope(tt.occurrence, tt.following, tt.all)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecurrenceRewriting(t *testing.T) {
+	rules := []string{"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=10", "EXDATE:20240311T090000Z"}
+
+	t.Run("end before timed occurrence", func(t *testing.T) {
+		when := domain.EventWhen{StartTime: time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC).Unix()}
+		assert.Equal(t, []string{
+			"RRULE:FREQ=WEEKLY;BYDAY=MO;UNTIL=20240318T085959Z",
+			"EXDATE:20240311T090000Z",
+		}, endRecurrence(rules, when))
+	})
+
+	t.Run("end before all-day occurrence", func(t *testing.T) {
+		when := domain.EventWhen{Date: "2024-03-18", Object: "date"}
+		got := endRecurrence([]string{"RRULE:FREQ=DAILY;UNTIL=20241231"}, when)
+		assert.Equal(t, []string{"RRULE:FREQ=DAILY;UNTIL=20240317"}, got)
+	})
+
+	t.Run("continue reduces count", func(t *testing.T) {
+		assert.Equal(t, "RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=7", continueRecurrence(rules, 3)[0])
+		assert.Equal(t, "RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=1", continueRecurrence(rules, 20)[0])
+		assert.Equal(t, rules, continueRecurrence(rules, 0))
+	})
+
+	t.Run("shift weekday", func(t *testing.T) {
+		monday := time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)
+		got := shiftRecurrenceWeekday(rules, monday, monday.AddDate(0, 0, 2))
+		assert.Equal(t, "RRULE:FREQ=WEEKLY;BYDAY=WE;COUNT=10", got[0])
+
+		assert.Nil(t, shiftRecurrenceWeekday(rules, monday, monday.Add(time.Hour)), "same weekday")
+		assert.Nil(t, shiftRecurrenceWeekday([]string{"RRULE:FREQ=WEEKLY;BYDAY=MO,WE"}, monday, monday.AddDate(0, 0, 1)), "multi-day BYDAY")
+	})
+
+	count, ok := recurrenceCount(rules)
+	assert.True(t, ok)
+	assert.Equal(t, 10, count)
+}
+
+// recurringTestClient serves a weekly series of five Monday 09:00 UTC
+// occurrences starting 2024-03-04.
+type recurringTestClient struct {
+	*nylas.MockClient
+	master          domain.Event
+	instanceUpdates []string
+	updates         map[string]*domain.UpdateEventRequest
+	created         []*domain.CreateEventRequest
+	deleted         []string
+	failUpdate      bool
+}
+
+func newRecurringTestClient() *recurringTestClient {
+	c := &recurringTestClient{
+		MockClient: nylas.NewMockClient(),
+		master: domain.Event{
+			ID:         "master-1",
+			Title:      "Weekly sync",
+			Location:   "Room 1",
+			Busy:       true,
+			When:       domain.EventWhen{StartTime: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC).Unix(), EndTime: time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC).Unix(), StartTimezone: "UTC"},
+			Recurrence: []string{"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=5"},
+		},
+		updates: map[string]*domain.UpdateEventRequest{},
+	}
+	c.GetEventFunc = func(_ context.Context, _, _, eventID string) (*domain.Event, error) {
+		if eventID == c.master.ID {
+			master := c.master
+			return &master, nil
+		}
+		for _, inst := range c.instances() {
+			if inst.ID == eventID {
+				return &inst, nil
+			}
+		}
+		return nil, domain.ErrEventNotFound
+	}
+	c.CreateEventFunc = func(_ context.Context, _, _ string, req *domain.CreateEventRequest) (*domain.Event, error) {
+		c.created = append(c.created, req)
+		return &domain.Event{ID: "new-series", Title: req.Title, When: req.When, Recurrence: req.Recurrence}, nil
+	}
+	c.UpdateEventFunc = func(_ context.Context, _, _, eventID string, req *domain.UpdateEventRequest) (*domain.Event, error) {
+		if c.failUpdate {
+			return nil, errors.New("boom")
+		}
+		c.updates[eventID] = req
+		return &domain.Event{ID: eventID, Title: c.master.Title}, nil
+	}
+	return c
+}
+
+func (c *recurringTestClient) instances() []domain.Event {
+	var out []domain.Event
+	start := c.master.When.StartDateTime()
+	for i := range 5 {
+		s := start.AddDate(0, 0, 7*i)
+		out = append(out, domain.Event{
+			ID:            "inst-" + s.Format("0102"),
+			MasterEventID: c.master.ID,
+			When:          domain.EventWhen{StartTime: s.Unix(), EndTime: s.Add(time.Hour).Unix(), StartTimezone: "UTC"},
+		})
+	}
+	return out
+}
+
+func (c *recurringTestClient) GetRecurringEventInstances(_ context.Context, _, _, _ string, params *domain.EventQueryParams) ([]domain.Event, error) {
+	var out []domain.Event
+	for _, inst := range c.instances() {
+		if inst.When.StartTime >= params.Start && inst.When.StartTime < params.End {
+			out = append(out, inst)
+		}
+	}
+	return out, nil
+}
+
+func (c *recurringTestClient) UpdateRecurringEventInstance(_ context.Context, _, _, eventID string, _ *domain.UpdateEventRequest) (*domain.Event, error) {
+	c.instanceUpdates = append(c.instanceUpdates, eventID)
+	return &domain.Event{ID: eventID}, nil
+}
+
+func (c *recurringTestClient) DeleteEvent(_ context.Context, _, _, eventID string) error {
+	c.deleted = append(c.deleted, eventID)
+	return nil
+}
+
+func TestUpdateRecurringEvent(t *testing.T) {
+	ctx := context.Background()
+	title := "Sync v2"
+
+	t.Run("single occurrence", func(t *testing.T) {
+		client := newRecurringTestClient()
+		event, note, err := updateRecurringEvent(ctx, client, "grant", "cal", "master-1", editOccurrence, "2024-03-18",
+			&domain.UpdateEventRequest{Title: &title})
+		require.NoError(t, err)
+		assert.Equal(t, "inst-0318", event.ID)
+		assert.Empty(t, note)
+		assert.Equal(t, []string{"inst-0318"}, client.instanceUpdates)
+		assert.Empty(t, client.updates)
+	})
+
+	t.Run("missing occurrence", func(t *testing.T) {
+		client := newRecurringTestClient()
+		_, _, err := updateRecurringEvent(ctx, client, "grant", "cal", "master-1", editOccurrence, "2024-03-19",
+			&domain.UpdateEventRequest{Title: &title})
+		assert.ErrorContains(t, err, "no occurrence")
+	})
+
+	t.Run("all from an instance ID", func(t *testing.T) {
+		client := newRecurringTestClient()
+		newStart := time.Date(2024, 3, 6, 14, 0, 0, 0, time.UTC)
+		req := &domain.UpdateEventRequest{When: &domain.EventWhen{StartTime: newStart.Unix(), EndTime: newStart.Add(time.Hour).Unix()}}
+
+		_, _, err := updateRecurringEvent(ctx, client, "grant", "cal", "inst-0311", editAllOccurrences, "", req)
+		require.NoError(t, err)
+		require.Contains(t, client.updates, "master-1")
+		assert.Equal(t, []string{"RRULE:FREQ=WEEKLY;BYDAY=WE;COUNT=5"}, client.updates["master-1"].Recurrence)
+	})
+
+	t.Run("this and following splits the series", func(t *testing.T) {
+		client := newRecurringTestClient()
+		event, note, err := updateRecurringEvent(ctx, client, "grant", "cal", "master-1", editThisAndFollowing, "2024-03-18",
+			&domain.UpdateEventRequest{Title: &title})
+		require.NoError(t, err)
+		assert.Equal(t, "new-series", event.ID)
+		assert.Contains(t, note, "before 2024-03-18")
+
+		require.Len(t, client.created, 1)
+		created := client.created[0]
+		assert.Equal(t, "Sync v2", created.Title)
+		assert.Equal(t, "Room 1", created.Location)
+		assert.Equal(t, time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC).Unix(), created.When.StartTime)
+		assert.Equal(t, []string{"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=3"}, created.Recurrence)
+
+		assert.Equal(t, []string{"RRULE:FREQ=WEEKLY;BYDAY=MO;UNTIL=20240318T085959Z"}, client.updates["master-1"].Recurrence)
+	})
+
+	t.Run("this and following from the first occurrence updates the master", func(t *testing.T) {
+		client := newRecurringTestClient()
+		_, _, err := updateRecurringEvent(ctx, client, "grant", "cal", "master-1", editThisAndFollowing, "2024-03-04",
+			&domain.UpdateEventRequest{Title: &title})
+		require.NoError(t, err)
+		assert.Empty(t, client.created)
+		assert.Contains(t, client.updates, "master-1")
+	})
+
+	t.Run("failed truncation removes the new series", func(t *testing.T) {
+		client := newRecurringTestClient()
+		client.failUpdate = true
+		_, _, err := updateRecurringEvent(ctx, client, "grant", "cal", "master-1", editThisAndFollowing, "2024-03-18",
+			&domain.UpdateEventRequest{Title: &title})
+		require.Error(t, err)
+		assert.Equal(t, []string{"new-series"}, client.deleted)
+	})
+
+	t.Run("non-recurring event", func(t *testing.T) {
+		client := newRecurringTestClient()
+		client.master.Recurrence = nil
+		_, _, err := updateRecurringEvent(ctx, client, "grant", "cal", "master-1", editAllOccurrences, "",
+			&domain.UpdateEventRequest{Title: &title})
+		assert.ErrorContains(t, err, "not recurring")
+	})
+}
@@ -23,10 +23,11 @@ func newFindTimeCmd() *cobra.Command {
 		workingEnd      string
 		days            int
 		excludeWeekends bool
+		calOpts         calendarFindTimeOptions
 	)
 
 	cmd := &cobra.Command{
-		Use:   "find-time",
+		Use:   "find-time [grant-id]",
 		Short: "Find optimal meeting times across multiple timezones",
 		Long: `Find optimal meeting times across multiple timezones.
 
@@ -35,7 +36,13 @@ Analyzes participant timezones and suggests meeting times with a 100-point scori
 - Time Quality (25 pts): Quality of time for participants (morning/afternoon)
 - Cultural (15 pts): Respects cultural norms (no Friday PM, no lunch hour)
 - Weekday (10 pts): Prefers mid-week meetings
-- Holiday (10 pts): Avoids holidays`,
+- Holiday (10 pts): Avoids holidays
+
+With --window (or --book), find-time checks real calendars: participants with a
+connected grant are queried through the availability API and their own
+free/busy, everyone else through the organizer grant's free/busy. Only slots
+where everyone is free within working hours are ranked. --book creates the
+event for the top slot (or --pick N) on the organizer's calendar.`,
 		Example: `  # Find time for 2 participants
   nylas calendar find-time --participants alice@example.com,bob@example.com --duration 1h
 
@@ -45,9 +52,19 @@ Analyzes participant timezones and suggests meeting times with a 100-point scori
     --duration 1h \
     --working-start 09:00 \
     --working-end 17:00 \
-    --days 7`,
+    --days 7
+
+  # Check connected calendars and rank free 45-minute slots next week
+  nylas calendar find-time --participants alice@example.com,bob@example.com \
+    --duration 45m --window "next week"
+
+  # Book the best slot
+  nylas calendar find-time --participants alice@example.com,bob@example.com \
+    --duration 45m --window "next week" --book --title "Design review"`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(participants) < 2 {
+			calendarMode := calOpts.window != "" || calOpts.book
+			if len(participants) < 2 && !(calendarMode && len(participants) == 1) {
 				return common.NewUserError(
 					"at least 2 participants required",
 					"Specify participants with --participants alice@example.com,bob@example.com",
@@ -94,6 +111,32 @@ Analyzes participant timezones and suggests meeting times with a 100-point scori
 				return err
 			}
 
+			if calendarMode {
+				window := calOpts.window
+				if window == "" {
+					window = fmt.Sprintf("next %d days", days)
+				}
+				start, end, err := parseFindTimeWindow(window, time.Now())
+				if err != nil {
+					return err
+				}
+				organizerTZ, err := time.LoadLocation(getLocalTimeZone())
+				if err != nil {
+					organizerTZ = time.Local
+				}
+				return runCalendarFindTime(cmd, args, calendarSearch{
+					participants:    participants,
+					timezones:       timezones,
+					organizerTZ:     organizerTZ,
+					duration:        dur,
+					start:           start,
+					end:             end,
+					workStart:       workStart,
+					workEnd:         workEnd,
+					excludeWeekends: excludeWeekends,
+				}, calOpts)
+			}
+
 			ctx, cancel := common.CreateContext()
 			defer cancel()
 
@@ -103,6 +146,14 @@ Analyzes participant timezones and suggests meeting times with a 100-point scori
 				return err
 			}
 
+			if common.IsStructuredOutput(cmd) {
+				out := newFindTimeOutput(slots)
+				if usedFallback {
+					out.Warnings = []string{fmt.Sprintf("no participant timezones were provided; used %s for all participants", timezones[0])}
+				}
+				return common.GetOutputWriter(cmd).Write(out)
+			}
+
 			// Display results
 			displayFindTimeResults(participants, timezones, slots, usedFallback, workStart, workEnd)
 
@@ -118,6 +169,14 @@ Analyzes participant timezones and suggests meeting times with a 100-point scori
 	cmd.Flags().IntVar(&days, "days", 7, "Number of days to search")
 	cmd.Flags().BoolVar(&excludeWeekends, "exclude-weekends", true, "Exclude weekends from search")
 
+	cmd.Flags().StringVar(&calOpts.window, "window", "", `Check real calendars in this window ("next week", "tomorrow", "next 3 days", "<start>..<end>")`)
+	cmd.Flags().IntVar(&calOpts.top, "top", 5, "Number of ranked slots to show with --window")
+	cmd.Flags().BoolVar(&calOpts.book, "book", false, "Create the event for the chosen slot")
+	cmd.Flags().IntVar(&calOpts.pick, "pick", 1, "Ranked slot to book with --book")
+	cmd.Flags().StringVar(&calOpts.title, "title", "Meeting", "Event title for --book")
+	cmd.Flags().StringVarP(&calOpts.calendarID, "calendar", "c", "", "Calendar to book on (defaults to primary)")
+	common.AddYesFlag(cmd, &calOpts.yes)
+
 	_ = cmd.MarkFlagRequired("participants")
 
 	return cmd
@@ -132,16 +191,9 @@ func findMeetingSlots(
 	days int,
 	excludeWeekends bool,
 ) ([]scheduling.TimeSlot, error) {
-	locations := make([]*time.Location, len(timezones))
-	for i, tz := range timezones {
-		loc, err := time.LoadLocation(tz)
-		if err != nil {
-			return nil, common.NewUserError(
-				fmt.Sprintf("invalid timezone: %s", tz),
-				"Use IANA timezone IDs like 'America/Los_Angeles'",
-			)
-		}
-		locations[i] = loc
+	locations, err := loadTimezoneLocations(timezones)
+	if err != nil {
+		return nil, err
 	}
 
 	workStart, err := parseWorkingTime(workingStart)
@@ -181,28 +233,7 @@ func findMeetingSlots(
 
 	slots := make([]scheduling.TimeSlot, 0, len(result.Slots))
 	for _, slot := range result.Slots {
-		participants := make([]scheduling.ParticipantTime, len(timezones))
-		for i, loc := range locations {
-			localTime := slot.StartTime.In(loc)
-			localMinutes := localTime.Hour()*60 + localTime.Minute()
-			isWorking := localMinutes >= workStart && localMinutes < workEnd
-			quality, icon := scheduling.GetQualityLabel(localTime, isWorking)
-			participants[i] = scheduling.ParticipantTime{
-				TimeZone:    timezones[i],
-				LocalTime:   localTime,
-				IsWorking:   isWorking,
-				Quality:     quality,
-				QualityIcon: icon,
-			}
-		}
-
-		breakdown := scheduling.ScoreTimeSlot(slot.StartTime, slot.EndTime, participants)
-		slots = append(slots, scheduling.TimeSlot{
-			StartTime: slot.StartTime,
-			EndTime:   slot.EndTime,
-			Score:     breakdown.Total,
-			Breakdown: breakdown,
-		})
+		slots = append(slots, scoreMeetingSlot(slot.StartTime, slot.EndTime, timezones, locations, workStart, workEnd))
 	}
 
 	if len(slots) > 5 {
@@ -212,6 +243,48 @@ func findMeetingSlots(
 	return slots, nil
 }
 
+// loadTimezoneLocations loads the IANA locations for timezones.
+func loadTimezoneLocations(timezones []string) ([]*time.Location, error) {
+	locations := make([]*time.Location, len(timezones))
+	for i, tz := range timezones {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, common.NewUserError(
+				fmt.Sprintf("invalid timezone: %s", tz),
+				"Use IANA timezone IDs like 'America/Los_Angeles'",
+			)
+		}
+		locations[i] = loc
+	}
+	return locations, nil
+}
+
+// scoreMeetingSlot scores a slot using each participant's local time.
+func scoreMeetingSlot(start, end time.Time, timezones []string, locations []*time.Location, workStart, workEnd int) scheduling.TimeSlot {
+	participants := make([]scheduling.ParticipantTime, len(timezones))
+	for i, loc := range locations {
+		localTime := start.In(loc)
+		localMinutes := localTime.Hour()*60 + localTime.Minute()
+		isWorking := localMinutes >= workStart && localMinutes < workEnd
+		quality, icon := scheduling.GetQualityLabel(localTime, isWorking)
+		participants[i] = scheduling.ParticipantTime{
+			TimeZone:    timezones[i],
+			LocalTime:   localTime,
+			IsWorking:   isWorking,
+			Quality:     quality,
+			QualityIcon: icon,
+		}
+	}
+
+	breakdown := scheduling.ScoreTimeSlot(start, end, participants)
+	return scheduling.TimeSlot{
+		StartTime: start,
+		EndTime:   end,
+		Score:     breakdown.Total,
+		Breakdown: breakdown,
+	}
+}
+
 // displayFindTimeResults displays the found meeting times.
 func displayFindTimeResults(participants []string, timezones []string, slots []scheduling.TimeSlot, usedFallback bool, workStart, workEnd int) {
 	fmt.Println("\n🌍 Multi-Timezone Meeting Finder")
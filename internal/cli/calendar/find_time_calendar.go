@@ -0,0 +1,452 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/utilities/scheduling"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// findTimeInterval is the step between candidate slot start times.
+const findTimeInterval = 15 * time.Minute
+
+// maxFindTimeCandidates bounds locally generated candidates for long windows.
+const maxFindTimeCandidates = 5000
+
+// calendarFindTimeOptions holds the flags that switch find-time to real
+// calendar data.
+type calendarFindTimeOptions struct {
+	window     string
+	book       bool
+	yes        bool
+	title      string
+	calendarID string
+	pick       int
+	top        int
+}
+
+// calendarSearch describes a calendar-aware slot search.
+type calendarSearch struct {
+	participants    []string
+	timezones       []string
+	organizerTZ     *time.Location
+	duration        time.Duration
+	start, end      time.Time
+	workStart       int
+	workEnd         int
+	excludeWeekends bool
+}
+
+// calendarSearchResult holds ranked free slots and where the data came from.
+type calendarSearchResult struct {
+	Slots     []scheduling.TimeSlot
+	Connected []string
+	Unchecked []string
+	Warnings  []string
+}
+
+// rankedSlot is the structured-output form of a ranked slot.
+type rankedSlot struct {
+	Rank      int       `json:"rank"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Score     float64   `json:"score"`
+}
+
+// findTimeOutput is the structured output of find-time.
+type findTimeOutput struct {
+	Slots           []rankedSlot `json:"slots"`
+	ConnectedGrants []string     `json:"connected_grants"`
+	Unchecked       []string     `json:"unchecked"`
+	Warnings        []string     `json:"warnings"`
+}
+
+func newFindTimeOutput(slots []scheduling.TimeSlot) findTimeOutput {
+	out := findTimeOutput{Slots: make([]rankedSlot, len(slots))}
+	for i, slot := range slots {
+		out.Slots[i] = rankedSlot{Rank: i + 1, StartTime: slot.StartTime, EndTime: slot.EndTime, Score: slot.Score}
+	}
+	return out
+}
+
+type busyInterval struct {
+	start, end int64
+}
+
+// parseFindTimeWindow parses --window: "today", "tomorrow", "this week",
+// "next week", "next N days", a duration such as "3d", or "<start>..<end>".
+func parseFindTimeWindow(window string, now time.Time) (time.Time, time.Time, error) {
+	w := strings.ToLower(strings.TrimSpace(window))
+	loc := now.Location()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	daysToMonday := (8 - int(now.Weekday())) % 7
+	if daysToMonday == 0 {
+		daysToMonday = 7
+	}
+
+	switch w {
+	case "today":
+		return now, midnight.AddDate(0, 0, 1), nil
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), midnight.AddDate(0, 0, 2), nil
+	case "this week":
+		return now, midnight.AddDate(0, 0, daysToMonday), nil
+	case "next week":
+		start := midnight.AddDate(0, 0, daysToMonday)
+		return start, start.AddDate(0, 0, 7), nil
+	}
+
+	if rest, ok := strings.CutPrefix(w, "next "); ok {
+		if n, ok := strings.CutSuffix(rest, " days"); ok {
+			if days, err := strconv.Atoi(n); err == nil && days > 0 {
+				return now, midnight.AddDate(0, 0, days+1), nil
+			}
+		}
+	}
+
+	if from, to, ok := strings.Cut(window, ".."); ok {
+		start, err := common.ParseHumanTime(from, common.ParseHumanTimeOpts{Now: now})
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end, err := common.ParseHumanTime(to, common.ParseHumanTimeOpts{Now: now})
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if !end.After(start) {
+			return time.Time{}, time.Time{}, common.NewInputError("--window end must be after its start")
+		}
+		return start, end, nil
+	}
+
+	if d, err := common.ParseDuration(w); err == nil && d > 0 {
+		return now, now.Add(d), nil
+	}
+
+	return time.Time{}, time.Time{}, common.NewUserError(
+		fmt.Sprintf("invalid --window: %s", window),
+		`Use "today", "tomorrow", "this week", "next week", "next 3 days", "5d", or "<start>..<end>"`,
+	)
+}
+
+// solveCalendarSlots combines the availability API (for participants with a
+// connected grant) with free/busy data (each connected grant checks its own
+// calendar; the organizer grant checks everyone else) and ranks the free
+// slots that remain.
+func solveCalendarSlots(ctx context.Context, client ports.NylasClient, organizerGrantID string, grants []domain.GrantInfo, s calendarSearch) (*calendarSearchResult, error) {
+	result := &calendarSearchResult{}
+
+	grantByEmail := map[string]string{}
+	for _, g := range grants {
+		if g.Email != "" {
+			grantByEmail[strings.ToLower(g.Email)] = g.ID
+		}
+	}
+	var connected, others []string
+	for _, email := range s.participants {
+		if _, ok := grantByEmail[strings.ToLower(email)]; ok {
+			connected = append(connected, email)
+		} else {
+			others = append(others, email)
+		}
+	}
+	result.Connected = connected
+
+	candidates := candidateSlotsFromAvailability(ctx, client, connected, s, result)
+	if candidates == nil {
+		candidates = generateCandidateSlots(s)
+	}
+
+	busy := map[string][]busyInterval{}
+	for _, email := range connected {
+		collectFreeBusy(ctx, client, grantByEmail[strings.ToLower(email)], []string{email}, s, busy, result)
+	}
+	if len(others) > 0 {
+		collectFreeBusy(ctx, client, organizerGrantID, others, s, busy, result)
+	}
+
+	locations, err := loadTimezoneLocations(s.timezones)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range candidates {
+		if !withinWorkingHours(c, s) || overlapsBusy(c, busy) {
+			continue
+		}
+		result.Slots = append(result.Slots, scoreMeetingSlot(c.StartTime, c.EndTime, s.timezones, locations, s.workStart, s.workEnd))
+	}
+
+	sort.SliceStable(result.Slots, func(i, j int) bool {
+		if result.Slots[i].Score != result.Slots[j].Score {
+			return result.Slots[i].Score > result.Slots[j].Score
+		}
+		return result.Slots[i].StartTime.Before(result.Slots[j].StartTime)
+	})
+	return result, nil
+}
+
+// candidateSlotsFromAvailability asks the availability API for slots where
+// every connected participant is free. It returns nil when the API cannot be
+// used, so the caller falls back to generated candidates.
+func candidateSlotsFromAvailability(ctx context.Context, client ports.NylasClient, connected []string, s calendarSearch, result *calendarSearchResult) []scheduling.TimeSlot {
+	if len(connected) == 0 {
+		return nil
+	}
+
+	participants := make([]domain.AvailabilityParticipant, len(connected))
+	for i, email := range connected {
+		participants[i] = domain.AvailabilityParticipant{Email: email}
+	}
+	resp, err := client.GetAvailability(ctx, &domain.AvailabilityRequest{
+		StartTime:       s.start.Unix(),
+		EndTime:         s.end.Unix(),
+		DurationMinutes: int(s.duration.Minutes()),
+		Participants:    participants,
+		IntervalMinutes: int(findTimeInterval.Minutes()),
+	})
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("availability API unavailable, using free/busy only: %v", err))
+		return nil
+	}
+
+	slots := make([]scheduling.TimeSlot, 0, len(resp.Data.TimeSlots))
+	for _, slot := range resp.Data.TimeSlots {
+		slots = append(slots, scheduling.TimeSlot{
+			StartTime: time.Unix(slot.StartTime, 0),
+			EndTime:   time.Unix(slot.EndTime, 0),
+		})
+	}
+	return slots
+}
+
+// generateCandidateSlots steps through the window at findTimeInterval.
+func generateCandidateSlots(s calendarSearch) []scheduling.TimeSlot {
+	var slots []scheduling.TimeSlot
+	start := s.start.Truncate(findTimeInterval)
+	if start.Before(s.start) {
+		start = start.Add(findTimeInterval)
+	}
+	for t := start; !t.Add(s.duration).After(s.end) && len(slots) < maxFindTimeCandidates; t = t.Add(findTimeInterval) {
+		slots = append(slots, scheduling.TimeSlot{StartTime: t, EndTime: t.Add(s.duration)})
+	}
+	return slots
+}
+
+// collectFreeBusy records busy intervals per email. Emails that cannot be
+// checked are listed in result.Unchecked rather than failing the search.
+func collectFreeBusy(ctx context.Context, client ports.NylasClient, grantID string, emails []string, s calendarSearch, busy map[string][]busyInterval, result *calendarSearchResult) {
+	resp, err := client.GetFreeBusy(ctx, grantID, &domain.FreeBusyRequest{
+		StartTime: s.start.Unix(),
+		EndTime:   s.end.Unix(),
+		Emails:    emails,
+	})
+	if err != nil {
+		result.Unchecked = append(result.Unchecked, emails...)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("free/busy for %s: %v", strings.Join(emails, ", "), err))
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, cal := range resp.Data {
+		seen[strings.ToLower(cal.Email)] = true
+		for _, slot := range cal.TimeSlots {
+			if slot.Status == "free" {
+				continue
+			}
+			key := strings.ToLower(cal.Email)
+			busy[key] = append(busy[key], busyInterval{start: slot.StartTime, end: slot.EndTime})
+		}
+	}
+	for _, email := range emails {
+		if !seen[strings.ToLower(email)] {
+			result.Unchecked = append(result.Unchecked, email)
+		}
+	}
+}
+
+func withinWorkingHours(slot scheduling.TimeSlot, s calendarSearch) bool {
+	start := slot.StartTime.In(s.organizerTZ)
+	end := slot.EndTime.In(s.organizerTZ)
+	if s.excludeWeekends && (start.Weekday() == time.Saturday || start.Weekday() == time.Sunday) {
+		return false
+	}
+	if start.YearDay() != end.YearDay() && !(end.Hour() == 0 && end.Minute() == 0) {
+		return false
+	}
+	startMinutes := start.Hour()*60 + start.Minute()
+	endMinutes := startMinutes + int(slot.EndTime.Sub(slot.StartTime).Minutes())
+	return startMinutes >= s.workStart && endMinutes <= s.workEnd
+}
+
+func overlapsBusy(slot scheduling.TimeSlot, busy map[string][]busyInterval) bool {
+	start, end := slot.StartTime.Unix(), slot.EndTime.Unix()
+	for _, intervals := range busy {
+		for _, b := range intervals {
+			if start < b.end && b.start < end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runCalendarFindTime runs the calendar-aware search and optionally books
+// the chosen slot on the organizer's calendar.
+func runCalendarFindTime(cmd *cobra.Command, args []string, s calendarSearch, opts calendarFindTimeOptions) error {
+	var grants []domain.GrantInfo
+	if store, err := common.NewDefaultGrantStore(); err == nil {
+		grants, _ = store.ListGrants()
+	}
+
+	_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+		result, err := common.RunWithSpinnerResult("Checking calendars...", func() (*calendarSearchResult, error) {
+			return solveCalendarSlots(ctx, client, grantID, grants, s)
+		})
+		if err != nil {
+			return struct{}{}, err
+		}
+
+		ranked := result.Slots
+		if opts.top > 0 && len(ranked) > opts.top {
+			result.Slots = ranked[:opts.top]
+		}
+
+		if common.IsStructuredOutput(cmd) && !opts.book {
+			out := newFindTimeOutput(result.Slots)
+			out.ConnectedGrants, out.Unchecked, out.Warnings = result.Connected, result.Unchecked, result.Warnings
+			return struct{}{}, common.GetOutputWriter(cmd).Write(out)
+		}
+		if !common.IsStructuredOutput(cmd) {
+			displayCalendarFindTime(s, result)
+		}
+
+		if !opts.book {
+			return struct{}{}, nil
+		}
+		if len(ranked) == 0 {
+			return struct{}{}, common.NewUserError("no free slot to book", "Try a wider --window or different working hours")
+		}
+		if opts.pick < 1 || opts.pick > len(ranked) {
+			return struct{}{}, common.NewInputError(fmt.Sprintf("--pick must be between 1 and %d", len(ranked)))
+		}
+		slot := ranked[opts.pick-1]
+
+		if !opts.yes && !common.Confirm(fmt.Sprintf("Book %q at %s?", opts.title, slot.StartTime.In(s.organizerTZ).Format("Mon, Jan 2 3:04 PM MST")), true) {
+			fmt.Println("Cancelled.")
+			return struct{}{}, nil
+		}
+
+		event, err := bookFindTimeSlot(ctx, client, grantID, slot, s, opts)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if common.IsStructuredOutput(cmd) {
+			return struct{}{}, common.GetOutputWriter(cmd).Write(event)
+		}
+		common.PrintSuccess("Booked %q (event %s)", event.Title, event.ID)
+		return struct{}{}, nil
+	})
+	return err
+}
+
+// bookFindTimeSlot creates the event on the organizer's calendar, inviting
+// every participant except the organizer.
+func bookFindTimeSlot(ctx context.Context, client ports.NylasClient, grantID string, slot scheduling.TimeSlot, s calendarSearch, opts calendarFindTimeOptions) (*domain.Event, error) {
+	calendarID, err := GetDefaultCalendarID(ctx, client, grantID, opts.calendarID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var organizer string
+	if grant, err := client.GetGrant(ctx, grantID); err == nil {
+		organizer = strings.ToLower(grant.Email)
+	}
+	var participants []domain.Participant
+	for _, email := range s.participants {
+		if strings.ToLower(email) != organizer {
+			participants = append(participants, domain.Participant{Person: domain.Person{Email: email}})
+		}
+	}
+
+	tz := s.organizerTZ.String()
+	event, err := client.CreateEvent(ctx, grantID, calendarID, &domain.CreateEventRequest{
+		Title: opts.title,
+		When: domain.EventWhen{
+			StartTime:     slot.StartTime.Unix(),
+			EndTime:       slot.EndTime.Unix(),
+			StartTimezone: tz,
+			EndTimezone:   tz,
+		},
+		Participants: participants,
+		Busy:         true,
+	})
+	if err != nil {
+		return nil, common.WrapCreateError("event", err)
+	}
+	return event, nil
+}
+
+func displayCalendarFindTime(s calendarSearch, result *calendarSearchResult) {
+	fmt.Printf("\n📅 Free slots for %s (%s)\n", strings.Join(s.participants, ", "), formatFindTimeDuration(s.duration))
+	fmt.Printf("   %s – %s\n\n",
+		s.start.In(s.organizerTZ).Format("Mon Jan 2 3:04 PM"),
+		s.end.In(s.organizerTZ).Format("Mon Jan 2 3:04 PM MST"))
+
+	if len(result.Connected) > 0 {
+		fmt.Printf("Connected calendars: %s\n", strings.Join(result.Connected, ", "))
+	}
+	if len(result.Unchecked) > 0 {
+		fmt.Printf("%s Could not check: %s\n", common.Yellow.Sprint("⚠"), strings.Join(result.Unchecked, ", "))
+	}
+	for _, w := range result.Warnings {
+		fmt.Printf("%s %s\n", common.Yellow.Sprint("⚠"), w)
+	}
+
+	if len(result.Slots) == 0 {
+		fmt.Println("\n❌ No slot where everyone is free")
+		fmt.Println("Try a wider --window, a shorter --duration, or different working hours")
+		return
+	}
+
+	fmt.Println()
+	for i, slot := range result.Slots {
+		fmt.Printf("%d. %s %s – %s (Score: %.0f/100)\n",
+			i+1,
+			scheduling.GetScoreColor(slot.Score),
+			slot.StartTime.In(s.organizerTZ).Format("Mon, Jan 2 3:04 PM"),
+			slot.EndTime.In(s.organizerTZ).Format("3:04 PM MST"),
+			slot.Score,
+		)
+		// One line per distinct participant timezone.
+		for j, tz := range s.timezones {
+			if slices.Index(s.timezones, tz) != j || tz == s.organizerTZ.String() {
+				continue
+			}
+			loc, _ := time.LoadLocation(tz)
+			fmt.Printf("   %s: %s\n", tz, slot.StartTime.In(loc).Format("Mon 3:04 PM"))
+		}
+	}
+	fmt.Println()
+	fmt.Println("💡 Book a slot with --book (and --pick N for a slot other than #1)")
+}
+
+func formatFindTimeDuration(d time.Duration) string {
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	if d > time.Hour {
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
@@ -0,0 +1,183 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/adapters/utilities/scheduling"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFindTimeWindow(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		window    string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{window: "today", wantStart: now, wantEnd: day(15)},
+		{window: "tomorrow", wantStart: day(15), wantEnd: day(16)},
+		{window: "this week", wantStart: now, wantEnd: day(19)},
+		{window: "Next Week", wantStart: day(19), wantEnd: day(26)},
+		{window: "next 3 days", wantStart: now, wantEnd: day(18)},
+		{window: "2d", wantStart: now, wantEnd: now.Add(48 * time.Hour)},
+		{window: "2026-10-20 09:00..2026-10-20 17:00", wantStart: time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC), wantEnd: time.Date(2026, 10, 20, 17, 0, 0, 0, time.UTC)},
+		{window: "2026-10-20 17:00..2026-10-20 09:00", wantErr: true},
+		{window: "someday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.window, func(t *testing.T) {
+			start, end, err := parseFindTimeWindow(tt.window, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func testCalendarSearch(participants ...string) calendarSearch {
+	timezones := make([]string, len(participants))
+	for i := range timezones {
+		timezones[i] = "UTC"
+	}
+	return calendarSearch{
+		participants:    participants,
+		timezones:       timezones,
+		organizerTZ:     time.UTC,
+		duration:        time.Hour,
+		start:           time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), // Tuesday
+		end:             time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+		workStart:       9 * 60,
+		workEnd:         17 * 60,
+		excludeWeekends: true,
+	}
+}
+
+func TestSolveCalendarSlots_CombinesGrants(t *testing.T) {
+	client := nylas.NewMockClient()
+	at := func(h, m int) int64 { return time.Date(2026, 10, 20, h, m, 0, 0, time.UTC).Unix() }
+
+	var availabilityEmails []string
+	client.GetAvailabilityFunc = func(_ context.Context, req *domain.AvailabilityRequest) (*domain.AvailabilityResponse, error) {
+		for _, p := range req.Participants {
+			availabilityEmails = append(availabilityEmails, p.Email)
+		}
+		assert.Equal(t, 60, req.DurationMinutes)
+		return &domain.AvailabilityResponse{Data: domain.AvailabilityData{TimeSlots: []domain.AvailableSlot{
+			{StartTime: at(7, 0), EndTime: at(8, 0)},   // before working hours
+			{StartTime: at(10, 0), EndTime: at(11, 0)}, // bob is busy
+			{StartTime: at(14, 0), EndTime: at(15, 0)},
+			{StartTime: at(15, 0), EndTime: at(16, 0)},
+		}}}, nil
+	}
+
+	freeBusyGrants := map[string][]string{}
+	client.GetFreeBusyFunc = func(_ context.Context, grantID string, req *domain.FreeBusyRequest) (*domain.FreeBusyResponse, error) {
+		freeBusyGrants[grantID] = req.Emails
+		resp := &domain.FreeBusyResponse{}
+		for _, email := range req.Emails {
+			cal := domain.FreeBusyCalendar{Email: email}
+			if email == "bob@y.com" {
+				cal.TimeSlots = []domain.TimeSlot{{StartTime: at(10, 30), EndTime: at(11, 30), Status: "busy"}}
+			}
+			resp.Data = append(resp.Data, cal)
+		}
+		return resp, nil
+	}
+
+	grants := []domain.GrantInfo{
+		{ID: "grant-alice", Email: "alice@x.com"},
+		{ID: "grant-carol", Email: "Carol@x.com"},
+	}
+	s := testCalendarSearch("alice@x.com", "bob@y.com", "carol@x.com")
+
+	result, err := solveCalendarSlots(context.Background(), client, "grant-organizer", grants, s)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"alice@x.com", "carol@x.com"}, availabilityEmails)
+	assert.Equal(t, []string{"alice@x.com"}, freeBusyGrants["grant-alice"])
+	assert.Equal(t, []string{"carol@x.com"}, freeBusyGrants["grant-carol"])
+	assert.Equal(t, []string{"bob@y.com"}, freeBusyGrants["grant-organizer"])
+	assert.Equal(t, []string{"alice@x.com", "carol@x.com"}, result.Connected)
+
+	require.Len(t, result.Slots, 2)
+	for _, slot := range result.Slots {
+		assert.NotEqual(t, at(10, 0), slot.StartTime.Unix(), "busy slot must be excluded")
+		assert.NotEqual(t, at(7, 0), slot.StartTime.Unix(), "slot outside working hours must be excluded")
+	}
+	assert.GreaterOrEqual(t, result.Slots[0].Score, result.Slots[1].Score)
+}
+
+func TestSolveCalendarSlots_FallsBackWithoutConnectedGrants(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetAvailabilityFunc = func(context.Context, *domain.AvailabilityRequest) (*domain.AvailabilityResponse, error) {
+		t.Fatal("availability API needs connected grants")
+		return nil, nil
+	}
+	client.GetFreeBusyFunc = func(context.Context, string, *domain.FreeBusyRequest) (*domain.FreeBusyResponse, error) {
+		return nil, errors.New("forbidden")
+	}
+
+	result, err := solveCalendarSlots(context.Background(), client, "grant-organizer", nil, testCalendarSearch("a@x.com", "b@y.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a@x.com", "b@y.com"}, result.Unchecked)
+	require.NotEmpty(t, result.Warnings)
+	// 9:00 to 16:00 starts at 15-minute steps.
+	assert.Len(t, result.Slots, 29)
+	for _, slot := range result.Slots {
+		assert.True(t, slot.StartTime.Hour() >= 9 && slot.EndTime.Hour() <= 17)
+	}
+}
+
+func TestBookFindTimeSlot(t *testing.T) {
+	client := nylas.NewMockClient()
+	var got *domain.CreateEventRequest
+	var gotCalendar string
+	client.CreateEventFunc = func(_ context.Context, grantID, calendarID string, req *domain.CreateEventRequest) (*domain.Event, error) {
+		got, gotCalendar = req, calendarID
+		return &domain.Event{ID: "evt-1", Title: req.Title}, nil
+	}
+	client.GetGrantFunc = func(context.Context, string) (*domain.Grant, error) {
+		return &domain.Grant{ID: "grant-1", Email: "Me@x.com"}, nil
+	}
+
+	s := testCalendarSearch("me@x.com", "bob@y.com")
+	slot := generateCandidateSlots(s)[40]
+	event, err := bookFindTimeSlot(context.Background(), client, "grant-1", slot, s, calendarFindTimeOptions{title: "Sync", calendarID: "cal-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "evt-1", event.ID)
+	assert.Equal(t, "cal-1", gotCalendar)
+	assert.Equal(t, "Sync", got.Title)
+	assert.Equal(t, slot.StartTime.Unix(), got.When.StartTime)
+	assert.Equal(t, "UTC", got.When.StartTimezone)
+	require.Len(t, got.Participants, 1, "the organizer is not invited to their own event")
+	assert.Equal(t, "bob@y.com", got.Participants[0].Email)
+}
+
+func TestNewFindTimeOutput_RanksSlots(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	out := newFindTimeOutput([]scheduling.TimeSlot{
+		{StartTime: start, EndTime: start.Add(time.Hour), Score: 92},
+		{StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour), Score: 80},
+	})
+
+	require.Len(t, out.Slots, 2)
+	assert.Equal(t, rankedSlot{Rank: 1, StartTime: start, EndTime: start.Add(time.Hour), Score: 92}, out.Slots[0])
+	assert.Equal(t, 2, out.Slots[1].Rank)
+}
@@ -63,11 +63,11 @@ automatically blocks focus time to protect deep work sessions.`,
 				}
 
 				if analyze || enable {
-					return struct{}{}, runFocusTimeAnalysis(ctx, optimizer, grantID, settings)
+					return struct{}{}, runFocusTimeAnalysis(ctx, cmd, optimizer, grantID, settings)
 				}
 
 				if create {
-					return struct{}{}, runCreateFocusBlocks(ctx, optimizer, grantID, settings)
+					return struct{}{}, runCreateFocusBlocks(ctx, cmd, optimizer, grantID, settings)
 				}
 
 				return struct{}{}, cmd.Help()
@@ -87,15 +87,22 @@ automatically blocks focus time to protect deep work sessions.`,
 }
 
 // runFocusTimeAnalysis analyzes productivity patterns and shows recommendations.
-func runFocusTimeAnalysis(ctx context.Context, optimizer *analytics.FocusOptimizer, grantID string, settings *domain.FocusTimeSettings) error {
-	fmt.Println("\n🧠 AI Focus Time Protection")
-	fmt.Println("Analyzing your productivity patterns...")
+func runFocusTimeAnalysis(ctx context.Context, cmd *cobra.Command, optimizer *analytics.FocusOptimizer, grantID string, settings *domain.FocusTimeSettings) error {
+	structured := common.IsStructuredOutput(cmd)
+	if !structured {
+		fmt.Println("\n🧠 AI Focus Time Protection")
+		fmt.Println("Analyzing your productivity patterns...")
+	}
 
 	analysis, err := optimizer.AnalyzeFocusTimePatterns(ctx, grantID, settings)
 	if err != nil {
 		return fmt.Errorf("analyze focus patterns: %w", err)
 	}
 
+	if structured {
+		return common.GetOutputWriter(cmd).Write(analysis)
+	}
+
 	// Display discovered patterns
 	fmt.Println("\n✨ Discovered Focus Patterns:")
 
@@ -218,8 +225,11 @@ func displayWeeklySchedule(blocks []domain.FocusTimeBlock) {
 }
 
 // runCreateFocusBlocks creates the recommended focus blocks in the calendar.
-func runCreateFocusBlocks(ctx context.Context, optimizer *analytics.FocusOptimizer, grantID string, settings *domain.FocusTimeSettings) error {
-	fmt.Println("\n🔨 Creating Focus Time Blocks...")
+func runCreateFocusBlocks(ctx context.Context, cmd *cobra.Command, optimizer *analytics.FocusOptimizer, grantID string, settings *domain.FocusTimeSettings) error {
+	structured := common.IsStructuredOutput(cmd)
+	if !structured {
+		fmt.Println("\n🔨 Creating Focus Time Blocks...")
+	}
 
 	// First analyze to get recommendations
 	analysis, err := optimizer.AnalyzeFocusTimePatterns(ctx, grantID, settings)
@@ -228,6 +238,9 @@ func runCreateFocusBlocks(ctx context.Context, optimizer *analytics.FocusOptimiz
 	}
 
 	if len(analysis.RecommendedBlocks) == 0 {
+		if structured {
+			return common.GetOutputWriter(cmd).Write([]*domain.ProtectedBlock{})
+		}
 		fmt.Println("❌ No focus blocks recommended. Need more calendar history.")
 		return nil
 	}
@@ -238,6 +251,10 @@ func runCreateFocusBlocks(ctx context.Context, optimizer *analytics.FocusOptimiz
 		return common.WrapCreateError("protected blocks", err)
 	}
 
+	if structured {
+		return common.GetOutputWriter(cmd).Write(protectedBlocks)
+	}
+
 	fmt.Printf("✅ Created %d focus time blocks:\n\n", len(protectedBlocks))
 
 	for i, block := range protectedBlocks {
@@ -0,0 +1,499 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Free/busy cell statuses.
+const (
+	cellFree    = "free"
+	cellPartial = "partial"
+	cellBusy    = "busy"
+)
+
+// Where a participant's timezone came from.
+const (
+	tzSourceFlag     = "flag"
+	tzSourceCalendar = "calendar"
+	tzSourceAssumed  = "assumed"
+)
+
+// freeBusyGrid is an hour-by-hour view of several people's free/busy data.
+type freeBusyGrid struct {
+	Day          string        `json:"day"`
+	Timezone     string        `json:"timezone"`
+	Hours        []time.Time   `json:"hours"`
+	Participants []freeBusyRow `json:"participants"`
+}
+
+// freeBusyRow is one participant's line of the grid.
+type freeBusyRow struct {
+	Email          string         `json:"email"`
+	Timezone       string         `json:"timezone"`
+	TimezoneSource string         `json:"timezone_source"`
+	Cells          []freeBusyCell `json:"cells,omitempty"`
+	Busy           []freeBusySlot `json:"busy"`
+	Error          string         `json:"error,omitempty"`
+}
+
+// freeBusyCell is one hour of a participant's day. LocalTime is the
+// participant's own wall-clock time at the start of the hour.
+type freeBusyCell struct {
+	Start        time.Time `json:"start"`
+	LocalTime    string    `json:"local_time"`
+	Status       string    `json:"status"`
+	BusyMinutes  int       `json:"busy_minutes"`
+	WorkingHours bool      `json:"working_hours"`
+}
+
+type freeBusySlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// participantTZ is a participant's resolved timezone.
+type participantTZ struct {
+	name   string
+	loc    *time.Location
+	source string
+}
+
+// freeBusyLayout holds the grid's time window and display settings.
+type freeBusyLayout struct {
+	dayStart, dayEnd time.Time
+	viewer           *time.Location
+	fromHour, toHour int // Viewer hours shown, [fromHour, toHour)
+	workStart        int // Participant working hours, minutes after midnight
+	workEnd          int
+}
+
+func newFreeBusyGridCmd() *cobra.Command {
+	var (
+		emails       []string
+		timezones    []string
+		day          string
+		viewerTZ     string
+		hours        string
+		workingStart string
+		workingEnd   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "freebusy [grant-id]",
+		Short: "Show an hour-by-hour free/busy grid for several people",
+		Long: `Show an hour-by-hour free/busy grid for one day.
+
+Each row is a participant and each column an hour in your timezone (or
+--timezone). Hours outside a participant's working hours, in their own
+timezone, are dimmed. Participant timezones come from --timezones, then from
+the primary calendar of a connected grant with the same email, and otherwise
+are assumed to match yours.`,
+		Example: `  # Tomorrow for two people
+  nylas calendar freebusy --emails alice@example.com,bob@example.com --day tomorrow
+
+  # Business hours only, with explicit participant timezones
+  nylas calendar freebusy --emails alice@example.com,kenji@example.com \
+    --timezones America/New_York,Asia/Tokyo --hours 7-19
+
+  # The grid as JSON
+  nylas calendar freebusy --emails alice@example.com --day 2026-11-02 --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// "freebusy" used to be an alias of "availability"; point old
+			// scripts at the new name instead of treating the subcommand as a
+			// grant ID.
+			if len(args) > 0 && (args[0] == "check" || args[0] == "find") {
+				return common.NewUserError(
+					fmt.Sprintf("'nylas calendar freebusy %s' is now 'nylas calendar availability %s'", args[0], args[0]),
+					"'calendar freebusy' is the free/busy grid; see 'nylas calendar freebusy --help'",
+				)
+			}
+
+			viewer := time.Local
+			if viewerTZ != "" {
+				if err := validateTimeZone(viewerTZ); err != nil {
+					return err
+				}
+				viewer, _ = time.LoadLocation(viewerTZ)
+			} else {
+				viewerTZ = getLocalTimeZone()
+			}
+
+			layout := freeBusyLayout{viewer: viewer}
+			var err error
+			if layout.dayStart, layout.dayEnd, err = parseFreeBusyDay(day, time.Now().In(viewer)); err != nil {
+				return err
+			}
+			if layout.fromHour, layout.toHour, err = parseHourRange(hours); err != nil {
+				return err
+			}
+			if layout.workStart, err = parseWorkingTime(workingStart); err != nil {
+				return common.NewInputError(fmt.Sprintf("invalid --working-start %q: use HH:MM", workingStart))
+			}
+			if layout.workEnd, err = parseWorkingTime(workingEnd); err != nil {
+				return common.NewInputError(fmt.Sprintf("invalid --working-end %q: use HH:MM", workingEnd))
+			}
+			for i := range emails {
+				emails[i] = strings.TrimSpace(emails[i])
+			}
+			if len(timezones) > 0 && len(timezones) != len(emails) {
+				return common.NewUserError(
+					fmt.Sprintf("got %d timezones for %d emails", len(timezones), len(emails)),
+					"Provide one timezone per email, in the same order as --emails (use - to look it up)",
+				)
+			}
+
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				list := emails
+				if len(list) == 0 {
+					email := grantEmail(ctx, client, grantID)
+					if email == "" {
+						return struct{}{}, common.NewUserError("no email found for grant",
+							"Specify the people to show with --emails")
+					}
+					list = []string{email}
+				}
+
+				grid, err := common.RunWithSpinnerResult("Checking free/busy...", func() (freeBusyGrid, error) {
+					tzs, err := resolveFreeBusyTimezones(ctx, client, list, timezones, connectedGrants(), viewer, viewerTZ)
+					if err != nil {
+						return freeBusyGrid{}, err
+					}
+					resp, err := client.GetFreeBusy(ctx, grantID, &domain.FreeBusyRequest{
+						StartTime: layout.dayStart.Unix(),
+						EndTime:   layout.dayEnd.Unix(),
+						Emails:    list,
+					})
+					if err != nil {
+						return freeBusyGrid{}, common.WrapGetError("free/busy", err)
+					}
+					grid := buildFreeBusyGrid(resp, list, tzs, layout)
+					grid.Timezone = viewerTZ
+					return grid, nil
+				})
+				if err != nil {
+					return struct{}{}, err
+				}
+
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(grid)
+				}
+				renderFreeBusyGrid(cmd.OutOrStdout(), grid, layout)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&emails, "emails", "e", nil, "Email addresses to show (comma-separated; default: the grant's email)")
+	cmd.Flags().StringSliceVar(&timezones, "timezones", nil, "Participant IANA timezones, aligned with --emails (- to look up)")
+	cmd.Flags().StringVar(&day, "day", "today", `Day to show ("today", "tomorrow", "monday", "2026-11-02")`)
+	cmd.Flags().StringVar(&viewerTZ, "timezone", "", "IANA timezone of the grid columns (defaults to system timezone)")
+	cmd.Flags().StringVar(&hours, "hours", "0-24", "Hours of the day to show, in --timezone (e.g. 8-18)")
+	cmd.Flags().StringVar(&workingStart, "working-start", "09:00", "Participants' working hours start (HH:MM, their local time)")
+	cmd.Flags().StringVar(&workingEnd, "working-end", "17:00", "Participants' working hours end (HH:MM, their local time)")
+
+	return cmd
+}
+
+// parseFreeBusyDay returns the [midnight, next midnight) window of day in
+// now's location. day is "today", "tomorrow", a weekday (its next
+// occurrence, counting today), a YYYY-MM-DD date, or any time
+// ParseHumanTime accepts.
+func parseFreeBusyDay(day string, now time.Time) (time.Time, time.Time, error) {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	d := strings.ToLower(strings.TrimSpace(day))
+
+	start, ok := time.Time{}, true
+	switch d {
+	case "", "today":
+		start = today
+	case "tomorrow":
+		start = today.AddDate(0, 0, 1)
+	case "yesterday":
+		start = today.AddDate(0, 0, -1)
+	default:
+		ok = false
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if d == strings.ToLower(wd.String()) || d == strings.ToLower(wd.String()[:3]) {
+				start, ok = today.AddDate(0, 0, (int(wd)-int(now.Weekday())+7)%7), true
+				break
+			}
+		}
+	}
+	if !ok {
+		if t, err := time.ParseInLocation("2006-01-02", d, loc); err == nil {
+			start, ok = t, true
+		} else if t, err := common.ParseHumanTime(day, common.ParseHumanTimeOpts{Now: now}); err == nil {
+			t = t.In(loc)
+			start, ok = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), true
+		}
+	}
+	if !ok {
+		return time.Time{}, time.Time{}, common.NewUserError(
+			fmt.Sprintf("invalid --day: %s", day),
+			`Use "today", "tomorrow", a weekday such as "monday", or a date such as 2026-11-02`,
+		)
+	}
+	return start, start.AddDate(0, 0, 1), nil
+}
+
+// parseHourRange parses --hours, e.g. "8-18", into [from, to).
+func parseHourRange(s string) (int, int, error) {
+	from, to, ok := strings.Cut(s, "-")
+	f, err1 := strconv.Atoi(strings.TrimSpace(from))
+	t, err2 := strconv.Atoi(strings.TrimSpace(to))
+	if !ok || err1 != nil || err2 != nil || f < 0 || t > 24 || f >= t {
+		return 0, 0, common.NewUserError(
+			fmt.Sprintf("invalid --hours: %s", s),
+			"Use START-END in whole hours between 0 and 24, e.g. 8-18",
+		)
+	}
+	return f, t, nil
+}
+
+// connectedGrants returns the locally known grants, or nil.
+func connectedGrants() []domain.GrantInfo {
+	store, err := common.NewDefaultGrantStore()
+	if err != nil {
+		return nil
+	}
+	grants, _ := store.ListGrants()
+	return grants
+}
+
+// resolveFreeBusyTimezones picks each email's timezone: the --timezones
+// entry, else the primary calendar's timezone of a connected grant with that
+// email, else the viewer's timezone.
+func resolveFreeBusyTimezones(ctx context.Context, client ports.NylasClient, emails, provided []string, grants []domain.GrantInfo, viewer *time.Location, viewerTZ string) ([]participantTZ, error) {
+	grantByEmail := map[string]string{}
+	for _, g := range grants {
+		if g.Email != "" {
+			grantByEmail[strings.ToLower(g.Email)] = g.ID
+		}
+	}
+
+	out := make([]participantTZ, len(emails))
+	for i, email := range emails {
+		if i < len(provided) {
+			if tz := strings.TrimSpace(provided[i]); tz != "" && tz != "-" {
+				if err := validateTimeZone(tz); err != nil {
+					return nil, err
+				}
+				loc, _ := time.LoadLocation(tz)
+				out[i] = participantTZ{name: tz, loc: loc, source: tzSourceFlag}
+				continue
+			}
+		}
+		out[i] = participantTZ{name: viewerTZ, loc: viewer, source: tzSourceAssumed}
+		grantID, ok := grantByEmail[strings.ToLower(email)]
+		if !ok {
+			continue
+		}
+		if tz := primaryCalendarTimezone(ctx, client, grantID); tz != "" {
+			if loc, err := time.LoadLocation(tz); err == nil {
+				out[i] = participantTZ{name: tz, loc: loc, source: tzSourceCalendar}
+			}
+		}
+	}
+	return out, nil
+}
+
+// primaryCalendarTimezone returns the timezone of the grant's primary
+// calendar, or "" when it can't be read.
+func primaryCalendarTimezone(ctx context.Context, client ports.NylasClient, grantID string) string {
+	calendars, err := client.GetCalendars(ctx, grantID)
+	if err != nil {
+		return ""
+	}
+	for _, cal := range calendars {
+		if cal.IsPrimary {
+			return cal.Timezone
+		}
+	}
+	return ""
+}
+
+// buildFreeBusyGrid lays the free/busy response out by hour. Emails missing
+// from the response get a row with an error instead of cells.
+func buildFreeBusyGrid(resp *domain.FreeBusyResponse, emails []string, tzs []participantTZ, layout freeBusyLayout) freeBusyGrid {
+	grid := freeBusyGrid{Day: layout.dayStart.Format("2006-01-02"), Hours: gridHours(layout)}
+
+	byEmail := map[string]domain.FreeBusyCalendar{}
+	for _, cal := range resp.Data {
+		byEmail[strings.ToLower(cal.Email)] = cal
+	}
+
+	for i, email := range emails {
+		row := freeBusyRow{Email: email, Timezone: tzs[i].name, TimezoneSource: tzs[i].source, Busy: []freeBusySlot{}}
+		cal, ok := byEmail[strings.ToLower(email)]
+		if !ok {
+			row.Error = "no free/busy data returned"
+			grid.Participants = append(grid.Participants, row)
+			continue
+		}
+		row.Busy = mergeBusySlots(cal.TimeSlots)
+		for _, h := range grid.Hours {
+			minutes := busyMinutes(row.Busy, h, h.Add(time.Hour))
+			status := cellFree
+			switch {
+			case minutes >= 60:
+				status = cellBusy
+			case minutes > 0:
+				status = cellPartial
+			}
+			local := h.In(tzs[i].loc)
+			clock := local.Hour()*60 + local.Minute()
+			row.Cells = append(row.Cells, freeBusyCell{
+				Start:        h,
+				LocalTime:    local.Format("Mon 15:04"),
+				Status:       status,
+				BusyMinutes:  minutes,
+				WorkingHours: clock >= layout.workStart && clock < layout.workEnd,
+			})
+		}
+		grid.Participants = append(grid.Participants, row)
+	}
+	return grid
+}
+
+// gridHours returns the start of each shown hour. Stepping in absolute
+// time gives 23 or 25 hours on DST transition days.
+func gridHours(layout freeBusyLayout) []time.Time {
+	var hours []time.Time
+	for h := layout.dayStart; h.Before(layout.dayEnd); h = h.Add(time.Hour) {
+		if hour := h.In(layout.viewer).Hour(); hour >= layout.fromHour && hour < layout.toHour {
+			hours = append(hours, h)
+		}
+	}
+	return hours
+}
+
+// mergeBusySlots returns the busy slots sorted and with overlaps merged.
+func mergeBusySlots(slots []domain.TimeSlot) []freeBusySlot {
+	busy := make([]domain.TimeSlot, 0, len(slots))
+	for _, s := range slots {
+		if s.Status != "free" && s.EndTime > s.StartTime {
+			busy = append(busy, s)
+		}
+	}
+	sort.Slice(busy, func(i, j int) bool { return busy[i].StartTime < busy[j].StartTime })
+
+	merged := []freeBusySlot{}
+	for _, s := range busy {
+		start, end := time.Unix(s.StartTime, 0), time.Unix(s.EndTime, 0)
+		if n := len(merged); n > 0 && !start.After(merged[n-1].End) {
+			if end.After(merged[n-1].End) {
+				merged[n-1].End = end
+			}
+			continue
+		}
+		merged = append(merged, freeBusySlot{Start: start, End: end})
+	}
+	return merged
+}
+
+// busyMinutes returns how many minutes of [from, to) the merged slots cover.
+func busyMinutes(slots []freeBusySlot, from, to time.Time) int {
+	var total time.Duration
+	for _, s := range slots {
+		start, end := s.Start, s.End
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return int(total.Round(time.Minute).Minutes())
+}
+
+func renderFreeBusyGrid(w io.Writer, grid freeBusyGrid, layout freeBusyLayout) {
+	label := 0
+	for _, p := range grid.Participants {
+		label = max(label, len(common.Truncate(p.Email, 32)))
+	}
+	const zoneWidth = 12
+
+	_, _ = fmt.Fprintf(w, "Free/busy for %s (%s)\n\n", layout.dayStart.Format("Mon Jan 2, 2006"), grid.Timezone)
+	_, _ = fmt.Fprintf(w, "%-*s %-*s", label, "", zoneWidth, "")
+	for _, h := range grid.Hours {
+		_, _ = fmt.Fprintf(w, " %02d", h.In(layout.viewer).Hour())
+	}
+	_, _ = fmt.Fprintln(w)
+
+	var assumed []string
+	for _, p := range grid.Participants {
+		_, _ = fmt.Fprintf(w, "%-*s %-*s", label, common.Truncate(p.Email, 32), zoneWidth, zoneLabel(p, layout))
+		if p.Error != "" {
+			_, _ = fmt.Fprintf(w, " %s\n", common.Dim.Sprint(p.Error))
+			continue
+		}
+		for _, c := range p.Cells {
+			_, _ = fmt.Fprintf(w, " %s", cellGlyph(c))
+		}
+		_, _ = fmt.Fprintln(w)
+		if p.TimezoneSource == tzSourceAssumed {
+			assumed = append(assumed, p.Email)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%s busy  %s partly busy  %s free  %s outside their working hours\n",
+		common.Red.Sprint("██"), common.Yellow.Sprint("▓▓"), common.Green.Sprint("░░"), common.Dim.Sprint("··"))
+	if len(assumed) > 0 && len(grid.Participants) > 1 {
+		_, _ = fmt.Fprintln(w, common.Dim.Sprintf("Assumed %s for %s; set --timezones for their local hours.", grid.Timezone, strings.Join(assumed, ", ")))
+	}
+}
+
+// zoneLabel shows a participant's timezone abbreviation and its offset
+// from the grid's timezone, e.g. "JST +13h".
+func zoneLabel(p freeBusyRow, layout freeBusyLayout) string {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return ""
+	}
+	at := layout.dayStart.Add(12 * time.Hour)
+	abbr, theirs := at.In(loc).Zone()
+	_, ours := at.In(layout.viewer).Zone()
+	diff := time.Duration(theirs-ours) * time.Second
+	if diff == 0 {
+		return abbr
+	}
+	sign := "+"
+	if diff < 0 {
+		sign, diff = "-", -diff
+	}
+	offset := fmt.Sprintf("%s%dh", sign, int(diff.Hours()))
+	if m := int(diff.Minutes()) % 60; m != 0 {
+		offset = fmt.Sprintf("%s%dh%02d", sign, int(diff.Hours()), m)
+	}
+	return abbr + " " + offset
+}
+
+func cellGlyph(c freeBusyCell) string {
+	switch {
+	case c.Status == cellBusy:
+		return common.Red.Sprint("██")
+	case c.Status == cellPartial:
+		return common.Yellow.Sprint("▓▓")
+	case !c.WorkingHours:
+		return common.Dim.Sprint("··")
+	}
+	return common.Green.Sprint("░░")
+}
@@ -0,0 +1,142 @@
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParseFreeBusyDay(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, ny)
+
+	start, end, err := parseFreeBusyDay("tomorrow", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, ny), start)
+	assert.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, ny), end)
+
+	// The US DST change makes Nov 1 a 25-hour day.
+	start, end, err = parseFreeBusyDay("2026-11-01", now)
+	require.NoError(t, err)
+	assert.Equal(t, 25*time.Hour, end.Sub(start))
+
+	// Oct 18, 2026 is a Sunday.
+	start, _, err = parseFreeBusyDay("Wednesday", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 21, 0, 0, 0, 0, ny), start)
+	start, _, err = parseFreeBusyDay("sun", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, ny), start)
+
+	_, _, err = parseFreeBusyDay("someday", now)
+	assert.Error(t, err)
+}
+
+func TestParseHourRange(t *testing.T) {
+	from, to, err := parseHourRange("8-18")
+	require.NoError(t, err)
+	assert.Equal(t, []int{8, 18}, []int{from, to})
+
+	for _, bad := range []string{"18-8", "8", "0-25", "a-b", "-1-5"} {
+		_, _, err := parseHourRange(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestResolveFreeBusyTimezones(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetCalendarsFunc = func(_ context.Context, grantID string) ([]domain.Calendar, error) {
+		assert.Equal(t, "grant-bob", grantID)
+		return []domain.Calendar{{ID: "c1"}, {ID: "c2", IsPrimary: true, Timezone: "Asia/Tokyo"}}, nil
+	}
+	grants := []domain.GrantInfo{{ID: "grant-bob", Email: "Bob@example.com"}}
+	viewer := mustLoadLocation(t, "America/New_York")
+
+	tzs, err := resolveFreeBusyTimezones(context.Background(), client,
+		[]string{"alice@example.com", "bob@example.com", "carol@example.com"},
+		[]string{"Europe/Berlin", "-", ""}, grants, viewer, "America/New_York")
+	require.NoError(t, err)
+
+	assert.Equal(t, participantTZ{name: "Europe/Berlin", loc: mustLoadLocation(t, "Europe/Berlin"), source: tzSourceFlag}, tzs[0])
+	assert.Equal(t, "Asia/Tokyo", tzs[1].name)
+	assert.Equal(t, tzSourceCalendar, tzs[1].source)
+	assert.Equal(t, "America/New_York", tzs[2].name)
+	assert.Equal(t, tzSourceAssumed, tzs[2].source)
+
+	_, err = resolveFreeBusyTimezones(context.Background(), client, []string{"alice@example.com"}, []string{"Mars/Base"}, nil, viewer, "America/New_York")
+	assert.Error(t, err)
+}
+
+func TestBuildFreeBusyGrid(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	dayStart := time.Date(2026, 10, 19, 0, 0, 0, 0, ny)
+	at := func(h, m int) int64 {
+		return dayStart.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute).Unix()
+	}
+
+	resp := &domain.FreeBusyResponse{Data: []domain.FreeBusyCalendar{{
+		Email: "alice@example.com",
+		TimeSlots: []domain.TimeSlot{
+			{StartTime: at(9, 0), EndTime: at(10, 0), Status: "busy"},
+			{StartTime: at(9, 30), EndTime: at(10, 30), Status: "busy"}, // overlaps the first
+			{StartTime: at(11, 0), EndTime: at(12, 0), Status: "free"},
+		},
+	}}}
+	layout := freeBusyLayout{dayStart: dayStart, dayEnd: dayStart.AddDate(0, 0, 1), viewer: ny, fromHour: 8, toHour: 12, workStart: 9 * 60, workEnd: 17 * 60}
+	tzs := []participantTZ{
+		{name: "Asia/Tokyo", loc: tokyo, source: tzSourceFlag},
+		{name: "America/New_York", loc: ny, source: tzSourceAssumed},
+	}
+
+	grid := buildFreeBusyGrid(resp, []string{"alice@example.com", "bob@example.com"}, tzs, layout)
+
+	require.Len(t, grid.Hours, 4)
+	assert.Equal(t, "2026-10-19", grid.Day)
+	alice := grid.Participants[0]
+	require.Len(t, alice.Cells, 4)
+	assert.Equal(t, []string{cellFree, cellBusy, cellPartial, cellFree},
+		[]string{alice.Cells[0].Status, alice.Cells[1].Status, alice.Cells[2].Status, alice.Cells[3].Status})
+	assert.Equal(t, 30, alice.Cells[2].BusyMinutes)
+	assert.Equal(t, []freeBusySlot{{Start: time.Unix(at(9, 0), 0), End: time.Unix(at(10, 30), 0)}}, alice.Busy, "overlapping slots are merged")
+
+	// 8:00 in New York is 21:00 in Tokyo, outside Alice's working hours.
+	assert.Equal(t, "Mon 21:00", alice.Cells[0].LocalTime)
+	assert.False(t, alice.Cells[0].WorkingHours)
+
+	bob := grid.Participants[1]
+	assert.Equal(t, "no free/busy data returned", bob.Error)
+	assert.Empty(t, bob.Cells)
+
+	var out bytes.Buffer
+	grid.Timezone = "America/New_York"
+	renderFreeBusyGrid(&out, grid, layout)
+	assert.Contains(t, out.String(), "Free/busy for Mon Oct 19, 2026 (America/New_York)")
+	assert.Contains(t, out.String(), "JST +13h")
+	assert.Contains(t, out.String(), "no free/busy data returned")
+}
+
+func TestFreeBusyGridCmd_PointsOldAliasAtAvailability(t *testing.T) {
+	cmd := newFreeBusyGridCmd()
+	cmd.SetArgs([]string{"check"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nylas calendar availability check")
+}
@@ -2,9 +2,7 @@ package calendar
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -25,8 +23,8 @@ updating or deleting specific occurrences.
 API reference: https://developer.nylas.com/docs/v3/calendar/recurring-events/`,
 	}
 
-	cmd.AddCommand(newRecurringListCmd())
-	cmd.AddCommand(newRecurringUpdateCmd())
+	cmd.AddCommand(common.DeclareOutput(newRecurringListCmd(), []domain.Event{}))
+	cmd.AddCommand(common.DeclareOutput(newRecurringUpdateCmd(), domain.Event{}))
 	cmd.AddCommand(newRecurringDeleteCmd())
 
 	return cmd
@@ -100,8 +98,8 @@ The master event ID is the ID of the parent recurring event.`,
 					return struct{}{}, common.WrapFetchError("recurring event instances", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(os.Stdout).Encode(instances)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(instances)
 				}
 
 				if len(instances) == 0 {
@@ -210,8 +208,8 @@ This creates an exception for that particular instance.`,
 					return struct{}{}, common.WrapUpdateError("recurring event instance", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(os.Stdout).Encode(event)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(event)
 				}
 
 				fmt.Printf("✓ Updated recurring event instance\n")
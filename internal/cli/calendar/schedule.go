@@ -1,6 +1,8 @@
 package calendar
 
 import (
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -15,7 +17,7 @@ The AI will understand your request, analyze participant timezones,
 check availability, and suggest optimal meeting times.`,
 	}
 
-	cmd.AddCommand(newAIScheduleCmd())
+	cmd.AddCommand(common.RequireCapabilities(common.DeclareOutput(newAIScheduleCmd(), scheduleOutput{}), domain.CapabilityAI))
 
 	return cmd
 }
@@ -4,12 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
 )
 
@@ -24,9 +24,9 @@ Perfect for conference rooms, equipment, or external contractors.
 API reference: https://developer.nylas.com/docs/v3/calendar/virtual-calendars/`,
 	}
 
-	cmd.AddCommand(newVirtualListCmd())
-	cmd.AddCommand(newVirtualCreateCmd())
-	cmd.AddCommand(newVirtualShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newVirtualListCmd(), []domain.VirtualCalendarGrant{}))
+	cmd.AddCommand(common.DeclareOutput(newVirtualCreateCmd(), virtualCalendarResult{}, domain.VirtualCalendarGrant{}))
+	cmd.AddCommand(common.DeclareOutput(newVirtualShowCmd(), domain.VirtualCalendarGrant{}))
 	cmd.AddCommand(newVirtualDeleteCmd())
 
 	return cmd
@@ -50,8 +50,8 @@ func newVirtualListCmd() *cobra.Command {
 					return struct{}{}, common.WrapFetchError("virtual calendar grants", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(os.Stdout).Encode(grants)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(grants)
 				}
 
 				table := common.NewTable("ID", "EMAIL", "STATUS", "CREATED")
@@ -70,43 +70,98 @@ func newVirtualListCmd() *cobra.Command {
 	return cmd
 }
 
+// virtualCalendarOptions holds the flags of virtual create.
+type virtualCalendarOptions struct {
+	email        string
+	withCalendar string
+	timezone     string
+	description  string
+}
+
+// virtualCalendarResult is a new virtual calendar grant with its first
+// calendar, and the participant entry to use it in availability and
+// scheduler configurations.
+type virtualCalendarResult struct {
+	Grant       *domain.VirtualCalendarGrant     `json:"grant"`
+	Calendar    *domain.Calendar                 `json:"calendar"`
+	Participant *domain.ConfigurationParticipant `json:"participant"`
+}
+
+// QuietField returns the grant ID, which availability configurations use.
+func (r *virtualCalendarResult) QuietField() string {
+	return r.Grant.ID
+}
+
 // newVirtualCreateCmd creates the create virtual calendar command.
 func newVirtualCreateCmd() *cobra.Command {
-	var (
-		email string
-	)
+	var opts virtualCalendarOptions
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new virtual calendar grant",
 		Long: `Create a new virtual calendar account (grant).
-The email can be any identifier - it doesn't need to be a real email address.`,
+The email can be any identifier - it doesn't need to be a real email address.
+
+With --with-calendar, the grant's first calendar is created too, and the
+participant entry for availability and scheduler configurations is printed.`,
 		Example: `  # Create a virtual calendar for a conference room
   nylas calendar virtual create --email conference-room-a@company.com
 
-  # Create a virtual calendar for equipment
-  nylas calendar virtual create --email projector-1@company.com`,
+  # Create the grant and its calendar in one step
+  nylas calendar virtual create --email projector-1@company.com \
+    --with-calendar "Projector 1" --timezone America/New_York
+
+  # Just the grant ID, for scripts
+  nylas calendar virtual create --email room-b@company.com --with-calendar "Room B" -q`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if email == "" {
+			if opts.email == "" {
 				return common.NewUserError("email is required", "Use --email to specify an identifier for the virtual calendar")
 			}
+			if opts.withCalendar == "" && (opts.timezone != "" || opts.description != "") {
+				return common.NewUserError("--timezone and --description need --with-calendar",
+					"Name the calendar to create with --with-calendar")
+			}
+			if opts.timezone != "" {
+				if err := validateTimeZone(opts.timezone); err != nil {
+					return err
+				}
+			}
 
 			_, err := common.WithClientNoGrant(func(ctx context.Context, client ports.NylasClient) (struct{}, error) {
-				grant, err := client.CreateVirtualCalendarGrant(ctx, email)
+				result, err := createVirtualCalendar(ctx, client, opts)
 				if err != nil {
-					return struct{}{}, common.WrapCreateError("virtual calendar grant", err)
+					return struct{}{}, err
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(os.Stdout).Encode(grant)
+				if common.IsStructuredOutput(cmd) {
+					if result.Calendar == nil {
+						return struct{}{}, common.GetOutputWriter(cmd).Write(result.Grant)
+					}
+					return struct{}{}, common.GetOutputWriter(cmd).Write(result)
 				}
 
+				grant := result.Grant
 				fmt.Printf("✓ Created virtual calendar grant\n")
 				fmt.Printf("  ID:     %s\n", grant.ID)
 				fmt.Printf("  Email:  %s\n", grant.Email)
 				fmt.Printf("  Status: %s\n", grant.GrantStatus)
-				fmt.Printf("\nYou can now create calendars using this grant ID:\n")
-				fmt.Printf("  nylas calendar create %s --name \"My Calendar\"\n", grant.ID)
+
+				if result.Calendar == nil {
+					fmt.Printf("\nYou can now create calendars using this grant ID:\n")
+					fmt.Printf("  nylas calendar create %s --name \"My Calendar\"\n", grant.ID)
+					return struct{}{}, nil
+				}
+
+				fmt.Printf("\n✓ Created calendar\n")
+				fmt.Printf("  ID:     %s\n", result.Calendar.ID)
+				fmt.Printf("  Name:   %s\n", result.Calendar.Name)
+				if result.Calendar.Timezone != "" {
+					fmt.Printf("  Zone:   %s\n", result.Calendar.Timezone)
+				}
+				participant, _ := json.MarshalIndent(result.Participant, "  ", "  ")
+				fmt.Printf("\nParticipant for availability and scheduler configurations:\n  %s\n", participant)
+				fmt.Printf("\nCreate events on it with:\n")
+				fmt.Printf("  nylas calendar events create %s --calendar %s --title \"Booked\" --start \"tomorrow 10am\"\n", grant.ID, result.Calendar.ID)
 
 				return struct{}{}, nil
 			})
@@ -114,12 +169,50 @@ The email can be any identifier - it doesn't need to be a real email address.`,
 		},
 	}
 
-	cmd.Flags().StringVar(&email, "email", "", "Email identifier for the virtual calendar (required)")
+	cmd.Flags().StringVar(&opts.email, "email", "", "Email identifier for the virtual calendar (required)")
+	cmd.Flags().StringVar(&opts.withCalendar, "with-calendar", "", "Also create the grant's first calendar with this name")
+	cmd.Flags().StringVar(&opts.timezone, "timezone", "", "IANA timezone of the calendar created with --with-calendar")
+	cmd.Flags().StringVar(&opts.description, "description", "", "Description of the calendar created with --with-calendar")
 	_ = cmd.MarkFlagRequired("email") // Hardcoded flag name, won't fail
 
 	return cmd
 }
 
+// createVirtualCalendar creates the grant and, with --with-calendar, its
+// first calendar. The grant is kept when the calendar can't be created; the
+// error says how to finish or undo the setup.
+func createVirtualCalendar(ctx context.Context, client ports.NylasClient, opts virtualCalendarOptions) (*virtualCalendarResult, error) {
+	grant, err := client.CreateVirtualCalendarGrant(ctx, opts.email)
+	if err != nil {
+		return nil, common.WrapCreateError("virtual calendar grant", err)
+	}
+	result := &virtualCalendarResult{Grant: grant}
+	if opts.withCalendar == "" {
+		return result, nil
+	}
+
+	cal, err := client.CreateCalendar(ctx, grant.ID, &domain.CreateCalendarRequest{
+		Name:        opts.withCalendar,
+		Description: opts.description,
+		Timezone:    opts.timezone,
+	})
+	if err != nil {
+		return nil, common.NewUserErrorWithSuggestions(
+			fmt.Sprintf("created virtual calendar grant %s, but not its calendar: %v", grant.ID, err),
+			fmt.Sprintf("Create the calendar with: nylas calendar create %s --name %q", grant.ID, opts.withCalendar),
+			fmt.Sprintf("Or remove the grant with: nylas calendar virtual delete %s", grant.ID),
+		)
+	}
+	result.Calendar = cal
+	result.Participant = &domain.ConfigurationParticipant{
+		Email:        grant.Email,
+		Name:         cal.Name,
+		Availability: domain.ConfigurationAvailability{CalendarIDs: []string{cal.ID}},
+		Booking:      &domain.ParticipantBooking{CalendarID: cal.ID},
+	}
+	return result, nil
+}
+
 // newVirtualShowCmd creates the show virtual calendar command.
 func newVirtualShowCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -141,8 +234,8 @@ func newVirtualShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("virtual calendar grant", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(os.Stdout).Encode(grant)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(grant)
 				}
 
 				fmt.Printf("Virtual Calendar Grant\n")
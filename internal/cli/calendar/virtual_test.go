@@ -0,0 +1,70 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// virtualTestClient records the calendar created on the new grant.
+type virtualTestClient struct {
+	*nylas.MockClient
+	calendarErr error
+	grantID     string
+	created     *domain.CreateCalendarRequest
+}
+
+func (c *virtualTestClient) CreateCalendar(_ context.Context, grantID string, req *domain.CreateCalendarRequest) (*domain.Calendar, error) {
+	c.grantID, c.created = grantID, req
+	if c.calendarErr != nil {
+		return nil, c.calendarErr
+	}
+	return &domain.Calendar{ID: "vcal-1", Name: req.Name, Timezone: req.Timezone}, nil
+}
+
+func TestCreateVirtualCalendar(t *testing.T) {
+	newClient := func(calendarErr error) *virtualTestClient {
+		return &virtualTestClient{MockClient: nylas.NewMockClient(), calendarErr: calendarErr}
+	}
+
+	t.Run("grant only", func(t *testing.T) {
+		client := newClient(nil)
+		result, err := createVirtualCalendar(context.Background(), client, virtualCalendarOptions{email: "room-a@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "vcal-grant-1", result.Grant.ID)
+		assert.Nil(t, result.Calendar)
+		assert.Nil(t, client.created, "no calendar is created")
+	})
+
+	t.Run("with calendar", func(t *testing.T) {
+		client := newClient(nil)
+		result, err := createVirtualCalendar(context.Background(), client, virtualCalendarOptions{
+			email: "room-a@example.com", withCalendar: "Room A", timezone: "Europe/Berlin", description: "3rd floor",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "vcal-grant-1", client.grantID)
+		assert.Equal(t, domain.CreateCalendarRequest{Name: "Room A", Timezone: "Europe/Berlin", Description: "3rd floor"}, *client.created)
+		assert.Equal(t, "vcal-1", result.Calendar.ID)
+		assert.Equal(t, "vcal-grant-1", result.QuietField())
+		assert.Equal(t, &domain.ConfigurationParticipant{
+			Email:        "room-a@example.com",
+			Name:         "Room A",
+			Availability: domain.ConfigurationAvailability{CalendarIDs: []string{"vcal-1"}},
+			Booking:      &domain.ParticipantBooking{CalendarID: "vcal-1"},
+		}, result.Participant)
+	})
+
+	t.Run("calendar failure keeps the grant and says so", func(t *testing.T) {
+		client := newClient(errors.New("limit reached"))
+		_, err := createVirtualCalendar(context.Background(), client, virtualCalendarOptions{email: "room-a@example.com", withCalendar: "Room A"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "vcal-grant-1")
+		assert.Contains(t, err.Error(), "limit reached")
+	})
+}
@@ -0,0 +1,30 @@
+// Package changes provides the change feed command.
+package changes
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// NewChangesCmd creates the changes command.
+func NewChangesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "changes",
+		Short: "Follow changes to a mailbox as an NDJSON stream",
+		Long: `Follow changes to messages, events and contacts as a single ordered stream.
+
+Each change is one JSON line with a cursor. Save the cursor of the last line
+you processed and pass it back with --cursor to resume where you left off.
+
+Examples:
+  nylas changes tail --types messages,events
+  nylas changes tail --cursor "$(cat last-cursor)"`,
+	}
+
+	cmd.AddCommand(common.RunLocally(common.RequireCapabilities(common.RequireScopes(newTailCmd(),
+		domain.ScopeEmailRead, domain.ScopeCalendarRead, domain.ScopeContactsRead), domain.CapabilityNetwork)))
+
+	return cmd
+}
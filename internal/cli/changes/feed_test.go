@@ -0,0 +1,100 @@
+package changes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, data []byte) []change {
+	t.Helper()
+	var out []change
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var c change
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &c))
+		out = append(out, c)
+	}
+	return out
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	c := feedCursor{Seq: 7, Marks: map[string]int64{typeMessages: 100, typeEvents: 200}}
+
+	decoded, err := decodeCursor(c.encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+
+	_, err = decodeCursor("garbage")
+	assert.Error(t, err)
+	_, err = decodeCursor(cursorVersion + "!!")
+	assert.Error(t, err)
+}
+
+func TestFeedEmit(t *testing.T) {
+	var out bytes.Buffer
+	f := newFeed(&out, newCursor(allTypes, time.Unix(1000, 0)))
+
+	polled := change{Type: typeEvents, Action: actionUpdated, ObjectID: "ev-1", Time: 1500, Source: sourcePoll, version: "1500"}
+	pushed := polled
+	pushed.Source = sourceWebhook
+	later := change{Type: typeEvents, Action: actionUpdated, ObjectID: "ev-1", Time: 1600, Source: sourceWebhook, version: "1600"}
+
+	require.NoError(t, f.emit(polled))
+	require.NoError(t, f.emit(pushed))
+	require.NoError(t, f.emit(later))
+
+	lines := decodeLines(t, out.Bytes())
+	require.Len(t, lines, 2, "the webhook copy of a polled change is dropped")
+	assert.Equal(t, []int64{1, 2}, []int64{lines[0].Seq, lines[1].Seq})
+	assert.Equal(t, int64(1500), f.mark(typeEvents), "webhook changes don't advance the poll mark")
+
+	resumed, err := decodeCursor(lines[1].Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resumed.Seq)
+	assert.Equal(t, int64(1500), resumed.Marks[typeEvents])
+	assert.Equal(t, int64(1000), resumed.Marks[typeMessages])
+}
+
+func TestWebhookChange(t *testing.T) {
+	event := func(trigger string, object map[string]any) *ports.WebhookEvent {
+		return &ports.WebhookEvent{ID: "wh-1", Type: trigger, Timestamp: time.Unix(900, 0), Body: map[string]any{"data": map[string]any{"object": object}}}
+	}
+	types := []string{typeMessages, typeEvents}
+
+	t.Run("message created matches the polled version", func(t *testing.T) {
+		c, ok := webhookChange(event("message.created", map[string]any{"id": "m1", "grant_id": "g1", "date": float64(1200)}), "g1", types)
+		require.True(t, ok)
+		assert.Equal(t, typeMessages, c.Type)
+		assert.Equal(t, actionCreated, c.Action)
+		assert.Equal(t, int64(1200), c.Time)
+		assert.Equal(t, "created:1200", c.version)
+		assert.Equal(t, sourceWebhook, c.Source)
+	})
+
+	t.Run("event deleted", func(t *testing.T) {
+		c, ok := webhookChange(event("event.deleted", map[string]any{"id": "e1", "grant_id": "g1"}), "g1", types)
+		require.True(t, ok)
+		assert.Equal(t, actionDeleted, c.version)
+		assert.Equal(t, int64(900), c.Time, "falls back to the notification time")
+	})
+
+	for name, ev := range map[string]*ports.WebhookEvent{
+		"other grant":     event("message.created", map[string]any{"id": "m1", "grant_id": "g2"}),
+		"type not tailed": event("contact.updated", map[string]any{"id": "c1", "grant_id": "g1"}),
+		"not a change":    event("message.opened", map[string]any{"id": "m1", "grant_id": "g1"}),
+		"missing object":  {Type: "message.created", GrantID: "g1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, ok := webhookChange(ev, "g1", types)
+			assert.False(t, ok)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package changes
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// changesClient is the part of the Nylas client the delta queries use.
+type changesClient interface {
+	GetMessagesWithCursor(ctx context.Context, grantID string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error)
+	GetEventsWithCursor(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error)
+	GetContactsWithCursor(ctx context.Context, grantID string, params *domain.ContactQueryParams) (*domain.ContactListResponse, error)
+}
+
+// createdWithin is how close an event's created_at and updated_at must be
+// for a polled event to count as newly created.
+const createdWithin = 2 * time.Second
+
+// poller runs the periodic delta queries. Messages are found by received
+// date, events by updated_at. Contacts have no server-side filter, so each
+// poll lists them all and keeps those updated since the mark.
+type poller struct {
+	client     changesClient
+	grantID    string
+	calendarID string
+}
+
+// fetch returns the changes to objectType at or after since, oldest first.
+// The mark is inclusive so nothing updated within the same second is lost;
+// the feed drops the repeats.
+func (p *poller) fetch(ctx context.Context, objectType string, since int64) ([]change, error) {
+	var changes []change
+	var err error
+	switch objectType {
+	case typeMessages:
+		changes, err = p.messages(ctx, since)
+	case typeEvents:
+		changes, err = p.events(ctx, since)
+	case typeContacts:
+		changes, err = p.contacts(ctx, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(changes, func(a, b change) int { return cmp.Compare(a.Time, b.Time) })
+	return changes, nil
+}
+
+func (p *poller) messages(ctx context.Context, since int64) ([]change, error) {
+	params := &domain.MessageQueryParams{Limit: common.MaxAPILimit, ReceivedAfter: since}
+	messages, err := common.FetchCursorPages(ctx, params.Limit, 0, func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+		params.PageToken = cursor
+		resp, err := p.client.GetMessagesWithCursor(ctx, p.grantID, params)
+		if err != nil {
+			return common.PageResult[domain.Message]{}, err
+		}
+		return common.PageResult[domain.Message]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]change, 0, len(messages))
+	for _, msg := range messages {
+		ts := msg.Date.Unix()
+		if ts < since {
+			continue
+		}
+		c, err := p.polled(typeMessages, actionCreated, msg.ID, ts, msg)
+		if err != nil {
+			return nil, err
+		}
+		c.version = actionCreated + ":" + strconv.FormatInt(ts, 10)
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+func (p *poller) events(ctx context.Context, since int64) ([]change, error) {
+	params := &domain.EventQueryParams{Limit: common.MaxAPILimit, CalendarID: p.calendarID, UpdatedAfter: since, ShowCancelled: true}
+	events, err := common.FetchCursorPages(ctx, params.Limit, 0, func(ctx context.Context, cursor string) (common.PageResult[domain.Event], error) {
+		params.PageToken = cursor
+		resp, err := p.client.GetEventsWithCursor(ctx, p.grantID, p.calendarID, params)
+		if err != nil {
+			return common.PageResult[domain.Event]{}, err
+		}
+		return common.PageResult[domain.Event]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]change, 0, len(events))
+	for _, event := range events {
+		ts := event.UpdatedAt.Unix()
+		if ts < since {
+			continue
+		}
+		action := actionUpdated
+		switch {
+		case event.Status == "cancelled":
+			action = actionDeleted
+		case event.UpdatedAt.Sub(event.CreatedAt) < createdWithin:
+			action = actionCreated
+		}
+		c, err := p.polled(typeEvents, action, event.ID, ts, event)
+		if err != nil {
+			return nil, err
+		}
+		c.version = objectVersion(action, ts)
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+func (p *poller) contacts(ctx context.Context, since int64) ([]change, error) {
+	params := &domain.ContactQueryParams{Limit: common.MaxAPILimit}
+	contacts, err := common.FetchCursorPages(ctx, params.Limit, 0, func(ctx context.Context, cursor string) (common.PageResult[domain.Contact], error) {
+		params.PageToken = cursor
+		resp, err := p.client.GetContactsWithCursor(ctx, p.grantID, params)
+		if err != nil {
+			return common.PageResult[domain.Contact]{}, err
+		}
+		return common.PageResult[domain.Contact]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []change
+	for _, contact := range contacts {
+		if contact.UpdatedAt < since || contact.UpdatedAt == 0 {
+			continue
+		}
+		// Contacts carry no creation time, so every polled change is an update.
+		c, err := p.polled(typeContacts, actionUpdated, contact.ID, contact.UpdatedAt, contact)
+		if err != nil {
+			return nil, err
+		}
+		c.version = objectVersion(actionUpdated, contact.UpdatedAt)
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// polled builds a change from an API object, converting it to the webhook
+// wire format so polled and pushed objects look the same.
+func (p *poller) polled(objectType, action, id string, ts int64, object any) (change, error) {
+	data, err := domain.WebhookObject(strings.TrimSuffix(objectType, "s"), p.grantID, object)
+	if err != nil {
+		return change{}, err
+	}
+	return change{
+		Type:     objectType,
+		Action:   action,
+		ObjectID: id,
+		GrantID:  p.grantID,
+		Time:     ts,
+		Source:   sourcePoll,
+		Object:   data,
+	}, nil
+}
+
+// objectVersion identifies a state of an event or contact: its updated_at,
+// or just "deleted" once it is gone.
+func objectVersion(action string, updatedAt int64) string {
+	if action == actionDeleted {
+		return actionDeleted
+	}
+	return strconv.FormatInt(updatedAt, 10)
+}
@@ -0,0 +1,293 @@
+package changes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/tunnel"
+	"github.com/nylas/cli/internal/adapters/webhookserver"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/ports"
+)
+
+type tailOptions struct {
+	grant         string
+	types         []string
+	calendarID    string
+	interval      time.Duration
+	since         string
+	cursor        string
+	once          bool
+	port          int
+	path          string
+	tunnelType    string
+	secret        string
+	allowUnsigned bool
+}
+
+func newTailCmd() *cobra.Command {
+	opts := tailOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Stream changes as NDJSON, combining delta queries and webhooks",
+		Long: `Stream changes to a grant's messages, events and contacts as NDJSON.
+
+Changes are found by delta queries run every --interval: messages by received
+date, events on --calendar by updated_at, and contacts by updated_at. With
+--port, a webhook receiver also runs and pushed notifications are merged into
+the same stream as they arrive. A change seen by both is written once.
+
+Each line has the fields:
+  seq        position in this stream
+  cursor     resume point; pass it to --cursor to continue after this line
+  type       messages, events or contacts
+  action     created, updated or deleted
+  object_id  ID of the changed object
+  grant_id   grant the object belongs to
+  time       when the object changed (Unix seconds)
+  source     poll or webhook
+  object     the object, in the webhook wire format
+
+Delivery is at-least-once: after resuming from a cursor, changes from the
+same second may be written again, so consumers should apply them idempotently.
+Delta queries only see new messages and can't tell a new contact from an
+updated one; run the webhook receiver to get message updates and contact
+creations and deletions.
+
+Without --cursor the stream starts now, or --since ago. Press Ctrl+C to stop.`,
+		Example: `  # Follow new messages and event changes
+  nylas changes tail --types messages,events
+
+  # Replay the last day, then keep following
+  nylas changes tail --grant me@example.com --since 24h
+
+  # Resume from the last processed line
+  nylas changes tail --cursor "$(tail -n1 changes.ndjson | jq -r .cursor)" >> changes.ndjson
+
+  # Catch up once and exit (e.g. from cron)
+  nylas changes tail --cursor "$CURSOR" --once
+
+  # Merge webhooks pushed through a cloudflared tunnel
+  nylas changes tail --port 3000 --tunnel cloudflared --secret <webhook-secret>`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			types, err := validateTailOptions(&opts)
+			if err != nil {
+				return err
+			}
+			cursor, err := startCursor(opts, types, time.Now())
+			if err != nil {
+				return err
+			}
+
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+			var grantArgs []string
+			if opts.grant != "" {
+				grantID, err := common.ResolveGrantIdentifier(opts.grant)
+				if err != nil {
+					return err
+				}
+				grantArgs = []string{grantID}
+			}
+			grantID, err := common.GetGrantID(grantArgs)
+			if err != nil {
+				return err
+			}
+			if common.AuditGrantHook != nil {
+				common.AuditGrantHook(grantID)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			f := newFeed(cmd.OutOrStdout(), cursor)
+			if opts.port > 0 {
+				server, err := startWebhookReceiver(ctx, opts, f, grantID, types)
+				if err != nil {
+					if errors.Is(err, context.Canceled) {
+						return nil
+					}
+					return common.WrapError(err)
+				}
+				defer func() { _ = server.Stop() }()
+			}
+
+			p := &poller{client: client, grantID: grantID, calendarID: opts.calendarID}
+			return runTail(ctx, f, p, types, opts.interval, opts.once)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.grant, "grant", "", "Grant ID or email (defaults to the default grant)")
+	cmd.Flags().StringSliceVar(&opts.types, "types", allTypes, "Object types to follow: "+strings.Join(allTypes, ", "))
+	cmd.Flags().StringVarP(&opts.calendarID, "calendar", "c", "primary", "Calendar to follow for events")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 30*time.Second, "Time between delta queries")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Start this far back (e.g. 1h, 7d) instead of now")
+	cmd.Flags().StringVar(&opts.cursor, "cursor", "", "Resume after the change that carried this cursor")
+	cmd.Flags().BoolVar(&opts.once, "once", false, "Run the delta queries once and exit")
+	cmd.Flags().IntVarP(&opts.port, "port", "p", 0, "Also receive webhooks on this port (0 to poll only)")
+	cmd.Flags().StringVar(&opts.path, "path", "/webhook", "Webhook endpoint path")
+	cmd.Flags().StringVarP(&opts.tunnelType, "tunnel", "t", "", "Tunnel provider for the webhook receiver (cloudflared)")
+	cmd.Flags().StringVarP(&opts.secret, "secret", "s", "", "Webhook secret for signature verification")
+	cmd.Flags().BoolVar(&opts.allowUnsigned, "allow-unsigned", false, "Allow unsigned webhook events when --tunnel is set (insecure)")
+
+	return cmd
+}
+
+// validateTailOptions checks the flags and returns the deduplicated types.
+func validateTailOptions(opts *tailOptions) ([]string, error) {
+	var types []string
+	for _, t := range opts.types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if err := common.ValidateOneOf("type", t, allTypes); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		return nil, common.NewUserError("--types is required", "Allowed values: "+strings.Join(allTypes, ", "))
+	}
+
+	if opts.interval < time.Second {
+		return nil, common.NewInputError("--interval must be at least 1s")
+	}
+	if opts.cursor != "" && opts.since != "" {
+		return nil, common.NewUserError("--cursor and --since cannot be combined", "The cursor already records where to resume")
+	}
+	if opts.once && opts.port > 0 {
+		return nil, common.NewUserError("--once cannot be combined with --port", "Webhooks only arrive while the stream is running")
+	}
+	if opts.port <= 0 && (opts.tunnelType != "" || opts.secret != "") {
+		return nil, common.NewUserError("--tunnel and --secret need --port", "Pass --port to run the webhook receiver")
+	}
+	if opts.tunnelType != "" && opts.secret == "" && !opts.allowUnsigned {
+		return nil, common.NewUserError(
+			"--secret is required when --tunnel is set",
+			"Pass --secret <value> to verify each event, or --allow-unsigned to accept unverified events (insecure)",
+		)
+	}
+	return types, nil
+}
+
+// startCursor decodes --cursor, or starts every type at now minus --since.
+// Types added since the cursor was issued start now.
+func startCursor(opts tailOptions, types []string, now time.Time) (feedCursor, error) {
+	if opts.cursor != "" {
+		cursor, err := decodeCursor(opts.cursor)
+		if err != nil {
+			return cursor, common.NewInputError(fmt.Sprintf("invalid --cursor: %v", err))
+		}
+		for _, t := range types {
+			if _, ok := cursor.Marks[t]; !ok {
+				cursor.Marks[t] = now.Unix()
+			}
+		}
+		return cursor, nil
+	}
+
+	start := now
+	if opts.since != "" {
+		since, err := common.ParseDuration(opts.since)
+		if err != nil || since <= 0 {
+			return feedCursor{}, common.NewInputError(fmt.Sprintf("invalid --since value %q (use e.g. 1h, 7d)", opts.since))
+		}
+		start = now.Add(-since)
+	}
+	return newCursor(types, start), nil
+}
+
+// runTail polls every interval until ctx is done, or once. A failed query
+// is reported and retried on the next tick without moving its mark.
+func runTail(ctx context.Context, f *feed, p *poller, types []string, interval time.Duration, once bool) error {
+	for {
+		for _, t := range types {
+			// Each request is bounded by the client's own timeout.
+			changes, err := p.fetch(ctx, t, f.mark(t))
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				if once {
+					return common.WrapFetchError(t, err)
+				}
+				common.PrintWarningStderr("%s delta query failed: %v", t, err)
+				continue
+			}
+			for _, c := range changes {
+				if err := f.emit(c); err != nil {
+					return err
+				}
+			}
+		}
+		if once {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// startWebhookReceiver runs a webhook server whose notifications for
+// grantID are written to the feed.
+func startWebhookReceiver(ctx context.Context, opts tailOptions, f *feed, grantID string, types []string) (*webhookserver.Server, error) {
+	config := ports.WebhookServerConfig{Port: opts.port, Path: opts.path, WebhookSecret: opts.secret, TunnelProvider: opts.tunnelType}
+	if opts.secret != "" {
+		config.MaxEventAge = 5 * time.Minute
+	}
+	server := webhookserver.NewServer(config)
+
+	if opts.tunnelType != "" {
+		switch strings.ToLower(opts.tunnelType) {
+		case "cloudflared", "cloudflare", "cf":
+			if !tunnel.IsCloudflaredInstalled() {
+				return nil, common.NewUserError("cloudflared is not installed", "Install it with: brew install cloudflared")
+			}
+			server.SetTunnel(tunnel.NewCloudflaredTunnel(webhookserver.LocalBaseURL(opts.port)))
+		default:
+			return nil, common.NewUserError(fmt.Sprintf("unsupported tunnel provider: %s", opts.tunnelType), "Supported providers: cloudflared")
+		}
+	}
+
+	server.OnEvent(func(ev *ports.WebhookEvent) {
+		c, ok := webhookChange(ev, grantID, types)
+		if !ok {
+			return
+		}
+		if err := f.emit(c); err != nil {
+			common.PrintWarningStderr("writing %s change: %v", ev.Type, err)
+		}
+	})
+
+	if err := server.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	// Drain the raw event stream; events are handled through OnEvent.
+	go func() {
+		for range server.Events() {
+		}
+	}()
+
+	if !common.IsQuiet() {
+		fmt.Fprintln(os.Stderr, common.Dim.Sprintf("Receiving webhooks at %s", server.GetPublicURL()))
+	}
+	return server, nil
+}
@@ -0,0 +1,194 @@
+package changes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChangesClient serves one page of each object type, applying the
+// received_after and updated_after filters.
+type fakeChangesClient struct {
+	messages  []domain.Message
+	events    []domain.Event
+	contacts  []domain.Contact
+	eventsErr error
+
+	messageParams []domain.MessageQueryParams
+	eventParams   []domain.EventQueryParams
+}
+
+func (c *fakeChangesClient) GetMessagesWithCursor(_ context.Context, _ string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+	c.messageParams = append(c.messageParams, *params)
+	resp := &domain.MessageListResponse{}
+	for _, m := range c.messages {
+		if m.Date.Unix() >= params.ReceivedAfter {
+			resp.Data = append(resp.Data, m)
+		}
+	}
+	return resp, nil
+}
+
+func (c *fakeChangesClient) GetEventsWithCursor(_ context.Context, _, _ string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
+	c.eventParams = append(c.eventParams, *params)
+	if c.eventsErr != nil {
+		return nil, c.eventsErr
+	}
+	resp := &domain.EventListResponse{}
+	for _, e := range c.events {
+		if e.UpdatedAt.Unix() >= params.UpdatedAfter {
+			resp.Data = append(resp.Data, e)
+		}
+	}
+	return resp, nil
+}
+
+func (c *fakeChangesClient) GetContactsWithCursor(context.Context, string, *domain.ContactQueryParams) (*domain.ContactListResponse, error) {
+	return &domain.ContactListResponse{Data: c.contacts}, nil
+}
+
+func TestPollerFetch(t *testing.T) {
+	client := &fakeChangesClient{
+		messages: []domain.Message{
+			{ID: "m-new", Date: time.Unix(2000, 0)},
+			{ID: "m-mid", Date: time.Unix(1500, 0)},
+		},
+		events: []domain.Event{
+			{ID: "e-new", CreatedAt: time.Unix(1800, 0), UpdatedAt: time.Unix(1800, 0)},
+			{ID: "e-edit", CreatedAt: time.Unix(100, 0), UpdatedAt: time.Unix(1700, 0)},
+			{ID: "e-gone", CreatedAt: time.Unix(100, 0), UpdatedAt: time.Unix(1600, 0), Status: "cancelled"},
+		},
+		contacts: []domain.Contact{
+			{ID: "c-old", UpdatedAt: 500},
+			{ID: "c-new", UpdatedAt: 1900},
+		},
+	}
+	p := &poller{client: client, grantID: "g1", calendarID: "primary"}
+
+	messages, err := p.fetch(context.Background(), typeMessages, 1000)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, []string{"m-mid", "m-new"}, []string{messages[0].ObjectID, messages[1].ObjectID}, "oldest first")
+	assert.Equal(t, int64(1000), client.messageParams[0].ReceivedAfter)
+	assert.Equal(t, int64(1500), messages[0].Object["date"], "objects use Unix timestamps")
+	assert.Equal(t, "message", messages[0].Object["object"])
+
+	events, err := p.fetch(context.Background(), typeEvents, 1000)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, []string{actionDeleted, actionUpdated, actionCreated}, []string{events[0].Action, events[1].Action, events[2].Action})
+	assert.True(t, client.eventParams[0].ShowCancelled)
+
+	contacts, err := p.fetch(context.Background(), typeContacts, 1000)
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	assert.Equal(t, "c-new", contacts[0].ObjectID)
+	assert.Equal(t, actionUpdated, contacts[0].Action)
+}
+
+func TestRunTailOnce(t *testing.T) {
+	client := &fakeChangesClient{
+		messages: []domain.Message{{ID: "m1", Date: time.Unix(2000, 0)}},
+		events:   []domain.Event{{ID: "e1", CreatedAt: time.Unix(2100, 0), UpdatedAt: time.Unix(2100, 0)}},
+	}
+	p := &poller{client: client, grantID: "g1", calendarID: "primary"}
+	types := []string{typeMessages, typeEvents}
+
+	var out bytes.Buffer
+	f := newFeed(&out, newCursor(types, time.Unix(1000, 0)))
+	require.NoError(t, runTail(context.Background(), f, p, types, time.Second, true))
+
+	lines := decodeLines(t, out.Bytes())
+	require.Len(t, lines, 2)
+	assert.Equal(t, "m1", lines[0].ObjectID)
+	assert.Equal(t, "e1", lines[1].ObjectID)
+
+	// Resuming from the last cursor writes the changes at the marks again
+	// (at-least-once) along with anything newer.
+	cursor, err := decodeCursor(lines[1].Cursor)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{typeMessages: 2000, typeEvents: 2100}, cursor.Marks)
+
+	client.messages = append([]domain.Message{{ID: "m2", Date: time.Unix(2500, 0)}}, client.messages...)
+	out.Reset()
+	f = newFeed(&out, cursor)
+	require.NoError(t, runTail(context.Background(), f, p, types, time.Second, true))
+	lines = decodeLines(t, out.Bytes())
+	require.Len(t, lines, 3)
+	assert.Equal(t, "m2", lines[1].ObjectID)
+	assert.Equal(t, int64(3), lines[0].Seq, "sequence continues from the cursor")
+}
+
+func TestRunTailOnce_QueryError(t *testing.T) {
+	client := &fakeChangesClient{eventsErr: errors.New("rate limited")}
+	p := &poller{client: client, grantID: "g1", calendarID: "primary"}
+
+	err := runTail(context.Background(), newFeed(&bytes.Buffer{}, newCursor(allTypes, time.Now())), p, allTypes, time.Second, true)
+
+	assert.ErrorContains(t, err, "rate limited")
+}
+
+func TestRunTail_StopsOnCancel(t *testing.T) {
+	client := &fakeChangesClient{eventsErr: errors.New("temporarily unavailable")}
+	p := &poller{client: client, grantID: "g1", calendarID: "primary"}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := runTail(ctx, newFeed(&bytes.Buffer{}, newCursor(allTypes, time.Now())), p, allTypes, time.Second, false)
+
+	assert.NoError(t, err, "failed queries are retried until the stream is stopped")
+}
+
+func TestValidateTailOptions(t *testing.T) {
+	base := func() tailOptions {
+		return tailOptions{types: []string{"Messages", "events", "messages"}, interval: 30 * time.Second}
+	}
+
+	opts := base()
+	types, err := validateTailOptions(&opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{typeMessages, typeEvents}, types)
+
+	tests := map[string]func(*tailOptions){
+		"unknown type":          func(o *tailOptions) { o.types = []string{"threads"} },
+		"no types":              func(o *tailOptions) { o.types = nil },
+		"short interval":        func(o *tailOptions) { o.interval = 100 * time.Millisecond },
+		"cursor and since":      func(o *tailOptions) { o.cursor, o.since = "c1.x", "1h" },
+		"once with receiver":    func(o *tailOptions) { o.once, o.port = true, 3000 },
+		"secret without port":   func(o *tailOptions) { o.secret = "s" },
+		"tunnel without secret": func(o *tailOptions) { o.port, o.tunnelType = 3000, "cloudflared" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := base()
+			mutate(&opts)
+			_, err := validateTailOptions(&opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStartCursor(t *testing.T) {
+	now := time.Unix(10000, 0)
+
+	c, err := startCursor(tailOptions{since: "1h"}, []string{typeMessages}, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000-3600), c.Marks[typeMessages])
+
+	prev := feedCursor{Seq: 3, Marks: map[string]int64{typeMessages: 500}}
+	c, err = startCursor(tailOptions{cursor: prev.encode()}, []string{typeMessages, typeContacts}, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), c.Seq)
+	assert.Equal(t, map[string]int64{typeMessages: 500, typeContacts: 10000}, c.Marks, "new types start now")
+
+	_, err = startCursor(tailOptions{cursor: "nope"}, allTypes, now)
+	assert.Error(t, err)
+	_, err = startCursor(tailOptions{since: "soon"}, allTypes, now)
+	assert.Error(t, err)
+}
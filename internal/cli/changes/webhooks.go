@@ -0,0 +1,79 @@
+package changes
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+// webhookChange converts a webhook notification to a change. ok is false
+// for notifications about other grants, other object types, or triggers
+// that don't describe an object change (e.g. message.opened).
+func webhookChange(event *ports.WebhookEvent, grantID string, types []string) (change, bool) {
+	prefix, action, _ := strings.Cut(event.Type, ".")
+	objectType := prefix + "s"
+	if !slices.Contains(types, objectType) {
+		return change{}, false
+	}
+	switch action {
+	case actionCreated, actionUpdated, actionDeleted:
+	default:
+		return change{}, false
+	}
+
+	data, _ := event.Body["data"].(map[string]any)
+	object, _ := data["object"].(map[string]any)
+	id, _ := object["id"].(string)
+	if id == "" {
+		return change{}, false
+	}
+	eventGrant := event.GrantID
+	if g, _ := object["grant_id"].(string); g != "" {
+		eventGrant = g
+	}
+	if eventGrant != grantID {
+		return change{}, false
+	}
+
+	c := change{
+		Type:     objectType,
+		Action:   action,
+		ObjectID: id,
+		GrantID:  grantID,
+		Time:     webhookTime(event, object),
+		Source:   sourceWebhook,
+		Object:   object,
+	}
+	switch {
+	case objectType == typeMessages && action == actionCreated:
+		c.version = actionCreated + ":" + strconv.FormatInt(unixField(object, "date"), 10)
+	case objectType == typeMessages:
+		// Polls never report message updates, so there is nothing to match;
+		// the notification ID keeps separate updates apart.
+		c.version = event.ID
+	default:
+		c.version = objectVersion(action, unixField(object, "updated_at"))
+	}
+	return c, true
+}
+
+// webhookTime is when the object changed: its updated_at or date, falling
+// back to the notification time.
+func webhookTime(event *ports.WebhookEvent, object map[string]any) int64 {
+	for _, field := range []string{"updated_at", "date"} {
+		if ts := unixField(object, field); ts > 0 {
+			return ts
+		}
+	}
+	if !event.Timestamp.IsZero() {
+		return event.Timestamp.Unix()
+	}
+	return event.ReceivedAt.Unix()
+}
+
+func unixField(object map[string]any, field string) int64 {
+	ts, _ := object[field].(float64)
+	return int64(ts)
+}
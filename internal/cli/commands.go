@@ -34,6 +34,7 @@ type commandSpec struct {
 	Hidden         bool              `json:"hidden,omitempty" yaml:"hidden,omitempty"`
 	Runnable       bool              `json:"runnable" yaml:"runnable"`
 	HasSubcommands bool              `json:"has_subcommands" yaml:"has_subcommands"`
+	OutputSchema   string            `json:"output_schema,omitempty" yaml:"output_schema,omitempty"`
 	Flags          []commandFlagSpec `json:"flags,omitempty" yaml:"flags,omitempty"`
 	InheritedFlags []commandFlagSpec `json:"inherited_flags,omitempty" yaml:"inherited_flags,omitempty"`
 	Subcommands    []commandSpec     `json:"subcommands,omitempty" yaml:"subcommands,omitempty"`
@@ -141,6 +142,7 @@ func buildCommandSpec(cmd *cobra.Command, includeHidden bool) *commandSpec {
 		Hidden:         cmd.Hidden,
 		Runnable:       cmd.Runnable(),
 		HasSubcommands: cmd.HasAvailableSubCommands(),
+		OutputSchema:   common.OutputSchemaID(cmd),
 	}
 
 	localFlags, localNames := collectCommandFlagSpecs(cmd, includeHidden)
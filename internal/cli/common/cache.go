@@ -0,0 +1,58 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/nylas/cli/internal/adapters/httpcache"
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+var noCache atomic.Bool
+
+// SetNoCache bypasses the response cache for this process (the global
+// --no-cache flag).
+func SetNoCache(disabled bool) {
+	noCache.Store(disabled)
+}
+
+// ResponseCacheDir returns the directory of the on-disk API response cache.
+func ResponseCacheDir() (string, error) {
+	root, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, "nylas", "responses"), nil
+}
+
+// NewResponseCache opens the on-disk API response cache.
+func NewResponseCache() (*httpcache.Store, error) {
+	dir, err := ResponseCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return httpcache.New(dir), nil
+}
+
+// applyResponseCache turns on response caching for c when the config
+// enables it and neither --no-cache nor --emit-code was given. Cached
+// responses would skip the requests --emit-code prints. Shell completion
+// always caches, so pressing Tab doesn't wait on the API.
+func applyResponseCache(c *nylas.HTTPClient, cfg *domain.Config) {
+	if completing.Load() {
+		if store, err := NewResponseCache(); err == nil {
+			c.SetResponseCache(store, cfg.Cache)
+			c.SetServeStale(true)
+			c.SetMaxRetries(0)
+		}
+		return
+	}
+	if noCache.Load() || emitCodeLanguage() != "" || !cfg.CacheEnabled() {
+		return
+	}
+	if store, err := NewResponseCache(); err == nil {
+		c.SetResponseCache(store, cfg.Cache)
+	}
+}
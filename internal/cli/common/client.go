@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/nylas/cli/internal/adapters/config"
 	"github.com/nylas/cli/internal/adapters/keyring"
@@ -22,8 +23,38 @@ var (
 
 	// AuditGrantHook is called when a grant ID is resolved (set by cli package).
 	AuditGrantHook func(grantID string)
+
+	// AuditDetailHook records a command-specific audit detail (set by cli package).
+	AuditDetailHook func(key, value string)
+
+	// Set by EnableDemoMode.
+	demoClient ports.NylasClient
+	demoGrant  string
 )
 
+// EnableDemoMode makes GetNylasClient return client, and GetGrantID fall
+// back to grantID instead of the stored default, for the rest of the
+// process. `nylas demo` uses it to run the real commands on sample data.
+func EnableDemoMode(client ports.NylasClient, grantID string) {
+	demoClient, demoGrant = client, grantID
+	ResetCachedClient()
+}
+
+// IsDemoMode reports whether commands run against the demo client. Code
+// that reaches outside the Nylas client, such as an SMTP relay, checks it.
+func IsDemoMode() bool {
+	return demoClient != nil
+}
+
+// RecordAuditDetail attaches a key/value detail to the current command's
+// audit entry, e.g. which transport delivered a message. It is a no-op when
+// auditing is not wired up.
+func RecordAuditDetail(key, value string) {
+	if AuditDetailHook != nil {
+		AuditDetailHook(key, value)
+	}
+}
+
 // GetNylasClient creates a Nylas API client with credentials from environment variables or keyring.
 // It checks credentials in this order:
 // 1. Environment variables (NYLAS_API_KEY, NYLAS_CLIENT_ID, NYLAS_CLIENT_SECRET) - highest priority
@@ -36,6 +67,10 @@ var (
 // - Integration tests (environment variables with NYLAS_DISABLE_KEYRING=true)
 // - Local development (keyring)
 func GetNylasClient() (ports.NylasClient, error) {
+	if demoClient != nil {
+		return demoClient, nil
+	}
+
 	// Load configuration
 	configStore := config.NewDefaultFileStore()
 	cfg, err := configStore.Load()
@@ -43,6 +78,14 @@ func GetNylasClient() (ports.NylasClient, error) {
 		cfg = &domain.Config{Region: "us"}
 	}
 
+	envName, err := applyEnvironment(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if envName != "" {
+		RecordAuditDetail("environment", envName)
+	}
+
 	// Propagate the install's API timeout to CreateContext (the per-command
 	// deadline) so it matches the client this function builds.
 	SetAPITimeout(cfg.ResolveAPITimeout())
@@ -52,6 +95,14 @@ func GetNylasClient() (ports.NylasClient, error) {
 	clientID := os.Getenv("NYLAS_CLIENT_ID")
 	clientSecret := os.Getenv("NYLAS_CLIENT_SECRET")
 
+	// An environment profile supplies its own API key
+	if apiKey == "" && envName != "" {
+		apiKey, err = environmentAPIKey(envName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// If API key not in env, try keyring/file store
 	if apiKey == "" {
 		secretStore, err := openSecretStore()
@@ -79,15 +130,32 @@ func GetNylasClient() (ports.NylasClient, error) {
 
 	// Validate that we have at least the API key
 	if apiKey == "" {
-		return nil, NewUserErrorWithSuggestions(
-			"API key not configured",
-			"Configure with: nylas auth config",
-			"Or use environment variable: export NYLAS_API_KEY=<your-key>",
-			"Get your API key from: https://dashboard-v3.nylas.com",
-		)
+		return nil, &CLIError{
+			Message: "API key not configured",
+			Suggestions: []string{
+				"Configure with: nylas auth config",
+				"Or use environment variable: export NYLAS_API_KEY=<your-key>",
+				"Get your API key from: https://dashboard-v3.nylas.com",
+			},
+			Code: ErrCodeNotConfigured,
+		}
+	}
+
+	return newConfiguredClient(cfg, clientID, clientSecret, apiKey), nil
+}
+
+// NewNylasClientWithAPIKey returns a client configured like GetNylasClient
+// but authenticated with apiKey, e.g. to check a new key before storing it.
+func NewNylasClientWithAPIKey(apiKey string) ports.NylasClient {
+	cfg, err := config.NewDefaultFileStore().Load()
+	if err != nil {
+		cfg = &domain.Config{Region: "us"}
 	}
+	_, _ = applyEnvironment(cfg)
+	return newConfiguredClient(cfg, os.Getenv("NYLAS_CLIENT_ID"), "", apiKey)
+}
 
-	// Create and configure the HTTP client
+func newConfiguredClient(cfg *domain.Config, clientID, clientSecret, apiKey string) *nylas.HTTPClient {
 	c := nylas.NewHTTPClient()
 
 	c.ApplyConfig(cfg)
@@ -97,8 +165,10 @@ func GetNylasClient() (ports.NylasClient, error) {
 	}
 
 	c.SetCredentials(clientID, clientSecret, apiKey)
+	applyResponseCache(c, cfg)
+	applyCodeEmitter(c)
 
-	return c, nil
+	return c
 }
 
 // GetCachedNylasClient returns a singleton Nylas client.
@@ -130,26 +200,41 @@ func ResetCachedClient() {
 func GetAPIKey() (string, error) {
 	// First check environment variable (highest priority)
 	apiKey := os.Getenv("NYLAS_API_KEY")
+	if apiKey != "" {
+		return apiKey, nil
+	}
 
-	// If not in env, try keyring/file store
-	if apiKey == "" {
-		secretStore, err := openSecretStore()
+	// An environment profile supplies its own API key
+	if cfg, err := config.NewDefaultFileStore().Load(); err == nil {
+		envName, err := applyEnvironment(cfg)
 		if err != nil {
 			return "", err
 		}
-		apiKey, err = getStoredSecret(secretStore, ports.KeyAPIKey)
-		if err != nil {
-			return "", err
+		if envName != "" {
+			return environmentAPIKey(envName)
 		}
 	}
 
+	// Otherwise try keyring/file store
+	secretStore, err := openSecretStore()
+	if err != nil {
+		return "", err
+	}
+	apiKey, err = getStoredSecret(secretStore, ports.KeyAPIKey)
+	if err != nil {
+		return "", err
+	}
+
 	if apiKey == "" {
-		return "", NewUserErrorWithSuggestions(
-			"API key not configured",
-			"Configure with: nylas auth config",
-			"Or use environment variable: export NYLAS_API_KEY=<your-key>",
-			"Get your API key from: https://dashboard-v3.nylas.com",
-		)
+		return "", &CLIError{
+			Message: "API key not configured",
+			Suggestions: []string{
+				"Configure with: nylas auth config",
+				"Or use environment variable: export NYLAS_API_KEY=<your-key>",
+				"Get your API key from: https://dashboard-v3.nylas.com",
+			},
+			Code: ErrCodeNotConfigured,
+		}
 	}
 
 	return apiKey, nil
@@ -159,7 +244,8 @@ func GetAPIKey() (string, error) {
 // It checks in this order:
 // 1. Command line argument (if provided) - supports email lookup if arg contains "@"
 // 2. Environment variable (NYLAS_GRANT_ID)
-// 3. Stored default grant (from local grant cache)
+// 3. Default grant of the active environment profile
+// 4. Stored default grant (from local grant cache)
 func GetGrantID(args []string) (string, error) {
 	// If provided as argument
 	if len(args) > 0 && args[0] != "" {
@@ -171,11 +257,22 @@ func GetGrantID(args []string) (string, error) {
 		}
 	}
 
+	if demoGrant != "" {
+		return demoGrant, nil
+	}
+
 	// Check environment variable
 	if grantID := os.Getenv("NYLAS_GRANT_ID"); grantID != "" {
 		return grantID, nil
 	}
 
+	// The active environment profile's default grant
+	if len(args) == 0 || args[0] == "" {
+		if grantID := environmentDefaultGrant(); grantID != "" {
+			return grantID, nil
+		}
+	}
+
 	grantStore, err := NewDefaultGrantStore()
 	if err != nil {
 		return "", err
@@ -203,13 +300,16 @@ func GetGrantID(args []string) (string, error) {
 		return "", err
 	}
 
-	return "", NewUserErrorWithSuggestions(
-		"No grant ID provided. Run 'nylas auth list' to find a grant, then 'nylas auth switch <grant-id-or-email>' to set the default.",
-		"List available grants with: nylas auth list",
-		"Set a default grant with: nylas auth switch <grant-id-or-email>",
-		"Use environment variable: export NYLAS_GRANT_ID=<grant-id>",
-		"Or specify as argument: nylas [command] <grant-id>",
-	)
+	return "", &CLIError{
+		Message: "No grant ID provided. Run 'nylas auth list' to find a grant, then 'nylas auth switch <grant-id-or-email>' to set the default.",
+		Suggestions: []string{
+			"List available grants with: nylas auth list",
+			"Set a default grant with: nylas auth switch <grant-id-or-email>",
+			"Use environment variable: export NYLAS_GRANT_ID=<grant-id>",
+			"Or specify as argument: nylas [command] <grant-id>",
+		},
+		Code: ErrCodeNotConfigured,
+	}
 }
 
 // containsAt checks if a string contains "@" (for email detection).
@@ -217,11 +317,41 @@ func containsAt(s string) bool {
 	return strings.ContainsRune(s, '@')
 }
 
+var (
+	secretCacheMu  sync.Mutex
+	secretCacheTTL time.Duration
+	secretStores   map[string]ports.SecretStore
+)
+
+// EnableSecretCache keeps the secret store open and its secrets in memory
+// for ttl for the rest of the process. `nylas daemon` uses it so commands
+// don't reach the keychain every time.
+func EnableSecretCache(ttl time.Duration) {
+	secretCacheMu.Lock()
+	defer secretCacheMu.Unlock()
+	secretCacheTTL = ttl
+	secretStores = make(map[string]ports.SecretStore)
+}
+
 func openSecretStore() (ports.SecretStore, error) {
+	secretCacheMu.Lock()
+	defer secretCacheMu.Unlock()
+
+	// Which backend NewSecretStore picks depends on the environment, which
+	// the daemon switches for every command.
+	cacheKey := config.DefaultConfigDir() + "\x00" + os.Getenv("NYLAS_DISABLE_KEYRING") + "\x00" + os.Getenv("NYLAS_FILE_STORE_PASSPHRASE")
+	if store, ok := secretStores[cacheKey]; ok {
+		return store, nil
+	}
+
 	secretStore, err := keyring.NewSecretStore(config.DefaultConfigDir())
 	if err != nil {
 		return nil, wrapSecretStoreError(err)
 	}
+	if secretCacheTTL > 0 {
+		secretStore = keyring.NewCachedStore(secretStore, secretCacheTTL)
+		secretStores[cacheKey] = secretStore
+	}
 	return secretStore, nil
 }
 
@@ -301,7 +431,7 @@ func grantFromDefault(args []string) bool {
 	if len(args) > 0 && args[0] != "" {
 		return false
 	}
-	return os.Getenv("NYLAS_GRANT_ID") == ""
+	return os.Getenv("NYLAS_GRANT_ID") == "" && environmentDefaultGrant() == ""
 }
 
 // validateDefaultGrant confirms the resolved default grant still exists. If it
@@ -318,12 +448,15 @@ func validateDefaultGrant(ctx context.Context, client ports.NylasClient, grantID
 		_ = store.SetDefaultGrant("")
 	}
 
-	return NewUserErrorWithSuggestions(
-		fmt.Sprintf("The default grant (%s) is no longer available — it may have been removed or re-authenticated.", grantID),
-		"List current accounts with: nylas auth list",
-		"Select an active account with: nylas auth switch <grant-id-or-email>",
-		"Or specify one directly: nylas [command] <grant-id>",
-	)
+	return &CLIError{
+		Message: fmt.Sprintf("The default grant (%s) is no longer available — it may have been removed or re-authenticated.", grantID),
+		Suggestions: []string{
+			"List current accounts with: nylas auth list",
+			"Select an active account with: nylas auth switch <grant-id-or-email>",
+			"Or specify one directly: nylas [command] <grant-id>",
+		},
+		Code: ErrCodeNotFound,
+	}
 }
 
 // WithClientNoGrant is a generic helper for commands that don't need a grant ID.
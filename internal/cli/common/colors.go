@@ -1,8 +1,11 @@
 package common
 
 import (
+	"os"
+
 	"charm.land/lipgloss/v2"
 	"github.com/fatih/color"
+	"golang.org/x/term"
 )
 
 // Common color definitions used across CLI commands.
@@ -37,3 +40,22 @@ var (
 	// Use for ASCII art, banners, and branded elements.
 	Brand = lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
 )
+
+// DisableColor turns off ANSI colors for all output.
+func DisableColor() {
+	color.NoColor = true
+}
+
+// UseColorOutput sends color output to stdout and stderr, with colors on
+// when stdout is a terminal and NO_COLOR is unset, as decided at startup.
+// It returns a function that restores the previous settings. `nylas
+// daemon` uses it to run commands with a client's files.
+func UseColorOutput(stdout, stderr *os.File) (restore func()) {
+	noColor, output, errOutput := color.NoColor, color.Output, color.Error
+	color.Output, color.Error = stdout, stderr
+	color.NoColor = os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" ||
+		!term.IsTerminal(int(stdout.Fd())) // #nosec G115 -- file descriptors fit in int
+	return func() {
+		color.NoColor, color.Output, color.Error = noColor, output, errOutput
+	}
+}
@@ -184,6 +184,65 @@ func TestSpinner_StopWithMessage(t *testing.T) {
 	assert.Contains(t, buf.String(), "Done!")
 }
 
+func TestProgressBar_LogMode(t *testing.T) {
+	SetQuiet(false)
+
+	clock := time.Unix(0, 0)
+	var buf bytes.Buffer
+	progress := NewProgressBar("Importing", 100).SetWriter(&buf)
+	progress.now = func() time.Time { return clock }
+	progress.start, progress.lastDraw = clock, clock
+
+	progress.Add(10)
+	assert.Empty(t, buf.String(), "logs wait for the interval")
+
+	clock = clock.Add(progressLogInterval)
+	progress.Add(15)
+	assert.Equal(t, "Importing: 25/100 (25%), 2.5/s, ETA 30s\n", buf.String())
+
+	buf.Reset()
+	clock = clock.Add(30 * time.Second)
+	progress.Set(100)
+	assert.Empty(t, buf.String(), "the final line comes from Finish")
+	progress.Finish()
+	assert.Equal(t, "Importing: 100/100 (100%), 2.5/s, in 40s\n", buf.String())
+}
+
+func TestProgressBar_UnknownTotal(t *testing.T) {
+	SetQuiet(false)
+
+	clock := time.Unix(0, 0)
+	var buf bytes.Buffer
+	progress := NewProgressBar("Fetching items", 0).SetWriter(&buf)
+	progress.now = func() time.Time { return clock }
+	progress.start, progress.lastDraw = clock, clock
+
+	clock = clock.Add(4 * time.Second)
+	progress.Add(200)
+	progress.Finish()
+	assert.Equal(t, "Fetching items: 200, 50/s, in 4s\n", buf.String())
+}
+
+func TestProgressBar_Quiet(t *testing.T) {
+	SetQuiet(true)
+	defer SetQuiet(false)
+
+	var buf bytes.Buffer
+	progress := NewProgressBar("Importing", 2).SetWriter(&buf)
+	progress.Increment()
+	progress.Increment()
+	progress.Finish()
+	assert.Empty(t, buf.String())
+}
+
+func TestProgressBar_NothingDone(t *testing.T) {
+	SetQuiet(false)
+
+	var buf bytes.Buffer
+	NewProgressBar("Importing", 5).SetWriter(&buf).Finish()
+	assert.Empty(t, buf.String())
+}
+
 // =============================================================================
 // Format Tests
 // =============================================================================
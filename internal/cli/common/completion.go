@@ -0,0 +1,125 @@
+package common
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// completionTimeout bounds the API calls behind one shell completion.
+// Past it, cached candidates are used however old they are.
+const completionTimeout = 2 * time.Second
+
+// completing switches clients to completion mode: responses are always
+// cached, stale entries are used when the API is slow, and nothing is
+// retried.
+var completing atomic.Bool
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// flagCompletions completes flags by name wherever they are defined.
+var flagCompletions = map[string]completionFunc{
+	"grant":    completeGrants,
+	"calendar": completeCalendars,
+	"folder":   completeFolders,
+}
+
+// RegisterDynamicCompletions adds completion of grants, calendars and
+// folders to every --grant, --calendar and --folder flag under root.
+func RegisterDynamicCompletions(root *cobra.Command) {
+	for name, fn := range flagCompletions {
+		if root.Flags().Lookup(name) != nil || root.PersistentFlags().Lookup(name) != nil {
+			// Already registered completions are kept.
+			_ = root.RegisterFlagCompletionFunc(name, fn)
+		}
+	}
+	for _, sub := range root.Commands() {
+		RegisterDynamicCompletions(sub)
+	}
+}
+
+// completionClient returns a client in completion mode and the grant to
+// complete for: --grant when given, else the default grant.
+func completionClient(cmd *cobra.Command) (context.Context, context.CancelFunc, ports.NylasClient, string, bool) {
+	grant, _ := cmd.Flags().GetString("grant")
+	grantID, err := GetGrantID([]string{grant})
+	if err != nil || grantID == "" {
+		return nil, nil, nil, "", false
+	}
+	completing.Store(true)
+	client, err := GetNylasClient()
+	if err != nil {
+		return nil, nil, nil, "", false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	return ctx, cancel, client, grantID, true
+}
+
+func completeGrants(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	store, err := NewDefaultGrantStore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	grants, err := store.ListGrants()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return grantCandidates(grants, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// grantCandidates offers grant IDs, or emails when toComplete starts like
+// one of them.
+func grantCandidates(grants []domain.GrantInfo, toComplete string) []string {
+	var out []string
+	prefix := strings.ToLower(toComplete)
+	for _, g := range grants {
+		if g.Email != "" && prefix != "" && strings.HasPrefix(strings.ToLower(g.Email), prefix) {
+			out = append(out, g.Email+"\t"+g.ID)
+			continue
+		}
+		if strings.HasPrefix(g.ID, toComplete) {
+			out = append(out, g.ID+"\t"+g.Email)
+		}
+	}
+	return out
+}
+
+func completeCalendars(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	ctx, cancel, client, grantID, ok := completionClient(cmd)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer cancel()
+	calendars, err := client.GetCalendars(ctx, grantID)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	out := []string{"primary\tPrimary calendar"}
+	for _, c := range calendars {
+		out = append(out, c.ID+"\t"+c.Name)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeFolders(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	ctx, cancel, client, grantID, ok := completionClient(cmd)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	defer cancel()
+	folders, err := client.GetFolders(ctx, grantID)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	out := make([]string, 0, len(folders))
+	for _, f := range folders {
+		out = append(out, f.ID+"\t"+f.Name)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,110 @@
+package common
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestGrantCandidates(t *testing.T) {
+	grants := []domain.GrantInfo{
+		{ID: "grant-1", Email: "alice@example.com"},
+		{ID: "grant-2", Email: "bob@example.com"},
+	}
+
+	assert.Equal(t, []string{"grant-1\talice@example.com", "grant-2\tbob@example.com"}, grantCandidates(grants, ""))
+	assert.Equal(t, []string{"grant-2\tbob@example.com"}, grantCandidates(grants, "grant-2"))
+	assert.Equal(t, []string{"alice@example.com\tgrant-1"}, grantCandidates(grants, "Al"))
+}
+
+// complete runs cobra's hidden completion command and returns the
+// candidates it prints.
+func complete(t *testing.T, root *cobra.Command, args ...string) []string {
+	t.Helper()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	require.NoError(t, root.Execute())
+	var candidates []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line != "" && !strings.HasPrefix(line, ":") {
+			candidates = append(candidates, line)
+		}
+	}
+	return candidates
+}
+
+func TestRegisterDynamicCompletions(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(tempDir, "cache"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tempDir, "config"))
+	t.Setenv("HOME", tempDir)
+	t.Setenv("NYLAS_DISABLE_KEYRING", "true")
+	t.Setenv("NYLAS_API_KEY", "test-key")
+	t.Setenv("NYLAS_GRANT_ID", "grant-1")
+	t.Cleanup(func() { completing.Store(false) })
+
+	store, err := NewDefaultGrantStore()
+	require.NoError(t, err)
+	require.NoError(t, store.SaveGrant(domain.GrantInfo{ID: "grant-1", Email: "alice@example.com"}))
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"inbox","name":"INBOX"},{"id":"sent","name":"Sent"}]}`))
+	}))
+	t.Setenv("NYLAS_API_BASE_URL", server.URL)
+
+	root := &cobra.Command{Use: "nylas"}
+	list := &cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}
+	list.Flags().String("folder", "", "")
+	list.Flags().String("grant", "", "")
+	email := &cobra.Command{Use: "email"}
+	email.AddCommand(list)
+	root.AddCommand(email)
+	RegisterDynamicCompletions(root)
+
+	assert.Equal(t, []string{"grant-1\talice@example.com"}, complete(t, root, "email", "list", "--grant", ""))
+	assert.Equal(t, []string{"inbox\tINBOX", "sent\tSent"}, complete(t, root, "email", "list", "--folder", ""))
+	assert.Equal(t, 1, requests)
+
+	// Cached folders are offered without the API, even when it's gone.
+	server.Close()
+	assert.Equal(t, []string{"inbox\tINBOX", "sent\tSent"}, complete(t, root, "email", "list", "--folder", ""))
+}
+
+func TestRegisterDynamicCompletions_LeavesFlagSetsUnmerged(t *testing.T) {
+	// A subcommand that reuses a global shorthand only fails when it runs;
+	// registering completions must not turn that into a startup panic.
+	root := &cobra.Command{Use: "nylas"}
+	root.PersistentFlags().BoolP("quiet", "q", false, "")
+	search := &cobra.Command{Use: "search", Run: func(*cobra.Command, []string) {}}
+	search.Flags().StringP("query", "q", "", "")
+	search.Flags().String("grant", "", "")
+	root.AddCommand(search)
+
+	assert.NotPanics(t, func() { RegisterDynamicCompletions(root) })
+}
+
+func TestRecipientCandidates(t *testing.T) {
+	indexed := []domain.Recipient{
+		{Email: "ana@example.com", Name: "Ana Silva", Count: 2},
+		{Email: "andy@example.com", Count: 1},
+		{Email: "bob@example.com", Name: "Bob Stone"},
+	}
+
+	assert.Equal(t, []string{"ana@example.com\tAna Silva", "andy@example.com"}, recipientCandidates(indexed, "an"))
+	assert.Equal(t, []string{"ana@example.com,andy@example.com"}, recipientCandidates(indexed, "ana@example.com,an"),
+		"completes after the last comma and skips addresses already listed")
+	assert.Equal(t, []string{"ana@example.com, bob@example.com\tBob Stone"}, recipientCandidates(indexed, "ana@example.com, st"))
+}
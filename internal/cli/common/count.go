@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// CountResult is the output of the count commands.
+type CountResult struct {
+	Resource string `json:"resource"`
+	Count    int    `json:"count"`
+	// Complete is false when counting stopped at --max, so Count is a
+	// lower bound.
+	Complete bool `json:"complete"`
+	Pages    int  `json:"pages"`
+}
+
+// CountPages walks every page from fetcher and counts the items without
+// keeping them. The Nylas API returns no totals, so callers should request
+// full-size pages with only the id field selected. maxItems > 0 stops once
+// that many items have been seen.
+func CountPages[T any](ctx context.Context, resource string, maxItems int, fetcher PageFetcher[T]) (CountResult, error) {
+	result := CountResult{Resource: resource, Complete: true}
+	cursor := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		page, err := fetcher(ctx, cursor)
+		if err != nil {
+			return result, fmt.Errorf("failed to fetch page %d: %w", result.Pages+1, err)
+		}
+		result.Pages++
+		result.Count += len(page.Data)
+
+		if maxItems > 0 && result.Count >= maxItems {
+			result.Complete = result.Count == maxItems && !page.HasMore()
+			result.Count = maxItems
+			return result, nil
+		}
+		// Same loop guards as FetchAllPages.
+		if !page.HasMore() || len(page.Data) == 0 || page.NextCursor == cursor {
+			return result, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// PrintCount writes a count result: the bare number for scripts, with a
+// trailing "+" when counting stopped early, or the full result as JSON/YAML.
+func PrintCount(cmd *cobra.Command, result CountResult) error {
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	if IsStructuredOutput(cmd) && !quiet && !IsIDsOnly(cmd) {
+		return GetOutputWriter(cmd).Write(result)
+	}
+	suffix := ""
+	if !result.Complete {
+		suffix = "+"
+	}
+	_, err := fmt.Fprintf(cmd.OutOrStdout(), "%d%s\n", result.Count, suffix)
+	return err
+}
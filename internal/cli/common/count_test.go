@@ -0,0 +1,100 @@
+//go:build !integration
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedFetcher serves pages of the given sizes, chained by cursor.
+func pagedFetcher(sizes ...int) PageFetcher[int] {
+	return func(_ context.Context, cursor string) (PageResult[int], error) {
+		i := 0
+		if cursor != "" {
+			i = int(cursor[0] - '0')
+		}
+		page := PageResult[int]{Data: make([]int, sizes[i])}
+		if i+1 < len(sizes) {
+			page.NextCursor = string(rune('0' + i + 1))
+		}
+		return page, nil
+	}
+}
+
+func TestCountPages(t *testing.T) {
+	tests := []struct {
+		name         string
+		sizes        []int
+		max          int
+		wantCount    int
+		wantComplete bool
+		wantPages    int
+	}{
+		{name: "all pages", sizes: []int{200, 200, 17}, wantCount: 417, wantComplete: true, wantPages: 3},
+		{name: "empty", sizes: []int{0}, wantCount: 0, wantComplete: true, wantPages: 1},
+		{name: "stops at max", sizes: []int{200, 200, 17}, max: 300, wantCount: 300, wantComplete: false, wantPages: 2},
+		{name: "max equals total", sizes: []int{200, 17}, max: 217, wantCount: 217, wantComplete: true, wantPages: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CountPages(context.Background(), "items", tt.max, pagedFetcher(tt.sizes...))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, result.Count)
+			assert.Equal(t, tt.wantComplete, result.Complete)
+			assert.Equal(t, tt.wantPages, result.Pages)
+			assert.Equal(t, "items", result.Resource)
+		})
+	}
+}
+
+func TestCountPages_StopsOnRepeatedCursor(t *testing.T) {
+	calls := 0
+	result, err := CountPages(context.Background(), "items", 0, func(_ context.Context, _ string) (PageResult[int], error) {
+		calls++
+		return PageResult[int]{Data: []int{1}, NextCursor: "same"}, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, result.Count)
+}
+
+func TestCountPages_ReturnsFetchError(t *testing.T) {
+	_, err := CountPages(context.Background(), "items", 0, func(_ context.Context, _ string) (PageResult[int], error) {
+		return PageResult[int]{}, errors.New("boom")
+	})
+
+	assert.ErrorContains(t, err, "failed to fetch page 1: boom")
+}
+
+func runPrintCount(t *testing.T, result CountResult, args ...string) string {
+	t.Helper()
+	root := &cobra.Command{Use: "test", SilenceErrors: true, SilenceUsage: true}
+	AddOutputFlags(root)
+	root.AddCommand(&cobra.Command{
+		Use:  "count",
+		RunE: func(cmd *cobra.Command, _ []string) error { return PrintCount(cmd, result) },
+	})
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetArgs(append([]string{"count"}, args...))
+	require.NoError(t, root.Execute())
+	return stdout.String()
+}
+
+func TestPrintCount(t *testing.T) {
+	assert.Equal(t, "42\n", runPrintCount(t, CountResult{Count: 42, Complete: true}))
+	assert.Equal(t, "1000+\n", runPrintCount(t, CountResult{Count: 1000}))
+
+	var got CountResult
+	require.NoError(t, json.Unmarshal([]byte(runPrintCount(t, CountResult{Resource: "messages", Count: 5, Complete: true, Pages: 1}, "--json")), &got))
+	assert.Equal(t, CountResult{Resource: "messages", Count: 5, Complete: true, Pages: 1}, got)
+}
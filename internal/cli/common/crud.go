@@ -48,6 +48,14 @@ type DeleteConfig struct {
 	GrantID      string                                                      // Grant ID
 	Force        bool                                                        // Skip confirmation
 	DeleteFunc   func(ctx context.Context, grantID, resourceID string) error // Actual delete function
+	Cmd          *cobra.Command                                              // Optional; enables --json/--yaml output
+}
+
+// DeleteResult is the structured output of delete commands.
+type DeleteResult struct {
+	Resource string `json:"resource" yaml:"resource"`
+	ID       string `json:"id" yaml:"id"`
+	Deleted  bool   `json:"deleted" yaml:"deleted"`
 }
 
 // RunDelete executes a standard delete operation with confirmation, spinner, and success message.
@@ -72,13 +80,17 @@ func RunDelete(config DeleteConfig) error {
 		return WrapDeleteError(config.ResourceName, err)
 	}
 
+	if config.Cmd != nil && IsStructuredOutput(config.Cmd) {
+		return GetOutputWriter(config.Cmd).Write(DeleteResult{Resource: config.ResourceName, ID: config.ResourceID, Deleted: true})
+	}
+
 	// Success message
 	// Capitalize first letter manually
 	resourceName := config.ResourceName
 	if len(resourceName) > 0 {
 		resourceName = strings.ToUpper(resourceName[:1]) + resourceName[1:]
 	}
-	fmt.Printf("%s %s deleted successfully.\n", Green.Sprint("✓"), resourceName)
+	PrintSuccess("%s deleted successfully.", resourceName)
 
 	return nil
 }
@@ -223,12 +235,16 @@ func NewDeleteCommand(config DeleteCommandConfig) *cobra.Command {
 					return WrapDeleteError(config.ResourceName, err)
 				}
 
+				if IsStructuredOutput(cmd) {
+					return GetOutputWriter(cmd).Write(DeleteResult{Resource: config.ResourceName, ID: resourceID, Deleted: true})
+				}
+
 				// Success message
 				resourceName := config.ResourceName
 				if len(resourceName) > 0 {
 					resourceName = strings.ToUpper(resourceName[:1]) + resourceName[1:]
 				}
-				fmt.Printf("%s %s deleted successfully.\n", Green.Sprint("✓"), resourceName)
+				PrintSuccess("%s deleted successfully.", resourceName)
 				return nil
 			}
 
@@ -256,13 +272,14 @@ func NewDeleteCommand(config DeleteCommandConfig) *cobra.Command {
 				GrantID:      resourceArgs.GrantID,
 				Force:        force,
 				DeleteFunc:   config.DeleteFunc,
+				Cmd:          cmd,
 			})
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
 
-	return cmd
+	return DeclareOutput(cmd, DeleteResult{})
 }
 
 // ShowCommandConfig configures a show command with custom display logic.
@@ -379,11 +396,11 @@ func SetupUpdateCommand(args []string) (*UpdateSetup, error) {
 
 // PrintUpdateSuccess prints a standardized success message for update operations.
 func PrintUpdateSuccess(resourceName string, details ...string) {
-	msg := fmt.Sprintf("%s %s updated successfully", Green.Sprint("✓"), capitalize(resourceName))
+	msg := capitalize(resourceName) + " updated successfully"
 	if len(details) > 0 {
 		msg += ": " + details[0]
 	}
-	fmt.Println(msg)
+	PrintSuccess("%s", msg)
 }
 
 // capitalize capitalizes the first letter of a string.
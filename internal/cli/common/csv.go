@@ -0,0 +1,303 @@
+package common
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CSVColumn is one column of a resource's CSV export.
+type CSVColumn[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+// WriteCSV writes items as CSV with a header row of column names.
+func WriteCSV[T any](w io.Writer, items []T, columns []CSVColumn[T]) error {
+	cw := csv.NewWriter(w)
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.Name
+	}
+	_ = cw.Write(headers)
+	for _, item := range items {
+		record := make([]string, len(columns))
+		for i, col := range columns {
+			record[i] = col.Value(item)
+		}
+		_ = cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// CSVMapping maps spreadsheet columns to resource fields for import.
+//
+//	columns:
+//	  "First Name": given_name
+//	  "E-mail Address": email
+//	defaults:
+//	  company_name: Acme
+type CSVMapping struct {
+	// Columns maps a CSV header to a field name.
+	Columns map[string]string `yaml:"columns"`
+	// Defaults fills fields that are missing or empty in a row.
+	Defaults map[string]string `yaml:"defaults"`
+}
+
+// LoadCSVMapping reads a mapping file. An empty path returns an empty
+// mapping, which matches headers to fields by name.
+func LoadCSVMapping(path string) (*CSVMapping, error) {
+	mapping := &CSVMapping{}
+	if path == "" {
+		return mapping, nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- user-supplied mapping file
+	if err != nil {
+		return nil, WrapError(fmt.Errorf("failed to read mapping file: %w", err))
+	}
+	if err := yaml.Unmarshal(data, mapping); err != nil {
+		return nil, NewUserError(
+			fmt.Sprintf("invalid mapping file %s: %v", path, err),
+			"Expected YAML with a 'columns' map of CSV header to field name",
+		)
+	}
+	return mapping, nil
+}
+
+// CSVRecord is one data row keyed by field name.
+type CSVRecord struct {
+	Line   int
+	Fields map[string]string
+}
+
+// ReadCSVRecords reads CSV rows and maps them to the given field names.
+// Headers are mapped through mapping.Columns first; any other header that
+// matches a field name (ignoring case, spaces and dashes) maps to it, so a
+// file exported with --format csv imports without a mapping. Columns that
+// map to nothing are returned as ignored.
+func ReadCSVRecords(r io.Reader, mapping *CSVMapping, fields []string) ([]CSVRecord, []string, error) {
+	if mapping == nil {
+		mapping = &CSVMapping{}
+	}
+	for header, field := range mapping.Columns {
+		if !slices.Contains(fields, field) {
+			return nil, nil, NewUserError(
+				fmt.Sprintf("mapping for column %q uses unknown field %q", header, field),
+				"Valid fields: "+strings.Join(fields, ", "),
+			)
+		}
+	}
+	for field := range mapping.Defaults {
+		if !slices.Contains(fields, field) {
+			return nil, nil, NewUserError(
+				fmt.Sprintf("mapping default uses unknown field %q", field),
+				"Valid fields: "+strings.Join(fields, ", "),
+			)
+		}
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	headers, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, NewUserError("CSV file is empty", "The first row must be a header row")
+	}
+	if err != nil {
+		return nil, nil, NewInputError(fmt.Sprintf("invalid CSV: %v", err))
+	}
+	if len(headers) > 0 {
+		headers[0] = strings.TrimPrefix(headers[0], "\ufeff") // Excel BOM
+	}
+
+	targets := make([]string, len(headers))
+	var ignored []string
+	for i, header := range headers {
+		header = strings.TrimSpace(header)
+		if field, ok := mapping.Columns[header]; ok {
+			targets[i] = field
+			continue
+		}
+		if field := normalizeCSVHeader(header); slices.Contains(fields, field) {
+			targets[i] = field
+			continue
+		}
+		if header != "" {
+			ignored = append(ignored, header)
+		}
+	}
+
+	var records []CSVRecord
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, NewInputError(fmt.Sprintf("invalid CSV: %v", err))
+		}
+		line, _ := cr.FieldPos(0)
+
+		record := CSVRecord{Line: line, Fields: make(map[string]string)}
+		blank := true
+		for i, value := range row {
+			value = strings.TrimSpace(value)
+			if i >= len(targets) || targets[i] == "" || value == "" {
+				continue
+			}
+			blank = false
+			if prev, ok := record.Fields[targets[i]]; ok {
+				// Several columns mapped to one field, e.g. two email columns.
+				value = prev + "; " + value
+			}
+			record.Fields[targets[i]] = value
+		}
+		if blank {
+			continue
+		}
+		for field, value := range mapping.Defaults {
+			if record.Fields[field] == "" {
+				record.Fields[field] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, ignored, nil
+}
+
+// SplitCSVList splits a multi-value cell ("a; b" or "a, b").
+func SplitCSVList(value string) []string {
+	var out []string
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func normalizeCSVHeader(header string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(header)))
+}
+
+// ReadCSVFile reads records from path ("-" for stdin) using the mapping file
+// at mappingPath (optional). Ignored columns are reported on stderr.
+func ReadCSVFile(path, mappingPath string, fields []string) ([]CSVRecord, error) {
+	mapping, err := LoadCSVMapping(mappingPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path) // #nosec G304 -- user-supplied import file
+		if err != nil {
+			return nil, WrapError(fmt.Errorf("failed to open %s: %w", path, err))
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	records, ignored, err := ReadCSVRecords(r, mapping, fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(ignored) > 0 {
+		PrintWarningStderr("Ignoring unmapped columns: %s (map them with --mapping)", strings.Join(ignored, ", "))
+	}
+	return records, nil
+}
+
+// CSVImportFailure is a row that could not be imported.
+type CSVImportFailure struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// CSVImportResult summarizes an import.
+type CSVImportResult struct {
+	Created []string           `json:"created"`
+	Failed  []CSVImportFailure `json:"failed,omitempty"`
+}
+
+// ImportCSVRecords builds and creates one resource per record, continuing
+// past failures so one bad row doesn't abort a large import. create returns
+// the new resource's ID.
+func ImportCSVRecords[R any](records []CSVRecord, build func(map[string]string) (R, error), create func(R) (string, error)) CSVImportResult {
+	result := CSVImportResult{Created: []string{}}
+	progress := NewProgressBar("Importing", len(records))
+	for _, record := range records {
+		req, err := build(record.Fields)
+		if err == nil {
+			var id string
+			if id, err = create(req); err == nil {
+				result.Created = append(result.Created, id)
+			}
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, CSVImportFailure{Line: record.Line, Error: csvErrorMessage(err)})
+		}
+		progress.Increment()
+	}
+	progress.Finish()
+	return result
+}
+
+// BuildCSVRecords converts records without creating anything, for dry runs.
+func BuildCSVRecords[R any](records []CSVRecord, build func(map[string]string) (R, error)) ([]R, []CSVImportFailure) {
+	var (
+		built  []R
+		failed []CSVImportFailure
+	)
+	for _, record := range records {
+		req, err := build(record.Fields)
+		if err != nil {
+			failed = append(failed, CSVImportFailure{Line: record.Line, Error: csvErrorMessage(err)})
+			continue
+		}
+		built = append(built, req)
+	}
+	return built, failed
+}
+
+// PrintCSVDryRun prints the dry-run summary line and any rows that would fail.
+func PrintCSVDryRun(w io.Writer, count int, resource string, failed []CSVImportFailure) {
+	_, _ = fmt.Fprintf(w, "\nDry run: %d %s would be created", count, resource)
+	if len(failed) == 0 {
+		_, _ = fmt.Fprintln(w)
+		return
+	}
+	_, _ = fmt.Fprintf(w, ", %d row(s) skipped:\n", len(failed))
+	for _, f := range failed {
+		_, _ = fmt.Fprintf(w, "  line %d: %s\n", f.Line, f.Error)
+	}
+}
+
+// PrintCSVImportResult prints a human-readable import summary.
+func PrintCSVImportResult(w io.Writer, result CSVImportResult, resource string) {
+	if len(result.Created) > 0 {
+		PrintSuccess("Imported %d %s", len(result.Created), resource)
+	}
+	if len(result.Failed) == 0 {
+		return
+	}
+	_, _ = Red.Fprintf(w, "Failed to import %d row(s):\n", len(result.Failed))
+	for _, f := range result.Failed {
+		_, _ = fmt.Fprintf(w, "  line %d: %s\n", f.Line, f.Error)
+	}
+}
+
+func csvErrorMessage(err error) string {
+	var cliErr *CLIError
+	if errors.As(err, &cliErr) {
+		return cliErr.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,143 @@
+package common
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var csvTestFields = []string{"given_name", "surname", "email", "company_name"}
+
+func TestReadCSVRecords(t *testing.T) {
+	input := "\ufeffFirst Name,Surname,Work Email,Home Email,Extra\n" +
+		"Jane,Doe,jane@work.com,jane@home.com,x\n" +
+		",,,,\n" +
+		"John,,,,y\n"
+	mapping := &CSVMapping{
+		Columns:  map[string]string{"First Name": "given_name", "Work Email": "email", "Home Email": "email"},
+		Defaults: map[string]string{"company_name": "Acme"},
+	}
+
+	records, ignored, err := ReadCSVRecords(strings.NewReader(input), mapping, csvTestFields)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Extra"}, ignored)
+	require.Len(t, records, 2, "blank rows are skipped")
+	assert.Equal(t, 2, records[0].Line)
+	assert.Equal(t, map[string]string{
+		"given_name":   "Jane",
+		"surname":      "Doe",
+		"email":        "jane@work.com; jane@home.com",
+		"company_name": "Acme",
+	}, records[0].Fields)
+	assert.Equal(t, 4, records[1].Line)
+	assert.Equal(t, "John", records[1].Fields["given_name"])
+}
+
+func TestReadCSVRecords_Errors(t *testing.T) {
+	_, _, err := ReadCSVRecords(strings.NewReader("a\n1\n"), &CSVMapping{Columns: map[string]string{"a": "nope"}}, csvTestFields)
+	assert.ErrorContains(t, err, `unknown field "nope"`)
+
+	_, _, err = ReadCSVRecords(strings.NewReader("a\n1\n"), &CSVMapping{Defaults: map[string]string{"nope": "x"}}, csvTestFields)
+	assert.ErrorContains(t, err, `unknown field "nope"`)
+
+	_, _, err = ReadCSVRecords(strings.NewReader(""), nil, csvTestFields)
+	assert.ErrorContains(t, err, "empty")
+
+	_, _, err = ReadCSVRecords(strings.NewReader("email\n\"unterminated\n"), nil, csvTestFields)
+	assert.Error(t, err)
+}
+
+func TestLoadCSVMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("columns:\n  \"E-mail\": email\ndefaults:\n  company_name: Acme\n"), 0o600))
+
+	mapping, err := LoadCSVMapping(path)
+	require.NoError(t, err)
+	assert.Equal(t, "email", mapping.Columns["E-mail"])
+	assert.Equal(t, "Acme", mapping.Defaults["company_name"])
+
+	empty, err := LoadCSVMapping("")
+	require.NoError(t, err)
+	assert.Empty(t, empty.Columns)
+
+	require.NoError(t, os.WriteFile(path, []byte("columns: [oops"), 0o600))
+	_, err = LoadCSVMapping(path)
+	assert.ErrorContains(t, err, "invalid mapping file")
+}
+
+func TestWriteCSV(t *testing.T) {
+	type item struct{ name, note string }
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, []item{{"a", "has, comma"}}, []CSVColumn[item]{
+		{Name: "name", Value: func(i item) string { return i.name }},
+		{Name: "note", Value: func(i item) string { return i.note }},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "name,note\na,\"has, comma\"\n", buf.String())
+}
+
+func TestImportCSVRecords(t *testing.T) {
+	records := []CSVRecord{
+		{Line: 2, Fields: map[string]string{"email": "a@x.com"}},
+		{Line: 3, Fields: map[string]string{}},
+		{Line: 4, Fields: map[string]string{"email": "fail@x.com"}},
+	}
+	build := func(fields map[string]string) (string, error) {
+		if fields["email"] == "" {
+			return "", NewUserError("row has no email", "")
+		}
+		return fields["email"], nil
+	}
+	create := func(email string) (string, error) {
+		if strings.HasPrefix(email, "fail") {
+			return "", assert.AnError
+		}
+		return "id-" + email, nil
+	}
+
+	result := ImportCSVRecords(records, build, create)
+
+	assert.Equal(t, []string{"id-a@x.com"}, result.Created)
+	assert.Equal(t, []CSVImportFailure{
+		{Line: 3, Error: "row has no email"},
+		{Line: 4, Error: assert.AnError.Error()},
+	}, result.Failed)
+
+	built, failed := BuildCSVRecords(records, build)
+	assert.Equal(t, []string{"a@x.com", "fail@x.com"}, built)
+	assert.Len(t, failed, 1)
+}
+
+func TestSplitCSVList(t *testing.T) {
+	assert.Equal(t, []string{"a@x.com", "b@x.com", "c@x.com"}, SplitCSVList(" a@x.com; b@x.com,c@x.com ;"))
+	assert.Nil(t, SplitCSVList(""))
+}
+
+func TestIsCSV(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{args: []string{"--format", "csv"}, want: true},
+		{args: []string{"--format", "csv", "--json"}, want: false},
+		{args: []string{"--format", "csv", "--quiet"}, want: false},
+		{args: []string{"--format", "json"}, want: false},
+		{args: nil, want: false},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		AddOutputFlags(cmd)
+		require.NoError(t, cmd.ParseFlags(tt.args))
+		assert.Equal(t, tt.want, IsCSV(cmd), tt.args)
+		if tt.want {
+			assert.True(t, IsStructuredOutput(cmd))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// runEditor runs the editor command on path. Tests replace it.
+var runEditor = func(editor []string, path string) error {
+	cmd, err := SafeCommand(editor[0], append(editor[1:], path)...)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// EditorCommand returns the user's editor from $VISUAL or $EDITOR, split into
+// the program and its arguments (e.g. "code --wait"). It falls back to vi, or
+// notepad on Windows.
+func EditorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// CanEdit reports whether an editor can be opened, i.e. stdin is a terminal.
+func CanEdit() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) // #nosec G115 -- file descriptors fit in int
+}
+
+// EditText opens initial in the user's editor and returns the saved text with
+// trailing whitespace removed. The temporary file is only readable by the
+// user and is removed afterwards.
+func EditText(initial string) (string, error) {
+	f, err := os.CreateTemp("", "nylas-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	path := f.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	_, err = f.WriteString(initial)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	editor := EditorCommand()
+	if err := runEditor(editor, path); err != nil {
+		return "", NewUserError(fmt.Sprintf("editor %q failed: %v", editor[0], err),
+			"Set $EDITOR to your preferred editor, e.g. export EDITOR=nano")
+	}
+
+	// #nosec G304 -- path is the temp file created above
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited text: %w", err)
+	}
+	return strings.TrimRight(string(data), " \t\r\n"), nil
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, EditorCommand())
+
+	t.Setenv("VISUAL", "nano")
+	assert.Equal(t, []string{"nano"}, EditorCommand(), "$VISUAL wins over $EDITOR")
+}
+
+func TestEditText(t *testing.T) {
+	original := runEditor
+	t.Cleanup(func() { runEditor = original })
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "ed -s")
+
+	var tempPath string
+	runEditor = func(editor []string, path string) error {
+		tempPath = path
+		assert.Equal(t, []string{"ed", "-s"}, editor)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", string(data))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		return os.WriteFile(path, []byte("Hello, Ana\n\n"), 0o600)
+	}
+
+	text, err := EditText("Hello")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, Ana", text)
+	assert.NoFileExists(t, tempPath, "the temp file is removed")
+}
+
+func TestEditText_EditorFails(t *testing.T) {
+	original := runEditor
+	t.Cleanup(func() { runEditor = original })
+	runEditor = func([]string, string) error { return os.ErrNotExist }
+
+	_, err := EditText("Hello")
+	assert.ErrorContains(t, err, "failed")
+}
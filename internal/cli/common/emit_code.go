@@ -0,0 +1,67 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/nylas/cli/internal/adapters/codegen"
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+var (
+	emitMu   sync.Mutex
+	emitLang string
+	// emitOut receives the snippets; stdout stays reserved for command output.
+	emitOut io.Writer = os.Stderr
+)
+
+// SetEmitCode prints the code for every API request made by this process
+// in lang (the global --emit-code flag). An empty lang turns it off.
+func SetEmitCode(lang string) error {
+	if err := ValidateOneOf("--emit-code", lang, codegen.Languages); err != nil {
+		return err
+	}
+	emitMu.Lock()
+	defer emitMu.Unlock()
+	emitLang = lang
+	return nil
+}
+
+func emitCodeLanguage() string {
+	emitMu.Lock()
+	defer emitMu.Unlock()
+	return emitLang
+}
+
+// applyCodeEmitter prints c's requests as code when --emit-code is set.
+func applyCodeEmitter(c *nylas.HTTPClient) {
+	lang := emitCodeLanguage()
+	if lang == "" {
+		return
+	}
+	c.SetRequestObserver(func(req domain.APIRequest) {
+		emitCode(lang, req)
+	})
+}
+
+// emitCode writes one snippet, keeping concurrent requests' snippets whole.
+func emitCode(lang string, req domain.APIRequest) {
+	snippet, err := codegen.Render(lang, req)
+	if err != nil {
+		return
+	}
+	emitMu.Lock()
+	defer emitMu.Unlock()
+	_, _ = fmt.Fprintf(emitOut, "%s\n%s\n", Dim.Sprintf("%s %s %s", commentPrefix(lang), req.Method, req.URL), snippet)
+}
+
+func commentPrefix(lang string) string {
+	switch lang {
+	case codegen.LangGo, codegen.LangNode:
+		return "//"
+	}
+	return "#"
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestSetEmitCode(t *testing.T) {
+	t.Cleanup(func() { _ = SetEmitCode("") })
+
+	require.NoError(t, SetEmitCode("python"))
+	assert.Equal(t, "python", emitCodeLanguage())
+
+	assert.Error(t, SetEmitCode("cobol"))
+	assert.Equal(t, "python", emitCodeLanguage(), "an invalid language keeps the previous setting")
+
+	require.NoError(t, SetEmitCode(""))
+	assert.Empty(t, emitCodeLanguage())
+}
+
+func TestEmitCode(t *testing.T) {
+	var out bytes.Buffer
+	prev := emitOut
+	emitOut = &out
+	t.Cleanup(func() { emitOut = prev })
+
+	emitCode("go", domain.APIRequest{Method: "GET", URL: "https://api.us.nylas.com/v3/grants", Auth: domain.APIAuthAPIKey})
+
+	assert.Contains(t, out.String(), "// GET https://api.us.nylas.com/v3/grants")
+	assert.Contains(t, out.String(), `os.Getenv("NYLAS_API_KEY")`)
+}
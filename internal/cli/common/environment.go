@@ -0,0 +1,102 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+var (
+	envOverrideMu sync.RWMutex
+	envOverride   string
+)
+
+// SetActiveEnvironment selects an environment profile for this process. The
+// root command calls it with the global --env flag.
+func SetActiveEnvironment(name string) {
+	envOverrideMu.Lock()
+	defer envOverrideMu.Unlock()
+	envOverride = strings.TrimSpace(name)
+}
+
+// ActiveEnvironment returns the selected profile name, checking the --env
+// flag, then NYLAS_ENV, then current_env in the config file. It returns ""
+// when the top-level settings are in use.
+func ActiveEnvironment(cfg *domain.Config) string {
+	envOverrideMu.RLock()
+	name := envOverride
+	envOverrideMu.RUnlock()
+
+	if name == "" {
+		name = strings.TrimSpace(os.Getenv("NYLAS_ENV"))
+	}
+	if name == "" && cfg != nil {
+		name = cfg.CurrentEnv
+	}
+	if name == domain.DefaultEnvironment {
+		return ""
+	}
+	return name
+}
+
+// applyEnvironment overlays the active profile on cfg and returns its name.
+func applyEnvironment(cfg *domain.Config) (string, error) {
+	name := ActiveEnvironment(cfg)
+	if err := cfg.UseEnvironment(name); err != nil {
+		if errors.Is(err, domain.ErrEnvironmentNotFound) {
+			return "", NewUserErrorWithSuggestions(
+				fmt.Sprintf("environment %q is not configured", name),
+				"List environments with: nylas config env list",
+				fmt.Sprintf("Create it with: nylas config env create %s --api-key <key>", name),
+				"Or unset NYLAS_ENV / --env to use the default settings",
+			)
+		}
+		return "", err
+	}
+	return name, nil
+}
+
+// environmentAPIKey reads the API key stored for an environment profile.
+// Profiles never fall back to the top-level key, so a missing sandbox key
+// can't silently send requests to production.
+func environmentAPIKey(env string) (string, error) {
+	secretStore, err := openSecretStore()
+	if err != nil {
+		return "", err
+	}
+	apiKey, err := getStoredSecret(secretStore, ports.EnvironmentSecretKey(env, ports.KeyAPIKey))
+	if err != nil {
+		return "", err
+	}
+	if apiKey == "" {
+		return "", NewUserErrorWithSuggestions(
+			fmt.Sprintf("API key not configured for environment %q", env),
+			fmt.Sprintf("Store one with: nylas config env create %s --api-key <key> --force", env),
+			"Or use environment variable: export NYLAS_API_KEY=<your-key>",
+		)
+	}
+	return apiKey, nil
+}
+
+// environmentDefaultGrant returns the default grant of the active profile,
+// or "" when no profile is active or it has none.
+func environmentDefaultGrant() string {
+	cfg, err := config.NewDefaultFileStore().Load()
+	if err != nil {
+		return ""
+	}
+	env := ActiveEnvironment(cfg)
+	if env == "" {
+		return ""
+	}
+	if p := cfg.Environments[env]; p != nil {
+		return p.DefaultGrant
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+//go:build !integration
+
+package common
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// setupEnvironmentProfiles isolates config and secrets in a temp dir and
+// saves a config with a "sandbox" profile.
+func setupEnvironmentProfiles(t *testing.T) {
+	t.Helper()
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tempDir, "xdg"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(tempDir, "cache"))
+	t.Setenv("HOME", tempDir)
+	t.Setenv("NYLAS_DISABLE_KEYRING", "true")
+	t.Setenv("NYLAS_FILE_STORE_PASSPHRASE", "test-file-store-passphrase")
+	t.Setenv("NYLAS_API_KEY", "")
+	t.Setenv("NYLAS_GRANT_ID", "")
+	t.Setenv("NYLAS_ENV", "")
+	t.Cleanup(func() { SetActiveEnvironment("") })
+
+	require.NoError(t, config.NewDefaultFileStore().Save(&domain.Config{
+		Region: "us",
+		Environments: map[string]*domain.EnvironmentProfile{
+			"sandbox": {Region: "eu", DefaultGrant: "grant-sandbox"},
+			"nokey":   {Region: "us"},
+		},
+	}))
+	secrets, err := keyring.NewSecretStore(config.DefaultConfigDir())
+	require.NoError(t, err)
+	require.NoError(t, secrets.Set(ports.KeyAPIKey, "top-level-key"))
+	require.NoError(t, secrets.Set(ports.EnvironmentSecretKey("sandbox", ports.KeyAPIKey), "sandbox-key"))
+}
+
+func TestActiveEnvironment_Precedence(t *testing.T) {
+	t.Setenv("NYLAS_ENV", "")
+	t.Cleanup(func() { SetActiveEnvironment("") })
+	cfg := &domain.Config{CurrentEnv: "saved"}
+
+	assert.Equal(t, "saved", ActiveEnvironment(cfg))
+
+	t.Setenv("NYLAS_ENV", "from-env")
+	assert.Equal(t, "from-env", ActiveEnvironment(cfg))
+
+	SetActiveEnvironment("from-flag")
+	assert.Equal(t, "from-flag", ActiveEnvironment(cfg))
+
+	SetActiveEnvironment(domain.DefaultEnvironment)
+	assert.Empty(t, ActiveEnvironment(cfg))
+}
+
+func TestGetAPIKey_UsesEnvironmentProfile(t *testing.T) {
+	setupEnvironmentProfiles(t)
+
+	key, err := GetAPIKey()
+	require.NoError(t, err)
+	assert.Equal(t, "top-level-key", key)
+
+	SetActiveEnvironment("sandbox")
+	key, err = GetAPIKey()
+	require.NoError(t, err)
+	assert.Equal(t, "sandbox-key", key)
+
+	// A profile without a key never falls back to the top-level key.
+	SetActiveEnvironment("nokey")
+	_, err = GetAPIKey()
+	assert.ErrorContains(t, err, `API key not configured for environment "nokey"`)
+
+	SetActiveEnvironment("missing")
+	_, err = GetNylasClient()
+	assert.ErrorContains(t, err, `environment "missing" is not configured`)
+}
+
+func TestGetGrantID_UsesEnvironmentDefaultGrant(t *testing.T) {
+	setupEnvironmentProfiles(t)
+	t.Setenv("NYLAS_ENV", "sandbox")
+
+	grantID, err := GetGrantID(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "grant-sandbox", grantID)
+	assert.False(t, grantFromDefault(nil))
+
+	grantID, err = GetGrantID([]string{"explicit"})
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", grantID)
+}
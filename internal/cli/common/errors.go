@@ -1,6 +1,7 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -64,14 +65,17 @@ func WrapError(err error) *CLIError {
 				Err:     err,
 				Message: msg,
 				Suggestions: []string{
-					"Run 'nylas auth show' to inspect the grant's current scopes",
-					"Run 'nylas auth login' to re-authorize the grant with the required scopes",
+					"Run 'nylas auth show' to inspect the grant, or 'nylas auth scopes' to see which scopes it is missing",
+					"Run 'nylas auth login --reauth <grant-id> --add-scopes <scopes>' to re-authorize the grant with the required scopes",
 					"Or 'nylas auth switch <grant-id-or-email>' to use a different grant",
 				},
 				Code:      ErrCodePermissionDenied,
 				RequestID: apiErr.RequestID,
 			}
 		}
+		if cliErr := translateAPIError(err, apiErr); cliErr != nil {
+			return cliErr
+		}
 		switch {
 		case apiErr.StatusCode == http.StatusTooManyRequests:
 			return &CLIError{
@@ -236,6 +240,10 @@ func WrapError(err error) *CLIError {
 	// Check for common error patterns in the error message
 	errMsg := err.Error()
 
+	if cliErr := translateProviderError(err, 0, "", errMsg); cliErr != nil {
+		return cliErr
+	}
+
 	if strings.Contains(errMsg, "Invalid API Key") {
 		return &CLIError{
 			Err:        err,
@@ -297,7 +305,7 @@ func FormatError(err error) string {
 	var sb strings.Builder
 
 	// Error message
-	_, _ = Red.Fprintf(&sb, "✗ Error: %s\n", cliErr.Message)
+	_, _ = Red.Fprintf(&sb, "%sError: %s\n", statusSymbol("✗"), cliErr.Message)
 
 	// Error code (if available)
 	if cliErr.Code != "" {
@@ -326,6 +334,49 @@ func FormatError(err error) string {
 	return sb.String()
 }
 
+// ErrorJSON is the machine-readable form of an error, written to stderr with
+// --error-format json.
+type ErrorJSON struct {
+	Code      string   `json:"code"`
+	ExitCode  int      `json:"exit_code"`
+	Message   string   `json:"message"`
+	Hint      string   `json:"hint,omitempty"`
+	Hints     []string `json:"hints,omitempty"`
+	RequestID string   `json:"request_id,omitempty"`
+}
+
+// ErrCodeUnknown is reported for errors without a more specific code.
+const ErrCodeUnknown = "E000"
+
+// NewErrorJSON converts err to its machine-readable form. Hint is the first
+// suggestion; Hints lists all of them when there are several.
+func NewErrorJSON(err error) ErrorJSON {
+	cliErr := WrapError(err)
+	out := ErrorJSON{
+		Code:      cliErr.Code,
+		ExitCode:  ExitCode(err),
+		Message:   cliErr.Message,
+		Hint:      cliErr.Suggestion,
+		RequestID: cliErr.RequestID,
+	}
+	if out.Code == "" {
+		out.Code = ErrCodeUnknown
+	}
+	if len(cliErr.Suggestions) > 0 {
+		out.Hint = cliErr.Suggestions[0]
+		if len(cliErr.Suggestions) > 1 {
+			out.Hints = cliErr.Suggestions
+		}
+	}
+	return out
+}
+
+// FormatErrorJSON formats an error as a single line of JSON.
+func FormatErrorJSON(err error) string {
+	data, _ := json.Marshal(NewErrorJSON(err))
+	return string(data) + "\n"
+}
+
 // PrintFormattedError prints a formatted error to stderr.
 func PrintFormattedError(err error) {
 	_, _ = fmt.Fprint(color.Error, FormatError(err))
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ExitError ends the process with Code without printing an error message.
+// Commands return it when the exit status is itself part of the output, such
+// as monitoring plugins that follow the Nagios exit code convention.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// Process exit codes. They are part of the CLI's contract with scripts:
+// codes are never reused for a different cause. ExitCodeError covers every
+// failure without a more specific code.
+const (
+	ExitCodeError         = 1
+	ExitCodeValidation    = 2
+	ExitCodeAuth          = 3
+	ExitCodeNotFound      = 4
+	ExitCodeRateLimited   = 5
+	ExitCodeNetwork       = 6
+	ExitCodePermission    = 7
+	ExitCodeServer        = 8
+	ExitCodeNotConfigured = 9
+)
+
+// exitCodes maps error codes to exit codes.
+var exitCodes = map[string]int{
+	ErrCodeNotConfigured:    ExitCodeNotConfigured,
+	ErrCodeAuthFailed:       ExitCodeAuth,
+	ErrCodeNetworkError:     ExitCodeNetwork,
+	ErrCodeNotFound:         ExitCodeNotFound,
+	ErrCodePermissionDenied: ExitCodePermission,
+	ErrCodeInvalidInput:     ExitCodeValidation,
+	ErrCodeRateLimited:      ExitCodeRateLimited,
+	ErrCodeServerError:      ExitCodeServer,
+}
+
+// ExitCode returns the process exit code for err: the code of an ExitError,
+// or the code for err's CLI error category.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	if code, ok := exitCodes[WrapError(err).Code]; ok {
+		return code
+	}
+	return ExitCodeError
+}
@@ -0,0 +1,70 @@
+//go:build !integration
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "exit error", err: &ExitError{Code: 2}, want: 2},
+		{name: "generic", err: errors.New("boom"), want: ExitCodeError},
+		{name: "validation", err: NewInputError("bad --limit"), want: ExitCodeValidation},
+		{name: "auth", err: domain.ErrAuthFailed, want: ExitCodeAuth},
+		{name: "not configured", err: domain.ErrNotConfigured, want: ExitCodeNotConfigured},
+		{name: "not found", err: &domain.APIError{StatusCode: http.StatusNotFound}, want: ExitCodeNotFound},
+		{name: "rate limited", err: &domain.APIError{StatusCode: http.StatusTooManyRequests}, want: ExitCodeRateLimited},
+		{name: "server", err: &domain.APIError{StatusCode: http.StatusBadGateway}, want: ExitCodeServer},
+		{name: "network", err: fmt.Errorf("send: %w", domain.ErrNetworkError), want: ExitCodeNetwork},
+		{name: "permission", err: &domain.APIError{StatusCode: http.StatusForbidden}, want: ExitCodePermission},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestFormatErrorJSON(t *testing.T) {
+	t.Run("api error", func(t *testing.T) {
+		var got ErrorJSON
+		err := &domain.APIError{StatusCode: http.StatusTooManyRequests, RequestID: "req-123"}
+		require.NoError(t, json.Unmarshal([]byte(FormatErrorJSON(err)), &got))
+
+		assert.Equal(t, ErrorJSON{
+			Code:      ErrCodeRateLimited,
+			ExitCode:  ExitCodeRateLimited,
+			Message:   "Rate limit exceeded",
+			Hint:      "Wait a moment and try again, or reduce the frequency of requests",
+			RequestID: "req-123",
+		}, got)
+	})
+
+	t.Run("several suggestions", func(t *testing.T) {
+		got := NewErrorJSON(NewUserErrorWithSuggestions("bad input", "first", "second"))
+		assert.Equal(t, "first", got.Hint)
+		assert.Equal(t, []string{"first", "second"}, got.Hints)
+	})
+
+	t.Run("uncategorized", func(t *testing.T) {
+		got := NewErrorJSON(errors.New("boom"))
+		assert.Equal(t, ErrCodeUnknown, got.Code)
+		assert.Equal(t, ExitCodeError, got.ExitCode)
+		assert.Equal(t, "boom", got.Message)
+	})
+}
@@ -139,7 +139,7 @@ func PrintSuccess(format string, args ...any) {
 
 // PrintError prints an error message.
 func PrintError(format string, args ...any) {
-	_, _ = Red.Fprintf(os.Stderr, "✗ "+format+"\n", args...)
+	_, _ = Red.Fprintf(os.Stderr, statusSymbol("✗")+format+"\n", args...)
 }
 
 // PrintWarning prints a warning message.
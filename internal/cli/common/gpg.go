@@ -0,0 +1,44 @@
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/gpg"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// OpenPGPHomeEnv overrides where the native OpenPGP backend keeps its
+// keyring, like GNUPGHOME does for gpg.
+const OpenPGPHomeEnv = "NYLAS_OPENPGP_HOME"
+
+// OpenPGPKeyringDir returns the native backend's keyring directory.
+func OpenPGPKeyringDir() string {
+	if dir := os.Getenv(OpenPGPHomeEnv); dir != "" {
+		return dir
+	}
+	return filepath.Join(config.DefaultConfigDir(), "openpgp")
+}
+
+// NewGPGBackend returns the OpenPGP backend selected by gpg.backend in the
+// config, after checking that it is usable.
+func NewGPGBackend(ctx context.Context, store ports.ConfigStore) (gpg.Backend, error) {
+	var kind domain.GPGBackend
+	if cfg, err := store.Load(); err == nil && cfg != nil && cfg.GPG != nil {
+		kind = cfg.GPG.Backend
+	}
+
+	backend, err := gpg.NewBackend(kind, OpenPGPKeyringDir())
+	if err != nil {
+		return nil, NewUserError(err.Error(), "Set a supported backend with: nylas config set gpg.backend native")
+	}
+	if err := backend.CheckGPGAvailable(ctx); err != nil {
+		return nil, NewUserErrorWithSuggestions("GnuPG is not installed",
+			"Install with: sudo apt install gnupg (Linux) or brew install gnupg (macOS)",
+			"Or use the built-in backend: nylas config set gpg.backend native")
+	}
+	return backend, nil
+}
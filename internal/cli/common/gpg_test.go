@@ -0,0 +1,46 @@
+//go:build !integration
+
+package common
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/gpg"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenPGPKeyringDir(t *testing.T) {
+	t.Setenv(OpenPGPHomeEnv, "/tmp/keys")
+	assert.Equal(t, "/tmp/keys", OpenPGPKeyringDir())
+
+	t.Setenv(OpenPGPHomeEnv, "")
+	assert.Equal(t, filepath.Join(config.DefaultConfigDir(), "openpgp"), OpenPGPKeyringDir())
+}
+
+func TestNewGPGBackend(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(OpenPGPHomeEnv, dir)
+	store := config.NewMockConfigStore()
+	ctx := context.Background()
+
+	cfg, err := store.Load()
+	require.NoError(t, err)
+	cfg.GPG = &domain.GPGConfig{Backend: domain.GPGBackendNative}
+	require.NoError(t, store.Save(cfg))
+
+	backend, err := NewGPGBackend(ctx, store)
+	require.NoError(t, err)
+	native, ok := backend.(*gpg.NativeService)
+	require.True(t, ok, "got %T", backend)
+	assert.Equal(t, dir, native.Dir())
+
+	cfg.GPG.Backend = "pgp4win"
+	require.NoError(t, store.Save(cfg))
+	_, err = NewGPGBackend(ctx, store)
+	assert.ErrorContains(t, err, `unknown gpg backend "pgp4win"`)
+}
@@ -19,3 +19,12 @@ func SetQuiet(quiet bool) {
 func IsQuiet() bool {
 	return quietMode.Load()
 }
+
+// statusSymbol returns a decorative status symbol followed by a space, or ""
+// in quiet mode so messages that are still shown (errors) stay plain text.
+func statusSymbol(symbol string) string {
+	if IsQuiet() {
+		return ""
+	}
+	return symbol + " "
+}
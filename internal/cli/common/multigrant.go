@@ -0,0 +1,223 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// AllGrants is the --grant value that selects every locally known grant.
+const AllGrants = "all"
+
+// maxGrantFanOut bounds how many grants are queried at once.
+const maxGrantFanOut = 4
+
+// GrantSelection holds the --grant and --grants flags of commands that can
+// run across several accounts.
+type GrantSelection struct {
+	Grant  string
+	Grants []string
+}
+
+// AddGrantSelectionFlags registers --grant and --grants on cmd.
+func AddGrantSelectionFlags(cmd *cobra.Command) *GrantSelection {
+	sel := &GrantSelection{}
+	cmd.Flags().StringVar(&sel.Grant, "grant", "", `Grant ID or email, or "all" for every authenticated account`)
+	cmd.Flags().StringSliceVar(&sel.Grants, "grants", nil, "Comma-separated grant IDs or emails to query together")
+	cmd.MarkFlagsMutuallyExclusive("grant", "grants")
+	return sel
+}
+
+// Multi reports whether more than one grant may be queried.
+func (s *GrantSelection) Multi() bool {
+	return s != nil && (strings.EqualFold(s.Grant, AllGrants) || len(s.Grants) > 0)
+}
+
+// Args returns the positional grant arguments for a single-grant run, with
+// a --grant value taking the place of a [grant-id] argument.
+func (s *GrantSelection) Args(args []string) ([]string, error) {
+	if s == nil || (s.Grant == "" && len(s.Grants) == 0) {
+		return args, nil
+	}
+	if len(args) > 0 {
+		return nil, NewUserError("grant given twice", "Pass the grant as an argument or with --grant/--grants, not both")
+	}
+	if s.Multi() {
+		return nil, nil
+	}
+	return []string{s.Grant}, nil
+}
+
+// GrantResult is what one grant returned in a multi-grant run.
+type GrantResult[T any] struct {
+	Grant domain.GrantInfo
+	Items []T
+	Err   error
+}
+
+// Account returns the label shown for the result's grant.
+func (r GrantResult[T]) Account() string {
+	return GrantLabel(r.Grant)
+}
+
+// MergeGrantResults flattens the items of successful grants, tagging each
+// with its account label.
+func MergeGrantResults[T, R any](results []GrantResult[T], tag func(account string, item T) R) []R {
+	merged := make([]R, 0)
+	for _, r := range results {
+		account := r.Account()
+		for _, item := range r.Items {
+			merged = append(merged, tag(account, item))
+		}
+	}
+	return merged
+}
+
+// GrantLabel returns a grant's email, or its ID when the email is unknown.
+func GrantLabel(g domain.GrantInfo) string {
+	if g.Email != "" {
+		return g.Email
+	}
+	return g.ID
+}
+
+// ResolveGrantSelection returns the grants a multi-grant run covers, from
+// the local grant store.
+func ResolveGrantSelection(sel *GrantSelection) ([]domain.GrantInfo, error) {
+	store, err := NewDefaultGrantStore()
+	if err != nil {
+		return nil, err
+	}
+	return resolveGrantSelection(store, sel)
+}
+
+func resolveGrantSelection(store ports.GrantStore, sel *GrantSelection) ([]domain.GrantInfo, error) {
+	if strings.EqualFold(sel.Grant, AllGrants) {
+		grants, err := store.ListGrants()
+		if err != nil {
+			return nil, wrapSecretStoreError(err)
+		}
+		if len(grants) == 0 {
+			return nil, NewUserError("no authenticated accounts", "Add one with: nylas auth login")
+		}
+		return grants, nil
+	}
+
+	grants := make([]domain.GrantInfo, 0, len(sel.Grants))
+	seen := make(map[string]bool, len(sel.Grants))
+	for _, identifier := range sel.Grants {
+		identifier = strings.TrimSpace(identifier)
+		if identifier == "" {
+			continue
+		}
+		grant, err := lookupGrant(store, identifier)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[grant.ID] {
+			seen[grant.ID] = true
+			grants = append(grants, grant)
+		}
+	}
+	if len(grants) == 0 {
+		return nil, NewInputError("--grants requires at least one grant ID or email")
+	}
+	return grants, nil
+}
+
+// lookupGrant finds a grant by ID or email. An unknown ID is still used, as
+// it is in single-grant commands; an unknown email is an error.
+func lookupGrant(store ports.GrantStore, identifier string) (domain.GrantInfo, error) {
+	if containsAt(identifier) {
+		grant, err := store.GetGrantByEmail(identifier)
+		if err != nil {
+			if errors.Is(err, domain.ErrGrantNotFound) {
+				return domain.GrantInfo{}, fmt.Errorf("no grant found for email: %s", identifier)
+			}
+			return domain.GrantInfo{}, wrapSecretStoreError(err)
+		}
+		return *grant, nil
+	}
+	if grant, err := store.GetGrant(identifier); err == nil {
+		return *grant, nil
+	}
+	return domain.GrantInfo{ID: identifier}, nil
+}
+
+// FanOutGrants calls fn for every grant concurrently and returns the results
+// in grant order. A failing grant doesn't stop the others.
+func FanOutGrants[T any](ctx context.Context, grants []domain.GrantInfo, fn func(ctx context.Context, grantID string) ([]T, error)) []GrantResult[T] {
+	results := make([]GrantResult[T], len(grants))
+	sem := make(chan struct{}, maxGrantFanOut)
+	var wg sync.WaitGroup
+	for i, grant := range grants {
+		results[i].Grant = grant
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i].Items, results[i].Err = fn(ctx, grant.ID)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// WithGrants is the multi-grant counterpart of WithClient: it resolves the
+// selected grants and runs fn for each of them concurrently. Failed grants
+// are reported on stderr; an error is returned only when every grant failed.
+func WithGrants[T any](sel *GrantSelection, fn func(ctx context.Context, client ports.NylasClient, grantID string) ([]T, error)) ([]GrantResult[T], error) {
+	client, err := GetNylasClient()
+	if err != nil {
+		return nil, err
+	}
+	grants, err := ResolveGrantSelection(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	if AuditGrantHook != nil {
+		ids := make([]string, len(grants))
+		for i, g := range grants {
+			ids[i] = g.ID
+		}
+		AuditGrantHook(strings.Join(ids, ","))
+	}
+
+	ctx, cancel := CreateContext()
+	defer cancel()
+
+	results := FanOutGrants(ctx, grants, func(ctx context.Context, grantID string) ([]T, error) {
+		return fn(ctx, client, grantID)
+	})
+	return results, reportGrantFailures(results)
+}
+
+// reportGrantFailures warns about each failed grant and returns the first
+// error when none succeeded.
+func reportGrantFailures[T any](results []GrantResult[T]) error {
+	var firstErr error
+	failed := 0
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		failed++
+		if firstErr == nil {
+			firstErr = r.Err
+		}
+		PrintWarningStderr("%s: %v", r.Account(), r.Err)
+	}
+	if failed > 0 && failed == len(results) {
+		return firstErr
+	}
+	return nil
+}
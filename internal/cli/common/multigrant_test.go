@@ -0,0 +1,132 @@
+//go:build !integration
+
+package common
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/grantcache"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGrantStore(t *testing.T, grants ...domain.GrantInfo) *grantcache.Store {
+	t.Helper()
+	store := grantcache.New(filepath.Join(t.TempDir(), "grants.json"))
+	for _, g := range grants {
+		require.NoError(t, store.SaveGrant(g))
+	}
+	return store
+}
+
+func TestResolveGrantSelection(t *testing.T) {
+	work := domain.GrantInfo{ID: "grant-work", Email: "me@work.com"}
+	home := domain.GrantInfo{ID: "grant-home", Email: "me@home.com"}
+	store := newTestGrantStore(t, work, home)
+
+	t.Run("all", func(t *testing.T) {
+		grants, err := resolveGrantSelection(store, &GrantSelection{Grant: "ALL"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.GrantInfo{work, home}, grants)
+	})
+
+	t.Run("list by email and ID", func(t *testing.T) {
+		grants, err := resolveGrantSelection(store, &GrantSelection{Grants: []string{"me@home.com", "grant-work", "grant-home", "unknown-id"}})
+		require.NoError(t, err)
+		assert.Equal(t, []domain.GrantInfo{home, work, {ID: "unknown-id"}}, grants, "duplicates dropped, unknown IDs kept")
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		_, err := resolveGrantSelection(store, &GrantSelection{Grants: []string{"nobody@example.com"}})
+		assert.ErrorContains(t, err, "nobody@example.com")
+	})
+
+	t.Run("all without accounts", func(t *testing.T) {
+		_, err := resolveGrantSelection(newTestGrantStore(t), &GrantSelection{Grant: AllGrants})
+		assert.Error(t, err)
+	})
+
+	t.Run("blank list", func(t *testing.T) {
+		_, err := resolveGrantSelection(store, &GrantSelection{Grants: []string{" "}})
+		assert.Error(t, err)
+	})
+}
+
+func TestGrantSelectionArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		sel     *GrantSelection
+		args    []string
+		want    []string
+		multi   bool
+		wantErr bool
+	}{
+		{name: "no flags", sel: &GrantSelection{}, args: []string{"g1"}, want: []string{"g1"}},
+		{name: "single grant flag", sel: &GrantSelection{Grant: "me@work.com"}, want: []string{"me@work.com"}},
+		{name: "all", sel: &GrantSelection{Grant: "all"}, multi: true},
+		{name: "list", sel: &GrantSelection{Grants: []string{"a", "b"}}, multi: true},
+		{name: "flag and argument", sel: &GrantSelection{Grant: "all"}, args: []string{"g1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sel.Args(tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.multi, tt.sel.Multi())
+		})
+	}
+}
+
+func TestAddGrantSelectionFlags_MutuallyExclusive(t *testing.T) {
+	cmd := &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+	AddGrantSelectionFlags(cmd)
+	cmd.SetArgs([]string{"--grant", "all", "--grants", "a,b"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	assert.Error(t, cmd.Execute())
+}
+
+func TestFanOutGrants(t *testing.T) {
+	grants := []domain.GrantInfo{{ID: "a"}, {ID: "b", Email: "b@example.com"}, {ID: "c"}, {ID: "d"}, {ID: "e"}, {ID: "f"}}
+	var running, peak atomic.Int32
+
+	results := FanOutGrants(context.Background(), grants, func(_ context.Context, grantID string) ([]string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if grantID == "c" {
+			return nil, errors.New("token expired")
+		}
+		return []string{grantID + "-1", grantID + "-2"}, nil
+	})
+
+	require.Len(t, results, len(grants))
+	assert.LessOrEqual(t, peak.Load(), int32(maxGrantFanOut))
+	assert.Equal(t, "a", results[0].Account())
+	assert.Equal(t, "b@example.com", results[1].Account())
+	assert.EqualError(t, results[2].Err, "token expired")
+
+	merged := MergeGrantResults(results, func(account, item string) string { return account + ":" + item })
+	assert.Len(t, merged, 10)
+	assert.Equal(t, "b@example.com:b-1", merged[2])
+
+	assert.NoError(t, reportGrantFailures(results), "partial failure is not an error")
+	assert.EqualError(t, reportGrantFailures(results[2:3]), "token expired")
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/notify"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// NewNotifier returns a notifier routed by the notify config, or nil when
+// notifications aren't configured. client and grantID back the email
+// channel; pass a nil client where no grant is in use.
+func NewNotifier(client ports.MessageClient, grantID string) (ports.Notifier, error) {
+	cfg, err := config.NewDefaultFileStore().Load()
+	if err != nil {
+		return nil, WrapLoadError("config", err)
+	}
+	if cfg.Notify == nil {
+		return nil, nil
+	}
+
+	channels := map[string]ports.Notifier{domain.NotifyDesktop: notify.NewDesktop()}
+	if secretStore, err := openSecretStore(); err == nil {
+		url, err := getStoredSecret(secretStore, ports.KeyNotifySlackWebhook)
+		if err != nil {
+			return nil, err
+		}
+		if url != "" {
+			channels[domain.NotifySlack] = notify.NewSlack(url)
+		}
+	}
+	if client != nil && grantID != "" && cfg.Notify.EmailTo != "" {
+		channels[domain.NotifyEmail] = notify.NewEmail(client, grantID, cfg.Notify.EmailTo)
+	}
+	return notify.NewRouter(cfg.Notify, channels), nil
+}
+
+// SendNotification sends n through notifier, reporting a failure on stderr
+// rather than stopping the caller. A nil notifier sends nothing.
+func SendNotification(ctx context.Context, notifier ports.Notifier, n domain.Notification) {
+	if notifier == nil {
+		return
+	}
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+	if err := notifier.Notify(ctx, n); err != nil && ctx.Err() == nil {
+		PrintWarningStderr("%s notification failed: %v", n.Category, err)
+	}
+}
@@ -1,19 +1,37 @@
 package common
 
 import (
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/nylas/cli/internal/adapters/output"
 	"github.com/nylas/cli/internal/ports"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// outputFlagAnnotation marks the global --output format flag, so a command's
+// own --output <file> flag is never read as a format.
+const outputFlagAnnotation = "nylas_output_format"
+
+// outputFormats are the plain --output values.
+var outputFormats = []string{"table", "json", "yaml", "csv", "quiet"}
+
+// AddOutputFormatFlag registers the --output format flag on a flag set.
+func AddOutputFormatFlag(flags *pflag.FlagSet) {
+	flags.String("output", "", "Output format: table, json, yaml, csv, quiet, or template=<go-template>")
+	_ = flags.SetAnnotation("output", outputFlagAnnotation, []string{"true"})
+}
+
 // AddOutputFlags adds common output flags to a command
 // These flags are inherited by all subcommands when added to a parent
 func AddOutputFlags(cmd *cobra.Command) {
-	cmd.PersistentFlags().String("format", "", "Output format: table, json, yaml")
+	cmd.PersistentFlags().String("format", "", "Output format: table, json, yaml, csv")
 	cmd.PersistentFlags().Bool("json", false, "Output in JSON format")
+	AddOutputFormatFlag(cmd.PersistentFlags())
 	cmd.PersistentFlags().BoolP("quiet", "q", false, "Quiet mode - only output essential data (IDs)")
+	cmd.PersistentFlags().Bool("ids", false, "Print only resource IDs, one per line")
 	cmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
 	cmd.PersistentFlags().BoolP("wide", "w", false, "Wide output - show full IDs without truncation")
 }
@@ -29,31 +47,93 @@ func GetOutputWriterTo(cmd *cobra.Command, w io.Writer) ports.OutputWriter {
 	return output.NewWriter(w, opts)
 }
 
+// GetStreamWriter returns a writer that outputs a list item by item, for
+// listings written as they are fetched. ok is false for table output and
+// for formats that need the whole list.
+func GetStreamWriter(cmd *cobra.Command) (w ports.StreamWriter, ok bool) {
+	out := cmd.OutOrStdout()
+	return output.NewStreamWriter(out, GetOutputOptions(cmd, out))
+}
+
 // GetOutputOptions extracts output options from command flags
 func GetOutputOptions(cmd *cobra.Command, w io.Writer) ports.OutputOptions {
 	format := getOutputFormat(cmd)
 	noColor, _ := cmd.Flags().GetBool("no-color")
 
-	return ports.OutputOptions{
+	opts := ports.OutputOptions{
 		Format:  format,
 		NoColor: noColor,
 		Writer:  w,
 	}
+	if format == ports.FormatTemplate {
+		_, opts.Template, _ = ParseOutputFlag(outputFlagValue(cmd))
+	}
+	return opts
+}
+
+// outputFlagValue returns the global --output value, or "" when the command
+// has no such flag or defines its own --output (a file path).
+func outputFlagValue(cmd *cobra.Command) string {
+	f := cmd.Flags().Lookup("output")
+	if f == nil || f.Annotations[outputFlagAnnotation] == nil {
+		return ""
+	}
+	return f.Value.String()
+}
+
+// ParseOutputFlag splits an --output value into a format and, for
+// template=<text>, the template text. An empty value returns an empty format.
+func ParseOutputFlag(value string) (ports.OutputFormat, string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", "", nil
+	}
+	if name, text, ok := strings.Cut(value, "="); ok && (name == "template" || name == "go-template") {
+		if strings.TrimSpace(text) == "" {
+			return "", "", NewUserError("--output template= needs template text", "Example: --output 'template={{.ID}} {{.Subject}}'")
+		}
+		if _, err := output.ParseTemplate(text); err != nil {
+			return "", "", NewUserError(err.Error(), "Templates use Go syntax and Go field names, e.g. {{.ID}} {{.Subject}}")
+		}
+		return ports.FormatTemplate, text, nil
+	}
+	lower := strings.ToLower(value)
+	if lower == "template" || lower == "go-template" {
+		return "", "", NewUserError("--output template needs template text", "Example: --output 'template={{.ID}} {{.Subject}}'")
+	}
+	for _, f := range outputFormats {
+		if lower == f {
+			return ports.OutputFormat(f), "", nil
+		}
+	}
+	return "", "", NewUserError(
+		fmt.Sprintf("invalid --output %q", value),
+		"Use one of: "+strings.Join(outputFormats, ", ")+", or template=<go-template>",
+	)
+}
+
+// ValidateOutputFlag rejects an invalid --output value before the command runs.
+func ValidateOutputFlag(cmd *cobra.Command) error {
+	_, _, err := ParseOutputFlag(outputFlagValue(cmd))
+	return err
 }
 
 // getOutputFormat determines the output format from flags
 func getOutputFormat(cmd *cobra.Command) ports.OutputFormat {
-	// --quiet/-q takes precedence
+	// --ids and --quiet/-q take precedence
 	quiet, _ := cmd.Flags().GetBool("quiet")
-	if quiet {
+	if quiet || IsIDsOnly(cmd) {
 		return ports.FormatQuiet
 	}
 
-	// --json takes precedence over --format
+	// --json takes precedence over --output, which takes precedence over --format
 	jsonFlag, _ := cmd.Flags().GetBool("json")
 	if jsonFlag {
 		return ports.FormatJSON
 	}
+	if format, _, err := ParseOutputFlag(outputFlagValue(cmd)); err == nil && format != "" {
+		return format
+	}
 
 	// Check --format flag
 	format, _ := cmd.Flags().GetString("format")
@@ -64,29 +144,51 @@ func getOutputFormat(cmd *cobra.Command) ports.OutputFormat {
 		return ports.FormatYAML
 	case "quiet":
 		return ports.FormatQuiet
+	case "csv":
+		return ports.FormatCSV
 	default:
 		return ports.FormatTable
 	}
 }
 
+// IsIDsOnly returns true if --ids asked for resource IDs only.
+func IsIDsOnly(cmd *cobra.Command) bool {
+	ids, _ := cmd.Flags().GetBool("ids")
+	return ids
+}
+
 // IsJSON returns true if JSON output is enabled
 func IsJSON(cmd *cobra.Command) bool {
+	if IsIDsOnly(cmd) {
+		return false
+	}
 	jsonFlag, _ := cmd.Flags().GetBool("json")
 	if jsonFlag {
 		return true
 	}
+	if outputFlagValue(cmd) != "" {
+		return getOutputFormat(cmd) == ports.FormatJSON
+	}
 	format, _ := cmd.Flags().GetString("format")
 	return format == "json"
 }
 
-// IsStructuredOutput returns true if non-table output is requested (JSON, YAML, or quiet).
+// IsCSV returns true if CSV output is requested.
+func IsCSV(cmd *cobra.Command) bool {
+	return getOutputFormat(cmd) == ports.FormatCSV
+}
+
+// IsStructuredOutput returns true if non-table output is requested (JSON, YAML, CSV, template, or quiet).
 func IsStructuredOutput(cmd *cobra.Command) bool {
 	if IsJSON(cmd) {
 		return true
 	}
+	if outputFlagValue(cmd) != "" {
+		return getOutputFormat(cmd) != ports.FormatTable
+	}
 	format, _ := cmd.Flags().GetString("format")
 	quiet, _ := cmd.Flags().GetBool("quiet")
-	return format == "yaml" || format == "quiet" || quiet
+	return format == "yaml" || format == "csv" || format == "quiet" || quiet || IsIDsOnly(cmd)
 }
 
 // IsWide returns true if wide output mode is enabled
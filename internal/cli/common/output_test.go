@@ -0,0 +1,120 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/ports"
+)
+
+func TestParseOutputFlag(t *testing.T) {
+	tests := []struct {
+		value    string
+		format   ports.OutputFormat
+		template string
+		wantErr  bool
+	}{
+		{value: "", format: ""},
+		{value: "json", format: ports.FormatJSON},
+		{value: "YAML", format: ports.FormatYAML},
+		{value: "csv", format: ports.FormatCSV},
+		{value: "table", format: ports.FormatTable},
+		{value: "template={{.ID}} {{.Subject}}", format: ports.FormatTemplate, template: "{{.ID}} {{.Subject}}"},
+		{value: "go-template={{.ID}}", format: ports.FormatTemplate, template: "{{.ID}}"},
+		{value: "template", wantErr: true},
+		{value: "template=", wantErr: true},
+		{value: "template={{.ID", wantErr: true},
+		{value: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			format, text, err := ParseOutputFlag(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, format)
+			assert.Equal(t, tt.template, text)
+		})
+	}
+}
+
+func newOutputTestCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	AddOutputFlags(cmd)
+	return cmd
+}
+
+func TestGetOutputFormat_OutputFlag(t *testing.T) {
+	cmd := newOutputTestCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--output=yaml"}))
+	assert.Equal(t, ports.FormatYAML, getOutputFormat(cmd))
+	assert.True(t, IsStructuredOutput(cmd))
+	assert.False(t, IsJSON(cmd))
+
+	// --output wins over --format, --json wins over --output.
+	require.NoError(t, cmd.ParseFlags([]string{"--format=csv"}))
+	assert.Equal(t, ports.FormatYAML, getOutputFormat(cmd))
+	require.NoError(t, cmd.ParseFlags([]string{"--json=true"}))
+	assert.Equal(t, ports.FormatJSON, getOutputFormat(cmd))
+}
+
+func TestGetOutputWriter_Template(t *testing.T) {
+	cmd := newOutputTestCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.ParseFlags([]string{"--output=template={{.ID}}: {{.Name}}"}))
+
+	items := []struct{ ID, Name string }{{"a1", "Ana"}, {"b2", "Bob"}}
+	require.NoError(t, GetOutputWriter(cmd).Write(items))
+	assert.Equal(t, "a1: Ana\nb2: Bob\n", buf.String())
+}
+
+func TestOutputFlag_IgnoresCommandFileFlag(t *testing.T) {
+	cmd := &cobra.Command{Use: "export"}
+	cmd.Flags().String("output", "", "Output file path")
+	require.NoError(t, cmd.Flags().Set("output", "audit.json"))
+
+	assert.NoError(t, ValidateOutputFlag(cmd))
+	assert.Equal(t, ports.FormatTable, getOutputFormat(cmd))
+}
+
+func TestValidateOutputFlag_Invalid(t *testing.T) {
+	cmd := newOutputTestCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--output=xml"}))
+	assert.Error(t, ValidateOutputFlag(cmd))
+}
+
+func TestIDsFlag(t *testing.T) {
+	cmd := newOutputTestCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.ParseFlags([]string{"--ids", "--json", "--output=yaml"}))
+
+	assert.True(t, IsIDsOnly(cmd))
+	assert.False(t, IsJSON(cmd), "--ids wins over --json")
+	assert.True(t, IsStructuredOutput(cmd))
+	assert.Equal(t, ports.FormatQuiet, getOutputFormat(cmd))
+
+	items := []struct{ ID, Name string }{{"a1", "Ana"}, {"b2", "Bob"}}
+	require.NoError(t, GetOutputWriter(cmd).Write(items))
+	assert.Equal(t, "a1\nb2\n", buf.String())
+}
+
+func TestQuietModeDropsStatusSymbols(t *testing.T) {
+	defer SetQuiet(false)
+
+	SetQuiet(false)
+	assert.Contains(t, FormatError(NewUserError("boom", "")), "✗ Error: boom")
+
+	SetQuiet(true)
+	msg := FormatError(NewUserError("boom", ""))
+	assert.Contains(t, msg, "Error: boom")
+	assert.NotContains(t, msg, "✗")
+}
@@ -4,12 +4,21 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/ports"
 )
 
 // MaxAPILimit is the maximum number of items the Nylas API returns per request.
 const MaxAPILimit = 200
 
+// DefaultMaxItems is the default --max of list commands: a safety cap on how
+// many items --all fetches.
+const DefaultMaxItems = 10000
+
 // PageResult represents a paginated API response.
 type PageResult[T any] struct {
 	Data       []T    // The items in this page
@@ -61,9 +70,12 @@ func FetchAllPages[T any](ctx context.Context, config PaginationConfig, fetcher
 	cursor := ""
 	pageCount := 0
 
-	var counter *Counter
+	var progress *ProgressBar
 	if config.ShowProgress && !IsQuiet() {
-		counter = NewCounter("Fetching items")
+		progress = NewProgressBar("Fetching items", config.MaxItems)
+		if config.Writer != nil {
+			progress.SetWriter(config.Writer)
+		}
 	}
 
 	for {
@@ -77,8 +89,8 @@ func FetchAllPages[T any](ctx context.Context, config PaginationConfig, fetcher
 		// Fetch the next page
 		page, err := fetcher(ctx, cursor)
 		if err != nil {
-			if counter != nil {
-				counter.Finish()
+			if progress != nil {
+				progress.Finish()
 			}
 			return results, fmt.Errorf("failed to fetch page %d: %w", pageCount+1, err)
 		}
@@ -88,10 +100,12 @@ func FetchAllPages[T any](ctx context.Context, config PaginationConfig, fetcher
 		pageCount++
 
 		// Update progress
-		if counter != nil {
-			for range page.Data {
-				counter.Increment()
+		if progress != nil {
+			fetched := len(results)
+			if config.MaxItems > 0 {
+				fetched = min(fetched, config.MaxItems)
 			}
+			progress.Set(fetched)
 		}
 
 		// Check if we've reached the limit
@@ -118,8 +132,8 @@ func FetchAllPages[T any](ctx context.Context, config PaginationConfig, fetcher
 		cursor = page.NextCursor
 	}
 
-	if counter != nil {
-		counter.Finish()
+	if progress != nil {
+		progress.Finish()
 	}
 
 	return results, nil
@@ -186,3 +200,99 @@ func SetupPagination(limit int, fetchAll bool, maxItems int) PaginationLimits {
 		Mode:  PaginateSinglePage,
 	}
 }
+
+// FetchCap returns the item cap for fetch helpers that page only when the
+// cap is positive: 0 for a single page, MaxItems, or math.MaxInt to follow
+// every page.
+func (p PaginationLimits) FetchCap() int {
+	switch p.Mode {
+	case PaginateWithCap:
+		return p.MaxItems
+	case PaginateAll:
+		return math.MaxInt
+	default:
+		return 0
+	}
+}
+
+// Page is one page of a cursor listing as written for structured output:
+// the items and the cursor of the next page, empty on the last page.
+type Page[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PageSelection holds the --page-token, --all and --max flags of list
+// commands.
+type PageSelection struct {
+	Token string
+	All   bool
+	Max   int
+
+	cmd *cobra.Command
+}
+
+// AddPageSelectionFlags registers --page-token, --all/-a and --max on cmd.
+// noun names the listed items in the flag help, e.g. "messages".
+func AddPageSelectionFlags(cmd *cobra.Command, noun string) *PageSelection {
+	sel := &PageSelection{cmd: cmd}
+	AddPageTokenFlag(cmd, &sel.Token)
+	cmd.Flags().BoolVarP(&sel.All, "all", "a", false, fmt.Sprintf("Fetch all %s, following every page up to --max", noun))
+	cmd.Flags().IntVar(&sel.Max, "max", DefaultMaxItems, fmt.Sprintf("Maximum %s to fetch with --all (0=unlimited)", noun))
+	cmd.MarkFlagsMutuallyExclusive("page-token", "all")
+	return sel
+}
+
+// Cursor reports whether --page-token was given. An empty token starts at
+// the first page. A cursor listing fetches one page and reports the cursor
+// of the next.
+func (s *PageSelection) Cursor() bool {
+	return s != nil && s.cmd != nil && s.cmd.Flags().Changed("page-token")
+}
+
+// Limits resolves the pagination of a listing of limit items.
+func (s *PageSelection) Limits(limit int) PaginationLimits {
+	return SetupPagination(limit, s.All, s.Max)
+}
+
+// Check rejects --page-token for a listing of several accounts, where every
+// account has its own cursor.
+func (s *PageSelection) Check(grants *GrantSelection) error {
+	if s.Cursor() && grants.Multi() {
+		return NewUserError("--page-token can't be combined with --grant all or --grants",
+			"Page tokens belong to a single account; list one account at a time to page through it")
+	}
+	return nil
+}
+
+// WarnCapped notes on stderr when --all stopped at the --max safety cap
+// after fetching n items.
+func (s *PageSelection) WarnCapped(n int) {
+	if !s.All || s.Max <= 0 || n < s.Max || IsQuiet() {
+		return
+	}
+	_, _ = fmt.Fprintf(s.cmd.ErrOrStderr(), "Stopped at %d items (--max); raise --max, or pass --max 0 for no cap\n", s.Max)
+}
+
+// WritePage writes one page of a cursor listing for structured output. JSON
+// and YAML get a Page carrying the next cursor; other formats get the items.
+func WritePage[T any](cmd *cobra.Command, data []T, nextCursor string) error {
+	if data == nil {
+		data = []T{}
+	}
+	out := GetOutputWriter(cmd)
+	switch getOutputFormat(cmd) {
+	case ports.FormatJSON, ports.FormatYAML:
+		return out.Write(Page[T]{Data: data, NextCursor: nextCursor})
+	}
+	return out.Write(data)
+}
+
+// PrintNextPage prints, after a text cursor listing, how to fetch the next
+// page with the same filters. Nothing is printed on the last page.
+func PrintNextPage(cmd *cobra.Command, nextCursor string) {
+	if nextCursor == "" || IsStructuredOutput(cmd) {
+		return
+	}
+	fmt.Printf("\nMore results: rerun with --page-token %s\n", nextCursor)
+}
@@ -3,12 +3,15 @@
 package common
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"math"
 	"strconv"
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -457,3 +460,67 @@ func TestFetchAllPages_EmptyPageClaimingMore(t *testing.T) {
 	assert.Equal(t, 1, fetcherCalls)
 	assert.Empty(t, results)
 }
+
+func TestPaginationLimits_FetchCap(t *testing.T) {
+	assert.Equal(t, 0, SetupPagination(50, false, 0).FetchCap())
+	assert.Equal(t, 500, SetupPagination(500, false, 0).FetchCap())
+	assert.Equal(t, 1000, SetupPagination(50, true, 1000).FetchCap())
+	assert.Equal(t, math.MaxInt, SetupPagination(50, true, 0).FetchCap())
+}
+
+func TestPageSelection(t *testing.T) {
+	newCmd := func() (*cobra.Command, *PageSelection) {
+		cmd := &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+		return cmd, AddPageSelectionFlags(cmd, "items")
+	}
+
+	t.Run("defaults to one page with a safety cap for --all", func(t *testing.T) {
+		cmd, sel := newCmd()
+		require.NoError(t, cmd.ParseFlags(nil))
+		assert.False(t, sel.Cursor())
+		assert.Equal(t, DefaultMaxItems, sel.Max)
+
+		require.NoError(t, cmd.ParseFlags([]string{"--all"}))
+		assert.Equal(t, DefaultMaxItems, sel.Limits(10).FetchCap())
+	})
+
+	t.Run("an empty page token starts a cursor listing", func(t *testing.T) {
+		cmd, sel := newCmd()
+		require.NoError(t, cmd.ParseFlags([]string{"--page-token="}))
+		assert.True(t, sel.Cursor())
+		assert.Empty(t, sel.Token)
+	})
+
+	t.Run("rejects a page token for several accounts", func(t *testing.T) {
+		cmd, sel := newCmd()
+		require.NoError(t, cmd.ParseFlags([]string{"--page-token", "abc"}))
+		assert.Error(t, sel.Check(&GrantSelection{Grant: AllGrants}))
+		assert.NoError(t, sel.Check(&GrantSelection{Grant: "me@example.com"}))
+	})
+
+	t.Run("--page-token and --all are exclusive", func(t *testing.T) {
+		cmd, _ := newCmd()
+		cmd.SetArgs([]string{"--page-token", "abc", "--all"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		assert.Error(t, cmd.Execute())
+	})
+}
+
+func TestWritePage(t *testing.T) {
+	items := []struct{ ID string }{{"a1"}}
+
+	cmd := newOutputTestCmd()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.ParseFlags([]string{"--json"}))
+	require.NoError(t, WritePage(cmd, items, "next"))
+	assert.JSONEq(t, `{"data":[{"ID":"a1"}],"next_cursor":"next"}`, buf.String())
+
+	cmd = newOutputTestCmd()
+	buf.Reset()
+	cmd.SetOut(&buf)
+	require.NoError(t, cmd.ParseFlags([]string{"--ids"}))
+	require.NoError(t, WritePage(cmd, items, "next"))
+	assert.Equal(t, "a1\n", buf.String(), "other formats get the items")
+}
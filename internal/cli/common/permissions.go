@@ -0,0 +1,74 @@
+package common
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// Command annotation keys used to record permission metadata at registration.
+const (
+	AnnotationScopes       = "nylas/scopes"
+	AnnotationCapabilities = "nylas/capabilities"
+)
+
+// RequireScopes records the Nylas scopes a command (and its subcommands) need.
+// It returns cmd so it can wrap a constructor call inline.
+func RequireScopes(cmd *cobra.Command, scopes ...domain.Scope) *cobra.Command {
+	values := make([]string, len(scopes))
+	for i, s := range scopes {
+		values[i] = string(s)
+	}
+	appendAnnotation(cmd, AnnotationScopes, values)
+	return cmd
+}
+
+// RequireCapabilities records local capabilities (gpg, keychain, ...) a
+// command needs. It returns cmd so it can wrap a constructor call inline.
+func RequireCapabilities(cmd *cobra.Command, caps ...domain.Capability) *cobra.Command {
+	values := make([]string, len(caps))
+	for i, c := range caps {
+		values[i] = string(c)
+	}
+	appendAnnotation(cmd, AnnotationCapabilities, values)
+	return cmd
+}
+
+// CommandPermissions returns the scopes and capabilities a command needs,
+// including those inherited from its parent commands. Results are sorted and
+// de-duplicated.
+func CommandPermissions(cmd *cobra.Command) ([]domain.Scope, []domain.Capability) {
+	var scopes []domain.Scope
+	var caps []domain.Capability
+
+	for c := cmd; c != nil; c = c.Parent() {
+		for _, v := range splitAnnotation(c, AnnotationScopes) {
+			scopes = append(scopes, domain.Scope(v))
+		}
+		for _, v := range splitAnnotation(c, AnnotationCapabilities) {
+			caps = append(caps, domain.Capability(v))
+		}
+	}
+
+	slices.Sort(scopes)
+	slices.Sort(caps)
+	return slices.Compact(scopes), slices.Compact(caps)
+}
+
+func appendAnnotation(cmd *cobra.Command, key string, values []string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	existing := splitAnnotation(cmd, key)
+	cmd.Annotations[key] = strings.Join(append(existing, values...), ",")
+}
+
+func splitAnnotation(cmd *cobra.Command, key string) []string {
+	raw := cmd.Annotations[key]
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"golang.org/x/term"
 )
 
 // Spinner provides an animated spinner for indeterminate operations.
@@ -120,39 +122,173 @@ func (s *Spinner) StopWithError(message string) {
 	}
 }
 
-// Counter provides a simple counter display.
-type Counter struct {
-	message string
-	count   int
-	writer  io.Writer
-	mu      sync.Mutex
+// progressLogInterval is how often a ProgressBar writing to a file or pipe
+// logs a line; terminals are redrawn continuously instead.
+var progressLogInterval = 10 * time.Second
+
+// progressRedrawInterval limits how often a terminal bar is redrawn.
+const progressRedrawInterval = 100 * time.Millisecond
+
+// ProgressBar reports progress through a known or unknown number of items,
+// with the rate and, when the total is known, the time remaining. On a
+// terminal it redraws one line as a bar; otherwise it logs a plain line
+// every progressLogInterval so CI and log files stay readable.
+type ProgressBar struct {
+	message  string
+	total    int
+	done     int
+	writer   io.Writer
+	tty      bool
+	start    time.Time
+	lastDraw time.Time
+	drawn    bool
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+// NewProgressBar creates a progress bar for total items. A total of 0 means
+// the total isn't known; only the count and rate are shown.
+func NewProgressBar(message string, total int) *ProgressBar {
+	p := &ProgressBar{message: message, total: total, now: time.Now}
+	p.SetWriter(os.Stderr)
+	p.start = p.now()
+	p.lastDraw = p.start
+	return p
+}
+
+// SetWriter sets the output writer. Only terminals get a redrawn bar.
+func (p *ProgressBar) SetWriter(w io.Writer) *ProgressBar {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.writer = w
+	f, ok := w.(*os.File)
+	p.tty = ok && term.IsTerminal(int(f.Fd())) // #nosec G115 -- file descriptors fit in int
+	return p
+}
+
+// SetTotal changes the number of items, e.g. once a count becomes known.
+func (p *ProgressBar) SetTotal(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
 }
 
-// NewCounter creates a new counter.
-func NewCounter(message string) *Counter {
-	return &Counter{
-		message: message,
-		count:   0,
-		writer:  os.Stderr,
+// Increment records one more finished item.
+func (p *ProgressBar) Increment() {
+	p.Add(1)
+}
+
+// Add records n more finished items.
+func (p *ProgressBar) Add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	p.draw(false)
+}
+
+// Set records the number of items finished so far.
+func (p *ProgressBar) Set(done int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done = done
+	p.draw(false)
+}
+
+// Finish draws the final state and ends the line. Nothing is written when
+// no progress was recorded.
+func (p *ProgressBar) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done == 0 && !p.drawn {
+		return
+	}
+	p.draw(true)
+	if p.tty && !IsQuiet() {
+		_, _ = fmt.Fprintln(p.writer)
 	}
 }
 
-// Increment increments the counter.
-func (c *Counter) Increment() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// draw writes the current state when it is due. Callers hold p.mu.
+func (p *ProgressBar) draw(final bool) {
+	if IsQuiet() {
+		return
+	}
+	now := p.now()
+	interval := progressLogInterval
+	if p.tty {
+		interval = progressRedrawInterval
+	}
+	if !final && p.done != p.total && now.Sub(p.lastDraw) < interval {
+		return
+	}
+	if !final && !p.tty && p.done == p.total {
+		return // the final log line covers it
+	}
+	p.lastDraw, p.drawn = now, true
 
-	c.count++
-	if !IsQuiet() {
-		_, _ = fmt.Fprintf(c.writer, "\r%s: %d", c.message, c.count)
+	if p.tty {
+		_, _ = fmt.Fprintf(p.writer, "\r\033[K%s", p.line(now.Sub(p.start), true, final))
+		return
 	}
+	_, _ = fmt.Fprintln(p.writer, p.line(now.Sub(p.start), false, final))
 }
 
-// Finish completes the counter display.
-func (c *Counter) Finish() {
-	if !IsQuiet() {
-		_, _ = fmt.Fprintln(c.writer)
+// line formats the progress: a bar for terminals, plain text for logs. The
+// final line gives the elapsed time instead of an estimate.
+func (p *ProgressBar) line(elapsed time.Duration, bar, final bool) string {
+	total := max(p.total, p.done)
+	var rate float64
+	if secs := elapsed.Seconds(); secs > 0 {
+		rate = float64(p.done) / secs
+	}
+
+	parts := []string{}
+	switch {
+	case p.total <= 0:
+		parts = append(parts, fmt.Sprintf("%d", p.done))
+	case bar:
+		parts = append(parts, progressBarCells(p.done, total, 20), fmt.Sprintf("%d/%d", p.done, total), fmt.Sprintf("%3d%%", p.done*100/total))
+	default:
+		parts = append(parts, fmt.Sprintf("%d/%d (%d%%)", p.done, total, p.done*100/total))
+	}
+	if rate > 0 {
+		parts = append(parts, formatRate(rate))
+	}
+	switch {
+	case final || (p.total > 0 && p.done >= total):
+		parts = append(parts, "in "+formatProgressDuration(elapsed))
+	case p.total > 0 && rate > 0:
+		parts = append(parts, "ETA "+formatProgressDuration(time.Duration(float64(total-p.done)/rate*float64(time.Second))))
+	}
+
+	if bar {
+		return p.message + "  " + strings.Join(parts, "  ")
+	}
+	return p.message + ": " + strings.Join(parts, ", ")
+}
+
+// progressBarCells draws done/total as width cells.
+func progressBarCells(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = min(done, total) * width / total
+	}
+	return Cyan.Sprint(strings.Repeat("█", filled)) + strings.Repeat("░", width-filled)
+}
+
+func formatRate(rate float64) string {
+	if rate < 10 {
+		return fmt.Sprintf("%.1f/s", rate)
+	}
+	return fmt.Sprintf("%.0f/s", rate)
+}
+
+// formatProgressDuration rounds d to whole seconds, e.g. "1m32s".
+func formatProgressDuration(d time.Duration) string {
+	if d < time.Second {
+		return "<1s"
 	}
+	return d.Round(time.Second).String()
 }
 
 // RunWithSpinner executes a function while displaying a spinner.
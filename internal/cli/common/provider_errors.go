@@ -0,0 +1,166 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// providerRemedy maps a recognizable provider failure to a readable cause and
+// the steps that fix it. Nylas passes Google, Microsoft and IMAP errors through
+// mostly verbatim, so a 403 can mean a quota, an admin policy or a revoked
+// token depending on the provider's wording.
+type providerRemedy struct {
+	// markers are lowercase substrings matched against the error type,
+	// message and raw provider payload. Any one marker is enough.
+	markers []string
+	// statuses restricts the match to these HTTP statuses. Empty matches any
+	// status, which also lets the remedy apply to errors without one.
+	statuses    []int
+	message     string
+	code        string
+	suggestions []string
+}
+
+// providerRemedies is checked in order; put specific markers before broad ones.
+var providerRemedies = []providerRemedy{
+	{
+		markers:  []string{"ratelimitexceeded", "userratelimitexceeded", "quotaexceeded", "dailylimitexceeded", "user-rate limit"},
+		statuses: []int{http.StatusForbidden, http.StatusTooManyRequests},
+		message:  "Google rate limit reached for this account",
+		code:     ErrCodeRateLimited,
+		suggestions: []string{
+			"Google limits requests per user; wait a minute before retrying",
+			"Lower --limit or split large runs into smaller batches",
+			"If the daily quota is exhausted, retry after midnight Pacific time",
+		},
+	},
+	{
+		markers: []string{"admin_policy_enforced", "domain policy", "domain administrator has disabled", "access_denied_by_admin"},
+		message: "Blocked by a Google Workspace admin policy",
+		code:    ErrCodePermissionDenied,
+		suggestions: []string{
+			"Ask your Workspace admin to allow the app under Admin console > Security > API controls",
+			"Then run 'nylas auth login' to re-authorize the grant",
+		},
+	},
+	{
+		markers: []string{"mail service not enabled", "gmail service is not enabled"},
+		message: "Gmail is not enabled for this Google account",
+		code:    ErrCodePermissionDenied,
+		suggestions: []string{
+			"Ask your Workspace admin to turn on Gmail for this user",
+			"Or use a grant for an account that has a mailbox: 'nylas auth switch <grant-id-or-email>'",
+		},
+	},
+	{
+		markers: []string{"aadsts53000", "aadsts53001", "aadsts53002", "aadsts53003", "conditional access"},
+		message: "Blocked by a Microsoft Entra Conditional Access policy",
+		code:    ErrCodePermissionDenied,
+		suggestions: []string{
+			"Ask your Microsoft 365 admin which Conditional Access policy applies (look up the sign-in in Entra ID > Sign-in logs)",
+			"The policy may require a compliant device, an approved location or an exclusion for the Nylas app",
+			"After the policy is updated, run 'nylas auth login' to re-authorize the grant",
+		},
+	},
+	{
+		markers: []string{"aadsts65001", "aadsts90094", "aadsts90008", "admin consent", "consent_required"},
+		message: "Microsoft admin consent is required",
+		code:    ErrCodePermissionDenied,
+		suggestions: []string{
+			"Ask your Microsoft 365 admin to grant tenant-wide consent to the Nylas app in Entra ID > Enterprise applications",
+			"Then run 'nylas auth login' to re-authorize the grant",
+		},
+	},
+	{
+		markers: []string{"aadsts50076", "aadsts50079", "aadsts50158"},
+		message: "Microsoft requires multi-factor authentication for this account",
+		code:    ErrCodeAuthFailed,
+		suggestions: []string{
+			"Run 'nylas auth login' and complete the MFA prompt",
+		},
+	},
+	{
+		markers: []string{"aadsts50055", "aadsts50057", "aadsts50053"},
+		message: "The Microsoft account is disabled, locked or has an expired password",
+		code:    ErrCodeAuthFailed,
+		suggestions: []string{
+			"Sign in at https://outlook.office.com to reset the password or unlock the account",
+			"Then run 'nylas auth login' to re-authorize the grant",
+		},
+	},
+	{
+		markers: []string{"applicationthrottled", "mailboxconcurrency", "errorserverbusy"},
+		message: "Microsoft is throttling requests for this mailbox",
+		code:    ErrCodeRateLimited,
+		suggestions: []string{
+			"Exchange Online limits concurrent requests per mailbox; wait a minute before retrying",
+			"Avoid running several commands against the same mailbox at once",
+		},
+	},
+	{
+		markers: []string{"mailboxnotenabledforrestapi", "mailboxnotsupportedforrestapi", "mailboxnotlicensed"},
+		message: "This Microsoft mailbox cannot be reached through the Graph API",
+		code:    ErrCodePermissionDenied,
+		suggestions: []string{
+			"The user needs an Exchange Online license; on-premises and inactive mailboxes are not supported",
+			"Ask your Microsoft 365 admin to check the user's license in the admin center",
+		},
+	},
+	{
+		markers: []string{"invalid_grant", "token has been expired or revoked", "token has been revoked", "aadsts70008", "aadsts700082"},
+		message: "The provider revoked or expired this grant's authorization",
+		code:    ErrCodeAuthFailed,
+		suggestions: []string{
+			"Run 'nylas auth login' to re-authorize the grant",
+			"Authorization is revoked when the user changes their password or removes app access",
+		},
+	},
+	{
+		markers: []string{"application-specific password", "app password", "web login required", "[alert] please log in via your web browser"},
+		message: "The IMAP provider requires an app password",
+		code:    ErrCodeAuthFailed,
+		suggestions: []string{
+			"Create an app password in the provider's account security settings",
+			"Then run 'nylas auth login' and use the app password instead of the account password",
+		},
+	},
+}
+
+// translateProviderError returns a readable error for a known provider
+// failure, or nil when nothing in the table matches.
+func translateProviderError(err error, statusCode int, requestID string, parts ...string) *CLIError {
+	text := strings.ToLower(strings.Join(parts, " "))
+	for _, remedy := range providerRemedies {
+		if len(remedy.statuses) > 0 && !slices.Contains(remedy.statuses, statusCode) {
+			continue
+		}
+		if !slices.ContainsFunc(remedy.markers, func(m string) bool { return strings.Contains(text, m) }) {
+			continue
+		}
+		return &CLIError{
+			Err:         err,
+			Message:     remedy.message,
+			Suggestions: slices.Clone(remedy.suggestions),
+			Code:        remedy.code,
+			RequestID:   requestID,
+		}
+	}
+	return nil
+}
+
+// translateAPIError applies the provider table to a structured API error,
+// keeping the provider's own wording next to the readable cause.
+func translateAPIError(err error, apiErr *domain.APIError) *CLIError {
+	cliErr := translateProviderError(err, apiErr.StatusCode, apiErr.RequestID, apiErr.Type, apiErr.Message, apiErr.ProviderError)
+	if cliErr == nil {
+		return nil
+	}
+	if msg := strings.TrimSpace(apiErr.Message); msg != "" {
+		cliErr.Message = fmt.Sprintf("%s (%s)", cliErr.Message, Truncate(msg, 160))
+	}
+	return cliErr
+}
@@ -0,0 +1,113 @@
+//go:build !integration
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapError_TranslatesProviderErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantMessage string
+		wantCode    string
+	}{
+		{
+			name: "google rate limit in provider payload",
+			err: &domain.APIError{
+				StatusCode:    http.StatusForbidden,
+				Type:          "provider_error",
+				Message:       "Rate Limit Exceeded",
+				ProviderError: `{"error":{"code":403,"errors":[{"reason":"rateLimitExceeded"}]}}`,
+				RequestID:     "req-1",
+			},
+			wantMessage: "Google rate limit reached for this account (Rate Limit Exceeded)",
+			wantCode:    ErrCodeRateLimited,
+		},
+		{
+			name: "microsoft conditional access",
+			err: &domain.APIError{
+				StatusCode: http.StatusForbidden,
+				Type:       "provider_error",
+				Message:    "AADSTS53003: Access has been blocked by Conditional Access policies.",
+			},
+			wantMessage: "Blocked by a Microsoft Entra Conditional Access policy",
+			wantCode:    ErrCodePermissionDenied,
+		},
+		{
+			name: "microsoft throttling",
+			err: &domain.APIError{
+				StatusCode:    http.StatusTooManyRequests,
+				Type:          "provider_error",
+				ProviderError: `{"error":{"code":"ApplicationThrottled"}}`,
+			},
+			wantMessage: "Microsoft is throttling requests for this mailbox",
+			wantCode:    ErrCodeRateLimited,
+		},
+		{
+			name: "wrapped api error",
+			err: fmt.Errorf("failed to list messages: %w", &domain.APIError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "Token has been expired or revoked.",
+			}),
+			wantMessage: "The provider revoked or expired this grant's authorization",
+			wantCode:    ErrCodeAuthFailed,
+		},
+		{
+			name:        "plain text error",
+			err:         errors.New("sync failed: AADSTS65001: The user or administrator has not consented"),
+			wantMessage: "Microsoft admin consent is required",
+			wantCode:    ErrCodePermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cliErr := WrapError(tt.err)
+			require.NotNil(t, cliErr)
+			assert.Contains(t, cliErr.Message, tt.wantMessage)
+			assert.Equal(t, tt.wantCode, cliErr.Code)
+			assert.NotEmpty(t, cliErr.Suggestions)
+			assert.ErrorIs(t, cliErr, tt.err)
+		})
+	}
+}
+
+func TestWrapError_ProviderTranslationKeepsRequestID(t *testing.T) {
+	cliErr := WrapError(&domain.APIError{
+		StatusCode: http.StatusForbidden,
+		Message:    "AADSTS53003: blocked",
+		RequestID:  "req-42",
+	})
+
+	assert.Equal(t, "req-42", cliErr.RequestID)
+	assert.Contains(t, FormatError(cliErr), "Conditional Access")
+}
+
+func TestWrapError_GoogleRateLimitNeedsMatchingStatus(t *testing.T) {
+	// A 400 that mentions a quota reason is not a rate limit.
+	cliErr := WrapError(&domain.APIError{
+		StatusCode: http.StatusBadRequest,
+		Message:    "quotaExceeded is not a valid label",
+	})
+
+	assert.NotEqual(t, ErrCodeRateLimited, cliErr.Code)
+}
+
+func TestWrapError_UnknownProviderErrorFallsThrough(t *testing.T) {
+	cliErr := WrapError(&domain.APIError{
+		StatusCode: http.StatusForbidden,
+		Type:       "provider_error",
+		Message:    "Something unusual",
+	})
+
+	assert.Equal(t, "Permission denied", cliErr.Message)
+}
@@ -0,0 +1,94 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/recipients"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// maxRecipientCompletions caps the addresses offered for one completion.
+const maxRecipientCompletions = 20
+
+// OpenRecipientIndex opens the local recipient index, kept in the user cache
+// directory next to the response cache.
+func OpenRecipientIndex() (*recipients.Store, error) {
+	root, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return recipients.New(filepath.Join(root, "nylas", "recipients.json")), nil
+}
+
+// CompleteRecipients completes a --to, --cc or --bcc value from the recipient
+// index of the --grant or default grant. Values are comma-separated, so only
+// the part after the last comma is completed.
+func CompleteRecipients(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	grant, _ := cmd.Flags().GetString("grant")
+	grantID, err := GetGrantID([]string{grant})
+	if err != nil || grantID == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	index, err := OpenRecipientIndex()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	indexed, _, err := index.List(grantID)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return recipientCandidates(indexed, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// recipientCandidates offers the indexed addresses that complete the last
+// comma-separated part of toComplete, skipping ones already listed.
+func recipientCandidates(indexed []domain.Recipient, toComplete string) []string {
+	prefix, partial := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, partial = toComplete[:i+1], toComplete[i+1:]
+	}
+	// Keep spacing after the comma so candidates still start with toComplete.
+	trimmed := strings.TrimLeft(partial, " ")
+	prefix, partial = prefix+partial[:len(partial)-len(trimmed)], trimmed
+	var listed []string
+	for _, addr := range strings.Split(prefix, ",") {
+		listed = append(listed, strings.ToLower(strings.TrimSpace(addr)))
+	}
+
+	var out []string
+	for _, r := range recipients.Match(indexed, partial, 0) {
+		if slices.Contains(listed, strings.ToLower(r.Email)) {
+			continue
+		}
+		candidate := prefix + r.Email
+		if r.Name != "" {
+			candidate += "\t" + r.Name
+		}
+		out = append(out, candidate)
+		if len(out) == maxRecipientCompletions {
+			break
+		}
+	}
+	return out
+}
+
+// RecordRecipients counts the addresses of a sent message in the recipient
+// index, when the grant has one. Failures are ignored: the index is only a
+// convenience.
+func RecordRecipients(grantID string, lists ...[]domain.EmailParticipant) {
+	index, err := OpenRecipientIndex()
+	if err != nil {
+		return
+	}
+	var all []domain.EmailParticipant
+	for _, list := range lists {
+		all = append(all, list...)
+	}
+	_ = index.Record(grantID, all, time.Now())
+}
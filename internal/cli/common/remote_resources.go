@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/nylas/cli/internal/domain"
 )
 
@@ -76,6 +79,34 @@ func LoadJSONFile(path string, target any) error {
 	return nil
 }
 
+// LoadJSONOrYAMLFile decodes a JSON file, or a YAML file when the extension is
+// .yaml or .yml, into target. YAML keys follow the target's json tags.
+func LoadJSONOrYAMLFile(path string, target any) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	default:
+		return LoadJSONFile(path, target)
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- user-supplied config path
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	// Round-trip through JSON so the json struct tags apply.
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := json.Unmarshal(encoded, target); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
 // ReadJSONStringMap parses inline JSON or JSON from a file into a map.
 func ReadJSONStringMap(value, file string) (map[string]any, error) {
 	if value != "" && file != "" {
@@ -3,6 +3,7 @@
 package common
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -120,3 +121,27 @@ func TestResolveScopeGrantID_AppScopeRejectsGrantID(t *testing.T) {
 	assert.Empty(t, grantID)
 	assert.Contains(t, err.Error(), "`--grant-id` requires `--scope grant`")
 }
+
+func TestLoadJSONOrYAMLFile(t *testing.T) {
+	type target struct {
+		Name    string `json:"name"`
+		Minutes []int  `json:"reminder_minutes"`
+	}
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte("name: Demo\nreminder_minutes: [10, 60]\n"), 0o600))
+	var fromYAML target
+	require.NoError(t, LoadJSONOrYAMLFile(yamlPath, &fromYAML))
+	assert.Equal(t, target{Name: "Demo", Minutes: []int{10, 60}}, fromYAML)
+
+	jsonPath := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"name":"Demo"}`), 0o600))
+	var fromJSON target
+	require.NoError(t, LoadJSONOrYAMLFile(jsonPath, &fromJSON))
+	assert.Equal(t, "Demo", fromJSON.Name)
+
+	badPath := filepath.Join(dir, "bad.yml")
+	require.NoError(t, os.WriteFile(badPath, []byte("name: [unterminated\n"), 0o600))
+	assert.Error(t, LoadJSONOrYAMLFile(badPath, &fromJSON))
+}
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// AnnotationRunLocally marks commands `nylas daemon` must not run for a
+// client: servers and watchers that run until interrupted, interactive
+// flows, and commands that change process-wide state.
+const AnnotationRunLocally = "nylas/run-locally"
+
+// runAlways is recorded when RunLocally is given no flags.
+const runAlways = "*"
+
+// RunLocally records that cmd (and its subcommands) always run in the
+// invoking process. With flags, only invocations that set one of them do.
+// It returns cmd so it can wrap a constructor call inline.
+func RunLocally(cmd *cobra.Command, flags ...string) *cobra.Command {
+	if len(flags) == 0 {
+		flags = []string{runAlways}
+	}
+	appendAnnotation(cmd, AnnotationRunLocally, flags)
+	return cmd
+}
+
+// MustRunLocally reports whether cmd, invoked with args, has to run in the
+// invoking process. Flags are looked for in args without parsing them, so
+// the command's flag values stay untouched.
+func MustRunLocally(cmd *cobra.Command, args []string) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		for _, name := range splitAnnotation(c, AnnotationRunLocally) {
+			if name == runAlways || hasFlagArg(cmd, name, args) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasFlagArg(cmd *cobra.Command, name string, args []string) bool {
+	shorthand := ""
+	if flag := cmd.Flags().Lookup(name); flag != nil && flag.Shorthand != "" {
+		shorthand = "-" + flag.Shorthand
+	}
+	for _, arg := range args {
+		switch {
+		case arg == "--":
+			return false
+		case arg == "--"+name || strings.HasPrefix(arg, "--"+name+"="):
+			return true
+		case shorthand != "" && !strings.HasPrefix(arg, "--") && strings.HasPrefix(arg, shorthand):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+package common
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// AnnotationOutput records the Go types a command writes with --json.
+const AnnotationOutput = "nylas/output"
+
+// OutputSchemaVersion is the version of the --json output contract. Bump it
+// when a declared output type changes incompatibly: a field is removed,
+// renamed or changes type. Adding fields does not need a bump.
+const OutputSchemaVersion = 1
+
+var (
+	outputTypesMu sync.RWMutex
+	outputTypes   = map[string]reflect.Type{}
+)
+
+// DeclareOutput records the value types a command writes in structured
+// output, one sample per shape (most commands have one). It returns cmd so it
+// can wrap a constructor call inline, like RequireScopes.
+func DeclareOutput(cmd *cobra.Command, samples ...any) *cobra.Command {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+
+	outputTypesMu.Lock()
+	defer outputTypesMu.Unlock()
+
+	// Type names are keyed with newlines: struct type names may contain
+	// commas and semicolons, but never a raw newline.
+	keys := commandOutputKeys(cmd)
+	for _, sample := range samples {
+		t := reflect.TypeOf(sample)
+		outputTypes[t.String()] = t
+		keys = append(keys, t.String())
+	}
+	cmd.Annotations[AnnotationOutput] = strings.Join(keys, "\n")
+	return cmd
+}
+
+// CommandOutputTypes returns the output types declared for cmd. Unlike
+// permissions they are not inherited: each command declares its own.
+func CommandOutputTypes(cmd *cobra.Command) []reflect.Type {
+	outputTypesMu.RLock()
+	defer outputTypesMu.RUnlock()
+
+	var types []reflect.Type
+	for _, key := range commandOutputKeys(cmd) {
+		if t, ok := outputTypes[key]; ok {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func commandOutputKeys(cmd *cobra.Command) []string {
+	raw := cmd.Annotations[AnnotationOutput]
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, "\n")
+}
+
+// OutputSchema returns a JSON Schema (draft 2020-12) describing the --json
+// output of cmd, or nil when the command declares no output.
+func OutputSchema(cmd *cobra.Command) map[string]any {
+	types := CommandOutputTypes(cmd)
+	if len(types) == 0 {
+		return nil
+	}
+
+	b := &schemaBuilder{defs: map[string]any{}}
+	schema := map[string]any{}
+	if len(types) == 1 {
+		schema = b.schemaFor(types[0])
+	} else {
+		shapes := make([]any, len(types))
+		for i, t := range types {
+			shapes[i] = b.schemaFor(t)
+		}
+		schema["anyOf"] = shapes
+	}
+
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = OutputSchemaID(cmd)
+	schema["title"] = cmd.CommandPath()
+	schema["x-nylas-schema-version"] = OutputSchemaVersion
+	if len(b.defs) > 0 {
+		schema["$defs"] = b.defs
+	}
+	return schema
+}
+
+// OutputSchemaID names the output schema of cmd by command path and version,
+// e.g. "urn:nylas:cli:output:v1:email.list". It is "" when cmd declares no
+// output.
+func OutputSchemaID(cmd *cobra.Command) string {
+	if len(commandOutputKeys(cmd)) == 0 {
+		return ""
+	}
+	path := strings.Fields(cmd.CommandPath())
+	if len(path) > 1 {
+		path = path[1:]
+	}
+	return "urn:nylas:cli:output:v" + strconv.Itoa(OutputSchemaVersion) + ":" + strings.Join(path, ".")
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schemaBuilder converts Go types to JSON Schema the way encoding/json would
+// marshal them. Named struct types go in $defs so shared and recursive types
+// are described once.
+type schemaBuilder struct {
+	defs map[string]any
+}
+
+func (b *schemaBuilder) schemaFor(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		return map[string]any{}
+	case implements(t, jsonMarshalerType):
+		// Custom JSON encoding: the shape is not knowable from the type.
+		return map[string]any{}
+	case implements(t, textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := defName(t)
+		if _, ok := b.defs[name]; !ok {
+			b.defs[name] = map[string]any{} // placeholder for recursive types
+			b.defs[name] = b.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	default:
+		// Interfaces and anything else encoding/json can hold.
+		return map[string]any{}
+	}
+}
+
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	b.addFields(t, properties, &required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// addFields adds t's fields to properties, flattening untagged embedded
+// structs as encoding/json does. Fields without omitempty are required.
+func (b *schemaBuilder) addFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				b.addFields(ft, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		schema := b.schemaFor(field.Type)
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			*required = append(*required, name)
+			// Always present, but nil values are written as null.
+			switch field.Type.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+				if len(schema) > 0 {
+					schema = map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+				}
+			}
+		}
+		properties[name] = schema
+	}
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// importPath matches the import path before a package name in the type
+// arguments of a generic type.
+var importPath = regexp.MustCompile(`[\w.-]+(/[\w.-]+)*/`)
+
+// defName is the $defs key for a named type, e.g. "domain.Message", or
+// "common.Page_domain.Message_" for common.Page[domain.Message].
+func defName(t reflect.Type) string {
+	name := importPath.ReplaceAllString(t.String(), "")
+	return strings.NewReplacer("[", "_", "]", "_", "*", "", "/", "_").Replace(name)
+}
@@ -0,0 +1,75 @@
+//go:build !integration
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaFixtureBase struct {
+	ID string `json:"id"`
+}
+
+type schemaFixtureNode struct {
+	schemaFixtureBase
+	Name     string              `json:"name"`
+	Note     string              `json:"note,omitempty"`
+	Created  time.Time           `json:"created_at"`
+	Tags     []string            `json:"tags"`
+	Counts   map[string]int      `json:"counts,omitempty"`
+	Children []schemaFixtureNode `json:"children,omitempty"`
+	Secret   string              `json:"-"`
+	internal string
+}
+
+func TestOutputSchema(t *testing.T) {
+	root := &cobra.Command{Use: "nylas"}
+	list := DeclareOutput(&cobra.Command{Use: "list"}, []schemaFixtureNode{})
+	group := &cobra.Command{Use: "nodes"}
+	group.AddCommand(list)
+	root.AddCommand(group)
+
+	schema := OutputSchema(list)
+	require.NotNil(t, schema)
+	assert.Equal(t, "urn:nylas:cli:output:v1:nodes.list", schema["$id"])
+	assert.Equal(t, OutputSchemaVersion, schema["x-nylas-schema-version"])
+	assert.Equal(t, "array", schema["type"])
+
+	defs := schema["$defs"].(map[string]any)
+	node := defs["common.schemaFixtureNode"].(map[string]any)
+	props := node["properties"].(map[string]any)
+
+	assert.Contains(t, props, "id", "embedded struct fields are flattened")
+	assert.NotContains(t, props, "Secret")
+	assert.NotContains(t, props, "internal")
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, props["created_at"])
+	assert.Equal(t, map[string]any{"$ref": "#/$defs/common.schemaFixtureNode"},
+		props["children"].(map[string]any)["items"], "recursive types refer to their definition")
+	assert.Equal(t, []string{"id", "name", "created_at", "tags"}, node["required"])
+
+	tags := props["tags"].(map[string]any)
+	assert.Len(t, tags["anyOf"], 2, "required slices may be null")
+}
+
+func TestOutputSchema_Undeclared(t *testing.T) {
+	parent := DeclareOutput(&cobra.Command{Use: "nodes"}, schemaFixtureNode{})
+	child := &cobra.Command{Use: "list"}
+	parent.AddCommand(child)
+
+	assert.Nil(t, OutputSchema(child), "output declarations are not inherited")
+	assert.Empty(t, OutputSchemaID(child))
+}
+
+func TestOutputSchema_SeveralShapes(t *testing.T) {
+	cmd := DeclareOutput(&cobra.Command{Use: "show"}, schemaFixtureNode{}, CountResult{})
+
+	schema := OutputSchema(cmd)
+	require.NotNil(t, schema)
+	assert.Len(t, schema["anyOf"], 2)
+	assert.Len(t, CommandOutputTypes(cmd), 2)
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/statuspage"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newStatusPage is replaced in tests.
+var newStatusPage = func() ports.StatusPage { return statuspage.New() }
+
+// statusCheckEnabled is replaced in tests.
+var statusCheckEnabled = func() bool {
+	cfg, err := config.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil {
+		cfg = domain.DefaultConfig()
+	}
+	return cfg.StatusCheckEnabled()
+}
+
+// AnnotateServiceStatus adds status page notes (for example "Nylas reports
+// degraded Google since 09:20 UTC") to errors that look like a Nylas or
+// provider outage. It is a no-op unless api.status_check or
+// NYLAS_STATUS_CHECK is enabled, and returns err unchanged when the status
+// page is unreachable or reports no problems.
+func AnnotateServiceStatus(err error) error {
+	if err == nil || !isOutageCandidate(err) || !statusCheckEnabled() {
+		return err
+	}
+
+	ctx, cancel := CreateContextWithTimeout(domain.TimeoutQuickCheck)
+	defer cancel()
+	status, statusErr := newStatusPage().Summary(ctx)
+	if statusErr != nil {
+		return err
+	}
+	notes := status.OutageNotes(time.Now())
+	if len(notes) == 0 {
+		return err
+	}
+
+	annotated := *WrapError(err)
+	suggestions := annotated.Suggestions
+	if len(suggestions) == 0 && annotated.Suggestion != "" {
+		suggestions = []string{annotated.Suggestion}
+	}
+	annotated.Suggestions = append(append(notes, "Details: "+status.PageURL), suggestions...)
+	return &annotated
+}
+
+// isOutageCandidate reports whether err could be caused by a service or
+// provider outage: server errors, network failures, and provider errors.
+func isOutageCandidate(err error) bool {
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError ||
+			strings.Contains(strings.ToLower(apiErr.Type), "provider")
+	}
+	cliErr := WrapError(err)
+	return slices.Contains([]string{ErrCodeNetworkError, ErrCodeServerError}, cliErr.Code)
+}
@@ -0,0 +1,99 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStatusPage struct {
+	status *domain.ServiceStatus
+	err    error
+	calls  int
+}
+
+func (f *fakeStatusPage) Summary(context.Context) (*domain.ServiceStatus, error) {
+	f.calls++
+	return f.status, f.err
+}
+
+func swapStatusPageForTest(t *testing.T, page *fakeStatusPage, enabled bool) {
+	t.Helper()
+	origPage, origEnabled := newStatusPage, statusCheckEnabled
+	newStatusPage = func() ports.StatusPage { return page }
+	statusCheckEnabled = func() bool { return enabled }
+	t.Cleanup(func() { newStatusPage, statusCheckEnabled = origPage, origEnabled })
+}
+
+func degradedGoogle() *domain.ServiceStatus {
+	return &domain.ServiceStatus{
+		PageURL: "https://status.nylas.com",
+		Components: []domain.StatusComponent{
+			{ID: "g", Name: "Google", Status: "degraded_performance", UpdatedAt: time.Now().Add(-time.Minute)},
+		},
+	}
+}
+
+func TestAnnotateServiceStatus(t *testing.T) {
+	serverErr := &domain.APIError{StatusCode: 503, Message: "service unavailable"}
+
+	t.Run("adds notes to server errors", func(t *testing.T) {
+		page := &fakeStatusPage{status: degradedGoogle()}
+		swapStatusPageForTest(t, page, true)
+
+		err := AnnotateServiceStatus(serverErr)
+		var cliErr *CLIError
+		require.True(t, errors.As(err, &cliErr))
+		require.GreaterOrEqual(t, len(cliErr.Suggestions), 2)
+		assert.Contains(t, cliErr.Suggestions[0], "Nylas reports degraded Google since")
+		assert.Equal(t, "Details: https://status.nylas.com", cliErr.Suggestions[1])
+		assert.ErrorIs(t, err, serverErr)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		page := &fakeStatusPage{status: degradedGoogle()}
+		swapStatusPageForTest(t, page, false)
+
+		assert.Same(t, serverErr, AnnotateServiceStatus(serverErr))
+		assert.Zero(t, page.calls)
+	})
+
+	t.Run("client errors are not checked", func(t *testing.T) {
+		page := &fakeStatusPage{status: degradedGoogle()}
+		swapStatusPageForTest(t, page, true)
+
+		notFound := &domain.APIError{StatusCode: 404, Message: "not found"}
+		assert.Same(t, notFound, AnnotateServiceStatus(notFound))
+		assert.Zero(t, page.calls)
+	})
+
+	t.Run("status page failure keeps original error", func(t *testing.T) {
+		swapStatusPageForTest(t, &fakeStatusPage{err: errors.New("offline")}, true)
+
+		assert.Same(t, serverErr, AnnotateServiceStatus(serverErr))
+	})
+
+	t.Run("all operational keeps original error", func(t *testing.T) {
+		swapStatusPageForTest(t, &fakeStatusPage{status: &domain.ServiceStatus{}}, true)
+
+		assert.Same(t, serverErr, AnnotateServiceStatus(serverErr))
+	})
+}
+
+func TestConfig_StatusCheckEnabled(t *testing.T) {
+	cfg := domain.DefaultConfig()
+	t.Setenv("NYLAS_STATUS_CHECK", "")
+	assert.False(t, cfg.StatusCheckEnabled())
+
+	cfg.API = &domain.APIConfig{StatusCheck: true}
+	assert.True(t, cfg.StatusCheckEnabled())
+
+	t.Setenv("NYLAS_STATUS_CHECK", "false")
+	assert.False(t, cfg.StatusCheckEnabled())
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	adapterconfig "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/configbundle"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// envConfigPassphrase supplies the bundle or config file passphrase without
+// a prompt.
+const envConfigPassphrase = adapterconfig.PassphraseEnv
+
+func newExportCmd() *cobra.Command {
+	var (
+		out     string
+		encrypt bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export this machine's CLI setup to a file",
+		Long: `Export the CLI setup so it can be imported on another machine in one step.
+
+The bundle contains config.yaml (environment profiles, saved searches, AI,
+email and calendar settings), local templates, custom TUI themes, the audit
+log settings and the list of connected grants.
+
+Credentials (API keys, client secret, SMTP password) are only included with
+--encrypt. An AI API key written literally in config.yaml also needs
+--encrypt; without it the export is refused. The bundle is then encrypted with a passphrase, read from
+NYLAS_CONFIG_PASSPHRASE or prompted for. Dashboard sessions and the grant
+token signing key stay on this machine.`,
+		Example: `  # Everything except secrets, as readable JSON
+  nylas config export --out nylas-config.json
+
+  # Everything including credentials, encrypted
+  nylas config export --encrypt --out nylas-config.enc`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			grants, err := common.NewDefaultGrantStore()
+			if err != nil {
+				return fmt.Errorf("access grant store: %w", err)
+			}
+			if configStore.IsEncrypted() && !encrypt {
+				return common.NewUserError("config file is encrypted", "Add --encrypt so the exported bundle is encrypted too")
+			}
+			var secrets ports.SecretStore
+			passphrase := ""
+			if encrypt {
+				if secrets, err = keyring.NewSecretStore(adapterconfig.DefaultConfigDir()); err != nil {
+					return fmt.Errorf("access secret store: %w", err)
+				}
+				if passphrase, err = readPassphrase(true); err != nil {
+					return err
+				}
+			}
+
+			bundle, err := configbundle.Collect(filepath.Dir(configStore.Path()), grants, secrets)
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			data, err := configbundle.Marshal(bundle, passphrase)
+			if errors.Is(err, configbundle.ErrPlaintextSecrets) {
+				return common.NewUserError(err.Error(),
+					"Add --encrypt, or move the keys to the secret store with 'nylas config migrate-secrets'")
+			}
+			if err != nil {
+				return err
+			}
+
+			if out == "" || out == "-" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+			if err := os.WriteFile(out, data, 0600); err != nil {
+				return common.WrapWriteError("config bundle", err)
+			}
+			common.PrintSuccess("Exported %d files, %d grants and %d secrets to %s",
+				len(bundle.Files), len(bundle.Grants), len(bundle.Secrets), out)
+			if !encrypt {
+				fmt.Println(common.Dim.Sprintf("Credentials were not included; use --encrypt to carry them."))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&out, "out", "o", "", "File to write (default: stdout)")
+	cmd.Flags().BoolVar(&encrypt, "encrypt", false, "Include credentials and encrypt the bundle with a passphrase")
+
+	return cmd
+}
+
+func newImportCmd() *cobra.Command {
+	var (
+		force  bool
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import a CLI setup exported with 'config export'",
+		Long: `Import a bundle written by 'nylas config export'.
+
+Files, grants and credentials that don't exist here are created. Existing
+files and credentials with different contents are kept unless --force is
+given. Encrypted bundles ask for their passphrase, or read it from
+NYLAS_CONFIG_PASSPHRASE. Use "-" to read the bundle from stdin.`,
+		Example: `  # Preview what would change
+  nylas config import nylas-config.enc --dry-run
+
+  # Import, replacing this machine's settings
+  nylas config import nylas-config.enc --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				data []byte
+				err  error
+			)
+			if args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return common.WrapLoadError("config bundle", err)
+			}
+
+			passphrase := ""
+			if configbundle.IsEncrypted(data) {
+				if passphrase, err = readPassphrase(false); err != nil {
+					return err
+				}
+			}
+			bundle, err := configbundle.Unmarshal(data, passphrase)
+			if errors.Is(err, keyring.ErrWrongPassphrase) {
+				return common.NewUserError("could not decrypt the config bundle", "Check the passphrase used for 'nylas config export --encrypt'")
+			}
+			if err != nil {
+				return common.NewUserError(err.Error(), "Import a file written by 'nylas config export'")
+			}
+
+			grants, err := common.NewDefaultGrantStore()
+			if err != nil {
+				return fmt.Errorf("access grant store: %w", err)
+			}
+			var secrets ports.SecretStore
+			if len(bundle.Secrets) > 0 {
+				if secrets, err = keyring.NewSecretStore(adapterconfig.DefaultConfigDir()); err != nil {
+					return fmt.Errorf("access secret store: %w", err)
+				}
+			}
+
+			changes, err := configbundle.Apply(bundle, filepath.Dir(configStore.Path()), grants, secrets,
+				configbundle.ApplyOptions{Overwrite: force, DryRun: dryRun})
+			if err != nil {
+				return err
+			}
+			return printImportChanges(cmd, changes, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Replace existing files and credentials")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without writing anything")
+
+	return cmd
+}
+
+func printImportChanges(cmd *cobra.Command, changes []configbundle.Change, dryRun bool) error {
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(changes)
+	}
+
+	table := common.NewTable("KIND", "NAME", "ACTION")
+	skipped := 0
+	for _, c := range changes {
+		table.AddRow(c.Kind, c.Name, c.Action)
+		if c.Action == "skip" {
+			skipped++
+		}
+	}
+	table.Render()
+
+	if dryRun {
+		fmt.Println(common.Dim.Sprintf("Dry run: nothing was written."))
+	} else {
+		common.PrintSuccess("Imported config bundle")
+	}
+	if skipped > 0 {
+		fmt.Println(common.Dim.Sprintf("%d existing item(s) kept; re-run with --force to replace them.", skipped))
+	}
+	return nil
+}
+
+// readPassphrase reads a passphrase from NYLAS_CONFIG_PASSPHRASE or
+// the terminal, asking twice when confirm is set.
+func readPassphrase(confirm bool) (string, error) {
+	if p := os.Getenv(envConfigPassphrase); p != "" {
+		return checkPassphrase(p, confirm)
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", common.NewUserError("a passphrase is required", "Set "+envConfigPassphrase+" when not running in a terminal")
+	}
+	_, _ = fmt.Fprint(os.Stderr, "Passphrase: ")
+	first, err := term.ReadPassword(fd)
+	_, _ = fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", common.WrapError(err)
+	}
+	if confirm {
+		_, _ = fmt.Fprint(os.Stderr, "Confirm passphrase: ")
+		second, err := term.ReadPassword(fd)
+		_, _ = fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", common.WrapError(err)
+		}
+		if string(first) != string(second) {
+			return "", common.NewUserError("passphrases do not match", "Run the command again")
+		}
+	}
+	return checkPassphrase(strings.TrimRight(string(first), "\r\n"), confirm)
+}
+
+// checkPassphrase enforces the minimum length for new bundles; existing
+// bundles are opened with whatever passphrase they were sealed with.
+func checkPassphrase(p string, creating bool) (string, error) {
+	if creating && len(p) < keyring.MinPassphraseLen {
+		return "", common.NewUserError(
+			fmt.Sprintf("passphrase must be at least %d characters", keyring.MinPassphraseLen),
+			"Choose a longer passphrase",
+		)
+	}
+	return p, nil
+}
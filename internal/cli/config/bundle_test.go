@@ -0,0 +1,112 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configadapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// useMachine points the config, cache and file secret store at a fresh
+// temp "machine".
+func useMachine(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config-home"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache-home"))
+	configStore = configadapter.NewDefaultFileStore()
+	return configadapter.DefaultConfigDir()
+}
+
+func TestConfigExportImport(t *testing.T) {
+	t.Setenv("NYLAS_DISABLE_KEYRING", "true")
+	t.Setenv("NYLAS_FILE_STORE_PASSPHRASE", "test-passphrase")
+	t.Setenv(envConfigPassphrase, "bundle-passphrase")
+	originalStore := configStore
+	t.Cleanup(func() { configStore = originalStore })
+
+	// Source machine.
+	useMachine(t)
+	cfg := domain.DefaultConfig()
+	cfg.TUITheme = "dark"
+	require.NoError(t, configStore.Save(cfg))
+	grants, err := common.NewDefaultGrantStore()
+	require.NoError(t, err)
+	require.NoError(t, grants.SaveGrant(domain.GrantInfo{ID: "grant-1", Email: "a@example.com"}))
+	secrets, err := keyring.NewSecretStore(configadapter.DefaultConfigDir())
+	require.NoError(t, err)
+	require.NoError(t, secrets.Set(ports.KeyAPIKey, "nyk_source"))
+
+	bundlePath := filepath.Join(t.TempDir(), "nylas-config.enc")
+	export := newExportCmd()
+	export.SetArgs([]string{"--encrypt", "--out", bundlePath})
+	require.NoError(t, export.Execute())
+	data, err := os.ReadFile(bundlePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "nyk_source")
+
+	// Target machine.
+	useMachine(t)
+	dryRun := newImportCmd()
+	dryRun.SetArgs([]string{bundlePath, "--dry-run"})
+	require.NoError(t, dryRun.Execute())
+	assert.False(t, configStore.Exists(), "dry run writes nothing")
+
+	importCmd := newImportCmd()
+	importCmd.SetArgs([]string{bundlePath})
+	require.NoError(t, importCmd.Execute())
+
+	got, err := configStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "dark", got.TUITheme)
+	grants, err = common.NewDefaultGrantStore()
+	require.NoError(t, err)
+	grant, err := grants.GetGrant("grant-1")
+	require.NoError(t, err)
+	assert.Equal(t, "a@example.com", grant.Email)
+	secrets, err = keyring.NewSecretStore(configadapter.DefaultConfigDir())
+	require.NoError(t, err)
+	apiKey, err := secrets.Get(ports.KeyAPIKey)
+	require.NoError(t, err)
+	assert.Equal(t, "nyk_source", apiKey)
+
+	t.Setenv(envConfigPassphrase, "not the passphrase")
+	wrong := newImportCmd()
+	wrong.SetArgs([]string{bundlePath})
+	wrong.SetOut(&bytes.Buffer{})
+	wrong.SetErr(&bytes.Buffer{})
+	err = wrong.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not decrypt")
+}
+
+func TestConfigExportPlainToStdout(t *testing.T) {
+	originalStore := configStore
+	t.Cleanup(func() { configStore = originalStore })
+	useMachine(t)
+	require.NoError(t, configStore.Save(domain.DefaultConfig()))
+
+	var out bytes.Buffer
+	cmd := newExportCmd()
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), `"config.yaml"`)
+	assert.NotContains(t, out.String(), `"secrets"`)
+}
+
+func TestCheckPassphrase(t *testing.T) {
+	_, err := checkPassphrase("short", true)
+	assert.Error(t, err)
+	p, err := checkPassphrase("short", false)
+	require.NoError(t, err)
+	assert.Equal(t, "short", p)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/configbundle"
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -45,6 +46,16 @@ If the config file doesn't exist, sensible defaults are used automatically.`,
   # Initialize config with defaults
   nylas config init
 
+  # Move this setup, including credentials, to another machine
+  nylas config export --encrypt --out nylas-config.enc
+  nylas config import nylas-config.enc
+
+  # Move plaintext API keys out of config.yaml into the OS keychain
+  nylas config migrate-secrets
+
+  # Encrypt config.yaml with a passphrase (servers without a keychain)
+  nylas config encrypt
+
   # Reset everything (credentials, grants, config)
   nylas config reset`,
 	}
@@ -55,6 +66,12 @@ If the config file doesn't exist, sensible defaults are used automatically.`,
 	cmd.AddCommand(newInitCmd())
 	cmd.AddCommand(newPathCmd())
 	cmd.AddCommand(newResetCmd())
+	cmd.AddCommand(newEnvCmd())
+	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(common.DeclareOutput(newImportCmd(), []configbundle.Change{}))
+	cmd.AddCommand(common.DeclareOutput(newMigrateSecretsCmd(), []secretMove{}))
+	cmd.AddCommand(common.RunLocally(newEncryptCmd()))
+	cmd.AddCommand(common.RunLocally(newDecryptCmd()))
 
 	return cmd
 }
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func newEncryptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the config file with a passphrase",
+		Long: `Encrypt config.yaml with a passphrase, for headless servers without an OS
+keychain.
+
+The passphrase is read from NYLAS_CONFIG_PASSPHRASE or prompted for. Every
+later command needs it: set NYLAS_CONFIG_PASSPHRASE, or enter it when asked.
+Changes made with 'nylas config set' and other commands stay encrypted.
+
+This protects the settings in config.yaml. API keys belong in the secret
+store; see 'nylas config migrate-secrets'.`,
+		Example: `  # Encrypt, prompting for a new passphrase
+  nylas config encrypt
+
+  # Non-interactive
+  NYLAS_CONFIG_PASSPHRASE=... nylas config encrypt`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if configStore.IsEncrypted() {
+				return common.NewUserError("config file is already encrypted", "Run 'nylas config decrypt' first to change the passphrase")
+			}
+			if !configStore.Exists() {
+				if err := configStore.Save(domain.DefaultConfig()); err != nil {
+					return common.WrapSaveError("config", err)
+				}
+			}
+			passphrase, err := readPassphrase(true)
+			if err != nil {
+				return err
+			}
+			if err := configStore.Encrypt(passphrase); err != nil {
+				return fmt.Errorf("encrypt config: %w", err)
+			}
+			common.PrintSuccess("Encrypted %s", configStore.Path())
+			fmt.Println(common.Dim.Sprintf("Commands now need the passphrase: set NYLAS_CONFIG_PASSPHRASE or enter it when asked."))
+			return nil
+		},
+	}
+}
+
+func newDecryptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "decrypt",
+		Short: "Store the config file as plain YAML again",
+		Long: `Decrypt config.yaml, written by 'nylas config encrypt', back to plain YAML.
+The passphrase is read from NYLAS_CONFIG_PASSPHRASE or prompted for.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !configStore.IsEncrypted() {
+				return common.NewUserError("config file is not encrypted", "")
+			}
+			if err := configStore.Decrypt(); err != nil {
+				return err
+			}
+			common.PrintSuccess("Decrypted %s", configStore.Path())
+			return nil
+		},
+	}
+}
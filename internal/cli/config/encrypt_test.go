@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configadapter "github.com/nylas/cli/internal/adapters/config"
+)
+
+func runConfigCmd(t *testing.T, args ...string) error {
+	t.Helper()
+	cmd := NewConfigCmd()
+	cmd.SetArgs(args)
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	return cmd.Execute()
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	setupEnvTest(t)
+	t.Cleanup(func() { configadapter.SetPassphraseSource(nil) })
+	t.Setenv(envConfigPassphrase, "config-passphrase")
+
+	require.NoError(t, runConfigCmd(t, "set", "region", "eu"))
+	require.NoError(t, runConfigCmd(t, "encrypt"))
+	assert.True(t, configStore.IsEncrypted())
+	assert.NotContains(t, readConfigFile(t), "region")
+	assert.ErrorContains(t, runConfigCmd(t, "encrypt"), "already encrypted")
+
+	// Later commands read and write through the passphrase.
+	configadapter.SetPassphraseSource(nil)
+	require.NoError(t, runConfigCmd(t, "set", "callback_port", "9100"))
+	assert.True(t, configStore.IsEncrypted())
+	cfg, err := configStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "eu", cfg.Region)
+	assert.Equal(t, 9100, cfg.CallbackPort)
+
+	assert.ErrorContains(t, runConfigCmd(t, "export", "--out", t.TempDir()+"/bundle.json"), "encrypted")
+
+	t.Setenv(envConfigPassphrase, "")
+	configadapter.SetPassphraseSource(nil)
+	_, err = configStore.Load()
+	assert.ErrorIs(t, err, configadapter.ErrPassphraseRequired)
+
+	t.Setenv(envConfigPassphrase, "config-passphrase")
+	require.NoError(t, runConfigCmd(t, "decrypt"))
+	assert.False(t, configStore.IsEncrypted())
+	assert.Contains(t, readConfigFile(t), "region: eu")
+	assert.ErrorContains(t, runConfigCmd(t, "decrypt"), "not encrypted")
+}
@@ -0,0 +1,321 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	adapterconfig "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// openSecretStore opens the store holding per-environment API keys and
+// secrets moved out of config.yaml.
+// Replaced in tests.
+var openSecretStore = func() (ports.SecretStore, error) {
+	return keyring.NewSecretStore(adapterconfig.DefaultConfigDir())
+}
+
+// envEntry is one row of `config env list`.
+type envEntry struct {
+	Name         string `json:"name"`
+	Active       bool   `json:"active"`
+	Region       string `json:"region,omitempty"`
+	BaseURL      string `json:"base_url,omitempty"`
+	DefaultGrant string `json:"default_grant,omitempty"`
+	HasAPIKey    bool   `json:"has_api_key"`
+}
+
+func newEnvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "env",
+		Aliases: []string{"environments"},
+		Short:   "Manage environment profiles (sandbox, production, ...)",
+		Long: `Manage named environment profiles. Each profile has its own API key,
+region or base URL, and default grant, so you can switch between a sandbox
+and a production application without re-running auth.
+
+The active profile is chosen by, in order:
+  1. The --env flag:        nylas --env staging email list
+  2. NYLAS_ENV:             NYLAS_ENV=staging nylas email list
+  3. 'nylas config env use <name>' (saved in config.yaml)
+
+The name "default" selects the top-level settings from 'nylas auth config'.
+NYLAS_API_KEY and NYLAS_GRANT_ID still override any profile.`,
+		Example: `  # Create profiles
+  nylas config env create sandbox --api-key nyk_sandbox... --grant grant_123
+  nylas config env create prod --region eu --api-key-file ~/prod-key.txt
+
+  # Run one command against a profile
+  nylas --env sandbox email list
+
+  # Switch the default profile
+  nylas config env use prod
+  nylas config env use default`,
+	}
+
+	cmd.AddCommand(newEnvCreateCmd())
+	cmd.AddCommand(common.DeclareOutput(newEnvListCmd(), []envEntry{}))
+	cmd.AddCommand(newEnvUseCmd())
+	cmd.AddCommand(newEnvCurrentCmd())
+	cmd.AddCommand(newEnvDeleteCmd())
+
+	return cmd
+}
+
+func newEnvCreateCmd() *cobra.Command {
+	var (
+		profile    domain.EnvironmentProfile
+		apiKey     string
+		apiKeyFile string
+		use        bool
+		force      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create or replace an environment profile",
+		Example: `  nylas config env create staging --region us --api-key nyk_...
+  nylas config env create local --base-url http://localhost:8080 --api-key test --use`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if err := domain.ValidateEnvironmentName(name); err != nil {
+				return common.NewInputError(err.Error())
+			}
+			if err := common.ValidateOneOf("region", profile.Region, []string{"us", "eu"}); err != nil {
+				return err
+			}
+			key, err := common.ReadStringOrFile("api-key", apiKey, apiKeyFile, false)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := configStore.Load()
+			if err != nil {
+				return common.WrapLoadError("configuration", err)
+			}
+			if _, exists := cfg.Environments[name]; exists && !force {
+				return common.NewUserError(
+					fmt.Sprintf("environment %q already exists", name),
+					"Use --force to replace it",
+				)
+			}
+
+			if key = strings.TrimSpace(key); key != "" {
+				secrets, err := openSecretStore()
+				if err != nil {
+					return fmt.Errorf("access secret store: %w", err)
+				}
+				if err := secrets.Set(ports.EnvironmentSecretKey(name, ports.KeyAPIKey), key); err != nil {
+					return fmt.Errorf("store API key: %w", err)
+				}
+			}
+
+			if cfg.Environments == nil {
+				cfg.Environments = map[string]*domain.EnvironmentProfile{}
+			}
+			p := profile
+			cfg.Environments[name] = &p
+			if use {
+				cfg.CurrentEnv = name
+			}
+			if err := configStore.Save(cfg); err != nil {
+				return common.WrapSaveError("configuration", err)
+			}
+
+			common.PrintSuccess("Environment %q saved", name)
+			if key == "" {
+				common.PrintWarningStderr("no API key stored; add one with --api-key and --force before using %q", name)
+			}
+			if !use {
+				fmt.Printf("Use it with: nylas --env %s <command>  or  nylas config env use %s\n", name, name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&profile.Region, "region", "us", "API region: us or eu")
+	cmd.Flags().StringVar(&profile.BaseURL, "base-url", "", "API base URL (overrides --region)")
+	cmd.Flags().StringVar(&profile.DefaultGrant, "grant", "", "Default grant ID for this environment")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for this environment")
+	cmd.Flags().StringVar(&apiKeyFile, "api-key-file", "", "Read the API key from a file")
+	cmd.Flags().BoolVar(&use, "use", false, "Make this the active environment")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing environment")
+
+	return cmd
+}
+
+func newEnvListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List environment profiles",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := configStore.Load()
+			if err != nil {
+				return common.WrapLoadError("configuration", err)
+			}
+
+			entries := listEnvironments(cfg)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(entries)
+			}
+			if len(cfg.Environments) == 0 {
+				common.PrintEmptyStateWithHint("environments", "Create one with: nylas config env create <name> --api-key <key>")
+				return nil
+			}
+
+			table := common.NewTable("", "NAME", "REGION", "BASE URL", "DEFAULT GRANT", "API KEY")
+			for _, e := range entries {
+				marker, keyState := "", "stored"
+				if e.Active {
+					marker = "*"
+				}
+				if !e.HasAPIKey {
+					keyState = common.Yellow.Sprint("missing")
+				}
+				if e.Name == domain.DefaultEnvironment {
+					keyState = "(auth config)"
+				}
+				table.AddRow(marker, e.Name, e.Region, e.BaseURL, e.DefaultGrant, keyState)
+			}
+			table.Render()
+			return nil
+		},
+	}
+}
+
+// listEnvironments returns the top-level settings as "default" followed by
+// each profile.
+func listEnvironments(cfg *domain.Config) []envEntry {
+	active := common.ActiveEnvironment(cfg)
+	baseURL := ""
+	if cfg.API != nil {
+		baseURL = cfg.API.BaseURL
+	}
+	entries := []envEntry{{
+		Name:      domain.DefaultEnvironment,
+		Active:    active == "",
+		Region:    cfg.Region,
+		BaseURL:   baseURL,
+		HasAPIKey: true,
+	}}
+
+	secrets, err := openSecretStore()
+	for _, name := range cfg.EnvironmentNames() {
+		p := cfg.Environments[name]
+		e := envEntry{Name: name, Active: active == name}
+		if p != nil {
+			e.Region, e.BaseURL, e.DefaultGrant = p.Region, p.BaseURL, p.DefaultGrant
+		}
+		if err == nil {
+			key, kerr := secrets.Get(ports.EnvironmentSecretKey(name, ports.KeyAPIKey))
+			e.HasAPIKey = kerr == nil && key != ""
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func newEnvUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Set the active environment profile",
+		Long:  `Set the environment used when neither --env nor NYLAS_ENV is given. Use "default" to go back to the top-level settings.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			cfg, err := configStore.Load()
+			if err != nil {
+				return common.WrapLoadError("configuration", err)
+			}
+			if name == domain.DefaultEnvironment {
+				cfg.CurrentEnv = ""
+			} else if _, ok := cfg.Environments[name]; ok {
+				cfg.CurrentEnv = name
+			} else {
+				return common.NewUserError(fmt.Sprintf("environment %q not found", name), "List environments with: nylas config env list")
+			}
+			if err := configStore.Save(cfg); err != nil {
+				return common.WrapSaveError("configuration", err)
+			}
+
+			common.PrintSuccess("Now using environment %q", name)
+			if env := os.Getenv("NYLAS_ENV"); env != "" && env != name {
+				common.PrintWarningStderr("NYLAS_ENV=%s is set and takes precedence in this shell", env)
+			}
+			return nil
+		},
+	}
+}
+
+func newEnvCurrentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "current",
+		Short: "Show the active environment profile",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := configStore.Load()
+			if err != nil {
+				return common.WrapLoadError("configuration", err)
+			}
+			name := common.ActiveEnvironment(cfg)
+			if name == "" {
+				name = domain.DefaultEnvironment
+			}
+			fmt.Println(name)
+			return nil
+		},
+	}
+}
+
+func newEnvDeleteCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete an environment profile and its stored API key",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			cfg, err := configStore.Load()
+			if err != nil {
+				return common.WrapLoadError("configuration", err)
+			}
+			if _, ok := cfg.Environments[name]; !ok {
+				return common.NewUserError(fmt.Sprintf("environment %q not found", name), "List environments with: nylas config env list")
+			}
+			if !yes && !common.Confirm(fmt.Sprintf("Delete environment %q and its API key?", name), false) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+
+			delete(cfg.Environments, name)
+			if cfg.CurrentEnv == name {
+				cfg.CurrentEnv = ""
+			}
+			if err := configStore.Save(cfg); err != nil {
+				return common.WrapSaveError("configuration", err)
+			}
+			if secrets, err := openSecretStore(); err == nil {
+				_ = secrets.Delete(ports.EnvironmentSecretKey(name, ports.KeyAPIKey))
+				_ = secrets.Delete(ports.EnvironmentSecretKey(name, ports.KeyAPIKeyID))
+			}
+
+			common.PrintSuccess("Environment %q deleted", name)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
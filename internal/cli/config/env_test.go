@@ -0,0 +1,102 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configadapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// setupEnvTest points configStore at a temp config and secrets at memory.
+func setupEnvTest(t *testing.T) *memStore {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(t.TempDir(), "config-home"))
+	t.Setenv("NYLAS_ENV", "")
+
+	originalStore := configStore
+	configStore = configadapter.NewDefaultFileStore()
+	t.Cleanup(func() { configStore = originalStore })
+
+	secrets := &memStore{data: map[string]string{}}
+	originalOpen := openSecretStore
+	openSecretStore = func() (ports.SecretStore, error) { return secrets, nil }
+	t.Cleanup(func() { openSecretStore = originalOpen })
+	t.Cleanup(func() { common.SetActiveEnvironment("") })
+
+	return secrets
+}
+
+func runEnvCmd(t *testing.T, args ...string) error {
+	t.Helper()
+	cmd := newEnvCmd()
+	cmd.SetArgs(args)
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	return cmd.Execute()
+}
+
+func TestEnvCreate(t *testing.T) {
+	secrets := setupEnvTest(t)
+
+	require.NoError(t, runEnvCmd(t, "create", "staging", "--region", "eu", "--api-key", "nyk_staging", "--grant", "grant-1", "--use"))
+
+	cfg, err := configStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, &domain.EnvironmentProfile{Region: "eu", DefaultGrant: "grant-1"}, cfg.Environments["staging"])
+	assert.Equal(t, "staging", cfg.CurrentEnv)
+	assert.Equal(t, "nyk_staging", secrets.data["env.staging.api_key"])
+	assert.NotContains(t, readConfigFile(t), "nyk_staging")
+
+	assert.ErrorContains(t, runEnvCmd(t, "create", "staging"), "already exists")
+	require.NoError(t, runEnvCmd(t, "create", "staging", "--base-url", "http://localhost:8080", "--force"))
+	cfg, err = configStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", cfg.Environments["staging"].BaseURL)
+	assert.Equal(t, "nyk_staging", secrets.data["env.staging.api_key"], "key kept when not replaced")
+
+	assert.ErrorContains(t, runEnvCmd(t, "create", "default"), "reserved")
+	assert.ErrorContains(t, runEnvCmd(t, "create", "x", "--region", "apac"), "invalid region")
+}
+
+func TestEnvUseAndDelete(t *testing.T) {
+	secrets := setupEnvTest(t)
+	require.NoError(t, runEnvCmd(t, "create", "prod", "--api-key", "nyk_prod"))
+
+	assert.ErrorContains(t, runEnvCmd(t, "use", "missing"), "not found")
+
+	require.NoError(t, runEnvCmd(t, "use", "prod"))
+	cfg, err := configStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", cfg.CurrentEnv)
+
+	entries := listEnvironments(cfg)
+	require.Len(t, entries, 2)
+	assert.False(t, entries[0].Active)
+	assert.Equal(t, envEntry{Name: "prod", Active: true, Region: "us", HasAPIKey: true}, entries[1])
+
+	require.NoError(t, runEnvCmd(t, "use", "default"))
+	cfg, err = configStore.Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.CurrentEnv)
+
+	require.NoError(t, runEnvCmd(t, "use", "prod"))
+	require.NoError(t, runEnvCmd(t, "delete", "prod", "--yes"))
+	cfg, err = configStore.Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Environments)
+	assert.Empty(t, cfg.CurrentEnv)
+	assert.NotContains(t, secrets.data, "env.prod.api_key")
+}
+
+func readConfigFile(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile(configStore.Path())
+	require.NoError(t, err)
+	return string(data)
+}
@@ -23,7 +23,9 @@ Examples of keys:
   output.format
   output.color
   gpg.default_key
-  gpg.auto_sign`,
+  gpg.auto_sign
+  gpg.backend
+  mcp.tools.send_message`,
 		Example: `  # Get API timeout
   nylas config get api.timeout
 
@@ -65,7 +67,15 @@ func getConfigValue(cfg any, key string) (string, error) {
 		v = v.Elem()
 	}
 
-	for _, part := range parts {
+	for i, part := range parts {
+		// A string-keyed map takes the last part as its key.
+		if v.Kind() == reflect.Map && i == len(parts)-1 && v.Type().Key().Kind() == reflect.String {
+			entry := v.MapIndex(reflect.ValueOf(part).Convert(v.Type().Key()))
+			if !entry.IsValid() {
+				return "", nil
+			}
+			return fmt.Sprintf("%v", entry.Interface()), nil
+		}
 		if v.Kind() != reflect.Struct {
 			return "", fmt.Errorf("cannot access field %s", part)
 		}
@@ -99,6 +109,7 @@ func snakeToPascal(s string) string {
 		"ai":  "AI",
 		"gpg": "GPG",
 		"id":  "ID",
+		"mcp": "MCP",
 		"url": "URL",
 	}
 
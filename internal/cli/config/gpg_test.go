@@ -62,6 +62,19 @@ func TestGPGConfig_SetAndGet(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "set gpg.backend to native",
+			key:   "gpg.backend",
+			value: "native",
+			validate: func(t *testing.T, cfg *domain.Config) {
+				if cfg.GPG == nil {
+					t.Fatal("GPG config is nil")
+				}
+				if cfg.GPG.Backend != domain.GPGBackendNative {
+					t.Errorf("expected backend=native, got %s", cfg.GPG.Backend)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
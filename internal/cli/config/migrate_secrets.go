@@ -0,0 +1,210 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	adapterconfig "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Statuses reported by `config migrate-secrets`.
+const (
+	secretMoved     = "moved"
+	secretWouldMove = "would move"
+	secretConflict  = "conflict"
+)
+
+// listableSecretStore is a secret store that can enumerate its keys.
+type listableSecretStore interface {
+	ports.SecretStore
+	Keys() ([]string, error)
+}
+
+// openFallbackSecretStore opens the encrypted file store so its secrets can
+// move into target. It returns nil when target is not the system keyring,
+// since the file store is then already the active backend.
+// Replaced in tests.
+var openFallbackSecretStore = func(target ports.SecretStore) (listableSecretStore, error) {
+	if _, ok := target.(*keyring.SystemKeyring); !ok {
+		return nil, nil
+	}
+	return keyring.NewEncryptedFileStore(adapterconfig.DefaultConfigDir())
+}
+
+// secretMove is one row of `config migrate-secrets`.
+type secretMove struct {
+	Secret string `json:"secret"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Status string `json:"status"`
+}
+
+func newMigrateSecretsCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate-secrets",
+		Short: "Move plaintext secrets into the OS keychain",
+		Long: `Move secrets out of plaintext storage into the secret store.
+
+The secret store is the OS keychain (macOS Keychain, libsecret on Linux,
+Windows Credential Manager). Where no keychain is available, it is an
+encrypted file protected by NYLAS_FILE_STORE_PASSPHRASE.
+
+Two kinds of secrets are moved:
+  - API keys written literally in config.yaml (ai.*.api_key,
+    ai.translation.api_key). Each is replaced with a ${secret:...}
+    reference that the CLI resolves at run time.
+  - Secrets in the encrypted file store, when the keychain is available.
+    A secret the keychain already holds with a different value is left in
+    the file and reported as a conflict.
+
+Values that use ${ENV_VAR} are left alone. Running the command again is safe.`,
+		Example: `  # Preview what would move
+  nylas config migrate-secrets --dry-run
+
+  # Move secrets into the keychain
+  nylas config migrate-secrets`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := configStore.Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			target, err := openSecretStore()
+			if err != nil {
+				return fmt.Errorf("access secret store: %w", err)
+			}
+			fallback, err := openFallbackSecretStore(target)
+			if err != nil {
+				return fmt.Errorf("access encrypted file store: %w", err)
+			}
+
+			moves, err := migrateConfigSecrets(cfg, target, dryRun)
+			if err != nil {
+				return err
+			}
+			if len(moves) > 0 && !dryRun {
+				if err := configStore.Save(cfg); err != nil {
+					return common.WrapSaveError("config", err)
+				}
+			}
+
+			if fallback != nil {
+				fileMoves, err := migrateFileSecrets(fallback, target, dryRun)
+				if err != nil {
+					return err
+				}
+				moves = append(moves, fileMoves...)
+			}
+
+			return printSecretMoves(cmd, moves, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would move without changing anything")
+
+	return cmd
+}
+
+// migrateConfigSecrets stores each plaintext API key in cfg in target and
+// replaces it with a ${secret:...} reference. cfg is left untouched on a dry
+// run.
+func migrateConfigSecrets(cfg *domain.Config, target ports.SecretStore, dryRun bool) ([]secretMove, error) {
+	var moves []secretMove
+	for _, field := range cfg.AI.SecretFields() {
+		if !domain.IsPlaintextSecret(*field.Value) {
+			continue
+		}
+		move := secretMove{Secret: field.Path, From: "config.yaml", To: target.Name(), Status: secretWouldMove}
+		if !dryRun {
+			key := ports.ConfigSecretKey(field.Path)
+			if err := target.Set(key, *field.Value); err != nil {
+				return nil, fmt.Errorf("store %s: %w", field.Path, err)
+			}
+			*field.Value = domain.SecretRef(key)
+			move.Status = secretMoved
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+// migrateFileSecrets copies every secret in the encrypted file store into
+// target and removes it from the file. Secrets target already holds with a
+// different value stay in the file.
+func migrateFileSecrets(file listableSecretStore, target ports.SecretStore, dryRun bool) ([]secretMove, error) {
+	keys, err := file.Keys()
+	if err != nil {
+		return nil, fmt.Errorf("read encrypted file store: %w", err)
+	}
+
+	var moves []secretMove
+	for _, key := range keys {
+		value, err := file.Get(key)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", key, err)
+		}
+		move := secretMove{Secret: key, From: file.Name(), To: target.Name(), Status: secretWouldMove}
+
+		existing, err := target.Get(key)
+		switch {
+		case err == nil && existing != value:
+			move.Status = secretConflict
+		case err != nil && !errors.Is(err, domain.ErrSecretNotFound):
+			return nil, fmt.Errorf("read %s from %s: %w", key, target.Name(), err)
+		case !dryRun:
+			if err != nil {
+				if err := target.Set(key, value); err != nil {
+					return nil, fmt.Errorf("store %s: %w", key, err)
+				}
+			}
+			if err := file.Delete(key); err != nil {
+				return nil, fmt.Errorf("remove %s from %s: %w", key, file.Name(), err)
+			}
+			move.Status = secretMoved
+		}
+		moves = append(moves, move)
+	}
+	return moves, nil
+}
+
+func printSecretMoves(cmd *cobra.Command, moves []secretMove, dryRun bool) error {
+	if common.IsStructuredOutput(cmd) {
+		if moves == nil {
+			moves = []secretMove{}
+		}
+		return common.GetOutputWriter(cmd).Write(moves)
+	}
+
+	if len(moves) == 0 {
+		fmt.Println("No plaintext secrets found.")
+		return nil
+	}
+
+	table := common.NewTable("SECRET", "FROM", "TO", "STATUS")
+	conflicts := 0
+	for _, m := range moves {
+		table.AddRow(m.Secret, m.From, m.To, m.Status)
+		if m.Status == secretConflict {
+			conflicts++
+		}
+	}
+	table.Render()
+
+	if dryRun {
+		fmt.Println(common.Dim.Sprintf("Dry run: nothing was written."))
+	} else {
+		common.PrintSuccess("Secrets migrated")
+	}
+	if conflicts > 0 {
+		fmt.Println(common.Dim.Sprintf("%d secret(s) differ between the keychain and the encrypted file; the file copy was kept.", conflicts))
+	}
+	return nil
+}
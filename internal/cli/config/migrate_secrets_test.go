@@ -0,0 +1,84 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// setupMigrateSecretsTest points the keychain and the encrypted file store
+// at in-memory stores.
+func setupMigrateSecretsTest(t *testing.T) (keychain, file *keyring.MockSecretStore) {
+	t.Helper()
+	setupEnvTest(t)
+
+	keychain, file = keyring.NewMockSecretStore(), keyring.NewMockSecretStore()
+	originalOpen, originalFallback := openSecretStore, openFallbackSecretStore
+	openSecretStore = func() (ports.SecretStore, error) { return keychain, nil }
+	openFallbackSecretStore = func(ports.SecretStore) (listableSecretStore, error) { return file, nil }
+	t.Cleanup(func() { openSecretStore, openFallbackSecretStore = originalOpen, originalFallback })
+	return keychain, file
+}
+
+func runMigrateSecrets(t *testing.T, args ...string) error {
+	t.Helper()
+	cmd := newMigrateSecretsCmd()
+	cmd.SetArgs(args)
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	return cmd.Execute()
+}
+
+func TestMigrateSecrets(t *testing.T) {
+	keychain, file := setupMigrateSecretsTest(t)
+
+	cfg := domain.DefaultConfig()
+	cfg.AI = &domain.AIConfig{
+		Claude: &domain.ClaudeConfig{APIKey: "sk-ant-plaintext", Model: "claude"},
+		OpenAI: &domain.OpenAIConfig{APIKey: "${OPENAI_API_KEY}", Model: "gpt"},
+	}
+	require.NoError(t, configStore.Save(cfg))
+	require.NoError(t, file.Set(ports.KeyAPIKey, "nyk_file"))
+	require.NoError(t, file.Set(ports.KeyClientID, "client-file"))
+	require.NoError(t, keychain.Set(ports.KeyClientID, "client-keychain"))
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		require.NoError(t, runMigrateSecrets(t, "--dry-run"))
+		assert.Contains(t, readConfigFile(t), "sk-ant-plaintext")
+		assert.Len(t, file.GetAll(), 2)
+		assert.Len(t, keychain.GetAll(), 1)
+	})
+
+	t.Run("moves plaintext secrets", func(t *testing.T) {
+		require.NoError(t, runMigrateSecrets(t))
+
+		assert.NotContains(t, readConfigFile(t), "sk-ant-plaintext")
+		loaded, err := configStore.Load()
+		require.NoError(t, err)
+		assert.Equal(t, "${secret:config.ai.claude.api_key}", loaded.AI.Claude.APIKey)
+		assert.Equal(t, "${OPENAI_API_KEY}", loaded.AI.OpenAI.APIKey, "env references stay")
+
+		assert.Equal(t, map[string]string{
+			"config.ai.claude.api_key": "sk-ant-plaintext",
+			ports.KeyAPIKey:            "nyk_file",
+			ports.KeyClientID:          "client-keychain",
+		}, keychain.GetAll())
+		assert.Equal(t, map[string]string{ports.KeyClientID: "client-file"}, file.GetAll(), "conflicting secret kept in file")
+	})
+
+	t.Run("rerun only reports the conflict", func(t *testing.T) {
+		loaded, err := configStore.Load()
+		require.NoError(t, err)
+		moves, err := migrateConfigSecrets(loaded, keychain, false)
+		require.NoError(t, err)
+		assert.Empty(t, moves)
+
+		moves, err = migrateFileSecrets(file, keychain, false)
+		require.NoError(t, err)
+		assert.Equal(t, []secretMove{{Secret: ports.KeyClientID, From: "mock", To: "mock", Status: secretConflict}}, moves)
+	})
+}
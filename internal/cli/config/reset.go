@@ -24,6 +24,7 @@ func newResetCmd() *cobra.Command {
   - API credentials (API key, client ID, client secret)
   - Dashboard session (login tokens, selected app)
   - Grants (authenticated email accounts)
+  - Cached API responses
   - Config file (reset to defaults)
 
 After reset, run 'nylas init' to set up again.
@@ -73,7 +74,12 @@ To reset only part of the CLI:
 			}
 			_, _ = common.Green.Println("  ✓ Grants cleared")
 
-			// 4. Reset config file to defaults
+			// 4. Clear cached API responses
+			if cache, err := common.NewResponseCache(); err == nil {
+				_, _ = cache.Clear()
+			}
+
+			// 5. Reset config file to defaults
 			if err := configStore.Save(domain.DefaultConfig()); err != nil {
 				return fmt.Errorf("reset config file: %w", err)
 			}
@@ -34,7 +34,16 @@ The configuration file will be created if it doesn't exist.`,
   nylas config set gpg.default_key 601FEE9B1D60185F
 
   # Enable auto-sign for all emails
-  nylas config set gpg.auto_sign true`,
+  nylas config set gpg.auto_sign true
+
+  # Use the built-in OpenPGP backend instead of the gpg binary
+  nylas config set gpg.backend native
+
+  # Confirm every email send from an MCP assistant
+  nylas config set mcp.tools.send_message ask
+
+  # Set a list (comma-separated)
+  nylas config set email.unsafe_attachment_types exe,msi,scr,application/x-msdownload`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := configStore.Load()
@@ -61,6 +70,9 @@ The configuration file will be created if it doesn't exist.`,
 			if err := setConfigValue(cfg, key, value); err != nil {
 				return err
 			}
+			if err := cfg.MCP.Validate(); err != nil {
+				return common.NewUserError(err.Error(), "Example: nylas config set mcp.tools.send_message ask")
+			}
 
 			if err := configStore.Save(cfg); err != nil {
 				return common.WrapSaveError("configuration", err)
@@ -98,6 +110,11 @@ func setConfigValue(cfg any, key, value string) error {
 			return setFieldValue(field, value)
 		}
 
+		// A string-keyed map takes the last part as its key.
+		if field.Kind() == reflect.Map && i == len(parts)-2 {
+			return setMapEntry(field, parts[i+1], value)
+		}
+
 		// If field is a pointer, dereference or initialize
 		if field.Kind() == reflect.Pointer {
 			if field.IsNil() {
@@ -113,6 +130,25 @@ func setConfigValue(cfg any, key, value string) error {
 	return nil
 }
 
+// setMapEntry sets key in a map of strings; an empty value removes it.
+func setMapEntry(field reflect.Value, key, value string) error {
+	if field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.String {
+		return fmt.Errorf("unsupported field type: %s", field.Type())
+	}
+	mapKey := reflect.ValueOf(key).Convert(field.Type().Key())
+	if value == "" {
+		if !field.IsNil() {
+			field.SetMapIndex(mapKey, reflect.Value{})
+		}
+		return nil
+	}
+	if field.IsNil() {
+		field.Set(reflect.MakeMap(field.Type()))
+	}
+	field.SetMapIndex(mapKey, reflect.ValueOf(value).Convert(field.Type().Elem()))
+	return nil
+}
+
 func setFieldValue(field reflect.Value, value string) error {
 	if !field.CanSet() {
 		return fmt.Errorf("cannot set field")
@@ -133,6 +169,18 @@ func setFieldValue(field reflect.Value, value string) error {
 			return fmt.Errorf("invalid boolean value: %s (use true/false)", value)
 		}
 		field.SetBool(b)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type: %s", field.Type())
+		}
+		// Comma-separated list; an empty value clears it.
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
 	default:
 		return fmt.Errorf("unsupported field type: %s", field.Kind())
 	}
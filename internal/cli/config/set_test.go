@@ -82,6 +82,29 @@ func TestSetFieldValue(t *testing.T) {
 				return v.Bool() == false
 			},
 		},
+		{
+			name:      "set string slice field",
+			fieldType: reflect.Slice,
+			value:     "exe, msi,,application/x-msdownload",
+			setupFunc: func() reflect.Value {
+				var s []string
+				return reflect.ValueOf(&s).Elem()
+			},
+			checkFunc: func(v reflect.Value) bool {
+				return reflect.DeepEqual(v.Interface(), []string{"exe", "msi", "application/x-msdownload"})
+			},
+		},
+		{
+			name:      "unsupported slice type",
+			fieldType: reflect.Slice,
+			value:     "1,2",
+			setupFunc: func() reflect.Value {
+				var s []int
+				return reflect.ValueOf(&s).Elem()
+			},
+			wantErr: true,
+			errMsg:  "unsupported field type",
+		},
 		{
 			name:      "invalid int value",
 			fieldType: reflect.Int,
@@ -335,3 +358,24 @@ func TestSetConfigValue_RoundTrip(t *testing.T) {
 		t.Errorf("round trip failed: set %q, got %q", value, got)
 	}
 }
+
+func TestSetConfigValue_MapEntry(t *testing.T) {
+	cfg := &domain.Config{}
+
+	require.NoError(t, setConfigValue(cfg, "mcp.tools.send_message", "ask"))
+	require.NoError(t, setConfigValue(cfg, "mcp.tools.delete_*", "deny"))
+	require.Equal(t, domain.MCPToolAsk, cfg.MCP.Tools["send_message"])
+	require.Equal(t, domain.MCPToolDeny, cfg.MCP.Tools["delete_*"])
+
+	got, err := getConfigValue(cfg, "mcp.tools.send_message")
+	require.NoError(t, err)
+	require.Equal(t, "ask", got)
+
+	got, err = getConfigValue(cfg, "mcp.tools.create_event")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	// An empty value removes the entry.
+	require.NoError(t, setConfigValue(cfg, "mcp.tools.send_message", ""))
+	require.NotContains(t, cfg.MCP.Tools, "send_message")
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	adapterconfig "github.com/nylas/cli/internal/adapters/config"
+)
+
+// promptConfigPassphrase supplies the passphrase for a config file
+// encrypted with `nylas config encrypt`: NYLAS_CONFIG_PASSPHRASE, or a
+// prompt when stdin is a terminal.
+func promptConfigPassphrase() (string, error) {
+	if p := os.Getenv(adapterconfig.PassphraseEnv); p != "" {
+		return p, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", adapterconfig.ErrPassphraseRequired
+	}
+	_, _ = fmt.Fprint(os.Stderr, "Config passphrase: ")
+	p, err := term.ReadPassword(fd)
+	_, _ = fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(p), "\r\n"), nil
+}
@@ -3,6 +3,9 @@ package contacts
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 )
 
 // NewContactsCmd creates the contacts command group.
@@ -18,15 +21,20 @@ View contacts, create new contacts, update and delete contacts.
 API reference: https://developer.nylas.com/docs/v3/email/contacts/`,
 	}
 
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newShowCmd())
-	cmd.AddCommand(newCreateCmd())
-	cmd.AddCommand(newUpdateCmd())
-	cmd.AddCommand(newDeleteCmd())
+	common.RequireScopes(cmd, domain.ScopeContactsRead)
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.Contact{}, common.Page[domain.Contact]{}))
+	cmd.AddCommand(common.DeclareOutput(newShowCmd(), domain.Contact{}))
+	cmd.AddCommand(common.DeclareOutput(newCountCmd(), common.CountResult{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newCreateCmd(), domain.Contact{}), domain.ScopeContactsWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newUpdateCmd(), domain.Contact{}), domain.ScopeContactsWrite))
+	cmd.AddCommand(common.RequireScopes(newDeleteCmd(), domain.ScopeContactsWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newImportCmd(), []*domain.CreateContactRequest{}, common.CSVImportResult{}), domain.ScopeContactsWrite))
 	cmd.AddCommand(newGroupsCmd())
-	cmd.AddCommand(newSearchCmd())
+	cmd.AddCommand(common.DeclareOutput(newSearchCmd(), []domain.Contact{}))
 	cmd.AddCommand(newPhotoCmd())
 	cmd.AddCommand(newSyncCmd())
+	cmd.AddCommand(newIndexCmd())
 
 	return cmd
 }
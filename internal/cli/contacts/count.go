@@ -0,0 +1,76 @@
+package contacts
+
+import (
+	"context"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+func newCountCmd() *cobra.Command {
+	var (
+		email    string
+		source   string
+		group    string
+		maxItems int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "count [grant-id]",
+		Short: "Count contacts",
+		Long: `Count contacts without downloading them.
+
+Count walks every page with only contact IDs selected, one request per 200
+contacts. The output is the bare number, with a trailing "+" when --max was
+reached. Use --json for the count, whether it is complete, and the number of
+pages fetched.`,
+		Example: `  # All contacts
+  nylas contacts count
+
+  # Contacts in a group
+  nylas contacts count --group <group-id>
+
+  # Address book contacts only
+  nylas contacts count --source address_book --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := &domain.ContactQueryParams{
+				Limit:  common.MaxAPILimit,
+				Email:  email,
+				Source: source,
+				Group:  group,
+				Select: "id",
+			}
+
+			result, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (common.CountResult, error) {
+				return countContacts(ctx, client, grantID, params, maxItems)
+			})
+			if err != nil {
+				return common.WrapListError("contacts", err)
+			}
+			return common.PrintCount(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVarP(&email, "email", "e", "", "Count contacts with this email address")
+	cmd.Flags().StringVarP(&source, "source", "s", "", "Filter by source (address_book, inbox, domain)")
+	cmd.Flags().StringVar(&group, "group", "", "Count contacts in this group ID")
+	cmd.Flags().IntVar(&maxItems, "max", 0, "Stop counting at this many contacts (0=unlimited)")
+
+	return cmd
+}
+
+// countContacts counts the contacts matching params page by page.
+func countContacts(ctx context.Context, client ports.NylasClient, grantID string, params *domain.ContactQueryParams, maxItems int) (common.CountResult, error) {
+	return common.CountPages(ctx, "contacts", maxItems, func(ctx context.Context, cursor string) (common.PageResult[domain.Contact], error) {
+		page := *params
+		page.PageToken = cursor
+		resp, err := client.GetContactsWithCursor(ctx, grantID, &page)
+		if err != nil {
+			return common.PageResult[domain.Contact]{}, err
+		}
+		return common.PageResult[domain.Contact]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+}
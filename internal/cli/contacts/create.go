@@ -66,8 +66,8 @@ Examples:
 					return struct{}{}, common.WrapCreateError("contact", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(contact)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(contact)
 				}
 
 				fmt.Printf("%s Contact created successfully!\n\n", common.Green.Sprint("✓"))
@@ -0,0 +1,91 @@
+package contacts
+
+import (
+	"strings"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// contactCSVFields are the fields a CSV import can set. The export uses the
+// same names, so `contacts list --format csv` output imports unchanged.
+var contactCSVFields = []string{
+	"given_name", "middle_name", "surname", "suffix", "nickname", "birthday",
+	"email", "phone", "company_name", "job_title", "manager_name", "web_page", "notes",
+}
+
+// contactCSVColumns are the columns of `contacts list --format csv`.
+var contactCSVColumns = []common.CSVColumn[domain.Contact]{
+	{Name: "id", Value: func(c domain.Contact) string { return c.ID }},
+	{Name: "given_name", Value: func(c domain.Contact) string { return c.GivenName }},
+	{Name: "middle_name", Value: func(c domain.Contact) string { return c.MiddleName }},
+	{Name: "surname", Value: func(c domain.Contact) string { return c.Surname }},
+	{Name: "suffix", Value: func(c domain.Contact) string { return c.Suffix }},
+	{Name: "nickname", Value: func(c domain.Contact) string { return c.Nickname }},
+	{Name: "birthday", Value: func(c domain.Contact) string { return c.Birthday }},
+	{Name: "email", Value: func(c domain.Contact) string {
+		emails := make([]string, len(c.Emails))
+		for i, e := range c.Emails {
+			emails[i] = e.Email
+		}
+		return strings.Join(emails, "; ")
+	}},
+	{Name: "phone", Value: func(c domain.Contact) string {
+		phones := make([]string, len(c.PhoneNumbers))
+		for i, p := range c.PhoneNumbers {
+			phones[i] = p.Number
+		}
+		return strings.Join(phones, "; ")
+	}},
+	{Name: "company_name", Value: func(c domain.Contact) string { return c.CompanyName }},
+	{Name: "job_title", Value: func(c domain.Contact) string { return c.JobTitle }},
+	{Name: "manager_name", Value: func(c domain.Contact) string { return c.ManagerName }},
+	{Name: "web_page", Value: func(c domain.Contact) string {
+		pages := make([]string, len(c.WebPages))
+		for i, p := range c.WebPages {
+			pages[i] = p.URL
+		}
+		return strings.Join(pages, "; ")
+	}},
+	{Name: "notes", Value: func(c domain.Contact) string { return c.Notes }},
+}
+
+// contactFromCSV builds a create request from an imported row. The first
+// email and phone are typed work and mobile, like `contacts create`; the
+// rest are typed other.
+func contactFromCSV(fields map[string]string) *domain.CreateContactRequest {
+	req := &domain.CreateContactRequest{
+		GivenName:   fields["given_name"],
+		MiddleName:  fields["middle_name"],
+		Surname:     fields["surname"],
+		Suffix:      fields["suffix"],
+		Nickname:    fields["nickname"],
+		Birthday:    fields["birthday"],
+		CompanyName: fields["company_name"],
+		JobTitle:    fields["job_title"],
+		ManagerName: fields["manager_name"],
+		Notes:       fields["notes"],
+	}
+	for i, email := range common.SplitCSVList(fields["email"]) {
+		typ := "other"
+		if i == 0 {
+			typ = "work"
+		}
+		req.Emails = append(req.Emails, domain.ContactEmail{Email: email, Type: typ})
+	}
+	// Phone numbers may contain commas in some locales, so only ";" splits.
+	for phone := range strings.SplitSeq(fields["phone"], ";") {
+		if phone = strings.TrimSpace(phone); phone == "" {
+			continue
+		}
+		typ := "other"
+		if len(req.PhoneNumbers) == 0 {
+			typ = "mobile"
+		}
+		req.PhoneNumbers = append(req.PhoneNumbers, domain.ContactPhone{Number: phone, Type: typ})
+	}
+	for _, url := range common.SplitCSVList(fields["web_page"]) {
+		req.WebPages = append(req.WebPages, domain.ContactWebPage{URL: url})
+	}
+	return req
+}
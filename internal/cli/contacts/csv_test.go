@@ -0,0 +1,56 @@
+package contacts
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestContactCSVRoundTrip(t *testing.T) {
+	contact := domain.Contact{
+		ID:          "contact-1",
+		GivenName:   "Jane",
+		Surname:     "Doe",
+		CompanyName: "Acme, Inc.",
+		JobTitle:    "Engineer",
+		Emails:      []domain.ContactEmail{{Email: "jane@acme.com", Type: "work"}, {Email: "jane@home.com", Type: "home"}},
+		PhoneNumbers: []domain.ContactPhone{
+			{Number: "+1 555 0100", Type: "mobile"},
+			{Number: "+49 30 1234,5", Type: "work"},
+		},
+		WebPages: []domain.ContactWebPage{{URL: "https://jane.dev"}},
+		Notes:    "Met at \"GopherCon\"",
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, common.WriteCSV(&buf, []domain.Contact{contact}, contactCSVColumns))
+
+	records, ignored, err := common.ReadCSVRecords(&buf, nil, contactCSVFields)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id"}, ignored)
+	require.Len(t, records, 1)
+
+	req := contactFromCSV(records[0].Fields)
+	assert.Equal(t, "Jane", req.GivenName)
+	assert.Equal(t, "Doe", req.Surname)
+	assert.Equal(t, "Acme, Inc.", req.CompanyName)
+	assert.Equal(t, "Engineer", req.JobTitle)
+	assert.Equal(t, `Met at "GopherCon"`, req.Notes)
+	assert.Equal(t, []domain.ContactEmail{{Email: "jane@acme.com", Type: "work"}, {Email: "jane@home.com", Type: "other"}}, req.Emails)
+	assert.Equal(t, []domain.ContactPhone{{Number: "+1 555 0100", Type: "mobile"}, {Number: "+49 30 1234,5", Type: "other"}}, req.PhoneNumbers)
+	assert.Equal(t, []domain.ContactWebPage{{URL: "https://jane.dev"}}, req.WebPages)
+}
+
+func TestBuildContactFromCSV(t *testing.T) {
+	_, err := buildContactFromCSV(map[string]string{"company_name": "Acme"})
+	assert.ErrorContains(t, err, "no given_name, surname or email")
+
+	req, err := buildContactFromCSV(map[string]string{"email": "a@x.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "a@x.com", req.Emails[0].Email)
+}
@@ -15,14 +15,17 @@ func newGroupsCmd() *cobra.Command {
 		Use:     "groups",
 		Aliases: []string{"group"},
 		Short:   "Manage contact groups",
-		Long:    "List, create, update, and delete contact groups.",
+		Long:    "List, create, update, and delete contact groups, and manage their members.",
 	}
 
-	cmd.AddCommand(newGroupsListCmd())
+	cmd.AddCommand(common.DeclareOutput(newGroupsListCmd(), []domain.ContactGroup{}))
 	cmd.AddCommand(newGroupsShowCmd())
-	cmd.AddCommand(newGroupsCreateCmd())
-	cmd.AddCommand(newGroupsUpdateCmd())
-	cmd.AddCommand(newGroupsDeleteCmd())
+	cmd.AddCommand(common.RequireScopes(newGroupsCreateCmd(), domain.ScopeContactsWrite))
+	cmd.AddCommand(common.RequireScopes(newGroupsUpdateCmd(), domain.ScopeContactsWrite))
+	cmd.AddCommand(common.RequireScopes(newGroupsDeleteCmd(), domain.ScopeContactsWrite))
+	cmd.AddCommand(common.DeclareOutput(newGroupsMembersCmd(), []domain.Contact{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newGroupsAddMemberCmd(), domain.Contact{}), domain.ScopeContactsWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newGroupsRemoveMemberCmd(), domain.Contact{}), domain.ScopeContactsWrite))
 
 	return cmd
 }
@@ -0,0 +1,147 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+func newGroupsMembersCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "members <group-id> [grant-id]",
+		Short: "List the contacts in a group",
+		Long: `List the contacts that belong to a contact group.
+
+Examples:
+  nylas contacts groups members <group-id>
+  nylas contacts groups members <group-id> --limit 500 --json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupID := args[0]
+
+			_, err := common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				params := &domain.ContactQueryParams{Limit: limit, Group: groupID}
+				contacts, err := fetchContacts(ctx, client, grantID, params, limit)
+				if err != nil {
+					return struct{}{}, common.WrapListError("group members", err)
+				}
+
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(contacts)
+				}
+
+				if len(contacts) == 0 {
+					common.PrintEmptyState("contacts in this group")
+					return struct{}{}, nil
+				}
+
+				fmt.Printf("Found %d contact(s):\n\n", len(contacts))
+
+				table := common.NewTable("NAME", "EMAIL", "ID")
+				for _, contact := range contacts {
+					table.AddRow(
+						common.Cyan.Sprint(contact.DisplayName()),
+						contact.PrimaryEmail(),
+						common.Dim.Sprint(contact.ID),
+					)
+				}
+				table.Render()
+
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 50, "Maximum number of contacts to show (auto-paginates if >200)")
+
+	return cmd
+}
+
+func newGroupsAddMemberCmd() *cobra.Command {
+	return newGroupsMembershipCmd(true)
+}
+
+func newGroupsRemoveMemberCmd() *cobra.Command {
+	return newGroupsMembershipCmd(false)
+}
+
+// newGroupsMembershipCmd builds add-member (member true) and
+// remove-member.
+func newGroupsMembershipCmd(member bool) *cobra.Command {
+	use, short := "add-member", "Add a contact to a group"
+	done, unchanged := "Added contact %s to group %s", "Contact %s is already in group %s\n"
+	if !member {
+		use, short = "remove-member", "Remove a contact from a group"
+		done, unchanged = "Removed contact %s from group %s", "Contact %s is not in group %s\n"
+	}
+
+	return &cobra.Command{
+		Use:   use + " <group-id> <contact-id> [grant-id]",
+		Short: short,
+		Long: short + `.
+
+The contact's other group memberships are kept.
+
+Examples:
+  nylas contacts groups ` + use + ` <group-id> <contact-id>`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupID, contactID := args[0], args[1]
+
+			_, err := common.WithClient(args[2:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				contact, changed, err := setGroupMembership(ctx, client, grantID, groupID, contactID, member)
+				if err != nil {
+					return struct{}{}, err
+				}
+
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(contact)
+				}
+				if !changed {
+					fmt.Printf(unchanged, contactID, groupID)
+					return struct{}{}, nil
+				}
+				common.PrintSuccess(done, contactID, groupID)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+}
+
+// setGroupMembership adds the contact to the group, or removes it, by
+// updating the contact's full list of groups. changed is false when the
+// contact was already in the requested state; no update is made then.
+func setGroupMembership(ctx context.Context, client ports.NylasClient, grantID, groupID, contactID string, member bool) (contact *domain.Contact, changed bool, err error) {
+	contact, err = client.GetContact(ctx, grantID, contactID)
+	if err != nil {
+		return nil, false, common.WrapGetError("contact", err)
+	}
+
+	isMember := func(g domain.ContactGroupInfo) bool { return g.ID == groupID }
+	if slices.ContainsFunc(contact.Groups, isMember) == member {
+		return contact, false, nil
+	}
+
+	groups := slices.DeleteFunc(slices.Clone(contact.Groups), isMember)
+	if member {
+		groups = append(groups, domain.ContactGroupInfo{ID: groupID})
+	}
+	if groups == nil {
+		groups = []domain.ContactGroupInfo{}
+	}
+
+	updated, err := client.UpdateContact(ctx, grantID, contactID, &domain.UpdateContactRequest{Groups: &groups})
+	if err != nil {
+		return nil, false, common.WrapUpdateError("contact", err)
+	}
+	return updated, true, nil
+}
@@ -0,0 +1,87 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// membershipClient serves one contact and records the updates made to it.
+type membershipClient struct {
+	*nylas.MockClient
+	contact domain.Contact
+	updates []*domain.UpdateContactRequest
+}
+
+func (c *membershipClient) GetContact(ctx context.Context, grantID, contactID string) (*domain.Contact, error) {
+	contact := c.contact
+	return &contact, nil
+}
+
+func (c *membershipClient) UpdateContact(ctx context.Context, grantID, contactID string, req *domain.UpdateContactRequest) (*domain.Contact, error) {
+	c.updates = append(c.updates, req)
+	return c.MockClient.UpdateContact(ctx, grantID, contactID, req)
+}
+
+func newMembershipClient(groupIDs ...string) *membershipClient {
+	contact := domain.Contact{ID: "contact-1"}
+	for _, id := range groupIDs {
+		contact.Groups = append(contact.Groups, domain.ContactGroupInfo{ID: id})
+	}
+	return &membershipClient{MockClient: nylas.NewMockClient(), contact: contact}
+}
+
+func TestSetGroupMembership(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("adds and keeps other groups", func(t *testing.T) {
+		client := newMembershipClient("friends")
+		contact, changed, err := setGroupMembership(ctx, client, "grant", "vip", "contact-1", true)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		require.Len(t, client.updates, 1)
+		assert.Equal(t, []domain.ContactGroupInfo{{ID: "friends"}, {ID: "vip"}}, *client.updates[0].Groups)
+		assert.Equal(t, []domain.ContactGroupInfo{{ID: "friends"}, {ID: "vip"}}, contact.Groups)
+	})
+
+	t.Run("removes the last group explicitly", func(t *testing.T) {
+		client := newMembershipClient("vip")
+		_, changed, err := setGroupMembership(ctx, client, "grant", "vip", "contact-1", false)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		require.Len(t, client.updates, 1)
+
+		body, err := json.Marshal(client.updates[0])
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"groups":[]}`, string(body))
+	})
+
+	t.Run("skips no-op changes", func(t *testing.T) {
+		client := newMembershipClient("vip")
+		_, changed, err := setGroupMembership(ctx, client, "grant", "vip", "contact-1", true)
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		_, changed, err = setGroupMembership(ctx, client, "grant", "other", "contact-1", false)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Empty(t, client.updates)
+	})
+}
+
+func TestGroupsMembershipCmds(t *testing.T) {
+	for _, name := range []string{"members", "add-member", "remove-member"} {
+		sub, _, err := newGroupsCmd().Find([]string{name})
+		require.NoError(t, err)
+		assert.Equal(t, name, sub.Name())
+	}
+
+	_, _, err := executeCommand(NewContactsCmd(), "groups", "add-member", "group-1")
+	assert.Error(t, err, "contact ID is required")
+}
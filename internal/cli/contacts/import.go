@@ -0,0 +1,133 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+func newImportCmd() *cobra.Command {
+	var (
+		mappingPath string
+		dryRun      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file.csv> [grant-id]",
+		Short: "Create contacts from a CSV file",
+		Long: `Create one contact per row of a CSV file ("-" reads stdin).
+
+Headers that match a field name (ignoring case, spaces and dashes) are used
+directly, so a file written by "contacts list --format csv" imports as is.
+Other spreadsheets need a YAML mapping from their headers to fields:
+
+  columns:
+    "First Name": given_name
+    "Last Name": surname
+    "E-mail Address": email
+    "Mobile Phone": phone
+    "Organization": company_name
+  defaults:
+    notes: Imported from CRM
+
+Fields: ` + strings.Join(contactCSVFields, ", ") + `
+
+Multiple emails or web pages in one cell are separated by ";" or ",";
+multiple phone numbers by ";". Mapping several columns to the same field
+also adds each value.`,
+		Example: `  # Re-import an export
+  nylas contacts list --limit 1000 --format csv > contacts.csv
+  nylas contacts import contacts.csv
+
+  # Import a spreadsheet with its own column names
+  nylas contacts import crm.csv --mapping crm-mapping.yaml
+
+  # Check the mapping without creating anything
+  nylas contacts import crm.csv --mapping crm-mapping.yaml --dry-run`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			records, err := common.ReadCSVFile(args[0], mappingPath, contactCSVFields)
+			if err != nil {
+				return err
+			}
+			if len(records) == 0 {
+				common.PrintEmptyStateWithHint("rows", "Check that the file has data rows below the header")
+				return nil
+			}
+
+			if dryRun {
+				return printContactImportPreview(cmd, records)
+			}
+
+			_, err = common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				result := common.ImportCSVRecords(records, buildContactFromCSV,
+					func(req *domain.CreateContactRequest) (string, error) {
+						contact, err := client.CreateContact(ctx, grantID, req)
+						if err != nil {
+							return "", common.WrapCreateError("contact", err)
+						}
+						return contact.ID, nil
+					})
+
+				if common.IsStructuredOutput(cmd) && !common.IsCSV(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(result)
+				}
+				common.PrintCSVImportResult(cmd.OutOrStdout(), result, "contact(s)")
+				if len(result.Failed) > 0 {
+					return struct{}{}, common.NewUserError(
+						fmt.Sprintf("%d of %d rows failed to import", len(result.Failed), len(records)),
+						"Fix the listed rows and import them again",
+					)
+				}
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&mappingPath, "mapping", "m", "", "YAML file mapping CSV headers to contact fields")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be imported without creating contacts")
+
+	return cmd
+}
+
+// buildContactFromCSV converts a row, requiring the same minimum as
+// `contacts create`: a name or an email.
+func buildContactFromCSV(fields map[string]string) (*domain.CreateContactRequest, error) {
+	req := contactFromCSV(fields)
+	if req.GivenName == "" && req.Surname == "" && len(req.Emails) == 0 {
+		return nil, errors.New("row has no given_name, surname or email")
+	}
+	return req, nil
+}
+
+func printContactImportPreview(cmd *cobra.Command, records []common.CSVRecord) error {
+	reqs, failed := common.BuildCSVRecords(records, buildContactFromCSV)
+	if common.IsStructuredOutput(cmd) && !common.IsCSV(cmd) {
+		return common.GetOutputWriter(cmd).Write(reqs)
+	}
+
+	w := cmd.OutOrStdout()
+	table := common.NewTable("NAME", "EMAIL", "PHONE", "COMPANY").SetWriter(w)
+	for _, req := range reqs {
+		name := strings.TrimSpace(req.GivenName + " " + req.Surname)
+		var email, phone string
+		if len(req.Emails) > 0 {
+			email = req.Emails[0].Email
+		}
+		if len(req.PhoneNumbers) > 0 {
+			phone = req.PhoneNumbers[0].Number
+		}
+		table.AddRow(name, email, phone, req.CompanyName)
+	}
+	table.Render()
+	common.PrintCSVDryRun(w, len(reqs), "contact(s)", failed)
+	return nil
+}
@@ -0,0 +1,144 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/recipients"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// openRecipientIndex opens the local recipient index. Replaced in tests.
+var openRecipientIndex = common.OpenRecipientIndex
+
+// indexResult reports a rebuilt recipient index.
+type indexResult struct {
+	GrantID    string `json:"grant_id"`
+	Recipients int    `json:"recipients"`
+	Contacts   int    `json:"contacts"`
+	Messages   int    `json:"messages"`
+	Path       string `json:"path"`
+}
+
+func newIndexCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "index",
+		Short: "Manage the local recipient index",
+		Long: `Manage the local recipient index used to complete --to, --cc and --bcc.
+
+The index holds the addresses from your contacts and from your recent mail,
+ranked by how often you write to them. It's kept per grant in the user cache
+directory, and sending mail keeps it up to date once it's built.`,
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newIndexRebuildCmd(), indexResult{}))
+
+	return cmd
+}
+
+func newIndexRebuildCmd() *cobra.Command {
+	var messages int
+
+	cmd := &cobra.Command{
+		Use:   "rebuild [grant-id]",
+		Short: "Rebuild the recipient index from contacts and recent mail",
+		Long: `Rebuild the local recipient index of a grant from its contacts and the
+senders and recipients of its most recent messages.
+
+Once built, pressing Tab after --to, --cc or --bcc on email commands offers
+matching addresses, and names typed in interactive mode are looked up.`,
+		Example: `  # Build the index for the default grant
+  nylas contacts index rebuild
+
+  # Read more mail history, for a richer ranking
+  nylas contacts index rebuild --messages 2000
+
+  # Contacts only
+  nylas contacts index rebuild --messages 0`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if messages < 0 {
+				return common.NewInputError("--messages can't be negative")
+			}
+			index, err := openRecipientIndex()
+			if err != nil {
+				return common.WrapLoadError("recipient index", err)
+			}
+
+			result, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (*indexResult, error) {
+				return rebuildRecipientIndex(ctx, client, grantID, index, messages)
+			})
+			if err != nil {
+				return err
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(result)
+			}
+			common.PrintSuccess("Indexed %d recipient(s) from %d contact(s) and %d message(s)", result.Recipients, result.Contacts, result.Messages)
+			fmt.Println(common.Dim.Sprint("Index: " + result.Path))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&messages, "messages", 500, "How many recent messages to read addresses from (0 for contacts only)")
+
+	return cmd
+}
+
+func rebuildRecipientIndex(ctx context.Context, client ports.NylasClient, grantID string, index *recipients.Store, maxMessages int) (*indexResult, error) {
+	var self []string
+	if grant, err := client.GetGrant(ctx, grantID); err == nil && grant.Email != "" {
+		self = append(self, grant.Email)
+	}
+
+	contacts, err := common.RunWithSpinnerResult("Fetching contacts...", func() ([]domain.Contact, error) {
+		return common.FetchCursorPages(ctx, common.MaxAPILimit, common.DefaultMaxItems, func(ctx context.Context, cursor string) (common.PageResult[domain.Contact], error) {
+			resp, err := client.GetContactsWithCursor(ctx, grantID, &domain.ContactQueryParams{Limit: common.MaxAPILimit, PageToken: cursor})
+			if err != nil {
+				return common.PageResult[domain.Contact]{}, err
+			}
+			return common.PageResult[domain.Contact]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+		})
+	})
+	if err != nil {
+		return nil, common.WrapFetchError("contacts", err)
+	}
+
+	var msgs []domain.Message
+	if maxMessages > 0 {
+		msgs, err = common.RunWithSpinnerResult("Reading recent mail...", func() ([]domain.Message, error) {
+			limit := common.NormalizePageSize(maxMessages)
+			return common.FetchCursorPages(ctx, limit, maxMessages, func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+				resp, err := client.GetMessagesWithCursor(ctx, grantID, &domain.MessageQueryParams{
+					Limit:     limit,
+					PageToken: cursor,
+					Select:    "id,date,from,to,cc,bcc",
+				})
+				if err != nil {
+					return common.PageResult[domain.Message]{}, err
+				}
+				return common.PageResult[domain.Message]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+			})
+		})
+		if err != nil {
+			return nil, common.WrapFetchError("messages", err)
+		}
+	}
+
+	built := recipients.Build(contacts, msgs, self)
+	if err := index.Replace(grantID, built); err != nil {
+		return nil, fmt.Errorf("failed to save recipient index: %w", err)
+	}
+	return &indexResult{
+		GrantID:    grantID,
+		Recipients: len(built),
+		Contacts:   len(contacts),
+		Messages:   len(msgs),
+		Path:       index.Path(),
+	}, nil
+}
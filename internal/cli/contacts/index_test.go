@@ -0,0 +1,54 @@
+package contacts
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/adapters/recipients"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestRebuildRecipientIndex(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetGrantFunc = func(context.Context, string) (*domain.Grant, error) {
+		return &domain.Grant{ID: "grant-1", Email: "me@example.com"}, nil
+	}
+	client.GetMessagesWithParamsFunc = func(_ context.Context, _ string, params *domain.MessageQueryParams) ([]domain.Message, error) {
+		assert.Equal(t, 50, params.Limit)
+		assert.Contains(t, params.Select, "from")
+		return []domain.Message{{
+			From: []domain.EmailParticipant{{Email: "me@example.com"}},
+			To:   []domain.EmailParticipant{{Name: "Ana", Email: "ana@example.com"}, {Email: "john@example.com"}},
+		}}, nil
+	}
+	index := recipients.New(filepath.Join(t.TempDir(), "recipients.json"))
+
+	result, err := rebuildRecipientIndex(context.Background(), client, "grant-1", index, 50)
+	require.NoError(t, err)
+
+	assert.Equal(t, &indexResult{GrantID: "grant-1", Recipients: 2, Contacts: 1, Messages: 1, Path: index.Path()}, result)
+	got, _, err := index.List("grant-1")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, domain.Recipient{Email: "john@example.com", Name: "John Doe", Contact: true, Count: 1}, got[0])
+	assert.Equal(t, "ana@example.com", got[1].Email)
+}
+
+func TestRebuildRecipientIndex_ContactsOnly(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetMessagesWithParamsFunc = func(context.Context, string, *domain.MessageQueryParams) ([]domain.Message, error) {
+		t.Fatal("--messages 0 reads no mail")
+		return nil, nil
+	}
+	index := recipients.New(filepath.Join(t.TempDir(), "recipients.json"))
+
+	result, err := rebuildRecipientIndex(context.Background(), client, "grant-1", index, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Recipients)
+	assert.Zero(t, result.Messages)
+}
@@ -12,37 +12,72 @@ import (
 
 func newListCmd() *cobra.Command {
 	var (
-		limit  int
-		email  string
-		source string
-		showID bool
+		limit    int
+		email    string
+		source   string
+		showID   bool
+		pictures bool
+		grants   *common.GrantSelection
+		pages    *common.PageSelection
 	)
 
 	cmd := &cobra.Command{
 		Use:     "list [grant-id]",
 		Aliases: []string{"ls"},
 		Short:   "List contacts",
-		Long:    "List all contacts for the specified grant or default account.",
-		Args:    cobra.MaximumNArgs(1),
+		Long: `List all contacts for the specified grant or default account.
+
+Use --format csv to export for spreadsheets; the CSV can be re-imported
+with "nylas contacts import". Add --include-pictures to --json or --yaml
+output to export each contact's profile picture as Base64 data.
+
+Use --all to fetch every contact, up to --max (10000 by default; 0 for no
+cap). Use --page-token to fetch one page at a time: an empty token starts at
+the first page, and the token of the next page is printed after the list, or
+returned as next_cursor with --json.
+
+Use --grant all, or --grants with a list, to list several accounts at once.
+The limit applies per account.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			pag := common.SetupPagination(limit, false, 0)
+			args, err := grants.Args(args)
+			if err != nil {
+				return err
+			}
+			if err := pages.Check(grants); err != nil {
+				return err
+			}
+			pag := pages.Limits(limit)
 			limit = pag.Limit
-			maxItems := pag.MaxItems
+			maxItems := pag.FetchCap()
 
-			// Check if we should use structured output (JSON/YAML/quiet)
+			if grants.Multi() {
+				params := domain.ContactQueryParams{Limit: limit, Email: email, Source: source, ProfilePicture: pictures}
+				return runListAccounts(cmd, grants, params, maxItems, showID)
+			}
+
+			// Check if we should use structured output (JSON/YAML/CSV/quiet)
 			if common.IsStructuredOutput(cmd) {
-				_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 					params := &domain.ContactQueryParams{
-						Limit:  limit,
-						Email:  email,
-						Source: source,
+						Limit:          limit,
+						Email:          email,
+						Source:         source,
+						ProfilePicture: pictures,
 					}
 
-					contacts, err := fetchContacts(ctx, client, grantID, params, maxItems)
+					contacts, next, err := listContacts(ctx, client, grantID, params, maxItems, pages)
 					if err != nil {
 						return struct{}{}, common.WrapListError("contacts", err)
 					}
+					pages.WarnCapped(len(contacts))
 
+					if common.IsCSV(cmd) {
+						return struct{}{}, common.WriteCSV(cmd.OutOrStdout(), contacts, contactCSVColumns)
+					}
+					if pages.Cursor() {
+						return struct{}{}, common.WritePage(cmd, contacts, next)
+					}
 					out := common.GetOutputWriter(cmd)
 					return struct{}{}, out.Write(contacts)
 				})
@@ -50,17 +85,18 @@ func newListCmd() *cobra.Command {
 			}
 
 			// Traditional table output
-			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				params := &domain.ContactQueryParams{
 					Limit:  limit,
 					Email:  email,
 					Source: source,
 				}
 
-				contacts, err := fetchContacts(ctx, client, grantID, params, maxItems)
+				contacts, next, err := listContacts(ctx, client, grantID, params, maxItems, pages)
 				if err != nil {
 					return struct{}{}, common.WrapListError("contacts", err)
 				}
+				pages.WarnCapped(len(contacts))
 
 				if len(contacts) == 0 {
 					common.PrintEmptyState("contacts")
@@ -104,6 +140,7 @@ func newListCmd() *cobra.Command {
 					}
 				}
 				table.Render()
+				common.PrintNextPage(cmd, next)
 
 				return struct{}{}, nil
 			})
@@ -115,10 +152,30 @@ func newListCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&email, "email", "e", "", "Filter by email address")
 	cmd.Flags().StringVarP(&source, "source", "s", "", "Filter by source (address_book, inbox, domain)")
 	cmd.Flags().BoolVar(&showID, "id", false, "Show contact IDs")
+	cmd.Flags().BoolVar(&pictures, "include-pictures", false, "Include Base64-encoded profile pictures in structured output")
+	grants = common.AddGrantSelectionFlags(cmd)
+	pages = common.AddPageSelectionFlags(cmd, "contacts")
 
 	return cmd
 }
 
+// listContacts fetches the contacts of `contacts list`: one page from
+// --page-token with the cursor of the next page, or up to maxItems.
+func listContacts(ctx context.Context, client ports.NylasClient, grantID string, params *domain.ContactQueryParams, maxItems int, pages *common.PageSelection) ([]domain.Contact, string, error) {
+	if !pages.Cursor() {
+		contacts, err := fetchContacts(ctx, client, grantID, params, maxItems)
+		return contacts, "", err
+	}
+
+	params.Limit = common.NormalizePageSize(params.Limit)
+	params.PageToken = pages.Token
+	resp, err := client.GetContactsWithCursor(ctx, grantID, params)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Data, resp.Pagination.NextCursor, nil
+}
+
 // fetchContacts retrieves contacts, using pagination when maxItems > 0.
 func fetchContacts(ctx context.Context, client ports.NylasClient, grantID string, params *domain.ContactQueryParams, maxItems int) ([]domain.Contact, error) {
 	if maxItems > 0 {
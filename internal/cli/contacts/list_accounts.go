@@ -0,0 +1,83 @@
+package contacts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// accountContact is a contact tagged with the account it came from.
+type accountContact struct {
+	Account        string `json:"account" yaml:"account"`
+	domain.Contact `yaml:",inline"`
+}
+
+// accountContactCSVColumns are contactCSVColumns after an account column.
+func accountContactCSVColumns() []common.CSVColumn[accountContact] {
+	columns := []common.CSVColumn[accountContact]{
+		{Name: "account", Value: func(c accountContact) string { return c.Account }},
+	}
+	for _, col := range contactCSVColumns {
+		columns = append(columns, common.CSVColumn[accountContact]{
+			Name:  col.Name,
+			Value: func(c accountContact) string { return col.Value(c.Contact) },
+		})
+	}
+	return columns
+}
+
+// runListAccounts lists contacts from several grants, sorted by name.
+func runListAccounts(cmd *cobra.Command, sel *common.GrantSelection, params domain.ContactQueryParams, maxItems int, showID bool) error {
+	results, err := common.WithGrants(sel, func(ctx context.Context, client ports.NylasClient, grantID string) ([]domain.Contact, error) {
+		p := params
+		return fetchContacts(ctx, client, grantID, &p, maxItems)
+	})
+	if err != nil {
+		return common.WrapListError("contacts", err)
+	}
+	contacts := common.MergeGrantResults(results, func(account string, c domain.Contact) accountContact {
+		return accountContact{Account: account, Contact: c}
+	})
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return strings.ToLower(contacts[i].DisplayName()) < strings.ToLower(contacts[j].DisplayName())
+	})
+
+	if common.IsCSV(cmd) {
+		return common.WriteCSV(cmd.OutOrStdout(), contacts, accountContactCSVColumns())
+	}
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(contacts)
+	}
+	if len(contacts) == 0 {
+		common.PrintEmptyState("contacts")
+		return nil
+	}
+
+	fmt.Printf("Found %d contact(s) across %d accounts:\n\n", len(contacts), len(results))
+	headers := []string{"ACCOUNT", "NAME", "EMAIL", "PHONE", "COMPANY"}
+	if showID {
+		headers = append(headers, "ID")
+	}
+	table := common.NewTable(headers...)
+	for _, c := range contacts {
+		row := []string{
+			common.Blue.Sprint(c.Account),
+			common.Cyan.Sprint(c.DisplayName()),
+			c.PrimaryEmail(),
+			c.PrimaryPhone(),
+			common.Dim.Sprint(c.CompanyName),
+		}
+		if showID {
+			row = append(row, common.Dim.Sprint(c.ID))
+		}
+		table.AddRow(row...)
+	}
+	table.Render()
+	return nil
+}
@@ -1,12 +1,16 @@
 package contacts
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -82,3 +86,64 @@ func TestFetchContacts(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to fetch page 1")
 	})
 }
+
+func TestListContacts_Cursor(t *testing.T) {
+	client := &testContactsClient{
+		MockClient: nylas.NewMockClient(),
+		getContactsWithCursorFunc: func(ctx context.Context, grantID string, params *domain.ContactQueryParams) (*domain.ContactListResponse, error) {
+			assert.Equal(t, "page-2", params.PageToken)
+			assert.Equal(t, common.MaxAPILimit, params.Limit)
+			return &domain.ContactListResponse{
+				Data:       []domain.Contact{{ID: "contact-3"}},
+				Pagination: domain.Pagination{NextCursor: "page-3"},
+			}, nil
+		},
+	}
+	cmd := &cobra.Command{Use: "list"}
+	pages := common.AddPageSelectionFlags(cmd, "contacts")
+	require.NoError(t, cmd.ParseFlags([]string{"--page-token", "page-2"}))
+
+	contacts, next, err := listContacts(context.Background(), client, "grant-123", &domain.ContactQueryParams{Limit: 500}, 0, pages)
+
+	require.NoError(t, err)
+	assert.Len(t, contacts, 1)
+	assert.Equal(t, "page-3", next)
+}
+
+func TestAccountContactCSVColumns(t *testing.T) {
+	contacts := []accountContact{
+		{Account: "me@work.com", Contact: domain.Contact{ID: "c1", GivenName: "Ada"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, common.WriteCSV(&buf, contacts, accountContactCSVColumns()))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "account,id,given_name,"), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "me@work.com,c1,Ada,"), lines[1])
+}
+
+func TestCountContacts(t *testing.T) {
+	client := &testContactsClient{
+		MockClient: nylas.NewMockClient(),
+		getContactsWithCursorFunc: func(ctx context.Context, grantID string, params *domain.ContactQueryParams) (*domain.ContactListResponse, error) {
+			assert.Equal(t, "id", params.Select)
+			assert.Equal(t, "group-1", params.Group)
+			if params.PageToken == "" {
+				return &domain.ContactListResponse{
+					Data:       []domain.Contact{{ID: "contact-1"}, {ID: "contact-2"}},
+					Pagination: domain.Pagination{NextCursor: "next"},
+				}, nil
+			}
+			return &domain.ContactListResponse{Data: []domain.Contact{{ID: "contact-3"}}}, nil
+		},
+	}
+
+	result, err := countContacts(context.Background(), client, "grant-123", &domain.ContactQueryParams{Group: "group-1", Select: "id"}, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Count)
+	assert.True(t, result.Complete)
+	assert.Equal(t, 2, result.Pages)
+}
@@ -4,33 +4,49 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"os"
+	"slices"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
 )
 
+// contactPictureTypes are the image types providers accept for contact
+// photos.
+var contactPictureTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
 func newPhotoCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "photo",
 		Short: "Manage contact profile photos",
-		Long:  `Download and view contact profile pictures from the email provider.`,
+		Long:  `Download, view and set contact profile pictures.`,
 	}
 
-	cmd.AddCommand(newPhotoDownloadCmd())
+	cmd.AddCommand(common.DeclareOutput(newPhotoDownloadCmd(), contactPhoto{}))
+	cmd.AddCommand(common.DeclareOutput(newPhotoSetCmd(), domain.Contact{}))
 	cmd.AddCommand(newPhotoInfoCmd())
 
 	return cmd
 }
 
+// contactPhoto is the --json output of photo download: the picture as
+// base64, as the API returns it.
+type contactPhoto struct {
+	ContactID string `json:"contact_id"`
+	Picture   string `json:"picture"`
+}
+
 func newPhotoDownloadCmd() *cobra.Command {
 	var outputFile string
 
 	cmd := &cobra.Command{
-		Use:   "download <contact-id>",
-		Short: "Download contact profile picture",
+		Use:     "download <contact-id>",
+		Aliases: []string{"get"},
+		Short:   "Download contact profile picture",
 		Long: `Download a contact's profile picture as a Base64-encoded image.
 
 The Nylas API returns profile pictures as Base64-encoded data when you include
@@ -66,8 +82,7 @@ and saves it to a file or displays the Base64 data.`,
 					fmt.Printf("Profile picture saved to: %s\n", outputFile)
 					fmt.Printf("Size: %d bytes\n", len(imageData))
 				} else if common.IsJSON(cmd) {
-					// Print as JSON
-					fmt.Printf(`{"contact_id":"%s","picture":"%s"}`+"\n", contactID, contact.Picture)
+					return struct{}{}, common.GetOutputWriter(cmd).Write(contactPhoto{ContactID: contactID, Picture: contact.Picture})
 				} else {
 					// Print Base64 data
 					fmt.Println("Base64-encoded profile picture:")
@@ -86,6 +101,90 @@ and saves it to a file or displays the Base64 data.`,
 	return cmd
 }
 
+func newPhotoSetCmd() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "set <contact-id> [grant-id]",
+		Short: "Set contact profile picture",
+		Long: `Set a contact's profile picture from a JPEG, PNG or GIF file.
+
+The image is checked against the provider's size limit (4 MB for Microsoft
+and Exchange, 2 MB for others) and sent Base64-encoded. Providers that keep
+contact pictures read-only ignore or reject it.`,
+		Example: `  nylas contacts photo set <contact-id> --file avatar.jpg`,
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contactID := args[0]
+
+			_, err := common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				// The provider only picks the size limit; without it the
+				// smaller default applies.
+				var provider domain.Provider
+				if grant, err := client.GetGrant(ctx, grantID); err == nil && grant != nil {
+					provider = grant.Provider
+				}
+
+				picture, err := readContactPicture(file, provider)
+				if err != nil {
+					return struct{}{}, err
+				}
+
+				contact, err := client.UpdateContact(ctx, grantID, contactID, &domain.UpdateContactRequest{Picture: &picture})
+				if err != nil {
+					return struct{}{}, common.WrapUpdateError("contact", err)
+				}
+
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(contact)
+				}
+				common.PrintSuccess("Profile picture set for contact %s", contactID)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Image file (JPEG, PNG or GIF)")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// readContactPicture reads an image for a contact photo and returns it
+// Base64-encoded, after checking its type and the provider's size limit.
+func readContactPicture(path string, provider domain.Provider) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", common.WrapLoadError("image", err)
+	}
+
+	limit := domain.ContactPictureSizeLimit(provider)
+	if info.Size() > limit {
+		providerName := "the provider"
+		if provider != "" {
+			providerName = provider.DisplayName()
+		}
+		return "", common.NewUserError(
+			fmt.Sprintf("image is %s, over %s's %s limit for contact photos", common.FormatSize(info.Size()), providerName, common.FormatSize(limit)),
+			"Resize or compress the image",
+		)
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 -- user-supplied image file
+	if err != nil {
+		return "", common.WrapLoadError("image", err)
+	}
+	if contentType := http.DetectContentType(data); !slices.Contains(contactPictureTypes, contentType) {
+		return "", common.NewUserError(
+			fmt.Sprintf("%s is not a JPEG, PNG or GIF image (detected %s)", path, contentType),
+			"Convert the image to JPEG or PNG",
+		)
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
 func newPhotoInfoCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "info",
@@ -101,16 +200,17 @@ Retrieval:
   - Image comes directly from the email provider
 
 Upload:
-  - Not supported in Nylas API v3
-  - Profile pictures must be managed through the email provider
-  - For Gmail: Visit https://contacts.google.com
-  - For Outlook: Visit https://outlook.office.com/people
-  - For other providers: Use their native contact management
+  - 'nylas contacts photo set' sends a JPEG, PNG or GIF as Base64 data
+    in the contact's 'picture' field
+  - Size limits: 4 MB for Microsoft and Exchange, 2 MB for others
+  - Providers that keep pictures read-only ignore or reject it; manage
+    those through the provider (https://contacts.google.com,
+    https://outlook.office.com/people)
 
 Limitations:
   - Picture availability depends on email provider
   - Not all contacts have profile pictures
-  - Image format and size controlled by provider
+  - Stored image format and size controlled by provider
 
 Best Practices:
   - Cache pictures locally if using frequently
@@ -126,6 +226,12 @@ Example Usage:
 
   # Get as JSON
   nylas contacts photo download <contact-id> --json
+
+  # Set a profile picture
+  nylas contacts photo set <contact-id> --file avatar.jpg
+
+  # Export all contacts with their pictures
+  nylas contacts list --all --include-pictures --json
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			fmt.Println(cmd.Long)
@@ -0,0 +1,75 @@
+package contacts
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// pngHeader is enough of a PNG for content type detection.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func writeImage(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestReadContactPicture(t *testing.T) {
+	t.Run("encodes images", func(t *testing.T) {
+		got, err := readContactPicture(writeImage(t, "avatar.png", pngHeader), domain.ProviderGoogle)
+		require.NoError(t, err)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(pngHeader), got)
+	})
+
+	t.Run("rejects other file types", func(t *testing.T) {
+		_, err := readContactPicture(writeImage(t, "avatar.txt", []byte("not an image")), domain.ProviderGoogle)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a JPEG, PNG or GIF image")
+	})
+
+	t.Run("enforces the provider limit", func(t *testing.T) {
+		// 3 MB is over the default limit but within Microsoft's.
+		big := append(bytes.Clone(pngHeader), make([]byte, 3<<20)...)
+		path := writeImage(t, "big.png", big)
+
+		_, err := readContactPicture(path, domain.ProviderGoogle)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "over Google's")
+
+		_, err = readContactPicture(path, domain.ProviderMicrosoft)
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readContactPicture(filepath.Join(t.TempDir(), "missing.png"), "")
+		assert.Error(t, err)
+	})
+}
+
+func TestPhotoSetCmd(t *testing.T) {
+	cmd := newPhotoSetCmd()
+	assert.Equal(t, "set <contact-id> [grant-id]", cmd.Use)
+
+	flag := cmd.Flags().Lookup("file")
+	require.NotNil(t, flag)
+	assert.Equal(t, "f", flag.Shorthand)
+	assert.Equal(t, []string{"true"}, flag.Annotations["cobra_annotation_bash_completion_one_required_flag"])
+
+	_, _, err := executeCommand(NewContactsCmd(), "photo", "set", "contact-1")
+	assert.ErrorContains(t, err, `required flag(s) "file" not set`)
+}
+
+func TestPhotoGetAlias(t *testing.T) {
+	sub, _, err := NewContactsCmd().Find([]string{"photo", "get"})
+	require.NoError(t, err)
+	assert.Equal(t, "download", sub.Name())
+}
@@ -2,9 +2,7 @@ package contacts
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -71,10 +69,8 @@ text searches, use the regular list command with additional filtering.`,
 					filtered = append(filtered, contact)
 				}
 
-				if common.IsJSON(cmd) {
-					encoder := json.NewEncoder(os.Stdout)
-					encoder.SetIndent("", "  ")
-					return struct{}{}, encoder.Encode(filtered)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(filtered)
 				}
 
 				// Print results as table
@@ -27,8 +27,8 @@ func newShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("contact", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(contact)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(contact)
 				}
 
 				return struct{}{}, displayContact(contact)
@@ -104,8 +104,8 @@ Examples:
 				return common.WrapUpdateError("contact", err)
 			}
 
-			if common.IsJSON(cmd) {
-				return common.PrintJSON(contact)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(contact)
 			}
 
 			fmt.Printf("%s Contact updated successfully!\n\n", common.Green.Sprint("✓"))
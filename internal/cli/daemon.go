@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/daemon"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/cli/webhook"
+	"github.com/nylas/cli/internal/version"
+)
+
+// daemonSecretTTL is how long the daemon reuses a secret it read from the
+// secret store. Changes made by other processes show up after it.
+const daemonSecretTTL = time.Minute
+
+// newDaemonClient connects to the daemon. Replaced in tests.
+var newDaemonClient = func() *daemon.Client {
+	return daemon.NewClient(daemon.DefaultSocketPath())
+}
+
+func newDaemonCmd() *cobra.Command {
+	var (
+		webhookPort   int
+		webhookPath   string
+		webhookSecret string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run commands from a warm background process",
+		Long: `Keep a background process running that commands are handed to, so they
+skip start-up work: loading config, reading the keychain, and opening new
+connections to the API.
+
+While the daemon runs, the CLI passes non-interactive invocations (stdin is
+not a terminal, as in scripts, pipelines and agents) to it over a unix
+socket. Output, exit codes and audit entries are the same as running the
+command directly; the daemon runs with the caller's environment and working
+directory. Interactive sessions, servers and watchers always run in the
+calling process.
+
+With --webhook-port, the daemon also runs a webhook receiver on 127.0.0.1
+and saves events for 'nylas webhooks events'. Expose it with your own
+tunnel or reverse proxy.
+
+Secrets are re-read from the keychain after a minute. Set NYLAS_NO_DAEMON=1
+to run a command without the daemon. NYLAS_DAEMON_SOCKET overrides the
+socket path. Not available on Windows.`,
+		Example: `  # Start the daemon in the background
+  nylas daemon &
+
+  # With a webhook receiver
+  nylas daemon --webhook-port 9000 --webhook-secret "$WEBHOOK_SECRET"
+
+  # Check and stop it
+  nylas daemon status
+  nylas daemon stop`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaemon(webhookPort, webhookPath, webhookSecret)
+		},
+	}
+
+	cmd.Flags().IntVar(&webhookPort, "webhook-port", 0, "Also receive webhooks on this port")
+	cmd.Flags().StringVar(&webhookPath, "webhook-path", "/webhook", "Webhook endpoint path")
+	cmd.Flags().StringVar(&webhookSecret, "webhook-secret", "", "Webhook secret for signature verification")
+
+	cmd.AddCommand(common.DeclareOutput(newDaemonStatusCmd(), daemonStatus{}))
+	cmd.AddCommand(newDaemonStopCmd())
+
+	return common.RunLocally(cmd)
+}
+
+func runDaemon(webhookPort int, webhookPath, webhookSecret string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := daemon.NewServer(daemon.DefaultSocketPath(), version.Version, runForClient)
+	if webhookPort > 0 {
+		listener, err := webhook.StartEventListener(ctx, webhookPort, webhookPath, webhookSecret)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = listener.Stop() }()
+		server.AddService("webhook receiver on " + listener.GetLocalURL())
+	}
+
+	if err := server.Listen(); err != nil {
+		switch {
+		case errors.Is(err, daemon.ErrAlreadyRunning):
+			return common.NewUserError("a daemon is already running", "Run 'nylas daemon status' to see it, or 'nylas daemon stop' to stop it")
+		case errors.Is(err, daemon.ErrUnsupported):
+			return common.NewUserError("nylas daemon is not supported on this platform", "")
+		}
+		return err
+	}
+	common.EnableSecretCache(daemonSecretTTL)
+
+	if !common.IsQuiet() {
+		common.PrintSuccess("Daemon listening on %s", server.Socket())
+		for _, service := range server.Status().Services {
+			fmt.Printf("  Running %s\n", service)
+		}
+		fmt.Println(common.Dim.Sprintf("  Stop with Ctrl+C or 'nylas daemon stop'"))
+	}
+	return server.Serve(ctx)
+}
+
+func newDaemonStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the daemon is running",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, err := newDaemonClient().Status()
+			if err != nil && !errors.Is(err, daemon.ErrNotRunning) {
+				return err
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(daemonStatus{Running: status != nil, Status: status})
+			}
+			if status == nil {
+				fmt.Println("Daemon is not running.")
+				fmt.Println(common.Dim.Sprintf("Start it with: nylas daemon &"))
+				return nil
+			}
+			common.PrintSuccess("Daemon is running")
+			fmt.Printf("  PID:      %d\n", status.PID)
+			fmt.Printf("  Version:  %s\n", status.Version)
+			fmt.Printf("  Socket:   %s\n", status.Socket)
+			fmt.Printf("  Started:  %s\n", status.StartedAt.Local().Format(time.RFC3339))
+			fmt.Printf("  Commands: %d\n", status.Served)
+			for _, service := range status.Services {
+				fmt.Printf("  Running:  %s\n", service)
+			}
+			return nil
+		},
+	}
+}
+
+// daemonStatus is the structured output of `daemon status`.
+type daemonStatus struct {
+	Running bool `json:"running"`
+	*daemon.Status
+}
+
+func newDaemonStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the daemon",
+		Long:  "Stop the daemon once the commands it is running finish.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := newDaemonClient().Stop(); err != nil {
+				if errors.Is(err, daemon.ErrNotRunning) {
+					return common.NewUserError("daemon is not running", "")
+				}
+				return err
+			}
+			common.PrintSuccess("Daemon stopped")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"golang.org/x/term"
+
+	adapterconfig "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/daemon"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/version"
+)
+
+// noDaemonEnv makes the CLI run every command itself, even while a daemon
+// is running.
+const noDaemonEnv = "NYLAS_NO_DAEMON"
+
+// runInDaemon hands args to a running `nylas daemon`. ok is false when the
+// command has to run in this process: no daemon is running, NYLAS_NO_DAEMON
+// is set, stdin is a terminal, or the command is marked RunLocally. The
+// error is the command's exit status.
+func runInDaemon(args []string) (ok bool, err error) {
+	if os.Getenv(noDaemonEnv) != "" || term.IsTerminal(int(os.Stdin.Fd())) { // #nosec G115 -- file descriptors fit in int
+		return false, nil
+	}
+	socket := daemon.DefaultSocketPath()
+	if _, err := os.Stat(socket); err != nil {
+		return false, nil
+	}
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil || common.MustRunLocally(cmd, args) {
+		return false, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return false, nil
+	}
+
+	req := &daemon.Request{Version: version.Version, Args: args, Dir: dir, Env: os.Environ()}
+	code, err := daemon.NewClient(socket).Run(req, &daemon.Stdio{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr})
+	if err != nil {
+		var declined *daemon.DeclinedError
+		if errors.Is(err, daemon.ErrNotRunning) || errors.As(err, &declined) {
+			return false, nil
+		}
+		// The command may have run partly, so it is not run again.
+		return true, err
+	}
+	if code != 0 {
+		return true, &common.ExitError{Code: code}
+	}
+	return true, nil
+}
+
+// runForClient runs a client's command in the daemon. Commands read the
+// environment and write to os.Stdout directly, so for the duration of the
+// run the process takes on the client's environment, working directory and
+// files. Each run gets a fresh command tree, so no flag value carries over.
+func runForClient(ctx context.Context, req *daemon.Request, stdio *daemon.Stdio) (code int) {
+	restore, err := enterClientProcess(req, stdio)
+	if err != nil {
+		_, _ = fmt.Fprintf(stdio.Stderr, "Error: %v\n", err)
+		return common.ExitCode(err)
+	}
+	defer restore()
+
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "panic: %v\n\n%s", r, debug.Stack())
+			code = 1
+		}
+	}()
+
+	err = classifyExecuteError(rootCmd.ExecuteContextC(ctx))
+	if err == nil {
+		return 0
+	}
+	LogAuditError(err)
+	return ReportError(err)
+}
+
+// enterClientProcess sets the process up to run a command for a client and
+// returns a function that undoes it.
+func enterClientProcess(req *daemon.Request, stdio *daemon.Stdio) (restore func(), err error) {
+	var undo []func()
+	restore = func() {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+	}
+
+	env := os.Environ()
+	undo = append(undo, func() { setEnviron(env) })
+	setEnviron(req.Env)
+
+	if dir, err := os.Getwd(); err == nil {
+		undo = append(undo, func() { _ = os.Chdir(dir) })
+	}
+	if err := os.Chdir(req.Dir); err != nil {
+		restore()
+		return nil, err
+	}
+
+	args, stdin, stdout, stderr := os.Args, os.Stdin, os.Stdout, os.Stderr
+	undo = append(undo, func() { os.Args, os.Stdin, os.Stdout, os.Stderr = args, stdin, stdout, stderr })
+	os.Args = append([]string{"nylas"}, req.Args...)
+	os.Stdin, os.Stdout, os.Stderr = stdio.Stdin, stdio.Stdout, stdio.Stderr
+	undo = append(undo, common.UseColorOutput(stdio.Stdout, stdio.Stderr))
+
+	root := rootCmd
+	undo = append(undo, func() { rootCmd = root })
+	rootCmd = newRootCmd()
+	if commandSet != nil {
+		rootCmd.AddCommand(commandSet()...)
+	}
+	common.RegisterDynamicCompletions(rootCmd)
+	wrapArgsErrors(rootCmd)
+	rootCmd.SetArgs(req.Args)
+
+	// Start from the defaults auditPreRun would set, in case the command
+	// fails before it runs.
+	common.SetQuiet(false)
+	common.SetActiveEnvironment("")
+	common.SetNoCache(false)
+	_ = common.SetEmitCode("")
+	common.SetAPITimeout(0)
+	common.ResetCachedClient()
+	adapterconfig.SetPassphraseSource(promptConfigPassphrase)
+	auditMu.Lock()
+	currentAudit = nil
+	auditMu.Unlock()
+
+	return restore, nil
+}
+
+// setEnviron replaces the process environment with env.
+func setEnviron(env []string) {
+	os.Clearenv()
+	for _, kv := range env {
+		if key, value, ok := strings.Cut(kv, "="); ok && key != "" {
+			_ = os.Setenv(key, value)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+//go:build !windows
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/daemon"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/version"
+)
+
+// newEchoCmd prints its --word flags and $ECHO_SUFFIX.
+func newEchoCmd() *cobra.Command {
+	var words []string
+	var fail bool
+	cmd := &cobra.Command{
+		Use: "echo",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fail {
+				return common.NewUserError("echo failed", "")
+			}
+			dir, _ := os.Getwd()
+			fmt.Printf("%s%s %s\n", strings.Join(words, ","), os.Getenv("ECHO_SUFFIX"), filepath.Base(dir))
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&words, "word", nil, "")
+	cmd.Flags().BoolVar(&fail, "fail", false, "")
+	return cmd
+}
+
+// startTestDaemon runs a daemon serving the echo command until the test
+// ends.
+func startTestDaemon(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	socket := filepath.Join(t.TempDir(), "d.sock")
+	t.Setenv(daemon.SocketEnv, socket)
+
+	original := commandSet
+	commandSet = func() []*cobra.Command { return []*cobra.Command{newEchoCmd()} }
+	rootCmd.AddCommand(newEchoCmd())
+	t.Cleanup(func() {
+		commandSet = original
+		if echo, _, err := rootCmd.Find([]string{"echo"}); err == nil && echo != rootCmd {
+			rootCmd.RemoveCommand(echo)
+		}
+	})
+
+	server := daemon.NewServer(socket, version.Version, runForClient)
+	if err := server.Listen(); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// proxy runs args the way Execute does, with files standing in for the
+// terminal, and returns what was written to stdout and stderr.
+func proxy(t *testing.T, args ...string) (ok bool, stdout, stderr string, err error) {
+	t.Helper()
+	dir := t.TempDir()
+	files := make([]*os.File, 3)
+	for i, name := range []string{"stdin", "stdout", "stderr"} {
+		f, createErr := os.Create(filepath.Join(dir, name))
+		if createErr != nil {
+			t.Fatal(createErr)
+		}
+		defer func() { _ = f.Close() }()
+		files[i] = f
+	}
+	stdin, out, errOut := os.Stdin, os.Stdout, os.Stderr
+	os.Stdin, os.Stdout, os.Stderr = files[0], files[1], files[2]
+	ok, err = runInDaemon(args)
+	os.Stdin, os.Stdout, os.Stderr = stdin, out, errOut
+
+	read := func(f *os.File) string {
+		data, readErr := os.ReadFile(f.Name())
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		return string(data)
+	}
+	return ok, read(files[1]), read(files[2]), err
+}
+
+func TestDaemonRunsCommandsForClients(t *testing.T) {
+	startTestDaemon(t)
+	workDir := t.TempDir()
+	t.Chdir(workDir)
+	want := func(words string) string { return words + " " + filepath.Base(workDir) + "\n" }
+
+	ok, stdout, _, err := proxy(t, "echo", "--word", "a", "--word", "b")
+	if !ok || err != nil || stdout != want("a,b") {
+		t.Fatalf("first run = %v, %q, %v", ok, stdout, err)
+	}
+
+	// Flag values and the environment are the client's, not the last run's.
+	t.Setenv("ECHO_SUFFIX", "!")
+	ok, stdout, _, err = proxy(t, "echo", "--word", "c")
+	if !ok || err != nil || stdout != want("c!") {
+		t.Fatalf("second run = %v, %q, %v", ok, stdout, err)
+	}
+
+	ok, stdout, stderr, err := proxy(t, "echo", "--fail")
+	var exitErr *common.ExitError
+	if !ok || !errors.As(err, &exitErr) || exitErr.Code != common.ExitCode(common.NewUserError("echo failed", "")) {
+		t.Fatalf("failing run = %v, %v", ok, err)
+	}
+	if stdout != "" || !strings.Contains(stderr, "echo failed") {
+		t.Errorf("failing run wrote stdout %q, stderr %q", stdout, stderr)
+	}
+}
+
+func TestDaemonLeavesLocalCommands(t *testing.T) {
+	startTestDaemon(t)
+
+	for _, args := range [][]string{
+		{"daemon", "status"},
+		{"nosuchcommand"},
+	} {
+		if ok, _, _, _ := proxy(t, args...); ok {
+			t.Errorf("%v ran in the daemon", args)
+		}
+	}
+
+	t.Setenv(noDaemonEnv, "1")
+	if ok, _, _, _ := proxy(t, "echo"); ok {
+		t.Errorf("echo ran in the daemon with %s set", noDaemonEnv)
+	}
+}
+
+func TestMustRunLocally(t *testing.T) {
+	parent := common.RunLocally(&cobra.Command{Use: "server"})
+	child := &cobra.Command{Use: "start"}
+	parent.AddCommand(child)
+
+	watch := common.RunLocally(&cobra.Command{Use: "status"}, "watch")
+	watch.Flags().DurationP("watch", "w", 0, "")
+
+	tests := []struct {
+		cmd  *cobra.Command
+		args []string
+		want bool
+	}{
+		{child, nil, true},
+		{watch, []string{"status"}, false},
+		{watch, []string{"status", "--watch", "5s"}, true},
+		{watch, []string{"status", "--watch=5s"}, true},
+		{watch, []string{"status", "-w5s"}, true},
+		{watch, []string{"status", "--", "--watch"}, false},
+	}
+	for _, tt := range tests {
+		if got := common.MustRunLocally(tt.cmd, tt.args); got != tt.want {
+			t.Errorf("MustRunLocally(%s, %v) = %v, want %v", tt.cmd.Name(), tt.args, got, tt.want)
+		}
+	}
+}
@@ -18,7 +18,7 @@ func newAppsCmd() *cobra.Command {
 		Long:  `List and create Nylas applications via the Dashboard API.`,
 	}
 
-	cmd.AddCommand(newAppsListCmd())
+	cmd.AddCommand(common.DeclareOutput(newAppsListCmd(), []appRow{}))
 	cmd.AddCommand(newAppsCreateCmd())
 	cmd.AddCommand(newAppsUseCmd())
 	cmd.AddCommand(newAPIKeysCmd())
@@ -4,6 +4,8 @@ package dashboard
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
 )
 
 // NewDashboardCmd creates the dashboard command group.
@@ -27,9 +29,9 @@ Commands:
 Guide: https://developer.nylas.com/docs/dev-guide/dashboard/`,
 	}
 
-	cmd.AddCommand(newRegisterCmd())
-	cmd.AddCommand(newLoginCmd())
-	cmd.AddCommand(newSSOCmd())
+	cmd.AddCommand(common.RunLocally(newRegisterCmd()))
+	cmd.AddCommand(common.RunLocally(newLoginCmd()))
+	cmd.AddCommand(common.RunLocally(newSSOCmd()))
 	cmd.AddCommand(newLogoutCmd())
 	cmd.AddCommand(newStatusCmd())
 	cmd.AddCommand(newRefreshCmd())
@@ -34,14 +34,14 @@ custom domain, configure the DNS records printed by the dns command, then run
 verification.`,
 	}
 
-	cmd.AddCommand(newDomainsListCmd())
-	cmd.AddCommand(newDomainsCheckCmd())
-	cmd.AddCommand(newDomainsCreateCmd())
-	cmd.AddCommand(newDomainsShowCmd())
-	cmd.AddCommand(newDomainsDNSCmd())
-	cmd.AddCommand(newDomainsVerifyCmd())
-	cmd.AddCommand(newDomainsUpdateCmd())
-	cmd.AddCommand(newDomainsDeleteCmd())
+	cmd.AddCommand(common.DeclareOutput(newDomainsListCmd(), domainListResult{}))
+	cmd.AddCommand(common.DeclareOutput(newDomainsCheckCmd(), domainAvailabilityRow{}))
+	cmd.AddCommand(common.DeclareOutput(newDomainsCreateCmd(), domainRow{}))
+	cmd.AddCommand(common.DeclareOutput(newDomainsShowCmd(), domainRow{}))
+	cmd.AddCommand(common.DeclareOutput(newDomainsDNSCmd(), []domainDNSRow{}))
+	cmd.AddCommand(common.DeclareOutput(newDomainsVerifyCmd(), []domainVerificationRow{}))
+	cmd.AddCommand(common.DeclareOutput(newDomainsUpdateCmd(), domainRow{}))
+	cmd.AddCommand(common.DeclareOutput(newDomainsDeleteCmd(), domainDeleteResult{}))
 
 	return cmd
 }
@@ -557,14 +557,17 @@ func listDomainsForResolution(ctx context.Context, domainSvc domainService) ([]d
 	return nil, fmt.Errorf("too many domain pages while resolving region; pass --region us or --region eu")
 }
 
+// domainDeleteResult is the --json output of domains delete.
+type domainDeleteResult struct {
+	Success bool `json:"success"`
+}
+
 func writeDomainDeleteResult(cmd *cobra.Command, deleted bool) error {
 	if !deleted {
 		return dashboardError("domain was not deleted", "Check the domain ID and region")
 	}
 	if common.IsStructuredOutput(cmd) {
-		return common.GetOutputWriter(cmd).Write(struct {
-			Success bool `json:"success"`
-		}{Success: true})
+		return common.GetOutputWriter(cmd).Write(domainDeleteResult{Success: true})
 	}
 	common.PrintSuccess("Domain deleted")
 	return nil
@@ -24,7 +24,7 @@ func newAPIKeysCmd() *cobra.Command {
 		Short:   "Manage API keys for an application",
 	}
 
-	cmd.AddCommand(newAPIKeysListCmd())
+	cmd.AddCommand(common.DeclareOutput(newAPIKeysListCmd(), []apiKeyRow{}))
 	cmd.AddCommand(newAPIKeysCreateCmd())
 
 	return cmd
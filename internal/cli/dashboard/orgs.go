@@ -15,7 +15,7 @@ func newOrgsCmd() *cobra.Command {
 		Long:  `List and manage organizations you belong to.`,
 	}
 
-	cmd.AddCommand(newOrgsListCmd())
+	cmd.AddCommand(common.DeclareOutput(newOrgsListCmd(), []orgRow{}))
 	cmd.AddCommand(newSwitchOrgCmd())
 
 	return cmd
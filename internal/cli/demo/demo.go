@@ -3,9 +3,33 @@
 package demo
 
 import (
+	"fmt"
+	"os"
+	"slices"
+
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/calendar"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/cli/contacts"
+	"github.com/nylas/cli/internal/cli/email"
+	"github.com/nylas/cli/internal/cli/notetaker"
+	"github.com/nylas/cli/internal/cli/scheduler"
+	"github.com/nylas/cli/internal/domain"
 )
 
+// demoGrantID is the grant demo commands use when none is given.
+const demoGrantID = "demo-grant"
+
+// localOnlyCommands are left out of demo mode: they change local settings
+// or reach real servers rather than the Nylas API. Commands that need an AI
+// provider are left out as well, so sample data never reaches a model.
+var localOnlyCommands = map[string]bool{
+	"email smtp":               true,
+	"scheduler bookings watch": true,
+}
+
 // NewDemoCmd creates the demo parent command with all demo subcommands.
 func NewDemoCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -14,13 +38,17 @@ func NewDemoCmd() *cobra.Command {
 		Args:  cobra.NoArgs,
 		Long: `Demo mode lets you try out the Nylas CLI without any account or credentials.
 
-All commands use realistic sample data so you can explore:
-  - Email management (list, send simulation)
-  - Calendar events (list, create simulation)
-  - Contacts management
-  - Scheduling capabilities
-  - AI notetaker features
-  - Interactive TUI
+Every command under demo is the real command, with the same flags and
+output formats, run against sample data instead of the Nylas API:
+  - nylas demo email ...      Email, threads, drafts, folders
+  - nylas demo calendar ...   Calendars and events
+  - nylas demo contacts ...   Contacts and groups
+  - nylas demo scheduler ...  Scheduling pages and bookings
+  - nylas demo notetaker ...  AI notetaker
+  - nylas demo tui            Interactive TUI
+
+Changes such as sending, creating or deleting are simulated; nothing leaves
+your machine.
 
 This is perfect for:
   - Evaluating the CLI before signing up
@@ -28,6 +56,15 @@ This is perfect for:
   - Taking screenshots for documentation
   - Testing integrations with mock data
 
+By default the same handful of hand-written samples is shown. With --seed or
+--size, a synthetic mailbox, calendar and contact list is generated instead.
+The same seed and size always produce the same data, dated around the
+current day, so output is stable for screenshots and rendering tests.
+
+  small   25 messages, 15 events, 20 contacts
+  medium  250 messages, 120 events, 150 contacts
+  large   5000 messages, 1500 events, 2000 contacts
+
 To connect your real email account, run: nylas auth login`,
 		Example: `  # Explore the interactive TUI with sample data
   nylas demo tui
@@ -35,29 +72,121 @@ To connect your real email account, run: nylas auth login`,
   # List sample emails
   nylas demo email list
 
+  # Any output format works, as with the real commands
+  nylas demo email list --json
+
   # List sample calendar events
-  nylas demo calendar list
+  nylas demo calendar events list
 
   # List sample contacts
   nylas demo contacts list
 
-  # Try the scheduler
-  nylas demo scheduler list
-
   # Try the notetaker
-  nylas demo notetaker list`,
+  nylas demo notetaker list
+
+  # Generated, reproducible dataset
+  nylas demo email list --seed 42 --size medium --limit 50`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
 		},
 	}
 
+	cmd.PersistentFlags().Int64("seed", 0, "Generate sample data from this seed")
+	cmd.PersistentFlags().Var(new(sizeFlag), "size", "Generated dataset size: small, medium, large (default small)")
+
 	// Add demo subcommands
 	cmd.AddCommand(newDemoTUICmd())
-	cmd.AddCommand(newDemoEmailCmd())
-	cmd.AddCommand(newDemoCalendarCmd())
-	cmd.AddCommand(newDemoContactsCmd())
-	cmd.AddCommand(newDemoSchedulerCmd())
-	cmd.AddCommand(newDemoNotetakerCmd())
+	for _, sub := range []*cobra.Command{
+		email.NewEmailCmd(),
+		calendar.NewCalendarCmd(),
+		contacts.NewContactsCmd(),
+		scheduler.NewSchedulerCmd(),
+		notetaker.NewNotetakerCmd(),
+	} {
+		useDemoClient(sub, "")
+		cmd.AddCommand(sub)
+	}
+
+	return common.RunLocally(cmd)
+}
+
+// useDemoClient makes cmd and its subcommands run against the demo client.
+// path is cmd's parent path below demo, used to match localOnlyCommands.
+func useDemoClient(cmd *cobra.Command, path string) {
+	if path != "" {
+		path += " "
+	}
+	path += cmd.Name()
+
+	for _, sub := range cmd.Commands() {
+		if localOnlyCommands[path+" "+sub.Name()] || needsAI(sub) {
+			cmd.RemoveCommand(sub)
+			continue
+		}
+		useDemoClient(sub, path)
+	}
+
+	// PreRunE runs before RunE or Run; cobra ignores PreRun when it is set.
+	preRunE, preRun := cmd.PreRunE, cmd.PreRun
+	cmd.PreRun = nil
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		enableDemoMode(cmd)
+		switch {
+		case preRunE != nil:
+			return preRunE(cmd, args)
+		case preRun != nil:
+			preRun(cmd, args)
+		}
+		return nil
+	}
+}
+
+// needsAI reports whether cmd, or a parent, is marked as needing an AI
+// provider.
+func needsAI(cmd *cobra.Command) bool {
+	_, caps := common.CommandPermissions(cmd)
+	return slices.Contains(caps, domain.CapabilityAI)
+}
+
+// enableDemoMode points the command at sample data and says so on stderr,
+// leaving stdout to the command's own output.
+func enableDemoMode(cmd *cobra.Command) {
+	common.EnableDemoMode(newDemoClient(cmd), demoGrantID)
+	if !common.IsQuiet() && !common.IsStructuredOutput(cmd) {
+		_, _ = fmt.Fprintln(os.Stderr, common.Dim.Sprint("Demo mode: sample data, changes are simulated. Connect your account with: nylas auth login"))
+	}
+}
 
-	return cmd
+// sizeFlag is a --size value, checked when the flag is parsed.
+type sizeFlag nylas.DemoSize
+
+func (f *sizeFlag) String() string { return string(*f) }
+func (f *sizeFlag) Type() string   { return "size" }
+
+func (f *sizeFlag) Set(s string) error {
+	size, err := nylas.ParseDemoSize(s)
+	if err != nil {
+		return err
+	}
+	*f = sizeFlag(size)
+	return nil
+}
+
+// newDemoClient returns the client for a demo command: the built-in
+// samples, or a generated dataset when --seed or --size is given.
+func newDemoClient(cmd *cobra.Command) *nylas.DemoClient {
+	flags := cmd.Flags()
+	if !flags.Changed("seed") && !flags.Changed("size") {
+		return nylas.NewDemoClient()
+	}
+
+	seed := int64(1)
+	if flags.Changed("seed") {
+		seed, _ = flags.GetInt64("seed")
+	}
+	size := nylas.DemoSizeSmall
+	if f := flags.Lookup("size"); f != nil && f.Changed {
+		size = nylas.DemoSize(f.Value.String())
+	}
+	return nylas.NewSeededDemoClient(seed, size)
 }
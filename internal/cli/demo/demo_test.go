@@ -0,0 +1,87 @@
+package demo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestNewDemoCmd_UsesRealCommands(t *testing.T) {
+	cmd := NewDemoCmd()
+
+	for _, path := range [][]string{
+		{"email", "list"},
+		{"email", "threads", "list"},
+		{"calendar", "events", "list"},
+		{"contacts", "list"},
+		{"notetaker", "list"},
+	} {
+		found, _, err := cmd.Find(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, path[len(path)-1], found.Name())
+		assert.NotNil(t, found.PreRunE, "%v should switch to demo mode", path)
+	}
+
+	email, _, err := cmd.Find([]string{"email"})
+	require.NoError(t, err)
+	for _, sub := range email.Commands() {
+		assert.NotEqual(t, "smtp", sub.Name(), "local-only commands are left out")
+	}
+}
+
+func TestNewDemoCmd_LeavesOutExternalServices(t *testing.T) {
+	cmd := NewDemoCmd()
+
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		path := c.CommandPath()
+		_, caps := common.CommandPermissions(c)
+		assert.False(t, slices.Contains(caps, domain.CapabilityAI), "%s would send sample data to an AI provider", path)
+		assert.False(t, slices.Contains(caps, domain.CapabilityKeychain), "%s would use local credentials", path)
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(cmd)
+
+	for _, path := range [][]string{
+		{"email", "smtp"},
+		{"email", "summarize"},
+		{"email", "digest"},
+		{"email", "ai", "analyze"},
+		{"calendar", "ai", "analyze-thread"},
+		{"calendar", "schedule", "ai"},
+		{"notetaker", "actions"},
+		{"scheduler", "bookings", "watch"},
+	} {
+		found, _, err := cmd.Find(path)
+		if err == nil {
+			assert.NotEqual(t, path[len(path)-1], found.Name(), "%v should not be in demo mode", path)
+		}
+	}
+}
+
+func TestDemoCommandsRunOnDemoClient(t *testing.T) {
+	t.Cleanup(func() { common.EnableDemoMode(nil, "") })
+
+	root := &cobra.Command{Use: "nylas", SilenceUsage: true, SilenceErrors: true}
+	root.PersistentFlags().Bool("json", false, "")
+	root.AddCommand(NewDemoCmd())
+	root.SetArgs([]string{"demo", "contacts", "list", "--seed", "9", "--size", "small", "--json"})
+	require.NoError(t, root.Execute())
+
+	client, err := common.GetNylasClient()
+	require.NoError(t, err)
+	assert.IsType(t, &nylas.DemoClient{}, client)
+
+	grantID, err := common.GetGrantID(nil)
+	require.NoError(t, err)
+	assert.Equal(t, demoGrantID, grantID)
+}
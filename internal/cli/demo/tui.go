@@ -53,7 +53,7 @@ Themes:
 			if len(args) > 0 {
 				initialView = args[0]
 			}
-			return runDemoTUI(time.Duration(refreshInterval)*time.Second, initialView, tui.ThemeName(theme))
+			return runDemoTUI(newDemoClient(cmd), time.Duration(refreshInterval)*time.Second, initialView, tui.ThemeName(theme))
 		},
 	}
 
@@ -63,9 +63,7 @@ Themes:
 	return cmd
 }
 
-func runDemoTUI(refreshInterval time.Duration, initialView string, theme tui.ThemeName) error {
-	client := nylas.NewDemoClient()
-
+func runDemoTUI(client *nylas.DemoClient, refreshInterval time.Duration, initialView string, theme tui.ThemeName) error {
 	app := tui.NewApp(tui.Config{
 		Client:          client,
 		GrantID:         "demo-grant-001",
@@ -0,0 +1,122 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func newCleanCmd() *cobra.Command {
+	var (
+		grantID string
+		yes     bool
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove the data seeded into a sandbox account",
+		Long: `Remove the messages, events and contacts 'nylas dev seed' created.
+
+Only objects recorded by seed are deleted; everything else in the account is
+left alone. Messages are removed by thread, which covers both the sent and the
+received copy. Objects already deleted elsewhere are skipped. Anything that
+fails stays recorded, so clean can be run again.`,
+		Example: `  # Remove everything seeded into a sandbox account
+  nylas dev clean --grant <sandbox-grant>
+
+  # Show what would be removed
+  nylas dev clean --grant <sandbox-grant> --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := common.ValidateRequiredFlag("--grant", grantID); err != nil {
+				return err
+			}
+
+			return withSeedClient(grantID, func(ctx context.Context, client seedClient, grantID string) error {
+				store := openSeedStore()
+				items, err := store.List(grantID)
+				if err != nil {
+					return common.WrapLoadError("seeded items", err)
+				}
+				if len(items) == 0 {
+					common.PrintEmptyStateWithHint("seeded data", "Seed an account with: nylas dev seed --grant "+grantID)
+					return nil
+				}
+
+				counts := countSeedKinds(items)
+				summary := fmt.Sprintf("%d threads, %d events and %d contacts", counts[domain.SeedThread]+counts[domain.SeedMessage], counts[domain.SeedEvent], counts[domain.SeedContact])
+				if dryRun {
+					fmt.Printf("Would delete %s\n", summary)
+					return nil
+				}
+				if !yes && !common.Confirm("Delete "+summary+"?", false) {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+
+				var removed []string
+				var failed int
+				for _, item := range items {
+					if err := deleteSeeded(ctx, client, grantID, item); err != nil && !isGone(err) {
+						failed++
+						common.PrintWarningStderr("could not delete %s %s: %v", item.Kind, item.ID, err)
+						continue
+					}
+					removed = append(removed, item.ID)
+				}
+				if err := store.Delete(grantID, removed...); err != nil {
+					return common.WrapSaveError("seeded items", err)
+				}
+
+				common.PrintSuccess("Deleted %d of %d seeded objects", len(removed), len(items))
+				if failed > 0 {
+					return common.NewUserError(
+						fmt.Sprintf("%d seeded objects could not be deleted", failed),
+						"Run 'nylas dev clean --grant "+grantID+"' again to retry them",
+					)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&grantID, "grant", "", "Sandbox grant ID or email to clean (required)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be deleted without deleting it")
+
+	return cmd
+}
+
+func countSeedKinds(items []domain.SeededItem) map[domain.SeedKind]int {
+	counts := make(map[domain.SeedKind]int)
+	for _, item := range items {
+		counts[item.Kind]++
+	}
+	return counts
+}
+
+func deleteSeeded(ctx context.Context, client seedClient, grantID string, item domain.SeededItem) error {
+	switch item.Kind {
+	case domain.SeedThread:
+		return client.DeleteThread(ctx, grantID, item.ID)
+	case domain.SeedMessage:
+		return client.DeleteMessage(ctx, grantID, item.ID)
+	case domain.SeedEvent:
+		return client.DeleteEvent(ctx, grantID, item.CalendarID, item.ID)
+	case domain.SeedContact:
+		return client.DeleteContact(ctx, grantID, item.ID)
+	}
+	return fmt.Errorf("unknown kind %q", item.Kind)
+}
+
+// isGone reports whether err means the object no longer exists.
+func isGone(err error) bool {
+	var apiErr *domain.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
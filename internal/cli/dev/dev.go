@@ -0,0 +1,59 @@
+// Package dev provides developer tooling commands.
+package dev
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/devseed"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// seedClient is the part of the Nylas client seed and clean use.
+type seedClient interface {
+	GetGrant(ctx context.Context, grantID string) (*domain.Grant, error)
+	GetCalendars(ctx context.Context, grantID string) ([]domain.Calendar, error)
+	SendMessage(ctx context.Context, grantID string, req *domain.SendMessageRequest) (*domain.Message, error)
+	CreateEvent(ctx context.Context, grantID, calendarID string, req *domain.CreateEventRequest) (*domain.Event, error)
+	CreateContact(ctx context.Context, grantID string, req *domain.CreateContactRequest) (*domain.Contact, error)
+	DeleteThread(ctx context.Context, grantID, threadID string) error
+	DeleteMessage(ctx context.Context, grantID, messageID string) error
+	DeleteEvent(ctx context.Context, grantID, calendarID, eventID string) error
+	DeleteContact(ctx context.Context, grantID, contactID string) error
+}
+
+// withSeedClient runs fn with a client for grantID. Replaced in tests.
+var withSeedClient = func(grantID string, fn func(context.Context, seedClient, string) error) error {
+	_, err := common.WithClient([]string{grantID}, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+		return struct{}{}, fn(ctx, client, grantID)
+	})
+	return err
+}
+
+// openSeedStore opens the record of seeded objects. Replaced in tests.
+var openSeedStore = func() ports.SeedStore {
+	return devseed.New(filepath.Join(config.DefaultConfigDir(), "dev-seed.json"))
+}
+
+// NewDevCmd creates the dev command group.
+func NewDevCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Developer tools for sandbox accounts",
+		Long: `Developer tools for working with sandbox accounts.
+
+Seed a test account with realistic synthetic messages, events and contacts
+for demos and integration tests, then remove exactly what was seeded.`,
+	}
+
+	cmd.AddCommand(common.RequireScopes(newSeedCmd(),
+		domain.ScopeEmailSend, domain.ScopeCalendarRead, domain.ScopeCalendarWrite, domain.ScopeContactsWrite))
+	cmd.AddCommand(common.RequireScopes(newCleanCmd(),
+		domain.ScopeEmailModify, domain.ScopeCalendarWrite, domain.ScopeContactsWrite))
+
+	return cmd
+}
@@ -0,0 +1,221 @@
+package dev
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Synthetic people work at companies under example.com, which is reserved
+// for documentation, so nothing seeded can reach a real mailbox.
+var (
+	seedFirstNames = []string{"Ava", "Ben", "Chloe", "Daniel", "Elena", "Farid", "Grace", "Hiro", "Isabel", "Jonas", "Kira", "Liam", "Maya", "Noah", "Olivia", "Priya", "Quinn", "Rafael", "Sofia", "Tariq", "Uma", "Victor", "Wen", "Yara", "Zane"}
+	seedLastNames  = []string{"Anders", "Brooks", "Castillo", "Dubois", "Evans", "Fischer", "Garcia", "Hughes", "Ito", "Jensen", "Kowalski", "Lindqvist", "Moreau", "Nakamura", "Okafor", "Patel", "Quinn", "Rossi", "Schmidt", "Tanaka", "Ueda", "Varga", "Walsh", "Xu", "Young"}
+	seedCompanies  = []string{"Acme", "Bluepeak", "Cobalt Labs", "Driftwood", "Evergreen", "Foundry", "Granite", "Harbor", "Ironwood", "Juniper"}
+	seedJobTitles  = []string{"Account Executive", "Product Manager", "Engineering Lead", "Designer", "Finance Director", "Operations Manager", "Customer Success Manager", "Recruiter", "Data Analyst", "CTO"}
+
+	seedSubjects = []string{
+		"Q%d budget review", "Contract renewal for %s", "Onboarding checklist", "Invoice #%d",
+		"Feedback on the %s proposal", "Weekly status update", "Travel plans for the offsite",
+		"Interview loop for %s", "Launch readiness", "Security questionnaire", "Quarterly roadmap",
+		"Follow-up from today's call", "Pricing question", "Updated slide deck", "Hiring plan",
+	}
+	seedOpenings = []string{
+		"Thanks for the quick turnaround on this.",
+		"Following up on our conversation earlier.",
+		"Sharing the latest version for your review.",
+		"A few notes before we meet.",
+		"Circling back on the open items.",
+	}
+	seedMiddles = []string{
+		"The numbers look good overall, but the timeline is tight.",
+		"Legal signed off on the main terms; two clauses are still open.",
+		"I've attached the details so we can go through them together.",
+		"The team prefers the second option, mostly for cost reasons.",
+		"We should loop in finance before committing to the new scope.",
+		"Let me know if anything is missing and I'll update it today.",
+	}
+	seedClosings = []string{"Best,", "Thanks,", "Cheers,", "Talk soon,", "Regards,"}
+
+	seedEventTitles = []string{
+		"1:1 with %s", "Project sync", "Design review", "Customer call: %s", "Sprint planning",
+		"Lunch with %s", "Interview: %s", "Roadmap review", "Team standup", "Budget check-in",
+	}
+	seedLocations = []string{"", "Room 4A", "Main office", "Video call", "Cafe downstairs", "Room 2B"}
+	seedRRules    = []string{"RRULE:FREQ=WEEKLY;COUNT=8", "RRULE:FREQ=DAILY;COUNT=5;BYDAY=MO,TU,WE,TH,FR", "RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=6"}
+)
+
+// seedCounts is how many of each object to generate.
+type seedCounts struct {
+	messages int
+	events   int
+	contacts int
+}
+
+// seedMessage is a message to send. replyTo is the index of the message it
+// answers, or -1 for the first message of a thread.
+type seedMessage struct {
+	req     domain.SendMessageRequest
+	replyTo int
+}
+
+// seedData is everything one `dev seed` run creates.
+type seedData struct {
+	messages []seedMessage
+	events   []domain.CreateEventRequest
+	contacts []domain.CreateContactRequest
+}
+
+// threads returns the number of message threads.
+func (d seedData) threads() int {
+	n := 0
+	for _, m := range d.messages {
+		if m.replyTo < 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// recurring returns the number of recurring events.
+func (d seedData) recurring() int {
+	n := 0
+	for _, e := range d.events {
+		if len(e.Recurrence) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// seedPerson is a synthetic person used in contacts and message text.
+type seedPerson struct {
+	first, last, company string
+}
+
+func (p seedPerson) name() string { return p.first + " " + p.last }
+
+func (p seedPerson) email() string {
+	domainPart := strings.ToLower(strings.ReplaceAll(p.company, " ", ""))
+	return strings.ToLower(p.first+"."+p.last) + "@" + domainPart + ".example.com"
+}
+
+func randomPerson(rng *rand.Rand) seedPerson {
+	return seedPerson{
+		first:   pick(rng, seedFirstNames),
+		last:    pick(rng, seedLastNames),
+		company: pick(rng, seedCompanies),
+	}
+}
+
+func pick(rng *rand.Rand, options []string) string {
+	return options[rng.Intn(len(options))]
+}
+
+// generateSeedData builds the objects of one seed run. The same rng seed
+// always produces the same data. Messages go to self, the grant's own
+// address, in threads of one to four messages.
+func generateSeedData(rng *rand.Rand, counts seedCounts, self string, now time.Time) seedData {
+	var data seedData
+
+	for range counts.contacts {
+		p := randomPerson(rng)
+		data.contacts = append(data.contacts, domain.CreateContactRequest{
+			GivenName:    p.first,
+			Surname:      p.last,
+			CompanyName:  p.company,
+			JobTitle:     pick(rng, seedJobTitles),
+			Emails:       []domain.ContactEmail{{Email: p.email(), Type: "work"}},
+			PhoneNumbers: []domain.ContactPhone{{Number: fmt.Sprintf("+1-555-%04d", rng.Intn(10000)), Type: "work"}},
+		})
+	}
+
+	to := []domain.EmailParticipant{{Email: self}}
+	for len(data.messages) < counts.messages {
+		p := randomPerson(rng)
+		subject := seedSubject(rng, p)
+		req := domain.SendMessageRequest{Subject: subject, Body: seedBody(rng, p), To: to}
+		if rng.Intn(5) == 0 {
+			req.Attachments = []domain.Attachment{seedAttachment(rng, subject)}
+		}
+		data.messages = append(data.messages, seedMessage{req: req, replyTo: -1})
+
+		replies := min(rng.Intn(4), counts.messages-len(data.messages))
+		for range replies {
+			data.messages = append(data.messages, seedMessage{
+				req:     domain.SendMessageRequest{Subject: "Re: " + subject, Body: seedBody(rng, p), To: to},
+				replyTo: len(data.messages) - 1,
+			})
+		}
+	}
+
+	// Events fall in business hours of now's location, which must be a
+	// named zone: recurring events need one.
+	tz := now.Location().String()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for range counts.events {
+		p := randomPerson(rng)
+		title := pick(rng, seedEventTitles)
+		if strings.Contains(title, "%s") {
+			title = fmt.Sprintf(title, p.name())
+		}
+		start := day.AddDate(0, 0, rng.Intn(37)-7).Add(time.Duration(9*60+rng.Intn(16)*30) * time.Minute)
+		length := time.Duration(30*(1+rng.Intn(3))) * time.Minute
+		event := domain.CreateEventRequest{
+			Title:       title,
+			Description: pick(rng, seedMiddles),
+			Location:    pick(rng, seedLocations),
+			When: domain.EventWhen{
+				StartTime: start.Unix(), EndTime: start.Add(length).Unix(),
+				StartTimezone: tz, EndTimezone: tz,
+			},
+			Busy: true,
+		}
+		if rng.Intn(5) == 0 {
+			event.Recurrence = []string{pick(rng, seedRRules)}
+		}
+		data.events = append(data.events, event)
+	}
+
+	return data
+}
+
+func seedSubject(rng *rand.Rand, p seedPerson) string {
+	subject := pick(rng, seedSubjects)
+	switch {
+	case strings.Contains(subject, "Q%d"):
+		return fmt.Sprintf(subject, 1+rng.Intn(4))
+	case strings.Contains(subject, "#%d"):
+		return fmt.Sprintf(subject, 10000+rng.Intn(90000))
+	case strings.Contains(subject, "%s"):
+		return fmt.Sprintf(subject, p.company)
+	}
+	return subject
+}
+
+func seedBody(rng *rand.Rand, p seedPerson) string {
+	return fmt.Sprintf("<p>Hi,</p><p>%s %s</p><p>%s</p><p>%s<br>%s<br>%s</p>",
+		pick(rng, seedOpenings), pick(rng, seedMiddles), pick(rng, seedMiddles),
+		pick(rng, seedClosings), p.name(), p.company)
+}
+
+// seedAttachment returns a small CSV report or text notes.
+func seedAttachment(rng *rand.Rand, subject string) domain.Attachment {
+	base := strings.ToLower(strings.Join(strings.FieldsFunc(subject, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	}), "-"))
+	if rng.Intn(2) == 0 {
+		var sb strings.Builder
+		sb.WriteString("item,quantity,amount\n")
+		for i := range 3 + rng.Intn(8) {
+			fmt.Fprintf(&sb, "line-%d,%d,%d.%02d\n", i+1, 1+rng.Intn(20), 10+rng.Intn(990), rng.Intn(100))
+		}
+		content := []byte(sb.String())
+		return domain.Attachment{Filename: base + ".csv", ContentType: "text/csv", Size: int64(len(content)), Content: content}
+	}
+	content := []byte(strings.Join([]string{subject, "", pick(rng, seedMiddles), pick(rng, seedMiddles)}, "\n") + "\n")
+	return domain.Attachment{Filename: base + "-notes.txt", ContentType: "text/plain", Size: int64(len(content)), Content: content}
+}
@@ -0,0 +1,251 @@
+package dev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/devseed"
+	"github.com/nylas/cli/internal/cli/common"
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSeedClient creates objects with sequential IDs and records deletes.
+type fakeSeedClient struct {
+	sent      []domain.SendMessageRequest
+	events    []string
+	contacts  int
+	deleted   []string
+	deleteErr map[string]error
+	failSend  int // fail the nth send (1-based); 0 never fails
+}
+
+func (f *fakeSeedClient) GetGrant(_ context.Context, grantID string) (*domain.Grant, error) {
+	return &domain.Grant{ID: grantID, Email: "sandbox@example.com"}, nil
+}
+
+func (f *fakeSeedClient) GetCalendars(_ context.Context, _ string) ([]domain.Calendar, error) {
+	return []domain.Calendar{
+		{ID: "cal-readonly", IsPrimary: true, ReadOnly: true},
+		{ID: "cal-writable"},
+	}, nil
+}
+
+func (f *fakeSeedClient) SendMessage(_ context.Context, _ string, req *domain.SendMessageRequest) (*domain.Message, error) {
+	if f.failSend > 0 && len(f.sent)+1 == f.failSend {
+		return nil, errors.New("send failed")
+	}
+	f.sent = append(f.sent, *req)
+	id := fmt.Sprintf("msg-%d", len(f.sent))
+	// Replies join the thread of the message they answer.
+	thread := "thread-" + strings.TrimPrefix(req.Subject, "Re: ")
+	return &domain.Message{ID: id, ThreadID: thread}, nil
+}
+
+func (f *fakeSeedClient) CreateEvent(_ context.Context, _, calendarID string, _ *domain.CreateEventRequest) (*domain.Event, error) {
+	f.events = append(f.events, calendarID)
+	return &domain.Event{ID: fmt.Sprintf("event-%d", len(f.events))}, nil
+}
+
+func (f *fakeSeedClient) CreateContact(_ context.Context, _ string, _ *domain.CreateContactRequest) (*domain.Contact, error) {
+	f.contacts++
+	return &domain.Contact{ID: fmt.Sprintf("contact-%d", f.contacts)}, nil
+}
+
+func (f *fakeSeedClient) del(id string) error {
+	if err := f.deleteErr[id]; err != nil {
+		return err
+	}
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeSeedClient) DeleteThread(_ context.Context, _, id string) error  { return f.del(id) }
+func (f *fakeSeedClient) DeleteMessage(_ context.Context, _, id string) error { return f.del(id) }
+func (f *fakeSeedClient) DeleteEvent(_ context.Context, _, _, id string) error {
+	return f.del(id)
+}
+func (f *fakeSeedClient) DeleteContact(_ context.Context, _, id string) error { return f.del(id) }
+
+// stubDev points the command at client and a temporary seed store.
+func stubDev(t *testing.T, client *fakeSeedClient) ports.SeedStore {
+	t.Helper()
+	store := devseed.New(filepath.Join(t.TempDir(), "dev-seed.json"))
+	origClient, origStore := withSeedClient, openSeedStore
+	t.Cleanup(func() { withSeedClient, openSeedStore = origClient, origStore })
+	withSeedClient = func(grantID string, fn func(context.Context, seedClient, string) error) error {
+		return fn(context.Background(), client, grantID)
+	}
+	openSeedStore = func() ports.SeedStore { return store }
+	return store
+}
+
+func runDev(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := &cobra.Command{Use: "test", SilenceErrors: true, SilenceUsage: true}
+	common.AddOutputFlags(root)
+	root.AddCommand(NewDevCmd())
+	stdout, _, err := clitestutil.ExecuteCommand(root, append([]string{"dev"}, args...)...)
+	return stdout, err
+}
+
+func TestGenerateSeedData(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	counts := seedCounts{messages: 50, events: 30, contacts: 40}
+
+	data := generateSeedData(rand.New(rand.NewSource(7)), counts, "me@sandbox.test", now)
+
+	require.Len(t, data.messages, 50)
+	require.Len(t, data.events, 30)
+	require.Len(t, data.contacts, 40)
+	assert.Equal(t, data, generateSeedData(rand.New(rand.NewSource(7)), counts, "me@sandbox.test", now), "same seed, same data")
+
+	assert.Equal(t, -1, data.messages[0].replyTo)
+	assert.Less(t, data.threads(), 50, "some messages are replies")
+	attachments := 0
+	for i, m := range data.messages {
+		assert.Equal(t, []domain.EmailParticipant{{Email: "me@sandbox.test"}}, m.req.To)
+		if m.replyTo >= 0 {
+			assert.Less(t, m.replyTo, i)
+			assert.True(t, strings.HasPrefix(m.req.Subject, "Re: "))
+		}
+		for _, a := range m.req.Attachments {
+			attachments++
+			assert.NotEmpty(t, a.Content)
+			assert.Equal(t, int64(len(a.Content)), a.Size)
+		}
+	}
+	assert.Positive(t, attachments)
+
+	for _, c := range data.contacts {
+		require.Len(t, c.Emails, 1)
+		assert.True(t, strings.HasSuffix(c.Emails[0].Email, ".example.com"), c.Emails[0].Email)
+	}
+	for _, e := range data.events {
+		assert.Greater(t, e.When.EndTime, e.When.StartTime)
+		assert.Equal(t, "UTC", e.When.StartTimezone)
+		assert.Empty(t, e.Participants, "seeded events never invite anyone")
+	}
+	assert.Positive(t, data.recurring())
+}
+
+func TestSeedCmd_CreatesAndRecords(t *testing.T) {
+	client := &fakeSeedClient{}
+	store := stubDev(t, client)
+
+	_, err := runDev(t, "seed", "--grant", "grant-1", "--messages", "12", "--events", "3", "--contacts", "4", "--seed", "1", "--yes")
+	require.NoError(t, err)
+
+	require.Len(t, client.sent, 12)
+	assert.Equal(t, []string{"cal-writable", "cal-writable", "cal-writable"}, client.events)
+	assert.Equal(t, 4, client.contacts)
+	for _, req := range client.sent {
+		if strings.HasPrefix(req.Subject, "Re: ") {
+			assert.NotEmpty(t, req.ReplyToMsgID)
+		}
+	}
+
+	items, err := store.List("grant-1")
+	require.NoError(t, err)
+	counts := countSeedKinds(items)
+	assert.Equal(t, 4, counts[domain.SeedContact])
+	assert.Equal(t, 3, counts[domain.SeedEvent])
+	assert.Positive(t, counts[domain.SeedThread])
+	assert.LessOrEqual(t, counts[domain.SeedThread], 12)
+}
+
+func TestSeedCmd_RecordsPartialRunOnFailure(t *testing.T) {
+	client := &fakeSeedClient{failSend: 3}
+	store := stubDev(t, client)
+
+	_, err := runDev(t, "seed", "--grant", "grant-1", "--messages", "5", "--contacts", "2", "--yes")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "send message")
+
+	items, err := store.List("grant-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, countSeedKinds(items)[domain.SeedContact], "created objects stay recorded for clean")
+}
+
+func TestSeedCmd_DryRunCreatesNothing(t *testing.T) {
+	client := &fakeSeedClient{}
+	store := stubDev(t, client)
+
+	_, err := runDev(t, "seed", "--grant", "grant-1", "--messages", "5", "--dry-run")
+	require.NoError(t, err)
+
+	assert.Empty(t, client.sent)
+	items, err := store.List("grant-1")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestSeedCmd_Validation(t *testing.T) {
+	stubDev(t, &fakeSeedClient{})
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"grant required", []string{"seed", "--messages", "5"}, "--grant"},
+		{"nothing to seed", []string{"seed", "--grant", "g"}, "nothing to seed"},
+		{"too many messages", []string{"seed", "--grant", "g", "--messages", "501"}, "--messages must be between 0 and 500"},
+		{"bad timezone", []string{"seed", "--grant", "g", "--events", "1", "--timezone", "Mars/Base"}, "unknown time zone"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runDev(t, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestCleanCmd_DeletesOnlySeededItems(t *testing.T) {
+	client := &fakeSeedClient{deleteErr: map[string]error{
+		"event-gone":   &domain.APIError{StatusCode: http.StatusNotFound},
+		"contact-fail": errors.New("server error"),
+	}}
+	store := stubDev(t, client)
+	require.NoError(t, store.Save(
+		domain.SeededItem{GrantID: "grant-1", Kind: domain.SeedThread, ID: "thread-1"},
+		domain.SeededItem{GrantID: "grant-1", Kind: domain.SeedEvent, ID: "event-gone", CalendarID: "cal"},
+		domain.SeededItem{GrantID: "grant-1", Kind: domain.SeedContact, ID: "contact-fail"},
+		domain.SeededItem{GrantID: "grant-2", Kind: domain.SeedContact, ID: "contact-other"},
+	))
+
+	_, err := runDev(t, "clean", "--grant", "grant-1", "--yes")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 seeded objects could not be deleted")
+
+	assert.Equal(t, []string{"thread-1"}, client.deleted)
+	items, err := store.List("grant-1")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "contact-fail", items[0].ID, "failures stay recorded for a retry")
+
+	other, err := store.List("grant-2")
+	require.NoError(t, err)
+	assert.Len(t, other, 1)
+}
+
+func TestCleanCmd_DryRun(t *testing.T) {
+	client := &fakeSeedClient{}
+	store := stubDev(t, client)
+	require.NoError(t, store.Save(domain.SeededItem{GrantID: "grant-1", Kind: domain.SeedContact, ID: "contact-1"}))
+
+	_, err := runDev(t, "clean", "--grant", "grant-1", "--dry-run")
+	require.NoError(t, err)
+	assert.Empty(t, client.deleted)
+}
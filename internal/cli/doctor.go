@@ -200,7 +200,3 @@ func currentDoctorEnvironment() doctorEnvironment {
 		ConfigDir: config.DefaultConfigDir(),
 	}
 }
-
-func init() {
-	rootCmd.AddCommand(newDoctorCmd())
-}
@@ -0,0 +1,66 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// unsafeAttachmentTypes returns the configured blocklist. Replaced in tests.
+var unsafeAttachmentTypes = func() []string {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil {
+		return domain.DefaultUnsafeAttachmentTypes
+	}
+	return cfg.Email.UnsafeTypes()
+}
+
+// checkAttachmentSafety sniffs an attachment's leading bytes, warns when
+// they don't match its extension, and refuses blocked types unless
+// allowUnsafe is set.
+func checkAttachmentSafety(filename, declaredType string, head []byte, allowUnsafe bool) error {
+	if len(head) > domain.SniffLength {
+		head = head[:domain.SniffLength]
+	}
+	result := domain.InspectAttachment(filename, declaredType, head, unsafeAttachmentTypes())
+
+	if result.Mismatch {
+		common.PrintWarningStderr("%s looks like %s, which doesn't match its extension", filename, result.SniffedType)
+	}
+	if !result.Unsafe {
+		return nil
+	}
+	if allowUnsafe {
+		common.PrintWarningStderr("%s is a potentially unsafe file type (%s); continuing because of --allow-unsafe", filename, result.Reason)
+		return nil
+	}
+	return common.NewUserError(
+		fmt.Sprintf("%s is a blocked file type (%s)", filename, result.Reason),
+		"Pass --allow-unsafe if you trust the file, or change the list with: nylas config set email.unsafe_attachment_types <types>",
+	)
+}
+
+// checkOutgoingAttachments applies checkAttachmentSafety to files about to
+// be sent.
+func checkOutgoingAttachments(atts []domain.Attachment, allowUnsafe bool) error {
+	var blocked []string
+	var firstErr error
+	for _, att := range atts {
+		if err := checkAttachmentSafety(att.Filename, att.ContentType, att.Content, allowUnsafe); err != nil {
+			blocked = append(blocked, att.Filename)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if len(blocked) > 1 {
+		return common.NewUserError(
+			fmt.Sprintf("%d attachments are blocked file types: %s", len(blocked), strings.Join(blocked, ", ")),
+			"Pass --allow-unsafe if you trust the files, or change the list with: nylas config set email.unsafe_attachment_types <types>",
+		)
+	}
+	return firstErr
+}
@@ -0,0 +1,69 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func useUnsafeAttachmentTypes(t *testing.T, types []string) {
+	t.Helper()
+	orig := unsafeAttachmentTypes
+	unsafeAttachmentTypes = func() []string { return types }
+	t.Cleanup(func() { unsafeAttachmentTypes = orig })
+}
+
+func TestCheckAttachmentSafety(t *testing.T) {
+	useUnsafeAttachmentTypes(t, domain.DefaultUnsafeAttachmentTypes)
+	pe := []byte("MZ\x90\x00\x03\x00\x00\x00")
+
+	assert.NoError(t, checkAttachmentSafety("report.pdf", "application/pdf", []byte("%PDF-1.7"), false))
+
+	err := checkAttachmentSafety("invoice.pdf", "application/pdf", pe, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invoice.pdf is a blocked file type")
+
+	assert.NoError(t, checkAttachmentSafety("invoice.pdf", "application/pdf", pe, true))
+	assert.Error(t, checkAttachmentSafety("setup.exe", "", nil, false))
+}
+
+func TestCheckAttachmentSafety_ConfiguredList(t *testing.T) {
+	useUnsafeAttachmentTypes(t, []string{"zip"})
+
+	assert.NoError(t, checkAttachmentSafety("setup.exe", "", []byte("MZ"), false))
+	assert.Error(t, checkAttachmentSafety("bundle.zip", "", []byte("PK\x03\x04"), false))
+}
+
+func TestCheckOutgoingAttachments(t *testing.T) {
+	useUnsafeAttachmentTypes(t, domain.DefaultUnsafeAttachmentTypes)
+	atts := []domain.Attachment{
+		{Filename: "notes.txt", Content: []byte("hello")},
+		{Filename: "a.exe", Content: []byte("MZ")},
+		{Filename: "b.vbs", Content: []byte("MsgBox 1")},
+	}
+
+	err := checkOutgoingAttachments(atts, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 attachments are blocked file types: a.exe, b.vbs")
+
+	err = checkOutgoingAttachments(atts[:2], false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a.exe is a blocked file type")
+
+	assert.NoError(t, checkOutgoingAttachments(atts, true))
+}
+
+func TestDraftContent_AttachmentsBlocksUnsafe(t *testing.T) {
+	useUnsafeAttachmentTypes(t, domain.DefaultUnsafeAttachmentTypes)
+	tool := writeTempFile(t, "tool.exe", "MZ\x90\x00")
+
+	_, err := (&draftContent{attach: []string{tool}}).attachments("")
+	require.Error(t, err)
+
+	atts, err := (&draftContent{attach: []string{tool}, allowUnsafe: true}).attachments("")
+	require.NoError(t, err)
+	assert.Len(t, atts, 1)
+}
@@ -1,6 +1,7 @@
 package email
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/httputil"
 	"github.com/nylas/cli/internal/ports"
 	"github.com/spf13/cobra"
@@ -18,14 +20,17 @@ func newAttachmentsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "attachments",
 		Short: "Manage email attachments",
-		Long: `Commands to list, view, and download email attachments.
+		Long: `Commands to list, view, and download email attachments, and to
+bulk-extract or download them from matching messages.
 
 API reference: https://developer.nylas.com/docs/v3/email/attachments/`,
 	}
 
-	cmd.AddCommand(newAttachmentsListCmd())
+	cmd.AddCommand(common.DeclareOutput(newAttachmentsListCmd(), []domain.Attachment{}))
 	cmd.AddCommand(newAttachmentsShowCmd())
 	cmd.AddCommand(newAttachmentsDownloadCmd())
+	cmd.AddCommand(common.DeclareOutput(newAttachmentsExtractCmd(), []extractResult{}))
+	cmd.AddCommand(common.DeclareOutput(newAttachmentsDownloadAllCmd(), downloadManifest{}))
 
 	return cmd
 }
@@ -127,12 +132,17 @@ func newAttachmentsShowCmd() *cobra.Command {
 // newAttachmentsDownloadCmd creates the attachments download command.
 func newAttachmentsDownloadCmd() *cobra.Command {
 	var outputPath string
+	var allowUnsafe bool
 
 	cmd := &cobra.Command{
 		Use:   "download <attachment-id> <message-id> [grant-id]",
 		Short: "Download an attachment",
-		Long:  "Download an attachment to a local file.",
-		Args:  cobra.RangeArgs(2, 3),
+		Long: `Download an attachment to a local file.
+
+The content is checked before it is saved: a warning is shown when it doesn't
+match the file extension, and types on the email.unsafe_attachment_types
+blocklist (executables and scripts by default) need --allow-unsafe.`,
+		Args: cobra.RangeArgs(2, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			attachmentID := args[0]
 			messageID := args[1]
@@ -181,6 +191,13 @@ func newAttachmentsDownloadCmd() *cobra.Command {
 				}
 				defer func() { _ = reader.Close() }()
 
+				// Sniff the real type before anything touches the disk.
+				buffered := bufio.NewReaderSize(reader, domain.SniffLength)
+				head, _ := buffered.Peek(domain.SniffLength)
+				if err := checkAttachmentSafety(safeFilename, attachment.ContentType, head, allowUnsafe); err != nil {
+					return struct{}{}, err
+				}
+
 				// Create output file
 				file, err := os.Create(finalOutputPath)
 				if err != nil {
@@ -192,7 +209,7 @@ func newAttachmentsDownloadCmd() *cobra.Command {
 
 				// Copy content and close, surfacing write errors that only
 				// appear at Close time on some filesystems.
-				written, err := common.CopyAndClose(file, reader)
+				written, err := common.CopyAndClose(file, buffered)
 				if err != nil {
 					return struct{}{}, common.WrapWriteError("file", err)
 				}
@@ -205,6 +222,7 @@ func newAttachmentsDownloadCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (default: original filename)")
+	cmd.Flags().BoolVar(&allowUnsafe, "allow-unsafe", false, "Save file types on the email.unsafe_attachment_types blocklist (e.g. .exe)")
 
 	return cmd
 }
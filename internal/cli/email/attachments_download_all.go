@@ -0,0 +1,418 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/httputil"
+)
+
+const (
+	// defaultDownloadWorkers keeps parallel downloads well under the
+	// per-grant rate limit.
+	defaultDownloadWorkers = 4
+	maxDownloadWorkers     = 16
+	// downloadManifestName is the manifest file written in --dir.
+	downloadManifestName = "manifest.json"
+	// downloadDuplicate marks an attachment whose content was already saved.
+	downloadDuplicate = "duplicate"
+)
+
+type downloadAllOptions struct {
+	dir           string
+	query         string
+	limit         int
+	workers       int
+	includeInline bool
+	allowUnsafe   bool
+}
+
+// downloadManifest records every attachment download-all has handled in a
+// directory. Paths are relative to the directory.
+type downloadManifest struct {
+	Query       string              `json:"query,omitempty"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Attachments []downloadAllResult `json:"attachments"`
+}
+
+// downloadAllResult reports what happened to one attachment. A duplicate's
+// path is the file holding the same content.
+type downloadAllResult struct {
+	MessageID    string `json:"message_id"`
+	AttachmentID string `json:"attachment_id"`
+	Filename     string `json:"filename"`
+	From         string `json:"from,omitempty"`
+	Subject      string `json:"subject,omitempty"`
+	Path         string `json:"path,omitempty"`
+	Size         int64  `json:"size"`
+	SHA256       string `json:"sha256,omitempty"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+func (r downloadAllResult) key() string {
+	return r.MessageID + "/" + r.AttachmentID
+}
+
+// done reports whether a manifest entry needs no new download.
+func (r downloadAllResult) done() bool {
+	return r.Status == extractSaved || r.Status == downloadDuplicate
+}
+
+func newAttachmentsDownloadAllCmd() *cobra.Command {
+	var opts downloadAllOptions
+
+	cmd := &cobra.Command{
+		Use:   "download-all [grant-id]",
+		Short: "Download every attachment of matching messages",
+		Long: `Download the attachments of every message matching a query into a
+directory, several at a time.
+
+--query uses the provider's search syntax (Gmail operators, Microsoft KQL);
+only messages with attachments are scanned. Files with the same content are
+saved once: later copies are recorded as duplicates of the first. A file
+name already taken by different content is saved as "name (2).ext".
+
+A manifest.json in --dir lists every attachment with its path, size, SHA-256
+and status. Re-running the command skips the attachments the manifest
+already holds and retries failed ones. Inline images are skipped unless
+--include-inline is set, and blocked file types unless --allow-unsafe is set.`,
+		Example: `  # Billing attachments into ./out
+  nylas email attachments download-all --query "has:attachment from:billing" --dir ./out
+
+  # A larger scan with more parallel downloads
+  nylas email attachments download-all --query "filename:pdf" --dir ./pdfs --limit 2000 --concurrency 8`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateRequiredFlag("--dir", opts.dir); err != nil {
+				return err
+			}
+			if opts.limit < 1 {
+				return common.NewInputError("--limit must be at least 1")
+			}
+			if opts.workers < 1 || opts.workers > maxDownloadWorkers {
+				return common.NewInputError(fmt.Sprintf("--concurrency must be between 1 and %d", maxDownloadWorkers))
+			}
+
+			return withExtractClient(args, func(ctx context.Context, client extractClient, grantID string) error {
+				manifest, kept, err := downloadAllAttachments(ctx, client, grantID, opts)
+				if err != nil {
+					return err
+				}
+				return printDownloadAll(cmd, manifest, kept, opts.dir)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.dir, "dir", "", "Directory to save attachments and the manifest in (required)")
+	cmd.Flags().StringVar(&opts.query, "query", "", "Provider search query selecting the messages, e.g. \"from:billing\"")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 500, "Maximum number of messages to scan")
+	cmd.Flags().IntVar(&opts.workers, "concurrency", defaultDownloadWorkers, fmt.Sprintf("Number of parallel downloads (1-%d)", maxDownloadWorkers))
+	cmd.Flags().BoolVar(&opts.includeInline, "include-inline", false, "Also save inline attachments such as embedded images")
+	cmd.Flags().BoolVar(&opts.allowUnsafe, "allow-unsafe", false, "Save file types on the email.unsafe_attachment_types blocklist")
+
+	return cmd
+}
+
+// downloadJob is one attachment to download.
+type downloadJob struct {
+	index int
+	msg   *domain.Message
+	att   *domain.Attachment
+}
+
+// downloadStore assigns file names and deduplicates content across the
+// workers of one run.
+type downloadStore struct {
+	dir     string
+	mu      sync.Mutex
+	claimed map[string]bool   // relative paths taken in this run or by the manifest
+	hashes  map[string]string // SHA-256 to relative path
+}
+
+// downloadAllAttachments scans up to opts.limit messages and downloads their
+// attachments with a worker pool, then writes the manifest. Per-file
+// failures are recorded in the manifest. It also returns how many of the
+// manifest's leading entries were already downloaded by an earlier run.
+func downloadAllAttachments(ctx context.Context, client extractClient, grantID string, opts downloadAllOptions) (*downloadManifest, int, error) {
+	if err := os.MkdirAll(opts.dir, 0o750); err != nil {
+		return nil, 0, common.WrapCreateError("directory", err)
+	}
+	previous, err := loadDownloadManifest(opts.dir)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	store := &downloadStore{
+		dir:     opts.dir,
+		claimed: map[string]bool{downloadManifestName: true},
+		hashes:  map[string]string{},
+	}
+	kept := map[string]downloadAllResult{}
+	for _, r := range previous.Attachments {
+		if !r.done() {
+			continue
+		}
+		kept[r.key()] = r
+		store.claimed[r.Path] = true
+		if r.Status == extractSaved && r.SHA256 != "" {
+			store.hashes[r.SHA256] = r.Path
+		}
+	}
+
+	messages, err := scanAttachmentMessages(ctx, client, grantID, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []downloadJob
+	for i := range messages {
+		m := &messages[i]
+		for j := range m.Attachments {
+			a := &m.Attachments[j]
+			if a.IsInline && !opts.includeInline {
+				continue
+			}
+			if _, ok := kept[m.ID+"/"+a.ID]; ok {
+				continue
+			}
+			jobs = append(jobs, downloadJob{index: len(jobs), msg: m, att: a})
+		}
+	}
+
+	results := make([]downloadAllResult, len(jobs))
+	queue := make(chan downloadJob)
+	progress := common.NewProgressBar("Downloading attachments", len(jobs))
+	var wg sync.WaitGroup
+	for range min(opts.workers, len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				results[job.index] = store.download(ctx, client, grantID, job, opts.allowUnsafe)
+				progress.Increment()
+			}
+		}()
+	}
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+	if len(jobs) > 0 {
+		progress.Finish()
+	}
+
+	manifest := &downloadManifest{Query: opts.query, UpdatedAt: time.Now().UTC()}
+	for _, r := range previous.Attachments {
+		if r.done() {
+			manifest.Attachments = append(manifest.Attachments, r)
+		}
+	}
+	for _, r := range results {
+		if r.Status != "" {
+			manifest.Attachments = append(manifest.Attachments, r)
+		}
+	}
+	if err := writeDownloadManifest(opts.dir, manifest); err != nil {
+		return nil, 0, err
+	}
+	return manifest, len(kept), ctx.Err()
+}
+
+// scanAttachmentMessages returns up to opts.limit messages with attachments
+// that match the query.
+func scanAttachmentMessages(ctx context.Context, client extractClient, grantID string, opts downloadAllOptions) ([]domain.Message, error) {
+	hasAttachment := true
+	params := &domain.MessageQueryParams{HasAttachment: &hasAttachment, NativeQuery: opts.query}
+	fetcher := func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+		params.PageToken = cursor
+		resp, err := client.GetMessagesWithCursor(ctx, grantID, params)
+		if err != nil {
+			return common.PageResult[domain.Message]{}, err
+		}
+		return common.PageResult[domain.Message]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	}
+
+	messages, err := common.FetchCursorPages(ctx, opts.limit, opts.limit, fetcher)
+	if err != nil {
+		return nil, common.WrapListError("messages", err)
+	}
+	return messages, nil
+}
+
+// download saves one attachment, or records it as a duplicate of a file
+// with the same content.
+func (s *downloadStore) download(ctx context.Context, client extractClient, grantID string, job downloadJob, allowUnsafe bool) downloadAllResult {
+	m, a := job.msg, job.att
+	r := downloadAllResult{
+		MessageID: m.ID, AttachmentID: a.ID, Filename: a.Filename,
+		From: firstSender(m), Subject: m.Subject, Size: a.Size,
+	}
+
+	tmpPath, size, sum, err := s.fetch(ctx, client, grantID, m.ID, a, allowUnsafe)
+	var blocked *common.CLIError
+	switch {
+	case errors.As(err, &blocked):
+		r.Status, r.Error = extractBlocked, err.Error()
+		return r
+	case err != nil:
+		r.Status, r.Error = extractFailed, err.Error()
+		return r
+	}
+	defer func() { _ = os.Remove(tmpPath) }()
+	r.Size, r.SHA256 = size, sum
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.hashes[sum]; ok {
+		r.Status, r.Path = downloadDuplicate, existing
+		return r
+	}
+	rel := s.claim(renderAttachmentName("{filename}", m, a))
+	if err := os.Rename(tmpPath, filepath.Join(s.dir, rel)); err != nil {
+		delete(s.claimed, rel)
+		r.Status, r.Error = extractFailed, err.Error()
+		return r
+	}
+	s.hashes[sum] = rel
+	r.Status, r.Path = extractSaved, rel
+	return r
+}
+
+// fetch downloads an attachment to a temporary file in the directory and
+// returns its path, size and SHA-256.
+func (s *downloadStore) fetch(ctx context.Context, client extractClient, grantID, messageID string, a *domain.Attachment, allowUnsafe bool) (string, int64, string, error) {
+	dlCtx, cancel := context.WithTimeout(ctx, httputil.DefaultClientTimeout)
+	defer cancel()
+	reader, err := client.DownloadAttachment(dlCtx, grantID, messageID, a.ID)
+	if err != nil {
+		return "", 0, "", err
+	}
+	defer func() { _ = reader.Close() }()
+
+	buffered := bufio.NewReaderSize(reader, domain.SniffLength)
+	head, _ := buffered.Peek(domain.SniffLength)
+	if err := checkAttachmentSafety(filepath.Base(a.Filename), a.ContentType, head, allowUnsafe); err != nil {
+		return "", 0, "", err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".download-*.tmp")
+	if err != nil {
+		return "", 0, "", err
+	}
+	hash := sha256.New()
+	written, err := common.CopyAndClose(tmp, io.TeeReader(buffered, hash))
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", 0, "", err
+	}
+	return tmp.Name(), written, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// claim returns rel, or "name (2).ext" and so on when rel is taken in this
+// run or on disk, and marks the result taken. Callers hold s.mu.
+func (s *downloadStore) claim(rel string) string {
+	ext := filepath.Ext(rel)
+	stem := strings.TrimSuffix(rel, ext)
+	for n := 1; ; n++ {
+		candidate := rel
+		if n > 1 {
+			candidate = fmt.Sprintf("%s (%d)%s", stem, n, ext)
+		}
+		if s.claimed[candidate] {
+			continue
+		}
+		if _, err := os.Lstat(filepath.Join(s.dir, candidate)); err == nil {
+			continue
+		}
+		s.claimed[candidate] = true
+		return candidate
+	}
+}
+
+// loadDownloadManifest reads the manifest in dir; a missing one is empty.
+func loadDownloadManifest(dir string) (*downloadManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, downloadManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &downloadManifest{}, nil
+	}
+	if err != nil {
+		return nil, common.WrapLoadError("manifest", err)
+	}
+	var m downloadManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, common.NewUserError(fmt.Sprintf("invalid manifest %s: %v", filepath.Join(dir, downloadManifestName), err),
+			"Move the file away, or use a different --dir")
+	}
+	return &m, nil
+}
+
+// writeDownloadManifest replaces the manifest in dir through a temporary
+// file, so an interrupted write never leaves it truncated.
+func writeDownloadManifest(dir string, m *downloadManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".manifest-*.tmp")
+	if err != nil {
+		return common.WrapWriteError("manifest", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := common.CopyAndClose(tmp, strings.NewReader(string(data)+"\n")); err != nil {
+		return common.WrapWriteError("manifest", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, downloadManifestName)); err != nil {
+		return common.WrapWriteError("manifest", err)
+	}
+	return nil
+}
+
+// printDownloadAll reports a run. The first kept manifest entries were
+// downloaded by earlier runs.
+func printDownloadAll(cmd *cobra.Command, m *downloadManifest, kept int, dir string) error {
+	counts := map[string]int{}
+	for _, r := range m.Attachments[kept:] {
+		counts[r.Status]++
+	}
+
+	if common.IsStructuredOutput(cmd) {
+		if err := common.GetOutputWriter(cmd).Write(m); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range m.Attachments[kept:] {
+			if r.Error != "" {
+				fmt.Printf("%s: %s (message %s): %s\n", r.Status, r.Filename, r.MessageID, r.Error)
+			}
+		}
+		common.PrintSuccess("Saved %d attachment(s) to %s; %d duplicate, %d already downloaded, %d blocked, %d failed",
+			counts[extractSaved], dir, counts[downloadDuplicate], kept, counts[extractBlocked], counts[extractFailed])
+		fmt.Printf("Manifest: %s\n", filepath.Join(dir, downloadManifestName))
+	}
+
+	if counts[extractFailed] > 0 {
+		return common.NewUserError(fmt.Sprintf("%d attachment(s) failed to download", counts[extractFailed]),
+			"Re-run the same command to retry; downloaded attachments are skipped")
+	}
+	return nil
+}
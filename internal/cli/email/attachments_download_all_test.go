@@ -0,0 +1,114 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func downloadAllFixture() *fakeExtractClient {
+	return &fakeExtractClient{
+		pages: [][]domain.Message{
+			{
+				extractMessage("m1", "billing@acme.com", "Invoice 1",
+					domain.Attachment{ID: "a1", Filename: "invoice.pdf", ContentType: "application/pdf", Size: 9},
+					domain.Attachment{ID: "logo", Filename: "logo.png", ContentType: "image/png", Size: 4, IsInline: true}),
+				extractMessage("m2", "billing@acme.com", "Invoice 1 (resent)",
+					domain.Attachment{ID: "a2", Filename: "copy.pdf", ContentType: "application/pdf", Size: 9}),
+			},
+			{
+				extractMessage("m3", "billing@acme.com", "Invoice 2",
+					domain.Attachment{ID: "a3", Filename: "invoice.pdf", ContentType: "application/pdf", Size: 11},
+					domain.Attachment{ID: "a4", Filename: "notes.txt", ContentType: "text/plain", Size: 5}),
+			},
+		},
+		contents: map[string]string{
+			"a1": "%PDF-1.7\n", "a2": "%PDF-1.7\n", "a3": "%PDF-1.7\nx\n", "a4": "hello", "logo": "\x89PNG",
+		},
+		failID: "a4",
+	}
+}
+
+func TestDownloadAllAttachments(t *testing.T) {
+	useUnsafeAttachmentTypes(t, domain.DefaultUnsafeAttachmentTypes)
+	dir := t.TempDir()
+	client := downloadAllFixture()
+	opts := downloadAllOptions{dir: dir, query: "from:billing", limit: 100, workers: 3}
+
+	manifest, kept, err := downloadAllAttachments(context.Background(), client, "grant", opts)
+	require.NoError(t, err)
+	assert.Zero(t, kept)
+
+	require.NotEmpty(t, client.params)
+	assert.Equal(t, "from:billing", client.params[0].NativeQuery)
+	require.NotNil(t, client.params[0].HasAttachment)
+	assert.True(t, *client.params[0].HasAttachment)
+
+	byID := map[string]downloadAllResult{}
+	for _, r := range manifest.Attachments {
+		byID[r.AttachmentID] = r
+	}
+	assert.NotContains(t, byID, "logo", "inline attachments are skipped")
+	assert.Equal(t, extractFailed, byID["a4"].Status)
+
+	// a1 and a2 hold the same bytes: one is saved, the other points at it.
+	first, second := byID["a1"], byID["a2"]
+	statuses := []string{first.Status, second.Status}
+	assert.ElementsMatch(t, []string{extractSaved, downloadDuplicate}, statuses)
+	assert.Equal(t, first.Path, second.Path)
+	assert.Equal(t, first.SHA256, second.SHA256)
+
+	// a3 has a different body under a taken name.
+	assert.Equal(t, extractSaved, byID["a3"].Status)
+	assert.NotEqual(t, first.Path, byID["a3"].Path)
+	body, err := os.ReadFile(filepath.Join(dir, byID["a3"].Path))
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.7\nx\n", string(body))
+
+	var onDisk downloadManifest
+	data, err := os.ReadFile(filepath.Join(dir, downloadManifestName))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &onDisk))
+	assert.Len(t, onDisk.Attachments, 4)
+	assert.Equal(t, "from:billing", onDisk.Query)
+
+	leftovers, _ := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+	assert.Empty(t, leftovers, "temporary files are removed")
+}
+
+func TestDownloadAllAttachments_Rerun(t *testing.T) {
+	useUnsafeAttachmentTypes(t, domain.DefaultUnsafeAttachmentTypes)
+	dir := t.TempDir()
+	opts := downloadAllOptions{dir: dir, limit: 100, workers: 2}
+
+	_, _, err := downloadAllAttachments(context.Background(), downloadAllFixture(), "grant", opts)
+	require.NoError(t, err)
+
+	client := downloadAllFixture()
+	client.failID = ""
+	manifest, kept, err := downloadAllAttachments(context.Background(), client, "grant", opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, kept, "saved and duplicate entries carry over")
+	assert.Equal(t, 1, client.downloads, "only the failed attachment is fetched again")
+	require.Len(t, manifest.Attachments, 4)
+	retried := manifest.Attachments[3]
+	assert.Equal(t, "a4", retried.AttachmentID)
+	assert.Equal(t, extractSaved, retried.Status)
+}
+
+func TestDownloadAllAttachments_InvalidManifest(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, downloadManifestName), []byte("{"), 0o600))
+
+	_, _, err := downloadAllAttachments(context.Background(), downloadAllFixture(), "grant", downloadAllOptions{dir: dir, limit: 10, workers: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid manifest")
+}
@@ -0,0 +1,186 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// fakeExtractClient serves pages of messages and attachment bodies keyed by
+// attachment ID.
+type fakeExtractClient struct {
+	pages     [][]domain.Message
+	contents  map[string]string
+	failID    string
+	params    []domain.MessageQueryParams
+	mu        sync.Mutex
+	downloads int
+}
+
+func (f *fakeExtractClient) GetMessagesWithCursor(_ context.Context, _ string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+	f.params = append(f.params, *params)
+	page := len(f.params) - 1
+	resp := &domain.MessageListResponse{}
+	if page < len(f.pages) {
+		resp.Data = f.pages[page]
+	}
+	if page+1 < len(f.pages) {
+		resp.Pagination.NextCursor = "next"
+	}
+	return resp, nil
+}
+
+func (f *fakeExtractClient) DownloadAttachment(_ context.Context, _, _, attachmentID string) (io.ReadCloser, error) {
+	f.mu.Lock()
+	f.downloads++
+	f.mu.Unlock()
+	if attachmentID == f.failID {
+		return nil, errors.New("connection reset")
+	}
+	return io.NopCloser(bytes.NewReader([]byte(f.contents[attachmentID]))), nil
+}
+
+func extractMessage(id, from, subject string, atts ...domain.Attachment) domain.Message {
+	return domain.Message{
+		ID: id, Subject: subject, Date: time.Date(2026, 10, 1, 12, 0, 0, 0, time.Local),
+		From: []domain.EmailParticipant{{Email: from}}, Attachments: atts,
+	}
+}
+
+func TestExtractAttachments(t *testing.T) {
+	useUnsafeAttachmentTypes(t, domain.DefaultUnsafeAttachmentTypes)
+	dir := t.TempDir()
+	client := &fakeExtractClient{
+		pages: [][]domain.Message{
+			{
+				extractMessage("m1", "billing@acme.com", "Invoice 1",
+					domain.Attachment{ID: "a1", Filename: "invoice.pdf", ContentType: "application/pdf", Size: 9},
+					domain.Attachment{ID: "logo", Filename: "logo.png", ContentType: "image/png", Size: 4, IsInline: true}),
+				extractMessage("m2", "ana@example.com", "Lunch",
+					domain.Attachment{ID: "a2", Filename: "menu.pdf", ContentType: "application/pdf", Size: 5}),
+			},
+			{
+				extractMessage("m3", "billing@acme.com", "Invoice 2",
+					domain.Attachment{ID: "a3", Filename: "invoice.pdf", ContentType: "application/pdf", Size: 11},
+					domain.Attachment{ID: "a4", Filename: "../../setup.exe", ContentType: "application/octet-stream", Size: 8},
+					domain.Attachment{ID: "a5", Filename: "notes.txt", ContentType: "text/plain", Size: 5}),
+			},
+		},
+		contents: map[string]string{
+			"a1": "%PDF-1.7\n", "a3": "%PDF-1.7\nx\n", "a4": "MZ\x90\x00\x03\x00\x00\x00", "a5": "hello", "logo": "\x89PNG",
+		},
+		failID: "a5",
+	}
+	filter, err := parseExtractFilters([]string{"from=billing@"}, time.Now())
+	require.NoError(t, err)
+	opts := extractOptions{saveDir: dir, nameTemplate: "{filename}", filter: filter, limit: 10}
+
+	results, err := extractAttachments(context.Background(), client, "grant-1", opts)
+	require.NoError(t, err)
+
+	statuses := map[string]string{}
+	for _, r := range results {
+		statuses[r.AttachmentID] = r.Status
+	}
+	assert.Equal(t, map[string]string{"a1": extractSaved, "a3": extractSaved, "a4": extractBlocked, "a5": extractFailed}, statuses,
+		"other senders and inline images are skipped")
+	require.NotNil(t, client.params[0].HasAttachment)
+	assert.True(t, *client.params[0].HasAttachment)
+	assert.Equal(t, "next", client.params[1].PageToken)
+
+	first, err := os.ReadFile(filepath.Join(dir, "invoice.pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.7\n", string(first))
+	second, err := os.ReadFile(filepath.Join(dir, "invoice (2).pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.7\nx\n", string(second), "a different file with the same name gets a numbered name")
+	assert.NoFileExists(t, filepath.Join(dir, "setup.exe"))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files are left behind")
+
+	// A second run skips what is already saved.
+	client.params, client.downloads = nil, 0
+	client.failID = ""
+	results, err = extractAttachments(context.Background(), client, "grant-1", opts)
+	require.NoError(t, err)
+	for _, r := range results {
+		if r.AttachmentID == "a1" || r.AttachmentID == "a3" {
+			assert.Equal(t, extractExists, r.Status)
+		}
+	}
+	assert.Equal(t, 2, client.downloads, "only the blocked and previously failed files are fetched again")
+}
+
+func TestExtractAttachments_DryRunAndLimit(t *testing.T) {
+	dir := t.TempDir()
+	client := &fakeExtractClient{pages: [][]domain.Message{{
+		extractMessage("m1", "a@example.com", "One", domain.Attachment{ID: "a1", Filename: "one.pdf", Size: 1}),
+		extractMessage("m2", "b@example.com", "Two", domain.Attachment{ID: "a2", Filename: "two.pdf", Size: 1}),
+	}}}
+
+	results, err := extractAttachments(context.Background(), client, "grant-1",
+		extractOptions{saveDir: dir, nameTemplate: "{filename}", limit: 1, dryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.params[0].Limit)
+	require.Len(t, results, 2, "the fake ignores the limit; the loop stops after the page")
+	assert.Equal(t, extractPlanned, results[0].Status)
+	assert.Zero(t, client.downloads)
+	assert.NoFileExists(t, filepath.Join(dir, "one.pdf"))
+}
+
+func TestParseExtractFilters(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	f, err := parseExtractFilters([]string{"From=Billing@", "filename=*.PDF", "type=pdf", "after=7d", "before=2026-10-10"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "billing@", f.from)
+	assert.Equal(t, "*.pdf", f.filename)
+	assert.Equal(t, now.AddDate(0, 0, -7), f.after)
+	assert.Equal(t, 2026, f.before.Year())
+
+	for _, bad := range []string{"from", "size=10", "after=yesterday", "filename=[", "from="} {
+		_, err := parseExtractFilters([]string{bad}, now)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestExtractFilter_MatchesAttachment(t *testing.T) {
+	pdf := &domain.Attachment{Filename: "Q3 Invoice.PDF", ContentType: "application/pdf"}
+	png := &domain.Attachment{Filename: "scan.png", ContentType: "image/png"}
+
+	assert.True(t, (&extractFilter{filename: "*invoice*"}).matchesAttachment(pdf))
+	assert.False(t, (&extractFilter{filename: "*invoice*"}).matchesAttachment(png))
+	assert.True(t, (&extractFilter{contentType: "pdf"}).matchesAttachment(pdf), "extension match")
+	assert.True(t, (&extractFilter{contentType: "image/"}).matchesAttachment(png), "content type prefix match")
+	assert.False(t, (&extractFilter{contentType: "pdf"}).matchesAttachment(png))
+}
+
+func TestRenderAttachmentName(t *testing.T) {
+	m := &domain.Message{
+		ID: "msg/1", Subject: "Re: invoice #4", Date: time.Date(2026, 10, 1, 12, 0, 0, 0, time.Local),
+		From: []domain.EmailParticipant{{Email: "billing@acme.com"}},
+	}
+	a := &domain.Attachment{ID: "att-1", Filename: "../report.final.pdf"}
+
+	assert.Equal(t, "report.final.pdf", renderAttachmentName("{filename}", m, a))
+	assert.Equal(t, filepath.Join("billing@acme.com", "2026-10-01-report.final.pdf"), renderAttachmentName("{from}/{date}-{filename}", m, a))
+	assert.Equal(t, "msg_1-att-1.pdf", renderAttachmentName("{message_id}-{attachment_id}.{ext}", m, a))
+	assert.Equal(t, "Re_ invoice #4 - report.final", renderAttachmentName("{subject} - {name}", m, a))
+	assert.Equal(t, filepath.Join("x", "report.final.pdf"), renderAttachmentName("../x/./{filename}", m, a), "dot segments are dropped")
+
+	assert.Error(t, validateNameTemplate("/abs/{filename}"))
+	assert.Error(t, validateNameTemplate("dir/"))
+	assert.NoError(t, validateNameTemplate("{date}/{filename}"))
+}
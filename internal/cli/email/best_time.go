@@ -0,0 +1,215 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// bestTimeHistory is how many recent messages from the recipient are
+// inspected for their Date header offsets.
+const bestTimeHistory = 25
+
+// tzInference is a recipient time zone and how it was chosen.
+type tzInference struct {
+	Location *time.Location
+	Source   string   // "flag", "headers", "contact", or "local"
+	Reasons  []string // human-readable evidence, shown before sending
+}
+
+// countryZones maps countries that use a single time zone, by name and ISO
+// code, to that zone.
+var countryZones = map[string]string{
+	"at": "Europe/Vienna", "austria": "Europe/Vienna",
+	"be": "Europe/Brussels", "belgium": "Europe/Brussels",
+	"ch": "Europe/Zurich", "switzerland": "Europe/Zurich",
+	"cn": "Asia/Shanghai", "china": "Asia/Shanghai",
+	"de": "Europe/Berlin", "germany": "Europe/Berlin",
+	"dk": "Europe/Copenhagen", "denmark": "Europe/Copenhagen",
+	"fi": "Europe/Helsinki", "finland": "Europe/Helsinki",
+	"fr": "Europe/Paris", "france": "Europe/Paris",
+	"gb": "Europe/London", "uk": "Europe/London", "united kingdom": "Europe/London",
+	"ie": "Europe/Dublin", "ireland": "Europe/Dublin",
+	"il": "Asia/Jerusalem", "israel": "Asia/Jerusalem",
+	"in": "Asia/Kolkata", "india": "Asia/Kolkata",
+	"it": "Europe/Rome", "italy": "Europe/Rome",
+	"jp": "Asia/Tokyo", "japan": "Asia/Tokyo",
+	"kr": "Asia/Seoul", "south korea": "Asia/Seoul",
+	"nl": "Europe/Amsterdam", "netherlands": "Europe/Amsterdam",
+	"no": "Europe/Oslo", "norway": "Europe/Oslo",
+	"nz": "Pacific/Auckland", "new zealand": "Pacific/Auckland",
+	"ph": "Asia/Manila", "philippines": "Asia/Manila",
+	"pl": "Europe/Warsaw", "poland": "Europe/Warsaw",
+	"se": "Europe/Stockholm", "sweden": "Europe/Stockholm",
+	"sg": "Asia/Singapore", "singapore": "Asia/Singapore",
+	"ae": "Asia/Dubai", "united arab emirates": "Asia/Dubai",
+	"za": "Africa/Johannesburg", "south africa": "Africa/Johannesburg",
+}
+
+// inferRecipientTimezone works out the recipient's time zone: an explicit
+// --recipient-tz, then the UTC offsets in Date headers of their recent
+// messages, then their contact's address, then local time.
+func inferRecipientTimezone(ctx context.Context, client ports.NylasClient, grantID, email, explicit string) (*tzInference, error) {
+	if explicit != "" {
+		loc, err := time.LoadLocation(explicit)
+		if err != nil {
+			return nil, common.NewInputError(fmt.Sprintf("unknown time zone %q for --recipient-tz", explicit))
+		}
+		return &tzInference{Location: loc, Source: "flag", Reasons: []string{"--recipient-tz " + explicit}}, nil
+	}
+
+	msgs, err := client.GetMessagesWithParams(ctx, grantID, &domain.MessageQueryParams{
+		From:   email,
+		Limit:  bestTimeHistory,
+		Fields: "include_headers",
+	})
+	if err == nil {
+		if inf := inferFromHeaders(email, msgs); inf != nil {
+			return inf, nil
+		}
+	}
+
+	contacts, err := client.GetContacts(ctx, grantID, &domain.ContactQueryParams{Email: email, Limit: 5})
+	if err == nil {
+		if inf := inferFromContacts(contacts); inf != nil {
+			return inf, nil
+		}
+	}
+
+	return &tzInference{
+		Location: time.Local,
+		Source:   "local",
+		Reasons:  []string{fmt.Sprintf("no messages or contact address for %s to infer from; using local time", email)},
+	}, nil
+}
+
+// inferFromHeaders picks the most common UTC offset in the messages' Date
+// headers. Offsets are fixed, so the result does not follow DST changes,
+// which is fine for a send in the next few days.
+func inferFromHeaders(email string, msgs []domain.Message) *tzInference {
+	counts := map[int]int{}
+	total := 0
+	for _, msg := range msgs {
+		for _, h := range msg.Headers {
+			if !strings.EqualFold(h.Name, "Date") {
+				continue
+			}
+			t, err := mail.ParseDate(h.Value)
+			if err != nil {
+				break
+			}
+			_, offset := t.Zone()
+			counts[offset]++
+			total++
+			break
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	offsets := make([]int, 0, len(counts))
+	for offset := range counts {
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool {
+		if counts[offsets[i]] != counts[offsets[j]] {
+			return counts[offsets[i]] > counts[offsets[j]]
+		}
+		return offsets[i] < offsets[j]
+	})
+	best := offsets[0]
+	name := formatUTCOffset(best)
+
+	reasons := []string{fmt.Sprintf("%d of %d recent messages from %s were sent at %s", counts[best], total, email, name)}
+	if len(offsets) > 1 {
+		others := make([]string, 0, len(offsets)-1)
+		for _, o := range offsets[1:] {
+			others = append(others, fmt.Sprintf("%s (%d)", formatUTCOffset(o), counts[o]))
+		}
+		reasons = append(reasons, "also seen: "+strings.Join(others, ", "))
+	}
+	return &tzInference{Location: time.FixedZone(name, best), Source: "headers", Reasons: reasons}
+}
+
+// inferFromContacts maps the first contact address in a single-zone
+// country to its time zone.
+func inferFromContacts(contacts []domain.Contact) *tzInference {
+	for _, c := range contacts {
+		for _, addr := range c.PhysicalAddresses {
+			zone, ok := countryZones[strings.ToLower(strings.TrimSpace(addr.Country))]
+			if !ok {
+				continue
+			}
+			loc, err := time.LoadLocation(zone)
+			if err != nil {
+				continue
+			}
+			return &tzInference{
+				Location: loc,
+				Source:   "contact",
+				Reasons:  []string{fmt.Sprintf("contact address is in %s (%s)", addr.Country, zone)},
+			}
+		}
+	}
+	return nil
+}
+
+func formatUTCOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// loadBestSendTime returns the configured recipient-local send time.
+// Replaced in tests.
+var loadBestSendTime = func() string {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil || cfg.Email == nil || cfg.Email.BestSendTime == "" {
+		return domain.DefaultBestSendTime
+	}
+	return cfg.Email.BestSendTime
+}
+
+// bestSendTime schedules a send for the next weekday at the best local time
+// of the first recipient, printing how their time zone was inferred.
+func bestSendTime(ctx context.Context, client ports.NylasClient, grantID string, recipients []domain.EmailParticipant, explicitTZ, clock string, now time.Time) (time.Time, error) {
+	if len(recipients) == 0 {
+		return time.Time{}, common.NewUserError("--send-at-best-time needs a --to recipient", "")
+	}
+	if clock == "" {
+		clock = loadBestSendTime()
+	}
+	recipient := recipients[0].Email
+
+	inf, err := inferRecipientTimezone(ctx, client, grantID, recipient, explicitTZ)
+	if err != nil {
+		return time.Time{}, err
+	}
+	at, err := domain.NextSendTime(now, inf.Location, clock)
+	if err != nil {
+		return time.Time{}, common.NewUserError(err.Error(), "Use --best-time HH:MM, or set email.best_send_time in the config")
+	}
+
+	fmt.Printf("\n%s Best time to send to %s:\n", common.Cyan.Sprint("🕘"), recipient)
+	for _, r := range inf.Reasons {
+		fmt.Printf("  • %s\n", r)
+	}
+	if len(recipients) > 1 {
+		fmt.Printf("  • timed for the first recipient; %d others may be in other zones\n", len(recipients)-1)
+	}
+	fmt.Printf("  → %s their time (%s your time)\n",
+		at.Format(common.DisplayWeekdayFullWithTZ), at.In(time.Local).Format(common.DisplayWeekdayFullWithTZ))
+	return at.In(time.Local), nil
+}
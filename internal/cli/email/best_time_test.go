@@ -0,0 +1,137 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func dateHeaderMessage(date string) domain.Message {
+	return domain.Message{Headers: []domain.Header{{Name: "Date", Value: date}}}
+}
+
+func TestInferFromHeaders(t *testing.T) {
+	msgs := []domain.Message{
+		dateHeaderMessage("Tue, 10 Mar 2026 09:12:00 -0500"),
+		dateHeaderMessage("Wed, 11 Mar 2026 16:40:00 -0500"),
+		dateHeaderMessage("Thu, 12 Mar 2026 03:00:00 +0100"),
+		dateHeaderMessage("not a date"),
+		{},
+	}
+
+	inf := inferFromHeaders("ana@example.com", msgs)
+	require.NotNil(t, inf)
+	assert.Equal(t, "headers", inf.Source)
+	_, offset := time.Date(2026, 3, 11, 0, 0, 0, 0, inf.Location).Zone()
+	assert.Equal(t, -5*3600, offset)
+	assert.Contains(t, inf.Reasons[0], "2 of 3 recent messages")
+	assert.Contains(t, inf.Reasons[1], "UTC+01:00 (1)")
+
+	assert.Nil(t, inferFromHeaders("ana@example.com", []domain.Message{{}}))
+}
+
+func TestInferFromContacts(t *testing.T) {
+	inf := inferFromContacts([]domain.Contact{
+		{PhysicalAddresses: []domain.ContactAddress{{Country: "United States"}}},
+		{PhysicalAddresses: []domain.ContactAddress{{Country: "Germany"}}},
+	})
+	require.NotNil(t, inf)
+	assert.Equal(t, "Europe/Berlin", inf.Location.String())
+
+	assert.Nil(t, inferFromContacts([]domain.Contact{{PhysicalAddresses: []domain.ContactAddress{{Country: "US"}}}}))
+}
+
+func TestInferRecipientTimezone(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("explicit zone wins", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		client.GetMessagesWithParamsFunc = func(context.Context, string, *domain.MessageQueryParams) ([]domain.Message, error) {
+			t.Fatal("should not fetch messages")
+			return nil, nil
+		}
+		inf, err := inferRecipientTimezone(ctx, client, "grant-1", "a@example.com", "Asia/Tokyo")
+		require.NoError(t, err)
+		assert.Equal(t, "flag", inf.Source)
+
+		_, err = inferRecipientTimezone(ctx, client, "grant-1", "a@example.com", "Mars/Base")
+		assert.ErrorContains(t, err, "unknown time zone")
+	})
+
+	t.Run("headers are queried for the recipient", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		client.GetMessagesWithParamsFunc = func(_ context.Context, _ string, params *domain.MessageQueryParams) ([]domain.Message, error) {
+			assert.Equal(t, "a@example.com", params.From)
+			assert.Equal(t, "include_headers", params.Fields)
+			return []domain.Message{dateHeaderMessage("Tue, 10 Mar 2026 09:12:00 +0900")}, nil
+		}
+		inf, err := inferRecipientTimezone(ctx, client, "grant-1", "a@example.com", "")
+		require.NoError(t, err)
+		assert.Equal(t, "headers", inf.Source)
+	})
+
+	t.Run("falls back to contacts then local time", func(t *testing.T) {
+		client := nylas.NewMockClient()
+		client.GetMessagesWithParamsFunc = func(context.Context, string, *domain.MessageQueryParams) ([]domain.Message, error) {
+			return nil, errors.New("boom")
+		}
+		client.GetContactsFunc = func(context.Context, string, *domain.ContactQueryParams) ([]domain.Contact, error) {
+			return []domain.Contact{{PhysicalAddresses: []domain.ContactAddress{{Country: "JP"}}}}, nil
+		}
+		inf, err := inferRecipientTimezone(ctx, client, "grant-1", "a@example.com", "")
+		require.NoError(t, err)
+		assert.Equal(t, "contact", inf.Source)
+
+		client.GetContactsFunc = func(context.Context, string, *domain.ContactQueryParams) ([]domain.Contact, error) {
+			return nil, nil
+		}
+		inf, err = inferRecipientTimezone(ctx, client, "grant-1", "a@example.com", "")
+		require.NoError(t, err)
+		assert.Equal(t, "local", inf.Source)
+		assert.Equal(t, time.Local, inf.Location)
+	})
+}
+
+func TestBestSendTime(t *testing.T) {
+	orig := loadBestSendTime
+	loadBestSendTime = func() string { return "10:00" }
+	t.Cleanup(func() { loadBestSendTime = orig })
+
+	client := nylas.NewMockClient()
+	to := []domain.EmailParticipant{{Email: "a@example.com"}}
+	// Wednesday 2026-03-11 12:00 UTC is 21:00 in Tokyo.
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+
+	got, err := bestSendTime(context.Background(), client, "grant-1", to, "Asia/Tokyo", "", now)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2026, 3, 12, 1, 0, 0, 0, time.UTC)), "got %s", got)
+
+	got, err = bestSendTime(context.Background(), client, "grant-1", to, "Asia/Tokyo", "08:30", now)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2026, 3, 11, 23, 30, 0, 0, time.UTC)), "got %s", got)
+
+	_, err = bestSendTime(context.Background(), client, "grant-1", to, "Asia/Tokyo", "8am", now)
+	assert.ErrorContains(t, err, "invalid send time")
+
+	_, err = bestSendTime(context.Background(), client, "grant-1", nil, "", "", now)
+	assert.Error(t, err)
+}
+
+func TestSendCommand_BestTimeFlags(t *testing.T) {
+	cmd := newSendCmd()
+	cmd.SetArgs([]string{"--to", "a@example.com", "--subject", "x", "--send-at-best-time", "--schedule", "2h", "--yes"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	assert.ErrorContains(t, cmd.Execute(), "--send-at-best-time")
+
+	cmd = newSendCmd()
+	cmd.SetArgs([]string{"--to", "a@example.com", "--subject", "x", "--best-time", "10:00", "--yes"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	assert.ErrorContains(t, cmd.Execute(), "requires --send-at-best-time")
+}
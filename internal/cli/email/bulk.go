@@ -0,0 +1,342 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// defaultBulkConcurrency keeps parallel writes well under the per-grant rate
+// limit while still finishing hundreds of updates in seconds.
+const (
+	defaultBulkConcurrency = 4
+	maxBulkConcurrency     = 16
+	defaultBulkLimit       = 500
+)
+
+// bulkClient is the subset of the Nylas client used by bulk operations.
+type bulkClient interface {
+	messagesClient
+	UpdateMessage(ctx context.Context, grantID, messageID string, req *domain.UpdateMessageRequest) (*domain.Message, error)
+	DeleteMessage(ctx context.Context, grantID, messageID string) error
+}
+
+// bulkSelector holds the message filters for a bulk operation.
+type bulkSelector struct {
+	query     string
+	olderThan string
+	from      string
+	folder    string
+}
+
+// bulkAction describes the change applied to every selected message.
+type bulkAction struct {
+	markRead bool
+	star     bool
+	moveTo   string
+	delete   bool
+}
+
+// bulkFailure records a message the action could not be applied to.
+type bulkFailure struct {
+	MessageID string `json:"message_id"`
+	Error     string `json:"error"`
+}
+
+// bulkResult summarizes a bulk run.
+type bulkResult struct {
+	Action    string        `json:"action"`
+	Matched   int           `json:"matched"`
+	Succeeded int           `json:"succeeded"`
+	Failed    []bulkFailure `json:"failed,omitempty"`
+	DryRun    bool          `json:"dry_run,omitempty"`
+}
+
+func newBulkCmd() *cobra.Command {
+	var (
+		sel         bulkSelector
+		action      bulkAction
+		limit       int
+		concurrency int
+		dryRun      bool
+		yes         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bulk [grant-id]",
+		Short: "Apply an action to every message matching a selection",
+		Long: `Select messages with one or more filters and apply an action to all of them.
+
+Selectors (at least one is required):
+  --query        Full-text search query (provider-native syntax)
+  --older-than   Only messages received before now minus a duration (7d, 2w, 48h)
+  --from         Only messages from this sender
+  --folder       Only messages in this folder ID
+
+Actions (at least one is required; --mark-read, --star and --move-to can be
+combined, --delete must be used on its own):
+  --mark-read    Mark messages as read
+  --star         Star messages
+  --move-to      Move messages to a folder ID
+  --delete       Delete messages (moves to trash)
+
+Updates run in parallel (see --concurrency). Use --dry-run to preview which
+messages would be affected without changing anything.`,
+		Example: `  # Preview marking old newsletters as read
+  nylas email bulk --from news@example.com --older-than 30d --mark-read --dry-run
+
+  # Archive everything from a sender into a folder
+  nylas email bulk --from alerts@example.com --move-to <folder-id> --yes
+
+  # Delete matching messages with more parallelism
+  nylas email bulk --query "unsubscribe" --folder INBOX --delete --concurrency 8`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBulkFlags(sel, action, concurrency); err != nil {
+				return err
+			}
+
+			params, err := buildBulkQuery(sel, time.Now())
+			if err != nil {
+				return err
+			}
+
+			var result *bulkResult
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				messages, err := common.RunWithSpinnerResult("Selecting messages...", func() ([]domain.Message, error) {
+					return fetchMessages(ctx, client, grantID, params, limit)
+				})
+				if err != nil {
+					return struct{}{}, common.WrapSearchError("messages", err)
+				}
+
+				if dryRun || len(messages) == 0 {
+					result = &bulkResult{Action: action.describe(), Matched: len(messages), DryRun: dryRun}
+					if !common.IsStructuredOutput(cmd) {
+						printBulkPreview(messages, action, dryRun)
+					}
+					return struct{}{}, nil
+				}
+
+				if !yes && !common.Confirm(fmt.Sprintf("%s %d messages?", capitalize(action.describe()), len(messages)), false) {
+					fmt.Println("Cancelled.")
+					return struct{}{}, nil
+				}
+
+				result = runBulk(ctx, client, grantID, messageIDs(messages), action, concurrency)
+				return struct{}{}, nil
+			})
+			if err != nil || result == nil {
+				return err
+			}
+
+			return writeBulkResult(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&sel.query, "query", "", "Full-text search query")
+	cmd.Flags().StringVar(&sel.olderThan, "older-than", "", "Only messages older than a duration (e.g. 30d, 2w)")
+	cmd.Flags().StringVar(&sel.from, "from", "", "Only messages from this sender")
+	cmd.Flags().StringVar(&sel.folder, "folder", "", "Only messages in this folder ID")
+	cmd.Flags().BoolVar(&action.markRead, "mark-read", false, "Mark selected messages as read")
+	cmd.Flags().BoolVar(&action.star, "star", false, "Star selected messages")
+	cmd.Flags().StringVar(&action.moveTo, "move-to", "", "Move selected messages to this folder ID")
+	cmd.Flags().BoolVar(&action.delete, "delete", false, "Delete selected messages")
+	cmd.Flags().IntVarP(&limit, "limit", "l", defaultBulkLimit, "Maximum number of messages to select (0 for no limit)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultBulkConcurrency, fmt.Sprintf("Number of parallel requests (1-%d)", maxBulkConcurrency))
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show matching messages without changing them")
+	common.AddYesFlag(cmd, &yes)
+
+	return cmd
+}
+
+// validateBulkFlags rejects selections that would touch the whole mailbox and
+// action combinations that cannot be expressed as a single request.
+func validateBulkFlags(sel bulkSelector, action bulkAction, concurrency int) error {
+	if sel.query == "" && sel.olderThan == "" && sel.from == "" && sel.folder == "" {
+		return common.NewUserError(
+			"no message selector specified",
+			"Pass at least one of --query, --older-than, --from, or --folder.",
+		)
+	}
+	if !action.markRead && !action.star && action.moveTo == "" && !action.delete {
+		return common.NewUserError(
+			"no action specified",
+			"Pass at least one of --mark-read, --star, --move-to, or --delete.",
+		)
+	}
+	if action.delete && (action.markRead || action.star || action.moveTo != "") {
+		return common.NewUserError(
+			"--delete cannot be combined with other actions",
+			"Run the update actions and the delete as separate bulk commands.",
+		)
+	}
+	if concurrency < 1 || concurrency > maxBulkConcurrency {
+		return common.NewInputError(fmt.Sprintf("--concurrency must be between 1 and %d", maxBulkConcurrency))
+	}
+	return nil
+}
+
+// buildBulkQuery converts the selector flags into message query parameters.
+func buildBulkQuery(sel bulkSelector, now time.Time) (*domain.MessageQueryParams, error) {
+	params := &domain.MessageQueryParams{
+		Limit:       common.MaxAPILimit,
+		SearchQuery: sel.query,
+		From:        sel.from,
+	}
+	if sel.folder != "" {
+		params.In = []string{sel.folder}
+	}
+	if sel.olderThan != "" {
+		age, err := common.ParseDuration(sel.olderThan)
+		if err != nil {
+			return nil, err
+		}
+		if age <= 0 {
+			return nil, common.NewInputError("--older-than must be a positive duration")
+		}
+		params.ReceivedBefore = now.Add(-age).Unix()
+	}
+	return params, nil
+}
+
+// updateRequest returns the message update for non-delete actions.
+func (a bulkAction) updateRequest() *domain.UpdateMessageRequest {
+	req := &domain.UpdateMessageRequest{}
+	if a.markRead {
+		unread := false
+		req.Unread = &unread
+	}
+	if a.star {
+		starred := true
+		req.Starred = &starred
+	}
+	if a.moveTo != "" {
+		req.Folders = []string{a.moveTo}
+	}
+	return req
+}
+
+// describe returns a short human-readable summary of the action.
+func (a bulkAction) describe() string {
+	if a.delete {
+		return "delete"
+	}
+	var parts []string
+	if a.markRead {
+		parts = append(parts, "mark read")
+	}
+	if a.star {
+		parts = append(parts, "star")
+	}
+	if a.moveTo != "" {
+		parts = append(parts, "move to "+a.moveTo)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runBulk applies the action to every message ID using a bounded worker pool.
+// Failures are collected rather than aborting so one bad message doesn't stop
+// the rest of the batch.
+func runBulk(ctx context.Context, client bulkClient, grantID string, ids []string, action bulkAction, concurrency int) *bulkResult {
+	result := &bulkResult{Action: action.describe(), Matched: len(ids)}
+	req := action.updateRequest()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		progress = common.NewProgressBar("Processing messages", len(ids))
+		jobs     = make(chan string)
+	)
+
+	for range min(concurrency, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				var err error
+				if action.delete {
+					err = client.DeleteMessage(ctx, grantID, id)
+				} else {
+					_, err = client.UpdateMessage(ctx, grantID, id, req)
+				}
+
+				mu.Lock()
+				if err != nil {
+					result.Failed = append(result.Failed, bulkFailure{MessageID: id, Error: err.Error()})
+				} else {
+					result.Succeeded++
+				}
+				mu.Unlock()
+				progress.Increment()
+			}
+		}()
+	}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- id
+	}
+	close(jobs)
+	wg.Wait()
+	progress.Finish()
+
+	return result
+}
+
+func messageIDs(messages []domain.Message) []string {
+	ids := make([]string, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.ID
+	}
+	return ids
+}
+
+func printBulkPreview(messages []domain.Message, action bulkAction, dryRun bool) {
+	if len(messages) == 0 {
+		common.PrintEmptyStateWithHint("messages", "adjust the selector flags")
+		return
+	}
+	if !dryRun {
+		return
+	}
+
+	fmt.Printf("Dry run: would %s %d messages:\n\n", action.describe(), len(messages))
+	for i, msg := range messages {
+		printMessageSummaryWithID(msg, i+1, true)
+	}
+}
+
+func writeBulkResult(cmd *cobra.Command, result *bulkResult) error {
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(result)
+	}
+	if result.DryRun || result.Matched == 0 {
+		return nil
+	}
+
+	common.PrintSuccess("%s: %d of %d messages", capitalize(result.Action), result.Succeeded, result.Matched)
+	for _, f := range result.Failed {
+		common.PrintError("%s: %s", f.MessageID, f.Error)
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("%d of %d messages failed", len(result.Failed), result.Matched)
+	}
+	return nil
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,146 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBulkClient is safe for concurrent use, unlike the shared mock client.
+type fakeBulkClient struct {
+	mu       sync.Mutex
+	updated  map[string]*domain.UpdateMessageRequest
+	deleted  []string
+	failOnID string
+}
+
+func (f *fakeBulkClient) GetMessagesWithParams(context.Context, string, *domain.MessageQueryParams) ([]domain.Message, error) {
+	return nil, nil
+}
+
+func (f *fakeBulkClient) GetMessagesWithCursor(context.Context, string, *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+	return &domain.MessageListResponse{}, nil
+}
+
+func (f *fakeBulkClient) UpdateMessage(_ context.Context, _, id string, req *domain.UpdateMessageRequest) (*domain.Message, error) {
+	if id == f.failOnID {
+		return nil, errors.New("boom")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.updated == nil {
+		f.updated = map[string]*domain.UpdateMessageRequest{}
+	}
+	f.updated[id] = req
+	return &domain.Message{ID: id}, nil
+}
+
+func (f *fakeBulkClient) DeleteMessage(_ context.Context, _, id string) error {
+	if id == f.failOnID {
+		return errors.New("boom")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func TestBulkCommand_Structure(t *testing.T) {
+	cmd := newBulkCmd()
+
+	assert.Equal(t, "bulk [grant-id]", cmd.Use)
+	for _, name := range []string{"query", "older-than", "from", "folder", "mark-read", "move-to", "delete", "star", "concurrency", "dry-run", "yes", "limit"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "missing flag %q", name)
+	}
+}
+
+// Validation runs before any client call, so these exercise the guards that
+// stop a bulk command from touching an entire mailbox by accident.
+func TestBulkCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no selector", []string{"--mark-read"}, "selector"},
+		{"no action", []string{"--from", "a@b.com"}, "no action"},
+		{"delete with update", []string{"--from", "a@b.com", "--delete", "--star"}, "--delete"},
+		{"concurrency too high", []string{"--from", "a@b.com", "--star", "--concurrency", "99"}, "concurrency"},
+		{"concurrency zero", []string{"--from", "a@b.com", "--star", "--concurrency", "0"}, "concurrency"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := testutil.ExecuteCommand(newBulkCmd(), tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestBuildBulkQuery(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	params, err := buildBulkQuery(bulkSelector{
+		query:     "invoice",
+		olderThan: "30d",
+		from:      "billing@example.com",
+		folder:    "INBOX",
+	}, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "invoice", params.SearchQuery)
+	assert.Equal(t, "billing@example.com", params.From)
+	assert.Equal(t, []string{"INBOX"}, params.In)
+	assert.Equal(t, now.AddDate(0, 0, -30).Unix(), params.ReceivedBefore)
+
+	_, err = buildBulkQuery(bulkSelector{olderThan: "soon"}, now)
+	assert.Error(t, err)
+}
+
+func TestBulkAction_UpdateRequest(t *testing.T) {
+	req := bulkAction{markRead: true, star: true, moveTo: "F1"}.updateRequest()
+
+	require.NotNil(t, req.Unread)
+	assert.False(t, *req.Unread)
+	require.NotNil(t, req.Starred)
+	assert.True(t, *req.Starred)
+	assert.Equal(t, []string{"F1"}, req.Folders)
+
+	// An action that doesn't move must leave folders untouched (nil), not
+	// clear them (empty slice), or the update would archive every message.
+	assert.Nil(t, bulkAction{markRead: true}.updateRequest().Folders)
+}
+
+func TestRunBulk_CollectsFailures(t *testing.T) {
+	client := &fakeBulkClient{failOnID: "m2"}
+	ids := []string{"m1", "m2", "m3", "m4"}
+
+	result := runBulk(context.Background(), client, "grant", ids, bulkAction{star: true}, 3)
+
+	assert.Equal(t, 4, result.Matched)
+	assert.Equal(t, 3, result.Succeeded)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, "m2", result.Failed[0].MessageID)
+	assert.Len(t, client.updated, 3)
+}
+
+func TestRunBulk_Delete(t *testing.T) {
+	client := &fakeBulkClient{}
+	ids := []string{"m1", "m2", "m3"}
+
+	result := runBulk(context.Background(), client, "grant", ids, bulkAction{delete: true}, 2)
+
+	assert.Equal(t, 3, result.Succeeded)
+	sort.Strings(client.deleted)
+	assert.Equal(t, ids, client.deleted)
+	assert.Empty(t, client.updated)
+}
@@ -0,0 +1,222 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// defaultAIReplyPrompt is the Smart Compose instruction for reply --ai
+// without --prompt.
+const defaultAIReplyPrompt = "Write a reply to this email"
+
+// composeAction is what happens to a composed email after review.
+type composeAction string
+
+const (
+	composeSaveDraft composeAction = "draft"
+	composeSend      composeAction = "send"
+	composeDiscard   composeAction = "discard"
+)
+
+// editText and canEdit run the user's editor. Tests replace them.
+var (
+	editText = common.EditText
+	canEdit  = common.CanEdit
+)
+
+func newComposeCmd() *cobra.Command {
+	var (
+		prompt    string
+		to        []string
+		cc        []string
+		subject   string
+		saveDraft bool
+		noEdit    bool
+		noConfirm bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compose [grant-id]",
+		Short: "Write an email with AI and review it before sending",
+		Long: `Write an email with Nylas Smart Compose and review it in your editor
+($VISUAL or $EDITOR) before anything is sent.
+
+After editing you choose to send it, save it as a draft or discard it.
+Without --to, or when not run in a terminal, the email is saved as a draft
+so nothing AI-written is sent unreviewed.
+
+Smart Compose requires a Nylas Plus package subscription.`,
+		Example: `  # Write an email, edit it, then send it
+  nylas email compose --prompt "decline the meeting politely" --to ana@example.com
+
+  # Save the result as a draft to finish later
+  nylas email compose --prompt "thank the team for the launch" --draft
+
+  # Reply to a message with AI instead
+  nylas email reply <message-id> --ai --prompt "accept and suggest Tuesday"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(prompt) == "" {
+				return common.NewUserError("prompt is required", "Use --prompt to describe the email you want to write")
+			}
+
+			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				// Check recipients before spending a Smart Compose call.
+				req := &domain.SendMessageRequest{}
+				var err error
+				if req.To, err = parseContacts(to); err != nil {
+					return struct{}{}, common.WrapRecipientError("to", err)
+				}
+				if req.Cc, err = parseContacts(cc); err != nil {
+					return struct{}{}, common.WrapRecipientError("cc", err)
+				}
+
+				suggestion, err := common.RunWithSpinnerResult("Writing email with AI...", func() (*domain.SmartComposeSuggestion, error) {
+					return client.SmartCompose(ctx, grantID, &domain.SmartComposeRequest{Prompt: prompt})
+				})
+				if err != nil {
+					return struct{}{}, common.WrapGenerateError("email", err)
+				}
+
+				suggestedSubject, body := splitSuggestedSubject(suggestion.Suggestion)
+				if subject == "" {
+					subject = suggestedSubject
+				}
+				body, err = reviewAIText(body, noEdit)
+				if err != nil {
+					return struct{}{}, err
+				}
+				if body == "" {
+					fmt.Println("Cancelled: the email body is empty.")
+					return struct{}{}, nil
+				}
+
+				req.Subject, req.Body = subject, body
+				action, err := chooseComposeAction(req, saveDraft, noConfirm)
+				if err != nil {
+					return struct{}{}, err
+				}
+				switch action {
+				case composeDiscard:
+					fmt.Println("Discarded.")
+					return struct{}{}, nil
+				case composeSaveDraft:
+					return struct{}{}, saveComposedDraft(ctx, cmd, client, grantID, req)
+				}
+
+				grant, err := getGrantForSend(ctx, client, grantID)
+				if err != nil {
+					return struct{}{}, err
+				}
+				msg, err := common.RunWithSpinnerResult("Sending email...", func() (*domain.Message, error) {
+					return sendMessageForGrant(ctx, client, grantID, grant, req)
+				})
+				if err != nil {
+					return struct{}{}, common.WrapSendError("email", err)
+				}
+				if common.IsJSON(cmd) {
+					return struct{}{}, common.PrintJSON(msg)
+				}
+				common.PrintSuccess("Email sent successfully! Message ID: %s", msg.ID)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&prompt, "prompt", "", "What the email should say (required)")
+	cmd.Flags().StringSliceVarP(&to, "to", "t", nil, "Recipient email addresses")
+	cmd.Flags().StringSliceVar(&cc, "cc", nil, "CC email addresses")
+	cmd.Flags().StringVarP(&subject, "subject", "s", "", "Email subject (default: the one the AI suggests)")
+	cmd.Flags().BoolVar(&saveDraft, "draft", false, "Save the email as a draft instead of sending it")
+	cmd.Flags().BoolVar(&noEdit, "no-edit", false, "Don't open the editor; use the AI text as written")
+	cmd.Flags().BoolVarP(&noConfirm, "yes", "y", false, "Send without asking after editing")
+	_ = cmd.MarkFlagRequired("prompt")
+
+	return cmd
+}
+
+// splitSuggestedSubject separates a leading "Subject:" line, which Smart
+// Compose sometimes includes, from the body.
+func splitSuggestedSubject(suggestion string) (subject, body string) {
+	text := strings.TrimSpace(suggestion)
+	first, rest, _ := strings.Cut(text, "\n")
+	if name, value, ok := strings.Cut(first, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "subject") {
+		return strings.TrimSpace(value), strings.TrimSpace(rest)
+	}
+	return "", text
+}
+
+// reviewAIText opens AI-written text in the editor when there is a terminal
+// to run it in, and returns the text to use.
+func reviewAIText(text string, noEdit bool) (string, error) {
+	if noEdit || !canEdit() {
+		return strings.TrimSpace(text), nil
+	}
+	edited, err := editText(text)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(edited), nil
+}
+
+// chooseComposeAction decides what to do with a composed email. Anything
+// that can't be confirmed in a terminal is saved as a draft, never sent.
+func chooseComposeAction(req *domain.SendMessageRequest, saveDraft, noConfirm bool) (composeAction, error) {
+	if saveDraft || len(req.To) == 0 {
+		return composeSaveDraft, nil
+	}
+	if noConfirm {
+		return composeSend, nil
+	}
+
+	printSendPreview("Email preview:", req)
+	fmt.Println()
+	// Select picks the first option without a terminal, so drafts come first.
+	return common.Select("What do you want to do with this email?", []common.SelectOption[composeAction]{
+		{Label: "Save as draft", Value: composeSaveDraft},
+		{Label: "Send now", Value: composeSend},
+		{Label: "Discard", Value: composeDiscard},
+	})
+}
+
+func saveComposedDraft(ctx context.Context, cmd *cobra.Command, client ports.NylasClient, grantID string, req *domain.SendMessageRequest) error {
+	draft, err := client.CreateDraft(ctx, grantID, &domain.CreateDraftRequest{
+		Subject: req.Subject,
+		Body:    req.Body,
+		To:      req.To,
+		Cc:      req.Cc,
+	})
+	if err != nil {
+		return common.WrapCreateError("draft", err)
+	}
+	if common.IsJSON(cmd) {
+		return common.PrintJSON(draft)
+	}
+	common.PrintSuccess("Draft saved! ID: %s", draft.ID)
+	fmt.Printf("Send it with: nylas email drafts send %s\n", draft.ID)
+	return nil
+}
+
+// generateAIReply asks Smart Compose for a reply to messageID and lets the
+// user edit it.
+func generateAIReply(ctx context.Context, client ports.NylasClient, grantID, messageID, prompt string, noEdit bool) (string, error) {
+	if strings.TrimSpace(prompt) == "" {
+		prompt = defaultAIReplyPrompt
+	}
+	suggestion, err := common.RunWithSpinnerResult("Writing reply with AI...", func() (*domain.SmartComposeSuggestion, error) {
+		return client.SmartComposeReply(ctx, grantID, messageID, &domain.SmartComposeRequest{Prompt: prompt})
+	})
+	if err != nil {
+		return "", common.WrapGenerateError("reply", err)
+	}
+	_, body := splitSuggestedSubject(suggestion.Suggestion)
+	return reviewAIText(body, noEdit)
+}
@@ -0,0 +1,82 @@
+package email
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestSplitSuggestedSubject(t *testing.T) {
+	subject, body := splitSuggestedSubject("Subject: Can't make it\n\nHi Ana,\nI have to decline.")
+	assert.Equal(t, "Can't make it", subject)
+	assert.Equal(t, "Hi Ana,\nI have to decline.", body)
+
+	subject, body = splitSuggestedSubject("  Hi Ana: sorry, I can't make it.\n")
+	assert.Empty(t, subject)
+	assert.Equal(t, "Hi Ana: sorry, I can't make it.", body)
+}
+
+func TestChooseComposeAction(t *testing.T) {
+	withTo := &domain.SendMessageRequest{To: []domain.EmailParticipant{{Email: "ana@example.com"}}}
+
+	tests := []struct {
+		name      string
+		req       *domain.SendMessageRequest
+		saveDraft bool
+		noConfirm bool
+		want      composeAction
+	}{
+		{name: "draft flag", req: withTo, saveDraft: true, noConfirm: true, want: composeSaveDraft},
+		{name: "no recipients", req: &domain.SendMessageRequest{}, noConfirm: true, want: composeSaveDraft},
+		{name: "yes", req: withTo, noConfirm: true, want: composeSend},
+		{name: "no terminal to confirm in", req: withTo, want: composeSaveDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := chooseComposeAction(tt.req, tt.saveDraft, tt.noConfirm)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerateAIReply(t *testing.T) {
+	client := nylas.NewMockClient()
+
+	body, err := generateAIReply(context.Background(), client, "grant", "msg-1", "", true)
+	require.NoError(t, err)
+	assert.Contains(t, body, "Thank you for your message")
+	assert.Equal(t, "msg-1", client.LastMessageID)
+}
+
+func TestReviewAIText_NoEdit(t *testing.T) {
+	original := editText
+	t.Cleanup(func() { editText = original })
+	editText = func(string) (string, error) {
+		t.Fatal("the editor must not open with --no-edit")
+		return "", nil
+	}
+
+	text, err := reviewAIText("  Hello\n", true)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", text)
+}
+
+func TestReplyCommand_AIValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"msg-1", "--ai", "--body", "Hi"},
+		{"msg-1", "--ai", "--interactive"},
+		{"msg-1", "--prompt", "decline", "--body", "Hi"},
+	} {
+		cmd := newReplyCmd()
+		cmd.SetArgs(args)
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		assert.Error(t, cmd.Execute(), args)
+	}
+}
@@ -0,0 +1,87 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+func newCountCmd() *cobra.Command {
+	var (
+		flags    searchFlags
+		query    string
+		maxItems int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "count [grant-id]",
+		Short: "Count messages matching a query",
+		Long: `Count messages matching a query or filters without downloading them.
+
+The Nylas API does not return totals, so count walks every page with only
+message IDs selected. Large mailboxes take one request per 200 messages; use
+--max to stop early. The output is the bare number, with a trailing "+" when
+--max was reached. Use --json for the count, whether it is complete, and the
+number of pages fetched.
+
+Filters are the same as 'nylas email search'. Without --in, every folder is
+counted.`,
+		Example: `  # Unread messages in the inbox
+  nylas email count --in INBOX --unread
+
+  # Invoices from the last 30 days
+  nylas email count --query invoice --from billing@example.com --after 30d
+
+  # Provider search syntax
+  nylas email count --query "has:attachment larger:10M" --native
+
+  # Stop counting after 1000
+  nylas email count --max 1000 --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			search := &domain.SavedSearch{Query: query}
+			if search.Query == "" {
+				search.Query = "*"
+			}
+			flags.apply(cmd, search)
+			params, err := searchParams(search, time.Now())
+			if err != nil {
+				return err
+			}
+			params.Limit = common.MaxAPILimit
+			params.Select = "id"
+
+			var result common.CountResult
+			_, err = withSearchClient(args, func(ctx context.Context, client messagesClient, grantID string) (struct{}, error) {
+				result, err = countMessages(ctx, client, grantID, params, maxItems)
+				return struct{}{}, err
+			})
+			if err != nil {
+				return common.WrapFetchError("messages", err)
+			}
+			return common.PrintCount(cmd, result)
+		},
+	}
+
+	cmd.Flags().StringVar(&query, "query", "", "Match the subject, or the provider search syntax with --native")
+	flags.registerFilters(cmd)
+	cmd.Flags().IntVar(&maxItems, "max", 0, "Stop counting at this many messages (0=unlimited)")
+
+	return cmd
+}
+
+// countMessages counts the messages matching params page by page.
+func countMessages(ctx context.Context, client messagesClient, grantID string, params *domain.MessageQueryParams, maxItems int) (common.CountResult, error) {
+	return common.CountPages(ctx, "messages", maxItems, func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+		page := *params
+		page.PageToken = cursor
+		resp, err := client.GetMessagesWithCursor(ctx, grantID, &page)
+		if err != nil {
+			return common.PageResult[domain.Message]{}, err
+		}
+		return common.PageResult[domain.Message]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+}
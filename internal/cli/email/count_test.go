@@ -0,0 +1,93 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runCount(t *testing.T, client *stubMessagesClient, args ...string) string {
+	t.Helper()
+	orig := withSearchClient
+	t.Cleanup(func() { withSearchClient = orig })
+	withSearchClient = func(_ []string, fn func(context.Context, messagesClient, string) (struct{}, error)) (struct{}, error) {
+		return fn(context.Background(), client, "grant-123")
+	}
+
+	root := &cobra.Command{Use: "test", SilenceErrors: true, SilenceUsage: true}
+	common.AddOutputFlags(root)
+	root.AddCommand(newCountCmd())
+	stdout, _, err := clitestutil.ExecuteCommand(root, append([]string{"count"}, args...)...)
+	require.NoError(t, err)
+	return stdout
+}
+
+func TestCountCmd_WalksPagesWithIDsOnly(t *testing.T) {
+	var seen []*domain.MessageQueryParams
+	client := &stubMessagesClient{
+		getMessagesWithCursorFunc: func(_ context.Context, _ string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+			seen = append(seen, params)
+			if params.PageToken == "" {
+				return &domain.MessageListResponse{
+					Data:       make([]domain.Message, 200),
+					Pagination: domain.Pagination{NextCursor: "p2", HasMore: true},
+				}, nil
+			}
+			return &domain.MessageListResponse{Data: make([]domain.Message, 3)}, nil
+		},
+	}
+
+	stdout := runCount(t, client, "--query", "invoice", "--from", "billing@example.com", "--after", "30d", "--unread")
+
+	assert.Equal(t, "203\n", stdout)
+	require.Len(t, seen, 2)
+	params := seen[0]
+	assert.Equal(t, "id", params.Select)
+	assert.Equal(t, common.MaxAPILimit, params.Limit)
+	assert.Equal(t, "invoice", params.Subject)
+	assert.Equal(t, "billing@example.com", params.From)
+	require.NotNil(t, params.Unread)
+	assert.True(t, *params.Unread)
+	assert.InDelta(t, time.Now().AddDate(0, 0, -30).Unix(), params.ReceivedAfter, 5)
+	assert.Equal(t, "p2", seen[1].PageToken)
+}
+
+func TestCountCmd_MaxAndJSON(t *testing.T) {
+	client := &stubMessagesClient{
+		getMessagesWithCursorFunc: func(_ context.Context, _ string, _ *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+			return &domain.MessageListResponse{
+				Data:       make([]domain.Message, 200),
+				Pagination: domain.Pagination{NextCursor: "more", HasMore: true},
+			}, nil
+		},
+	}
+
+	stdout := runCount(t, client, "--max", "150", "--json")
+
+	var result common.CountResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, common.CountResult{Resource: "messages", Count: 150, Complete: false, Pages: 1}, result)
+	assert.Equal(t, 1, client.getMessagesWithCursorCalls)
+}
+
+func TestCountCmd_NativeQuery(t *testing.T) {
+	var got *domain.MessageQueryParams
+	client := &stubMessagesClient{
+		getMessagesWithCursorFunc: func(_ context.Context, _ string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+			got = params
+			return &domain.MessageListResponse{}, nil
+		},
+	}
+
+	assert.Equal(t, "0\n", runCount(t, client, "--query", "has:attachment", "--native"))
+	assert.Equal(t, "has:attachment", got.NativeQuery)
+	assert.Empty(t, got.Subject)
+}
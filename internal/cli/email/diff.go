@@ -0,0 +1,289 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultDiffLimit = 10000
+	defaultDiffShow  = 20
+)
+
+// diffClient is the subset of the Nylas client used by email diff.
+type diffClient interface {
+	messagesClient
+	GetFolders(ctx context.Context, grantID string) ([]domain.Folder, error)
+}
+
+// getDiffClient is replaced in tests.
+var getDiffClient = func() (diffClient, error) {
+	return common.GetNylasClient()
+}
+
+type diffOptions struct {
+	grantA     string
+	grantB     string
+	folder     string
+	after      string
+	limit      int
+	show       int
+	failOnDiff bool
+}
+
+func newDiffCmd() *cobra.Command {
+	var opts diffOptions
+
+	cmd := &cobra.Command{
+		Use:   "diff --grant-a <grant> --grant-b <grant>",
+		Short: "Compare folders and messages between two accounts",
+		Long: `Compare two accounts, e.g. before and after a migration.
+
+Folders are matched by role (inbox, sent, trash, ...) or by path for user
+folders, and their message counts compared. Messages are matched by a hash
+of their Message-ID header, so copies keep matching after moving between
+providers; messages without one are matched by subject, sender and date.
+
+By default the newest 10000 messages of each account are compared; when
+the limit is reached, only the date range both accounts cover is compared.`,
+		Example: `  # Validate a migration
+  nylas email diff --grant-a old@example.com --grant-b new@example.com
+
+  # One folder, recent mail only, with a non-zero exit when anything differs
+  nylas email diff --grant-a old@example.com --grant-b new@example.com \
+    --folder Projects/2024 --after 2024-01-01 --fail-on-diff
+
+  # Full report as JSON
+  nylas email diff --grant-a <grant-id> --grant-b <grant-id> --limit 0 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := common.ValidateRequiredFlag("--grant-a", opts.grantA); err != nil {
+				return err
+			}
+			if err := common.ValidateRequiredFlag("--grant-b", opts.grantB); err != nil {
+				return err
+			}
+			if opts.limit < 0 {
+				return common.NewInputError("--limit must be 0 (no limit) or positive")
+			}
+
+			var after time.Time
+			if opts.after != "" {
+				t, err := parseDate(opts.after)
+				if err != nil {
+					return common.WrapDateParseError("after", err)
+				}
+				after = t
+			}
+
+			grantA, err := common.ResolveGrantIdentifier(opts.grantA)
+			if err != nil {
+				return err
+			}
+			grantB, err := common.ResolveGrantIdentifier(opts.grantB)
+			if err != nil {
+				return err
+			}
+			if grantA == grantB {
+				return common.NewInputError("--grant-a and --grant-b are the same account")
+			}
+
+			client, err := getDiffClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := common.CreateLongContext()
+			defer cancel()
+
+			a, cappedA, err := loadMailboxSnapshot(ctx, client, grantA, opts.grantA, opts, after)
+			if err != nil {
+				return err
+			}
+			b, cappedB, err := loadMailboxSnapshot(ctx, client, grantB, opts.grantB, opts, after)
+			if err != nil {
+				return err
+			}
+			if cutoff := commonWindowStart(a.Messages, cappedA, b.Messages, cappedB); !cutoff.IsZero() {
+				a.Messages = messagesSince(a.Messages, cutoff)
+				b.Messages = messagesSince(b.Messages, cutoff)
+				common.PrintWarningStderr("--limit %d reached; comparing messages since %s only (use --limit 0 to compare everything)",
+					opts.limit, cutoff.Format("2006-01-02 15:04"))
+			}
+
+			diff := domain.CompareMailboxes(*a, *b)
+			diff.GrantA, diff.GrantB = opts.grantA, opts.grantB
+
+			if common.IsStructuredOutput(cmd) {
+				if err := common.GetOutputWriter(cmd).Write(diff); err != nil {
+					return err
+				}
+			} else {
+				printMailboxDiff(diff, opts.show)
+			}
+
+			if opts.failOnDiff && !diff.Identical() {
+				return common.NewUserError("mailboxes differ",
+					fmt.Sprintf("%d missing in %s, %d missing in %s", len(diff.MissingInB), opts.grantB, len(diff.MissingInA), opts.grantA))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.grantA, "grant-a", "", "First account: grant ID or email (required)")
+	cmd.Flags().StringVar(&opts.grantB, "grant-b", "", "Second account: grant ID or email (required)")
+	cmd.Flags().StringVar(&opts.folder, "folder", "", "Only compare this folder (path, name or ID)")
+	cmd.Flags().StringVar(&opts.after, "after", "", "Only compare messages received after date (YYYY-MM-DD)")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", defaultDiffLimit, "Maximum messages to fetch per account (0 for no limit)")
+	cmd.Flags().IntVar(&opts.show, "show", defaultDiffShow, "Maximum missing messages to list per side (0 for all)")
+	cmd.Flags().BoolVar(&opts.failOnDiff, "fail-on-diff", false, "Exit with an error when the accounts differ")
+
+	return cmd
+}
+
+// loadMailboxSnapshot fetches folders and messages (with headers) for one
+// account. capped reports whether the message limit was reached.
+func loadMailboxSnapshot(ctx context.Context, client diffClient, grantID, label string, opts diffOptions, after time.Time) (*domain.MailboxSnapshot, bool, error) {
+	folders, err := common.RunWithSpinnerResult(fmt.Sprintf("Fetching folders of %s...", label), func() ([]domain.Folder, error) {
+		return client.GetFolders(ctx, grantID)
+	})
+	if err != nil {
+		return nil, false, common.WrapListError("folders", err)
+	}
+
+	params := &domain.MessageQueryParams{
+		Limit:  common.MaxAPILimit,
+		Fields: "include_headers",
+	}
+	if !after.IsZero() {
+		params.ReceivedAfter = after.Unix()
+	}
+	if opts.folder != "" {
+		folder := findDiffFolder(folders, opts.folder)
+		if folder == nil {
+			return nil, false, common.NewUserError(fmt.Sprintf("folder %q not found in %s", opts.folder, label),
+				"List folders with: nylas email folders list "+label)
+		}
+		folders = []domain.Folder{*folder}
+		params.In = []string{folder.ID}
+	}
+
+	messages, err := common.RunWithSpinnerResult(fmt.Sprintf("Fetching messages of %s...", label), func() ([]domain.Message, error) {
+		return fetchMessages(ctx, client, grantID, params, opts.limit)
+	})
+	if err != nil {
+		return nil, false, common.WrapListError("messages", err)
+	}
+
+	capped := opts.limit > 0 && len(messages) >= opts.limit
+	return &domain.MailboxSnapshot{GrantID: grantID, Folders: folders, Messages: messages}, capped, nil
+}
+
+// findDiffFolder finds a folder by ID, path or name (case-insensitive).
+func findDiffFolder(folders []domain.Folder, ref string) *domain.Folder {
+	paths := domain.FolderPaths(folders)
+	for i := range folders {
+		f := &folders[i]
+		if f.ID == ref || strings.EqualFold(paths[f.ID], ref) || strings.EqualFold(f.Name, ref) || strings.EqualFold(f.SystemFolder, ref) {
+			return f
+		}
+	}
+	return nil
+}
+
+// commonWindowStart returns the oldest date both accounts were fully
+// fetched back to, or zero when neither hit the limit.
+func commonWindowStart(a []domain.Message, cappedA bool, b []domain.Message, cappedB bool) time.Time {
+	var cutoff time.Time
+	for _, side := range []struct {
+		msgs   []domain.Message
+		capped bool
+	}{{a, cappedA}, {b, cappedB}} {
+		if !side.capped {
+			continue
+		}
+		oldest := side.msgs[0].Date
+		for _, m := range side.msgs {
+			if m.Date.Before(oldest) {
+				oldest = m.Date
+			}
+		}
+		if oldest.After(cutoff) {
+			cutoff = oldest
+		}
+	}
+	return cutoff
+}
+
+func messagesSince(msgs []domain.Message, cutoff time.Time) []domain.Message {
+	out := msgs[:0:0]
+	for _, m := range msgs {
+		if !m.Date.Before(cutoff) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func printMailboxDiff(diff *domain.MailboxDiff, show int) {
+	fmt.Printf("Comparing %s (A) with %s (B)\n\n", diff.GrantA, diff.GrantB)
+
+	fmt.Println(common.Bold.Sprint("Folders"))
+	fmt.Printf("  %-36s %8s %8s  %s\n", "PATH", "A", "B", "STATUS")
+	for _, f := range diff.Folders {
+		status := f.Status
+		switch f.Status {
+		case domain.FolderMatch:
+			status = common.Green.Sprint("ok")
+		case domain.FolderMissingInA:
+			status = common.Yellow.Sprint("missing in A")
+		case domain.FolderMissingInB:
+			status = common.Yellow.Sprint("missing in B")
+		case domain.FolderCountMismatch:
+			status = common.Yellow.Sprintf("count differs (%+d)", f.CountB-f.CountA)
+		}
+		fmt.Printf("  %-36s %8s %8s  %s\n", common.Truncate(f.Path, 36),
+			diffCount(f.CountA, f.Status != domain.FolderMissingInA), diffCount(f.CountB, f.Status != domain.FolderMissingInB), status)
+	}
+
+	fmt.Printf("\n%s\n", common.Bold.Sprint("Messages"))
+	fmt.Printf("  A: %d  B: %d  matched: %d\n", diff.MessagesA, diff.MessagesB, diff.Matched)
+	if diff.NoMessageID > 0 {
+		fmt.Println(common.Dim.Sprintf("  %d without a Message-ID header were matched by subject, sender and date", diff.NoMessageID))
+	}
+	printDiffItems("Missing in B", diff.MissingInB, show)
+	printDiffItems("Missing in A", diff.MissingInA, show)
+
+	fmt.Println()
+	if diff.Identical() {
+		common.PrintSuccess("Mailboxes match")
+	} else {
+		common.PrintWarning("Mailboxes differ: %d missing in B, %d missing in A", len(diff.MissingInB), len(diff.MissingInA))
+	}
+}
+
+func diffCount(n int, present bool) string {
+	if !present {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
+
+func printDiffItems(title string, items []domain.MessageDiffItem, show int) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Printf("\n  %s (%d):\n", title, len(items))
+	for i, item := range items {
+		if show > 0 && i == show {
+			fmt.Println(common.Dim.Sprintf("    ... and %d more (use --show 0 or --json for all)", len(items)-show))
+			break
+		}
+		fmt.Printf("    %s  %-24s %s\n", item.Date.Format("2006-01-02"), common.Truncate(item.From, 24), common.Truncate(item.Subject, 50))
+	}
+}
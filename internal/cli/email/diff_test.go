@@ -0,0 +1,125 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+)
+
+type stubDiffClient struct {
+	stubMessagesClient
+	folders  map[string][]domain.Folder
+	messages map[string][]domain.Message
+	params   []*domain.MessageQueryParams
+}
+
+func (s *stubDiffClient) GetFolders(_ context.Context, grantID string) ([]domain.Folder, error) {
+	return s.folders[grantID], nil
+}
+
+func newStubDiffClient() *stubDiffClient {
+	s := &stubDiffClient{folders: map[string][]domain.Folder{}, messages: map[string][]domain.Message{}}
+	s.getMessagesWithCursorFunc = func(_ context.Context, grantID string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+		copied := *params
+		s.params = append(s.params, &copied)
+		var out []domain.Message
+		for _, m := range s.messages[grantID] {
+			if len(params.In) > 0 && !containsFolder(m.Folders, params.In[0]) {
+				continue
+			}
+			out = append(out, m)
+		}
+		return &domain.MessageListResponse{Data: out}, nil
+	}
+	return s
+}
+
+func containsFolder(folders []string, id string) bool {
+	for _, f := range folders {
+		if f == id {
+			return true
+		}
+	}
+	return false
+}
+
+func useDiffClient(t *testing.T, client diffClient) {
+	t.Helper()
+	orig := getDiffClient
+	getDiffClient = func() (diffClient, error) { return client, nil }
+	t.Cleanup(func() { getDiffClient = orig })
+}
+
+func diffMessage(id, messageID, folder string, day int) domain.Message {
+	return domain.Message{
+		ID:      id,
+		Subject: "Subject " + messageID,
+		Date:    time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC),
+		Folders: []string{folder},
+		Headers: []domain.Header{{Name: "Message-ID", Value: "<" + messageID + "@example.com>"}},
+	}
+}
+
+func TestDiffCmd_ReportsMissing(t *testing.T) {
+	client := newStubDiffClient()
+	client.folders["old"] = []domain.Folder{{ID: "in-a", Name: "INBOX", SystemFolder: "inbox", TotalCount: 2}}
+	client.folders["new"] = []domain.Folder{{ID: "in-b", Name: "Inbox", SystemFolder: "inbox", TotalCount: 1}}
+	client.messages["old"] = []domain.Message{diffMessage("a1", "one", "in-a", 1), diffMessage("a2", "two", "in-a", 2)}
+	client.messages["new"] = []domain.Message{diffMessage("b1", "one", "in-b", 1)}
+	useDiffClient(t, client)
+
+	stdout, _, err := clitestutil.ExecuteSubCommand(newDiffCmd(), "--grant-a", "old", "--grant-b", "new", "--json")
+	require.NoError(t, err)
+	var diff domain.MailboxDiff
+	require.NoError(t, json.Unmarshal([]byte(stdout), &diff))
+	assert.Equal(t, 1, diff.Matched)
+	require.Len(t, diff.MissingInB, 1)
+	assert.Equal(t, "a2", diff.MissingInB[0].ID)
+	assert.Equal(t, domain.FolderCountMismatch, diff.Folders[0].Status)
+	assert.Equal(t, "include_headers", client.params[0].Fields)
+
+	stdout, _, err = clitestutil.ExecuteSubCommand(newDiffCmd(), "--grant-a", "old", "--grant-b", "new")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Missing in B (1)")
+	assert.Contains(t, stdout, "Subject two")
+
+	_, _, err = clitestutil.ExecuteSubCommand(newDiffCmd(), "--grant-a", "old", "--grant-b", "new", "--fail-on-diff", "--json")
+	assert.ErrorContains(t, err, "mailboxes differ")
+}
+
+func TestDiffCmd_Folder(t *testing.T) {
+	client := newStubDiffClient()
+	client.folders["old"] = []domain.Folder{{ID: "p", Name: "Projects"}, {ID: "p24", Name: "2024", ParentID: "p", TotalCount: 1}}
+	client.folders["new"] = []domain.Folder{{ID: "q", Name: "Projects"}}
+	client.messages["old"] = []domain.Message{diffMessage("a1", "one", "p24", 1)}
+	useDiffClient(t, client)
+
+	_, _, err := clitestutil.ExecuteSubCommand(newDiffCmd(), "--grant-a", "old", "--grant-b", "new", "--folder", "projects/2024")
+	assert.ErrorContains(t, err, `folder "projects/2024" not found in new`)
+	assert.Equal(t, []string{"p24"}, client.params[0].In)
+}
+
+func TestDiffCmd_Validation(t *testing.T) {
+	_, _, err := clitestutil.ExecuteSubCommand(newDiffCmd(), "--grant-a", "old")
+	assert.ErrorContains(t, err, "--grant-b")
+
+	_, _, err = clitestutil.ExecuteSubCommand(newDiffCmd(), "--grant-a", "same", "--grant-b", "same")
+	assert.ErrorContains(t, err, "same account")
+}
+
+func TestCommonWindowStart(t *testing.T) {
+	a := []domain.Message{diffMessage("a1", "1", "f", 5), diffMessage("a2", "2", "f", 3)}
+	b := []domain.Message{diffMessage("b1", "1", "f", 5), diffMessage("b2", "3", "f", 1)}
+
+	assert.True(t, commonWindowStart(a, false, b, false).IsZero())
+	assert.Equal(t, 3, commonWindowStart(a, true, b, false).Day())
+	assert.Equal(t, 3, commonWindowStart(a, true, b, true).Day())
+	assert.Len(t, messagesSince(b, commonWindowStart(a, true, b, true)), 1)
+}
@@ -25,8 +25,9 @@ func newDraftsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/drafts/`,
 	}
 
-	cmd.AddCommand(newDraftsListCmd())
+	cmd.AddCommand(common.DeclareOutput(newDraftsListCmd(), []domain.Draft{}))
 	cmd.AddCommand(newDraftsCreateCmd())
+	cmd.AddCommand(common.DeclareOutput(newDraftsUpdateCmd(), domain.Draft{}))
 	cmd.AddCommand(newDraftsShowCmd())
 	cmd.AddCommand(newDraftsSendCmd())
 	cmd.AddCommand(newDraftsDeleteCmd())
@@ -115,24 +116,53 @@ func newDraftsCreateCmd() *cobra.Command {
 	var to []string
 	var cc []string
 	var subject string
-	var body string
 	var replyTo string
-	var attachFiles []string
+	var content draftContent
 	var signatureID string
+	var edit bool
 
 	cmd := &cobra.Command{
 		Use:   "create [grant-id]",
 		Short: "Create a new draft",
-		Long:  "Create a new draft email with optional attachments.",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Create a new draft email with optional attachments.
+
+The body comes from --body, a plain-text --body-file, or an --html-file.
+--inline embeds files in an HTML body: --inline logo=./logo.png is shown
+where the body has <img src="cid:logo">.
+
+--edit writes the draft in your editor ($VISUAL or $EDITOR) as To, Cc, Bcc
+and Subject headers, a blank line and the body, starting from any flags
+given.`,
+		Example: `  nylas email drafts create --to ana@example.com --subject "Q3 report" \
+    --html-file report.html --inline chart=./chart.png --attach q3.pdf
+
+  # Write the draft in your editor
+  nylas email drafts create --edit`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Interactive mode if nothing provided (runs before WithClient)
-			if len(to) == 0 && subject == "" && body == "" && len(attachFiles) == 0 {
+			body, err := content.resolveBody()
+			if err != nil {
+				return err
+			}
+
+			// Editor or interactive mode if nothing provided (runs before WithClient)
+			var bcc []string
+			if edit {
+				edited, err := editEmailTemplate(&emailTemplate{To: to, Cc: cc, Subject: subject, Body: body}, false)
+				if err != nil {
+					return err
+				}
+				if edited == nil {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+				to, cc, bcc, subject, body = edited.To, edited.Cc, edited.Bcc, edited.Subject, edited.Body
+			} else if len(to) == 0 && subject == "" && body == "" && !content.hasAttachments() {
 				reader := bufio.NewReader(os.Stdin)
 
 				fmt.Print("To (comma-separated, optional): ")
 				input, _ := reader.ReadString('\n')
-				to = parseEmails(strings.TrimSpace(input))
+				to = expandRecipients(args, parseEmails(strings.TrimSpace(input)))
 
 				fmt.Print("Subject: ")
 				subject, _ = reader.ReadString('\n')
@@ -142,7 +172,7 @@ func newDraftsCreateCmd() *cobra.Command {
 				body = readBodyLines(reader)
 			}
 
-			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				// Parse and validate recipients
 				toContacts, err := parseContacts(to)
 				if err != nil {
@@ -168,12 +198,19 @@ func newDraftsCreateCmd() *cobra.Command {
 					}
 					req.Cc = ccContacts
 				}
+				if len(bcc) > 0 {
+					bccContacts, err := parseContacts(bcc)
+					if err != nil {
+						return struct{}{}, common.WrapRecipientError("bcc", err)
+					}
+					req.Bcc = bccContacts
+				}
 
-				// Load attachments from files
-				if len(attachFiles) > 0 {
-					attachments, err := loadAttachmentsFromFiles(attachFiles)
+				// Load attachments and inline files
+				if content.hasAttachments() {
+					attachments, err := content.attachments(body)
 					if err != nil {
-						return struct{}{}, common.WrapLoadError("attachments", err)
+						return struct{}{}, err
 					}
 					req.Attachments = attachments
 					fmt.Printf("Attaching %d file(s)...\n", len(attachments))
@@ -197,10 +234,10 @@ func newDraftsCreateCmd() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&to, "to", "t", nil, "Recipient email addresses")
 	cmd.Flags().StringSliceVar(&cc, "cc", nil, "CC email addresses")
 	cmd.Flags().StringVarP(&subject, "subject", "s", "", "Email subject")
-	cmd.Flags().StringVarP(&body, "body", "b", "", "Email body")
 	cmd.Flags().StringVar(&replyTo, "reply-to", "", "Message ID to reply to")
 	cmd.Flags().StringVar(&signatureID, "signature-id", "", "Stored signature ID to append when creating the draft")
-	cmd.Flags().StringSliceVarP(&attachFiles, "attach", "a", nil, "File paths to attach")
+	cmd.Flags().BoolVar(&edit, "edit", false, "Write the draft in $EDITOR, starting from the other flags")
+	content.addFlags(cmd)
 
 	return cmd
 }
@@ -0,0 +1,317 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// draftContent holds the body and attachment flags shared by drafts create
+// and drafts update.
+type draftContent struct {
+	body     string
+	bodyFile string
+	htmlFile string
+	attach   []string
+	inline   []string
+
+	allowUnsafe bool
+}
+
+func (c *draftContent) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&c.body, "body", "b", "", "Email body")
+	cmd.Flags().StringVar(&c.bodyFile, "body-file", "", "Read a plain-text body from a file")
+	cmd.Flags().StringVar(&c.htmlFile, "html-file", "", "Read an HTML body from a file")
+	cmd.Flags().StringSliceVarP(&c.attach, "attach", "a", nil, "File paths to attach")
+	cmd.Flags().StringArrayVar(&c.inline, "inline", nil, "Inline file for the HTML body, as [content-id=]path; reference it with src=\"cid:<content-id>\"")
+	cmd.Flags().BoolVar(&c.allowUnsafe, "allow-unsafe", false, "Attach file types on the email.unsafe_attachment_types blocklist (e.g. .exe)")
+}
+
+// hasBody reports whether any body flag was given.
+func (c *draftContent) hasBody() bool {
+	return c.body != "" || c.bodyFile != "" || c.htmlFile != ""
+}
+
+// hasAttachments reports whether any attachment flag was given.
+func (c *draftContent) hasAttachments() bool {
+	return len(c.attach) > 0 || len(c.inline) > 0
+}
+
+// resolveBody returns the body from --body, --body-file or --html-file. A
+// plain-text file is escaped and its line breaks kept; an HTML file is used
+// as is.
+func (c *draftContent) resolveBody() (string, error) {
+	set := 0
+	for _, v := range []string{c.body, c.bodyFile, c.htmlFile} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return "", common.NewUserError("only one of --body, --body-file or --html-file may be used",
+			"Use --html-file for an HTML body and --body-file for plain text")
+	}
+
+	switch {
+	case c.bodyFile != "":
+		data, err := os.ReadFile(c.bodyFile) // #nosec G304 -- user-supplied body file
+		if err != nil {
+			return "", common.WrapLoadError("body file", err)
+		}
+		return strings.ReplaceAll(html.EscapeString(string(data)), "\n", "<br>\n"), nil
+	case c.htmlFile != "":
+		data, err := os.ReadFile(c.htmlFile) // #nosec G304 -- user-supplied body file
+		if err != nil {
+			return "", common.WrapLoadError("HTML file", err)
+		}
+		return string(data), nil
+	}
+	return c.body, nil
+}
+
+// attachments loads --attach and --inline files and checks them against the
+// unsafe-type policy. Inline files are checked against the body so a typo in
+// a content ID doesn't silently drop an image.
+func (c *draftContent) attachments(body string) ([]domain.Attachment, error) {
+	atts, err := loadAttachmentsFromFiles(c.attach)
+	if err != nil {
+		return nil, common.WrapLoadError("attachments", err)
+	}
+	inline, err := loadInlineAttachments(c.inline)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOutgoingAttachments(append(atts, inline...), c.allowUnsafe); err != nil {
+		return nil, err
+	}
+	for _, att := range inline {
+		if body != "" && !strings.Contains(body, "cid:"+att.ContentID) {
+			common.PrintWarningStderr("Inline file %s is not referenced in the body (expected src=\"cid:%s\")", att.Filename, att.ContentID)
+		}
+	}
+	return append(atts, inline...), nil
+}
+
+// loadInlineAttachments reads inline files given as [content-id=]path. The
+// content ID defaults to the file name.
+func loadInlineAttachments(specs []string) ([]domain.Attachment, error) {
+	atts := make([]domain.Attachment, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		cid, path, ok := strings.Cut(spec, "=")
+		if !ok {
+			cid, path = filepath.Base(spec), spec
+		}
+		cid, path = strings.TrimSpace(cid), strings.TrimSpace(path)
+		if cid == "" || path == "" {
+			return nil, common.NewInputError(fmt.Sprintf("invalid --inline %q: use [content-id=]path", spec))
+		}
+		if seen[cid] {
+			return nil, common.NewInputError(fmt.Sprintf("duplicate inline content ID %q", cid))
+		}
+		seen[cid] = true
+
+		loaded, err := loadAttachmentsFromFiles([]string{path})
+		if err != nil {
+			return nil, common.WrapLoadError("inline file", err)
+		}
+		att := loaded[0]
+		att.ContentID = cid
+		att.IsInline = true
+		atts = append(atts, att)
+	}
+	return atts, nil
+}
+
+// existingDraftAttachments downloads a draft's current attachments so they
+// can be sent again alongside new ones; a multipart update replaces the
+// draft's attachment list.
+func existingDraftAttachments(ctx context.Context, client ports.NylasClient, grantID string, draft *domain.Draft) ([]domain.Attachment, error) {
+	atts := make([]domain.Attachment, 0, len(draft.Attachments))
+	for _, att := range draft.Attachments {
+		rc, err := client.DownloadAttachment(ctx, grantID, draft.ID, att.ID)
+		if err != nil {
+			return nil, fmt.Errorf("download %s: %w", att.Filename, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("download %s: %w", att.Filename, err)
+		}
+		att.ID = ""
+		att.Content = content
+		att.Size = int64(len(content))
+		atts = append(atts, att)
+	}
+	return atts, nil
+}
+
+func newDraftsUpdateCmd() *cobra.Command {
+	var (
+		content            draftContent
+		to                 []string
+		cc                 []string
+		subject            string
+		replaceAttachments bool
+		edit               bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <draft-id> [grant-id]",
+		Short: "Update a draft",
+		Long: `Update a draft's recipients, subject, body or attachments. Fields you
+don't pass are kept.
+
+--attach and --inline add files to the draft's existing attachments; use
+--replace-attachments to drop the existing ones instead.
+
+--edit opens the draft in your editor ($VISUAL or $EDITOR) as To, Cc, Bcc
+and Subject headers, a blank line and the body, with any other flags
+already applied.`,
+		Example: `  # Replace the body with an HTML file that embeds an image
+  nylas email drafts update <draft-id> --html-file newsletter.html --inline logo=./logo.png
+
+  # Add two attachments
+  nylas email drafts update <draft-id> --attach report.pdf --attach data.csv
+
+  # Rework the text in your editor
+  nylas email drafts update <draft-id> --edit`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			draftID := args[0]
+			body, err := content.resolveBody()
+			if err != nil {
+				return err
+			}
+			if !edit && !content.hasBody() && !content.hasAttachments() && len(to) == 0 && len(cc) == 0 && !cmd.Flags().Changed("subject") {
+				return common.NewUserError("nothing to update",
+					"Pass --to, --cc, --subject, --body, --body-file, --html-file, --attach or --inline, or use --edit")
+			}
+
+			_, err = common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				draft, err := client.GetDraft(ctx, grantID, draftID)
+				if err != nil {
+					return struct{}{}, common.WrapGetError("draft", err)
+				}
+
+				req := &domain.CreateDraftRequest{
+					Subject:      draft.Subject,
+					Body:         draft.Body,
+					To:           draft.To,
+					Cc:           draft.Cc,
+					Bcc:          draft.Bcc,
+					ReplyTo:      draft.ReplyTo,
+					ReplyToMsgID: draft.ReplyToMsgID,
+				}
+				if cmd.Flags().Changed("subject") {
+					req.Subject = subject
+				}
+				if content.hasBody() {
+					req.Body = body
+				}
+				if len(to) > 0 {
+					if req.To, err = parseContacts(to); err != nil {
+						return struct{}{}, common.WrapRecipientError("to", err)
+					}
+				}
+				if len(cc) > 0 {
+					if req.Cc, err = parseContacts(cc); err != nil {
+						return struct{}{}, common.WrapRecipientError("cc", err)
+					}
+				}
+
+				if edit {
+					cancelled, err := editDraftRequest(req)
+					if err != nil || cancelled {
+						return struct{}{}, err
+					}
+				}
+
+				if content.hasAttachments() {
+					added, err := content.attachments(req.Body)
+					if err != nil {
+						return struct{}{}, err
+					}
+					if !replaceAttachments && len(draft.Attachments) > 0 {
+						kept, err := existingDraftAttachments(ctx, client, grantID, draft)
+						if err != nil {
+							return struct{}{}, common.NewUserError(
+								fmt.Sprintf("could not keep the draft's existing attachments: %v", err),
+								"Use --replace-attachments to replace them instead",
+							)
+						}
+						added = append(kept, added...)
+					}
+					req.Attachments = added
+				}
+
+				updated, err := client.UpdateDraft(ctx, grantID, draftID, req)
+				if err != nil {
+					return struct{}{}, common.WrapUpdateError("draft", err)
+				}
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(updated)
+				}
+				common.PrintSuccess("Draft updated! ID: %s", updated.ID)
+				if len(updated.Attachments) > 0 {
+					fmt.Printf("  Attachments: %d\n", len(updated.Attachments))
+				}
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	content.addFlags(cmd)
+	cmd.Flags().StringSliceVarP(&to, "to", "t", nil, "Replace recipients")
+	cmd.Flags().StringSliceVar(&cc, "cc", nil, "Replace CC recipients")
+	cmd.Flags().StringVarP(&subject, "subject", "s", "", "New subject")
+	cmd.Flags().BoolVar(&replaceAttachments, "replace-attachments", false, "Replace existing attachments instead of adding to them")
+	cmd.Flags().BoolVar(&edit, "edit", false, "Edit the draft's headers and body in $EDITOR")
+
+	return cmd
+}
+
+// editDraftRequest opens req's recipients, subject and body in the editor
+// and applies the result. It reports whether the user cancelled.
+func editDraftRequest(req *domain.CreateDraftRequest) (bool, error) {
+	edited, err := editEmailTemplate(&emailTemplate{
+		To:      participantStrings(req.To),
+		Cc:      participantStrings(req.Cc),
+		Bcc:     participantStrings(req.Bcc),
+		Subject: req.Subject,
+		Body:    req.Body,
+	}, false)
+	if err != nil {
+		return false, err
+	}
+	if edited == nil {
+		fmt.Println("Cancelled.")
+		return true, nil
+	}
+
+	// The addresses were validated by editEmailTemplate.
+	req.To, _ = parseContacts(edited.To)
+	req.Cc, _ = parseContacts(edited.Cc)
+	req.Bcc, _ = parseContacts(edited.Bcc)
+	req.Subject, req.Body = edited.Subject, edited.Body
+	return false, nil
+}
+
+func participantStrings(participants []domain.EmailParticipant) []string {
+	out := make([]string, len(participants))
+	for i, p := range participants {
+		out[i] = p.String()
+	}
+	return out
+}
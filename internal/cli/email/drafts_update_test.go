@@ -0,0 +1,107 @@
+package email
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestDraftContent_ResolveBody(t *testing.T) {
+	text := writeTempFile(t, "body.txt", "a < b\nnext")
+	page := writeTempFile(t, "body.html", "<p>Hi <img src=\"cid:logo\"></p>")
+
+	body, err := (&draftContent{bodyFile: text}).resolveBody()
+	require.NoError(t, err)
+	assert.Equal(t, "a &lt; b<br>\nnext", body)
+
+	body, err = (&draftContent{htmlFile: page}).resolveBody()
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Hi <img src=\"cid:logo\"></p>", body)
+
+	body, err = (&draftContent{body: "inline"}).resolveBody()
+	require.NoError(t, err)
+	assert.Equal(t, "inline", body)
+
+	_, err = (&draftContent{body: "x", htmlFile: page}).resolveBody()
+	assert.Error(t, err)
+
+	_, err = (&draftContent{bodyFile: filepath.Join(t.TempDir(), "missing.txt")}).resolveBody()
+	assert.Error(t, err)
+}
+
+func TestLoadInlineAttachments(t *testing.T) {
+	logo := writeTempFile(t, "logo.png", "\x89PNG\r\n")
+	chart := writeTempFile(t, "chart.gif", "GIF89a")
+
+	atts, err := loadInlineAttachments([]string{"brand=" + logo, chart})
+	require.NoError(t, err)
+	require.Len(t, atts, 2)
+
+	assert.Equal(t, "brand", atts[0].ContentID)
+	assert.True(t, atts[0].IsInline)
+	assert.Equal(t, "logo.png", atts[0].Filename)
+	assert.Equal(t, "image/png", atts[0].ContentType)
+	assert.Equal(t, "chart.gif", atts[1].ContentID, "content ID defaults to the file name")
+
+	_, err = loadInlineAttachments([]string{"a=" + logo, "a=" + chart})
+	assert.Error(t, err, "duplicate content IDs")
+	_, err = loadInlineAttachments([]string{"=" + logo})
+	assert.Error(t, err)
+}
+
+func TestDraftContent_AttachmentsCombinesFiles(t *testing.T) {
+	c := &draftContent{
+		attach: []string{writeTempFile(t, "report.pdf", "%PDF-1.4"), writeTempFile(t, "data.csv", "a,b")},
+		inline: []string{"logo=" + writeTempFile(t, "logo.png", "\x89PNG")},
+	}
+	atts, err := c.attachments(`<img src="cid:logo">`)
+	require.NoError(t, err)
+	require.Len(t, atts, 3)
+	assert.False(t, atts[0].IsInline)
+	assert.True(t, atts[2].IsInline)
+}
+
+func TestExistingDraftAttachments(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.DownloadAttachmentFunc = func(_ context.Context, grantID, messageID, attachmentID string) (io.ReadCloser, error) {
+		assert.Equal(t, "draft-1", messageID)
+		return io.NopCloser(strings.NewReader("content of " + attachmentID)), nil
+	}
+	draft := &domain.Draft{ID: "draft-1", Attachments: []domain.Attachment{
+		{ID: "att-1", Filename: "a.pdf", ContentType: "application/pdf"},
+		{ID: "att-2", Filename: "logo.png", ContentID: "logo", IsInline: true},
+	}}
+
+	atts, err := existingDraftAttachments(context.Background(), client, "grant-1", draft)
+	require.NoError(t, err)
+	require.Len(t, atts, 2)
+	assert.Equal(t, []byte("content of att-1"), atts[0].Content)
+	assert.Empty(t, atts[0].ID)
+	assert.True(t, atts[1].IsInline, "inline attachments stay inline")
+	assert.Equal(t, "logo", atts[1].ContentID)
+}
+
+func TestDraftsUpdateCmd_NothingToUpdate(t *testing.T) {
+	cmd := newDraftsUpdateCmd()
+	cmd.SetArgs([]string{"draft-1"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to update")
+}
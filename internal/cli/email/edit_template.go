@@ -0,0 +1,143 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nylas/cli/internal/cli/common"
+)
+
+// emailTemplateHelp heads the --edit template; lines starting with # are
+// dropped from the headers when it is read back.
+const emailTemplateHelp = `# Headers go first, then a blank line, then the body.
+# Separate addresses with commas. Lines starting with # are ignored.
+# Delete everything to cancel.
+`
+
+// emailTemplate is an email as edited with --edit: RFC 822-style headers, a
+// blank line and the body.
+type emailTemplate struct {
+	To      []string
+	Cc      []string
+	Bcc     []string
+	Subject string
+	Body    string
+}
+
+// formatEmailTemplate renders t for editing.
+func formatEmailTemplate(t *emailTemplate) string {
+	var sb strings.Builder
+	sb.WriteString(emailTemplateHelp)
+	fmt.Fprintf(&sb, "To: %s\n", strings.Join(t.To, ", "))
+	fmt.Fprintf(&sb, "Cc: %s\n", strings.Join(t.Cc, ", "))
+	fmt.Fprintf(&sb, "Bcc: %s\n", strings.Join(t.Bcc, ", "))
+	fmt.Fprintf(&sb, "Subject: %s\n\n", t.Subject)
+	sb.WriteString(t.Body)
+	if t.Body != "" && !strings.HasSuffix(t.Body, "\n") {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// parseEmailTemplate reads back an edited template. Header lines that start
+// with whitespace continue the previous header. It returns nil when nothing
+// but comments is left, which cancels.
+func parseEmailTemplate(text string) (*emailTemplate, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	var (
+		t       emailTemplate
+		headers []string
+		i       int
+	)
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			i++
+			break
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(headers) > 0 {
+			headers[len(headers)-1] += " " + strings.TrimSpace(line)
+			continue
+		}
+		headers = append(headers, line)
+	}
+	t.Body = strings.TrimSpace(strings.Join(lines[min(i, len(lines)):], "\n"))
+
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return nil, common.NewInputError(fmt.Sprintf("header line %q has no ':'", header))
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "to":
+			t.To = parseEmails(value)
+		case "cc":
+			t.Cc = parseEmails(value)
+		case "bcc":
+			t.Bcc = parseEmails(value)
+		case "subject":
+			t.Subject = value
+		default:
+			return nil, common.NewInputError(fmt.Sprintf("unknown header %q (use To, Cc, Bcc or Subject)", strings.TrimSpace(name)))
+		}
+	}
+
+	if len(headers) == 0 && t.Body == "" {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+// validate checks every address, and with requireTo that there is one.
+func (t *emailTemplate) validate(requireTo bool) error {
+	if requireTo && len(t.To) == 0 {
+		return common.NewInputError("To: at least one recipient is required")
+	}
+	for _, field := range []struct {
+		name  string
+		addrs []string
+	}{{"To", t.To}, {"Cc", t.Cc}, {"Bcc", t.Bcc}} {
+		if _, err := parseContacts(field.addrs); err != nil {
+			return common.NewInputError(fmt.Sprintf("%s: %v", field.name, err))
+		}
+	}
+	return nil
+}
+
+// editEmailTemplate opens t in the user's editor until it parses and its
+// recipients are valid, offering to edit again after a mistake. It returns
+// nil when the user cancels.
+func editEmailTemplate(t *emailTemplate, requireTo bool) (*emailTemplate, error) {
+	if !canEdit() {
+		return nil, common.NewUserError("--edit needs a terminal to run your editor",
+			"Pass the email with --to, --subject and --body instead")
+	}
+
+	text := formatEmailTemplate(t)
+	for {
+		edited, err := editText(text)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseEmailTemplate(edited)
+		if err == nil && parsed != nil {
+			err = parsed.validate(requireTo)
+		}
+		if err == nil {
+			return parsed, nil
+		}
+
+		common.PrintError("%v", err)
+		again, promptErr := common.ConfirmPrompt("Edit again?", true)
+		if promptErr != nil || !again {
+			return nil, err
+		}
+		text = edited
+	}
+}
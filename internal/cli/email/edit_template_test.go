@@ -0,0 +1,123 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func stubEditor(t *testing.T, edits ...string) *[]string {
+	t.Helper()
+	origEdit, origCan := editText, canEdit
+	t.Cleanup(func() { editText, canEdit = origEdit, origCan })
+
+	var seen []string
+	canEdit = func() bool { return true }
+	editText = func(text string) (string, error) {
+		seen = append(seen, text)
+		require.Less(t, len(seen)-1, len(edits), "editor opened too often")
+		return edits[len(seen)-1], nil
+	}
+	return &seen
+}
+
+func TestEmailTemplate_RoundTrip(t *testing.T) {
+	in := &emailTemplate{
+		To:      []string{"ana@example.com", "Bob <bob@example.com>"},
+		Bcc:     []string{"audit@example.com"},
+		Subject: "Q3 report",
+		Body:    "Hi all,\n\n# Numbers\nAttached.",
+	}
+
+	out, err := parseEmailTemplate(formatEmailTemplate(in))
+	require.NoError(t, err)
+	assert.Equal(t, in, out, "# lines in the body are kept")
+}
+
+func TestParseEmailTemplate(t *testing.T) {
+	t.Run("folded header", func(t *testing.T) {
+		got, err := parseEmailTemplate("to: a@example.com,\n  b@example.com\r\nSubject: Hi\r\n\r\nBody\r\n")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, got.To)
+		assert.Equal(t, "Hi", got.Subject)
+		assert.Equal(t, "Body", got.Body)
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		got, err := parseEmailTemplate("To: a@example.com\nSubject: Hi")
+		require.NoError(t, err)
+		assert.Equal(t, "Hi", got.Subject)
+		assert.Empty(t, got.Body)
+	})
+
+	t.Run("only comments cancels", func(t *testing.T) {
+		got, err := parseEmailTemplate(emailTemplateHelp + "\n\n")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("unknown header", func(t *testing.T) {
+		_, err := parseEmailTemplate("From: me@example.com\n\nBody")
+		assert.ErrorContains(t, err, `unknown header "From"`)
+	})
+
+	t.Run("missing colon", func(t *testing.T) {
+		_, err := parseEmailTemplate("Hello there\n\nBody")
+		assert.ErrorContains(t, err, "has no ':'")
+	})
+}
+
+func TestEmailTemplate_Validate(t *testing.T) {
+	assert.NoError(t, (&emailTemplate{}).validate(false))
+	assert.ErrorContains(t, (&emailTemplate{}).validate(true), "at least one recipient")
+	assert.ErrorContains(t, (&emailTemplate{To: []string{"a@example.com"}, Cc: []string{"bob"}}).validate(true), "Cc: invalid email address: bob")
+}
+
+func TestEditEmailTemplate(t *testing.T) {
+	seen := stubEditor(t,
+		"To: ana.example.com\nSubject: Hi\n\nHello",
+		"To: ana@example.com\nSubject: Hi\n\nHello",
+	)
+
+	got, err := editEmailTemplate(&emailTemplate{Subject: "Hi"}, true)
+	require.NoError(t, err)
+
+	require.Len(t, *seen, 2, "an invalid address reopens the editor")
+	assert.Contains(t, (*seen)[0], "Subject: Hi\n")
+	assert.Equal(t, "To: ana.example.com\nSubject: Hi\n\nHello", (*seen)[1], "the second edit starts from the first")
+	assert.Equal(t, []string{"ana@example.com"}, got.To)
+	assert.Equal(t, "Hello", got.Body)
+}
+
+func TestEditEmailTemplate_NoTerminal(t *testing.T) {
+	origCan := canEdit
+	t.Cleanup(func() { canEdit = origCan })
+	canEdit = func() bool { return false }
+
+	_, err := editEmailTemplate(&emailTemplate{}, false)
+	assert.ErrorContains(t, err, "needs a terminal")
+}
+
+func TestEditDraftRequest(t *testing.T) {
+	seen := stubEditor(t, "To: Ana <ana@example.com>\nBcc: audit@example.com\nSubject: Q3 (final)\n\n<p>Updated</p>")
+	req := &domain.CreateDraftRequest{
+		To:      []domain.EmailParticipant{{Name: "Ana", Email: "ana@example.com"}},
+		Cc:      []domain.EmailParticipant{{Email: "bob@example.com"}},
+		Subject: "Q3",
+		Body:    "<p>Draft</p>",
+	}
+
+	cancelled, err := editDraftRequest(req)
+	require.NoError(t, err)
+	assert.False(t, cancelled)
+
+	assert.Contains(t, (*seen)[0], "To: Ana <ana@example.com>\nCc: bob@example.com\n")
+	assert.Equal(t, []domain.EmailParticipant{{Name: "Ana", Email: "ana@example.com"}}, req.To)
+	assert.Empty(t, req.Cc)
+	assert.Equal(t, []domain.EmailParticipant{{Email: "audit@example.com"}}, req.Bcc)
+	assert.Equal(t, "Q3 (final)", req.Subject)
+	assert.Equal(t, "<p>Updated</p>", req.Body)
+}
@@ -3,6 +3,9 @@ package email
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 )
 
 // NewEmailCmd creates the email command with all subcommands.
@@ -15,26 +18,43 @@ func NewEmailCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/v3/email/`,
 	}
 
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newReadCmd())
-	cmd.AddCommand(newSendCmd())
-	cmd.AddCommand(newReplyCmd())
-	cmd.AddCommand(newSearchCmd())
-	cmd.AddCommand(newMarkCmd())
-	cmd.AddCommand(newMoveCmd())
-	cmd.AddCommand(newCleanCmd())
-	cmd.AddCommand(newDeleteCmd())
+	common.RequireScopes(cmd, domain.ScopeEmailRead)
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.Message{}, common.Page[domain.Message]{}))
+	cmd.AddCommand(common.RequireCapabilities(common.DeclareOutput(newReadCmd(), domain.Message{}, translatedMessageJSON{}), domain.CapabilityGPG))
+	cmd.AddCommand(common.RequireCapabilities(common.RequireScopes(common.DeclareOutput(newSendCmd(), domain.Message{}, domain.TemplateRenderResult{}), domain.ScopeEmailSend), domain.CapabilityGPG))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newReplyCmd(), domain.Message{}), domain.ScopeEmailSend))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newForwardCmd(), domain.Message{}), domain.ScopeEmailSend))
+	cmd.AddCommand(newRawCmd())
+	cmd.AddCommand(common.DeclareOutput(newLinksCmd(), []messageLink{}))
+	cmd.AddCommand(common.DeclareOutput(newSearchCmd(), []domain.Message{}))
+	cmd.AddCommand(common.DeclareOutput(newCountCmd(), common.CountResult{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newStatusCmd(), domain.MessageStatus{}), domain.ScopeApplicationAPI))
+	cmd.AddCommand(common.DeclareOutput(newDiffCmd(), domain.MailboxDiff{}))
+	cmd.AddCommand(common.RequireScopes(newMarkCmd(), domain.ScopeEmailModify))
+	cmd.AddCommand(common.RequireScopes(newMoveCmd(), domain.ScopeEmailModify))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newBulkCmd(), bulkResult{}), domain.ScopeEmailModify))
+	cmd.AddCommand(common.DeclareOutput(newCleanCmd(), []domain.CleanedMessage{}))
+	cmd.AddCommand(common.RequireScopes(newDeleteCmd(), domain.ScopeEmailModify))
 	cmd.AddCommand(newFoldersCmd())
 	cmd.AddCommand(newThreadsCmd())
-	cmd.AddCommand(newDraftsCmd())
+	cmd.AddCommand(common.RequireScopes(newDraftsCmd(), domain.ScopeEmailModify))
 	cmd.AddCommand(newAttachmentsCmd())
 	cmd.AddCommand(newScheduledCmd())
-	cmd.AddCommand(newSmartComposeCmd())
+	cmd.AddCommand(common.DeclareOutput(newSmartComposeCmd(), domain.SmartComposeSuggestion{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newComposeCmd(), domain.Draft{}, domain.Message{}), domain.ScopeEmailSend))
 	cmd.AddCommand(newTrackingInfoCmd())
 	cmd.AddCommand(newMetadataCmd())
-	cmd.AddCommand(newAICmd())
+	cmd.AddCommand(common.RequireCapabilities(newAICmd(), domain.CapabilityAI))
+	cmd.AddCommand(common.RequireCapabilities(common.DeclareOutput(newSummarizeCmd(), domain.EmailSummary{}), domain.CapabilityAI))
+	cmd.AddCommand(common.RequireCapabilities(common.DeclareOutput(newDigestCmd(), domain.EmailSummary{}), domain.CapabilityAI))
 	cmd.AddCommand(newTemplatesCmd())
 	cmd.AddCommand(newSignaturesCmd())
+	cmd.AddCommand(common.RequireCapabilities(newSMTPCmd(), domain.CapabilityKeychain))
+	cmd.AddCommand(newQuietHoursCmd())
+	cmd.AddCommand(newIdentitiesCmd())
+
+	registerRecipientCompletions(cmd)
 
 	return cmd
 }
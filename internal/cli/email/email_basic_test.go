@@ -92,6 +92,12 @@ func TestReadCommand(t *testing.T) {
 		flag := cmd.Flags().Lookup("raw")
 		assert.NotNil(t, flag)
 	})
+
+	t.Run("has_strip_quotes_flag", func(t *testing.T) {
+		flag := cmd.Flags().Lookup("strip-quotes")
+		assert.NotNil(t, flag)
+		assert.Equal(t, "false", flag.DefValue)
+	})
 }
 
 func TestSendCommand(t *testing.T) {
@@ -19,7 +19,7 @@ func newFoldersCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/v3/email/folders/`,
 	}
 
-	cmd.AddCommand(newFoldersListCmd())
+	cmd.AddCommand(common.DeclareOutput(newFoldersListCmd(), []domain.Folder{}))
 	cmd.AddCommand(newFoldersShowCmd())
 	cmd.AddCommand(newFoldersCreateCmd())
 	cmd.AddCommand(newFoldersRenameCmd())
@@ -0,0 +1,170 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+func newForwardCmd() *cobra.Command {
+	var to, cc, bcc []string
+	var body string
+	var noAttachments bool
+	var sizeOpts messageSizeOptions
+	var noConfirm bool
+
+	cmd := &cobra.Command{
+		Use:   "forward <message-id> [grant-id]",
+		Short: "Forward an email",
+		Long: `Forward an email message to new recipients.
+
+The original message is fetched and included below an optional note with a
+"Forwarded message" header block (From, Date, Subject, To, Cc). The subject
+is prefixed with "Fwd: ".
+
+The original attachments are downloaded and re-attached; use --no-attachments
+to forward only the message text.`,
+		Example: `  # Forward to a colleague
+  nylas email forward <message-id> --to bob@example.com
+
+  # Add a note and skip the original attachments
+  nylas email forward <message-id> --to bob@example.com \
+    --body "FYI, see below." --no-attachments`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			messageID := args[0]
+
+			if len(to) == 0 {
+				return common.NewUserError("at least one recipient is required", "Use --to to specify who to forward the message to")
+			}
+			toContacts, err := parseContacts(to)
+			if err != nil {
+				return err
+			}
+			ccContacts, err := parseContacts(cc)
+			if err != nil {
+				return err
+			}
+			bccContacts, err := parseContacts(bcc)
+			if err != nil {
+				return err
+			}
+
+			_, err = common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				grant, err := getGrantForSend(ctx, client, grantID)
+				if err != nil {
+					return struct{}{}, err
+				}
+
+				orig, err := client.GetMessage(ctx, grantID, messageID)
+				if err != nil {
+					return struct{}{}, common.WrapGetError("message", err)
+				}
+
+				req := buildForwardRequest(orig, body)
+				req.To, req.Cc, req.Bcc = toContacts, ccContacts, bccContacts
+
+				if !noAttachments && len(orig.Attachments) > 0 {
+					req.Attachments, err = downloadForwardAttachments(ctx, client, grantID, orig)
+					if err != nil {
+						return struct{}{}, err
+					}
+				}
+
+				sizeOpts.Prompt = !noConfirm
+				if err := enforceMessageSize(ctx, grant, req, sizeOpts); err != nil {
+					return struct{}{}, err
+				}
+
+				printForwardPreview(req)
+
+				if !noConfirm {
+					if !common.Confirm("\nForward this message?", false) {
+						fmt.Println("Cancelled.")
+						return struct{}{}, nil
+					}
+				}
+
+				msg, err := common.RunWithSpinnerResult("Forwarding message...", func() (*domain.Message, error) {
+					return sendMessageForGrant(ctx, client, grantID, grant, req)
+				})
+				if err != nil {
+					return struct{}{}, common.WrapSendError("forward", err)
+				}
+
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(msg)
+				}
+				common.PrintSuccess("Message forwarded successfully! Message ID: %s", msg.ID)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&to, "to", "t", nil, "Recipient email addresses (comma-separated)")
+	cmd.Flags().StringSliceVar(&cc, "cc", nil, "CC email addresses (comma-separated)")
+	cmd.Flags().StringSliceVar(&bcc, "bcc", nil, "BCC email addresses (comma-separated)")
+	cmd.Flags().StringVarP(&body, "body", "b", "", "Note to include above the forwarded message")
+	cmd.Flags().BoolVar(&noAttachments, "no-attachments", false, "Don't include the original attachments")
+	cmd.Flags().BoolVar(&sizeOpts.LinkAttachments, "link-attachments", false, "Upload the attachments and send links instead (requires email.attachment_upload_url)")
+	cmd.Flags().BoolVar(&sizeOpts.SkipCheck, "skip-size-check", false, "Send even if the message exceeds the provider's size limit")
+	cmd.Flags().BoolVarP(&noConfirm, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// buildForwardRequest assembles the subject and body of a forward. Recipients
+// and attachments are filled in by the caller.
+func buildForwardRequest(orig *domain.Message, note string) *domain.SendMessageRequest {
+	return &domain.SendMessageRequest{
+		Subject: forwardSubject(orig.Subject),
+		Body:    forwardBody(note, orig),
+	}
+}
+
+// downloadForwardAttachments fetches the content of each of the original
+// message's attachments so they can be re-sent.
+func downloadForwardAttachments(ctx context.Context, client ports.NylasClient, grantID string, orig *domain.Message) ([]domain.Attachment, error) {
+	attachments := make([]domain.Attachment, 0, len(orig.Attachments))
+	for _, att := range orig.Attachments {
+		rc, err := client.DownloadAttachment(ctx, grantID, orig.ID, att.ID)
+		if err != nil {
+			return nil, common.WrapDownloadError(fmt.Sprintf("attachment %q", att.Filename), err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, common.WrapDownloadError(fmt.Sprintf("attachment %q", att.Filename), err)
+		}
+		attachments = append(attachments, domain.Attachment{
+			Filename:    att.Filename,
+			ContentType: att.ContentType,
+			Size:        int64(len(content)),
+			ContentID:   att.ContentID,
+			IsInline:    att.IsInline,
+			Content:     content,
+		})
+	}
+	return attachments, nil
+}
+
+func printForwardPreview(req *domain.SendMessageRequest) {
+	fmt.Println("\nForward preview:")
+	fmt.Printf("  To:      %s\n", participantList(req.To))
+	if len(req.Cc) > 0 {
+		fmt.Printf("  Cc:      %s\n", participantList(req.Cc))
+	}
+	if len(req.Bcc) > 0 {
+		fmt.Printf("  Bcc:     %s\n", participantList(req.Bcc))
+	}
+	fmt.Printf("  Subject: %s\n", req.Subject)
+	for _, att := range req.Attachments {
+		fmt.Printf("  Attach:  %s (%s)\n", att.Filename, common.FormatSize(att.Size))
+	}
+}
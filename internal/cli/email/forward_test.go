@@ -0,0 +1,103 @@
+package email
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForwardSubject(t *testing.T) {
+	assert.Equal(t, "Fwd: Report", forwardSubject("Report"))
+	assert.Equal(t, "Fwd: Report", forwardSubject("Fwd: Report"))
+	assert.Equal(t, "FW: Report", forwardSubject("FW: Report"))
+	assert.Equal(t, "Fwd:", forwardSubject("  "))
+}
+
+func TestBodyToHTML(t *testing.T) {
+	assert.Equal(t, "a &lt; b<br>\nnext", bodyToHTML("a < b\nnext"))
+	assert.Equal(t, "<p>already html</p>", bodyToHTML("<p>already html</p>"))
+}
+
+func TestQuoteReplyBody(t *testing.T) {
+	orig := &domain.Message{
+		From: []domain.EmailParticipant{{Name: "Alice", Email: "alice@example.com"}},
+		Date: time.Date(2026, 3, 2, 15, 4, 0, 0, time.UTC),
+		Body: "<p>Original</p>",
+	}
+
+	got := quoteReplyBody("Thanks!", orig)
+
+	assert.True(t, strings.HasPrefix(got, "Thanks!<br><br>"))
+	assert.Contains(t, got, "On Mon, Mar 2, 2026 at 3:04 PM, Alice &lt;alice@example.com&gt; wrote:")
+	assert.Contains(t, got, "<blockquote")
+	assert.Contains(t, got, "<p>Original</p>")
+}
+
+func TestBuildReplyRequest_Quotes(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetMessageFunc = func(_ context.Context, _, messageID string) (*domain.Message, error) {
+		return &domain.Message{
+			ID:      messageID,
+			Subject: "Plan",
+			From:    []domain.EmailParticipant{{Email: "alice@example.com"}},
+			Body:    "Original text",
+		}, nil
+	}
+
+	req, err := buildReplyRequest(context.Background(), client, "grant-1", nil, "msg-1", "Agreed", false, true)
+	require.NoError(t, err)
+	assert.Contains(t, req.Body, "Agreed")
+	assert.Contains(t, req.Body, "alice@example.com wrote:")
+	assert.Contains(t, req.Body, "Original text")
+	assert.Equal(t, "msg-1", req.ReplyToMsgID)
+}
+
+func TestBuildForwardRequest(t *testing.T) {
+	orig := &domain.Message{
+		Subject: "Q3 numbers",
+		From:    []domain.EmailParticipant{{Email: "alice@example.com"}},
+		To:      []domain.EmailParticipant{{Email: "me@example.com"}},
+		Body:    "<p>Numbers inside</p>",
+	}
+
+	req := buildForwardRequest(orig, "FYI")
+
+	assert.Equal(t, "Fwd: Q3 numbers", req.Subject)
+	assert.Empty(t, req.ReplyToMsgID, "forwards start a new thread")
+	assert.True(t, strings.HasPrefix(req.Body, "FYI<br><br>"))
+	assert.Contains(t, req.Body, "---------- Forwarded message ---------")
+	assert.Contains(t, req.Body, "From: alice@example.com")
+	assert.Contains(t, req.Body, "Subject: Q3 numbers")
+	assert.Contains(t, req.Body, "<p>Numbers inside</p>")
+	assert.NotContains(t, req.Body, "Cc:")
+}
+
+func TestDownloadForwardAttachments(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.DownloadAttachmentFunc = func(_ context.Context, _, messageID, attachmentID string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(messageID + "/" + attachmentID)), nil
+	}
+	orig := &domain.Message{
+		ID: "msg-1",
+		Attachments: []domain.Attachment{
+			{ID: "att-1", Filename: "a.pdf", ContentType: "application/pdf", Size: 999},
+			{ID: "att-2", Filename: "logo.png", ContentType: "image/png", ContentID: "logo", IsInline: true},
+		},
+	}
+
+	got, err := downloadForwardAttachments(context.Background(), client, "grant-1", orig)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "a.pdf", got[0].Filename)
+	assert.Equal(t, []byte("msg-1/att-1"), got[0].Content)
+	assert.Equal(t, int64(len("msg-1/att-1")), got[0].Size)
+	assert.True(t, got[1].IsInline)
+	assert.Equal(t, "logo", got[1].ContentID)
+}
@@ -10,6 +10,12 @@ import (
 
 // printMessage prints a message in a formatted way.
 func printMessage(msg domain.Message, showBody bool) {
+	printMessageWithLanguage(msg, showBody, "")
+}
+
+// printMessageWithLanguage prints a message with a Language header line when
+// language is set.
+func printMessageWithLanguage(msg domain.Message, showBody bool, language string) {
 	// Status indicators
 	status := ""
 	if msg.Unread {
@@ -27,6 +33,9 @@ func printMessage(msg domain.Message, showBody bool) {
 		fmt.Printf("To:      %s\n", common.FormatParticipants(msg.To))
 	}
 	fmt.Printf("Date:    %s (%s)\n", msg.Date.Format(common.DisplayDateTime), common.FormatTimeAgo(msg.Date))
+	if language != "" {
+		fmt.Printf("Language: %s\n", language)
+	}
 	if status != "" {
 		fmt.Printf("Status:  %s\n", status)
 	}
@@ -0,0 +1,317 @@
+package email
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// identitySentScan is how many messages of the Sent folder are read when
+// looking for send-as aliases.
+const identitySentScan = 200
+
+func newIdentitiesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "identities",
+		Short: "Manage the addresses you send as",
+		Long: `Manage the send-as identities of a grant: its own address and the
+provider aliases it sends from, such as Gmail "Send mail as" addresses or
+Microsoft 365 proxy addresses.
+
+Providers don't expose aliases through the API, so they're found in the From
+of the grant's sent mail. 'nylas email send --from' accepts any of them, and
+'nylas email identities use' picks the one used by default.`,
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newIdentitiesListCmd(), []domain.SendIdentity{}))
+	cmd.AddCommand(newIdentitiesUseCmd())
+
+	return cmd
+}
+
+func newIdentitiesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [grant-id]",
+		Short: "Discover and list send-as identities",
+		Long: `Discover the addresses a grant sends as from its sent mail and list
+them. The list is saved to the config, so 'email send --from' can check an
+address without searching again.`,
+		Example: `  # Identities of the default grant
+  nylas email identities list
+
+  # As JSON
+  nylas email identities list --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (*domain.GrantIdentities, error) {
+				return common.RunWithSpinnerResult("Looking for send-as addresses...", func() (*domain.GrantIdentities, error) {
+					return refreshIdentities(ctx, client, grantID)
+				})
+			})
+			if err != nil {
+				return err
+			}
+
+			list := identityList(ids)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(list)
+			}
+			if len(list) == 0 {
+				common.PrintEmptyStateWithHint("identities", "Send a message first, then run this again")
+				return nil
+			}
+
+			table := common.NewTable("", "EMAIL", "NAME", "SOURCE")
+			for _, id := range list {
+				marker := ""
+				if id.Default {
+					marker = "*"
+				}
+				table.AddRow(marker, id.Email, id.Name, id.Source)
+			}
+			table.Render()
+			return nil
+		},
+	}
+}
+
+func newIdentitiesUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <email> [grant-id]",
+		Short: "Send as an identity by default",
+		Long: `Make an identity the From of 'nylas email send' when --from isn't given.
+The address must be one the grant sends as; choose the grant's own address
+to go back to it.`,
+		Example: `  # Send from an alias by default
+  nylas email identities use sales@example.com
+
+  # Back to the grant's own address
+  nylas email identities use me@example.com`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			email := strings.TrimSpace(args[0])
+			_, err := common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				ids, err := findIdentity(ctx, client, grantID, email)
+				if err != nil {
+					return struct{}{}, err
+				}
+				id := ids.Find(email)
+				if id.Source == domain.IdentitySourceAccount {
+					ids.Default = ""
+				} else {
+					ids.Default = id.Email
+				}
+				if err := saveIdentities(grantID, ids); err != nil {
+					return struct{}{}, err
+				}
+				common.PrintSuccess("Sending as %s by default", id.String())
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+}
+
+// identityList returns the identities with the default one marked. Without
+// a stored default, the grant's own address is the default.
+func identityList(ids *domain.GrantIdentities) []domain.SendIdentity {
+	list := slices.Clone(ids.Known)
+	for i := range list {
+		if ids.Default == "" {
+			list[i].Default = list[i].Source == domain.IdentitySourceAccount
+		} else {
+			list[i].Default = strings.EqualFold(list[i].Email, ids.Default)
+		}
+	}
+	return list
+}
+
+// findIdentity returns the grant's identities once they include email,
+// searching the sent mail when the stored ones don't.
+func findIdentity(ctx context.Context, client ports.NylasClient, grantID, email string) (*domain.GrantIdentities, error) {
+	ids := loadIdentities(grantID)
+	if ids.Find(email) != nil {
+		return ids, nil
+	}
+	ids, err := refreshIdentities(ctx, client, grantID)
+	if err != nil {
+		return nil, err
+	}
+	if ids.Find(email) == nil {
+		return nil, common.NewUserError(
+			fmt.Sprintf("%s is not an address this grant sends as", email),
+			"Run 'nylas email identities list' to see the addresses you can use")
+	}
+	return ids, nil
+}
+
+// refreshIdentities searches the grant's sent mail for identities and saves
+// them with the stored ones.
+func refreshIdentities(ctx context.Context, client ports.NylasClient, grantID string) (*domain.GrantIdentities, error) {
+	found, err := discoverIdentities(ctx, client, grantID)
+	if err != nil {
+		return nil, err
+	}
+	ids := mergeIdentities(loadIdentities(grantID), found)
+	if err := saveIdentities(grantID, ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// discoverIdentities returns the grant's own address followed by the other
+// From addresses of its recent sent mail, most used first.
+func discoverIdentities(ctx context.Context, client ports.NylasClient, grantID string) ([]domain.SendIdentity, error) {
+	grant, err := client.GetGrant(ctx, grantID)
+	if err != nil {
+		return nil, common.WrapGetError("grant", err)
+	}
+
+	sent, err := resolveFolderName(ctx, client, grantID, "sent")
+	if err != nil {
+		return nil, common.WrapFetchError("folders", err)
+	}
+	if sent == "" {
+		sent = "SENT"
+	}
+	msgs, err := client.GetMessagesWithParams(ctx, grantID, &domain.MessageQueryParams{
+		In:     []string{sent},
+		Limit:  identitySentScan,
+		Select: "id,from",
+	})
+	if err != nil {
+		return nil, common.WrapFetchError("sent messages", err)
+	}
+
+	var (
+		aliases []domain.SendIdentity
+		ownName string
+	)
+	counts := map[string]int{}
+	for _, msg := range msgs {
+		if len(msg.From) == 0 {
+			continue
+		}
+		from := msg.From[0]
+		key := strings.ToLower(strings.TrimSpace(from.Email))
+		if strings.EqualFold(key, grant.Email) {
+			ownName = cmp.Or(ownName, from.Name)
+			continue
+		}
+		if !strings.Contains(key, "@") {
+			continue
+		}
+		if counts[key] == 0 {
+			aliases = append(aliases, domain.SendIdentity{Email: strings.TrimSpace(from.Email), Name: from.Name, Source: domain.IdentitySourceSent})
+		}
+		counts[key]++
+	}
+	slices.SortStableFunc(aliases, func(a, b domain.SendIdentity) int {
+		return cmp.Compare(counts[strings.ToLower(b.Email)], counts[strings.ToLower(a.Email)])
+	})
+
+	var found []domain.SendIdentity
+	if grant.Email != "" {
+		found = append(found, domain.SendIdentity{Email: grant.Email, Name: ownName, Source: domain.IdentitySourceAccount})
+	}
+	return append(found, aliases...), nil
+}
+
+// mergeIdentities adds freshly found identities to the stored ones. Stored
+// identities that weren't found again are kept, since older sent mail may
+// have aged out of the search.
+func mergeIdentities(stored *domain.GrantIdentities, found []domain.SendIdentity) *domain.GrantIdentities {
+	merged := &domain.GrantIdentities{Known: slices.Clone(found)}
+	if stored == nil {
+		return merged
+	}
+	merged.Default = stored.Default
+	for _, old := range stored.Known {
+		if id := merged.Find(old.Email); id != nil {
+			if id.Name == "" {
+				id.Name = old.Name
+			}
+			continue
+		}
+		merged.Known = append(merged.Known, old)
+	}
+	return merged
+}
+
+// resolveSendFrom returns the From for `email send`: the --from address
+// once it's checked against the grant's identities, the default identity
+// without --from, or nil to let the provider use the grant's address.
+func resolveSendFrom(ctx context.Context, client ports.NylasClient, grantID, from string) ([]domain.EmailParticipant, error) {
+	if from == "" {
+		ids := loadIdentities(grantID)
+		if ids == nil || ids.Default == "" {
+			return nil, nil
+		}
+		if id := ids.Find(ids.Default); id != nil {
+			return []domain.EmailParticipant{{Email: id.Email, Name: id.Name}}, nil
+		}
+		return []domain.EmailParticipant{{Email: ids.Default}}, nil
+	}
+
+	parsed, err := parseContacts([]string{from})
+	if err != nil {
+		return nil, common.WrapRecipientError("from", err)
+	}
+	sender := parsed[0]
+	ids, err := findIdentity(ctx, client, grantID, sender.Email)
+	if err != nil {
+		return nil, err
+	}
+	if sender.Name == "" {
+		sender.Name = ids.Find(sender.Email).Name
+	}
+	return []domain.EmailParticipant{sender}, nil
+}
+
+// fillSendFrom sets the From of req to the grant's address when neither
+// --from nor a default identity chose one. Signed and encrypted messages
+// are built locally and need an explicit sender.
+func fillSendFrom(req *domain.SendMessageRequest, grant *domain.Grant) {
+	if len(req.From) == 0 && grant != nil && grant.Email != "" {
+		req.From = []domain.EmailParticipant{{Email: grant.Email}}
+	}
+}
+
+// loadIdentities returns the stored identities of grantID, or nil.
+// Replaced in tests.
+var loadIdentities = func(grantID string) *domain.GrantIdentities {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil || cfg.Email == nil {
+		return nil
+	}
+	return cfg.Email.Identities[grantID]
+}
+
+// saveIdentities stores the identities of grantID. Replaced in tests.
+var saveIdentities = func(grantID string, ids *domain.GrantIdentities) error {
+	store := configAdapter.NewDefaultFileStore()
+	cfg, err := store.Load()
+	if err != nil {
+		return common.WrapLoadError("config", err)
+	}
+	if cfg.Email == nil {
+		cfg.Email = &domain.EmailConfig{}
+	}
+	if cfg.Email.Identities == nil {
+		cfg.Email.Identities = map[string]*domain.GrantIdentities{}
+	}
+	cfg.Email.Identities[grantID] = ids
+	if err := store.Save(cfg); err != nil {
+		return common.WrapSaveError("config", err)
+	}
+	return nil
+}
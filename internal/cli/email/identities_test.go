@@ -0,0 +1,195 @@
+package email
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// useIdentities keeps identities in memory instead of the config file.
+func useIdentities(t *testing.T, initial map[string]*domain.GrantIdentities) map[string]*domain.GrantIdentities {
+	t.Helper()
+	stored := map[string]*domain.GrantIdentities{}
+	for k, v := range initial {
+		stored[k] = v
+	}
+	origLoad, origSave := loadIdentities, saveIdentities
+	loadIdentities = func(grantID string) *domain.GrantIdentities { return stored[grantID] }
+	saveIdentities = func(grantID string, ids *domain.GrantIdentities) error {
+		stored[grantID] = ids
+		return nil
+	}
+	t.Cleanup(func() { loadIdentities, saveIdentities = origLoad, origSave })
+	return stored
+}
+
+func identityClient(sent ...domain.EmailParticipant) (*nylas.MockClient, *int) {
+	calls := 0
+	client := nylas.NewMockClient()
+	client.GetGrantFunc = func(ctx context.Context, grantID string) (*domain.Grant, error) {
+		return &domain.Grant{ID: grantID, Email: "me@example.com"}, nil
+	}
+	client.GetFoldersFunc = func(ctx context.Context, grantID string) ([]domain.Folder, error) {
+		return []domain.Folder{{ID: "folder-sent", Name: "Sent Items"}}, nil
+	}
+	client.GetMessagesWithParamsFunc = func(ctx context.Context, grantID string, params *domain.MessageQueryParams) ([]domain.Message, error) {
+		calls++
+		if len(params.In) != 1 || params.In[0] != "folder-sent" {
+			return nil, nil
+		}
+		msgs := make([]domain.Message, len(sent))
+		for i, p := range sent {
+			msgs[i] = domain.Message{ID: "m", From: []domain.EmailParticipant{p}}
+		}
+		return msgs, nil
+	}
+	return client, &calls
+}
+
+func TestDiscoverIdentities(t *testing.T) {
+	client, _ := identityClient(
+		domain.EmailParticipant{Email: "support@example.com"},
+		domain.EmailParticipant{Email: "Me@example.com", Name: "Me"},
+		domain.EmailParticipant{Email: "sales@example.com", Name: "Sales"},
+		domain.EmailParticipant{Email: "sales@example.com"},
+		domain.EmailParticipant{Email: "undisclosed"},
+	)
+
+	found, err := discoverIdentities(context.Background(), client, "grant-1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SendIdentity{
+		{Email: "me@example.com", Name: "Me", Source: domain.IdentitySourceAccount},
+		{Email: "sales@example.com", Name: "Sales", Source: domain.IdentitySourceSent},
+		{Email: "support@example.com", Source: domain.IdentitySourceSent},
+	}, found)
+}
+
+func TestMergeIdentities(t *testing.T) {
+	stored := &domain.GrantIdentities{
+		Default: "old@example.com",
+		Known: []domain.SendIdentity{
+			{Email: "old@example.com", Name: "Old", Source: domain.IdentitySourceSent},
+			{Email: "sales@example.com", Name: "Sales", Source: domain.IdentitySourceSent},
+		},
+	}
+	merged := mergeIdentities(stored, []domain.SendIdentity{
+		{Email: "me@example.com", Source: domain.IdentitySourceAccount},
+		{Email: "SALES@example.com", Source: domain.IdentitySourceSent},
+	})
+
+	assert.Equal(t, "old@example.com", merged.Default)
+	assert.Equal(t, []domain.SendIdentity{
+		{Email: "me@example.com", Source: domain.IdentitySourceAccount},
+		{Email: "SALES@example.com", Name: "Sales", Source: domain.IdentitySourceSent},
+		{Email: "old@example.com", Name: "Old", Source: domain.IdentitySourceSent},
+	}, merged.Known)
+}
+
+func TestIdentityList(t *testing.T) {
+	ids := &domain.GrantIdentities{Known: []domain.SendIdentity{
+		{Email: "me@example.com", Source: domain.IdentitySourceAccount},
+		{Email: "sales@example.com", Source: domain.IdentitySourceSent},
+	}}
+
+	list := identityList(ids)
+	assert.True(t, list[0].Default)
+	assert.False(t, list[1].Default)
+	assert.False(t, ids.Known[0].Default, "stored identities are not modified")
+
+	ids.Default = "Sales@example.com"
+	list = identityList(ids)
+	assert.False(t, list[0].Default)
+	assert.True(t, list[1].Default)
+}
+
+func TestResolveSendFrom(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no flag and no default", func(t *testing.T) {
+		useIdentities(t, nil)
+		client, calls := identityClient()
+		from, err := resolveSendFrom(ctx, client, "grant-1", "")
+		require.NoError(t, err)
+		assert.Nil(t, from)
+		assert.Zero(t, *calls)
+	})
+
+	t.Run("default identity", func(t *testing.T) {
+		useIdentities(t, map[string]*domain.GrantIdentities{"grant-1": {
+			Default: "sales@example.com",
+			Known:   []domain.SendIdentity{{Email: "sales@example.com", Name: "Sales", Source: domain.IdentitySourceSent}},
+		}})
+		client, _ := identityClient()
+		from, err := resolveSendFrom(ctx, client, "grant-1", "")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.EmailParticipant{{Email: "sales@example.com", Name: "Sales"}}, from)
+	})
+
+	t.Run("known identity is not searched for", func(t *testing.T) {
+		useIdentities(t, map[string]*domain.GrantIdentities{"grant-1": {
+			Known: []domain.SendIdentity{{Email: "sales@example.com", Name: "Sales", Source: domain.IdentitySourceSent}},
+		}})
+		client, calls := identityClient()
+		from, err := resolveSendFrom(ctx, client, "grant-1", "Sales Team <SALES@example.com>")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.EmailParticipant{{Email: "SALES@example.com", Name: "Sales Team"}}, from)
+		assert.Zero(t, *calls)
+	})
+
+	t.Run("unknown identity is discovered and saved", func(t *testing.T) {
+		stored := useIdentities(t, nil)
+		client, calls := identityClient(domain.EmailParticipant{Email: "sales@example.com", Name: "Sales"})
+		from, err := resolveSendFrom(ctx, client, "grant-1", "sales@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.EmailParticipant{{Email: "sales@example.com", Name: "Sales"}}, from)
+		assert.Equal(t, 1, *calls)
+		require.NotNil(t, stored["grant-1"])
+		assert.Len(t, stored["grant-1"].Known, 2)
+	})
+
+	t.Run("grant address is always allowed", func(t *testing.T) {
+		useIdentities(t, nil)
+		client, _ := identityClient()
+		from, err := resolveSendFrom(ctx, client, "grant-1", "me@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "me@example.com", from[0].Email)
+	})
+
+	t.Run("address the grant doesn't send as", func(t *testing.T) {
+		useIdentities(t, nil)
+		client, _ := identityClient(domain.EmailParticipant{Email: "sales@example.com"})
+		_, err := resolveSendFrom(ctx, client, "grant-1", "ceo@example.com")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ceo@example.com is not an address this grant sends as")
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		useIdentities(t, nil)
+		client, _ := identityClient()
+		_, err := resolveSendFrom(ctx, client, "grant-1", "sales")
+		require.Error(t, err)
+	})
+}
+
+func TestFillSendFrom(t *testing.T) {
+	grant := &domain.Grant{Email: "me@example.com"}
+
+	req := &domain.SendMessageRequest{}
+	fillSendFrom(req, grant)
+	require.Len(t, req.From, 1)
+	assert.Equal(t, "me@example.com", req.From[0].Email)
+
+	alias := []domain.EmailParticipant{{Email: "sales@example.com"}}
+	req = &domain.SendMessageRequest{From: alias}
+	fillSendFrom(req, grant)
+	assert.Equal(t, alias, req.From, "a chosen identity is kept")
+
+	req = &domain.SendMessageRequest{}
+	fillSendFrom(req, nil)
+	assert.Empty(t, req.From)
+}
@@ -0,0 +1,379 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/nylas/cli/internal/adapters/browser"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// maxLinkRedirects caps how many redirects are followed when resolving.
+const maxLinkRedirects = 10
+
+var (
+	anchorPattern  = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))[^>]*>(.*?)</a\s*>`)
+	bareURLPattern = regexp.MustCompile(`(?i)https?://[^\s<>"'\]]+`)
+	hostPattern    = regexp.MustCompile(`(?i)^(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+)
+
+// linkShorteners are URL shortener hosts, whose links are resolved by
+// default because they hide where they lead.
+var linkShorteners = map[string]bool{
+	"bit.ly": true, "buff.ly": true, "cutt.ly": true, "goo.gl": true, "is.gd": true,
+	"lnkd.in": true, "ow.ly": true, "rb.gy": true, "rebrand.ly": true, "shorturl.at": true,
+	"t.co": true, "t.ly": true, "tiny.cc": true, "tinyurl.com": true, "trib.al": true,
+}
+
+// linkHTTPClient resolves links. Replaced in tests.
+var linkHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// openLink opens a URL in the browser. Replaced in tests.
+var openLink = func(u string) error { return browser.NewDefaultBrowser().Open(u) }
+
+// messageLink is one URL found in a message.
+type messageLink struct {
+	Index       int      `json:"index"`
+	URL         string   `json:"url"`
+	Text        string   `json:"text,omitempty"`
+	Domain      string   `json:"domain"`
+	ResolvedURL string   `json:"resolved_url,omitempty"`
+	Redirects   int      `json:"redirects,omitempty"`
+	ResolveErr  string   `json:"resolve_error,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"`
+}
+
+func newLinksCmd() *cobra.Command {
+	var (
+		resolve string
+		open    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "links <message-id> [grant-id]",
+		Short: "List the links in a message",
+		Long: `List every web link in a message with its domain highlighted, to check
+where a message really points before clicking anything.
+
+Links on URL shorteners are followed with HEAD requests to show where they
+lead (--resolve shorteners, the default). --resolve all follows every link
+and --resolve none never contacts the linked servers, which is safest for
+mail you suspect is phishing: a resolved link tells its server the message
+was looked at.
+
+Notes flag links whose text shows a different domain than they go to, plain
+HTTP, IP address and punycode hosts, and user names in the URL.
+
+--open picks a link from a list and opens it in the browser, asking first
+when the link has notes.`,
+		Example: `  # Check the links in a suspicious message without contacting them
+  nylas email links <message-id> --resolve none
+
+  # Pick a link and open it
+  nylas email links <message-id> --open
+
+  # Every link's final destination, as JSON
+  nylas email links <message-id> --resolve all --json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if resolve != "shorteners" && resolve != "all" && resolve != "none" {
+				return common.NewInputError(fmt.Sprintf("invalid --resolve %q: use shorteners, all or none", resolve))
+			}
+			if open && (common.IsStructuredOutput(cmd) || !term.IsTerminal(int(os.Stdin.Fd()))) {
+				return common.NewUserError("--open needs an interactive terminal", "Copy the link from the list instead")
+			}
+
+			// Sample links would be resolved against real servers.
+			if common.IsDemoMode() {
+				resolve = "none"
+			}
+
+			messageID := args[0]
+			links, err := common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) ([]messageLink, error) {
+				msg, err := client.GetMessage(ctx, grantID, messageID)
+				if err != nil {
+					return nil, common.WrapGetError("message", err)
+				}
+				links := extractLinks(msg.Body)
+				if n := countToResolve(links, resolve); n > 0 {
+					_ = common.RunWithSpinner(fmt.Sprintf("Resolving %d link(s)...", n), func() error {
+						resolveLinks(ctx, links, resolve)
+						return nil
+					})
+				}
+				for i := range links {
+					links[i].Warnings = linkWarnings(&links[i])
+				}
+				return links, nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(links)
+			}
+			if len(links) == 0 {
+				common.PrintEmptyState("links")
+				return nil
+			}
+			printLinks(links)
+			if open {
+				return pickAndOpenLink(links)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&resolve, "resolve", "shorteners", "Follow redirects for: shorteners, all or none")
+	cmd.Flags().BoolVar(&open, "open", false, "Pick a link and open it in the browser")
+
+	return cmd
+}
+
+// extractLinks returns the distinct http(s) links in body, in order: anchors
+// first with their text, then bare URLs in the text.
+func extractLinks(body string) []messageLink {
+	var links []messageLink
+	seen := map[string]bool{}
+	add := func(raw, text string) {
+		raw = strings.TrimSpace(html.UnescapeString(raw))
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || seen[raw] {
+			return
+		}
+		seen[raw] = true
+		links = append(links, messageLink{Index: len(links) + 1, URL: raw, Text: text, Domain: strings.ToLower(u.Hostname())})
+	}
+
+	for _, m := range anchorPattern.FindAllStringSubmatch(body, -1) {
+		href := m[1] + m[2] + m[3]
+		text := strings.Join(strings.Fields(common.StripHTML(m[4])), " ")
+		add(href, text)
+	}
+	for _, raw := range bareURLPattern.FindAllString(common.StripHTML(anchorPattern.ReplaceAllString(body, " ")), -1) {
+		add(strings.TrimRight(raw, ".,;:!?)"), "")
+	}
+	return links
+}
+
+// shouldResolve reports whether mode resolves l.
+func shouldResolve(l *messageLink, mode string) bool {
+	switch mode {
+	case "all":
+		return true
+	case "shorteners":
+		return linkShorteners[strings.TrimPrefix(l.Domain, "www.")]
+	default:
+		return false
+	}
+}
+
+func countToResolve(links []messageLink, mode string) int {
+	n := 0
+	for i := range links {
+		if shouldResolve(&links[i], mode) {
+			n++
+		}
+	}
+	return n
+}
+
+// resolveLinks follows the redirects of each link mode selects.
+func resolveLinks(ctx context.Context, links []messageLink, mode string) {
+	for i := range links {
+		l := &links[i]
+		if !shouldResolve(l, mode) {
+			continue
+		}
+		final, hops, err := resolveLink(ctx, l.URL)
+		if err != nil {
+			l.ResolveErr = err.Error()
+			continue
+		}
+		if final != l.URL {
+			l.ResolvedURL, l.Redirects = final, hops
+		}
+	}
+}
+
+// resolveLink follows rawURL's redirects with HEAD requests and returns the
+// last URL reached. Bodies are never fetched.
+func resolveLink(ctx context.Context, rawURL string) (string, int, error) {
+	hops := 0
+	client := *linkHTTPClient
+	client.Jar = nil
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxLinkRedirects {
+			return http.ErrUseLastResponse
+		}
+		hops = len(via)
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.Request.URL.String(), hops, nil
+}
+
+// linkWarnings lists what looks wrong about where l goes.
+func linkWarnings(l *messageLink) []string {
+	var warnings []string
+	target := l.URL
+	if l.ResolvedURL != "" {
+		target = l.ResolvedURL
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return []string{"unparseable URL"}
+	}
+	host := strings.ToLower(u.Hostname())
+
+	if shown := textDomain(l.Text); shown != "" && baseDomain(shown) != baseDomain(l.Domain) {
+		warnings = append(warnings, "text shows "+shown)
+	}
+	if l.ResolvedURL != "" && baseDomain(host) != baseDomain(l.Domain) {
+		warnings = append(warnings, "leads to "+host)
+	}
+	if u.Scheme == "http" {
+		warnings = append(warnings, "not HTTPS")
+	}
+	if net.ParseIP(host) != nil {
+		warnings = append(warnings, "IP address host")
+	}
+	if strings.HasPrefix(host, "xn--") || strings.Contains(host, ".xn--") {
+		warnings = append(warnings, "punycode domain")
+	}
+	if u.User != nil {
+		warnings = append(warnings, "user name in URL")
+	}
+	return warnings
+}
+
+// textDomain returns the host shown by link text that looks like a URL or
+// domain, such as "https://bank.com/login" or "www.bank.com".
+func textDomain(text string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" || strings.ContainsAny(text, " \t\n") {
+		return ""
+	}
+	if i := strings.Index(text, "://"); i >= 0 {
+		text = text[i+3:]
+	}
+	if i := strings.IndexAny(text, "/?#:"); i >= 0 {
+		text = text[:i]
+	}
+	if !hostPattern.MatchString(text) {
+		return ""
+	}
+	return text
+}
+
+// baseDomain returns the registrable part of host, e.g. "example.co.uk" for
+// "mail.example.co.uk". IP addresses are returned as-is.
+func baseDomain(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	labels := strings.Split(host, ".")
+	n := 2
+	if len(labels) > 2 && len(labels[len(labels)-1]) == 2 {
+		switch labels[len(labels)-2] {
+		case "co", "com", "net", "org", "gov", "edu", "ac", "ne", "or":
+			n = 3
+		}
+	}
+	if len(labels) <= n {
+		return host
+	}
+	return strings.Join(labels[len(labels)-n:], ".")
+}
+
+// highlightDomain dims the subdomains of host so the registrable domain,
+// the part that says who owns the link, stands out.
+func highlightDomain(host string) string {
+	base := baseDomain(host)
+	prefix := strings.TrimSuffix(host, base)
+	return common.Dim.Sprint(prefix) + common.BoldWhite.Sprint(base)
+}
+
+func printLinks(links []messageLink) {
+	table := common.NewTable("#", "DOMAIN", "URL", "TEXT", "NOTES")
+	table.SetMaxWidth(2, 60).SetMaxWidth(3, 30)
+	flagged := 0
+	for _, l := range links {
+		notes := strings.Join(l.Warnings, "; ")
+		if l.ResolvedURL != "" && len(l.Warnings) == 0 {
+			notes = fmt.Sprintf("→ %s", l.ResolvedURL)
+		}
+		if l.ResolveErr != "" {
+			notes = strings.TrimPrefix(notes+"; not resolved", "; ")
+		}
+		if len(l.Warnings) > 0 {
+			flagged++
+			notes = common.Yellow.Sprint(notes)
+		}
+		table.AddRow(fmt.Sprintf("%d", l.Index), highlightDomain(l.Domain), l.URL, l.Text, notes)
+	}
+	table.Render()
+	fmt.Printf("\n%d link(s)", len(links))
+	if flagged > 0 {
+		fmt.Printf(", %s", common.Yellow.Sprintf("%d with notes", flagged))
+	}
+	fmt.Println()
+}
+
+// pickAndOpenLink lets the user choose a link and opens it, confirming
+// first when the link has warnings.
+func pickAndOpenLink(links []messageLink) error {
+	options := make([]common.SelectOption[int], len(links))
+	for i, l := range links {
+		label := fmt.Sprintf("%d. %s", l.Index, common.Truncate(l.URL, 70))
+		if len(l.Warnings) > 0 {
+			label += " ⚠"
+		}
+		options[i] = common.SelectOption[int]{Label: label, Value: i}
+	}
+	i, err := common.Select("Open which link?", options)
+	if err != nil {
+		return err
+	}
+
+	l := links[i]
+	target := l.URL
+	if l.ResolvedURL != "" {
+		target = l.ResolvedURL
+	}
+	if len(l.Warnings) > 0 {
+		common.PrintWarning("%s: %s", target, strings.Join(l.Warnings, "; "))
+		ok, err := common.ConfirmPrompt("Open it anyway?", false)
+		if err != nil || !ok {
+			return err
+		}
+	}
+	if err := openLink(target); err != nil {
+		return common.NewUserError("could not open the browser: "+err.Error(), "Open this URL yourself: "+target)
+	}
+	common.PrintSuccess("Opened %s", target)
+	return nil
+}
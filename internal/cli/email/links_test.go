@@ -0,0 +1,131 @@
+package email
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestExtractLinks(t *testing.T) {
+	body := `<p>Hi, <a class="btn" href="https://accounts.example.com/reset?a=1&amp;b=2">Reset <b>password</b></a>.</p>
+<p>Docs: https://docs.example.com/guide. Or <a href='https://docs.example.com/guide'>again</a></p>
+<a href="mailto:help@example.com">mail us</a> <a href="#top">top</a>
+<a href=http://bit.ly/x>http://paypal.com/login</a>`
+
+	links := extractLinks(body)
+
+	require.Len(t, links, 3)
+	assert.Equal(t, messageLink{Index: 1, URL: "https://accounts.example.com/reset?a=1&b=2", Text: "Reset password", Domain: "accounts.example.com"}, links[0])
+	assert.Equal(t, "https://docs.example.com/guide", links[1].URL)
+	assert.Equal(t, "again", links[1].Text, "anchors come first and bare duplicates are dropped")
+	assert.Equal(t, "http://bit.ly/x", links[2].URL)
+	assert.Equal(t, "bit.ly", links[2].Domain)
+}
+
+func TestExtractLinks_PlainText(t *testing.T) {
+	links := extractLinks("See https://example.com/a, and (https://example.org/b).")
+
+	require.Len(t, links, 2)
+	assert.Equal(t, "https://example.com/a", links[0].URL)
+	assert.Equal(t, "https://example.org/b", links[1].URL)
+}
+
+func TestLinkWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		link messageLink
+		want []string
+	}{
+		{name: "clean", link: messageLink{URL: "https://www.example.com/x", Domain: "www.example.com", Text: "example.com"}},
+		{name: "text mismatch", link: messageLink{URL: "https://evil.io/login", Domain: "evil.io", Text: "https://paypal.com/login"}, want: []string{"text shows paypal.com"}},
+		{name: "plain words are not a domain", link: messageLink{URL: "https://evil.io", Domain: "evil.io", Text: "Click here"}},
+		{name: "http", link: messageLink{URL: "http://example.com", Domain: "example.com"}, want: []string{"not HTTPS"}},
+		{name: "ip", link: messageLink{URL: "https://192.0.2.7/x", Domain: "192.0.2.7"}, want: []string{"IP address host"}},
+		{name: "punycode", link: messageLink{URL: "https://xn--pypal-4ve.com", Domain: "xn--pypal-4ve.com"}, want: []string{"punycode domain"}},
+		{name: "user info", link: messageLink{URL: "https://paypal.com@evil.io/", Domain: "evil.io"}, want: []string{"user name in URL"}},
+		{
+			name: "shortener leads elsewhere",
+			link: messageLink{URL: "https://bit.ly/x", Domain: "bit.ly", ResolvedURL: "http://login.evil.io/p"},
+			want: []string{"leads to login.evil.io", "not HTTPS"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, linkWarnings(&tt.link))
+		})
+	}
+}
+
+func TestBaseDomain(t *testing.T) {
+	assert.Equal(t, "example.com", baseDomain("mail.example.com"))
+	assert.Equal(t, "example.co.uk", baseDomain("www.example.co.uk"))
+	assert.Equal(t, "example.com", baseDomain("example.com."))
+	assert.Equal(t, "localhost", baseDomain("localhost"))
+	assert.Equal(t, "192.0.2.7", baseDomain("192.0.2.7"))
+}
+
+func TestResolveLinks(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch r.URL.Path {
+		case "/short":
+			http.Redirect(w, r, "/hop", http.StatusMovedPermanently)
+		case "/hop":
+			http.Redirect(w, r, "/final", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	links := []messageLink{
+		{URL: srv.URL + "/short", Domain: "127.0.0.1"},
+		{URL: srv.URL + "/final", Domain: "127.0.0.1"},
+		{URL: "https://bit.ly/unresolvable", Domain: "bit.ly"},
+	}
+
+	resolveLinks(context.Background(), links[:2], "none")
+	assert.Empty(t, methods, "--resolve none makes no requests")
+
+	resolveLinks(context.Background(), links[:2], "all")
+	assert.Equal(t, srv.URL+"/final", links[0].ResolvedURL)
+	assert.Equal(t, 2, links[0].Redirects)
+	assert.Empty(t, links[1].ResolvedURL, "links without redirects keep no resolved URL")
+	for _, m := range methods {
+		assert.Equal(t, http.MethodHead, m)
+	}
+
+	assert.True(t, shouldResolve(&links[2], "shorteners"))
+	assert.False(t, shouldResolve(&links[0], "shorteners"))
+}
+
+func TestLinksCmd_DemoModeResolvesNothing(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer srv.Close()
+
+	client := nylas.NewMockClient()
+	client.GetMessageFunc = func(context.Context, string, string) (*domain.Message, error) {
+		return &domain.Message{ID: "msg-1", Body: `<a href="` + srv.URL + `/short">Open</a>`}, nil
+	}
+	common.EnableDemoMode(client, "demo-grant")
+	t.Cleanup(func() { common.EnableDemoMode(nil, "") })
+
+	stdout, _, err := testutil.ExecuteCommand(newLinksCmd(), "msg-1", "--resolve", "all")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, srv.URL+"/short")
+	assert.Zero(t, requests, "sample links are never resolved")
+}
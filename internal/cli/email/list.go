@@ -20,10 +20,10 @@ func newListCmd() *cobra.Command {
 	var from string
 	var folder string
 	var showID bool
-	var all bool
 	var allFolders bool
-	var maxItems int
 	var metadataPair string
+	var grants *common.GrantSelection
+	var pages *common.PageSelection
 
 	cmd := &cobra.Command{
 		Use:   "list [grant-id]",
@@ -33,8 +33,16 @@ func newListCmd() *cobra.Command {
 By default, only shows messages from INBOX. Use --folder to specify a different
 folder, or --all-folders to show messages from all folders.
 
-Use --all to fetch all messages (paginated automatically).
-Use --max to limit total messages when using --all.`,
+Use --all, or --limit 0, to fetch all messages, up to --max (10000 by
+default; 0 for no cap). Listings larger than one page are fetched
+concurrently and written as they arrive.
+
+Use --page-token to fetch one page at a time: an empty token starts at the
+first page, and the token of the next page is printed after the list, or
+returned as next_cursor with --json.
+
+Use --grant all, or --grants with a list, to list several accounts at once.
+Limits apply per account; results are merged newest first.`,
 		Example: `  # List recent emails from inbox
   nylas email list
 
@@ -48,9 +56,22 @@ Use --max to limit total messages when using --all.`,
   nylas email list --folder SENT
 
   # Fetch all emails with pagination
-  nylas email list --all --max 500`,
+  nylas email list --all --max 500
+
+  # Page through unread mail as JSON, one page at a time
+  nylas email list --unread --json --page-token ""
+
+  # Unread mail from every account
+  nylas email list --grant all --unread`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			args, err := grants.Args(args)
+			if err != nil {
+				return err
+			}
+			if err := pages.Check(grants); err != nil {
+				return err
+			}
 			opts := listOptions{
 				limit:        limit,
 				unread:       unread,
@@ -58,18 +79,32 @@ Use --max to limit total messages when using --all.`,
 				from:         from,
 				folder:       folder,
 				allFolders:   allFolders,
-				all:          all,
-				maxItems:     maxItems,
+				all:          pages.All,
+				maxItems:     pages.Max,
 				metadataPair: metadataPair,
 			}
 
+			if grants.Multi() {
+				return runListAccounts(cmd, grants, opts, showID)
+			}
+
+			if pages.Cursor() {
+				return runListPage(cmd, args, opts, pages.Token, showID)
+			}
+
+			// Listings of more than one page are fetched concurrently and
+			// written as they arrive.
+			if _, maxItems := resolveListPagination(opts.limit, opts.all, opts.maxItems); maxItems >= 0 {
+				return runListStream(cmd, args, opts, showID)
+			}
+
 			// Check if we should use structured output (JSON/YAML/quiet)
 			if common.IsStructuredOutput(cmd) {
 				return runListStructured(cmd, args, opts)
 			}
 
 			// Traditional formatted output
-			_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				messages, err := fetchListMessages(ctx, cmd, client, grantID, opts)
 				if err != nil {
 					return struct{}{}, common.WrapFetchError("messages", err)
@@ -91,16 +126,16 @@ Use --max to limit total messages when using --all.`,
 		},
 	}
 
-	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Number of messages to fetch (auto-paginates if >200)")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Number of messages to fetch (0 for all; auto-paginates if >200)")
 	cmd.Flags().BoolVarP(&unread, "unread", "u", false, "Only show unread messages")
 	cmd.Flags().BoolVarP(&starred, "starred", "s", false, "Only show starred messages")
 	cmd.Flags().StringVarP(&from, "from", "f", "", "Filter by sender email")
 	cmd.Flags().StringVar(&folder, "folder", "", "Filter by folder (e.g., INBOX, SENT, TRASH, or folder ID)")
 	cmd.Flags().BoolVar(&allFolders, "all-folders", false, "Show messages from all folders (default: INBOX only)")
 	cmd.Flags().BoolVar(&showID, "id", false, "Show message IDs")
-	cmd.Flags().BoolVarP(&all, "all", "a", false, "Fetch all messages (paginated)")
-	cmd.Flags().IntVar(&maxItems, "max", 0, "Maximum messages to fetch with --all (0=unlimited)")
 	cmd.Flags().StringVar(&metadataPair, "metadata", "", "Filter by metadata (format: key:value, only key1-key5 supported)")
+	grants = common.AddGrantSelectionFlags(cmd)
+	pages = common.AddPageSelectionFlags(cmd, "messages")
 
 	return cmd
 }
@@ -117,7 +152,12 @@ type listOptions struct {
 	metadataPair string
 }
 
+// resolveListPagination returns the page size and fetchMessages item cap.
+// A limit of 0 lists everything, like --all.
 func resolveListPagination(limit int, all bool, maxItems int) (int, int) {
+	if limit == 0 {
+		all = true
+	}
 	pag := common.SetupPagination(limit, all, maxItems)
 	limit = pag.Limit
 
@@ -134,6 +174,13 @@ func resolveListPagination(limit int, all bool, maxItems int) (int, int) {
 }
 
 func fetchListMessages(ctx context.Context, cmd *cobra.Command, client ports.NylasClient, grantID string, opts listOptions) ([]domain.Message, error) {
+	params, maxItems := listQueryParams(ctx, cmd, client, grantID, opts)
+	return fetchMessages(ctx, client, grantID, params, maxItems)
+}
+
+// listQueryParams builds the query of `email list` and returns it with the
+// fetchMessages item cap (-1 for a single page, 0 for all).
+func listQueryParams(ctx context.Context, cmd *cobra.Command, client ports.NylasClient, grantID string, opts listOptions) (*domain.MessageQueryParams, int) {
 	limit, maxItems := resolveListPagination(opts.limit, opts.all, opts.maxItems)
 
 	params := &domain.MessageQueryParams{
@@ -155,7 +202,7 @@ func fetchListMessages(ctx context.Context, cmd *cobra.Command, client ports.Nyl
 
 	applyListFolderFilter(ctx, cmd.ErrOrStderr(), client, grantID, params, opts.folder, opts.allFolders)
 
-	return fetchMessages(ctx, client, grantID, params, maxItems)
+	return params, maxItems
 }
 
 // resolveFolderName looks up a folder by name and returns its ID.
@@ -266,3 +313,35 @@ func writeListStructured(ctx context.Context, cmd *cobra.Command, client ports.N
 	out := common.GetOutputWriter(cmd)
 	return out.Write(messages)
 }
+
+// runListPage lists one page of messages from a cursor and reports the
+// cursor of the next page.
+func runListPage(cmd *cobra.Command, args []string, opts listOptions, token string, showID bool) error {
+	_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+		params, _ := listQueryParams(ctx, cmd, client, grantID, opts)
+		params.Limit = common.NormalizePageSize(opts.limit)
+		params.PageToken = token
+
+		resp, err := client.GetMessagesWithCursor(ctx, grantID, params)
+		if err != nil {
+			return struct{}{}, common.WrapFetchError("messages", err)
+		}
+		next := resp.Pagination.NextCursor
+
+		if common.IsStructuredOutput(cmd) {
+			return struct{}{}, common.WritePage(cmd, resp.Data, next)
+		}
+
+		if len(resp.Data) == 0 {
+			common.PrintEmptyState("messages")
+			return struct{}{}, nil
+		}
+		fmt.Printf("Found %d messages:\n\n", len(resp.Data))
+		for i, msg := range resp.Data {
+			printMessageSummaryWithID(msg, i+1, showID)
+		}
+		common.PrintNextPage(cmd, next)
+		return struct{}{}, nil
+	})
+	return err
+}
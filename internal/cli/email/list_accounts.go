@@ -0,0 +1,77 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// accountMessage is a message tagged with the account it came from.
+type accountMessage struct {
+	Account        string `json:"account" yaml:"account"`
+	domain.Message `yaml:",inline"`
+}
+
+// runListAccounts lists messages from several grants, newest first.
+func runListAccounts(cmd *cobra.Command, sel *common.GrantSelection, opts listOptions, showID bool) error {
+	results, err := common.WithGrants(sel, func(ctx context.Context, client ports.NylasClient, grantID string) ([]domain.Message, error) {
+		return fetchListMessages(ctx, cmd, client, grantID, opts)
+	})
+	if err != nil {
+		return common.WrapFetchError("messages", err)
+	}
+	messages := mergeAccountMessages(results)
+
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(messages)
+	}
+	if len(messages) == 0 {
+		common.PrintEmptyState("messages")
+		return nil
+	}
+
+	fmt.Printf("Found %d messages across %d accounts:\n\n", len(messages), len(results))
+	for _, msg := range messages {
+		printAccountMessageSummary(msg, showID)
+	}
+	return nil
+}
+
+func mergeAccountMessages(results []common.GrantResult[domain.Message]) []accountMessage {
+	messages := common.MergeGrantResults(results, func(account string, msg domain.Message) accountMessage {
+		return accountMessage{Account: account, Message: msg}
+	})
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Date.After(messages[j].Date)
+	})
+	return messages
+}
+
+func printAccountMessageSummary(msg accountMessage, showID bool) {
+	status := " "
+	if msg.Unread {
+		status = common.Cyan.Sprint("●")
+	}
+	star := " "
+	if msg.Starred {
+		star = common.Yellow.Sprint("★")
+	}
+
+	account := common.Truncate(msg.Account, 24)
+	from := common.Truncate(common.FormatParticipants(msg.From), 20)
+	subject := common.Truncate(msg.Subject, 34)
+	dateStr := common.FormatTimeAgo(msg.Date)
+	if len(dateStr) > 12 {
+		dateStr = msg.Date.Format("Jan 2")
+	}
+
+	fmt.Printf("%s %s %s %-20s %-34s %s\n", status, star, common.Blue.Sprintf("%-24s", account), from, subject, common.Dim.Sprint(dateStr))
+	if showID {
+		fmt.Println(common.Dim.Sprintf("      ID: %s", msg.ID))
+	}
+}
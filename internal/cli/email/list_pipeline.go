@@ -0,0 +1,187 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+const (
+	// listFetchWorkers bounds how many date windows are paged at once.
+	listFetchWorkers = 4
+	// listFetchWindow is the span of received dates one worker pages through.
+	listFetchWindow = 7 * 24 * time.Hour
+	// listWindowBuffer is how many pages a worker fetches ahead of output.
+	listWindowBuffer = 2
+)
+
+// errStreamDone stops a stream once enough messages have been written.
+var errStreamDone = errors.New("stream done")
+
+// fetchWindow is a range of received dates, [after, before) in Unix seconds,
+// paged by one worker.
+type fetchWindow struct {
+	after, before int64
+	pages         chan []domain.Message
+	err           error // Set before pages is closed
+}
+
+// messagePipeline fetches a large listing concurrently. A cursor can only be
+// followed one page at a time, so the mailbox is cut into windows of
+// received dates that are paged in parallel; pages are emitted newest first
+// as soon as every newer window has been written.
+type messagePipeline struct {
+	client  messagesClient
+	grantID string
+	params  domain.MessageQueryParams
+	workers int
+	window  time.Duration
+}
+
+func newMessagePipeline(client messagesClient, grantID string, params domain.MessageQueryParams) *messagePipeline {
+	params.Limit = common.NormalizePageSize(params.Limit)
+	params.PageToken = ""
+	return &messagePipeline{client: client, grantID: grantID, params: params, workers: listFetchWorkers, window: listFetchWindow}
+}
+
+// run calls emit for each message, newest first, until maxItems have been
+// emitted (0 for all). It returns the number emitted.
+func (p *messagePipeline) run(parent context.Context, maxItems int, emit func(domain.Message) error) (int, error) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	ordered := make(chan *fetchWindow, p.workers)
+	jobs := make(chan *fetchWindow)
+	var dispatchErr error
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(ordered)
+		defer close(jobs)
+		dispatchErr = p.dispatch(ctx, ordered, jobs)
+	}()
+	for range p.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for w := range jobs {
+				w.err = p.page(ctx, w)
+				close(w.pages)
+			}
+		}()
+	}
+
+	emitted, err := p.drain(ordered, maxItems, emit)
+	cancel()
+	wg.Wait()
+	switch {
+	case errors.Is(err, errStreamDone):
+		return emitted, nil
+	case err != nil:
+		return emitted, err
+	case dispatchErr != nil && !errors.Is(dispatchErr, context.Canceled):
+		return emitted, dispatchErr
+	}
+	return emitted, parent.Err()
+}
+
+// dispatch queues windows from newest to oldest. Before each window it asks
+// for the newest message older than the last one, so gaps in the mailbox
+// are skipped and the listing ends when nothing older is left.
+func (p *messagePipeline) dispatch(ctx context.Context, ordered, jobs chan<- *fetchWindow) error {
+	upper := p.params.ReceivedBefore
+	span := int64(p.window / time.Second)
+	for {
+		probe := p.params
+		probe.Limit = 1
+		probe.ReceivedBefore = upper
+		newest, err := p.client.GetMessagesWithParams(ctx, p.grantID, &probe)
+		if err != nil {
+			return err
+		}
+		if len(newest) == 0 {
+			return nil
+		}
+
+		w := &fetchWindow{before: newest[0].Date.Unix() + 1, pages: make(chan []domain.Message, listWindowBuffer)}
+		w.after = w.before - span
+		// A window reaching the requested start, or the epoch, is the last.
+		last := w.after <= max(p.params.ReceivedAfter, 0)
+		if last {
+			w.after = p.params.ReceivedAfter
+		}
+		for _, ch := range []chan<- *fetchWindow{ordered, jobs} {
+			select {
+			case ch <- w:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if last {
+			return nil
+		}
+		upper = w.after
+	}
+}
+
+// page follows the cursor through one window.
+func (p *messagePipeline) page(ctx context.Context, w *fetchWindow) error {
+	params := p.params
+	// One second of overlap with the older window; drain drops repeats.
+	params.ReceivedAfter = max(w.after-1, p.params.ReceivedAfter)
+	params.ReceivedBefore = w.before
+	for {
+		resp, err := p.client.GetMessagesWithCursor(ctx, p.grantID, &params)
+		if err != nil {
+			return err
+		}
+		select {
+		case w.pages <- resp.Data:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		next := resp.Pagination.NextCursor
+		if next == "" || next == params.PageToken || len(resp.Data) == 0 {
+			return nil
+		}
+		params.PageToken = next
+	}
+}
+
+// drain emits windows in order, dropping messages already emitted from the
+// edge of the previous window.
+func (p *messagePipeline) drain(ordered <-chan *fetchWindow, maxItems int, emit func(domain.Message) error) (int, error) {
+	emitted := 0
+	var edge map[string]bool
+	for w := range ordered {
+		nextEdge := make(map[string]bool)
+		for page := range w.pages {
+			for _, msg := range page {
+				if edge[msg.ID] || nextEdge[msg.ID] {
+					continue
+				}
+				if msg.Date.Unix() <= w.after+1 {
+					nextEdge[msg.ID] = true
+				}
+				if err := emit(msg); err != nil {
+					return emitted, err
+				}
+				emitted++
+				if maxItems > 0 && emitted >= maxItems {
+					return emitted, errStreamDone
+				}
+			}
+		}
+		if w.err != nil {
+			return emitted, w.err
+		}
+		edge = nextEdge
+	}
+	return emitted, nil
+}
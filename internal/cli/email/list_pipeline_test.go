@@ -0,0 +1,203 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMailbox serves received-date and cursor queries over messages sorted
+// newest first.
+type fakeMailbox struct {
+	messages        []domain.Message
+	inclusiveBefore bool // Treat received_before as <= instead of <
+	failCursor      string
+
+	mu      sync.Mutex
+	active  int
+	peak    int
+	queries int
+}
+
+func newFakeMailbox(dates ...time.Time) *fakeMailbox {
+	mb := &fakeMailbox{}
+	for i, d := range dates {
+		mb.messages = append(mb.messages, domain.Message{ID: fmt.Sprintf("msg-%04d", i), Date: d})
+	}
+	return mb
+}
+
+func (mb *fakeMailbox) match(params *domain.MessageQueryParams) []domain.Message {
+	var out []domain.Message
+	for _, m := range mb.messages {
+		ts := m.Date.Unix()
+		if params.ReceivedAfter > 0 && ts < params.ReceivedAfter {
+			continue
+		}
+		if params.ReceivedBefore > 0 && (ts > params.ReceivedBefore || (ts == params.ReceivedBefore && !mb.inclusiveBefore)) {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func (mb *fakeMailbox) track() func() {
+	mb.mu.Lock()
+	mb.active++
+	mb.queries++
+	mb.peak = max(mb.peak, mb.active)
+	mb.mu.Unlock()
+	time.Sleep(time.Millisecond)
+	return func() {
+		mb.mu.Lock()
+		mb.active--
+		mb.mu.Unlock()
+	}
+}
+
+func (mb *fakeMailbox) GetMessagesWithParams(_ context.Context, _ string, params *domain.MessageQueryParams) ([]domain.Message, error) {
+	defer mb.track()()
+	matched := mb.match(params)
+	return matched[:min(params.Limit, len(matched))], nil
+}
+
+func (mb *fakeMailbox) GetMessagesWithCursor(ctx context.Context, _ string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+	defer mb.track()()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if mb.failCursor != "" && params.PageToken == mb.failCursor {
+		return nil, errors.New("rate limited")
+	}
+	matched := mb.match(params)
+	offset, _ := strconv.Atoi(params.PageToken)
+	end := min(offset+params.Limit, len(matched))
+	resp := &domain.MessageListResponse{Data: matched[offset:end]}
+	if end < len(matched) {
+		resp.Pagination.NextCursor = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+// mailboxDates returns n dates, newest first, with bursts sharing a second
+// and a two-month gap in the middle.
+func mailboxDates(n int) []time.Time {
+	t := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	dates := make([]time.Time, 0, n)
+	for i := range n {
+		dates = append(dates, t)
+		switch {
+		case i == n/2:
+			t = t.AddDate(0, -2, 0)
+		case i%5 != 0:
+			t = t.Add(-3 * time.Hour)
+		}
+	}
+	return dates
+}
+
+func collect(t *testing.T, p *messagePipeline, maxItems int) ([]string, error) {
+	t.Helper()
+	var ids []string
+	_, err := p.run(context.Background(), maxItems, func(m domain.Message) error {
+		ids = append(ids, m.ID)
+		return nil
+	})
+	return ids, err
+}
+
+func allIDs(mb *fakeMailbox) []string {
+	ids := make([]string, len(mb.messages))
+	for i, m := range mb.messages {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+func TestMessagePipeline_ListsEverythingInOrder(t *testing.T) {
+	for _, inclusive := range []bool{false, true} {
+		t.Run(fmt.Sprintf("inclusive before %v", inclusive), func(t *testing.T) {
+			mb := newFakeMailbox(mailboxDates(600)...)
+			mb.inclusiveBefore = inclusive
+			p := newMessagePipeline(mb, "grant", domain.MessageQueryParams{Limit: 7})
+			p.window = 2 * 24 * time.Hour
+
+			ids, err := collect(t, p, 0)
+
+			require.NoError(t, err)
+			assert.Equal(t, allIDs(mb), ids)
+			assert.Greater(t, mb.peak, 1, "windows are paged concurrently")
+			assert.LessOrEqual(t, mb.peak, listFetchWorkers+1)
+		})
+	}
+}
+
+func TestMessagePipeline_HonorsDateRange(t *testing.T) {
+	mb := newFakeMailbox(mailboxDates(200)...)
+	after, before := mb.messages[150].Date.Unix(), mb.messages[20].Date.Unix()
+	p := newMessagePipeline(mb, "grant", domain.MessageQueryParams{Limit: 10, ReceivedAfter: after, ReceivedBefore: before})
+	p.window = 24 * time.Hour
+
+	ids, err := collect(t, p, 0)
+
+	require.NoError(t, err)
+	var want []string
+	for _, m := range mb.match(&domain.MessageQueryParams{ReceivedAfter: after, ReceivedBefore: before}) {
+		want = append(want, m.ID)
+	}
+	assert.Equal(t, want, ids)
+}
+
+func TestMessagePipeline_StopsAtMax(t *testing.T) {
+	mb := newFakeMailbox(mailboxDates(600)...)
+	p := newMessagePipeline(mb, "grant", domain.MessageQueryParams{Limit: 5})
+	p.window = 24 * time.Hour
+
+	ids, err := collect(t, p, 42)
+
+	require.NoError(t, err)
+	assert.Equal(t, allIDs(mb)[:42], ids)
+	assert.Less(t, mb.queries, 120, "stops fetching once the cap is reached")
+}
+
+func TestMessagePipeline_PageError(t *testing.T) {
+	mb := newFakeMailbox(mailboxDates(100)...)
+	mb.failCursor = "10"
+	p := newMessagePipeline(mb, "grant", domain.MessageQueryParams{Limit: 10})
+
+	ids, err := collect(t, p, 0)
+
+	require.EqualError(t, err, "rate limited")
+	assert.Equal(t, allIDs(mb)[:len(ids)], ids, "messages before the failure are kept")
+}
+
+func TestMessagePipeline_EmptyMailbox(t *testing.T) {
+	ids, err := collect(t, newMessagePipeline(newFakeMailbox(), "grant", domain.MessageQueryParams{}), 0)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestStreamListMessages_JSON(t *testing.T) {
+	mb := newFakeMailbox(mailboxDates(30)...)
+	cmd := newListCmd()
+	cmd.Flags().Bool("json", true, "")
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+
+	require.NoError(t, streamListMessages(context.Background(), cmd, newMessagePipeline(mb, "grant", domain.MessageQueryParams{Limit: 4}), 0, false))
+
+	var decoded []domain.Message
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &decoded))
+	assert.Len(t, decoded, 30)
+}
@@ -0,0 +1,76 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// runListStream lists more than one page of messages with the concurrent
+// pipeline.
+func runListStream(cmd *cobra.Command, args []string, opts listOptions, showID bool) error {
+	_, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+		params, maxItems := listQueryParams(ctx, cmd, client, grantID, opts)
+
+		// The listing may take far longer than one request's timeout; each
+		// request is still bounded by the client. Ctrl-C stops it.
+		streamCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return struct{}{}, streamListMessages(streamCtx, cmd, newMessagePipeline(client, grantID, *params), maxItems, showID)
+	})
+	return err
+}
+
+// streamListMessages writes the pipeline's messages as they arrive: JSON
+// and IDs item by item, the table line by line. Other formats need the
+// whole list and are written at the end.
+func streamListMessages(ctx context.Context, cmd *cobra.Command, pipeline *messagePipeline, maxItems int, showID bool) error {
+	if sw, ok := common.GetStreamWriter(cmd); ok {
+		_, err := pipeline.run(ctx, maxItems, func(msg domain.Message) error {
+			return sw.WriteItem(msg)
+		})
+		// Close the output even after a failure so what was written stays valid.
+		if closeErr := sw.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return common.WrapFetchError("messages", err)
+		}
+		return nil
+	}
+
+	if common.IsStructuredOutput(cmd) {
+		messages := make([]domain.Message, 0)
+		if _, err := pipeline.run(ctx, maxItems, func(msg domain.Message) error {
+			messages = append(messages, msg)
+			return nil
+		}); err != nil {
+			return common.WrapFetchError("messages", err)
+		}
+		return common.GetOutputWriter(cmd).Write(messages)
+	}
+
+	count, err := pipeline.run(ctx, maxItems, func(msg domain.Message) error {
+		printMessageSummaryWithID(msg, 0, showID)
+		return nil
+	})
+	if err != nil {
+		if count > 0 {
+			fmt.Println()
+		}
+		return common.WrapFetchError("messages", err)
+	}
+	if count == 0 {
+		common.PrintEmptyState("messages")
+		return nil
+	}
+	fmt.Printf("\nListed %d messages\n", count)
+	return nil
+}
@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
 	"github.com/nylas/cli/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +52,12 @@ func TestResolveListPagination(t *testing.T) {
 			wantLimit:    200,
 			wantMaxItems: 350,
 		},
+		{
+			name:         "zero limit fetches everything",
+			limit:        0,
+			wantLimit:    200,
+			wantMaxItems: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -279,3 +288,36 @@ func TestRunListStructured(t *testing.T) {
 	require.Len(t, decoded, 1)
 	assert.Equal(t, "msg-structured", decoded[0].ID)
 }
+
+func TestMergeAccountMessages(t *testing.T) {
+	now := time.Now()
+	results := []common.GrantResult[domain.Message]{
+		{Grant: domain.GrantInfo{ID: "g1", Email: "me@work.com"}, Items: []domain.Message{
+			{ID: "w1", Subject: "Older", Date: now.Add(-2 * time.Hour)},
+		}},
+		{Grant: domain.GrantInfo{ID: "g2"}, Err: errors.New("expired")},
+		{Grant: domain.GrantInfo{ID: "g3", Email: "me@home.com"}, Items: []domain.Message{
+			{ID: "h1", Subject: "Newest", Date: now},
+			{ID: "h2", Subject: "Oldest", Date: now.Add(-3 * time.Hour)},
+		}},
+	}
+
+	merged := mergeAccountMessages(results)
+
+	require.Len(t, merged, 3)
+	assert.Equal(t, []string{"h1", "w1", "h2"}, []string{merged[0].ID, merged[1].ID, merged[2].ID})
+	assert.Equal(t, "me@home.com", merged[0].Account)
+
+	data, err := json.Marshal(merged[1])
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "me@work.com", decoded["account"])
+	assert.Equal(t, "Older", decoded["subject"], "message fields stay at the top level")
+}
+
+func TestListCmd_GrantFlagConflicts(t *testing.T) {
+	cmd := newListCmd()
+	_, _, err := clitestutil.ExecuteSubCommand(cmd, "grant-1", "--grant", "all")
+	assert.ErrorContains(t, err, "grant given twice")
+}
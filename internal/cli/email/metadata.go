@@ -26,7 +26,7 @@ Metadata is set when sending or creating drafts. You cannot update metadata
 on existing messages through the API.`,
 	}
 
-	cmd.AddCommand(newMetadataShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newMetadataShowCmd(), map[string]string{}))
 	cmd.AddCommand(newMetadataInfoCmd())
 
 	return cmd
@@ -0,0 +1,240 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func newQuietHoursCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quiet-hours",
+		Short: "Hold sends during quiet hours",
+		Long: `Configure quiet hours per grant. While quiet hours are in effect in the
+recipients' time zone, 'nylas email send' turns an immediate send into a
+scheduled send at the end of the window. Pass --now to send anyway.
+
+Quiet hours set with --all apply to every grant without its own setting.`,
+	}
+
+	cmd.AddCommand(newQuietHoursSetCmd())
+	cmd.AddCommand(common.DeclareOutput(newQuietHoursShowCmd(), map[string]*domain.QuietHours{}))
+	cmd.AddCommand(newQuietHoursClearCmd())
+
+	return cmd
+}
+
+func newQuietHoursSetCmd() *cobra.Command {
+	var (
+		q   domain.QuietHours
+		all bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set [grant-id]",
+		Short: "Set quiet hours for a grant",
+		Example: `  # Never send 20:00-07:00 New York time from the default grant
+  nylas email quiet-hours set --start 20:00 --end 07:00 --timezone America/New_York
+
+  # Hold weekend sends too, for every grant
+  nylas email quiet-hours set --all --start 19:00 --end 08:00 --weekends`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := q.Validate(); err != nil {
+				return common.NewUserError(err.Error(), "Use 24-hour HH:MM times and an IANA time zone such as Europe/Berlin")
+			}
+			key, err := quietHoursKey(args, all)
+			if err != nil {
+				return err
+			}
+
+			err = updateQuietHours(func(m map[string]*domain.QuietHours) { m[key] = &q })
+			if err != nil {
+				return err
+			}
+			common.PrintSuccess("Quiet hours for %s set to %s", quietHoursLabel(key), q.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&q.Start, "start", "", "Start of quiet hours, HH:MM (required)")
+	cmd.Flags().StringVar(&q.End, "end", "", "End of quiet hours, HH:MM (required)")
+	cmd.Flags().StringVar(&q.Timezone, "timezone", "", "Recipients' IANA time zone (default: local)")
+	cmd.Flags().BoolVar(&q.Weekends, "weekends", false, "Also hold sends all day on Saturdays and Sundays")
+	cmd.Flags().BoolVar(&all, "all", false, "Apply to every grant without its own quiet hours")
+	_ = cmd.MarkFlagRequired("start")
+	_ = cmd.MarkFlagRequired("end")
+
+	return cmd
+}
+
+func newQuietHoursShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show configured quiet hours",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := configAdapter.NewDefaultFileStore().Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			var quiet map[string]*domain.QuietHours
+			if cfg.Email != nil {
+				quiet = cfg.Email.QuietHours
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				if quiet == nil {
+					quiet = map[string]*domain.QuietHours{}
+				}
+				return common.GetOutputWriter(cmd).Write(quiet)
+			}
+			if len(quiet) == 0 {
+				common.PrintEmptyStateWithHint("quiet hours", "Set them with 'nylas email quiet-hours set --start 20:00 --end 07:00'")
+				return nil
+			}
+
+			keys := make([]string, 0, len(quiet))
+			for k := range quiet {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			table := common.NewTable("GRANT", "START", "END", "TIME ZONE", "WEEKENDS")
+			for _, k := range keys {
+				q := quiet[k]
+				tz := q.Timezone
+				if tz == "" {
+					tz = "local"
+				}
+				weekends := "-"
+				if q.Weekends {
+					weekends = "held"
+				}
+				table.AddRow(quietHoursLabel(k), q.Start, q.End, tz, weekends)
+			}
+			table.Render()
+			return nil
+		},
+	}
+}
+
+func newQuietHoursClearCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "clear [grant-id]",
+		Short: "Remove quiet hours for a grant",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := quietHoursKey(args, all)
+			if err != nil {
+				return err
+			}
+			err = updateQuietHours(func(m map[string]*domain.QuietHours) { delete(m, key) })
+			if err != nil {
+				return err
+			}
+			common.PrintSuccess("Quiet hours for %s cleared", quietHoursLabel(key))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Clear the quiet hours that apply to every grant")
+
+	return cmd
+}
+
+// quietHoursKey returns the config key for a grant argument or --all.
+func quietHoursKey(args []string, all bool) (string, error) {
+	if all {
+		if len(args) > 0 {
+			return "", common.NewInputError("pass either a grant or --all, not both")
+		}
+		return domain.QuietHoursAllGrants, nil
+	}
+	return common.GetGrantID(args)
+}
+
+func quietHoursLabel(key string) string {
+	if key == domain.QuietHoursAllGrants {
+		return "all grants"
+	}
+	return key
+}
+
+// updateQuietHours applies fn to the stored quiet hours and saves them.
+func updateQuietHours(fn func(map[string]*domain.QuietHours)) error {
+	store := configAdapter.NewDefaultFileStore()
+	cfg, err := store.Load()
+	if err != nil {
+		return common.WrapLoadError("config", err)
+	}
+	if cfg.Email == nil {
+		cfg.Email = &domain.EmailConfig{}
+	}
+	if cfg.Email.QuietHours == nil {
+		cfg.Email.QuietHours = map[string]*domain.QuietHours{}
+	}
+	fn(cfg.Email.QuietHours)
+	if len(cfg.Email.QuietHours) == 0 {
+		cfg.Email.QuietHours = nil
+	}
+	if err := store.Save(cfg); err != nil {
+		return common.WrapSaveError("config", err)
+	}
+	return nil
+}
+
+// loadQuietHours returns the quiet hours that apply to grantID, or nil.
+// Replaced in tests.
+var loadQuietHours = func(grantID string) *domain.QuietHours {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil {
+		return nil
+	}
+	return cfg.Email.QuietHoursFor(grantID)
+}
+
+// holdForQuietHours returns when an immediate send from grantID may go out:
+// the zero time when it can go now, or the end of the quiet window.
+// recipientTZ overrides the configured time zone.
+func holdForQuietHours(grantID string, now time.Time, recipientTZ, via string) (time.Time, error) {
+	q := loadQuietHours(grantID)
+	if q == nil {
+		return time.Time{}, nil
+	}
+	if recipientTZ != "" {
+		override := *q
+		override.Timezone = recipientTZ
+		q = &override
+	}
+
+	next, held, err := q.NextAllowed(now)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			return time.Time{}, common.NewUserError(err.Error(),
+				"Fix them with 'nylas email quiet-hours set', or pass --now to send immediately")
+		}
+		return time.Time{}, err
+	}
+	if !held {
+		return time.Time{}, nil
+	}
+	if via == sendViaSMTP {
+		return time.Time{}, common.NewUserError(
+			fmt.Sprintf("quiet hours (%s) are in effect and --via smtp cannot schedule", q),
+			"Pass --now to send immediately, or send through Nylas to schedule it")
+	}
+
+	fmt.Printf("\n%s Quiet hours (%s) are in effect; holding until %s recipient time. Use --now to send immediately.\n",
+		common.Yellow.Sprint("⏸"), q, next.Format(common.DisplayWeekdayFullWithTZ))
+	return next.In(time.Local), nil
+}
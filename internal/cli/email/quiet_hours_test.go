@@ -0,0 +1,108 @@
+package email
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func useQuietHours(t *testing.T, q *domain.QuietHours) *string {
+	t.Helper()
+	var gotGrant string
+	orig := loadQuietHours
+	loadQuietHours = func(grantID string) *domain.QuietHours {
+		gotGrant = grantID
+		return q
+	}
+	t.Cleanup(func() { loadQuietHours = orig })
+	return &gotGrant
+}
+
+func TestHoldForQuietHours(t *testing.T) {
+	evening := time.Date(2026, 3, 11, 22, 0, 0, 0, time.UTC)
+	noon := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+	q := &domain.QuietHours{Start: "20:00", End: "07:00", Timezone: "UTC"}
+
+	t.Run("no quiet hours configured", func(t *testing.T) {
+		useQuietHours(t, nil)
+		held, err := holdForQuietHours("grant-1", evening, "", sendViaNylas)
+		require.NoError(t, err)
+		assert.True(t, held.IsZero())
+	})
+
+	t.Run("outside the window", func(t *testing.T) {
+		useQuietHours(t, q)
+		held, err := holdForQuietHours("grant-1", noon, "", sendViaNylas)
+		require.NoError(t, err)
+		assert.True(t, held.IsZero())
+	})
+
+	t.Run("inside the window schedules for its end", func(t *testing.T) {
+		gotGrant := useQuietHours(t, q)
+		held, err := holdForQuietHours("grant-1", evening, "", sendViaNylas)
+		require.NoError(t, err)
+		assert.Equal(t, "grant-1", *gotGrant)
+		assert.True(t, held.Equal(time.Date(2026, 3, 12, 7, 0, 0, 0, time.UTC)), "got %s", held)
+	})
+
+	t.Run("recipient time zone override", func(t *testing.T) {
+		useQuietHours(t, q)
+		// 12:00 UTC is 21:00 in Tokyo.
+		held, err := holdForQuietHours("grant-1", noon, "Asia/Tokyo", sendViaNylas)
+		require.NoError(t, err)
+		assert.True(t, held.Equal(time.Date(2026, 3, 11, 22, 0, 0, 0, time.UTC)), "got %s", held)
+
+		_, err = holdForQuietHours("grant-1", noon, "Nowhere/Special", sendViaNylas)
+		assert.ErrorContains(t, err, "unknown time zone")
+	})
+
+	t.Run("smtp cannot schedule", func(t *testing.T) {
+		useQuietHours(t, q)
+		_, err := holdForQuietHours("grant-1", evening, "", sendViaSMTP)
+		assert.ErrorContains(t, err, "--via smtp cannot schedule")
+	})
+}
+
+func TestQuietHoursKey(t *testing.T) {
+	key, err := quietHoursKey(nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QuietHoursAllGrants, key)
+
+	key, err = quietHoursKey([]string{"grant-123"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "grant-123", key)
+
+	_, err = quietHoursKey([]string{"grant-123"}, true)
+	assert.Error(t, err)
+
+	assert.Equal(t, "all grants", quietHoursLabel(domain.QuietHoursAllGrants))
+}
+
+func TestQuietHoursCommand(t *testing.T) {
+	cmd := newQuietHoursCmd()
+	assert.Equal(t, "quiet-hours", cmd.Use)
+
+	set, _, err := cmd.Find([]string{"set"})
+	require.NoError(t, err)
+	for _, flag := range []string{"start", "end", "timezone", "weekends", "all"} {
+		assert.NotNil(t, set.Flags().Lookup(flag), flag)
+	}
+
+	cmd.SetArgs([]string{"set", "--all", "--start", "8pm", "--end", "07:00"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	assert.ErrorContains(t, cmd.Execute(), "invalid quiet hours start")
+}
+
+func TestSendCommand_NowConflictsWithSchedule(t *testing.T) {
+	cmd := newSendCmd()
+	assert.NotNil(t, cmd.Flags().Lookup("now"))
+	assert.NotNil(t, cmd.Flags().Lookup("recipient-tz"))
+
+	cmd.SetArgs([]string{"--to", "a@example.com", "--subject", "x", "--now", "--schedule", "2h", "--yes"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	assert.ErrorContains(t, cmd.Execute(), "--now")
+}
@@ -0,0 +1,87 @@
+package email
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// htmlTagPattern detects bodies that are already HTML.
+var htmlTagPattern = regexp.MustCompile(`(?i)<(html|body|div|p|br|span|table|a|blockquote)\b`)
+
+// quoteDateFormat matches the attribution line mail clients write.
+const quoteDateFormat = "Mon, Jan 2, 2006 at 3:04 PM"
+
+// bodyToHTML returns body unchanged when it is HTML, otherwise escapes it and
+// converts newlines to <br> so plain text survives being embedded in HTML.
+func bodyToHTML(body string) string {
+	if htmlTagPattern.MatchString(body) {
+		return body
+	}
+	return strings.ReplaceAll(html.EscapeString(body), "\n", "<br>\n")
+}
+
+// quoteReplyBody appends the original message as a blockquote below the
+// reply text, with an "On <date>, <sender> wrote:" attribution.
+func quoteReplyBody(body string, orig *domain.Message) string {
+	sender := "someone"
+	if len(orig.From) > 0 {
+		sender = orig.From[0].String()
+	}
+	attribution := fmt.Sprintf("On %s, %s wrote:", orig.Date.Format(quoteDateFormat), sender)
+	if orig.Date.IsZero() {
+		attribution = sender + " wrote:"
+	}
+
+	var b strings.Builder
+	b.WriteString(bodyToHTML(body))
+	b.WriteString("<br><br>\n<div class=\"nylas_quote\">")
+	b.WriteString(html.EscapeString(attribution))
+	b.WriteString("<br>\n<blockquote style=\"margin:0 0 0 .8ex;border-left:1px solid #ccc;padding-left:1ex\">\n")
+	b.WriteString(bodyToHTML(orig.Body))
+	b.WriteString("\n</blockquote></div>")
+	return b.String()
+}
+
+// forwardBody builds the forwarded-message block, preceded by an optional note.
+func forwardBody(note string, orig *domain.Message) string {
+	var b strings.Builder
+	if strings.TrimSpace(note) != "" {
+		b.WriteString(bodyToHTML(note))
+		b.WriteString("<br><br>\n")
+	}
+	b.WriteString("<div class=\"nylas_forward\">---------- Forwarded message ---------<br>\n")
+	writeHeader := func(name, value string) {
+		if value != "" {
+			b.WriteString(name + ": " + html.EscapeString(value) + "<br>\n")
+		}
+	}
+	writeHeader("From", participantList(orig.From))
+	if !orig.Date.IsZero() {
+		writeHeader("Date", orig.Date.Format(quoteDateFormat))
+	}
+	writeHeader("Subject", orig.Subject)
+	writeHeader("To", participantList(orig.To))
+	writeHeader("Cc", participantList(orig.Cc))
+	b.WriteString("<br>\n")
+	b.WriteString(bodyToHTML(orig.Body))
+	b.WriteString("\n</div>")
+	return b.String()
+}
+
+// forwardSubject prefixes the original subject with "Fwd: " unless it
+// already carries a forward prefix.
+func forwardSubject(original string) string {
+	trimmed := strings.TrimSpace(original)
+	lower := strings.ToLower(trimmed)
+	if strings.HasPrefix(lower, "fwd:") || strings.HasPrefix(lower, "fw:") {
+		return original
+	}
+	if trimmed == "" {
+		return "Fwd:"
+	}
+	return "Fwd: " + original
+}
@@ -0,0 +1,78 @@
+package email
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+func newRawCmd() *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "raw <message-id> [grant-id]",
+		Short: "Download a message's raw RFC 822 source",
+		Long: `Download the original RFC 822 (MIME) source of a message, exactly as the
+provider returns it, with all headers, parts and signatures intact.
+
+Use it to inspect headers and MIME structure, keep evidence for forensics, or
+import the message into another mail client. Without --output the source is
+written to stdout. When --output is a directory, the file is named
+<message-id>.eml. Files are created readable only by you.`,
+		Example: `  # Save a message for another mail client
+  nylas email raw <message-id> --output msg.eml
+
+  # Inspect the headers
+  nylas email raw <message-id> | sed '/^\r\?$/q'`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			messageID := args[0]
+			_, err := common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				msg, err := client.GetMessageWithFields(ctx, grantID, messageID, "raw_mime")
+				if err != nil {
+					return struct{}{}, common.WrapGetError("message", err)
+				}
+				if msg.RawMIME == "" {
+					return struct{}{}, common.NewUserError("no raw MIME source available for message "+messageID,
+						"Use 'nylas email read "+messageID+" --headers' to view its headers")
+				}
+
+				if outputPath == "" {
+					_, err := io.WriteString(cmd.OutOrStdout(), msg.RawMIME)
+					return struct{}{}, err
+				}
+				path, err := writeRawMessage(msg, outputPath)
+				if err != nil {
+					return struct{}{}, err
+				}
+				common.PrintSuccess("Saved %s (%s) to %s", messageID, common.FormatSize(int64(len(msg.RawMIME))), path)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "File or directory to save the .eml to (default: stdout)")
+
+	return cmd
+}
+
+// writeRawMessage saves the message source to path, or to <id>.eml inside
+// path when it is a directory, and returns the file written.
+func writeRawMessage(msg *domain.Message, path string) (string, error) {
+	path = filepath.Clean(path)
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, filepath.Base(msg.ID)+".eml")
+	}
+	if err := os.WriteFile(path, []byte(msg.RawMIME), 0o600); err != nil {
+		return "", common.WrapWriteError("message file", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,54 @@
+package email
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+const testRawMIME = "From: a@example.com\r\nSubject: Hi\r\n\r\nBody\r\n"
+
+func TestWriteRawMessage(t *testing.T) {
+	msg := &domain.Message{ID: "msg-1", RawMIME: testRawMIME}
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "saved.eml")
+		got, err := writeRawMessage(msg, path)
+		require.NoError(t, err)
+		assert.Equal(t, path, got)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, testRawMIME, string(data), "source must be saved byte for byte")
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		got, err := writeRawMessage(msg, dir)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "msg-1.eml"), got)
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := writeRawMessage(msg, filepath.Join(t.TempDir(), "nope", "msg.eml"))
+		assert.Error(t, err)
+	})
+}
+
+func TestRawCmd(t *testing.T) {
+	cmd := newRawCmd()
+	assert.Equal(t, "raw <message-id> [grant-id]", cmd.Use)
+	flag := cmd.Flags().Lookup("output")
+	require.NotNil(t, flag)
+	assert.Equal(t, "o", flag.Shorthand)
+	assert.Error(t, cmd.Args(cmd, nil))
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/nylas/cli/internal/adapters/ai"
+	"github.com/nylas/cli/internal/adapters/replyparser"
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
@@ -18,6 +20,9 @@ func newReadCmd() *cobra.Command {
 	var headersOutput bool
 	var verifySignature bool
 	var decryptMessage bool
+	var stripQuotes bool
+	var translateTo string
+	var detectLanguage bool
 
 	cmd := &cobra.Command{
 		Use:     "read <message-id> [grant-id]",
@@ -27,12 +32,45 @@ func newReadCmd() *cobra.Command {
 
 Supports GPG/PGP encrypted and signed messages:
 - --decrypt: Decrypt PGP/MIME encrypted emails
-- --verify: Verify GPG/PGP signature of signed emails`,
+- --verify: Verify GPG/PGP signature of signed emails (S/MIME signatures are
+  detected and verified with openssl)
+
+Use --strip-quotes to show only the new content of a reply, without the
+quoted history and signature. Parsing is done locally and handles plain-text
+and HTML replies from Gmail, Outlook, Apple Mail, Thunderbird and Yahoo.
+
+Use --translate <lang> to translate the subject and body, showing the detected
+source language in the headers. The backend is set with
+'nylas ai config set translation.backend' (llm or libretranslate); the llm
+backend uses the configured AI provider, e.g. a local Ollama model.
+--detect-language only shows the language, detected locally.`,
+		Example: `  nylas email read <message-id> --translate en
+  nylas email read <message-id> --translate german --strip-quotes
+  nylas email read <message-id> --detect-language`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			messageID := args[0]
 			remainingArgs := args[1:]
 
+			var (
+				targetLanguage string
+				translator     ports.Translator
+			)
+			if translateTo != "" {
+				for _, flag := range []string{"mime", "headers", "decrypt", "verify"} {
+					if cmd.Flags().Changed(flag) {
+						return common.NewMutuallyExclusiveError("translate", flag)
+					}
+				}
+				var err error
+				if targetLanguage, err = parseTargetLanguage(translateTo); err != nil {
+					return err
+				}
+				if translator, err = loadTranslator(cmd); err != nil {
+					return err
+				}
+			}
+
 			_, err := common.WithClient(remainingArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				// Determine which fields to request
 				var fields string
@@ -56,10 +94,35 @@ Supports GPG/PGP encrypted and signed messages:
 					return struct{}{}, common.WrapGetError("message", err)
 				}
 
+				if stripQuotes {
+					msg.Body = replyparser.StripQuotes(msg.Body)
+				}
+
+				var (
+					language    string
+					translation *domain.MessageTranslation
+				)
+				switch {
+				case translator != nil:
+					translation, err = common.RunWithSpinnerResult("Translating message...", func() (*domain.MessageTranslation, error) {
+						return translateMessage(ctx, translator, msg, targetLanguage)
+					})
+					if err != nil {
+						return struct{}{}, err
+					}
+					language = translation.SourceLanguage
+				case detectLanguage:
+					language = ai.DetectLanguage(msg.Subject + "\n" + messageText(msg))
+				}
+
 				// Handle JSON output
 				jsonOutput, _ := cmd.Flags().GetBool("json")
 				if jsonOutput {
-					data, err := json.MarshalIndent(msg, "", "  ")
+					var out any = msg
+					if translation != nil || detectLanguage {
+						out = translatedMessageJSON{Message: msg, Language: language, Translation: translation}
+					}
+					data, err := json.MarshalIndent(out, "", "  ")
 					if err != nil {
 						return struct{}{}, common.WrapMarshalError("JSON", err)
 					}
@@ -93,6 +156,12 @@ Supports GPG/PGP encrypted and signed messages:
 						printMessage(*fullMsg, true)
 					}
 					// Verify signature using raw MIME
+					if isSMIMESigned(msg.RawMIME) {
+						if err := verifySMIMESignature(ctx, msg); err != nil {
+							return struct{}{}, fmt.Errorf("S/MIME verification failed: %w", err)
+						}
+						return struct{}{}, nil
+					}
 					if err := verifyGPGSignature(ctx, msg); err != nil {
 						return struct{}{}, fmt.Errorf("GPG verification failed: %w", err)
 					}
@@ -108,9 +177,15 @@ Supports GPG/PGP encrypted and signed messages:
 				case headersOutput:
 					printMessageHeaders(*msg)
 				case rawOutput:
+					applyTranslation(msg, translation)
 					printMessageRaw(*msg)
 				default:
-					printMessage(*msg, true)
+					header := ""
+					if translation != nil || detectLanguage {
+						header = languageHeader(language, translation)
+					}
+					applyTranslation(msg, translation)
+					printMessageWithLanguage(*msg, true, header)
 				}
 
 				// Mark as read if requested
@@ -136,8 +211,11 @@ Supports GPG/PGP encrypted and signed messages:
 	cmd.Flags().BoolVar(&rawOutput, "raw", false, "Show raw email body without HTML processing")
 	cmd.Flags().BoolVar(&mimeOutput, "mime", false, "Show raw RFC822/MIME message format")
 	cmd.Flags().BoolVar(&headersOutput, "headers", false, "Show email headers (works with all providers)")
-	cmd.Flags().BoolVar(&verifySignature, "verify", false, "Verify GPG/PGP signature of the message")
+	cmd.Flags().BoolVar(&verifySignature, "verify", false, "Verify the GPG/PGP or S/MIME signature of the message")
 	cmd.Flags().BoolVar(&decryptMessage, "decrypt", false, "Decrypt PGP/MIME encrypted message")
+	cmd.Flags().BoolVar(&stripQuotes, "strip-quotes", false, "Show only the new content, without quoted replies and signature")
+	cmd.Flags().StringVar(&translateTo, "translate", "", "Translate the message into a language (ISO 639-1 code or name, e.g. en)")
+	cmd.Flags().BoolVar(&detectLanguage, "detect-language", false, "Show the detected language of the message")
 
 	return cmd
 }
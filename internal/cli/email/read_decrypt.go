@@ -43,8 +43,8 @@ func decryptGPGEmail(ctx context.Context, msg *domain.Message) (*gpg.DecryptResu
 	}
 
 	// Initialize GPG service
-	gpgSvc := gpg.NewService()
-	if err := gpgSvc.CheckGPGAvailable(ctx); err != nil {
+	gpgSvc, err := newGPGBackend(ctx)
+	if err != nil {
 		return nil, err
 	}
 
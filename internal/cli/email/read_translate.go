@@ -0,0 +1,140 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nylas/cli/internal/adapters/ai"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+// languageCodePattern accepts ISO 639 codes the backends may know even if
+// they aren't in our name table.
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// parseTargetLanguage validates the --translate value.
+func parseTargetLanguage(value string) (string, error) {
+	if code, ok := ai.NormalizeLanguage(value); ok {
+		return code, nil
+	}
+	if code := strings.ToLower(strings.TrimSpace(value)); languageCodePattern.MatchString(code) {
+		return code, nil
+	}
+	return "", common.NewUserError(
+		fmt.Sprintf("unknown language: %s", value),
+		"Use an ISO 639-1 code or English name, e.g. --translate en or --translate german",
+	)
+}
+
+// loadTranslator builds the configured translation backend. Demo mode has
+// none: the sample messages would go to a real model or server.
+func loadTranslator(cmd *cobra.Command) (ports.Translator, error) {
+	if common.IsDemoMode() {
+		return nil, common.NewUserError("--translate is not available in demo mode",
+			"Connect your account with: nylas auth login")
+	}
+	cfg, err := common.GetConfigStore(cmd).Load()
+	if err != nil {
+		return nil, common.WrapLoadError("config", err)
+	}
+	translator, err := ai.NewTranslator(cfg.AI)
+	if err != nil {
+		return nil, common.NewUserErrorWithSuggestions(
+			fmt.Sprintf("translation is not available: %v", err),
+			"Use a local model: nylas ai config set default_provider ollama",
+			"Or a LibreTranslate server: nylas ai config set translation.backend libretranslate",
+		)
+	}
+	return translator, nil
+}
+
+// messageText returns the message body as plain text.
+func messageText(msg *domain.Message) string {
+	body := msg.Body
+	if body == "" {
+		body = msg.Snippet
+	}
+	return common.StripHTML(body)
+}
+
+// translateMessage translates the subject and body of msg into target. A
+// message already in the target language is not sent to the backend.
+func translateMessage(ctx context.Context, translator ports.Translator, msg *domain.Message, target string) (*domain.MessageTranslation, error) {
+	body := messageText(msg)
+	tr := &domain.MessageTranslation{
+		SourceLanguage: ai.DetectLanguage(msg.Subject + "\n" + body),
+		TargetLanguage: target,
+		Backend:        translator.Name(),
+	}
+	if tr.SourceLanguage == target {
+		tr.Skipped = true
+		return tr, nil
+	}
+
+	if strings.TrimSpace(body) != "" {
+		result, err := translator.Translate(ctx, &domain.TranslationRequest{Text: body, TargetLanguage: target})
+		if err != nil {
+			return nil, err
+		}
+		tr.Body = result.Text
+		if result.SourceLanguage != "" {
+			tr.SourceLanguage = result.SourceLanguage
+		}
+	}
+
+	if strings.TrimSpace(msg.Subject) != "" {
+		result, err := translator.Translate(ctx, &domain.TranslationRequest{
+			Text:           msg.Subject,
+			TargetLanguage: target,
+			SourceLanguage: tr.SourceLanguage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tr.Subject = result.Text
+		if tr.SourceLanguage == "" {
+			tr.SourceLanguage = result.SourceLanguage
+		}
+	}
+
+	return tr, nil
+}
+
+// languageHeader renders the Language header: the detected language, or the
+// translation direction when tr is set.
+func languageHeader(detected string, tr *domain.MessageTranslation) string {
+	if tr == nil {
+		return ai.FormatLanguage(detected)
+	}
+	if tr.Skipped {
+		return ai.FormatLanguage(tr.SourceLanguage) + " (already in target language)"
+	}
+	return fmt.Sprintf("%s → %s (translated via %s)", ai.FormatLanguage(tr.SourceLanguage), ai.FormatLanguage(tr.TargetLanguage), tr.Backend)
+}
+
+// applyTranslation replaces the subject and body of msg with their
+// translations.
+func applyTranslation(msg *domain.Message, tr *domain.MessageTranslation) {
+	if tr == nil || tr.Skipped {
+		return
+	}
+	if tr.Subject != "" {
+		msg.Subject = tr.Subject
+	}
+	if tr.Body != "" {
+		msg.Body = tr.Body
+	}
+}
+
+// translatedMessageJSON is the --json output of a translated or
+// language-tagged message: the original message plus language details.
+type translatedMessageJSON struct {
+	*domain.Message
+	Language    string                     `json:"language,omitempty"`
+	Translation *domain.MessageTranslation `json:"translation,omitempty"`
+}
@@ -0,0 +1,137 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// fakeTranslator prefixes text with the target language and reports German.
+type fakeTranslator struct {
+	calls []domain.TranslationRequest
+}
+
+func (f *fakeTranslator) Name() string { return "fake" }
+
+func (f *fakeTranslator) Translate(_ context.Context, req *domain.TranslationRequest) (*domain.TranslationResult, error) {
+	f.calls = append(f.calls, *req)
+	return &domain.TranslationResult{
+		Text:           "[" + req.TargetLanguage + "] " + req.Text,
+		SourceLanguage: "de",
+		TargetLanguage: req.TargetLanguage,
+		Backend:        f.Name(),
+	}, nil
+}
+
+func TestParseTargetLanguage(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"en", "en", false},
+		{"English", "en", false},
+		{"pt-BR", "pt", false},
+		{"haw", "haw", false},
+		{"not a language", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseTargetLanguage(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTranslateMessage(t *testing.T) {
+	t.Run("translates subject and body", func(t *testing.T) {
+		translator := &fakeTranslator{}
+		msg := &domain.Message{Subject: "Besprechung", Body: "<p>Wir sehen uns morgen.</p>"}
+
+		tr, err := translateMessage(context.Background(), translator, msg, "en")
+		require.NoError(t, err)
+		assert.Equal(t, "de", tr.SourceLanguage)
+		assert.Equal(t, "en", tr.TargetLanguage)
+		assert.Equal(t, "[en] Besprechung", tr.Subject)
+		assert.Equal(t, "[en] Wir sehen uns morgen.", tr.Body)
+		assert.False(t, tr.Skipped)
+
+		require.Len(t, translator.calls, 2)
+		assert.Equal(t, "Wir sehen uns morgen.", translator.calls[0].Text, "body is sent as plain text")
+		assert.Equal(t, "de", translator.calls[1].SourceLanguage, "subject reuses the body's language")
+
+		applyTranslation(msg, tr)
+		assert.Equal(t, "[en] Besprechung", msg.Subject)
+		assert.Equal(t, "[en] Wir sehen uns morgen.", msg.Body)
+	})
+
+	t.Run("skips messages already in the target language", func(t *testing.T) {
+		translator := &fakeTranslator{}
+		msg := &domain.Message{Subject: "Update", Body: "Thanks for the notes, we will have the draft ready for you this week."}
+
+		tr, err := translateMessage(context.Background(), translator, msg, "en")
+		require.NoError(t, err)
+		assert.True(t, tr.Skipped)
+		assert.Empty(t, translator.calls)
+
+		applyTranslation(msg, tr)
+		assert.Equal(t, "Update", msg.Subject)
+	})
+}
+
+func TestLoadTranslator_RefusedInDemoMode(t *testing.T) {
+	common.EnableDemoMode(nylas.NewMockClient(), "demo-grant")
+	t.Cleanup(func() { common.EnableDemoMode(nil, "") })
+
+	_, err := loadTranslator(newReadCmd())
+	assert.ErrorContains(t, err, "demo mode")
+}
+
+func TestLanguageHeader(t *testing.T) {
+	assert.Equal(t, "German (de)", languageHeader("de", nil))
+	assert.Equal(t, "unknown", languageHeader("", nil))
+	assert.Equal(t, "German (de) → English (en) (translated via fake)",
+		languageHeader("", &domain.MessageTranslation{SourceLanguage: "de", TargetLanguage: "en", Backend: "fake"}))
+	assert.Equal(t, "English (en) (already in target language)",
+		languageHeader("", &domain.MessageTranslation{SourceLanguage: "en", TargetLanguage: "en", Skipped: true}))
+}
+
+func TestTranslatedMessageJSON(t *testing.T) {
+	out := translatedMessageJSON{
+		Message:     &domain.Message{ID: "msg-1", Subject: "Hallo"},
+		Language:    "de",
+		Translation: &domain.MessageTranslation{SourceLanguage: "de", TargetLanguage: "en", Subject: "Hello"},
+	}
+	data, err := json.Marshal(out)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "msg-1", decoded["id"])
+	assert.Equal(t, "Hallo", decoded["subject"], "original subject is kept")
+	assert.Equal(t, "de", decoded["language"])
+	assert.Equal(t, "Hello", decoded["translation"].(map[string]any)["subject"])
+}
+
+func TestReadCommand_TranslateFlags(t *testing.T) {
+	cmd := newReadCmd()
+	assert.NotNil(t, cmd.Flags().Lookup("translate"))
+	assert.NotNil(t, cmd.Flags().Lookup("detect-language"))
+
+	cmd.SetArgs([]string{"msg-1", "--translate", "en", "--mime"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "--translate and --mime")
+}
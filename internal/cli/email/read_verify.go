@@ -35,8 +35,8 @@ func verifyGPGSignature(ctx context.Context, msg *domain.Message) error {
 	}
 
 	// Initialize GPG service
-	gpgSvc := gpg.NewService()
-	if err := gpgSvc.CheckGPGAvailable(ctx); err != nil {
+	gpgSvc, err := newGPGBackend(ctx)
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,178 @@
+package email
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+
+	"github.com/nylas/cli/internal/adapters/smime"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// isSMIMESigned reports whether a message is S/MIME signed, either as
+// multipart/signed with a PKCS#7 signature or as opaque signed-data.
+func isSMIMESigned(rawMIME string) bool {
+	mediaType, params, err := mime.ParseMediaType(extractFullContentType(rawMIME))
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "multipart/signed":
+		protocol := strings.ToLower(params["protocol"])
+		return protocol == "application/pkcs7-signature" || protocol == "application/x-pkcs7-signature"
+	case "application/pkcs7-mime", "application/x-pkcs7-mime":
+		return strings.EqualFold(params["smime-type"], "signed-data")
+	}
+	return false
+}
+
+// verifySMIMESignature verifies the S/MIME signature of a message.
+func verifySMIMESignature(ctx context.Context, msg *domain.Message) error {
+	if msg.RawMIME == "" {
+		return fmt.Errorf("no raw MIME data available for verification")
+	}
+	content, signature, err := parseSMIMESigned(msg.RawMIME)
+	if err != nil {
+		return fmt.Errorf("failed to parse S/MIME message: %w", err)
+	}
+
+	svc := newSMIMEService()
+	if err := svc.CheckOpenSSLAvailable(ctx); err != nil {
+		return err
+	}
+	result, err := common.RunWithSpinnerResult("Verifying S/MIME signature...", func() (*smime.VerifyResult, error) {
+		return svc.Verify(ctx, content, signature)
+	})
+	if err != nil {
+		return err
+	}
+
+	printSMIMEVerifyResult(result, messageSender(msg.RawMIME))
+	return nil
+}
+
+// parseSMIMESigned returns the signed content and DER signature of an S/MIME
+// message. For opaque signed-data the content is nil: it is inside the
+// signature.
+func parseSMIMESigned(rawMIME string) (content, signature []byte, err error) {
+	mediaType, params, err := mime.ParseMediaType(extractFullContentType(rawMIME))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Content-Type: %w", err)
+	}
+	headerEnd := findHeaderEnd(rawMIME)
+	if headerEnd == -1 {
+		return nil, nil, fmt.Errorf("could not find end of headers")
+	}
+
+	if mediaType != "multipart/signed" {
+		signature, err = decodeBase64Body(rawMIME[headerEnd:])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode signed-data: %w", err)
+		}
+		return nil, signature, nil
+	}
+
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, nil, fmt.Errorf("no boundary found in Content-Type")
+	}
+	content, err = extractSignedContent(rawMIME, boundary)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to extract signed content: %w", err)
+	}
+
+	mr := multipart.NewReader(strings.NewReader(rawMIME[headerEnd:]), boundary)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read MIME part: %w", err)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if partType != "application/pkcs7-signature" && partType != "application/x-pkcs7-signature" {
+			continue
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read signature part: %w", err)
+		}
+		if signature, err = decodeBase64Body(string(data)); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode signature: %w", err)
+		}
+		return content, signature, nil
+	}
+	return nil, nil, fmt.Errorf("could not find signature part")
+}
+
+// decodeBase64Body decodes a base64 body, ignoring line breaks.
+func decodeBase64Body(body string) ([]byte, error) {
+	body = strings.NewReplacer("\r", "", "\n", "", " ", "", "\t", "").Replace(body)
+	return base64.StdEncoding.DecodeString(body)
+}
+
+// messageSender returns the From address of a raw message, if any.
+func messageSender(rawMIME string) string {
+	msg, err := mail.ReadMessage(strings.NewReader(rawMIME))
+	if err != nil {
+		return ""
+	}
+	addr, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return ""
+	}
+	return addr.Address
+}
+
+// printSMIMEVerifyResult displays the S/MIME verification result and warns
+// when the certificate wasn't issued to the sender.
+func printSMIMEVerifyResult(result *smime.VerifyResult, sender string) {
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 60))
+
+	if result.Valid {
+		_, _ = common.Green.Println("✓ Good S/MIME signature")
+	} else {
+		_, _ = common.Red.Println("✗ BAD S/MIME signature")
+	}
+
+	fmt.Println(strings.Repeat("─", 60))
+
+	if result.SignerSubject != "" {
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Signer:"), result.SignerSubject)
+	}
+	if result.SignerEmail != "" {
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Email:"), result.SignerEmail)
+	}
+	if result.Issuer != "" {
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Issuer:"), result.Issuer)
+	}
+	if result.Fingerprint != "" {
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Fingerprint:"), result.Fingerprint)
+	}
+	if !result.NotAfter.IsZero() {
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Expires:"), result.NotAfter.Format("2006-01-02"))
+	}
+	if result.Valid {
+		if result.Trusted {
+			fmt.Printf("  %s %s\n", common.Cyan.Sprint("Trust:"), common.Green.Sprint("trusted (chains to a system CA)"))
+		} else {
+			fmt.Printf("  %s %s\n", common.Cyan.Sprint("Trust:"), common.Yellow.Sprint("untrusted"))
+			if result.TrustError != "" {
+				fmt.Printf("         %s\n", result.TrustError)
+			}
+		}
+		if sender != "" && result.SignerEmail != "" && !strings.EqualFold(sender, result.SignerEmail) {
+			common.PrintWarning("Certificate is issued to %s, but the message is from %s", result.SignerEmail, sender)
+		}
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,81 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// maxRecipientChoices caps the matches offered for a name typed in
+// interactive mode.
+const maxRecipientChoices = 10
+
+// openRecipientIndex opens the local recipient index. Replaced in tests.
+var openRecipientIndex = common.OpenRecipientIndex
+
+// recipientFlags are the flags completed from the recipient index.
+var recipientFlags = []string{"to", "cc", "bcc"}
+
+// registerRecipientCompletions completes --to, --cc and --bcc from the
+// recipient index on cmd and every command under it.
+func registerRecipientCompletions(cmd *cobra.Command) {
+	for _, name := range recipientFlags {
+		if cmd.Flags().Lookup(name) != nil {
+			_ = cmd.RegisterFlagCompletionFunc(name, common.CompleteRecipients)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		registerRecipientCompletions(sub)
+	}
+}
+
+// expandRecipients looks up entries typed in interactive mode that aren't
+// addresses, such as "ana" or "Silva", in the recipient index. A single
+// match is used as is; several are offered to pick from. Entries without a
+// match are kept, so validation reports them.
+func expandRecipients(args []string, entries []string) []string {
+	grantID, err := common.GetGrantID(args)
+	if err != nil || grantID == "" {
+		return entries
+	}
+	index, err := openRecipientIndex()
+	if err != nil {
+		return entries
+	}
+
+	out := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if strings.Contains(entry, "@") {
+			out = append(out, entry)
+			continue
+		}
+		matches, err := index.Search(grantID, entry, maxRecipientChoices)
+		if err != nil || len(matches) == 0 {
+			out = append(out, entry)
+			continue
+		}
+		out = append(out, pickRecipient(entry, matches))
+	}
+	return out
+}
+
+// pickRecipient returns the address for entry among its index matches.
+func pickRecipient(entry string, matches []domain.Recipient) string {
+	if len(matches) == 1 {
+		fmt.Printf("  %s → %s\n", entry, matches[0].String())
+		return matches[0].Email
+	}
+	options := make([]common.SelectOption[string], len(matches))
+	for i, r := range matches {
+		options[i] = common.SelectOption[string]{Label: r.String(), Value: r.Email}
+	}
+	email, err := common.Select(fmt.Sprintf("Which %q?", entry), options)
+	if err != nil || email == "" {
+		return entry
+	}
+	return email
+}
@@ -0,0 +1,45 @@
+package email
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/recipients"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestExpandRecipients(t *testing.T) {
+	index := recipients.New(filepath.Join(t.TempDir(), "recipients.json"))
+	require.NoError(t, index.Replace("grant-1", []domain.Recipient{
+		{Email: "ana@example.com", Name: "Ana Silva", Count: 3},
+		{Email: "anna@example.com", Name: "Anna Berg", Count: 1},
+		{Email: "bob@example.com", Name: "Bob Stone"},
+	}))
+	original := openRecipientIndex
+	t.Cleanup(func() { openRecipientIndex = original })
+	openRecipientIndex = func() (*recipients.Store, error) { return index, nil }
+
+	got := expandRecipients([]string{"grant-1"}, []string{"stone", "carol@example.com", "an", "zed"})
+
+	// Without a terminal the best of several matches is picked.
+	assert.Equal(t, []string{"bob@example.com", "carol@example.com", "ana@example.com", "zed"}, got)
+}
+
+func TestRegisterRecipientCompletions(t *testing.T) {
+	root := &cobra.Command{Use: "email"}
+	send := &cobra.Command{Use: "send"}
+	send.Flags().StringSlice("to", nil, "")
+	send.Flags().StringSlice("bcc", nil, "")
+	root.AddCommand(send)
+
+	registerRecipientCompletions(root)
+
+	for _, name := range []string{"to", "bcc"} {
+		_, ok := send.GetFlagCompletionFunc(name)
+		assert.True(t, ok, name)
+	}
+}
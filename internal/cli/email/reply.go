@@ -19,6 +19,10 @@ func newReplyCmd() *cobra.Command {
 	var all bool
 	var interactive bool
 	var noConfirm bool
+	var noQuote bool
+	var aiReply bool
+	var aiPrompt string
+	var noEdit bool
 
 	cmd := &cobra.Command{
 		Use:   "reply <message-id> [grant-id]",
@@ -29,8 +33,15 @@ The original message is fetched to populate the recipient and subject
 automatically. By default the reply goes only to the original sender; use
 --all to also include the other To/Cc recipients (excluding yourself).
 
+The original message is quoted below the reply text with an "On <date>,
+<sender> wrote:" attribution; use --no-quote to send only your text.
+
 Threading is preserved via the message's reply_to_message_id, so the reply
-groups with the original conversation in mail clients.`,
+groups with the original conversation in mail clients.
+
+--ai writes the reply with Nylas Smart Compose, following --prompt, and opens
+it in your editor ($VISUAL or $EDITOR) for review before the usual
+confirmation. Smart Compose requires a Nylas Plus package subscription.`,
 		Example: `  # Reply to the sender
   nylas email reply <message-id> --body "Sounds good, thanks!"
 
@@ -40,6 +51,9 @@ groups with the original conversation in mail clients.`,
   # Compose the body interactively
   nylas email reply <message-id> --interactive
 
+  # Let AI write the reply, then edit it before sending
+  nylas email reply <message-id> --ai --prompt "decline politely"
+
   # Reply using a specific grant
   nylas email reply <message-id> <grant-id> --body "On it."`,
 		Args: cobra.RangeArgs(1, 2),
@@ -48,10 +62,16 @@ groups with the original conversation in mail clients.`,
 			remainingArgs := args[1:]
 			jsonOutput := common.IsJSON(cmd)
 
+			if aiReply && (body != "" || interactive) {
+				return common.NewUserError("--ai can't be combined with --body or --interactive", "The AI-written reply opens in your editor for changes")
+			}
+			if !aiReply && aiPrompt != "" {
+				return common.NewUserError("--prompt needs --ai", "Use --ai --prompt \"...\" to have AI write the reply")
+			}
 			if interactive && body == "" {
 				body = promptReplyBody()
 			}
-			if strings.TrimSpace(body) == "" {
+			if !aiReply && strings.TrimSpace(body) == "" {
 				return common.NewUserError("reply body is required", "Use --body to provide the reply text, or --interactive to compose it")
 			}
 
@@ -61,12 +81,22 @@ groups with the original conversation in mail clients.`,
 					return struct{}{}, err
 				}
 
-				req, err := buildReplyRequest(ctx, client, grantID, grant, messageID, body, all)
+				if aiReply {
+					if body, err = generateAIReply(ctx, client, grantID, messageID, aiPrompt, noEdit); err != nil {
+						return struct{}{}, err
+					}
+					if body == "" {
+						fmt.Println("Cancelled: the reply body is empty.")
+						return struct{}{}, nil
+					}
+				}
+
+				req, err := buildReplyRequest(ctx, client, grantID, grant, messageID, body, all, !noQuote)
 				if err != nil {
 					return struct{}{}, err
 				}
 
-				printReplyPreview(req)
+				printSendPreview("Reply preview:", req)
 
 				if !noConfirm {
 					if !common.Confirm("\nSend this reply?", false) {
@@ -97,19 +127,23 @@ groups with the original conversation in mail clients.`,
 	cmd.Flags().BoolVar(&all, "all", false, "Reply to all recipients (original To and Cc, excluding yourself)")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Compose the reply body interactively")
 	cmd.Flags().BoolVarP(&noConfirm, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&noQuote, "no-quote", false, "Don't quote the original message below the reply")
+	cmd.Flags().BoolVar(&aiReply, "ai", false, "Write the reply with Smart Compose and edit it before sending")
+	cmd.Flags().StringVar(&aiPrompt, "prompt", "", "What the AI reply should say (with --ai)")
+	cmd.Flags().BoolVar(&noEdit, "no-edit", false, "With --ai, don't open the editor; use the AI text as written")
 
 	return cmd
 }
 
 // buildReplyRequest fetches the original message and assembles a send request
-// that threads as a reply to it.
+// that threads as a reply to it, quoting the original when quote is set.
 func buildReplyRequest(
 	ctx context.Context,
 	client ports.NylasClient,
 	grantID string,
 	grant *domain.Grant,
 	messageID, body string,
-	all bool,
+	all, quote bool,
 ) (*domain.SendMessageRequest, error) {
 	orig, err := client.GetMessage(ctx, grantID, messageID)
 	if err != nil {
@@ -126,6 +160,10 @@ func buildReplyRequest(
 		return nil, err
 	}
 
+	if quote {
+		body = quoteReplyBody(body, orig)
+	}
+
 	return &domain.SendMessageRequest{
 		Subject:      replySubject(orig.Subject),
 		Body:         body,
@@ -255,8 +293,8 @@ func readBodyLines(reader *bufio.Reader) string {
 	return strings.Join(lines, "\n")
 }
 
-func printReplyPreview(req *domain.SendMessageRequest) {
-	fmt.Println("\nReply preview:")
+func printSendPreview(title string, req *domain.SendMessageRequest) {
+	fmt.Println("\n" + title)
 	if len(req.To) > 0 {
 		fmt.Printf("  To:      %s\n", participantList(req.To))
 	}
@@ -196,7 +196,7 @@ func TestReplyCmd_ThreadsViaReplyToMsgID(t *testing.T) {
 	}
 
 	grant := &domain.Grant{ID: "grant-1", Provider: domain.ProviderGoogle, Email: "me@example.com"}
-	req, err := buildReplyRequest(context.Background(), client, "grant-1", grant, "msg-original", "Sounds good", false)
+	req, err := buildReplyRequest(context.Background(), client, "grant-1", grant, "msg-original", "Sounds good", false, false)
 	require.NoError(t, err)
 	require.NotNil(t, req)
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
 	"github.com/spf13/cobra"
 )
@@ -19,9 +20,9 @@ func newScheduledCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/v3/email/scheduled-send/`,
 	}
 
-	cmd.AddCommand(newScheduledListCmd())
+	cmd.AddCommand(common.DeclareOutput(newScheduledListCmd(), []domain.ScheduledMessage{}))
 	cmd.AddCommand(newScheduledShowCmd())
-	cmd.AddCommand(newScheduledCancelCmd())
+	cmd.AddCommand(common.RequireScopes(newScheduledCancelCmd(), domain.ScopeEmailSend))
 
 	return cmd
 }
@@ -22,18 +22,82 @@ var withSearchClient = func(args []string, fn func(context.Context, messagesClie
 	})
 }
 
+// defaultSearchLimit is the number of results when --limit isn't given.
+const defaultSearchLimit = 20
+
+// searchFlags are the filters of email search. Saved searches keep the
+// same filters, and `search run` overrides them with the ones given.
+type searchFlags struct {
+	limit         int
+	native        bool
+	from          string
+	to            string
+	subject       string
+	after         string
+	before        string
+	inFolder      string
+	hasAttachment bool
+	unread        bool
+	starred       bool
+}
+
+func (f *searchFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&f.limit, "limit", "l", defaultSearchLimit, "Maximum number of results (auto-paginates if >200)")
+	f.registerFilters(cmd)
+}
+
+// registerFilters registers every search flag except --limit.
+func (f *searchFlags) registerFilters(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.native, "native", false, "Treat the query as provider search syntax (e.g. Gmail \"from:x has:attachment\")")
+	cmd.Flags().StringVar(&f.from, "from", "", "Filter by sender")
+	cmd.Flags().StringVar(&f.to, "to", "", "Filter by recipient")
+	cmd.Flags().StringVar(&f.subject, "subject", "", "Filter by subject")
+	cmd.Flags().StringVar(&f.after, "after", "", "Messages after date (YYYY-MM-DD) or duration ago (e.g. 7d)")
+	cmd.Flags().StringVar(&f.before, "before", "", "Messages before date (YYYY-MM-DD) or duration ago (e.g. 7d)")
+	cmd.Flags().BoolVar(&f.hasAttachment, "has-attachment", false, "Only messages with attachments")
+	cmd.Flags().BoolVar(&f.unread, "unread", false, "Only unread messages")
+	cmd.Flags().BoolVar(&f.starred, "starred", false, "Only starred messages")
+	cmd.Flags().StringVar(&f.inFolder, "in", "", "Filter by folder (e.g., INBOX, SENT)")
+}
+
+// apply copies the filters given on the command line into s.
+func (f *searchFlags) apply(cmd *cobra.Command, s *domain.SavedSearch) {
+	flags := cmd.Flags()
+	if flags.Changed("limit") {
+		s.Limit = f.limit
+	}
+	if flags.Changed("native") {
+		s.Native = f.native
+	}
+	for _, opt := range []struct {
+		name  string
+		field *string
+		value string
+	}{
+		{"from", &s.From, f.from}, {"to", &s.To, f.to}, {"subject", &s.Subject, f.subject},
+		{"in", &s.In, f.inFolder}, {"after", &s.After, f.after}, {"before", &s.Before, f.before},
+	} {
+		if flags.Changed(opt.name) {
+			*opt.field = opt.value
+		}
+	}
+	for _, opt := range []struct {
+		name  string
+		field **bool
+		value bool
+	}{
+		{"has-attachment", &s.HasAttachment, f.hasAttachment}, {"unread", &s.Unread, f.unread}, {"starred", &s.Starred, f.starred},
+	} {
+		if flags.Changed(opt.name) {
+			*opt.field = &opt.value
+		}
+	}
+}
+
 func newSearchCmd() *cobra.Command {
 	var (
-		limit         int
-		from          string
-		to            string
-		subject       string
-		after         string
-		before        string
-		hasAttachment bool
-		unread        bool
-		starred       bool
-		inFolder      string
+		flags       searchFlags
+		archiveOpts archiveSearchOptions
 	)
 
 	cmd := &cobra.Command{
@@ -57,92 +121,166 @@ Examples:
   # Search with date filters
   nylas email search "invoice" --after 2024-01-01 --before 2024-12-31
 
+  # Search the last week
+  nylas email search "*" --after 7d --has-attachment
+
   # Search for messages with attachments
-  nylas email search "*" --has-attachment --from "hr@company.com"`,
+  nylas email search "*" --has-attachment --from "hr@company.com"
+
+  # Use the provider's own search syntax (Gmail operators, Microsoft KQL)
+  nylas email search "from:billing has:attachment older_than:1y" --native
+
+  # Save a search and run it later
+  nylas email search save invoices "invoice" --from billing@example.com --after 30d
+  nylas email search run invoices
+
+  # Search exported mbox/.eml archives only (no API call)
+  nylas email search "contract" --archive-only --archive ~/Mail/2019.mbox
+
+Without --native the query matches the subject. With --native it is passed
+to the provider as is; some providers ignore other filters alongside it.
+
+Archives listed in email.archive_paths are searched alongside live mail.
+Their first search builds an index in the user cache directory; later
+searches reuse it until a file changes. In archives the query matches the
+subject, participants and body. Archives are skipped when --unread,
+--starred, --in or --native is given.`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			query := args[0]
-			remainingArgs := args[1:]
-
-			_, err := withSearchClient(remainingArgs, func(ctx context.Context, client messagesClient, grantID string) (struct{}, error) {
-				// maxItems >= 0 triggers auto-pagination; < 0 means single-page fetch
-				maxItems := -1
-				if limit > common.MaxAPILimit {
-					maxItems = limit
-				}
-
-				params := &domain.MessageQueryParams{
-					Limit: limit,
-				}
-
-				// Use query as subject search unless it's a wildcard
-				// If --subject flag is also provided, it takes precedence
-				if subject != "" {
-					params.Subject = subject
-				} else if query != "*" && query != "" {
-					params.Subject = query
-				}
-
-				if from != "" {
-					params.From = from
-				}
-				if to != "" {
-					params.To = to
-				}
-				if inFolder != "" {
-					params.In = []string{inFolder}
-				}
-				if cmd.Flags().Changed("has-attachment") {
-					params.HasAttachment = &hasAttachment
-				}
-				if cmd.Flags().Changed("unread") {
-					params.Unread = &unread
-				}
-				if cmd.Flags().Changed("starred") {
-					params.Starred = &starred
-				}
-
-				// Parse date filters
-				if after != "" {
-					t, err := parseDate(after)
-					if err != nil {
-						return struct{}{}, common.WrapDateParseError("after", err)
-					}
-					params.ReceivedAfter = t.Unix()
-				}
-				if before != "" {
-					t, err := parseDate(before)
-					if err != nil {
-						return struct{}{}, common.WrapDateParseError("before", err)
-					}
-					params.ReceivedBefore = t.Unix()
-				}
-
-				messages, err := fetchMessages(ctx, client, grantID, params, maxItems)
-				if err != nil {
-					return struct{}{}, common.WrapSearchError("messages", err)
-				}
-
-				return struct{}{}, writeSearchOutput(cmd, messages)
-			})
-			return err
+			search := &domain.SavedSearch{Query: args[0]}
+			flags.apply(cmd, search)
+			return runSearch(cmd, search, args[1:], &archiveOpts)
 		},
 	}
 
-	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Maximum number of results (auto-paginates if >200)")
-	cmd.Flags().StringVar(&from, "from", "", "Filter by sender")
-	cmd.Flags().StringVar(&to, "to", "", "Filter by recipient")
-	cmd.Flags().StringVar(&subject, "subject", "", "Filter by subject")
-	cmd.Flags().StringVar(&after, "after", "", "Messages after date (YYYY-MM-DD)")
-	cmd.Flags().StringVar(&before, "before", "", "Messages before date (YYYY-MM-DD)")
-	cmd.Flags().BoolVar(&hasAttachment, "has-attachment", false, "Only messages with attachments")
-	cmd.Flags().BoolVar(&unread, "unread", false, "Only unread messages")
-	cmd.Flags().BoolVar(&starred, "starred", false, "Only starred messages")
-	cmd.Flags().StringVar(&inFolder, "in", "", "Filter by folder (e.g., INBOX, SENT)")
+	flags.register(cmd)
+	archiveOpts.register(cmd)
+
+	cmd.AddCommand(newSearchSaveCmd())
+	cmd.AddCommand(common.DeclareOutput(newSearchRunCmd(), []domain.Message{}))
+	cmd.AddCommand(common.DeclareOutput(newSearchSavedCmd(), []domain.SavedSearch{}))
 
 	return cmd
 }
 
+// searchParams turns a search into API parameters. Relative dates are
+// measured back from now.
+func searchParams(s *domain.SavedSearch, now time.Time) (*domain.MessageQueryParams, error) {
+	params := &domain.MessageQueryParams{Limit: s.Limit}
+	if params.Limit <= 0 {
+		params.Limit = defaultSearchLimit
+	}
+
+	// The query matches the subject unless it's a wildcard or native.
+	// If --subject is also provided, it takes precedence.
+	switch {
+	case s.Native && s.Query != "*":
+		params.NativeQuery = s.Query
+	case s.Subject == "" && s.Query != "*":
+		params.Subject = s.Query
+	}
+	if s.Subject != "" {
+		params.Subject = s.Subject
+	}
+
+	params.From = s.From
+	params.To = s.To
+	if s.In != "" {
+		params.In = []string{s.In}
+	}
+	params.HasAttachment = s.HasAttachment
+	params.Unread = s.Unread
+	params.Starred = s.Starred
+
+	if s.After != "" {
+		t, err := parseSearchDate(s.After, now)
+		if err != nil {
+			return nil, common.WrapDateParseError("after", err)
+		}
+		params.ReceivedAfter = t.Unix()
+	}
+	if s.Before != "" {
+		t, err := parseSearchDate(s.Before, now)
+		if err != nil {
+			return nil, common.WrapDateParseError("before", err)
+		}
+		params.ReceivedBefore = t.Unix()
+	}
+	return params, nil
+}
+
+// parseSearchDate parses a date (YYYY-MM-DD) or a duration before now
+// ("7d", "2w", "12h").
+func parseSearchDate(s string, now time.Time) (time.Time, error) {
+	if t, err := parseDate(s); err == nil {
+		return t, nil
+	}
+	d, err := common.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("%q is neither a YYYY-MM-DD date nor a duration such as 7d", s)
+	}
+	return now.Add(-d), nil
+}
+
+// runSearch searches live mail and local archives.
+func runSearch(cmd *cobra.Command, search *domain.SavedSearch, grantArgs []string, archiveOpts *archiveSearchOptions) error {
+	params, err := searchParams(search, time.Now())
+	if err != nil {
+		return err
+	}
+	limit := params.Limit
+
+	archivePaths := archiveOpts.paths()
+	archiveQuery, archiveOK := archiveQueryFromParams(search.Query, params, limit)
+	archiveOK = archiveOK && !search.Native
+
+	if archiveOpts.only {
+		if len(archivePaths) == 0 {
+			return common.NewUserError("no archives to search",
+				"Set email.archive_paths with: nylas config set email.archive_paths ~/Mail/export.mbox or pass --archive")
+		}
+		if search.Native {
+			return common.NewUserError("--native can't be used with --archive-only",
+				"Provider search syntax only works against the server")
+		}
+		if !archiveOK {
+			return common.NewUserError("--unread, --starred and --in can't be used with --archive-only",
+				"Archived mail has no read state, stars or folders")
+		}
+		ctx, cancel := common.CreateLongContext()
+		defer cancel()
+		archived, err := searchArchives(ctx, archivePaths, archiveQuery)
+		if err != nil {
+			return common.WrapSearchError("archives", err)
+		}
+		return writeSearchOutput(cmd, archived)
+	}
+
+	_, err = withSearchClient(grantArgs, func(ctx context.Context, client messagesClient, grantID string) (struct{}, error) {
+		// maxItems >= 0 triggers auto-pagination; < 0 means single-page fetch
+		maxItems := -1
+		if limit > common.MaxAPILimit {
+			maxItems = limit
+		}
+
+		messages, err := fetchMessages(ctx, client, grantID, params, maxItems)
+		if err != nil {
+			return struct{}{}, common.WrapSearchError("messages", err)
+		}
+
+		if len(archivePaths) > 0 && archiveOK {
+			archived, err := searchArchives(ctx, archivePaths, archiveQuery)
+			if err != nil {
+				common.PrintWarningStderr("skipping archives: %v", err)
+			}
+			messages = append(messages, archived...)
+		}
+
+		return struct{}{}, writeSearchOutput(cmd, messages)
+	})
+	return err
+}
+
 // fetchMessages retrieves messages, using automatic pagination when maxItems >= 0.
 // Pass maxItems = 0 for unlimited pagination, >0 for a capped fetch, or <0 to
 // skip pagination and perform a single-page request via GetMessagesWithParams.
@@ -184,10 +322,28 @@ func writeSearchOutput(cmd *cobra.Command, messages []domain.Message) error {
 		return nil
 	}
 
+	var live, archived []domain.Message
+	for _, msg := range messages {
+		if domain.IsArchivedMessage(&msg) {
+			archived = append(archived, msg)
+		} else {
+			live = append(live, msg)
+		}
+	}
+
 	fmt.Printf("Found %d messages:\n\n", len(messages))
-	for i, msg := range messages {
+	for i, msg := range live {
 		printMessageSummary(msg, i+1)
 	}
+	if len(archived) > 0 {
+		if len(live) > 0 {
+			fmt.Println()
+		}
+		fmt.Println(common.Dim.Sprintf("From archives (%d):", len(archived)))
+		for i, msg := range archived {
+			printMessageSummary(msg, len(live)+i+1)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,92 @@
+package email
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/mailarchive"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// configuredArchivePaths returns email.archive_paths. Replaced in tests.
+var configuredArchivePaths = func() []string {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil || cfg.Email == nil {
+		return nil
+	}
+	return cfg.Email.ArchivePaths
+}
+
+// newArchiveIndex opens the archive index in the user cache directory.
+// Replaced in tests.
+var newArchiveIndex = func() (*mailarchive.Index, error) {
+	root, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return mailarchive.NewIndex(filepath.Join(root, "nylas", "archive-index")), nil
+}
+
+// archiveSearchOptions are the archive flags of email search.
+type archiveSearchOptions struct {
+	extra    []string
+	only     bool
+	disabled bool
+}
+
+func (o *archiveSearchOptions) register(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&o.extra, "archive", nil, "Also search this mbox/.eml file or directory (can be repeated)")
+	cmd.Flags().BoolVar(&o.only, "archive-only", false, "Search only local archives, not the server")
+	cmd.Flags().BoolVar(&o.disabled, "no-archive", false, "Skip local archives (email.archive_paths)")
+	cmd.MarkFlagsMutuallyExclusive("archive-only", "no-archive")
+}
+
+// paths returns the archives to search: email.archive_paths plus --archive.
+func (o *archiveSearchOptions) paths() []string {
+	if o.disabled {
+		return nil
+	}
+	return append(append([]string{}, configuredArchivePaths()...), o.extra...)
+}
+
+// searchArchives searches the local archives at paths.
+func searchArchives(ctx context.Context, paths []string, q domain.ArchiveQuery) ([]domain.Message, error) {
+	idx, err := newArchiveIndex()
+	if err != nil {
+		return nil, err
+	}
+	return common.RunWithSpinnerResult("Searching archives...", func() ([]domain.Message, error) {
+		return idx.Search(ctx, paths, q)
+	})
+}
+
+// archiveQueryFromParams builds an archive query equivalent to a live
+// search. ok is false when the filters can't match archived mail (read
+// state, stars and folders aren't kept in archives).
+func archiveQueryFromParams(query string, params *domain.MessageQueryParams, limit int) (q domain.ArchiveQuery, ok bool) {
+	if params.Unread != nil || params.Starred != nil || len(params.In) > 0 {
+		return q, false
+	}
+	q = domain.ArchiveQuery{
+		Text:          query,
+		From:          params.From,
+		To:            params.To,
+		HasAttachment: params.HasAttachment,
+		Limit:         limit,
+	}
+	if params.Subject != query {
+		q.Subject = params.Subject
+	}
+	if params.ReceivedAfter > 0 {
+		q.After = time.Unix(params.ReceivedAfter, 0)
+	}
+	if params.ReceivedBefore > 0 {
+		q.Before = time.Unix(params.ReceivedBefore, 0)
+	}
+	return q, true
+}
@@ -0,0 +1,136 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/mailarchive"
+	"github.com/nylas/cli/internal/cli/common"
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+)
+
+const searchTestMbox = `From alice@example.com Mon Jan  6 10:00:00 2020
+From: Alice <alice@example.com>
+To: bob@example.com
+Subject: Signed contract
+Date: Mon, 6 Jan 2020 10:00:00 +0000
+
+The contract is signed.
+
+From carol@example.com Tue Feb  4 09:30:00 2020
+From: Carol <carol@example.com>
+To: bob@example.com
+Subject: Lunch
+Date: Tue, 4 Feb 2020 09:30:00 +0000
+
+Pizza?
+`
+
+// useArchives configures a temporary archive and index for search.
+func useArchives(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "old.mbox")
+	require.NoError(t, os.WriteFile(path, []byte(searchTestMbox), 0o600))
+
+	origPaths, origIndex := configuredArchivePaths, newArchiveIndex
+	configuredArchivePaths = func() []string { return []string{path} }
+	newArchiveIndex = func() (*mailarchive.Index, error) {
+		return mailarchive.NewIndex(filepath.Join(dir, "index")), nil
+	}
+	t.Cleanup(func() { configuredArchivePaths, newArchiveIndex = origPaths, origIndex })
+	return path
+}
+
+func newArchiveSearchRoot() *cobra.Command {
+	root := &cobra.Command{Use: "test", SilenceErrors: true, SilenceUsage: true}
+	common.AddOutputFlags(root)
+	root.AddCommand(newSearchCmd())
+	return root
+}
+
+func TestArchiveQueryFromParams(t *testing.T) {
+	yes := true
+	params := &domain.MessageQueryParams{Subject: "contract", From: "alice", HasAttachment: &yes, ReceivedAfter: 1577836800}
+	q, ok := archiveQueryFromParams("contract", params, 10)
+	require.True(t, ok)
+	assert.Equal(t, "contract", q.Text)
+	assert.Empty(t, q.Subject, "query already covers the subject")
+	assert.Equal(t, "alice", q.From)
+	assert.Equal(t, int64(1577836800), q.After.Unix())
+	assert.True(t, q.Before.IsZero())
+	assert.Equal(t, 10, q.Limit)
+
+	q, ok = archiveQueryFromParams("*", &domain.MessageQueryParams{Subject: "lunch"}, 5)
+	require.True(t, ok)
+	assert.Equal(t, "lunch", q.Subject)
+
+	_, ok = archiveQueryFromParams("x", &domain.MessageQueryParams{In: []string{"INBOX"}}, 5)
+	assert.False(t, ok)
+	_, ok = archiveQueryFromParams("x", &domain.MessageQueryParams{Unread: &yes}, 5)
+	assert.False(t, ok)
+}
+
+func TestArchiveSearchOptions_Paths(t *testing.T) {
+	path := useArchives(t)
+	assert.Equal(t, []string{path, "extra.mbox"}, (&archiveSearchOptions{extra: []string{"extra.mbox"}}).paths())
+	assert.Nil(t, (&archiveSearchOptions{disabled: true}).paths())
+}
+
+func TestSearchCommand_ArchiveOnly(t *testing.T) {
+	useArchives(t)
+	original := withSearchClient
+	defer func() { withSearchClient = original }()
+	withSearchClient = func([]string, func(context.Context, messagesClient, string) (struct{}, error)) (struct{}, error) {
+		t.Fatal("--archive-only must not call the API")
+		return struct{}{}, nil
+	}
+
+	stdout, _, err := clitestutil.ExecuteCommand(newArchiveSearchRoot(), "search", "signed", "--archive-only", "--json")
+	require.NoError(t, err)
+	var messages []domain.Message
+	require.NoError(t, json.Unmarshal([]byte(stdout), &messages))
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Signed contract", messages[0].Subject)
+	assert.Equal(t, domain.ArchiveMessageObject, messages[0].Object)
+
+	_, _, err = clitestutil.ExecuteCommand(newArchiveSearchRoot(), "search", "x", "--archive-only", "--in", "INBOX")
+	assert.ErrorContains(t, err, "--archive-only")
+}
+
+func TestSearchCommand_MergesArchives(t *testing.T) {
+	useArchives(t)
+	original := withSearchClient
+	defer func() { withSearchClient = original }()
+	withSearchClient = func(_ []string, fn func(context.Context, messagesClient, string) (struct{}, error)) (struct{}, error) {
+		client := &stubMessagesClient{
+			getMessagesWithParamsFunc: func(context.Context, string, *domain.MessageQueryParams) ([]domain.Message, error) {
+				return []domain.Message{{ID: "live-1", Subject: "Contract renewal"}}, nil
+			},
+		}
+		return fn(context.Background(), client, "grant-123")
+	}
+
+	stdout, _, err := clitestutil.ExecuteCommand(newArchiveSearchRoot(), "search", "contract")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Found 2 messages")
+	assert.Contains(t, stdout, "Contract renewal")
+	assert.Contains(t, stdout, "From archives (1)")
+	assert.Contains(t, stdout, "Signed contract")
+
+	stdout, _, err = clitestutil.ExecuteCommand(newArchiveSearchRoot(), "search", "contract", "--no-archive")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Found 1 messages")
+
+	stdout, _, err = clitestutil.ExecuteCommand(newArchiveSearchRoot(), "search", "contract", "--unread")
+	require.NoError(t, err)
+	assert.NotContains(t, stdout, "From archives")
+}
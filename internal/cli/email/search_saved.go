@@ -0,0 +1,248 @@
+package email
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func newSearchSaveCmd() *cobra.Command {
+	var flags searchFlags
+
+	cmd := &cobra.Command{
+		Use:   "save <name> [query]",
+		Short: "Save a search to run later",
+		Long: `Save a search query and its filters in config under email.saved_searches.
+The query defaults to "*" (any subject). Saving under an existing name
+replaces it.
+
+Relative dates such as --after 7d are kept as written and measured from the
+time the search runs.`,
+		Example: `  # Unread invoices from the last month
+  nylas email search save invoices "invoice" --from billing@example.com --after 30d --unread
+
+  # A provider-syntax search
+  nylas email search save big-files "larger:10M" --native`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !domain.ValidSavedSearchName(name) {
+				return common.NewInputError(fmt.Sprintf("invalid name %q: use letters, digits, '.', '_' and '-'", name))
+			}
+			search := &domain.SavedSearch{Query: "*"}
+			if len(args) > 1 {
+				search.Query = args[1]
+			}
+			flags.apply(cmd, search)
+			// Catch bad dates now rather than on every run.
+			if _, err := searchParams(search, time.Now()); err != nil {
+				return err
+			}
+
+			replaced := false
+			err := updateSavedSearches(func(m map[string]*domain.SavedSearch) {
+				_, replaced = m[name]
+				m[name] = search
+			})
+			if err != nil {
+				return err
+			}
+			if replaced {
+				common.PrintSuccess("Updated saved search %q", name)
+			} else {
+				common.PrintSuccess("Saved search %q", name)
+			}
+			fmt.Println(common.Dim.Sprintf("Run it with: nylas email search run %s", name))
+			return nil
+		},
+	}
+
+	flags.register(cmd)
+
+	return cmd
+}
+
+func newSearchRunCmd() *cobra.Command {
+	var (
+		flags       searchFlags
+		archiveOpts archiveSearchOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run <name> [grant-id]",
+		Short: "Run a saved search",
+		Long: `Run a search saved with 'nylas email search save'. Filters given here
+override the saved ones for this run only.`,
+		Example: `  # Run a saved search
+  nylas email search run invoices
+
+  # Override a saved filter for this run
+  nylas email search run invoices --after 2026-01-01 --limit 100`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			saved, err := loadSavedSearches()
+			if err != nil {
+				return err
+			}
+			search, ok := saved[args[0]]
+			if !ok {
+				return common.NewUserError(fmt.Sprintf("saved search %q not found", args[0]),
+					"List saved searches with: nylas email search saved")
+			}
+			flags.apply(cmd, search)
+			return runSearch(cmd, search, args[1:], &archiveOpts)
+		},
+	}
+
+	flags.register(cmd)
+	archiveOpts.register(cmd)
+
+	return cmd
+}
+
+func newSearchSavedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "saved",
+		Short: "List saved searches",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			saved, err := loadSavedSearches()
+			if err != nil {
+				return err
+			}
+			names := make([]string, 0, len(saved))
+			for name := range saved {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			searches := make([]domain.SavedSearch, len(names))
+			for i, name := range names {
+				searches[i] = *saved[name]
+				searches[i].Name = name
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).WriteList(searches, nil)
+			}
+			if len(searches) == 0 {
+				common.PrintEmptyStateWithHint("saved searches", "Save one with 'nylas email search save <name> <query>'")
+				return nil
+			}
+			table := common.NewTable("NAME", "QUERY", "FILTERS")
+			for _, s := range searches {
+				table.AddRow(s.Name, common.Truncate(s.Query, 40), describeSearchFilters(&s))
+			}
+			table.Render()
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newSearchSavedDeleteCmd())
+
+	return cmd
+}
+
+func newSearchSavedDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a saved search",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			found := false
+			err := updateSavedSearches(func(m map[string]*domain.SavedSearch) {
+				_, found = m[args[0]]
+				delete(m, args[0])
+			})
+			if err != nil {
+				return err
+			}
+			if !found {
+				return common.NewUserError(fmt.Sprintf("saved search %q not found", args[0]),
+					"List saved searches with: nylas email search saved")
+			}
+			common.PrintSuccess("Deleted saved search %q", args[0])
+			return nil
+		},
+	}
+}
+
+// describeSearchFilters summarizes the filters of a saved search.
+func describeSearchFilters(s *domain.SavedSearch) string {
+	var parts []string
+	for _, f := range []struct{ label, value string }{
+		{"from", s.From}, {"to", s.To}, {"subject", s.Subject}, {"in", s.In}, {"after", s.After}, {"before", s.Before},
+	} {
+		if f.value != "" {
+			parts = append(parts, f.label+":"+f.value)
+		}
+	}
+	for _, f := range []struct {
+		value   *bool
+		yes, no string
+	}{
+		{s.HasAttachment, "has-attachment", "no-attachment"},
+		{s.Unread, "unread", "read"},
+		{s.Starred, "starred", "unstarred"},
+	} {
+		switch {
+		case f.value == nil:
+		case *f.value:
+			parts = append(parts, f.yes)
+		default:
+			parts = append(parts, f.no)
+		}
+	}
+	if s.Native {
+		parts = append(parts, "native")
+	}
+	if s.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("limit:%d", s.Limit))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+// loadSavedSearches returns email.saved_searches.
+func loadSavedSearches() (map[string]*domain.SavedSearch, error) {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil {
+		return nil, common.WrapLoadError("config", err)
+	}
+	if cfg.Email == nil {
+		return nil, nil
+	}
+	return cfg.Email.SavedSearches, nil
+}
+
+// updateSavedSearches applies fn to the saved searches and saves them.
+func updateSavedSearches(fn func(map[string]*domain.SavedSearch)) error {
+	store := configAdapter.NewDefaultFileStore()
+	cfg, err := store.Load()
+	if err != nil {
+		return common.WrapLoadError("config", err)
+	}
+	if cfg.Email == nil {
+		cfg.Email = &domain.EmailConfig{}
+	}
+	if cfg.Email.SavedSearches == nil {
+		cfg.Email.SavedSearches = map[string]*domain.SavedSearch{}
+	}
+	fn(cfg.Email.SavedSearches)
+	if len(cfg.Email.SavedSearches) == 0 {
+		cfg.Email.SavedSearches = nil
+	}
+	if err := store.Save(cfg); err != nil {
+		return common.WrapSaveError("config", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/cli/common"
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestSearchParams(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	yes := true
+
+	t.Run("query matches the subject", func(t *testing.T) {
+		params, err := searchParams(&domain.SavedSearch{Query: "invoice", From: "billing@example.com", Unread: &yes}, now)
+		require.NoError(t, err)
+		assert.Equal(t, "invoice", params.Subject)
+		assert.Empty(t, params.NativeQuery)
+		assert.Equal(t, "billing@example.com", params.From)
+		assert.Equal(t, &yes, params.Unread)
+		assert.Equal(t, defaultSearchLimit, params.Limit)
+	})
+
+	t.Run("native query goes to the provider", func(t *testing.T) {
+		params, err := searchParams(&domain.SavedSearch{Query: "from:alice has:attachment", Native: true, Subject: "Q3"}, now)
+		require.NoError(t, err)
+		assert.Equal(t, "from:alice has:attachment", params.NativeQuery)
+		assert.Equal(t, "Q3", params.Subject)
+	})
+
+	t.Run("relative dates", func(t *testing.T) {
+		params, err := searchParams(&domain.SavedSearch{Query: "*", After: "7d", Before: "2026-03-09"}, now)
+		require.NoError(t, err)
+		assert.Empty(t, params.Subject)
+		assert.Equal(t, now.AddDate(0, 0, -7).Unix(), params.ReceivedAfter)
+		before, _ := parseDate("2026-03-09")
+		assert.Equal(t, before.Unix(), params.ReceivedBefore)
+	})
+
+	t.Run("bad date", func(t *testing.T) {
+		_, err := searchParams(&domain.SavedSearch{Query: "*", After: "last week"}, now)
+		assert.ErrorContains(t, err, "after")
+	})
+}
+
+func newSavedSearchRoot(t *testing.T) (*cobra.Command, *[]*domain.MessageQueryParams) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	origClient, origArchives := withSearchClient, configuredArchivePaths
+	t.Cleanup(func() { withSearchClient, configuredArchivePaths = origClient, origArchives })
+	configuredArchivePaths = func() []string { return nil }
+
+	var calls []*domain.MessageQueryParams
+	withSearchClient = func(args []string, fn func(context.Context, messagesClient, string) (struct{}, error)) (struct{}, error) {
+		client := &stubMessagesClient{
+			getMessagesWithParamsFunc: func(_ context.Context, _ string, params *domain.MessageQueryParams) ([]domain.Message, error) {
+				calls = append(calls, params)
+				return []domain.Message{{ID: "msg-1"}}, nil
+			},
+		}
+		return fn(context.Background(), client, "grant-123")
+	}
+
+	root := &cobra.Command{Use: "test", SilenceErrors: true, SilenceUsage: true}
+	common.AddOutputFlags(root)
+	root.AddCommand(newSearchCmd())
+	return root, &calls
+}
+
+func TestSavedSearch_SaveRunDelete(t *testing.T) {
+	root, calls := newSavedSearchRoot(t)
+
+	_, _, err := clitestutil.ExecuteCommand(root, "search", "save", "invoices", "invoice", "--from", "billing@example.com", "--after", "30d", "--unread")
+	require.NoError(t, err)
+
+	_, _, err = clitestutil.ExecuteCommand(root, "search", "run", "invoices", "--limit", "5", "--json")
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	params := (*calls)[0]
+	assert.Equal(t, "invoice", params.Subject)
+	assert.Equal(t, "billing@example.com", params.From)
+	require.NotNil(t, params.Unread)
+	assert.True(t, *params.Unread)
+	assert.Equal(t, 5, params.Limit, "flags given to run override the saved search")
+	assert.InDelta(t, time.Now().AddDate(0, 0, -30).Unix(), params.ReceivedAfter, 5)
+
+	stdout, _, err := clitestutil.ExecuteCommand(root, "search", "saved", "--json")
+	require.NoError(t, err)
+	var saved []domain.SavedSearch
+	require.NoError(t, json.Unmarshal([]byte(stdout), &saved))
+	require.Len(t, saved, 1)
+	assert.Equal(t, "invoices", saved[0].Name)
+	assert.Equal(t, "30d", saved[0].After, "relative dates are stored as written")
+	assert.Zero(t, saved[0].Limit, "the run override is not saved")
+
+	_, _, err = clitestutil.ExecuteCommand(root, "search", "saved", "delete", "invoices")
+	require.NoError(t, err)
+	_, _, err = clitestutil.ExecuteCommand(root, "search", "run", "invoices")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestSavedSearch_Validation(t *testing.T) {
+	root, _ := newSavedSearchRoot(t)
+
+	_, _, err := clitestutil.ExecuteCommand(root, "search", "save", "bad name", "x")
+	assert.ErrorContains(t, err, "invalid name")
+
+	_, _, err = clitestutil.ExecuteCommand(root, "search", "save", "recent", "--after", "yesterday")
+	assert.ErrorContains(t, err, "after")
+
+	_, _, err = clitestutil.ExecuteCommand(root, "search", "saved", "delete", "nope")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestSearchCmd_Native(t *testing.T) {
+	root, calls := newSavedSearchRoot(t)
+
+	_, _, err := clitestutil.ExecuteCommand(root, "search", "from:alice has:attachment", "--native", "--json")
+	require.NoError(t, err)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "from:alice has:attachment", (*calls)[0].NativeQuery)
+	assert.Empty(t, (*calls)[0].Subject)
+
+	_, _, err = clitestutil.ExecuteCommand(root, "search", "x", "--native", "--archive-only", "--archive", t.TempDir())
+	assert.ErrorContains(t, err, "--native can't be used with --archive-only")
+}
+
+func TestDescribeSearchFilters(t *testing.T) {
+	no := false
+	s := &domain.SavedSearch{From: "a@example.com", After: "7d", Unread: &no, Native: true, Limit: 50}
+	assert.Equal(t, "from:a@example.com after:7d read native limit:50", describeSearchFilters(s))
+	assert.Equal(t, "-", describeSearchFilters(&domain.SavedSearch{}))
+}
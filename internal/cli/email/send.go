@@ -1,10 +1,8 @@
 package email
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -22,7 +20,9 @@ func newSendCmd() *cobra.Command {
 	var subject string
 	var body string
 	var replyTo string
+	var from string
 	var interactive bool
+	var edit bool
 	var scheduleAt string
 	var noConfirm bool
 	var trackOpens bool
@@ -33,9 +33,18 @@ func newSendCmd() *cobra.Command {
 	var gpgKeyID string
 	var listGPGKeys bool
 	var encrypt bool
+	var smimeOpts smimeSendOptions
 	var recipientKey string
 	var signatureID string
 	var templateOpts hostedTemplateSendOptions
+	var attachFiles []string
+	var allowUnsafe bool
+	var sizeOpts messageSizeOptions
+	var via string
+	var sendNow bool
+	var recipientTZ string
+	var sendAtBestTime bool
+	var bestTime string
 
 	cmd := &cobra.Command{
 		Use:   "send [grant-id]",
@@ -52,12 +61,34 @@ Supports GPG/PGP email encryption:
 - --recipient-key <key-id>: Use specific GPG key for encryption
 - --sign --encrypt: Sign AND encrypt for maximum security
 
+Supports S/MIME, for organizations that mandate it instead of PGP:
+- --smime-sign: Sign with your certificate (.p12/.pfx in ~/.config/nylas/smime, or --smime-cert)
+- --smime-encrypt: Encrypt with recipients' certificates (from that directory, the
+  macOS keychain, or --smime-recipient-cert). Set NYLAS_SMIME_PASSWORD to skip the prompt.
+
+--from sends as one of the grant's send-as identities, such as a Gmail
+"Send mail as" alias; see 'nylas email identities'. Without it, the identity
+chosen with 'nylas email identities use' is used, or the grant's address.
+
+--edit writes the email in your editor ($VISUAL or $EDITOR) as To, Cc, Bcc
+and Subject headers, a blank line and the body, starting from any flags
+given. Recipients are checked when you save, before anything is sent.
+
 Supports scheduled sending with the --schedule flag. You can specify:
 - Duration: "30m", "2h", "1d" (minutes, hours, days from now)
 - Time: "14:30" or "2:30pm" (today or tomorrow if past)
 - Date/time: "2024-01-15 14:30" or "tomorrow 9am"
 - Unix timestamp: "1705320600"
 
+Immediate sends during quiet hours (see 'nylas email quiet-hours') are
+scheduled for the end of the window in the recipients' time zone. Use --now
+to send anyway, or --recipient-tz to evaluate them in another time zone.
+
+--send-at-best-time schedules the message for the next weekday at a good
+local hour for the first recipient (09:00, or email.best_send_time). Their
+time zone comes from --recipient-tz, the Date headers of their recent
+messages, or their contact address, and the reasoning is shown.
+
 Supports email tracking:
 - --track-opens: Track when recipients open the email
 - --track-links: Track when recipients click links
@@ -66,6 +97,20 @@ Supports email tracking:
 Supports custom metadata:
 - --metadata key=value: Add custom key-value metadata (can be repeated)
 
+Supports attachments:
+- --attach <file>: Attach a file (can be repeated)
+- The message size (body plus encoded attachments) is checked against the
+  provider's limit before sending. Over the limit, you're offered to upload
+  the attachments and send links instead (see email.attachment_upload_url)
+- --link-attachments: Always send attachments as links
+- --skip-size-check: Send even if the message exceeds the provider's limit
+
+Supports an SMTP relay (see 'nylas email smtp setup'):
+- --via smtp: Send through the relay instead of Nylas
+- --via auto: Send through Nylas, falling back to the relay when Nylas is
+  rate limited or can't be reached (the default when email.smtp.fallback is set)
+- Scheduling, tracking, metadata, signatures and GPG require Nylas
+
 Supports hosted templates:
 - --template-id <id>: Render and send a Nylas-hosted template
 - --template-data <json>: Provide template variables as inline JSON
@@ -74,6 +119,12 @@ Supports hosted templates:
 		Example: `  # Send immediately
   nylas email send --to user@example.com --subject "Hello" --body "Hi there!"
 
+  # Write the email in your editor
+  nylas email send --to user@example.com --edit
+
+  # Send from an alias
+  nylas email send --to user@example.com --subject "Quote" --body "Attached" --from sales@example.com
+
   # Send using a hosted template
   nylas email send --to user@example.com --template-id tpl_123 --template-data '{"user":{"name":"Ada"}}'
 
@@ -98,6 +149,9 @@ Supports hosted templates:
   # List available GPG keys
   nylas email send --list-gpg-keys
 
+  # S/MIME sign and encrypt
+  nylas email send --to bob@example.com --subject "Contract" --body "Attached" --smime-sign --smime-encrypt
+
   # Send in 2 hours
   nylas email send --to user@example.com --subject "Reminder" --schedule 2h
 
@@ -107,9 +161,22 @@ Supports hosted templates:
   # Send at a specific time
   nylas email send --to user@example.com --subject "Meeting" --schedule "2024-01-15 14:30"
 
+  # Send at 10:00 in the recipient's inferred time zone
+  nylas email send --to user@example.com --subject "Hello" --send-at-best-time --best-time 10:00
+
   # Send with open and link tracking
   nylas email send --to user@example.com --subject "Newsletter" --track-opens --track-links
 
+  # Send with attachments
+  nylas email send --to user@example.com --subject "Report" --attach report.pdf --attach data.csv
+
+  # Upload large attachments and send links instead
+  nylas config set email.attachment_upload_url https://transfer.sh
+  nylas email send --to user@example.com --subject "Video" --attach demo.mp4 --link-attachments
+
+  # Send through Nylas, falling back to the SMTP relay when unreachable
+  nylas email send --to user@example.com --subject "Status" --body "All good" --via auto
+
   # Send with custom metadata
   nylas email send --to user@example.com --subject "Invoice" --metadata campaign=q4 --metadata type=invoice`,
 		Args: cobra.MaximumNArgs(1),
@@ -119,9 +186,13 @@ Supports hosted templates:
 			if listGPGKeys {
 				return handleListGPGKeys(cmd.Context())
 			}
+			if smimeOpts.listCerts {
+				return handleListSMIMECerts(cmd.Context())
+			}
 
 			// Check auto-sign config if --sign flag not explicitly set
-			if !cmd.Flags().Changed("sign") {
+			// (S/MIME replaces GPG for this message)
+			if !cmd.Flags().Changed("sign") && !smimeOpts.enabled() {
 				configStore := configAdapter.NewDefaultFileStore()
 				cfg, err := configStore.Load()
 				if err == nil && cfg != nil && cfg.GPG != nil && cfg.GPG.AutoSign {
@@ -129,27 +200,41 @@ Supports hosted templates:
 				}
 			}
 
-			// Interactive mode (runs before client setup).
-			if shouldUseInteractiveSendMode(interactive, to, subject, body, templateOpts) {
-				reader := bufio.NewReader(os.Stdin)
-
-				if len(to) == 0 && !templateOpts.RenderOnly {
-					fmt.Print("To (comma-separated): ")
-					input, _ := reader.ReadString('\n')
-					to = parseEmails(strings.TrimSpace(input))
-				}
+			if err := smimeOpts.validate(sign, encrypt); err != nil {
+				return err
+			}
+			if sendNow && scheduleAt != "" {
+				return common.NewMutuallyExclusiveError("--now", "--schedule")
+			}
+			if sendAtBestTime && scheduleAt != "" {
+				return common.NewMutuallyExclusiveError("--send-at-best-time", "--schedule")
+			}
+			if bestTime != "" && !sendAtBestTime {
+				return common.NewUserError("--best-time requires --send-at-best-time", "")
+			}
+			if edit && interactive {
+				return common.NewMutuallyExclusiveError("edit", "interactive")
+			}
+			if edit && templateOpts.TemplateID != "" {
+				return common.NewMutuallyExclusiveError("edit", "template-id")
+			}
 
-				if templateOpts.TemplateID == "" && subject == "" {
-					fmt.Print("Subject: ")
-					subject, _ = reader.ReadString('\n')
-					subject = strings.TrimSpace(subject)
-				}
+			sendVia, err := resolveSendVia(via)
+			if err != nil {
+				return err
+			}
 
-				if templateOpts.TemplateID == "" && body == "" {
-					fmt.Println("Body (end with a line containing only '.'):")
-					body = readBodyLines(reader)
-				}
+			// Editor and interactive modes (run before client setup).
+			fields := &emailTemplate{To: to, Cc: cc, Bcc: bcc, Subject: subject, Body: body}
+			composed, err := composeSendFields(fields, args, edit, interactive, templateOpts)
+			if err != nil {
+				return err
+			}
+			if !composed {
+				fmt.Println("Cancelled.")
+				return nil
 			}
+			to, cc, bcc, subject, body = fields.To, fields.Cc, fields.Bcc, fields.Subject, fields.Body
 
 			if err := validateHostedTemplateSendOptions(templateOpts, subject, body); err != nil {
 				return err
@@ -200,6 +285,16 @@ Supports hosted templates:
 				}
 			}
 
+			attachments, err := loadAttachmentsFromFiles(attachFiles)
+			if err != nil {
+				return common.WrapLoadError("attachments", err)
+			}
+			if err := checkOutgoingAttachments(attachments, allowUnsafe); err != nil {
+				return err
+			}
+			sizeOpts.Prompt = !noConfirm
+			sizeOpts.Encrypted = encrypt || smimeOpts.encrypt
+
 			sendNeedsGrant, err := hostedTemplateSendNeedsGrant(templateOpts)
 			if err != nil {
 				return err
@@ -236,10 +331,16 @@ Supports hosted templates:
 					Cc:          ccContacts,
 					Bcc:         bccContacts,
 					SignatureID: signatureID,
+					Attachments: attachments,
 				}
 				if replyTo != "" {
 					req.ReplyToMsgID = replyTo
 				}
+				sender, err := resolveSendFrom(ctx, client, grantID, from)
+				if err != nil {
+					return struct{}{}, err
+				}
+				req.From = sender
 				if trackOpens || trackLinks || trackLabel != "" {
 					req.TrackingOpts = &domain.TrackingOptions{
 						Opens: trackOpens,
@@ -258,144 +359,49 @@ Supports hosted templates:
 						return struct{}{}, common.NewInputError(fmt.Sprintf("invalid metadata format: %s (expected key=value)", m))
 					}
 				}
-				if !scheduledTime.IsZero() {
-					req.SendAt = scheduledTime.Unix()
-				}
-
-				fmt.Println("\nEmail preview:")
-				if templatePreviewLabel != "" {
-					fmt.Printf("  Template: %s\n", templatePreviewLabel)
-				}
-				if len(to) > 0 {
-					fmt.Printf("  To:      %s\n", strings.Join(to, ", "))
-				}
-				if len(cc) > 0 {
-					fmt.Printf("  Cc:      %s\n", strings.Join(cc, ", "))
-				}
-				if len(bcc) > 0 {
-					fmt.Printf("  Bcc:     %s\n", strings.Join(bcc, ", "))
-				}
-				fmt.Printf("  Subject: %s\n", activeSubject)
-				if activeBody != "" {
-					fmt.Printf("  Body:    %s\n", common.Truncate(activeBody, 50))
-				}
-				if !scheduledTime.IsZero() {
-					fmt.Printf("  %s %s\n", common.Yellow.Sprint("Scheduled:"), scheduledTime.Format(common.DisplayWeekdayFullWithTZ))
-				}
-				if trackOpens || trackLinks {
-					tracking := []string{}
-					if trackOpens {
-						tracking = append(tracking, "opens")
-					}
-					if trackLinks {
-						tracking = append(tracking, "links")
-					}
-					fmt.Printf("  %s %s\n", common.Cyan.Sprint("Tracking:"), strings.Join(tracking, ", "))
-				}
-				if len(metadata) > 0 {
-					fmt.Printf("  %s %s\n", common.Cyan.Sprint("Metadata:"), strings.Join(metadata, ", "))
-				}
-				if sign {
-					signingInfo := "default key from git config"
-					if gpgKeyID != "" {
-						signingInfo = fmt.Sprintf("key %s", gpgKeyID)
+				if sendAtBestTime {
+					bestAt, err := bestSendTime(ctx, client, grantID, toContacts, recipientTZ, bestTime, time.Now())
+					if err != nil {
+						return struct{}{}, err
 					}
-					fmt.Printf("  %s %s\n", common.Green.Sprint("GPG Signed:"), signingInfo)
+					scheduledTime = bestAt
 				}
-				if encrypt {
-					var encryptInfo string
-					if recipientKey != "" {
-						encryptInfo = fmt.Sprintf("with key %s", recipientKey)
-					} else {
-						encryptInfo = fmt.Sprintf("for %s (auto-fetch)", strings.Join(to, ", "))
+				if scheduledTime.IsZero() && !sendNow {
+					heldUntil, err := holdForQuietHours(grantID, time.Now(), recipientTZ, sendVia)
+					if err != nil {
+						return struct{}{}, err
 					}
-					fmt.Printf("  %s %s\n", common.Blue.Sprint("GPG Encrypted:"), encryptInfo)
+					scheduledTime = heldUntil
 				}
-				if signatureID != "" {
-					fmt.Printf("  %s %s\n", common.Cyan.Sprint("Signature:"), signatureID)
+				if !scheduledTime.IsZero() {
+					req.SendAt = scheduledTime.Unix()
 				}
-
-				if !noConfirm {
-					prompt := "\nSend this email?"
-					if !scheduledTime.IsZero() {
-						prompt = "\nSchedule this email?"
-					}
-
-					if !common.Confirm(prompt, false) {
-						fmt.Println("Cancelled.")
-						return struct{}{}, nil
-					}
+				activeVia, err := checkSendVia(sendVia, req, sign, encrypt)
+				if err == nil {
+					activeVia, err = smimeOpts.checkVia(activeVia)
 				}
-
-				var msg *domain.Message
-				var err error
-
-				// Get grant info to determine provider and email
-				grant, err := getGrantForSend(ctx, client, grantID)
 				if err != nil {
 					return struct{}{}, err
 				}
-				if signatureID != "" {
-					if err := validateSendSignatureSupport(signatureID, sign, encrypt, grant); err != nil {
-						return struct{}{}, err
-					}
-					if _, err := validateSignatureSelection(ctx, client, grantID, signatureID, grant); err != nil {
-						return struct{}{}, err
-					}
-				}
-
-				if sign || encrypt {
-					if err := validateManagedSecureSendSupport(sign, encrypt, grant); err != nil {
-						return struct{}{}, err
-					}
-					if grant.Email != "" {
-						// Populate From field with grant's email address
-						req.From = []domain.EmailParticipant{
-							{Email: grant.Email},
-						}
-					}
-
-					// GPG signing and/or encryption flow
-					msg, err = sendSecureEmail(ctx, client, grantID, req, gpgKeyID, recipientKey, toContacts, activeSubject, activeBody, sign, encrypt)
-				} else {
-					// Standard flow
-					var sendMsg string
-					if scheduledTime.IsZero() {
-						sendMsg = "Sending email..."
-					} else {
-						sendMsg = "Scheduling email..."
-					}
 
-					spinner := common.NewSpinner(sendMsg)
-					spinner.Start()
-
-					msg, err = sendMessageForGrant(ctx, client, grantID, grant, req)
-					spinner.Stop()
-				}
-
-				if err != nil {
-					return struct{}{}, common.WrapSendError("email", err)
-				}
-
-				if jsonOutput {
-					return struct{}{}, common.PrintJSON(msg)
-				}
-
-				if !scheduledTime.IsZero() {
-					common.PrintSuccess("Email scheduled successfully! Message ID: %s", msg.ID)
-					fmt.Printf("Scheduled to send: %s\n", scheduledTime.Format(common.DisplayWeekdayFullWithTZ))
-				} else {
-					if sign && encrypt {
-						common.PrintSuccess("Signed and encrypted email sent successfully! Message ID: %s", msg.ID)
-					} else if encrypt {
-						common.PrintSuccess("Encrypted email sent successfully! Message ID: %s", msg.ID)
-					} else if sign {
-						common.PrintSuccess("Signed email sent successfully! Message ID: %s", msg.ID)
-					} else {
-						common.PrintSuccess("Email sent successfully! Message ID: %s", msg.ID)
-					}
-				}
-				return struct{}{}, nil
+				return struct{}{}, sendPlanned(ctx, client, grantID, &sendPlan{
+					req:           req,
+					via:           activeVia,
+					to:            to,
+					cc:            cc,
+					bcc:           bcc,
+					templateLabel: templatePreviewLabel,
+					metadata:      metadata,
+					scheduledTime: scheduledTime,
+					sign:          sign,
+					encrypt:       encrypt,
+					gpgKeyID:      gpgKeyID,
+					recipientKey:  recipientKey,
+					smime:         &smimeOpts,
+					size:          sizeOpts,
+					noConfirm:     noConfirm,
+					jsonOutput:    jsonOutput,
+				})
 			}
 
 			if sendNeedsGrant {
@@ -416,8 +422,14 @@ Supports hosted templates:
 	cmd.Flags().StringVarP(&subject, "subject", "s", "", "Email subject")
 	cmd.Flags().StringVarP(&body, "body", "b", "", "Email body (HTML or plain text)")
 	cmd.Flags().StringVar(&replyTo, "reply-to", "", "Message ID to reply to")
+	cmd.Flags().StringVar(&from, "from", "", "Send as one of the grant's identities (see 'nylas email identities list')")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Interactive mode")
+	cmd.Flags().BoolVar(&edit, "edit", false, "Write the email in $EDITOR, starting from the other flags")
 	cmd.Flags().StringVar(&scheduleAt, "schedule", "", "Schedule sending (e.g., '2h', 'tomorrow 9am', '2024-01-15 14:30')")
+	cmd.Flags().BoolVar(&sendNow, "now", false, "Send immediately, even during quiet hours")
+	cmd.Flags().StringVar(&recipientTZ, "recipient-tz", "", "Recipients' IANA time zone for quiet hours and --send-at-best-time")
+	cmd.Flags().BoolVar(&sendAtBestTime, "send-at-best-time", false, "Schedule for a good local hour in the recipient's inferred time zone")
+	cmd.Flags().StringVar(&bestTime, "best-time", "", "Recipient-local HH:MM for --send-at-best-time (default: email.best_send_time or 09:00)")
 	cmd.Flags().BoolVarP(&noConfirm, "yes", "y", false, "Skip confirmation prompt")
 	cmd.Flags().BoolVar(&trackOpens, "track-opens", false, "Track email opens")
 	cmd.Flags().BoolVar(&trackLinks, "track-links", false, "Track link clicks")
@@ -428,6 +440,12 @@ Supports hosted templates:
 	cmd.Flags().BoolVar(&listGPGKeys, "list-gpg-keys", false, "List available GPG signing keys and exit")
 	cmd.Flags().BoolVar(&encrypt, "encrypt", false, "Encrypt email with recipient's GPG public key")
 	cmd.Flags().StringVar(&recipientKey, "recipient-key", "", "Specific GPG key ID for encryption (auto-detected from recipient email if not specified)")
+	smimeOpts.addFlags(cmd)
+	cmd.Flags().StringArrayVarP(&attachFiles, "attach", "a", nil, "File to attach (can be repeated)")
+	cmd.Flags().BoolVar(&allowUnsafe, "allow-unsafe", false, "Attach file types on the email.unsafe_attachment_types blocklist (e.g. .exe)")
+	cmd.Flags().BoolVar(&sizeOpts.LinkAttachments, "link-attachments", false, "Upload attachments and send links instead (requires email.attachment_upload_url)")
+	cmd.Flags().BoolVar(&sizeOpts.SkipCheck, "skip-size-check", false, "Send even if the message exceeds the provider's size limit")
+	cmd.Flags().StringVar(&via, "via", "", "Delivery path: nylas, smtp (configured relay), or auto (Nylas with SMTP fallback)")
 	cmd.Flags().StringVar(&signatureID, "signature-id", "", "Stored signature ID to append when sending")
 	cmd.Flags().StringVar(&templateOpts.TemplateID, "template-id", "", "Hosted template ID to render and send")
 	cmd.Flags().StringVar(&templateOpts.TemplateScope, "template-scope", string(domain.ScopeApplication), "Hosted template scope: app or grant")
@@ -0,0 +1,221 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// sendPlan is a composed `email send` message with the options that decide
+// how it is checked, previewed and delivered.
+type sendPlan struct {
+	req           *domain.SendMessageRequest
+	via           string
+	to, cc, bcc   []string // As given, for the preview
+	templateLabel string
+	metadata      []string
+	scheduledTime time.Time
+	sign          bool
+	encrypt       bool
+	gpgKeyID      string
+	recipientKey  string
+	smime         *smimeSendOptions
+	size          messageSizeOptions
+	noConfirm     bool
+	jsonOutput    bool
+}
+
+// signed reports whether the message is GPG or S/MIME signed.
+func (p *sendPlan) signed() bool {
+	return p.sign || p.smime.sign
+}
+
+// encrypted reports whether the message is GPG or S/MIME encrypted.
+func (p *sendPlan) encrypted() bool {
+	return p.encrypt || p.smime.encrypt
+}
+
+// sendPlanned checks the planned message against the grant, previews it,
+// asks for confirmation and delivers it.
+func sendPlanned(ctx context.Context, client ports.NylasClient, grantID string, p *sendPlan) error {
+	grant, err := getGrantForDelivery(ctx, client, grantID, p.via)
+	if err != nil {
+		return err
+	}
+	if signatureID := p.req.SignatureID; signatureID != "" {
+		if err := validateSendSignatureSupport(signatureID, p.signed(), p.encrypted(), grant); err != nil {
+			return err
+		}
+		if _, err := validateSignatureSelection(ctx, client, grantID, signatureID, grant); err != nil {
+			return err
+		}
+	}
+
+	// Checked before the preview, so a size problem shows before the send
+	// is confirmed.
+	if err := enforceMessageSize(ctx, grant, p.req, p.size); err != nil {
+		return err
+	}
+
+	printSendPlan(p)
+	if !p.noConfirm {
+		prompt := "\nSend this email?"
+		if !p.scheduledTime.IsZero() {
+			prompt = "\nSchedule this email?"
+		}
+		if !common.Confirm(prompt, false) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	msg, delivery, err := deliverPlanned(ctx, client, grantID, grant, p)
+	if err != nil {
+		return common.WrapSendError("email", err)
+	}
+
+	if p.jsonOutput {
+		return common.PrintJSON(msg)
+	}
+	printDeliveryNote(delivery)
+
+	if !p.scheduledTime.IsZero() {
+		common.PrintSuccess("Email scheduled successfully! Message ID: %s", msg.ID)
+		fmt.Printf("Scheduled to send: %s\n", p.scheduledTime.Format(common.DisplayWeekdayFullWithTZ))
+		return nil
+	}
+	switch signed, encrypted := p.signed(), p.encrypted(); {
+	case signed && encrypted:
+		common.PrintSuccess("Signed and encrypted email sent successfully! Message ID: %s", msg.ID)
+	case encrypted:
+		common.PrintSuccess("Encrypted email sent successfully! Message ID: %s", msg.ID)
+	case signed:
+		common.PrintSuccess("Signed email sent successfully! Message ID: %s", msg.ID)
+	default:
+		common.PrintSuccess("Email sent successfully! Message ID: %s", msg.ID)
+	}
+	return nil
+}
+
+// deliverPlanned sends the message with S/MIME, with GPG, or as is. The
+// delivery is only set for messages that went through deliverMessage.
+func deliverPlanned(
+	ctx context.Context,
+	client ports.NylasClient,
+	grantID string,
+	grant *domain.Grant,
+	p *sendPlan,
+) (*domain.Message, *smtpDelivery, error) {
+	req := p.req
+	switch {
+	case p.smime.enabled():
+		if err := p.smime.validateGrant(grant); err != nil {
+			return nil, nil, err
+		}
+		fillSendFrom(req, grant)
+		msg, err := sendSMIMEEmail(ctx, client, grantID, req, req.To, req.Subject, req.Body, p.smime)
+		return msg, nil, err
+
+	case p.sign || p.encrypt:
+		if err := validateManagedSecureSendSupport(p.sign, p.encrypt, grant); err != nil {
+			return nil, nil, err
+		}
+		fillSendFrom(req, grant)
+		msg, err := sendSecureEmail(ctx, client, grantID, req, p.gpgKeyID, p.recipientKey, req.To, req.Subject, req.Body, p.sign, p.encrypt)
+		return msg, nil, err
+	}
+
+	sendMsg := "Sending email..."
+	if !p.scheduledTime.IsZero() {
+		sendMsg = "Scheduling email..."
+	}
+	spinner := common.NewSpinner(sendMsg)
+	spinner.Start()
+	delivery, err := deliverMessage(ctx, client, grantID, grant, req, p.via)
+	spinner.Stop()
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery.Message, delivery, nil
+}
+
+// printSendPlan shows the message and how it will be sent.
+func printSendPlan(p *sendPlan) {
+	req := p.req
+	fmt.Println("\nEmail preview:")
+	if p.templateLabel != "" {
+		fmt.Printf("  Template: %s\n", p.templateLabel)
+	}
+	if len(req.From) > 0 {
+		fmt.Printf("  From:    %s\n", req.From[0].String())
+	}
+	if len(p.to) > 0 {
+		fmt.Printf("  To:      %s\n", strings.Join(p.to, ", "))
+	}
+	if len(p.cc) > 0 {
+		fmt.Printf("  Cc:      %s\n", strings.Join(p.cc, ", "))
+	}
+	if len(p.bcc) > 0 {
+		fmt.Printf("  Bcc:     %s\n", strings.Join(p.bcc, ", "))
+	}
+	fmt.Printf("  Subject: %s\n", req.Subject)
+	if req.Body != "" {
+		fmt.Printf("  Body:    %s\n", common.Truncate(req.Body, 50))
+	}
+	if !p.scheduledTime.IsZero() {
+		fmt.Printf("  %s %s\n", common.Yellow.Sprint("Scheduled:"), p.scheduledTime.Format(common.DisplayWeekdayFullWithTZ))
+	}
+	if opts := req.TrackingOpts; opts != nil && (opts.Opens || opts.Links) {
+		tracking := []string{}
+		if opts.Opens {
+			tracking = append(tracking, "opens")
+		}
+		if opts.Links {
+			tracking = append(tracking, "links")
+		}
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Tracking:"), strings.Join(tracking, ", "))
+	}
+	if len(p.metadata) > 0 {
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Metadata:"), strings.Join(p.metadata, ", "))
+	}
+	if p.sign {
+		signingInfo := "default key from git config"
+		if p.gpgKeyID != "" {
+			signingInfo = fmt.Sprintf("key %s", p.gpgKeyID)
+		}
+		fmt.Printf("  %s %s\n", common.Green.Sprint("GPG Signed:"), signingInfo)
+	}
+	if p.encrypt {
+		var encryptInfo string
+		if p.recipientKey != "" {
+			encryptInfo = fmt.Sprintf("with key %s", p.recipientKey)
+		} else {
+			encryptInfo = fmt.Sprintf("for %s (auto-fetch)", strings.Join(p.to, ", "))
+		}
+		fmt.Printf("  %s %s\n", common.Blue.Sprint("GPG Encrypted:"), encryptInfo)
+	}
+	if p.smime.sign {
+		signingInfo := "sender's certificate"
+		if p.smime.certFile != "" {
+			signingInfo = p.smime.certFile
+		}
+		fmt.Printf("  %s %s\n", common.Green.Sprint("S/MIME Signed:"), signingInfo)
+	}
+	if p.smime.encrypt {
+		fmt.Printf("  %s for %s\n", common.Blue.Sprint("S/MIME Encrypted:"), strings.Join(p.to, ", "))
+	}
+	if req.SignatureID != "" {
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Signature:"), req.SignatureID)
+	}
+	if p.via == sendViaSMTP {
+		fmt.Printf("  %s %s\n", common.Cyan.Sprint("Via:"), "SMTP relay")
+	}
+	for _, att := range req.Attachments {
+		fmt.Printf("  Attach:  %s (%s)\n", att.Filename, common.FormatSize(att.Size))
+	}
+}
@@ -14,12 +14,15 @@ import (
 	"github.com/nylas/cli/internal/ports"
 )
 
+// newGPGBackend returns the OpenPGP backend selected by gpg.backend.
+var newGPGBackend = func(ctx context.Context) (gpg.Backend, error) {
+	return common.NewGPGBackend(ctx, config.NewDefaultFileStore())
+}
+
 // handleListGPGKeys lists available GPG signing keys.
 func handleListGPGKeys(ctx context.Context) error {
-	gpgSvc := gpg.NewService()
-
-	// Check GPG is available
-	if err := gpgSvc.CheckGPGAvailable(ctx); err != nil {
+	gpgSvc, err := newGPGBackend(ctx)
+	if err != nil {
 		return err
 	}
 
@@ -31,7 +34,7 @@ func handleListGPGKeys(ctx context.Context) error {
 
 	if len(keys) == 0 {
 		fmt.Println("No GPG signing keys found.")
-		fmt.Println("\nTo generate a new GPG key, run: gpg --gen-key")
+		fmt.Println("\nTo generate a new GPG key, run: nylas gpg keys generate --name \"Your Name\" --email you@example.com")
 		return nil
 	}
 
@@ -73,16 +76,14 @@ func handleListGPGKeys(ctx context.Context) error {
 
 // sendSecureEmail sends an email with GPG signing and/or encryption.
 func sendSecureEmail(ctx context.Context, client ports.NylasClient, grantID string, req *domain.SendMessageRequest, gpgKeyID, recipientKeyID string, toContacts []domain.EmailParticipant, subject, body string, doSign, doEncrypt bool) (*domain.Message, error) {
-	gpgSvc := gpg.NewService()
-
 	// Step 1: Check GPG is available
 	spinner := common.NewSpinner("Checking GPG...")
 	spinner.Start()
-	if err := gpgSvc.CheckGPGAvailable(ctx); err != nil {
-		spinner.Stop()
+	gpgSvc, err := newGPGBackend(ctx)
+	spinner.Stop()
+	if err != nil {
 		return nil, err
 	}
-	spinner.Stop()
 
 	// Step 2: Resolve keys for signing and/or encryption
 	var signerKeyID string
@@ -103,7 +104,6 @@ func sendSecureEmail(ctx context.Context, client ports.NylasClient, grantID stri
 	if doEncrypt {
 		spinner = common.NewSpinner("Resolving recipient public keys...")
 		spinner.Start()
-		var err error
 		recipientKeyIDs, err = resolveRecipientKeys(ctx, gpgSvc, recipientKeyID, toContacts, req.Cc, req.Bcc)
 		if err != nil {
 			spinner.Stop()
@@ -162,7 +162,7 @@ func sendSecureEmail(ctx context.Context, client ports.NylasClient, grantID stri
 }
 
 // resolveSigningKey determines the signing key to use.
-func resolveSigningKey(ctx context.Context, gpgSvc *gpg.Service, explicitKeyID string, req *domain.SendMessageRequest) (keyID, identity string) {
+func resolveSigningKey(ctx context.Context, gpgSvc gpg.Backend, explicitKeyID string, req *domain.SendMessageRequest) (keyID, identity string) {
 	if explicitKeyID != "" {
 		return explicitKeyID, explicitKeyID
 	}
@@ -193,7 +193,7 @@ func resolveSigningKey(ctx context.Context, gpgSvc *gpg.Service, explicitKeyID s
 }
 
 // resolveRecipientKeys determines the encryption keys for all recipients.
-func resolveRecipientKeys(ctx context.Context, gpgSvc *gpg.Service, explicitKeyID string, to, cc, bcc []domain.EmailParticipant) ([]string, error) {
+func resolveRecipientKeys(ctx context.Context, gpgSvc gpg.Backend, explicitKeyID string, to, cc, bcc []domain.EmailParticipant) ([]string, error) {
 	// If explicit key provided, use it
 	if explicitKeyID != "" {
 		return []string{explicitKeyID}, nil
@@ -237,7 +237,7 @@ func resolveRecipientKeys(ctx context.Context, gpgSvc *gpg.Service, explicitKeyI
 }
 
 // buildSignedMessage builds a signed-only PGP/MIME message.
-func buildSignedMessage(ctx context.Context, gpgSvc *gpg.Service, mimeBuilder *mime.Builder, req *domain.SendMessageRequest, toContacts []domain.EmailParticipant, subject, body, contentType, signerKeyID, signingIdentity string) ([]byte, error) {
+func buildSignedMessage(ctx context.Context, gpgSvc gpg.Backend, mimeBuilder *mime.Builder, req *domain.SendMessageRequest, toContacts []domain.EmailParticipant, subject, body, contentType, signerKeyID, signingIdentity string) ([]byte, error) {
 	spinner := common.NewSpinner(fmt.Sprintf("Signing email with GPG identity: %s...", signingIdentity))
 	spinner.Start()
 	defer spinner.Stop()
@@ -285,7 +285,7 @@ func buildSignedMessage(ctx context.Context, gpgSvc *gpg.Service, mimeBuilder *m
 }
 
 // buildEncryptedMessage builds an encrypted-only PGP/MIME message.
-func buildEncryptedMessage(ctx context.Context, gpgSvc *gpg.Service, mimeBuilder *mime.Builder, req *domain.SendMessageRequest, toContacts []domain.EmailParticipant, subject, body, contentType string, recipientKeyIDs []string) ([]byte, error) {
+func buildEncryptedMessage(ctx context.Context, gpgSvc gpg.Backend, mimeBuilder *mime.Builder, req *domain.SendMessageRequest, toContacts []domain.EmailParticipant, subject, body, contentType string, recipientKeyIDs []string) ([]byte, error) {
 	spinner := common.NewSpinner("Encrypting email...")
 	spinner.Start()
 	defer spinner.Stop()
@@ -323,7 +323,7 @@ func buildEncryptedMessage(ctx context.Context, gpgSvc *gpg.Service, mimeBuilder
 
 // buildSignedEncryptedMessage builds a signed AND encrypted PGP/MIME message.
 // Order: Sign first, then encrypt (per OpenPGP best practice).
-func buildSignedEncryptedMessage(ctx context.Context, gpgSvc *gpg.Service, mimeBuilder *mime.Builder, req *domain.SendMessageRequest, toContacts []domain.EmailParticipant, subject, body, contentType, signerKeyID string, recipientKeyIDs []string) ([]byte, error) {
+func buildSignedEncryptedMessage(ctx context.Context, gpgSvc gpg.Backend, mimeBuilder *mime.Builder, req *domain.SendMessageRequest, toContacts []domain.EmailParticipant, subject, body, contentType, signerKeyID string, recipientKeyIDs []string) ([]byte, error) {
 	spinner := common.NewSpinner("Signing and encrypting email...")
 	spinner.Start()
 	defer spinner.Stop()
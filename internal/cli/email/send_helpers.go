@@ -1,7 +1,11 @@
 package email
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"os"
+	"strings"
 
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/domain"
@@ -23,6 +27,8 @@ func getGrantForSend(ctx context.Context, client ports.NylasClient, grantID stri
 // The per-grant endpoint is the only one that archives the message to the
 // sender's Sent folder; the domain-based transactional endpoint is a relay
 // (and injects a developer-account banner), so it is *not* used here.
+//
+// The recipients of a sent message are counted in the recipient index.
 func sendMessageForGrant(
 	ctx context.Context,
 	client ports.NylasClient,
@@ -33,7 +39,11 @@ func sendMessageForGrant(
 	if isNylasProviderGrant(grant) && len(req.From) == 0 && grant.Email != "" {
 		req.From = []domain.EmailParticipant{{Email: grant.Email}}
 	}
-	return client.SendMessage(ctx, grantID, req)
+	msg, err := client.SendMessage(ctx, grantID, req)
+	if err == nil {
+		common.RecordRecipients(grantID, req.To, req.Cc, req.Bcc)
+	}
+	return msg, err
 }
 
 func shouldUseInteractiveSendMode(
@@ -50,3 +60,42 @@ func shouldUseInteractiveSendMode(
 	}
 	return len(to) == 0 && subject == "" && body == ""
 }
+
+// composeSendFields completes the message in $EDITOR with --edit, or by
+// prompting for what's missing in interactive mode. It returns false when
+// the user cancels the edit.
+func composeSendFields(
+	fields *emailTemplate,
+	args []string,
+	edit, interactive bool,
+	templateOpts hostedTemplateSendOptions,
+) (bool, error) {
+	if edit {
+		edited, err := editEmailTemplate(fields, true)
+		if err != nil || edited == nil {
+			return false, err
+		}
+		*fields = *edited
+		return true, nil
+	}
+	if !shouldUseInteractiveSendMode(interactive, fields.To, fields.Subject, fields.Body, templateOpts) {
+		return true, nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	if len(fields.To) == 0 && !templateOpts.RenderOnly {
+		fmt.Print("To (comma-separated): ")
+		input, _ := reader.ReadString('\n')
+		fields.To = expandRecipients(args, parseEmails(strings.TrimSpace(input)))
+	}
+	if templateOpts.TemplateID == "" && fields.Subject == "" {
+		fmt.Print("Subject: ")
+		subject, _ := reader.ReadString('\n')
+		fields.Subject = strings.TrimSpace(subject)
+	}
+	if templateOpts.TemplateID == "" && fields.Body == "" {
+		fmt.Println("Body (end with a line containing only '.'):")
+		fields.Body = readBodyLines(reader)
+	}
+	return true, nil
+}
@@ -0,0 +1,186 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/fileshare"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newAttachmentUploader returns the configured uploader, or nil when
+// email.attachment_upload_url is not set. Replaced in tests.
+var newAttachmentUploader = func() ports.AttachmentUploader {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil || cfg.Email == nil || cfg.Email.AttachmentUploadURL == "" {
+		return nil
+	}
+	return fileshare.New(cfg.Email.AttachmentUploadURL)
+}
+
+// messageSizeOptions controls the pre-send size check.
+type messageSizeOptions struct {
+	LinkAttachments bool // always send attachments as links
+	SkipCheck       bool // don't enforce the provider limit
+	Prompt          bool // offer links interactively when over the limit
+	Encrypted       bool // the message is encrypted, so attachments are never linked
+}
+
+// enforceMessageSize checks req against the grant provider's message size
+// limit before sending. Over the limit, it offers to upload the attachments
+// and send links instead (when an upload URL is configured and prompting is
+// allowed), or fails with guidance. With LinkAttachments it always links.
+//
+// Encrypted messages never link: the uploaded copies would be unencrypted.
+func enforceMessageSize(ctx context.Context, grant *domain.Grant, req *domain.SendMessageRequest, opts messageSizeOptions) error {
+	if opts.LinkAttachments && opts.Encrypted {
+		return common.NewUserError("--link-attachments can't be used with encryption",
+			"Uploaded attachments are stored unencrypted; attach them to the encrypted message instead")
+	}
+	if opts.LinkAttachments {
+		return linkAttachments(ctx, req)
+	}
+	if opts.SkipCheck {
+		return nil
+	}
+
+	var provider domain.Provider
+	if grant != nil {
+		provider = grant.Provider
+	}
+	limit := domain.MessageSizeLimit(provider)
+	size := domain.EstimateMessageSize(req.Body, req.Attachments)
+	if size <= limit {
+		return nil
+	}
+
+	providerName := "the provider"
+	if provider != "" {
+		providerName = provider.DisplayName()
+	}
+	summary := fmt.Sprintf("message is about %s encoded, over %s's %s limit",
+		common.FormatSize(size), providerName, common.FormatSize(limit))
+
+	canLink := !opts.Encrypted && !common.IsDemoMode()
+	if canLink && opts.Prompt && hasLinkableAttachments(req) && newAttachmentUploader() != nil {
+		fmt.Printf("\n%s %s.\n", common.Yellow.Sprint("⚠"), capitalize(summary))
+		if common.Confirm("Upload the attachments and send links instead?", false) {
+			return linkAttachments(ctx, req)
+		}
+	}
+
+	suggestions := []string{}
+	if largest := largestAttachments(req.Attachments, 3); largest != "" {
+		suggestions = append(suggestions, "Largest attachments: "+largest)
+	}
+	if canLink {
+		suggestions = append(suggestions,
+			"Send attachments as links with --link-attachments (set the upload endpoint with: nylas config set email.attachment_upload_url <url>)")
+	}
+	suggestions = append(suggestions,
+		"Compress or split the attachments across several messages",
+		"If your mail server accepts larger messages, skip the check with --skip-size-check",
+	)
+	return common.NewUserErrorWithSuggestions(summary, suggestions...)
+}
+
+// linkAttachments uploads every non-inline attachment and replaces it with a
+// link at the end of the body. Inline attachments stay, since the body
+// references them by content ID.
+func linkAttachments(ctx context.Context, req *domain.SendMessageRequest) error {
+	if !hasLinkableAttachments(req) {
+		return nil
+	}
+	if common.IsDemoMode() {
+		return common.NewUserError("attachments are not uploaded in demo mode", "Drop --link-attachments")
+	}
+	uploader := newAttachmentUploader()
+	if uploader == nil {
+		return common.NewUserError(
+			"no attachment upload endpoint configured",
+			"Set one with: nylas config set email.attachment_upload_url <url> (it receives PUT <url>/<filename> and returns a link)",
+		)
+	}
+
+	type link struct {
+		att domain.Attachment
+		url string
+	}
+	var (
+		links []link
+		kept  []domain.Attachment
+	)
+	uploads := 0
+	for _, att := range req.Attachments {
+		if !att.IsInline {
+			uploads++
+		}
+	}
+	progress := common.NewProgressBar("Uploading attachments", uploads)
+	for _, att := range req.Attachments {
+		if att.IsInline {
+			kept = append(kept, att)
+			continue
+		}
+		url, err := uploader.Upload(ctx, att.Filename, att.ContentType, att.Content)
+		if err != nil {
+			progress.Finish()
+			return common.WrapError(fmt.Errorf("failed to upload attachment %q: %w", att.Filename, err))
+		}
+		links = append(links, link{att: att, url: url})
+		progress.Increment()
+	}
+	progress.Finish()
+
+	if htmlTagPattern.MatchString(req.Body) {
+		var b strings.Builder
+		b.WriteString(req.Body)
+		b.WriteString("<br><br>\n<p>Attachments:</p>\n<ul>\n")
+		for _, l := range links {
+			fmt.Fprintf(&b, "<li><a href=\"%s\">%s</a> (%s)</li>\n",
+				html.EscapeString(l.url), html.EscapeString(l.att.Filename), common.FormatSize(attachmentSize(l.att)))
+		}
+		b.WriteString("</ul>")
+		req.Body = b.String()
+	} else {
+		var b strings.Builder
+		b.WriteString(req.Body)
+		b.WriteString("\n\nAttachments:\n")
+		for _, l := range links {
+			fmt.Fprintf(&b, "- %s (%s): %s\n", l.att.Filename, common.FormatSize(attachmentSize(l.att)), l.url)
+		}
+		req.Body = b.String()
+	}
+	req.Attachments = kept
+	return nil
+}
+
+func hasLinkableAttachments(req *domain.SendMessageRequest) bool {
+	return slices.ContainsFunc(req.Attachments, func(a domain.Attachment) bool { return !a.IsInline })
+}
+
+func attachmentSize(a domain.Attachment) int64 {
+	if a.Size > 0 {
+		return a.Size
+	}
+	return int64(len(a.Content))
+}
+
+// largestAttachments lists up to n attachments, largest first.
+func largestAttachments(attachments []domain.Attachment, n int) string {
+	sorted := slices.Clone(attachments)
+	slices.SortFunc(sorted, func(a, b domain.Attachment) int {
+		return int(attachmentSize(b) - attachmentSize(a))
+	})
+	parts := make([]string, 0, n)
+	for _, a := range sorted[:min(n, len(sorted))] {
+		parts = append(parts, fmt.Sprintf("%s (%s)", a.Filename, common.FormatSize(attachmentSize(a))))
+	}
+	return strings.Join(parts, ", ")
+}
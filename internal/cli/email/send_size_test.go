@@ -0,0 +1,141 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+type fakeUploader struct {
+	uploaded []string
+	err      error
+}
+
+func (f *fakeUploader) Upload(_ context.Context, filename, _ string, _ []byte) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.uploaded = append(f.uploaded, filename)
+	return "https://files.example.com/" + filename, nil
+}
+
+func swapUploaderForTest(t *testing.T, uploader ports.AttachmentUploader) {
+	t.Helper()
+	original := newAttachmentUploader
+	newAttachmentUploader = func() ports.AttachmentUploader { return uploader }
+	t.Cleanup(func() { newAttachmentUploader = original })
+}
+
+func sizedAttachment(name string, size int64) domain.Attachment {
+	return domain.Attachment{Filename: name, ContentType: "application/octet-stream", Size: size, Content: make([]byte, 16)}
+}
+
+func TestEnforceMessageSize(t *testing.T) {
+	ctx := context.Background()
+	google := &domain.Grant{Provider: domain.ProviderGoogle}
+
+	t.Run("under the limit", func(t *testing.T) {
+		req := &domain.SendMessageRequest{Body: "hi", Attachments: []domain.Attachment{sizedAttachment("a.pdf", 10<<20)}}
+		assert.NoError(t, enforceMessageSize(ctx, google, req, messageSizeOptions{}))
+		assert.Len(t, req.Attachments, 1)
+	})
+
+	t.Run("over the limit after encoding", func(t *testing.T) {
+		// 20 MB raw is ~27 MB base64, over Gmail's 25 MB.
+		req := &domain.SendMessageRequest{Body: "hi", Attachments: []domain.Attachment{
+			sizedAttachment("small.txt", 1<<10),
+			sizedAttachment("video.mp4", 20<<20),
+		}}
+		err := enforceMessageSize(ctx, google, req, messageSizeOptions{})
+		var cliErr *common.CLIError
+		require.True(t, errors.As(err, &cliErr))
+		assert.Contains(t, cliErr.Message, "over Google's 25 MB limit")
+		assert.Contains(t, cliErr.Suggestions[0], "video.mp4 (20 MB), small.txt (1 KB)")
+	})
+
+	t.Run("same message fits Microsoft", func(t *testing.T) {
+		req := &domain.SendMessageRequest{Attachments: []domain.Attachment{sizedAttachment("video.mp4", 20<<20)}}
+		assert.NoError(t, enforceMessageSize(ctx, &domain.Grant{Provider: domain.ProviderMicrosoft}, req, messageSizeOptions{}))
+	})
+
+	t.Run("skip check", func(t *testing.T) {
+		req := &domain.SendMessageRequest{Attachments: []domain.Attachment{sizedAttachment("video.mp4", 40<<20)}}
+		assert.NoError(t, enforceMessageSize(ctx, google, req, messageSizeOptions{SkipCheck: true}))
+	})
+
+	t.Run("link attachments in plain text", func(t *testing.T) {
+		uploader := &fakeUploader{}
+		swapUploaderForTest(t, uploader)
+		inline := domain.Attachment{Filename: "logo.png", IsInline: true, ContentID: "logo"}
+		req := &domain.SendMessageRequest{Body: "See attached.", Attachments: []domain.Attachment{
+			sizedAttachment("video.mp4", 40<<20), inline,
+		}}
+
+		require.NoError(t, enforceMessageSize(ctx, google, req, messageSizeOptions{LinkAttachments: true}))
+		assert.Equal(t, []string{"video.mp4"}, uploader.uploaded)
+		assert.Equal(t, []domain.Attachment{inline}, req.Attachments)
+		assert.Equal(t, "See attached.\n\nAttachments:\n- video.mp4 (40 MB): https://files.example.com/video.mp4\n", req.Body)
+	})
+
+	t.Run("link attachments in HTML", func(t *testing.T) {
+		swapUploaderForTest(t, &fakeUploader{})
+		req := &domain.SendMessageRequest{Body: "<p>Hi</p>", Attachments: []domain.Attachment{sizedAttachment("a&b.pdf", 1<<20)}}
+
+		require.NoError(t, enforceMessageSize(ctx, google, req, messageSizeOptions{LinkAttachments: true}))
+		assert.Contains(t, req.Body, `<li><a href="https://files.example.com/a&amp;b.pdf">a&amp;b.pdf</a> (1 MB)</li>`)
+		assert.Empty(t, req.Attachments)
+	})
+
+	t.Run("link attachments without an uploader", func(t *testing.T) {
+		swapUploaderForTest(t, nil)
+		req := &domain.SendMessageRequest{Attachments: []domain.Attachment{sizedAttachment("a.pdf", 1<<20)}}
+		err := enforceMessageSize(ctx, google, req, messageSizeOptions{LinkAttachments: true})
+		assert.ErrorContains(t, err, "no attachment upload endpoint configured")
+	})
+
+	t.Run("upload failure", func(t *testing.T) {
+		swapUploaderForTest(t, &fakeUploader{err: errors.New("quota exceeded")})
+		req := &domain.SendMessageRequest{Attachments: []domain.Attachment{sizedAttachment("a.pdf", 1<<20)}}
+		err := enforceMessageSize(ctx, google, req, messageSizeOptions{LinkAttachments: true})
+		assert.ErrorContains(t, err, "a.pdf")
+		assert.Len(t, req.Attachments, 1, "request untouched on failure")
+	})
+
+	t.Run("encrypted messages are never linked", func(t *testing.T) {
+		uploader := &fakeUploader{}
+		swapUploaderForTest(t, uploader)
+		req := &domain.SendMessageRequest{Attachments: []domain.Attachment{sizedAttachment("a.pdf", 1<<20)}}
+		err := enforceMessageSize(ctx, google, req, messageSizeOptions{LinkAttachments: true, Encrypted: true})
+		assert.ErrorContains(t, err, "--link-attachments can't be used with encryption")
+
+		req = &domain.SendMessageRequest{Attachments: []domain.Attachment{sizedAttachment("video.mp4", 20<<20)}}
+		err = enforceMessageSize(ctx, google, req, messageSizeOptions{Prompt: true, Encrypted: true})
+		var cliErr *common.CLIError
+		require.True(t, errors.As(err, &cliErr))
+		for _, s := range cliErr.Suggestions {
+			assert.NotContains(t, s, "--link-attachments")
+		}
+		assert.Empty(t, uploader.uploaded)
+		assert.Len(t, req.Attachments, 1)
+	})
+
+	t.Run("no uploads in demo mode", func(t *testing.T) {
+		uploader := &fakeUploader{}
+		swapUploaderForTest(t, uploader)
+		common.EnableDemoMode(nylas.NewMockClient(), "demo-grant")
+		t.Cleanup(func() { common.EnableDemoMode(nil, "") })
+
+		req := &domain.SendMessageRequest{Attachments: []domain.Attachment{sizedAttachment("a.pdf", 1<<20)}}
+		err := enforceMessageSize(ctx, google, req, messageSizeOptions{LinkAttachments: true})
+		assert.ErrorContains(t, err, "demo mode")
+		assert.Empty(t, uploader.uploaded)
+	})
+}
@@ -0,0 +1,319 @@
+package email
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/mime"
+	"github.com/nylas/cli/internal/adapters/smime"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// smimePasswordEnv holds the PKCS#12 password for non-interactive use.
+const smimePasswordEnv = "NYLAS_SMIME_PASSWORD"
+
+// newSMIMEService is replaced in tests.
+var newSMIMEService = func() *smime.Service {
+	return smime.NewService(filepath.Join(config.DefaultConfigDir(), "smime"))
+}
+
+// smimeSendOptions are the S/MIME flags of email send.
+type smimeSendOptions struct {
+	sign           bool
+	encrypt        bool
+	certFile       string
+	recipientCerts []string
+	listCerts      bool
+}
+
+func (o *smimeSendOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.sign, "smime-sign", false, "Sign email with your S/MIME certificate")
+	cmd.Flags().BoolVar(&o.encrypt, "smime-encrypt", false, "Encrypt email with the recipients' S/MIME certificates")
+	cmd.Flags().StringVar(&o.certFile, "smime-cert", "", "PKCS#12 (.p12/.pfx) file to sign with (default: the sender's identity in ~/.config/nylas/smime)")
+	cmd.Flags().StringArrayVar(&o.recipientCerts, "smime-recipient-cert", nil, "Recipient certificate file for --smime-encrypt (can be repeated; default: looked up by email)")
+	cmd.Flags().BoolVar(&o.listCerts, "list-smime-certs", false, "List available S/MIME certificates and exit")
+}
+
+// enabled reports whether the message is S/MIME signed or encrypted.
+func (o *smimeSendOptions) enabled() bool {
+	return o.sign || o.encrypt
+}
+
+// flagName names the S/MIME flag in use, for error messages.
+func (o *smimeSendOptions) flagName() string {
+	if o.sign {
+		return "--smime-sign"
+	}
+	return "--smime-encrypt"
+}
+
+// validate rejects mixing S/MIME with GPG and dangling S/MIME options.
+func (o *smimeSendOptions) validate(gpgSign, gpgEncrypt bool) error {
+	if o.enabled() && gpgSign {
+		return common.NewMutuallyExclusiveError(o.flagName(), "--sign")
+	}
+	if o.enabled() && gpgEncrypt {
+		return common.NewMutuallyExclusiveError(o.flagName(), "--encrypt")
+	}
+	if o.certFile != "" && !o.sign {
+		return common.NewUserError("--smime-cert requires --smime-sign", "")
+	}
+	if len(o.recipientCerts) > 0 && !o.encrypt {
+		return common.NewUserError("--smime-recipient-cert requires --smime-encrypt", "")
+	}
+	return nil
+}
+
+// checkVia keeps S/MIME sends on the Nylas path, like GPG sends.
+func (o *smimeSendOptions) checkVia(via string) (string, error) {
+	if !o.enabled() || via == sendViaNylas {
+		return via, nil
+	}
+	if via == sendViaSMTP {
+		return "", common.NewUserError(
+			fmt.Sprintf("%s is not supported with --via smtp", o.flagName()),
+			"Send through Nylas with --via nylas, or drop "+o.flagName())
+	}
+	return sendViaNylas, nil
+}
+
+// validateGrant rejects S/MIME for Agent Account grants, which can't send
+// raw MIME.
+func (o *smimeSendOptions) validateGrant(grant *domain.Grant) error {
+	if !o.enabled() || !isNylasProviderGrant(grant) {
+		return nil
+	}
+	return common.NewUserError(
+		"`--smime-sign` and `--smime-encrypt` are not supported for Agent Account sends",
+		"Agent Account sends use per-grant JSON message send in the CLI; raw MIME S/MIME send is not supported for provider=nylas grants",
+	)
+}
+
+// smimePassword returns the PKCS#12 password from the environment or a
+// prompt. An empty password is valid for unprotected files.
+func smimePassword() (string, error) {
+	if pw, ok := os.LookupEnv(smimePasswordEnv); ok {
+		return pw, nil
+	}
+	return common.PasswordPrompt("S/MIME certificate password")
+}
+
+// handleListSMIMECerts lists the S/MIME certificates and identities
+// available for sending.
+func handleListSMIMECerts(ctx context.Context) error {
+	svc := newSMIMEService()
+	if err := svc.CheckOpenSSLAvailable(ctx); err != nil {
+		return err
+	}
+	password, err := smimePassword()
+	if err != nil {
+		return err
+	}
+	certs, err := svc.ListCertificates(ctx, password)
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		fmt.Printf("No S/MIME certificates found in %s.\n", svc.Dir())
+		fmt.Println("\nCopy your PKCS#12 identity (.p12/.pfx) and recipients' certificates (.pem/.crt/.cer) there.")
+		return nil
+	}
+
+	fmt.Printf("Available S/MIME certificates (%d):\n\n", len(certs))
+	for i, cert := range certs {
+		kind := "certificate"
+		if ext := strings.ToLower(filepath.Ext(cert.Source)); ext == ".p12" || ext == ".pfx" {
+			kind = "identity (can sign)"
+		}
+		fmt.Printf("%d. %s [%s]\n", i+1, cert.Subject, kind)
+		if len(cert.Emails) > 0 {
+			fmt.Printf("   Email: %s\n", strings.Join(cert.Emails, ", "))
+		}
+		fmt.Printf("   Issuer: %s\n", cert.Issuer)
+		fmt.Printf("   Expires: %s\n", cert.NotAfter.Format("2006-01-02"))
+		fmt.Printf("   File: %s\n", cert.Source)
+		fmt.Println()
+	}
+	return nil
+}
+
+// sendSMIMEEmail sends an email with S/MIME signing and/or encryption.
+// Signed and encrypted messages are signed first, then the signed entity is
+// encrypted (RFC 8551 Section 3.7).
+func sendSMIMEEmail(ctx context.Context, client ports.NylasClient, grantID string, req *domain.SendMessageRequest, toContacts []domain.EmailParticipant, subject, body string, opts *smimeSendOptions) (*domain.Message, error) {
+	svc := newSMIMEService()
+	if err := svc.CheckOpenSSLAvailable(ctx); err != nil {
+		return nil, err
+	}
+
+	var senderEmail string
+	if len(req.From) > 0 {
+		senderEmail = req.From[0].Email
+	}
+
+	var identity *smime.Identity
+	if opts.sign {
+		password, err := smimePassword()
+		if err != nil {
+			return nil, err
+		}
+		identity, err = resolveSMIMEIdentity(ctx, svc, opts.certFile, senderEmail, password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	contentType := "text/plain"
+	if strings.Contains(strings.ToLower(body), "<html") {
+		contentType = "text/html"
+	}
+	mimeBuilder := mime.NewBuilder()
+	entity, err := mimeBuilder.PrepareContentToSign(body, contentType, req.Attachments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare content: %w", err)
+	}
+
+	if opts.sign {
+		signature, err := common.RunWithSpinnerResult("Signing email with S/MIME certificate...", func() ([]byte, error) {
+			return svc.Sign(ctx, identity, entity)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if entity, err = mimeBuilder.BuildSMIMESignedEntity(entity, signature); err != nil {
+			return nil, fmt.Errorf("failed to build S/MIME signed entity: %w", err)
+		}
+	}
+
+	if opts.encrypt {
+		certs, err := resolveSMIMERecipients(ctx, svc, opts.recipientCerts, toContacts, req.Cc, req.Bcc)
+		if err != nil {
+			return nil, err
+		}
+		// Include the sender so the copy in Sent stays readable.
+		if identity != nil {
+			certs = append(certs, identity.Certificate)
+		} else if senderEmail != "" {
+			if cert, _, err := svc.FindCertificate(ctx, senderEmail); err == nil {
+				certs = append(certs, cert)
+			}
+		}
+		result, err := common.RunWithSpinnerResult("Encrypting email...", func() (*smime.EncryptResult, error) {
+			return svc.Encrypt(ctx, certs, entity)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if entity, err = mimeBuilder.BuildSMIMEEnvelopedEntity(result.Ciphertext); err != nil {
+			return nil, fmt.Errorf("failed to build S/MIME encrypted entity: %w", err)
+		}
+	}
+
+	rawMIME, err := mimeBuilder.BuildSMIMEMessage(&mime.SMIMEMessageRequest{
+		From:    req.From,
+		To:      toContacts,
+		Cc:      req.Cc,
+		Bcc:     req.Bcc,
+		ReplyTo: req.ReplyTo,
+		Subject: subject,
+		Entity:  entity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build MIME message: %w", err)
+	}
+
+	return common.RunWithSpinnerResult("Sending S/MIME email...", func() (*domain.Message, error) {
+		return client.SendRawMessage(ctx, grantID, rawMIME)
+	})
+}
+
+// resolveSMIMEIdentity loads the signing identity from --smime-cert or finds
+// the sender's identity in the S/MIME directory.
+func resolveSMIMEIdentity(ctx context.Context, svc *smime.Service, certFile, senderEmail, password string) (*smime.Identity, error) {
+	if certFile != "" {
+		identity, err := svc.LoadIdentity(ctx, certFile, password)
+		if err != nil {
+			return nil, common.NewUserError(err.Error(), "Check the file and set "+smimePasswordEnv+" or enter the password when prompted")
+		}
+		if senderEmail != "" && !certificateHasEmail(identity.Certificate, senderEmail) {
+			common.PrintWarningStderr("S/MIME certificate is not issued to %s; recipients may flag the signature", senderEmail)
+		}
+		return identity, nil
+	}
+	if senderEmail == "" {
+		return nil, common.NewUserError("could not determine the sender address to find an S/MIME certificate",
+			"Pass the identity file with --smime-cert")
+	}
+	identity, err := svc.FindIdentity(ctx, senderEmail, password)
+	if err != nil {
+		return nil, common.NewUserError(err.Error(),
+			fmt.Sprintf("Copy your .p12/.pfx file to %s or pass it with --smime-cert", svc.Dir()))
+	}
+	return identity, nil
+}
+
+// resolveSMIMERecipients returns a certificate for every recipient: from
+// --smime-recipient-cert files first, then the S/MIME directory or keychain.
+func resolveSMIMERecipients(ctx context.Context, svc *smime.Service, files []string, to, cc, bcc []domain.EmailParticipant) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for _, path := range files {
+		loaded, err := smime.LoadCertificates(path)
+		if err != nil {
+			return nil, common.WrapLoadError("recipient certificate", err)
+		}
+		certs = append(certs, loaded...)
+	}
+
+	var missing []string
+	for _, list := range [][]domain.EmailParticipant{to, cc, bcc} {
+		for _, p := range list {
+			if p.Email == "" || hasCertificateFor(certs, p.Email) {
+				continue
+			}
+			cert, _, err := svc.FindCertificate(ctx, p.Email)
+			if errors.Is(err, smime.ErrNoCertificate) {
+				missing = append(missing, p.Email)
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, cert)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, common.NewUserError(
+			fmt.Sprintf("no S/MIME certificate for %s", strings.Join(missing, ", ")),
+			fmt.Sprintf("Ask them for a signed email or their certificate, then pass it with --smime-recipient-cert or copy it to %s", svc.Dir()),
+		)
+	}
+	return certs, nil
+}
+
+func hasCertificateFor(certs []*x509.Certificate, email string) bool {
+	for _, cert := range certs {
+		if certificateHasEmail(cert, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func certificateHasEmail(cert *x509.Certificate, email string) bool {
+	for _, e := range smime.Info(cert, "").Emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/adapters/smime"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// writeSMIMEIdentity writes a self-signed certificate for email to dir as
+// <name>.pem and <name>.p12 with an empty password.
+func writeSMIMEIdentity(t *testing.T, dir, name, email string) {
+	t.Helper()
+	if _, err := exec.LookPath("openssl"); err != nil {
+		t.Skip("openssl not available")
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(time.Now().UnixNano()),
+		Subject:        pkix.Name{CommonName: name},
+		EmailAddresses: []string{email},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(24 * time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, name+".pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	keyPath := filepath.Join(t.TempDir(), name+".key")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+	out, err := exec.Command("openssl", "pkcs12", "-export", "-in", certPath, "-inkey", keyPath,
+		"-passout", "pass:", "-out", filepath.Join(dir, name+".p12")).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func useSMIMEDir(t *testing.T, dir string) {
+	t.Helper()
+	orig := newSMIMEService
+	newSMIMEService = func() *smime.Service { return smime.NewService(dir) }
+	t.Cleanup(func() { newSMIMEService = orig })
+	t.Setenv(smimePasswordEnv, "")
+}
+
+func TestSMIMESendOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    smimeSendOptions
+		gpgSign bool
+		gpgEnc  bool
+		wantErr string
+	}{
+		{name: "none", opts: smimeSendOptions{}},
+		{name: "sign and encrypt", opts: smimeSendOptions{sign: true, encrypt: true}},
+		{name: "with gpg sign", opts: smimeSendOptions{sign: true}, gpgSign: true, wantErr: "--smime-sign"},
+		{name: "with gpg encrypt", opts: smimeSendOptions{encrypt: true}, gpgEnc: true, wantErr: "--smime-encrypt"},
+		{name: "cert without sign", opts: smimeSendOptions{encrypt: true, certFile: "me.p12"}, wantErr: "--smime-cert requires --smime-sign"},
+		{name: "recipient cert without encrypt", opts: smimeSendOptions{sign: true, recipientCerts: []string{"bob.pem"}}, wantErr: "--smime-recipient-cert requires --smime-encrypt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate(tt.gpgSign, tt.gpgEnc)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSMIMESendOptions_CheckViaAndGrant(t *testing.T) {
+	opts := &smimeSendOptions{sign: true}
+
+	via, err := opts.checkVia(sendViaAuto)
+	require.NoError(t, err)
+	assert.Equal(t, sendViaNylas, via)
+
+	_, err = opts.checkVia(sendViaSMTP)
+	assert.ErrorContains(t, err, "--smime-sign is not supported with --via smtp")
+
+	via, err = (&smimeSendOptions{}).checkVia(sendViaSMTP)
+	require.NoError(t, err)
+	assert.Equal(t, sendViaSMTP, via)
+
+	assert.Error(t, opts.validateGrant(&domain.Grant{Provider: domain.ProviderNylas}))
+	assert.NoError(t, opts.validateGrant(&domain.Grant{Provider: domain.ProviderGoogle}))
+}
+
+func TestResolveSMIMERecipients_Missing(t *testing.T) {
+	dir := t.TempDir()
+	writeSMIMEIdentity(t, dir, "bob", "bob@example.com")
+	svc := smime.NewService(dir)
+
+	certs, err := resolveSMIMERecipients(context.Background(), svc, nil,
+		[]domain.EmailParticipant{{Email: "bob@example.com"}}, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, certs, 1)
+
+	_, err = resolveSMIMERecipients(context.Background(), svc, nil,
+		[]domain.EmailParticipant{{Email: "bob@example.com"}}, []domain.EmailParticipant{{Email: "carol@example.com"}}, nil)
+	assert.ErrorContains(t, err, "no S/MIME certificate for carol@example.com")
+
+	// An explicit certificate file covers its address.
+	certs, err = resolveSMIMERecipients(context.Background(), smime.NewService(t.TempDir()), []string{filepath.Join(dir, "bob.pem")},
+		[]domain.EmailParticipant{{Email: "bob@example.com"}}, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, certs, 1)
+}
+
+func TestSendSMIMEEmail_SignedRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	writeSMIMEIdentity(t, dir, "alice", "alice@example.com")
+	useSMIMEDir(t, dir)
+
+	var raw []byte
+	client := nylas.NewMockClient()
+	client.SendRawMessageFunc = func(_ context.Context, _ string, rawMIME []byte) (*domain.Message, error) {
+		raw = rawMIME
+		return &domain.Message{ID: "msg-1"}, nil
+	}
+	req := &domain.SendMessageRequest{
+		From: []domain.EmailParticipant{{Email: "alice@example.com"}},
+		To:   []domain.EmailParticipant{{Email: "bob@example.com"}},
+	}
+
+	msg, err := sendSMIMEEmail(context.Background(), client, "grant-1", req, req.To, "Hello", "Signed body", &smimeSendOptions{sign: true})
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", msg.ID)
+	require.True(t, isSMIMESigned(string(raw)))
+
+	content, signature, err := parseSMIMESigned(string(raw))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Signed body")
+	result, err := newSMIMEService().Verify(context.Background(), content, signature)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "alice@example.com", result.SignerEmail)
+	assert.Equal(t, "alice@example.com", messageSender(string(raw)))
+}
+
+func TestSendSMIMEEmail_Encrypted(t *testing.T) {
+	dir := t.TempDir()
+	writeSMIMEIdentity(t, dir, "alice", "alice@example.com")
+	writeSMIMEIdentity(t, dir, "bob", "bob@example.com")
+	useSMIMEDir(t, dir)
+
+	var raw []byte
+	client := nylas.NewMockClient()
+	client.SendRawMessageFunc = func(_ context.Context, _ string, rawMIME []byte) (*domain.Message, error) {
+		raw = rawMIME
+		return &domain.Message{ID: "msg-2"}, nil
+	}
+	req := &domain.SendMessageRequest{
+		From: []domain.EmailParticipant{{Email: "alice@example.com"}},
+		To:   []domain.EmailParticipant{{Email: "bob@example.com"}},
+	}
+
+	_, err := sendSMIMEEmail(context.Background(), client, "grant-1", req, req.To, "Secret", "Hidden body",
+		&smimeSendOptions{sign: true, encrypt: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "smime-type=enveloped-data")
+	assert.NotContains(t, string(raw), "Hidden body")
+
+	_, err = sendSMIMEEmail(context.Background(), client, "grant-1", req,
+		[]domain.EmailParticipant{{Email: "dave@example.com"}}, "Secret", "Hidden body", &smimeSendOptions{encrypt: true})
+	assert.ErrorContains(t, err, "dave@example.com")
+}
+
+func TestIsSMIMESigned(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{`multipart/signed; protocol="application/pkcs7-signature"; micalg=sha-256; boundary="b"`, true},
+		{`multipart/signed; protocol="application/x-pkcs7-signature"; boundary="b"`, true},
+		{`application/pkcs7-mime; smime-type=signed-data; name=smime.p7m`, true},
+		{`application/pkcs7-mime; smime-type=enveloped-data; name=smime.p7m`, false},
+		{`multipart/signed; protocol="application/pgp-signature"; boundary="b"`, false},
+		{`text/plain`, false},
+	}
+	for _, tt := range tests {
+		raw := "From: a@example.com\r\nContent-Type: " + tt.contentType + "\r\n\r\nbody"
+		assert.Equal(t, tt.want, isSMIMESigned(raw), tt.contentType)
+	}
+}
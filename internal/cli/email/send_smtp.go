@@ -0,0 +1,343 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/adapters/mime"
+	"github.com/nylas/cli/internal/adapters/smtprelay"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Delivery paths for `email send --via`.
+const (
+	sendViaNylas = "nylas"
+	sendViaSMTP  = "smtp"
+	sendViaAuto  = "auto" // Nylas, falling back to SMTP when unreachable or rate limited
+)
+
+// smtpPasswordEnv overrides the keyring secret, for CI and containers.
+const smtpPasswordEnv = "NYLAS_SMTP_PASSWORD"
+
+// htmlBodyPattern spots bodies that should be sent as text/html over SMTP.
+// The Nylas API sniffs this itself.
+var htmlBodyPattern = regexp.MustCompile(`(?i)<(html|body|p|div|br|a|table|span|b|i|strong|em|ul|ol|li|h[1-6])[\s/>]`)
+
+// smtpDelivery records how a message left the CLI.
+type smtpDelivery struct {
+	Message        *domain.Message
+	Transport      string // "nylas" or "smtp"
+	Relay          string // host:port when Transport is "smtp"
+	FallbackReason string // why Nylas was skipped in auto mode
+}
+
+// loadSMTPConfig returns the configured relay settings, or nil.
+func loadSMTPConfig() *domain.SMTPConfig {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil || cfg == nil || cfg.Email == nil {
+		return nil
+	}
+	return cfg.Email.SMTP
+}
+
+// loadSMTPSecret returns the relay password or XOAUTH2 token from
+// NYLAS_SMTP_PASSWORD or the keyring. A missing secret is not an error:
+// some relays accept unauthenticated mail from trusted networks.
+func loadSMTPSecret() (string, error) {
+	if secret := os.Getenv(smtpPasswordEnv); secret != "" {
+		return secret, nil
+	}
+	store, err := keyring.NewSecretStore(configAdapter.DefaultConfigDir())
+	if err != nil {
+		return "", common.WrapLoadError("SMTP credentials", err)
+	}
+	secret, err := store.Get(ports.KeySMTPSecret)
+	if err != nil && !errors.Is(err, domain.ErrSecretNotFound) {
+		return "", common.WrapLoadError("SMTP credentials", err)
+	}
+	return secret, nil
+}
+
+// loadSMTPRelay returns a transport for the configured relay. Replaced in tests.
+var loadSMTPRelay = func() (ports.MailTransport, *domain.SMTPConfig, error) {
+	if common.IsDemoMode() {
+		return nil, nil, common.NewUserError("SMTP relays are not used in demo mode", "Drop --via smtp")
+	}
+	cfg := loadSMTPConfig()
+	if cfg == nil || cfg.Host == "" {
+		return nil, nil, common.NewUserError("no SMTP relay configured",
+			"Run 'nylas email smtp setup --host smtp.example.com --username you@example.com'")
+	}
+	secret, err := loadSMTPSecret()
+	if err != nil {
+		return nil, nil, err
+	}
+	return smtprelay.New(*cfg, secret), cfg, nil
+}
+
+// resolveSendVia validates --via. When the flag is unset, sending falls
+// back to SMTP automatically if email.smtp.fallback is enabled.
+func resolveSendVia(via string) (string, error) {
+	if err := common.ValidateOneOf("--via", via, []string{sendViaNylas, sendViaSMTP, sendViaAuto}); err != nil {
+		return "", err
+	}
+	if via != "" {
+		return via, nil
+	}
+	if cfg := loadSMTPConfig(); cfg != nil && cfg.Host != "" && cfg.Fallback {
+		return sendViaAuto, nil
+	}
+	return sendViaNylas, nil
+}
+
+// smtpUnsupportedOption names the first requested feature that only the
+// Nylas API provides, or "" when the message can go over SMTP.
+func smtpUnsupportedOption(req *domain.SendMessageRequest, sign, encrypt bool) string {
+	switch {
+	case sign:
+		return "--sign"
+	case encrypt:
+		return "--encrypt"
+	case req.SendAt != 0:
+		return "--schedule"
+	case req.TrackingOpts != nil:
+		return "tracking"
+	case len(req.Metadata) > 0:
+		return "--metadata"
+	case req.SignatureID != "":
+		return "--signature-id"
+	case req.ReplyToMsgID != "":
+		return "--reply-to"
+	default:
+		return ""
+	}
+}
+
+// checkSendVia rejects --via smtp for Nylas-only features and quietly
+// disables the fallback for them in auto mode.
+func checkSendVia(via string, req *domain.SendMessageRequest, sign, encrypt bool) (string, error) {
+	opt := smtpUnsupportedOption(req, sign, encrypt)
+	if opt == "" || via == sendViaNylas {
+		return via, nil
+	}
+	if via == sendViaSMTP {
+		return "", common.NewUserError(
+			fmt.Sprintf("%s is not supported with --via smtp", opt),
+			"Send through Nylas with --via nylas, or drop "+opt)
+	}
+	return sendViaNylas, nil
+}
+
+// shouldFallback reports whether a Nylas send error warrants retrying over
+// SMTP. Only errors where Nylas provably never accepted the message qualify:
+// a rate limit, or a connection that was never made (DNS failure, connection
+// refused). After a server error or a timeout the message may already be on
+// its way, and sending it again over SMTP would deliver it twice.
+func shouldFallback(err error) (string, bool) {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return "", false
+	}
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusTooManyRequests {
+			return "Nylas rate limit (HTTP 429)", true
+		}
+		return "", false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return "Nylas unreachable (DNS lookup failed)", true
+	}
+	var opErr *net.OpError
+	if errors.Is(err, syscall.ECONNREFUSED) || (errors.As(err, &opErr) && opErr.Op == "dial") {
+		return "Nylas unreachable (connection failed)", true
+	}
+	return "", false
+}
+
+// getGrantForDelivery fetches the grant, tolerating a Nylas outage when the
+// message can still go out over SMTP. The grant is then nil. Unlike a send,
+// a failed lookup can't have delivered anything, so server errors and
+// timeouts are tolerated too.
+func getGrantForDelivery(ctx context.Context, client ports.NylasClient, grantID, via string) (*domain.Grant, error) {
+	grant, err := getGrantForSend(ctx, client, grantID)
+	if err != nil && via != sendViaNylas && isOutage(err) {
+		return nil, nil
+	}
+	return grant, err
+}
+
+// isOutage reports whether err means Nylas is down or rate limited rather
+// than rejecting the request.
+func isOutage(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *domain.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return errors.Is(err, domain.ErrNetworkError) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// deliverMessage sends req through Nylas, the SMTP relay, or Nylas with
+// SMTP fallback, and records the path taken in the audit log.
+func deliverMessage(
+	ctx context.Context,
+	client ports.NylasClient,
+	grantID string,
+	grant *domain.Grant,
+	req *domain.SendMessageRequest,
+	via string,
+) (*smtpDelivery, error) {
+	var reason string
+	if via != sendViaSMTP {
+		msg, err := sendMessageForGrant(ctx, client, grantID, grant, req)
+		var ok bool
+		if reason, ok = shouldFallback(err); via == sendViaNylas || !ok {
+			if err == nil {
+				common.RecordAuditDetail("transport", sendViaNylas)
+			}
+			return &smtpDelivery{Message: msg, Transport: sendViaNylas}, err
+		}
+		common.RecordAuditDetail("fallback_reason", reason)
+	}
+
+	relay, cfg, err := loadSMTPRelay()
+	if err != nil {
+		return nil, err
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// The Nylas attempt used up the command's deadline; give the relay
+		// its own.
+		var cancel context.CancelFunc
+		ctx, cancel = common.CreateContext()
+		defer cancel()
+	}
+	msg, err := sendSMTPMessage(ctx, relay, cfg, grant, req)
+	if err != nil {
+		if reason != "" {
+			return nil, fmt.Errorf("%s, and SMTP fallback via %s failed: %w", reason, relay.Name(), err)
+		}
+		return nil, fmt.Errorf("SMTP relay %s: %w", relay.Name(), err)
+	}
+	common.RecordAuditDetail("transport", sendViaSMTP)
+	common.RecordAuditDetail("smtp_relay", relay.Name())
+	return &smtpDelivery{Message: msg, Transport: sendViaSMTP, Relay: relay.Name(), FallbackReason: reason}, nil
+}
+
+// sendSMTPMessage builds req as MIME and hands it to relay. The returned
+// message's ID is the generated Message-ID header, since there is no Nylas
+// message ID.
+func sendSMTPMessage(
+	ctx context.Context,
+	relay ports.MailTransport,
+	cfg *domain.SMTPConfig,
+	grant *domain.Grant,
+	req *domain.SendMessageRequest,
+) (*domain.Message, error) {
+	from := smtpSender(cfg, grant, req)
+	if from == "" {
+		return nil, common.NewUserError("no sender address for SMTP",
+			"Set one with 'nylas email smtp setup --from you@example.com'")
+	}
+
+	contentType := "text/plain"
+	if htmlBodyPattern.MatchString(req.Body) {
+		contentType = "text/html"
+	}
+	messageID := generateMessageID(from)
+	now := time.Now()
+
+	raw, err := mime.NewBuilder().BuildMessage(&mime.MessageRequest{
+		From:        []domain.EmailParticipant{{Email: from}},
+		To:          req.To,
+		Cc:          req.Cc,
+		Bcc:         req.Bcc,
+		ReplyTo:     req.ReplyTo,
+		Subject:     req.Subject,
+		Body:        req.Body,
+		ContentType: contentType,
+		Attachments: req.Attachments,
+		MessageID:   messageID,
+		Date:        now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []string
+	for _, list := range [][]domain.EmailParticipant{req.To, req.Cc, req.Bcc} {
+		for _, p := range list {
+			recipients = append(recipients, p.Email)
+		}
+	}
+	if err := relay.Send(ctx, from, recipients, raw); err != nil {
+		return nil, err
+	}
+
+	return &domain.Message{
+		ID:      "<" + messageID + ">",
+		Subject: req.Subject,
+		From:    []domain.EmailParticipant{{Email: from}},
+		To:      req.To,
+		Cc:      req.Cc,
+		Bcc:     req.Bcc,
+		Date:    now,
+	}, nil
+}
+
+// smtpSender picks the From address: an explicit From on the request, the
+// relay's configured sender, the grant's email, then the SMTP username.
+func smtpSender(cfg *domain.SMTPConfig, grant *domain.Grant, req *domain.SendMessageRequest) string {
+	switch {
+	case len(req.From) > 0 && req.From[0].Email != "":
+		return req.From[0].Email
+	case cfg.From != "":
+		return cfg.From
+	case grant != nil && grant.Email != "":
+		return grant.Email
+	case strings.Contains(cfg.Username, "@"):
+		return cfg.Username
+	default:
+		return ""
+	}
+}
+
+// generateMessageID returns a unique Message-ID (without angle brackets)
+// in the sender's domain.
+func generateMessageID(from string) string {
+	domainPart := "nylas-cli.local"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domainPart = from[at+1:]
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b) + "@" + domainPart
+}
+
+// printDeliveryNote tells the user when a message went out over SMTP.
+func printDeliveryNote(d *smtpDelivery) {
+	if d == nil || d.Transport != sendViaSMTP {
+		return
+	}
+	if d.FallbackReason != "" {
+		common.PrintWarningStderr("%s; sent via SMTP relay %s instead", d.FallbackReason, d.Relay)
+		return
+	}
+	fmt.Printf("Sent via SMTP relay %s\n", d.Relay)
+}
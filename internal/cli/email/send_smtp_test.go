@@ -0,0 +1,314 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// fakeTransport records what would have been sent over SMTP.
+type fakeTransport struct {
+	err        error
+	from       string
+	recipients []string
+	raw        string
+	sent       int
+	ctxErr     error
+}
+
+func (f *fakeTransport) Name() string                 { return "smtp.example.com:587" }
+func (f *fakeTransport) Verify(context.Context) error { return f.err }
+
+func (f *fakeTransport) Send(ctx context.Context, from string, recipients []string, raw []byte) error {
+	f.sent++
+	f.ctxErr = ctx.Err()
+	f.from, f.recipients, f.raw = from, recipients, string(raw)
+	return f.err
+}
+
+// useFakeRelay swaps in transport and captures audit details.
+func useFakeRelay(t *testing.T, transport *fakeTransport, cfg *domain.SMTPConfig) map[string]string {
+	t.Helper()
+	details := map[string]string{}
+
+	origLoad, origHook := loadSMTPRelay, common.AuditDetailHook
+	loadSMTPRelay = func() (ports.MailTransport, *domain.SMTPConfig, error) {
+		return transport, cfg, nil
+	}
+	common.AuditDetailHook = func(key, value string) { details[key] = value }
+	t.Cleanup(func() {
+		loadSMTPRelay, common.AuditDetailHook = origLoad, origHook
+	})
+	return details
+}
+
+func testSendRequest() *domain.SendMessageRequest {
+	return &domain.SendMessageRequest{
+		Subject: "Status",
+		Body:    "<p>All good</p>",
+		To:      []domain.EmailParticipant{{Email: "to@example.com"}},
+		Cc:      []domain.EmailParticipant{{Email: "cc@example.com"}},
+		Bcc:     []domain.EmailParticipant{{Email: "bcc@example.com"}},
+	}
+}
+
+func TestShouldFallback(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"success", nil, false},
+		{"rate limited", &domain.APIError{StatusCode: 429}, true},
+		{"server error may have sent", fmt.Errorf("wrapped: %w", &domain.APIError{StatusCode: 503}), false},
+		{"bad request", &domain.APIError{StatusCode: 400}, false},
+		{"unauthorized", &domain.APIError{StatusCode: 401}, false},
+		{"connection refused", fmt.Errorf("%w: %w", domain.ErrNetworkError, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), true},
+		{"dns failure", fmt.Errorf("%w: %w", domain.ErrNetworkError, &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "api.us.nylas.com"}}), true},
+		{"connection reset mid-request", fmt.Errorf("%w: %w", domain.ErrNetworkError, &net.OpError{Op: "read", Err: syscall.ECONNRESET}), false},
+		{"network error without cause", fmt.Errorf("%w: connection reset", domain.ErrNetworkError), false},
+		{"timeout", fmt.Errorf("%w: %w", domain.ErrNetworkError, context.DeadlineExceeded), false},
+		{"cancelled", context.Canceled, false},
+		{"other", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := shouldFallback(tt.err)
+			assert.Equal(t, tt.want, ok)
+			assert.Equal(t, tt.want, reason != "")
+		})
+	}
+}
+
+func TestCheckSendVia(t *testing.T) {
+	plain := testSendRequest()
+	scheduled := testSendRequest()
+	scheduled.SendAt = 1700000000
+
+	via, err := checkSendVia(sendViaSMTP, plain, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, sendViaSMTP, via)
+
+	_, err = checkSendVia(sendViaSMTP, scheduled, false, false)
+	assert.ErrorContains(t, err, "--schedule is not supported with --via smtp")
+
+	_, err = checkSendVia(sendViaSMTP, plain, true, false)
+	assert.ErrorContains(t, err, "--sign")
+
+	via, err = checkSendVia(sendViaAuto, scheduled, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, sendViaNylas, via, "auto mode drops the fallback for Nylas-only features")
+
+	via, err = checkSendVia(sendViaAuto, plain, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, sendViaAuto, via)
+}
+
+func TestResolveSendVia_RejectsUnknownValue(t *testing.T) {
+	_, err := resolveSendVia("pigeon")
+	assert.Error(t, err)
+
+	via, err := resolveSendVia(sendViaSMTP)
+	require.NoError(t, err)
+	assert.Equal(t, sendViaSMTP, via)
+}
+
+func TestDeliverMessage(t *testing.T) {
+	grant := &domain.Grant{Email: "me@example.com", Provider: domain.ProviderGoogle}
+
+	t.Run("nylas success does not touch the relay", func(t *testing.T) {
+		transport := &fakeTransport{}
+		details := useFakeRelay(t, transport, &domain.SMTPConfig{Host: "smtp.example.com"})
+		client := nylas.NewMockClient()
+		client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+			return &domain.Message{ID: "msg-1"}, nil
+		}
+
+		d, err := deliverMessage(context.Background(), client, "grant-1", grant, testSendRequest(), sendViaAuto)
+		require.NoError(t, err)
+		assert.Equal(t, "msg-1", d.Message.ID)
+		assert.Equal(t, sendViaNylas, d.Transport)
+		assert.Zero(t, transport.sent)
+		assert.Equal(t, map[string]string{"transport": "nylas"}, details)
+	})
+
+	t.Run("auto falls back on rate limit", func(t *testing.T) {
+		transport := &fakeTransport{}
+		details := useFakeRelay(t, transport, &domain.SMTPConfig{Host: "smtp.example.com"})
+		client := nylas.NewMockClient()
+		client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+			return nil, &domain.APIError{StatusCode: 429}
+		}
+
+		d, err := deliverMessage(context.Background(), client, "grant-1", grant, testSendRequest(), sendViaAuto)
+		require.NoError(t, err)
+		assert.Equal(t, sendViaSMTP, d.Transport)
+		assert.Equal(t, "smtp.example.com:587", d.Relay)
+		assert.Contains(t, d.FallbackReason, "429")
+		assert.Equal(t, 1, transport.sent)
+		assert.Equal(t, "smtp", details["transport"])
+		assert.Contains(t, details["fallback_reason"], "429")
+	})
+
+	t.Run("auto does not fall back on client errors", func(t *testing.T) {
+		transport := &fakeTransport{}
+		useFakeRelay(t, transport, &domain.SMTPConfig{Host: "smtp.example.com"})
+		client := nylas.NewMockClient()
+		client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+			return nil, &domain.APIError{StatusCode: 400, Message: "invalid recipient"}
+		}
+
+		_, err := deliverMessage(context.Background(), client, "grant-1", grant, testSendRequest(), sendViaAuto)
+		assert.ErrorContains(t, err, "invalid recipient")
+		assert.Zero(t, transport.sent)
+	})
+
+	t.Run("nylas mode never falls back", func(t *testing.T) {
+		transport := &fakeTransport{}
+		useFakeRelay(t, transport, &domain.SMTPConfig{Host: "smtp.example.com"})
+		client := nylas.NewMockClient()
+		client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+			return nil, &domain.APIError{StatusCode: 503}
+		}
+
+		_, err := deliverMessage(context.Background(), client, "grant-1", grant, testSendRequest(), sendViaNylas)
+		assert.Error(t, err)
+		assert.Zero(t, transport.sent)
+	})
+
+	t.Run("auto does not fall back when nylas may have sent", func(t *testing.T) {
+		transport := &fakeTransport{}
+		useFakeRelay(t, transport, &domain.SMTPConfig{Host: "smtp.example.com"})
+		client := nylas.NewMockClient()
+		client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+			return nil, &domain.APIError{StatusCode: 502}
+		}
+
+		_, err := deliverMessage(context.Background(), client, "grant-1", grant, testSendRequest(), sendViaAuto)
+		assert.Error(t, err)
+		assert.Zero(t, transport.sent)
+	})
+
+	t.Run("failed fallback reports both errors", func(t *testing.T) {
+		transport := &fakeTransport{err: errors.New("535 bad credentials")}
+		useFakeRelay(t, transport, &domain.SMTPConfig{Host: "smtp.example.com"})
+		client := nylas.NewMockClient()
+		client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+			return nil, &domain.APIError{StatusCode: 429}
+		}
+
+		_, err := deliverMessage(context.Background(), client, "grant-1", grant, testSendRequest(), sendViaAuto)
+		assert.ErrorContains(t, err, "HTTP 429")
+		assert.ErrorContains(t, err, "535 bad credentials")
+	})
+
+	t.Run("fallback after the deadline gets a fresh context", func(t *testing.T) {
+		transport := &fakeTransport{}
+		useFakeRelay(t, transport, &domain.SMTPConfig{Host: "smtp.example.com"})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		client := nylas.NewMockClient()
+		client.SendMessageFunc = func(ctx context.Context, _ string, _ *domain.SendMessageRequest) (*domain.Message, error) {
+			<-ctx.Done()
+			return nil, fmt.Errorf("%w: %w", domain.ErrNetworkError, &net.OpError{Op: "dial", Err: ctx.Err()})
+		}
+
+		d, err := deliverMessage(ctx, client, "grant-1", grant, testSendRequest(), sendViaAuto)
+		require.NoError(t, err)
+		assert.Equal(t, sendViaSMTP, d.Transport)
+		assert.NoError(t, transport.ctxErr, "the relay gets a live context")
+	})
+
+	t.Run("smtp mode skips nylas", func(t *testing.T) {
+		transport := &fakeTransport{}
+		details := useFakeRelay(t, transport, &domain.SMTPConfig{Host: "smtp.example.com"})
+		client := nylas.NewMockClient()
+		client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+			t.Fatal("Nylas send must not be called with --via smtp")
+			return nil, nil
+		}
+
+		d, err := deliverMessage(context.Background(), client, "grant-1", grant, testSendRequest(), sendViaSMTP)
+		require.NoError(t, err)
+		assert.Empty(t, d.FallbackReason)
+		assert.Equal(t, "smtp", details["transport"])
+		assert.NotContains(t, details, "fallback_reason")
+	})
+}
+
+func TestSendSMTPMessage(t *testing.T) {
+	transport := &fakeTransport{}
+	cfg := &domain.SMTPConfig{Host: "smtp.example.com", Username: "relay-user"}
+	grant := &domain.Grant{Email: "me@example.com"}
+
+	msg, err := sendSMTPMessage(context.Background(), transport, cfg, grant, testSendRequest())
+	require.NoError(t, err)
+
+	assert.Equal(t, "me@example.com", transport.from)
+	assert.Equal(t, []string{"to@example.com", "cc@example.com", "bcc@example.com"}, transport.recipients)
+	assert.Contains(t, transport.raw, "From: me@example.com")
+	assert.Contains(t, transport.raw, "Content-Type: text/html; charset=utf-8")
+	assert.NotContains(t, transport.raw, "bcc@example.com")
+
+	assert.True(t, strings.HasPrefix(msg.ID, "<") && strings.HasSuffix(msg.ID, "@example.com>"))
+	assert.Contains(t, transport.raw, "Message-ID: "+msg.ID)
+}
+
+func TestSMTPSender(t *testing.T) {
+	req := &domain.SendMessageRequest{}
+	grant := &domain.Grant{Email: "grant@example.com"}
+
+	assert.Equal(t, "cfg@example.com", smtpSender(&domain.SMTPConfig{From: "cfg@example.com"}, grant, req))
+	assert.Equal(t, "grant@example.com", smtpSender(&domain.SMTPConfig{Username: "user@example.com"}, grant, req))
+	assert.Equal(t, "user@example.com", smtpSender(&domain.SMTPConfig{Username: "user@example.com"}, nil, req))
+	assert.Empty(t, smtpSender(&domain.SMTPConfig{Username: "apikey"}, nil, req))
+
+	req.From = []domain.EmailParticipant{{Email: "explicit@example.com"}}
+	assert.Equal(t, "explicit@example.com", smtpSender(&domain.SMTPConfig{From: "cfg@example.com"}, grant, req))
+}
+
+func TestGetGrantForDelivery(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetGrantFunc = func(context.Context, string) (*domain.Grant, error) {
+		return nil, &domain.APIError{StatusCode: 503}
+	}
+
+	_, err := getGrantForDelivery(context.Background(), client, "grant-1", sendViaNylas)
+	assert.Error(t, err)
+
+	grant, err := getGrantForDelivery(context.Background(), client, "grant-1", sendViaAuto)
+	require.NoError(t, err, "an outage must not block the SMTP fallback")
+	assert.Nil(t, grant)
+}
+
+func TestSMTPCommand(t *testing.T) {
+	cmd := newSMTPCmd()
+	assert.Equal(t, "smtp", cmd.Use)
+
+	names := map[string]bool{}
+	for _, sub := range cmd.Commands() {
+		names[sub.Name()] = true
+	}
+	assert.True(t, names["setup"] && names["test"] && names["remove"])
+
+	setup, _, err := cmd.Find([]string{"setup"})
+	require.NoError(t, err)
+	for _, flag := range []string{"host", "port", "username", "from", "auth", "tls", "fallback", "password-stdin"} {
+		assert.NotNil(t, setup.Flags().Lookup(flag), flag)
+	}
+
+	assert.NotNil(t, newSendCmd().Flags().Lookup("via"))
+}
@@ -17,10 +17,10 @@ func newSignaturesCmd() *cobra.Command {
 		Long:  "List, show, create, update, and delete stored email signatures for a grant.",
 	}
 
-	cmd.AddCommand(newSignaturesListCmd())
-	cmd.AddCommand(newSignaturesShowCmd())
-	cmd.AddCommand(newSignaturesCreateCmd())
-	cmd.AddCommand(newSignaturesUpdateCmd())
+	cmd.AddCommand(common.DeclareOutput(newSignaturesListCmd(), []domain.Signature{}))
+	cmd.AddCommand(common.DeclareOutput(newSignaturesShowCmd(), domain.Signature{}))
+	cmd.AddCommand(common.DeclareOutput(newSignaturesCreateCmd(), domain.Signature{}))
+	cmd.AddCommand(common.DeclareOutput(newSignaturesUpdateCmd(), domain.Signature{}))
 	cmd.AddCommand(newSignaturesDeleteCmd())
 
 	return cmd
@@ -0,0 +1,228 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+func newSMTPCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "smtp",
+		Short: "Configure an SMTP relay for sending",
+		Long: `Configure an SMTP relay that 'nylas email send' can use instead of, or as a
+fallback for, the Nylas send endpoint.
+
+The relay settings are stored in the config file under email.smtp. The
+password (or OAuth access token for XOAUTH2) is stored in the system keyring
+and can be overridden with the NYLAS_SMTP_PASSWORD environment variable.`,
+	}
+
+	cmd.AddCommand(newSMTPSetupCmd())
+	cmd.AddCommand(newSMTPTestCmd())
+	cmd.AddCommand(newSMTPRemoveCmd())
+
+	return cmd
+}
+
+func newSMTPSetupCmd() *cobra.Command {
+	var (
+		cfg           domain.SMTPConfig
+		auth          string
+		tlsMode       string
+		passwordStdin bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "setup",
+		Short: "Save SMTP relay settings and credentials",
+		Long: `Save SMTP relay settings and store its password or token in the keyring.
+
+Authentication methods:
+- plain (default): username and password
+- login: AUTH LOGIN, required by some Microsoft 365 relays
+- xoauth2: an OAuth access token (Gmail, Microsoft 365)
+
+TLS modes:
+- starttls (default, port 587): upgrade a plaintext connection
+- tls (port 465): implicit TLS
+- none: no encryption (credentials are only sent to localhost)`,
+		Example: `  # Gmail with an app password, as a fallback when Nylas is unavailable
+  nylas email smtp setup --host smtp.gmail.com --username you@gmail.com --fallback
+
+  # Microsoft 365 with an OAuth token from stdin
+  echo "$TOKEN" | nylas email smtp setup --host smtp.office365.com \
+    --username you@contoso.com --auth xoauth2 --password-stdin
+
+  # Implicit TLS on port 465
+  nylas email smtp setup --host mail.example.com --tls tls --username me`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateOneOf("--auth", auth, []string{
+				string(domain.SMTPAuthPlain), string(domain.SMTPAuthLogin), string(domain.SMTPAuthXOAuth2),
+			}); err != nil {
+				return err
+			}
+			if err := common.ValidateOneOf("--tls", tlsMode, []string{
+				string(domain.MailTLSStartTLS), string(domain.MailTLSImplicit), string(domain.MailTLSNone),
+			}); err != nil {
+				return err
+			}
+			if cfg.Port < 0 || cfg.Port > 65535 {
+				return common.NewInputError(fmt.Sprintf("invalid --port: %d", cfg.Port))
+			}
+			cfg.Auth = domain.SMTPAuthMethod(auth)
+			cfg.TLS = domain.MailTLSMode(tlsMode)
+
+			secret, err := readSMTPSecret(cfg.Auth, passwordStdin)
+			if err != nil {
+				return err
+			}
+
+			store := configAdapter.NewDefaultFileStore()
+			config, err := store.Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			if config.Email == nil {
+				config.Email = &domain.EmailConfig{}
+			}
+			config.Email.SMTP = &cfg
+			if err := store.Save(config); err != nil {
+				return common.WrapSaveError("config", err)
+			}
+
+			if secret != "" {
+				secrets, err := keyring.NewSecretStore(configAdapter.DefaultConfigDir())
+				if err != nil {
+					return common.WrapSaveError("SMTP credentials", err)
+				}
+				if err := secrets.Set(ports.KeySMTPSecret, secret); err != nil {
+					return common.WrapSaveError("SMTP credentials", err)
+				}
+			}
+
+			common.PrintSuccess("SMTP relay %s saved", cfg.Host)
+			if secret == "" {
+				fmt.Println("No password stored; the relay will be used without authentication unless NYLAS_SMTP_PASSWORD is set.")
+			}
+			if cfg.Fallback {
+				fmt.Println("'nylas email send' will fall back to this relay when Nylas is rate limited or unavailable.")
+			}
+			fmt.Println("Run 'nylas email smtp test' to check the connection.")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.Host, "host", "", "SMTP server hostname (required)")
+	cmd.Flags().IntVar(&cfg.Port, "port", 0, "SMTP server port (default: 465 for --tls tls, 587 otherwise)")
+	cmd.Flags().StringVar(&cfg.Username, "username", "", "SMTP username")
+	cmd.Flags().StringVar(&cfg.From, "from", "", "Sender address (default: the grant's email, then the username)")
+	cmd.Flags().StringVar(&auth, "auth", string(domain.SMTPAuthPlain), "Authentication method: plain, login, or xoauth2")
+	cmd.Flags().StringVar(&tlsMode, "tls", string(domain.MailTLSStartTLS), "TLS mode: starttls, tls, or none")
+	cmd.Flags().BoolVar(&cfg.Fallback, "fallback", false, "Fall back to this relay automatically when Nylas sends fail")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "Read the password or token from stdin")
+	_ = cmd.MarkFlagRequired("host")
+
+	return cmd
+}
+
+// readSMTPSecret reads the relay secret from stdin or a hidden prompt.
+// Without a terminal and without --password-stdin, no secret is stored.
+func readSMTPSecret(auth domain.SMTPAuthMethod, fromStdin bool) (string, error) {
+	if fromStdin {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", common.WrapError(err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", nil
+	}
+
+	label := "Password"
+	if auth == domain.SMTPAuthXOAuth2 {
+		label = "OAuth access token"
+	}
+	fmt.Printf("%s (hidden, empty for none): ", label)
+	secret, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", common.WrapError(err)
+	}
+	return strings.TrimSpace(string(secret)), nil
+}
+
+func newSMTPTestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "test",
+		Short: "Check the SMTP relay connection and credentials",
+		Long: `Connect to the configured SMTP relay, negotiate TLS and authenticate,
+without sending a message.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			relay, _, err := loadSMTPRelay()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			err = common.RunWithSpinner(fmt.Sprintf("Connecting to %s...", relay.Name()), func() error {
+				return relay.Verify(ctx)
+			})
+			if err != nil {
+				return common.NewUserError(fmt.Sprintf("SMTP relay check failed: %v", err),
+					"Re-run 'nylas email smtp setup' with the correct host, port, TLS mode and credentials")
+			}
+
+			common.PrintSuccess("Connected and authenticated to %s", relay.Name())
+			return nil
+		},
+	}
+}
+
+func newSMTPRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove",
+		Short: "Remove the SMTP relay settings and credentials",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := configAdapter.NewDefaultFileStore()
+			config, err := store.Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			if config.Email != nil && config.Email.SMTP != nil {
+				config.Email.SMTP = nil
+				if err := store.Save(config); err != nil {
+					return common.WrapSaveError("config", err)
+				}
+			}
+
+			if secrets, err := keyring.NewSecretStore(configAdapter.DefaultConfigDir()); err == nil {
+				if err := secrets.Delete(ports.KeySMTPSecret); err != nil && !errors.Is(err, domain.ErrSecretNotFound) {
+					return common.WrapDeleteError("SMTP credentials", err)
+				}
+			}
+
+			common.PrintSuccess("SMTP relay removed")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,260 @@
+package email
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/webhookstore"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newEventStore opens the local webhook event store. Replaced in tests.
+var newEventStore = func() ports.WebhookEventStore {
+	return webhookstore.NewFileStore("")
+}
+
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <message-id> [grant-id]",
+		Short: "Show delivery, bounce and open status of a sent message",
+		Long: `Show whether a sent message was delivered, bounced, failed or opened,
+from the webhook events received by 'nylas webhooks server'.
+
+The server must be running with a webhook subscribed to the message
+triggers, and keeps events for 30 days:
+
+  nylas webhooks create --url <public-url> \
+    --triggers message.send_success,message.send_failed,message.bounce_detected,message.opened,message.link_clicked
+  nylas webhooks server
+
+Opens and clicks are only reported for messages sent with --track-opens or
+--track-links.`,
+		Example: `  # Did it bounce?
+  nylas email status <message-id>
+
+  # As JSON, for scripts
+  nylas email status <message-id> --json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			messageID := strings.TrimSpace(args[0])
+			if messageID == "" {
+				return common.NewInputError("message ID cannot be empty")
+			}
+			grantID := ""
+			if len(args) > 1 {
+				var err error
+				if grantID, err = common.GetGrantID(args[1:]); err != nil {
+					return err
+				}
+			}
+
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			records, err := newEventStore().Query(ctx, &domain.WebhookEventQuery{Triggers: domain.MessageStatusTriggers})
+			if err != nil {
+				return common.WrapFetchError("webhook events", err)
+			}
+			status := messageStatus(messageID, grantID, records)
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(status)
+			}
+			printMessageStatus(status)
+			return nil
+		},
+	}
+}
+
+// messageStatus correlates the stored webhook events with messageID. Events
+// for another grant are skipped when grantID is set.
+func messageStatus(messageID, grantID string, records []domain.WebhookEventRecord) *domain.MessageStatus {
+	status := &domain.MessageStatus{MessageID: messageID, GrantID: grantID, State: domain.MessageStateUnknown, Events: []domain.MessageStatusEvent{}}
+	for i := range records {
+		rec := &records[i]
+		if grantID != "" && rec.GrantID != "" && rec.GrantID != grantID {
+			continue
+		}
+		event, ok := messageStatusEvent(rec, messageID)
+		if !ok {
+			continue
+		}
+		if status.GrantID == "" {
+			status.GrantID = rec.GrantID
+		}
+		status.Events = append(status.Events, event)
+	}
+
+	slices.SortStableFunc(status.Events, func(a, b domain.MessageStatusEvent) int {
+		return a.At.Compare(b.At)
+	})
+	for _, e := range status.Events {
+		state := domain.MessageStateUnknown
+		switch e.Type {
+		case domain.TriggerMessageSendSuccess:
+			state = domain.MessageStateSent
+		case domain.TriggerMessageOpened:
+			state = domain.MessageStateOpened
+			status.Opens++
+		case domain.TriggerMessageLinkClicked:
+			state = domain.MessageStateOpened
+			status.Clicks++
+		case domain.TriggerMessageSendFailed:
+			state = domain.MessageStateFailed
+		case domain.TriggerMessageBounceDetected:
+			state = domain.MessageStateBounced
+			status.Bounces++
+		}
+		if messageStateRank(state) > messageStateRank(status.State) {
+			status.State = state
+		}
+	}
+	return status
+}
+
+// messageStateRank orders states so a bounce or failure isn't hidden by a
+// later open of another recipient's copy.
+func messageStateRank(state string) int {
+	return slices.Index([]string{
+		domain.MessageStateUnknown,
+		domain.MessageStateSent,
+		domain.MessageStateOpened,
+		domain.MessageStateFailed,
+		domain.MessageStateBounced,
+	}, state)
+}
+
+// statusPayload is the part of a message webhook that identifies the
+// message. Bounces carry the sent message as origin, tracking events its ID
+// as message_id, and send events the message itself or as message_data.
+type statusPayload struct {
+	Time int64 `json:"time"`
+	Data struct {
+		Object struct {
+			ID             string          `json:"id"`
+			MessageID      string          `json:"message_id"`
+			Origin         *statusMessage  `json:"origin"`
+			Message        *statusMessage  `json:"message"`
+			MessageData    json.RawMessage `json:"message_data"`
+			BouncedAddress string          `json:"bounced_address"`
+			BounceReason   string          `json:"bounce_reason"`
+			BounceType     string          `json:"type"`
+			Code           json.RawMessage `json:"code"`
+			Reason         string          `json:"reason"`
+			Error          json.RawMessage `json:"error"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+type statusMessage struct {
+	ID        string `json:"id"`
+	MessageID string `json:"message_id"`
+}
+
+// messageStatusEvent returns the event of rec when it is about messageID.
+func messageStatusEvent(rec *domain.WebhookEventRecord, messageID string) (domain.MessageStatusEvent, bool) {
+	var p statusPayload
+	if err := json.Unmarshal(rec.Payload, &p); err != nil {
+		return domain.MessageStatusEvent{}, false
+	}
+	obj := &p.Data.Object
+
+	ids := []string{obj.MessageID}
+	for _, m := range []*statusMessage{obj.Origin, obj.Message} {
+		if m != nil {
+			ids = append(ids, m.ID, m.MessageID)
+		}
+	}
+	var data statusMessage
+	if json.Unmarshal(obj.MessageData, &data) == nil {
+		ids = append(ids, data.ID)
+	}
+	// A send event's object is the message itself; a bounce's own ID isn't
+	// a message ID.
+	if rec.Type != domain.TriggerMessageBounceDetected {
+		ids = append(ids, obj.ID)
+	}
+	if !slices.Contains(ids, messageID) {
+		return domain.MessageStatusEvent{}, false
+	}
+
+	event := domain.MessageStatusEvent{Type: rec.Type, At: rec.ReceivedAt, EventID: rec.ID}
+	if p.Time > 0 {
+		event.At = time.Unix(p.Time, 0)
+	}
+	switch rec.Type {
+	case domain.TriggerMessageBounceDetected:
+		event.Recipient = obj.BouncedAddress
+		event.Detail = joinNonEmpty(rawString(obj.Code), obj.BounceType, obj.BounceReason)
+	case domain.TriggerMessageSendFailed:
+		event.Detail = joinNonEmpty(obj.Reason, rawString(obj.Error))
+	}
+	return event, true
+}
+
+// rawString returns a JSON string or number as text, and "" for anything
+// else.
+func rawString(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
+
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ": ")
+}
+
+var messageEventLabels = map[string]string{
+	domain.TriggerMessageSendSuccess:    "Sent",
+	domain.TriggerMessageSendFailed:     "Send failed",
+	domain.TriggerMessageBounceDetected: "Bounced",
+	domain.TriggerMessageOpened:         "Opened",
+	domain.TriggerMessageLinkClicked:    "Link clicked",
+}
+
+func printMessageStatus(status *domain.MessageStatus) {
+	if len(status.Events) == 0 {
+		common.PrintEmptyStateWithHint("delivery events for "+status.MessageID,
+			"Run 'nylas webhooks server' with a webhook for message.send_success and message.bounce_detected (see 'nylas email status --help')")
+		return
+	}
+
+	state := status.State
+	switch status.State {
+	case domain.MessageStateBounced:
+		state = common.Red.Sprintf("Bounced (%d)", status.Bounces)
+	case domain.MessageStateFailed:
+		state = common.Red.Sprint("Send failed")
+	case domain.MessageStateOpened:
+		state = common.Green.Sprintf("Opened (%d opens, %d clicks)", status.Opens, status.Clicks)
+	case domain.MessageStateSent:
+		state = common.Green.Sprint("Sent")
+	}
+	fmt.Printf("Message: %s\n", status.MessageID)
+	fmt.Printf("Status:  %s\n\n", state)
+
+	table := common.NewTable("TIME", "EVENT", "RECIPIENT", "DETAIL")
+	for _, e := range status.Events {
+		table.AddRow(e.At.Local().Format(common.DisplayWeekdayShort), messageEventLabels[e.Type], e.Recipient, e.Detail)
+	}
+	table.Render()
+}
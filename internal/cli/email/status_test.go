@@ -0,0 +1,104 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/webhookstore"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+func statusRecord(id, trigger, grantID string, at time.Time, payload string) domain.WebhookEventRecord {
+	return domain.WebhookEventRecord{ID: id, Type: trigger, GrantID: grantID, ReceivedAt: at, Payload: json.RawMessage(payload)}
+}
+
+func TestMessageStatus(t *testing.T) {
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	records := []domain.WebhookEventRecord{
+		statusRecord("evt-open", domain.TriggerMessageOpened, "grant-1", base.Add(2*time.Hour),
+			`{"type":"message.opened","data":{"object":{"message_id":"msg-1","message_data":{"count":1}}}}`),
+		statusRecord("evt-bounce", domain.TriggerMessageBounceDetected, "grant-1", base.Add(time.Hour),
+			`{"type":"message.bounce_detected","time":1773147600,"data":{"object":{"id":"bounce-1","bounced_address":"bob@example.com","bounce_reason":"Mailbox not found","type":"mailbox_unavailable","code":550,"origin":{"id":"msg-1"}}}}`),
+		statusRecord("evt-sent", domain.TriggerMessageSendSuccess, "grant-1", base,
+			`{"type":"message.send_success","data":{"object":{"id":"msg-1","grant_id":"grant-1"}}}`),
+		statusRecord("evt-other", domain.TriggerMessageSendSuccess, "grant-1", base,
+			`{"type":"message.send_success","data":{"object":{"id":"msg-2"}}}`),
+		statusRecord("evt-other-grant", domain.TriggerMessageOpened, "grant-2", base,
+			`{"type":"message.opened","data":{"object":{"message_id":"msg-1"}}}`),
+		statusRecord("evt-bad", domain.TriggerMessageOpened, "grant-1", base, `"not json"`),
+	}
+
+	status := messageStatus("msg-1", "grant-1", records)
+	assert.Equal(t, domain.MessageStateBounced, status.State, "a bounce outranks a later open")
+	assert.Equal(t, "grant-1", status.GrantID)
+	assert.Equal(t, 1, status.Bounces)
+	assert.Equal(t, 1, status.Opens)
+	require.Len(t, status.Events, 3)
+	assert.Equal(t, "evt-sent", status.Events[0].EventID, "events are oldest first")
+	assert.Equal(t, "evt-bounce", status.Events[1].EventID)
+	assert.Equal(t, time.Unix(1773147600, 0), status.Events[1].At, "the payload time is preferred")
+	assert.Equal(t, "bob@example.com", status.Events[1].Recipient)
+	assert.Equal(t, "550: mailbox_unavailable: Mailbox not found", status.Events[1].Detail)
+
+	t.Run("any grant", func(t *testing.T) {
+		status := messageStatus("msg-1", "", records)
+		assert.Len(t, status.Events, 4)
+		assert.Equal(t, 2, status.Opens)
+	})
+
+	t.Run("bounce ID is not a message ID", func(t *testing.T) {
+		status := messageStatus("bounce-1", "", records)
+		assert.Equal(t, domain.MessageStateUnknown, status.State)
+		assert.Empty(t, status.Events)
+	})
+
+	t.Run("send failure", func(t *testing.T) {
+		status := messageStatus("msg-3", "", []domain.WebhookEventRecord{
+			statusRecord("evt-fail", domain.TriggerMessageSendFailed, "", base,
+				`{"data":{"object":{"message_data":{"id":"msg-3"},"reason":"quota exceeded"}}}`),
+		})
+		assert.Equal(t, domain.MessageStateFailed, status.State)
+		require.Len(t, status.Events, 1)
+		assert.Equal(t, "quota exceeded", status.Events[0].Detail)
+	})
+
+	t.Run("opens and clicks", func(t *testing.T) {
+		status := messageStatus("msg-4", "", []domain.WebhookEventRecord{
+			statusRecord("evt-sent", domain.TriggerMessageSendSuccess, "", base, `{"data":{"object":{"message":{"id":"msg-4"}}}}`),
+			statusRecord("evt-click", domain.TriggerMessageLinkClicked, "", base.Add(time.Minute), `{"data":{"object":{"message_id":"msg-4"}}}`),
+		})
+		assert.Equal(t, domain.MessageStateOpened, status.State)
+		assert.Equal(t, 1, status.Clicks)
+	})
+}
+
+func TestStatusCmd_JSON(t *testing.T) {
+	store := webhookstore.NewFileStore(t.TempDir())
+	orig := newEventStore
+	newEventStore = func() ports.WebhookEventStore { return store }
+	t.Cleanup(func() { newEventStore = orig })
+
+	rec := statusRecord("evt-bounce", domain.TriggerMessageBounceDetected, "grant-1", time.Now(),
+		`{"data":{"object":{"bounced_address":"bob@example.com","origin":{"id":"msg-1"}}}}`)
+	require.NoError(t, store.Append(&rec))
+
+	cmd := newStatusCmd()
+	cmd.Flags().Bool("json", false, "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"msg-1", "--json"})
+	require.NoError(t, cmd.Execute())
+
+	var status domain.MessageStatus
+	require.NoError(t, json.Unmarshal(out.Bytes(), &status))
+	assert.Equal(t, domain.MessageStateBounced, status.State)
+	assert.Equal(t, "grant-1", status.GrantID)
+	require.Len(t, status.Events, 1)
+	assert.Equal(t, "bob@example.com", status.Events[0].Recipient)
+}
@@ -0,0 +1,297 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/ai"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// maxThreadSummaryMessages caps how many messages of a thread are read.
+const maxThreadSummaryMessages = 100
+
+// summaryAIOptions selects the AI provider for summaries.
+type summaryAIOptions struct {
+	provider  string
+	localOnly bool
+}
+
+func (o *summaryAIOptions) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.provider, "provider", "p", "", "AI provider to use (ollama, claude, openai, groq)")
+	cmd.Flags().BoolVar(&o.localOnly, "local-only", false, "Only use the local Ollama provider, so mail never leaves this machine")
+}
+
+// router builds the AI router and returns the provider to ask, which is
+// always ollama with --local-only so the router can't fall back to a cloud
+// provider.
+func (o *summaryAIOptions) router(cmd *cobra.Command) (ports.LLMRouter, string, error) {
+	if o.localOnly && o.provider != "" && o.provider != "ollama" {
+		return nil, "", common.NewInputError(fmt.Sprintf("--local-only can't use the %s provider", o.provider))
+	}
+	cfg, err := common.GetConfigStore(cmd).Load()
+	if err != nil {
+		return nil, "", common.WrapLoadError("config", err)
+	}
+	if cfg.AI == nil || !cfg.AI.IsConfigured() {
+		return nil, "", common.NewUserError("AI is not configured", "Run 'nylas config ai setup' to configure AI providers")
+	}
+	if !o.localOnly {
+		return ai.NewRouter(cfg.AI), o.provider, nil
+	}
+	if cfg.AI.Ollama == nil {
+		return nil, "", common.NewUserError("--local-only needs Ollama configured",
+			"Set it up with: nylas ai config set ollama.host http://localhost:11434")
+	}
+	return ai.NewRouter(cfg.AI), "ollama", nil
+}
+
+func newSummarizeCmd() *cobra.Command {
+	var (
+		opts   summaryAIOptions
+		thread bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "summarize <message-id|thread-id> [grant-id]",
+		Short: "Summarize a message or thread with AI",
+		Long: `Summarize a message or a whole thread with the configured AI provider and
+list the action items it asks of you.
+
+The ID can be a message or a thread. --thread summarizes the whole thread a
+message belongs to. Quoted history and signatures are left out.
+
+--local-only only uses a local Ollama model, so the mail is never sent to a
+cloud provider.`,
+		Example: `  # Summarize a message
+  nylas email summarize <message-id>
+
+  # Summarize the conversation a message is part of, locally
+  nylas email summarize <message-id> --thread --local-only
+
+  # As JSON
+  nylas email summarize <thread-id> --json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			router, provider, err := opts.router(cmd)
+			if err != nil {
+				return err
+			}
+
+			id := args[0]
+			summary, err := common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (*domain.EmailSummary, error) {
+				subject, messages, err := messagesToSummarize(ctx, client, grantID, id, thread)
+				if err != nil {
+					return nil, err
+				}
+				return summarizeWithSpinner(ctx, router, &ai.EmailSummaryRequest{Subject: subject, Messages: messages, ProviderName: provider})
+			})
+			if err != nil {
+				return err
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(summary)
+			}
+			title := "Summary"
+			if summary.Subject != "" {
+				title += fmt.Sprintf(" of %q", summary.Subject)
+			}
+			printEmailSummary(summary, title)
+			return nil
+		},
+	}
+
+	opts.register(cmd)
+	cmd.Flags().BoolVar(&thread, "thread", false, "Summarize the whole thread of the given message")
+
+	return cmd
+}
+
+func newDigestCmd() *cobra.Command {
+	var (
+		opts   summaryAIOptions
+		since  string
+		folder string
+		limit  int
+		unread bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "digest [grant-id]",
+		Short: "Summarize recent mail with AI",
+		Long: `Summarize the mail received recently with the configured AI provider: what
+arrived, what matters, and the action items waiting for you.
+
+--local-only only uses a local Ollama model, so the mail is never sent to a
+cloud provider.`,
+		Example: `  # What arrived in the last day
+  nylas email digest
+
+  # Unread mail from the last week, summarized locally
+  nylas email digest --since 7d --unread --local-only
+
+  # As JSON, for a morning briefing script
+  nylas email digest --since 12h --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			window, err := common.ParseDuration(since)
+			if err != nil || window <= 0 {
+				return common.NewInputError(fmt.Sprintf("invalid --since value %q (use e.g. 24h, 7d, 2w)", since))
+			}
+			if limit < 1 || limit > common.MaxAPILimit {
+				return common.NewInputError(fmt.Sprintf("--limit must be between 1 and %d", common.MaxAPILimit))
+			}
+			router, provider, err := opts.router(cmd)
+			if err != nil {
+				return err
+			}
+
+			start := time.Now().Add(-window)
+			summary, err := common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (*domain.EmailSummary, error) {
+				params := &domain.MessageQueryParams{
+					In:            []string{folder},
+					ReceivedAfter: start.Unix(),
+					Limit:         limit,
+				}
+				if unread {
+					params.Unread = &unread
+				}
+				messages, err := client.GetMessagesWithParams(ctx, grantID, params)
+				if err != nil {
+					return nil, common.WrapFetchError("emails", err)
+				}
+				if len(messages) == 0 {
+					return nil, nil
+				}
+				sortOldestFirst(messages)
+				summary, err := summarizeWithSpinner(ctx, router, &ai.EmailSummaryRequest{Messages: messages, Digest: true, ProviderName: provider})
+				if err != nil {
+					return nil, err
+				}
+				summary.Since = start.UTC()
+				return summary, nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if summary == nil {
+				summary = &domain.EmailSummary{Since: start.UTC(), ActionItems: []domain.EmailActionItem{}}
+			}
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(summary)
+			}
+			if summary.MessageCount == 0 {
+				common.PrintEmptyStateWithHint("emails since "+start.Format(common.DisplayDateTime), "Try a longer --since")
+				return nil
+			}
+			printEmailSummary(summary, "Digest since "+start.Format(common.DisplayDateTime))
+			return nil
+		},
+	}
+
+	opts.register(cmd)
+	cmd.Flags().StringVar(&since, "since", "24h", "How far back to go (e.g. 12h, 7d, 2w)")
+	cmd.Flags().StringVar(&folder, "folder", "INBOX", "Folder to digest")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 50, "Maximum number of emails to read")
+	cmd.Flags().BoolVar(&unread, "unread", false, "Only include unread emails")
+
+	return cmd
+}
+
+// messagesToSummarize resolves id as a message, or as a thread when no
+// message has that ID, and returns the subject and messages oldest first.
+func messagesToSummarize(ctx context.Context, client ports.NylasClient, grantID, id string, wholeThread bool) (string, []domain.Message, error) {
+	threadID := id
+	msg, err := client.GetMessage(ctx, grantID, id)
+	switch {
+	case err == nil && !wholeThread:
+		return msg.Subject, []domain.Message{*msg}, nil
+	case err == nil:
+		threadID = msg.ThreadID
+	case !errors.Is(err, domain.ErrMessageNotFound):
+		return "", nil, common.WrapGetError("message", err)
+	}
+
+	thread, err := client.GetThread(ctx, grantID, threadID)
+	if err != nil {
+		if errors.Is(err, domain.ErrThreadNotFound) && threadID == id {
+			return "", nil, common.NewUserError("no message or thread with ID "+id, "Find IDs with: nylas email list")
+		}
+		return "", nil, common.WrapGetError("thread", err)
+	}
+	messages, err := client.GetMessagesWithParams(ctx, grantID, &domain.MessageQueryParams{ThreadID: thread.ID, Limit: maxThreadSummaryMessages})
+	if err != nil {
+		return "", nil, common.WrapFetchError("thread messages", err)
+	}
+	if len(messages) == 0 {
+		return "", nil, common.NewUserError("thread "+thread.ID+" has no messages", "")
+	}
+	sortOldestFirst(messages)
+	return thread.Subject, messages, nil
+}
+
+func sortOldestFirst(messages []domain.Message) {
+	slices.SortStableFunc(messages, func(a, b domain.Message) int { return a.Date.Compare(b.Date) })
+}
+
+func summarizeWithSpinner(ctx context.Context, router ports.LLMRouter, req *ai.EmailSummaryRequest) (*domain.EmailSummary, error) {
+	summary, err := common.RunWithSpinnerResult(fmt.Sprintf("Summarizing %d email(s)...", len(req.Messages)), func() (*domain.EmailSummary, error) {
+		return ai.SummarizeEmail(ctx, router, req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AI summary failed: %w", err)
+	}
+	return summary, nil
+}
+
+func printEmailSummary(s *domain.EmailSummary, title string) {
+	_, _ = common.BoldCyan.Printf("%s (%d email(s))\n\n", title, s.MessageCount)
+	fmt.Println(s.Summary)
+
+	if len(s.KeyPoints) > 0 {
+		_, _ = common.Bold.Println("\nKey points")
+		for _, p := range s.KeyPoints {
+			fmt.Printf("  • %s\n", p)
+		}
+	}
+
+	_, _ = common.Bold.Println("\nAction items")
+	if len(s.ActionItems) == 0 {
+		fmt.Println(common.Dim.Sprint("  None"))
+	}
+	for _, item := range s.ActionItems {
+		var details []string
+		if item.From != "" {
+			details = append(details, "from "+item.From)
+		}
+		if item.Due != "" {
+			details = append(details, common.Yellow.Sprint("due "+item.Due))
+		}
+		if item.Subject != "" {
+			details = append(details, fmt.Sprintf("re %q", common.Truncate(item.Subject, 40)))
+		}
+		line := "  • " + item.Task
+		if len(details) > 0 {
+			line += common.Dim.Sprint(" — ") + strings.Join(details, ", ")
+		}
+		fmt.Println(line)
+	}
+
+	footer := "\nProvider: " + s.Provider
+	if s.TokensUsed > 0 {
+		footer += fmt.Sprintf(" | Tokens: %d", s.TokensUsed)
+	}
+	if s.Provider != "" {
+		fmt.Println(common.Dim.Sprint(footer))
+	}
+}
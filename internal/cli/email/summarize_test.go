@@ -0,0 +1,117 @@
+package email
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestMessagesToSummarize(t *testing.T) {
+	newClient := func() *nylas.MockClient {
+		client := nylas.NewMockClient()
+		client.GetMessageFunc = func(_ context.Context, _, id string) (*domain.Message, error) {
+			if id != "msg-1" {
+				return nil, domain.ErrMessageNotFound
+			}
+			return &domain.Message{ID: "msg-1", ThreadID: "thr-1", Subject: "Hello"}, nil
+		}
+		client.GetThreadFunc = func(_ context.Context, _, id string) (*domain.Thread, error) {
+			if id != "thr-1" {
+				return nil, domain.ErrThreadNotFound
+			}
+			return &domain.Thread{ID: "thr-1", Subject: "Hello thread"}, nil
+		}
+		client.GetMessagesWithParamsFunc = func(_ context.Context, _ string, params *domain.MessageQueryParams) ([]domain.Message, error) {
+			assert.Equal(t, "thr-1", params.ThreadID)
+			return []domain.Message{
+				{ID: "b", Date: time.Unix(200, 0)},
+				{ID: "a", Date: time.Unix(100, 0)},
+			}, nil
+		}
+		return client
+	}
+
+	t.Run("message", func(t *testing.T) {
+		subject, msgs, err := messagesToSummarize(context.Background(), newClient(), "grant", "msg-1", false)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", subject)
+		require.Len(t, msgs, 1)
+	})
+
+	t.Run("message thread", func(t *testing.T) {
+		subject, msgs, err := messagesToSummarize(context.Background(), newClient(), "grant", "msg-1", true)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello thread", subject)
+		require.Len(t, msgs, 2)
+		assert.Equal(t, "a", msgs[0].ID, "oldest first")
+	})
+
+	t.Run("thread ID", func(t *testing.T) {
+		subject, msgs, err := messagesToSummarize(context.Background(), newClient(), "grant", "thr-1", false)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello thread", subject)
+		assert.Len(t, msgs, 2)
+	})
+
+	t.Run("unknown ID", func(t *testing.T) {
+		_, _, err := messagesToSummarize(context.Background(), newClient(), "grant", "nope", false)
+		assert.ErrorContains(t, err, "no message or thread with ID nope")
+	})
+}
+
+func TestSummaryAIOptions_Router(t *testing.T) {
+	newCmd := func(t *testing.T, config string) *cobra.Command {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+		cmd := &cobra.Command{Use: "summarize"}
+		cmd.Flags().String("config", path, "")
+		return cmd
+	}
+	cloudAndLocal := "ai:\n  default_provider: claude\n  claude:\n    api_key: key\n  ollama:\n    host: http://localhost:11434\n"
+
+	tests := []struct {
+		name         string
+		config       string
+		opts         summaryAIOptions
+		wantProvider string
+		wantErr      string
+	}{
+		{name: "default provider", config: cloudAndLocal},
+		{name: "chosen provider", config: cloudAndLocal, opts: summaryAIOptions{provider: "claude"}, wantProvider: "claude"},
+		{name: "local only", config: cloudAndLocal, opts: summaryAIOptions{localOnly: true}, wantProvider: "ollama"},
+		{name: "local only with cloud provider", config: cloudAndLocal, opts: summaryAIOptions{provider: "claude", localOnly: true}, wantErr: "--local-only can't use the claude provider"},
+		{name: "local only without ollama", config: "ai:\n  claude:\n    api_key: key\n", opts: summaryAIOptions{localOnly: true}, wantErr: "needs Ollama configured"},
+		{name: "no AI", config: "region: us\n", wantErr: "AI is not configured"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, provider, err := tt.opts.router(newCmd(t, tt.config))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, router)
+			assert.Equal(t, tt.wantProvider, provider)
+		})
+	}
+}
+
+func TestDigestCommand_Validation(t *testing.T) {
+	for _, args := range [][]string{{"--since", "soon"}, {"--since", "0h"}, {"--limit", "0"}} {
+		cmd := newDigestCmd()
+		cmd.SetArgs(args)
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		assert.Error(t, cmd.Execute(), args)
+	}
+}
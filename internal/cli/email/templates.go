@@ -3,6 +3,7 @@ package email
 import (
 	"github.com/nylas/cli/internal/adapters/templates"
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -35,12 +36,12 @@ Templates are local to your machine and are not synced with Nylas.`,
 	common.AddOutputFlags(cmd)
 
 	// Add subcommands
-	cmd.AddCommand(newTemplatesListCmd())
-	cmd.AddCommand(newTemplatesShowCmd())
-	cmd.AddCommand(newTemplatesCreateCmd())
-	cmd.AddCommand(newTemplatesUpdateCmd())
+	cmd.AddCommand(common.DeclareOutput(newTemplatesListCmd(), []domain.EmailTemplate{}))
+	cmd.AddCommand(common.DeclareOutput(newTemplatesShowCmd(), domain.EmailTemplate{}))
+	cmd.AddCommand(common.DeclareOutput(newTemplatesCreateCmd(), domain.EmailTemplate{}))
+	cmd.AddCommand(common.DeclareOutput(newTemplatesUpdateCmd(), domain.EmailTemplate{}))
 	cmd.AddCommand(newTemplatesDeleteCmd())
-	cmd.AddCommand(newTemplatesUseCmd())
+	cmd.AddCommand(common.DeclareOutput(newTemplatesUseCmd(), domain.Message{}))
 
 	return cmd
 }
@@ -3,6 +3,8 @@ package email
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/domain"
@@ -12,15 +14,16 @@ import (
 
 func newThreadsCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "threads",
-		Short: "Manage email threads/conversations",
+		Use:     "threads",
+		Aliases: []string{"thread"},
+		Short:   "Manage email threads/conversations",
 		Long: `List, view, mark, and delete email threads (conversations).
 
 API reference: https://developer.nylas.com/docs/v3/email/threads/`,
 	}
 
-	cmd.AddCommand(newThreadsListCmd())
-	cmd.AddCommand(newThreadsShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newThreadsListCmd(), []domain.Thread{}))
+	cmd.AddCommand(common.DeclareOutput(newThreadsShowCmd(), domain.Thread{}, threadConversation{}))
 	cmd.AddCommand(newThreadsMarkCmd())
 	cmd.AddCommand(newThreadsDeleteCmd())
 	cmd.AddCommand(newThreadsSearchCmd())
@@ -93,10 +96,25 @@ func newThreadsListCmd() *cobra.Command {
 }
 
 func newThreadsShowCmd() *cobra.Command {
-	return &cobra.Command{
+	var expand, showQuoted bool
+
+	cmd := &cobra.Command{
 		Use:   "show <thread-id> [grant-id]",
 		Short: "Show thread details",
-		Args:  cobra.RangeArgs(1, 2),
+		Long: `Show a thread's details, or with --expand the whole conversation.
+
+--expand fetches every message in the thread and prints them oldest first,
+with each participant in their own color, attachments listed, and quoted
+text from earlier messages collapsed (use --quoted to keep it).`,
+		Example: `  # Summary and message IDs
+  nylas email threads show <thread-id>
+
+  # Read the whole conversation
+  nylas email thread show <thread-id> --expand
+
+  # Thread and all messages as JSON
+  nylas email thread show <thread-id> --expand --json`,
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			threadID := args[0]
 			remainingArgs := args[1:]
@@ -107,6 +125,24 @@ func newThreadsShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("thread", err)
 				}
 
+				if expand {
+					messages, err := common.RunWithSpinnerResult("Fetching messages...", func() ([]domain.Message, error) {
+						return fetchThreadMessages(ctx, client, grantID, threadID)
+					})
+					if err != nil {
+						return struct{}{}, common.WrapListError("messages", err)
+					}
+					if common.IsStructuredOutput(cmd) {
+						return struct{}{}, common.GetOutputWriter(cmd).Write(threadConversation{Thread: thread, Messages: messages})
+					}
+					printConversation(os.Stdout, thread, messages, showQuoted)
+					return struct{}{}, nil
+				}
+
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(thread)
+				}
+
 				// Print thread details
 				fmt.Println("════════════════════════════════════════════════════════════")
 				_, _ = common.BoldWhite.Printf("Thread: %s\n", thread.Subject)
@@ -129,7 +165,7 @@ func newThreadsShowCmd() *cobra.Command {
 					status = append(status, "has attachments")
 				}
 				if len(status) > 0 {
-					fmt.Printf("Status:       %s\n", common.FormatParticipants(nil))
+					fmt.Printf("Status:       %s\n", strings.Join(status, ", "))
 				}
 
 				fmt.Printf("\nFirst message: %s\n", thread.EarliestMessageDate.Format(common.DisplayDateTime))
@@ -142,12 +178,18 @@ func newThreadsShowCmd() *cobra.Command {
 				for i, msgID := range thread.MessageIDs {
 					fmt.Printf("  %d. %s\n", i+1, msgID)
 				}
+				fmt.Println(common.Dim.Sprint("\nRead the conversation with --expand"))
 
 				return struct{}{}, nil
 			})
 			return err
 		},
 	}
+
+	cmd.Flags().BoolVarP(&expand, "expand", "e", false, "Show every message in the conversation")
+	cmd.Flags().BoolVar(&showQuoted, "quoted", false, "Keep quoted text from earlier messages (with --expand)")
+
+	return cmd
 }
 
 func newThreadsMarkCmd() *cobra.Command {
@@ -0,0 +1,199 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// threadConversation is the structured output of `threads show --expand`.
+type threadConversation struct {
+	Thread   *domain.Thread   `json:"thread"`
+	Messages []domain.Message `json:"messages"`
+}
+
+var (
+	// quoteAttributionPattern matches "On <date>, <sender> wrote:" lines.
+	quoteAttributionPattern = regexp.MustCompile(`^On\s.+\swrote:$`)
+
+	// quoteSeparatorPattern matches the lines Outlook and others put above
+	// the original message.
+	quoteSeparatorPattern = regexp.MustCompile(`^(-{2,}\s*(Original Message|Forwarded message)\s*-{2,}|_{10,})$`)
+)
+
+// senderPalette colors participants in order of first appearance.
+var senderPalette = []*color.Color{common.BoldCyan, common.BoldGreen, common.BoldYellow, common.BoldBlue, common.BoldRed}
+
+// fetchThreadMessages returns every message in the thread, oldest first.
+func fetchThreadMessages(ctx context.Context, client ports.NylasClient, grantID, threadID string) ([]domain.Message, error) {
+	pageSize := common.NormalizePageSize(common.MaxAPILimit)
+	params := &domain.MessageQueryParams{Limit: pageSize, ThreadID: threadID}
+	messages, err := common.FetchCursorPages(ctx, pageSize, 0, func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+		params.PageToken = cursor
+		resp, err := client.GetMessagesWithCursor(ctx, grantID, params)
+		if err != nil {
+			return common.PageResult[domain.Message]{}, err
+		}
+		return common.PageResult[domain.Message]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(messages, func(a, b domain.Message) int { return a.Date.Compare(b.Date) })
+	return messages, nil
+}
+
+// collapseQuoted hides quoted text in a plain-text body: everything from a
+// reply attribution or original-message separator on, and runs of "> "
+// lines. Each hidden block is replaced by a marker line.
+func collapseQuoted(text string) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		if quoteStart(lines, i, strings.TrimSpace(line)) {
+			hidden := countNonBlank(lines[i:])
+			lines = append(trimTrailingBlank(lines[:i]), quotedMarker(hidden))
+			break
+		}
+	}
+
+	var out []string
+	run := 0
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), ">") {
+			run++
+			continue
+		}
+		if run > 0 {
+			out = append(out, quotedMarker(run))
+			run = 0
+		}
+		out = append(out, line)
+	}
+	if run > 0 {
+		out = append(out, quotedMarker(run))
+	}
+	return strings.Join(out, "\n")
+}
+
+// quoteStart reports whether line i begins the quoted original message.
+func quoteStart(lines []string, i int, line string) bool {
+	switch {
+	case quoteAttributionPattern.MatchString(line), quoteSeparatorPattern.MatchString(line):
+		return true
+	case strings.HasPrefix(line, "On ") && i+1 < len(lines) && strings.HasSuffix(strings.TrimSpace(lines[i+1]), "wrote:"):
+		// Attribution wrapped over two lines
+		return true
+	case strings.HasPrefix(line, "From:") && i > 0:
+		// Outlook header block: From: / Sent: (or Date:) / To: / Subject:
+		for _, next := range lines[i+1 : min(i+4, len(lines))] {
+			next = strings.TrimSpace(next)
+			if strings.HasPrefix(next, "Sent:") || strings.HasPrefix(next, "Date:") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func quotedMarker(n int) string {
+	if n == 1 {
+		return "··· 1 quoted line hidden"
+	}
+	return fmt.Sprintf("··· %d quoted lines hidden", n)
+}
+
+func countNonBlank(lines []string) int {
+	n := 0
+	for _, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+func trimTrailingBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// senderColors assigns each participant a color the first time they are seen.
+type senderColors map[string]*color.Color
+
+func (c senderColors) sprint(p domain.EmailParticipant) string {
+	key := strings.ToLower(p.Email)
+	col, ok := c[key]
+	if !ok {
+		col = senderPalette[len(c)%len(senderPalette)]
+		c[key] = col
+	}
+	return col.Sprint(common.FormatParticipant(p))
+}
+
+// printConversation renders the thread's messages in order, with quoted text
+// collapsed unless showQuoted is set.
+func printConversation(w io.Writer, thread *domain.Thread, messages []domain.Message, showQuoted bool) {
+	colors := senderColors{}
+
+	_, _ = fmt.Fprintln(w, strings.Repeat("═", 60))
+	_, _ = fmt.Fprintln(w, common.BoldWhite.Sprintf("Thread: %s", thread.Subject))
+	_, _ = fmt.Fprintln(w, strings.Repeat("═", 60))
+	names := make([]string, len(thread.Participants))
+	for i, p := range thread.Participants {
+		names[i] = colors.sprint(p)
+	}
+	_, _ = fmt.Fprintf(w, "Participants: %s\n", strings.Join(names, ", "))
+	_, _ = fmt.Fprintf(w, "Messages:     %d\n", len(messages))
+
+	for i, msg := range messages {
+		_, _ = fmt.Fprintln(w)
+		sender := "(unknown sender)"
+		if len(msg.From) > 0 {
+			sender = colors.sprint(msg.From[0])
+		}
+		unread := ""
+		if msg.Unread {
+			unread = common.Cyan.Sprint(" ●")
+		}
+		_, _ = fmt.Fprintf(w, "%s %s%s\n", common.Dim.Sprintf("[%d/%d]", i+1, len(messages)), sender, unread)
+		_, _ = fmt.Fprintln(w, common.Dim.Sprintf("      %s (%s)", msg.Date.Format(common.DisplayDateTime), common.FormatTimeAgo(msg.Date)))
+		if len(msg.To) > 0 {
+			_, _ = fmt.Fprintln(w, common.Dim.Sprintf("      to %s", common.FormatParticipants(msg.To)))
+		}
+		if len(msg.Attachments) > 0 {
+			files := make([]string, len(msg.Attachments))
+			for j, a := range msg.Attachments {
+				files[j] = fmt.Sprintf("%s (%s)", a.Filename, common.FormatSize(a.Size))
+			}
+			_, _ = fmt.Fprintf(w, "      📎 %s\n", strings.Join(files, ", "))
+		}
+		_, _ = fmt.Fprintln(w, strings.Repeat("─", 60))
+
+		body := msg.Body
+		if body == "" {
+			body = msg.Snippet
+		}
+		body = common.StripHTML(body)
+		if !showQuoted {
+			body = collapseQuoted(body)
+		}
+		for _, line := range strings.Split(body, "\n") {
+			if strings.HasPrefix(line, "··· ") {
+				line = common.Dim.Sprint(line)
+			}
+			_, _ = fmt.Fprintln(w, line)
+		}
+	}
+}
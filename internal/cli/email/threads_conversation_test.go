@@ -0,0 +1,111 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestCollapseQuoted(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "attribution",
+			in:   "Sounds good.\n\nOn Mon, Mar 2, 2026 at 3:04 PM, Alice <alice@example.com> wrote:\nOriginal\nmore",
+			want: "Sounds good.\n··· 3 quoted lines hidden",
+		},
+		{
+			name: "wrapped attribution",
+			in:   "Yes\nOn Mon, Mar 2, 2026 at 3:04 PM Alice <alice@example.com>\nwrote:\nOriginal",
+			want: "Yes\n··· 3 quoted lines hidden",
+		},
+		{
+			name: "outlook header block",
+			in:   "Done.\n\nFrom: Alice\nSent: Monday\nTo: Bob\nSubject: Plan\n\nOriginal",
+			want: "Done.\n··· 5 quoted lines hidden",
+		},
+		{
+			name: "separator",
+			in:   "FYI\n-----Original Message-----\nOld",
+			want: "FYI\n··· 2 quoted lines hidden",
+		},
+		{
+			name: "inline quotes",
+			in:   "> question one\n> detail\nanswer one\n> question two\nanswer two",
+			want: "··· 2 quoted lines hidden\nanswer one\n··· 1 quoted line hidden\nanswer two",
+		},
+		{
+			name: "no quotes",
+			in:   "From: the desk of Bob\nhello",
+			want: "From: the desk of Bob\nhello",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, collapseQuoted(tt.in))
+		})
+	}
+}
+
+func TestFetchThreadMessages_Chronological(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetMessagesWithParamsFunc = func(_ context.Context, _ string, params *domain.MessageQueryParams) ([]domain.Message, error) {
+		assert.Equal(t, "thread-1", params.ThreadID)
+		return []domain.Message{
+			{ID: "m2", Date: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+			{ID: "m1", Date: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil
+	}
+
+	got, err := fetchThreadMessages(context.Background(), client, "grant-1", "thread-1")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "m1", got[0].ID)
+	assert.Equal(t, "m2", got[1].ID)
+}
+
+func TestPrintConversation(t *testing.T) {
+	alice := domain.EmailParticipant{Name: "Alice", Email: "alice@example.com"}
+	bob := domain.EmailParticipant{Name: "Bob", Email: "bob@example.com"}
+	thread := &domain.Thread{Subject: "Plan", Participants: []domain.EmailParticipant{alice, bob}}
+	messages := []domain.Message{
+		{From: []domain.EmailParticipant{alice}, To: []domain.EmailParticipant{bob}, Body: "<p>Shall we?</p>", Date: time.Now().Add(-time.Hour)},
+		{
+			From: []domain.EmailParticipant{bob}, Body: "Yes.<br>On Mon, Mar 2, 2026, Alice wrote:<br>Shall we?",
+			Attachments: []domain.Attachment{{Filename: "plan.pdf", Size: 2048}},
+			Unread:      true,
+		},
+	}
+
+	var out bytes.Buffer
+	printConversation(&out, thread, messages, false)
+	text := out.String()
+	assert.Contains(t, text, "Thread: Plan")
+	assert.Contains(t, text, "[1/2]")
+	assert.Contains(t, text, "📎 plan.pdf (2 KB)")
+	assert.Contains(t, text, "··· 2 quoted lines hidden")
+	assert.Equal(t, 1, strings.Count(text, "Shall we?"))
+
+	out.Reset()
+	printConversation(&out, thread, messages, true)
+	assert.Equal(t, 2, strings.Count(out.String(), "Shall we?"))
+}
+
+func TestThreadsShowCmd_Flags(t *testing.T) {
+	cmd := newThreadsShowCmd()
+	require.NotNil(t, cmd.Flags().Lookup("expand"))
+	assert.Equal(t, "e", cmd.Flags().Lookup("expand").Shorthand)
+	require.NotNil(t, cmd.Flags().Lookup("quoted"))
+	assert.Contains(t, newThreadsCmd().Aliases, "thread")
+}
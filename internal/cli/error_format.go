@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+)
+
+const (
+	errorFormatFlag = "error-format"
+	errorFormatEnv  = "NYLAS_ERROR_FORMAT"
+)
+
+// errorFormatValue is the --error-format flag: "text" or "json".
+type errorFormatValue string
+
+func (v *errorFormatValue) String() string {
+	if *v == "" {
+		return "text"
+	}
+	return string(*v)
+}
+
+func (v *errorFormatValue) Set(s string) error {
+	switch s {
+	case "text", "json":
+		*v = errorFormatValue(s)
+		return nil
+	}
+	return fmt.Errorf("must be text or json")
+}
+
+func (v *errorFormatValue) Type() string { return "string" }
+
+var errorFormat errorFormatValue
+
+// ReportError prints err to stderr in the --error-format format and returns
+// the exit code for it. ExitError is not printed: the command already wrote
+// its output.
+func ReportError(err error) int {
+	code := common.ExitCode(err)
+	var exitErr *common.ExitError
+	if errors.As(err, &exitErr) {
+		return code
+	}
+
+	err = common.AnnotateServiceStatus(err)
+	if currentErrorFormat() == "json" {
+		fmt.Fprint(os.Stderr, common.FormatErrorJSON(err))
+	} else {
+		fmt.Fprint(os.Stderr, common.FormatError(err))
+	}
+	return code
+}
+
+// currentErrorFormat is --error-format, or NYLAS_ERROR_FORMAT when the flag
+// was not given. Cobra reports unknown commands before parsing flags, so the
+// raw arguments are checked too.
+func currentErrorFormat() string {
+	if errorFormat != "" {
+		return string(errorFormat)
+	}
+	for i, arg := range os.Args {
+		if arg == "--"+errorFormatFlag+"=json" || (arg == "--"+errorFormatFlag && i+1 < len(os.Args) && os.Args[i+1] == "json") {
+			return "json"
+		}
+	}
+	if os.Getenv(errorFormatEnv) == "json" {
+		return "json"
+	}
+	return "text"
+}
+
+// usageError marks a flag or argument error as invalid input, so it exits
+// with ExitCodeValidation. Errors that already carry a code are kept.
+func usageError(cmd *cobra.Command, err error) error {
+	var cliErr *common.CLIError
+	if errors.As(err, &cliErr) {
+		return err
+	}
+	return &common.CLIError{
+		Err:        err,
+		Message:    err.Error(),
+		Suggestion: fmt.Sprintf("Run '%s --help' for usage", cmd.CommandPath()),
+		Code:       common.ErrCodeInvalidInput,
+	}
+}
+
+var wrapArgsOnce sync.Once
+
+// wrapArgsErrors makes every command's argument validation return a usage
+// error. Cobra has a hook for flag errors but none for argument errors.
+func wrapArgsErrors(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			if err := validate(c, args); err != nil {
+				return usageError(c, err)
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		wrapArgsErrors(child)
+	}
+}
+
+// classifyExecuteError marks cobra's own command lookup and flag group
+// errors as usage errors. cmd is the command that ran, or failed to.
+func classifyExecuteError(cmd *cobra.Command, err error) error {
+	if err == nil {
+		return nil
+	}
+	if cmd == nil {
+		cmd = rootCmd
+	}
+	msg := err.Error()
+	if strings.HasPrefix(msg, "unknown command ") || strings.HasPrefix(msg, "if any flags in the group ") {
+		return usageError(cmd, err)
+	}
+	return err
+}
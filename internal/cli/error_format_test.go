@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+)
+
+func newUsageFixtureRoot() *cobra.Command {
+	root := &cobra.Command{Use: "nylas", SilenceErrors: true, SilenceUsage: true}
+	root.SetFlagErrorFunc(usageError)
+	show := &cobra.Command{Use: "show <id>", Args: cobra.ExactArgs(1), RunE: func(*cobra.Command, []string) error { return nil }}
+	show.Flags().Int("limit", 0, "")
+	root.AddCommand(show)
+	wrapArgsErrors(root)
+	return root
+}
+
+func TestUsageErrors_ExitWithValidationCode(t *testing.T) {
+	tests := map[string][]string{
+		"unknown flag":    {"show", "id-1", "--bogus"},
+		"bad flag value":  {"show", "id-1", "--limit", "many"},
+		"wrong arg count": {"show"},
+		"too many args":   {"show", "id-1", "id-2"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := executeCommand(newUsageFixtureRoot(), args...)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := common.ExitCode(err); got != common.ExitCodeValidation {
+				t.Fatalf("ExitCode(%v) = %d, want %d", err, got, common.ExitCodeValidation)
+			}
+		})
+	}
+}
+
+func TestClassifyExecuteError_FlagGroups(t *testing.T) {
+	root := &cobra.Command{Use: "nylas", SilenceErrors: true, SilenceUsage: true}
+	list := &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+	list.Flags().String("page-token", "", "")
+	list.Flags().Bool("all", false, "")
+	list.MarkFlagsMutuallyExclusive("page-token", "all")
+	root.AddCommand(list)
+	root.SetArgs([]string{"list", "--page-token", "abc", "--all"})
+
+	err := classifyExecuteError(root.ExecuteC())
+	if got := common.ExitCode(err); got != common.ExitCodeValidation {
+		t.Fatalf("ExitCode(%v) = %d, want %d", err, got, common.ExitCodeValidation)
+	}
+}
+
+func TestUsageError_KeepsCategorizedErrors(t *testing.T) {
+	err := common.NewUserError("bad", "fix it")
+	if got := usageError(&cobra.Command{Use: "x"}, err); !errors.Is(got, err) {
+		t.Fatalf("usageError replaced a categorized error: %v", got)
+	}
+}
+
+func TestErrorFormatValue(t *testing.T) {
+	var v errorFormatValue
+	if v.String() != "text" {
+		t.Fatalf("default = %q, want text", v.String())
+	}
+	if err := v.Set("json"); err != nil || v.String() != "json" {
+		t.Fatalf("Set(json) = %v, value %q", err, v.String())
+	}
+	if err := v.Set("xml"); err == nil {
+		t.Fatal("Set(xml) should fail")
+	}
+}
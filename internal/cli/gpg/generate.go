@@ -0,0 +1,168 @@
+package gpg
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	gpgAdapter "github.com/nylas/cli/internal/adapters/gpg"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// passphraseEnv holds the passphrase for a new key in non-interactive use.
+const passphraseEnv = gpgAdapter.PassphraseEnv
+
+type generateOptions struct {
+	name         string
+	email        string
+	algorithm    string
+	expire       string
+	noPassphrase bool
+	setDefault   bool
+}
+
+func newGenerateCmd() *cobra.Command {
+	var opts generateOptions
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Create a new key pair",
+		Long: `Create a new key pair for signing and encrypting email.
+
+Defaults: an ed25519 signing key with a cv25519 encryption subkey, expiring
+in two years. Use --algo rsa4096 for recipients on older PGP software.
+
+The passphrase is prompted for, or read from NYLAS_GPG_PASSPHRASE.
+Use --no-passphrase only for keys on machines you fully control.`,
+		Example: `  # Create a key and make it the default for email send --sign
+  nylas gpg keys generate --name "Ada Lovelace" --email ada@example.com --set-default
+
+  # RSA key that never expires
+  nylas gpg keys generate --name "Ada" --email ada@example.com --algo rsa4096 --expire never`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := common.ValidateRequiredFlag("--name", opts.name); err != nil {
+				return err
+			}
+			if err := common.ValidateRequiredFlag("--email", opts.email); err != nil {
+				return err
+			}
+
+			passphrase, err := generatePassphrase(opts.noPassphrase)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateLongContext()
+			defer cancel()
+
+			svc, err := newBackend(ctx)
+			if err != nil {
+				return err
+			}
+
+			key, err := common.RunWithSpinnerResult("Generating key...", func() (*gpgAdapter.KeyInfo, error) {
+				return svc.GenerateKey(ctx, gpgAdapter.GenerateKeyOptions{
+					Name:       opts.name,
+					Email:      opts.email,
+					Algorithm:  opts.algorithm,
+					Expire:     opts.expire,
+					Passphrase: passphrase,
+				})
+			})
+			if err != nil {
+				return common.WrapGenerateError("GPG key", err)
+			}
+
+			if opts.setDefault {
+				if err := setDefaultKey(key.Fingerprint); err != nil {
+					return err
+				}
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(newKeyRow(*key, true, configuredDefaultKey()))
+			}
+			printGeneratedKey(key, opts.setDefault)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Your name for the key's user ID (required)")
+	cmd.Flags().StringVar(&opts.email, "email", "", "Email address for the key's user ID (required)")
+	cmd.Flags().StringVar(&opts.algorithm, "algo", gpgAdapter.DefaultKeyAlgorithm,
+		"Key algorithm: "+strings.Join(gpgAdapter.KeyAlgorithms(), " or "))
+	cmd.Flags().StringVar(&opts.expire, "expire", gpgAdapter.DefaultKeyExpire, "Expiry, e.g. 1y, 6m, 90d or never")
+	cmd.Flags().BoolVar(&opts.noPassphrase, "no-passphrase", false, "Create the key without a passphrase")
+	cmd.Flags().BoolVar(&opts.setDefault, "set-default", false, "Use the new key as gpg.default_key for email send --sign")
+
+	return cmd
+}
+
+// generatePassphrase reads the new key's passphrase from the environment
+// or prompts for it twice.
+func generatePassphrase(noPassphrase bool) (string, error) {
+	if noPassphrase {
+		return "", nil
+	}
+	if pw, ok := os.LookupEnv(passphraseEnv); ok && pw != "" {
+		return pw, nil
+	}
+
+	pw, err := common.PasswordPrompt("Passphrase for the new key")
+	if err != nil {
+		return "", err
+	}
+	if pw == "" {
+		return "", common.NewUserError("a passphrase is required",
+			"Enter one when prompted, set "+passphraseEnv+", or pass --no-passphrase")
+	}
+	confirm, err := common.PasswordPrompt("Repeat passphrase")
+	if err != nil {
+		return "", err
+	}
+	if confirm != pw {
+		return "", common.NewInputError("passphrases do not match")
+	}
+	return pw, nil
+}
+
+// setDefaultKey stores fingerprint as gpg.default_key.
+func setDefaultKey(fingerprint string) error {
+	store := newConfigStore()
+	cfg, err := store.Load()
+	if err != nil {
+		return common.WrapLoadError("config", err)
+	}
+	if cfg.GPG == nil {
+		cfg.GPG = &domain.GPGConfig{}
+	}
+	cfg.GPG.DefaultKey = fingerprint
+	if err := store.Save(cfg); err != nil {
+		return common.WrapSaveError("config", err)
+	}
+	return nil
+}
+
+func printGeneratedKey(key *gpgAdapter.KeyInfo, isDefault bool) {
+	common.PrintSuccess("Generated key %s", key.KeyID)
+	fmt.Printf("  Fingerprint: %s\n", key.Fingerprint)
+	for _, uid := range key.UIDs {
+		fmt.Printf("  User ID:     %s\n", uid)
+	}
+	if key.Expires != nil {
+		fmt.Printf("  Expires:     %s\n", key.Expires.Format("2006-01-02"))
+	} else {
+		fmt.Printf("  Expires:     never\n")
+	}
+	if isDefault {
+		fmt.Println("  Set as default signing key (gpg.default_key)")
+	}
+
+	fmt.Println("\nNext steps:")
+	fmt.Printf("  nylas gpg keys publish %s\n", key.KeyID)
+	fmt.Printf("  nylas gpg keys export %s --output key.asc\n", key.KeyID)
+	fmt.Printf("  nylas email send --sign --gpg-key %s --attach key.asc ...\n", key.KeyID)
+}
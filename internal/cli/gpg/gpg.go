@@ -0,0 +1,49 @@
+// Package gpg provides the gpg subcommands for managing OpenPGP keys used
+// by email send --sign and --encrypt.
+package gpg
+
+import (
+	gpgAdapter "github.com/nylas/cli/internal/adapters/gpg"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// NewGPGCmd creates the gpg command group.
+func NewGPGCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gpg",
+		Short: "Manage GPG/PGP keys for signed and encrypted email",
+		Long: `Manage the GPG keys used by 'nylas email send --sign' and '--encrypt'
+without dropping to the gpg command line. Requires GnuPG 2.1 or later.
+
+Commands:
+  keys list      List keys in your keyring
+  keys generate  Create a new key pair
+  keys import    Import keys from a file
+  keys export    Export a public key to share or attach
+  keys publish   Publish a public key to keys.openpgp.org`,
+	}
+
+	common.RequireCapabilities(cmd, domain.CapabilityGPG)
+
+	cmd.AddCommand(newKeysCmd())
+
+	return cmd
+}
+
+func newKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "List, generate, import, export and publish keys",
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []keyRow{}))
+	cmd.AddCommand(common.DeclareOutput(newGenerateCmd(), keyRow{}))
+	cmd.AddCommand(common.DeclareOutput(newImportCmd(), gpgAdapter.ImportResult{}))
+	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(common.DeclareOutput(newPublishCmd(), gpgAdapter.PublishResult{}))
+
+	return cmd
+}
@@ -0,0 +1,219 @@
+package gpg
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	gpgAdapter "github.com/nylas/cli/internal/adapters/gpg"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// useTestKeyring points gpg at an empty keyring and the commands at an
+// in-memory config.
+func useTestKeyring(t *testing.T) *configAdapter.MockConfigStore {
+	t.Helper()
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg not available")
+	}
+	t.Setenv("GNUPGHOME", t.TempDir())
+
+	store := configAdapter.NewMockConfigStore()
+	orig := newConfigStore
+	newConfigStore = func() ports.ConfigStore { return store }
+	t.Cleanup(func() { newConfigStore = orig })
+	return store
+}
+
+func TestNewGPGCmd(t *testing.T) {
+	cmd := NewGPGCmd()
+	assert.Equal(t, "gpg", cmd.Use)
+
+	keys, _, err := cmd.Find([]string{"keys"})
+	require.NoError(t, err)
+	var names []string
+	for _, sub := range keys.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"list", "generate", "import", "export", "publish"}, names)
+}
+
+func TestMatchesKey(t *testing.T) {
+	key := gpgAdapter.KeyInfo{
+		KeyID:       "C446DB4C8D0B2AD2",
+		Fingerprint: "68DBE29384F336F72C10834EC446DB4C8D0B2AD2",
+		UIDs:        []string{"Ada Lovelace <ada@example.com>"},
+	}
+	assert.True(t, matchesKey(key, "c446db4c8d0b2ad2"))
+	assert.True(t, matchesKey(key, "0x8D0B2AD2"))
+	assert.True(t, matchesKey(key, key.Fingerprint))
+	assert.True(t, matchesKey(key, "ADA@example.com"))
+	assert.False(t, matchesKey(key, "bob@example.com"))
+	assert.False(t, matchesKey(key, ""))
+}
+
+func TestNewKeyRow(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	row := newKeyRow(gpgAdapter.KeyInfo{KeyID: "ABCD", Expires: &past}, true, "")
+	assert.Equal(t, "expired", row.ExpiresText)
+	assert.Equal(t, []string{}, row.UIDs)
+	assert.Nil(t, row.Created)
+
+	row = newKeyRow(gpgAdapter.KeyInfo{KeyID: "ABCD", UIDs: []string{"A <a@example.com>"}}, false, "abcd")
+	assert.Equal(t, "never", row.ExpiresText)
+	assert.Equal(t, "A <a@example.com>", row.UserID)
+	assert.True(t, row.Default)
+}
+
+func TestGenerateCmd_RequiresFlags(t *testing.T) {
+	_, _, err := testutil.ExecuteSubCommand(newGenerateCmd(), "--email", "ada@example.com")
+	assert.ErrorContains(t, err, "--name")
+
+	_, _, err = testutil.ExecuteSubCommand(newGenerateCmd(), "--name", "Ada")
+	assert.ErrorContains(t, err, "--email")
+}
+
+func TestGeneratePassphrase(t *testing.T) {
+	pw, err := generatePassphrase(true)
+	require.NoError(t, err)
+	assert.Empty(t, pw)
+
+	t.Setenv(passphraseEnv, "s3cret")
+	pw, err = generatePassphrase(false)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", pw)
+
+	// Not a terminal and no env: refuse to create an unprotected key silently.
+	t.Setenv(passphraseEnv, "")
+	_, err = generatePassphrase(false)
+	assert.ErrorContains(t, err, "a passphrase is required")
+}
+
+func TestKeysCommands_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+	store := useTestKeyring(t)
+
+	stdout, _, err := testutil.ExecuteSubCommand(newGenerateCmd(),
+		"--name", "Ada Lovelace", "--email", "ada@example.com", "--no-passphrase", "--set-default", "--json")
+	require.NoError(t, err)
+	var generated keyRow
+	require.NoError(t, json.Unmarshal([]byte(stdout), &generated))
+	assert.Len(t, generated.Fingerprint, 40)
+	assert.True(t, generated.Default)
+
+	cfg, err := store.Load()
+	require.NoError(t, err)
+	require.NotNil(t, cfg.GPG)
+	assert.Equal(t, generated.Fingerprint, cfg.GPG.DefaultKey)
+
+	stdout, _, err = testutil.ExecuteSubCommand(newListCmd(), "--json")
+	require.NoError(t, err)
+	var rows []keyRow
+	require.NoError(t, json.Unmarshal([]byte(stdout), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, []string{"Ada Lovelace <ada@example.com>"}, rows[0].UIDs)
+
+	// Export without an argument uses gpg.default_key.
+	keyFile := filepath.Join(t.TempDir(), "ada.asc")
+	stdout, _, err = testutil.ExecuteSubCommand(newExportCmd(), "--output", keyFile)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "--sign --attach "+keyFile)
+	armored, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(armored), "BEGIN PGP PUBLIC KEY BLOCK")
+
+	// Import into a fresh keyring.
+	t.Setenv("GNUPGHOME", t.TempDir())
+	stdout, _, err = testutil.ExecuteSubCommand(newImportCmd(), keyFile, "--json")
+	require.NoError(t, err)
+	var imported gpgAdapter.ImportResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &imported))
+	assert.Equal(t, 1, imported.Imported)
+	assert.Equal(t, []string{generated.Fingerprint}, imported.Fingerprints)
+
+	stdout, _, err = testutil.ExecuteSubCommand(newListCmd(), "--public", "--json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(stdout), &rows))
+	require.Len(t, rows, 1)
+	assert.False(t, rows[0].Secret)
+}
+
+func TestKeysCommands_NativeBackend(t *testing.T) {
+	store := configAdapter.NewMockConfigStore()
+	cfg, err := store.Load()
+	require.NoError(t, err)
+	cfg.GPG = &domain.GPGConfig{Backend: domain.GPGBackendNative}
+	require.NoError(t, store.Save(cfg))
+	orig := newConfigStore
+	newConfigStore = func() ports.ConfigStore { return store }
+	t.Cleanup(func() { newConfigStore = orig })
+
+	dir := t.TempDir()
+	t.Setenv(common.OpenPGPHomeEnv, dir)
+	// No gpg on PATH: the native backend must not need it.
+	t.Setenv("PATH", "")
+
+	stdout, _, err := testutil.ExecuteSubCommand(newGenerateCmd(),
+		"--name", "Ada", "--email", "ada@example.com", "--no-passphrase", "--set-default", "--json")
+	require.NoError(t, err)
+	var generated keyRow
+	require.NoError(t, json.Unmarshal([]byte(stdout), &generated))
+	assert.True(t, generated.Default)
+	assert.FileExists(t, filepath.Join(dir, "secring.asc"))
+
+	stdout, _, err = testutil.ExecuteSubCommand(newExportCmd())
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "BEGIN PGP PUBLIC KEY BLOCK")
+
+	stdout, _, err = testutil.ExecuteSubCommand(newListCmd(), "--json")
+	require.NoError(t, err)
+	var rows []keyRow
+	require.NoError(t, json.Unmarshal([]byte(stdout), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, generated.Fingerprint, rows[0].Fingerprint)
+}
+
+func TestPublishCmd_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+	useTestKeyring(t)
+	_, _, err := testutil.ExecuteSubCommand(newGenerateCmd(),
+		"--name", "Ada", "--email", "ada@example.com", "--no-passphrase", "--set-default")
+	require.NoError(t, err)
+
+	var uploaded string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/vks/v1/upload":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			uploaded = body["keytext"]
+			_, _ = w.Write([]byte(`{"key_fpr":"F00D","token":"t","status":{"ada@example.com":"unpublished"}}`))
+		case "/vks/v1/request-verify":
+			_, _ = w.Write([]byte(`{"key_fpr":"F00D","token":"t","status":{"ada@example.com":"pending"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	stdout, _, err := testutil.ExecuteSubCommand(newPublishCmd(), "--keyserver", srv.URL)
+	require.NoError(t, err)
+	assert.Contains(t, uploaded, "BEGIN PGP PUBLIC KEY BLOCK")
+	assert.Contains(t, stdout, "ada@example.com")
+	assert.Contains(t, stdout, "pending")
+	assert.Contains(t, stdout, "Verification emails sent to 1 address(es)")
+}
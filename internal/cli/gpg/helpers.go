@@ -0,0 +1,112 @@
+package gpg
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	gpgAdapter "github.com/nylas/cli/internal/adapters/gpg"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newConfigStore is replaced in tests.
+var newConfigStore = func() ports.ConfigStore {
+	return configAdapter.NewDefaultFileStore()
+}
+
+// keyRow is the list/structured-output view of a key.
+type keyRow struct {
+	KeyID       string     `json:"key_id"`
+	Fingerprint string     `json:"fingerprint"`
+	UIDs        []string   `json:"uids"`
+	UserID      string     `json:"-"`
+	Created     *time.Time `json:"created,omitempty"`
+	Expires     *time.Time `json:"expires,omitempty"`
+	ExpiresText string     `json:"-"`
+	Secret      bool       `json:"secret"`
+	Default     bool       `json:"default,omitempty"`
+}
+
+var keyColumns = []ports.Column{
+	{Header: "KEY ID", Field: "KeyID", Width: 16},
+	{Header: "USER ID", Field: "UserID", Width: 44},
+	{Header: "EXPIRES", Field: "ExpiresText", Width: 10},
+}
+
+func newKeyRow(key gpgAdapter.KeyInfo, secret bool, defaultKey string) keyRow {
+	row := keyRow{
+		KeyID:       key.KeyID,
+		Fingerprint: key.Fingerprint,
+		UIDs:        key.UIDs,
+		Expires:     key.Expires,
+		ExpiresText: "never",
+		Secret:      secret,
+		Default:     defaultKey != "" && matchesKey(key, defaultKey),
+	}
+	if row.UIDs == nil {
+		row.UIDs = []string{}
+	}
+	if len(key.UIDs) > 0 {
+		row.UserID = key.UIDs[0]
+	}
+	if !key.Created.IsZero() {
+		created := key.Created
+		row.Created = &created
+	}
+	if key.Expires != nil {
+		row.ExpiresText = key.Expires.Format("2006-01-02")
+		if key.Expires.Before(time.Now()) {
+			row.ExpiresText = "expired"
+		}
+	}
+	return row
+}
+
+// matchesKey reports whether ref (key ID, fingerprint or email) names key.
+func matchesKey(key gpgAdapter.KeyInfo, ref string) bool {
+	ref = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(ref)), "0X")
+	if ref == "" {
+		return false
+	}
+	if strings.EqualFold(key.KeyID, ref) || strings.HasSuffix(strings.ToUpper(key.Fingerprint), ref) {
+		return true
+	}
+	for _, uid := range key.UIDs {
+		if strings.Contains(strings.ToUpper(uid), "<"+ref+">") || strings.EqualFold(uid, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// configuredDefaultKey returns gpg.default_key from the Nylas config.
+func configuredDefaultKey() string {
+	cfg, err := newConfigStore().Load()
+	if err != nil || cfg == nil || cfg.GPG == nil {
+		return ""
+	}
+	return cfg.GPG.DefaultKey
+}
+
+// resolveKeyRef returns ref, or the default signing key (Nylas config, then
+// git config) when ref is empty.
+func resolveKeyRef(ctx context.Context, svc gpgAdapter.Backend, ref string) (string, error) {
+	if ref = strings.TrimSpace(ref); ref != "" {
+		return ref, nil
+	}
+	if key := configuredDefaultKey(); key != "" {
+		return key, nil
+	}
+	if key, err := svc.GetDefaultSigningKey(ctx); err == nil {
+		return key.Fingerprint, nil
+	}
+	return "", common.NewUserError("no key specified and no default signing key configured",
+		"Pass a key ID or email, or set one with: nylas gpg keys generate --set-default")
+}
+
+// newBackend returns the OpenPGP backend selected by gpg.backend.
+func newBackend(ctx context.Context) (gpgAdapter.Backend, error) {
+	return common.NewGPGBackend(ctx, newConfigStore())
+}
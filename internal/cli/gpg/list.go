@@ -0,0 +1,78 @@
+package gpg
+
+import (
+	"fmt"
+
+	gpgAdapter "github.com/nylas/cli/internal/adapters/gpg"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/spf13/cobra"
+)
+
+func newListCmd() *cobra.Command {
+	var public bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List keys in your keyring",
+		Long: `List the secret keys you can sign with, or with --public every public
+key in the keyring (including recipients' keys used by --encrypt).`,
+		Example: `  # Keys you can sign with
+  nylas gpg keys list
+
+  # All public keys, as JSON
+  nylas gpg keys list --public --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			svc, err := newBackend(ctx)
+			if err != nil {
+				return err
+			}
+
+			var keys []gpgAdapter.KeyInfo
+			if public {
+				keys, err = svc.ListPublicKeys(ctx)
+			} else {
+				keys, err = svc.ListSigningKeys(ctx)
+			}
+			if err != nil {
+				return common.WrapListError("GPG keys", err)
+			}
+
+			defaultKey := configuredDefaultKey()
+			rows := make([]keyRow, 0, len(keys))
+			for _, key := range keys {
+				rows = append(rows, newKeyRow(key, !public, defaultKey))
+			}
+
+			out := common.GetOutputWriter(cmd)
+			if common.IsStructuredOutput(cmd) {
+				return out.Write(rows)
+			}
+			if len(rows) == 0 {
+				if public {
+					fmt.Println("No public keys found.")
+				} else {
+					fmt.Println("No GPG signing keys found.")
+					fmt.Println("\nCreate one with: nylas gpg keys generate --name \"Your Name\" --email you@example.com")
+				}
+				return nil
+			}
+			if err := out.WriteList(rows, keyColumns); err != nil {
+				return err
+			}
+			for _, row := range rows {
+				if row.Default {
+					_, _ = common.Dim.Printf("\nDefault signing key (gpg.default_key): %s\n", row.KeyID)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&public, "public", false, "List all public keys instead of your signing keys")
+
+	return cmd
+}
@@ -0,0 +1,86 @@
+package gpg
+
+import (
+	"fmt"
+	"sort"
+
+	gpgAdapter "github.com/nylas/cli/internal/adapters/gpg"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/spf13/cobra"
+)
+
+func newPublishCmd() *cobra.Command {
+	var keyserver string
+
+	cmd := &cobra.Command{
+		Use:   "publish [key-id|email]",
+		Short: "Publish a public key to keys.openpgp.org",
+		Long: `Upload a public key so recipients (and 'nylas email send --encrypt') can
+find it by email address. Without an argument, publishes the default
+signing key.
+
+keys.openpgp.org only serves addresses whose owner confirmed them, so each
+unverified address receives a verification email. The key is findable by
+fingerprint immediately and by address once you click the link.`,
+		Example: `  # Publish your default key
+  nylas gpg keys publish
+
+  # Publish a specific key to another VKS keyserver
+  nylas gpg keys publish ada@example.com --keyserver https://keys.example.org`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			svc, err := newBackend(ctx)
+			if err != nil {
+				return err
+			}
+
+			ref, err := resolveKeyRef(ctx, svc, firstArg(args))
+			if err != nil {
+				return err
+			}
+			armored, err := svc.ExportPublicKey(ctx, ref)
+			if err != nil {
+				return common.WrapGetError("public key", err)
+			}
+
+			publisher := gpgAdapter.NewKeyPublisher(keyserver)
+			result, err := common.RunWithSpinnerResult("Publishing to "+publisher.Server()+"...", func() (*gpgAdapter.PublishResult, error) {
+				return publisher.Publish(ctx, armored)
+			})
+			if err != nil {
+				return common.NewUserError(fmt.Sprintf("failed to publish key: %v", err),
+					"Check your network connection and the --keyserver URL")
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(result)
+			}
+			printPublishResult(result)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&keyserver, "keyserver", gpgAdapter.DefaultPublishServer, "VKS keyserver URL")
+
+	return cmd
+}
+
+func printPublishResult(result *gpgAdapter.PublishResult) {
+	common.PrintSuccess("Uploaded key %s to %s", result.Fingerprint, result.Server)
+
+	addrs := make([]string, 0, len(result.Addresses))
+	for addr := range result.Addresses {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	for _, addr := range addrs {
+		fmt.Printf("  %-40s %s\n", addr, result.Addresses[addr])
+	}
+
+	if len(result.Requested) > 0 {
+		fmt.Printf("\nVerification emails sent to %d address(es). Click the link in each to make\nthe key findable by that address.\n", len(result.Requested))
+	}
+}
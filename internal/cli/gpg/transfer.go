@@ -0,0 +1,147 @@
+package gpg
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// maxKeyFileSize caps key files read by import.
+const maxKeyFileSize = 10 << 20
+
+func newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file|->",
+		Short: "Import keys from a file",
+		Long: `Import ASCII-armored or binary OpenPGP keys, such as a correspondent's
+public key for email send --encrypt or your own key from another machine.
+Use - to read from stdin.`,
+		Example: `  # Import a colleague's key received as an attachment
+  nylas gpg keys import bob.asc
+
+  # Import from stdin
+  curl -s https://example.com/key.asc | nylas gpg keys import -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := readKeyFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			svc, err := newBackend(ctx)
+			if err != nil {
+				return err
+			}
+
+			result, err := svc.ImportKeys(ctx, data)
+			if err != nil {
+				return common.NewUserError(fmt.Sprintf("failed to import keys: %v", err),
+					"Check that the file contains an OpenPGP key block")
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(result)
+			}
+			common.PrintSuccess("Imported %d new, %d unchanged, %d secret", result.Imported, result.Unchanged, result.SecretKeys)
+			for _, fpr := range result.Fingerprints {
+				fmt.Printf("  %s\n", fpr)
+			}
+			if result.NotImported > 0 {
+				common.PrintWarning("%d key(s) could not be imported", result.NotImported)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newExportCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export [key-id|email]",
+		Short: "Export a public key to share or attach",
+		Long: `Export an ASCII-armored public key. Without an argument, exports the
+default signing key (gpg.default_key, then git's user.signingkey).
+
+Write it to a file with --output and attach it to a signed introduction
+email so recipients can verify your signatures and encrypt to you.`,
+		Example: `  # Print your default public key
+  nylas gpg keys export
+
+  # Send a signed intro email with your key attached
+  nylas gpg keys export ada@example.com --output ada.asc
+  nylas email send --to bob@example.com --subject "My PGP key" \
+    --body "My public key is attached." --sign --attach ada.asc`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			svc, err := newBackend(ctx)
+			if err != nil {
+				return err
+			}
+
+			ref, err := resolveKeyRef(ctx, svc, firstArg(args))
+			if err != nil {
+				return err
+			}
+			armored, err := svc.ExportPublicKey(ctx, ref)
+			if err != nil {
+				return common.WrapGetError("public key", err)
+			}
+
+			if output == "" {
+				_, err := cmd.OutOrStdout().Write(armored)
+				return err
+			}
+			if err := os.WriteFile(output, armored, 0o600); err != nil {
+				return common.WrapWriteError("public key", err)
+			}
+			common.PrintSuccess("Exported public key %s to %s", ref, output)
+			fmt.Printf("\nAttach it to a signed email:\n  nylas email send --to <address> --sign --attach %s ...\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the key to a file (e.g. key.asc) instead of stdout")
+
+	return cmd
+}
+
+func readKeyFile(path string) ([]byte, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path) // #nosec G304 -- user-specified key file
+		if err != nil {
+			return nil, common.WrapLoadError("key file", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	data, err := io.ReadAll(io.LimitReader(r, maxKeyFileSize+1))
+	if err != nil {
+		return nil, common.WrapLoadError("key file", err)
+	}
+	if len(data) > maxKeyFileSize {
+		return nil, common.NewInputError("key file is larger than 10 MB")
+	}
+	return data, nil
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// contactNode is one email address in the communication graph.
+type contactNode struct {
+	Email    string `json:"email"`
+	Name     string `json:"name,omitempty"`
+	Sent     int    `json:"sent"`
+	Received int    `json:"received"`
+}
+
+// contactEdge counts messages from one address to another.
+type contactEdge struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Messages  int       `json:"messages"`
+	Replies   int       `json:"replies"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	// AvgResponseSeconds is how long From takes on average to reply to To.
+	AvgResponseSeconds float64 `json:"avg_response_seconds,omitempty"`
+
+	totalResponse time.Duration
+}
+
+// contactGraph is a directed graph of who emails whom.
+type contactGraph struct {
+	Since    time.Time     `json:"since"`
+	Messages int           `json:"messages"`
+	Nodes    []contactNode `json:"nodes"`
+	Edges    []contactEdge `json:"edges"`
+}
+
+// buildContactGraph builds the graph from messages. Each message adds an
+// edge from its sender to every To, Cc and Bcc recipient. Within a thread, a
+// message from someone who was a recipient of an earlier message counts as a
+// reply to that message's sender, and the gap is its response latency.
+// Edges with fewer than minMessages messages are dropped, along with nodes
+// left without edges.
+func buildContactGraph(messages []domain.Message, since time.Time, minMessages int) *contactGraph {
+	nodes := make(map[string]*contactNode)
+	edges := make(map[[2]string]*contactEdge)
+
+	node := func(p domain.EmailParticipant) *contactNode {
+		email := normalizeEmail(p.Email)
+		n, ok := nodes[email]
+		if !ok {
+			n = &contactNode{Email: email}
+			nodes[email] = n
+		}
+		if n.Name == "" && p.Name != "" && !strings.EqualFold(p.Name, p.Email) {
+			n.Name = p.Name
+		}
+		return n
+	}
+	edge := func(from, to string) *contactEdge {
+		key := [2]string{from, to}
+		e, ok := edges[key]
+		if !ok {
+			e = &contactEdge{From: from, To: to}
+			edges[key] = e
+		}
+		return e
+	}
+
+	sorted := slices.Clone(messages)
+	slices.SortStableFunc(sorted, func(a, b domain.Message) int { return a.Date.Compare(b.Date) })
+
+	counted := 0
+	byThread := make(map[string][]domain.Message)
+	for _, msg := range sorted {
+		if len(msg.From) == 0 || msg.From[0].Email == "" {
+			continue
+		}
+		counted++
+		sender := node(msg.From[0])
+		sender.Sent++
+
+		seen := map[string]bool{sender.Email: true}
+		for _, p := range recipients(msg) {
+			email := normalizeEmail(p.Email)
+			if email == "" || seen[email] {
+				continue
+			}
+			seen[email] = true
+			rcpt := node(p)
+			rcpt.Received++
+
+			e := edge(sender.Email, rcpt.Email)
+			e.Messages++
+			if e.FirstSeen.IsZero() || msg.Date.Before(e.FirstSeen) {
+				e.FirstSeen = msg.Date
+			}
+			if msg.Date.After(e.LastSeen) {
+				e.LastSeen = msg.Date
+			}
+		}
+
+		if msg.ThreadID == "" {
+			continue
+		}
+		if prev := repliedTo(byThread[msg.ThreadID], sender.Email); prev != nil {
+			e := edge(sender.Email, normalizeEmail(prev.From[0].Email))
+			e.Replies++
+			e.totalResponse += msg.Date.Sub(prev.Date)
+		}
+		byThread[msg.ThreadID] = append(byThread[msg.ThreadID], msg)
+	}
+
+	graph := &contactGraph{Since: since, Messages: counted}
+	linked := make(map[string]bool)
+	for _, e := range edges {
+		// Reply-only edges (the reply went to someone else) have no
+		// messages of their own.
+		if e.Messages < max(minMessages, 1) {
+			continue
+		}
+		if e.Replies > 0 {
+			e.AvgResponseSeconds = (e.totalResponse / time.Duration(e.Replies)).Seconds()
+		}
+		graph.Edges = append(graph.Edges, *e)
+		linked[e.From], linked[e.To] = true, true
+	}
+	for email, n := range nodes {
+		if linked[email] {
+			graph.Nodes = append(graph.Nodes, *n)
+		}
+	}
+
+	slices.SortFunc(graph.Nodes, func(a, b contactNode) int {
+		return cmp.Or(cmp.Compare(b.Sent+b.Received, a.Sent+a.Received), strings.Compare(a.Email, b.Email))
+	})
+	slices.SortFunc(graph.Edges, func(a, b contactEdge) int {
+		return cmp.Or(cmp.Compare(b.Messages, a.Messages), strings.Compare(a.From, b.From), strings.Compare(a.To, b.To))
+	})
+	return graph
+}
+
+// repliedTo returns the latest earlier message in the thread that sender
+// received from someone else, or nil.
+func repliedTo(thread []domain.Message, sender string) *domain.Message {
+	for i := len(thread) - 1; i >= 0; i-- {
+		prev := &thread[i]
+		if normalizeEmail(prev.From[0].Email) == sender {
+			continue
+		}
+		for _, p := range recipients(*prev) {
+			if normalizeEmail(p.Email) == sender {
+				return prev
+			}
+		}
+	}
+	return nil
+}
+
+func recipients(msg domain.Message) []domain.EmailParticipant {
+	out := make([]domain.EmailParticipant, 0, len(msg.To)+len(msg.Cc)+len(msg.Bcc))
+	out = append(out, msg.To...)
+	out = append(out, msg.Cc...)
+	return append(out, msg.Bcc...)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func participant(email string) domain.EmailParticipant {
+	return domain.EmailParticipant{Email: email}
+}
+
+func TestBuildContactGraph(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	messages := []domain.Message{
+		// Out of order on purpose: the builder sorts by date.
+		{ID: "m2", ThreadID: "t1", Date: base.Add(2 * time.Hour),
+			From: []domain.EmailParticipant{participant("bob@example.com")},
+			To:   []domain.EmailParticipant{participant("Alice@Example.com")}},
+		{ID: "m1", ThreadID: "t1", Date: base,
+			From: []domain.EmailParticipant{{Name: "Alice", Email: "alice@example.com"}},
+			To:   []domain.EmailParticipant{participant("bob@example.com")},
+			Cc:   []domain.EmailParticipant{participant("carol@example.com"), participant("bob@example.com")}},
+		{ID: "m3", ThreadID: "t2", Date: base.Add(24 * time.Hour),
+			From: []domain.EmailParticipant{participant("alice@example.com")},
+			To:   []domain.EmailParticipant{participant("bob@example.com")}},
+		{ID: "no-sender", Date: base, To: []domain.EmailParticipant{participant("bob@example.com")}},
+	}
+
+	g := buildContactGraph(messages, base, 1)
+
+	assert.Equal(t, 3, g.Messages)
+	require.Len(t, g.Edges, 3)
+
+	ab := g.Edges[0]
+	assert.Equal(t, "alice@example.com", ab.From)
+	assert.Equal(t, "bob@example.com", ab.To)
+	assert.Equal(t, 2, ab.Messages, "duplicate recipients count once per message")
+	assert.Equal(t, base, ab.FirstSeen)
+	assert.Equal(t, base.Add(24*time.Hour), ab.LastSeen)
+
+	var ba contactEdge
+	for _, e := range g.Edges {
+		if e.From == "bob@example.com" {
+			ba = e
+		}
+	}
+	assert.Equal(t, "alice@example.com", ba.To, "addresses are normalized")
+	assert.Equal(t, 1, ba.Replies)
+	assert.Equal(t, (2 * time.Hour).Seconds(), ba.AvgResponseSeconds)
+
+	require.Len(t, g.Nodes, 3)
+	assert.Equal(t, "alice@example.com", g.Nodes[0].Email)
+	assert.Equal(t, "Alice", g.Nodes[0].Name)
+	assert.Equal(t, 2, g.Nodes[0].Sent)
+}
+
+func TestBuildContactGraphMinMessages(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	messages := []domain.Message{
+		{Date: base, From: []domain.EmailParticipant{participant("a@x.com")}, To: []domain.EmailParticipant{participant("b@x.com")}},
+		{Date: base, From: []domain.EmailParticipant{participant("a@x.com")}, To: []domain.EmailParticipant{participant("b@x.com")}},
+		{Date: base, From: []domain.EmailParticipant{participant("a@x.com")}, To: []domain.EmailParticipant{participant("c@x.com")}},
+	}
+
+	g := buildContactGraph(messages, base, 2)
+
+	require.Len(t, g.Edges, 1)
+	assert.Equal(t, "b@x.com", g.Edges[0].To)
+	assert.Len(t, g.Nodes, 2, "nodes without edges are dropped")
+}
+
+func TestParseGraphSince(t *testing.T) {
+	now := time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "6m", want: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{in: "2mo", want: time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)},
+		{in: "1y", want: time.Date(2023, 7, 15, 12, 0, 0, 0, time.UTC)},
+		{in: "30d", want: now.AddDate(0, 0, -30)},
+		{in: "2w", want: now.AddDate(0, 0, -14)},
+		{in: "2024-03-01", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "soon", wantErr: true},
+		{in: "0d", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseGraphSince(tt.in, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s", got)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// defaultMaxMessages caps how many messages a graph is built from.
+const defaultMaxMessages = 5000
+
+func newContactsCmd() *cobra.Command {
+	var (
+		since       string
+		out         string
+		format      string
+		minMessages int
+		limit       int
+		top         int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "contacts [grant-id]",
+		Short: "Build a who-emails-whom graph",
+		Long: `Build a directed communication graph from recent messages.
+
+Each address is a node. An edge from A to B counts the messages A sent to B
+(To, Cc or Bcc), when they were first and last seen, and how many of them
+were replies within a thread along with the average response time.
+
+With --out, the graph is written to a file whose extension picks the format:
+  .graphml        GraphML, for Gephi, yEd, Cytoscape and NetworkX (default)
+  .dot, .gv       Graphviz DOT, e.g. dot -Tsvg graph.dot > graph.svg
+  .json           nodes and edges as JSON
+
+Without --out, the busiest edges are printed as a table.`,
+		Example: `  # Export the last 6 months for Gephi
+  nylas graph contacts --since 6m --out graph.graphml
+
+  # Render with Graphviz, ignoring one-off messages
+  nylas graph contacts --since 90d --min-messages 3 --out graph.dot
+
+  # Show the 10 busiest links since a date
+  nylas graph contacts --since 2024-01-01 --top 10`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, err := parseGraphSince(since, time.Now())
+			if err != nil {
+				return err
+			}
+			if format == "" {
+				format = formatFromPath(out)
+			}
+			if err := common.ValidateOneOf("graph format", format, exportFormats); err != nil {
+				return err
+			}
+
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				messages, err := common.RunWithSpinnerResult("Fetching messages...", func() ([]domain.Message, error) {
+					return fetchGraphMessages(ctx, client, grantID, start, limit)
+				})
+				if err != nil {
+					return struct{}{}, common.WrapListError("messages", err)
+				}
+				if limit > 0 && len(messages) >= limit {
+					common.PrintWarningStderr("Stopped at %d messages; raise --limit to cover the whole period", limit)
+				}
+
+				graph := buildContactGraph(messages, start, minMessages)
+
+				if out != "" {
+					return struct{}{}, exportGraph(out, graph, format)
+				}
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(graph)
+				}
+				if len(graph.Edges) == 0 {
+					common.PrintEmptyStateWithHint("connections", "Try a longer period, e.g. --since 1y")
+					return struct{}{}, nil
+				}
+				printTopEdges(cmd, graph, top)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "6m", "Period to analyze: 30d, 6m (months), 1y, or a date (YYYY-MM-DD)")
+	cmd.Flags().StringVarP(&out, "out", "o", "", "Write the graph to a file (- for stdout); format from the extension")
+	cmd.Flags().StringVar(&format, "graph-format", "", "Export format: graphml, dot, json (overrides the extension)")
+	cmd.Flags().IntVar(&minMessages, "min-messages", 1, "Drop edges with fewer messages")
+	cmd.Flags().IntVar(&limit, "limit", defaultMaxMessages, "Maximum messages to analyze (0 for no limit)")
+	cmd.Flags().IntVar(&top, "top", 20, "Number of edges to show in the table")
+
+	return cmd
+}
+
+// fetchGraphMessages pages through messages received after start, newest
+// first, up to maxItems (0 = all).
+func fetchGraphMessages(ctx context.Context, client ports.NylasClient, grantID string, start time.Time, maxItems int) ([]domain.Message, error) {
+	pageSize := common.NormalizePageSize(common.MaxAPILimit)
+	params := &domain.MessageQueryParams{Limit: pageSize, ReceivedAfter: start.Unix()}
+	return common.FetchCursorPages(ctx, pageSize, maxItems, func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+		params.PageToken = cursor
+		resp, err := client.GetMessagesWithCursor(ctx, grantID, params)
+		if err != nil {
+			return common.PageResult[domain.Message]{}, err
+		}
+		return common.PageResult[domain.Message]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+}
+
+var monthsPattern = regexp.MustCompile(`^(\d+)(m|mo|y)$`)
+
+// parseGraphSince resolves --since to a start time. Unlike common.ParseDuration,
+// "m" means months here ("6m" is half a year); "y" is years. Dates and the
+// d/w/h suffixes are also accepted.
+func parseGraphSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return t, nil
+	}
+	if m := monthsPattern.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "y" {
+			return now.AddDate(-n, 0, 0), nil
+		}
+		return now.AddDate(0, -n, 0), nil
+	}
+	d, err := common.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return time.Time{}, common.NewInputError(fmt.Sprintf("invalid --since value %q (use 30d, 6m, 1y or YYYY-MM-DD)", s))
+	}
+	return now.Add(-d), nil
+}
+
+func exportGraph(path string, graph *contactGraph, format string) error {
+	if path == "-" {
+		return writeGraph(os.Stdout, graph, format)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return common.WrapError(fmt.Errorf("failed to create %s: %w", path, err))
+	}
+	if err := writeGraph(f, graph, format); err != nil {
+		_ = f.Close()
+		return common.WrapError(fmt.Errorf("failed to write %s: %w", path, err))
+	}
+	if err := f.Close(); err != nil {
+		return common.WrapError(fmt.Errorf("failed to write %s: %w", path, err))
+	}
+	common.PrintSuccess("Wrote %d contacts and %d connections from %d messages to %s",
+		len(graph.Nodes), len(graph.Edges), graph.Messages, path)
+	return nil
+}
+
+func printTopEdges(cmd *cobra.Command, graph *contactGraph, top int) {
+	table := common.NewTable("FROM", "TO", "MESSAGES", "REPLIES", "AVG RESPONSE").
+		SetWriter(cmd.OutOrStdout()).
+		AlignRight(2).AlignRight(3).AlignRight(4)
+	for i, e := range graph.Edges {
+		if top > 0 && i >= top {
+			break
+		}
+		latency := "-"
+		if e.Replies > 0 {
+			latency = formatLatency(e.AvgResponseSeconds)
+		}
+		table.AddRow(e.From, e.To, strconv.Itoa(e.Messages), strconv.Itoa(e.Replies), latency)
+	}
+	table.Render()
+	_, _ = common.Dim.Fprintf(cmd.OutOrStdout(), "\n%d contacts, %d connections from %d messages since %s\n",
+		len(graph.Nodes), len(graph.Edges), graph.Messages, graph.Since.Format(time.DateOnly))
+}
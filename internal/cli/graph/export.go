@@ -0,0 +1,141 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// exportFormats are the supported --graph-format values.
+var exportFormats = []string{"graphml", "dot", "json"}
+
+// formatFromPath infers the export format from a file extension.
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".dot", ".gv":
+		return "dot"
+	case ".json":
+		return "json"
+	default:
+		return "graphml"
+	}
+}
+
+// writeGraph writes g in the given format.
+func writeGraph(w io.Writer, g *contactGraph, format string) error {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case "dot":
+		writeDOT(bw, g)
+	case "json":
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(g); err != nil {
+			return err
+		}
+	default:
+		writeGraphML(bw, g)
+	}
+	return bw.Flush()
+}
+
+// writeGraphML writes GraphML, which Gephi, yEd, Cytoscape and NetworkX read.
+// Edge "weight" is the message count, which Gephi uses for layout.
+func writeGraphML(w io.Writer, g *contactGraph) {
+	_, _ = fmt.Fprintln(w, `<?xml version="1.0" encoding="UTF-8"?>`)
+	_, _ = fmt.Fprintln(w, `<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	for _, key := range []struct{ id, target, name, typ string }{
+		{"label", "node", "label", "string"},
+		{"sent", "node", "sent", "int"},
+		{"received", "node", "received", "int"},
+		{"weight", "edge", "weight", "double"},
+		{"replies", "edge", "replies", "int"},
+		{"avg_response_seconds", "edge", "avg_response_seconds", "double"},
+		{"first_seen", "edge", "first_seen", "string"},
+		{"last_seen", "edge", "last_seen", "string"},
+	} {
+		_, _ = fmt.Fprintf(w, "  <key id=%q for=%q attr.name=%q attr.type=%q/>\n", key.id, key.target, key.name, key.typ)
+	}
+	_, _ = fmt.Fprintln(w, `  <graph id="contacts" edgedefault="directed">`)
+
+	for _, n := range g.Nodes {
+		_, _ = fmt.Fprintf(w, "    <node id=\"%s\">\n", xmlEscape(n.Email))
+		_, _ = fmt.Fprintf(w, "      <data key=\"label\">%s</data>\n", xmlEscape(nodeLabel(n)))
+		_, _ = fmt.Fprintf(w, "      <data key=\"sent\">%d</data>\n", n.Sent)
+		_, _ = fmt.Fprintf(w, "      <data key=\"received\">%d</data>\n", n.Received)
+		_, _ = fmt.Fprintln(w, "    </node>")
+	}
+	for i, e := range g.Edges {
+		_, _ = fmt.Fprintf(w, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, xmlEscape(e.From), xmlEscape(e.To))
+		_, _ = fmt.Fprintf(w, "      <data key=\"weight\">%d</data>\n", e.Messages)
+		_, _ = fmt.Fprintf(w, "      <data key=\"replies\">%d</data>\n", e.Replies)
+		if e.Replies > 0 {
+			_, _ = fmt.Fprintf(w, "      <data key=\"avg_response_seconds\">%.0f</data>\n", e.AvgResponseSeconds)
+		}
+		_, _ = fmt.Fprintf(w, "      <data key=\"first_seen\">%s</data>\n", e.FirstSeen.UTC().Format(time.RFC3339))
+		_, _ = fmt.Fprintf(w, "      <data key=\"last_seen\">%s</data>\n", e.LastSeen.UTC().Format(time.RFC3339))
+		_, _ = fmt.Fprintln(w, "    </edge>")
+	}
+
+	_, _ = fmt.Fprintln(w, "  </graph>")
+	_, _ = fmt.Fprintln(w, "</graphml>")
+}
+
+// writeDOT writes a Graphviz digraph. Edge pen width grows with the message
+// count so heavy links stand out in `dot -Tsvg` renders.
+func writeDOT(w io.Writer, g *contactGraph) {
+	maxMessages := 1
+	for _, e := range g.Edges {
+		maxMessages = max(maxMessages, e.Messages)
+	}
+
+	_, _ = fmt.Fprintln(w, "digraph contacts {")
+	_, _ = fmt.Fprintln(w, `  graph [overlap=false, splines=true];`)
+	_, _ = fmt.Fprintln(w, `  node [shape=box, style=rounded, fontname="Helvetica"];`)
+	for _, n := range g.Nodes {
+		_, _ = fmt.Fprintf(w, "  %s [label=%s];\n", dotQuote(n.Email), dotQuote(nodeLabel(n)))
+	}
+	for _, e := range g.Edges {
+		attrs := fmt.Sprintf("weight=%d, penwidth=%.2f, label=\"%d\"", e.Messages, 1+4*float64(e.Messages)/float64(maxMessages), e.Messages)
+		if e.Replies > 0 {
+			attrs += fmt.Sprintf(", tooltip=%s", dotQuote("avg reply "+formatLatency(e.AvgResponseSeconds)))
+		}
+		_, _ = fmt.Fprintf(w, "  %s -> %s [%s];\n", dotQuote(e.From), dotQuote(e.To), attrs)
+	}
+	_, _ = fmt.Fprintln(w, "}")
+}
+
+func nodeLabel(n contactNode) string {
+	if n.Name == "" {
+		return n.Email
+	}
+	return n.Name + " <" + n.Email + ">"
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// formatLatency renders seconds as a short human duration, e.g. "3h 20m".
+func formatLatency(seconds float64) string {
+	d := time.Duration(seconds) * time.Second
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph() *contactGraph {
+	seen := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	return &contactGraph{
+		Since:    seen,
+		Messages: 4,
+		Nodes: []contactNode{
+			{Email: "alice@example.com", Name: `Alice "A" & Co`, Sent: 3, Received: 1},
+			{Email: "bob@example.com", Sent: 1, Received: 3},
+		},
+		Edges: []contactEdge{
+			{From: "alice@example.com", To: "bob@example.com", Messages: 3, FirstSeen: seen, LastSeen: seen},
+			{From: "bob@example.com", To: "alice@example.com", Messages: 1, Replies: 1, AvgResponseSeconds: 7200, FirstSeen: seen, LastSeen: seen},
+		},
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	assert.Equal(t, "graphml", formatFromPath("graph.graphml"))
+	assert.Equal(t, "dot", formatFromPath("graph.DOT"))
+	assert.Equal(t, "dot", formatFromPath("graph.gv"))
+	assert.Equal(t, "json", formatFromPath("graph.json"))
+	assert.Equal(t, "graphml", formatFromPath("-"))
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeGraph(&buf, testGraph(), "graphml"))
+
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc), "output must be well-formed XML")
+	require.Len(t, doc.Graph.Nodes, 2)
+	assert.Equal(t, "alice@example.com", doc.Graph.Nodes[0].ID)
+	assert.Equal(t, `Alice "A" & Co <alice@example.com>`, doc.Graph.Nodes[0].Data[0].Value)
+	require.Len(t, doc.Graph.Edges, 2)
+	assert.Equal(t, "bob@example.com", doc.Graph.Edges[0].Target)
+	assert.Contains(t, buf.String(), `<data key="avg_response_seconds">7200</data>`)
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeGraph(&buf, testGraph(), "dot"))
+
+	out := buf.String()
+	assert.Contains(t, out, "digraph contacts {")
+	assert.Contains(t, out, `"alice@example.com" [label="Alice \"A\" & Co <alice@example.com>"];`)
+	assert.Contains(t, out, `"alice@example.com" -> "bob@example.com" [weight=3, penwidth=5.00, label="3"];`)
+	assert.Contains(t, out, `tooltip="avg reply 2h 0m"`)
+}
+
+func TestWriteGraphJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeGraph(&buf, testGraph(), "json"))
+
+	var decoded contactGraph
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.Nodes, 2)
+	assert.Equal(t, 3, decoded.Edges[0].Messages)
+}
+
+func TestFormatLatency(t *testing.T) {
+	assert.Equal(t, "45m", formatLatency(45*60))
+	assert.Equal(t, "3h 20m", formatLatency(3*3600+20*60))
+	assert.Equal(t, "2d 1h", formatLatency(49*3600))
+}
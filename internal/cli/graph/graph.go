@@ -0,0 +1,28 @@
+// Package graph provides commands that export relationship graphs built from
+// mailbox data.
+package graph
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// NewGraphCmd creates the graph command group.
+func NewGraphCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export communication graphs",
+		Long: `Build graphs from mailbox data for network analysis.
+
+Graphs export as GraphML (Gephi, yEd, Cytoscape, NetworkX), Graphviz DOT,
+or JSON.`,
+	}
+
+	common.RequireScopes(cmd, domain.ScopeEmailRead)
+
+	cmd.AddCommand(common.DeclareOutput(newContactsCmd(), contactGraph{}))
+
+	return cmd
+}
@@ -0,0 +1,21 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGraphCmd(t *testing.T) {
+	cmd := NewGraphCmd()
+
+	assert.Equal(t, "graph", cmd.Use)
+
+	contacts, _, err := cmd.Find([]string{"contacts"})
+	assert.NoError(t, err)
+	assert.Equal(t, "contacts [grant-id]", contacts.Use)
+	for _, flag := range []string{"since", "out", "graph-format", "min-messages", "limit", "top"} {
+		assert.NotNil(t, contacts.Flags().Lookup(flag), flag)
+	}
+	assert.Equal(t, "6m", contacts.Flags().Lookup("since").DefValue)
+}
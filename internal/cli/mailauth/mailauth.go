@@ -0,0 +1,294 @@
+// Package mailauth provides the imap/smtp bearer-token test commands.
+package mailauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/mailauth"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// tokenEnvVar holds a provider OAuth access token when --token isn't passed.
+const tokenEnvVar = "NYLAS_OAUTH_TOKEN"
+
+// protocol describes the per-protocol defaults for a test command.
+type protocol struct {
+	name         string
+	implicitPort int
+	startTLSPort int
+	hosts        map[domain.Provider]string
+	run          func(ports.MailAuthTester, context.Context, domain.MailAuthOptions) (*domain.MailAuthResult, error)
+}
+
+var imapProtocol = protocol{
+	name:         "imap",
+	implicitPort: 993,
+	startTLSPort: 143,
+	hosts: map[domain.Provider]string{
+		domain.ProviderGoogle:    "imap.gmail.com",
+		domain.ProviderMicrosoft: "outlook.office365.com",
+	},
+	run: ports.MailAuthTester.TestIMAP,
+}
+
+var smtpProtocol = protocol{
+	name:         "smtp",
+	implicitPort: 465,
+	startTLSPort: 587,
+	hosts: map[domain.Provider]string{
+		domain.ProviderGoogle:    "smtp.gmail.com",
+		domain.ProviderMicrosoft: "smtp.office365.com",
+	},
+	run: ports.MailAuthTester.TestSMTP,
+}
+
+// newTester is replaced in tests.
+var newTester = func() ports.MailAuthTester { return mailauth.NewTester() }
+
+// NewIMAPCmd creates the imap command group.
+func NewIMAPCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "imap",
+		Short: "IMAP debugging tools",
+	}
+	cmd.AddCommand(common.DeclareOutput(newTestCmd(imapProtocol), domain.MailAuthResult{}))
+	return cmd
+}
+
+// NewSMTPCmd creates the smtp command group.
+func NewSMTPCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "smtp",
+		Short: "SMTP debugging tools",
+	}
+	cmd.AddCommand(common.DeclareOutput(newTestCmd(smtpProtocol), domain.MailAuthResult{}))
+	return cmd
+}
+
+type testFlags struct {
+	host      string
+	port      int
+	user      string
+	token     string
+	tokenFile string
+	mechanism string
+	tlsMode   string
+	grant     string
+	insecure  bool
+}
+
+func newTestCmd(p protocol) *cobra.Command {
+	f := &testFlags{}
+	upper := strings.ToUpper(p.name)
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: fmt.Sprintf("Test XOAUTH2/OAUTHBEARER authentication against an %s server", upper),
+		Long: fmt.Sprintf(`Authenticate to an %[1]s server with an OAuth bearer token and print the
+SASL exchange, to verify a provider's OAuth configuration without swaks or curl.
+
+The username and default server come from the grant store (--grant, or the
+default grant): Google grants use %[2]s, Microsoft grants use %[3]s.
+Nylas does not expose provider access tokens, so the token itself must be
+passed with --token, --token-file, or the %[4]s environment variable.
+
+The bearer token is redacted in the transcript. When the server rejects the
+token, its base64 error challenge is decoded and shown.`,
+			upper, p.hosts[domain.ProviderGoogle], p.hosts[domain.ProviderMicrosoft], tokenEnvVar),
+		Example: fmt.Sprintf(`  # Test against the default grant's provider
+  nylas %[1]s test --token "$ACCESS_TOKEN"
+
+  # Arbitrary server with OAUTHBEARER over STARTTLS
+  nylas %[1]s test --host mail.example.com --port %[2]d --tls starttls \
+    --user me@example.com --mechanism oauthbearer --token-file token.txt`, p.name, p.startTLSPort),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := resolveOptions(p, f)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			result, err := p.run(newTester(), ctx, opts)
+			if common.IsStructuredOutput(cmd) && result != nil {
+				if werr := common.GetOutputWriter(cmd).Write(result); werr != nil {
+					return werr
+				}
+			} else if result != nil {
+				printResult(cmd, result)
+			}
+			if err != nil {
+				return err
+			}
+			if !result.Authenticated {
+				return errors.New("authentication failed")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&f.host, "host", "", "Server hostname (defaults from the grant's provider)")
+	cmd.Flags().IntVar(&f.port, "port", 0, fmt.Sprintf("Server port (default %d, or %d with --tls starttls)", p.implicitPort, p.startTLSPort))
+	cmd.Flags().StringVar(&f.user, "user", "", "Username to authenticate as (defaults to the grant email)")
+	cmd.Flags().StringVar(&f.token, "token", "", "OAuth access token (or set "+tokenEnvVar+")")
+	cmd.Flags().StringVar(&f.tokenFile, "token-file", "", "Read the OAuth access token from a file")
+	cmd.Flags().StringVar(&f.mechanism, "mechanism", "xoauth2", "SASL mechanism: xoauth2 or oauthbearer")
+	cmd.Flags().StringVar(&f.tlsMode, "tls", "", "TLS mode: tls, starttls, or none (default by port)")
+	cmd.Flags().StringVarP(&f.grant, "grant", "g", "", "Grant ID or email used for username and server defaults")
+	cmd.Flags().BoolVar(&f.insecure, "insecure", false, "Skip TLS certificate verification")
+
+	return cmd
+}
+
+// resolveOptions fills in defaults from flags, the environment, and the grant store.
+func resolveOptions(p protocol, f *testFlags) (domain.MailAuthOptions, error) {
+	opts := domain.MailAuthOptions{
+		Host:               f.host,
+		Port:               f.port,
+		Username:           f.user,
+		InsecureSkipVerify: f.insecure,
+	}
+
+	switch strings.ToLower(f.mechanism) {
+	case "xoauth2":
+		opts.Mechanism = domain.SASLXOAuth2
+	case "oauthbearer":
+		opts.Mechanism = domain.SASLOAuthBearer
+	default:
+		return opts, common.NewInputError(fmt.Sprintf("invalid --mechanism %q (use xoauth2 or oauthbearer)", f.mechanism))
+	}
+
+	token, err := ResolveToken(f.token, f.tokenFile)
+	if err != nil {
+		return opts, err
+	}
+	opts.Token = token
+
+	if opts.Host == "" || opts.Username == "" {
+		grant, err := lookupGrant(f.grant)
+		if err != nil {
+			return opts, err
+		}
+		if opts.Username == "" {
+			opts.Username = grant.Email
+		}
+		if opts.Host == "" {
+			opts.Host = p.hosts[grant.Provider]
+		}
+	}
+	if opts.Host == "" {
+		return opts, common.NewUserError("no server host", "Pass --host; defaults exist only for Google and Microsoft grants.")
+	}
+	if opts.Username == "" {
+		return opts, common.NewUserError("no username", "Pass --user or select a grant with --grant.")
+	}
+
+	switch domain.MailTLSMode(strings.ToLower(f.tlsMode)) {
+	case "":
+		if opts.Port == 0 || opts.Port == p.implicitPort {
+			opts.TLSMode = domain.MailTLSImplicit
+		} else {
+			opts.TLSMode = domain.MailTLSStartTLS
+		}
+	case domain.MailTLSImplicit, domain.MailTLSStartTLS, domain.MailTLSNone:
+		opts.TLSMode = domain.MailTLSMode(strings.ToLower(f.tlsMode))
+	default:
+		return opts, common.NewInputError(fmt.Sprintf("invalid --tls %q (use tls, starttls, or none)", f.tlsMode))
+	}
+
+	if opts.Port == 0 {
+		opts.Port = p.implicitPort
+		if opts.TLSMode != domain.MailTLSImplicit {
+			opts.Port = p.startTLSPort
+		}
+	}
+
+	return opts, nil
+}
+
+// DefaultIMAPHost returns the IMAP server for a provider's grants, or ""
+// when there is no default.
+func DefaultIMAPHost(provider domain.Provider) string {
+	return imapProtocol.hosts[provider]
+}
+
+// ResolveToken returns the access token (or password) from --token,
+// --token-file, or the NYLAS_OAUTH_TOKEN environment variable.
+func ResolveToken(token, tokenFile string) (string, error) {
+	if token != "" && tokenFile != "" {
+		return "", common.NewMutuallyExclusiveError("token", "token-file")
+	}
+	if tokenFile != "" {
+		data, err := os.ReadFile(tokenFile) // #nosec G304 -- user-supplied token path
+		if err != nil {
+			return "", common.WrapLoadError("token file", err)
+		}
+		token = string(data)
+	}
+	if token == "" {
+		token = os.Getenv(tokenEnvVar)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", common.NewUserError(
+			"no OAuth access token provided",
+			fmt.Sprintf("Pass --token, --token-file, or set %s.", tokenEnvVar),
+		)
+	}
+	return token, nil
+}
+
+func lookupGrant(identifier string) (*domain.GrantInfo, error) {
+	var args []string
+	if identifier != "" {
+		args = []string{identifier}
+	}
+	grantID, err := common.GetGrantID(args)
+	if err != nil {
+		return nil, err
+	}
+	store, err := common.NewDefaultGrantStore()
+	if err != nil {
+		return nil, err
+	}
+	grant, err := store.GetGrant(grantID)
+	if err != nil {
+		return nil, common.WrapGetError("grant", err)
+	}
+	return grant, nil
+}
+
+func printResult(cmd *cobra.Command, result *domain.MailAuthResult) {
+	out := cmd.OutOrStdout()
+
+	_, _ = fmt.Fprintf(out, "%s %s via %s\n\n", strings.ToUpper(result.Protocol), result.Server, result.Mechanism)
+	for _, line := range result.Transcript {
+		prefix := common.Cyan.Sprint("C:")
+		if line.Direction == "S" {
+			prefix = common.Green.Sprint("S:")
+		}
+		_, _ = fmt.Fprintf(out, "%s %s\n", prefix, line.Line)
+		if line.Decoded != "" {
+			_, _ = fmt.Fprintf(out, "   %s %s\n", common.Dim.Sprint("decoded:"), line.Decoded)
+		}
+	}
+	_, _ = fmt.Fprintln(out)
+
+	if result.Authenticated {
+		common.PrintSuccess("Authentication succeeded")
+		return
+	}
+	if result.ServerError != "" {
+		common.PrintError("Server rejected the token: %s", result.ServerError)
+	}
+}
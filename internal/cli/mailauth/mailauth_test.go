@@ -0,0 +1,116 @@
+package mailauth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTester struct {
+	got    domain.MailAuthOptions
+	result *domain.MailAuthResult
+}
+
+func (f *fakeTester) TestIMAP(_ context.Context, opts domain.MailAuthOptions) (*domain.MailAuthResult, error) {
+	f.got = opts
+	return f.result, nil
+}
+
+func (f *fakeTester) TestSMTP(_ context.Context, opts domain.MailAuthOptions) (*domain.MailAuthResult, error) {
+	f.got = opts
+	return f.result, nil
+}
+
+func useFakeTester(t *testing.T, result *domain.MailAuthResult) *fakeTester {
+	t.Helper()
+	fake := &fakeTester{result: result}
+	orig := newTester
+	newTester = func() ports.MailAuthTester { return fake }
+	t.Cleanup(func() { newTester = orig })
+	return fake
+}
+
+func TestResolveOptions_PortAndTLSDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    testFlags
+		wantPort int
+		wantTLS  domain.MailTLSMode
+	}{
+		{"implicit default", testFlags{}, 465, domain.MailTLSImplicit},
+		{"starttls flag", testFlags{tlsMode: "starttls"}, 587, domain.MailTLSStartTLS},
+		{"submission port", testFlags{port: 587}, 587, domain.MailTLSStartTLS},
+		{"explicit none", testFlags{port: 2525, tlsMode: "none"}, 2525, domain.MailTLSNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.flags
+			f.host, f.user, f.token, f.mechanism = "smtp.example.com", "me@example.com", "tok", "xoauth2"
+
+			opts, err := resolveOptions(smtpProtocol, &f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPort, opts.Port)
+			assert.Equal(t, tt.wantTLS, opts.TLSMode)
+			assert.Equal(t, domain.SASLXOAuth2, opts.Mechanism)
+		})
+	}
+}
+
+func TestResolveOptions_Errors(t *testing.T) {
+	t.Setenv(tokenEnvVar, "")
+
+	base := testFlags{host: "h", user: "u", token: "t", mechanism: "xoauth2"}
+
+	f := base
+	f.mechanism = "plain"
+	_, err := resolveOptions(imapProtocol, &f)
+	assert.ErrorContains(t, err, "mechanism")
+
+	f = base
+	f.tlsMode = "ssl"
+	_, err = resolveOptions(imapProtocol, &f)
+	assert.ErrorContains(t, err, "--tls")
+
+	f = base
+	f.token = ""
+	_, err = resolveOptions(imapProtocol, &f)
+	assert.ErrorContains(t, err, "token")
+}
+
+func TestResolveToken_Sources(t *testing.T) {
+	t.Setenv(tokenEnvVar, "from-env")
+
+	tok, err := ResolveToken("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", tok)
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+	tok, err = ResolveToken("", path)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", tok)
+
+	_, err = ResolveToken("a", path)
+	assert.Error(t, err)
+}
+
+func TestIMAPTestCmd_FailsWhenNotAuthenticated(t *testing.T) {
+	fake := useFakeTester(t, &domain.MailAuthResult{Protocol: "imap", ServerError: `{"status":"400"}`})
+
+	stdout, _, err := testutil.ExecuteSubCommand(NewIMAPCmd(), "test",
+		"--host", "imap.example.com", "--user", "me@example.com", "--token", "tok", "--mechanism", "oauthbearer")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "authentication failed")
+	assert.Equal(t, domain.SASLOAuthBearer, fake.got.Mechanism)
+	assert.Equal(t, 993, fake.got.Port)
+	assert.Contains(t, stdout, "IMAP")
+}
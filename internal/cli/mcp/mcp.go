@@ -3,6 +3,8 @@ package mcp
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
 )
 
 // NewMCPCmd creates the mcp command with all subcommands.
@@ -41,10 +43,10 @@ Example configuration for Claude Code (.mcp.json):
 For more information about Nylas MCP: https://developer.nylas.com/docs/dev-guide/mcp/`,
 	}
 
-	cmd.AddCommand(newServeCmd())
+	cmd.AddCommand(common.RunLocally(newServeCmd()))
 	cmd.AddCommand(newInstallCmd())
 	cmd.AddCommand(newUninstallCmd())
-	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(common.DeclareOutput(newStatusCmd(), []assistantStatus{}))
 
 	return cmd
 }
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/audit"
+	"github.com/nylas/cli/internal/adapters/mcp"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// confirmTool asks the user at the terminal whether an assistant may run a
+// tool. Stdin and stdout carry the MCP stream, so it talks to /dev/tty and
+// fails when there is no terminal. Replaced in tests.
+var confirmTool = func(tool string, args map[string]any) (bool, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return false, errors.New("no terminal available to confirm")
+	}
+	defer func() { _ = tty.Close() }()
+
+	_, _ = fmt.Fprintf(tty, "\nAn MCP assistant wants to run %s%s\nAllow? [y/N]: ", tool, describeToolArgs(args))
+	answer, _ := bufio.NewReader(tty).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// recordToolDecision writes a tool call decision to the audit log when audit
+// logging is enabled. Replaced in tests.
+var recordToolDecision = func(entry *domain.AuditEntry) {
+	store, err := audit.NewFileStore("")
+	if err != nil {
+		return
+	}
+	if cfg, err := store.GetConfig(); err != nil || !cfg.Enabled {
+		return
+	}
+	_ = store.Log(entry)
+}
+
+// newToolGuard enforces cfg's tool permissions. Prompts are serialized so
+// concurrent calls cannot interleave on the terminal.
+func newToolGuard(cfg *domain.MCPConfig) mcp.ToolGuard {
+	var promptMu sync.Mutex
+
+	return func(_ context.Context, tool string, args map[string]any) error {
+		start := time.Now()
+		perm := cfg.ToolPermission(tool)
+
+		var refusal error
+		switch perm {
+		case domain.MCPToolDeny:
+			refusal = fmt.Errorf("%s is denied by mcp.tools in the Nylas CLI config", tool)
+		case domain.MCPToolAsk:
+			promptMu.Lock()
+			allowed, err := confirmTool(tool, args)
+			promptMu.Unlock()
+			switch {
+			case err != nil:
+				refusal = fmt.Errorf("%s needs confirmation, but %v; set mcp.tools.%s to auto to allow it", tool, err, tool)
+			case !allowed:
+				refusal = fmt.Errorf("the user declined to run %s", tool)
+			}
+		}
+
+		entry := &domain.AuditEntry{
+			Timestamp:     start,
+			Command:       "mcp tool " + tool,
+			Status:        domain.AuditStatusSuccess,
+			Duration:      time.Since(start),
+			InvokerSource: "mcp",
+			Details: map[string]string{
+				"permission": string(perm),
+				"decision":   "allowed",
+			},
+		}
+		if keys := argumentNames(args); keys != "" {
+			entry.Details["arguments"] = keys
+		}
+		if refusal != nil {
+			entry.Status = domain.AuditStatusError
+			entry.Error = refusal.Error()
+			entry.Details["decision"] = "denied"
+		}
+		recordToolDecision(entry)
+
+		return refusal
+	}
+}
+
+// argumentNames lists argument names only; values can hold message bodies.
+func argumentNames(args map[string]any) string {
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// describeToolArgs renders arguments for the confirmation prompt.
+func describeToolArgs(args map[string]any) string {
+	if len(args) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(":")
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "\n  %s: %s", key, common.Truncate(fmt.Sprint(args[key]), 80))
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestToolGuard(t *testing.T) {
+	var recorded []*domain.AuditEntry
+	var prompted []string
+	confirmAnswer, confirmErr := true, error(nil)
+
+	origConfirm, origRecord := confirmTool, recordToolDecision
+	t.Cleanup(func() { confirmTool, recordToolDecision = origConfirm, origRecord })
+	confirmTool = func(tool string, _ map[string]any) (bool, error) {
+		prompted = append(prompted, tool)
+		return confirmAnswer, confirmErr
+	}
+	recordToolDecision = func(entry *domain.AuditEntry) { recorded = append(recorded, entry) }
+
+	guard := newToolGuard(&domain.MCPConfig{Tools: map[string]domain.MCPToolPermission{
+		"send_message": domain.MCPToolAsk,
+		"delete_*":     domain.MCPToolDeny,
+	}})
+	ctx := context.Background()
+	args := map[string]any{"to": "a@example.com", "body": "secret"}
+
+	if err := guard(ctx, "list_messages", nil); err != nil {
+		t.Fatalf("auto tool refused: %v", err)
+	}
+	if err := guard(ctx, "send_message", args); err != nil {
+		t.Fatalf("confirmed tool refused: %v", err)
+	}
+
+	confirmAnswer = false
+	if err := guard(ctx, "send_message", args); err == nil || !strings.Contains(err.Error(), "declined") {
+		t.Errorf("declined prompt: got %v", err)
+	}
+
+	confirmErr = errors.New("no terminal available to confirm")
+	if err := guard(ctx, "send_message", args); err == nil || !strings.Contains(err.Error(), "mcp.tools.send_message") {
+		t.Errorf("prompt without terminal: got %v", err)
+	}
+
+	if err := guard(ctx, "delete_event", nil); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("deny: got %v", err)
+	}
+
+	if len(prompted) != 3 {
+		t.Errorf("prompted %d times, want 3", len(prompted))
+	}
+	if len(recorded) != 5 {
+		t.Fatalf("recorded %d decisions, want 5", len(recorded))
+	}
+
+	allowed := recorded[1]
+	if allowed.Command != "mcp tool send_message" || allowed.Status != domain.AuditStatusSuccess ||
+		allowed.Details["decision"] != "allowed" || allowed.Details["permission"] != "ask" {
+		t.Errorf("allowed entry: %+v", allowed)
+	}
+	if allowed.Details["arguments"] != "body,to" {
+		t.Errorf("arguments = %q, want names only", allowed.Details["arguments"])
+	}
+
+	denied := recorded[4]
+	if denied.Status != domain.AuditStatusError || denied.Details["decision"] != "denied" || denied.Error == "" {
+		t.Errorf("denied entry: %+v", denied)
+	}
+}
+
+func TestToolGuard_DefaultsAskForMutatingTools(t *testing.T) {
+	var prompted []string
+	origConfirm, origRecord := confirmTool, recordToolDecision
+	t.Cleanup(func() { confirmTool, recordToolDecision = origConfirm, origRecord })
+	confirmTool = func(tool string, _ map[string]any) (bool, error) {
+		prompted = append(prompted, tool)
+		return false, errors.New("no terminal available to confirm")
+	}
+	recordToolDecision = func(*domain.AuditEntry) {}
+
+	guard := newToolGuard(nil)
+	ctx := context.Background()
+
+	for _, tool := range []string{"send_message", "create_event", "update_draft", "delete_message"} {
+		if err := guard(ctx, tool, nil); err == nil || !strings.Contains(err.Error(), "mcp.tools."+tool) {
+			t.Errorf("%s without config: got %v, want a refusal naming the setting", tool, err)
+		}
+	}
+	for _, tool := range []string{"list_messages", "get_grant", "confirm_send_message"} {
+		if err := guard(ctx, tool, nil); err != nil {
+			t.Errorf("%s without config refused: %v", tool, err)
+		}
+	}
+	if len(prompted) != 4 {
+		t.Errorf("prompted for %v, want only the 4 mutating tools", prompted)
+	}
+
+	allowed := newToolGuard(&domain.MCPConfig{Tools: map[string]domain.MCPToolPermission{"send_*": domain.MCPToolAuto}})
+	if err := allowed(ctx, "send_message", nil); err != nil {
+		t.Errorf("allowlisted send refused: %v", err)
+	}
+}
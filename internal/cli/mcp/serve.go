@@ -10,6 +10,7 @@ import (
 	"github.com/nylas/cli/internal/adapters/config"
 	"github.com/nylas/cli/internal/adapters/mcp"
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +30,22 @@ MCP server and adds local enhancements:
   - Local grant lookup (get_grant without email)
   - Timezone-aware timestamp display
   - Secure credential handling via system keyring
+  - Per-tool permissions: run, confirm on the terminal, or refuse
+
+Tool permissions are set in config under mcp.tools as auto, ask or deny,
+by tool name or pattern:
+
+  nylas config set mcp.tools.send_message ask
+  nylas config set 'mcp.tools.delete_*' deny
+
+Tools without an entry that send, create, update or delete data (send_*,
+create_*, update_*, delete_*) ask first; all other tools run. To let an
+assistant without a terminal, such as a desktop app, send mail, allow it
+explicitly:
+
+  nylas config set 'mcp.tools.send_*' auto
+
+Every decision is written to the audit log when audit logging is enabled.
 
 The server communicates via STDIO (standard input/output) and requires
 Nylas credentials to be configured via 'nylas auth login'.
@@ -47,11 +64,18 @@ func runServe(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get API key: %w\n\nPlease run 'nylas auth login' first", err)
 	}
 
-	// Get region from config (defaults to "us")
+	// Get region and tool permissions from config (region defaults to "us")
 	region := "us"
+	var toolPermissions *domain.MCPConfig
 	configStore := config.NewDefaultFileStore()
-	if cfg, err := configStore.Load(); err == nil && cfg.Region != "" {
-		region = cfg.Region
+	if cfg, err := configStore.Load(); err == nil {
+		if cfg.Region != "" {
+			region = cfg.Region
+		}
+		toolPermissions = cfg.MCP
+	}
+	if err := toolPermissions.Validate(); err != nil {
+		return common.NewUserError(err.Error(), "Fix it with: nylas config set mcp.tools.<tool> auto|ask|deny")
 	}
 
 	// Get default grant ID (optional - helps Claude know which account to use)
@@ -63,6 +87,8 @@ func runServe(cmd *cobra.Command, args []string) error {
 		proxy.SetDefaultGrant(grantID)
 	}
 
+	proxy.SetToolGuard(newToolGuard(toolPermissions))
+
 	// Set up grant store for local grant lookups (allows get_grant without email).
 	if grantStore, err := common.NewDefaultGrantStore(); err == nil {
 		proxy.SetGrantStore(grantStore)
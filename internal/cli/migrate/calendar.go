@@ -0,0 +1,295 @@
+package migrate
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// getCalendarClient is replaced in tests.
+var getCalendarClient = func() (calendarClient, error) {
+	return common.GetNylasClient()
+}
+
+type calendarMigrationOptions struct {
+	from         string
+	to           string
+	calendars    []string
+	after        string
+	before       string
+	noRecurrence bool
+	attendees    bool
+	notify       bool
+	rate         float64
+	checkpoint   string
+	restart      bool
+	dryRun       bool
+	mappingFile  string
+}
+
+func newCalendarCmd() *cobra.Command {
+	var opts calendarMigrationOptions
+
+	cmd := &cobra.Command{
+		Use:   "calendar --from <grant> --to <grant>",
+		Short: "Copy calendars and events from one account to another",
+		Long: `Copy calendars and their events from one connected account to another.
+
+The primary calendar is copied into the destination's primary calendar;
+other calendars go into a writable calendar with the same name, which is
+created when missing. Without --calendars, every calendar the source account
+owns is copied. Cancelled events are skipped.
+
+Recurring events are copied as series with their recurrence rules. Changes
+to single occurrences are not carried over; pass --no-recurrence to copy
+every occurrence as a separate event instead. Occurrences are expanded up to
+--before, or one year ahead.
+
+Attendees are left out unless --attendees is set, and even then they are not
+sent invitations unless --notify is also set. Conferencing details are kept
+as they are; no new meetings are provisioned.
+
+Re-running the same command is incremental: events copied before are
+updated when they changed in the source and skipped otherwise, so it can
+keep the destination in step until the switch-over. Use --mapping to save
+the source → destination event ID mapping as CSV.`,
+		Example: `  # Copy the Work calendar
+  nylas migrate calendar --from old@example.com --to new@example.com --calendars Work
+
+  # Preview what would be created or updated
+  nylas migrate calendar --from old@example.com --to new@example.com --dry-run
+
+  # Upcoming events only, with attendees (no invitations sent)
+  nylas migrate calendar --from <grant-id> --to <grant-id> --after 2026-01-01 --attendees
+
+  # Save the event ID mapping
+  nylas migrate calendar --from <grant-id> --to <grant-id> --mapping events.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCalendarMigration(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "Source account: grant ID or email (required)")
+	cmd.Flags().StringVar(&opts.to, "to", "", "Destination account: grant ID or email (required)")
+	cmd.Flags().StringSliceVar(&opts.calendars, "calendars", nil, "Calendars to copy by name or ID, or \"primary\" (default: all owned)")
+	cmd.Flags().StringVar(&opts.after, "after", "", "Only copy events ending after date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&opts.before, "before", "", "Only copy events starting before date (YYYY-MM-DD)")
+	cmd.Flags().BoolVar(&opts.noRecurrence, "no-recurrence", false, "Copy each occurrence of recurring events as a separate event")
+	cmd.Flags().BoolVar(&opts.attendees, "attendees", false, "Copy attendees")
+	cmd.Flags().BoolVar(&opts.notify, "notify", false, "Send invitations to copied attendees (with --attendees)")
+	cmd.Flags().Float64Var(&opts.rate, "rate", defaultMailRate, "Maximum events written per second (0 for no limit)")
+	cmd.Flags().StringVar(&opts.checkpoint, "checkpoint", "", "Checkpoint file (default: in the user cache directory)")
+	cmd.Flags().BoolVar(&opts.restart, "restart", false, "Ignore the checkpoint and copy every event again")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be copied without writing")
+	cmd.Flags().StringVar(&opts.mappingFile, "mapping", "", "Write the event ID mapping to a CSV file")
+
+	return cmd
+}
+
+func runCalendarMigration(cmd *cobra.Command, opts *calendarMigrationOptions) error {
+	if err := common.ValidateRequiredFlag("--from", opts.from); err != nil {
+		return err
+	}
+	if err := common.ValidateRequiredFlag("--to", opts.to); err != nil {
+		return err
+	}
+	if opts.rate < 0 {
+		return common.NewInputError("--rate must be 0 (no limit) or positive")
+	}
+	if opts.notify && !opts.attendees {
+		return common.NewInputError("--notify requires --attendees")
+	}
+	copyOpts := calendarOptions{recurrence: !opts.noRecurrence, attendees: opts.attendees, notify: opts.notify}
+	var err error
+	if opts.after != "" {
+		if copyOpts.after, err = time.ParseInLocation("2006-01-02", opts.after, time.Local); err != nil {
+			return common.WrapDateParseError("after", err)
+		}
+	}
+	if opts.before != "" {
+		if copyOpts.before, err = time.ParseInLocation("2006-01-02", opts.before, time.Local); err != nil {
+			return common.WrapDateParseError("before", err)
+		}
+	}
+	if !copyOpts.after.IsZero() && !copyOpts.before.IsZero() && !copyOpts.before.After(copyOpts.after) {
+		return common.NewInputError("--before must be after --after")
+	}
+	if opts.noRecurrence && copyOpts.before.IsZero() {
+		copyOpts.before = time.Now().AddDate(1, 0, 0)
+	}
+
+	from, err := common.ResolveGrantIdentifier(opts.from)
+	if err != nil {
+		return err
+	}
+	to, err := common.ResolveGrantIdentifier(opts.to)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return common.NewInputError("--from and --to are the same account")
+	}
+
+	checkpointPath := opts.checkpoint
+	if checkpointPath == "" {
+		if checkpointPath, err = defaultCheckpointPath("calendar", from, to); err != nil {
+			return err
+		}
+	}
+	checkpoint := domain.NewCalendarMigrationCheckpoint(from, to)
+	if !opts.restart {
+		if checkpoint, err = loadCalendarCheckpoint(checkpointPath, from, to); err != nil {
+			return err
+		}
+	}
+
+	client, err := getCalendarClient()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	sourceCalendars, err := common.RunWithSpinnerResult("Fetching source calendars...", func() ([]domain.Calendar, error) {
+		return client.GetCalendars(ctx, from)
+	})
+	if err != nil {
+		return common.WrapListError("calendars", err)
+	}
+	calendars, err := selectCalendars(sourceCalendars, opts.calendars, opts.from)
+	if err != nil {
+		return err
+	}
+
+	structured := common.IsStructuredOutput(cmd)
+	report := &domain.CalendarMigrationReport{
+		From: opts.from, To: opts.to, DryRun: opts.dryRun,
+		Calendars: []domain.CalendarMigration{}, Mappings: []domain.EventMapping{},
+	}
+	copier := &calendarCopier{
+		client:     client,
+		from:       from,
+		to:         to,
+		opts:       copyOpts,
+		dryRun:     opts.dryRun,
+		throttle:   newThrottle(opts.rate),
+		checkpoint: checkpoint,
+		save: func(cp *domain.CalendarMigrationCheckpoint) error {
+			return saveCheckpoint(checkpointPath, cp)
+		},
+		report: report,
+	}
+	progress := &stepProgress{}
+	if !structured {
+		copier.progress = func(cm *domain.CalendarMigration) {
+			progress.update(cm.Calendar, cm.Events, 0)
+		}
+	}
+
+	runErr := copier.run(ctx, calendars)
+	progress.finish()
+	if errors.Is(runErr, context.Canceled) {
+		report.Interrupted = true
+		runErr = nil
+	}
+
+	if opts.mappingFile != "" {
+		if err := writeEventMapping(opts.mappingFile, report.Mappings); err != nil {
+			return err
+		}
+	}
+
+	if structured {
+		if err := common.GetOutputWriter(cmd).Write(report); err != nil {
+			return err
+		}
+	} else {
+		printCalendarReport(report, checkpointPath, opts.mappingFile)
+	}
+
+	switch {
+	case runErr != nil:
+		return runErr
+	case report.Failed > 0:
+		return common.NewUserError(fmt.Sprintf("%d events failed to copy", report.Failed),
+			"Re-run the same command to retry them; copied events are skipped")
+	}
+	return nil
+}
+
+// writeEventMapping saves the source → destination event IDs as CSV.
+func writeEventMapping(path string, mappings []domain.EventMapping) error {
+	f, err := os.Create(path) // #nosec G304 -- user-specified output path
+	if err != nil {
+		return common.WrapSaveError("event mapping", err)
+	}
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"calendar", "source_id", "destination_id", "action", "title"})
+	for _, m := range mappings {
+		_ = w.Write([]string{m.Calendar, m.SourceID, m.DestinationID, m.Action, m.Title})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		_ = f.Close()
+		return common.WrapSaveError("event mapping", err)
+	}
+	if err := f.Close(); err != nil {
+		return common.WrapSaveError("event mapping", err)
+	}
+	return nil
+}
+
+func printCalendarReport(r *domain.CalendarMigrationReport, checkpointPath, mappingFile string) {
+	if r.DryRun {
+		fmt.Printf("Dry run: %s → %s (nothing written)\n\n", r.From, r.To)
+	} else {
+		fmt.Printf("Migrating %s → %s\n\n", r.From, r.To)
+	}
+
+	fmt.Printf("  %-25s %-25s %7s %8s %8s %10s %7s\n", "CALENDAR", "DESTINATION", "EVENTS", "CREATED", "UPDATED", "UNCHANGED", "FAILED")
+	for _, c := range r.Calendars {
+		dest := c.Destination
+		if c.CalendarCreated {
+			dest += " (new)"
+		}
+		fmt.Printf("  %-25s %-25s %7d %8d %8d %10d %7d\n", common.Truncate(c.Calendar, 25), common.Truncate(dest, 25),
+			c.Events, c.Created, c.Updated, c.Skipped, c.Failed)
+	}
+	fmt.Println()
+
+	verb := "Created"
+	if r.DryRun {
+		verb = "Would create"
+	}
+	common.PrintSuccess("%s %d events, updated %d, %d unchanged", verb, r.Created, r.Updated, r.Skipped)
+	if r.Failed > 0 {
+		common.PrintWarning("%d events failed:", r.Failed)
+		for i, f := range r.Failures {
+			if i == maxListedErrors {
+				fmt.Printf("    ... and %d more (use --json for all)\n", len(r.Failures)-i)
+				break
+			}
+			fmt.Printf("    %s  %s: %s\n", common.Truncate(f.Title, 40), f.Calendar, f.Error)
+		}
+	}
+	if r.Interrupted {
+		common.PrintWarning("Interrupted; re-run the same command to resume")
+	}
+	if mappingFile != "" {
+		fmt.Println(common.Dim.Sprintf("Event mapping: %s", mappingFile))
+	}
+	if !r.DryRun {
+		fmt.Println(common.Dim.Sprintf("Checkpoint: %s (re-run to sync changes)", checkpointPath))
+	}
+}
@@ -0,0 +1,332 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Event mapping actions.
+const (
+	actionCreated   = "created"
+	actionUpdated   = "updated"
+	actionUnchanged = "unchanged"
+)
+
+// calendarClient is the part of the Nylas client used by calendar migration.
+type calendarClient interface {
+	GetCalendars(ctx context.Context, grantID string) ([]domain.Calendar, error)
+	CreateCalendar(ctx context.Context, grantID string, req *domain.CreateCalendarRequest) (*domain.Calendar, error)
+	GetEventsWithCursor(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error)
+	CreateEvent(ctx context.Context, grantID, calendarID string, req *domain.CreateEventRequest) (*domain.Event, error)
+	UpdateEvent(ctx context.Context, grantID, calendarID, eventID string, req *domain.UpdateEventRequest) (*domain.Event, error)
+}
+
+// selectCalendars resolves --calendars references (ID, name or "primary")
+// or, without any, returns the calendars the account owns.
+func selectCalendars(calendars []domain.Calendar, refs []string, label string) ([]domain.Calendar, error) {
+	if len(refs) == 0 {
+		var owned []domain.Calendar
+		for _, c := range calendars {
+			if c.IsPrimary || (c.IsOwner && !c.ReadOnly) {
+				owned = append(owned, c)
+			}
+		}
+		return owned, nil
+	}
+
+	var selected []domain.Calendar
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		idx := slices.IndexFunc(calendars, func(c domain.Calendar) bool {
+			return c.ID == ref || strings.EqualFold(c.Name, ref) || (c.IsPrimary && strings.EqualFold(ref, "primary"))
+		})
+		if idx < 0 {
+			return nil, common.NewUserError(fmt.Sprintf("calendar %q not found in %s", ref, label),
+				"List calendars with: nylas calendar list "+label)
+		}
+		if !slices.ContainsFunc(selected, func(c domain.Calendar) bool { return c.ID == calendars[idx].ID }) {
+			selected = append(selected, calendars[idx])
+		}
+	}
+	return selected, nil
+}
+
+// calendarOptions controls what is copied for each event.
+type calendarOptions struct {
+	recurrence bool // Copy recurring series as series (otherwise as occurrences)
+	attendees  bool
+	notify     bool
+	after      time.Time
+	before     time.Time
+}
+
+// calendarCopier copies calendars from one grant to another through the
+// Nylas API.
+type calendarCopier struct {
+	client     calendarClient
+	from       string
+	to         string
+	opts       calendarOptions
+	dryRun     bool
+	throttle   *throttle
+	checkpoint *domain.CalendarMigrationCheckpoint
+	save       func(*domain.CalendarMigrationCheckpoint) error
+	progress   func(cm *domain.CalendarMigration)
+	report     *domain.CalendarMigrationReport
+
+	destCalendars []domain.Calendar
+	unsaved       int
+	consecutive   int
+}
+
+// run copies each calendar in turn. The checkpoint is saved on the way out,
+// including when ctx is cancelled.
+func (c *calendarCopier) run(ctx context.Context, calendars []domain.Calendar) (err error) {
+	c.destCalendars, err = c.client.GetCalendars(ctx, c.to)
+	if err != nil {
+		return common.WrapListError("destination calendars", err)
+	}
+	defer func() {
+		if saveErr := c.saveCheckpoint(); err == nil {
+			err = saveErr
+		}
+	}()
+
+	for i := range calendars {
+		src := &calendars[i]
+		dest, created, err := c.destination(ctx, src)
+		if err != nil {
+			return fmt.Errorf("failed to prepare calendar for %s: %w", src.Name, err)
+		}
+		cm := domain.CalendarMigration{Calendar: src.Name, Destination: dest.Name, DestinationID: dest.ID, CalendarCreated: created}
+		// Copies made into a calendar that no longer exists can't be updated.
+		if mark := c.checkpoint.Mark(src.ID); mark.Calendar != dest.ID {
+			mark.Calendar, mark.Events = dest.ID, map[string]domain.EventMigrationMark{}
+		}
+		err = c.copyCalendar(ctx, src, &cm)
+		c.addCalendar(cm)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// destination returns the calendar src is copied into: the one used by an
+// earlier run, the primary calendar for the primary calendar, a writable
+// calendar with the same name, or a new one.
+func (c *calendarCopier) destination(ctx context.Context, src *domain.Calendar) (*domain.Calendar, bool, error) {
+	if mark := c.checkpoint.Calendars[src.ID]; mark != nil && mark.Calendar != "" {
+		if idx := slices.IndexFunc(c.destCalendars, func(d domain.Calendar) bool { return d.ID == mark.Calendar }); idx >= 0 {
+			return &c.destCalendars[idx], false, nil
+		}
+	}
+	idx := slices.IndexFunc(c.destCalendars, func(d domain.Calendar) bool {
+		if src.IsPrimary {
+			return d.IsPrimary
+		}
+		return !d.ReadOnly && strings.EqualFold(d.Name, src.Name)
+	})
+	if idx >= 0 {
+		return &c.destCalendars[idx], false, nil
+	}
+
+	if c.dryRun {
+		return &domain.Calendar{Name: src.Name}, true, nil
+	}
+	created, err := c.client.CreateCalendar(ctx, c.to, &domain.CreateCalendarRequest{
+		Name:        src.Name,
+		Description: src.Description,
+		Location:    src.Location,
+		Timezone:    src.Timezone,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	c.destCalendars = append(c.destCalendars, *created)
+	return created, true, nil
+}
+
+func (c *calendarCopier) copyCalendar(ctx context.Context, src *domain.Calendar, cm *domain.CalendarMigration) error {
+	mark := c.checkpoint.Mark(src.ID)
+	params := &domain.EventQueryParams{Limit: common.MaxAPILimit, ExpandRecurring: !c.opts.recurrence}
+	if !c.opts.after.IsZero() {
+		params.Start = c.opts.after.Unix()
+	}
+	if !c.opts.before.IsZero() {
+		params.End = c.opts.before.Unix()
+	}
+
+	for {
+		resp, err := c.client.GetEventsWithCursor(ctx, c.from, src.ID, params)
+		if err != nil {
+			return common.WrapListError("events", err)
+		}
+		for i := range resp.Data {
+			event := &resp.Data[i]
+			if !c.copyable(event) {
+				continue
+			}
+			cm.Events++
+			if err := c.copyEvent(ctx, src, event, mark, cm); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				cm.Failed++
+				c.report.Failures = append(c.report.Failures, domain.CalendarMigrationFailure{
+					EventID: event.ID, Calendar: src.Name, Title: event.Title, Error: err.Error(),
+				})
+				if c.consecutive++; c.consecutive >= maxConsecutiveFailures {
+					return fmt.Errorf("stopped after %d consecutive failures: %w", c.consecutive, err)
+				}
+				continue
+			}
+			c.consecutive = 0
+			if c.progress != nil {
+				c.progress(cm)
+			}
+		}
+		if resp.Pagination.NextCursor == "" {
+			return nil
+		}
+		params.PageToken = resp.Pagination.NextCursor
+	}
+}
+
+// copyable skips cancelled events and, when series are copied whole, the
+// provider's per-occurrence exceptions.
+func (c *calendarCopier) copyable(e *domain.Event) bool {
+	if strings.EqualFold(e.Status, "cancelled") {
+		return false
+	}
+	return !c.opts.recurrence || e.MasterEventID == ""
+}
+
+// copyEvent creates the event in the destination, updates the earlier copy
+// when the source has changed since, or skips it.
+func (c *calendarCopier) copyEvent(ctx context.Context, src *domain.Calendar, e *domain.Event, mark *domain.CalendarMigrationMark, cm *domain.CalendarMigration) error {
+	mapping := domain.EventMapping{Calendar: src.Name, SourceID: e.ID, Title: e.Title}
+	prev, seen := mark.Events[e.ID]
+	switch {
+	case seen && !e.UpdatedAt.After(prev.UpdatedAt):
+		cm.Skipped++
+		mapping.DestinationID, mapping.Action = prev.DestinationID, actionUnchanged
+		c.report.Mappings = append(c.report.Mappings, mapping)
+		return nil
+	case seen:
+		mapping.DestinationID, mapping.Action = prev.DestinationID, actionUpdated
+	default:
+		mapping.Action = actionCreated
+	}
+
+	if !c.dryRun {
+		if err := c.throttle.wait(ctx); err != nil {
+			return err
+		}
+		req := c.eventRequest(e)
+		if seen {
+			if _, err := c.client.UpdateEvent(ctx, c.to, cm.DestinationID, prev.DestinationID, updateRequest(req)); err != nil {
+				return err
+			}
+		} else {
+			created, err := c.client.CreateEvent(ctx, c.to, cm.DestinationID, req)
+			if err != nil {
+				return err
+			}
+			mapping.DestinationID = created.ID
+		}
+		mark.Events[e.ID] = domain.EventMigrationMark{DestinationID: mapping.DestinationID, UpdatedAt: e.UpdatedAt}
+		if c.unsaved++; c.unsaved >= checkpointEvery {
+			if err := c.saveCheckpoint(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if seen {
+		cm.Updated++
+	} else {
+		cm.Created++
+	}
+	c.report.Mappings = append(c.report.Mappings, mapping)
+	return nil
+}
+
+// eventRequest builds the destination event. Provider-specific fields
+// (IDs, organizer, links) are not carried over; conferencing is kept only
+// as existing meeting details, never re-provisioned.
+func (c *calendarCopier) eventRequest(e *domain.Event) *domain.CreateEventRequest {
+	when := e.When
+	when.Object = ""
+	req := &domain.CreateEventRequest{
+		Title:       e.Title,
+		Description: e.Description,
+		Location:    e.Location,
+		When:        when,
+		Busy:        e.Busy,
+		Visibility:  e.Visibility,
+		Reminders:   e.Reminders,
+		Metadata:    maps.Clone(e.Metadata),
+	}
+	if c.opts.recurrence {
+		req.Recurrence = e.Recurrence
+	}
+	if e.Conferencing != nil && e.Conferencing.Details != nil {
+		req.Conferencing = &domain.Conferencing{Provider: e.Conferencing.Provider, Details: e.Conferencing.Details}
+	}
+	if c.opts.attendees && len(e.Participants) > 0 {
+		req.Participants = make([]domain.Participant, len(e.Participants))
+		for i, p := range e.Participants {
+			req.Participants[i] = domain.Participant{Person: p.Person}
+		}
+		notify := c.opts.notify
+		req.NotifyParticipants = &notify
+	}
+	return req
+}
+
+// updateRequest turns a create request into a full update of the copy.
+func updateRequest(req *domain.CreateEventRequest) *domain.UpdateEventRequest {
+	update := &domain.UpdateEventRequest{
+		Title:              &req.Title,
+		Description:        &req.Description,
+		Location:           &req.Location,
+		When:               &req.When,
+		Participants:       req.Participants,
+		Busy:               &req.Busy,
+		Recurrence:         req.Recurrence,
+		Conferencing:       req.Conferencing,
+		Reminders:          req.Reminders,
+		Metadata:           req.Metadata,
+		NotifyParticipants: req.NotifyParticipants,
+	}
+	if req.Visibility != "" {
+		update.Visibility = &req.Visibility
+	}
+	return update
+}
+
+func (c *calendarCopier) addCalendar(cm domain.CalendarMigration) {
+	r := c.report
+	r.Calendars = append(r.Calendars, cm)
+	r.Created += cm.Created
+	r.Updated += cm.Updated
+	r.Skipped += cm.Skipped
+	r.Failed += cm.Failed
+}
+
+func (c *calendarCopier) saveCheckpoint() error {
+	if c.dryRun || c.save == nil {
+		return nil
+	}
+	c.unsaved = 0
+	c.checkpoint.UpdatedAt = time.Now()
+	return c.save(c.checkpoint)
+}
@@ -0,0 +1,243 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+)
+
+type createdEvent struct {
+	calendarID string
+	req        *domain.CreateEventRequest
+}
+
+type updatedEvent struct {
+	calendarID, eventID string
+	req                 *domain.UpdateEventRequest
+}
+
+type fakeCalendarClient struct {
+	calendars map[string][]domain.Calendar
+	events    map[string][]domain.Event // Keyed by source calendar ID
+	created   []createdEvent
+	updated   []updatedEvent
+	newCals   []string
+	failOn    string // Title that makes CreateEvent fail
+}
+
+func (c *fakeCalendarClient) GetCalendars(_ context.Context, grantID string) ([]domain.Calendar, error) {
+	return c.calendars[grantID], nil
+}
+
+func (c *fakeCalendarClient) CreateCalendar(_ context.Context, grantID string, req *domain.CreateCalendarRequest) (*domain.Calendar, error) {
+	cal := domain.Calendar{ID: "new-" + strings.ToLower(req.Name), Name: req.Name, IsOwner: true}
+	c.calendars[grantID] = append(c.calendars[grantID], cal)
+	c.newCals = append(c.newCals, req.Name)
+	return &cal, nil
+}
+
+func (c *fakeCalendarClient) GetEventsWithCursor(_ context.Context, _, calendarID string, _ *domain.EventQueryParams) (*domain.EventListResponse, error) {
+	return &domain.EventListResponse{Data: c.events[calendarID]}, nil
+}
+
+func (c *fakeCalendarClient) CreateEvent(_ context.Context, _, calendarID string, req *domain.CreateEventRequest) (*domain.Event, error) {
+	if c.failOn != "" && req.Title == c.failOn {
+		return nil, errors.New("calendar is read-only")
+	}
+	c.created = append(c.created, createdEvent{calendarID, req})
+	return &domain.Event{ID: "copy-" + req.Title}, nil
+}
+
+func (c *fakeCalendarClient) UpdateEvent(_ context.Context, _, calendarID, eventID string, req *domain.UpdateEventRequest) (*domain.Event, error) {
+	c.updated = append(c.updated, updatedEvent{calendarID, eventID, req})
+	return &domain.Event{ID: eventID}, nil
+}
+
+func calendarEvent(id, title string, updated time.Time) domain.Event {
+	return domain.Event{
+		ID:    id,
+		Title: title,
+		When:  domain.EventWhen{StartTime: 1767261600, EndTime: 1767265200, Object: "timespan"},
+		Participants: []domain.Participant{
+			{Person: domain.Person{Email: "bob@example.com"}, Status: "yes"},
+		},
+		Recurrence: []string{"RRULE:FREQ=WEEKLY"},
+		UpdatedAt:  updated,
+	}
+}
+
+func setupCalendarMigration(t *testing.T) *fakeCalendarClient {
+	t.Helper()
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := &fakeCalendarClient{
+		calendars: map[string][]domain.Calendar{
+			"old": {
+				{ID: "old-primary", Name: "me@old.test", IsPrimary: true, IsOwner: true},
+				{ID: "old-work", Name: "Work", IsOwner: true},
+				{ID: "holidays", Name: "Holidays", ReadOnly: true},
+			},
+			"new": {
+				{ID: "new-primary", Name: "me@new.test", IsPrimary: true, IsOwner: true},
+			},
+		},
+		events: map[string][]domain.Event{
+			"old-primary": {calendarEvent("e1", "Standup", updated)},
+			"old-work": {
+				calendarEvent("e2", "Planning", updated),
+				{ID: "e3", Title: "Dropped", Status: "cancelled"},
+				{ID: "e4", Title: "Moved standup", MasterEventID: "e2"},
+			},
+		},
+	}
+
+	dir := t.TempDir()
+	origDir, origClient := checkpointDir, getCalendarClient
+	checkpointDir = func() (string, error) { return dir, nil }
+	getCalendarClient = func() (calendarClient, error) { return client, nil }
+	t.Cleanup(func() { checkpointDir, getCalendarClient = origDir, origClient })
+	return client
+}
+
+func runCalendarMigrate(t *testing.T, args ...string) (*domain.CalendarMigrationReport, error) {
+	t.Helper()
+	base := []string{"--from", "old", "--to", "new", "--rate", "0", "--json"}
+	stdout, _, err := clitestutil.ExecuteSubCommand(newCalendarCmd(), append(base, args...)...)
+	var report domain.CalendarMigrationReport
+	// On error cobra appends the usage after the report.
+	require.NoError(t, json.NewDecoder(strings.NewReader(stdout)).Decode(&report), stdout)
+	return &report, err
+}
+
+func TestCalendarCmd_CopiesOwnedCalendars(t *testing.T) {
+	client := setupCalendarMigration(t)
+
+	report, err := runCalendarMigrate(t)
+	require.NoError(t, err)
+
+	require.Len(t, report.Calendars, 2)
+	assert.Equal(t, "me@new.test", report.Calendars[0].Destination)
+	assert.True(t, report.Calendars[1].CalendarCreated)
+	assert.Equal(t, []string{"Work"}, client.newCals)
+	assert.Equal(t, 2, report.Created)
+
+	require.Len(t, client.created, 2)
+	assert.Equal(t, "new-primary", client.created[0].calendarID)
+	assert.Equal(t, "new-work", client.created[1].calendarID)
+	req := client.created[0].req
+	assert.Equal(t, []string{"RRULE:FREQ=WEEKLY"}, req.Recurrence)
+	assert.Empty(t, req.Participants, "attendees are opt-in")
+	assert.Empty(t, req.When.Object)
+
+	require.Len(t, report.Mappings, 2)
+	assert.Equal(t, domain.EventMapping{Calendar: "Work", SourceID: "e2", DestinationID: "copy-Planning", Title: "Planning", Action: "created"}, report.Mappings[1])
+}
+
+func TestCalendarCmd_Attendees(t *testing.T) {
+	client := setupCalendarMigration(t)
+
+	_, err := runCalendarMigrate(t, "--calendars", "primary", "--attendees", "--no-recurrence")
+	require.NoError(t, err)
+
+	require.Len(t, client.created, 1)
+	req := client.created[0].req
+	assert.Equal(t, []domain.Participant{{Person: domain.Person{Email: "bob@example.com"}}}, req.Participants)
+	require.NotNil(t, req.NotifyParticipants)
+	assert.False(t, *req.NotifyParticipants)
+	assert.Empty(t, req.Recurrence)
+}
+
+func TestCalendarCmd_IncrementalRerun(t *testing.T) {
+	client := setupCalendarMigration(t)
+
+	_, err := runCalendarMigrate(t, "--calendars", "Work")
+	require.NoError(t, err)
+	require.Len(t, client.created, 1)
+
+	report, err := runCalendarMigrate(t, "--calendars", "Work")
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, "unchanged", report.Mappings[0].Action)
+	assert.Empty(t, client.updated)
+
+	client.events["old-work"][0].Title = "Planning v2"
+	client.events["old-work"][0].UpdatedAt = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	report, err = runCalendarMigrate(t, "--calendars", "Work")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	require.Len(t, client.updated, 1)
+	assert.Equal(t, "new-work", client.updated[0].calendarID)
+	assert.Equal(t, "copy-Planning", client.updated[0].eventID)
+	assert.Equal(t, "Planning v2", *client.updated[0].req.Title)
+	assert.Len(t, client.created, 1)
+}
+
+func TestCalendarCmd_DryRunAndMapping(t *testing.T) {
+	client := setupCalendarMigration(t)
+	mapping := filepath.Join(t.TempDir(), "map.csv")
+
+	report, err := runCalendarMigrate(t, "--dry-run", "--mapping", mapping)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Created)
+	assert.Empty(t, client.created)
+	assert.Empty(t, client.newCals)
+
+	data, err := os.ReadFile(mapping)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "calendar,source_id,destination_id,action,title")
+	assert.Contains(t, string(data), "Work,e2,,created,Planning")
+
+	dir, _ := checkpointDir()
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries, "dry runs don't write a checkpoint")
+}
+
+func TestCalendarCmd_RecordsFailures(t *testing.T) {
+	client := setupCalendarMigration(t)
+	client.failOn = "Planning"
+
+	report, err := runCalendarMigrate(t)
+	require.Error(t, err)
+	assert.Equal(t, 1, report.Failed)
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, "e2", report.Failures[0].EventID)
+
+	client.failOn = ""
+	report, err = runCalendarMigrate(t)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Skipped)
+}
+
+func TestCalendarCmd_Validation(t *testing.T) {
+	setupCalendarMigration(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "notify without attendees", args: []string{"--from", "old", "--to", "new", "--notify"}, want: "--notify requires --attendees"},
+		{name: "same account", args: []string{"--from", "old", "--to", "old"}, want: "same account"},
+		{name: "unknown calendar", args: []string{"--from", "old", "--to", "new", "--calendars", "Nope"}, want: "not found"},
+		{name: "bad range", args: []string{"--from", "old", "--to", "new", "--after", "2026-02-01", "--before", "2026-01-01"}, want: "--before must be after --after"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := clitestutil.ExecuteSubCommand(newCalendarCmd(), tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
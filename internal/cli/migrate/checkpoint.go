@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// checkpointDir is where checkpoints live by default. Replaced in tests.
+var checkpointDir = func() (string, error) {
+	root, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, "nylas", "migrate"), nil
+}
+
+// defaultCheckpointPath returns the checkpoint file for kind ("mail",
+// "calendar", "contacts") from one grant to another.
+func defaultCheckpointPath(kind, from, to string) (string, error) {
+	dir, err := checkpointDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(from + "\x00" + to))
+	return filepath.Join(dir, fmt.Sprintf("%s-%s.json", kind, hex.EncodeToString(sum[:8]))), nil
+}
+
+// loadMailCheckpoint reads the checkpoint at path, or returns an empty one
+// when it doesn't exist.
+func loadMailCheckpoint(path, from, to string) (*domain.MailMigrationCheckpoint, error) {
+	var cp domain.MailMigrationCheckpoint
+	found, err := readCheckpoint(path, &cp)
+	if err != nil || !found {
+		return domain.NewMailMigrationCheckpoint(from, to), err
+	}
+	if err := checkGrants(path, cp.From, cp.To, from, to); err != nil {
+		return nil, err
+	}
+	if cp.Folders == nil {
+		cp.Folders = map[string]*domain.FolderMigrationMark{}
+	}
+	return &cp, nil
+}
+
+// loadCalendarCheckpoint reads the checkpoint at path, or returns an empty
+// one when it doesn't exist.
+func loadCalendarCheckpoint(path, from, to string) (*domain.CalendarMigrationCheckpoint, error) {
+	var cp domain.CalendarMigrationCheckpoint
+	found, err := readCheckpoint(path, &cp)
+	if err != nil || !found {
+		return domain.NewCalendarMigrationCheckpoint(from, to), err
+	}
+	if err := checkGrants(path, cp.From, cp.To, from, to); err != nil {
+		return nil, err
+	}
+	if cp.Calendars == nil {
+		cp.Calendars = map[string]*domain.CalendarMigrationMark{}
+	}
+	return &cp, nil
+}
+
+// loadContactCheckpoint reads the contact mapping table at path, or returns
+// an empty one when it doesn't exist.
+func loadContactCheckpoint(path, from, to string) (*domain.ContactSyncCheckpoint, error) {
+	var cp domain.ContactSyncCheckpoint
+	found, err := readCheckpoint(path, &cp)
+	if err != nil || !found {
+		return domain.NewContactSyncCheckpoint(from, to), err
+	}
+	if err := checkGrants(path, cp.From, cp.To, from, to); err != nil {
+		return nil, err
+	}
+	if cp.Contacts == nil {
+		cp.Contacts = map[string]*domain.ContactPair{}
+	}
+	return &cp, nil
+}
+
+// readCheckpoint decodes the checkpoint at path into v. It reports false
+// when there is no checkpoint yet.
+func readCheckpoint(path string, v any) (bool, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- checkpoint path from flag or cache dir
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, common.WrapLoadError("checkpoint", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, common.NewUserError(fmt.Sprintf("checkpoint %s is corrupt: %v", path, err), "Start over with --restart")
+	}
+	return true, nil
+}
+
+func checkGrants(path, cpFrom, cpTo, from, to string) error {
+	if cpFrom != from || cpTo != to {
+		return common.NewUserError(fmt.Sprintf("checkpoint %s is for %s → %s", path, cpFrom, cpTo),
+			"Use a different --checkpoint file, or start over with --restart")
+	}
+	return nil
+}
+
+// saveCheckpoint writes v to path atomically.
+func saveCheckpoint(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return common.WrapSaveError("checkpoint", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return common.WrapSaveError("checkpoint", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return common.WrapSaveError("checkpoint", err)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package migrate
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// getContactClient is replaced in tests.
+var getContactClient = func() (contactClient, error) {
+	return common.GetNylasClient()
+}
+
+var (
+	contactSyncModes  = []string{domain.ContactSyncCopy, domain.ContactSyncTwoWay}
+	contactConflicts  = []string{domain.ContactConflictNewest, domain.ContactConflictSource, domain.ContactConflictDestination, domain.ContactConflictSkip}
+	contactModeLabels = map[string]string{domain.ContactSyncCopy: "→", domain.ContactSyncTwoWay: "⇄"}
+)
+
+type contactMigrationOptions struct {
+	from        string
+	to          string
+	mode        string
+	conflict    string
+	rate        float64
+	checkpoint  string
+	dryRun      bool
+	mappingFile string
+}
+
+func newContactsCmd() *cobra.Command {
+	var opts contactMigrationOptions
+
+	cmd := &cobra.Command{
+		Use:   "contacts --from <grant> --to <grant>",
+		Short: "Copy or two-way sync contacts between accounts",
+		Long: `Copy address book contacts from one connected account to another, or keep
+two address books in step.
+
+--mode copy (the default) creates every source contact in the destination
+and, on later runs, updates the copies whose source changed.
+--mode two-way-sync also copies contacts and changes made in the destination
+back to the source.
+
+Each contact is paired with its counterpart in a mapping table, so
+repeated runs never duplicate contacts. On the first run, contacts that
+share an email address are paired instead of copied.
+
+When both sides of a pair changed since the last run, --conflict decides:
+  newest       the most recently updated side wins (default for two-way-sync)
+  source       the source wins (default for copy)
+  destination  the destination wins; in copy mode its edits are kept
+  skip         leave both alone and report the conflict
+
+Names, emails, phone numbers, addresses, web pages, IM addresses, company,
+job title, birthday and notes are synced. Groups and photos are not. Deleted
+contacts are not propagated: the counterpart is left alone and no longer
+synced.`,
+		Example: `  # Copy contacts to the new account
+  nylas migrate contacts --from old@example.com --to new@example.com
+
+  # Keep two address books in sync, the source winning conflicts
+  nylas migrate contacts --from <grant-id> --to <grant-id> --mode two-way-sync --conflict source
+
+  # Preview and save the contact ID mapping
+  nylas migrate contacts --from <grant-id> --to <grant-id> --dry-run --mapping contacts.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runContactMigration(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "Source account: grant ID or email (required)")
+	cmd.Flags().StringVar(&opts.to, "to", "", "Destination account: grant ID or email (required)")
+	cmd.Flags().StringVar(&opts.mode, "mode", domain.ContactSyncCopy, "Sync mode: copy or two-way-sync")
+	cmd.Flags().StringVar(&opts.conflict, "conflict", "", "Conflict strategy: newest, source, destination or skip")
+	cmd.Flags().Float64Var(&opts.rate, "rate", defaultMailRate, "Maximum contacts written per second (0 for no limit)")
+	cmd.Flags().StringVar(&opts.checkpoint, "checkpoint", "", "Mapping table file (default: in the user cache directory)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be synced without writing")
+	cmd.Flags().StringVar(&opts.mappingFile, "mapping", "", "Write the contact ID mapping to a CSV file")
+
+	return cmd
+}
+
+func runContactMigration(cmd *cobra.Command, opts *contactMigrationOptions) error {
+	if err := common.ValidateRequiredFlag("--from", opts.from); err != nil {
+		return err
+	}
+	if err := common.ValidateRequiredFlag("--to", opts.to); err != nil {
+		return err
+	}
+	if !slices.Contains(contactSyncModes, opts.mode) {
+		return common.NewInputError(fmt.Sprintf("invalid --mode %q: use copy or two-way-sync", opts.mode))
+	}
+	twoWay := opts.mode == domain.ContactSyncTwoWay
+	if opts.conflict == "" {
+		opts.conflict = domain.ContactConflictSource
+		if twoWay {
+			opts.conflict = domain.ContactConflictNewest
+		}
+	}
+	if !slices.Contains(contactConflicts, opts.conflict) {
+		return common.NewInputError(fmt.Sprintf("invalid --conflict %q: use newest, source, destination or skip", opts.conflict))
+	}
+	if opts.rate < 0 {
+		return common.NewInputError("--rate must be 0 (no limit) or positive")
+	}
+
+	from, err := common.ResolveGrantIdentifier(opts.from)
+	if err != nil {
+		return err
+	}
+	to, err := common.ResolveGrantIdentifier(opts.to)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return common.NewInputError("--from and --to are the same account")
+	}
+
+	checkpointPath := opts.checkpoint
+	if checkpointPath == "" {
+		if checkpointPath, err = defaultCheckpointPath("contacts", from, to); err != nil {
+			return err
+		}
+	}
+	checkpoint, err := loadContactCheckpoint(checkpointPath, from, to)
+	if err != nil {
+		return err
+	}
+
+	client, err := getContactClient()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	structured := common.IsStructuredOutput(cmd)
+	report := &domain.ContactMigrationReport{
+		From: opts.from, To: opts.to, Mode: opts.mode, Conflict: opts.conflict, DryRun: opts.dryRun,
+		Mappings: []domain.ContactMapping{},
+	}
+	syncer := &contactSyncer{
+		client:     client,
+		from:       from,
+		to:         to,
+		twoWay:     twoWay,
+		conflict:   opts.conflict,
+		dryRun:     opts.dryRun,
+		throttle:   newThrottle(opts.rate),
+		checkpoint: checkpoint,
+		save: func(cp *domain.ContactSyncCheckpoint) error {
+			return saveCheckpoint(checkpointPath, cp)
+		},
+		report: report,
+	}
+	progress := common.NewProgressBar("Syncing contacts", 0)
+	if !structured {
+		syncer.progress = func(r *domain.ContactMigrationReport) {
+			if !twoWay {
+				progress.SetTotal(r.SourceContacts)
+			}
+			progress.Set(len(r.Mappings) + r.Failed)
+		}
+	}
+
+	runErr := syncer.run(ctx)
+	progress.Finish()
+	if errors.Is(runErr, context.Canceled) {
+		report.Interrupted = true
+		runErr = nil
+	}
+
+	if opts.mappingFile != "" {
+		if err := writeContactMapping(opts.mappingFile, report.Mappings); err != nil {
+			return err
+		}
+	}
+
+	if structured {
+		if err := common.GetOutputWriter(cmd).Write(report); err != nil {
+			return err
+		}
+	} else {
+		printContactReport(report, checkpointPath, opts.mappingFile)
+	}
+
+	switch {
+	case runErr != nil:
+		return runErr
+	case report.Failed > 0:
+		return common.NewUserError(fmt.Sprintf("%d contacts failed to sync", report.Failed),
+			"Re-run the same command to retry them; synced contacts are skipped")
+	}
+	return nil
+}
+
+// writeContactMapping saves the source ↔ destination contact IDs as CSV.
+func writeContactMapping(path string, mappings []domain.ContactMapping) error {
+	f, err := os.Create(path) // #nosec G304 -- user-specified output path
+	if err != nil {
+		return common.WrapSaveError("contact mapping", err)
+	}
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"source_id", "destination_id", "action", "direction", "name"})
+	for _, m := range mappings {
+		_ = w.Write([]string{m.SourceID, m.DestinationID, m.Action, m.Direction, m.Name})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		_ = f.Close()
+		return common.WrapSaveError("contact mapping", err)
+	}
+	if err := f.Close(); err != nil {
+		return common.WrapSaveError("contact mapping", err)
+	}
+	return nil
+}
+
+func printContactReport(r *domain.ContactMigrationReport, checkpointPath, mappingFile string) {
+	arrow := contactModeLabels[r.Mode]
+	if r.DryRun {
+		fmt.Printf("Dry run: %s %s %s (nothing written)\n\n", r.From, arrow, r.To)
+	} else {
+		fmt.Printf("Syncing contacts %s %s %s\n\n", r.From, arrow, r.To)
+	}
+	fmt.Printf("  Source contacts:      %d\n", r.SourceContacts)
+	fmt.Printf("  Destination contacts: %d\n", r.DestinationContacts)
+	fmt.Printf("  Conflict strategy:    %s\n\n", r.Conflict)
+
+	verb := "Created"
+	if r.DryRun {
+		verb = "Would create"
+	}
+	common.PrintSuccess("%s %d contacts, updated %d, %d unchanged (%d paired by email)",
+		verb, r.Created, r.Updated, r.Unchanged, r.Linked)
+	if r.Conflicts > 0 {
+		common.PrintWarning("%d contacts changed on both sides were left alone (--conflict skip)", r.Conflicts)
+	}
+	if r.Detached > 0 {
+		fmt.Println(common.Dim.Sprintf("%d contacts deleted on one side are no longer synced", r.Detached))
+	}
+	if r.Failed > 0 {
+		common.PrintWarning("%d contacts failed:", r.Failed)
+		for i, f := range r.Failures {
+			if i == maxListedErrors {
+				fmt.Printf("    ... and %d more (use --json for all)\n", len(r.Failures)-i)
+				break
+			}
+			fmt.Printf("    %s: %s\n", common.Truncate(f.Name, 40), f.Error)
+		}
+	}
+	if r.Interrupted {
+		common.PrintWarning("Interrupted; re-run the same command to resume")
+	}
+	if mappingFile != "" {
+		fmt.Println(common.Dim.Sprintf("Contact mapping: %s", mappingFile))
+	}
+	if !r.DryRun {
+		fmt.Println(common.Dim.Sprintf("Mapping table: %s (re-run to sync changes)", checkpointPath))
+	}
+}
@@ -0,0 +1,396 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Contact mapping actions and directions.
+const (
+	actionConflict = "conflict"
+
+	toDestination = "to_destination"
+	toSource      = "to_source"
+)
+
+// contactClient is the part of the Nylas client used by contact migration.
+type contactClient interface {
+	GetContactsWithCursor(ctx context.Context, grantID string, params *domain.ContactQueryParams) (*domain.ContactListResponse, error)
+	CreateContact(ctx context.Context, grantID string, req *domain.CreateContactRequest) (*domain.Contact, error)
+	UpdateContact(ctx context.Context, grantID, contactID string, req *domain.UpdateContactRequest) (*domain.Contact, error)
+}
+
+// contactSyncer copies or two-way syncs address book contacts between two
+// grants, pairing them through the checkpoint's mapping table.
+type contactSyncer struct {
+	client     contactClient
+	from       string
+	to         string
+	twoWay     bool
+	conflict   string
+	dryRun     bool
+	throttle   *throttle
+	checkpoint *domain.ContactSyncCheckpoint
+	save       func(*domain.ContactSyncCheckpoint) error
+	progress   func(r *domain.ContactMigrationReport)
+	report     *domain.ContactMigrationReport
+
+	unsaved     int
+	consecutive int
+}
+
+// run syncs every contact. The checkpoint is saved on the way out,
+// including when ctx is cancelled.
+func (s *contactSyncer) run(ctx context.Context) (err error) {
+	source, err := s.fetch(ctx, s.from)
+	if err != nil {
+		return common.WrapListError("source contacts", err)
+	}
+	dest, err := s.fetch(ctx, s.to)
+	if err != nil {
+		return common.WrapListError("destination contacts", err)
+	}
+	s.report.SourceContacts, s.report.DestinationContacts = len(source), len(dest)
+	defer func() {
+		if saveErr := s.saveCheckpoint(); err == nil {
+			err = saveErr
+		}
+	}()
+
+	sourceByID := make(map[string]bool, len(source))
+	for _, c := range source {
+		sourceByID[c.ID] = true
+	}
+	destByID := make(map[string]*domain.Contact, len(dest))
+	for i := range dest {
+		destByID[dest[i].ID] = &dest[i]
+	}
+	// Contacts deleted on one side keep their pair, detached, so the other
+	// side is neither updated nor copied back.
+	paired := map[string]bool{}
+	for srcID, pair := range s.checkpoint.Contacts {
+		paired[pair.DestinationID] = true
+		if !pair.Detached && (!sourceByID[srcID] || destByID[pair.DestinationID] == nil) {
+			s.report.Detached++
+			if !s.dryRun {
+				pair.Detached = true
+			}
+		}
+	}
+	byEmail := unpairedByEmail(dest, paired)
+
+	for i := range source {
+		src := &source[i]
+		pair := s.checkpoint.Contacts[src.ID]
+		var err error
+		switch {
+		case pair != nil && (pair.Detached || destByID[pair.DestinationID] == nil):
+			continue
+		case pair != nil:
+			err = s.syncPair(ctx, src, destByID[pair.DestinationID], pair, false)
+		default:
+			if dst := matchByEmail(src, byEmail); dst != nil {
+				paired[dst.ID] = true
+				s.report.Linked++
+				err = s.syncPair(ctx, src, dst, &domain.ContactPair{DestinationID: dst.ID}, true)
+			} else {
+				err = s.create(ctx, src, toDestination)
+			}
+		}
+		if err = s.done(ctx, src, err); err != nil {
+			return err
+		}
+	}
+
+	if !s.twoWay {
+		return nil
+	}
+	for i := range dest {
+		dst := &dest[i]
+		if paired[dst.ID] {
+			continue
+		}
+		if err := s.done(ctx, dst, s.create(ctx, dst, toSource)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetch lists the grant's address book contacts. Contacts collected from
+// mail or the domain directory aren't synced.
+func (s *contactSyncer) fetch(ctx context.Context, grantID string) ([]domain.Contact, error) {
+	params := &domain.ContactQueryParams{Limit: common.MaxAPILimit, Source: "address_book"}
+	return common.FetchCursorPages(ctx, common.MaxAPILimit, 0, func(ctx context.Context, cursor string) (common.PageResult[domain.Contact], error) {
+		params.PageToken = cursor
+		resp, err := s.client.GetContactsWithCursor(ctx, grantID, params)
+		if err != nil {
+			return common.PageResult[domain.Contact]{}, err
+		}
+		return common.PageResult[domain.Contact]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+}
+
+// syncPair brings a paired source and destination contact in step. A side
+// has changed when its fingerprint differs from the one recorded at the
+// last sync; when both have, the conflict strategy decides.
+func (s *contactSyncer) syncPair(ctx context.Context, src, dst *domain.Contact, pair *domain.ContactPair, linked bool) error {
+	mapping := domain.ContactMapping{SourceID: src.ID, DestinationID: dst.ID, Name: src.DisplayName(), Linked: linked}
+	srcHash, dstHash := contactFingerprint(src), contactFingerprint(dst)
+	srcChanged, dstChanged := srcHash != pair.SourceHash, dstHash != pair.DestinationHash
+
+	direction := ""
+	switch {
+	case !srcChanged && !dstChanged, srcHash == dstHash:
+	case srcChanged && !dstChanged:
+		direction = toDestination
+	case !srcChanged:
+		if s.twoWay {
+			direction = toSource
+		}
+	default:
+		direction = s.resolve(src, dst)
+		if direction == actionConflict {
+			s.report.Conflicts++
+			mapping.Action = actionConflict
+			s.report.Mappings = append(s.report.Mappings, mapping)
+			return nil
+		}
+	}
+
+	if direction == "" {
+		s.report.Unchanged++
+		mapping.Action = actionUnchanged
+		s.report.Mappings = append(s.report.Mappings, mapping)
+		return s.record(src.ID, &domain.ContactPair{DestinationID: dst.ID, SourceHash: srcHash, DestinationHash: dstHash})
+	}
+
+	if !s.dryRun {
+		if err := s.throttle.wait(ctx); err != nil {
+			return err
+		}
+		if direction == toDestination {
+			updated, err := s.client.UpdateContact(ctx, s.to, dst.ID, contactUpdateRequest(src))
+			if err != nil {
+				return err
+			}
+			dstHash = contactFingerprint(updated)
+		} else {
+			updated, err := s.client.UpdateContact(ctx, s.from, src.ID, contactUpdateRequest(dst))
+			if err != nil {
+				return err
+			}
+			srcHash = contactFingerprint(updated)
+		}
+		if err := s.record(src.ID, &domain.ContactPair{DestinationID: dst.ID, SourceHash: srcHash, DestinationHash: dstHash}); err != nil {
+			return err
+		}
+	}
+	s.report.Updated++
+	mapping.Action, mapping.Direction = actionUpdated, direction
+	s.report.Mappings = append(s.report.Mappings, mapping)
+	return nil
+}
+
+// resolve picks the winner of a pair changed on both sides: the direction
+// to copy in, "" to keep both as they are, or actionConflict to leave the
+// pair for a later run. In copy mode the source is never written, so a
+// destination win keeps the destination's edits.
+func (s *contactSyncer) resolve(src, dst *domain.Contact) string {
+	winner := s.conflict
+	if winner == domain.ContactConflictNewest {
+		winner = domain.ContactConflictSource
+		if dst.UpdatedAt > src.UpdatedAt {
+			winner = domain.ContactConflictDestination
+		}
+	}
+	switch winner {
+	case domain.ContactConflictSource:
+		return toDestination
+	case domain.ContactConflictDestination:
+		if s.twoWay {
+			return toSource
+		}
+		return ""
+	default:
+		return actionConflict
+	}
+}
+
+// create copies a contact that has no counterpart to the other side.
+func (s *contactSyncer) create(ctx context.Context, c *domain.Contact, direction string) error {
+	mapping := domain.ContactMapping{Name: c.DisplayName(), Action: actionCreated, Direction: direction}
+	if direction == toDestination {
+		mapping.SourceID = c.ID
+	} else {
+		mapping.DestinationID = c.ID
+	}
+
+	if !s.dryRun {
+		if err := s.throttle.wait(ctx); err != nil {
+			return err
+		}
+		grantID := s.to
+		if direction == toSource {
+			grantID = s.from
+		}
+		created, err := s.client.CreateContact(ctx, grantID, contactCreateRequest(c))
+		if err != nil {
+			return err
+		}
+		src, dst := c, created
+		if direction == toDestination {
+			mapping.DestinationID = created.ID
+		} else {
+			mapping.SourceID = created.ID
+			src, dst = created, c
+		}
+		pair := &domain.ContactPair{DestinationID: dst.ID, SourceHash: contactFingerprint(src), DestinationHash: contactFingerprint(dst)}
+		if err := s.record(src.ID, pair); err != nil {
+			return err
+		}
+	}
+	s.report.Created++
+	s.report.Mappings = append(s.report.Mappings, mapping)
+	return nil
+}
+
+// done accounts for the outcome of syncing c. It returns an error only when
+// the run should stop.
+func (s *contactSyncer) done(ctx context.Context, c *domain.Contact, err error) error {
+	if err == nil {
+		s.consecutive = 0
+		if s.progress != nil {
+			s.progress(s.report)
+		}
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	s.report.Failed++
+	s.report.Failures = append(s.report.Failures, domain.ContactMigrationFailure{
+		ContactID: c.ID, Name: c.DisplayName(), Error: err.Error(),
+	})
+	if s.consecutive++; s.consecutive >= maxConsecutiveFailures {
+		return fmt.Errorf("stopped after %d consecutive failures: %w", s.consecutive, err)
+	}
+	return nil
+}
+
+// record stores a pair in the mapping table, saving it every so often.
+func (s *contactSyncer) record(sourceID string, pair *domain.ContactPair) error {
+	if s.dryRun {
+		return nil
+	}
+	s.checkpoint.Contacts[sourceID] = pair
+	if s.unsaved++; s.unsaved >= checkpointEvery {
+		return s.saveCheckpoint()
+	}
+	return nil
+}
+
+func (s *contactSyncer) saveCheckpoint() error {
+	if s.dryRun || s.save == nil {
+		return nil
+	}
+	s.unsaved = 0
+	s.checkpoint.UpdatedAt = time.Now()
+	return s.save(s.checkpoint)
+}
+
+// unpairedByEmail indexes the contacts not yet paired by lower-cased email
+// address, so a first run links contacts that exist on both sides instead
+// of duplicating them.
+func unpairedByEmail(contacts []domain.Contact, paired map[string]bool) map[string]*domain.Contact {
+	index := map[string]*domain.Contact{}
+	for i := range contacts {
+		if paired[contacts[i].ID] {
+			continue
+		}
+		for _, e := range contacts[i].Emails {
+			key := strings.ToLower(strings.TrimSpace(e.Email))
+			if _, taken := index[key]; key != "" && !taken {
+				index[key] = &contacts[i]
+			}
+		}
+	}
+	return index
+}
+
+// matchByEmail returns the unpaired contact sharing an address with c and
+// removes it from the index.
+func matchByEmail(c *domain.Contact, index map[string]*domain.Contact) *domain.Contact {
+	for _, e := range c.Emails {
+		match := index[strings.ToLower(strings.TrimSpace(e.Email))]
+		if match == nil {
+			continue
+		}
+		for key, other := range index {
+			if other == match {
+				delete(index, key)
+			}
+		}
+		return match
+	}
+	return nil
+}
+
+// contactCreateRequest copies the synced fields. Groups are grant-specific
+// and pictures can't be written through the API, so neither is carried over.
+func contactCreateRequest(c *domain.Contact) *domain.CreateContactRequest {
+	return &domain.CreateContactRequest{
+		GivenName:         c.GivenName,
+		MiddleName:        c.MiddleName,
+		Surname:           c.Surname,
+		Suffix:            c.Suffix,
+		Nickname:          c.Nickname,
+		Birthday:          c.Birthday,
+		CompanyName:       c.CompanyName,
+		JobTitle:          c.JobTitle,
+		ManagerName:       c.ManagerName,
+		Notes:             c.Notes,
+		Emails:            c.Emails,
+		PhoneNumbers:      c.PhoneNumbers,
+		WebPages:          c.WebPages,
+		IMAddresses:       c.IMAddresses,
+		PhysicalAddresses: c.PhysicalAddresses,
+	}
+}
+
+// contactUpdateRequest turns the synced fields into a full update.
+func contactUpdateRequest(c *domain.Contact) *domain.UpdateContactRequest {
+	req := contactCreateRequest(c)
+	return &domain.UpdateContactRequest{
+		GivenName:         &req.GivenName,
+		MiddleName:        &req.MiddleName,
+		Surname:           &req.Surname,
+		Suffix:            &req.Suffix,
+		Nickname:          &req.Nickname,
+		Birthday:          &req.Birthday,
+		CompanyName:       &req.CompanyName,
+		JobTitle:          &req.JobTitle,
+		ManagerName:       &req.ManagerName,
+		Notes:             &req.Notes,
+		Emails:            req.Emails,
+		PhoneNumbers:      req.PhoneNumbers,
+		WebPages:          req.WebPages,
+		IMAddresses:       req.IMAddresses,
+		PhysicalAddresses: req.PhysicalAddresses,
+	}
+}
+
+// contactFingerprint hashes the synced fields of c.
+func contactFingerprint(c *domain.Contact) string {
+	data, _ := json.Marshal(contactCreateRequest(c))
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,255 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+)
+
+type contactWrite struct {
+	grantID, contactID string
+}
+
+type fakeContactClient struct {
+	contacts map[string][]domain.Contact // Keyed by grant ID
+	created  []contactWrite
+	updated  []contactWrite
+	clock    int64
+}
+
+func (c *fakeContactClient) GetContactsWithCursor(_ context.Context, grantID string, params *domain.ContactQueryParams) (*domain.ContactListResponse, error) {
+	if params.Source != "address_book" {
+		return nil, fmt.Errorf("unexpected source %q", params.Source)
+	}
+	return &domain.ContactListResponse{Data: slices.Clone(c.contacts[grantID])}, nil
+}
+
+func (c *fakeContactClient) CreateContact(_ context.Context, grantID string, req *domain.CreateContactRequest) (*domain.Contact, error) {
+	contact := domain.Contact{
+		ID: fmt.Sprintf("%s-%d", grantID, len(c.contacts[grantID])+1), GivenName: req.GivenName, Surname: req.Surname,
+		CompanyName: req.CompanyName, Emails: req.Emails, PhoneNumbers: req.PhoneNumbers, UpdatedAt: c.tick(),
+	}
+	c.contacts[grantID] = append(c.contacts[grantID], contact)
+	c.created = append(c.created, contactWrite{grantID, contact.ID})
+	return &contact, nil
+}
+
+func (c *fakeContactClient) UpdateContact(_ context.Context, grantID, contactID string, req *domain.UpdateContactRequest) (*domain.Contact, error) {
+	contact := c.find(grantID, contactID)
+	contact.GivenName, contact.Surname, contact.CompanyName = *req.GivenName, *req.Surname, *req.CompanyName
+	contact.Emails, contact.PhoneNumbers, contact.UpdatedAt = req.Emails, req.PhoneNumbers, c.tick()
+	c.updated = append(c.updated, contactWrite{grantID, contactID})
+	return contact, nil
+}
+
+func (c *fakeContactClient) find(grantID, contactID string) *domain.Contact {
+	for i := range c.contacts[grantID] {
+		if c.contacts[grantID][i].ID == contactID {
+			return &c.contacts[grantID][i]
+		}
+	}
+	return nil
+}
+
+// edit changes a contact as a user would, between runs.
+func (c *fakeContactClient) edit(grantID, contactID, company string) {
+	contact := c.find(grantID, contactID)
+	contact.CompanyName, contact.UpdatedAt = company, c.tick()
+}
+
+func (c *fakeContactClient) tick() int64 {
+	c.clock++
+	return c.clock
+}
+
+func contact(id, name, email string) domain.Contact {
+	return domain.Contact{ID: id, GivenName: name, Emails: []domain.ContactEmail{{Email: email, Type: "work"}}}
+}
+
+func setupContactMigration(t *testing.T) *fakeContactClient {
+	t.Helper()
+	client := &fakeContactClient{contacts: map[string][]domain.Contact{
+		"old": {
+			contact("a1", "Alice", "alice@example.com"),
+			contact("a2", "Bob", "bob@example.com"),
+			contact("a3", "Carol", "carol@example.com"),
+		},
+		"new": {
+			contact("b1", "Carol", "carol@example.com"),
+			contact("b2", "Dave", "dave@example.com"),
+		},
+	}}
+
+	dir := t.TempDir()
+	origDir, origClient := checkpointDir, getContactClient
+	checkpointDir = func() (string, error) { return dir, nil }
+	getContactClient = func() (contactClient, error) { return client, nil }
+	t.Cleanup(func() { checkpointDir, getContactClient = origDir, origClient })
+	return client
+}
+
+func runContactMigrate(t *testing.T, args ...string) (*domain.ContactMigrationReport, error) {
+	t.Helper()
+	base := []string{"--from", "old", "--to", "new", "--rate", "0", "--json"}
+	stdout, _, err := clitestutil.ExecuteSubCommand(newContactsCmd(), append(base, args...)...)
+	var report domain.ContactMigrationReport
+	// On error cobra appends the usage after the report.
+	require.NoError(t, json.NewDecoder(strings.NewReader(stdout)).Decode(&report), stdout)
+	return &report, err
+}
+
+func TestContactsCmd_CopyLinksByEmail(t *testing.T) {
+	client := setupContactMigration(t)
+
+	report, err := runContactMigrate(t)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ContactConflictSource, report.Conflict)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 1, report.Linked)
+	assert.Equal(t, 1, report.Unchanged)
+	assert.Equal(t, []contactWrite{{"new", "new-3"}, {"new", "new-4"}}, client.created)
+	assert.Empty(t, client.updated)
+
+	carol := report.Mappings[2]
+	assert.Equal(t, domain.ContactMapping{SourceID: "a3", DestinationID: "b1", Name: "Carol", Action: "unchanged", Linked: true}, carol)
+	assert.Equal(t, "to_destination", report.Mappings[0].Direction)
+
+	report, err = runContactMigrate(t)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Created+report.Updated+report.Linked)
+	assert.Equal(t, 3, report.Unchanged)
+	assert.Len(t, client.created, 2, "re-runs don't duplicate contacts")
+}
+
+func TestContactsCmd_CopyUpdatesChangedSource(t *testing.T) {
+	client := setupContactMigration(t)
+	_, err := runContactMigrate(t)
+	require.NoError(t, err)
+
+	client.edit("old", "a1", "Acme")
+	client.edit("new", "b1", "Initech") // Carol's copy, changed in the destination only
+	report, err := runContactMigrate(t)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, []contactWrite{{"new", "new-3"}}, client.updated)
+	assert.Equal(t, "Acme", client.find("new", "new-3").CompanyName)
+	assert.Equal(t, "Initech", client.find("new", "b1").CompanyName, "copy mode keeps destination edits")
+	assert.Equal(t, "Carol", client.find("old", "a3").GivenName)
+	assert.Empty(t, client.find("old", "a3").CompanyName)
+}
+
+func TestContactsCmd_TwoWaySync(t *testing.T) {
+	client := setupContactMigration(t)
+
+	report, err := runContactMigrate(t, "--mode", "two-way-sync")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ContactConflictNewest, report.Conflict)
+	assert.Equal(t, 3, report.Created)
+	assert.Contains(t, client.created, contactWrite{"old", "old-4"}, "Dave is copied back to the source")
+
+	client.edit("new", "b2", "Globex")
+	report, err = runContactMigrate(t, "--mode", "two-way-sync")
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, "Globex", client.find("old", "old-4").CompanyName)
+	m := report.Mappings[slices.IndexFunc(report.Mappings, func(m domain.ContactMapping) bool { return m.Action == "updated" })]
+	assert.Equal(t, domain.ContactMapping{SourceID: "old-4", DestinationID: "b2", Name: "Dave", Action: "updated", Direction: "to_source"}, m)
+
+	report, err = runContactMigrate(t, "--mode", "two-way-sync")
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Unchanged)
+}
+
+func TestContactsCmd_Conflicts(t *testing.T) {
+	client := setupContactMigration(t)
+	_, err := runContactMigrate(t, "--mode", "two-way-sync")
+	require.NoError(t, err)
+
+	client.edit("old", "a1", "Acme")
+	client.edit("new", "new-3", "Initech")
+
+	report, err := runContactMigrate(t, "--mode", "two-way-sync", "--conflict", "skip")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Conflicts)
+	assert.Empty(t, client.updated)
+
+	report, err = runContactMigrate(t, "--mode", "two-way-sync")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, "Initech", client.find("old", "a1").CompanyName, "the newer destination wins")
+
+	client.edit("old", "a1", "Acme")
+	client.edit("new", "new-3", "Hooli")
+	_, err = runContactMigrate(t, "--mode", "two-way-sync", "--conflict", "source")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", client.find("new", "new-3").CompanyName)
+}
+
+func TestContactsCmd_DeletedContactsDetach(t *testing.T) {
+	client := setupContactMigration(t)
+	_, err := runContactMigrate(t, "--mode", "two-way-sync")
+	require.NoError(t, err)
+
+	client.contacts["old"] = client.contacts["old"][1:] // Alice deleted in the source
+	report, err := runContactMigrate(t, "--mode", "two-way-sync")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Detached)
+	assert.Equal(t, 0, report.Created, "the copy is not recreated in the source")
+
+	report, err = runContactMigrate(t, "--mode", "two-way-sync")
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Detached+report.Created)
+}
+
+func TestContactsCmd_DryRunAndMapping(t *testing.T) {
+	client := setupContactMigration(t)
+	mapping := filepath.Join(t.TempDir(), "contacts.csv")
+
+	report, err := runContactMigrate(t, "--mode", "two-way-sync", "--dry-run", "--mapping", mapping)
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Created)
+	assert.Empty(t, client.created)
+
+	data, err := os.ReadFile(mapping)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "source_id,destination_id,action,direction,name")
+	assert.Contains(t, string(data), ",b2,created,to_source,Dave")
+
+	dir, _ := checkpointDir()
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries, "dry runs don't write the mapping table")
+}
+
+func TestContactsCmd_Validation(t *testing.T) {
+	setupContactMigration(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "bad mode", args: []string{"--from", "old", "--to", "new", "--mode", "mirror"}, want: "invalid --mode"},
+		{name: "bad conflict", args: []string{"--from", "old", "--to", "new", "--conflict", "oldest"}, want: "invalid --conflict"},
+		{name: "same account", args: []string{"--from", "old", "--to", "old"}, want: "same account"},
+		{name: "missing from", args: []string{"--to", "new"}, want: "--from"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := clitestutil.ExecuteSubCommand(newContactsCmd(), tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
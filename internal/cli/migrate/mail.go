@@ -0,0 +1,382 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/mailauth"
+	"github.com/nylas/cli/internal/cli/common"
+	mailauthcmd "github.com/nylas/cli/internal/cli/mailauth"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const (
+	defaultMailRate = 5
+	maxListedErrors = 10
+)
+
+// getMailClient is replaced in tests.
+var getMailClient = func() (mailClient, error) {
+	return common.GetNylasClient()
+}
+
+// openMailboxWriter is replaced in tests.
+var openMailboxWriter = func(ctx context.Context, opts domain.MailAuthOptions) (ports.MailboxWriter, error) {
+	return mailauth.NewTester().OpenMailboxWriter(ctx, opts)
+}
+
+type mailOptions struct {
+	from       string
+	to         string
+	folders    []string
+	after      string
+	rate       float64
+	checkpoint string
+	restart    bool
+	dryRun     bool
+	noVerify   bool
+
+	imapHost  string
+	imapPort  int
+	imapUser  string
+	token     string
+	tokenFile string
+	mechanism string
+	tlsMode   string
+	insecure  bool
+}
+
+func newMailCmd() *cobra.Command {
+	var opts mailOptions
+
+	cmd := &cobra.Command{
+		Use:   "mail --from <grant> --to <grant>",
+		Short: "Copy messages and folders from one account to another",
+		Long: `Copy messages and folder structure from one connected account to another.
+
+Messages are read through the Nylas API with their original MIME source
+(raw_mime) and written to the destination over IMAP APPEND, since the API
+cannot insert existing messages. System folders map to the destination's
+matching mailboxes (INBOX, \Sent, \Drafts, \Trash, \Junk, \Archive); user
+folders are created by path. Read and starred state are kept. Messages
+whose provider exposes no raw MIME are rebuilt from their body, without
+attachments.
+
+Without --folders, every folder is copied except virtual ones such as
+Gmail's All Mail, STARRED and CATEGORY_* labels.
+
+The destination IMAP login uses an OAuth access token (or, with
+--mechanism plain, a password or app password) from --token, --token-file
+or NYLAS_OAUTH_TOKEN. Host and username default from the destination grant.
+
+Progress is checkpointed, so an interrupted run picks up where it stopped
+when re-run with the same --from and --to. When the copy completes, both
+accounts are compared by Message-ID; the destination may need a few
+minutes to sync before every message shows up.`,
+		Example: `  # Copy the inbox and sent mail
+  nylas migrate mail --from old@example.com --to new@example.com \
+    --folders INBOX,Sent --token-file token.txt
+
+  # Preview the folder mapping without writing anything
+  nylas migrate mail --from old@example.com --to new@example.com --dry-run
+
+  # Recent mail only, at most 2 messages per second
+  nylas migrate mail --from <grant-id> --to <grant-id> --after 2025-01-01 --rate 2`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runMailMigration(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "Source account: grant ID or email (required)")
+	cmd.Flags().StringVar(&opts.to, "to", "", "Destination account: grant ID or email (required)")
+	cmd.Flags().StringSliceVar(&opts.folders, "folders", nil, "Folders to copy by name, path, role or ID (default: all)")
+	cmd.Flags().StringVar(&opts.after, "after", "", "Only copy messages received after date (YYYY-MM-DD)")
+	cmd.Flags().Float64Var(&opts.rate, "rate", defaultMailRate, "Maximum messages copied per second (0 for no limit)")
+	cmd.Flags().StringVar(&opts.checkpoint, "checkpoint", "", "Checkpoint file (default: in the user cache directory)")
+	cmd.Flags().BoolVar(&opts.restart, "restart", false, "Ignore the checkpoint and copy everything again")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be copied without writing")
+	cmd.Flags().BoolVar(&opts.noVerify, "no-verify", false, "Skip comparing the accounts after copying")
+
+	cmd.Flags().StringVar(&opts.imapHost, "imap-host", "", "Destination IMAP server (defaults from the grant's provider)")
+	cmd.Flags().IntVar(&opts.imapPort, "imap-port", 0, "Destination IMAP port (default 993, or 143 with --tls starttls)")
+	cmd.Flags().StringVar(&opts.imapUser, "imap-user", "", "Destination IMAP username (defaults to the grant email)")
+	cmd.Flags().StringVar(&opts.token, "token", "", "Destination OAuth access token or password (or set NYLAS_OAUTH_TOKEN)")
+	cmd.Flags().StringVar(&opts.tokenFile, "token-file", "", "Read the destination token or password from a file")
+	cmd.Flags().StringVar(&opts.mechanism, "mechanism", "xoauth2", "SASL mechanism: xoauth2, oauthbearer, or plain")
+	cmd.Flags().StringVar(&opts.tlsMode, "tls", "", "TLS mode: tls, starttls, or none (default by port)")
+	cmd.Flags().BoolVar(&opts.insecure, "insecure", false, "Skip TLS certificate verification")
+
+	return cmd
+}
+
+func runMailMigration(cmd *cobra.Command, opts *mailOptions) error {
+	if err := common.ValidateRequiredFlag("--from", opts.from); err != nil {
+		return err
+	}
+	if err := common.ValidateRequiredFlag("--to", opts.to); err != nil {
+		return err
+	}
+	if opts.rate < 0 {
+		return common.NewInputError("--rate must be 0 (no limit) or positive")
+	}
+	var after time.Time
+	if opts.after != "" {
+		t, err := time.ParseInLocation("2006-01-02", opts.after, time.Local)
+		if err != nil {
+			return common.WrapDateParseError("after", err)
+		}
+		after = t
+	}
+
+	from, err := common.ResolveGrantIdentifier(opts.from)
+	if err != nil {
+		return err
+	}
+	to, err := common.ResolveGrantIdentifier(opts.to)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return common.NewInputError("--from and --to are the same account")
+	}
+
+	dest, err := resolveDestination(opts, to)
+	if err != nil {
+		return err
+	}
+
+	checkpointPath := opts.checkpoint
+	if checkpointPath == "" {
+		if checkpointPath, err = defaultCheckpointPath("mail", from, to); err != nil {
+			return err
+		}
+	}
+	checkpoint := domain.NewMailMigrationCheckpoint(from, to)
+	if !opts.restart {
+		if checkpoint, err = loadMailCheckpoint(checkpointPath, from, to); err != nil {
+			return err
+		}
+	}
+
+	client, err := getMailClient()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	sourceFolders, err := common.RunWithSpinnerResult("Fetching source folders...", func() ([]domain.Folder, error) {
+		return client.GetFolders(ctx, from)
+	})
+	if err != nil {
+		return common.WrapListError("folders", err)
+	}
+	folders, err := selectFolders(sourceFolders, opts.folders, opts.from)
+	if err != nil {
+		return err
+	}
+
+	writer, err := openMailboxWriter(ctx, dest)
+	if err != nil {
+		return common.NewUserError(fmt.Sprintf("cannot log in to the destination IMAP server: %v", err),
+			"Check the login with: nylas imap test --grant "+opts.to)
+	}
+	defer func() { _ = writer.Close() }()
+
+	structured := common.IsStructuredOutput(cmd)
+	report := &domain.MailMigrationReport{From: opts.from, To: opts.to, DryRun: opts.dryRun, Folders: []domain.FolderMigration{}}
+	copier := &mailCopier{
+		client:     client,
+		writer:     writer,
+		from:       from,
+		after:      after,
+		dryRun:     opts.dryRun,
+		throttle:   newThrottle(opts.rate),
+		checkpoint: checkpoint,
+		save: func(cp *domain.MailMigrationCheckpoint) error {
+			return saveCheckpoint(checkpointPath, cp)
+		},
+		report: report,
+	}
+	progress := &stepProgress{}
+	if !structured {
+		copier.progress = func(fm *domain.FolderMigration, total int) {
+			progress.update(fm.Folder, fm.Messages, total)
+		}
+	}
+
+	runErr := copier.run(ctx, folders)
+	progress.finish()
+	if errors.Is(runErr, context.Canceled) {
+		report.Interrupted = true
+		runErr = nil
+	}
+
+	if runErr == nil && !report.Interrupted && !opts.dryRun && !opts.noVerify {
+		diff, err := common.RunWithSpinnerResult("Verifying...", func() (*domain.MailboxDiff, error) {
+			return verifyMailMigration(ctx, client, from, to, folders, after)
+		})
+		if err != nil {
+			common.PrintWarningStderr("verification failed: %v", err)
+		} else {
+			diff.GrantA, diff.GrantB = opts.from, opts.to
+			report.Verification = diff
+		}
+	}
+
+	if structured {
+		if err := common.GetOutputWriter(cmd).Write(report); err != nil {
+			return err
+		}
+	} else {
+		printMailReport(report, checkpointPath)
+	}
+
+	switch {
+	case runErr != nil:
+		return runErr
+	case report.Failed > 0:
+		return common.NewUserError(fmt.Sprintf("%d messages failed to copy", report.Failed),
+			"Re-run the same command to retry them; copied messages are skipped")
+	}
+	return nil
+}
+
+// resolveDestination builds the IMAP login for the destination grant.
+func resolveDestination(opts *mailOptions, grantID string) (domain.MailAuthOptions, error) {
+	dest := domain.MailAuthOptions{
+		Host:               opts.imapHost,
+		Port:               opts.imapPort,
+		Username:           opts.imapUser,
+		InsecureSkipVerify: opts.insecure,
+	}
+
+	switch strings.ToLower(opts.mechanism) {
+	case "xoauth2":
+		dest.Mechanism = domain.SASLXOAuth2
+	case "oauthbearer":
+		dest.Mechanism = domain.SASLOAuthBearer
+	case "plain":
+		dest.Mechanism = domain.SASLPlain
+	default:
+		return dest, common.NewInputError(fmt.Sprintf("invalid --mechanism %q (use xoauth2, oauthbearer, or plain)", opts.mechanism))
+	}
+
+	token, err := mailauthcmd.ResolveToken(opts.token, opts.tokenFile)
+	if err != nil {
+		return dest, err
+	}
+	dest.Token = token
+
+	if dest.Host == "" || dest.Username == "" {
+		if store, err := common.NewDefaultGrantStore(); err == nil {
+			if grant, err := store.GetGrant(grantID); err == nil {
+				if dest.Host == "" {
+					dest.Host = mailauthcmd.DefaultIMAPHost(grant.Provider)
+				}
+				if dest.Username == "" {
+					dest.Username = grant.Email
+				}
+			}
+		}
+	}
+	if dest.Host == "" {
+		return dest, common.NewUserError("no IMAP server for the destination account",
+			"Pass --imap-host; defaults exist only for Google and Microsoft grants.")
+	}
+	if dest.Username == "" {
+		return dest, common.NewUserError("no IMAP username for the destination account", "Pass --imap-user.")
+	}
+
+	switch domain.MailTLSMode(strings.ToLower(opts.tlsMode)) {
+	case "":
+		dest.TLSMode = domain.MailTLSImplicit
+		if dest.Port != 0 && dest.Port != 993 {
+			dest.TLSMode = domain.MailTLSStartTLS
+		}
+	case domain.MailTLSImplicit, domain.MailTLSStartTLS, domain.MailTLSNone:
+		dest.TLSMode = domain.MailTLSMode(strings.ToLower(opts.tlsMode))
+	default:
+		return dest, common.NewInputError(fmt.Sprintf("invalid --tls %q (use tls, starttls, or none)", opts.tlsMode))
+	}
+	if dest.Port == 0 {
+		dest.Port = 993
+		if dest.TLSMode != domain.MailTLSImplicit {
+			dest.Port = 143
+		}
+	}
+	return dest, nil
+}
+
+func printMailReport(r *domain.MailMigrationReport, checkpointPath string) {
+	verb := "Copied"
+	if r.DryRun {
+		verb = "Would copy"
+		fmt.Printf("Dry run: %s → %s (nothing written)\n\n", r.From, r.To)
+	} else {
+		fmt.Printf("Migrating %s → %s\n\n", r.From, r.To)
+	}
+
+	fmt.Printf("  %-30s %-30s %8s %8s %8s %8s\n", "FOLDER", "MAILBOX", "MESSAGES", "COPIED", "SKIPPED", "FAILED")
+	for _, f := range r.Folders {
+		mailbox := f.Mailbox
+		if f.Created {
+			mailbox += " (new)"
+		}
+		fmt.Printf("  %-30s %-30s %8d %8d %8d %8d\n", common.Truncate(f.Folder, 30), common.Truncate(mailbox, 30),
+			f.Messages, f.Copied, f.Skipped, f.Failed)
+	}
+	fmt.Println()
+
+	summary := fmt.Sprintf("%s %d messages", verb, r.Copied)
+	if r.Skipped > 0 {
+		summary += fmt.Sprintf(", %d already copied", r.Skipped)
+	}
+	common.PrintSuccess("%s", summary)
+	if r.Rebuilt > 0 {
+		common.PrintWarning("%d messages had no raw MIME and were rebuilt without attachments", r.Rebuilt)
+	}
+	if r.Failed > 0 {
+		common.PrintWarning("%d messages failed:", r.Failed)
+		for i, f := range r.Failures {
+			if i == maxListedErrors {
+				fmt.Printf("    ... and %d more (use --json for all)\n", len(r.Failures)-i)
+				break
+			}
+			fmt.Printf("    %s  %s: %s\n", common.Truncate(f.Subject, 40), f.Folder, f.Error)
+		}
+	}
+	if r.Interrupted {
+		common.PrintWarning("Interrupted; re-run the same command to resume")
+	}
+	if !r.DryRun && (r.Interrupted || r.Failed > 0) {
+		fmt.Println(common.Dim.Sprintf("Checkpoint: %s", checkpointPath))
+	}
+
+	if d := r.Verification; d != nil {
+		fmt.Printf("\n%s\n", common.Bold.Sprint("Verification"))
+		fmt.Printf("  source: %d  destination: %d  matched: %d\n", d.MessagesA, d.MessagesB, d.Matched)
+		if len(d.MissingInB) == 0 {
+			common.PrintSuccess("All source messages are in the destination")
+			return
+		}
+		common.PrintWarning("%d source messages not found in the destination", len(d.MissingInB))
+		for i, item := range d.MissingInB {
+			if i == maxListedErrors {
+				fmt.Printf("    ... and %d more\n", len(d.MissingInB)-i)
+				break
+			}
+			fmt.Printf("    %s  %s\n", item.Date.Format("2006-01-02"), common.Truncate(item.Subject, 60))
+		}
+		fmt.Println(common.Dim.Sprintf("The destination may still be syncing; re-check with: nylas email diff --grant-a %s --grant-b %s", r.From, r.To))
+	}
+}
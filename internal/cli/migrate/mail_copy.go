@@ -0,0 +1,380 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/mime"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const (
+	// checkpointEvery is how many copied messages pass between checkpoint saves.
+	checkpointEvery = 25
+
+	// maxConsecutiveFailures stops a run whose destination has gone away
+	// instead of failing every remaining message.
+	maxConsecutiveFailures = 5
+)
+
+// mailClient is the part of the Nylas client used by mail migration.
+type mailClient interface {
+	GetFolders(ctx context.Context, grantID string) ([]domain.Folder, error)
+	GetMessagesWithCursor(ctx context.Context, grantID string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error)
+	GetMessageWithFields(ctx context.Context, grantID, messageID, fields string) (*domain.Message, error)
+}
+
+// sourceFolder is a folder selected for copying, with its full path.
+type sourceFolder struct {
+	folder domain.Folder
+	path   string
+}
+
+// specialUse maps folder roles to RFC 6154 mailbox attributes.
+var specialUse = map[string]string{
+	domain.FolderSent:    `\Sent`,
+	domain.FolderDrafts:  `\Drafts`,
+	domain.FolderTrash:   `\Trash`,
+	domain.FolderSpam:    `\Junk`,
+	domain.FolderArchive: `\Archive`,
+	domain.FolderAll:     `\All`,
+}
+
+// folderRole returns the system role of f (inbox, sent, ...) or "".
+func folderRole(f *domain.Folder) string {
+	if f.SystemFolder != "" {
+		return strings.ToLower(f.SystemFolder)
+	}
+	if strings.EqualFold(f.Name, "INBOX") {
+		return domain.FolderInbox
+	}
+	for role, attr := range specialUse {
+		if slices.ContainsFunc(f.Attributes, func(a string) bool { return strings.EqualFold(a, attr) }) {
+			return role
+		}
+	}
+	return ""
+}
+
+// virtualFolder reports whether f is a view over other folders (Gmail's
+// All Mail, STARRED, CATEGORY_* ...) that would only duplicate messages.
+func virtualFolder(f *domain.Folder) bool {
+	if folderRole(f) == domain.FolderAll {
+		return true
+	}
+	switch strings.ToUpper(f.ID) {
+	case "UNREAD", "STARRED", "IMPORTANT", "CHAT":
+		return true
+	}
+	return strings.HasPrefix(strings.ToUpper(f.ID), "CATEGORY_")
+}
+
+// selectFolders resolves --folders references (ID, path, name or role) or,
+// without any, returns every non-virtual folder.
+func selectFolders(folders []domain.Folder, refs []string, label string) ([]sourceFolder, error) {
+	paths := domain.FolderPaths(folders)
+	var selected []sourceFolder
+	seen := map[string]bool{}
+	add := func(f *domain.Folder) {
+		if !seen[f.ID] {
+			seen[f.ID] = true
+			selected = append(selected, sourceFolder{folder: *f, path: paths[f.ID]})
+		}
+	}
+
+	if len(refs) == 0 {
+		for i := range folders {
+			if !virtualFolder(&folders[i]) {
+				add(&folders[i])
+			}
+		}
+		return selected, nil
+	}
+
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		idx := slices.IndexFunc(folders, func(f domain.Folder) bool {
+			return f.ID == ref || strings.EqualFold(paths[f.ID], ref) || strings.EqualFold(f.Name, ref) || strings.EqualFold(folderRole(&f), ref)
+		})
+		if idx < 0 {
+			return nil, common.NewUserError(fmt.Sprintf("folder %q not found in %s", ref, label),
+				"List folders with: nylas email folders list "+label)
+		}
+		add(&folders[idx])
+	}
+	return selected, nil
+}
+
+// mailboxMap resolves source folders to destination IMAP mailboxes,
+// creating missing ones.
+type mailboxMap struct {
+	writer ports.MailboxWriter
+	boxes  []domain.IMAPMailbox
+	delim  string
+	dryRun bool
+}
+
+func newMailboxMap(ctx context.Context, writer ports.MailboxWriter, dryRun bool) (*mailboxMap, error) {
+	boxes, err := writer.Mailboxes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list destination mailboxes: %w", err)
+	}
+	m := &mailboxMap{writer: writer, boxes: boxes, delim: "/", dryRun: dryRun}
+	for _, b := range boxes {
+		if b.Delimiter != "" {
+			m.delim = b.Delimiter
+			break
+		}
+	}
+	return m, nil
+}
+
+// resolve returns the mailbox for f: INBOX, the special-use mailbox for its
+// role, or its path, created (with parents) when missing.
+func (m *mailboxMap) resolve(ctx context.Context, f *domain.Folder, path string) (string, bool, error) {
+	role := folderRole(f)
+	if role == domain.FolderInbox {
+		return "INBOX", false, nil
+	}
+	if attr, ok := specialUse[role]; ok {
+		for _, b := range m.boxes {
+			if b.HasAttribute(attr) {
+				return b.Name, false, nil
+			}
+		}
+	}
+
+	parts := strings.Split(path, "/")
+	created := false
+	for i := range parts {
+		name := strings.Join(parts[:i+1], m.delim)
+		if existing := m.find(name); existing != "" {
+			if i == len(parts)-1 {
+				return existing, created, nil
+			}
+			continue
+		}
+		if !m.dryRun {
+			if err := m.writer.CreateMailbox(ctx, name); err != nil {
+				return "", created, err
+			}
+		}
+		m.boxes = append(m.boxes, domain.IMAPMailbox{Name: name, Delimiter: m.delim})
+		created = true
+	}
+	return strings.Join(parts, m.delim), created, nil
+}
+
+func (m *mailboxMap) find(name string) string {
+	for _, b := range m.boxes {
+		if strings.EqualFold(b.Name, name) {
+			return b.Name
+		}
+	}
+	return ""
+}
+
+// throttle spaces out operations to at most one per interval.
+type throttle struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newThrottle(perSecond float64) *throttle {
+	t := &throttle{}
+	if perSecond > 0 {
+		t.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	return t
+}
+
+func (t *throttle) wait(ctx context.Context) error {
+	if t.interval <= 0 {
+		return ctx.Err()
+	}
+	if d := time.Until(t.next); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	t.next = time.Now().Add(t.interval)
+	return nil
+}
+
+// mailCopier copies folders from one grant into an IMAP mailbox writer.
+type mailCopier struct {
+	client     mailClient
+	writer     ports.MailboxWriter
+	from       string
+	after      time.Time
+	dryRun     bool
+	throttle   *throttle
+	checkpoint *domain.MailMigrationCheckpoint
+	save       func(*domain.MailMigrationCheckpoint) error
+	progress   func(fm *domain.FolderMigration, total int)
+	report     *domain.MailMigrationReport
+
+	unsaved     int
+	consecutive int
+}
+
+// run copies each folder in turn. The checkpoint is saved on the way out,
+// including when ctx is cancelled.
+func (c *mailCopier) run(ctx context.Context, folders []sourceFolder) (err error) {
+	boxes, err := newMailboxMap(ctx, c.writer, c.dryRun)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if saveErr := c.saveCheckpoint(); err == nil {
+			err = saveErr
+		}
+	}()
+
+	for _, sf := range folders {
+		mailbox, created, err := boxes.resolve(ctx, &sf.folder, sf.path)
+		if err != nil {
+			return fmt.Errorf("failed to prepare mailbox for %s: %w", sf.path, err)
+		}
+		fm := domain.FolderMigration{Folder: sf.path, Mailbox: mailbox, Created: created}
+		err = c.copyFolder(ctx, &sf, &fm)
+		c.addFolder(fm)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mailCopier) copyFolder(ctx context.Context, sf *sourceFolder, fm *domain.FolderMigration) error {
+	done := c.checkpoint.Copied(sf.folder.ID)
+	params := &domain.MessageQueryParams{In: []string{sf.folder.ID}, Limit: common.MaxAPILimit}
+	if !c.after.IsZero() {
+		params.ReceivedAfter = c.after.Unix()
+	}
+
+	for {
+		resp, err := c.client.GetMessagesWithCursor(ctx, c.from, params)
+		if err != nil {
+			return common.WrapListError("messages", err)
+		}
+		for i := range resp.Data {
+			msg := &resp.Data[i]
+			fm.Messages++
+			switch {
+			case done[msg.ID]:
+				fm.Skipped++
+			case c.dryRun:
+				fm.Copied++
+			default:
+				if err := c.copyMessage(ctx, msg, fm); err != nil {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					fm.Failed++
+					c.report.Failures = append(c.report.Failures, domain.MigrationFailure{
+						MessageID: msg.ID, Folder: sf.path, Subject: msg.Subject, Error: err.Error(),
+					})
+					if c.consecutive++; c.consecutive >= maxConsecutiveFailures {
+						return fmt.Errorf("stopped after %d consecutive failures: %w", c.consecutive, err)
+					}
+					continue
+				}
+				c.consecutive = 0
+				fm.Copied++
+				c.checkpoint.MarkCopied(sf.folder.ID, fm.Mailbox, msg.ID)
+				if c.unsaved++; c.unsaved >= checkpointEvery {
+					if err := c.saveCheckpoint(); err != nil {
+						return err
+					}
+				}
+			}
+			if c.progress != nil {
+				c.progress(fm, sf.folder.TotalCount)
+			}
+		}
+		if resp.Pagination.NextCursor == "" {
+			return nil
+		}
+		params.PageToken = resp.Pagination.NextCursor
+	}
+}
+
+func (c *mailCopier) copyMessage(ctx context.Context, msg *domain.Message, fm *domain.FolderMigration) error {
+	if err := c.throttle.wait(ctx); err != nil {
+		return err
+	}
+	raw, rebuilt, err := c.rawMessage(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	var flags []string
+	if !msg.Unread {
+		flags = append(flags, `\Seen`)
+	}
+	if msg.Starred {
+		flags = append(flags, `\Flagged`)
+	}
+	if err := c.writer.Append(ctx, fm.Mailbox, domain.AppendMessage{Raw: raw, Flags: flags, Date: msg.Date}); err != nil {
+		return err
+	}
+	if rebuilt {
+		fm.Rebuilt++
+	}
+	return nil
+}
+
+// rawMessage returns the message's original MIME source or, when the
+// provider doesn't expose it, a rebuilt message without attachments.
+func (c *mailCopier) rawMessage(ctx context.Context, msg *domain.Message) ([]byte, bool, error) {
+	full, err := c.client.GetMessageWithFields(ctx, c.from, msg.ID, "raw_mime")
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch message: %w", err)
+	}
+	if full.RawMIME != "" {
+		return []byte(full.RawMIME), false, nil
+	}
+	raw, err := mime.NewBuilder().BuildMessage(&mime.MessageRequest{
+		From:        msg.From,
+		To:          msg.To,
+		Cc:          msg.Cc,
+		ReplyTo:     msg.ReplyTo,
+		Subject:     msg.Subject,
+		Body:        msg.Body,
+		ContentType: "text/html",
+		MessageID:   domain.MessageIDHeader(msg),
+		Date:        msg.Date,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("no raw MIME and cannot rebuild message: %w", err)
+	}
+	return raw, true, nil
+}
+
+func (c *mailCopier) addFolder(fm domain.FolderMigration) {
+	r := c.report
+	r.Folders = append(r.Folders, fm)
+	r.Copied += fm.Copied
+	r.Skipped += fm.Skipped
+	r.Rebuilt += fm.Rebuilt
+	r.Failed += fm.Failed
+}
+
+func (c *mailCopier) saveCheckpoint() error {
+	if c.dryRun || c.save == nil {
+		return nil
+	}
+	c.unsaved = 0
+	c.checkpoint.UpdatedAt = time.Now()
+	return c.save(c.checkpoint)
+}
@@ -0,0 +1,322 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// fakeMailClient serves folders and messages per grant. Messages appended
+// through fakeWriter show up in the "new" grant, as after a sync.
+type fakeMailClient struct {
+	folders  map[string][]domain.Folder
+	messages map[string][]domain.Message
+}
+
+func (c *fakeMailClient) GetFolders(_ context.Context, grantID string) ([]domain.Folder, error) {
+	return c.folders[grantID], nil
+}
+
+func (c *fakeMailClient) GetMessagesWithCursor(_ context.Context, grantID string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+	var out []domain.Message
+	for _, m := range c.messages[grantID] {
+		if len(params.In) == 0 || (len(m.Folders) > 0 && m.Folders[0] == params.In[0]) {
+			out = append(out, m)
+		}
+	}
+	return &domain.MessageListResponse{Data: out}, nil
+}
+
+func (c *fakeMailClient) GetMessageWithFields(_ context.Context, grantID, messageID, _ string) (*domain.Message, error) {
+	for _, m := range c.messages[grantID] {
+		if m.ID == messageID {
+			if m.Body != "" {
+				m.RawMIME = "" // Body-only messages stand in for providers without raw MIME
+			} else {
+				m.RawMIME = "Message-ID: <" + m.ID + "@example.com>\r\nSubject: " + m.Subject + "\r\n\r\nhi\r\n"
+			}
+			return &m, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+type appended struct {
+	mailbox string
+	msg     domain.AppendMessage
+}
+
+type fakeWriter struct {
+	client   *fakeMailClient
+	boxes    []domain.IMAPMailbox
+	created  []string
+	appended []appended
+	failOn   string // Subject substring that makes Append fail
+}
+
+var _ ports.MailboxWriter = (*fakeWriter)(nil)
+
+func (w *fakeWriter) Mailboxes(context.Context) ([]domain.IMAPMailbox, error) { return w.boxes, nil }
+
+func (w *fakeWriter) CreateMailbox(_ context.Context, name string) error {
+	w.created = append(w.created, name)
+	return nil
+}
+
+func (w *fakeWriter) Append(_ context.Context, mailbox string, msg domain.AppendMessage) error {
+	if w.failOn != "" && strings.Contains(string(msg.Raw), w.failOn) {
+		return errors.New("NO [OVERQUOTA] mailbox full")
+	}
+	w.appended = append(w.appended, appended{mailbox, msg})
+	folderID := map[string]string{"INBOX": "n-inbox", "Sent Items": "n-sent"}[mailbox]
+	id := "copy-" + strings.TrimSuffix(strings.TrimPrefix(strings.SplitN(string(msg.Raw), "\r\n", 2)[0], "Message-ID: <"), "@example.com>")
+	w.client.messages["new"] = append(w.client.messages["new"], migrateMessage(id, folderID, false))
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+func migrateMessage(id, folder string, unread bool) domain.Message {
+	messageID := strings.TrimPrefix(id, "copy-")
+	return domain.Message{
+		ID:      id,
+		Subject: "Subject " + messageID,
+		Date:    time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Unread:  unread,
+		Folders: []string{folder},
+		Headers: []domain.Header{{Name: "Message-ID", Value: "<" + messageID + "@example.com>"}},
+	}
+}
+
+func setupMigration(t *testing.T) (*fakeMailClient, *fakeWriter) {
+	t.Helper()
+	t.Setenv("NYLAS_OAUTH_TOKEN", "tok")
+
+	client := &fakeMailClient{
+		folders: map[string][]domain.Folder{
+			"old": {
+				{ID: "INBOX", Name: "INBOX", SystemFolder: "inbox"},
+				{ID: "SENT", Name: "Sent", SystemFolder: "sent"},
+				{ID: "STARRED", Name: "Starred"},
+				{ID: "proj", Name: "Projects"},
+				{ID: "proj-24", Name: "2024", ParentID: "proj"},
+			},
+			"new": {
+				{ID: "n-inbox", Name: "Inbox", SystemFolder: "inbox"},
+				{ID: "n-sent", Name: "Sent Items", SystemFolder: "sent"},
+			},
+		},
+		messages: map[string][]domain.Message{
+			"old": {
+				migrateMessage("m1", "INBOX", true),
+				migrateMessage("m2", "INBOX", false),
+				migrateMessage("m3", "SENT", false),
+			},
+		},
+	}
+	writer := &fakeWriter{
+		client: client,
+		boxes: []domain.IMAPMailbox{
+			{Name: "INBOX", Delimiter: "/"},
+			{Name: "Sent Items", Delimiter: "/", Attributes: []string{`\Sent`}},
+		},
+	}
+
+	dir := t.TempDir()
+	origDir, origClient, origWriter := checkpointDir, getMailClient, openMailboxWriter
+	checkpointDir = func() (string, error) { return dir, nil }
+	getMailClient = func() (mailClient, error) { return client, nil }
+	openMailboxWriter = func(_ context.Context, opts domain.MailAuthOptions) (ports.MailboxWriter, error) {
+		assert.Equal(t, "tok", opts.Token)
+		return writer, nil
+	}
+	t.Cleanup(func() { checkpointDir, getMailClient, openMailboxWriter = origDir, origClient, origWriter })
+	return client, writer
+}
+
+func runMigrate(t *testing.T, args ...string) (*domain.MailMigrationReport, error) {
+	t.Helper()
+	base := []string{"--from", "old", "--to", "new", "--imap-host", "imap.example.com", "--imap-user", "me@example.com", "--rate", "0", "--json"}
+	stdout, _, err := clitestutil.ExecuteSubCommand(newMailCmd(), append(base, args...)...)
+	var report domain.MailMigrationReport
+	// On error cobra appends the usage after the report.
+	require.NoError(t, json.NewDecoder(strings.NewReader(stdout)).Decode(&report), stdout)
+	return &report, err
+}
+
+func TestMailCmd_CopiesAndVerifies(t *testing.T) {
+	_, writer := setupMigration(t)
+
+	report, err := runMigrate(t, "--folders", "inbox,Sent")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.Copied)
+	require.Len(t, report.Folders, 2)
+	assert.Equal(t, "INBOX", report.Folders[0].Mailbox)
+	assert.Equal(t, "Sent Items", report.Folders[1].Mailbox)
+	assert.Empty(t, writer.created)
+
+	require.Len(t, writer.appended, 3)
+	assert.Empty(t, writer.appended[0].msg.Flags, "unread messages stay unread")
+	assert.Equal(t, []string{`\Seen`}, writer.appended[1].msg.Flags)
+
+	require.NotNil(t, report.Verification)
+	assert.Equal(t, 3, report.Verification.Matched)
+	assert.Empty(t, report.Verification.MissingInB)
+}
+
+func TestMailCmd_ResumesFromCheckpoint(t *testing.T) {
+	_, writer := setupMigration(t)
+
+	_, err := runMigrate(t, "--folders", "INBOX", "--no-verify")
+	require.NoError(t, err)
+	require.Len(t, writer.appended, 2)
+
+	report, err := runMigrate(t, "--folders", "INBOX", "--no-verify")
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Copied)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Len(t, writer.appended, 2)
+
+	report, err = runMigrate(t, "--folders", "INBOX", "--no-verify", "--restart")
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Copied)
+}
+
+func TestMailCmd_RecordsFailures(t *testing.T) {
+	_, writer := setupMigration(t)
+	writer.failOn = "Subject m2"
+
+	report, err := runMigrate(t, "--folders", "INBOX", "--no-verify")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 messages failed")
+	assert.Equal(t, 1, report.Copied)
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, "m2", report.Failures[0].MessageID)
+	assert.Contains(t, report.Failures[0].Error, "OVERQUOTA")
+
+	// The failed message is retried on the next run.
+	writer.failOn = ""
+	report, err = runMigrate(t, "--folders", "INBOX", "--no-verify")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Copied)
+	assert.Equal(t, 1, report.Skipped)
+}
+
+func TestMailCmd_DryRunCreatesFoldersByPath(t *testing.T) {
+	client, writer := setupMigration(t)
+	client.messages["old"] = append(client.messages["old"], migrateMessage("m4", "proj-24", false))
+
+	report, err := runMigrate(t, "--dry-run")
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Nil(t, report.Verification)
+	assert.Empty(t, writer.appended)
+	assert.Empty(t, writer.created)
+
+	var mailboxes []string
+	for _, f := range report.Folders {
+		mailboxes = append(mailboxes, f.Mailbox)
+	}
+	assert.Equal(t, []string{"INBOX", "Sent Items", "Projects", "Projects/2024"}, mailboxes, "STARRED is skipped")
+	assert.True(t, report.Folders[3].Created)
+	assert.Equal(t, 4, report.Copied)
+
+	dir, _ := checkpointDir()
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries, "dry runs don't write a checkpoint")
+}
+
+func TestMailCmd_RebuildsWithoutRawMIME(t *testing.T) {
+	client, writer := setupMigration(t)
+	msg := migrateMessage("m9", "INBOX", false)
+	msg.Body = "<p>hello</p>"
+	msg.To = []domain.EmailParticipant{{Email: "me@example.com"}}
+	client.messages["old"] = []domain.Message{msg}
+
+	report, err := runMigrate(t, "--folders", "INBOX", "--no-verify")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Rebuilt)
+	require.Len(t, writer.appended, 1)
+	assert.Contains(t, string(writer.appended[0].msg.Raw), "Subject: Subject m9")
+}
+
+func TestMailCmd_UnknownFolder(t *testing.T) {
+	setupMigration(t)
+	_, _, err := clitestutil.ExecuteSubCommand(newMailCmd(), "--from", "old", "--to", "new",
+		"--imap-host", "h", "--imap-user", "u", "--folders", "Nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `folder "Nope" not found`)
+}
+
+func TestMailboxMap_Resolve(t *testing.T) {
+	writer := &fakeWriter{boxes: []domain.IMAPMailbox{
+		{Name: "INBOX", Delimiter: "."},
+		{Name: "Junk", Delimiter: ".", Attributes: []string{`\Junk`}},
+		{Name: "Clients", Delimiter: "."},
+	}}
+	m, err := newMailboxMap(context.Background(), writer, false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		folder  domain.Folder
+		path    string
+		want    string
+		created bool
+	}{
+		{domain.Folder{Name: "Inbox", SystemFolder: "inbox"}, "Inbox", "INBOX", false},
+		{domain.Folder{Name: "Spam", Attributes: []string{`\Junk`}}, "Spam", "Junk", false},
+		{domain.Folder{Name: "clients"}, "clients", "Clients", false},
+		{domain.Folder{Name: "Acme"}, "Clients/Acme", "Clients.Acme", true},
+		{domain.Folder{Name: "Trash", SystemFolder: "trash"}, "Trash", "Trash", true},
+	}
+	for _, tt := range tests {
+		got, created, err := m.resolve(context.Background(), &tt.folder, tt.path)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.path)
+		assert.Equal(t, tt.created, created, tt.path)
+	}
+	assert.Equal(t, []string{"Clients.Acme", "Trash"}, writer.created)
+}
+
+func TestResolveDestination(t *testing.T) {
+	t.Setenv("NYLAS_OAUTH_TOKEN", "tok")
+
+	dest, err := resolveDestination(&mailOptions{imapHost: "h", imapUser: "u", mechanism: "plain"}, "g")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SASLPlain, dest.Mechanism)
+	assert.Equal(t, 993, dest.Port)
+	assert.Equal(t, domain.MailTLSImplicit, dest.TLSMode)
+
+	dest, err = resolveDestination(&mailOptions{imapHost: "h", imapUser: "u", mechanism: "xoauth2", tlsMode: "starttls"}, "g")
+	require.NoError(t, err)
+	assert.Equal(t, 143, dest.Port)
+
+	_, err = resolveDestination(&mailOptions{imapHost: "h", imapUser: "u", mechanism: "cram-md5"}, "g")
+	assert.Error(t, err)
+}
+
+func TestThrottle(t *testing.T) {
+	th := newThrottle(100)
+	start := time.Now()
+	for range 3 {
+		require.NoError(t, th.wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, newThrottle(0).wait(ctx), context.Canceled)
+}
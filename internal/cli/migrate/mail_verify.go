@@ -0,0 +1,85 @@
+package migrate
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// verifyMailMigration compares the migrated folders of both accounts by
+// Message-ID. Folder counts are of the messages fetched, so --after windows
+// compare like with like.
+func verifyMailMigration(ctx context.Context, client mailClient, from, to string, folders []sourceFolder, after time.Time) (*domain.MailboxDiff, error) {
+	destFolders, err := client.GetFolders(ctx, to)
+	if err != nil {
+		return nil, common.WrapListError("folders", err)
+	}
+	destPaths := domain.FolderPaths(destFolders)
+
+	a := domain.MailboxSnapshot{GrantID: from}
+	b := domain.MailboxSnapshot{GrantID: to}
+	for _, sf := range folders {
+		msgs, err := fetchFolderMessages(ctx, client, from, sf.folder.ID, after)
+		if err != nil {
+			return nil, err
+		}
+		a.Folders = append(a.Folders, withCount(sf.folder, len(msgs)))
+		a.Messages = append(a.Messages, msgs...)
+
+		dest := matchDestFolder(&sf, destFolders, destPaths)
+		if dest == nil {
+			continue
+		}
+		msgs, err = fetchFolderMessages(ctx, client, to, dest.ID, after)
+		if err != nil {
+			return nil, err
+		}
+		b.Folders = append(b.Folders, withCount(*dest, len(msgs)))
+		b.Messages = append(b.Messages, msgs...)
+	}
+	return domain.CompareMailboxes(a, b), nil
+}
+
+// matchDestFolder finds the destination folder a source folder was copied
+// to: same role, or same path for user folders.
+func matchDestFolder(sf *sourceFolder, dest []domain.Folder, paths map[string]string) *domain.Folder {
+	role := folderRole(&sf.folder)
+	for i := range dest {
+		f := &dest[i]
+		if role != "" && folderRole(f) == role {
+			return f
+		}
+		if role == "" && strings.EqualFold(paths[f.ID], sf.path) {
+			return f
+		}
+	}
+	return nil
+}
+
+func withCount(f domain.Folder, n int) domain.Folder {
+	f.TotalCount = n
+	return f
+}
+
+func fetchFolderMessages(ctx context.Context, client mailClient, grantID, folderID string, after time.Time) ([]domain.Message, error) {
+	params := &domain.MessageQueryParams{In: []string{folderID}, Limit: common.MaxAPILimit, Fields: "include_headers"}
+	if !after.IsZero() {
+		params.ReceivedAfter = after.Unix()
+	}
+	fetcher := func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+		params.PageToken = cursor
+		resp, err := client.GetMessagesWithCursor(ctx, grantID, params)
+		if err != nil {
+			return common.PageResult[domain.Message]{}, err
+		}
+		return common.PageResult[domain.Message]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	}
+	msgs, err := common.FetchCursorPages(ctx, params.Limit, 0, fetcher)
+	if err != nil {
+		return nil, common.WrapListError("messages", err)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,33 @@
+// Package migrate provides commands that copy data between connected
+// accounts.
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// NewMigrateCmd creates the migrate command group.
+func NewMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy mail, calendars and contacts between connected accounts",
+		Long: `Copy data from one connected account to another, e.g. when moving a
+user between providers.
+
+Commands:
+  mail      Copy messages and folders between mailboxes
+  calendar  Copy calendars and events between accounts
+  contacts  Copy or two-way sync contacts between accounts`,
+	}
+
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newMailCmd(), domain.MailMigrationReport{}), domain.ScopeEmailRead))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newCalendarCmd(), domain.CalendarMigrationReport{}),
+		domain.ScopeCalendarRead, domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newContactsCmd(), domain.ContactMigrationReport{}),
+		domain.ScopeContactsRead, domain.ScopeContactsWrite))
+
+	return common.RunLocally(cmd)
+}
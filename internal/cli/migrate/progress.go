@@ -0,0 +1,29 @@
+package migrate
+
+import "github.com/nylas/cli/internal/cli/common"
+
+// stepProgress shows a progress bar per folder or calendar, finishing each
+// one when the next starts so completed steps stay on screen.
+type stepProgress struct {
+	name string
+	bar  *common.ProgressBar
+}
+
+// update records done of total items in the named step. A total of 0 means
+// the size of the step isn't known.
+func (s *stepProgress) update(name string, done, total int) {
+	if s.bar == nil || name != s.name {
+		s.finish()
+		s.name = name
+		s.bar = common.NewProgressBar(common.Truncate(name, 40), total)
+	}
+	s.bar.SetTotal(total)
+	s.bar.Set(done)
+}
+
+// finish ends the current step's bar.
+func (s *stepProgress) finish() {
+	if s.bar != nil {
+		s.bar.Finish()
+	}
+}
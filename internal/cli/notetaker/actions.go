@@ -0,0 +1,237 @@
+package notetaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/ai"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+type actionsOptions struct {
+	provider         string
+	createTasks      bool
+	fromParticipants bool
+	participants     []string
+	calendarID       string
+	send             bool
+	yes              bool
+}
+
+// actionsResult is the structured output of the actions command.
+type actionsResult struct {
+	NotetakerID  string                     `json:"notetaker_id"`
+	MeetingTitle string                     `json:"meeting_title,omitempty"`
+	EventID      string                     `json:"event_id,omitempty"`
+	ActionItems  []domain.MeetingActionItem `json:"action_items"`
+	FollowUps    []followUp                 `json:"follow_ups,omitempty"`
+}
+
+func newActionsCmd() *cobra.Command {
+	var opts actionsOptions
+
+	cmd := &cobra.Command{
+		Use:   "actions <notetaker-id> [grant-id]",
+		Short: "Extract action items from a meeting transcript",
+		Long: `Extract the action items agreed in a recorded meeting, using the
+configured AI provider to read the notetaker's transcript.
+
+With --create-tasks, each owner gets one follow-up email listing their
+tasks. Follow-ups are saved as drafts for review unless --send is given.
+Owners are matched to email addresses from --participants and, with
+--assignees-from-participants, from the attendees of the calendar event
+whose conferencing link matches the notetaker's meeting link. Items whose
+owner can't be matched are listed but not sent anywhere.
+
+Requires AI to be configured: nylas config ai setup`,
+		Example: `  # List the action items from a meeting
+  nylas notetaker actions <notetaker-id>
+
+  # Draft a follow-up email to each owner, found among the event's attendees
+  nylas notetaker actions <notetaker-id> --create-tasks --assignees-from-participants
+
+  # Send the follow-ups without prompting
+  nylas notetaker actions <notetaker-id> --create-tasks --assignees-from-participants --send --yes
+
+  # Name the attendees yourself
+  nylas notetaker actions <notetaker-id> --create-tasks --participants bob@example.com,carol@example.com`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			notetakerID := args[0]
+			if opts.createTasks && !opts.fromParticipants && len(opts.participants) == 0 {
+				return common.NewUserError(
+					"--create-tasks needs a way to address owners",
+					"Pass --assignees-from-participants or --participants <emails>",
+				)
+			}
+			if opts.send && !opts.createTasks {
+				return common.NewUserError("--send requires --create-tasks", "Add --create-tasks to email the owners")
+			}
+
+			cfg, err := common.GetConfigStore(cmd).Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			if cfg.AI == nil || !cfg.AI.IsConfigured() {
+				return common.NewUserError("AI is not configured", "Run 'nylas config ai setup' to configure AI providers")
+			}
+			router := ai.NewRouter(cfg.AI)
+
+			_, err = common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				result, err := extractMeetingActions(ctx, client, router, grantID, notetakerID, opts)
+				if err != nil {
+					return struct{}{}, err
+				}
+
+				structured := common.IsStructuredOutput(cmd)
+				printed := false
+				if opts.createTasks {
+					result.FollowUps = buildFollowUps(result.MeetingTitle, result.ActionItems)
+					if len(result.FollowUps) > 0 && opts.send && !opts.yes && !structured {
+						printActionItems(result)
+						printed = true
+						if !common.Confirm(fmt.Sprintf("Send %d follow-up email(s)?", len(result.FollowUps)), false) {
+							fmt.Println("Cancelled.")
+							return struct{}{}, nil
+						}
+					}
+					if err := deliverFollowUps(ctx, client, grantID, result.FollowUps, opts.send); err != nil {
+						return struct{}{}, err
+					}
+				}
+
+				if structured {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(result)
+				}
+				if !printed {
+					printActionItems(result)
+				}
+				printFollowUps(result, opts)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.provider, "provider", "p", "", "AI provider to use (ollama, claude, openai, groq)")
+	cmd.Flags().BoolVar(&opts.createTasks, "create-tasks", false, "Email each owner a follow-up listing their action items")
+	cmd.Flags().BoolVar(&opts.fromParticipants, "assignees-from-participants", false, "Match owners to the attendees of the meeting's calendar event")
+	cmd.Flags().StringSliceVar(&opts.participants, "participants", nil, "Attendee emails to match owners against (comma-separated)")
+	cmd.Flags().StringVar(&opts.calendarID, "calendar", "primary", "Calendar to search for the meeting's event")
+	cmd.Flags().BoolVar(&opts.send, "send", false, "Send the follow-ups instead of saving drafts")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Send without confirmation")
+
+	return cmd
+}
+
+// extractMeetingActions reads the transcript and returns its action items
+// with owners resolved against the meeting's attendees.
+func extractMeetingActions(ctx context.Context, client ports.NylasClient, router ports.LLMRouter, grantID, notetakerID string, opts actionsOptions) (*actionsResult, error) {
+	nt, err := client.GetNotetaker(ctx, grantID, notetakerID)
+	if err != nil {
+		return nil, common.WrapGetError("notetaker", err)
+	}
+	transcript, err := client.GetNotetakerTranscript(ctx, grantID, notetakerID)
+	if errors.Is(err, domain.ErrTranscriptNotReady) {
+		return nil, common.NewUserError(
+			fmt.Sprintf("notetaker %s has no transcript yet (state: %s)", notetakerID, nt.State),
+			"Transcripts are available once the meeting ends and processing completes",
+		)
+	}
+	if err != nil {
+		return nil, common.WrapGetError("transcript", err)
+	}
+
+	result := &actionsResult{NotetakerID: nt.ID, MeetingTitle: nt.MeetingTitle}
+	var attendees []domain.Person
+	for _, email := range opts.participants {
+		if email = strings.TrimSpace(email); email != "" {
+			attendees = append(attendees, domain.Person{Email: email})
+		}
+	}
+	if opts.fromParticipants {
+		event, err := findMeetingEvent(ctx, client, grantID, opts.calendarID, nt)
+		if err != nil {
+			return nil, common.WrapFetchError("events", err)
+		}
+		if event == nil {
+			common.PrintWarningStderr("no calendar event found for meeting link %s", nt.MeetingLink)
+		} else {
+			result.EventID = event.ID
+			if result.MeetingTitle == "" {
+				result.MeetingTitle = event.Title
+			}
+			attendees = mergeAttendees(attendees, event)
+		}
+	}
+
+	items, err := common.RunWithSpinnerResult("Extracting action items...", func() ([]domain.MeetingActionItem, error) {
+		return ai.ExtractActionItems(ctx, router, &ai.MeetingActionsRequest{
+			Title:        result.MeetingTitle,
+			Transcript:   transcript,
+			Participants: attendees,
+			ProviderName: opts.provider,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AI analysis failed: %w", err)
+	}
+	result.ActionItems = resolveOwners(items, attendees)
+	return result, nil
+}
+
+func printActionItems(result *actionsResult) {
+	if len(result.ActionItems) == 0 {
+		common.PrintEmptyState("action items")
+		return
+	}
+	title := result.MeetingTitle
+	if title == "" {
+		title = result.NotetakerID
+	}
+	_, _ = common.BoldCyan.Printf("Action items from %s\n\n", title)
+
+	table := common.NewTable("TASK", "OWNER", "DUE")
+	for _, item := range result.ActionItems {
+		owner := item.Owner
+		if item.OwnerEmail != "" {
+			owner = item.OwnerEmail
+		}
+		if owner == "" {
+			owner = "-"
+		}
+		due := item.Due
+		if due == "" {
+			due = "-"
+		}
+		table.AddRow(item.Task, owner, due)
+	}
+	table.Render()
+}
+
+func printFollowUps(result *actionsResult, opts actionsOptions) {
+	if !opts.createTasks {
+		return
+	}
+	fmt.Println()
+	for _, f := range result.FollowUps {
+		switch {
+		case f.MessageID != "":
+			common.PrintSuccess("Sent %d item(s) to %s", len(f.Items), f.To.Email)
+		case f.DraftID != "":
+			common.PrintSuccess("Drafted %d item(s) for %s (draft %s)", len(f.Items), f.To.Email, f.DraftID)
+		}
+	}
+	if n := countUnassigned(result.ActionItems); n > 0 {
+		common.PrintWarningStderr("%d item(s) have no matched owner and were not sent", n)
+	}
+	if len(result.FollowUps) > 0 && !opts.send {
+		fmt.Println(common.Dim.Sprintf("Review the drafts with 'nylas email drafts list', or rerun with --send."))
+	}
+}
@@ -0,0 +1,270 @@
+package notetaker
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// meetingSearchWindow is how far either side of the join time the
+// meeting's calendar event is looked for.
+const meetingSearchWindow = 12 * time.Hour
+
+// followUp is one owner's follow-up email.
+type followUp struct {
+	To        domain.Person              `json:"to"`
+	Items     []domain.MeetingActionItem `json:"items"`
+	DraftID   string                     `json:"draft_id,omitempty"`
+	MessageID string                     `json:"message_id,omitempty"`
+	subject   string
+	body      string
+}
+
+// findMeetingEvent returns the calendar event whose conferencing link,
+// location, or description holds the notetaker's meeting link, or nil.
+func findMeetingEvent(ctx context.Context, client ports.NylasClient, grantID, calendarID string, nt *domain.Notetaker) (*domain.Event, error) {
+	link := normalizeMeetingLink(nt.MeetingLink)
+	if link == "" {
+		return nil, nil
+	}
+	at := nt.JoinTime
+	if at.IsZero() {
+		at = nt.CreatedAt
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	events, err := client.GetEvents(ctx, grantID, calendarID, &domain.EventQueryParams{
+		Start: at.Add(-meetingSearchWindow).Unix(),
+		End:   at.Add(meetingSearchWindow).Unix(),
+		Limit: common.MaxAPILimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		if eventHasLink(&events[i], link) {
+			return &events[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func eventHasLink(e *domain.Event, link string) bool {
+	if e.Conferencing != nil && e.Conferencing.Details != nil && normalizeMeetingLink(e.Conferencing.Details.URL) == link {
+		return true
+	}
+	for _, text := range []string{e.Location, e.Description} {
+		if strings.Contains(strings.ToLower(text), link) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMeetingLink reduces a meeting URL to its lowercased host and path,
+// so links differing only in scheme, query (e.g. a Zoom pwd), or a trailing
+// slash compare equal.
+func normalizeMeetingLink(link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	return host + strings.TrimRight(strings.ToLower(u.Path), "/")
+}
+
+// mergeAttendees adds the event's organizer and participants to attendees,
+// skipping emails already present.
+func mergeAttendees(attendees []domain.Person, e *domain.Event) []domain.Person {
+	seen := make(map[string]bool, len(attendees))
+	for _, a := range attendees {
+		seen[strings.ToLower(a.Email)] = true
+	}
+	people := make([]domain.Person, 0, len(e.Participants)+1)
+	if e.Organizer != nil {
+		people = append(people, e.Organizer.Person)
+	}
+	for _, p := range e.Participants {
+		people = append(people, p.Person)
+	}
+	for _, p := range people {
+		key := strings.ToLower(p.Email)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		attendees = append(attendees, p)
+	}
+	return attendees
+}
+
+// resolveOwners sets each item's owner email from the attendees. An email
+// the model gave is kept only if it belongs to an attendee; otherwise the
+// owner's name is matched against attendee names, first names, and email
+// local parts. Ambiguous names stay unassigned.
+func resolveOwners(items []domain.MeetingActionItem, attendees []domain.Person) []domain.MeetingActionItem {
+	for i := range items {
+		item := &items[i]
+		email := item.OwnerEmail
+		item.OwnerEmail = ""
+		if p, ok := findAttendeeByEmail(attendees, email); ok {
+			item.OwnerEmail = p.Email
+		} else if p, ok := findAttendeeByName(attendees, item.Owner); ok {
+			item.OwnerEmail = p.Email
+		}
+		if item.Owner == "" && item.OwnerEmail != "" {
+			if p, _ := findAttendeeByEmail(attendees, item.OwnerEmail); p.Name != "" {
+				item.Owner = p.Name
+			}
+		}
+	}
+	return items
+}
+
+func findAttendeeByEmail(attendees []domain.Person, email string) (domain.Person, bool) {
+	if email == "" {
+		return domain.Person{}, false
+	}
+	for _, p := range attendees {
+		if strings.EqualFold(p.Email, email) {
+			return p, true
+		}
+	}
+	return domain.Person{}, false
+}
+
+func findAttendeeByName(attendees []domain.Person, name string) (domain.Person, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return domain.Person{}, false
+	}
+	matchers := []func(domain.Person) bool{
+		func(p domain.Person) bool { return strings.ToLower(p.Name) == name },
+		func(p domain.Person) bool {
+			first, _, _ := strings.Cut(strings.ToLower(p.Name), " ")
+			return first == name
+		},
+		func(p domain.Person) bool {
+			local, _, _ := strings.Cut(strings.ToLower(p.Email), "@")
+			first, _, _ := strings.Cut(name, " ")
+			return local == name || local == first || strings.HasPrefix(local, first+".")
+		},
+	}
+	for _, match := range matchers {
+		var found []domain.Person
+		for _, p := range attendees {
+			if match(p) {
+				found = append(found, p)
+			}
+		}
+		if len(found) == 1 {
+			return found[0], true
+		}
+		if len(found) > 1 {
+			return domain.Person{}, false
+		}
+	}
+	return domain.Person{}, false
+}
+
+func countUnassigned(items []domain.MeetingActionItem) int {
+	n := 0
+	for _, item := range items {
+		if item.OwnerEmail == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// buildFollowUps groups the assigned items by owner, in order of first
+// appearance.
+func buildFollowUps(title string, items []domain.MeetingActionItem) []followUp {
+	var followUps []followUp
+	index := make(map[string]int)
+	for _, item := range items {
+		if item.OwnerEmail == "" {
+			continue
+		}
+		key := strings.ToLower(item.OwnerEmail)
+		i, ok := index[key]
+		if !ok {
+			i = len(followUps)
+			index[key] = i
+			followUps = append(followUps, followUp{To: domain.Person{Name: item.Owner, Email: item.OwnerEmail}})
+		}
+		followUps[i].Items = append(followUps[i].Items, item)
+	}
+	for i := range followUps {
+		followUps[i].subject, followUps[i].body = followUpMessage(title, &followUps[i])
+	}
+	return followUps
+}
+
+func followUpMessage(title string, f *followUp) (subject, body string) {
+	subject = "Action items"
+	if title != "" {
+		subject += ": " + title
+	}
+
+	var sb strings.Builder
+	greeting := "Hi"
+	if f.To.Name != "" {
+		first, _, _ := strings.Cut(f.To.Name, " ")
+		greeting += " " + first
+	}
+	fmt.Fprintf(&sb, "<p>%s,</p>\n", html.EscapeString(greeting))
+	if title != "" {
+		fmt.Fprintf(&sb, "<p>Here are your action items from &quot;%s&quot;:</p>\n", html.EscapeString(title))
+	} else {
+		sb.WriteString("<p>Here are your action items from our meeting:</p>\n")
+	}
+	sb.WriteString("<ul>\n")
+	for _, item := range f.Items {
+		fmt.Fprintf(&sb, "<li>%s", html.EscapeString(item.Task))
+		if item.Due != "" {
+			fmt.Fprintf(&sb, " (due %s)", html.EscapeString(item.Due))
+		}
+		sb.WriteString("</li>\n")
+	}
+	sb.WriteString("</ul>\n")
+	return subject, sb.String()
+}
+
+// deliverFollowUps saves each follow-up as a draft, or sends it.
+func deliverFollowUps(ctx context.Context, client ports.NylasClient, grantID string, followUps []followUp, send bool) error {
+	for i := range followUps {
+		f := &followUps[i]
+		to := []domain.EmailParticipant{f.To}
+		if send {
+			msg, err := client.SendMessage(ctx, grantID, &domain.SendMessageRequest{Subject: f.subject, Body: f.body, To: to})
+			if err != nil {
+				return common.WrapSendError("follow-up to "+f.To.Email, err)
+			}
+			f.MessageID = msg.ID
+			continue
+		}
+		draft, err := client.CreateDraft(ctx, grantID, &domain.CreateDraftRequest{Subject: f.subject, Body: f.body, To: to})
+		if err != nil {
+			return common.WrapCreateError("follow-up draft for "+f.To.Email, err)
+		}
+		f.DraftID = draft.ID
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package notetaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// fakeRouter answers every chat with a canned reply.
+type fakeRouter struct {
+	reply string
+	req   *domain.ChatRequest
+}
+
+func (r *fakeRouter) GetProvider(string) (ports.LLMProvider, error) { return nil, nil }
+func (r *fakeRouter) ListProviders() []string                       { return nil }
+
+func (r *fakeRouter) Chat(ctx context.Context, req *domain.ChatRequest) (*domain.ChatResponse, error) {
+	return r.ChatWithProvider(ctx, "", req)
+}
+
+func (r *fakeRouter) ChatWithProvider(_ context.Context, _ string, req *domain.ChatRequest) (*domain.ChatResponse, error) {
+	r.req = req
+	return &domain.ChatResponse{Content: r.reply}, nil
+}
+
+var meetingAttendees = []domain.Person{
+	{Name: "Alice Johnson", Email: "alice@example.com"},
+	{Name: "Bob Smith", Email: "bob.smith@example.com"},
+	{Email: "carol@example.com"},
+	{Name: "Dan Lee", Email: "dan@example.com"},
+	{Name: "Dan Park", Email: "dpark@example.com"},
+}
+
+func TestResolveOwners(t *testing.T) {
+	items := resolveOwners([]domain.MeetingActionItem{
+		{Task: "full name", Owner: "Alice Johnson"},
+		{Task: "first name", Owner: "bob"},
+		{Task: "email local part", Owner: "Carol"},
+		{Task: "ambiguous first name", Owner: "Dan"},
+		{Task: "model email kept", OwnerEmail: "ALICE@example.com"},
+		{Task: "unknown email dropped", Owner: "Eve", OwnerEmail: "eve@example.com"},
+		{Task: "nobody"},
+	}, meetingAttendees)
+
+	got := make(map[string]string)
+	for _, item := range items {
+		got[item.Task] = item.OwnerEmail
+	}
+	assert.Equal(t, map[string]string{
+		"full name":             "alice@example.com",
+		"first name":            "bob.smith@example.com",
+		"email local part":      "carol@example.com",
+		"ambiguous first name":  "",
+		"model email kept":      "alice@example.com",
+		"unknown email dropped": "",
+		"nobody":                "",
+	}, got)
+	assert.Equal(t, "Alice Johnson", items[4].Owner, "the owner name is filled in from the attendee")
+	assert.Equal(t, 3, countUnassigned(items))
+}
+
+func TestNormalizeMeetingLink(t *testing.T) {
+	assert.Equal(t, "zoom.us/j/123", normalizeMeetingLink("https://zoom.us/j/123?pwd=abc"))
+	assert.Equal(t, "zoom.us/j/123", normalizeMeetingLink("http://www.Zoom.us/j/123/"))
+	assert.Equal(t, "meet.google.com/abc-defg-hij", normalizeMeetingLink("meet.google.com/abc-defg-hij"))
+	assert.Empty(t, normalizeMeetingLink(""))
+}
+
+func TestFindMeetingEvent(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetEventsFunc = func(_ context.Context, _, calendarID string, params *domain.EventQueryParams) ([]domain.Event, error) {
+		assert.Equal(t, "work", calendarID)
+		assert.Equal(t, int64(1_800_000_000-12*3600), params.Start)
+		return []domain.Event{
+			{ID: "evt-other", Location: "Room 4"},
+			{ID: "evt-zoom", Conferencing: &domain.Conferencing{Details: &domain.ConferencingDetails{URL: "https://zoom.us/j/123?pwd=x"}}},
+		}, nil
+	}
+	nt := &domain.Notetaker{MeetingLink: "https://zoom.us/j/123", JoinTime: time.Unix(1_800_000_000, 0)}
+
+	event, err := findMeetingEvent(context.Background(), client, "grant-1", "work", nt)
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "evt-zoom", event.ID)
+
+	nt.MeetingLink = "https://zoom.us/j/999"
+	event, err = findMeetingEvent(context.Background(), client, "grant-1", "work", nt)
+	require.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func TestBuildAndDeliverFollowUps(t *testing.T) {
+	items := []domain.MeetingActionItem{
+		{Task: "Send the proposal", Owner: "Bob Smith", OwnerEmail: "bob@example.com", Due: "Friday"},
+		{Task: "Book <room>", Owner: "Carol", OwnerEmail: "carol@example.com"},
+		{Task: "Update docs", Owner: "Bob", OwnerEmail: "BOB@example.com"},
+		{Task: "Unowned"},
+	}
+	followUps := buildFollowUps("Q4 & planning", items)
+	require.Len(t, followUps, 2)
+	assert.Equal(t, "bob@example.com", followUps[0].To.Email)
+	assert.Len(t, followUps[0].Items, 2)
+	assert.Equal(t, "Action items: Q4 & planning", followUps[0].subject)
+	assert.Contains(t, followUps[0].body, "<p>Hi Bob,</p>")
+	assert.Contains(t, followUps[0].body, "&quot;Q4 &amp; planning&quot;")
+	assert.Contains(t, followUps[0].body, "<li>Send the proposal (due Friday)</li>")
+	assert.Contains(t, followUps[1].body, "Book &lt;room&gt;")
+
+	client := nylas.NewMockClient()
+	var drafted []string
+	client.CreateDraftFunc = func(_ context.Context, _ string, req *domain.CreateDraftRequest) (*domain.Draft, error) {
+		drafted = append(drafted, req.To[0].Email)
+		return &domain.Draft{ID: "draft-" + req.To[0].Email}, nil
+	}
+	require.NoError(t, deliverFollowUps(context.Background(), client, "grant-1", followUps, false))
+	assert.Equal(t, []string{"bob@example.com", "carol@example.com"}, drafted)
+	assert.Equal(t, "draft-bob@example.com", followUps[0].DraftID)
+	assert.False(t, client.SendMessageCalled)
+
+	require.NoError(t, deliverFollowUps(context.Background(), client, "grant-1", followUps, true))
+	assert.True(t, client.SendMessageCalled)
+	assert.Equal(t, "sent-message-id", followUps[1].MessageID)
+}
+
+func TestExtractMeetingActions(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetEventsFunc = func(context.Context, string, string, *domain.EventQueryParams) ([]domain.Event, error) {
+		return []domain.Event{{
+			ID:          "evt-1",
+			Description: "Join: https://zoom.us/j/123456789",
+			Organizer:   &domain.Participant{Person: domain.Person{Name: "Alice Johnson", Email: "alice@example.com"}},
+			Participants: []domain.Participant{
+				{Person: domain.Person{Name: "Alice Johnson", Email: "alice@example.com"}},
+				{Person: domain.Person{Name: "Bob Smith", Email: "bob@example.com"}},
+			},
+		}}, nil
+	}
+	router := &fakeRouter{reply: `{"action_items":[{"task":"Send the proposal","owner":"Bob","due":"Friday"}]}`}
+
+	result, err := extractMeetingActions(context.Background(), client, router, "grant-1", "nt-1",
+		actionsOptions{fromParticipants: true, calendarID: "primary", participants: []string{"carol@example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, "evt-1", result.EventID)
+	assert.Equal(t, "Test Meeting", result.MeetingTitle)
+	assert.Equal(t, []domain.MeetingActionItem{
+		{Task: "Send the proposal", Owner: "Bob", OwnerEmail: "bob@example.com", Due: "Friday"},
+	}, result.ActionItems)
+
+	prompt := router.req.Messages[1].Content
+	assert.Contains(t, prompt, "- carol@example.com")
+	assert.Contains(t, prompt, "- Bob Smith <bob@example.com>")
+	assert.Contains(t, prompt, "Bob: I'll send the proposal by Friday.")
+}
+
+func TestExtractMeetingActions_TranscriptNotReady(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetNotetakerTranscriptFunc = func(context.Context, string, string) (*domain.NotetakerTranscript, error) {
+		return nil, domain.ErrTranscriptNotReady
+	}
+
+	_, err := extractMeetingActions(context.Background(), client, &fakeRouter{}, "grant-1", "nt-1", actionsOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no transcript yet")
+}
+
+func TestActionsCommand_Validation(t *testing.T) {
+	tests := map[string][]string{
+		"create-tasks without assignees": {"nt-1", "--create-tasks"},
+		"send without create-tasks":      {"nt-1", "--send"},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := testutil.ExecuteCommand(newActionsCmd(), args...)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNotetakerCmd_RegistersActions(t *testing.T) {
+	cmd := NewNotetakerCmd()
+	names := make(map[string]bool)
+	for _, sub := range cmd.Commands() {
+		names[sub.Name()] = true
+	}
+	assert.True(t, names["actions"], "notetaker command must register the actions subcommand")
+}
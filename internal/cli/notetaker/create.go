@@ -79,8 +79,8 @@ record the meeting, and generate a transcript when complete.`,
 					return struct{}{}, common.WrapCreateError("notetaker", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(notetaker)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(notetaker)
 				}
 
 				_, _ = common.BoldGreen.Println("✓ Notetaker created successfully!")
@@ -47,8 +47,8 @@ func newListCmd() *cobra.Command {
 					return struct{}{}, common.WrapListError("notetakers", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(notetakers)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(notetakers)
 				}
 
 				if len(notetakers) == 0 {
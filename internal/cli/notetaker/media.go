@@ -36,8 +36,8 @@ Note: Media URLs have an expiration time. Download them promptly.`,
 					return struct{}{}, common.WrapGetError("notetaker media", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(media)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(media)
 				}
 
 				if media.Recording == nil && media.Transcript == nil {
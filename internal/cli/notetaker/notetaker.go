@@ -1,6 +1,8 @@
 package notetaker
 
 import (
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -32,17 +34,32 @@ API reference: https://developer.nylas.com/docs/v3/notetaker/`,
   # Get recording/transcript
   nylas notetaker media <notetaker-id>
 
+  # Read the transcript, or save it as subtitles
+  nylas notetaker transcript <notetaker-id>
+  nylas notetaker transcript <notetaker-id> --format srt > meeting.srt
+
+  # Auto-join matching meetings
+  nylas notetaker rules add customers --keyword customer --organizer @acme.com
+  nylas notetaker sync --dry-run
+
   # Delete/cancel a notetaker
   nylas notetaker delete <notetaker-id>`,
 	}
 
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newShowCmd())
-	cmd.AddCommand(newCreateCmd())
+	common.RequireScopes(cmd, domain.ScopeApplicationAPI)
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.Notetaker{}))
+	cmd.AddCommand(common.DeclareOutput(newShowCmd(), domain.Notetaker{}))
+	cmd.AddCommand(common.DeclareOutput(newCreateCmd(), domain.Notetaker{}))
 	cmd.AddCommand(newDeleteCmd())
 	cmd.AddCommand(newLeaveCmd())
-	cmd.AddCommand(newUpdateCmd())
-	cmd.AddCommand(newMediaCmd())
+	cmd.AddCommand(common.DeclareOutput(newUpdateCmd(), domain.Notetaker{}))
+	cmd.AddCommand(common.DeclareOutput(newMediaCmd(), domain.MediaData{}))
+	cmd.AddCommand(common.RequireCapabilities(common.RequireScopes(common.DeclareOutput(newActionsCmd(), actionsResult{}),
+		domain.ScopeCalendarRead, domain.ScopeEmailModify, domain.ScopeEmailSend), domain.CapabilityAI))
+	cmd.AddCommand(common.DeclareOutput(newTranscriptCmd(), domain.NotetakerTranscript{}))
+	cmd.AddCommand(common.DeclareOutput(newRulesCmd(), []domain.NotetakerRule{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newSyncCmd(), []syncAction{}), domain.ScopeCalendarRead))
 
 	return cmd
 }
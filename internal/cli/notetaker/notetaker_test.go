@@ -44,7 +44,7 @@ func TestNewNotetakerCmd(t *testing.T) {
 	})
 
 	t.Run("has_required_subcommands", func(t *testing.T) {
-		expectedCmds := []string{"list", "show", "create", "delete", "media"}
+		expectedCmds := []string{"list", "show", "create", "delete", "media", "actions", "transcript", "rules", "sync"}
 
 		cmdMap := make(map[string]bool)
 		for _, sub := range cmd.Commands() {
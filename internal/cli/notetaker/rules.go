@@ -0,0 +1,229 @@
+package notetaker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func newRulesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "List auto-join rules",
+		Long: `List the rules 'nylas notetaker sync' uses to decide which meetings get a
+notetaker. Rules are kept in config under notetaker.rules.
+
+A meeting gets a notetaker when it matches at least one rule and no
+--exclude rule. Within a rule, every criterion that is set must match.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			rules, err := loadNotetakerRules()
+			if err != nil {
+				return err
+			}
+			list := sortedRules(rules)
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).WriteList(list, nil)
+			}
+			if len(list) == 0 {
+				common.PrintEmptyStateWithHint("auto-join rules", "Add one with 'nylas notetaker rules add <name> --keyword <word>'")
+				return nil
+			}
+			table := common.NewTable("NAME", "TYPE", "CRITERIA", "BOT NAME")
+			for _, r := range list {
+				kind := "join"
+				if r.Exclude {
+					kind = "exclude"
+				}
+				botName := r.BotName
+				if botName == "" {
+					botName = "-"
+				}
+				table.AddRow(r.Name, kind, describeRule(&r), botName)
+			}
+			table.Render()
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newRulesAddCmd())
+	cmd.AddCommand(newRulesDeleteCmd())
+
+	return cmd
+}
+
+func newRulesAddCmd() *cobra.Command {
+	var rule domain.NotetakerRule
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add or replace an auto-join rule",
+		Long: `Add an auto-join rule. Adding a rule under an existing name replaces it.
+
+Keywords match the event title or description, case-insensitively.
+Organizers are email addresses, or "@domain" for a whole domain.
+--working-hours limits the rule to meetings starting within the working
+hours in config (default 09:00-17:00).`,
+		Example: `  # Join customer calls organized by anyone at acme.com
+  nylas notetaker rules add customers --keyword customer --organizer @acme.com
+
+  # Join everything on the work calendar during working hours
+  nylas notetaker rules add work --calendar work@example.com --working-hours
+
+  # Never join 1:1s
+  nylas notetaker rules add no-1on1 --keyword "1:1" --exclude`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !domain.ValidNotetakerRuleName(name) {
+				return common.NewInputError(fmt.Sprintf("invalid name %q: use letters, digits, '.', '_' and '-'", name))
+			}
+			rule.Keywords = compactValues(rule.Keywords)
+			rule.Organizers = compactValues(rule.Organizers)
+			rule.Calendars = compactValues(rule.Calendars)
+			if rule.Exclude && rule.BotName != "" {
+				return common.NewInputError("--bot-name has no effect on an --exclude rule")
+			}
+
+			replaced := false
+			err := updateNotetakerRules(func(m map[string]*domain.NotetakerRule) {
+				_, replaced = m[name]
+				r := rule
+				m[name] = &r
+			})
+			if err != nil {
+				return err
+			}
+			if replaced {
+				common.PrintSuccess("Updated auto-join rule %q", name)
+			} else {
+				common.PrintSuccess("Added auto-join rule %q", name)
+			}
+			fmt.Println(common.Dim.Sprintf("Apply it with: nylas notetaker sync --dry-run"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&rule.Keywords, "keyword", nil, "Keyword in the event title or description (repeatable)")
+	cmd.Flags().StringSliceVar(&rule.Organizers, "organizer", nil, "Organizer email or @domain (repeatable)")
+	cmd.Flags().StringSliceVar(&rule.Calendars, "calendar", nil, "Calendar ID (repeatable)")
+	cmd.Flags().BoolVar(&rule.WorkingHours, "working-hours", false, "Only meetings starting within working hours")
+	cmd.Flags().BoolVar(&rule.Exclude, "exclude", false, "Never join meetings matching this rule")
+	cmd.Flags().StringVar(&rule.BotName, "bot-name", "", "Notetaker display name for meetings this rule joins")
+
+	return cmd
+}
+
+func newRulesDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete an auto-join rule",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			found := false
+			err := updateNotetakerRules(func(m map[string]*domain.NotetakerRule) {
+				_, found = m[args[0]]
+				delete(m, args[0])
+			})
+			if err != nil {
+				return err
+			}
+			if !found {
+				return common.NewUserError(fmt.Sprintf("auto-join rule %q not found", args[0]),
+					"List rules with: nylas notetaker rules")
+			}
+			common.PrintSuccess("Deleted auto-join rule %q", args[0])
+			return nil
+		},
+	}
+}
+
+// describeRule summarizes a rule's criteria.
+func describeRule(r *domain.NotetakerRule) string {
+	var parts []string
+	for _, f := range []struct {
+		label  string
+		values []string
+	}{
+		{"keyword", r.Keywords}, {"organizer", r.Organizers}, {"calendar", r.Calendars},
+	} {
+		if len(f.values) > 0 {
+			parts = append(parts, f.label+":"+strings.Join(f.values, ","))
+		}
+	}
+	if r.WorkingHours {
+		parts = append(parts, "working-hours")
+	}
+	if len(parts) == 0 {
+		return "all meetings"
+	}
+	return strings.Join(parts, " ")
+}
+
+// sortedRules returns the rules ordered by name, with Name set.
+func sortedRules(rules map[string]*domain.NotetakerRule) []domain.NotetakerRule {
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	list := make([]domain.NotetakerRule, len(names))
+	for i, name := range names {
+		list[i] = *rules[name]
+		list[i].Name = name
+	}
+	return list
+}
+
+func compactValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// loadNotetakerRules returns notetaker.rules.
+func loadNotetakerRules() (map[string]*domain.NotetakerRule, error) {
+	cfg, err := configAdapter.NewDefaultFileStore().Load()
+	if err != nil {
+		return nil, common.WrapLoadError("config", err)
+	}
+	if cfg.Notetaker == nil {
+		return nil, nil
+	}
+	return cfg.Notetaker.Rules, nil
+}
+
+// updateNotetakerRules applies fn to the rules and saves them.
+func updateNotetakerRules(fn func(map[string]*domain.NotetakerRule)) error {
+	store := configAdapter.NewDefaultFileStore()
+	cfg, err := store.Load()
+	if err != nil {
+		return common.WrapLoadError("config", err)
+	}
+	if cfg.Notetaker == nil {
+		cfg.Notetaker = &domain.NotetakerConfig{}
+	}
+	if cfg.Notetaker.Rules == nil {
+		cfg.Notetaker.Rules = map[string]*domain.NotetakerRule{}
+	}
+	fn(cfg.Notetaker.Rules)
+	if len(cfg.Notetaker.Rules) == 0 {
+		cfg.Notetaker = nil
+	}
+	if err := store.Save(cfg); err != nil {
+		return common.WrapSaveError("config", err)
+	}
+	return nil
+}
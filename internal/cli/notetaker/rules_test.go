@@ -0,0 +1,71 @@
+package notetaker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/cli/common"
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// runRules runs the rules command on a fresh command tree, so flag values
+// don't carry over between calls.
+func runRules(args ...string) (string, error) {
+	root := &cobra.Command{Use: "test", SilenceErrors: true, SilenceUsage: true}
+	common.AddOutputFlags(root)
+	root.AddCommand(newRulesCmd())
+	stdout, _, err := clitestutil.ExecuteCommand(root, append([]string{"rules"}, args...)...)
+	return stdout, err
+}
+
+func TestRulesCmd_AddListDelete(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	_, err := runRules("add", "customers",
+		"--keyword", "customer", "--keyword", " customer ", "--organizer", "@acme.com", "--bot-name", "Recorder")
+	require.NoError(t, err)
+	_, err = runRules("add", "no-1on1", "--keyword", "1:1", "--exclude")
+	require.NoError(t, err)
+
+	stdout, err := runRules("--json")
+	require.NoError(t, err)
+	var rules []domain.NotetakerRule
+	require.NoError(t, json.Unmarshal([]byte(stdout), &rules))
+	require.Len(t, rules, 2)
+	assert.Equal(t, "customers", rules[0].Name)
+	assert.Equal(t, []string{"customer"}, rules[0].Keywords, "values are trimmed and deduplicated")
+	assert.Equal(t, "Recorder", rules[0].BotName)
+	assert.Equal(t, "no-1on1", rules[1].Name)
+	assert.True(t, rules[1].Exclude)
+
+	_, err = runRules("delete", "customers")
+	require.NoError(t, err)
+	loaded, err := loadNotetakerRules()
+	require.NoError(t, err)
+	assert.NotContains(t, loaded, "customers")
+	assert.Contains(t, loaded, "no-1on1")
+
+	_, err = runRules("rm", "customers")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestRulesAddCmd_Validation(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	_, err := runRules("add", "has space")
+	assert.ErrorContains(t, err, "invalid name")
+
+	_, err = runRules("add", "skip", "--exclude", "--bot-name", "Bot")
+	assert.ErrorContains(t, err, "--bot-name")
+}
+
+func TestDescribeRule(t *testing.T) {
+	assert.Equal(t, "all meetings", describeRule(&domain.NotetakerRule{}))
+	assert.Equal(t, "keyword:a,b organizer:@acme.com working-hours",
+		describeRule(&domain.NotetakerRule{Keywords: []string{"a", "b"}, Organizers: []string{"@acme.com"}, WorkingHours: true}))
+}
@@ -29,8 +29,8 @@ func newShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("notetaker", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(notetaker)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(notetaker)
 				}
 
 				_, _ = common.Cyan.Printf("Notetaker: %s\n", notetaker.ID)
@@ -0,0 +1,409 @@
+package notetaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/autonotetaker"
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// openAutoNotetakerStore opens the record of notetakers sync created.
+// Replaced in tests.
+var openAutoNotetakerStore = func() (ports.AutoNotetakerStore, error) {
+	return autonotetaker.New(filepath.Join(config.DefaultConfigDir(), "notetaker-sync.json")), nil
+}
+
+// scheduledMatchWindow is how far apart a notetaker's join time and a
+// meeting's start can be for the notetaker to count as already scheduled.
+const scheduledMatchWindow = 5 * time.Minute
+
+// meetingURLPattern finds meeting links the Notetaker can join in free text.
+var meetingURLPattern = regexp.MustCompile(`(?i)https?://(?:[a-z0-9-]+\.)*(?:zoom\.us|meet\.google\.com|teams\.microsoft\.com|teams\.live\.com)/[^\s<>"')\]]+`)
+
+// Sync actions.
+const (
+	syncCreate = "create"
+	syncUpdate = "update"
+	syncDelete = "delete"
+	syncKeep   = "keep"
+)
+
+// syncAction is one planned change to the grant's notetakers.
+type syncAction struct {
+	Action      string    `json:"action"`
+	EventID     string    `json:"event_id,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	Start       time.Time `json:"start"`
+	Rule        string    `json:"rule,omitempty"`
+	NotetakerID string    `json:"notetaker_id,omitempty"`
+	MeetingLink string    `json:"meeting_link,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	Error       string    `json:"error,omitempty"`
+
+	calendarID string
+	botName    string
+}
+
+// syncPlanInput is everything planNotetakerSync decides from.
+type syncPlanInput struct {
+	events       []domain.Event
+	rules        []domain.NotetakerRule
+	workingHours *domain.WorkingHoursConfig
+	loc          *time.Location
+	tracked      []domain.AutoNotetaker
+	scheduled    []domain.Notetaker
+	now, until   time.Time
+}
+
+func newSyncCmd() *cobra.Command {
+	var (
+		days      int
+		calendars []string
+		dryRun    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync [grant-id]",
+		Short: "Create and remove notetakers for upcoming meetings using the auto-join rules",
+		Long: `Scan upcoming calendar events and bring the grant's notetakers in line with
+the auto-join rules ('nylas notetaker rules').
+
+  - Meetings that match a rule and have a Zoom, Google Meet or Teams link
+    get a notetaker joining at the start time.
+  - Notetakers created by an earlier sync follow their meeting when it moves
+    and are deleted when it is cancelled or stops matching.
+  - Notetakers created any other way are never changed, and meetings that
+    already have one are skipped.
+
+Run it from cron or a scheduler to keep notetakers current.`,
+		Example: `  # Preview the changes for the next week
+  nylas notetaker sync --dry-run
+
+  # Sync two weeks ahead on specific calendars
+  nylas notetaker sync --days 14 --calendar primary --calendar team@example.com`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if days < 1 || days > 31 {
+				return common.NewInputError("--days must be between 1 and 31")
+			}
+			rules, err := loadNotetakerRules()
+			if err != nil {
+				return err
+			}
+			if len(rules) == 0 {
+				return common.NewUserError("no auto-join rules defined",
+					"Add one with: nylas notetaker rules add <name> --keyword <word>")
+			}
+			cfg, err := common.GetConfigStore(cmd).Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			store, err := openAutoNotetakerStore()
+			if err != nil {
+				return err
+			}
+
+			sorted := sortedRules(rules)
+			if len(calendars) == 0 {
+				calendars = ruleCalendars(sorted)
+			}
+
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				now := time.Now()
+				in := syncPlanInput{
+					rules:        sorted,
+					workingHours: cfg.WorkingHours,
+					loc:          time.Local,
+					now:          now,
+					until:        now.AddDate(0, 0, days),
+				}
+				for _, calendarID := range calendars {
+					events, err := client.GetEvents(ctx, grantID, calendarID, &domain.EventQueryParams{
+						Start:           in.now.Unix(),
+						End:             in.until.Unix(),
+						ExpandRecurring: true,
+						Limit:           common.MaxAPILimit,
+					})
+					if err != nil {
+						return struct{}{}, common.WrapFetchError("events", err)
+					}
+					for i := range events {
+						if events[i].CalendarID == "" {
+							events[i].CalendarID = calendarID
+						}
+					}
+					in.events = append(in.events, events...)
+				}
+				in.scheduled, err = client.ListNotetakers(ctx, grantID, &domain.NotetakerQueryParams{
+					State: domain.NotetakerStateScheduled,
+					Limit: common.MaxAPILimit,
+				})
+				if err != nil {
+					return struct{}{}, common.WrapFetchError("notetakers", err)
+				}
+				if in.tracked, err = store.List(grantID); err != nil {
+					return struct{}{}, common.WrapLoadError("notetaker sync state", err)
+				}
+
+				actions, stale := planNotetakerSync(in)
+				if !dryRun {
+					applyNotetakerSync(ctx, client, store, grantID, actions, stale)
+				}
+				return struct{}{}, printSyncActions(cmd, actions, dryRun)
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 7, "How many days ahead to scan (1-31)")
+	cmd.Flags().StringSliceVar(&calendars, "calendar", nil, "Calendar ID to scan (repeatable; default: calendars named in rules, else primary)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes without making them")
+
+	return cmd
+}
+
+// ruleCalendars returns the calendars named by rules, or primary.
+func ruleCalendars(rules []domain.NotetakerRule) []string {
+	var calendars []string
+	for _, r := range rules {
+		for _, c := range r.Calendars {
+			if !slices.Contains(calendars, c) {
+				calendars = append(calendars, c)
+			}
+		}
+	}
+	if len(calendars) == 0 || slices.ContainsFunc(rules, func(r domain.NotetakerRule) bool { return !r.Exclude && len(r.Calendars) == 0 }) {
+		if !slices.Contains(calendars, "primary") {
+			calendars = append([]string{"primary"}, calendars...)
+		}
+	}
+	return calendars
+}
+
+// planNotetakerSync decides what to create, move and delete. It also returns
+// the IDs of tracked notetakers whose meetings have started, which sync no
+// longer manages.
+func planNotetakerSync(in syncPlanInput) (actions []syncAction, stale []string) {
+	wanted := map[string]*syncAction{}
+	var order []string
+	for i := range in.events {
+		e := &in.events[i]
+		start := e.When.StartDateTime()
+		if e.Status == "cancelled" || e.When.IsAllDay() || !start.After(in.now) || start.After(in.until) {
+			continue
+		}
+		if _, seen := wanted[e.ID]; seen {
+			continue
+		}
+		link := meetingLink(e)
+		if link == "" {
+			continue
+		}
+		rule := matchingRule(in.rules, e, in.workingHours, in.loc)
+		if rule == nil {
+			continue
+		}
+		wanted[e.ID] = &syncAction{
+			Action: syncCreate, EventID: e.ID, Title: e.Title, Start: start, Rule: rule.Name,
+			MeetingLink: link, calendarID: e.CalendarID, botName: rule.BotName,
+		}
+		order = append(order, e.ID)
+	}
+
+	handled := map[string]bool{}
+	for _, t := range in.tracked {
+		if !t.JoinTime.After(in.now) {
+			stale = append(stale, t.NotetakerID)
+			continue
+		}
+		w, ok := wanted[t.EventID]
+		if !ok || handled[t.EventID] {
+			if t.JoinTime.After(in.until) {
+				continue // outside this scan; leave it for a later sync
+			}
+			actions = append(actions, syncAction{
+				Action: syncDelete, EventID: t.EventID, Start: t.JoinTime, Rule: t.Rule,
+				NotetakerID: t.NotetakerID, MeetingLink: t.MeetingLink,
+				Reason: "meeting cancelled, moved out of range, or no longer matches",
+			})
+			continue
+		}
+		handled[t.EventID] = true
+		a := *w
+		a.NotetakerID = t.NotetakerID
+		switch {
+		case normalizeMeetingLink(t.MeetingLink) != normalizeMeetingLink(w.MeetingLink):
+			actions = append(actions, syncAction{
+				Action: syncDelete, EventID: t.EventID, Title: w.Title, Start: t.JoinTime, Rule: t.Rule,
+				NotetakerID: t.NotetakerID, MeetingLink: t.MeetingLink, Reason: "meeting link changed",
+			})
+			a.NotetakerID = ""
+		case !t.JoinTime.Equal(w.Start):
+			a.Action, a.Reason = syncUpdate, "meeting moved"
+		default:
+			a.Action = syncKeep
+		}
+		actions = append(actions, a)
+	}
+
+	for _, id := range order {
+		if handled[id] {
+			continue
+		}
+		a := *wanted[id]
+		if nt := scheduledFor(in.scheduled, a.MeetingLink, a.Start); nt != nil {
+			a.Action, a.NotetakerID, a.Reason = syncKeep, nt.ID, "already has a notetaker"
+		}
+		actions = append(actions, a)
+	}
+
+	slices.SortStableFunc(actions, func(a, b syncAction) int { return a.Start.Compare(b.Start) })
+	return actions, stale
+}
+
+// matchingRule returns the first rule (by name) that sends a notetaker to e,
+// or nil when none does or an exclude rule matches.
+func matchingRule(rules []domain.NotetakerRule, e *domain.Event, wh *domain.WorkingHoursConfig, loc *time.Location) *domain.NotetakerRule {
+	var match *domain.NotetakerRule
+	for i := range rules {
+		if !rules[i].Matches(e, wh, loc) {
+			continue
+		}
+		if rules[i].Exclude {
+			return nil
+		}
+		if match == nil {
+			match = &rules[i]
+		}
+	}
+	return match
+}
+
+// meetingLink returns the event's conferencing URL, or the first Zoom, Meet
+// or Teams link in its location or description.
+func meetingLink(e *domain.Event) string {
+	if e.Conferencing != nil && e.Conferencing.Details != nil && e.Conferencing.Details.URL != "" {
+		return e.Conferencing.Details.URL
+	}
+	for _, text := range []string{e.Location, e.Description} {
+		if link := meetingURLPattern.FindString(text); link != "" {
+			return strings.TrimRight(link, ".,;:!?")
+		}
+	}
+	return ""
+}
+
+// scheduledFor returns a scheduled notetaker joining link around start.
+func scheduledFor(notetakers []domain.Notetaker, link string, start time.Time) *domain.Notetaker {
+	link = normalizeMeetingLink(link)
+	for i := range notetakers {
+		nt := &notetakers[i]
+		diff := nt.JoinTime.Sub(start)
+		if normalizeMeetingLink(nt.MeetingLink) == link && diff <= scheduledMatchWindow && diff >= -scheduledMatchWindow {
+			return nt
+		}
+	}
+	return nil
+}
+
+// applyNotetakerSync carries out the actions, recording each failure on its
+// action so one bad meeting doesn't stop the rest.
+func applyNotetakerSync(ctx context.Context, client ports.NylasClient, store ports.AutoNotetakerStore, grantID string, actions []syncAction, stale []string) {
+	record := func(a *syncAction) domain.AutoNotetaker {
+		return domain.AutoNotetaker{
+			NotetakerID: a.NotetakerID, GrantID: grantID, EventID: a.EventID, CalendarID: a.calendarID,
+			Rule: a.Rule, MeetingLink: a.MeetingLink, JoinTime: a.Start,
+		}
+	}
+
+	for i := range actions {
+		a := &actions[i]
+		var err error
+		switch a.Action {
+		case syncCreate:
+			req := &domain.CreateNotetakerRequest{MeetingLink: a.MeetingLink, JoinTime: a.Start.Unix()}
+			if a.botName != "" {
+				req.BotConfig = &domain.BotConfig{Name: a.botName}
+			}
+			var nt *domain.Notetaker
+			if nt, err = client.CreateNotetaker(ctx, grantID, req); err == nil {
+				a.NotetakerID = nt.ID
+				err = store.Save(record(a))
+			}
+		case syncUpdate:
+			if _, err = client.UpdateNotetaker(ctx, grantID, a.NotetakerID, &domain.UpdateNotetakerRequest{JoinTime: a.Start.Unix()}); err == nil {
+				err = store.Save(record(a))
+			}
+		case syncDelete:
+			err = client.DeleteNotetaker(ctx, grantID, a.NotetakerID)
+			if err == nil || errors.Is(err, domain.ErrNotetakerNotFound) {
+				err = store.Delete(a.NotetakerID)
+			}
+		}
+		if err != nil {
+			a.Error = err.Error()
+		}
+	}
+	if len(stale) > 0 {
+		_ = store.Delete(stale...)
+	}
+}
+
+func printSyncActions(cmd *cobra.Command, actions []syncAction, dryRun bool) error {
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(actions)
+	}
+	if len(actions) == 0 {
+		common.PrintEmptyStateWithHint("matching meetings", "Check the rules with: nylas notetaker rules")
+		return nil
+	}
+
+	counts := map[string]int{}
+	failed := 0
+	table := common.NewTable("ACTION", "START", "MEETING", "RULE", "NOTETAKER")
+	for _, a := range actions {
+		action := a.Action
+		if a.Error != "" {
+			action += " (failed)"
+			failed++
+		} else {
+			counts[a.Action]++
+		}
+		title := a.Title
+		if title == "" {
+			title = a.MeetingLink
+		}
+		table.AddRow(action, a.Start.Local().Format(common.DisplayDateTime), common.Truncate(title, 40), a.Rule, a.NotetakerID)
+	}
+	table.Render()
+
+	for _, a := range actions {
+		if a.Error != "" {
+			common.PrintWarningStderr("%s %s: %s", a.Action, a.Title, a.Error)
+		}
+	}
+	summary := fmt.Sprintf("%d to create, %d to move, %d to delete, %d unchanged",
+		counts[syncCreate], counts[syncUpdate], counts[syncDelete], counts[syncKeep])
+	if dryRun {
+		fmt.Println(common.Dim.Sprintf("Dry run: %s", summary))
+		return nil
+	}
+	if failed > 0 {
+		return common.NewUserError(fmt.Sprintf("%d notetaker change(s) failed", failed), "Re-run 'nylas notetaker sync' to retry")
+	}
+	common.PrintSuccess("Synced notetakers: %d created, %d moved, %d deleted",
+		counts[syncCreate], counts[syncUpdate], counts[syncDelete])
+	return nil
+}
@@ -0,0 +1,115 @@
+package notetaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+func syncEvent(id, title string, start time.Time, link string) domain.Event {
+	return domain.Event{
+		ID:           id,
+		CalendarID:   "primary",
+		Title:        title,
+		When:         domain.EventWhen{StartTime: start.Unix(), EndTime: start.Add(time.Hour).Unix()},
+		Conferencing: &domain.Conferencing{Details: &domain.ConferencingDetails{URL: link}},
+	}
+}
+
+func TestPlanNotetakerSync(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return now.Add(time.Duration(h) * time.Hour) }
+	rules := []domain.NotetakerRule{
+		{Name: "customers", Keywords: []string{"customer"}, BotName: "Recorder"},
+		{Name: "no-internal", Keywords: []string{"internal"}, Exclude: true},
+	}
+
+	events := []domain.Event{
+		syncEvent("new", "Customer kickoff", at(2), "https://zoom.us/j/1"),
+		syncEvent("moved", "Customer review", at(5), "https://zoom.us/j/2"),
+		syncEvent("relinked", "Customer demo", at(6), "https://meet.google.com/new"),
+		syncEvent("same", "Customer sync", at(7), "https://zoom.us/j/4"),
+		syncEvent("manual", "Customer call", at(8), "https://zoom.us/j/5"),
+		syncEvent("excluded", "Customer internal prep", at(3), "https://zoom.us/j/6"),
+		syncEvent("other", "Standup", at(3), "https://zoom.us/j/7"),
+		syncEvent("nolink", "Customer lunch", at(3), ""),
+		syncEvent("started", "Customer early", now.Add(-time.Minute), "https://zoom.us/j/8"),
+	}
+	cancelled := syncEvent("cancelled", "Customer cancelled", at(4), "https://zoom.us/j/9")
+	cancelled.Status = "cancelled"
+	events = append(events, cancelled)
+
+	tracked := []domain.AutoNotetaker{
+		{NotetakerID: "nt-moved", EventID: "moved", MeetingLink: "https://zoom.us/j/2", JoinTime: at(4), Rule: "customers"},
+		{NotetakerID: "nt-relinked", EventID: "relinked", MeetingLink: "https://meet.google.com/old", JoinTime: at(6), Rule: "customers"},
+		{NotetakerID: "nt-same", EventID: "same", MeetingLink: "https://zoom.us/j/4/", JoinTime: at(7), Rule: "customers"},
+		{NotetakerID: "nt-cancelled", EventID: "cancelled", MeetingLink: "https://zoom.us/j/9", JoinTime: at(4), Rule: "customers"},
+		{NotetakerID: "nt-past", EventID: "past", JoinTime: now.Add(-time.Hour)},
+		{NotetakerID: "nt-later", EventID: "later", JoinTime: now.AddDate(0, 0, 30)},
+	}
+	scheduled := []domain.Notetaker{{ID: "nt-manual", MeetingLink: "https://zoom.us/j/5", JoinTime: at(8).Add(2 * time.Minute)}}
+
+	actions, stale := planNotetakerSync(syncPlanInput{
+		events: events, rules: rules, loc: time.UTC,
+		tracked: tracked, scheduled: scheduled,
+		now: now, until: now.AddDate(0, 0, 7),
+	})
+
+	assert.Equal(t, []string{"nt-past"}, stale)
+
+	got := map[string][]string{}
+	for _, a := range actions {
+		got[a.EventID] = append(got[a.EventID], a.Action)
+	}
+	assert.Equal(t, map[string][]string{
+		"new":       {syncCreate},
+		"moved":     {syncUpdate},
+		"relinked":  {syncDelete, syncCreate},
+		"same":      {syncKeep},
+		"manual":    {syncKeep},
+		"cancelled": {syncDelete},
+	}, got)
+
+	for i := 1; i < len(actions); i++ {
+		assert.False(t, actions[i].Start.Before(actions[i-1].Start), "actions are ordered by start")
+	}
+	for _, a := range actions {
+		switch {
+		case a.EventID == "new":
+			assert.Equal(t, "customers", a.Rule)
+			assert.Equal(t, "Recorder", a.botName)
+			assert.Empty(t, a.NotetakerID)
+		case a.EventID == "moved":
+			assert.Equal(t, "nt-moved", a.NotetakerID)
+			assert.True(t, at(5).Equal(a.Start), "update follows the new start")
+		case a.EventID == "manual":
+			assert.Equal(t, "nt-manual", a.NotetakerID)
+		case a.EventID == "relinked" && a.Action == syncCreate:
+			assert.Empty(t, a.NotetakerID)
+			assert.Equal(t, "https://meet.google.com/new", a.MeetingLink)
+		}
+	}
+}
+
+func TestMeetingLink(t *testing.T) {
+	e := &domain.Event{Location: "Room 4", Description: "Join: https://acme.zoom.us/j/123?pwd=x.\nThanks"}
+	assert.Equal(t, "https://acme.zoom.us/j/123?pwd=x", meetingLink(e), "trailing punctuation is dropped")
+
+	e.Conferencing = &domain.Conferencing{Details: &domain.ConferencingDetails{URL: "https://meet.google.com/abc"}}
+	assert.Equal(t, "https://meet.google.com/abc", meetingLink(e), "conferencing details win")
+
+	assert.Empty(t, meetingLink(&domain.Event{Description: "https://example.com/meeting"}))
+}
+
+func TestRuleCalendars(t *testing.T) {
+	require.Equal(t, []string{"primary"}, ruleCalendars([]domain.NotetakerRule{{Keywords: []string{"x"}}}))
+	assert.Equal(t, []string{"work"}, ruleCalendars([]domain.NotetakerRule{{Calendars: []string{"work"}}}))
+	assert.Equal(t, []string{"primary", "work"}, ruleCalendars([]domain.NotetakerRule{
+		{Calendars: []string{"work"}},
+		{Keywords: []string{"x"}},
+	}))
+}
@@ -0,0 +1,202 @@
+package notetaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// untimedCueLength is how long a subtitle cue lasts when the transcript
+// gives no end time and no later segment bounds it.
+const untimedCueLength = 3000
+
+func newTranscriptCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "transcript <notetaker-id> [grant-id]",
+		Short: "Download and render a notetaker transcript",
+		Long: `Download a completed notetaker's transcript and render it.
+
+Formats:
+  text  Speaker-labelled lines with timestamps (default)
+  srt   SubRip subtitles
+  vtt   WebVTT subtitles with voice tags
+  json  The parsed transcript segments`,
+		Example: `  # Read the transcript
+  nylas notetaker transcript abc123
+
+  # Save subtitles next to the recording
+  nylas notetaker transcript abc123 --format srt > meeting.srt
+  nylas notetaker transcript abc123 --format vtt > meeting.vtt`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			notetakerID := args[0]
+			format = strings.ToLower(strings.TrimSpace(format))
+			if common.IsJSON(cmd) {
+				format = "json"
+			}
+			render, ok := transcriptRenderers[format]
+			if !ok && format != "json" {
+				return common.NewUserError(
+					fmt.Sprintf("unsupported transcript format %q", format),
+					"Use --format text, srt, vtt or json",
+				)
+			}
+
+			transcript, err := common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (*domain.NotetakerTranscript, error) {
+				transcript, err := client.GetNotetakerTranscript(ctx, grantID, notetakerID)
+				if errors.Is(err, domain.ErrTranscriptNotReady) {
+					return nil, common.NewUserError(
+						fmt.Sprintf("notetaker %s has no transcript yet", notetakerID),
+						"Transcripts are available once the meeting ends and processing completes",
+					)
+				}
+				if err != nil {
+					return nil, common.WrapGetError("transcript", err)
+				}
+				return transcript, nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				return common.PrintJSON(transcript)
+			}
+			return render(cmd.OutOrStdout(), transcript.Segments)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text, srt, vtt, json")
+
+	return cmd
+}
+
+var transcriptRenderers = map[string]func(io.Writer, []domain.TranscriptSegment) error{
+	"text": renderTranscriptText,
+	"srt":  renderTranscriptSRT,
+	"vtt":  renderTranscriptVTT,
+}
+
+// renderTranscriptText writes one "[hh:mm:ss] Speaker: text" line per
+// segment. Untimed segments, as in raw transcripts, are written as-is.
+func renderTranscriptText(w io.Writer, segments []domain.TranscriptSegment) error {
+	timed := hasTimings(segments)
+	for _, s := range segments {
+		text := strings.TrimSpace(s.Text)
+		if text == "" {
+			continue
+		}
+		var prefix string
+		if timed {
+			prefix = "[" + clockTime(s.Start, 0) + "] "
+		}
+		if s.Speaker != "" {
+			prefix += s.Speaker + ": "
+		}
+		if _, err := fmt.Fprintln(w, prefix+text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderTranscriptSRT(w io.Writer, segments []domain.TranscriptSegment) error {
+	cues, err := subtitleCues(segments)
+	if err != nil {
+		return err
+	}
+	for i, c := range cues {
+		text := c.Text
+		if c.Speaker != "" {
+			text = c.Speaker + ": " + text
+		}
+		if _, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n", i+1, clockTime(c.Start, ','), clockTime(c.End, ','), text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderTranscriptVTT(w io.Writer, segments []domain.TranscriptSegment) error {
+	cues, err := subtitleCues(segments)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(w, "WEBVTT\n\n"); err != nil {
+		return err
+	}
+	for _, c := range cues {
+		text := vttEscaper.Replace(c.Text)
+		if c.Speaker != "" {
+			text = "<v " + vttEscaper.Replace(c.Speaker) + ">" + text
+		}
+		if _, err := fmt.Fprintf(w, "%s --> %s\n%s\n\n", clockTime(c.Start, '.'), clockTime(c.End, '.'), text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// subtitleCues returns the non-empty segments with usable end times. A
+// segment without one ends where the next begins.
+func subtitleCues(segments []domain.TranscriptSegment) ([]domain.TranscriptSegment, error) {
+	if !hasTimings(segments) {
+		return nil, common.NewUserError(
+			"transcript has no timings, so it can't be rendered as subtitles",
+			"Use --format text or --format json",
+		)
+	}
+
+	var cues []domain.TranscriptSegment
+	for _, s := range segments {
+		s.Text = strings.Join(strings.Fields(s.Text), " ")
+		if s.Text != "" {
+			cues = append(cues, s)
+		}
+	}
+	for i := range cues {
+		if cues[i].End > cues[i].Start {
+			continue
+		}
+		cues[i].End = cues[i].Start + untimedCueLength
+		if i+1 < len(cues) && cues[i+1].Start > cues[i].Start {
+			cues[i].End = cues[i+1].Start
+		}
+	}
+	return cues, nil
+}
+
+func hasTimings(segments []domain.TranscriptSegment) bool {
+	for _, s := range segments {
+		if s.Start > 0 || s.End > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// clockTime formats ms as hh:mm:ss, adding milliseconds after sep when sep
+// is non-zero (',' for SRT, '.' for WebVTT).
+func clockTime(ms int64, sep rune) string {
+	if ms < 0 {
+		ms = 0
+	}
+	secs := ms / 1000
+	clock := fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+	if sep == 0 {
+		return clock
+	}
+	return fmt.Sprintf("%s%c%03d", clock, sep, ms%1000)
+}
@@ -0,0 +1,68 @@
+package notetaker
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+var testSegments = []domain.TranscriptSegment{
+	{Speaker: "Alice", Start: 1500, End: 4200, Text: "Let's review the <draft>."},
+	{Speaker: "Bob", Start: 3_725_000, Text: "  I'll send the\nproposal by Friday. "},
+	{Speaker: "Alice", Start: 3_730_250, Text: ""},
+	{Speaker: "Alice", Start: 3_731_000, End: 3_733_000, Text: "Thanks & bye."},
+}
+
+func TestRenderTranscriptText(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderTranscriptText(&buf, testSegments))
+	assert.Equal(t, "[00:00:01] Alice: Let's review the <draft>.\n"+
+		"[01:02:05] Bob: I'll send the\nproposal by Friday.\n"+
+		"[01:02:11] Alice: Thanks & bye.\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, renderTranscriptText(&buf, []domain.TranscriptSegment{{Text: "raw transcript"}}))
+	assert.Equal(t, "raw transcript\n", buf.String())
+}
+
+func TestRenderTranscriptSRT(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderTranscriptSRT(&buf, testSegments))
+	assert.Equal(t, "1\n00:00:01,500 --> 00:00:04,200\nAlice: Let's review the <draft>.\n\n"+
+		"2\n01:02:05,000 --> 01:02:11,000\nBob: I'll send the proposal by Friday.\n\n"+
+		"3\n01:02:11,000 --> 01:02:13,000\nAlice: Thanks & bye.\n\n", buf.String())
+}
+
+func TestRenderTranscriptVTT(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderTranscriptVTT(&buf, testSegments[3:]))
+	assert.Equal(t, "WEBVTT\n\n01:02:11.000 --> 01:02:13.000\n<v Alice>Thanks &amp; bye.\n\n", buf.String())
+
+	buf.Reset()
+	last := []domain.TranscriptSegment{{Speaker: "Bob", Start: 2000, Text: "<b>done</b>"}}
+	require.NoError(t, renderTranscriptVTT(&buf, last))
+	assert.Contains(t, buf.String(), "00:00:02.000 --> 00:00:05.000\n<v Bob>&lt;b&gt;done&lt;/b&gt;\n")
+}
+
+func TestSubtitlesRequireTimings(t *testing.T) {
+	raw := []domain.TranscriptSegment{{Text: "no timings here"}}
+	assert.Error(t, renderTranscriptSRT(io.Discard, raw))
+	assert.Error(t, renderTranscriptVTT(io.Discard, raw))
+}
+
+func TestTranscriptCmd(t *testing.T) {
+	cmd := newTranscriptCmd()
+	assert.Equal(t, "text", cmd.Flags().Lookup("format").DefValue)
+
+	cmd.SetArgs([]string{"nt-1", "--format", "docx"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported transcript format")
+}
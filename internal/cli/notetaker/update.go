@@ -83,8 +83,8 @@ API reference: https://developer.nylas.com/docs/v3/notetaker/`,
 					return struct{}{}, common.WrapUpdateError("notetaker", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(notetaker)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(notetaker)
 				}
 
 				common.PrintSuccess("Notetaker updated")
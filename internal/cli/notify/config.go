@@ -0,0 +1,275 @@
+package notify
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	configAdapter "github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// openSecretStore opens the store holding the Slack webhook URL. Replaced
+// in tests.
+var openSecretStore = func() (ports.SecretStore, error) {
+	return keyring.NewSecretStore(configAdapter.DefaultConfigDir())
+}
+
+type configOptions struct {
+	routes             []string
+	slackWebhook       string
+	removeSlackWebhook bool
+	emailTo            string
+	addVIPs            []string
+	removeVIPs         []string
+	meetingLead        string
+	disable            bool
+}
+
+// routeView is one category's routing in `notify config` output.
+type routeView struct {
+	Category    string   `json:"category"`
+	Description string   `json:"description"`
+	Channels    []string `json:"channels"`
+}
+
+// configView is the `notify config` output.
+type configView struct {
+	Enabled      bool        `json:"enabled"`
+	Routes       []routeView `json:"routes"`
+	SlackWebhook bool        `json:"slack_webhook_set"`
+	EmailTo      string      `json:"email_to,omitempty"`
+	VIPs         []string    `json:"vips,omitempty"`
+	MeetingLead  string      `json:"meeting_lead"`
+}
+
+func newConfigCmd() *cobra.Command {
+	var opts configOptions
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show or change notification settings",
+		Long: `Show notification settings, or change them with flags.
+
+--route sets the channels for a category as category=channel[,channel]:
+channels are desktop, slack and email, or "off" to stop the category.
+Categories without a route go to the desktop.
+
+The Slack incoming webhook URL is kept in the secret store. The email
+channel sends from the grant the watching command runs as, to --email-to.
+
+VIPs are email addresses, or "@domain" for everyone at a domain.`,
+		Example: `  # Show the current settings
+  nylas notify config
+
+  # Send VIP email to the desktop and Slack, and webhook failures by email
+  nylas notify config --route vip_email=desktop,slack --slack-webhook https://hooks.slack.com/services/... \
+    --route webhook_failure=email --email-to oncall@example.com
+
+  # Add VIPs and get meeting reminders 5 minutes ahead
+  nylas notify config --add-vip ceo@example.com --add-vip @bigcustomer.com --meeting-lead 5m
+
+  # Stop booking notifications, or turn notifications off entirely
+  nylas notify config --route booking_created=off
+  nylas notify config --disable`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store := common.GetConfigStore(cmd)
+			cfg, err := store.Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			secrets, err := openSecretStore()
+			if err != nil {
+				return common.WrapError(err)
+			}
+
+			// Output flags like --json are inherited and don't count.
+			changed := false
+			cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) { changed = changed || f.Changed })
+			if changed {
+				if err := applyConfigOptions(cfg, secrets, opts); err != nil {
+					return err
+				}
+				if err := store.Save(cfg); err != nil {
+					return common.WrapSaveError("config", err)
+				}
+			}
+
+			slackSet := false
+			if v, err := secrets.Get(ports.KeyNotifySlackWebhook); err == nil && v != "" {
+				slackSet = true
+			}
+			view := newConfigView(cfg.Notify, slackSet)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(view)
+			}
+			if changed {
+				common.PrintSuccess("Notification settings updated")
+				fmt.Println()
+			}
+			printConfigView(view)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.routes, "route", nil, "Route a category: category=desktop,slack,email or category=off (repeatable)")
+	cmd.Flags().StringVar(&opts.slackWebhook, "slack-webhook", "", "Slack incoming webhook URL for the slack channel")
+	cmd.Flags().BoolVar(&opts.removeSlackWebhook, "remove-slack-webhook", false, "Remove the stored Slack webhook URL")
+	cmd.Flags().StringVar(&opts.emailTo, "email-to", "", "Recipient for the email channel")
+	cmd.Flags().StringSliceVar(&opts.addVIPs, "add-vip", nil, "Add a VIP sender: email or @domain (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.removeVIPs, "remove-vip", nil, "Remove a VIP sender (repeatable)")
+	cmd.Flags().StringVar(&opts.meetingLead, "meeting-lead", "", "How long before a meeting to notify (e.g. 10m)")
+	cmd.Flags().BoolVar(&opts.disable, "disable", false, "Turn notifications off and clear their settings")
+	cmd.MarkFlagsMutuallyExclusive("slack-webhook", "remove-slack-webhook")
+
+	return cmd
+}
+
+// applyConfigOptions updates cfg.Notify and the Slack webhook secret.
+func applyConfigOptions(cfg *domain.Config, secrets ports.SecretStore, opts configOptions) error {
+	if opts.disable {
+		cfg.Notify = nil
+		if err := secrets.Delete(ports.KeyNotifySlackWebhook); err != nil {
+			return common.WrapError(err)
+		}
+		return nil
+	}
+
+	n := cfg.Notify
+	if n == nil {
+		n = &domain.NotifyConfig{}
+	}
+	for _, spec := range opts.routes {
+		category, channels, err := parseRoute(spec)
+		if err != nil {
+			return err
+		}
+		if n.Routes == nil {
+			n.Routes = map[string][]string{}
+		}
+		n.Routes[category] = channels
+	}
+	if opts.slackWebhook != "" {
+		u, err := url.Parse(opts.slackWebhook)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return common.NewInputError("--slack-webhook must be an https:// URL")
+		}
+		if err := secrets.Set(ports.KeyNotifySlackWebhook, opts.slackWebhook); err != nil {
+			return common.WrapError(err)
+		}
+	}
+	if opts.removeSlackWebhook {
+		if err := secrets.Delete(ports.KeyNotifySlackWebhook); err != nil {
+			return common.WrapError(err)
+		}
+	}
+	if opts.emailTo != "" {
+		if !strings.Contains(opts.emailTo, "@") {
+			return common.NewInputError(fmt.Sprintf("invalid --email-to %q", opts.emailTo))
+		}
+		n.EmailTo = opts.emailTo
+	}
+	for _, v := range opts.addVIPs {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" && !slices.Contains(n.VIPs, v) {
+			n.VIPs = append(n.VIPs, v)
+		}
+	}
+	for _, v := range opts.removeVIPs {
+		v = strings.ToLower(strings.TrimSpace(v))
+		n.VIPs = slices.DeleteFunc(n.VIPs, func(x string) bool { return x == v })
+	}
+	if opts.meetingLead != "" {
+		d, err := time.ParseDuration(opts.meetingLead)
+		if err != nil || d <= 0 || d > 24*time.Hour {
+			return common.NewInputError("--meeting-lead must be a duration between 1s and 24h, e.g. 10m")
+		}
+		n.MeetingLead = opts.meetingLead
+	}
+	cfg.Notify = n
+	return nil
+}
+
+// parseRoute parses a --route value.
+func parseRoute(spec string) (string, []string, error) {
+	category, list, ok := strings.Cut(spec, "=")
+	category = strings.TrimSpace(category)
+	if !ok || !domain.IsNotifyCategory(category) {
+		return "", nil, common.NewUserError(fmt.Sprintf("invalid --route %q", spec),
+			"Use category=channel[,channel] with a category of vip_email, meeting_soon, booking_created or webhook_failure")
+	}
+	if strings.TrimSpace(list) == "off" {
+		return category, []string{}, nil
+	}
+	var channels []string
+	for _, ch := range strings.Split(list, ",") {
+		ch = strings.TrimSpace(ch)
+		if !slices.Contains(domain.NotifyChannels, ch) {
+			return "", nil, common.NewUserError(fmt.Sprintf("unknown channel %q in --route", ch),
+				"Channels are desktop, slack and email, or use off")
+		}
+		if !slices.Contains(channels, ch) {
+			channels = append(channels, ch)
+		}
+	}
+	return category, channels, nil
+}
+
+func newConfigView(n *domain.NotifyConfig, slackSet bool) configView {
+	view := configView{Enabled: n != nil, SlackWebhook: slackSet, MeetingLead: n.MeetingLeadTime().String()}
+	if n != nil {
+		view.EmailTo = n.EmailTo
+		view.VIPs = n.VIPs
+	}
+	for _, c := range domain.NotifyCategories {
+		channels := n.ChannelsFor(c.Name)
+		if channels == nil {
+			channels = []string{}
+		}
+		view.Routes = append(view.Routes, routeView{Category: c.Name, Description: c.Description, Channels: channels})
+	}
+	return view
+}
+
+func printConfigView(view configView) {
+	if !view.Enabled {
+		fmt.Println("Notifications are off.")
+		fmt.Println(common.Dim.Sprintf("Turn them on with: nylas notify config --route vip_email=desktop"))
+		return
+	}
+	table := common.NewTable("CATEGORY", "CHANNELS", "DESCRIPTION")
+	for _, r := range view.Routes {
+		channels := strings.Join(r.Channels, ", ")
+		if channels == "" {
+			channels = "off"
+		}
+		table.AddRow(r.Category, channels, r.Description)
+	}
+	table.Render()
+
+	fmt.Println()
+	slack := "not set"
+	if view.SlackWebhook {
+		slack = "set"
+	}
+	emailTo := view.EmailTo
+	if emailTo == "" {
+		emailTo = "not set"
+	}
+	vips := strings.Join(view.VIPs, ", ")
+	if vips == "" {
+		vips = "none"
+	}
+	fmt.Printf("Slack webhook: %s\n", slack)
+	fmt.Printf("Email to:      %s\n", emailTo)
+	fmt.Printf("VIPs:          %s\n", vips)
+	fmt.Printf("Meeting lead:  %s\n", view.MeetingLead)
+}
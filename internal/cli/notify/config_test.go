@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/keyring"
+	"github.com/nylas/cli/internal/cli/common"
+	clitestutil "github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+func withTempConfig(t *testing.T) *keyring.MockSecretStore {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	secrets := keyring.NewMockSecretStore()
+	orig := openSecretStore
+	openSecretStore = func() (ports.SecretStore, error) { return secrets, nil }
+	t.Cleanup(func() { openSecretStore = orig })
+	return secrets
+}
+
+func runNotify(args ...string) (string, error) {
+	root := &cobra.Command{Use: "test", SilenceErrors: true, SilenceUsage: true}
+	common.AddOutputFlags(root)
+	root.AddCommand(NewNotifyCmd())
+	stdout, _, err := clitestutil.ExecuteCommand(root, append([]string{"notify"}, args...)...)
+	return stdout, err
+}
+
+func TestConfigCmd_SetAndShow(t *testing.T) {
+	secrets := withTempConfig(t)
+
+	stdout, err := runNotify("config", "--json")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, `"enabled": false`, "output flags don't count as a change")
+
+	_, err = runNotify("config",
+		"--route", "vip_email=desktop,slack,desktop",
+		"--route", "booking_created=off",
+		"--slack-webhook", "https://hooks.slack.com/services/T/B/X",
+		"--email-to", "me@example.com",
+		"--add-vip", "CEO@example.com", "--add-vip", "@acme.com",
+		"--meeting-lead", "5m")
+	require.NoError(t, err)
+
+	url, err := secrets.Get(ports.KeyNotifySlackWebhook)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/X", url)
+
+	_, err = runNotify("config", "--remove-vip", "@acme.com")
+	require.NoError(t, err)
+
+	stdout, err = runNotify("config", "--json")
+	require.NoError(t, err)
+	var view configView
+	require.NoError(t, json.Unmarshal([]byte(stdout), &view))
+	assert.True(t, view.Enabled)
+	assert.True(t, view.SlackWebhook)
+	assert.Equal(t, "me@example.com", view.EmailTo)
+	assert.Equal(t, []string{"ceo@example.com"}, view.VIPs)
+	assert.Equal(t, "5m0s", view.MeetingLead)
+	routes := map[string][]string{}
+	for _, r := range view.Routes {
+		routes[r.Category] = r.Channels
+	}
+	assert.Equal(t, []string{"desktop", "slack"}, routes[domain.NotifyVIPEmail])
+	assert.Empty(t, routes[domain.NotifyBookingCreated])
+	assert.Equal(t, []string{"desktop"}, routes[domain.NotifyMeetingSoon])
+
+	_, err = runNotify("config", "--disable")
+	require.NoError(t, err)
+	stdout, err = runNotify("config")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Notifications are off")
+	_, err = secrets.Get(ports.KeyNotifySlackWebhook)
+	assert.Error(t, err, "disabling removes the Slack webhook")
+}
+
+func TestConfigCmd_Validation(t *testing.T) {
+	withTempConfig(t)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--route", "vip_email"}, "invalid --route"},
+		{[]string{"--route", "lunch=desktop"}, "invalid --route"},
+		{[]string{"--route", "vip_email=pager"}, `unknown channel "pager"`},
+		{[]string{"--slack-webhook", "http://hooks.slack.com/x"}, "https://"},
+		{[]string{"--email-to", "nobody"}, "invalid --email-to"},
+		{[]string{"--meeting-lead", "48h"}, "--meeting-lead"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			_, err := runNotify(append([]string{"config"}, tt.args...)...)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestTestCmd_RequiresConfig(t *testing.T) {
+	withTempConfig(t)
+
+	_, err := runNotify("test", "vip_email")
+	assert.ErrorContains(t, err, "not configured")
+
+	_, err = runNotify("config", "--route", "vip_email=off")
+	require.NoError(t, err)
+	_, err = runNotify("test", "vip_email")
+	assert.ErrorContains(t, err, "vip_email notifications are off")
+
+	_, err = runNotify("test", "lunch")
+	assert.ErrorContains(t, err, "unknown category")
+}
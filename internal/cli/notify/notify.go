@@ -0,0 +1,43 @@
+// Package notify provides the notification settings commands.
+package notify
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// NewNotifyCmd creates the notify command.
+func NewNotifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Configure notifications from watch and daemon commands",
+		Long: `Configure where notifications go, per category.
+
+Categories:
+  vip_email         New email from a VIP sender
+  meeting_soon      Meeting starting soon (default 10 minutes ahead)
+  booking_created   Scheduler booking created
+  webhook_failure   Webhook failing or a delivery failed
+
+Each category is routed to any of the desktop, a Slack incoming webhook, or
+email. 'nylas notify watch' sends vip_email, meeting_soon and
+webhook_failure; 'nylas scheduler bookings watch' sends booking_created; and
+'nylas webhook server --sink' sends webhook_failure when forwarding fails.
+Nothing is sent until notifications are configured.
+
+Examples:
+  nylas notify config --route vip_email=desktop,slack --slack-webhook <url>
+  nylas notify config --add-vip ceo@example.com --add-vip @bigcustomer.com
+  nylas notify test vip_email
+  nylas notify watch`,
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newConfigCmd(), configView{}))
+	cmd.AddCommand(common.RequireScopes(newTestCmd(), domain.ScopeEmailSend))
+	cmd.AddCommand(common.RunLocally(common.RequireScopes(common.DeclareOutput(newWatchCmd(), domain.Notification{}),
+		domain.ScopeEmailRead, domain.ScopeCalendarRead, domain.ScopeApplicationAPI)))
+
+	return cmd
+}
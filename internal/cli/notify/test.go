@@ -0,0 +1,81 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newNotifier builds the configured notifier. Replaced in tests.
+var newNotifier = common.NewNotifier
+
+func newTestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "test <category> [grant-id]",
+		Short: "Send a sample notification for a category",
+		Long: `Send a sample notification through the channels configured for a category.
+
+The email channel sends from the grant (the default grant unless given).`,
+		Example: `  nylas notify test vip_email
+  nylas notify test webhook_failure`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			category := args[0]
+			if !domain.IsNotifyCategory(category) {
+				return common.NewUserError(fmt.Sprintf("unknown category %q", category),
+					"Categories are vip_email, meeting_soon, booking_created and webhook_failure")
+			}
+
+			cfg, err := common.GetConfigStore(cmd).Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			if cfg.Notify == nil {
+				return common.NewUserError("notifications are not configured",
+					"Set them up with: nylas notify config --route "+category+"=desktop")
+			}
+			channels := cfg.Notify.ChannelsFor(category)
+			if len(channels) == 0 {
+				return common.NewUserError(category+" notifications are off",
+					"Route them with: nylas notify config --route "+category+"=desktop")
+			}
+
+			// The email channel needs a grant; the others work without one.
+			var client ports.MessageClient
+			grantID, err := common.GetGrantID(args[1:])
+			if err == nil {
+				if c, err := common.GetNylasClient(); err == nil {
+					client = c
+				}
+			}
+			notifier, err := newNotifier(client, grantID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+			if err := notifier.Notify(ctx, sampleNotification(category)); err != nil {
+				return common.NewUserError("sending the test notification failed: "+err.Error(),
+					"Check the settings with: nylas notify config")
+			}
+			common.PrintSuccess("Sent a test %s notification to %s", category, strings.Join(channels, ", "))
+			return nil
+		},
+	}
+}
+
+func sampleNotification(category string) domain.Notification {
+	n := domain.Notification{Category: category, Title: "Test notification", Body: "Sent by 'nylas notify test'."}
+	for _, c := range domain.NotifyCategories {
+		if c.Name == category {
+			n.Title = "Test: " + c.Description
+		}
+	}
+	return n
+}
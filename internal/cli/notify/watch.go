@@ -0,0 +1,296 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// messageOverlap re-checks this much before the last poll for new mail, so
+// messages indexed late aren't missed. Duplicates are dropped by ID.
+const messageOverlap = time.Minute
+
+func newWatchCmd() *cobra.Command {
+	var (
+		interval   time.Duration
+		calendarID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "watch [grant-id]",
+		Short: "Send VIP email, meeting and webhook notifications as they happen",
+		Long: `Poll a grant and send notifications for:
+
+  vip_email         new mail from a VIP sender ('nylas notify config --add-vip')
+  meeting_soon      meetings on --calendar starting within the meeting lead time
+  webhook_failure   webhooks whose status turns failing or failed
+
+Only categories with at least one channel are checked. Each notification is
+also printed (as NDJSON with --json). Press Ctrl+C to stop.`,
+		Example: `  # Watch the default grant
+  nylas notify watch
+
+  # Check every 30 seconds, with reminders from a work calendar
+  nylas notify watch --interval 30s --calendar work@example.com`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < 10*time.Second {
+				return common.NewInputError("--interval must be at least 10s")
+			}
+			cfg, err := common.GetConfigStore(cmd).Load()
+			if err != nil {
+				return common.WrapLoadError("config", err)
+			}
+			if cfg.Notify == nil {
+				return common.NewUserError("notifications are not configured",
+					"Set them up with: nylas notify config --route vip_email=desktop --add-vip <email>")
+			}
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+			grantID, err := common.GetGrantID(args)
+			if err != nil {
+				return err
+			}
+			if common.AuditGrantHook != nil {
+				common.AuditGrantHook(grantID)
+			}
+			notifier, err := newNotifier(client, grantID)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			w := newNotifyWatcher(cfg.Notify, time.Now())
+			if !common.IsQuiet() {
+				_, _ = fmt.Fprintln(os.Stderr, common.Dim.Sprintf("Watching for %s (every %s). Press Ctrl+C to stop.",
+					strings.Join(w.categories(), ", "), interval))
+			}
+			out, structured := cmd.OutOrStdout(), common.IsStructuredOutput(cmd)
+			for {
+				notes, err := w.poll(ctx, client, grantID, calendarID, time.Now())
+				if ctx.Err() != nil {
+					return nil
+				}
+				if err != nil {
+					common.PrintWarningStderr("poll failed: %v", err)
+				}
+				for _, n := range notes {
+					if n.Time.IsZero() {
+						n.Time = time.Now()
+					}
+					if err := writeNotification(out, structured, n); err != nil {
+						return err
+					}
+					common.SendNotification(ctx, notifier, n)
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(interval):
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "Time between checks")
+	cmd.Flags().StringVarP(&calendarID, "calendar", "c", "primary", "Calendar to check for upcoming meetings")
+
+	return cmd
+}
+
+// notifyWatcher tracks what has already been notified across polls.
+type notifyWatcher struct {
+	cfg      *domain.NotifyConfig
+	since    time.Time
+	messages map[string]time.Time // notified-or-seen message ID → received
+	meetings map[string]time.Time // event ID|start → start
+	webhooks map[string]string    // webhook ID → last status
+}
+
+func newNotifyWatcher(cfg *domain.NotifyConfig, start time.Time) *notifyWatcher {
+	return &notifyWatcher{
+		cfg:      cfg,
+		since:    start,
+		messages: map[string]time.Time{},
+		meetings: map[string]time.Time{},
+		webhooks: map[string]string{},
+	}
+}
+
+// categories returns the categories this watcher checks.
+func (w *notifyWatcher) categories() []string {
+	var names []string
+	for _, c := range []string{domain.NotifyVIPEmail, domain.NotifyMeetingSoon, domain.NotifyWebhookFailure} {
+		if w.wants(c) {
+			names = append(names, c)
+		}
+	}
+	return names
+}
+
+func (w *notifyWatcher) wants(category string) bool {
+	if category == domain.NotifyVIPEmail && len(w.cfg.VIPs) == 0 {
+		return false
+	}
+	return len(w.cfg.ChannelsFor(category)) > 0
+}
+
+// poll checks each wanted category once. A failing source doesn't stop the
+// others; their errors are joined.
+func (w *notifyWatcher) poll(ctx context.Context, client ports.NylasClient, grantID, calendarID string, now time.Time) ([]domain.Notification, error) {
+	var notes []domain.Notification
+	var errs []error
+
+	if w.wants(domain.NotifyVIPEmail) {
+		msgs, err := client.GetMessagesWithParams(ctx, grantID, &domain.MessageQueryParams{
+			ReceivedAfter: w.since.Add(-messageOverlap).Unix(),
+			Limit:         common.MaxAPILimit,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("messages: %w", err))
+		} else {
+			notes = append(notes, w.vipEmails(msgs)...)
+			w.since = now
+		}
+	}
+	if w.wants(domain.NotifyMeetingSoon) {
+		events, err := client.GetEvents(ctx, grantID, calendarID, &domain.EventQueryParams{
+			Start:           now.Unix(),
+			End:             now.Add(w.cfg.MeetingLeadTime()).Unix(),
+			ExpandRecurring: true,
+			Limit:           common.MaxAPILimit,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("events: %w", err))
+		} else {
+			notes = append(notes, w.upcomingMeetings(events, now)...)
+		}
+	}
+	if w.wants(domain.NotifyWebhookFailure) {
+		hooks, err := client.ListWebhooks(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhooks: %w", err))
+		} else {
+			notes = append(notes, w.failingWebhooks(hooks)...)
+		}
+	}
+	return notes, errors.Join(errs...)
+}
+
+// vipEmails returns a notification for each message from a VIP not seen
+// before, and forgets messages older than the overlap window.
+func (w *notifyWatcher) vipEmails(msgs []domain.Message) []domain.Notification {
+	var notes []domain.Notification
+	for _, m := range msgs {
+		if _, seen := w.messages[m.ID]; seen || len(m.From) == 0 || !w.cfg.IsVIP(m.From[0].Email) {
+			continue
+		}
+		w.messages[m.ID] = m.Date
+		from := m.From[0].Name
+		if from == "" {
+			from = m.From[0].Email
+		}
+		notes = append(notes, domain.Notification{
+			Category: domain.NotifyVIPEmail,
+			Title:    "Email from " + from,
+			Body:     strings.TrimSpace(m.Subject + "\n" + common.Truncate(m.Snippet, 140)),
+			Time:     m.Date,
+		})
+	}
+	cutoff := w.since.Add(-2 * messageOverlap)
+	for id, date := range w.messages {
+		if date.Before(cutoff) {
+			delete(w.messages, id)
+		}
+	}
+	return notes
+}
+
+// upcomingMeetings returns a notification for each meeting starting within
+// the lead time that hasn't been notified.
+func (w *notifyWatcher) upcomingMeetings(events []domain.Event, now time.Time) []domain.Notification {
+	lead := w.cfg.MeetingLeadTime()
+	var notes []domain.Notification
+	for _, e := range events {
+		start := e.When.StartDateTime()
+		if e.Status == "cancelled" || e.When.IsAllDay() || !start.After(now) || start.Sub(now) > lead {
+			continue
+		}
+		key := e.ID + "|" + start.UTC().Format(time.RFC3339)
+		if _, done := w.meetings[key]; done {
+			continue
+		}
+		w.meetings[key] = start
+
+		n := domain.Notification{
+			Category: domain.NotifyMeetingSoon,
+			Title:    fmt.Sprintf("In %d min: %s", int(start.Sub(now).Round(time.Minute).Minutes()), e.Title),
+			Body:     start.Local().Format("15:04"),
+			Time:     now,
+		}
+		if e.Location != "" {
+			n.Body += " · " + e.Location
+		}
+		if e.Conferencing != nil && e.Conferencing.Details != nil {
+			n.URL = e.Conferencing.Details.URL
+		}
+		notes = append(notes, n)
+	}
+	for key, start := range w.meetings {
+		if start.Before(now) {
+			delete(w.meetings, key)
+		}
+	}
+	return notes
+}
+
+// failingWebhooks returns a notification for each webhook whose status has
+// become failing or failed since the last poll.
+func (w *notifyWatcher) failingWebhooks(hooks []domain.Webhook) []domain.Notification {
+	var notes []domain.Notification
+	for _, h := range hooks {
+		prev, known := w.webhooks[h.ID]
+		w.webhooks[h.ID] = h.Status
+		if (h.Status != "failing" && h.Status != "failed") || (known && prev == h.Status) {
+			continue
+		}
+		name := h.Description
+		if name == "" {
+			name = h.WebhookURL
+		}
+		notes = append(notes, domain.Notification{
+			Category: domain.NotifyWebhookFailure,
+			Title:    "Webhook " + h.Status + ": " + name,
+			Body:     fmt.Sprintf("Webhook %s (%s) is %s. Check it with: nylas webhook show %s", h.ID, h.WebhookURL, h.Status, h.ID),
+			Time:     h.StatusUpdatedAt,
+		})
+	}
+	return notes
+}
+
+func writeNotification(out io.Writer, structured bool, n domain.Notification) error {
+	if structured {
+		return json.NewEncoder(out).Encode(n)
+	}
+	_, err := fmt.Fprintf(out, "[%s] %s  %s\n", time.Now().Format("15:04:05"),
+		common.Cyan.Sprintf("%-15s", n.Category), n.Title)
+	return err
+}
@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func TestNotifyWatcher_VIPEmails(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	w := newNotifyWatcher(&domain.NotifyConfig{VIPs: []string{"@acme.com"}}, start)
+
+	msgs := []domain.Message{
+		{ID: "m1", Subject: "Renewal", Snippet: "Let's talk", Date: start, From: []domain.EmailParticipant{{Name: "Ana", Email: "ana@acme.com"}}},
+		{ID: "m2", Subject: "Newsletter", Date: start, From: []domain.EmailParticipant{{Email: "news@example.com"}}},
+		{ID: "m3", Subject: "No sender", Date: start},
+	}
+	notes := w.vipEmails(msgs)
+	require.Len(t, notes, 1)
+	assert.Equal(t, domain.NotifyVIPEmail, notes[0].Category)
+	assert.Equal(t, "Email from Ana", notes[0].Title)
+	assert.Equal(t, "Renewal\nLet's talk", notes[0].Body)
+
+	assert.Empty(t, w.vipEmails(msgs), "a message is notified once")
+}
+
+func TestNotifyWatcher_UpcomingMeetings(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	w := newNotifyWatcher(&domain.NotifyConfig{MeetingLead: "10m"}, now)
+	at := func(d time.Duration) domain.EventWhen {
+		return domain.EventWhen{StartTime: now.Add(d).Unix(), EndTime: now.Add(d + time.Hour).Unix()}
+	}
+
+	events := []domain.Event{
+		{ID: "soon", Title: "Standup", When: at(8 * time.Minute), Location: "Room 1",
+			Conferencing: &domain.Conferencing{Details: &domain.ConferencingDetails{URL: "https://zoom.us/j/1"}}},
+		{ID: "later", Title: "Review", When: at(30 * time.Minute)},
+		{ID: "cancelled", Title: "Sync", When: at(5 * time.Minute), Status: "cancelled"},
+		{ID: "started", Title: "Ongoing", When: at(-time.Minute)},
+	}
+	notes := w.upcomingMeetings(events, now)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "In 8 min: Standup", notes[0].Title)
+	assert.Equal(t, "https://zoom.us/j/1", notes[0].URL)
+	assert.Contains(t, notes[0].Body, "Room 1")
+
+	assert.Empty(t, w.upcomingMeetings(events, now.Add(time.Minute)), "a meeting is notified once")
+
+	events[0].When = at(9 * time.Minute)
+	assert.Len(t, w.upcomingMeetings(events, now.Add(time.Minute)), 1, "a moved meeting is notified again")
+}
+
+func TestNotifyWatcher_FailingWebhooks(t *testing.T) {
+	w := newNotifyWatcher(&domain.NotifyConfig{}, time.Now())
+
+	hooks := []domain.Webhook{
+		{ID: "wh1", Description: "Prod", WebhookURL: "https://a", Status: "failing"},
+		{ID: "wh2", WebhookURL: "https://b", Status: "active"},
+	}
+	notes := w.failingWebhooks(hooks)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "Webhook failing: Prod", notes[0].Title)
+
+	assert.Empty(t, w.failingWebhooks(hooks), "unchanged status isn't notified again")
+
+	hooks[0].Status, hooks[1].Status = "failed", "failing"
+	assert.Len(t, w.failingWebhooks(hooks), 2)
+}
+
+func TestNotifyWatcher_PollOnlyWantedCategories(t *testing.T) {
+	now := time.Now()
+	client := nylas.NewMockClient()
+	var polled []string
+	client.GetMessagesWithParamsFunc = func(context.Context, string, *domain.MessageQueryParams) ([]domain.Message, error) {
+		polled = append(polled, "messages")
+		return nil, nil
+	}
+	client.GetEventsFunc = func(context.Context, string, string, *domain.EventQueryParams) ([]domain.Event, error) {
+		polled = append(polled, "events")
+		return nil, errors.New("boom")
+	}
+	client.ListWebhooksFunc = func(context.Context) ([]domain.Webhook, error) {
+		polled = append(polled, "webhooks")
+		return nil, nil
+	}
+
+	// No VIPs, and webhook failures turned off: only meetings are checked.
+	w := newNotifyWatcher(&domain.NotifyConfig{Routes: map[string][]string{domain.NotifyWebhookFailure: {}}}, now)
+	assert.Equal(t, []string{domain.NotifyMeetingSoon}, w.categories())
+	_, err := w.poll(context.Background(), client, "grant-1", "primary", now)
+	assert.ErrorContains(t, err, "events: boom")
+	assert.Equal(t, []string{"events"}, polled)
+}
@@ -27,9 +27,8 @@ func newListCmd() *cobra.Command {
 				return nil
 			}
 
-			jsonOutput, _ := cmd.Root().PersistentFlags().GetBool("json")
-			if jsonOutput {
-				return common.PrintJSON(accounts)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(accounts)
 			}
 
 			_, _ = common.BoldCyan.Println("Configured Accounts")
@@ -3,6 +3,9 @@ package otp
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 )
 
 // NewOTPCmd creates the otp command group.
@@ -21,10 +24,10 @@ Commands:
 Guide: https://developer.nylas.com/docs/cookbook/cli/extract-otp-codes/`,
 	}
 
-	cmd.AddCommand(newGetCmd())
-	cmd.AddCommand(newWatchCmd())
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newMessagesCmd())
+	cmd.AddCommand(common.DeclareOutput(newGetCmd(), domain.OTPResult{}))
+	cmd.AddCommand(common.RunLocally(newWatchCmd()))
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.GrantInfo{}))
+	cmd.AddCommand(common.DeclareOutput(newMessagesCmd(), []domain.Message{}))
 
 	return cmd
 }
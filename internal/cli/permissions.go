@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+type scopeRequirement struct {
+	Scope       domain.Scope        `json:"scope" yaml:"scope"`
+	Description string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Providers   map[string][]string `json:"provider_permissions,omitempty" yaml:"provider_permissions,omitempty"`
+}
+
+type capabilityRequirement struct {
+	Capability  domain.Capability `json:"capability" yaml:"capability"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type permissionsSpec struct {
+	Command      string                  `json:"command" yaml:"command"`
+	Scopes       []scopeRequirement      `json:"scopes" yaml:"scopes"`
+	Capabilities []capabilityRequirement `json:"capabilities" yaml:"capabilities"`
+}
+
+func newPermissionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "permissions <command-path...>",
+		Short: "Show the permissions a command needs",
+		Long: `Show the Nylas scopes, provider OAuth permissions, and local capabilities
+a command needs to run.
+
+Requirements are recorded when commands are registered and are inherited by
+subcommands, so "nylas permissions email send" includes everything "email"
+needs plus the send scope.`,
+		Example: `  nylas permissions email send
+  nylas permissions calendar events create --json
+  nylas permissions auth login`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := resolveCommandTarget(cmd.Root(), args)
+			if err != nil {
+				return err
+			}
+
+			spec := buildPermissionsSpec(target)
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(spec)
+			}
+
+			printPermissionsSpec(cmd, spec)
+			return nil
+		},
+	}
+}
+
+func buildPermissionsSpec(target *cobra.Command) permissionsSpec {
+	scopes, caps := common.CommandPermissions(target)
+
+	spec := permissionsSpec{
+		Command:      target.CommandPath(),
+		Scopes:       make([]scopeRequirement, 0, len(scopes)),
+		Capabilities: make([]capabilityRequirement, 0, len(caps)),
+	}
+
+	for _, scope := range scopes {
+		req := scopeRequirement{
+			Scope:       scope,
+			Description: domain.ScopeDescriptions[scope],
+		}
+		if providers, ok := domain.ProviderScopes[scope]; ok {
+			req.Providers = make(map[string][]string, len(providers))
+			for provider, perms := range providers {
+				req.Providers[string(provider)] = perms
+			}
+		}
+		spec.Scopes = append(spec.Scopes, req)
+	}
+
+	for _, c := range caps {
+		spec.Capabilities = append(spec.Capabilities, capabilityRequirement{
+			Capability:  c,
+			Description: domain.CapabilityDescriptions[c],
+		})
+	}
+
+	return spec
+}
+
+func printPermissionsSpec(cmd *cobra.Command, spec permissionsSpec) {
+	out := cmd.OutOrStdout()
+
+	_, _ = fmt.Fprintf(out, "%s\n\n", common.Bold.Sprint(spec.Command))
+
+	if len(spec.Scopes) == 0 && len(spec.Capabilities) == 0 {
+		_, _ = fmt.Fprintln(out, "No special permissions recorded for this command.")
+		return
+	}
+
+	if len(spec.Scopes) > 0 {
+		_, _ = fmt.Fprintln(out, "Nylas scopes:")
+		for _, s := range spec.Scopes {
+			_, _ = fmt.Fprintf(out, "  %-16s %s\n", s.Scope, s.Description)
+
+			providers := make([]string, 0, len(s.Providers))
+			for p := range s.Providers {
+				providers = append(providers, p)
+			}
+			sort.Strings(providers)
+			for _, p := range providers {
+				_, _ = fmt.Fprintf(out, "    %-12s %s\n", domain.Provider(p).DisplayName()+":", strings.Join(s.Providers[p], ", "))
+			}
+		}
+	}
+
+	if len(spec.Capabilities) > 0 {
+		if len(spec.Scopes) > 0 {
+			_, _ = fmt.Fprintln(out)
+		}
+		_, _ = fmt.Fprintln(out, "Local capabilities:")
+		for _, c := range spec.Capabilities {
+			_, _ = fmt.Fprintf(out, "  %-16s %s\n", c.Capability, c.Description)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func newPermissionsFixtureRoot() *cobra.Command {
+	root := &cobra.Command{Use: "nylas"}
+	root.PersistentFlags().Bool("json", false, "Output as JSON")
+	root.PersistentFlags().String("format", "", "Output format")
+	root.PersistentFlags().BoolP("quiet", "q", false, "Quiet output")
+
+	group := common.RequireScopes(&cobra.Command{Use: "email"}, domain.ScopeEmailRead)
+	send := &cobra.Command{Use: "send", Run: func(*cobra.Command, []string) {}}
+	common.RequireScopes(send, domain.ScopeEmailSend)
+	common.RequireCapabilities(send, domain.CapabilityGPG)
+	plain := &cobra.Command{Use: "plain", Run: func(*cobra.Command, []string) {}}
+
+	group.AddCommand(send)
+	root.AddCommand(group, plain, newPermissionsCmd())
+	return root
+}
+
+// Subcommands must inherit their parents' requirements, otherwise a leaf
+// command would under-report what a grant needs.
+func TestPermissionsCmd_InheritsParentScopes(t *testing.T) {
+	root := newPermissionsFixtureRoot()
+
+	stdout, stderr, err := executeCommand(root, "permissions", "email", "send", "--json")
+	if err != nil {
+		t.Fatalf("permissions failed: %v\nstderr: %s", err, stderr)
+	}
+
+	var spec permissionsSpec
+	if err := json.Unmarshal([]byte(stdout), &spec); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+
+	if spec.Command != "nylas email send" {
+		t.Fatalf("command = %q", spec.Command)
+	}
+	if len(spec.Scopes) != 2 || spec.Scopes[0].Scope != domain.ScopeEmailRead || spec.Scopes[1].Scope != domain.ScopeEmailSend {
+		t.Fatalf("scopes = %+v, want email.read and email.send", spec.Scopes)
+	}
+	if got := spec.Scopes[1].Providers["google"]; len(got) == 0 {
+		t.Fatalf("expected google provider permissions for email.send, got %+v", spec.Scopes[1].Providers)
+	}
+	if len(spec.Capabilities) != 1 || spec.Capabilities[0].Capability != domain.CapabilityGPG {
+		t.Fatalf("capabilities = %+v, want gpg", spec.Capabilities)
+	}
+}
+
+func TestPermissionsCmd_NoMetadata(t *testing.T) {
+	root := newPermissionsFixtureRoot()
+
+	stdout, _, err := executeCommand(root, "permissions", "plain")
+	if err != nil {
+		t.Fatalf("permissions failed: %v", err)
+	}
+	if !strings.Contains(stdout, "No special permissions") {
+		t.Fatalf("unexpected output: %s", stdout)
+	}
+}
+
+func TestCommandPermissions_Deduplicates(t *testing.T) {
+	cmd := &cobra.Command{Use: "x"}
+	common.RequireScopes(cmd, domain.ScopeEmailRead, domain.ScopeEmailRead)
+
+	scopes, _ := common.CommandPermissions(cmd)
+	if len(scopes) != 1 {
+		t.Fatalf("scopes = %v, want one entry", scopes)
+	}
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// policyOverrideFlag runs a command the invoker policy denies, once a person
+// confirms at the terminal.
+const policyOverrideFlag = "policy-override"
+
+// loadInvokerPolicy reads the invoker policy. Replaced in tests.
+var loadInvokerPolicy = func() (*domain.InvokerPolicy, error) {
+	return config.LoadInvokerPolicy(config.DefaultPolicyPath())
+}
+
+// confirmPolicyOverride asks the person at the terminal to allow a denied
+// command. Agents run the CLI without a terminal on stdin, so they cannot
+// answer. Replaced in tests.
+var confirmPolicyOverride = func(prompt string) (bool, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return false, errors.New("stdin is not a terminal")
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "%s\nRun it anyway? [y/N]: ", prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// enforceInvokerPolicy refuses command when policy.yaml denies it for the
+// invoker source, unless --policy-override is given and confirmed.
+func enforceInvokerPolicy(cmd *cobra.Command, command, source string) error {
+	policy, err := loadInvokerPolicy()
+	if err != nil {
+		// Fail closed: a broken policy must not turn into no policy.
+		return &common.CLIError{
+			Err:        err,
+			Message:    fmt.Sprintf("invalid invoker policy: %v", err),
+			Suggestion: "Fix or remove " + config.DefaultPolicyPath(),
+			Code:       common.ErrCodeInvalidInput,
+		}
+	}
+
+	match := policy.Denied(source, command)
+	if match == nil {
+		return nil
+	}
+
+	denial := fmt.Sprintf("'%s' is denied for invoker %s by policy (matches %q)", command, source, match.Pattern)
+	if match.Rule.Reason != "" {
+		denial += ": " + match.Rule.Reason
+	}
+
+	if override, _ := cmd.Flags().GetBool(policyOverrideFlag); !override {
+		return &common.CLIError{
+			Message: denial,
+			Suggestions: []string{
+				"Ask the user to run this command themselves",
+				"Or rerun with --" + policyOverrideFlag + " from an interactive terminal to confirm",
+			},
+			Code: common.ErrCodePermissionDenied,
+		}
+	}
+
+	allowed, err := confirmPolicyOverride(denial)
+	switch {
+	case err != nil:
+		return &common.CLIError{
+			Message:    fmt.Sprintf("%s; --%s needs confirmation, but %v", denial, policyOverrideFlag, err),
+			Suggestion: "Run the command yourself from an interactive terminal",
+			Code:       common.ErrCodePermissionDenied,
+		}
+	case !allowed:
+		return &common.CLIError{Message: denial + "; override declined", Code: common.ErrCodePermissionDenied}
+	}
+
+	SetAuditDetail("policy_override", match.Pattern)
+	return nil
+}
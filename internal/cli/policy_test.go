@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func stubInvokerPolicy(t *testing.T, policy *domain.InvokerPolicy, confirm func(string) (bool, error)) {
+	t.Helper()
+	origLoad, origConfirm := loadInvokerPolicy, confirmPolicyOverride
+	loadInvokerPolicy = func() (*domain.InvokerPolicy, error) { return policy, nil }
+	confirmPolicyOverride = confirm
+	t.Cleanup(func() { loadInvokerPolicy, confirmPolicyOverride = origLoad, origConfirm })
+}
+
+func newPolicyTestCmd(override bool) *cobra.Command {
+	cmd := &cobra.Command{Use: "send"}
+	cmd.Flags().Bool(policyOverrideFlag, false, "")
+	if override {
+		_ = cmd.Flags().Set(policyOverrideFlag, "true")
+	}
+	return cmd
+}
+
+func TestEnforceInvokerPolicy(t *testing.T) {
+	policy := &domain.InvokerPolicy{Rules: []domain.InvokerPolicyRule{
+		{Sources: []string{"claude-code"}, Deny: []string{"email send", "* delete"}, Reason: "agents draft only"},
+	}}
+	never := func(string) (bool, error) {
+		t.Fatal("confirmation should not be requested")
+		return false, nil
+	}
+
+	t.Run("allows commands the policy does not cover", func(t *testing.T) {
+		stubInvokerPolicy(t, policy, never)
+		assert.NoError(t, enforceInvokerPolicy(newPolicyTestCmd(false), "email list", "claude-code"))
+		assert.NoError(t, enforceInvokerPolicy(newPolicyTestCmd(false), "email send", "terminal"))
+	})
+
+	t.Run("allows everything without a policy", func(t *testing.T) {
+		stubInvokerPolicy(t, nil, never)
+		assert.NoError(t, enforceInvokerPolicy(newPolicyTestCmd(false), "email send", "claude-code"))
+	})
+
+	t.Run("denies with the matching rule", func(t *testing.T) {
+		stubInvokerPolicy(t, policy, never)
+		err := enforceInvokerPolicy(newPolicyTestCmd(false), "contacts delete", "claude-code")
+		var cliErr *common.CLIError
+		require.ErrorAs(t, err, &cliErr)
+		assert.Equal(t, common.ErrCodePermissionDenied, cliErr.Code)
+		assert.Contains(t, cliErr.Message, `"* delete"`)
+		assert.Contains(t, cliErr.Message, "agents draft only")
+	})
+
+	t.Run("override runs after confirmation", func(t *testing.T) {
+		var prompt string
+		stubInvokerPolicy(t, policy, func(p string) (bool, error) { prompt = p; return true, nil })
+		auditMu.Lock()
+		currentAudit = &AuditContext{Command: "email send"}
+		auditMu.Unlock()
+		t.Cleanup(func() {
+			auditMu.Lock()
+			currentAudit = nil
+			auditMu.Unlock()
+		})
+
+		require.NoError(t, enforceInvokerPolicy(newPolicyTestCmd(true), "email send", "claude-code"))
+		assert.Contains(t, prompt, "'email send' is denied")
+		assert.Equal(t, "email send", currentAudit.Details["policy_override"])
+	})
+
+	t.Run("override declined", func(t *testing.T) {
+		stubInvokerPolicy(t, policy, func(string) (bool, error) { return false, nil })
+		err := enforceInvokerPolicy(newPolicyTestCmd(true), "email send", "claude-code")
+		assert.ErrorContains(t, err, "override declined")
+	})
+
+	t.Run("override without a terminal", func(t *testing.T) {
+		stubInvokerPolicy(t, policy, func(string) (bool, error) { return false, errors.New("stdin is not a terminal") })
+		err := enforceInvokerPolicy(newPolicyTestCmd(true), "email send", "claude-code")
+		assert.ErrorContains(t, err, "stdin is not a terminal")
+	})
+
+	t.Run("invalid policy blocks commands", func(t *testing.T) {
+		origLoad := loadInvokerPolicy
+		loadInvokerPolicy = func() (*domain.InvokerPolicy, error) { return nil, errors.New("rule 1: sources is empty") }
+		t.Cleanup(func() { loadInvokerPolicy = origLoad })
+		assert.ErrorContains(t, enforceInvokerPolicy(newPolicyTestCmd(false), "email list", "terminal"), "invalid invoker policy")
+	})
+}
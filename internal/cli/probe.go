@@ -0,0 +1,358 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// probeStatus follows the Nagios plugin convention; the value is the exit code.
+type probeStatus int
+
+const (
+	probeOK probeStatus = iota
+	probeWarning
+	probeCritical
+	probeUnknown
+)
+
+func (s probeStatus) String() string {
+	switch s {
+	case probeOK:
+		return "ok"
+	case probeWarning:
+		return "warning"
+	case probeCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// probeCheck runs one check against the API and returns a short message.
+type probeCheck func(ctx context.Context, client ports.NylasClient, grantID string) (string, error)
+
+// probeChecks maps check names to their implementation. Replaced in tests.
+var probeChecks = map[string]probeCheck{
+	"auth":     probeAuth,
+	"messages": probeMessages,
+	"calendar": probeCalendar,
+	"contacts": probeContacts,
+}
+
+// probeCheckOrder is the order checks run and are reported in.
+var probeCheckOrder = []string{"auth", "messages", "calendar", "contacts"}
+
+type probeResult struct {
+	Check     string `json:"check" yaml:"check"`
+	Status    string `json:"status" yaml:"status"`
+	LatencyMS int64  `json:"latency_ms" yaml:"latency_ms"`
+	Message   string `json:"message,omitempty" yaml:"message,omitempty"`
+	status    probeStatus
+	latency   time.Duration
+}
+
+type probeReport struct {
+	GrantID string        `json:"grant_id,omitempty" yaml:"grant_id,omitempty"`
+	Status  string        `json:"status" yaml:"status"`
+	Checks  []probeResult `json:"checks" yaml:"checks"`
+	status  probeStatus
+}
+
+func (r probeReport) QuietField() string {
+	return r.Status
+}
+
+func newProbeCmd() *cobra.Command {
+	var (
+		grantID  string
+		checks   []string
+		warnAt   time.Duration
+		critAt   time.Duration
+		perCheck time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "probe",
+		Short: "Run timed health checks for monitoring systems",
+		Long: `Run health checks against the Nylas API and report a status and latency for
+each one, in a format monitoring systems understand.
+
+Checks:
+  auth       the grant exists and is valid
+  messages   list one message
+  calendar   list calendars
+  contacts   list one contact
+
+A check is critical when it fails or takes longer than --critical, and a
+warning when it takes longer than --warning.
+
+--format also accepts:
+  prometheus   text exposition format (node_exporter textfile collector,
+               or a script exporter). Always exits 0; status is in the metrics.
+  nagios       one status line with perfdata; exits 0 OK, 1 WARNING,
+               2 CRITICAL, 3 UNKNOWN.
+
+Other formats exit non-zero when any check is critical.`,
+		Example: `  # Human-readable check
+  nylas probe
+
+  # Nagios/Icinga plugin
+  nylas probe --grant <grant-id> --checks auth,messages,calendar --format nagios
+
+  # Prometheus textfile collector
+  nylas probe --format prometheus > /var/lib/node_exporter/nylas.prom
+
+  # JSON for custom tooling
+  nylas probe --checks auth,calendar --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, check := range checks {
+				if err := common.ValidateOneOf("check", check, probeCheckOrder); err != nil {
+					return err
+				}
+			}
+			if critAt <= warnAt {
+				return common.NewInputError("--critical must be greater than --warning")
+			}
+
+			report := runProbe(cmd.Context(), grantID, checks, warnAt, critAt, perCheck)
+			return writeProbeReport(cmd, report, warnAt, critAt)
+		},
+	}
+
+	cmd.Flags().StringVar(&grantID, "grant", "", "Grant ID or email to probe (defaults to the default grant)")
+	cmd.Flags().StringSliceVar(&checks, "checks", probeCheckOrder, "Checks to run: "+strings.Join(probeCheckOrder, ", "))
+	cmd.Flags().DurationVar(&warnAt, "warning", 2*time.Second, "Latency above which a check is a warning")
+	cmd.Flags().DurationVar(&critAt, "critical", 5*time.Second, "Latency above which a check is critical")
+	cmd.Flags().DurationVar(&perCheck, "timeout", domain.TimeoutHealthCheck, "Timeout for each check")
+
+	return cmd
+}
+
+// runProbe resolves the client and grant and runs each check in order,
+// bypassing the response cache. When the client or grant cannot be resolved,
+// every check is reported unknown.
+func runProbe(ctx context.Context, grantArg string, checks []string, warnAt, critAt, perCheck time.Duration) probeReport {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// A cached response would report a healthy API with no latency.
+	common.SetNoCache(true)
+	common.ResetCachedClient()
+
+	var args []string
+	if grantArg != "" {
+		args = []string{grantArg}
+	}
+	client, err := common.GetNylasClient()
+	var grantID string
+	if err == nil {
+		grantID, err = common.GetGrantID(args)
+	}
+	if err != nil {
+		report := probeReport{}
+		for _, name := range orderedProbeChecks(checks) {
+			report.Checks = append(report.Checks, newProbeResult(name, probeUnknown, 0, common.WrapError(err).Message))
+		}
+		return finishProbeReport(report)
+	}
+
+	return probeWith(ctx, client, grantID, checks, warnAt, critAt, perCheck)
+}
+
+// probeWith runs the checks with an already-resolved client and grant.
+func probeWith(ctx context.Context, client ports.NylasClient, grantID string, checks []string, warnAt, critAt, perCheck time.Duration) probeReport {
+	report := probeReport{GrantID: grantID}
+	for _, name := range orderedProbeChecks(checks) {
+		checkCtx, cancel := context.WithTimeout(ctx, perCheck)
+		start := time.Now()
+		msg, err := probeChecks[name](checkCtx, client, grantID)
+		latency := time.Since(start)
+		cancel()
+
+		status := probeOK
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			status, msg = probeCritical, fmt.Sprintf("timed out after %s", perCheck)
+		case err != nil:
+			status, msg = probeCritical, common.WrapError(err).Message
+		case latency > critAt:
+			status = probeCritical
+		case latency > warnAt:
+			status = probeWarning
+		}
+		report.Checks = append(report.Checks, newProbeResult(name, status, latency, msg))
+	}
+	return finishProbeReport(report)
+}
+
+func newProbeResult(name string, status probeStatus, latency time.Duration, msg string) probeResult {
+	return probeResult{
+		Check:     name,
+		Status:    status.String(),
+		LatencyMS: latency.Milliseconds(),
+		Message:   msg,
+		status:    status,
+		latency:   latency,
+	}
+}
+
+// finishProbeReport sets the overall status to the worst check status.
+func finishProbeReport(report probeReport) probeReport {
+	for _, r := range report.Checks {
+		if r.status > report.status {
+			report.status = r.status
+		}
+	}
+	report.Status = report.status.String()
+	return report
+}
+
+// orderedProbeChecks returns the selected checks in canonical order.
+func orderedProbeChecks(selected []string) []string {
+	var ordered []string
+	for _, name := range probeCheckOrder {
+		if slices.Contains(selected, name) {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+func writeProbeReport(cmd *cobra.Command, report probeReport, warnAt, critAt time.Duration) error {
+	w := cmd.OutOrStdout()
+	format, _ := cmd.Flags().GetString("format")
+
+	switch format {
+	case "prometheus":
+		writeProbePrometheus(w, report)
+		return nil
+	case "nagios":
+		writeProbeNagios(w, report, warnAt, critAt)
+		if report.status != probeOK {
+			return &common.ExitError{Code: int(report.status)}
+		}
+		return nil
+	}
+
+	if common.IsStructuredOutput(cmd) {
+		if err := common.GetOutputWriter(cmd).Write(report); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range report.Checks {
+			printProbeResult(w, r)
+		}
+	}
+
+	if report.status >= probeCritical {
+		return fmt.Errorf("probe %s", report.Status)
+	}
+	return nil
+}
+
+func printProbeResult(w io.Writer, r probeResult) {
+	colorFn, icon := common.Green, "✓"
+	switch r.status {
+	case probeWarning:
+		colorFn, icon = common.Yellow, "⚠"
+	case probeCritical:
+		colorFn, icon = common.Red, "✗"
+	case probeUnknown:
+		colorFn, icon = common.Dim, "?"
+	}
+	_, _ = colorFn.Fprintf(w, "  %s %-9s %6dms", icon, r.Check, r.LatencyMS)
+	if r.Message != "" {
+		_, _ = common.Dim.Fprintf(w, "  %s", r.Message)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+func writeProbePrometheus(w io.Writer, report probeReport) {
+	labels := func(check string) string {
+		return fmt.Sprintf(`check=%q,grant_id=%q`, check, report.GrantID)
+	}
+
+	_, _ = fmt.Fprintln(w, "# HELP nylas_probe_up Whether the check succeeded (1) or failed (0).")
+	_, _ = fmt.Fprintln(w, "# TYPE nylas_probe_up gauge")
+	for _, r := range report.Checks {
+		up := 0
+		if r.status == probeOK || r.status == probeWarning {
+			up = 1
+		}
+		_, _ = fmt.Fprintf(w, "nylas_probe_up{%s} %d\n", labels(r.Check), up)
+	}
+
+	_, _ = fmt.Fprintln(w, "# HELP nylas_probe_duration_seconds How long the check took.")
+	_, _ = fmt.Fprintln(w, "# TYPE nylas_probe_duration_seconds gauge")
+	for _, r := range report.Checks {
+		_, _ = fmt.Fprintf(w, "nylas_probe_duration_seconds{%s} %.3f\n", labels(r.Check), r.latency.Seconds())
+	}
+
+	_, _ = fmt.Fprintln(w, "# HELP nylas_probe_status Check status: 0 ok, 1 warning, 2 critical, 3 unknown.")
+	_, _ = fmt.Fprintln(w, "# TYPE nylas_probe_status gauge")
+	for _, r := range report.Checks {
+		_, _ = fmt.Fprintf(w, "nylas_probe_status{%s} %d\n", labels(r.Check), r.status)
+	}
+}
+
+func writeProbeNagios(w io.Writer, report probeReport, warnAt, critAt time.Duration) {
+	summary := make([]string, 0, len(report.Checks))
+	perfdata := make([]string, 0, len(report.Checks))
+	for _, r := range report.Checks {
+		item := fmt.Sprintf("%s %s", r.Check, r.Status)
+		if r.Message != "" && r.status != probeOK {
+			item += " (" + r.Message + ")"
+		}
+		summary = append(summary, item)
+		perfdata = append(perfdata, fmt.Sprintf("%s=%.3fs;%.3f;%.3f;0",
+			r.Check, r.latency.Seconds(), warnAt.Seconds(), critAt.Seconds()))
+	}
+	_, _ = fmt.Fprintf(w, "NYLAS %s - %s | %s\n",
+		strings.ToUpper(report.Status), strings.Join(summary, ", "), strings.Join(perfdata, " "))
+}
+
+func probeAuth(ctx context.Context, client ports.NylasClient, grantID string) (string, error) {
+	grant, err := client.GetGrant(ctx, grantID)
+	if err != nil {
+		return "", err
+	}
+	if !grant.IsValid() {
+		return "", fmt.Errorf("grant status is %q; re-authenticate with 'nylas auth login'", grant.GrantStatus)
+	}
+	return grant.Email, nil
+}
+
+func probeMessages(ctx context.Context, client ports.NylasClient, grantID string) (string, error) {
+	if _, err := client.GetMessages(ctx, grantID, 1); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+func probeCalendar(ctx context.Context, client ports.NylasClient, grantID string) (string, error) {
+	calendars, err := client.GetCalendars(ctx, grantID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d calendars", len(calendars)), nil
+}
+
+func probeContacts(ctx context.Context, client ports.NylasClient, grantID string) (string, error) {
+	if _, err := client.GetContacts(ctx, grantID, &domain.ContactQueryParams{Limit: 1}); err != nil {
+		return "", err
+	}
+	return "", nil
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func swapProbeChecksForTest(t *testing.T, checks map[string]probeCheck) {
+	t.Helper()
+	original := probeChecks
+	probeChecks = checks
+	t.Cleanup(func() { probeChecks = original })
+}
+
+func sleepingProbe(d time.Duration, err error) probeCheck {
+	return func(ctx context.Context, _ ports.NylasClient, _ string) (string, error) {
+		select {
+		case <-time.After(d):
+			return "", err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+func TestProbeWith_Statuses(t *testing.T) {
+	swapProbeChecksForTest(t, map[string]probeCheck{
+		"auth":     sleepingProbe(0, nil),
+		"messages": sleepingProbe(30*time.Millisecond, nil),
+		"calendar": sleepingProbe(0, errors.New("calendar API unavailable")),
+		"contacts": sleepingProbe(time.Second, nil),
+	})
+
+	report := probeWith(context.Background(), nylas.NewMockClient(), "grant-1",
+		[]string{"contacts", "calendar", "messages", "auth"}, 20*time.Millisecond, 500*time.Millisecond, 100*time.Millisecond)
+
+	require.Len(t, report.Checks, 4)
+	got := map[string]string{}
+	for _, r := range report.Checks {
+		got[r.Check] = r.Status
+	}
+	assert.Equal(t, []string{"auth", "messages", "calendar", "contacts"},
+		[]string{report.Checks[0].Check, report.Checks[1].Check, report.Checks[2].Check, report.Checks[3].Check})
+	assert.Equal(t, "ok", got["auth"])
+	assert.Equal(t, "warning", got["messages"])
+	assert.Equal(t, "critical", got["calendar"])
+	assert.Equal(t, "critical", got["contacts"])
+	assert.Contains(t, report.Checks[3].Message, "timed out")
+	assert.Equal(t, "critical", report.Status)
+}
+
+func TestRunProbe_BypassesResponseCache(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(tempDir, "cache"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tempDir, "config"))
+	t.Setenv("HOME", tempDir)
+	t.Setenv("NYLAS_DISABLE_KEYRING", "true")
+	t.Setenv("NYLAS_API_KEY", "test-key")
+	t.Setenv("NYLAS_GRANT_ID", "grant-1")
+	t.Setenv("NYLAS_CACHE", "true")
+	common.SetNoCache(false)
+	common.ResetCachedClient()
+	t.Cleanup(func() {
+		common.SetNoCache(false)
+		common.ResetCachedClient()
+	})
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"id":"primary","name":"Primary"}]}`))
+	}))
+	defer server.Close()
+	t.Setenv("NYLAS_API_BASE_URL", server.URL)
+
+	for range 2 {
+		report := runProbe(context.Background(), "", []string{"calendar"}, time.Second, 2*time.Second, time.Second)
+		require.Len(t, report.Checks, 1)
+		assert.Equal(t, "ok", report.Checks[0].Status, report.Checks[0].Message)
+		common.ResetCachedClient()
+	}
+	assert.Equal(t, 2, requests, "each probe must reach the API")
+}
+
+func TestProbeAuth_InvalidGrant(t *testing.T) {
+	client := nylas.NewMockClient()
+	client.GetGrantFunc = func(context.Context, string) (*domain.Grant, error) {
+		return &domain.Grant{ID: "grant-1", GrantStatus: "invalid"}, nil
+	}
+
+	_, err := probeAuth(context.Background(), client, "grant-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `grant status is "invalid"`)
+}
+
+func testProbeReport() probeReport {
+	return finishProbeReport(probeReport{
+		GrantID: "grant-1",
+		Checks: []probeResult{
+			newProbeResult("auth", probeOK, 120*time.Millisecond, "me@example.com"),
+			newProbeResult("messages", probeWarning, 2500*time.Millisecond, ""),
+		},
+	})
+}
+
+func newProbeTestCmd(format string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{Use: "probe"}
+	common.AddOutputFlags(cmd)
+	_ = cmd.ParseFlags([]string{"--format", format})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func TestWriteProbeReport_Nagios(t *testing.T) {
+	cmd, out := newProbeTestCmd("nagios")
+
+	err := writeProbeReport(cmd, testProbeReport(), 2*time.Second, 5*time.Second)
+
+	var exitErr *common.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.Code)
+	assert.Equal(t,
+		"NYLAS WARNING - auth ok, messages warning | auth=0.120s;2.000;5.000;0 messages=2.500s;2.000;5.000;0\n",
+		out.String())
+}
+
+func TestWriteProbeReport_Prometheus(t *testing.T) {
+	cmd, out := newProbeTestCmd("prometheus")
+
+	require.NoError(t, writeProbeReport(cmd, testProbeReport(), 2*time.Second, 5*time.Second))
+
+	text := out.String()
+	assert.Contains(t, text, "# TYPE nylas_probe_up gauge\n")
+	assert.Contains(t, text, `nylas_probe_up{check="messages",grant_id="grant-1"} 1`)
+	assert.Contains(t, text, `nylas_probe_duration_seconds{check="auth",grant_id="grant-1"} 0.120`)
+	assert.Contains(t, text, `nylas_probe_status{check="messages",grant_id="grant-1"} 1`)
+}
+
+func TestWriteProbeReport_JSON(t *testing.T) {
+	cmd, out := newProbeTestCmd("json")
+
+	require.NoError(t, writeProbeReport(cmd, testProbeReport(), 2*time.Second, 5*time.Second))
+
+	assert.Contains(t, out.String(), `"status": "warning"`)
+	assert.Contains(t, out.String(), `"latency_ms": 2500`)
+	assert.False(t, strings.Contains(out.String(), "latency\""), "unexported fields stay out of JSON")
+}
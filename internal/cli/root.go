@@ -3,19 +3,31 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"slices"
 
 	"github.com/spf13/cobra"
 
+	adapterconfig "github.com/nylas/cli/internal/adapters/config"
 	"github.com/nylas/cli/internal/cli/common"
 	"github.com/nylas/cli/internal/cli/setup"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/version"
 )
 
-var rootCmd = &cobra.Command{
-	Use:     "nylas",
-	Short:   "Nylas CLI - Email, calendar, and contacts from your terminal",
-	Version: version.Version,
-	Long: `Quick start:
+var rootCmd *cobra.Command
+
+// commandSet builds the top-level commands registered with SetCommands.
+var commandSet func() []*cobra.Command
+
+// newRootCmd builds the root command with its global flags and the commands
+// defined in this package.
+func newRootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "nylas",
+		Short:   "Nylas CLI - Email, calendar, and contacts from your terminal",
+		Version: version.Version,
+		Long: `Quick start:
   nylas init             Guided setup (first time)
   nylas email list       List recent emails
   nylas calendar events  Upcoming events
@@ -24,16 +36,54 @@ var rootCmd = &cobra.Command{
 
 Documentation: https://cli.nylas.com/
 API reference: https://developer.nylas.com/`,
-	SilenceUsage:  true,
-	SilenceErrors: true,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if setup.IsFirstRun() {
-			printWelcome()
-			return nil
-		}
-		printHelpHeader()
-		return cmd.Help()
-	},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if setup.IsFirstRun() {
+				printWelcome()
+				return nil
+			}
+			printHelpHeader()
+			return cmd.Help()
+		},
+		SuggestionsMinimumDistance: 2,
+	}
+
+	// Global output flags (format, json, output, quiet, ids, wide, no-color)
+	cmd.PersistentFlags().String("format", "", "Output format: table, json, yaml, csv")
+	cmd.PersistentFlags().Bool("json", false, "Output in JSON format")
+	common.AddOutputFormatFlag(cmd.PersistentFlags())
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "Quiet mode - only output essential data (IDs)")
+	cmd.PersistentFlags().Bool("ids", false, "Print only resource IDs, one per line")
+	cmd.PersistentFlags().BoolP("wide", "w", false, "Wide output - show full IDs without truncation")
+	cmd.PersistentFlags().Bool("no-color", false, "Disable color output")
+
+	// Other global flags
+	cmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.PersistentFlags().String("config", "", "Custom config file path")
+	cmd.PersistentFlags().String("env", "", "Environment profile to use (overrides NYLAS_ENV)")
+	cmd.PersistentFlags().Bool("no-cache", false, "Bypass the API response cache")
+	cmd.PersistentFlags().String("emit-code", "", "Print each API request as code on stderr: curl, go, python, node")
+	cmd.PersistentFlags().String("transcript", "", "Record the terminal session to an asciinema file (.cast)")
+	cmd.PersistentFlags().Bool(policyOverrideFlag, false, "Run a command denied by the invoker policy after confirming at the terminal")
+	errorFormat = ""
+	cmd.PersistentFlags().Var(&errorFormat, errorFormatFlag, "Error output on stderr: text or json")
+	cmd.SetFlagErrorFunc(usageError)
+
+	cmd.AddCommand(common.DeclareOutput(newCommandsCmd(), commandSpec{}))
+	cmd.AddCommand(common.DeclareOutput(newPermissionsCmd(), permissionsSpec{}))
+	cmd.AddCommand(common.DeclareOutput(newSchemaCmd(), map[string]any{}))
+	cmd.AddCommand(newVersionCmd())
+	cmd.AddCommand(common.DeclareOutput(newDoctorCmd(), doctorReport{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newProbeCmd(), probeReport{}),
+		domain.ScopeEmailRead, domain.ScopeCalendarRead, domain.ScopeContactsRead))
+	cmd.AddCommand(common.DeclareOutput(newStatusPageCmd(), domain.ServiceStatus{}))
+	cmd.AddCommand(newDaemonCmd())
+
+	// Initialize audit logging hooks
+	initAuditHooks(cmd)
+
+	return cmd
 }
 
 // printHelpHeader prints the branded ASCII art header.
@@ -117,22 +167,7 @@ func printCapability(name, desc string) {
 }
 
 func init() {
-	// Global output flags (format, json, quiet, wide, no-color)
-	rootCmd.PersistentFlags().String("format", "", "Output format: table, json, yaml")
-	rootCmd.PersistentFlags().Bool("json", false, "Output in JSON format")
-	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Quiet mode - only output essential data (IDs)")
-	rootCmd.PersistentFlags().BoolP("wide", "w", false, "Wide output - show full IDs without truncation")
-	rootCmd.PersistentFlags().Bool("no-color", false, "Disable color output")
-
-	// Other global flags
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
-	rootCmd.PersistentFlags().String("config", "", "Custom config file path")
-
-	rootCmd.AddCommand(newCommandsCmd())
-	rootCmd.AddCommand(newVersionCmd())
-
-	// Initialize audit logging hooks
-	initAuditHooks(rootCmd)
+	rootCmd = newRootCmd()
 }
 
 // GetRootCmd returns the root command for adding subcommands.
@@ -140,7 +175,33 @@ func GetRootCmd() *cobra.Command {
 	return rootCmd
 }
 
-// Execute runs the CLI.
+// SetCommands adds the top-level commands fn builds to the root command.
+// The daemon calls fn again for every command it runs, so each run starts
+// with fresh flag values.
+func SetCommands(fn func() []*cobra.Command) {
+	commandSet = fn
+	rootCmd.AddCommand(fn()...)
+}
+
+// Execute runs the CLI. With --transcript, the command runs in a recorded
+// child process instead; while `nylas daemon` runs, non-interactive commands
+// run there.
 func Execute() error {
-	return rootCmd.Execute()
+	adapterconfig.SetPassphraseSource(promptConfigPassphrase)
+	common.RegisterDynamicCompletions(rootCmd)
+	wrapArgsOnce.Do(func() { wrapArgsErrors(rootCmd) })
+	if os.Getenv(transcriptEnv) == "" {
+		path, args, ok, err := splitTranscriptFlag(os.Args[1:])
+		if err != nil {
+			return err
+		}
+		if ok {
+			common.SetQuiet(slices.Contains(args, "--quiet") || slices.Contains(args, "-q") || slices.Contains(args, "--ids"))
+			return runWithTranscript(path, args)
+		}
+	}
+	if ok, err := runInDaemon(os.Args[1:]); ok {
+		return err
+	}
+	return classifyExecuteError(rootCmd.ExecuteC())
 }
@@ -1,7 +1,12 @@
 // Package rpc provides JSON-RPC server commands.
 package rpc
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
 
 // NewRPCCmd creates the rpc command with all subcommands.
 func NewRPCCmd() *cobra.Command {
@@ -10,8 +15,11 @@ func NewRPCCmd() *cobra.Command {
 		Short: "JSON-RPC WebSocket server for Nylas",
 	}
 
-	cmd.AddCommand(newServeCmd())
-	cmd.AddCommand(newTokenCmd())
+	cmd.AddCommand(common.RunLocally(common.RequireCapabilities(common.RequireScopes(newServeCmd(),
+		domain.ScopeEmailRead, domain.ScopeEmailModify, domain.ScopeEmailSend,
+		domain.ScopeCalendarRead, domain.ScopeCalendarWrite, domain.ScopeContactsRead, domain.ScopeContactsWrite,
+		domain.ScopeApplicationAPI), domain.CapabilityNetwork, domain.CapabilityKeychain)))
+	cmd.AddCommand(common.DeclareOutput(newTokenCmd(), map[string]string{}))
 
 	return cmd
 }
@@ -10,10 +10,12 @@ import (
 
 	"github.com/nylas/cli/internal/adapters/audit"
 	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/granttoken"
 	"github.com/nylas/cli/internal/adapters/keyring"
 	"github.com/nylas/cli/internal/adapters/rpcserver"
 	otpapp "github.com/nylas/cli/internal/app/otp"
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -92,6 +94,10 @@ func runServe(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	grantTokenKey, err := granttoken.SigningKey(store)
+	if err != nil {
+		return err
+	}
 
 	d := rpcserver.NewDispatcher()
 	d.LogError = func(err error) { _, _ = fmt.Fprintf(cmd.ErrOrStderr(), "rpc handler error: %v\n", err) }
@@ -137,6 +143,10 @@ func runServe(cmd *cobra.Command, args []string) error {
 	srv := rpcserver.NewServer(rpcserver.Config{
 		Addr:  addr,
 		Token: token,
+		VerifyGrantToken: func(t string) (*domain.GrantToken, error) {
+			return granttoken.Verify(grantTokenKey, t, time.Now())
+		},
+		NotifyGrantID: grantID,
 	}, d)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -213,6 +223,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 	_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Nylas RPC WebSocket listening on %s\n", addr)
 	_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Authenticate with Authorization: Bearer <token> or ?token=<token>.")
 	_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "The token is stored in the keyring or read from NYLAS_WS_TOKEN.")
+	_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Scoped grant tokens from 'nylas auth token issue' are also accepted.")
 
 	return srv.Serve(ctx)
 }
@@ -2,7 +2,6 @@ package scheduler
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -23,10 +22,11 @@ func newBookingsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/bookings/`,
 	}
 
-	cmd.AddCommand(newBookingShowCmd())
-	cmd.AddCommand(newBookingConfirmCmd())
-	cmd.AddCommand(newBookingRescheduleCmd())
-	cmd.AddCommand(newBookingCancelCmd())
+	cmd.AddCommand(common.DeclareOutput(newBookingShowCmd(), domain.Booking{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newBookingConfirmCmd(), domain.Booking{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newBookingRescheduleCmd(), domain.Booking{}, rescheduledBooking{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(newBookingCancelCmd(), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RunLocally(common.DeclareOutput(newBookingWatchCmd(), bookingChange{})))
 
 	return cmd
 }
@@ -49,8 +49,8 @@ configuration, so --configuration-id is required.`,
 					return struct{}{}, common.WrapGetError("booking", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(booking)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(booking)
 				}
 
 				_, _ = common.Bold.Printf("Booking: %s\n", booking.Title)
@@ -141,8 +141,8 @@ Scheduler webhook payload.`,
 					return struct{}{}, common.WrapUpdateError("booking", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(booking)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(booking)
 				}
 
 				_, _ = common.Green.Printf("✓ Confirmed booking: %s\n", booking.BookingID)
@@ -207,8 +207,8 @@ You must provide the new start and end times as Unix timestamps.`,
 					common.PrintWarningStderr("%s", warning)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(rescheduleJSONPayload(booking, warning))
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(rescheduleJSONPayload(booking, warning))
 				}
 
 				_, _ = common.Green.Printf("✓ Rescheduled booking: %s\n", booking.BookingID)
@@ -242,10 +242,14 @@ func rescheduleJSONPayload(booking *domain.Booking, warning string) any {
 	if warning == "" {
 		return booking
 	}
-	return struct {
-		domain.Booking
-		Warning string `json:"warning"`
-	}{Booking: *booking, Warning: warning}
+	return rescheduledBooking{Booking: *booking, Warning: warning}
+}
+
+// rescheduledBooking is a booking whose reschedule was applied but could not
+// be read back.
+type rescheduledBooking struct {
+	domain.Booking
+	Warning string `json:"warning"`
 }
 
 // resolveRescheduleResult maps the port's typed partial success onto the CLI
@@ -0,0 +1,363 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Booking change actions.
+const (
+	bookingCreated     = "created"
+	bookingPending     = "pending"
+	bookingRescheduled = "rescheduled"
+	bookingCancelled   = "cancelled"
+)
+
+// bookingChange is one booking lifecycle change, written as a line of
+// output and passed to the --exec handler.
+type bookingChange struct {
+	Action          string     `json:"action"`
+	BookingID       string     `json:"booking_id,omitempty"`
+	EventID         string     `json:"event_id,omitempty"`
+	ConfigurationID string     `json:"configuration_id"`
+	Title           string     `json:"title"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         time.Time  `json:"end_time"`
+	PreviousStart   *time.Time `json:"previous_start_time,omitempty"`
+	Participants    []string   `json:"participants,omitempty"`
+	Source          string     `json:"source"`
+}
+
+// key identifies a change across the poll and webhook sources.
+func (c bookingChange) key() string {
+	id := c.EventID
+	if id == "" {
+		id = c.BookingID
+	}
+	return c.Action + "|" + id + "|" + strconv.FormatInt(c.StartTime.Unix(), 10)
+}
+
+type bookingWatchOptions struct {
+	configID      string
+	interval      time.Duration
+	exec          string
+	port          int
+	path          string
+	tunnelType    string
+	secret        string
+	allowUnsigned bool
+}
+
+func newBookingWatchCmd() *cobra.Command {
+	opts := bookingWatchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "watch [grant-id]",
+		Short: "Watch a configuration for new, rescheduled, and cancelled bookings",
+		Long: `Watch a scheduler configuration for booking changes and print each one,
+or run a handler for it with --exec.
+
+Bookings are found by polling the organizer's booking calendar every
+--interval for events whose title matches the configuration's event title.
+Polls report a reschedule when a booking's start time moves; bookings made
+before the watch started are tracked for the configuration's booking window.
+With --port, a webhook receiver also runs and booking.created,
+booking.pending, booking.rescheduled, and booking.cancelled notifications
+are merged into the same output. A change seen by both is reported once.
+
+With --exec, the command runs through the shell once per change, with the
+change as JSON on stdin and these environment variables:
+  NYLAS_BOOKING_ACTION      created, pending, rescheduled, or cancelled
+  NYLAS_BOOKING_ID          booking ID (webhooks only)
+  NYLAS_BOOKING_EVENT_ID    calendar event ID
+  NYLAS_BOOKING_TITLE       event title
+  NYLAS_BOOKING_START       start time (RFC 3339)
+  NYLAS_BOOKING_END         end time (RFC 3339)
+
+A failing handler is reported on stderr and the watch continues. New
+bookings also send a booking_created notification when notifications are
+configured ('nylas notify config').
+Press Ctrl+C to stop.`,
+		Example: `  # Print booking changes as they happen
+  nylas scheduler bookings watch --config <config-id>
+
+  # Post each new booking to Slack
+  nylas scheduler bookings watch --config <config-id> \
+    --exec 'curl -s -X POST -H "Content-Type: application/json" -d @- "$SLACK_WEBHOOK_URL"'
+
+  # Stream changes as NDJSON
+  nylas scheduler bookings watch --config <config-id> --json >> bookings.ndjson
+
+  # Also receive booking webhooks through a cloudflared tunnel
+  nylas scheduler bookings watch --config <config-id> --port 3000 \
+    --tunnel cloudflared --secret <webhook-secret>`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBookingWatchOptions(opts); err != nil {
+				return err
+			}
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+			grantID, err := common.GetGrantID(args)
+			if err != nil {
+				return err
+			}
+			if common.AuditGrantHook != nil {
+				common.AuditGrantHook(grantID)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			config, err := client.GetSchedulerConfiguration(ctx, grantID, opts.configID)
+			if err != nil {
+				return common.WrapGetError("configuration", err)
+			}
+			p := newBookingPoller(client, grantID, config, time.Now())
+			if err := p.seed(ctx); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return common.WrapFetchError("bookings", err)
+			}
+
+			w := newBookingWatcher(cmd.OutOrStdout(), common.IsStructuredOutput(cmd), opts.exec)
+			if w.notifier, err = common.NewNotifier(client, grantID); err != nil {
+				return err
+			}
+			if opts.port > 0 {
+				server, err := startBookingWebhookReceiver(ctx, opts, w)
+				if err != nil {
+					if errors.Is(err, context.Canceled) {
+						return nil
+					}
+					return common.WrapError(err)
+				}
+				defer func() { _ = server.Stop() }()
+			}
+
+			if !common.IsQuiet() {
+				fmt.Fprintln(os.Stderr, common.Dim.Sprintf("Watching bookings for %s on calendar %s (every %s). Press Ctrl+C to stop.",
+					config.Name, p.calendarID, opts.interval))
+			}
+			return runBookingWatch(ctx, w, p, opts.interval)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.configID, "config", "", "Scheduler configuration ID to watch (required)")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 30*time.Second, "Time between polls")
+	cmd.Flags().StringVar(&opts.exec, "exec", "", "Shell command to run for each change (change JSON on stdin)")
+	cmd.Flags().IntVarP(&opts.port, "port", "p", 0, "Also receive booking webhooks on this port (0 to poll only)")
+	cmd.Flags().StringVar(&opts.path, "path", "/webhook", "Webhook endpoint path")
+	cmd.Flags().StringVarP(&opts.tunnelType, "tunnel", "t", "", "Tunnel provider for the webhook receiver (cloudflared)")
+	cmd.Flags().StringVarP(&opts.secret, "secret", "s", "", "Webhook secret for signature verification")
+	cmd.Flags().BoolVar(&opts.allowUnsigned, "allow-unsigned", false, "Allow unsigned webhook events when --tunnel is set (insecure)")
+	_ = cmd.MarkFlagRequired("config")
+	// Accept the --configuration-id spelling used by the other booking commands.
+	cmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "configuration-id" {
+			name = "config"
+		}
+		return pflag.NormalizedName(name)
+	})
+
+	return cmd
+}
+
+func validateBookingWatchOptions(opts bookingWatchOptions) error {
+	if strings.TrimSpace(opts.configID) == "" {
+		return common.ValidateRequiredFlag("--config", "")
+	}
+	if opts.interval < time.Second {
+		return common.NewInputError("--interval must be at least 1s")
+	}
+	if opts.port <= 0 && (opts.tunnelType != "" || opts.secret != "") {
+		return common.NewUserError("--tunnel and --secret need --port", "Pass --port to run the webhook receiver")
+	}
+	if opts.tunnelType != "" && opts.secret == "" && !opts.allowUnsigned {
+		return common.NewUserError(
+			"--secret is required when --tunnel is set",
+			"Pass --secret <value> to verify each event, or --allow-unsigned to accept unverified events (insecure)",
+		)
+	}
+	return nil
+}
+
+// runBookingWatch polls every interval until ctx is done. A failed poll is
+// reported and retried on the next tick.
+func runBookingWatch(ctx context.Context, w *bookingWatcher, p *bookingPoller, interval time.Duration) error {
+	for {
+		changes, err := p.poll(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			common.PrintWarningStderr("booking poll failed: %v", err)
+		}
+		for _, c := range changes {
+			if err := w.handle(ctx, c); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// bookingWatcher reports each change once, however many sources see it.
+// Changes are handled one at a time so handlers never overlap.
+type bookingWatcher struct {
+	out        io.Writer
+	structured bool
+	command    string
+	runHandler func(ctx context.Context, command string, c bookingChange, stdout io.Writer) error
+	notifier   ports.Notifier // booking_created notifications; nil when not configured
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newBookingWatcher(out io.Writer, structured bool, command string) *bookingWatcher {
+	return &bookingWatcher{out: out, structured: structured, command: command, runHandler: runBookingHandler, seen: make(map[string]bool)}
+}
+
+// handle writes c and runs the handler. Only write errors are returned;
+// handler failures are reported and the watch continues.
+func (w *bookingWatcher) handle(ctx context.Context, c bookingChange) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := c.key()
+	if w.seen[key] {
+		return nil
+	}
+	w.seen[key] = true
+
+	if w.structured {
+		if err := json.NewEncoder(w.out).Encode(c); err != nil {
+			return err
+		}
+	} else {
+		_, _ = fmt.Fprintln(w.out, formatBookingChange(c))
+	}
+
+	if c.Action == bookingCreated {
+		common.SendNotification(ctx, w.notifier, domain.Notification{
+			Category: domain.NotifyBookingCreated,
+			Title:    "New booking: " + c.Title,
+			Body:     strings.TrimSpace(c.StartTime.Local().Format("Mon Jan 2, 15:04") + "  " + strings.Join(c.Participants, ", ")),
+		})
+	}
+
+	if w.command != "" {
+		// Handler output goes to stderr in structured mode to keep the
+		// NDJSON stream parseable.
+		stdout := w.out
+		if w.structured {
+			stdout = os.Stderr
+		}
+		if err := w.runHandler(ctx, w.command, c, stdout); err != nil && ctx.Err() == nil {
+			common.PrintWarningStderr("handler for %s booking %q failed: %v", c.Action, c.Title, err)
+		}
+	}
+	return nil
+}
+
+func formatBookingChange(c bookingChange) string {
+	action := common.Green
+	switch c.Action {
+	case bookingRescheduled:
+		action = common.Yellow
+	case bookingCancelled:
+		action = common.Red
+	case bookingPending:
+		action = common.Cyan
+	}
+
+	when := c.StartTime.Local().Format("Mon Jan 2, 15:04")
+	if !c.EndTime.IsZero() {
+		when += "–" + c.EndTime.Local().Format("15:04")
+	}
+	line := fmt.Sprintf("[%s] %s  %s  %s",
+		time.Now().Format("15:04:05"), action.Sprintf("%-11s", c.Action), common.Bold.Sprint(c.Title), when)
+	if c.PreviousStart != nil {
+		line += common.Dim.Sprintf(" (was %s)", c.PreviousStart.Local().Format("Mon Jan 2, 15:04"))
+	}
+	if len(c.Participants) > 0 {
+		line += "  " + strings.Join(c.Participants, ", ")
+	}
+	return line
+}
+
+// runBookingHandler runs command through the shell with c as JSON on stdin.
+func runBookingHandler(ctx context.Context, command string, c bookingChange, stdout io.Writer) error {
+	body, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	cmd := shellCommand(ctx, command)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), bookingHandlerEnv(c)...)
+	return cmd.Run()
+}
+
+func bookingHandlerEnv(c bookingChange) []string {
+	env := []string{
+		"NYLAS_BOOKING_ACTION=" + c.Action,
+		"NYLAS_BOOKING_ID=" + c.BookingID,
+		"NYLAS_BOOKING_EVENT_ID=" + c.EventID,
+		"NYLAS_BOOKING_TITLE=" + c.Title,
+		"NYLAS_BOOKING_START=" + c.StartTime.Format(time.RFC3339),
+	}
+	if !c.EndTime.IsZero() {
+		env = append(env, "NYLAS_BOOKING_END="+c.EndTime.Format(time.RFC3339))
+	}
+	return env
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command) // #nosec G204 -- the user's own --exec command
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command) // #nosec G204 -- the user's own --exec command
+}
+
+// bookingParticipants lists participant emails, skipping the organizer.
+func bookingParticipants(participants []domain.Participant, organizer string) []string {
+	var emails []string
+	for _, p := range participants {
+		if p.Email == "" || strings.EqualFold(p.Email, organizer) {
+			continue
+		}
+		emails = append(emails, p.Email)
+	}
+	return emails
+}
@@ -0,0 +1,292 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/tunnel"
+	"github.com/nylas/cli/internal/adapters/webhookserver"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const (
+	sourcePoll    = "poll"
+	sourceWebhook = "webhook"
+
+	// defaultBookingWindowDays is the API's default available_days_in_future.
+	defaultBookingWindowDays = 30
+)
+
+// bookingEventLister is the part of the client the poller needs.
+type bookingEventLister interface {
+	GetEventsWithCursor(ctx context.Context, grantID, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error)
+}
+
+// bookingPoller finds booking changes in the organizer's booking calendar.
+// There is no endpoint listing a configuration's bookings, so booked events
+// are recognized by their title.
+type bookingPoller struct {
+	client     bookingEventLister
+	grantID    string
+	calendarID string
+	configID   string
+	organizer  string
+	title      *regexp.Regexp
+	windowDays int
+	started    time.Time
+
+	mark      int64                // updated_after for the next poll
+	starts    map[string]time.Time // start time of each known booking
+	versions  map[string]int64     // last handled updated_at per event
+	cancelled map[string]bool
+}
+
+func newBookingPoller(client bookingEventLister, grantID string, config *domain.SchedulerConfiguration, now time.Time) *bookingPoller {
+	p := &bookingPoller{
+		client:     client,
+		grantID:    grantID,
+		calendarID: "primary",
+		configID:   config.ID,
+		title:      titlePattern(config.EventBooking.Title),
+		windowDays: config.Scheduler.AvailableDaysInFuture,
+		started:    now,
+		mark:       now.Unix(),
+		starts:     make(map[string]time.Time),
+		versions:   make(map[string]int64),
+		cancelled:  make(map[string]bool),
+	}
+	if p.windowDays <= 0 {
+		p.windowDays = defaultBookingWindowDays
+	}
+	if idx := editableParticipant(config.Participants); idx >= 0 {
+		organizer := config.Participants[idx]
+		p.organizer = organizer.Email
+		if organizer.Booking != nil && organizer.Booking.CalendarID != "" {
+			p.calendarID = organizer.Booking.CalendarID
+		}
+	}
+	return p
+}
+
+// titlePattern matches event titles produced by an event_booking.title
+// template; {{placeholders}} match anything.
+func titlePattern(template string) *regexp.Regexp {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		return nil
+	}
+	placeholder := regexp.MustCompile(`\{\{[^}]*\}\}`)
+	var parts []string
+	for _, literal := range placeholder.Split(template, -1) {
+		parts = append(parts, regexp.QuoteMeta(literal))
+	}
+	return regexp.MustCompile(`(?i)^` + strings.Join(parts, `.*`) + `$`)
+}
+
+func (p *bookingPoller) matches(e *domain.Event) bool {
+	return p.title == nil || p.title.MatchString(strings.TrimSpace(e.Title))
+}
+
+// seed records the start times of upcoming bookings so later polls can
+// tell when one is rescheduled.
+func (p *bookingPoller) seed(ctx context.Context) error {
+	events, err := p.list(ctx, &domain.EventQueryParams{
+		Start: p.started.Unix(),
+		End:   p.started.AddDate(0, 0, p.windowDays).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	for i := range events {
+		if e := &events[i]; p.matches(e) && e.Status != "cancelled" {
+			p.starts[e.ID] = e.When.StartDateTime()
+		}
+	}
+	return nil
+}
+
+// poll returns the booking changes since the previous poll. The mark only
+// advances when the poll succeeds, and events at the mark are fetched again
+// but skipped by version.
+func (p *bookingPoller) poll(ctx context.Context) ([]bookingChange, error) {
+	events, err := p.list(ctx, &domain.EventQueryParams{UpdatedAfter: p.mark, ShowCancelled: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []bookingChange
+	mark := p.mark
+	for i := range events {
+		e := &events[i]
+		updated := e.UpdatedAt.Unix()
+		mark = max(mark, updated)
+		if !p.matches(e) || p.versions[e.ID] >= updated {
+			continue
+		}
+		p.versions[e.ID] = updated
+		if c, ok := p.classify(e); ok {
+			changes = append(changes, c)
+		}
+	}
+	p.mark = mark
+	return changes, nil
+}
+
+// classify decides what an updated booked event means. Updates that don't
+// move a known booking (e.g. a guest's RSVP) aren't reported.
+func (p *bookingPoller) classify(e *domain.Event) (bookingChange, bool) {
+	start := e.When.StartDateTime()
+	previous, known := p.starts[e.ID]
+
+	c := bookingChange{
+		EventID:         e.ID,
+		ConfigurationID: p.configID,
+		Title:           e.Title,
+		StartTime:       start,
+		EndTime:         e.When.EndDateTime(),
+		Participants:    bookingParticipants(e.Participants, p.organizer),
+		Source:          sourcePoll,
+	}
+	switch {
+	case e.Status == "cancelled":
+		if p.cancelled[e.ID] {
+			return c, false
+		}
+		p.cancelled[e.ID] = true
+		delete(p.starts, e.ID)
+		c.Action = bookingCancelled
+	case !known && !e.CreatedAt.Before(p.started.Truncate(time.Second)):
+		p.starts[e.ID] = start
+		c.Action = bookingCreated
+	case !known:
+		// A booking outside the seeded window: track it from now on.
+		p.starts[e.ID] = start
+		return c, false
+	case !previous.Equal(start):
+		p.starts[e.ID] = start
+		c.Action = bookingRescheduled
+		c.PreviousStart = &previous
+	default:
+		return c, false
+	}
+	return c, true
+}
+
+func (p *bookingPoller) list(ctx context.Context, params *domain.EventQueryParams) ([]domain.Event, error) {
+	params.Limit = common.MaxAPILimit
+	params.CalendarID = p.calendarID
+	return common.FetchCursorPages(ctx, params.Limit, 0, func(ctx context.Context, cursor string) (common.PageResult[domain.Event], error) {
+		params.PageToken = cursor
+		resp, err := p.client.GetEventsWithCursor(ctx, p.grantID, p.calendarID, params)
+		if err != nil {
+			return common.PageResult[domain.Event]{}, err
+		}
+		return common.PageResult[domain.Event]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+	})
+}
+
+// webhookBookingActions maps booking triggers to change actions.
+var webhookBookingActions = map[string]string{
+	domain.TriggerBookingCreated:     bookingCreated,
+	domain.TriggerBookingPending:     bookingPending,
+	domain.TriggerBookingRescheduled: bookingRescheduled,
+	domain.TriggerBookingCancelled:   bookingCancelled,
+}
+
+// webhookBookingChange converts a booking notification to a change. ok is
+// false for other triggers and other configurations.
+func webhookBookingChange(event *ports.WebhookEvent, configID string) (bookingChange, bool) {
+	action, ok := webhookBookingActions[event.Type]
+	if !ok {
+		return bookingChange{}, false
+	}
+	object := webhookObject(event.Body)
+	if object == nil {
+		return bookingChange{}, false
+	}
+	// Booking details are under booking_info, or on the object itself.
+	info, _ := object["booking_info"].(map[string]any)
+	if info == nil {
+		info = object
+	}
+	id := stringField(object, "configuration_id")
+	if id == "" {
+		id = stringField(info, "configuration_id")
+	}
+	if id != configID {
+		return bookingChange{}, false
+	}
+
+	c := bookingChange{
+		Action:          action,
+		BookingID:       stringField(object, "booking_id"),
+		EventID:         stringField(info, "event_id"),
+		ConfigurationID: configID,
+		Title:           stringField(info, "title"),
+		StartTime:       unixField(info, "start_time"),
+		EndTime:         unixField(info, "end_time"),
+		Source:          sourceWebhook,
+	}
+	participants, _ := info["participants"].([]any)
+	for _, raw := range participants {
+		if p, ok := raw.(map[string]any); ok {
+			if email := stringField(p, "email"); email != "" {
+				c.Participants = append(c.Participants, email)
+			}
+		}
+	}
+	return c, true
+}
+
+// startBookingWebhookReceiver runs a webhook server whose booking
+// notifications for the watched configuration go to w.
+func startBookingWebhookReceiver(ctx context.Context, opts bookingWatchOptions, w *bookingWatcher) (*webhookserver.Server, error) {
+	config := ports.WebhookServerConfig{Port: opts.port, Path: opts.path, WebhookSecret: opts.secret, TunnelProvider: opts.tunnelType}
+	if opts.secret != "" {
+		config.MaxEventAge = 5 * time.Minute
+	}
+	server := webhookserver.NewServer(config)
+
+	if opts.tunnelType != "" {
+		switch strings.ToLower(opts.tunnelType) {
+		case "cloudflared", "cloudflare", "cf":
+			if !tunnel.IsCloudflaredInstalled() {
+				return nil, common.NewUserError("cloudflared is not installed", "Install it with: brew install cloudflared")
+			}
+			server.SetTunnel(tunnel.NewCloudflaredTunnel(webhookserver.LocalBaseURL(opts.port)))
+		default:
+			return nil, common.NewUserError(fmt.Sprintf("unsupported tunnel provider: %s", opts.tunnelType), "Supported providers: cloudflared")
+		}
+	}
+
+	server.OnEvent(func(ev *ports.WebhookEvent) {
+		c, ok := webhookBookingChange(ev, opts.configID)
+		if !ok {
+			return
+		}
+		if err := w.handle(ctx, c); err != nil {
+			common.PrintWarningStderr("writing %s change: %v", ev.Type, err)
+		}
+	})
+
+	if err := server.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	// Drain the raw event stream; events are handled through OnEvent.
+	go func() {
+		for range server.Events() {
+		}
+	}()
+
+	if !common.IsQuiet() {
+		fmt.Fprintln(os.Stderr, common.Dim.Sprintf("Receiving booking webhooks at %s", server.GetPublicURL()))
+	}
+	return server, nil
+}
@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// fakeBookingCalendar serves events, applying the updated_after filter.
+type fakeBookingCalendar struct {
+	events []domain.Event
+	params []domain.EventQueryParams
+}
+
+func (f *fakeBookingCalendar) GetEventsWithCursor(_ context.Context, _, _ string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
+	f.params = append(f.params, *params)
+	resp := &domain.EventListResponse{}
+	for _, e := range f.events {
+		if e.UpdatedAt.Unix() >= params.UpdatedAfter {
+			resp.Data = append(resp.Data, e)
+		}
+	}
+	return resp, nil
+}
+
+func bookedEvent(id, title string, created, updated, start time.Time) domain.Event {
+	return domain.Event{
+		ID:        id,
+		Title:     title,
+		CreatedAt: created,
+		UpdatedAt: updated,
+		When:      domain.EventWhen{StartTime: start.Unix(), EndTime: start.Add(30 * time.Minute).Unix()},
+		Participants: []domain.Participant{
+			{Person: domain.Person{Email: "host@example.com"}},
+			{Person: domain.Person{Email: "guest@example.com"}},
+		},
+	}
+}
+
+func TestTitlePattern(t *testing.T) {
+	pattern := titlePattern("Demo with {{invitee}}")
+	assert.True(t, pattern.MatchString("Demo with Jane Doe"))
+	assert.True(t, pattern.MatchString("demo with jane"))
+	assert.False(t, pattern.MatchString("Team sync"))
+	assert.False(t, titlePattern("a.b").MatchString("axb"), "literal text is escaped")
+	assert.Nil(t, titlePattern(" "))
+}
+
+func TestBookingPoller(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	slot := now.Add(48 * time.Hour)
+	calendar := &fakeBookingCalendar{events: []domain.Event{
+		bookedEvent("evt-old", "Demo with Ann", now.Add(-time.Hour), now.Add(-time.Hour), slot),
+		bookedEvent("evt-other", "Team sync", now.Add(-time.Hour), now.Add(-time.Hour), slot),
+	}}
+	config := &domain.SchedulerConfiguration{
+		ID:           "cfg-1",
+		Participants: []domain.ConfigurationParticipant{{Email: "host@example.com", IsOrganizer: true, Booking: &domain.ParticipantBooking{CalendarID: "cal-bookings"}}},
+		EventBooking: domain.EventBooking{Title: "Demo with {{invitee}}"},
+	}
+	p := newBookingPoller(calendar, "grant-1", config, now)
+	require.Equal(t, "cal-bookings", p.calendarID)
+	require.NoError(t, p.seed(context.Background()))
+	assert.Equal(t, int64(now.AddDate(0, 0, defaultBookingWindowDays).Unix()), calendar.params[0].End)
+
+	// A new booking, a reschedule of the seeded one, and an unrelated event.
+	later := now.Add(time.Minute)
+	moved := bookedEvent("evt-old", "Demo with Ann", now.Add(-time.Hour), later, slot.Add(time.Hour))
+	calendar.events = []domain.Event{
+		bookedEvent("evt-new", "Demo with Bob", later, later, slot),
+		moved,
+		bookedEvent("evt-other", "Team sync", later, later, slot),
+	}
+	changes, err := p.poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, bookingCreated, changes[0].Action)
+	assert.Equal(t, "evt-new", changes[0].EventID)
+	assert.Equal(t, []string{"guest@example.com"}, changes[0].Participants, "the organizer is left out")
+	assert.Equal(t, bookingRescheduled, changes[1].Action)
+	require.NotNil(t, changes[1].PreviousStart)
+	assert.True(t, changes[1].PreviousStart.Equal(slot))
+	assert.True(t, calendar.params[1].ShowCancelled)
+
+	// The same versions come back at the mark and are skipped.
+	changes, err = p.poll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	cancelled := bookedEvent("evt-new", "Demo with Bob", later, later.Add(time.Minute), slot)
+	cancelled.Status = "cancelled"
+	calendar.events = append(calendar.events, cancelled)
+	changes, err = p.poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, bookingCancelled, changes[0].Action)
+}
+
+func TestWebhookBookingChange(t *testing.T) {
+	event := func(trigger string, object map[string]any) *ports.WebhookEvent {
+		return &ports.WebhookEvent{Type: trigger, Body: map[string]any{"data": map[string]any{"object": object}}}
+	}
+	object := map[string]any{
+		"booking_id":       "bk-1",
+		"configuration_id": "cfg-1",
+		"booking_info": map[string]any{
+			"event_id":     "evt-1",
+			"title":        "Demo",
+			"start_time":   float64(1_800_000_000),
+			"end_time":     float64(1_800_001_800),
+			"participants": []any{map[string]any{"email": "guest@example.com"}},
+		},
+	}
+
+	c, ok := webhookBookingChange(event(domain.TriggerBookingRescheduled, object), "cfg-1")
+	require.True(t, ok)
+	assert.Equal(t, bookingChange{
+		Action:          bookingRescheduled,
+		BookingID:       "bk-1",
+		EventID:         "evt-1",
+		ConfigurationID: "cfg-1",
+		Title:           "Demo",
+		StartTime:       time.Unix(1_800_000_000, 0),
+		EndTime:         time.Unix(1_800_001_800, 0),
+		Participants:    []string{"guest@example.com"},
+		Source:          sourceWebhook,
+	}, c)
+
+	_, ok = webhookBookingChange(event(domain.TriggerBookingCreated, object), "cfg-2")
+	assert.False(t, ok, "other configurations are ignored")
+	_, ok = webhookBookingChange(event(domain.TriggerBookingReminder, object), "cfg-1")
+	assert.False(t, ok, "reminders are not lifecycle changes")
+	_, ok = webhookBookingChange(event(domain.TriggerEventCreated, object), "cfg-1")
+	assert.False(t, ok)
+}
+
+func TestBookingWatcher_DeduplicatesAndRunsHandler(t *testing.T) {
+	var out bytes.Buffer
+	w := newBookingWatcher(&out, true, "notify")
+	var handled []string
+	w.runHandler = func(_ context.Context, command string, c bookingChange, _ io.Writer) error {
+		handled = append(handled, command+":"+c.Action+":"+c.Source)
+		return nil
+	}
+
+	start := time.Unix(1_800_000_000, 0)
+	polled := bookingChange{Action: bookingCreated, EventID: "evt-1", Title: "Demo", StartTime: start, Source: sourcePoll}
+	pushed := polled
+	pushed.BookingID, pushed.Source = "bk-1", sourceWebhook
+
+	require.NoError(t, w.handle(context.Background(), polled))
+	require.NoError(t, w.handle(context.Background(), pushed))
+
+	assert.Equal(t, []string{"notify:created:poll"}, handled)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 1)
+	var decoded bookingChange
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, "evt-1", decoded.EventID)
+}
+
+func TestRunBookingHandler(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("handler test uses a POSIX shell")
+	}
+	dir := t.TempDir()
+	c := bookingChange{Action: bookingCancelled, EventID: "evt-1", Title: "Demo", StartTime: time.Unix(1_800_000_000, 0).UTC()}
+
+	t.Setenv("DIR", dir)
+	var stdout bytes.Buffer
+	require.NoError(t, runBookingHandler(context.Background(), `cat > "$DIR/change.json"; echo "$NYLAS_BOOKING_ACTION $NYLAS_BOOKING_START"`, c, &stdout))
+	assert.Equal(t, "cancelled 2027-01-15T08:00:00Z\n", stdout.String())
+
+	body, err := os.ReadFile(filepath.Join(dir, "change.json"))
+	require.NoError(t, err)
+	var decoded bookingChange
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, "evt-1", decoded.EventID)
+
+	assert.Error(t, runBookingHandler(context.Background(), "exit 3", c, &stdout))
+}
+
+func TestValidateBookingWatchOptions(t *testing.T) {
+	base := bookingWatchOptions{configID: "cfg-1", interval: 30 * time.Second}
+	require.NoError(t, validateBookingWatchOptions(base))
+
+	tests := map[string]func(*bookingWatchOptions){
+		"missing config":        func(o *bookingWatchOptions) { o.configID = "" },
+		"short interval":        func(o *bookingWatchOptions) { o.interval = time.Millisecond },
+		"secret without port":   func(o *bookingWatchOptions) { o.secret = "s" },
+		"tunnel without secret": func(o *bookingWatchOptions) { o.port, o.tunnelType = 3000, "cloudflared" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := base
+			mutate(&opts)
+			assert.Error(t, validateBookingWatchOptions(opts))
+		})
+	}
+}
+
+func TestBookingWatchCmd_AcceptsConfigurationIDFlag(t *testing.T) {
+	cmd := newBookingWatchCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--configuration-id", "cfg-1"}))
+	value, err := cmd.Flags().GetString("config")
+	require.NoError(t, err)
+	assert.Equal(t, "cfg-1", value)
+}
@@ -2,13 +2,15 @@ package scheduler
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/nylas/cli/internal/ports"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // Scheduler configuration endpoints are grant-scoped
@@ -27,10 +29,11 @@ func newConfigurationsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/configurations/`,
 	}
 
-	cmd.AddCommand(newConfigListCmd())
-	cmd.AddCommand(newConfigShowCmd())
-	cmd.AddCommand(newConfigCreateCmd())
-	cmd.AddCommand(newConfigUpdateCmd())
+	cmd.AddCommand(common.DeclareOutput(newConfigListCmd(), []domain.SchedulerConfiguration{}))
+	cmd.AddCommand(common.DeclareOutput(newConfigShowCmd(), domain.SchedulerConfiguration{}))
+	cmd.AddCommand(common.DeclareOutput(newConfigCreateCmd(), domain.SchedulerConfiguration{}))
+	cmd.AddCommand(common.DeclareOutput(newConfigUpdateCmd(), domain.SchedulerConfiguration{}))
+	cmd.AddCommand(newConfigEditCmd())
 	cmd.AddCommand(newConfigDeleteCmd())
 
 	return cmd
@@ -50,8 +53,8 @@ func newConfigListCmd() *cobra.Command {
 					return struct{}{}, common.WrapListError("configurations", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(configs)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(configs)
 				}
 
 				if len(configs) == 0 {
@@ -91,8 +94,8 @@ func newConfigShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("configuration", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(config)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(config)
 				}
 
 				formatConfigDetails(cmd.OutOrStdout(), config)
@@ -114,6 +117,7 @@ func newConfigCreateCmd() *cobra.Command {
 		title        string
 		description  string
 		location     string
+		interactive  bool
 	)
 	flags := &configFlags{}
 
@@ -124,7 +128,11 @@ func newConfigCreateCmd() *cobra.Command {
 		Long: `Create a new scheduler configuration (meeting type).
 
 Use flags for common settings, or --file for full JSON config input.
-When both are provided, flags override file values.`,
+When both are provided, flags override file values.
+
+With --interactive, prompts walk through duration, availability windows,
+buffers, conferencing, booking limits, and reminders, using any flag or
+file values as defaults, and show the resulting JSON before submitting.`,
 		Example: `  # Simple inline creation
   nylas scheduler configs create --name "Quick Chat" --title "Quick Chat" \
     --participants alice@co.com --duration 15
@@ -138,13 +146,22 @@ When both are provided, flags override file values.`,
   nylas scheduler configs create --file config.json
 
   # File as base, override specific values
-  nylas scheduler configs create --file config.json --duration 60`,
+  nylas scheduler configs create --file config.json --duration 60
+
+  # Step through every setting with prompts
+  nylas scheduler configs create --interactive`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateConfigFlags(flags); err != nil {
 				return err
 			}
+			if interactive && !term.IsTerminal(int(os.Stdin.Fd())) {
+				return common.NewUserError(
+					"interactive creation requires a terminal",
+					"Use flags or --file in scripts.",
+				)
+			}
 
-			if flags.file == "" {
+			if flags.file == "" && !interactive {
 				if len(participants) == 0 {
 					return common.NewUserError("at least one participant email is required", "Use --participants to specify email addresses")
 				}
@@ -161,9 +178,27 @@ When both are provided, flags override file values.`,
 			if err != nil {
 				return err
 			}
+			// Prompts run before WithClient so the request timeout doesn't
+			// cut off a slow walkthrough.
+			prompter := huhConfigPrompter{}
+			if interactive {
+				if err := runConfigWizard(prompter, req); err != nil {
+					return err
+				}
+			}
 			if err := validateCreateRequest(req); err != nil {
 				return err
 			}
+			if interactive {
+				ok, err := confirmConfigRequest(prompter, req)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
 
 			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
 				config, err := client.CreateSchedulerConfiguration(ctx, grantID, req)
@@ -171,8 +206,8 @@ When both are provided, flags override file values.`,
 					return struct{}{}, common.WrapCreateError("configuration", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(config)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(config)
 				}
 
 				_, _ = common.Green.Printf("✓ Created configuration: %s\n", config.Name)
@@ -193,6 +228,7 @@ When both are provided, flags override file values.`,
 	cmd.Flags().StringVar(&title, "title", "", "Event title")
 	cmd.Flags().StringVar(&description, "description", "", "Event description")
 	cmd.Flags().StringVar(&location, "location", "", "Event location")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for each setting and review the JSON before creating")
 
 	registerConfigFlags(cmd, flags)
 
@@ -247,8 +283,8 @@ When both are provided, flags override file values.`,
 					return struct{}{}, common.WrapUpdateError("configuration", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(config)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(config)
 				}
 
 				common.PrintUpdateSuccess("configuration", config.Name)
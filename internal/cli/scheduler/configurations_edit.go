@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/nylas/cli/internal/tui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newConfigEditCmd() *cobra.Command {
+	var interactive bool
+
+	cmd := &cobra.Command{
+		Use:   "edit <config-id> [grant-id]",
+		Short: "Edit a scheduler configuration's availability in a weekly grid",
+		Long: `Edit the organizer's open hours, meeting duration, and buffers in a
+full-screen weekly grid, then save the result back to the configuration.
+
+Keys:
+  arrows      move between half-hour slots
+  space/enter toggle a slot open or closed
+  + / -       change the meeting duration by 5 minutes
+  [ / ]       change the buffer before meetings by 5 minutes
+  { / }       change the buffer after meetings by 5 minutes
+  s / ctrl+s  save and exit
+  q / esc     exit without saving
+
+Only the organizer's open hours are edited (or the first participant's when
+no organizer is marked); other participants are left unchanged. Only the days
+you change are written back. Open hours the grid can't show exactly, such as
+times off the half-hour or in another timezone, are not drawn and are kept
+as they are. For flag-based edits use "nylas scheduler configurations update".`,
+		Example: `  nylas scheduler configurations edit <config-id> --interactive`,
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !interactive {
+				return common.NewUserError(
+					"edit requires --interactive",
+					"Use 'nylas scheduler configurations update' for flag-based changes.",
+				)
+			}
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				return common.NewUserError(
+					"interactive editing requires a terminal",
+					"Use 'nylas scheduler configurations update --file' in scripts.",
+				)
+			}
+			return runConfigEdit(args[0], args[1:])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Open the weekly availability grid editor")
+
+	return cmd
+}
+
+// runConfigEdit fetches the configuration, runs the editor, and writes the
+// result back. The editor runs between two API calls rather than inside one
+// so the per-command request timeout doesn't cut off a long editing session.
+func runConfigEdit(configID string, grantArgs []string) error {
+	config, err := common.WithClient(grantArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (*domain.SchedulerConfiguration, error) {
+		return client.GetSchedulerConfiguration(ctx, grantID, configID)
+	})
+	if err != nil {
+		return common.WrapGetError("configuration", err)
+	}
+
+	idx := editableParticipant(config.Participants)
+	if idx < 0 {
+		return common.NewUserError(
+			"configuration has no participants to edit",
+			"Add a participant with 'nylas scheduler configurations update --file'.",
+		)
+	}
+
+	grid := tui.NewAvailabilityGrid(config.Participants[idx].Availability.OpenHours, config.Availability)
+	saved, err := tui.EditAvailabilityGrid(fmt.Sprintf("Availability: %s", config.Name), grid)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		fmt.Println("Cancelled. No changes saved.")
+		return nil
+	}
+
+	req := buildGridUpdateRequest(config, idx, grid)
+
+	updated, err := common.WithClient(grantArgs, func(ctx context.Context, client ports.NylasClient, grantID string) (*domain.SchedulerConfiguration, error) {
+		return client.UpdateSchedulerConfiguration(ctx, grantID, configID, req)
+	})
+	if err != nil {
+		return common.WrapUpdateError("configuration", err)
+	}
+
+	common.PrintUpdateSuccess("configuration", updated.Name)
+	return nil
+}
+
+// editableParticipant returns the index of the organizer, falling back to the
+// first participant, or -1 when there are none.
+func editableParticipant(participants []domain.ConfigurationParticipant) int {
+	for i, p := range participants {
+		if p.IsOrganizer {
+			return i
+		}
+	}
+	if len(participants) > 0 {
+		return 0
+	}
+	return -1
+}
+
+// buildGridUpdateRequest applies the edited grid to a copy of the configuration.
+// The full participant list is sent because the API replaces it wholesale.
+func buildGridUpdateRequest(config *domain.SchedulerConfiguration, idx int, grid *tui.AvailabilityGrid) *domain.UpdateSchedulerConfigurationRequest {
+	participants := make([]domain.ConfigurationParticipant, len(config.Participants))
+	copy(participants, config.Participants)
+	participants[idx].Availability.OpenHours = grid.OpenHours()
+
+	rules := grid.Rules(config.Availability)
+	return &domain.UpdateSchedulerConfigurationRequest{
+		Participants: participants,
+		Availability: &rules,
+	}
+}
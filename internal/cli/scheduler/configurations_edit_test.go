@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigEditCmd_RequiresInteractive(t *testing.T) {
+	isolateSchedulerCommandEnv(t)
+
+	err := executeSchedulerCommand(t, newConfigEditCmd(), "config-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--interactive")
+}
+
+func TestEditableParticipant_PrefersOrganizer(t *testing.T) {
+	participants := []domain.ConfigurationParticipant{
+		{Email: "a@example.com"},
+		{Email: "b@example.com", IsOrganizer: true},
+	}
+	assert.Equal(t, 1, editableParticipant(participants))
+	assert.Equal(t, 0, editableParticipant(participants[:1]))
+	assert.Equal(t, -1, editableParticipant(nil))
+}
+
+// The update must carry every participant: the API replaces the list, so
+// sending only the edited one would silently drop the others.
+func TestBuildGridUpdateRequest_KeepsOtherParticipants(t *testing.T) {
+	config := &domain.SchedulerConfiguration{
+		Participants: []domain.ConfigurationParticipant{
+			{Email: "org@example.com", IsOrganizer: true},
+			{Email: "other@example.com", Availability: domain.ConfigurationAvailability{
+				OpenHours: []domain.OpenHours{{Days: []int{1}, Start: "08:00", End: "10:00"}},
+			}},
+		},
+		Availability: domain.AvailabilityRules{DurationMinutes: 30, IntervalMinutes: 15},
+	}
+
+	grid := tui.NewAvailabilityGrid(nil, config.Availability)
+	grid.Toggle(2, 20) // Tuesday 10:00-10:30
+	grid.AdjustDuration(15)
+
+	req := buildGridUpdateRequest(config, 0, grid)
+
+	require.Len(t, req.Participants, 2)
+	assert.Equal(t, []domain.OpenHours{{Days: []int{2}, Start: "10:00", End: "10:30"}}, req.Participants[0].Availability.OpenHours)
+	assert.Equal(t, config.Participants[1], req.Participants[1])
+	assert.Empty(t, config.Participants[0].Availability.OpenHours, "original configuration must not be mutated")
+
+	require.NotNil(t, req.Availability)
+	assert.Equal(t, 45, req.Availability.DurationMinutes)
+	assert.Equal(t, 15, req.Availability.IntervalMinutes)
+}
@@ -25,6 +25,7 @@ type configFlags struct {
 	conferencingProvider string
 	disableEmails        bool
 	reminderMinutes      []int
+	reminders            []string
 
 	// Scheduler settings
 	minBookingNotice      int
@@ -52,6 +53,7 @@ func registerConfigFlags(cmd *cobra.Command, f *configFlags) {
 	cmd.Flags().StringVar(&f.conferencingProvider, "conferencing-provider", "", "Conferencing provider (Google Meet, Zoom, Microsoft Teams)")
 	cmd.Flags().BoolVar(&f.disableEmails, "disable-emails", false, "Disable email notifications")
 	cmd.Flags().IntSliceVar(&f.reminderMinutes, "reminder-minutes", nil, "Reminder minutes (comma-separated, e.g., 10,60)")
+	cmd.Flags().StringArrayVar(&f.reminders, "reminder", nil, "Reminder as <before>[:email|webhook[:host|guest|all[:subject]]] (repeatable, e.g., 1h:email:guest)")
 
 	// Scheduler settings
 	cmd.Flags().IntVar(&f.minBookingNotice, "min-booking-notice", 0, "Minimum minutes before a booking can be made")
@@ -61,7 +63,7 @@ func registerConfigFlags(cmd *cobra.Command, f *configFlags) {
 	cmd.Flags().StringVar(&f.cancellationPolicy, "cancellation-policy", "", "Cancellation policy text")
 
 	// File input
-	cmd.Flags().StringVar(&f.file, "file", "", "Path to JSON config file (flags override file values)")
+	cmd.Flags().StringVar(&f.file, "file", "", "Path to JSON or YAML config file (flags override file values)")
 }
 
 // validateConfigFlags validates enum flag values.
@@ -90,6 +92,11 @@ func validateConfigFlags(f *configFlags) error {
 			return err
 		}
 	}
+	for _, spec := range f.reminders {
+		if _, err := parseReminderSpec(spec); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -105,7 +112,7 @@ func buildCreateRequest(
 	req := &domain.CreateSchedulerConfigurationRequest{}
 
 	if f.file != "" {
-		if err := common.LoadJSONFile(f.file, req); err != nil {
+		if err := common.LoadJSONOrYAMLFile(f.file, req); err != nil {
 			return nil, err
 		}
 	}
@@ -206,6 +213,14 @@ func applyEventBookingFlags(cmd *cobra.Command, f *configFlags, booking *domain.
 	if cmd.Flags().Changed("reminder-minutes") {
 		booking.ReminderMinutes = f.reminderMinutes
 	}
+	if cmd.Flags().Changed("reminder") {
+		// Specs are validated up front by validateConfigFlags.
+		booking.Reminders = make([]domain.SchedulerReminder, 0, len(f.reminders))
+		for _, spec := range f.reminders {
+			reminder, _ := parseReminderSpec(spec)
+			booking.Reminders = append(booking.Reminders, reminder)
+		}
+	}
 	if cmd.Flags().Changed("conferencing-provider") {
 		booking.Conferencing = &domain.ConferencingSettings{
 			Provider:   f.conferencingProvider,
@@ -242,7 +257,7 @@ func buildUpdateRequest(
 	req := &domain.UpdateSchedulerConfigurationRequest{}
 
 	if f.file != "" {
-		if err := common.LoadJSONFile(f.file, req); err != nil {
+		if err := common.LoadJSONOrYAMLFile(f.file, req); err != nil {
 			return nil, err
 		}
 	}
@@ -324,7 +339,8 @@ func hasEventBookingFlags(cmd *cobra.Command) bool {
 		cmd.Flags().Changed("booking-type") ||
 		cmd.Flags().Changed("conferencing-provider") ||
 		cmd.Flags().Changed("disable-emails") ||
-		cmd.Flags().Changed("reminder-minutes")
+		cmd.Flags().Changed("reminder-minutes") ||
+		cmd.Flags().Changed("reminder")
 }
 
 func hasSchedulerFlags(cmd *cobra.Command) bool {
@@ -399,6 +415,9 @@ func formatConfigDetails(w io.Writer, config *domain.SchedulerConfiguration) {
 		}
 		_, _ = fmt.Fprintf(w, "  Reminders: %s minutes\n", strings.Join(parts, ", "))
 	}
+	for i, r := range config.EventBooking.Reminders {
+		_, _ = fmt.Fprintf(w, "  Reminder %d: %s\n", i+1, describeReminder(r))
+	}
 
 	s := config.Scheduler
 	if s.AvailableDaysInFuture > 0 || s.MinBookingNotice > 0 || s.MinCancellationNotice > 0 ||
@@ -0,0 +1,485 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+// configPrompter abstracts the prompts asked by the create wizard so the
+// answer-to-request mapping can be tested without a terminal.
+type configPrompter interface {
+	Input(title, def string) (string, error)
+	Confirm(title string, def bool) (bool, error)
+	Choose(title string, options []string, def string) (string, error)
+}
+
+// huhConfigPrompter is the production configPrompter.
+type huhConfigPrompter struct{}
+
+func (huhConfigPrompter) Input(title, def string) (string, error) {
+	return common.InputPrompt(title, def)
+}
+
+func (huhConfigPrompter) Confirm(title string, def bool) (bool, error) {
+	return common.ConfirmPrompt(title, def)
+}
+
+// Choose lists def first so it is the highlighted option.
+func (huhConfigPrompter) Choose(title string, options []string, def string) (string, error) {
+	opts := make([]common.SelectOption[string], 0, len(options))
+	for _, o := range options {
+		if o == def {
+			opts = append([]common.SelectOption[string]{{Label: o, Value: o}}, opts...)
+			continue
+		}
+		opts = append(opts, common.SelectOption[string]{Label: o, Value: o})
+	}
+	return common.Select(title, opts)
+}
+
+const conferencingNone = "None"
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// runConfigWizard walks through the configuration schema, starting from
+// whatever req already holds (flags and --file act as defaults).
+func runConfigWizard(p configPrompter, req *domain.CreateSchedulerConfigurationRequest) error {
+	steps := []func(configPrompter, *domain.CreateSchedulerConfigurationRequest) error{
+		wizardBasics,
+		wizardAvailability,
+		wizardOpenHours,
+		wizardConferencing,
+		wizardBookingLimits,
+		wizardReminders,
+	}
+	for _, step := range steps {
+		if err := step(p, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func wizardBasics(p configPrompter, req *domain.CreateSchedulerConfigurationRequest) error {
+	var err error
+	if req.Name, err = askRequired(p, "Configuration name", req.Name); err != nil {
+		return err
+	}
+	if req.EventBooking.Title, err = askRequired(p, "Event title", defaultString(req.EventBooking.Title, req.Name)); err != nil {
+		return err
+	}
+
+	var current []string
+	for _, participant := range req.Participants {
+		current = append(current, participant.Email)
+	}
+	for {
+		raw, err := p.Input("Participant emails (comma-separated, first is organizer)", strings.Join(current, ", "))
+		if err != nil {
+			return err
+		}
+		emails := splitList(raw)
+		if len(emails) == 0 {
+			common.PrintWarningStderr("at least one participant is required")
+			continue
+		}
+		if !sameEmails(emails, current) {
+			req.Participants = buildParticipants(emails)
+		}
+		return nil
+	}
+}
+
+func wizardAvailability(p configPrompter, req *domain.CreateSchedulerConfigurationRequest) error {
+	avail := &req.Availability
+	var err error
+	if avail.DurationMinutes, err = askMinutes(p, "Meeting duration (minutes)", defaultInt(avail.DurationMinutes, 30), 1); err != nil {
+		return err
+	}
+	if avail.IntervalMinutes, err = askMinutes(p, "Slot interval (minutes, 0 for the duration)", avail.IntervalMinutes, 0); err != nil {
+		return err
+	}
+
+	buffer := domain.AvailabilityBuffer{}
+	if avail.Buffer != nil {
+		buffer = *avail.Buffer
+	}
+	if buffer.Before, err = askMinutes(p, "Buffer before meetings (minutes)", buffer.Before, 0); err != nil {
+		return err
+	}
+	if buffer.After, err = askMinutes(p, "Buffer after meetings (minutes)", buffer.After, 0); err != nil {
+		return err
+	}
+	avail.Buffer = nil
+	if buffer.Before > 0 || buffer.After > 0 {
+		avail.Buffer = &buffer
+	}
+
+	tz, err := p.Input("Timezone", defaultString(req.EventBooking.Timezone, localTimezone()))
+	if err != nil {
+		return err
+	}
+	tz = strings.TrimSpace(tz)
+	if _, err := time.LoadLocation(tz); err != nil {
+		return common.NewInputError(fmt.Sprintf("unknown timezone %q", tz))
+	}
+	req.EventBooking.Timezone = tz
+	return nil
+}
+
+// wizardOpenHours sets the organizer's weekly availability windows.
+func wizardOpenHours(p configPrompter, req *domain.CreateSchedulerConfigurationRequest) error {
+	idx := editableParticipant(req.Participants)
+	if idx < 0 {
+		return nil
+	}
+	participant := &req.Participants[idx]
+	existing := participant.Availability.OpenHours
+
+	edit, err := p.Confirm(fmt.Sprintf("Set open hours for %s?", participant.Email), len(existing) == 0)
+	if err != nil || !edit {
+		return err
+	}
+
+	var windows []domain.OpenHours
+	for i := 0; ; i++ {
+		def := domain.OpenHours{Days: []int{1, 2, 3, 4, 5}, Start: "09:00", End: "17:00"}
+		if i < len(existing) {
+			def = existing[i]
+		}
+		window, err := askOpenHours(p, len(windows)+1, def)
+		if err != nil {
+			return err
+		}
+		window.Timezone = req.EventBooking.Timezone
+		windows = append(windows, window)
+
+		more, err := p.Confirm("Add another availability window?", i+1 < len(existing))
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+	}
+	participant.Availability.OpenHours = windows
+	return nil
+}
+
+func askOpenHours(p configPrompter, n int, def domain.OpenHours) (domain.OpenHours, error) {
+	window := domain.OpenHours{}
+	for {
+		raw, err := p.Input(fmt.Sprintf("Window %d days (e.g. mon-fri, sat,sun)", n), formatWeekdays(def.Days))
+		if err != nil {
+			return window, err
+		}
+		if window.Days, err = parseWeekdays(raw); err == nil {
+			break
+		}
+		common.PrintWarningStderr("%v", err)
+	}
+	for {
+		var err error
+		if window.Start, err = askClock(p, fmt.Sprintf("Window %d start (HH:MM)", n), def.Start); err != nil {
+			return window, err
+		}
+		if window.End, err = askClock(p, fmt.Sprintf("Window %d end (HH:MM)", n), def.End); err != nil {
+			return window, err
+		}
+		if window.Start < window.End {
+			return window, nil
+		}
+		common.PrintWarningStderr("the window must end after it starts")
+	}
+}
+
+func wizardConferencing(p configPrompter, req *domain.CreateSchedulerConfigurationRequest) error {
+	booking := &req.EventBooking
+	current := conferencingNone
+	if booking.Conferencing != nil && booking.Conferencing.Provider != "" {
+		current = booking.Conferencing.Provider
+	}
+	provider, err := p.Choose("Conferencing", []string{conferencingNone, "Google Meet", "Zoom", "Microsoft Teams"}, current)
+	if err != nil {
+		return err
+	}
+	if provider == conferencingNone {
+		booking.Conferencing = nil
+		return nil
+	}
+	autocreate, err := p.Confirm(fmt.Sprintf("Create a %s link for each booking?", provider), true)
+	if err != nil {
+		return err
+	}
+	booking.Conferencing = &domain.ConferencingSettings{Provider: provider, Autocreate: autocreate}
+	return nil
+}
+
+func wizardBookingLimits(p configPrompter, req *domain.CreateSchedulerConfigurationRequest) error {
+	sched := &req.Scheduler
+	var err error
+	if sched.AvailableDaysInFuture, err = askNumber(p, "How many days ahead can guests book? (0 for the API default)", sched.AvailableDaysInFuture, 0, 24*time.Hour); err != nil {
+		return err
+	}
+	if sched.MinBookingNotice, err = askMinutes(p, "Minimum notice before a booking (minutes)", sched.MinBookingNotice, 0); err != nil {
+		return err
+	}
+	if sched.MinCancellationNotice, err = askMinutes(p, "Minimum notice before a cancellation (minutes)", sched.MinCancellationNotice, 0); err != nil {
+		return err
+	}
+
+	bookingType := defaultString(req.EventBooking.BookingType, "booking")
+	if req.EventBooking.BookingType, err = p.Choose("Booking type", []string{"booking", "organizer-confirmation"}, bookingType); err != nil {
+		return err
+	}
+	return nil
+}
+
+// wizardReminders reuses the --reminder spec syntax so the wizard and flags
+// accept the same reminders.
+func wizardReminders(p configPrompter, req *domain.CreateSchedulerConfigurationRequest) error {
+	var current []string
+	for _, r := range req.EventBooking.Reminders {
+		current = append(current, formatReminderSpec(r))
+	}
+	for {
+		raw, err := p.Input("Reminders (comma-separated, e.g. 1d:email:guest, 15m:webhook; empty for none)", strings.Join(current, ", "))
+		if err != nil {
+			return err
+		}
+		reminders, err := parseReminderSpecs(splitList(raw))
+		if err != nil {
+			common.PrintWarningStderr("%v", err)
+			continue
+		}
+		req.EventBooking.Reminders = reminders
+		return nil
+	}
+}
+
+func parseReminderSpecs(specs []string) ([]domain.SchedulerReminder, error) {
+	var reminders []domain.SchedulerReminder
+	for _, spec := range specs {
+		reminder, err := parseReminderSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, nil
+}
+
+func formatReminderSpec(r domain.SchedulerReminder) string {
+	parts := []string{strconv.Itoa(r.MinutesBeforeEvent), r.Type}
+	if r.Type == "email" {
+		parts = append(parts, r.Recipient)
+		if r.EmailSubject != "" {
+			parts = append(parts, r.EmailSubject)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// confirmConfigRequest prints the request body and asks before submitting.
+func confirmConfigRequest(p configPrompter, req *domain.CreateSchedulerConfigurationRequest) (bool, error) {
+	body, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	fmt.Println()
+	fmt.Println(common.Dim.Sprintf("Configuration to create:"))
+	fmt.Println(string(body))
+	fmt.Println()
+	return p.Confirm("Create this configuration?", true)
+}
+
+func askRequired(p configPrompter, title, def string) (string, error) {
+	for {
+		value, err := p.Input(title, def)
+		if err != nil {
+			return "", err
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			return value, nil
+		}
+		common.PrintWarningStderr("%s is required", strings.ToLower(title))
+	}
+}
+
+// askMinutes reads a whole number of minutes no smaller than minValue.
+// Durations such as "1h" are accepted and converted.
+func askMinutes(p configPrompter, title string, def, minValue int) (int, error) {
+	return askNumber(p, title, def, minValue, time.Minute)
+}
+
+// askNumber reads a whole number no smaller than minValue, converting
+// durations to a count of unit.
+func askNumber(p configPrompter, title string, def, minValue int, unit time.Duration) (int, error) {
+	for {
+		raw, err := p.Input(title, strconv.Itoa(def))
+		if err != nil {
+			return 0, err
+		}
+		raw = strings.TrimSpace(raw)
+		n, convErr := strconv.Atoi(raw)
+		if convErr != nil {
+			d, durErr := common.ParseDuration(raw)
+			if durErr != nil {
+				common.PrintWarningStderr("%q is not a number", raw)
+				continue
+			}
+			n = int(d / unit)
+		}
+		if n < minValue {
+			common.PrintWarningStderr("value must be at least %d", minValue)
+			continue
+		}
+		return n, nil
+	}
+}
+
+func askClock(p configPrompter, title, def string) (string, error) {
+	for {
+		raw, err := p.Input(title, def)
+		if err != nil {
+			return "", err
+		}
+		if clock, ok := normalizeClock(raw); ok {
+			return clock, nil
+		}
+		common.PrintWarningStderr("%q is not a time of day (HH:MM)", raw)
+	}
+}
+
+// normalizeClock accepts "9:00", "09:00", and "24:00" and returns zero-padded
+// HH:MM so windows compare as strings.
+func normalizeClock(s string) (string, bool) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return "", false
+	}
+	hour, err1 := strconv.Atoi(h)
+	minute, err2 := strconv.Atoi(m)
+	if err1 != nil || err2 != nil || len(m) != 2 || hour < 0 || minute < 0 || minute > 59 {
+		return "", false
+	}
+	if hour > 24 || (hour == 24 && minute != 0) {
+		return "", false
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), true
+}
+
+// parseWeekdays parses day lists like "mon-fri", "sat,sun", or "1-5"
+// (0=Sunday) into sorted, de-duplicated day numbers.
+func parseWeekdays(s string) ([]int, error) {
+	seen := make(map[int]bool)
+	for _, part := range splitList(s) {
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := parseWeekday(from)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseWeekday(to); err != nil {
+				return nil, err
+			}
+		}
+		for d := start; ; d = (d + 1) % 7 {
+			seen[d] = true
+			if d == end {
+				break
+			}
+		}
+	}
+	if len(seen) == 0 {
+		return nil, common.NewInputError("at least one day is required")
+	}
+	var days []int
+	for d := 0; d < 7; d++ {
+		if seen[d] {
+			days = append(days, d)
+		}
+	}
+	return days, nil
+}
+
+func parseWeekday(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 6 {
+		return n, nil
+	}
+	if len(s) >= 3 {
+		for i, name := range weekdayNames {
+			if strings.HasPrefix(s, name) {
+				return i, nil
+			}
+		}
+	}
+	return 0, common.NewInputError(fmt.Sprintf("unknown day %q (use mon, tue, ... or 0-6 with 0=Sunday)", s))
+}
+
+func formatWeekdays(days []int) string {
+	names := make([]string, 0, len(days))
+	for _, d := range days {
+		if d >= 0 && d < len(weekdayNames) {
+			names = append(names, weekdayNames[d])
+		}
+	}
+	if slices.Equal(days, []int{1, 2, 3, 4, 5}) {
+		return "mon-fri"
+	}
+	return strings.Join(names, ",")
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func sameEmails(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func defaultString(value, def string) string {
+	if value != "" {
+		return value
+	}
+	return def
+}
+
+func defaultInt(value, def int) int {
+	if value != 0 {
+		return value
+	}
+	return def
+}
+
+// localTimezone returns the IANA name of the local zone, or UTC when the
+// system doesn't expose one.
+func localTimezone() string {
+	if name := time.Local.String(); name != "" && name != "Local" {
+		return name
+	}
+	return "UTC"
+}
@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedPrompter answers prompts in order. An empty answer accepts the
+// prompt's default, as pressing enter does in the terminal.
+type scriptedPrompter struct {
+	answers []string
+}
+
+var errScriptExhausted = errors.New("no scripted answer left")
+
+func (s *scriptedPrompter) next() (string, bool, error) {
+	if len(s.answers) == 0 {
+		return "", false, errScriptExhausted
+	}
+	answer := s.answers[0]
+	s.answers = s.answers[1:]
+	return answer, answer != "", nil
+}
+
+func (s *scriptedPrompter) Input(_, def string) (string, error) {
+	answer, ok, err := s.next()
+	if err != nil || !ok {
+		return def, err
+	}
+	return answer, nil
+}
+
+func (s *scriptedPrompter) Confirm(_ string, def bool) (bool, error) {
+	answer, ok, err := s.next()
+	if err != nil || !ok {
+		return def, err
+	}
+	return answer == "y", nil
+}
+
+func (s *scriptedPrompter) Choose(_ string, _ []string, def string) (string, error) {
+	answer, ok, err := s.next()
+	if err != nil || !ok {
+		return def, err
+	}
+	return answer, nil
+}
+
+func TestRunConfigWizard_BuildsFullRequest(t *testing.T) {
+	p := &scriptedPrompter{answers: []string{
+		"Product Demo",                       // name
+		"",                                   // title defaults to the name
+		"alice@example.com, bob@example.com", // participants
+		"45",                                 // duration
+		"15",                                 // interval
+		"5",                                  // buffer before
+		"10m",                                // buffer after
+		"America/New_York",                   // timezone
+		"y",                                  // set open hours
+		"mon-thu",                            // window 1 days
+		"9:00",                               // window 1 start
+		"12:00",                              // window 1 end
+		"y",                                  // another window
+		"fri",                                // window 2 days
+		"13:00",                              // window 2 start
+		"15:30",                              // window 2 end
+		"n",                                  // no more windows
+		"Zoom",                               // conferencing
+		"y",                                  // autocreate
+		"2w",                                 // days in future
+		"1h",                                 // min booking notice
+		"120",                                // min cancellation notice
+		"organizer-confirmation",             // booking type
+		"1d:email:guest, 15m:webhook",        // reminders
+	}}
+	req := &domain.CreateSchedulerConfigurationRequest{}
+
+	require.NoError(t, runConfigWizard(p, req))
+	assert.Empty(t, p.answers, "every scripted answer is consumed")
+
+	assert.Equal(t, "Product Demo", req.Name)
+	assert.Equal(t, "Product Demo", req.EventBooking.Title)
+	require.Len(t, req.Participants, 2)
+	assert.True(t, req.Participants[0].IsOrganizer)
+	assert.Equal(t, []domain.OpenHours{
+		{Days: []int{1, 2, 3, 4}, Start: "09:00", End: "12:00", Timezone: "America/New_York"},
+		{Days: []int{5}, Start: "13:00", End: "15:30", Timezone: "America/New_York"},
+	}, req.Participants[0].Availability.OpenHours)
+	assert.Empty(t, req.Participants[1].Availability.OpenHours)
+
+	assert.Equal(t, domain.AvailabilityRules{
+		DurationMinutes: 45,
+		IntervalMinutes: 15,
+		Buffer:          &domain.AvailabilityBuffer{Before: 5, After: 10},
+	}, req.Availability)
+	assert.Equal(t, &domain.ConferencingSettings{Provider: "Zoom", Autocreate: true}, req.EventBooking.Conferencing)
+	assert.Equal(t, "organizer-confirmation", req.EventBooking.BookingType)
+	assert.Equal(t, domain.SchedulerSettings{AvailableDaysInFuture: 14, MinBookingNotice: 60, MinCancellationNotice: 120}, req.Scheduler)
+	assert.Equal(t, []domain.SchedulerReminder{
+		{Type: "email", MinutesBeforeEvent: 1440, Recipient: "guest"},
+		{Type: "webhook", MinutesBeforeEvent: 15},
+	}, req.EventBooking.Reminders)
+}
+
+// Flag and file values are offered as defaults, so accepting every prompt
+// leaves them unchanged.
+func TestRunConfigWizard_KeepsDefaults(t *testing.T) {
+	openHours := []domain.OpenHours{{Days: []int{1, 3}, Start: "10:00", End: "11:00", Timezone: "Europe/Paris"}}
+	req := &domain.CreateSchedulerConfigurationRequest{
+		Name: "Quick Chat",
+		Participants: []domain.ConfigurationParticipant{{
+			Email:        "alice@example.com",
+			IsOrganizer:  true,
+			Availability: domain.ConfigurationAvailability{CalendarIDs: []string{"cal-1"}, OpenHours: openHours},
+		}},
+		Availability: domain.AvailabilityRules{DurationMinutes: 15},
+		EventBooking: domain.EventBooking{
+			Title:        "Chat",
+			Timezone:     "Europe/Paris",
+			Conferencing: &domain.ConferencingSettings{Provider: "Google Meet", Autocreate: true},
+			Reminders:    []domain.SchedulerReminder{{Type: "email", MinutesBeforeEvent: 30, Recipient: "all"}},
+		},
+	}
+	// name, title, participants, duration, interval, buffers x2, timezone,
+	// open hours (declined), conferencing, autocreate, limits x3, booking
+	// type, reminders.
+	p := &scriptedPrompter{answers: make([]string, 16)}
+
+	require.NoError(t, runConfigWizard(p, req))
+	assert.Empty(t, p.answers)
+
+	assert.Equal(t, "Chat", req.EventBooking.Title)
+	assert.Equal(t, []string{"cal-1"}, req.Participants[0].Availability.CalendarIDs, "unchanged participants are kept as given")
+	assert.Equal(t, openHours, req.Participants[0].Availability.OpenHours)
+	assert.Equal(t, 15, req.Availability.DurationMinutes)
+	assert.Nil(t, req.Availability.Buffer)
+	assert.Equal(t, "Google Meet", req.EventBooking.Conferencing.Provider)
+	assert.Equal(t, "booking", req.EventBooking.BookingType)
+	assert.Equal(t, []domain.SchedulerReminder{{Type: "email", MinutesBeforeEvent: 30, Recipient: "all"}}, req.EventBooking.Reminders)
+}
+
+func TestRunConfigWizard_RepromptsInvalidAnswers(t *testing.T) {
+	p := &scriptedPrompter{answers: []string{
+		"Demo", "", "a@example.com",
+		"soon", "0", "30", // duration: not a number, below minimum, then valid
+		"", "", "", "UTC",
+		"y", "someday", "mon", "17:00", "9:00", "9:00", "17:00", "n",
+		"None",
+		"", "", "", "",
+		"5:carrier-pigeon", "",
+	}}
+	req := &domain.CreateSchedulerConfigurationRequest{}
+
+	require.NoError(t, runConfigWizard(p, req))
+	assert.Empty(t, p.answers)
+	assert.Equal(t, 30, req.Availability.DurationMinutes)
+	assert.Equal(t, []domain.OpenHours{{Days: []int{1}, Start: "09:00", End: "17:00", Timezone: "UTC"}}, req.Participants[0].Availability.OpenHours)
+	assert.Nil(t, req.EventBooking.Conferencing)
+	assert.Empty(t, req.EventBooking.Reminders)
+}
+
+func TestRunConfigWizard_RejectsUnknownTimezone(t *testing.T) {
+	p := &scriptedPrompter{answers: []string{"Demo", "", "a@example.com", "", "", "", "", "Mars/Olympus"}}
+
+	err := runConfigWizard(p, &domain.CreateSchedulerConfigurationRequest{})
+	assert.ErrorContains(t, err, "Mars/Olympus")
+}
+
+func TestParseWeekdays(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{in: "mon-fri", want: []int{1, 2, 3, 4, 5}},
+		{in: "Sat, sun", want: []int{0, 6}},
+		{in: "fri-mon", want: []int{0, 1, 5, 6}},
+		{in: "1-3,monday", want: []int{1, 2, 3}},
+		{in: "", wantErr: true},
+		{in: "funday", wantErr: true},
+		{in: "7", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseWeekdays(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.Equal(t, "mon-fri", formatWeekdays([]int{1, 2, 3, 4, 5}))
+	assert.Equal(t, "sun,sat", formatWeekdays([]int{0, 6}))
+}
+
+func TestNormalizeClock(t *testing.T) {
+	for in, want := range map[string]string{"9:00": "09:00", "17:30": "17:30", "24:00": "24:00"} {
+		got, ok := normalizeClock(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got)
+	}
+	for _, in := range []string{"9", "9:5", "24:30", "12:60", "noon"} {
+		_, ok := normalizeClock(in)
+		assert.False(t, ok, in)
+	}
+}
+
+func TestConfigCreateCmd_InteractiveRequiresTerminal(t *testing.T) {
+	isolateSchedulerCommandEnv(t)
+
+	err := executeSchedulerCommand(t, newConfigCreateCmd(), "--interactive")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "terminal")
+}
@@ -29,11 +29,11 @@ every command takes a configuration ID.
 API reference: https://developer.nylas.com/docs/v3/scheduler/`,
 	}
 
-	cmd.AddCommand(newGroupEventsListCmd())
-	cmd.AddCommand(newGroupEventCreateCmd())
-	cmd.AddCommand(newGroupEventUpdateCmd())
-	cmd.AddCommand(newGroupEventDeleteCmd())
-	cmd.AddCommand(newGroupEventsImportCmd())
+	cmd.AddCommand(common.DeclareOutput(newGroupEventsListCmd(), []domain.GroupEvent{}))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newGroupEventCreateCmd(), []domain.GroupEvent{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newGroupEventUpdateCmd(), []domain.GroupEvent{}), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(newGroupEventDeleteCmd(), domain.ScopeCalendarWrite))
+	cmd.AddCommand(common.RequireScopes(common.DeclareOutput(newGroupEventsImportCmd(), []domain.GroupEvent{}), domain.ScopeCalendarWrite))
 
 	return cmd
 }
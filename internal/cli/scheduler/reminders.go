@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reminderTypes      = []string{"email", "webhook"}
+	reminderRecipients = []string{"host", "guest", "all"}
+)
+
+func newRemindersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reminders",
+		Short: "Work with booking reminders",
+		Long: `Work with the reminders a scheduler configuration sends before booked events.
+
+Reminders are configured with --reminder on "configurations create/update",
+or under event_booking.reminders in a --file (JSON or YAML).`,
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newRemindersTestCmd(), domain.Message{}, domain.SendMessageRequest{}))
+
+	return cmd
+}
+
+func newRemindersTestCmd() *cobra.Command {
+	var (
+		to     string
+		index  int
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "test <config-id> [grant-id]",
+		Short: "Send yourself a rendered sample of a reminder email",
+		Long: `Render an email reminder from a scheduler configuration for a sample booking
+and send it to yourself, to check timing and subject before guests see it.
+
+The sample is sent from and to the grant's own address unless --to is given.
+It approximates the email Nylas sends; the exact layout is rendered by Nylas.`,
+		Example: `  # Send the first email reminder to yourself
+  nylas scheduler reminders test <config-id>
+
+  # Preview the second reminder without sending
+  nylas scheduler reminders test <config-id> --reminder 2 --dry-run`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configID := args[0]
+
+			_, err := common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				config, err := client.GetSchedulerConfiguration(ctx, grantID, configID)
+				if err != nil {
+					return struct{}{}, common.WrapGetError("configuration", err)
+				}
+				reminder, err := selectEmailReminder(config.EventBooking.Reminders, index)
+				if err != nil {
+					return struct{}{}, err
+				}
+
+				recipient := to
+				if recipient == "" {
+					grant, err := client.GetGrant(ctx, grantID)
+					if err != nil {
+						return struct{}{}, common.WrapGetError("grant", err)
+					}
+					recipient = grant.Email
+				}
+
+				req := renderReminderSample(config, reminder, recipient, time.Now())
+				if dryRun {
+					return struct{}{}, printReminderSample(cmd, req)
+				}
+
+				msg, err := client.SendMessage(ctx, grantID, req)
+				if err != nil {
+					return struct{}{}, common.WrapSendError("reminder sample", err)
+				}
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(msg)
+				}
+				common.PrintSuccess("Sent reminder sample to %s", recipient)
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Recipient address (defaults to the grant's email)")
+	cmd.Flags().IntVar(&index, "reminder", 0, "Reminder number to render, as listed by 'configurations show' (default: first email reminder)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the rendered reminder without sending")
+
+	return common.RequireScopes(cmd, domain.ScopeEmailSend)
+}
+
+// parseReminderSpec parses a --reminder value of the form
+// <before>[:type[:recipient[:subject]]], where <before> is minutes or a
+// duration such as 1h or 1d. The subject may itself contain colons.
+func parseReminderSpec(spec string) (domain.SchedulerReminder, error) {
+	parts := strings.SplitN(spec, ":", 4)
+	reminder := domain.SchedulerReminder{Type: "email"}
+
+	before := strings.TrimSpace(parts[0])
+	if minutes, err := strconv.Atoi(before); err == nil {
+		reminder.MinutesBeforeEvent = minutes
+	} else {
+		d, err := common.ParseDuration(before)
+		if err != nil {
+			return reminder, common.NewInputError(fmt.Sprintf("invalid --reminder %q: %s is not minutes or a duration", spec, before))
+		}
+		reminder.MinutesBeforeEvent = int(d / time.Minute)
+	}
+	if reminder.MinutesBeforeEvent <= 0 {
+		return reminder, common.NewInputError(fmt.Sprintf("invalid --reminder %q: time before the event must be positive", spec))
+	}
+
+	if len(parts) > 1 && parts[1] != "" {
+		reminder.Type = strings.ToLower(strings.TrimSpace(parts[1]))
+		if err := common.ValidateOneOf("reminder type", reminder.Type, reminderTypes); err != nil {
+			return reminder, err
+		}
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		reminder.Recipient = strings.ToLower(strings.TrimSpace(parts[2]))
+		if err := common.ValidateOneOf("reminder recipient", reminder.Recipient, reminderRecipients); err != nil {
+			return reminder, err
+		}
+	}
+	if len(parts) > 3 {
+		reminder.EmailSubject = strings.TrimSpace(parts[3])
+	}
+
+	if reminder.Type == "webhook" && (reminder.Recipient != "" || reminder.EmailSubject != "") {
+		return reminder, common.NewInputError(fmt.Sprintf("invalid --reminder %q: recipient and subject apply only to email reminders", spec))
+	}
+	if reminder.Type == "email" && reminder.Recipient == "" {
+		reminder.Recipient = "all"
+	}
+	return reminder, nil
+}
+
+// describeReminder returns a one-line summary of a reminder.
+func describeReminder(r domain.SchedulerReminder) string {
+	desc := fmt.Sprintf("%s %s before", r.Type, formatReminderLead(r.MinutesBeforeEvent))
+	if r.Recipient != "" {
+		desc += " to " + r.Recipient
+	}
+	if r.EmailSubject != "" {
+		desc += fmt.Sprintf(" (subject: %q)", r.EmailSubject)
+	}
+	return desc
+}
+
+// formatReminderLead formats minutes in the largest whole unit.
+func formatReminderLead(minutes int) string {
+	switch {
+	case minutes%(24*60) == 0:
+		return fmt.Sprintf("%dd", minutes/(24*60))
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// selectEmailReminder picks the 1-based reminder n, or the first email
+// reminder when n is 0.
+func selectEmailReminder(reminders []domain.SchedulerReminder, n int) (domain.SchedulerReminder, error) {
+	if n == 0 {
+		for _, r := range reminders {
+			if r.Type == "email" {
+				return r, nil
+			}
+		}
+		return domain.SchedulerReminder{}, common.NewUserError(
+			"configuration has no email reminders",
+			"Add one with 'nylas scheduler configurations update <config-id> --reminder 1h:email'",
+		)
+	}
+	if n < 0 || n > len(reminders) {
+		return domain.SchedulerReminder{}, common.NewInputError(
+			fmt.Sprintf("--reminder %d is out of range (configuration has %d reminders)", n, len(reminders)))
+	}
+	r := reminders[n-1]
+	if r.Type != "email" {
+		return r, common.NewInputError(fmt.Sprintf("reminder %d is a %s reminder; only email reminders can be sent", n, r.Type))
+	}
+	return r, nil
+}
+
+// renderReminderSample builds the sample email for a booking that starts
+// exactly one reminder lead time from now.
+func renderReminderSample(config *domain.SchedulerConfiguration, r domain.SchedulerReminder, recipient string, now time.Time) *domain.SendMessageRequest {
+	booking := config.EventBooking
+	loc := time.Local
+	if booking.Timezone != "" {
+		if tz, err := time.LoadLocation(booking.Timezone); err == nil {
+			loc = tz
+		}
+	}
+	start := now.Add(time.Duration(r.MinutesBeforeEvent) * time.Minute).In(loc)
+	end := start.Add(time.Duration(config.Availability.DurationMinutes) * time.Minute)
+
+	title := booking.Title
+	if title == "" {
+		title = config.Name
+	}
+	subject := r.EmailSubject
+	if subject == "" {
+		subject = fmt.Sprintf("Reminder: %s starts in %s", title, formatReminderLead(r.MinutesBeforeEvent))
+	}
+
+	var body strings.Builder
+	body.WriteString("<p><strong>[Sample reminder]</strong> ")
+	body.WriteString(html.EscapeString(fmt.Sprintf("Sent %s before the event to %s.", formatReminderLead(r.MinutesBeforeEvent), r.Recipient)))
+	body.WriteString("</p>\n<h2>" + html.EscapeString(title) + "</h2>\n<p>")
+	body.WriteString(html.EscapeString(fmt.Sprintf("%s – %s", start.Format("Mon, Jan 2, 2006 3:04 PM"), end.Format("3:04 PM MST"))))
+	body.WriteString("</p>\n")
+	if booking.Location != "" {
+		body.WriteString("<p>Location: " + html.EscapeString(booking.Location) + "</p>\n")
+	}
+	if booking.Conferencing != nil && booking.Conferencing.Provider != "" {
+		body.WriteString("<p>Join with " + html.EscapeString(booking.Conferencing.Provider) + "</p>\n")
+	}
+	if booking.Description != "" {
+		body.WriteString("<p>" + html.EscapeString(booking.Description) + "</p>\n")
+	}
+
+	return &domain.SendMessageRequest{
+		Subject: subject,
+		Body:    body.String(),
+		To:      []domain.EmailParticipant{{Email: recipient}},
+	}
+}
+
+func printReminderSample(cmd *cobra.Command, req *domain.SendMessageRequest) error {
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).Write(req)
+	}
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "To:      %s\n", req.To[0].Email)
+	_, _ = fmt.Fprintf(out, "Subject: %s\n\n", req.Subject)
+	_, _ = fmt.Fprintln(out, req.Body)
+	return nil
+}
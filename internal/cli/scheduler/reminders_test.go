@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReminderSpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    domain.SchedulerReminder
+		wantErr string
+	}{
+		{spec: "30", want: domain.SchedulerReminder{Type: "email", MinutesBeforeEvent: 30, Recipient: "all"}},
+		{spec: "1h:email:guest", want: domain.SchedulerReminder{Type: "email", MinutesBeforeEvent: 60, Recipient: "guest"}},
+		{spec: "1d:email:host:Tomorrow: demo", want: domain.SchedulerReminder{
+			Type: "email", MinutesBeforeEvent: 1440, Recipient: "host", EmailSubject: "Tomorrow: demo",
+		}},
+		{spec: "15m:webhook", want: domain.SchedulerReminder{Type: "webhook", MinutesBeforeEvent: 15}},
+		{spec: "soon", wantErr: "not minutes or a duration"},
+		{spec: "0", wantErr: "must be positive"},
+		{spec: "1h:sms", wantErr: "reminder type"},
+		{spec: "1h:email:everyone", wantErr: "reminder recipient"},
+		{spec: "1h:webhook:guest", wantErr: "only to email reminders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parseReminderSpec(tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBuildUpdateRequest_Reminders(t *testing.T) {
+	cmd := &cobra.Command{Use: "update"}
+	f := &configFlags{}
+	registerConfigFlags(cmd, f)
+	require.NoError(t, cmd.ParseFlags([]string{"--reminder", "1h:email:guest", "--reminder", "10:webhook"}))
+	require.NoError(t, validateConfigFlags(f))
+
+	req, err := buildUpdateRequest(cmd, f, "", 0, "", "")
+	require.NoError(t, err)
+	require.NotNil(t, req.EventBooking)
+	assert.Equal(t, []domain.SchedulerReminder{
+		{Type: "email", MinutesBeforeEvent: 60, Recipient: "guest"},
+		{Type: "webhook", MinutesBeforeEvent: 10},
+	}, req.EventBooking.Reminders)
+}
+
+func TestBuildCreateRequest_YAMLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`name: Demo
+participants:
+  - email: alice@example.com
+    is_organizer: true
+event_booking:
+  title: Product demo
+  reminders:
+    - type: email
+      minutes_before_event: 60
+      recipient: guest
+      email_subject: Your demo starts soon
+`), 0o600))
+
+	cmd := &cobra.Command{Use: "create"}
+	cmd.Flags().Int("duration", 30, "")
+	f := &configFlags{}
+	registerConfigFlags(cmd, f)
+	require.NoError(t, cmd.ParseFlags([]string{"--file", path}))
+
+	req, err := buildCreateRequest(cmd, f, "", nil, 30, "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Demo", req.Name)
+	assert.Equal(t, "Product demo", req.EventBooking.Title)
+	assert.Equal(t, []domain.SchedulerReminder{
+		{Type: "email", MinutesBeforeEvent: 60, Recipient: "guest", EmailSubject: "Your demo starts soon"},
+	}, req.EventBooking.Reminders)
+}
+
+func TestSelectEmailReminder(t *testing.T) {
+	reminders := []domain.SchedulerReminder{
+		{Type: "webhook", MinutesBeforeEvent: 5},
+		{Type: "email", MinutesBeforeEvent: 60, Recipient: "all"},
+	}
+
+	r, err := selectEmailReminder(reminders, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 60, r.MinutesBeforeEvent)
+
+	_, err = selectEmailReminder(reminders, 1)
+	assert.ErrorContains(t, err, "webhook reminder")
+
+	_, err = selectEmailReminder(reminders, 3)
+	assert.ErrorContains(t, err, "out of range")
+
+	_, err = selectEmailReminder(nil, 0)
+	assert.ErrorContains(t, err, "no email reminders")
+}
+
+func TestRenderReminderSample(t *testing.T) {
+	config := &domain.SchedulerConfiguration{
+		Name:         "Demo",
+		Availability: domain.AvailabilityRules{DurationMinutes: 30},
+		EventBooking: domain.EventBooking{
+			Title:    "Product <demo>",
+			Location: "Room 4",
+			Timezone: "UTC",
+		},
+	}
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	req := renderReminderSample(config, domain.SchedulerReminder{Type: "email", MinutesBeforeEvent: 60, Recipient: "guest"}, "me@example.com", now)
+
+	assert.Equal(t, "Reminder: Product <demo> starts in 1h", req.Subject)
+	assert.Equal(t, []domain.EmailParticipant{{Email: "me@example.com"}}, req.To)
+	assert.Contains(t, req.Body, "Product &lt;demo&gt;")
+	assert.Contains(t, req.Body, "Mon, Mar 2, 2026 10:00 AM – 10:30 AM UTC")
+	assert.Contains(t, req.Body, "Room 4")
+
+	req = renderReminderSample(config, domain.SchedulerReminder{Type: "email", MinutesBeforeEvent: 10, EmailSubject: "Heads up"}, "me@example.com", now)
+	assert.Equal(t, "Heads up", req.Subject)
+}
+
+func TestFormatConfigDetails_Reminders(t *testing.T) {
+	config := &domain.SchedulerConfiguration{
+		Name: "Demo",
+		EventBooking: domain.EventBooking{Reminders: []domain.SchedulerReminder{
+			{Type: "email", MinutesBeforeEvent: 1440, Recipient: "guest", EmailSubject: "Tomorrow"},
+		}},
+	}
+	var buf bytes.Buffer
+	formatConfigDetails(&buf, config)
+	assert.Contains(t, buf.String(), `Reminder 1: email 1d before to guest (subject: "Tomorrow")`)
+}
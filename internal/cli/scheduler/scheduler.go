@@ -2,6 +2,9 @@
 package scheduler
 
 import (
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+
 	"github.com/spf13/cobra"
 )
 
@@ -19,10 +22,14 @@ manage availability, and handle scheduling sessions.
 API reference: https://developer.nylas.com/docs/v3/scheduler/`,
 	}
 
+	common.RequireScopes(cmd, domain.ScopeCalendarRead)
+
 	cmd.AddCommand(newConfigurationsCmd())
 	cmd.AddCommand(newSessionsCmd())
 	cmd.AddCommand(newBookingsCmd())
 	cmd.AddCommand(newGroupEventsCmd())
+	cmd.AddCommand(newRemindersCmd())
+	cmd.AddCommand(newWaitlistCmd())
 
 	return cmd
 }
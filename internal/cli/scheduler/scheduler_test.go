@@ -38,7 +38,7 @@ func TestNewSchedulerCmd(t *testing.T) {
 	})
 
 	t.Run("has_required_subcommands", func(t *testing.T) {
-		expectedCmds := []string{"configurations", "sessions", "bookings"}
+		expectedCmds := []string{"configurations", "sessions", "bookings", "reminders", "waitlist"}
 
 		cmdMap := make(map[string]bool)
 		for _, sub := range cmd.Commands() {
@@ -291,7 +291,7 @@ func TestNewBookingsCmd(t *testing.T) {
 	})
 
 	t.Run("has_subcommands", func(t *testing.T) {
-		expectedCmds := []string{"show", "confirm", "reschedule", "cancel"}
+		expectedCmds := []string{"show", "confirm", "reschedule", "cancel", "watch"}
 
 		cmdMap := make(map[string]bool)
 		for _, sub := range cmd.Commands() {
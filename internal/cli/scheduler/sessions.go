@@ -2,7 +2,6 @@ package scheduler
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/nylas/cli/internal/cli/common"
@@ -21,8 +20,8 @@ func newSessionsCmd() *cobra.Command {
 API reference: https://developer.nylas.com/docs/reference/api/sessions/`,
 	}
 
-	cmd.AddCommand(newSessionCreateCmd())
-	cmd.AddCommand(newSessionShowCmd())
+	cmd.AddCommand(common.DeclareOutput(newSessionCreateCmd(), domain.SchedulerSession{}))
+	cmd.AddCommand(common.DeclareOutput(newSessionShowCmd(), domain.SchedulerSession{}))
 
 	return cmd
 }
@@ -49,8 +48,8 @@ func newSessionCreateCmd() *cobra.Command {
 					return struct{}{}, common.WrapCreateError("session", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(session)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(session)
 				}
 
 				_, _ = common.Green.Println("✓ Created scheduler session")
@@ -85,8 +84,8 @@ func newSessionShowCmd() *cobra.Command {
 					return struct{}{}, common.WrapGetError("session", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, json.NewEncoder(cmd.OutOrStdout()).Encode(session)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(session)
 				}
 
 				_, _ = common.Bold.Println("Scheduler Session")
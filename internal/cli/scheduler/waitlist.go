@@ -0,0 +1,259 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/config"
+	"github.com/nylas/cli/internal/adapters/waitlist"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// openWaitlistStore opens the local waitlist. Replaced in tests.
+var openWaitlistStore = func() (ports.WaitlistStore, error) {
+	return waitlist.New(filepath.Join(config.DefaultConfigDir(), "waitlist.json")), nil
+}
+
+func newWaitlistCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "waitlist",
+		Short: "Queue guests for full slots and offer them freed ones",
+		Long: `Manage a waitlist for scheduler configurations.
+
+Guests who try to book a full slot are queued, either by your booking page
+posting a waitlist.requested webhook to 'waitlist serve' or with
+'waitlist add'. When a booking is cancelled, the freed slot is emailed to the
+earliest queued guest who wants it. The email's accept link asks the guest
+to confirm, then books the slot through the Nylas booking API; unaccepted
+offers pass to the next guest.
+
+The waitlist is stored locally in waitlist.json in the config directory.`,
+	}
+
+	cmd.AddCommand(common.RunLocally(newWaitlistServeCmd()))
+	cmd.AddCommand(common.DeclareOutput(newWaitlistAddCmd(), domain.WaitlistEntry{}))
+	cmd.AddCommand(common.DeclareOutput(newWaitlistListCmd(), []domain.WaitlistEntry{}))
+	cmd.AddCommand(common.DeclareOutput(newWaitlistOfferCmd(), domain.WaitlistEntry{}))
+	cmd.AddCommand(newWaitlistRemoveCmd())
+
+	return cmd
+}
+
+func newWaitlistAddCmd() *cobra.Command {
+	var (
+		email    string
+		name     string
+		startStr string
+		endStr   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <config-id>",
+		Short: "Queue a guest for a slot",
+		Long:  `Queue a guest. Without --start they take the first slot that frees up on the configuration.`,
+		Example: `  nylas scheduler waitlist add <config-id> --email ana@example.com --name Ana \
+    --start "2026-01-05 10:00" --end "2026-01-05 10:30"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateRequiredFlag("--email", email); err != nil {
+				return err
+			}
+			entry := domain.WaitlistEntry{ConfigurationID: args[0], Email: strings.TrimSpace(email), Name: name, Source: "manual"}
+			start, err := parseGroupEventTime("start", startStr)
+			if err != nil {
+				return err
+			}
+			end, err := parseGroupEventTime("end", endStr)
+			if err != nil {
+				return err
+			}
+			if (start == 0) != (end == 0) {
+				return common.NewInputError("--start and --end must be given together")
+			}
+			if start != 0 {
+				if end <= start {
+					return common.NewInputError("--end must be after --start")
+				}
+				entry.Start, entry.End = time.Unix(start, 0), time.Unix(end, 0)
+			}
+
+			store, err := openWaitlistStore()
+			if err != nil {
+				return err
+			}
+			added, err := store.Add(entry)
+			if err != nil {
+				return common.WrapCreateError("waitlist entry", err)
+			}
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(added)
+			}
+			common.PrintSuccess("Queued %s for %s (%s)", added.Email, formatWaitlistSlot(added.Start, added.End), added.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&email, "email", "", "Guest email (required)")
+	cmd.Flags().StringVar(&name, "name", "", "Guest name")
+	cmd.Flags().StringVar(&startStr, "start", "", "Slot start (YYYY-MM-DD HH:MM, RFC3339, or Unix); omit for any slot")
+	cmd.Flags().StringVar(&endStr, "end", "", "Slot end")
+
+	return cmd
+}
+
+func newWaitlistListCmd() *cobra.Command {
+	var (
+		configID string
+		status   string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List waitlist entries in queue order",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if status != "" {
+				if err := common.ValidateOneOf("status", status, []string{domain.WaitlistWaiting, domain.WaitlistOffered, domain.WaitlistBooked}); err != nil {
+					return err
+				}
+			}
+			store, err := openWaitlistStore()
+			if err != nil {
+				return err
+			}
+			all, err := store.List()
+			if err != nil {
+				return common.WrapListError("waitlist", err)
+			}
+			entries := make([]domain.WaitlistEntry, 0, len(all))
+			for _, e := range all {
+				if (configID == "" || e.ConfigurationID == configID) && (status == "" || e.Status == status) {
+					entries = append(entries, e)
+				}
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(entries)
+			}
+			if len(entries) == 0 {
+				common.PrintEmptyStateWithHint("waitlist entries", "Queue a guest with: nylas scheduler waitlist add <config-id> --email <email>")
+				return nil
+			}
+			table := common.NewTable("ID", "GUEST", "CONFIGURATION", "SLOT", "STATUS", "QUEUED")
+			for _, e := range entries {
+				slot := formatWaitlistSlot(e.Start, e.End)
+				state := e.Status
+				if e.Offer != nil && e.Status == domain.WaitlistOffered {
+					slot = formatWaitlistSlot(e.Offer.Start, e.Offer.End)
+					state += " until " + e.Offer.ExpiresAt.Local().Format("Jan 2 3:04 PM")
+				}
+				table.AddRow(e.ID, e.Email, e.ConfigurationID, slot, state, common.FormatTimeAgo(e.CreatedAt))
+			}
+			table.Render()
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&configID, "configuration-id", "", "Only show entries for this configuration")
+	cmd.Flags().StringVar(&status, "status", "", "Only show entries with this status (waiting, offered, booked)")
+
+	return cmd
+}
+
+func newWaitlistOfferCmd() *cobra.Command {
+	var (
+		startStr  string
+		endStr    string
+		acceptURL string
+		offerTTL  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "offer <config-id> [grant-id]",
+		Short: "Offer a freed slot to the next guest in line",
+		Long: `Offer a slot to the earliest queued guest who wants it, as 'waitlist serve'
+does when a booking.cancelled webhook arrives. Use it for slots freed outside
+the Scheduler. --accept-url is the public URL where 'waitlist serve' runs.`,
+		Example: `  nylas scheduler waitlist offer <config-id> --start "2026-01-05 10:00" \
+    --end "2026-01-05 10:30" --accept-url https://hooks.example.com`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := common.ValidateRequiredFlag("--accept-url", acceptURL); err != nil {
+				return err
+			}
+			start, err := parseGroupEventTime("start", startStr)
+			if err != nil {
+				return err
+			}
+			end, err := parseGroupEventTime("end", endStr)
+			if err != nil {
+				return err
+			}
+			if start == 0 || end <= start {
+				return common.NewInputError("--start and a later --end are required")
+			}
+			store, err := openWaitlistStore()
+			if err != nil {
+				return err
+			}
+
+			_, err = common.WithClient(args[1:], func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				w := newWaitlister(client, store, grantID, acceptURL, offerTTL)
+				offered, err := w.offerSlot(ctx, args[0], time.Unix(start, 0), time.Unix(end, 0))
+				if err != nil {
+					return struct{}{}, common.WrapSendError("waitlist offer", err)
+				}
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(offered)
+				}
+				if offered == nil {
+					fmt.Println("Nobody on the waitlist wants this slot.")
+					return struct{}{}, nil
+				}
+				common.PrintSuccess("Offered %s to %s (expires %s)", formatWaitlistSlot(offered.Offer.Start, offered.Offer.End),
+					offered.Email, offered.Offer.ExpiresAt.Local().Format("Jan 2 3:04 PM"))
+				return struct{}{}, nil
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&startStr, "start", "", "Slot start (required)")
+	cmd.Flags().StringVar(&endStr, "end", "", "Slot end (required)")
+	cmd.Flags().StringVar(&acceptURL, "accept-url", "", "Public base URL of 'waitlist serve' (required)")
+	cmd.Flags().DurationVar(&offerTTL, "offer-ttl", defaultWaitlistOfferTTL, "How long the guest has to accept")
+
+	return common.RequireScopes(cmd, domain.ScopeEmailSend)
+}
+
+func newWaitlistRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <entry-id>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a guest from the waitlist",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openWaitlistStore()
+			if err != nil {
+				return err
+			}
+			if err := store.Remove(args[0]); err != nil {
+				if errors.Is(err, domain.ErrWaitlistEntryNotFound) {
+					return common.NewUserError(fmt.Sprintf("waitlist entry %q not found", args[0]), "List entries with: nylas scheduler waitlist list")
+				}
+				return common.WrapDeleteError("waitlist entry", err)
+			}
+			common.PrintSuccess("Removed %s from the waitlist", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,297 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+const (
+	waitlistAcceptPath      = "/waitlist/accept"
+	defaultWaitlistOfferTTL = 2 * time.Hour
+)
+
+var (
+	errOfferInvalid = errors.New("this offer link is not valid")
+	errOfferGone    = errors.New("this offer is no longer available")
+)
+
+// waitlister offers freed slots to waitlist entries in the order they were
+// queued and books them when an offer is accepted.
+type waitlister struct {
+	client     ports.NylasClient
+	store      ports.WaitlistStore
+	grantID    string // sends offer emails
+	acceptBase string // base URL that serves waitlistAcceptPath
+	offerTTL   time.Duration
+	now        func() time.Time
+
+	// mu serializes offer and accept so one slot is never offered twice and
+	// one offer is never booked twice.
+	mu sync.Mutex
+}
+
+func newWaitlister(client ports.NylasClient, store ports.WaitlistStore, grantID, acceptBase string, offerTTL time.Duration) *waitlister {
+	if offerTTL <= 0 {
+		offerTTL = defaultWaitlistOfferTTL
+	}
+	return &waitlister{
+		client:     client,
+		store:      store,
+		grantID:    grantID,
+		acceptBase: strings.TrimSuffix(acceptBase, "/"),
+		offerTTL:   offerTTL,
+		now:        time.Now,
+	}
+}
+
+// offerSlot emails the next entry in line an offer for the freed slot. It
+// returns nil when nobody is waiting for it. The entry is marked offered
+// before the email goes out and put back in line if sending fails.
+func (w *waitlister) offerSlot(ctx context.Context, configID string, start, end time.Time) (*domain.WaitlistEntry, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	var picked *domain.WaitlistEntry
+	err := w.store.Update(func(entries []domain.WaitlistEntry) error {
+		i := domain.NextInLine(entries, configID, start, end)
+		if i < 0 {
+			return nil
+		}
+		entries[i].Status = domain.WaitlistOffered
+		entries[i].Offer = &domain.WaitlistOffer{Start: start, End: end, OfferedAt: now, ExpiresAt: now.Add(w.offerTTL)}
+		e := entries[i]
+		picked = &e
+		return nil
+	})
+	if err != nil || picked == nil {
+		return nil, err
+	}
+
+	link, err := w.acceptLink(picked)
+	if err == nil {
+		_, err = w.client.SendMessage(ctx, w.grantID, w.offerMessage(ctx, picked, link))
+	}
+	if err != nil {
+		_ = w.setEntry(picked.ID, func(e *domain.WaitlistEntry) {
+			e.Status, e.Offer = domain.WaitlistWaiting, nil
+		})
+		return nil, fmt.Errorf("offer slot to %s: %w", picked.Email, err)
+	}
+	return picked, nil
+}
+
+// expireOffers puts entries whose offers lapsed back in line, skipping that
+// slot for them, and offers each lapsed slot to the next entry.
+func (w *waitlister) expireOffers(ctx context.Context) ([]*domain.WaitlistEntry, error) {
+	var lapsed []domain.WaitlistEntry
+	w.mu.Lock()
+	now := w.now()
+	err := w.store.Update(func(entries []domain.WaitlistEntry) error {
+		for i := range entries {
+			e := &entries[i]
+			if !e.OfferExpired(now) {
+				continue
+			}
+			lapsed = append(lapsed, *e)
+			e.Passed = append(e.Passed, e.Offer.Start)
+			e.Status, e.Offer = domain.WaitlistWaiting, nil
+		}
+		return nil
+	})
+	w.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var offered []*domain.WaitlistEntry
+	var errs []error
+	for _, e := range lapsed {
+		next, err := w.offerSlot(ctx, e.ConfigurationID, e.Offer.Start, e.Offer.End)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if next != nil {
+			offered = append(offered, next)
+		}
+	}
+	return offered, errors.Join(errs...)
+}
+
+// checkOffer returns the entry whose offer the link points at, without
+// booking it.
+func (w *waitlister) checkOffer(entryID, token string) (*domain.WaitlistEntry, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.openOffer(entryID, token)
+}
+
+// openOffer returns the entry when its offer can still be accepted. The
+// caller holds w.mu.
+func (w *waitlister) openOffer(entryID, token string) (*domain.WaitlistEntry, error) {
+	entry, err := w.findEntry(entryID)
+	if err != nil {
+		return nil, errOfferInvalid
+	}
+	if entry.Offer == nil || !w.validToken(entry, token) {
+		return nil, errOfferInvalid
+	}
+	if entry.Status != domain.WaitlistOffered || entry.OfferExpired(w.now()) {
+		return entry, errOfferGone
+	}
+	return entry, nil
+}
+
+// accept books the offered slot for the entry. A slot that was taken in the
+// meantime puts the entry back in line and returns errOfferGone.
+func (w *waitlister) accept(ctx context.Context, entryID, token string) (*domain.WaitlistEntry, *domain.Booking, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entry, err := w.openOffer(entryID, token)
+	if err != nil {
+		return entry, nil, err
+	}
+
+	booking, err := w.client.CreateBooking(ctx, entry.ConfigurationID, &domain.CreateBookingRequest{
+		StartTime: entry.Offer.Start.Unix(),
+		EndTime:   entry.Offer.End.Unix(),
+		Guest:     domain.BookingGuest{Name: entry.Name, Email: entry.Email},
+	})
+	if err != nil {
+		if isSlotTaken(err) {
+			slot := entry.Offer.Start
+			_ = w.setEntry(entry.ID, func(e *domain.WaitlistEntry) {
+				e.Passed = append(e.Passed, slot)
+				e.Status, e.Offer = domain.WaitlistWaiting, nil
+			})
+			return entry, nil, errOfferGone
+		}
+		return entry, nil, err
+	}
+
+	err = w.setEntry(entry.ID, func(e *domain.WaitlistEntry) {
+		e.Status, e.BookingID = domain.WaitlistBooked, booking.BookingID
+	})
+	return entry, booking, err
+}
+
+// isSlotTaken reports whether the booking API refused the slot itself.
+func isSlotTaken(err error) bool {
+	var apiErr *domain.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
+}
+
+func (w *waitlister) findEntry(id string) (*domain.WaitlistEntry, error) {
+	entries, err := w.store.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, domain.ErrWaitlistEntryNotFound
+}
+
+func (w *waitlister) setEntry(id string, fn func(*domain.WaitlistEntry)) error {
+	return w.store.Update(func(entries []domain.WaitlistEntry) error {
+		for i := range entries {
+			if entries[i].ID == id {
+				fn(&entries[i])
+				return nil
+			}
+		}
+		return domain.ErrWaitlistEntryNotFound
+	})
+}
+
+// offerToken signs the entry and the offered slot, so a link can't be
+// reused for a later offer or forged for another entry.
+func offerToken(key []byte, e *domain.WaitlistEntry) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = fmt.Fprintf(mac, "%s|%d|%d", e.ID, e.Offer.Start.Unix(), e.Offer.End.Unix())
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *waitlister) validToken(e *domain.WaitlistEntry, token string) bool {
+	key, err := w.store.SigningKey()
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(offerToken(key, e)), []byte(token))
+}
+
+func (w *waitlister) acceptLink(e *domain.WaitlistEntry) (string, error) {
+	key, err := w.store.SigningKey()
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{"entry": {e.ID}, "token": {offerToken(key, e)}}
+	return w.acceptBase + waitlistAcceptPath + "?" + q.Encode(), nil
+}
+
+// offerMessage builds the offer email. The configuration's booking title is
+// used when it can be read.
+func (w *waitlister) offerMessage(ctx context.Context, e *domain.WaitlistEntry, link string) *domain.SendMessageRequest {
+	title := "your meeting"
+	if cfg, err := w.client.GetSchedulerConfiguration(ctx, w.grantID, e.ConfigurationID); err == nil {
+		if cfg.EventBooking.Title != "" {
+			title = cfg.EventBooking.Title
+		} else if cfg.Name != "" {
+			title = cfg.Name
+		}
+	}
+	when := formatWaitlistSlot(e.Offer.Start, e.Offer.End)
+
+	var body strings.Builder
+	body.WriteString("<p>Hi" + html.EscapeString(prefixSpace(e.Name)) + ",</p>\n")
+	body.WriteString("<p>A slot you were waiting for has opened up for " + html.EscapeString(title) + ":</p>\n")
+	body.WriteString("<p><strong>" + html.EscapeString(when) + "</strong></p>\n")
+	body.WriteString(`<p><a href="` + html.EscapeString(link) + `">Book this slot</a></p>` + "\n")
+	body.WriteString("<p>This offer expires " + html.EscapeString(e.Offer.ExpiresAt.Local().Format("Mon, Jan 2 3:04 PM MST")) +
+		". After that it goes to the next person on the waitlist.</p>\n")
+
+	return &domain.SendMessageRequest{
+		Subject: "A slot opened up: " + when,
+		Body:    body.String(),
+		To:      []domain.EmailParticipant{{Name: e.Name, Email: e.Email}},
+	}
+}
+
+func prefixSpace(s string) string {
+	if s == "" {
+		return ""
+	}
+	return " " + s
+}
+
+// formatWaitlistSlot formats a slot in local time; a zero start means any slot.
+func formatWaitlistSlot(start, end time.Time) string {
+	if start.IsZero() {
+		return "any slot"
+	}
+	start, end = start.Local(), end.Local()
+	return fmt.Sprintf("%s – %s", start.Format("Mon, Jan 2 3:04 PM"), end.Format("3:04 PM MST"))
+}
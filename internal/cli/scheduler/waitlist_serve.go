@@ -0,0 +1,388 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/tunnel"
+	"github.com/nylas/cli/internal/adapters/webhookserver"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// Webhook event types handled by `waitlist serve`.
+const (
+	waitlistRequestedEvent = "waitlist.requested"
+	bookingCancelledEvent  = domain.TriggerBookingCancelled
+)
+
+func newWaitlistServeCmd() *cobra.Command {
+	var (
+		port          int
+		path          string
+		tunnelType    string
+		secret        string
+		allowUnsigned bool
+		acceptURL     string
+		offerTTL      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve [grant-id]",
+		Short: "Run the waitlist: capture full-slot attempts and offer freed slots",
+		Long: `Start a webhook receiver that runs the waitlist.
+
+  waitlist.requested   Queues the guest. Post this from your booking page when
+                       a guest picks a slot that is already full. data.object
+                       carries configuration_id, email, name, and optionally
+                       start_time/end_time (Unix) for the slot they wanted.
+  booking.cancelled    Offers the freed slot, by email, to the earliest queued
+                       guest who wants it (the Nylas Scheduler webhook).
+
+Each offer email has an accept link served by this command at
+/waitlist/accept. Opening it shows a confirmation page, so link scanners
+can't accept for the guest; confirming books the slot through the Nylas
+booking API. If an offer isn't accepted within --offer-ttl, the slot goes to
+the next guest.
+
+Offer emails are sent from the grant's mailbox. With --tunnel the receiver is
+public, so --secret (the webhook signing secret) is required unless
+--allow-unsigned is given.`,
+		Example: `  # Run behind a cloudflared tunnel
+  nylas scheduler waitlist serve --tunnel cloudflared --secret <webhook-secret>
+
+  # Run behind your own reverse proxy
+  nylas scheduler waitlist serve --port 8080 --secret <webhook-secret> \
+    --accept-url https://hooks.example.com
+
+  # Then point a Nylas webhook at it
+  nylas webhooks create --url <public-url>/webhook --triggers booking.cancelled`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if tunnelType != "" && secret == "" && !allowUnsigned {
+				return common.NewUserError(
+					"--secret is required when --tunnel is set",
+					"Pass --secret <value> to verify each event, or --allow-unsigned to accept unverified events (insecure)",
+				)
+			}
+			if offerTTL <= 0 {
+				return common.NewInputError("--offer-ttl must be positive")
+			}
+			client, err := common.GetNylasClient()
+			if err != nil {
+				return err
+			}
+			grantID, err := common.GetGrantID(args)
+			if err != nil {
+				return err
+			}
+			store, err := openWaitlistStore()
+			if err != nil {
+				return err
+			}
+			return runWaitlistServe(client, store, grantID, waitlistServeOptions{
+				port: port, path: path, tunnelType: tunnelType, secret: secret,
+				acceptURL: acceptURL, offerTTL: offerTTL,
+			})
+		},
+	}
+
+	cmd.Flags().IntVarP(&port, "port", "p", 3000, "Port to listen on")
+	cmd.Flags().StringVar(&path, "path", "/webhook", "Webhook endpoint path")
+	cmd.Flags().StringVarP(&tunnelType, "tunnel", "t", "", "Tunnel provider (cloudflared)")
+	cmd.Flags().StringVarP(&secret, "secret", "s", "", "Webhook secret for signature verification")
+	cmd.Flags().BoolVar(&allowUnsigned, "allow-unsigned", false, "Allow unsigned events when --tunnel is set (insecure)")
+	cmd.Flags().StringVar(&acceptURL, "accept-url", "", "Public base URL for accept links (defaults to the tunnel URL)")
+	cmd.Flags().DurationVar(&offerTTL, "offer-ttl", defaultWaitlistOfferTTL, "How long a guest has to accept an offer")
+
+	return common.RequireScopes(cmd, domain.ScopeEmailSend)
+}
+
+type waitlistServeOptions struct {
+	port       int
+	path       string
+	tunnelType string
+	secret     string
+	acceptURL  string
+	offerTTL   time.Duration
+}
+
+func runWaitlistServe(client ports.NylasClient, store ports.WaitlistStore, grantID string, opts waitlistServeOptions) error {
+	config := ports.WebhookServerConfig{Port: opts.port, Path: opts.path, WebhookSecret: opts.secret, TunnelProvider: opts.tunnelType}
+	if opts.secret != "" {
+		config.MaxEventAge = 5 * time.Minute
+	}
+	server := webhookserver.NewServer(config)
+
+	if opts.tunnelType != "" {
+		switch strings.ToLower(opts.tunnelType) {
+		case "cloudflared", "cloudflare", "cf":
+			if !tunnel.IsCloudflaredInstalled() {
+				return common.NewUserError("cloudflared is not installed", "Install it with: brew install cloudflared")
+			}
+			server.SetTunnel(tunnel.NewCloudflaredTunnel(webhookserver.LocalBaseURL(opts.port)))
+		default:
+			return common.NewUserError(fmt.Sprintf("unsupported tunnel provider: %s", opts.tunnelType), "Supported providers: cloudflared")
+		}
+	}
+
+	w := newWaitlister(client, store, grantID, opts.acceptURL, opts.offerTTL)
+	server.Handle(waitlistAcceptPath, w.acceptHandler())
+	server.OnEvent(func(ev *ports.WebhookEvent) {
+		ctx, cancel := common.CreateContext()
+		defer cancel()
+		msg, err := w.handleEvent(ctx, ev)
+		switch {
+		case err != nil:
+			common.PrintWarningStderr("%s: %v", ev.Type, err)
+		case msg != "":
+			fmt.Printf("%s  %s\n", time.Now().Format("15:04:05"), msg)
+		}
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Start(ctx); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return common.WrapError(err)
+	}
+	defer func() { _ = server.Stop() }()
+
+	// The accept base is only known once the tunnel is up.
+	w.mu.Lock()
+	if w.acceptBase == "" {
+		w.acceptBase = server.BaseURL()
+	}
+	w.mu.Unlock()
+
+	// Drain the raw event stream; events are handled through OnEvent.
+	go func() {
+		for range server.Events() {
+		}
+	}()
+
+	fmt.Printf("Waitlist receiver: %s\n", server.GetPublicURL())
+	fmt.Printf("Accept links:      %s%s\n", w.acceptBase, waitlistAcceptPath)
+	fmt.Printf("Offers expire after %s. Press Ctrl+C to stop.\n\n", opts.offerTTL)
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			tctx, cancel := common.CreateContext()
+			offered, err := w.expireOffers(tctx)
+			cancel()
+			if err != nil {
+				common.PrintWarningStderr("re-offering lapsed slots: %v", err)
+			}
+			for _, e := range offered {
+				fmt.Printf("%s  offer lapsed; offered %s to %s\n", time.Now().Format("15:04:05"), formatWaitlistSlot(e.Offer.Start, e.Offer.End), e.Email)
+			}
+		}
+	}
+}
+
+// handleEvent queues full-slot attempts and offers cancelled slots. It
+// returns a log line for events it acted on.
+func (w *waitlister) handleEvent(ctx context.Context, ev *ports.WebhookEvent) (string, error) {
+	obj := webhookObject(ev.Body)
+	switch ev.Type {
+	case waitlistRequestedEvent:
+		entry, err := parseWaitlistRequest(obj)
+		if err != nil {
+			return "", err
+		}
+		added, dup, err := w.enqueue(entry)
+		if err != nil || dup {
+			return "", err
+		}
+		return fmt.Sprintf("queued %s for %s (%s)", added.Email, formatWaitlistSlot(added.Start, added.End), added.ID), nil
+
+	case bookingCancelledEvent:
+		configID, start, end, ok := parseFreedSlot(obj)
+		if !ok {
+			return "", fmt.Errorf("payload has no configuration_id or slot times")
+		}
+		offered, err := w.offerSlot(ctx, configID, start, end)
+		if err != nil || offered == nil {
+			return "", err
+		}
+		return fmt.Sprintf("slot freed; offered %s to %s", formatWaitlistSlot(start, end), offered.Email), nil
+	}
+	return "", nil
+}
+
+// enqueue adds the entry unless the guest is already in line for the same
+// slot on the same configuration.
+func (w *waitlister) enqueue(entry domain.WaitlistEntry) (domain.WaitlistEntry, bool, error) {
+	entries, err := w.store.List()
+	if err != nil {
+		return entry, false, err
+	}
+	for _, e := range entries {
+		if e.ConfigurationID == entry.ConfigurationID && strings.EqualFold(e.Email, entry.Email) &&
+			e.Start.Equal(entry.Start) && e.Status != domain.WaitlistBooked {
+			return e, true, nil
+		}
+	}
+	added, err := w.store.Add(entry)
+	return added, false, err
+}
+
+// webhookObject returns data.object from a webhook payload.
+func webhookObject(body map[string]any) map[string]any {
+	data, _ := body["data"].(map[string]any)
+	obj, _ := data["object"].(map[string]any)
+	return obj
+}
+
+func parseWaitlistRequest(obj map[string]any) (domain.WaitlistEntry, error) {
+	entry := domain.WaitlistEntry{
+		ConfigurationID: stringField(obj, "configuration_id"),
+		Email:           strings.TrimSpace(stringField(obj, "email")),
+		Name:            stringField(obj, "name"),
+		Start:           unixField(obj, "start_time"),
+		End:             unixField(obj, "end_time"),
+		Source:          "webhook",
+	}
+	if entry.ConfigurationID == "" || !strings.Contains(entry.Email, "@") {
+		return entry, fmt.Errorf("payload needs configuration_id and email")
+	}
+	if !entry.Start.IsZero() && !entry.End.After(entry.Start) {
+		return entry, fmt.Errorf("end_time must be after start_time")
+	}
+	return entry, nil
+}
+
+// parseFreedSlot reads the configuration and slot of a cancelled booking.
+// Slot times are under booking_info, or on the object itself.
+func parseFreedSlot(obj map[string]any) (string, time.Time, time.Time, bool) {
+	configID := stringField(obj, "configuration_id")
+	info, _ := obj["booking_info"].(map[string]any)
+	if configID == "" {
+		configID = stringField(info, "configuration_id")
+	}
+	start, end := unixField(info, "start_time"), unixField(info, "end_time")
+	if start.IsZero() {
+		start, end = unixField(obj, "start_time"), unixField(obj, "end_time")
+	}
+	return configID, start, end, configID != "" && !start.IsZero() && end.After(start)
+}
+
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// unixField reads a Unix timestamp sent as a JSON number or string.
+func unixField(m map[string]any, key string) time.Time {
+	var ts int64
+	switch v := m[key].(type) {
+	case float64:
+		ts = int64(v)
+	case string:
+		ts, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if ts <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(ts, 0)
+}
+
+var acceptPage = template.Must(template.New("accept").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Title}}</title>
+<style>body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; max-width: 520px; margin: 60px auto; padding: 20px; }
+button { font-size: 1rem; padding: 10px 20px; }</style>
+</head>
+<body><h1>{{.Title}}</h1><p>{{.Message}}</p>
+{{- if .Entry}}
+<form method="post" action="">
+<input type="hidden" name="entry" value="{{.Entry}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<button type="submit">Book this slot</button>
+</form>
+{{- end}}
+</body>
+</html>`))
+
+// acceptPageData fills acceptPage. Entry and Token are only set on the
+// confirmation page.
+type acceptPageData struct {
+	Title, Message string
+	Entry, Token   string
+}
+
+// acceptHandler serves the accept links in offer emails. A GET only shows a
+// confirmation page: link scanners and previewers open links in mail, and
+// must not take the guest's one offer. The booking is made by the POST from
+// that page.
+func (w *waitlister) acceptHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var (
+			entryID, token string
+			entry          *domain.WaitlistEntry
+			booking        *domain.Booking
+			err            error
+		)
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+			entryID, token = q.Get("entry"), q.Get("token")
+			entry, err = w.checkOffer(entryID, token)
+		case http.MethodPost:
+			entryID, token = r.PostFormValue("entry"), r.PostFormValue("token")
+			entry, booking, err = w.accept(r.Context(), entryID, token)
+		default:
+			rw.Header().Set("Allow", "GET, POST")
+			http.Error(rw, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		status, page := http.StatusOK, acceptPageData{}
+		switch {
+		case err == nil && booking == nil:
+			page = acceptPageData{
+				Title:   "Book this slot?",
+				Message: fmt.Sprintf("A slot opened up for %s. Confirm to book it.", formatWaitlistSlot(entry.Offer.Start, entry.Offer.End)),
+				Entry:   entryID,
+				Token:   token,
+			}
+		case err == nil:
+			page.Title = "You're booked"
+			page.Message = fmt.Sprintf("Your booking for %s is confirmed. A calendar invitation is on its way.", formatWaitlistSlot(entry.Offer.Start, entry.Offer.End))
+			fmt.Printf("%s  %s accepted; booking %s\n", time.Now().Format("15:04:05"), entry.Email, booking.BookingID)
+		case errors.Is(err, errOfferInvalid):
+			status, page.Title, page.Message = http.StatusNotFound, "Link not valid", err.Error()+"."
+		case errors.Is(err, errOfferGone):
+			status, page.Title, page.Message = http.StatusGone, "Slot no longer available", "Sorry, "+err.Error()+". You're still on the waitlist."
+			if entry != nil && entry.Status == domain.WaitlistBooked {
+				page.Title, page.Message = "Already booked", "You already accepted this offer."
+			}
+		default:
+			status, page.Title, page.Message = http.StatusBadGateway, "Booking failed", "We couldn't book the slot. Please try again in a moment."
+			common.PrintWarningStderr("booking for waitlist entry %s failed: %v", entryID, err)
+		}
+
+		rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		rw.WriteHeader(status)
+		_ = acceptPage.Execute(rw, page)
+	})
+}
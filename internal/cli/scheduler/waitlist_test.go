@@ -0,0 +1,306 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/adapters/waitlist"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+var (
+	waitlistSlot    = time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	waitlistSlotEnd = waitlistSlot.Add(30 * time.Minute)
+)
+
+func newTestWaitlister(t *testing.T) (*waitlister, *nylas.MockClient, *[]*domain.SendMessageRequest) {
+	t.Helper()
+	client := nylas.NewMockClient()
+	var sent []*domain.SendMessageRequest
+	client.SendMessageFunc = func(_ context.Context, _ string, req *domain.SendMessageRequest) (*domain.Message, error) {
+		sent = append(sent, req)
+		return &domain.Message{ID: "msg-1"}, nil
+	}
+	store := waitlist.New(filepath.Join(t.TempDir(), "waitlist.json"))
+	w := newWaitlister(client, store, "grant-1", "https://hooks.example.com/", time.Hour)
+	w.now = func() time.Time { return waitlistSlot.Add(-24 * time.Hour) }
+	return w, client, &sent
+}
+
+func queue(t *testing.T, store ports.WaitlistStore, email string, queuedAt time.Time) domain.WaitlistEntry {
+	t.Helper()
+	e, err := store.Add(domain.WaitlistEntry{ConfigurationID: "cfg-1", Email: email, CreatedAt: queuedAt})
+	require.NoError(t, err)
+	return e
+}
+
+// acceptParams extracts the entry and token from the accept link in an offer.
+func acceptParams(t *testing.T, msg *domain.SendMessageRequest) (string, string) {
+	t.Helper()
+	i := strings.Index(msg.Body, "https://hooks.example.com/waitlist/accept?")
+	require.GreaterOrEqual(t, i, 0, "offer has no accept link")
+	raw := msg.Body[i:]
+	raw = strings.ReplaceAll(raw[:strings.Index(raw, `"`)], "&amp;", "&")
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	return u.Query().Get("entry"), u.Query().Get("token")
+}
+
+func TestWaitlister_OffersInQueueOrder(t *testing.T) {
+	w, _, sent := newTestWaitlister(t)
+	queued := waitlistSlot.Add(-72 * time.Hour)
+	queue(t, w.store, "second@example.com", queued.Add(time.Minute))
+	first := queue(t, w.store, "first@example.com", queued)
+
+	offered, err := w.offerSlot(context.Background(), "cfg-1", waitlistSlot, waitlistSlotEnd)
+	require.NoError(t, err)
+	require.NotNil(t, offered)
+	assert.Equal(t, first.ID, offered.ID)
+	assert.Equal(t, w.now().Add(time.Hour), offered.Offer.ExpiresAt)
+
+	require.Len(t, *sent, 1)
+	assert.Equal(t, "first@example.com", (*sent)[0].To[0].Email)
+	assert.Contains(t, (*sent)[0].Subject, "A slot opened up")
+
+	// The next slot goes to the next guest.
+	offered, err = w.offerSlot(context.Background(), "cfg-1", waitlistSlotEnd, waitlistSlotEnd.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, "second@example.com", offered.Email)
+
+	// Nobody left.
+	offered, err = w.offerSlot(context.Background(), "cfg-1", waitlistSlot, waitlistSlotEnd)
+	require.NoError(t, err)
+	assert.Nil(t, offered)
+}
+
+func TestWaitlister_SendFailureKeepsPlace(t *testing.T) {
+	w, client, _ := newTestWaitlister(t)
+	queue(t, w.store, "ana@example.com", time.Now())
+	client.SendMessageFunc = func(context.Context, string, *domain.SendMessageRequest) (*domain.Message, error) {
+		return nil, errors.New("smtp down")
+	}
+
+	_, err := w.offerSlot(context.Background(), "cfg-1", waitlistSlot, waitlistSlotEnd)
+	require.Error(t, err)
+
+	entries, err := w.store.List()
+	require.NoError(t, err)
+	assert.Equal(t, domain.WaitlistWaiting, entries[0].Status)
+	assert.Nil(t, entries[0].Offer)
+}
+
+func TestWaitlister_ExpiredOfferPassesToNext(t *testing.T) {
+	w, _, sent := newTestWaitlister(t)
+	queued := waitlistSlot.Add(-72 * time.Hour)
+	queue(t, w.store, "first@example.com", queued)
+	queue(t, w.store, "second@example.com", queued.Add(time.Minute))
+
+	_, err := w.offerSlot(context.Background(), "cfg-1", waitlistSlot, waitlistSlotEnd)
+	require.NoError(t, err)
+
+	later := w.now().Add(2 * time.Hour)
+	w.now = func() time.Time { return later }
+	offered, err := w.expireOffers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, offered, 1)
+	assert.Equal(t, "second@example.com", offered[0].Email)
+	assert.Len(t, *sent, 2)
+
+	entries, err := w.store.List()
+	require.NoError(t, err)
+	assert.Equal(t, domain.WaitlistWaiting, entries[0].Status)
+	assert.Equal(t, []time.Time{waitlistSlot}, entries[0].Passed)
+}
+
+func TestWaitlister_Accept(t *testing.T) {
+	w, client, sent := newTestWaitlister(t)
+	queue(t, w.store, "ana@example.com", time.Now())
+	var booked *domain.CreateBookingRequest
+	client.CreateBookingFunc = func(_ context.Context, configID string, req *domain.CreateBookingRequest) (*domain.Booking, error) {
+		assert.Equal(t, "cfg-1", configID)
+		booked = req
+		return &domain.Booking{BookingID: "bk-1"}, nil
+	}
+
+	_, err := w.offerSlot(context.Background(), "cfg-1", waitlistSlot, waitlistSlotEnd)
+	require.NoError(t, err)
+	entryID, token := acceptParams(t, (*sent)[0])
+
+	_, _, err = w.accept(context.Background(), entryID, token+"0")
+	assert.ErrorIs(t, err, errOfferInvalid)
+
+	_, booking, err := w.accept(context.Background(), entryID, token)
+	require.NoError(t, err)
+	assert.Equal(t, "bk-1", booking.BookingID)
+	assert.Equal(t, waitlistSlot.Unix(), booked.StartTime)
+	assert.Equal(t, "ana@example.com", booked.Guest.Email)
+
+	entries, err := w.store.List()
+	require.NoError(t, err)
+	assert.Equal(t, domain.WaitlistBooked, entries[0].Status)
+	assert.Equal(t, "bk-1", entries[0].BookingID)
+
+	// A second click doesn't book again.
+	_, _, err = w.accept(context.Background(), entryID, token)
+	assert.ErrorIs(t, err, errOfferGone)
+}
+
+func TestWaitlister_AcceptSlotTaken(t *testing.T) {
+	w, client, sent := newTestWaitlister(t)
+	queue(t, w.store, "ana@example.com", time.Now())
+	client.CreateBookingFunc = func(context.Context, string, *domain.CreateBookingRequest) (*domain.Booking, error) {
+		return nil, &domain.APIError{StatusCode: http.StatusConflict, Message: "slot unavailable"}
+	}
+	_, err := w.offerSlot(context.Background(), "cfg-1", waitlistSlot, waitlistSlotEnd)
+	require.NoError(t, err)
+	entryID, token := acceptParams(t, (*sent)[0])
+
+	rec := httptest.NewRecorder()
+	w.acceptHandler().ServeHTTP(rec, acceptPost(entryID, token))
+	assert.Equal(t, http.StatusGone, rec.Code)
+	assert.Contains(t, rec.Body.String(), "still on the waitlist")
+
+	entries, err := w.store.List()
+	require.NoError(t, err)
+	assert.Equal(t, domain.WaitlistWaiting, entries[0].Status)
+}
+
+func acceptPost(entryID, token string) *http.Request {
+	form := url.Values{"entry": {entryID}, "token": {token}}
+	req := httptest.NewRequest(http.MethodPost, "/waitlist/accept", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestWaitlister_AcceptHandler(t *testing.T) {
+	w, client, sent := newTestWaitlister(t)
+	queue(t, w.store, "ana@example.com", time.Now())
+	bookings := 0
+	client.CreateBookingFunc = func(context.Context, string, *domain.CreateBookingRequest) (*domain.Booking, error) {
+		bookings++
+		return &domain.Booking{BookingID: "bk-1"}, nil
+	}
+	_, err := w.offerSlot(context.Background(), "cfg-1", waitlistSlot, waitlistSlotEnd)
+	require.NoError(t, err)
+	entryID, token := acceptParams(t, (*sent)[0])
+
+	// Opening the link, as a mail scanner would, only asks for confirmation.
+	for range 2 {
+		rec := httptest.NewRecorder()
+		w.acceptHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/waitlist/accept?entry="+entryID+"&token="+token, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `<form method="post"`)
+		assert.Contains(t, rec.Body.String(), `value="`+token+`"`)
+	}
+	assert.Zero(t, bookings, "GET must not book")
+	entries, err := w.store.List()
+	require.NoError(t, err)
+	assert.Equal(t, domain.WaitlistOffered, entries[0].Status)
+
+	rec := httptest.NewRecorder()
+	w.acceptHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/waitlist/accept?entry="+entryID+"&token=bad", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	w.acceptHandler().ServeHTTP(rec, acceptPost(entryID, token))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "You&#39;re booked")
+	assert.Equal(t, 1, bookings)
+
+	rec = httptest.NewRecorder()
+	w.acceptHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/waitlist/accept", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestWaitlister_HandleEvent(t *testing.T) {
+	w, _, sent := newTestWaitlister(t)
+	ctx := context.Background()
+
+	request := &ports.WebhookEvent{Type: waitlistRequestedEvent, Body: map[string]any{
+		"data": map[string]any{"object": map[string]any{
+			"configuration_id": "cfg-1",
+			"email":            "ana@example.com",
+			"name":             "Ana",
+			"start_time":       float64(waitlistSlot.Unix()),
+			"end_time":         float64(waitlistSlotEnd.Unix()),
+		}},
+	}}
+	msg, err := w.handleEvent(ctx, request)
+	require.NoError(t, err)
+	assert.Contains(t, msg, "queued ana@example.com")
+
+	// The same request again is not queued twice.
+	msg, err = w.handleEvent(ctx, request)
+	require.NoError(t, err)
+	assert.Empty(t, msg)
+
+	cancelled := &ports.WebhookEvent{Type: bookingCancelledEvent, Body: map[string]any{
+		"data": map[string]any{"object": map[string]any{
+			"booking_id":       "bk-9",
+			"configuration_id": "cfg-1",
+			"booking_info": map[string]any{
+				"start_time": float64(waitlistSlot.Unix()),
+				"end_time":   float64(waitlistSlotEnd.Unix()),
+			},
+		}},
+	}}
+	msg, err = w.handleEvent(ctx, cancelled)
+	require.NoError(t, err)
+	assert.Contains(t, msg, "offered")
+	require.Len(t, *sent, 1)
+	assert.Equal(t, "Ana", (*sent)[0].To[0].Name)
+
+	_, err = w.handleEvent(ctx, &ports.WebhookEvent{Type: waitlistRequestedEvent, Body: map[string]any{}})
+	assert.Error(t, err)
+
+	msg, err = w.handleEvent(ctx, &ports.WebhookEvent{Type: "message.created"})
+	require.NoError(t, err)
+	assert.Empty(t, msg)
+}
+
+func TestWaitlistCommands(t *testing.T) {
+	store := waitlist.New(filepath.Join(t.TempDir(), "waitlist.json"))
+	orig := openWaitlistStore
+	openWaitlistStore = func() (ports.WaitlistStore, error) { return store, nil }
+	t.Cleanup(func() { openWaitlistStore = orig })
+
+	run := func(args ...string) error {
+		cmd := newWaitlistCmd()
+		cmd.SetArgs(args)
+		cmd.SilenceUsage, cmd.SilenceErrors = true, true
+		return cmd.Execute()
+	}
+
+	assert.ErrorContains(t, run("add", "cfg-1"), "--email")
+	assert.ErrorContains(t, run("add", "cfg-1", "--email", "a@example.com", "--start", "2026-01-05 10:00"), "together")
+	require.NoError(t, run("add", "cfg-1", "--email", "a@example.com", "--start", "2026-01-05 10:00", "--end", "2026-01-05 10:30"))
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "manual", entries[0].Source)
+	assert.False(t, entries[0].Start.IsZero())
+
+	require.NoError(t, run("list", "--status", "waiting"))
+	assert.ErrorContains(t, run("list", "--status", "gone"), "status")
+
+	assert.ErrorContains(t, run("remove", "wl_missing"), "not found")
+	require.NoError(t, run("remove", entries[0].ID))
+	entries, err = store.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.ErrorContains(t, run("offer", "cfg-1", "--start", "2026-01-05 10:00", "--end", "2026-01-05 10:30"), "--accept-url")
+}
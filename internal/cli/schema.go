@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/ports"
+)
+
+type schemaRow struct {
+	Command string `json:"command" yaml:"command"`
+	ID      string `json:"id" yaml:"id"`
+}
+
+func (r schemaRow) QuietField() string {
+	return r.Command
+}
+
+func newSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema [command-path...]",
+		Short: "Show the JSON Schema of a command's --json output",
+		Long: `Show the JSON Schema (draft 2020-12) describing what a command writes with
+--json, so scripts and agents can validate output instead of guessing.
+
+Schemas are versioned: "$id" and "x-nylas-schema-version" carry the output
+contract version, which changes only when a field is removed, renamed or
+changes type. New fields may appear within a version.
+
+Without arguments, lists the commands that declare an output schema.`,
+		Example: `  nylas schema
+  nylas schema email list
+  nylas schema calendar events show`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return listOutputSchemas(cmd)
+			}
+
+			target, err := resolveCommandTarget(cmd.Root(), args)
+			if err != nil {
+				return err
+			}
+
+			schema := common.OutputSchema(target)
+			if schema == nil {
+				return &common.CLIError{
+					Message:    fmt.Sprintf("'%s' does not declare an output schema", target.CommandPath()),
+					Suggestion: "Run 'nylas schema' to list commands with output schemas",
+					Code:       common.ErrCodeInvalidInput,
+				}
+			}
+
+			if common.IsStructuredOutput(cmd) && !common.IsJSON(cmd) {
+				return common.GetOutputWriter(cmd).Write(schema)
+			}
+			data, err := json.MarshalIndent(schema, "", "  ")
+			if err != nil {
+				return common.WrapMarshalError("JSON", err)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return err
+		},
+	}
+}
+
+func listOutputSchemas(cmd *cobra.Command) error {
+	var rows []schemaRow
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		if id := common.OutputSchemaID(c); id != "" {
+			rows = append(rows, schemaRow{Command: c.CommandPath(), ID: id})
+		}
+		for _, child := range c.Commands() {
+			if !child.Hidden {
+				walk(child)
+			}
+		}
+	}
+	walk(cmd.Root())
+
+	if common.IsStructuredOutput(cmd) {
+		return common.GetOutputWriter(cmd).WriteList(rows, nil)
+	}
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(cmd.OutOrStdout(), "No commands declare an output schema.")
+		return err
+	}
+	return common.GetOutputWriter(cmd).WriteList(rows, []ports.Column{
+		{Header: "Command", Field: "Command", Width: -1},
+		{Header: "Schema", Field: "ID", Width: -1},
+	})
+}
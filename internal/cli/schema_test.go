@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+)
+
+func newSchemaFixtureRoot() *cobra.Command {
+	root := &cobra.Command{Use: "nylas"}
+	root.PersistentFlags().Bool("json", false, "Output as JSON")
+	root.PersistentFlags().String("format", "", "Output format")
+	root.PersistentFlags().BoolP("quiet", "q", false, "Quiet output")
+
+	group := &cobra.Command{Use: "contacts"}
+	list := common.DeclareOutput(&cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}, []domain.Contact{})
+	plain := &cobra.Command{Use: "plain", Run: func(*cobra.Command, []string) {}}
+
+	group.AddCommand(list, plain)
+	root.AddCommand(group, newSchemaCmd())
+	return root
+}
+
+func TestSchemaCmd_EmitsJSONSchema(t *testing.T) {
+	root := newSchemaFixtureRoot()
+
+	stdout, stderr, err := executeCommand(root, "schema", "contacts", "list")
+	if err != nil {
+		t.Fatalf("schema failed: %v\nstderr: %s", err, stderr)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(stdout), &schema); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if schema["$id"] != "urn:nylas:cli:output:v1:contacts.list" {
+		t.Fatalf("$id = %v", schema["$id"])
+	}
+	if schema["type"] != "array" {
+		t.Fatalf("type = %v, want array", schema["type"])
+	}
+	defs, _ := schema["$defs"].(map[string]any)
+	if _, ok := defs["domain.Contact"]; !ok {
+		t.Fatalf("missing domain.Contact definition: %v", defs)
+	}
+}
+
+func TestSchemaCmd_NoSchema(t *testing.T) {
+	root := newSchemaFixtureRoot()
+
+	_, _, err := executeCommand(root, "schema", "contacts", "plain")
+	if err == nil || !strings.Contains(err.Error(), "does not declare an output schema") {
+		t.Fatalf("err = %v, want missing schema error", err)
+	}
+}
+
+func TestSchemaCmd_ListsDeclaredCommands(t *testing.T) {
+	root := newSchemaFixtureRoot()
+
+	stdout, _, err := executeCommand(root, "schema", "--json")
+	if err != nil {
+		t.Fatalf("schema failed: %v", err)
+	}
+
+	var rows []schemaRow
+	if err := json.Unmarshal([]byte(stdout), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(rows) != 1 || rows[0].Command != "nylas contacts list" {
+		t.Fatalf("rows = %+v, want only contacts list", rows)
+	}
+}
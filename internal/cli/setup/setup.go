@@ -2,6 +2,8 @@ package setup
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
 )
 
 // NewSetupCmd creates the "init" command for first-time CLI setup.
@@ -48,5 +50,5 @@ Already have an API key? Skip the wizard:
 	cmd.Flags().BoolVar(&opts.microsoft, "microsoft", false, "Use Microsoft SSO")
 	cmd.Flags().BoolVar(&opts.github, "github", false, "Use GitHub SSO")
 
-	return cmd
+	return common.RunLocally(cmd)
 }
@@ -438,6 +438,9 @@ func stepGrantSync(status *SetupStatus) {
 	clientID, _ := secretStore.Get(ports.KeyClientID)
 	configStore := config.NewDefaultFileStore()
 	cfg, _ := configStore.Load()
+	if cfg == nil {
+		cfg = domain.DefaultConfig()
+	}
 	region := cfg.Region
 
 	grantStore, err := common.NewDefaultGrantStore()
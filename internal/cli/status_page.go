@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/statuspage"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newStatusPageClient is replaced in tests.
+var newStatusPageClient = func() ports.StatusPage { return statuspage.New() }
+
+func newStatusPageCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "status-page",
+		Short: "Show Nylas service status and open incidents",
+		Long: `Show the Nylas status page: overall status, degraded components, open
+incidents, and scheduled maintenance.
+
+To annotate failing commands with this information automatically, enable
+api.status_check (or set NYLAS_STATUS_CHECK=true). Network and server errors
+then include notes such as "Nylas reports degraded Google since 09:20 UTC".
+
+NYLAS_STATUS_PAGE_URL overrides the status page URL.`,
+		Example: `  # Current status
+  nylas status-page
+
+  # Include operational components
+  nylas status-page --all
+
+  # Annotate API errors with status page notes
+  nylas config set api.status_check true`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, err := common.RunWithSpinnerResult("Checking Nylas status...", func() (*domain.ServiceStatus, error) {
+				ctx, cancel := common.CreateContextWithTimeout(domain.TimeoutHealthCheck)
+				defer cancel()
+				return newStatusPageClient().Summary(ctx)
+			})
+			if err != nil {
+				return common.WrapFetchError("status page", err)
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(status)
+			}
+			printServiceStatus(cmd.OutOrStdout(), status, all)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Show operational components too")
+
+	return cmd
+}
+
+func printServiceStatus(w io.Writer, status *domain.ServiceStatus, all bool) {
+	headline := common.Green
+	switch status.Indicator {
+	case "minor":
+		headline = common.Yellow
+	case "major", "critical":
+		headline = common.Red
+	}
+	_, _ = headline.Fprintln(w, status.Description)
+	_, _ = common.Dim.Fprintf(w, "%s\n", status.PageURL)
+
+	components := status.Degraded()
+	if all {
+		components = status.Components
+	}
+	if len(components) > 0 {
+		_, _ = fmt.Fprintln(w)
+		_, _ = common.Bold.Fprintln(w, "Components")
+		for _, c := range components {
+			if c.IsOperational() {
+				_, _ = common.Green.Fprintf(w, "  ✓ %s\n", c.Name)
+				continue
+			}
+			_, _ = common.Yellow.Fprintf(w, "  ⚠ %s: %s", c.Name, c.StatusLabel())
+			if since := status.DegradedSince(c); !since.IsZero() {
+				_, _ = common.Dim.Fprintf(w, " since %s", formatStatusTime(since))
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+	}
+
+	printStatusIncidents(w, "Incidents", status.Incidents)
+	printStatusIncidents(w, "Scheduled maintenance", status.Maintenance)
+}
+
+func printStatusIncidents(w io.Writer, title string, incidents []domain.StatusIncident) {
+	if len(incidents) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = common.Bold.Fprintln(w, title)
+	for _, inc := range incidents {
+		_, _ = fmt.Fprintf(w, "  %s\n", inc.Name)
+		_, _ = common.Dim.Fprintf(w, "    %s, impact %s, since %s\n", inc.Status, inc.Impact, formatStatusTime(inc.StartedAt))
+		if inc.LatestUpdate != "" {
+			_, _ = fmt.Fprintf(w, "    %s\n", inc.LatestUpdate)
+		}
+		if inc.Link != "" {
+			_, _ = common.Dim.Fprintf(w, "    %s\n", inc.Link)
+		}
+	}
+}
+
+func formatStatusTime(t time.Time) string {
+	return t.UTC().Format("Jan 2 15:04 UTC")
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubStatusPage struct{ status *domain.ServiceStatus }
+
+func (s stubStatusPage) Summary(context.Context) (*domain.ServiceStatus, error) {
+	return s.status, nil
+}
+
+func runStatusPageForTest(t *testing.T, status *domain.ServiceStatus, args ...string) string {
+	t.Helper()
+	original := newStatusPageClient
+	newStatusPageClient = func() ports.StatusPage { return stubStatusPage{status: status} }
+	t.Cleanup(func() { newStatusPageClient = original })
+
+	cmd := newStatusPageCmd()
+	common.AddOutputFlags(cmd)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+	return out.String()
+}
+
+func TestStatusPageCmd(t *testing.T) {
+	started := time.Date(2026, 10, 17, 9, 20, 0, 0, time.UTC)
+	status := &domain.ServiceStatus{
+		Indicator:   "minor",
+		Description: "Partially Degraded Service",
+		PageURL:     "https://status.nylas.com",
+		Components: []domain.StatusComponent{
+			{ID: "g", Name: "Google", Status: "degraded_performance", UpdatedAt: started.Add(time.Hour)},
+			{ID: "m", Name: "Microsoft", Status: "operational"},
+		},
+		Incidents: []domain.StatusIncident{
+			{Name: "Delayed Google sync", Status: "investigating", Impact: "minor", StartedAt: started, Components: []string{"g"}, LatestUpdate: "We are investigating."},
+		},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		out := runStatusPageForTest(t, status)
+		assert.Contains(t, out, "Partially Degraded Service")
+		assert.Contains(t, out, "Google: degraded since Oct 17 09:20 UTC")
+		assert.NotContains(t, out, "Microsoft")
+		assert.Contains(t, out, "Delayed Google sync")
+		assert.Contains(t, out, "We are investigating.")
+	})
+
+	t.Run("all components", func(t *testing.T) {
+		out := runStatusPageForTest(t, status, "--all")
+		assert.Contains(t, out, "Microsoft")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := runStatusPageForTest(t, status, "--format", "json")
+		var got domain.ServiceStatus
+		require.NoError(t, json.Unmarshal([]byte(out), &got))
+		assert.Equal(t, "minor", got.Indicator)
+		assert.Len(t, got.Incidents, 1)
+	})
+}
@@ -175,9 +175,10 @@ func newDeleteCmd() *cobra.Command {
 				if err := client.DeleteRemoteTemplate(ctx, scope, grantID, args[0]); err != nil {
 					return common.WrapDeleteError("template", err)
 				}
-				if !common.IsStructuredOutput(cmd) {
-					common.PrintSuccess("Template deleted")
+				if common.IsStructuredOutput(cmd) {
+					return common.GetOutputWriter(cmd).Write(common.DeleteResult{Resource: "template", ID: args[0], Deleted: true})
 				}
+				common.PrintSuccess("Template deleted")
 				return nil
 			})
 		},
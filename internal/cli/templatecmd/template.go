@@ -2,6 +2,7 @@ package templatecmd
 
 import (
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -24,13 +25,15 @@ API reference: https://developer.nylas.com/docs/reference/api/application-level-
 	}
 
 	common.AddOutputFlags(cmd)
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newShowCmd())
-	cmd.AddCommand(newCreateCmd())
-	cmd.AddCommand(newUpdateCmd())
-	cmd.AddCommand(newDeleteCmd())
-	cmd.AddCommand(newRenderCmd())
-	cmd.AddCommand(newRenderHTMLCmd())
+	common.RequireScopes(cmd, domain.ScopeApplicationAPI)
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), domain.RemoteTemplateListResponse{}))
+	cmd.AddCommand(common.DeclareOutput(newShowCmd(), domain.RemoteTemplate{}))
+	cmd.AddCommand(common.DeclareOutput(newCreateCmd(), domain.RemoteTemplate{}))
+	cmd.AddCommand(common.DeclareOutput(newUpdateCmd(), domain.RemoteTemplate{}))
+	cmd.AddCommand(common.DeclareOutput(newDeleteCmd(), common.DeleteResult{}))
+	cmd.AddCommand(common.DeclareOutput(newRenderCmd(), domain.TemplateRenderResult{}))
+	cmd.AddCommand(common.DeclareOutput(newRenderHTMLCmd(), domain.TemplateRenderResult{}))
 
 	return cmd
 }
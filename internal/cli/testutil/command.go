@@ -5,17 +5,21 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
 )
 
 // NewTestRoot returns a minimal root command that carries the global persistent
-// flags (--json, --format, --quiet) so that subcommands added to it can inherit
+// flags (--json, --format, --quiet, --ids) so that subcommands added to it can inherit
 // them, matching the real nylas root. This is needed in unit tests where commands
 // are constructed in isolation without the real root.
 func NewTestRoot(sub *cobra.Command) *cobra.Command {
 	root := &cobra.Command{Use: "nylas", Short: "test root"}
 	root.PersistentFlags().Bool("json", false, "Output as JSON")
 	root.PersistentFlags().String("format", "", "Output format")
+	common.AddOutputFormatFlag(root.PersistentFlags())
 	root.PersistentFlags().BoolP("quiet", "q", false, "Quiet output")
+	root.PersistentFlags().Bool("ids", false, "IDs only")
 	root.AddCommand(sub)
 	return root
 }
@@ -1,6 +1,9 @@
 package timezone
 
 import (
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+
 	"github.com/spf13/cobra"
 )
 
@@ -30,11 +33,11 @@ Examples:
 	}
 
 	// Add subcommands
-	cmd.AddCommand(newConvertCmd())
-	cmd.AddCommand(newFindMeetingCmd())
-	cmd.AddCommand(newDSTCmd())
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newInfoCmd())
+	cmd.AddCommand(common.DeclareOutput(newConvertCmd(), map[string]any{}))
+	cmd.AddCommand(common.DeclareOutput(newFindMeetingCmd(), domain.MeetingTimeSlots{}))
+	cmd.AddCommand(common.DeclareOutput(newDSTCmd(), map[string]any{}))
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), map[string]any{}))
+	cmd.AddCommand(common.DeclareOutput(newInfoCmd(), map[string]any{}))
 
 	return cmd
 }
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/nylas/cli/internal/adapters/asciicast"
+	"github.com/nylas/cli/internal/cli/common"
+)
+
+// transcriptEnv tells the recorded process where its transcript is, so its
+// audit entry can point to it. It also keeps the process from recording
+// itself again.
+const transcriptEnv = "NYLAS_TRANSCRIPT"
+
+// transcriptCommand builds the process whose session is recorded: the CLI
+// itself with the remaining arguments. Replaced in tests.
+var transcriptCommand = func(args []string) (*exec.Cmd, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return exec.Command(exe, args...), nil // #nosec G204 -- re-runs this executable
+}
+
+// splitTranscriptFlag removes the global --transcript flag from args. ok
+// reports whether it was given.
+func splitTranscriptFlag(args []string) (path string, rest []string, ok bool, err error) {
+	rest = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return path, append(rest, args[i:]...), ok, nil
+		case arg == "--transcript":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return "", nil, false, common.NewInputError("--transcript requires a file path")
+			}
+			path, ok = args[i+1], true
+			i++
+		case strings.HasPrefix(arg, "--transcript="):
+			path, ok = strings.TrimPrefix(arg, "--transcript="), true
+			if path == "" {
+				return "", nil, false, common.NewInputError("--transcript requires a file path")
+			}
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return path, rest, ok, nil
+}
+
+// runWithTranscript runs the CLI with args in a child process and records
+// everything it writes to the terminal to path, in asciicast v2 format.
+// Keyboard input is not recorded, so passwords typed at prompts never end
+// up in the file; what the terminal echoes is.
+func runWithTranscript(path string, args []string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return common.WrapSaveError("transcript", err)
+	}
+	f, err := os.OpenFile(abs, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) // #nosec G304 -- user-specified output path
+	if err != nil {
+		return common.WrapSaveError("transcript", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	cmd, err := transcriptCommand(args)
+	if err != nil {
+		return err
+	}
+	cmd.Env = append(os.Environ(), transcriptEnv+"="+abs)
+
+	header := asciicast.Header{
+		Command: strings.TrimSpace("nylas " + strings.Join(sanitizeArgs(args), " ")),
+		Title:   "nylas transcript",
+		Env:     map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	}
+
+	// Ctrl-C reaches the child directly; the recorder waits for it to exit.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	runErr := recordSession(cmd, f, header)
+	if !common.IsQuiet() {
+		_, _ = fmt.Fprintln(os.Stderr, common.Dim.Sprintf("Transcript saved to %s", abs))
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(runErr, &exitErr) {
+		return &common.ExitError{Code: exitErr.ExitCode()}
+	}
+	return runErr
+}
+
+// recordPipes records a session whose output isn't a terminal: the child
+// writes through pipes, as it would to a file.
+func recordPipes(cmd *exec.Cmd, f io.Writer, header asciicast.Header) error {
+	rec, err := asciicast.NewWriter(f, header)
+	if err != nil {
+		return common.WrapSaveError("transcript", err)
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = io.MultiWriter(os.Stdout, rec)
+	cmd.Stderr = io.MultiWriter(os.Stderr, rec)
+	runErr := cmd.Run()
+	if err := rec.Flush(); err != nil && runErr == nil {
+		runErr = common.WrapSaveError("transcript", err)
+	}
+	return runErr
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+)
+
+func TestSplitTranscriptFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantPath string
+		wantRest []string
+		wantOK   bool
+		wantErr  bool
+	}{
+		{"absent", []string{"email", "list"}, "", []string{"email", "list"}, false, false},
+		{"separate value", []string{"--transcript", "s.cast", "email", "list"}, "s.cast", []string{"email", "list"}, true, false},
+		{"inline value", []string{"email", "--transcript=s.cast", "list"}, "s.cast", []string{"email", "list"}, true, false},
+		{"after terminator", []string{"email", "--", "--transcript", "x"}, "", []string{"email", "--", "--transcript", "x"}, false, false},
+		{"missing value", []string{"email", "--transcript"}, "", nil, false, true},
+		{"empty inline value", []string{"--transcript="}, "", nil, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, rest, ok, err := splitTranscriptFlag(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if path != tt.wantPath || ok != tt.wantOK {
+				t.Errorf("got (%q, %v), want (%q, %v)", path, ok, tt.wantPath, tt.wantOK)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(rest, tt.wantRest) {
+				t.Errorf("rest = %v, want %v", rest, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestRunWithTranscript(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	common.SetQuiet(true)
+	defer common.SetQuiet(false)
+
+	orig := transcriptCommand
+	defer func() { transcriptCommand = orig }()
+	var gotArgs []string
+	transcriptCommand = func(args []string) (*exec.Cmd, error) {
+		gotArgs = args
+		return exec.Command("sh", "-c", `echo hello; echo "$NYLAS_TRANSCRIPT"; exit 3`), nil
+	}
+
+	path := filepath.Join(t.TempDir(), "session.cast")
+	err := runWithTranscript(path, []string{"auth", "login", "--api-key", "secret"})
+
+	var exitErr *common.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Fatalf("err = %v, want exit code 3", err)
+	}
+	if len(gotArgs) != 4 {
+		t.Errorf("child args = %v", gotArgs)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	data, _ := os.ReadFile(path) // #nosec G304 -- test file
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	var header map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatal(err)
+	}
+	if header["version"] != 2.0 {
+		t.Errorf("version = %v", header["version"])
+	}
+	if cmd, _ := header["command"].(string); strings.Contains(cmd, "secret") || !strings.HasPrefix(cmd, "nylas auth login") {
+		t.Errorf("command = %q, want sanitized args", cmd)
+	}
+
+	var output strings.Builder
+	for _, line := range lines[1:] {
+		var event []any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatal(err)
+		}
+		output.WriteString(event[2].(string))
+	}
+	want := "hello\n" + path + "\n"
+	if output.String() != want {
+		t.Errorf("recorded %q, want %q", output.String(), want)
+	}
+}
+
+func TestAuditPreRun_TranscriptDetail(t *testing.T) {
+	t.Setenv(transcriptEnv, "/tmp/session.cast")
+	defer func() {
+		auditMu.Lock()
+		currentAudit = nil
+		auditMu.Unlock()
+	}()
+
+	cmd := &cobra.Command{Use: "probe"}
+	if err := auditPreRun(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+	if currentAudit == nil || currentAudit.Details["transcript"] != "/tmp/session.cast" {
+		t.Errorf("audit details = %+v, want transcript path", currentAudit)
+	}
+}
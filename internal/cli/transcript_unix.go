@@ -0,0 +1,63 @@
+//go:build !windows
+
+package cli
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/creack/pty"
+	"golang.org/x/term"
+
+	"github.com/nylas/cli/internal/adapters/asciicast"
+	"github.com/nylas/cli/internal/cli/common"
+)
+
+// recordSession runs cmd and records its terminal output. When the CLI runs
+// in a terminal the child gets a pseudo-terminal of the same size, so
+// colors, prompts and full-screen views behave and are recorded as usual.
+func recordSession(cmd *exec.Cmd, f io.Writer, header asciicast.Header) error {
+	stdin, stdout := int(os.Stdin.Fd()), int(os.Stdout.Fd()) // #nosec G115 -- file descriptors fit in int
+	if !term.IsTerminal(stdin) || !term.IsTerminal(stdout) {
+		return recordPipes(cmd, f, header)
+	}
+
+	header.Width, header.Height, _ = term.GetSize(stdout)
+	rec, err := asciicast.NewWriter(f, header)
+	if err != nil {
+		return common.WrapSaveError("transcript", err)
+	}
+	ptmx, err := pty.StartWithSize(cmd, &pty.Winsize{Cols: uint16(header.Width), Rows: uint16(header.Height)}) // #nosec G115 -- terminal sizes fit in uint16
+	if err != nil {
+		return err
+	}
+	defer func() { _ = ptmx.Close() }()
+
+	resized := make(chan os.Signal, 1)
+	signal.Notify(resized, syscall.SIGWINCH)
+	defer signal.Stop(resized)
+	go func() {
+		for range resized {
+			_ = pty.InheritSize(os.Stdin, ptmx)
+			if width, height, err := term.GetSize(stdout); err == nil {
+				_ = rec.Resize(width, height)
+			}
+		}
+	}()
+
+	if state, err := term.MakeRaw(stdin); err == nil {
+		defer func() { _ = term.Restore(stdin, state) }()
+	}
+	go func() { _, _ = io.Copy(ptmx, os.Stdin) }()
+
+	// Reading the pty fails once the child has exited and its output is drained.
+	_, _ = io.Copy(io.MultiWriter(os.Stdout, rec), ptmx)
+	runErr := cmd.Wait()
+	if err := rec.Flush(); err != nil && runErr == nil {
+		runErr = common.WrapSaveError("transcript", err)
+	}
+	return runErr
+}
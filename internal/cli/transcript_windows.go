@@ -0,0 +1,16 @@
+//go:build windows
+
+package cli
+
+import (
+	"io"
+	"os/exec"
+
+	"github.com/nylas/cli/internal/adapters/asciicast"
+)
+
+// recordSession runs cmd and records its output. Windows has no
+// pseudo-terminals here, so the child writes through pipes.
+func recordSession(cmd *exec.Cmd, f io.Writer, header asciicast.Header) error {
+	return recordPipes(cmd, f, header)
+}
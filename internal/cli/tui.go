@@ -86,6 +86,10 @@ Resources:
 	cmd.Flags().StringVar(&theme, "theme", "k9s", "Color theme (k9s, amber, green, apple2, vintage, ibm, futuristic, matrix, norton, or custom)")
 
 	// Add subcommands for direct navigation
+	common.RequireScopes(cmd, domain.ScopeEmailRead, domain.ScopeEmailModify, domain.ScopeEmailSend,
+		domain.ScopeCalendarRead, domain.ScopeCalendarWrite, domain.ScopeContactsRead, domain.ScopeContactsWrite,
+		domain.ScopeApplicationAPI)
+
 	cmd.AddCommand(newTUIResourceCmd("messages", "m", "Launch TUI directly to messages view"))
 	cmd.AddCommand(newTUIResourceCmdWithAliases("events", []string{"e", "calendar", "cal"}, "Launch TUI directly to calendar/events view"))
 	cmd.AddCommand(newTUIResourceCmd("contacts", "c", "Launch TUI directly to contacts view"))
@@ -95,7 +99,7 @@ Resources:
 	// Add theme management subcommand
 	cmd.AddCommand(newThemeCmd())
 
-	return cmd
+	return common.RunLocally(cmd)
 }
 
 // newThemeCmd creates the theme management command.
@@ -48,7 +48,7 @@ This command will:
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force update even if already on latest version")
 	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompt")
 
-	return cmd
+	return common.RunLocally(cmd)
 }
 
 func runUpdate(ctx context.Context, checkOnly, force, yes bool) error {
@@ -0,0 +1,334 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/webhookserver"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// backfillSource is the CloudEvents "source" of synthesized events, so
+// consumers can tell them apart from live deliveries.
+const backfillSource = "/nylas-cli/backfill"
+
+// backfillTriggers are the trigger types that can be synthesized from
+// existing data.
+var backfillTriggers = []string{
+	domain.TriggerMessageCreated,
+	domain.TriggerEventCreated,
+	domain.TriggerContactCreated,
+}
+
+type backfillOptions struct {
+	triggers   []string
+	since      string
+	target     string
+	secret     string
+	calendarID string
+	limit      int
+	delay      time.Duration
+	dryRun     bool
+}
+
+// backfillEvent is one synthesized notification.
+type backfillEvent struct {
+	trigger  string
+	objectID string
+	payload  []byte
+}
+
+func newBackfillCmd() *cobra.Command {
+	opts := backfillOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "backfill [grant-id]",
+		Short: "Replay existing data to a webhook endpoint as synthesized events",
+		Long: `Synthesize webhook notifications from existing data and POST them to an
+endpoint, so a new webhook consumer can be primed with history.
+
+Each payload uses the same envelope as live Nylas notifications
+(specversion, type, id, time, data.object) with object timestamps in Unix
+seconds. Synthesized events have source "` + backfillSource + `" and carry an
+X-Nylas-Backfill: true header. With --secret, each request is signed with
+X-Nylas-Signature exactly like a live delivery.
+
+Supported triggers:
+  message.created   messages received within --since
+  event.created     events on --calendar updated within --since
+  contact.created   all contacts (contacts have no timestamp, --since is ignored)
+
+Events are delivered oldest first. Use --dry-run to print the payloads as
+JSON lines instead of sending them.`,
+		Example: `  # Prime a local consumer with the last 30 days of messages
+  nylas webhooks backfill --trigger message.created --since 30d --target http://localhost:3000
+
+  # Sign requests so the consumer's signature check passes
+  nylas webhooks backfill --trigger message.created --since 7d \
+    --target http://localhost:3000/webhook --secret "$WEBHOOK_SECRET"
+
+  # Messages and events for a specific grant, throttled
+  nylas webhooks backfill <grant-id> --trigger message.created --trigger event.created \
+    --since 2w --target http://localhost:3000 --delay 100ms
+
+  # Inspect the payloads without sending them
+  nylas webhooks backfill --trigger event.created --since 7d --dry-run`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			since, err := validateBackfillOptions(&opts)
+			if err != nil {
+				return err
+			}
+
+			_, err = common.WithClient(args, func(ctx context.Context, client ports.NylasClient, grantID string) (struct{}, error) {
+				events, err := common.RunWithSpinnerResult("Collecting history...", func() ([]backfillEvent, error) {
+					return collectBackfillEvents(ctx, client, grantID, opts, since, time.Now())
+				})
+				if err != nil {
+					return struct{}{}, common.WrapGetError("history for backfill", err)
+				}
+
+				if opts.dryRun {
+					return struct{}{}, writeBackfillPayloads(cmd.OutOrStdout(), events)
+				}
+				if len(events) == 0 {
+					common.PrintEmptyStateWithHint("objects", "try a longer --since window")
+					return struct{}{}, nil
+				}
+				return struct{}{}, deliverBackfill(cmd.Context(), cmd.OutOrStdout(), &http.Client{Timeout: 30 * time.Second}, events, opts)
+			})
+			return err
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.triggers, "trigger", "t", nil, "Trigger to synthesize: "+strings.Join(backfillTriggers, ", ")+" (repeatable)")
+	cmd.Flags().StringVar(&opts.since, "since", "30d", "How far back to go (e.g. 24h, 7d, 2w)")
+	cmd.Flags().StringVar(&opts.target, "target", "", "Endpoint URL to POST events to")
+	cmd.Flags().StringVarP(&opts.secret, "secret", "s", "", "Webhook secret used to sign requests (X-Nylas-Signature)")
+	cmd.Flags().StringVarP(&opts.calendarID, "calendar", "c", "primary", "Calendar ID for event.created")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 1000, "Maximum objects per trigger (0 for no limit)")
+	cmd.Flags().DurationVar(&opts.delay, "delay", 0, "Pause between deliveries (e.g. 100ms)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print payloads as JSON lines instead of sending them")
+
+	return cmd
+}
+
+// validateBackfillOptions checks the flags and returns the --since window.
+func validateBackfillOptions(opts *backfillOptions) (time.Duration, error) {
+	var triggers []string
+	for _, trigger := range opts.triggers {
+		if err := common.ValidateOneOf("trigger", trigger, backfillTriggers); err != nil {
+			return 0, err
+		}
+		if trigger != "" && !slices.Contains(triggers, trigger) {
+			triggers = append(triggers, trigger)
+		}
+	}
+	if len(triggers) == 0 {
+		return 0, common.NewUserError("--trigger is required", "Allowed values: "+strings.Join(backfillTriggers, ", "))
+	}
+	opts.triggers = triggers
+
+	since, err := common.ParseDuration(opts.since)
+	if err != nil || since <= 0 {
+		return 0, common.NewInputError(fmt.Sprintf("invalid --since value %q (use e.g. 24h, 7d, 2w)", opts.since))
+	}
+
+	if opts.limit < 0 {
+		return 0, common.NewInputError("--limit cannot be negative")
+	}
+
+	if opts.dryRun {
+		return since, nil
+	}
+	if opts.target == "" {
+		return 0, common.NewUserError("--target is required", "Pass the consumer URL, or use --dry-run to print payloads")
+	}
+	if u, err := url.Parse(opts.target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return 0, common.NewInputError(fmt.Sprintf("invalid --target URL %q (must be http:// or https://)", opts.target))
+	}
+	return since, nil
+}
+
+// collectBackfillEvents fetches the objects for each trigger and wraps them
+// in webhook envelopes, oldest first.
+func collectBackfillEvents(ctx context.Context, client ports.NylasClient, grantID string, opts backfillOptions, since time.Duration, now time.Time) ([]backfillEvent, error) {
+	cutoff := now.Add(-since).Unix()
+	pageSize := common.NormalizePageSize(opts.limit)
+
+	var events []backfillEvent
+	for _, trigger := range opts.triggers {
+		var objects []any
+		var err error
+
+		switch trigger {
+		case domain.TriggerMessageCreated:
+			params := &domain.MessageQueryParams{Limit: pageSize, ReceivedAfter: cutoff}
+			objects, err = fetchBackfillPages(ctx, pageSize, opts.limit, func(ctx context.Context, cursor string) (common.PageResult[domain.Message], error) {
+				params.PageToken = cursor
+				resp, err := client.GetMessagesWithCursor(ctx, grantID, params)
+				if err != nil {
+					return common.PageResult[domain.Message]{}, err
+				}
+				return common.PageResult[domain.Message]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+			})
+		case domain.TriggerEventCreated:
+			params := &domain.EventQueryParams{Limit: pageSize, CalendarID: opts.calendarID, UpdatedAfter: cutoff}
+			objects, err = fetchBackfillPages(ctx, pageSize, opts.limit, func(ctx context.Context, cursor string) (common.PageResult[domain.Event], error) {
+				params.PageToken = cursor
+				resp, err := client.GetEventsWithCursor(ctx, grantID, opts.calendarID, params)
+				if err != nil {
+					return common.PageResult[domain.Event]{}, err
+				}
+				return common.PageResult[domain.Event]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+			})
+		case domain.TriggerContactCreated:
+			params := &domain.ContactQueryParams{Limit: pageSize}
+			objects, err = fetchBackfillPages(ctx, pageSize, opts.limit, func(ctx context.Context, cursor string) (common.PageResult[domain.Contact], error) {
+				params.PageToken = cursor
+				resp, err := client.GetContactsWithCursor(ctx, grantID, params)
+				if err != nil {
+					return common.PageResult[domain.Contact]{}, err
+				}
+				return common.PageResult[domain.Contact]{Data: resp.Data, NextCursor: resp.Pagination.NextCursor}, nil
+			})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", trigger, err)
+		}
+
+		// The API lists newest first; consumers expect history in order.
+		slices.Reverse(objects)
+		for _, object := range objects {
+			event, err := buildBackfillEvent(trigger, grantID, object, now)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func fetchBackfillPages[T any](ctx context.Context, pageSize, limit int, fetcher common.PageFetcher[T]) ([]any, error) {
+	items, err := common.FetchCursorPages(ctx, pageSize, limit, fetcher)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]any, len(items))
+	for i, item := range items {
+		objects[i] = item
+	}
+	return objects, nil
+}
+
+// buildBackfillEvent wraps object in a Nylas v3 notification envelope.
+func buildBackfillEvent(trigger, grantID string, object any, now time.Time) (backfillEvent, error) {
+	data, err := domain.WebhookObject(trigger, grantID, object)
+	if err != nil {
+		return backfillEvent{}, err
+	}
+	objectID, _ := data["id"].(string)
+
+	envelope := map[string]any{
+		"specversion":              "1.0",
+		"type":                     trigger,
+		"source":                   backfillSource,
+		"id":                       uuid.NewString(),
+		"time":                     now.Unix(),
+		"webhook_delivery_attempt": 1,
+		"data":                     map[string]any{"object": data},
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return backfillEvent{}, err
+	}
+	return backfillEvent{trigger: trigger, objectID: objectID, payload: payload}, nil
+}
+
+func writeBackfillPayloads(w io.Writer, events []backfillEvent) error {
+	for _, event := range events {
+		if _, err := fmt.Fprintln(w, string(event.payload)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverBackfill POSTs each event to the target, continuing past failures
+// so one bad object does not stop the backfill.
+func deliverBackfill(ctx context.Context, w io.Writer, httpClient *http.Client, events []backfillEvent, opts backfillOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var failures []string
+	progress := common.NewProgressBar("Delivering events", len(events))
+	for i, event := range events {
+		if err := postBackfillEvent(ctx, httpClient, opts.target, opts.secret, event.payload); err != nil {
+			failures = append(failures, fmt.Sprintf("%s %s: %v", event.trigger, event.objectID, err))
+		}
+		progress.Increment()
+		if opts.delay > 0 && i < len(events)-1 {
+			select {
+			case <-ctx.Done():
+				progress.Finish()
+				return ctx.Err()
+			case <-time.After(opts.delay):
+			}
+		}
+	}
+	progress.Finish()
+
+	for _, failure := range failures {
+		_, _ = fmt.Fprintf(os.Stderr, "warn: %s\n", failure)
+	}
+	failed := len(failures)
+	sent := len(events) - failed
+	if failed > 0 {
+		return common.NewUserError(
+			fmt.Sprintf("%d of %d events failed to deliver to %s", failed, len(events), opts.target),
+			"Check that the endpoint is running and returns a 2xx status",
+		)
+	}
+	_, _ = fmt.Fprintf(w, "%s Delivered %d events to %s\n", common.Green.Sprint("✓"), sent, opts.target)
+	return nil
+}
+
+func postBackfillEvent(ctx context.Context, httpClient *http.Client, target, secret string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Nylas-Backfill", "true")
+	if secret != "" {
+		req.Header.Set("X-Nylas-Signature", webhookserver.ComputeSignature(payload, secret))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
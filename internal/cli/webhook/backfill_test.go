@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/nylas"
+	"github.com/nylas/cli/internal/adapters/webhookserver"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type backfillTestClient struct {
+	*nylas.MockClient
+	messageParams *domain.MessageQueryParams
+}
+
+func (c *backfillTestClient) GetMessagesWithCursor(_ context.Context, _ string, params *domain.MessageQueryParams) (*domain.MessageListResponse, error) {
+	c.messageParams = params
+	return &domain.MessageListResponse{Data: []domain.Message{
+		{ID: "msg-new", Subject: "Newer", Date: time.Unix(1760000200, 0), CreatedAt: time.Unix(1760000200, 0)},
+		{ID: "msg-old", Subject: "Older", Date: time.Unix(1760000100, 0)},
+	}}, nil
+}
+
+func TestValidateBackfillOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    backfillOptions
+		wantErr string
+	}{
+		{name: "valid", opts: backfillOptions{triggers: []string{"message.created"}, since: "30d", target: "http://localhost:3000"}},
+		{name: "dry run needs no target", opts: backfillOptions{triggers: []string{"event.created"}, since: "7d", dryRun: true}},
+		{name: "missing trigger", opts: backfillOptions{since: "7d", dryRun: true}, wantErr: "--trigger is required"},
+		{name: "unsupported trigger", opts: backfillOptions{triggers: []string{"message.updated"}, since: "7d", dryRun: true}, wantErr: "invalid trigger"},
+		{name: "bad since", opts: backfillOptions{triggers: []string{"message.created"}, since: "soon", dryRun: true}, wantErr: "invalid --since"},
+		{name: "missing target", opts: backfillOptions{triggers: []string{"message.created"}, since: "7d"}, wantErr: "--target is required"},
+		{name: "bad target", opts: backfillOptions{triggers: []string{"message.created"}, since: "7d", target: "localhost:3000"}, wantErr: "invalid --target"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateBackfillOptions(&tt.opts)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	opts := backfillOptions{triggers: []string{"message.created", "event.created", "message.created"}, since: "30d", dryRun: true}
+	since, err := validateBackfillOptions(&opts)
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, since)
+	assert.Equal(t, []string{"message.created", "event.created"}, opts.triggers)
+}
+
+func TestCollectBackfillEvents_Messages(t *testing.T) {
+	client := &backfillTestClient{MockClient: nylas.NewMockClient()}
+	now := time.Unix(1760086400, 0)
+	opts := backfillOptions{triggers: []string{domain.TriggerMessageCreated}, limit: 50}
+
+	events, err := collectBackfillEvents(context.Background(), client, "grant-1", opts, 24*time.Hour, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, now.Add(-24*time.Hour).Unix(), client.messageParams.ReceivedAfter)
+	require.Len(t, events, 2)
+	assert.Equal(t, "msg-old", events[0].objectID, "history is delivered oldest first")
+
+	var envelope map[string]any
+	require.NoError(t, json.Unmarshal(events[1].payload, &envelope))
+	assert.Equal(t, "1.0", envelope["specversion"])
+	assert.Equal(t, "message.created", envelope["type"])
+	assert.Equal(t, backfillSource, envelope["source"])
+	assert.NotEmpty(t, envelope["id"])
+	assert.EqualValues(t, now.Unix(), envelope["time"])
+
+	object := envelope["data"].(map[string]any)["object"].(map[string]any)
+	assert.Equal(t, "msg-new", object["id"])
+	assert.Equal(t, "message", object["object"])
+	assert.Equal(t, "grant-1", object["grant_id"])
+	assert.EqualValues(t, 1760000200, object["date"])
+	assert.EqualValues(t, 1760000200, object["created_at"])
+
+	var older map[string]any
+	require.NoError(t, json.Unmarshal(events[0].payload, &older))
+	_, hasCreatedAt := older["data"].(map[string]any)["object"].(map[string]any)["created_at"]
+	assert.False(t, hasCreatedAt, "zero timestamps are dropped")
+}
+
+func TestCollectBackfillEvents_Events(t *testing.T) {
+	client := nylas.NewMockClient()
+	var gotCalendar string
+	var gotParams *domain.EventQueryParams
+	client.GetEventsWithCursorFunc = func(_ context.Context, _, calendarID string, params *domain.EventQueryParams) (*domain.EventListResponse, error) {
+		gotCalendar, gotParams = calendarID, params
+		return &domain.EventListResponse{Data: []domain.Event{{ID: "evt-1", CalendarID: calendarID, Title: "Standup"}}}, nil
+	}
+	now := time.Unix(1760086400, 0)
+	opts := backfillOptions{triggers: []string{domain.TriggerEventCreated}, calendarID: "primary", limit: 10}
+
+	events, err := collectBackfillEvents(context.Background(), client, "grant-1", opts, time.Hour, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "primary", gotCalendar)
+	assert.Equal(t, now.Add(-time.Hour).Unix(), gotParams.UpdatedAfter)
+	require.Len(t, events, 1)
+	assert.Contains(t, string(events[0].payload), `"object":"event"`)
+}
+
+func TestDeliverBackfill(t *testing.T) {
+	var received [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "true", r.Header.Get("X-Nylas-Backfill"))
+		assert.Equal(t, webhookserver.ComputeSignature(body, "shh"), r.Header.Get("X-Nylas-Signature"))
+		received = append(received, body)
+		if strings.Contains(string(body), "reject") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	events := []backfillEvent{
+		{trigger: "message.created", objectID: "a", payload: []byte(`{"id":"a"}`)},
+		{trigger: "message.created", objectID: "b", payload: []byte(`{"id":"b"}`)},
+	}
+	opts := backfillOptions{target: server.URL, secret: "shh"}
+
+	var out bytes.Buffer
+	require.NoError(t, deliverBackfill(context.Background(), &out, server.Client(), events, opts))
+	assert.Len(t, received, 2)
+	assert.Contains(t, out.String(), "Delivered 2 events")
+
+	events = append(events, backfillEvent{trigger: "message.created", objectID: "c", payload: []byte(`{"id":"reject"}`)})
+	err := deliverBackfill(context.Background(), &out, server.Client(), events, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3 events failed")
+}
+
+func TestWriteBackfillPayloads(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeBackfillPayloads(&out, []backfillEvent{
+		{payload: []byte(`{"id":"a"}`)},
+		{payload: []byte(`{"id":"b"}`)},
+	}))
+	assert.Equal(t, "{\"id\":\"a\"}\n{\"id\":\"b\"}\n", out.String())
+}
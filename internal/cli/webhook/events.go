@@ -0,0 +1,315 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/webhookserver"
+	"github.com/nylas/cli/internal/adapters/webhookstore"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// eventRetention is how long `webhook server` keeps received events.
+const eventRetention = 30 * 24 * time.Hour
+
+// newEventStore opens the local webhook event store. Replaced in tests.
+var newEventStore = func() ports.WebhookEventStore {
+	return webhookstore.NewFileStore("")
+}
+
+func newEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Inspect webhook events received by 'webhook server'",
+		Long: `Inspect webhook events received by 'nylas webhooks server'.
+
+The server stores every accepted event locally (unless --no-store is set) and
+keeps them for 30 days.
+
+'events' used to be an alias of 'nylas webhook triggers'; use that command to
+list trigger types.`,
+	}
+
+	cmd.AddCommand(common.DeclareOutput(newEventsListCmd(), []domain.WebhookEventRecord{}))
+	cmd.AddCommand(common.DeclareOutput(newEventsShowCmd(), domain.WebhookEventRecord{}))
+	cmd.AddCommand(newEventsClearCmd())
+
+	return cmd
+}
+
+func newEventsListCmd() *cobra.Command {
+	var (
+		triggers []string
+		since    string
+		until    string
+		grantID  string
+		limit    int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List stored webhook events",
+		Example: `  # Events from the last hour
+  nylas webhooks events list --since 1h
+
+  # Only message events, as JSON
+  nylas webhooks events list --trigger message.created --since 1h --json
+
+  # Wildcards match every trigger with the prefix
+  nylas webhooks events list --trigger 'event.*' --grant <grant-id>`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query, err := buildEventQuery(triggers, since, until, grantID, limit, time.Now())
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			store := newEventStore()
+			records, err := store.Query(ctx, query)
+			if err != nil {
+				return common.WrapListError("webhook events", err)
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				if records == nil {
+					records = []domain.WebhookEventRecord{}
+				}
+				return common.GetOutputWriter(cmd).Write(records)
+			}
+
+			if len(records) == 0 {
+				common.PrintEmptyStateWithHint("webhook events", "Events are recorded while 'nylas webhooks server' is running")
+				return nil
+			}
+			printEventTable(records)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&triggers, "trigger", nil, "Filter by trigger type (repeatable; prefix.* wildcards allowed)")
+	cmd.Flags().StringVar(&since, "since", "", "Only events received after this (e.g. 1h, 7d, or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&until, "until", "", "Only events received before this (e.g. 1h, 7d, or YYYY-MM-DD)")
+	cmd.Flags().StringVar(&grantID, "grant", "", "Filter by grant ID")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of events to show (0 for all)")
+
+	return cmd
+}
+
+func newEventsShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <event-id>",
+		Short: "Show a stored webhook event",
+		Example: `  # Print the payload of an event
+  nylas webhooks events show <event-id>
+
+  # Print the full record, including headers
+  nylas webhooks events show <event-id> --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := common.CreateContext()
+			defer cancel()
+
+			record, err := newEventStore().Get(ctx, args[0])
+			if errors.Is(err, domain.ErrWebhookEventNotFound) {
+				return common.NewUserError(
+					fmt.Sprintf("webhook event %s not found", args[0]),
+					"Run 'nylas webhooks events list' to see stored events",
+				)
+			}
+			if err != nil {
+				return common.WrapGetError("webhook event", err)
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				return common.GetOutputWriter(cmd).Write(record)
+			}
+
+			fmt.Println(common.Bold.Sprint(record.Type))
+			fmt.Printf("  ID:       %s\n", record.ID)
+			fmt.Printf("  Received: %s\n", record.ReceivedAt.Local().Format(time.RFC3339))
+			if record.GrantID != "" {
+				fmt.Printf("  Grant:    %s\n", record.GrantID)
+			}
+			fmt.Printf("  Verified: %t\n", record.Verified)
+			fmt.Println()
+			fmt.Println(formatPayload(record.Payload))
+			return nil
+		},
+	}
+}
+
+func newEventsClearCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Delete all stored webhook events",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := newEventStore()
+			if !force && !common.Confirm(fmt.Sprintf("Delete all webhook events in %s?", store.Path()), false) {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+			if err := store.Clear(); err != nil {
+				return common.WrapDeleteError("webhook events", err)
+			}
+			common.PrintSuccess("Webhook events deleted")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
+
+	return cmd
+}
+
+// buildEventQuery validates the list flags.
+func buildEventQuery(triggers []string, since, until, grantID string, limit int, now time.Time) (*domain.WebhookEventQuery, error) {
+	for _, trigger := range triggers {
+		if !isKnownTrigger(trigger) {
+			return nil, common.NewUserError(
+				fmt.Sprintf("unknown trigger %q", trigger),
+				"Run 'nylas webhooks triggers' to list trigger types, or use a prefix like message.*",
+			)
+		}
+	}
+	if limit < 0 {
+		return nil, common.NewInputError("--limit cannot be negative")
+	}
+
+	query := &domain.WebhookEventQuery{Triggers: triggers, GrantID: grantID, Limit: limit}
+	var err error
+	if since != "" {
+		if query.Since, err = parseEventTime("--since", since, now); err != nil {
+			return nil, err
+		}
+	}
+	if until != "" {
+		if query.Until, err = parseEventTime("--until", until, now); err != nil {
+			return nil, err
+		}
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && query.Until.Before(query.Since) {
+		return nil, common.NewInputError("--until must be after --since")
+	}
+	return query, nil
+}
+
+// parseEventTime accepts a lookback duration ("1h", "7d") or a local date.
+func parseEventTime(flag, value string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	d, err := common.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, common.NewInputError(fmt.Sprintf("invalid %s value %q (use e.g. 1h, 7d, or YYYY-MM-DD)", flag, value))
+	}
+	return now.Add(-d), nil
+}
+
+func printEventTable(records []domain.WebhookEventRecord) {
+	table := common.NewTable("RECEIVED", "TYPE", "ID", "GRANT", "VERIFIED")
+	for _, r := range records {
+		verified := "no"
+		if r.Verified {
+			verified = "yes"
+		}
+		table.AddRow(
+			r.ReceivedAt.Local().Format("2006-01-02 15:04:05"),
+			r.Type,
+			common.Truncate(r.ID, 36),
+			common.Truncate(r.GrantID, 36),
+			verified,
+		)
+	}
+	table.Render()
+	fmt.Printf("\nTotal: %d events\n", len(records))
+}
+
+func formatPayload(payload json.RawMessage) string {
+	var v any
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return string(payload)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return string(payload)
+	}
+	return string(data)
+}
+
+// storeEvents returns an OnEvent handler that appends each event to store.
+// Write failures are reported on stderr and never affect the response sent
+// to Nylas.
+func storeEvents(store ports.WebhookEventStore) func(*ports.WebhookEvent) {
+	return func(event *ports.WebhookEvent) {
+		if err := store.Append(eventRecord(event)); err != nil {
+			fmt.Fprintf(os.Stderr, "warn: failed to store webhook event %s: %v\n", event.ID, err)
+		}
+	}
+}
+
+// openServerEventStore opens the store for `webhook server` and drops events
+// older than eventRetention.
+func openServerEventStore() ports.WebhookEventStore {
+	store := newEventStore()
+	if _, err := store.Prune(time.Now().Add(-eventRetention)); err != nil {
+		fmt.Fprintf(os.Stderr, "warn: failed to prune webhook events: %v\n", err)
+	}
+	return store
+}
+
+// StartEventListener starts a loopback webhook receiver that saves events
+// for 'webhooks events', like 'webhooks server' without a tunnel. With a
+// secret, only signed events are accepted. `nylas daemon` uses it to keep a
+// listener running between commands; stop the server when done.
+func StartEventListener(ctx context.Context, port int, path, secret string) (*webhookserver.Server, error) {
+	server := webhookserver.NewServer(newWebhookServerConfig(port, path, "", secret))
+	server.OnEvent(storeEvents(openServerEventStore()))
+	if err := server.Start(ctx); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+func eventRecord(event *ports.WebhookEvent) *domain.WebhookEventRecord {
+	record := &domain.WebhookEventRecord{
+		ID:         event.ID,
+		Type:       event.Type,
+		GrantID:    event.GrantID,
+		Source:     event.Source,
+		Verified:   event.Verified,
+		ReceivedAt: event.ReceivedAt,
+	}
+	if len(event.Headers) > 0 {
+		record.Headers = make(map[string]string, len(event.Headers))
+		for k, v := range event.Headers {
+			record.Headers[k] = v
+		}
+	}
+
+	// Keep the body exactly as delivered when it's JSON.
+	switch {
+	case json.Valid(event.RawBody):
+		record.Payload = append(json.RawMessage(nil), event.RawBody...)
+	case event.Body != nil:
+		record.Payload, _ = json.Marshal(event.Body)
+	default:
+		record.Payload, _ = json.Marshal(strings.TrimSpace(string(event.RawBody)))
+	}
+	return record
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/webhookstore"
+	"github.com/nylas/cli/internal/cli/testutil"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubEventStore(t *testing.T) ports.WebhookEventStore {
+	t.Helper()
+	store := webhookstore.NewFileStore(t.TempDir())
+	orig := newEventStore
+	newEventStore = func() ports.WebhookEventStore { return store }
+	t.Cleanup(func() { newEventStore = orig })
+	return store
+}
+
+func TestStoreEvents_RecordsRawPayload(t *testing.T) {
+	store := stubEventStore(t)
+	handle := storeEvents(store)
+
+	received := time.Now().Add(-5 * time.Minute)
+	handle(&ports.WebhookEvent{
+		ID: "evt-1", Type: "message.created", GrantID: "grant-1", Verified: true,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		RawBody:    []byte(`{"type":"message.created","data":{"object":{"id":"m1"}}}`),
+		ReceivedAt: received,
+	})
+	handle(&ports.WebhookEvent{ID: "evt-2", Type: "event.updated", RawBody: []byte("not json")})
+
+	got, err := store.Get(t.Context(), "evt-1")
+	require.NoError(t, err)
+	assert.Equal(t, "grant-1", got.GrantID)
+	assert.True(t, got.Verified)
+	assert.Equal(t, "application/json", got.Headers["Content-Type"])
+	assert.JSONEq(t, `{"type":"message.created","data":{"object":{"id":"m1"}}}`, string(got.Payload))
+
+	got, err = store.Get(t.Context(), "evt-2")
+	require.NoError(t, err)
+	assert.JSONEq(t, `"not json"`, string(got.Payload))
+}
+
+func TestBuildEventQuery(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+
+	q, err := buildEventQuery([]string{"message.created", "event.*"}, "1h", "", "g1", 10, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Hour), q.Since)
+	assert.True(t, q.Until.IsZero())
+	assert.Equal(t, "g1", q.GrantID)
+	assert.Equal(t, 10, q.Limit)
+
+	q, err = buildEventQuery(nil, "2026-03-01", "2d", "", 0, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), q.Since)
+	assert.Equal(t, now.Add(-48*time.Hour), q.Until)
+
+	tests := []struct {
+		name     string
+		triggers []string
+		since    string
+		until    string
+		limit    int
+	}{
+		{name: "unknown trigger", triggers: []string{"message.exploded"}},
+		{name: "bad since", since: "yesterday"},
+		{name: "negative limit", limit: -1},
+		{name: "until before since", since: "1h", until: "2h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildEventQuery(tt.triggers, tt.since, tt.until, "", tt.limit, now)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEventsListCmd(t *testing.T) {
+	store := stubEventStore(t)
+	now := time.Now()
+	for i, typ := range []string{"message.created", "event.created", "message.created"} {
+		require.NoError(t, store.Append(&domain.WebhookEventRecord{
+			ID: "evt-" + string(rune('a'+i)), Type: typ, Verified: true,
+			ReceivedAt: now.Add(-time.Duration(3-i) * time.Minute),
+			Payload:    json.RawMessage(`{}`),
+		}))
+	}
+	require.NoError(t, store.Append(&domain.WebhookEventRecord{
+		ID: "evt-old", Type: "message.created", ReceivedAt: now.Add(-3 * time.Hour), Payload: json.RawMessage(`{}`),
+	}))
+
+	root := testutil.NewTestRoot(NewWebhookCmd())
+	stdout, _, err := executeCommand(root, "webhook", "events", "list", "--trigger", "message.created", "--since", "1h", "--json")
+	require.NoError(t, err)
+
+	var records []domain.WebhookEventRecord
+	require.NoError(t, json.NewDecoder(strings.NewReader(stdout)).Decode(&records))
+	require.Len(t, records, 2)
+	assert.Equal(t, "evt-c", records[0].ID)
+	assert.Equal(t, "evt-a", records[1].ID)
+
+	root = testutil.NewTestRoot(NewWebhookCmd())
+	stdout, _, err = executeCommand(root, "webhook", "events", "list")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "event.created")
+	assert.Contains(t, stdout, "Total: 4 events")
+}
+
+func TestEventsShowAndClearCmd(t *testing.T) {
+	store := stubEventStore(t)
+	require.NoError(t, store.Append(&domain.WebhookEventRecord{
+		ID: "evt-1", Type: "message.created", ReceivedAt: time.Now(),
+		Payload: json.RawMessage(`{"data":{"object":{"subject":"Hello"}}}`),
+	}))
+
+	root := testutil.NewTestRoot(NewWebhookCmd())
+	stdout, _, err := executeCommand(root, "webhook", "events", "show", "evt-1")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "message.created")
+	assert.Contains(t, stdout, `"subject": "Hello"`)
+
+	root = testutil.NewTestRoot(NewWebhookCmd())
+	_, _, err = executeCommand(root, "webhook", "events", "show", "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	root = testutil.NewTestRoot(NewWebhookCmd())
+	_, _, err = executeCommand(root, "webhook", "events", "clear", "--force")
+	require.NoError(t, err)
+
+	records, err := store.Query(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -19,11 +20,11 @@ API reference: https://developer.nylas.com/docs/v3/notifications/pubsub-channel/
 	}
 
 	common.AddOutputFlags(cmd)
-	cmd.AddCommand(newPubSubListCmd())
-	cmd.AddCommand(newPubSubShowCmd())
-	cmd.AddCommand(newPubSubCreateCmd())
-	cmd.AddCommand(newPubSubUpdateCmd())
-	cmd.AddCommand(newPubSubDeleteCmd())
+	cmd.AddCommand(common.DeclareOutput(newPubSubListCmd(), domain.PubSubChannelListResponse{}))
+	cmd.AddCommand(common.DeclareOutput(newPubSubShowCmd(), domain.PubSubChannel{}))
+	cmd.AddCommand(common.DeclareOutput(newPubSubCreateCmd(), domain.PubSubChannel{}))
+	cmd.AddCommand(common.DeclareOutput(newPubSubUpdateCmd(), domain.PubSubChannel{}))
+	cmd.AddCommand(common.DeclareOutput(newPubSubDeleteCmd(), common.DeleteResult{}))
 
 	return cmd
 }
@@ -163,9 +163,10 @@ func newPubSubDeleteCmd() *cobra.Command {
 				if err := client.DeletePubSubChannel(ctx, args[0]); err != nil {
 					return struct{}{}, common.WrapDeleteError("pub/sub channel", err)
 				}
-				if !common.IsStructuredOutput(cmd) {
-					common.PrintSuccess("Pub/Sub channel deleted")
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(common.DeleteResult{Resource: "pub/sub channel", ID: args[0], Deleted: true})
 				}
+				common.PrintSuccess("Pub/Sub channel deleted")
 				return struct{}{}, nil
 			})
 			return err
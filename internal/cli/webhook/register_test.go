@@ -306,7 +306,7 @@ func TestRunServer_RegisterFlagConflicts(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			err := runServer(0, "/webhook", "", tt.secret, tt.allowUnsigned, tt.noTunnel,
-				true /* register */, []string{"message.created"}, false, true /* quiet */)
+				true /* register */, []string{"message.created"}, false, true /* quiet */, nil, true /* noStore */)
 			if err == nil {
 				t.Fatal("expected conflict error, got nil")
 			}
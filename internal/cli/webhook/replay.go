@@ -0,0 +1,308 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/adapters/webhookserver"
+	"github.com/nylas/cli/internal/cli/common"
+)
+
+// Tamper modes for `webhook replay --tamper`. Each produces a request that a
+// correct consumer must reject.
+const (
+	tamperBody      = "body"      // sign the original payload, send a modified one
+	tamperSignature = "signature" // send the payload with a corrupted signature
+	tamperSecret    = "secret"    // sign with a different secret
+	tamperMissing   = "missing"   // omit the signature header
+)
+
+var tamperModes = []string{tamperBody, tamperSignature, tamperSecret, tamperMissing}
+
+type replayOptions struct {
+	payload     string
+	payloadFile string
+	target      string
+	secret      string
+	tamper      string
+	headers     []string
+	timeout     time.Duration
+}
+
+// replayResult is the outcome of one replayed payload.
+type replayResult struct {
+	Index    int    `json:"index"`
+	Status   int    `json:"status,omitempty"`
+	Accepted bool   `json:"accepted"`
+	Expected bool   `json:"expected"` // whether the outcome was the desired one
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newReplayCmd() *cobra.Command {
+	opts := replayOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Re-sign webhook payloads and POST them to a local consumer",
+		Long: `Replay captured webhook payloads against an endpoint, signed with the
+webhook secret exactly like a live Nylas delivery (X-Nylas-Signature, a hex
+HMAC-SHA256 of the raw body).
+
+The payload file can hold one JSON payload, or one payload per line (JSON
+lines), such as the output of 'nylas webhook backfill --dry-run'.
+
+--tamper sends requests your consumer must reject, for negative tests:
+  body       sign the original payload, then send a modified body
+  signature  send a corrupted signature
+  secret     sign with the wrong secret
+  missing    leave out the signature header
+
+Without --tamper, the command fails if the consumer rejects a payload. With
+--tamper, it fails if the consumer accepts one (any 2xx response).`,
+		Example: `  # Replay a captured payload to a local consumer
+  nylas webhook replay --file payload.json --url http://localhost:3000/webhook --secret "$WEBHOOK_SECRET"
+
+  # Check that the consumer rejects a modified body
+  nylas webhook replay --file payload.json --url http://localhost:3000/webhook \
+    --secret "$WEBHOOK_SECRET" --tamper body
+
+  # Replay backfilled events
+  nylas webhook backfill --trigger message.created --since 1d --dry-run > events.jsonl
+  nylas webhook replay --file events.jsonl --url http://localhost:3000/webhook --secret "$WEBHOOK_SECRET"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateReplayOptions(&opts); err != nil {
+				return err
+			}
+			raw, err := common.ReadStringOrFile("payload", opts.payload, opts.payloadFile, true)
+			if err != nil {
+				return err
+			}
+			payloads, err := splitPayloads(raw)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			httpClient := &http.Client{Timeout: opts.timeout}
+			results := make([]replayResult, 0, len(payloads))
+			for i, payload := range payloads {
+				results = append(results, replayPayload(ctx, httpClient, i+1, payload, opts))
+			}
+
+			if common.IsStructuredOutput(cmd) {
+				if err := common.GetOutputWriter(cmd).Write(results); err != nil {
+					return err
+				}
+			} else {
+				printReplayResults(cmd.OutOrStdout(), results, opts)
+			}
+			return replayOutcome(results, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.payloadFile, "file", "f", "", "File with the raw payload, or one payload per line")
+	cmd.Flags().StringVar(&opts.payload, "payload", "", "Inline raw payload")
+	cmd.Flags().StringVar(&opts.target, "url", "", "Endpoint URL to POST payloads to (required)")
+	cmd.Flags().StringVarP(&opts.secret, "secret", "s", "", "Webhook secret used to sign requests")
+	cmd.Flags().StringVar(&opts.tamper, "tamper", "", "Send requests that must be rejected: "+strings.Join(tamperModes, ", "))
+	cmd.Flags().StringArrayVarP(&opts.headers, "header", "H", nil, "Extra request header as 'Name: value' (repeatable)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout per request")
+
+	return cmd
+}
+
+func validateReplayOptions(opts *replayOptions) error {
+	if err := common.ValidateRequiredFlag("--url", opts.target); err != nil {
+		return err
+	}
+	u, err := url.Parse(opts.target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return common.NewInputError(fmt.Sprintf("invalid --url: %s (expected http:// or https://)", opts.target))
+	}
+	if err := common.ValidateOneOf("--tamper", opts.tamper, tamperModes); err != nil {
+		return err
+	}
+	if opts.secret == "" && opts.tamper != "" && opts.tamper != tamperMissing {
+		return common.NewUserError("--tamper "+opts.tamper+" requires --secret",
+			"Pass the webhook secret your consumer verifies with")
+	}
+	for _, h := range opts.headers {
+		if name, _, ok := strings.Cut(h, ":"); !ok || strings.TrimSpace(name) == "" {
+			return common.NewInputError(fmt.Sprintf("invalid --header %q (expected 'Name: value')", h))
+		}
+	}
+	return nil
+}
+
+// splitPayloads returns the payloads in raw: the whole input when it is a
+// single JSON document, otherwise each non-empty line.
+func splitPayloads(raw string) ([][]byte, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return nil, common.NewInputError("payload is empty")
+	}
+	if json.Valid([]byte(trimmed)) {
+		return [][]byte{[]byte(trimmed)}, nil
+	}
+
+	var payloads [][]byte
+	scanner := bufio.NewScanner(strings.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if !json.Valid([]byte(text)) {
+			return nil, common.NewInputError(fmt.Sprintf("line %d is not valid JSON", line))
+		}
+		payloads = append(payloads, []byte(text))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, common.WrapDecodeError("payload", err)
+	}
+	return payloads, nil
+}
+
+// signReplayPayload returns the body to send and the signature header value
+// ("" to omit it) for the tamper mode.
+func signReplayPayload(payload []byte, secret, tamper string) ([]byte, string) {
+	switch tamper {
+	case tamperBody:
+		return tamperPayload(payload), webhookserver.ComputeSignature(payload, secret)
+	case tamperSignature:
+		sig := []byte(webhookserver.ComputeSignature(payload, secret))
+		// Flip the last hex digit so the signature is well-formed but wrong.
+		if sig[len(sig)-1] == '0' {
+			sig[len(sig)-1] = '1'
+		} else {
+			sig[len(sig)-1] = '0'
+		}
+		return payload, string(sig)
+	case tamperSecret:
+		return payload, webhookserver.ComputeSignature(payload, secret+"-wrong")
+	case tamperMissing:
+		return payload, ""
+	}
+	if secret == "" {
+		return payload, ""
+	}
+	return payload, webhookserver.ComputeSignature(payload, secret)
+}
+
+// tamperPayload changes a payload the way an attacker might: the
+// notification id for JSON objects, otherwise a trailing byte.
+func tamperPayload(payload []byte) []byte {
+	var obj map[string]any
+	if err := json.Unmarshal(payload, &obj); err == nil {
+		if id, ok := obj["id"].(string); ok {
+			obj["id"] = id + "-tampered"
+		} else {
+			obj["tampered"] = true
+		}
+		if out, err := json.Marshal(obj); err == nil {
+			return out
+		}
+	}
+	return append(append([]byte{}, payload...), ' ')
+}
+
+func replayPayload(ctx context.Context, httpClient *http.Client, index int, payload []byte, opts replayOptions) replayResult {
+	result := replayResult{Index: index}
+	body, signature := signReplayPayload(payload, opts.secret, opts.tamper)
+
+	start := time.Now()
+	status, err := postReplay(ctx, httpClient, opts.target, body, signature, opts.headers)
+	result.Duration = time.Since(start).Round(time.Millisecond).String()
+	result.Status = status
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.Accepted = err == nil && status/100 == 2
+	if opts.tamper != "" {
+		result.Expected = err == nil && !result.Accepted
+	} else {
+		result.Expected = result.Accepted
+	}
+	return result
+}
+
+func postReplay(ctx context.Context, httpClient *http.Client, target string, body []byte, signature string, headers []string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set("X-Nylas-Signature", signature)
+	}
+	for _, h := range headers {
+		name, value, _ := strings.Cut(h, ":")
+		req.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	return resp.StatusCode, nil
+}
+
+func printReplayResults(w io.Writer, results []replayResult, opts replayOptions) {
+	for _, r := range results {
+		mark := common.Green.Sprint("✓")
+		if !r.Expected {
+			mark = common.Red.Sprint("✗")
+		}
+		outcome := fmt.Sprintf("HTTP %d", r.Status)
+		if r.Error != "" {
+			outcome = r.Error
+		}
+		verdict := "accepted"
+		if !r.Accepted {
+			verdict = "rejected"
+		}
+		_, _ = fmt.Fprintf(w, "%s #%d %s, %s (%s)\n", mark, r.Index, outcome, verdict, r.Duration)
+	}
+	if opts.tamper == "" && opts.secret == "" {
+		_, _ = fmt.Fprintln(w, "Note: requests were unsigned; pass --secret to sign them.")
+	}
+}
+
+// replayOutcome fails the command when any payload had the wrong outcome.
+func replayOutcome(results []replayResult, opts replayOptions) error {
+	bad := 0
+	for _, r := range results {
+		if !r.Expected {
+			bad++
+		}
+	}
+	if bad == 0 {
+		return nil
+	}
+	if opts.tamper != "" {
+		return common.NewUserError(
+			fmt.Sprintf("%d of %d tampered requests (%s) were not rejected by %s", bad, len(results), opts.tamper, opts.target),
+			"Verify X-Nylas-Signature against the raw request body and reject mismatches with a 4xx status")
+	}
+	return common.NewUserError(
+		fmt.Sprintf("%d of %d payloads were not accepted by %s", bad, len(results), opts.target),
+		"Check that the consumer is running, uses the same webhook secret, and verifies the raw body")
+}
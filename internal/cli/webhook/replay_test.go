@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nylas/cli/internal/adapters/webhookserver"
+)
+
+const replaySecret = "replay-secret"
+
+// verifyingConsumer returns a server that accepts only correctly signed
+// requests, and the bodies it accepted.
+func verifyingConsumer(t *testing.T, accept bool) (*httptest.Server, *[]string) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		accepted []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ok := webhookserver.VerifySignature(body, r.Header.Get("X-Nylas-Signature"), replaySecret)
+		if !accept && !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		accepted = append(accepted, string(body))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &accepted
+}
+
+func TestReplayCommand_SignedDelivery(t *testing.T) {
+	srv, accepted := verifyingConsumer(t, false)
+	payloads := `{"id":"evt-1","type":"message.created"}
+{"id":"evt-2","type":"message.created"}
+`
+
+	stdout, _, err := executeCommand(newReplayCmd(),
+		"--payload", payloads, "--url", srv.URL, "--secret", replaySecret)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"id":"evt-1","type":"message.created"}`, `{"id":"evt-2","type":"message.created"}`}, *accepted)
+	assert.Contains(t, stdout, "#2 HTTP 200, accepted")
+}
+
+func TestReplayCommand_WrongSecretFails(t *testing.T) {
+	srv, _ := verifyingConsumer(t, false)
+
+	_, _, err := executeCommand(newReplayCmd(),
+		"--payload", `{"id":"evt-1"}`, "--url", srv.URL, "--secret", "other")
+	assert.ErrorContains(t, err, "1 of 1 payloads were not accepted")
+}
+
+func TestReplayCommand_Tamper(t *testing.T) {
+	for _, mode := range tamperModes {
+		t.Run(mode+" rejected", func(t *testing.T) {
+			srv, accepted := verifyingConsumer(t, false)
+			_, _, err := executeCommand(newReplayCmd(),
+				"--payload", `{"id":"evt-1"}`, "--url", srv.URL, "--secret", replaySecret, "--tamper", mode)
+			require.NoError(t, err)
+			assert.Empty(t, *accepted)
+		})
+
+		t.Run(mode+" accepted by a lax consumer", func(t *testing.T) {
+			srv, _ := verifyingConsumer(t, true)
+			_, _, err := executeCommand(newReplayCmd(),
+				"--payload", `{"id":"evt-1"}`, "--url", srv.URL, "--secret", replaySecret, "--tamper", mode)
+			assert.ErrorContains(t, err, "were not rejected")
+		})
+	}
+}
+
+func TestSignReplayPayload(t *testing.T) {
+	payload := []byte(`{"id":"evt-1"}`)
+	valid := webhookserver.ComputeSignature(payload, replaySecret)
+
+	body, sig := signReplayPayload(payload, replaySecret, "")
+	assert.Equal(t, payload, body)
+	assert.Equal(t, valid, sig)
+
+	body, sig = signReplayPayload(payload, replaySecret, tamperBody)
+	assert.JSONEq(t, `{"id":"evt-1-tampered"}`, string(body))
+	assert.Equal(t, valid, sig)
+
+	_, sig = signReplayPayload(payload, replaySecret, tamperSignature)
+	assert.Len(t, sig, len(valid))
+	assert.NotEqual(t, valid, sig)
+
+	_, sig = signReplayPayload(payload, replaySecret, tamperMissing)
+	assert.Empty(t, sig)
+
+	_, sig = signReplayPayload(payload, "", "")
+	assert.Empty(t, sig)
+}
+
+func TestSplitPayloads(t *testing.T) {
+	pretty := "{\n  \"id\": \"evt-1\"\n}\n"
+	got, err := splitPayloads(pretty)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	got, err = splitPayloads("{\"id\":\"a\"}\n\n{\"id\":\"b\"}\n")
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	_, err = splitPayloads("{\"id\":\"a\"}\nnot json\n")
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = splitPayloads("  ")
+	assert.Error(t, err)
+}
+
+func TestReplayCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing url", []string{"--payload", "{}"}, "--url"},
+		{"bad url", []string{"--payload", "{}", "--url", "localhost:3000"}, "invalid --url"},
+		{"bad tamper", []string{"--payload", "{}", "--url", "http://x", "--tamper", "nope"}, "--tamper"},
+		{"tamper needs secret", []string{"--payload", "{}", "--url", "http://x", "--tamper", "body"}, "requires --secret"},
+		{"bad header", []string{"--payload", "{}", "--url", "http://x", "-H", "nocolon"}, "invalid --header"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := executeCommand(newReplayCmd(), tt.args...)
+			require.Error(t, err)
+			assert.True(t, strings.Contains(err.Error(), tt.want), err.Error())
+		})
+	}
+}
+
+func TestVerifyCommand_Sign(t *testing.T) {
+	payload := `{"id":"evt-1"}`
+	stdout, _, err := executeCommand(newVerifyCmd(),
+		"--payload", payload, "--secret", replaySecret, "--sign")
+	require.NoError(t, err)
+	assert.Equal(t, webhookserver.ComputeSignature([]byte(payload), replaySecret), strings.TrimSpace(stdout))
+
+	_, _, err = executeCommand(newVerifyCmd(),
+		"--payload", payload, "--secret", replaySecret, "--sign", "--signature", "abc")
+	assert.Error(t, err)
+}
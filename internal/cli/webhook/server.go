@@ -36,6 +36,8 @@ func newServerCmd() *cobra.Command {
 		triggers      []string
 		jsonOutput    bool
 		quiet         bool
+		sinks         []string
+		noStore       bool
 	)
 
 	cmd := &cobra.Command{
@@ -78,9 +80,30 @@ Examples:
   # Start server with tunnel and explicitly accept unsigned events
   nylas webhooks server --tunnel cloudflared --allow-unsigned
 
+  # Forward verified events to message queues, per trigger
+  nylas webhooks server --register --triggers message.created,event.created \
+    --sink message.created=nats://localhost:4222/nylas.messages \
+    --sink 'event.*=sqs://sqs.us-east-1.amazonaws.com/123456789012/events'
+
+Sinks (--sink [trigger,...=]url, repeatable; triggers accept prefix.* wildcards):
+  nats://[user:pass@]host:4222/<subject>     NATS core (nats+tls:// for TLS)
+  kafka://host:8082/<topic>                  Kafka REST Proxy (kafka+https:// for TLS)
+  sqs://sqs.<region>.amazonaws.com/<account>/<queue>
+                                             Amazon SQS; credentials from AWS_ACCESS_KEY_ID,
+                                             AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN
+  pubsub://<project>/<topic>                 Google Pub/Sub; token from GOOGLE_OAUTH_ACCESS_TOKEN
+                                             (or PUBSUB_EMULATOR_HOST for the emulator)
+Only events that pass signature verification reach the sinks, so --sink needs
+--secret or --register. The original
+payload is forwarded unchanged; delivery failures are logged and don't affect
+the response to Nylas.
+
+Accepted events are also saved locally for 30 days; browse them later with
+'nylas webhooks events list'. Pass --no-store to turn this off.
+
 Press Ctrl+C to stop the server.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runServer(port, path, tunnelType, webhookSecret, allowUnsigned, noTunnel, register, triggers, jsonOutput, quiet)
+			return runServer(port, path, tunnelType, webhookSecret, allowUnsigned, noTunnel, register, triggers, jsonOutput, quiet, sinks, noStore)
 		},
 	}
 
@@ -94,11 +117,13 @@ Press Ctrl+C to stop the server.`,
 	cmd.Flags().StringSliceVar(&triggers, "triggers", nil, "Trigger types for --register (comma-separated or repeated; prompted if omitted)")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output events as JSON")
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress startup messages, only show events")
+	cmd.Flags().StringArrayVar(&sinks, "sink", nil, "Forward verified events to a queue: [trigger,...=]url (repeatable)")
+	cmd.Flags().BoolVar(&noStore, "no-store", false, "Don't save received events for 'nylas webhooks events'")
 
 	return cmd
 }
 
-func runServer(port int, path, tunnelType, webhookSecret string, allowUnsigned, noTunnel, register bool, triggers []string, jsonOutput, quiet bool) error {
+func runServer(port int, path, tunnelType, webhookSecret string, allowUnsigned, noTunnel, register bool, triggers []string, jsonOutput, quiet bool, sinkSpecs []string, noStore bool) error {
 	// --tunnel and --no-tunnel are mutually exclusive: the user can't both
 	// request a tunnel and opt out of one in the same invocation.
 	if tunnelType != "" && noTunnel {
@@ -225,6 +250,30 @@ func runServer(port int, path, tunnelType, webhookSecret string, allowUnsigned,
 	// Create webhook server
 	server := webhookserver.NewServer(config)
 
+	if len(sinkSpecs) > 0 && webhookSecret == "" {
+		return common.NewUserError(
+			"--sink requires --secret",
+			"Only verified events are forwarded; pass --secret <value> or --register so events can be verified",
+		)
+	}
+
+	// Sinks see only events that passed the server's signature checks.
+	// They publish with a background context so in-flight deliveries can
+	// finish during shutdown.
+	router, err := newSinkRouter(context.Background(), sinkSpecs)
+	if err != nil {
+		return err
+	}
+	defer router.Close()
+	if len(router.routes) > 0 {
+		server.OnEvent(router.Handle)
+	}
+	var eventStore ports.WebhookEventStore
+	if !noStore {
+		eventStore = openServerEventStore()
+		server.OnEvent(storeEvents(eventStore))
+	}
+
 	// Set up tunnel if requested
 	if tunnelType != "" {
 		switch strings.ToLower(tunnelType) {
@@ -330,6 +379,12 @@ func runServer(port int, path, tunnelType, webhookSecret string, allowUnsigned,
 		printStartupJSON(stats, tunnelType, registration)
 	} else if !quiet {
 		printServerInfo(stats, tunnelType, registration, registerTriggers)
+		for _, name := range router.Names() {
+			fmt.Printf("  Forwarding to %s\n", name)
+		}
+		if eventStore != nil {
+			fmt.Println(common.Dim.Sprintf("  Saving events to %s", eventStore.Path()))
+		}
 	}
 
 	// Event display loop. Recover from any panic in the formatters so a
@@ -377,6 +432,10 @@ func runServer(port int, path, tunnelType, webhookSecret string, allowUnsigned,
 	} else if !quiet {
 		finalStats := server.GetStats()
 		fmt.Printf("Server stopped. Total events received: %d\n", finalStats.EventsReceived)
+		if len(router.routes) > 0 {
+			forwarded, failed := router.Counts()
+			fmt.Printf("Forwarded: %d, failed: %d\n", forwarded, failed)
+		}
 	}
 
 	return nil
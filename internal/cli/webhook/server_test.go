@@ -236,7 +236,7 @@ func TestPreflightTunnelChoice_EmptySecretWithExplicitConfirmEnablesUnsigned(t *
 // rejected). Kept here next to the preflight tests so the security gate
 // is visible to anyone reading the file.
 func TestPreflightTunnelChoice_TunnelMutexErrorAtRunServer(t *testing.T) {
-	err := runServer(0, "/webhook", "cloudflared", "", false, true /* noTunnel */, false /* register */, nil /* triggers */, false, true /* quiet */)
+	err := runServer(0, "/webhook", "cloudflared", "", false, true /* noTunnel */, false /* register */, nil /* triggers */, false, true /* quiet */, nil, true /* noStore */)
 	if err == nil {
 		t.Fatal("expected --tunnel + --no-tunnel to error, got nil")
 	}
@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nylas/cli/internal/adapters/eventsink"
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+)
+
+// newEventSink is replaced in tests.
+var newEventSink = eventsink.New
+
+// newSinkNotifier returns the webhook_failure notifier. Replaced in tests.
+var newSinkNotifier = func() (ports.Notifier, error) { return common.NewNotifier(nil, "") }
+
+// sinkFailureNotifyEvery limits webhook_failure notifications to one per
+// sink in this period, so a sink that is down doesn't notify per event.
+const sinkFailureNotifyEvery = 10 * time.Minute
+
+// sinkRoute forwards events whose type matches one of triggers (all events
+// when triggers is empty) to sink.
+type sinkRoute struct {
+	triggers []string
+	sink     ports.EventSink
+}
+
+func (r sinkRoute) matches(eventType string) bool {
+	return domain.MatchesTrigger(r.triggers, eventType)
+}
+
+// sinkRouter publishes verified events to every matching sink. Failures are
+// reported on errOut and counted; they never fail the webhook response.
+type sinkRouter struct {
+	ctx      context.Context
+	routes   []sinkRoute
+	errOut   io.Writer
+	notifier ports.Notifier
+
+	mu           sync.Mutex
+	forwarded    int
+	failed       int
+	lastNotified map[string]time.Time
+}
+
+// parseSinkSpec splits a --sink value of the form [trigger,...=]url.
+func parseSinkSpec(spec string) (triggers []string, rawURL string) {
+	before, after, found := strings.Cut(spec, "=")
+	if !found || strings.Contains(before, "://") {
+		return nil, spec
+	}
+	for _, t := range strings.Split(before, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			triggers = append(triggers, t)
+		}
+	}
+	return triggers, after
+}
+
+// newSinkRouter builds the routes for the given --sink values, closing any
+// already-opened sinks if a later one is invalid.
+func newSinkRouter(ctx context.Context, specs []string) (*sinkRouter, error) {
+	router := &sinkRouter{ctx: ctx, errOut: os.Stderr}
+	for _, spec := range specs {
+		triggers, rawURL := parseSinkSpec(spec)
+		for _, trigger := range triggers {
+			if !isKnownTrigger(trigger) {
+				router.Close()
+				return nil, common.NewUserError(
+					fmt.Sprintf("unknown trigger %q in --sink", trigger),
+					"Run 'nylas webhooks triggers' to list trigger types, or use a prefix like message.*",
+				)
+			}
+		}
+		sink, err := newEventSink(rawURL)
+		if err != nil {
+			router.Close()
+			return nil, common.NewUserError(err.Error(), "See 'nylas webhooks server --help' for sink URL formats")
+		}
+		router.routes = append(router.routes, sinkRoute{triggers: triggers, sink: sink})
+	}
+	if len(router.routes) > 0 {
+		notifier, err := newSinkNotifier()
+		if err != nil {
+			router.Close()
+			return nil, err
+		}
+		router.notifier = notifier
+	}
+	return router, nil
+}
+
+// isKnownTrigger accepts trigger types and "<prefix>.*" wildcards.
+func isKnownTrigger(trigger string) bool {
+	prefix, wildcard := strings.CutSuffix(trigger, ".*")
+	for _, t := range domain.AllTriggerTypes() {
+		if t == trigger || (wildcard && strings.HasPrefix(t, prefix+".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle is registered with the webhook server via OnEvent.
+// Unverified events are dropped: a queue consumer can't tell them apart.
+func (r *sinkRouter) Handle(event *ports.WebhookEvent) {
+	if !event.Verified {
+		return
+	}
+	for _, route := range r.routes {
+		if !route.matches(event.Type) {
+			continue
+		}
+		err := route.sink.Publish(r.ctx, event)
+
+		r.mu.Lock()
+		notify := false
+		if err != nil {
+			r.failed++
+			_, _ = fmt.Fprintf(r.errOut, "warn: forwarding %s event %s to %s failed: %v\n",
+				event.Type, event.ID, route.sink.Name(), err)
+			notify = r.shouldNotify(route.sink.Name(), time.Now())
+		} else {
+			r.forwarded++
+		}
+		r.mu.Unlock()
+
+		if notify {
+			common.SendNotification(r.ctx, r.notifier, domain.Notification{
+				Category: domain.NotifyWebhookFailure,
+				Title:    "Webhook forwarding failed",
+				Body:     fmt.Sprintf("Forwarding %s event %s to %s failed: %v", event.Type, event.ID, route.sink.Name(), err),
+			})
+		}
+	}
+}
+
+// shouldNotify reports whether a failure of sink at now should notify, and
+// records it. Must be called with r.mu held.
+func (r *sinkRouter) shouldNotify(sink string, now time.Time) bool {
+	if r.notifier == nil {
+		return false
+	}
+	if last, ok := r.lastNotified[sink]; ok && now.Sub(last) < sinkFailureNotifyEvery {
+		return false
+	}
+	if r.lastNotified == nil {
+		r.lastNotified = map[string]time.Time{}
+	}
+	r.lastNotified[sink] = now
+	return true
+}
+
+// Counts returns the number of successful and failed deliveries.
+func (r *sinkRouter) Counts() (forwarded, failed int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.forwarded, r.failed
+}
+
+// Names returns the display names of the configured sinks with their triggers.
+func (r *sinkRouter) Names() []string {
+	names := make([]string, len(r.routes))
+	for i, route := range r.routes {
+		triggers := "all events"
+		if len(route.triggers) > 0 {
+			triggers = strings.Join(route.triggers, ", ")
+		}
+		names[i] = fmt.Sprintf("%s (%s)", route.sink.Name(), triggers)
+	}
+	return names
+}
+
+// Close closes every sink.
+func (r *sinkRouter) Close() {
+	for _, route := range r.routes {
+		_ = route.sink.Close()
+	}
+}
@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/nylas/cli/internal/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSink struct {
+	name   string
+	fail   bool
+	got    []string
+	closed bool
+}
+
+func (s *fakeSink) Name() string { return s.name }
+
+func (s *fakeSink) Publish(_ context.Context, event *ports.WebhookEvent) error {
+	if s.fail {
+		return errors.New("broker unavailable")
+	}
+	s.got = append(s.got, event.Type)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func stubSinks(t *testing.T) map[string]*fakeSink {
+	t.Helper()
+	created := map[string]*fakeSink{}
+	orig := newEventSink
+	newEventSink = func(rawURL string) (ports.EventSink, error) {
+		if rawURL == "bad://" {
+			return nil, errors.New("unsupported sink scheme")
+		}
+		s := &fakeSink{name: rawURL, fail: rawURL == "nats://down/x"}
+		created[rawURL] = s
+		return s, nil
+	}
+	origNotifier := newSinkNotifier
+	newSinkNotifier = func() (ports.Notifier, error) { return nil, nil }
+	t.Cleanup(func() { newEventSink, newSinkNotifier = orig, origNotifier })
+	return created
+}
+
+type fakeNotifier struct{ got []domain.Notification }
+
+func (n *fakeNotifier) Notify(_ context.Context, note domain.Notification) error {
+	n.got = append(n.got, note)
+	return nil
+}
+
+func TestParseSinkSpec(t *testing.T) {
+	triggers, url := parseSinkSpec("nats://localhost/events")
+	assert.Nil(t, triggers)
+	assert.Equal(t, "nats://localhost/events", url)
+
+	triggers, url = parseSinkSpec("message.created, event.*=sqs+http://localhost/1/q?region=us-east-1")
+	assert.Equal(t, []string{"message.created", "event.*"}, triggers)
+	assert.Equal(t, "sqs+http://localhost/1/q?region=us-east-1", url)
+
+	// An "=" inside the URL's query is not a trigger separator.
+	triggers, url = parseSinkSpec("sqs+http://localhost/1/q?region=us-east-1")
+	assert.Nil(t, triggers)
+	assert.Equal(t, "sqs+http://localhost/1/q?region=us-east-1", url)
+}
+
+func TestSinkRouter_RoutesByTrigger(t *testing.T) {
+	sinks := stubSinks(t)
+	router, err := newSinkRouter(context.Background(), []string{
+		"nats://all/x",
+		"message.created=kafka://proxy/messages",
+		"event.*=pubsub://proj/events",
+	})
+	require.NoError(t, err)
+
+	for _, typ := range []string{"message.created", "message.updated", "event.created", "event.deleted"} {
+		router.Handle(&ports.WebhookEvent{Type: typ, Verified: true})
+	}
+
+	assert.Equal(t, []string{"message.created", "message.updated", "event.created", "event.deleted"}, sinks["nats://all/x"].got)
+	assert.Equal(t, []string{"message.created"}, sinks["kafka://proxy/messages"].got)
+	assert.Equal(t, []string{"event.created", "event.deleted"}, sinks["pubsub://proj/events"].got)
+
+	forwarded, failed := router.Counts()
+	assert.Equal(t, 7, forwarded)
+	assert.Zero(t, failed)
+	assert.Equal(t, "kafka://proxy/messages (message.created)", router.Names()[1])
+}
+
+func TestSinkRouter_SkipsUnverifiedEvents(t *testing.T) {
+	sinks := stubSinks(t)
+	router, err := newSinkRouter(context.Background(), []string{"nats://all/x"})
+	require.NoError(t, err)
+
+	router.Handle(&ports.WebhookEvent{ID: "evt-1", Type: "message.created"})
+	router.Handle(&ports.WebhookEvent{ID: "evt-2", Type: "message.updated", Verified: true})
+
+	assert.Equal(t, []string{"message.updated"}, sinks["nats://all/x"].got)
+	forwarded, _ := router.Counts()
+	assert.Equal(t, 1, forwarded)
+}
+
+func TestSinkRouter_LogsFailures(t *testing.T) {
+	stubSinks(t)
+	router, err := newSinkRouter(context.Background(), []string{"nats://down/x"})
+	require.NoError(t, err)
+	var errOut bytes.Buffer
+	router.errOut = &errOut
+
+	router.Handle(&ports.WebhookEvent{ID: "evt-1", Type: "message.created", Verified: true})
+
+	_, failed := router.Counts()
+	assert.Equal(t, 1, failed)
+	assert.Contains(t, errOut.String(), "forwarding message.created event evt-1 to nats://down/x failed: broker unavailable")
+}
+
+func TestSinkRouter_NotifiesFailuresOncePerPeriod(t *testing.T) {
+	stubSinks(t)
+	notifier := &fakeNotifier{}
+	newSinkNotifier = func() (ports.Notifier, error) { return notifier, nil }
+	router, err := newSinkRouter(context.Background(), []string{"nats://down/x"})
+	require.NoError(t, err)
+	router.errOut = &bytes.Buffer{}
+
+	router.Handle(&ports.WebhookEvent{ID: "evt-1", Type: "message.created", Verified: true})
+	router.Handle(&ports.WebhookEvent{ID: "evt-2", Type: "message.created", Verified: true})
+
+	require.Len(t, notifier.got, 1)
+	assert.Equal(t, domain.NotifyWebhookFailure, notifier.got[0].Category)
+	assert.Contains(t, notifier.got[0].Body, "evt-1")
+
+	router.lastNotified["nats://down/x"] = time.Now().Add(-sinkFailureNotifyEvery)
+	router.Handle(&ports.WebhookEvent{ID: "evt-3", Type: "message.created", Verified: true})
+	assert.Len(t, notifier.got, 2)
+}
+
+func TestNewSinkRouter_Validation(t *testing.T) {
+	sinks := stubSinks(t)
+
+	_, err := newSinkRouter(context.Background(), []string{"nats://ok/x", "message.bogus=nats://other/x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown trigger "message.bogus"`)
+	assert.True(t, sinks["nats://ok/x"].closed, "already-opened sinks are closed on error")
+
+	_, err = newSinkRouter(context.Background(), []string{"bad://"})
+	assert.ErrorContains(t, err, "unsupported sink scheme")
+}
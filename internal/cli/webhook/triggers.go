@@ -14,7 +14,7 @@ func newTriggersCmd() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:     "triggers",
-		Aliases: []string{"trigger-types", "events"},
+		Aliases: []string{"trigger-types"},
 		Short:   "List available webhook trigger types",
 		Long: `List all available webhook trigger types.
 
@@ -66,7 +66,7 @@ Use these values when creating or updating webhooks.`,
 		},
 	}
 
-	cmd.Flags().StringVarP(&category, "category", "c", "", "Filter by category (grant, message, thread, event, contact, calendar, folder, notetaker)")
+	cmd.Flags().StringVarP(&category, "category", "c", "", "Filter by category (grant, message, thread, event, contact, calendar, folder, notetaker, booking)")
 
 	return cmd
 }
@@ -77,7 +77,7 @@ func displayTriggerCategories(categories map[string][]string) error {
 	fmt.Println()
 
 	// Display in a nice order
-	categoryOrder := []string{"grant", "message", "thread", "event", "contact", "calendar", "folder", "notetaker"}
+	categoryOrder := []string{"grant", "message", "thread", "event", "contact", "calendar", "folder", "notetaker", "booking"}
 	categoryDescriptions := map[string]string{
 		"grant":     "Authentication grant events",
 		"message":   "Email message events",
@@ -87,6 +87,7 @@ func displayTriggerCategories(categories map[string][]string) error {
 		"calendar":  "Calendar events",
 		"folder":    "Email folder events",
 		"notetaker": "Meeting notetaker events",
+		"booking":   "Scheduler booking events",
 	}
 
 	categoryEmojis := map[string]string{
@@ -98,6 +99,7 @@ func displayTriggerCategories(categories map[string][]string) error {
 		"calendar":  "📆",
 		"folder":    "📁",
 		"notetaker": "📝",
+		"booking":   "🗓️",
 	}
 
 	for _, cat := range categoryOrder {
@@ -13,6 +13,7 @@ func newVerifyCmd() *cobra.Command {
 	var payloadFile string
 	var signature string
 	var secret string
+	var sign bool
 
 	cmd := &cobra.Command{
 		Use:   "verify",
@@ -20,10 +21,23 @@ func newVerifyCmd() *cobra.Command {
 		Long: `Verify a webhook payload against the x-nylas-signature header value.
 
 The payload must be the exact raw body that Nylas sent. Do not reformat or
-re-encode the JSON before verifying it.`,
+re-encode the JSON before verifying it.
+
+With --sign, print the signature for the payload instead, e.g. to craft a
+request by hand. 'nylas webhook replay' signs and sends payloads for you.`,
+		Example: `  # Verify a captured delivery
+  nylas webhook verify --payload-file body.json --signature "$SIG" --secret "$WEBHOOK_SECRET"
+
+  # Compute the X-Nylas-Signature for a payload
+  nylas webhook verify --payload-file body.json --secret "$WEBHOOK_SECRET" --sign`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			if err := common.ValidateRequiredFlag("--signature", signature); err != nil {
-				return err
+			if sign && signature != "" {
+				return common.NewMutuallyExclusiveError("--sign", "--signature")
+			}
+			if !sign {
+				if err := common.ValidateRequiredFlag("--signature", signature); err != nil {
+					return err
+				}
 			}
 			if err := common.ValidateRequiredFlag("--secret", secret); err != nil {
 				return err
@@ -34,6 +48,15 @@ re-encode the JSON before verifying it.`,
 				return err
 			}
 
+			if sign {
+				computed := webhookserver.ComputeSignature([]byte(payload), secret)
+				if common.IsStructuredOutput(cmd) {
+					return common.GetOutputWriter(cmd).Write(map[string]string{"signature": computed})
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), computed)
+				return nil
+			}
+
 			if webhookserver.VerifySignature([]byte(payload), signature, secret) {
 				fmt.Printf("%s Signature is valid.\n", common.Green.Sprint("✓"))
 				return nil
@@ -50,6 +73,7 @@ re-encode the JSON before verifying it.`,
 	cmd.Flags().StringVar(&payloadFile, "payload-file", "", "Path to a file containing the raw webhook payload body")
 	cmd.Flags().StringVar(&signature, "signature", "", "Webhook signature from the x-nylas-signature header")
 	cmd.Flags().StringVar(&secret, "secret", "", "Webhook secret used to verify the signature")
+	cmd.Flags().BoolVar(&sign, "sign", false, "Print the payload's signature instead of verifying one")
 
 	return cmd
 }
@@ -3,6 +3,9 @@ package webhook
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 )
 
 // NewWebhookCmd creates the webhook command group.
@@ -21,17 +24,22 @@ Note: Notification destination management requires an API key (admin-level acces
 API reference: https://developer.nylas.com/docs/v3/notifications/`,
 	}
 
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newShowCmd())
-	cmd.AddCommand(newCreateCmd())
-	cmd.AddCommand(newUpdateCmd())
+	common.RequireScopes(cmd, domain.ScopeApplicationAPI)
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.Webhook{}))
+	cmd.AddCommand(common.DeclareOutput(newShowCmd(), domain.Webhook{}))
+	cmd.AddCommand(common.DeclareOutput(newCreateCmd(), domain.Webhook{}))
+	cmd.AddCommand(common.DeclareOutput(newUpdateCmd(), domain.Webhook{}))
 	cmd.AddCommand(newDeleteCmd())
 	cmd.AddCommand(newRotateSecretCmd())
-	cmd.AddCommand(newVerifyCmd())
+	cmd.AddCommand(common.DeclareOutput(newVerifyCmd(), map[string]string{}))
+	cmd.AddCommand(common.DeclareOutput(newReplayCmd(), []replayResult{}))
 	cmd.AddCommand(newPubSubCmd())
 	cmd.AddCommand(newTestCmd())
-	cmd.AddCommand(newTriggersCmd())
-	cmd.AddCommand(newServerCmd())
+	cmd.AddCommand(common.DeclareOutput(newTriggersCmd(), map[string][]string{}))
+	cmd.AddCommand(newEventsCmd())
+	cmd.AddCommand(common.RequireScopes(newBackfillCmd(), domain.ScopeEmailRead, domain.ScopeCalendarRead, domain.ScopeContactsRead))
+	cmd.AddCommand(common.RunLocally(common.RequireCapabilities(newServerCmd(), domain.CapabilityNetwork)))
 
 	return cmd
 }
@@ -17,7 +17,7 @@ func TestTriggersCommand(t *testing.T) {
 
 	t.Run("has_aliases", func(t *testing.T) {
 		assert.Contains(t, cmd.Aliases, "trigger-types")
-		assert.Contains(t, cmd.Aliases, "events")
+		assert.NotContains(t, cmd.Aliases, "events", "events is the stored-events command")
 	})
 
 	t.Run("format_flag_inherited_from_root", func(t *testing.T) {
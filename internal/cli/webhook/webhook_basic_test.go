@@ -44,7 +44,7 @@ func TestNewWebhookCmd(t *testing.T) {
 	t.Run("has_required_subcommands", func(t *testing.T) {
 		expectedCmds := []string{
 			"list", "show", "create", "update", "delete",
-			"rotate-secret", "verify", "pubsub", "test", "triggers", "server",
+			"rotate-secret", "verify", "pubsub", "test", "triggers", "server", "backfill", "events",
 		}
 
 		cmdMap := make(map[string]bool)
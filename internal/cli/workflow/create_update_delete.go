@@ -212,9 +212,10 @@ func newDeleteCmd() *cobra.Command {
 				if err := client.DeleteWorkflow(ctx, scope, grantID, args[0]); err != nil {
 					return common.WrapDeleteError("workflow", err)
 				}
-				if !common.IsStructuredOutput(cmd) {
-					common.PrintSuccess("Workflow deleted")
+				if common.IsStructuredOutput(cmd) {
+					return common.GetOutputWriter(cmd).Write(common.DeleteResult{Resource: "workflow", ID: args[0], Deleted: true})
 				}
+				common.PrintSuccess("Workflow deleted")
 				return nil
 			})
 		},
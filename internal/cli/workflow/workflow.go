@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
 	"github.com/spf13/cobra"
 )
 
@@ -23,11 +24,13 @@ API reference: https://developer.nylas.com/docs/reference/api/application-level-
 	}
 
 	common.AddOutputFlags(cmd)
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newShowCmd())
-	cmd.AddCommand(newCreateCmd())
-	cmd.AddCommand(newUpdateCmd())
-	cmd.AddCommand(newDeleteCmd())
+	common.RequireScopes(cmd, domain.ScopeApplicationAPI)
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), domain.RemoteWorkflowListResponse{}))
+	cmd.AddCommand(common.DeclareOutput(newShowCmd(), domain.RemoteWorkflow{}))
+	cmd.AddCommand(common.DeclareOutput(newCreateCmd(), domain.RemoteWorkflow{}))
+	cmd.AddCommand(common.DeclareOutput(newUpdateCmd(), domain.RemoteWorkflow{}))
+	cmd.AddCommand(common.DeclareOutput(newDeleteCmd(), common.DeleteResult{}))
 
 	return cmd
 }
@@ -28,8 +28,8 @@ Examples:
 					return struct{}{}, common.WrapListError("workspaces", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(workspaces)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(workspaces)
 				}
 
 				if len(workspaces) == 0 {
@@ -68,8 +68,8 @@ Examples:
 					return struct{}{}, common.WrapGetError("workspace", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(workspace)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(workspace)
 				}
 
 				printWorkspaceDetails(*workspace)
@@ -115,8 +115,8 @@ Examples:
 					return struct{}{}, common.WrapCreateError("workspace", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(workspace)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(workspace)
 				}
 
 				common.PrintSuccess("Created workspace: %s (%s)", workspace.Name, workspace.ID)
@@ -178,8 +178,8 @@ Examples:
 					return struct{}{}, common.WrapUpdateError("workspace", err)
 				}
 
-				if common.IsJSON(cmd) {
-					return struct{}{}, common.PrintJSON(workspace)
+				if common.IsStructuredOutput(cmd) {
+					return struct{}{}, common.GetOutputWriter(cmd).Write(workspace)
 				}
 
 				common.PrintSuccess("Updated workspace: %s (%s)", workspace.Name, workspace.ID)
@@ -1,6 +1,11 @@
 package workspace
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/nylas/cli/internal/cli/common"
+	"github.com/nylas/cli/internal/domain"
+
+	"github.com/spf13/cobra"
+)
 
 func NewWorkspaceCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -19,10 +24,12 @@ Examples:
   nylas workspace delete <workspace-id> --yes`,
 	}
 
-	cmd.AddCommand(newListCmd())
-	cmd.AddCommand(newGetCmd())
-	cmd.AddCommand(newCreateCmd())
-	cmd.AddCommand(newUpdateCmd())
+	common.RequireScopes(cmd, domain.ScopeApplicationAPI)
+
+	cmd.AddCommand(common.DeclareOutput(newListCmd(), []domain.Workspace{}))
+	cmd.AddCommand(common.DeclareOutput(newGetCmd(), domain.Workspace{}))
+	cmd.AddCommand(common.DeclareOutput(newCreateCmd(), domain.Workspace{}))
+	cmd.AddCommand(common.DeclareOutput(newUpdateCmd(), domain.Workspace{}))
 	cmd.AddCommand(newDeleteCmd())
 
 	return cmd
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"strings"
+	"time"
 )
 
 // deprecatedConnectorProviderInbox is the provider the CLI and API surface no
@@ -230,6 +231,17 @@ type ConnectorSettings struct {
 	SMTPSecurity string `json:"smtp_security,omitempty"` // "ssl", "starttls", "none"
 }
 
+// OAuthClientCheck is a provider OAuth client to test before a connector is
+// created with it. RedirectURI is the Nylas callback registered with the
+// provider.
+type OAuthClientCheck struct {
+	Provider     Provider
+	ClientID     string
+	ClientSecret string
+	Tenant       string // Microsoft only; defaults to "common"
+	RedirectURI  string
+}
+
 // CreateConnectorRequest represents a request to create a connector
 type CreateConnectorRequest struct {
 	Name     string             `json:"name"`
@@ -284,4 +296,45 @@ type GrantStats struct {
 	Valid      int            `json:"valid"`
 	Invalid    int            `json:"invalid"`
 	Revoked    int            `json:"revoked"`
+
+	// ByProviderStatus counts grants per provider, then per status.
+	ByProviderStatus map[string]map[string]int `json:"by_provider_status,omitempty"`
+	// CreatedByDay counts grants by UTC creation date (2006-01-02).
+	CreatedByDay map[string]int `json:"created_by_day,omitempty"`
+}
+
+// NewGrantStats counts grants by provider, status and creation date.
+func NewGrantStats(grants []Grant) *GrantStats {
+	stats := &GrantStats{
+		Total:            len(grants),
+		ByProvider:       make(map[string]int),
+		ByStatus:         make(map[string]int),
+		ByProviderStatus: make(map[string]map[string]int),
+		CreatedByDay:     make(map[string]int),
+	}
+
+	for _, grant := range grants {
+		provider := string(grant.Provider)
+		stats.ByProvider[provider]++
+
+		if grant.GrantStatus != "" {
+			stats.ByStatus[grant.GrantStatus]++
+			if stats.ByProviderStatus[provider] == nil {
+				stats.ByProviderStatus[provider] = make(map[string]int)
+			}
+			stats.ByProviderStatus[provider][grant.GrantStatus]++
+			switch grant.GrantStatus {
+			case "valid":
+				stats.Valid++
+			case "invalid":
+				stats.Invalid++
+			}
+		}
+
+		if !grant.CreatedAt.IsZero() {
+			stats.CreatedByDay[grant.CreatedAt.UTC().Format(time.DateOnly)]++
+		}
+	}
+
+	return stats
 }
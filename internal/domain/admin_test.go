@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -81,3 +82,25 @@ func TestResolveConnectorProvider(t *testing.T) {
 		assert.ElementsMatch(t, []string{"google", "microsoft"}, mErr.Providers)
 	})
 }
+
+func TestNewGrantStats(t *testing.T) {
+	day := func(d int) UnixTime { return UnixTime{time.Date(2026, 3, d, 23, 30, 0, 0, time.UTC)} }
+	grants := []Grant{
+		{Provider: ProviderGoogle, GrantStatus: "valid", CreatedAt: day(1)},
+		{Provider: ProviderGoogle, GrantStatus: "invalid", CreatedAt: day(1)},
+		{Provider: ProviderMicrosoft, GrantStatus: "valid", CreatedAt: day(3)},
+		{Provider: ProviderIMAP},
+	}
+
+	stats := NewGrantStats(grants)
+
+	assert.Equal(t, 4, stats.Total)
+	assert.Equal(t, 2, stats.Valid)
+	assert.Equal(t, 1, stats.Invalid)
+	assert.Equal(t, map[string]int{"google": 2, "microsoft": 1, "imap": 1}, stats.ByProvider)
+	assert.Equal(t, map[string]map[string]int{
+		"google":    {"valid": 1, "invalid": 1},
+		"microsoft": {"valid": 1},
+	}, stats.ByProviderStatus)
+	assert.Equal(t, map[string]int{"2026-03-01": 2, "2026-03-03": 1}, stats.CreatedByDay, "grants without a creation time are not dated")
+}
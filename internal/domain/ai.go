@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ChatMessage represents a chat message for AI/LLM interactions.
 type ChatMessage struct {
@@ -50,15 +53,16 @@ type TokenUsage struct {
 
 // AIConfig represents AI/LLM configuration.
 type AIConfig struct {
-	DefaultProvider string            `yaml:"default_provider"` // ollama, claude, openai, groq
-	Fallback        *AIFallbackConfig `yaml:"fallback,omitempty"`
-	Privacy         *PrivacyConfig    `yaml:"privacy,omitempty"`
-	Features        *FeaturesConfig   `yaml:"features,omitempty"`
-	Ollama          *OllamaConfig     `yaml:"ollama,omitempty"`
-	Claude          *ClaudeConfig     `yaml:"claude,omitempty"`
-	OpenAI          *OpenAIConfig     `yaml:"openai,omitempty"`
-	Groq            *GroqConfig       `yaml:"groq,omitempty"`
-	OpenRouter      *OpenRouterConfig `yaml:"openrouter,omitempty"`
+	DefaultProvider string             `yaml:"default_provider"` // ollama, claude, openai, groq
+	Fallback        *AIFallbackConfig  `yaml:"fallback,omitempty"`
+	Privacy         *PrivacyConfig     `yaml:"privacy,omitempty"`
+	Features        *FeaturesConfig    `yaml:"features,omitempty"`
+	Ollama          *OllamaConfig      `yaml:"ollama,omitempty"`
+	Claude          *ClaudeConfig      `yaml:"claude,omitempty"`
+	OpenAI          *OpenAIConfig      `yaml:"openai,omitempty"`
+	Groq            *GroqConfig        `yaml:"groq,omitempty"`
+	OpenRouter      *OpenRouterConfig  `yaml:"openrouter,omitempty"`
+	Translation     *TranslationConfig `yaml:"translation,omitempty"`
 }
 
 // AIFallbackConfig represents fallback configuration.
@@ -185,6 +189,27 @@ type FeaturesConfig struct {
 	EmailContextAnalysis      bool `yaml:"email_context_analysis"`      // Enable email context analysis
 }
 
+// EmailSummary is an AI summary of a message, a thread, or a digest of
+// recent messages.
+type EmailSummary struct {
+	Subject      string            `json:"subject,omitempty"`
+	MessageCount int               `json:"message_count"`
+	Since        time.Time         `json:"since,omitzero"` // digests only
+	Summary      string            `json:"summary"`
+	KeyPoints    []string          `json:"key_points,omitempty"`
+	ActionItems  []EmailActionItem `json:"action_items"`
+	Provider     string            `json:"provider,omitempty"`
+	TokensUsed   int               `json:"tokens_used,omitempty"`
+}
+
+// EmailActionItem is something an email asks the reader to do.
+type EmailActionItem struct {
+	Task    string `json:"task"`
+	From    string `json:"from,omitempty"`
+	Due     string `json:"due,omitempty"`
+	Subject string `json:"subject,omitempty"` // the message it comes from, in digests
+}
+
 // EmailThreadAnalysis represents the AI analysis of an email thread.
 type EmailThreadAnalysis struct {
 	ThreadID          string                 `json:"thread_id"`
@@ -0,0 +1,24 @@
+package domain
+
+// APIKey is an application API key, as listed by the admin API. The key
+// value itself is only returned once, by CreateAPIKey.
+type APIKey struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name,omitempty"`
+	Status      string   `json:"status,omitempty"` // active, revoked, expired
+	Permissions []string `json:"permissions,omitempty"`
+	ExpiresAt   UnixTime `json:"expires_at,omitempty"`
+	CreatedAt   UnixTime `json:"created_at,omitempty"`
+}
+
+// CreatedAPIKey is a newly created API key, including its secret value.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"api_key"`
+}
+
+// CreateAPIKeyRequest creates an application API key.
+type CreateAPIKeyRequest struct {
+	Name      string `json:"name"`
+	ExpiresIn int    `json:"expires_in,omitempty"` // days; 0 never expires
+}
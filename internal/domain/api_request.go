@@ -0,0 +1,21 @@
+package domain
+
+// Authorization schemes of an APIRequest.
+const (
+	APIAuthNone   = ""
+	APIAuthAPIKey = "api_key" // Bearer application API key
+	APIAuthToken  = "token"   // Bearer access or session token
+)
+
+// APIRequest describes an outgoing Nylas API request without its
+// credentials, e.g. to print the equivalent code for it.
+type APIRequest struct {
+	Method string
+	URL    string
+	// Auth is how the request is authorized; see the APIAuth constants.
+	Auth string
+	// Header holds the request headers other than Authorization,
+	// User-Agent and cache validators, keyed by canonical name.
+	Header map[string]string
+	Body   []byte
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// SniffLength is how many leading bytes to pass to SniffContentType and
+// InspectAttachment.
+const SniffLength = 512
+
+// DefaultUnsafeAttachmentTypes are blocked unless --allow-unsafe is given:
+// Windows executables and scripts, installers, shortcuts and disk images by
+// extension, and native executables by content.
+var DefaultUnsafeAttachmentTypes = []string{
+	"exe", "dll", "scr", "com", "pif", "cpl", "msi", "msp",
+	"bat", "cmd", "ps1", "vbs", "vbe", "js", "jse", "wsf", "wsh", "hta",
+	"lnk", "reg", "scf", "jar", "iso",
+	"application/x-msdownload", "application/x-executable", "application/x-mach-binary",
+}
+
+// fileSignature maps magic bytes at an offset to a content type and the
+// extensions such files normally carry.
+type fileSignature struct {
+	offset      int
+	magic       []byte
+	contentType string
+	extensions  []string
+}
+
+var fileSignatures = []fileSignature{
+	{0, []byte("MZ"), "application/x-msdownload", []string{"exe", "dll", "sys", "scr", "com", "cpl", "ocx", "efi", "drv"}},
+	{0, []byte("\x7fELF"), "application/x-executable", []string{"so", "elf", "axf", "prx"}},
+	{0, []byte("\xfe\xed\xfa\xce"), "application/x-mach-binary", []string{"dylib", "bundle"}},
+	{0, []byte("\xfe\xed\xfa\xcf"), "application/x-mach-binary", []string{"dylib", "bundle"}},
+	{0, []byte("\xce\xfa\xed\xfe"), "application/x-mach-binary", []string{"dylib", "bundle"}},
+	{0, []byte("\xcf\xfa\xed\xfe"), "application/x-mach-binary", []string{"dylib", "bundle"}},
+	{0, []byte("\xca\xfe\xba\xbe"), "application/java-vm", []string{"class", "dylib"}},
+	{0, []byte("#!"), "text/x-shellscript", []string{"sh", "bash", "zsh", "command", "py", "pl", "rb", "js"}},
+	{0, []byte("%PDF-"), "application/pdf", []string{"pdf", "ai"}},
+	{0, []byte("PK\x03\x04"), "application/zip", []string{
+		"zip", "docx", "docm", "xlsx", "xlsm", "pptx", "pptm", "odt", "ods", "odp",
+		"epub", "jar", "apk", "xpi", "vsix", "ipa", "key", "pages", "numbers", "kmz", "whl",
+	}},
+	{0, []byte("\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1"), "application/x-ole-storage", []string{"doc", "xls", "ppt", "msi", "msg", "pub", "vsd", "msp"}},
+	{0, []byte("\x89PNG\r\n\x1a\n"), "image/png", []string{"png"}},
+	{0, []byte("\xff\xd8\xff"), "image/jpeg", []string{"jpg", "jpeg", "jpe", "jfif"}},
+	{0, []byte("GIF87a"), "image/gif", []string{"gif"}},
+	{0, []byte("GIF89a"), "image/gif", []string{"gif"}},
+	{8, []byte("WEBP"), "image/webp", []string{"webp"}},
+	{0, []byte("II*\x00"), "image/tiff", []string{"tif", "tiff", "dng", "cr2", "nef"}},
+	{0, []byte("MM\x00*"), "image/tiff", []string{"tif", "tiff", "dng", "nef"}},
+	{4, []byte("ftyp"), "video/mp4", []string{"mp4", "m4a", "m4v", "mov", "heic", "heif", "avif", "3gp"}},
+	{0, []byte("ID3"), "audio/mpeg", []string{"mp3"}},
+	{0, []byte("OggS"), "audio/ogg", []string{"ogg", "oga", "ogv", "opus"}},
+	{0, []byte("\x1f\x8b"), "application/gzip", []string{"gz", "tgz"}},
+	{0, []byte("Rar!\x1a\x07"), "application/vnd.rar", []string{"rar"}},
+	{0, []byte("7z\xbc\xaf\x27\x1c"), "application/x-7z-compressed", []string{"7z"}},
+	{0, []byte("{\\rtf"), "application/rtf", []string{"rtf", "doc"}},
+}
+
+// textExtensions are plain-text formats; binary content in one is a mismatch.
+var textExtensions = []string{"txt", "csv", "tsv", "json", "md", "html", "htm", "xml", "log", "ics", "vcf", "eml", "yaml", "yml"}
+
+// AttachmentInspection is the result of checking an attachment's content
+// against its name and the unsafe-type policy.
+type AttachmentInspection struct {
+	Filename    string `json:"filename"`
+	SniffedType string `json:"sniffed_type,omitempty"` // From magic bytes; empty when unrecognized
+	// Mismatch is set when the content doesn't match the extension, e.g. an
+	// executable named invoice.pdf.
+	Mismatch bool `json:"mismatch,omitempty"`
+	// Unsafe is set when the extension, sniffed type or declared type is on
+	// the blocklist; Reason names the entry.
+	Unsafe bool   `json:"unsafe,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SniffContentType returns the content type recognized from the leading
+// bytes of a file, or "" when none matches.
+func SniffContentType(head []byte) string {
+	if sig := sniffSignature(head); sig != nil {
+		return sig.contentType
+	}
+	return ""
+}
+
+func sniffSignature(head []byte) *fileSignature {
+	for i := range fileSignatures {
+		sig := &fileSignatures[i]
+		end := sig.offset + len(sig.magic)
+		if len(head) >= end && bytes.Equal(head[sig.offset:end], sig.magic) {
+			return sig
+		}
+	}
+	return nil
+}
+
+// InspectAttachment sniffs head (the first SniffLength bytes or fewer),
+// compares it with the file extension, and checks the extension, sniffed
+// type and declared type against blocklist. Blocklist entries are
+// extensions ("exe", ".exe") or content types ("application/x-msdownload").
+func InspectAttachment(filename, declaredType string, head []byte, blocklist []string) AttachmentInspection {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	result := AttachmentInspection{Filename: filename}
+
+	sig := sniffSignature(head)
+	if sig != nil {
+		result.SniffedType = sig.contentType
+		result.Mismatch = ext != "" && !slices.Contains(sig.extensions, ext) && knownExtension(ext)
+	}
+
+	declaredType = strings.ToLower(strings.TrimSpace(strings.Split(declaredType, ";")[0]))
+	for _, entry := range blocklist {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case strings.Contains(entry, "/"):
+			if entry == result.SniffedType {
+				result.Unsafe, result.Reason = true, fmt.Sprintf("content is %s", entry)
+			} else if entry == declaredType {
+				result.Unsafe, result.Reason = true, fmt.Sprintf("declared as %s", entry)
+			}
+		case ext != "" && strings.TrimPrefix(entry, ".") == ext:
+			result.Unsafe, result.Reason = true, fmt.Sprintf(".%s file", ext)
+		}
+		if result.Unsafe {
+			break
+		}
+	}
+	return result
+}
+
+// knownExtension reports whether the extension belongs to a recognized
+// format, so that content of another format is a mismatch. Unknown
+// extensions (.dat, .bin) say nothing about the content.
+func knownExtension(ext string) bool {
+	if slices.Contains(textExtensions, ext) {
+		return true
+	}
+	for _, sig := range fileSignatures {
+		if slices.Contains(sig.extensions, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnsafeTypes returns the configured attachment blocklist, or the default
+// when none is set.
+func (c *EmailConfig) UnsafeTypes() []string {
+	if c == nil || len(c.UnsafeAttachmentTypes) == 0 {
+		return DefaultUnsafeAttachmentTypes
+	}
+	return c.UnsafeAttachmentTypes
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSniffContentType(t *testing.T) {
+	tests := []struct {
+		name string
+		head string
+		want string
+	}{
+		{"pe", "MZ\x90\x00\x03", "application/x-msdownload"},
+		{"elf", "\x7fELF\x02\x01", "application/x-executable"},
+		{"pdf", "%PDF-1.7\n", "application/pdf"},
+		{"zip", "PK\x03\x04\x14\x00", "application/zip"},
+		{"png", "\x89PNG\r\n\x1a\n\x00", "image/png"},
+		{"webp", "RIFF\x00\x00\x00\x00WEBPVP8 ", "image/webp"},
+		{"mp4", "\x00\x00\x00\x18ftypmp42", "video/mp4"},
+		{"text", "hello world", ""},
+		{"short", "M", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SniffContentType([]byte(tt.head)))
+		})
+	}
+}
+
+func TestInspectAttachment(t *testing.T) {
+	pe := []byte("MZ\x90\x00")
+	pdf := []byte("%PDF-1.4")
+	zip := []byte("PK\x03\x04")
+
+	tests := []struct {
+		name         string
+		filename     string
+		declared     string
+		head         []byte
+		blocklist    []string
+		wantMismatch bool
+		wantUnsafe   bool
+		wantReason   string
+	}{
+		{name: "matching pdf", filename: "report.pdf", head: pdf, blocklist: DefaultUnsafeAttachmentTypes},
+		{name: "docx is zip", filename: "Report.DOCX", head: zip, blocklist: DefaultUnsafeAttachmentTypes},
+		{name: "executable named pdf", filename: "invoice.pdf", head: pe, blocklist: DefaultUnsafeAttachmentTypes,
+			wantMismatch: true, wantUnsafe: true, wantReason: "content is application/x-msdownload"},
+		{name: "exe by extension", filename: "setup.exe", head: pe, blocklist: DefaultUnsafeAttachmentTypes,
+			wantUnsafe: true, wantReason: ".exe file"},
+		{name: "script by extension only", filename: "run.vbs", head: []byte("MsgBox"), blocklist: DefaultUnsafeAttachmentTypes,
+			wantUnsafe: true, wantReason: ".vbs file"},
+		{name: "declared type", filename: "payload", declared: "application/x-msdownload; name=payload", blocklist: DefaultUnsafeAttachmentTypes,
+			wantUnsafe: true, wantReason: "declared as application/x-msdownload"},
+		{name: "pdf named txt", filename: "notes.txt", head: pdf, wantMismatch: true},
+		{name: "unknown extension", filename: "blob.dat", head: pdf},
+		{name: "no extension", filename: "README", head: []byte("text"), blocklist: []string{"."}},
+		{name: "custom blocklist with dot", filename: "archive.ZIP", head: zip, blocklist: []string{".zip"},
+			wantUnsafe: true, wantReason: ".zip file"},
+		{name: "custom blocklist allows exe", filename: "tool.exe", head: pe, blocklist: []string{"zip"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InspectAttachment(tt.filename, tt.declared, tt.head, tt.blocklist)
+			assert.Equal(t, tt.wantMismatch, got.Mismatch, "mismatch")
+			assert.Equal(t, tt.wantUnsafe, got.Unsafe, "unsafe")
+			assert.Equal(t, tt.wantReason, got.Reason)
+		})
+	}
+}
+
+func TestEmailConfig_UnsafeTypes(t *testing.T) {
+	var nilCfg *EmailConfig
+	assert.Equal(t, DefaultUnsafeAttachmentTypes, nilCfg.UnsafeTypes())
+	assert.Equal(t, DefaultUnsafeAttachmentTypes, (&EmailConfig{}).UnsafeTypes())
+	assert.Equal(t, []string{"zip"}, (&EmailConfig{UnsafeAttachmentTypes: []string{"zip"}}).UnsafeTypes())
+}
@@ -34,6 +34,16 @@ type AuditEntry struct {
 	// Invocation tracking
 	Invoker       string `json:"invoker,omitempty"`        // Username: "alice", "dependabot[bot]"
 	InvokerSource string `json:"invoker_source,omitempty"` // Source: "claude-code", "github-actions", "terminal"
+
+	// Details are command-specific facts, e.g. "transport": "smtp" for email send.
+	Details map[string]string `json:"details,omitempty"`
+
+	// Hash chain: Seq numbers entries from 1, PrevHash is the previous
+	// entry's Hash, and Hash is the SHA-256 of this entry with Hash empty.
+	// Entries written before chaining was introduced have none of these.
+	Seq      int64  `json:"seq,omitempty"`
+	PrevHash string `json:"prev_hash,omitempty"`
+	Hash     string `json:"hash,omitempty"`
 }
 
 // AuditConfig contains all audit logging configuration.
@@ -55,6 +65,60 @@ type AuditConfig struct {
 	// Rotation settings
 	RotateDaily bool `json:"rotate_daily"` // Create new file each day
 	CompressOld bool `json:"compress_old"` // Gzip files older than 7 days
+
+	// Remote sinks that receive a copy of every entry
+	Sinks []AuditSink `json:"sinks,omitempty"`
+}
+
+// Sink returns the sink with the given name, or nil.
+func (c *AuditConfig) Sink(name string) *AuditSink {
+	for i := range c.Sinks {
+		if c.Sinks[i].Name == name {
+			return &c.Sinks[i]
+		}
+	}
+	return nil
+}
+
+// AuditSinkType is where a remote sink ships entries.
+type AuditSinkType string
+
+// Audit sink types.
+const (
+	AuditSinkSyslog AuditSinkType = "syslog" // RFC 5424 over UDP, TCP or TLS
+	AuditSinkHTTPS  AuditSinkType = "https"  // JSON array POSTed to a collector
+	AuditSinkS3     AuditSinkType = "s3"     // One JSON Lines object per batch
+)
+
+// Audit sink batching defaults.
+const (
+	DefaultAuditSinkBatchSize = 20
+	// AuditSinkMaxAge is how long an entry waits for a batch to fill
+	// before it is sent anyway.
+	AuditSinkMaxAge = 5 * time.Minute
+)
+
+// AuditSink ships audit entries to a central collector. Entries are queued
+// locally and sent in batches, so a collector outage loses nothing.
+type AuditSink struct {
+	Name string        `json:"name"`
+	Type AuditSinkType `json:"type"`
+	// URL is udp://, tcp:// or tls://host:port for syslog, the collector
+	// URL for https, and s3://bucket/prefix for s3.
+	URL string `json:"url"`
+	// Region and Endpoint apply to s3. Endpoint overrides the AWS endpoint
+	// for S3-compatible stores and uses path-style addressing.
+	Region    string `json:"region,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	BatchSize int    `json:"batch_size,omitempty"` // default DefaultAuditSinkBatchSize
+}
+
+// EffectiveBatchSize returns the batch size, applying the default.
+func (s AuditSink) EffectiveBatchSize() int {
+	if s.BatchSize > 0 {
+		return s.BatchSize
+	}
+	return DefaultAuditSinkBatchSize
 }
 
 // DefaultAuditConfig returns the default audit configuration.
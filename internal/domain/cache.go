@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Cacheable resources and their default TTLs. Only read-only list and get
+// endpoints for these resources are cached.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"folders":   10 * time.Minute,
+	"calendars": 10 * time.Minute,
+	"contacts":  5 * time.Minute,
+	"grants":    time.Minute,
+}
+
+// CacheConfig configures the on-disk API response cache.
+type CacheConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	FoldersTTL   string `yaml:"folders_ttl,omitempty"`   // e.g. "10m"
+	CalendarsTTL string `yaml:"calendars_ttl,omitempty"` // e.g. "10m"
+	ContactsTTL  string `yaml:"contacts_ttl,omitempty"`  // e.g. "5m"
+	GrantsTTL    string `yaml:"grants_ttl,omitempty"`    // e.g. "1m"
+}
+
+// TTL returns how long a cached response for resource stays fresh. Unset or
+// unparseable values fall back to DefaultCacheTTLs; "0" disables caching for
+// that resource.
+func (c *CacheConfig) TTL(resource string) time.Duration {
+	var raw string
+	if c != nil {
+		switch resource {
+		case "folders":
+			raw = c.FoldersTTL
+		case "calendars":
+			raw = c.CalendarsTTL
+		case "contacts":
+			raw = c.ContactsTTL
+		case "grants":
+			raw = c.GrantsTTL
+		}
+	}
+	if raw == "0" {
+		return 0
+	}
+	if d, ok := parsePositiveDuration(raw); ok {
+		return d
+	}
+	return DefaultCacheTTLs[resource]
+}
+
+// CacheEnabled reports whether API responses should be cached. NYLAS_CACHE
+// (true/false) overrides the cache.enabled setting.
+func (c *Config) CacheEnabled() bool {
+	if v, err := strconv.ParseBool(os.Getenv("NYLAS_CACHE")); err == nil {
+		return v
+	}
+	return c.Cache != nil && c.Cache.Enabled
+}
+
+// CachedResponse is a stored API response body with its validators.
+type CachedResponse struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// Fresh reports whether the response is younger than ttl at now.
+func (r *CachedResponse) Fresh(ttl time.Duration, now time.Time) bool {
+	return ttl > 0 && now.Sub(r.StoredAt) < ttl
+}
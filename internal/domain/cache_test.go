@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheConfig_TTL(t *testing.T) {
+	var unset *CacheConfig
+	assert.Equal(t, 10*time.Minute, unset.TTL("folders"))
+	assert.Equal(t, time.Minute, unset.TTL("grants"))
+	assert.Zero(t, unset.TTL("messages"))
+
+	cfg := &CacheConfig{ContactsTTL: "15m", CalendarsTTL: "0", GrantsTTL: "soon"}
+	assert.Equal(t, 15*time.Minute, cfg.TTL("contacts"))
+	assert.Zero(t, cfg.TTL("calendars"))
+	assert.Equal(t, time.Minute, cfg.TTL("grants"))
+}
+
+func TestConfig_CacheEnabled(t *testing.T) {
+	t.Setenv("NYLAS_CACHE", "")
+	assert.False(t, (&Config{}).CacheEnabled())
+	assert.True(t, (&Config{Cache: &CacheConfig{Enabled: true}}).CacheEnabled())
+
+	t.Setenv("NYLAS_CACHE", "false")
+	assert.False(t, (&Config{Cache: &CacheConfig{Enabled: true}}).CacheEnabled())
+	t.Setenv("NYLAS_CACHE", "true")
+	assert.True(t, (&Config{}).CacheEnabled())
+}
+
+func TestCachedResponse_Fresh(t *testing.T) {
+	now := time.Now()
+	r := &CachedResponse{StoredAt: now.Add(-2 * time.Minute)}
+	assert.True(t, r.Fresh(5*time.Minute, now))
+	assert.False(t, r.Fresh(time.Minute, now))
+	assert.False(t, r.Fresh(0, now))
+}
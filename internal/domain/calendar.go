@@ -183,8 +183,15 @@ type Participant struct {
 
 // Conferencing represents video conferencing details.
 type Conferencing struct {
-	Provider string               `json:"provider,omitempty"` // Google Meet, Zoom, etc.
-	Details  *ConferencingDetails `json:"details,omitempty"`
+	Provider   string                  `json:"provider,omitempty"` // Google Meet, Zoom, etc.
+	Details    *ConferencingDetails    `json:"details,omitempty"`
+	Autocreate *ConferencingAutocreate `json:"autocreate,omitempty"`
+}
+
+// ConferencingAutocreate asks the API to provision a meeting when the event
+// is created. Zoom needs the grant ID of a connected Zoom account.
+type ConferencingAutocreate struct {
+	ConfGrantID string `json:"conf_grant_id,omitempty"`
 }
 
 // ConferencingDetails contains conferencing URLs and info.
@@ -224,6 +231,7 @@ type EventQueryParams struct {
 	OrderBy         string `json:"order_by,omitempty"` // start, end
 	ExpandRecurring bool   `json:"expand_recurring,omitempty"`
 	ICalUID         string `json:"ical_uid,omitempty"` // resolves an emailed invite (VEVENT UID) to a Nylas event ID
+	Select          string `json:"select,omitempty"`   // Comma-separated fields to return, e.g. "id"
 }
 
 // CreateEventRequest for creating a new event.
@@ -240,6 +248,10 @@ type CreateEventRequest struct {
 	Reminders    *Reminders        `json:"reminders,omitempty"`
 	CalendarID   string            `json:"calendar_id,omitempty"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
+
+	// NotifyParticipants is sent as a query parameter; nil leaves the API
+	// default (participants are notified).
+	NotifyParticipants *bool `json:"-"`
 }
 
 // UpdateEventRequest for updating an event.
@@ -255,6 +267,10 @@ type UpdateEventRequest struct {
 	Conferencing *Conferencing     `json:"conferencing,omitempty"`
 	Reminders    *Reminders        `json:"reminders,omitempty"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
+
+	// NotifyParticipants is sent as a query parameter; nil leaves the API
+	// default (participants are notified).
+	NotifyParticipants *bool `json:"-"`
 }
 
 // CalendarListResponse represents a paginated calendar list response.
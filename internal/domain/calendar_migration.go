@@ -0,0 +1,87 @@
+package domain
+
+import "time"
+
+// CalendarMigrationCheckpoint records the events `migrate calendar` has
+// copied, so a re-run only creates new events and updates changed ones.
+type CalendarMigrationCheckpoint struct {
+	From      string                            `json:"from"`
+	To        string                            `json:"to"`
+	Calendars map[string]*CalendarMigrationMark `json:"calendars"` // Keyed by source calendar ID
+	UpdatedAt time.Time                         `json:"updated_at"`
+}
+
+// CalendarMigrationMark is the per-calendar progress in a checkpoint.
+type CalendarMigrationMark struct {
+	Calendar string                        `json:"calendar"` // Destination calendar ID
+	Events   map[string]EventMigrationMark `json:"events"`   // Keyed by source event ID
+}
+
+// EventMigrationMark links a source event to its copy.
+type EventMigrationMark struct {
+	DestinationID string    `json:"destination_id"`
+	UpdatedAt     time.Time `json:"updated_at"` // Source event's updated_at when copied
+}
+
+// NewCalendarMigrationCheckpoint returns an empty checkpoint for from → to.
+func NewCalendarMigrationCheckpoint(from, to string) *CalendarMigrationCheckpoint {
+	return &CalendarMigrationCheckpoint{From: from, To: to, Calendars: map[string]*CalendarMigrationMark{}}
+}
+
+// Mark returns the progress for calendarID, creating it if needed.
+func (c *CalendarMigrationCheckpoint) Mark(calendarID string) *CalendarMigrationMark {
+	mark := c.Calendars[calendarID]
+	if mark == nil {
+		mark = &CalendarMigrationMark{}
+		c.Calendars[calendarID] = mark
+	}
+	if mark.Events == nil {
+		mark.Events = map[string]EventMigrationMark{}
+	}
+	return mark
+}
+
+// CalendarMigration summarizes the copy of one source calendar.
+type CalendarMigration struct {
+	Calendar        string `json:"calendar"`    // Source calendar name
+	Destination     string `json:"destination"` // Destination calendar name
+	DestinationID   string `json:"destination_id,omitempty"`
+	CalendarCreated bool   `json:"calendar_created,omitempty"`
+	Events          int    `json:"events"`
+	Created         int    `json:"created"`
+	Updated         int    `json:"updated"`
+	Skipped         int    `json:"skipped"` // Unchanged since an earlier run
+	Failed          int    `json:"failed"`
+}
+
+// EventMapping links a source event to the event created or updated from it.
+type EventMapping struct {
+	Calendar      string `json:"calendar"`
+	SourceID      string `json:"source_id"`
+	DestinationID string `json:"destination_id,omitempty"` // Empty in dry runs of new events
+	Title         string `json:"title,omitempty"`
+	Action        string `json:"action"` // created, updated
+}
+
+// CalendarMigrationFailure is an event that could not be copied.
+type CalendarMigrationFailure struct {
+	EventID  string `json:"event_id"`
+	Calendar string `json:"calendar"`
+	Title    string `json:"title,omitempty"`
+	Error    string `json:"error"`
+}
+
+// CalendarMigrationReport is the result of `migrate calendar`.
+type CalendarMigrationReport struct {
+	From        string                     `json:"from"`
+	To          string                     `json:"to"`
+	DryRun      bool                       `json:"dry_run,omitempty"`
+	Calendars   []CalendarMigration        `json:"calendars"`
+	Created     int                        `json:"created"`
+	Updated     int                        `json:"updated"`
+	Skipped     int                        `json:"skipped"`
+	Failed      int                        `json:"failed"`
+	Mappings    []EventMapping             `json:"mappings"`
+	Failures    []CalendarMigrationFailure `json:"failures,omitempty"`
+	Interrupted bool                       `json:"interrupted,omitempty"`
+}
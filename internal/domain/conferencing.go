@@ -0,0 +1,50 @@
+package domain
+
+import "sort"
+
+// ConferenceProviders maps the short names accepted by the CLI to the
+// provider names used by the Nylas API.
+var ConferenceProviders = map[string]string{
+	"zoom":  "Zoom Meeting",
+	"meet":  "Google Meet",
+	"teams": "Microsoft Teams",
+}
+
+// ConferenceProviderNames returns the short provider names in sorted order.
+func ConferenceProviderNames() []string {
+	names := make([]string, 0, len(ConferenceProviders))
+	for name := range ConferenceProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConferencingConfig holds static join URLs used when a meeting can't be
+// provisioned automatically. URLs may use {{title}}.
+type ConferencingConfig struct {
+	ZoomURL  string `yaml:"zoom_url,omitempty"`
+	MeetURL  string `yaml:"meet_url,omitempty"`
+	TeamsURL string `yaml:"teams_url,omitempty"`
+
+	// ZoomGrantID is the grant of a connected Zoom account, required to
+	// autocreate Zoom meetings.
+	ZoomGrantID string `yaml:"zoom_grant_id,omitempty"`
+}
+
+// JoinURL returns the configured join URL template for a short provider
+// name, or "".
+func (c *ConferencingConfig) JoinURL(provider string) string {
+	if c == nil {
+		return ""
+	}
+	switch provider {
+	case "zoom":
+		return c.ZoomURL
+	case "meet":
+		return c.MeetURL
+	case "teams":
+		return c.TeamsURL
+	}
+	return ""
+}
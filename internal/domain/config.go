@@ -2,6 +2,7 @@ package domain
 
 import (
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -54,14 +55,38 @@ type Config struct {
 	// GPG settings
 	GPG *GPGConfig `yaml:"gpg,omitempty"`
 
+	// Email sending settings
+	Email *EmailConfig `yaml:"email,omitempty"`
+
 	// Dashboard authentication settings
 	Dashboard *DashboardConfig `yaml:"dashboard,omitempty"`
+
+	// On-disk API response cache (opt-in)
+	Cache *CacheConfig `yaml:"cache,omitempty"`
+
+	// Conferencing settings for calendar events
+	Conferencing *ConferencingConfig `yaml:"conferencing,omitempty"`
+
+	// Notetaker auto-join rules
+	Notetaker *NotetakerConfig `yaml:"notetaker,omitempty"`
+
+	// Notification routing for watch and daemon commands
+	Notify *NotifyConfig `yaml:"notify,omitempty"`
+
+	// Tool permissions for the MCP server
+	MCP *MCPConfig `yaml:"mcp,omitempty"`
+
+	// Named environment profiles (e.g. sandbox, prod) and the one in use.
+	// API keys for profiles live in the secret store.
+	Environments map[string]*EnvironmentProfile `yaml:"environments,omitempty"`
+	CurrentEnv   string                         `yaml:"current_env,omitempty"`
 }
 
 // APIConfig represents API-specific configuration.
 type APIConfig struct {
-	BaseURL string `yaml:"base_url,omitempty"` // API base URL
-	Timeout string `yaml:"timeout,omitempty"`  // API request timeout, e.g. "120s" (default TimeoutAPI)
+	BaseURL     string `yaml:"base_url,omitempty"`     // API base URL
+	Timeout     string `yaml:"timeout,omitempty"`      // API request timeout, e.g. "120s" (default TimeoutAPI)
+	StatusCheck bool   `yaml:"status_check,omitempty"` // Annotate outage-like errors with the Nylas status page
 }
 
 const (
@@ -80,6 +105,12 @@ func (c *Config) ResolveBaseURL() string {
 	return BaseURLUS
 }
 
+// ConnectorRedirectURI returns the Nylas callback URI that OAuth apps used by
+// connectors must register with their provider.
+func (c *Config) ConnectorRedirectURI() string {
+	return c.ResolveBaseURL() + "/v3/connect/callback"
+}
+
 // ResolveAPITimeout returns the effective per-request API timeout. Order of
 // precedence: NYLAS_API_TIMEOUT env var, then config api.timeout, then the
 // TimeoutAPI default. Unparseable or non-positive values are ignored so a bad
@@ -96,6 +127,16 @@ func (c *Config) ResolveAPITimeout() time.Duration {
 	return TimeoutAPI
 }
 
+// StatusCheckEnabled reports whether outage-like API errors should be
+// annotated with the Nylas status page. NYLAS_STATUS_CHECK (true/false)
+// overrides the api.status_check setting.
+func (c *Config) StatusCheckEnabled() bool {
+	if v, err := strconv.ParseBool(os.Getenv("NYLAS_STATUS_CHECK")); err == nil {
+		return v
+	}
+	return c.API != nil && c.API.StatusCheck
+}
+
 func parsePositiveDuration(s string) (time.Duration, bool) {
 	if s == "" {
 		return 0, false
@@ -230,8 +271,76 @@ func DefaultWorkingHours() *DaySchedule {
 	}
 }
 
+// EmailConfig represents email sending configuration.
+type EmailConfig struct {
+	// AttachmentUploadURL receives oversized attachments as HTTP PUT
+	// {url}/{filename} and responds with a download link, as transfer.sh does.
+	AttachmentUploadURL string `yaml:"attachment_upload_url,omitempty"`
+
+	// SMTP is a relay used by `email send --via smtp|auto` when the Nylas
+	// send endpoint is unavailable. Its password or token is kept in the
+	// keyring, not here.
+	SMTP *SMTPConfig `yaml:"smtp,omitempty"`
+
+	// QuietHours holds sends made during the window until it ends, keyed by
+	// grant ID or QuietHoursAllGrants.
+	QuietHours map[string]*QuietHours `yaml:"quiet_hours,omitempty"`
+
+	// BestSendTime is the recipient-local HH:MM that
+	// `email send --send-at-best-time` targets. Default: DefaultBestSendTime.
+	BestSendTime string `yaml:"best_send_time,omitempty"`
+
+	// UnsafeAttachmentTypes are extensions or content types that sending and
+	// downloading refuse without --allow-unsafe. Default:
+	// DefaultUnsafeAttachmentTypes.
+	UnsafeAttachmentTypes []string `yaml:"unsafe_attachment_types,omitempty"`
+
+	// ArchivePaths are exported mbox files, .eml files, or directories of
+	// them that `email search` indexes and searches alongside live mail.
+	ArchivePaths []string `yaml:"archive_paths,omitempty"`
+
+	// SavedSearches are `email search` queries kept by `email search save`,
+	// keyed by name.
+	SavedSearches map[string]*SavedSearch `yaml:"saved_searches,omitempty"`
+
+	// Identities are the send-as addresses found by `email identities list`,
+	// keyed by grant ID.
+	Identities map[string]*GrantIdentities `yaml:"identities,omitempty"`
+}
+
+// SMTPAuthMethod is how the CLI authenticates to an SMTP relay.
+type SMTPAuthMethod string
+
+// SMTP relay authentication methods.
+const (
+	SMTPAuthPlain   SMTPAuthMethod = "plain"
+	SMTPAuthLogin   SMTPAuthMethod = "login"
+	SMTPAuthXOAuth2 SMTPAuthMethod = "xoauth2" // OAuth access token (Gmail, Microsoft 365)
+)
+
+// SMTPConfig is an SMTP relay for sending outside the Nylas API.
+type SMTPConfig struct {
+	Host     string         `yaml:"host"`
+	Port     int            `yaml:"port,omitempty"`     // default: 465 for tls, 587 otherwise
+	Username string         `yaml:"username,omitempty"` // login; also the SASL user for xoauth2
+	From     string         `yaml:"from,omitempty"`     // envelope/header sender; default: grant email
+	Auth     SMTPAuthMethod `yaml:"auth,omitempty"`     // plain (default), login, xoauth2
+	TLS      MailTLSMode    `yaml:"tls,omitempty"`      // starttls (default), tls, none
+	Fallback bool           `yaml:"fallback,omitempty"` // make --via auto the default for email send
+}
+
+// GPGBackend selects the OpenPGP implementation used for signing and encryption.
+type GPGBackend string
+
+// OpenPGP backends.
+const (
+	GPGBackendSystem GPGBackend = "system" // Shell out to the gpg binary (default)
+	GPGBackendNative GPGBackend = "native" // Built-in Go implementation; no gnupg needed
+)
+
 // GPGConfig represents GPG/PGP email signing configuration.
 type GPGConfig struct {
-	DefaultKey string `yaml:"default_key,omitempty"` // Default GPG key ID for signing
-	AutoSign   bool   `yaml:"auto_sign,omitempty"`   // Automatically sign all outgoing emails
+	DefaultKey string     `yaml:"default_key,omitempty"` // Default GPG key ID for signing
+	AutoSign   bool       `yaml:"auto_sign,omitempty"`   // Automatically sign all outgoing emails
+	Backend    GPGBackend `yaml:"backend,omitempty"`     // system (default) or native
 }
@@ -69,6 +69,11 @@ func TestResolveBaseURL(t *testing.T) {
 	}
 }
 
+func TestConnectorRedirectURI(t *testing.T) {
+	assert.Equal(t, "https://api.us.nylas.com/v3/connect/callback", (&Config{}).ConnectorRedirectURI())
+	assert.Equal(t, "https://api.eu.nylas.com/v3/connect/callback", (&Config{Region: "eu"}).ConnectorRedirectURI())
+}
+
 func TestResolveAPITimeout(t *testing.T) {
 	t.Run("defaults to TimeoutAPI when unset", func(t *testing.T) {
 		t.Setenv("NYLAS_API_TIMEOUT", "")
@@ -131,6 +131,7 @@ type ContactQueryParams struct {
 	Group          string `json:"group,omitempty"`
 	Recurse        bool   `json:"recurse,omitempty"`
 	ProfilePicture bool   `json:"profile_picture,omitempty"` // Include Base64-encoded profile picture
+	Select         string `json:"select,omitempty"`          // Comma-separated fields to return, e.g. "id"
 }
 
 // CreateContactRequest for creating a new contact.
@@ -155,22 +156,23 @@ type CreateContactRequest struct {
 
 // UpdateContactRequest for updating a contact.
 type UpdateContactRequest struct {
-	GivenName         *string            `json:"given_name,omitempty"`
-	MiddleName        *string            `json:"middle_name,omitempty"`
-	Surname           *string            `json:"surname,omitempty"`
-	Suffix            *string            `json:"suffix,omitempty"`
-	Nickname          *string            `json:"nickname,omitempty"`
-	Birthday          *string            `json:"birthday,omitempty"`
-	CompanyName       *string            `json:"company_name,omitempty"`
-	JobTitle          *string            `json:"job_title,omitempty"`
-	ManagerName       *string            `json:"manager_name,omitempty"`
-	Notes             *string            `json:"notes,omitempty"`
-	Emails            []ContactEmail     `json:"emails,omitempty"`
-	PhoneNumbers      []ContactPhone     `json:"phone_numbers,omitempty"`
-	WebPages          []ContactWebPage   `json:"web_pages,omitempty"`
-	IMAddresses       []ContactIM        `json:"im_addresses,omitempty"`
-	PhysicalAddresses []ContactAddress   `json:"physical_addresses,omitempty"`
-	Groups            []ContactGroupInfo `json:"groups,omitempty"`
+	GivenName         *string             `json:"given_name,omitempty"`
+	MiddleName        *string             `json:"middle_name,omitempty"`
+	Surname           *string             `json:"surname,omitempty"`
+	Suffix            *string             `json:"suffix,omitempty"`
+	Nickname          *string             `json:"nickname,omitempty"`
+	Birthday          *string             `json:"birthday,omitempty"`
+	CompanyName       *string             `json:"company_name,omitempty"`
+	JobTitle          *string             `json:"job_title,omitempty"`
+	ManagerName       *string             `json:"manager_name,omitempty"`
+	Notes             *string             `json:"notes,omitempty"`
+	Emails            []ContactEmail      `json:"emails,omitempty"`
+	PhoneNumbers      []ContactPhone      `json:"phone_numbers,omitempty"`
+	WebPages          []ContactWebPage    `json:"web_pages,omitempty"`
+	IMAddresses       []ContactIM         `json:"im_addresses,omitempty"`
+	PhysicalAddresses []ContactAddress    `json:"physical_addresses,omitempty"`
+	Groups            *[]ContactGroupInfo `json:"groups,omitempty"`  // Replaces all memberships; empty removes them
+	Picture           *string             `json:"picture,omitempty"` // Base64-encoded image data
 }
 
 // ContactListResponse represents a paginated contact list response.
@@ -194,3 +196,23 @@ type CreateContactGroupRequest struct {
 type UpdateContactGroupRequest struct {
 	Name *string `json:"name,omitempty"`
 }
+
+// DefaultContactPictureSizeLimit applies to providers without a documented
+// limit on contact photos.
+const DefaultContactPictureSizeLimit int64 = 2 << 20
+
+// contactPictureSizeLimits are the providers' documented maximum contact
+// photo sizes, before Base64 encoding.
+var contactPictureSizeLimits = map[Provider]int64{
+	ProviderMicrosoft: 4 << 20, // Microsoft Graph contact photos
+	ProviderEWS:       4 << 20,
+}
+
+// ContactPictureSizeLimit returns the maximum contact photo size for a
+// provider.
+func ContactPictureSizeLimit(p Provider) int64 {
+	if limit, ok := contactPictureSizeLimits[p]; ok {
+		return limit
+	}
+	return DefaultContactPictureSizeLimit
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// Contact migration modes.
+const (
+	ContactSyncCopy   = "copy"
+	ContactSyncTwoWay = "two-way-sync"
+)
+
+// Contact conflict strategies, applied when a pair changed on both sides.
+const (
+	ContactConflictNewest      = "newest"
+	ContactConflictSource      = "source"
+	ContactConflictDestination = "destination"
+	ContactConflictSkip        = "skip"
+)
+
+// ContactSyncCheckpoint is the mapping table of `migrate contacts`: which
+// destination contact each source contact is paired with, and what both
+// looked like after the last sync. It keeps repeated runs idempotent.
+type ContactSyncCheckpoint struct {
+	From      string                  `json:"from"`
+	To        string                  `json:"to"`
+	Contacts  map[string]*ContactPair `json:"contacts"` // Keyed by source contact ID
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+// ContactPair links a source contact to its destination counterpart.
+// The fingerprints cover the synced fields, so a side has changed when its
+// current fingerprint differs from the recorded one.
+type ContactPair struct {
+	DestinationID   string `json:"destination_id"`
+	SourceHash      string `json:"source_hash,omitempty"`
+	DestinationHash string `json:"destination_hash,omitempty"`
+	Detached        bool   `json:"detached,omitempty"` // One side was deleted; no longer synced
+}
+
+// NewContactSyncCheckpoint returns an empty mapping table for from → to.
+func NewContactSyncCheckpoint(from, to string) *ContactSyncCheckpoint {
+	return &ContactSyncCheckpoint{From: from, To: to, Contacts: map[string]*ContactPair{}}
+}
+
+// ContactMapping links a source contact to its destination counterpart and
+// records what a run did with the pair.
+type ContactMapping struct {
+	SourceID      string `json:"source_id,omitempty"`      // Empty in dry runs of contacts new in the destination
+	DestinationID string `json:"destination_id,omitempty"` // Empty in dry runs of contacts new in the source
+	Name          string `json:"name"`
+	Action        string `json:"action"`              // created, updated, unchanged, conflict
+	Direction     string `json:"direction,omitempty"` // to_destination, to_source
+	Linked        bool   `json:"linked,omitempty"`    // Paired by email address in this run
+}
+
+// ContactMigrationFailure is a contact that could not be synced.
+type ContactMigrationFailure struct {
+	ContactID string `json:"contact_id"`
+	Name      string `json:"name,omitempty"`
+	Error     string `json:"error"`
+}
+
+// ContactMigrationReport is the result of `migrate contacts`.
+type ContactMigrationReport struct {
+	From                string                    `json:"from"`
+	To                  string                    `json:"to"`
+	Mode                string                    `json:"mode"`
+	Conflict            string                    `json:"conflict"`
+	DryRun              bool                      `json:"dry_run,omitempty"`
+	SourceContacts      int                       `json:"source_contacts"`
+	DestinationContacts int                       `json:"destination_contacts"`
+	Created             int                       `json:"created"`
+	Updated             int                       `json:"updated"`
+	Linked              int                       `json:"linked"`
+	Unchanged           int                       `json:"unchanged"`
+	Conflicts           int                       `json:"conflicts"` // Left alone by --conflict skip
+	Detached            int                       `json:"detached"`  // Deleted on one side since the last run
+	Failed              int                       `json:"failed"`
+	Mappings            []ContactMapping          `json:"mappings"`
+	Failures            []ContactMigrationFailure `json:"failures,omitempty"`
+	Interrupted         bool                      `json:"interrupted,omitempty"`
+}
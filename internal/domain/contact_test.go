@@ -353,3 +353,19 @@ func TestContactAddress_Creation(t *testing.T) {
 		t.Errorf("ContactAddress.Country = %q, want %q", addr.Country, "USA")
 	}
 }
+
+func TestContactPictureSizeLimit(t *testing.T) {
+	tests := []struct {
+		provider Provider
+		want     int64
+	}{
+		{ProviderMicrosoft, 4 << 20},
+		{ProviderGoogle, DefaultContactPictureSizeLimit},
+		{"", DefaultContactPictureSizeLimit},
+	}
+	for _, tt := range tests {
+		if got := ContactPictureSizeLimit(tt.provider); got != tt.want {
+			t.Errorf("ContactPictureSizeLimit(%q) = %d, want %d", tt.provider, got, tt.want)
+		}
+	}
+}
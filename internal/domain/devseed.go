@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// SeedKind is the type of object `dev seed` created.
+type SeedKind string
+
+// Seeded object kinds.
+const (
+	SeedThread  SeedKind = "thread"
+	SeedMessage SeedKind = "message"
+	SeedEvent   SeedKind = "event"
+	SeedContact SeedKind = "contact"
+)
+
+// SeededItem records an object `dev seed` created, so `dev clean` removes
+// only seeded data. Messages are recorded by thread, which covers both the
+// sent and the received copy of a message sent to the grant's own address.
+type SeededItem struct {
+	GrantID    string    `json:"grant_id"`
+	Kind       SeedKind  `json:"kind"`
+	ID         string    `json:"id"`
+	CalendarID string    `json:"calendar_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
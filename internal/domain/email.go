@@ -152,9 +152,11 @@ type MessageQueryParams struct {
 	ReceivedBefore int64    `json:"received_before,omitempty"`
 	ReceivedAfter  int64    `json:"received_after,omitempty"`
 	HasAttachment  *bool    `json:"has_attachment,omitempty"`
-	SearchQuery    string   `json:"q,omitempty"`             // Full-text search
-	Fields         string   `json:"fields,omitempty"`        // e.g., "include_headers"
-	MetadataPair   string   `json:"metadata_pair,omitempty"` // Metadata filtering (format: "key:value", only key1-key5 supported)
+	SearchQuery    string   `json:"q,omitempty"`                   // Full-text search
+	NativeQuery    string   `json:"search_query_native,omitempty"` // Provider search syntax (Gmail operators, Microsoft KQL, IMAP)
+	Fields         string   `json:"fields,omitempty"`              // e.g., "include_headers"
+	MetadataPair   string   `json:"metadata_pair,omitempty"`       // Metadata filtering (format: "key:value", only key1-key5 supported)
+	Select         string   `json:"select,omitempty"`              // Comma-separated fields to return, e.g. "id"
 }
 
 // ThreadQueryParams for filtering threads.
@@ -0,0 +1,46 @@
+package domain
+
+// DefaultMessageSizeLimit applies to providers without a documented limit,
+// and matches the Nylas send limit.
+const DefaultMessageSizeLimit int64 = 25 << 20
+
+// messageSizeLimits are the providers' documented maximum message sizes.
+var messageSizeLimits = map[Provider]int64{
+	ProviderGoogle:    25 << 20, // Gmail
+	ProviderMicrosoft: 35 << 20, // Exchange Online default
+	ProviderEWS:       35 << 20,
+	ProviderYahoo:     25 << 20,
+	ProviderICloud:    20 << 20,
+}
+
+// Per-message and per-part MIME overhead (headers, boundaries) used when
+// estimating the encoded size.
+const (
+	mimeMessageOverhead int64 = 4 << 10
+	mimePartOverhead    int64 = 512
+)
+
+// MessageSizeLimit returns the maximum encoded message size for a provider.
+func MessageSizeLimit(p Provider) int64 {
+	if limit, ok := messageSizeLimits[p]; ok {
+		return limit
+	}
+	return DefaultMessageSizeLimit
+}
+
+// EstimateMessageSize estimates the encoded MIME size of a message: the body
+// plus base64-encoded attachments (4/3 of their size, wrapped at 76
+// characters), plus header overhead. Providers enforce limits on this size,
+// not the raw file sizes.
+func EstimateMessageSize(body string, attachments []Attachment) int64 {
+	size := mimeMessageOverhead + int64(len(body))
+	for _, a := range attachments {
+		n := a.Size
+		if n == 0 {
+			n = int64(len(a.Content))
+		}
+		encoded := (n + 2) / 3 * 4
+		size += encoded + encoded/76*2 + mimePartOverhead
+	}
+	return size
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageSizeLimit(t *testing.T) {
+	assert.Equal(t, int64(25<<20), MessageSizeLimit(ProviderGoogle))
+	assert.Equal(t, int64(35<<20), MessageSizeLimit(ProviderMicrosoft))
+	assert.Equal(t, int64(20<<20), MessageSizeLimit(ProviderICloud))
+	assert.Equal(t, DefaultMessageSizeLimit, MessageSizeLimit(ProviderIMAP))
+}
+
+func TestEstimateMessageSize(t *testing.T) {
+	body := "hello"
+	base := EstimateMessageSize(body, nil)
+	assert.Equal(t, mimeMessageOverhead+5, base)
+
+	// 3 MB of raw data is 4 MB base64 plus line breaks.
+	att := Attachment{Size: 3 << 20}
+	got := EstimateMessageSize(body, []Attachment{att})
+	assert.Greater(t, got, base+4<<20)
+	assert.Less(t, got, base+4<<20+200<<10)
+
+	// Size falls back to the content length.
+	withContent := Attachment{Content: make([]byte, 300)}
+	assert.Equal(t, base+400+10+mimePartOverhead, EstimateMessageSize(body, []Attachment{withContent}))
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// DefaultEnvironment names the top-level settings in config.yaml, used when
+// no environment profile is selected.
+const DefaultEnvironment = "default"
+
+// EnvironmentProfile is a named set of connection settings, such as a
+// sandbox and a production application. Selecting a profile replaces the
+// top-level region, base URL, and default grant.
+type EnvironmentProfile struct {
+	Region       string `yaml:"region,omitempty" json:"region,omitempty"`
+	BaseURL      string `yaml:"base_url,omitempty" json:"base_url,omitempty"`
+	DefaultGrant string `yaml:"default_grant,omitempty" json:"default_grant,omitempty"`
+}
+
+var environmentNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// ValidateEnvironmentName checks that name can be used for a new profile.
+func ValidateEnvironmentName(name string) error {
+	if name == DefaultEnvironment {
+		return fmt.Errorf("%w: %q is reserved for the top-level settings", ErrInvalidInput, name)
+	}
+	if !environmentNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: environment names use lowercase letters, digits, '-' and '_' (max 32)", ErrInvalidInput)
+	}
+	return nil
+}
+
+// EnvironmentNames returns the profile names in sorted order.
+func (c *Config) EnvironmentNames() []string {
+	names := make([]string, 0, len(c.Environments))
+	for name := range c.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseEnvironment overlays the named profile on the config in memory. The
+// profile's base URL and default grant replace the top-level ones even when
+// empty, so settings from another environment never leak through. Selecting
+// DefaultEnvironment or "" leaves the config unchanged.
+func (c *Config) UseEnvironment(name string) error {
+	if name == "" || name == DefaultEnvironment {
+		return nil
+	}
+	p, ok := c.Environments[name]
+	if !ok || p == nil {
+		return fmt.Errorf("%w: %s", ErrEnvironmentNotFound, name)
+	}
+
+	if p.Region != "" {
+		c.Region = p.Region
+	}
+	api := APIConfig{}
+	if c.API != nil {
+		api = *c.API
+	}
+	api.BaseURL = p.BaseURL
+	c.API = &api
+	c.DefaultGrant = p.DefaultGrant
+	return nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEnvironmentName(t *testing.T) {
+	for _, name := range []string{"prod", "staging-eu", "sandbox_2"} {
+		assert.NoError(t, ValidateEnvironmentName(name), name)
+	}
+	for _, name := range []string{"", "default", "Prod", "-x", "a b", "env.name"} {
+		assert.ErrorIs(t, ValidateEnvironmentName(name), ErrInvalidInput, name)
+	}
+}
+
+func TestConfig_UseEnvironment(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Region:       "us",
+			DefaultGrant: "grant-top",
+			API:          &APIConfig{BaseURL: "https://proxy.example.com", Timeout: "30s"},
+			Environments: map[string]*EnvironmentProfile{
+				"eu":    {Region: "eu", DefaultGrant: "grant-eu"},
+				"local": {BaseURL: "http://localhost:8080"},
+			},
+		}
+	}
+
+	cfg := newConfig()
+	require.NoError(t, cfg.UseEnvironment("eu"))
+	assert.Equal(t, "eu", cfg.Region)
+	assert.Equal(t, BaseURLEU, cfg.ResolveBaseURL())
+	assert.Equal(t, "grant-eu", cfg.DefaultGrant)
+	assert.Equal(t, "30s", cfg.API.Timeout)
+
+	cfg = newConfig()
+	require.NoError(t, cfg.UseEnvironment("local"))
+	assert.Equal(t, "http://localhost:8080", cfg.ResolveBaseURL())
+	assert.Empty(t, cfg.DefaultGrant)
+
+	cfg = newConfig()
+	require.NoError(t, cfg.UseEnvironment(DefaultEnvironment))
+	assert.Equal(t, "grant-top", cfg.DefaultGrant)
+
+	assert.ErrorIs(t, newConfig().UseEnvironment("missing"), ErrEnvironmentNotFound)
+	assert.Equal(t, []string{"eu", "local"}, newConfig().EnvironmentNames())
+}
@@ -22,6 +22,10 @@ var (
 	ErrNetworkError    = errors.New("network error")
 	ErrInvalidInput    = errors.New("invalid input")
 
+	// ErrOAuthClientRejected is returned when a provider rejects an OAuth
+	// client ID, secret or redirect URI during a test token exchange.
+	ErrOAuthClientRejected = errors.New("OAuth client rejected by provider")
+
 	// Secret store errors
 	ErrSecretNotFound    = errors.New("secret not found")
 	ErrSecretStoreFailed = errors.New("secret store operation failed")
@@ -47,8 +51,10 @@ var (
 	ErrThreadNotFound        = errors.New("thread not found")
 	ErrAttachmentNotFound    = errors.New("attachment not found")
 	ErrWebhookNotFound       = errors.New("webhook not found")
+	ErrWebhookEventNotFound  = errors.New("webhook event not found")
 	ErrPubSubChannelNotFound = errors.New("pub/sub channel not found")
 	ErrNotetakerNotFound     = errors.New("notetaker not found")
+	ErrTranscriptNotReady    = errors.New("transcript not ready")
 	ErrTemplateNotFound      = errors.New("template not found")
 	ErrWorkflowNotFound      = errors.New("workflow not found")
 	ErrApplicationNotFound   = errors.New("application not found")
@@ -59,6 +65,8 @@ var (
 	ErrConnectorNotFound     = errors.New("connector not found")
 	ErrCredentialNotFound    = errors.New("credential not found")
 	ErrWorkspaceNotFound     = errors.New("workspace not found")
+	ErrAPIKeyNotFound        = errors.New("API key not found")
+	ErrEnvironmentNotFound   = errors.New("environment not found")
 
 	// Dashboard auth errors
 	ErrDashboardNotLoggedIn    = errors.New("not logged in to Nylas Dashboard")
@@ -72,6 +80,7 @@ var (
 	ErrBookingNotFound       = errors.New("booking not found")
 	ErrSessionNotFound       = errors.New("session not found")
 	ErrConfigurationNotFound = errors.New("configuration not found")
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
 	// ErrBookingReadBackFailed is a typed partial success: the reschedule PATCH
 	// was applied, but reading the booking back failed, so the server-side
 	// record could not be verified. Callers should report the change as applied
@@ -86,6 +95,9 @@ type APIError struct {
 	Type       string
 	Message    string
 	RequestID  string
+	// ProviderError is the raw provider_error payload Nylas passes through
+	// from Google, Microsoft or IMAP, when the response included one.
+	ProviderError string
 }
 
 func (e *APIError) Error() string {
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventFilter is a parsed event query such as
+//
+//	title~'Q3 review' and location='Room 4'
+//
+// Clauses are joined by "and" and compare a field with a value:
+// "~" contains, "=" equals, and "!~" / "!=" negate them. Comparisons ignore
+// case. Values may be single- or double-quoted.
+type EventFilter struct {
+	Clauses []EventFilterClause
+}
+
+// EventFilterClause is one comparison in an EventFilter.
+type EventFilterClause struct {
+	Field  string
+	Op     string
+	Value  string
+	Negate bool
+}
+
+// eventFilterFields are the fields a query can compare.
+var eventFilterFields = map[string]func(*Event) []string{
+	"title":       func(e *Event) []string { return []string{e.Title} },
+	"description": func(e *Event) []string { return []string{e.Description} },
+	"location":    func(e *Event) []string { return []string{e.Location} },
+	"status":      func(e *Event) []string { return []string{e.Status} },
+	"organizer": func(e *Event) []string {
+		if e.Organizer == nil {
+			return nil
+		}
+		return []string{e.Organizer.Email, e.Organizer.Name}
+	},
+	"participant": func(e *Event) []string {
+		values := make([]string, 0, 2*len(e.Participants))
+		for _, p := range e.Participants {
+			values = append(values, p.Email, p.Name)
+		}
+		return values
+	},
+}
+
+// ParseEventFilter parses an event query.
+func ParseEventFilter(query string) (*EventFilter, error) {
+	p := &filterParser{s: query}
+	f := &EventFilter{}
+	for {
+		p.skipSpace()
+		if p.done() {
+			break
+		}
+		if len(f.Clauses) > 0 {
+			if !p.keyword("and") {
+				return nil, fmt.Errorf("%w: expected 'and' at position %d of query", ErrInvalidInput, p.i+1)
+			}
+			p.skipSpace()
+		}
+		clause, err := p.clause()
+		if err != nil {
+			return nil, err
+		}
+		f.Clauses = append(f.Clauses, clause)
+	}
+	if len(f.Clauses) == 0 {
+		return nil, fmt.Errorf("%w: query is empty", ErrInvalidInput)
+	}
+	return f, nil
+}
+
+// Match reports whether the event satisfies every clause.
+func (f *EventFilter) Match(e *Event) bool {
+	for _, c := range f.Clauses {
+		if c.match(e) == c.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (c EventFilterClause) match(e *Event) bool {
+	want := strings.ToLower(c.Value)
+	for _, v := range eventFilterFields[c.Field](e) {
+		v = strings.ToLower(v)
+		if c.Op == "~" && strings.Contains(v, want) || c.Op == "=" && v == want {
+			return true
+		}
+	}
+	return false
+}
+
+type filterParser struct {
+	s string
+	i int
+}
+
+func (p *filterParser) done() bool { return p.i >= len(p.s) }
+
+func (p *filterParser) skipSpace() {
+	for !p.done() && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// keyword consumes word followed by a space, ignoring case.
+func (p *filterParser) keyword(word string) bool {
+	end := p.i + len(word)
+	if end >= len(p.s) || !strings.EqualFold(p.s[p.i:end], word) || p.s[end] != ' ' {
+		return false
+	}
+	p.i = end
+	return true
+}
+
+func (p *filterParser) clause() (EventFilterClause, error) {
+	start := p.i
+	for !p.done() && isFieldChar(p.s[p.i]) {
+		p.i++
+	}
+	field := strings.ToLower(p.s[start:p.i])
+	if _, ok := eventFilterFields[field]; !ok {
+		return EventFilterClause{}, fmt.Errorf("%w: unknown field %q in query (use title, description, location, status, organizer, or participant)", ErrInvalidInput, p.s[start:p.i])
+	}
+
+	p.skipSpace()
+	c := EventFilterClause{Field: field}
+	if strings.HasPrefix(p.s[p.i:], "!") {
+		c.Negate = true
+		p.i++
+	}
+	if p.done() || (p.s[p.i] != '~' && p.s[p.i] != '=') {
+		return EventFilterClause{}, fmt.Errorf("%w: expected ~, =, !~, or != after %q", ErrInvalidInput, field)
+	}
+	c.Op = string(p.s[p.i])
+	p.i++
+	p.skipSpace()
+
+	value, err := p.value()
+	if err != nil {
+		return EventFilterClause{}, err
+	}
+	c.Value = value
+	return c, nil
+}
+
+func (p *filterParser) value() (string, error) {
+	if p.done() {
+		return "", fmt.Errorf("%w: missing value at end of query", ErrInvalidInput)
+	}
+	if q := p.s[p.i]; q == '\'' || q == '"' {
+		end := strings.IndexByte(p.s[p.i+1:], q)
+		if end < 0 {
+			return "", fmt.Errorf("%w: unterminated quote in query", ErrInvalidInput)
+		}
+		v := p.s[p.i+1 : p.i+1+end]
+		p.i += end + 2
+		return v, nil
+	}
+	start := p.i
+	for !p.done() && p.s[p.i] != ' ' && p.s[p.i] != '\t' {
+		p.i++
+	}
+	return p.s[start:p.i], nil
+}
+
+func isFieldChar(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b == '_'
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventFilter(t *testing.T) {
+	f, err := ParseEventFilter(`title~'Q3 review' AND location = "Room 4" and status!=cancelled`)
+	require.NoError(t, err)
+	assert.Equal(t, []EventFilterClause{
+		{Field: "title", Op: "~", Value: "Q3 review"},
+		{Field: "location", Op: "=", Value: "Room 4"},
+		{Field: "status", Op: "=", Value: "cancelled", Negate: true},
+	}, f.Clauses)
+
+	for _, bad := range []string{
+		"",
+		"title",
+		"when~today",
+		"title~'unterminated",
+		"title~a location~b",
+		"title>3",
+	} {
+		_, err := ParseEventFilter(bad)
+		assert.ErrorIs(t, err, ErrInvalidInput, bad)
+	}
+}
+
+func TestEventFilter_Match(t *testing.T) {
+	event := &Event{
+		Title:     "Q3 Review: Sales",
+		Location:  "Room 4",
+		Status:    "confirmed",
+		Organizer: &Participant{Person: Person{Email: "boss@example.com"}},
+		Participants: []Participant{
+			{Person: Person{Name: "Ana", Email: "ana@example.com"}},
+		},
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"title~'q3 review'", true},
+		{"title~'Q4'", false},
+		{"title='Q3 Review: Sales'", true},
+		{"title='Q3 Review'", false},
+		{"title!~standup", true},
+		{"location='room 4' and participant~ana", true},
+		{"organizer=boss@example.com", true},
+		{"participant~bob", false},
+		{"status!=confirmed", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			f, err := ParseEventFilter(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f.Match(event))
+		})
+	}
+
+	f, err := ParseEventFilter("organizer~boss")
+	require.NoError(t, err)
+	assert.False(t, f.Match(&Event{}))
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// EWSScopes are the scopes an Exchange on-premises (EWS) connector grants by
+// default.
+var EWSScopes = []string{"ews.messages", "ews.calendars", "ews.contacts"}
+
+// EWSDefaultPath is where Exchange serves EWS.
+const EWSDefaultPath = "/EWS/Exchange.asmx"
+
+// EWSCheckOptions configures a connectivity pre-check against an Exchange
+// Web Services endpoint.
+type EWSCheckOptions struct {
+	Endpoint           string `json:"endpoint"`
+	Username           string `json:"username,omitempty"`
+	Password           string `json:"-"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+// EWSCheckResult is the outcome of an EWS pre-check. Credentials can only be
+// tested when the server offers Basic authentication; NTLM and Negotiate are
+// reported but not attempted.
+type EWSCheckResult struct {
+	Endpoint      string   `json:"endpoint"`
+	StatusCode    int      `json:"status_code"`
+	AuthSchemes   []string `json:"auth_schemes,omitempty"`
+	AuthTested    bool     `json:"auth_tested"`
+	Authenticated bool     `json:"authenticated"`
+	ServerVersion string   `json:"server_version,omitempty"`
+}
+
+// EWSEndpoint turns a host name or URL into the EWS endpoint URL. A bare host
+// gets https:// and the default /EWS/Exchange.asmx path.
+func EWSEndpoint(host string) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return "", fmt.Errorf("EWS host is required")
+	}
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid EWS host %q", host)
+	}
+	if u.Scheme != "https" {
+		return "", fmt.Errorf("EWS endpoint must use https: %s", host)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = EWSDefaultPath
+	}
+	return u.String(), nil
+}
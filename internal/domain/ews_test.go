@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEWSEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"mail.example.com":                         "https://mail.example.com/EWS/Exchange.asmx",
+		" mail.example.com:8443 ":                  "https://mail.example.com:8443/EWS/Exchange.asmx",
+		"https://mail.example.com/":                "https://mail.example.com/EWS/Exchange.asmx",
+		"https://mail.example.com/custom/ews.asmx": "https://mail.example.com/custom/ews.asmx",
+	}
+	for in, want := range tests {
+		got, err := EWSEndpoint(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "http://mail.example.com", "https://"} {
+		_, err := EWSEndpoint(in)
+		assert.Error(t, err, in)
+	}
+}
@@ -53,6 +53,15 @@ func (g *Grant) IsValid() bool {
 	return g.GrantStatus == "valid"
 }
 
+// AuthURLOptions customizes a hosted authentication URL.
+type AuthURLOptions struct {
+	// Scopes are the provider permissions to request, replacing the
+	// connector's default scopes. Empty keeps the defaults.
+	Scopes []string
+	// LoginHint is the email address of the account to sign in with.
+	LoginHint string
+}
+
 // GrantInfo is a lightweight representation of a grant for storage.
 type GrantInfo struct {
 	ID       string   `yaml:"id" json:"id"`
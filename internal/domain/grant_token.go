@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GrantToken is a locally issued, time-limited credential that lets another
+// local tool act on one grant within a set of scopes. The Nylas API has no
+// per-grant scoped tokens, so these are verified by the CLI's own servers
+// (e.g. `nylas rpc serve`), never sent to the API.
+type GrantToken struct {
+	ID        string    `json:"jti"`
+	GrantID   string    `json:"grant_id"`
+	Scopes    []Scope   `json:"scopes"`
+	IssuedAt  time.Time `json:"iat"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// Grant token errors.
+var (
+	ErrGrantTokenInvalid = errors.New("invalid grant token")
+	ErrGrantTokenExpired = errors.New("grant token expired")
+)
+
+// Allows reports whether the token carries scope.
+func (t *GrantToken) Allows(scope Scope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+// grantTokenScopeAliases maps resource-style scope names to the scopes they
+// stand for.
+var grantTokenScopeAliases = map[string]Scope{
+	"messages.read":   ScopeEmailRead,
+	"messages.write":  ScopeEmailModify,
+	"messages.modify": ScopeEmailModify,
+	"messages.send":   ScopeEmailSend,
+	"email.write":     ScopeEmailModify,
+	"calendar.write":  ScopeCalendarWrite,
+	"events.read":     ScopeCalendarRead,
+	"events.write":    ScopeCalendarWrite,
+	"contacts.write":  ScopeContactsWrite,
+}
+
+// ParseGrantTokenScopes validates scope names, resolving aliases such as
+// "messages.read", and returns them deduplicated in order.
+func ParseGrantTokenScopes(names []string) ([]Scope, error) {
+	var scopes []Scope
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		scope, ok := grantTokenScopeAliases[name]
+		if !ok {
+			scope = Scope(name)
+			if !slices.Contains(GrantTokenScopes(), scope) {
+				return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidInput, name)
+			}
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidInput)
+	}
+	return scopes, nil
+}
+
+// GrantTokenScopes lists the scopes a grant token can carry.
+func GrantTokenScopes() []Scope {
+	return []Scope{
+		ScopeEmailRead, ScopeEmailModify, ScopeEmailSend,
+		ScopeCalendarRead, ScopeCalendarWrite,
+		ScopeContactsRead, ScopeContactsWrite,
+	}
+}
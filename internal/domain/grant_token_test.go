@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGrantTokenScopes(t *testing.T) {
+	scopes, err := ParseGrantTokenScopes([]string{"messages.read", " Email.Read ", "calendar.write", "contacts.read", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []Scope{ScopeEmailRead, ScopeCalendarWrite, ScopeContactsRead}, scopes)
+
+	for _, names := range [][]string{nil, {""}, {"messages.delete"}, {"application"}} {
+		_, err := ParseGrantTokenScopes(names)
+		assert.ErrorIs(t, err, ErrInvalidInput, names)
+	}
+}
+
+func TestGrantToken_Allows(t *testing.T) {
+	token := &GrantToken{Scopes: []Scope{ScopeEmailRead}}
+	assert.True(t, token.Allows(ScopeEmailRead))
+	assert.False(t, token.Allows(ScopeEmailSend))
+}
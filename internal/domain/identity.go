@@ -0,0 +1,47 @@
+package domain
+
+import "strings"
+
+// Where a send-as identity was found.
+const (
+	IdentitySourceAccount = "account" // the grant's own address
+	IdentitySourceSent    = "sent"    // the From of a message in the Sent folder
+)
+
+// SendIdentity is an address a grant can send as: its own address or a
+// provider send-as alias.
+type SendIdentity struct {
+	Email   string `yaml:"email" json:"email"`
+	Name    string `yaml:"name,omitempty" json:"name,omitempty"`
+	Source  string `yaml:"source" json:"source"`
+	Default bool   `yaml:"-" json:"default"`
+}
+
+// String formats the identity as "Name <email>", or just the address when
+// it has no name.
+func (i SendIdentity) String() string {
+	if i.Name == "" {
+		return i.Email
+	}
+	return i.Name + " <" + i.Email + ">"
+}
+
+// GrantIdentities are the send-as identities found for a grant and the one
+// `email send` uses without --from.
+type GrantIdentities struct {
+	Default string         `yaml:"default,omitempty" json:"default,omitempty"`
+	Known   []SendIdentity `yaml:"known,omitempty" json:"known"`
+}
+
+// Find returns the known identity for email, ignoring case, or nil.
+func (g *GrantIdentities) Find(email string) *SendIdentity {
+	if g == nil {
+		return nil
+	}
+	for i := range g.Known {
+		if strings.EqualFold(g.Known[i].Email, email) {
+			return &g.Known[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantIdentities_Find(t *testing.T) {
+	ids := &GrantIdentities{Known: []SendIdentity{
+		{Email: "me@example.com", Source: IdentitySourceAccount},
+		{Email: "sales@example.com", Name: "Sales", Source: IdentitySourceSent},
+	}}
+
+	found := ids.Find("SALES@example.com")
+	if assert.NotNil(t, found) {
+		assert.Equal(t, "Sales <sales@example.com>", found.String())
+	}
+	assert.Nil(t, ids.Find("other@example.com"))
+
+	var none *GrantIdentities
+	assert.Nil(t, none.Find("me@example.com"))
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Invitation is a hosted-auth invitation emailed to a user during bulk
+// onboarding. It is completed once a grant for the user's email appears.
+type Invitation struct {
+	Email       string    `json:"email"`
+	Name        string    `json:"name,omitempty"`
+	Provider    string    `json:"provider"`
+	State       string    `json:"state"`
+	MessageID   string    `json:"message_id,omitempty"`
+	SentAt      time.Time `json:"sent_at"`
+	GrantID     string    `json:"grant_id,omitempty"`
+	CompletedAt time.Time `json:"completed_at,omitzero"`
+}
+
+// Completed reports whether the invited user has connected their account.
+func (i Invitation) Completed() bool {
+	return i.GrantID != ""
+}
+
+// MatchGrants marks invitations completed when a valid grant with the same
+// email exists and returns how many were newly completed.
+func MatchGrants(invitations []Invitation, grants []Grant) int {
+	byEmail := make(map[string]Grant, len(grants))
+	for _, g := range grants {
+		if g.IsValid() && g.Email != "" {
+			byEmail[strings.ToLower(g.Email)] = g
+		}
+	}
+
+	matched := 0
+	for i := range invitations {
+		inv := &invitations[i]
+		if inv.Completed() {
+			continue
+		}
+		g, ok := byEmail[strings.ToLower(inv.Email)]
+		if !ok {
+			continue
+		}
+		inv.GrantID = g.ID
+		inv.CompletedAt = g.CreatedAt.Time
+		if inv.CompletedAt.IsZero() {
+			inv.CompletedAt = time.Now()
+		}
+		matched++
+	}
+	return matched
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGrants(t *testing.T) {
+	created := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	invs := []Invitation{
+		{Email: "Ana@Acme.com"},
+		{Email: "bob@acme.com"},
+		{Email: "cy@acme.com", GrantID: "g-old"},
+		{Email: "dee@acme.com"},
+	}
+	grants := []Grant{
+		{ID: "g-ana", Email: "ana@acme.com", GrantStatus: "valid", CreatedAt: UnixTime{created}},
+		{ID: "g-bob", Email: "bob@acme.com", GrantStatus: "invalid"},
+		{ID: "g-cy", Email: "cy@acme.com", GrantStatus: "valid"},
+	}
+
+	assert.Equal(t, 1, MatchGrants(invs, grants))
+	assert.Equal(t, "g-ana", invs[0].GrantID)
+	assert.Equal(t, created, invs[0].CompletedAt)
+	assert.False(t, invs[1].Completed(), "invalid grants don't count")
+	assert.Equal(t, "g-old", invs[2].GrantID, "completed invitations are kept")
+	assert.False(t, invs[3].Completed())
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// InvokerPolicy restricts which commands may run depending on who invoked
+// the CLI. It is read from policy.yaml in the config directory.
+type InvokerPolicy struct {
+	Rules []InvokerPolicyRule `yaml:"rules"`
+}
+
+// InvokerPolicyRule denies commands for a set of invoker sources.
+type InvokerPolicyRule struct {
+	// Sources are invoker sources as recorded in the audit log, such as
+	// "claude-code", "github-copilot" or "script". "*" matches any source.
+	Sources []string `yaml:"sources"`
+
+	// Deny lists command patterns. A pattern matches a command path such as
+	// "email send" or any of its subcommands, so "email" covers the whole
+	// email tree. "*" matches any text, so "* delete" covers every delete.
+	Deny []string `yaml:"deny"`
+
+	// Reason is shown when the rule blocks a command.
+	Reason string `yaml:"reason,omitempty"`
+}
+
+// InvokerPolicyMatch is the rule and pattern that deny a command.
+type InvokerPolicyMatch struct {
+	Rule    InvokerPolicyRule
+	Pattern string
+}
+
+// Denied returns the first rule that denies command for source, or nil when
+// the command may run.
+func (p *InvokerPolicy) Denied(source, command string) *InvokerPolicyMatch {
+	if p == nil {
+		return nil
+	}
+	for _, rule := range p.Rules {
+		if !rule.appliesTo(source) {
+			continue
+		}
+		for _, pattern := range rule.Deny {
+			if commandMatches(pattern, command) {
+				return &InvokerPolicyMatch{Rule: rule, Pattern: pattern}
+			}
+		}
+	}
+	return nil
+}
+
+// Validate reports a rule without sources or commands, or a malformed
+// pattern.
+func (p *InvokerPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for i, rule := range p.Rules {
+		if len(rule.Sources) == 0 {
+			return fmt.Errorf("rule %d: sources is empty", i+1)
+		}
+		if len(rule.Deny) == 0 {
+			return fmt.Errorf("rule %d: deny is empty", i+1)
+		}
+		for _, pattern := range append(append([]string{}, rule.Sources...), rule.Deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rule %d: invalid pattern %q", i+1, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+func (r InvokerPolicyRule) appliesTo(source string) bool {
+	for _, s := range r.Sources {
+		if ok, _ := path.Match(s, source); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// commandMatches reports whether pattern matches command or one of the
+// commands above it.
+func commandMatches(pattern, command string) bool {
+	pattern = strings.Join(strings.Fields(pattern), " ")
+	words := strings.Fields(command)
+	for n := len(words); n > 0; n-- {
+		if ok, _ := path.Match(pattern, strings.Join(words[:n], " ")); ok {
+			return true
+		}
+	}
+	return false
+}
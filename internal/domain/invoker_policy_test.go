@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvokerPolicy_Denied(t *testing.T) {
+	var unset *InvokerPolicy
+	assert.Nil(t, unset.Denied("claude-code", "email send"))
+
+	policy := &InvokerPolicy{Rules: []InvokerPolicyRule{
+		{Sources: []string{"claude-code", "github-copilot"}, Deny: []string{"email send", "* delete"}, Reason: "agents draft only"},
+		{Sources: []string{"*"}, Deny: []string{"admin"}},
+	}}
+
+	tests := []struct {
+		source, command string
+		wantPattern     string
+	}{
+		{"claude-code", "email send", "email send"},
+		{"github-copilot", "email delete", "* delete"},
+		{"claude-code", "calendar events delete", "* delete"},
+		{"claude-code", "email list", ""},
+		{"claude-code", "email drafts send", ""},
+		{"terminal", "email send", ""},
+		{"terminal", "admin grants list", "admin"}, // subcommands are covered
+		{"script", "administer", ""},
+	}
+	for _, tt := range tests {
+		match := policy.Denied(tt.source, tt.command)
+		if tt.wantPattern == "" {
+			assert.Nil(t, match, "%s: %s", tt.source, tt.command)
+			continue
+		}
+		require.NotNil(t, match, "%s: %s", tt.source, tt.command)
+		assert.Equal(t, tt.wantPattern, match.Pattern)
+	}
+
+	assert.Equal(t, "agents draft only", policy.Denied("claude-code", "email send").Rule.Reason)
+}
+
+func TestInvokerPolicy_Validate(t *testing.T) {
+	assert.NoError(t, (*InvokerPolicy)(nil).Validate())
+	assert.NoError(t, (&InvokerPolicy{Rules: []InvokerPolicyRule{{Sources: []string{"*"}, Deny: []string{"* delete"}}}}).Validate())
+
+	assert.ErrorContains(t, (&InvokerPolicy{Rules: []InvokerPolicyRule{{Deny: []string{"email send"}}}}).Validate(), "sources is empty")
+	assert.ErrorContains(t, (&InvokerPolicy{Rules: []InvokerPolicyRule{{Sources: []string{"script"}}}}).Validate(), "deny is empty")
+	assert.ErrorContains(t, (&InvokerPolicy{Rules: []InvokerPolicyRule{{Sources: []string{"script"}, Deny: []string{"email ["}}}}).Validate(), "invalid pattern")
+}
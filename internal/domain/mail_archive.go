@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ArchiveFolder is the folder reported for messages found in a local
+// mail archive rather than on the server.
+const ArchiveFolder = "ARCHIVE"
+
+// ArchiveMessageObject is the Object value of archived messages.
+const ArchiveMessageObject = "archived_message"
+
+// ArchivePathMetadataKey is the Metadata key holding the archive file an
+// archived message was read from.
+const ArchivePathMetadataKey = "archive_path"
+
+// ArchiveQuery filters messages in local mail archives. String fields are
+// case-insensitive substring matches; zero values match everything.
+type ArchiveQuery struct {
+	Text          string // Subject, participants or body
+	From          string
+	To            string // To or Cc
+	Subject       string
+	After         time.Time
+	Before        time.Time
+	HasAttachment *bool
+	Limit         int // 0 means no limit
+}
+
+// IsArchivedMessage reports whether msg came from a local archive.
+func IsArchivedMessage(msg *Message) bool {
+	return msg != nil && msg.Object == ArchiveMessageObject
+}
+
+// ArchivePath returns the archive file msg was read from, or "".
+func ArchivePath(msg *Message) string {
+	if !IsArchivedMessage(msg) {
+		return ""
+	}
+	return msg.Metadata[ArchivePathMetadataKey]
+}
+
+// normalizeArchiveTerm lowercases and trims a query term; "*" means any.
+func normalizeArchiveTerm(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "*" {
+		return ""
+	}
+	return s
+}
+
+// Normalized returns q with its terms lowercased and "*" cleared.
+func (q ArchiveQuery) Normalized() ArchiveQuery {
+	q.Text = normalizeArchiveTerm(q.Text)
+	q.From = normalizeArchiveTerm(q.From)
+	q.To = normalizeArchiveTerm(q.To)
+	q.Subject = normalizeArchiveTerm(q.Subject)
+	return q
+}
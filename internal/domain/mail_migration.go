@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// IMAPMailbox is a mailbox returned by IMAP LIST.
+type IMAPMailbox struct {
+	Name       string   `json:"name"` // Decoded from modified UTF-7
+	Delimiter  string   `json:"delimiter,omitempty"`
+	Attributes []string `json:"attributes,omitempty"` // e.g. \Sent, \Noselect
+}
+
+// HasAttribute reports whether the mailbox has attr (case-insensitive).
+func (m *IMAPMailbox) HasAttribute(attr string) bool {
+	return slices.ContainsFunc(m.Attributes, func(a string) bool { return strings.EqualFold(a, attr) })
+}
+
+// AppendMessage is a message to store with IMAP APPEND.
+type AppendMessage struct {
+	Raw   []byte
+	Flags []string // e.g. \Seen
+	Date  time.Time
+}
+
+// MailMigrationCheckpoint records what `migrate mail` has copied so an
+// interrupted run can resume.
+type MailMigrationCheckpoint struct {
+	From      string                          `json:"from"`
+	To        string                          `json:"to"`
+	Folders   map[string]*FolderMigrationMark `json:"folders"` // Keyed by source folder ID
+	UpdatedAt time.Time                       `json:"updated_at"`
+}
+
+// FolderMigrationMark is the per-folder progress in a checkpoint.
+type FolderMigrationMark struct {
+	Mailbox string   `json:"mailbox"`
+	Copied  []string `json:"copied"` // Source message IDs
+}
+
+// NewMailMigrationCheckpoint returns an empty checkpoint for from → to.
+func NewMailMigrationCheckpoint(from, to string) *MailMigrationCheckpoint {
+	return &MailMigrationCheckpoint{From: from, To: to, Folders: map[string]*FolderMigrationMark{}}
+}
+
+// Copied returns the set of message IDs already copied from folderID.
+func (c *MailMigrationCheckpoint) Copied(folderID string) map[string]bool {
+	done := map[string]bool{}
+	if mark := c.Folders[folderID]; mark != nil {
+		for _, id := range mark.Copied {
+			done[id] = true
+		}
+	}
+	return done
+}
+
+// MarkCopied records messageID as copied from folderID into mailbox.
+func (c *MailMigrationCheckpoint) MarkCopied(folderID, mailbox, messageID string) {
+	mark := c.Folders[folderID]
+	if mark == nil {
+		mark = &FolderMigrationMark{}
+		c.Folders[folderID] = mark
+	}
+	mark.Mailbox = mailbox
+	mark.Copied = append(mark.Copied, messageID)
+}
+
+// FolderMigration summarizes the copy of one source folder.
+type FolderMigration struct {
+	Folder   string `json:"folder"`  // Source folder path
+	Mailbox  string `json:"mailbox"` // Destination IMAP mailbox
+	Created  bool   `json:"created,omitempty"`
+	Messages int    `json:"messages"`
+	Copied   int    `json:"copied"`
+	Skipped  int    `json:"skipped"` // Already copied by an earlier run
+	Rebuilt  int    `json:"rebuilt,omitempty"`
+	Failed   int    `json:"failed"`
+}
+
+// MigrationFailure is a message that could not be copied.
+type MigrationFailure struct {
+	MessageID string `json:"message_id"`
+	Folder    string `json:"folder"`
+	Subject   string `json:"subject,omitempty"`
+	Error     string `json:"error"`
+}
+
+// MailMigrationReport is the result of `migrate mail`.
+type MailMigrationReport struct {
+	From         string             `json:"from"`
+	To           string             `json:"to"`
+	DryRun       bool               `json:"dry_run,omitempty"`
+	Folders      []FolderMigration  `json:"folders"`
+	Copied       int                `json:"copied"`
+	Skipped      int                `json:"skipped"`
+	Rebuilt      int                `json:"rebuilt,omitempty"` // Copied without raw MIME
+	Failed       int                `json:"failed"`
+	Failures     []MigrationFailure `json:"failures,omitempty"`
+	Interrupted  bool               `json:"interrupted,omitempty"`
+	Verification *MailboxDiff       `json:"verification,omitempty"`
+}
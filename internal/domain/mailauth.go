@@ -0,0 +1,64 @@
+package domain
+
+// SASLMechanism is a SASL mechanism for IMAP/SMTP.
+type SASLMechanism string
+
+const (
+	SASLXOAuth2     SASLMechanism = "XOAUTH2"
+	SASLOAuthBearer SASLMechanism = "OAUTHBEARER"
+	SASLPlain       SASLMechanism = "PLAIN" // Password login; Token holds the password
+)
+
+// MailTLSMode controls how a mail connection is secured.
+type MailTLSMode string
+
+const (
+	MailTLSImplicit MailTLSMode = "tls"      // TLS from the first byte (IMAPS 993, SMTPS 465)
+	MailTLSStartTLS MailTLSMode = "starttls" // Plain connect, then upgrade (IMAP 143, submission 587)
+	MailTLSNone     MailTLSMode = "none"     // No TLS; only for local test servers
+)
+
+// MailAuthOptions configures an authentication probe.
+type MailAuthOptions struct {
+	Host               string        `json:"host"`
+	Port               int           `json:"port"`
+	Username           string        `json:"username"`
+	Token              string        `json:"-"`
+	Mechanism          SASLMechanism `json:"mechanism"`
+	TLSMode            MailTLSMode   `json:"tls_mode"`
+	InsecureSkipVerify bool          `json:"insecure_skip_verify,omitempty"`
+}
+
+// SASLExchangeLine is one line of a protocol transcript.
+type SASLExchangeLine struct {
+	Direction string `json:"direction"` // "C" (client) or "S" (server)
+	Line      string `json:"line"`
+	Decoded   string `json:"decoded,omitempty"` // Decoded SASL payload, with the token redacted
+}
+
+// MailAuthResult is the outcome of an authentication probe.
+type MailAuthResult struct {
+	Protocol      string             `json:"protocol"`
+	Server        string             `json:"server"`
+	Mechanism     SASLMechanism      `json:"mechanism"`
+	Authenticated bool               `json:"authenticated"`
+	Capabilities  []string           `json:"capabilities,omitempty"`
+	ServerError   string             `json:"server_error,omitempty"` // Decoded error challenge, when the server sent one
+	Transcript    []SASLExchangeLine `json:"transcript"`
+}
+
+// MailServer is a mail server endpoint.
+type MailServer struct {
+	Host    string      `json:"host"`
+	Port    int         `json:"port"`
+	TLSMode MailTLSMode `json:"tls_mode"`
+}
+
+// MailServerConfig is the IMAP and SMTP configuration discovered for an
+// email domain.
+type MailServerConfig struct {
+	Domain string      `json:"domain"`
+	Source string      `json:"source"` // autoconfig, ispdb, autodiscover or probe
+	IMAP   *MailServer `json:"imap,omitempty"`
+	SMTP   *MailServer `json:"smtp,omitempty"`
+}
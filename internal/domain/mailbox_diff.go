@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Folder comparison states in a MailboxDiff.
+const (
+	FolderMatch         = "match"
+	FolderMissingInA    = "missing_in_a"
+	FolderMissingInB    = "missing_in_b"
+	FolderCountMismatch = "count_mismatch"
+)
+
+// MailboxSnapshot is the folders and messages of one account to compare.
+type MailboxSnapshot struct {
+	GrantID  string
+	Folders  []Folder
+	Messages []Message
+}
+
+// FolderDiff compares one folder across two accounts. Folders are matched
+// by system folder role (inbox, sent, ...) or, for user folders, by their
+// case-insensitive path.
+type FolderDiff struct {
+	Path   string `json:"path"`
+	CountA int    `json:"count_a"`
+	CountB int    `json:"count_b"`
+	Status string `json:"status"`
+}
+
+// MessageDiffItem identifies a message present in only one account.
+type MessageDiffItem struct {
+	Key       string    `json:"key"` // Hash of the Message-ID, or of subject/sender/date without one
+	ID        string    `json:"id"`  // Message ID in the account that has it
+	MessageID string    `json:"message_id,omitempty"`
+	Subject   string    `json:"subject"`
+	From      string    `json:"from,omitempty"`
+	Date      time.Time `json:"date"`
+}
+
+// MailboxDiff is the result of comparing two accounts.
+type MailboxDiff struct {
+	GrantA      string            `json:"grant_a"`
+	GrantB      string            `json:"grant_b"`
+	Folders     []FolderDiff      `json:"folders"`
+	MessagesA   int               `json:"messages_a"`
+	MessagesB   int               `json:"messages_b"`
+	Matched     int               `json:"matched"`
+	MissingInA  []MessageDiffItem `json:"missing_in_a"`
+	MissingInB  []MessageDiffItem `json:"missing_in_b"`
+	NoMessageID int               `json:"no_message_id,omitempty"` // Messages matched by fallback key
+}
+
+// Identical reports whether the diff found no differences.
+func (d *MailboxDiff) Identical() bool {
+	if len(d.MissingInA) > 0 || len(d.MissingInB) > 0 {
+		return false
+	}
+	for _, f := range d.Folders {
+		if f.Status != FolderMatch {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareMailboxes compares folder structure and message sets of a and b.
+// Messages are matched by a hash of their Message-ID header (request it
+// with Fields: "include_headers"); messages without one fall back to a hash
+// of subject, sender and date. Duplicates of a key count once.
+func CompareMailboxes(a, b MailboxSnapshot) *MailboxDiff {
+	diff := &MailboxDiff{
+		GrantA:     a.GrantID,
+		GrantB:     b.GrantID,
+		Folders:    compareFolders(a.Folders, b.Folders),
+		MissingInA: []MessageDiffItem{},
+		MissingInB: []MessageDiffItem{},
+	}
+
+	keysA, noIDA := messageKeys(a.Messages)
+	keysB, noIDB := messageKeys(b.Messages)
+	diff.MessagesA, diff.MessagesB = len(keysA), len(keysB)
+	diff.NoMessageID = noIDA + noIDB
+
+	for key, item := range keysA {
+		if _, ok := keysB[key]; ok {
+			diff.Matched++
+		} else {
+			diff.MissingInB = append(diff.MissingInB, item)
+		}
+	}
+	for key, item := range keysB {
+		if _, ok := keysA[key]; !ok {
+			diff.MissingInA = append(diff.MissingInA, item)
+		}
+	}
+	sortDiffItems(diff.MissingInA)
+	sortDiffItems(diff.MissingInB)
+	return diff
+}
+
+func sortDiffItems(items []MessageDiffItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].Date.Equal(items[j].Date) {
+			return items[i].Date.After(items[j].Date)
+		}
+		return items[i].Key < items[j].Key
+	})
+}
+
+// MessageIDHeader returns the normalized Message-ID header of msg, or "".
+func MessageIDHeader(msg *Message) string {
+	for _, h := range msg.Headers {
+		if strings.EqualFold(h.Name, "Message-ID") {
+			return strings.ToLower(strings.Trim(strings.TrimSpace(h.Value), "<>"))
+		}
+	}
+	return ""
+}
+
+// MessageDiffKey returns the key messages are matched by, and whether it
+// came from the Message-ID header.
+func MessageDiffKey(msg *Message) (string, bool) {
+	source, hasID := MessageIDHeader(msg), true
+	if source == "" {
+		hasID = false
+		from := ""
+		if len(msg.From) > 0 {
+			from = strings.ToLower(msg.From[0].Email)
+		}
+		source = fmt.Sprintf("%s\x00%s\x00%d", strings.TrimSpace(msg.Subject), from, msg.Date.Unix())
+	}
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:12]), hasID
+}
+
+func messageKeys(msgs []Message) (map[string]MessageDiffItem, int) {
+	keys := make(map[string]MessageDiffItem, len(msgs))
+	noID := 0
+	for i := range msgs {
+		msg := &msgs[i]
+		key, hasID := MessageDiffKey(msg)
+		if _, dup := keys[key]; dup {
+			continue
+		}
+		if !hasID {
+			noID++
+		}
+		item := MessageDiffItem{Key: key, ID: msg.ID, MessageID: MessageIDHeader(msg), Subject: msg.Subject, Date: msg.Date}
+		if len(msg.From) > 0 {
+			item.From = msg.From[0].Email
+		}
+		keys[key] = item
+	}
+	return keys, noID
+}
+
+// FolderPaths returns each folder's path ("Parent/Child"), keyed by ID.
+func FolderPaths(folders []Folder) map[string]string {
+	byID := make(map[string]*Folder, len(folders))
+	for i := range folders {
+		byID[folders[i].ID] = &folders[i]
+	}
+	paths := make(map[string]string, len(folders))
+	for i := range folders {
+		f := &folders[i]
+		parts := []string{f.Name}
+		seen := map[string]bool{f.ID: true}
+		for p := byID[f.ParentID]; p != nil && !seen[p.ID]; p = byID[p.ParentID] {
+			seen[p.ID] = true
+			parts = append([]string{p.Name}, parts...)
+		}
+		paths[f.ID] = strings.Join(parts, "/")
+	}
+	return paths
+}
+
+// folderKey matches system folders by role and user folders by path.
+func folderKey(f *Folder, path string) string {
+	if f.SystemFolder != "" {
+		return "system:" + strings.ToLower(f.SystemFolder)
+	}
+	return "path:" + strings.ToLower(path)
+}
+
+func compareFolders(a, b []Folder) []FolderDiff {
+	type side struct {
+		path  string
+		count int
+	}
+	index := func(folders []Folder) map[string]side {
+		paths := FolderPaths(folders)
+		out := make(map[string]side, len(folders))
+		for i := range folders {
+			f := &folders[i]
+			key := folderKey(f, paths[f.ID])
+			s := out[key]
+			if s.path == "" {
+				s.path = paths[f.ID]
+			}
+			s.count += f.TotalCount
+			out[key] = s
+		}
+		return out
+	}
+	ia, ib := index(a), index(b)
+
+	diffs := make([]FolderDiff, 0, len(ia)+len(ib))
+	for key, sa := range ia {
+		d := FolderDiff{Path: sa.path, CountA: sa.count, Status: FolderMatch}
+		if sb, ok := ib[key]; !ok {
+			d.Status = FolderMissingInB
+		} else {
+			d.CountB = sb.count
+			if sa.count != sb.count {
+				d.Status = FolderCountMismatch
+			}
+		}
+		diffs = append(diffs, d)
+	}
+	for key, sb := range ib {
+		if _, ok := ia[key]; !ok {
+			diffs = append(diffs, FolderDiff{Path: sb.path, CountB: sb.count, Status: FolderMissingInA})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return strings.ToLower(diffs[i].Path) < strings.ToLower(diffs[j].Path) })
+	return diffs
+}
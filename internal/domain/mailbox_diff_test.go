@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diffTestMessage(id, messageID, subject string, day int) Message {
+	msg := Message{
+		ID:      id,
+		Subject: subject,
+		From:    []EmailParticipant{{Email: "alice@example.com"}},
+		Date:    time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC),
+	}
+	if messageID != "" {
+		msg.Headers = []Header{{Name: "Message-Id", Value: messageID}}
+	}
+	return msg
+}
+
+func TestCompareMailboxes_Messages(t *testing.T) {
+	a := MailboxSnapshot{GrantID: "grant-a", Messages: []Message{
+		diffTestMessage("a1", "<One@example.com>", "One", 1),
+		diffTestMessage("a2", "<two@example.com>", "Two", 2),
+		diffTestMessage("a3", "", "No header", 3),
+		diffTestMessage("a4", "<two@example.com>", "Two (copy in another folder)", 2),
+	}}
+	b := MailboxSnapshot{GrantID: "grant-b", Messages: []Message{
+		diffTestMessage("b1", "one@EXAMPLE.com", "One", 1),
+		diffTestMessage("b2", "", "No header", 3),
+		diffTestMessage("b3", "<three@example.com>", "Three", 4),
+	}}
+
+	diff := CompareMailboxes(a, b)
+	assert.Equal(t, 3, diff.MessagesA, "duplicate Message-IDs count once")
+	assert.Equal(t, 3, diff.MessagesB)
+	assert.Equal(t, 2, diff.Matched)
+	assert.Equal(t, 2, diff.NoMessageID)
+	require.Len(t, diff.MissingInB, 1)
+	assert.Equal(t, "a2", diff.MissingInB[0].ID)
+	assert.Equal(t, "two@example.com", diff.MissingInB[0].MessageID)
+	require.Len(t, diff.MissingInA, 1)
+	assert.Equal(t, "Three", diff.MissingInA[0].Subject)
+	assert.False(t, diff.Identical())
+}
+
+func TestCompareMailboxes_Folders(t *testing.T) {
+	a := []Folder{
+		{ID: "1", Name: "INBOX", SystemFolder: "inbox", TotalCount: 10},
+		{ID: "2", Name: "Projects", TotalCount: 3},
+		{ID: "3", Name: "Alpha", ParentID: "2", TotalCount: 2},
+		{ID: "4", Name: "Old", TotalCount: 1},
+	}
+	b := []Folder{
+		{ID: "x", Name: "Inbox", SystemFolder: "inbox", TotalCount: 9},
+		{ID: "y", Name: "projects", TotalCount: 3},
+		{ID: "z", Name: "alpha", ParentID: "y", TotalCount: 2},
+		{ID: "w", Name: "New", TotalCount: 0},
+	}
+
+	diff := CompareMailboxes(MailboxSnapshot{Folders: a}, MailboxSnapshot{Folders: b})
+	assert.Equal(t, []FolderDiff{
+		{Path: "INBOX", CountA: 10, CountB: 9, Status: FolderCountMismatch},
+		{Path: "New", Status: FolderMissingInA},
+		{Path: "Old", CountA: 1, Status: FolderMissingInB},
+		{Path: "Projects", CountA: 3, CountB: 3, Status: FolderMatch},
+		{Path: "Projects/Alpha", CountA: 2, CountB: 2, Status: FolderMatch},
+	}, diff.Folders)
+}
+
+func TestCompareMailboxes_Identical(t *testing.T) {
+	folders := []Folder{{ID: "1", Name: "INBOX", SystemFolder: "inbox", TotalCount: 1}}
+	msgs := []Message{diffTestMessage("m1", "<a@b>", "Hi", 1)}
+	diff := CompareMailboxes(MailboxSnapshot{Folders: folders, Messages: msgs}, MailboxSnapshot{Folders: folders, Messages: msgs})
+	assert.True(t, diff.Identical())
+	assert.NotNil(t, diff.MissingInA)
+}
+
+func TestFolderPaths_Cycle(t *testing.T) {
+	paths := FolderPaths([]Folder{{ID: "1", Name: "A", ParentID: "2"}, {ID: "2", Name: "B", ParentID: "1"}})
+	assert.Equal(t, "B/A", paths["1"])
+	assert.Equal(t, "A/B", paths["2"])
+}
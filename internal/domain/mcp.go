@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"sort"
+)
+
+// MCPToolPermission decides what `nylas mcp serve` does when an assistant
+// calls a tool.
+type MCPToolPermission string
+
+// MCP tool permissions.
+const (
+	MCPToolAuto MCPToolPermission = "auto" // Run the tool
+	MCPToolAsk  MCPToolPermission = "ask"  // Confirm on the terminal first
+	MCPToolDeny MCPToolPermission = "deny" // Refuse the call
+)
+
+// DefaultMCPToolPermissions apply to tools that match no mcp.tools entry.
+// Tools that send, create, change or delete data ask first; everything else
+// runs.
+var DefaultMCPToolPermissions = map[string]MCPToolPermission{
+	"send_*":   MCPToolAsk,
+	"create_*": MCPToolAsk,
+	"update_*": MCPToolAsk,
+	"delete_*": MCPToolAsk,
+}
+
+// MCPConfig holds `nylas mcp serve` settings kept in config under mcp.
+type MCPConfig struct {
+	// Tools maps a tool name, or a pattern such as "delete_*" or "*", to its
+	// permission. An exact name wins over patterns, and a longer pattern
+	// over a shorter one. Tools without an entry fall back to
+	// DefaultMCPToolPermissions, and then to auto.
+	Tools map[string]MCPToolPermission `yaml:"tools,omitempty"`
+}
+
+// ToolPermission returns the permission for the named tool.
+func (c *MCPConfig) ToolPermission(tool string) MCPToolPermission {
+	if c != nil {
+		if perm, ok := matchToolPermission(c.Tools, tool); ok {
+			return perm
+		}
+	}
+	if perm, ok := matchToolPermission(DefaultMCPToolPermissions, tool); ok {
+		return perm
+	}
+	return MCPToolAuto
+}
+
+// matchToolPermission returns the entry of tools for tool: its exact name,
+// or else the longest matching pattern.
+func matchToolPermission(tools map[string]MCPToolPermission, tool string) (MCPToolPermission, bool) {
+	if perm, ok := tools[tool]; ok {
+		return perm, true
+	}
+
+	patterns := make([]string, 0, len(tools))
+	for pattern := range tools {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, tool); ok {
+			return tools[pattern], true
+		}
+	}
+	return "", false
+}
+
+// Validate reports an unknown permission or a malformed pattern.
+func (c *MCPConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for tool, perm := range c.Tools {
+		switch perm {
+		case MCPToolAuto, MCPToolAsk, MCPToolDeny:
+		default:
+			return fmt.Errorf("mcp.tools.%s: unknown permission %q (use auto, ask or deny)", tool, perm)
+		}
+		if _, err := path.Match(tool, ""); err != nil {
+			return fmt.Errorf("mcp.tools.%s: invalid pattern", tool)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMCPConfig_ToolPermission(t *testing.T) {
+	var unset *MCPConfig
+	assert.Equal(t, MCPToolAsk, unset.ToolPermission("send_message"))
+
+	cfg := &MCPConfig{Tools: map[string]MCPToolPermission{
+		"*":             MCPToolAsk,
+		"delete_*":      MCPToolDeny,
+		"delete_draft":  MCPToolAuto,
+		"list_*":        MCPToolAuto,
+		"send_message":  MCPToolAsk,
+		"get_*":         MCPToolAuto,
+		"update_folder": MCPToolDeny,
+	}}
+
+	tests := map[string]MCPToolPermission{
+		"send_message":   MCPToolAsk,
+		"delete_event":   MCPToolDeny,
+		"delete_draft":   MCPToolAuto, // exact name wins over the pattern
+		"list_messages":  MCPToolAuto,
+		"create_event":   MCPToolAsk, // falls through to "*"
+		"update_folder":  MCPToolDeny,
+		"get_free_busy":  MCPToolAuto,
+		"confirm_send_x": MCPToolAsk,
+	}
+	for tool, want := range tests {
+		assert.Equal(t, want, cfg.ToolPermission(tool), tool)
+	}
+
+	assert.Equal(t, MCPToolAsk, (&MCPConfig{}).ToolPermission("send_message"))
+}
+
+func TestMCPConfig_ToolPermissionDefaults(t *testing.T) {
+	unset := &MCPConfig{}
+	tests := map[string]MCPToolPermission{
+		"send_message":         MCPToolAsk,
+		"send_draft":           MCPToolAsk,
+		"create_event":         MCPToolAsk,
+		"update_message":       MCPToolAsk,
+		"delete_folder":        MCPToolAsk,
+		"list_messages":        MCPToolAuto,
+		"get_grant":            MCPToolAuto,
+		"confirm_send_message": MCPToolAuto, // only issues a token for send_message
+		"availability":         MCPToolAuto,
+	}
+	for tool, want := range tests {
+		assert.Equal(t, want, unset.ToolPermission(tool), tool)
+	}
+
+	// Config entries, patterns included, take precedence over the defaults.
+	allowSends := &MCPConfig{Tools: map[string]MCPToolPermission{"send_*": MCPToolAuto}}
+	assert.Equal(t, MCPToolAuto, allowSends.ToolPermission("send_message"))
+	assert.Equal(t, MCPToolAsk, allowSends.ToolPermission("delete_event"))
+
+	allowAll := &MCPConfig{Tools: map[string]MCPToolPermission{"*": MCPToolAuto}}
+	assert.Equal(t, MCPToolAuto, allowAll.ToolPermission("delete_event"))
+}
+
+func TestMCPConfig_Validate(t *testing.T) {
+	var unset *MCPConfig
+	assert.NoError(t, unset.Validate())
+	assert.NoError(t, (&MCPConfig{Tools: map[string]MCPToolPermission{"send_*": MCPToolAsk}}).Validate())
+	assert.ErrorContains(t, (&MCPConfig{Tools: map[string]MCPToolPermission{"send_message": "maybe"}}).Validate(), "unknown permission")
+	assert.ErrorContains(t, (&MCPConfig{Tools: map[string]MCPToolPermission{"send_[": MCPToolDeny}}).Validate(), "invalid pattern")
+}
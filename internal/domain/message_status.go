@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Delivery states of a sent message, from the webhook events received for
+// it. A later state in this list outranks an earlier one.
+const (
+	MessageStateUnknown = "unknown" // no events received
+	MessageStateSent    = "sent"    // message.send_success
+	MessageStateOpened  = "opened"  // message.opened or message.link_clicked
+	MessageStateFailed  = "failed"  // message.send_failed
+	MessageStateBounced = "bounced" // message.bounce_detected
+)
+
+// MessageStatusTriggers are the webhook triggers that report on a sent
+// message.
+var MessageStatusTriggers = []string{
+	TriggerMessageSendSuccess,
+	TriggerMessageSendFailed,
+	TriggerMessageBounceDetected,
+	TriggerMessageOpened,
+	TriggerMessageLinkClicked,
+}
+
+// MessageStatus is the delivery status of a sent message.
+type MessageStatus struct {
+	MessageID string               `json:"message_id"`
+	GrantID   string               `json:"grant_id,omitempty"`
+	State     string               `json:"state"`
+	Bounces   int                  `json:"bounces"`
+	Opens     int                  `json:"opens"`
+	Clicks    int                  `json:"clicks"`
+	Events    []MessageStatusEvent `json:"events"`
+}
+
+// MessageStatusEvent is one webhook event about a sent message.
+type MessageStatusEvent struct {
+	Type      string    `json:"type"`
+	At        time.Time `json:"at"`
+	Recipient string    `json:"recipient,omitempty"` // the bounced address
+	Detail    string    `json:"detail,omitempty"`    // bounce or failure reason
+	EventID   string    `json:"event_id"`
+}
@@ -89,3 +89,26 @@ type NotetakerQueryParams struct {
 	PageToken string `json:"page_token,omitempty"`
 	State     string `json:"state,omitempty"` // Filter by state
 }
+
+// NotetakerTranscript is a downloaded meeting transcript.
+type NotetakerTranscript struct {
+	Type     string              `json:"type,omitempty"` // speaker_labelled or raw
+	Segments []TranscriptSegment `json:"transcript"`
+}
+
+// TranscriptSegment is one utterance in a transcript. Start and End are
+// milliseconds from the start of the recording.
+type TranscriptSegment struct {
+	Speaker string `json:"speaker,omitempty"`
+	Start   int64  `json:"start"`
+	End     int64  `json:"end"`
+	Text    string `json:"text"`
+}
+
+// MeetingActionItem is a follow-up task extracted from a meeting transcript.
+type MeetingActionItem struct {
+	Task       string `json:"task"`
+	Owner      string `json:"owner,omitempty"`
+	OwnerEmail string `json:"owner_email,omitempty"`
+	Due        string `json:"due,omitempty"`
+}
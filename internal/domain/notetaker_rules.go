@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// NotetakerConfig holds notetaker settings kept in config under notetaker.
+type NotetakerConfig struct {
+	// Rules decide which calendar events `notetaker sync` sends a notetaker
+	// to, keyed by rule name.
+	Rules map[string]*NotetakerRule `yaml:"rules,omitempty"`
+}
+
+// NotetakerRule is an auto-join rule. An event matches when it matches every
+// criterion the rule sets; a rule with no criteria matches every meeting.
+type NotetakerRule struct {
+	Name         string   `yaml:"-" json:"name,omitempty"`
+	Keywords     []string `yaml:"keywords,omitempty" json:"keywords,omitempty"`           // any, in the title or description
+	Organizers   []string `yaml:"organizers,omitempty" json:"organizers,omitempty"`       // emails, or "@domain"
+	Calendars    []string `yaml:"calendars,omitempty" json:"calendars,omitempty"`         // calendar IDs
+	WorkingHours bool     `yaml:"working_hours,omitempty" json:"working_hours,omitempty"` // only meetings starting within working hours
+	Exclude      bool     `yaml:"exclude,omitempty" json:"exclude,omitempty"`             // never join matching meetings
+	BotName      string   `yaml:"bot_name,omitempty" json:"bot_name,omitempty"`
+}
+
+// ValidNotetakerRuleName reports whether name can be used for a rule.
+func ValidNotetakerRuleName(name string) bool {
+	return savedSearchNamePattern.MatchString(name)
+}
+
+// Matches reports whether e matches the rule. wh supplies working hours for
+// rules that need them; start times are compared in loc.
+func (r *NotetakerRule) Matches(e *Event, wh *WorkingHoursConfig, loc *time.Location) bool {
+	if len(r.Calendars) > 0 && !slices.Contains(r.Calendars, e.CalendarID) {
+		return false
+	}
+	if len(r.Keywords) > 0 {
+		text := strings.ToLower(e.Title + "\n" + e.Description)
+		if !slices.ContainsFunc(r.Keywords, func(k string) bool { return strings.Contains(text, strings.ToLower(k)) }) {
+			return false
+		}
+	}
+	if len(r.Organizers) > 0 {
+		if e.Organizer == nil || !slices.ContainsFunc(r.Organizers, func(o string) bool { return emailPatternMatches(o, e.Organizer.Email) }) {
+			return false
+		}
+	}
+	if r.WorkingHours && !wh.Contains(e.When.StartDateTime().In(loc)) {
+		return false
+	}
+	return true
+}
+
+// emailPatternMatches reports whether email is pattern, or ends with pattern
+// when it is an "@domain".
+func emailPatternMatches(pattern, email string) bool {
+	pattern, email = strings.ToLower(strings.TrimSpace(pattern)), strings.ToLower(email)
+	if strings.HasPrefix(pattern, "@") {
+		return strings.HasSuffix(email, pattern)
+	}
+	return pattern != "" && pattern == email
+}
+
+// Contains reports whether t falls within the working hours of its day. Days
+// whose schedule is disabled have no working hours.
+func (w *WorkingHoursConfig) Contains(t time.Time) bool {
+	schedule := w.GetScheduleForDay(t.Weekday().String())
+	if schedule == nil || !schedule.Enabled {
+		return false
+	}
+	start, err := time.Parse("15:04", schedule.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", schedule.End)
+	if err != nil {
+		return false
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	return minutes >= start.Hour()*60+start.Minute() && minutes < end.Hour()*60+end.Minute()
+}
+
+// AutoNotetaker records a notetaker that `notetaker sync` created for a
+// calendar event, so later syncs can move or remove it.
+type AutoNotetaker struct {
+	NotetakerID string    `json:"notetaker_id"`
+	GrantID     string    `json:"grant_id"`
+	EventID     string    `json:"event_id"`
+	CalendarID  string    `json:"calendar_id,omitempty"`
+	Rule        string    `json:"rule,omitempty"`
+	MeetingLink string    `json:"meeting_link"`
+	JoinTime    time.Time `json:"join_time"`
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotetakerRule_Matches(t *testing.T) {
+	start := time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC) // Monday
+	event := &Event{
+		CalendarID:  "work",
+		Title:       "Weekly Customer Sync",
+		Description: "Agenda: renewal",
+		When:        EventWhen{StartTime: start.Unix(), EndTime: start.Add(time.Hour).Unix()},
+		Organizer:   &Participant{Person: Person{Email: "Ana@Acme.com"}},
+	}
+	evening := *event
+	evening.When = EventWhen{StartTime: start.Add(9 * time.Hour).Unix()}
+
+	tests := []struct {
+		name  string
+		rule  NotetakerRule
+		event *Event
+		want  bool
+	}{
+		{"no criteria", NotetakerRule{}, event, true},
+		{"keyword in title", NotetakerRule{Keywords: []string{"customer"}}, event, true},
+		{"keyword in description", NotetakerRule{Keywords: []string{"standup", "RENEWAL"}}, event, true},
+		{"keyword missing", NotetakerRule{Keywords: []string{"standup"}}, event, false},
+		{"organizer email", NotetakerRule{Organizers: []string{"ana@acme.com"}}, event, true},
+		{"organizer domain", NotetakerRule{Organizers: []string{"@acme.com"}}, event, true},
+		{"organizer other", NotetakerRule{Organizers: []string{"@example.com"}}, event, false},
+		{"calendar", NotetakerRule{Calendars: []string{"personal"}}, event, false},
+		{"within working hours", NotetakerRule{WorkingHours: true}, event, true},
+		{"outside working hours", NotetakerRule{WorkingHours: true}, &evening, false},
+		{"all criteria", NotetakerRule{Keywords: []string{"sync"}, Organizers: []string{"@acme.com"}, Calendars: []string{"work"}, WorkingHours: true}, event, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Matches(tt.event, nil, time.UTC))
+		})
+	}
+}
+
+func TestWorkingHoursConfig_Contains(t *testing.T) {
+	wh := &WorkingHoursConfig{
+		Default: &DaySchedule{Enabled: true, Start: "08:00", End: "16:00"},
+		Weekend: &DaySchedule{Enabled: false},
+	}
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, wh.Contains(monday.Add(8*time.Hour)))
+	assert.False(t, wh.Contains(monday.Add(16*time.Hour)), "end is exclusive")
+	assert.False(t, wh.Contains(monday.Add(7*time.Hour+59*time.Minute)))
+	assert.False(t, wh.Contains(monday.AddDate(0, 0, 5).Add(10*time.Hour)), "weekend is disabled")
+
+	var unset *WorkingHoursConfig
+	assert.True(t, unset.Contains(monday.Add(9*time.Hour)), "defaults to 09:00-17:00")
+}
+
+func TestValidNotetakerRuleName(t *testing.T) {
+	assert.True(t, ValidNotetakerRuleName("customer-calls"))
+	assert.False(t, ValidNotetakerRuleName("has space"))
+	assert.False(t, ValidNotetakerRuleName(""))
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Notification categories.
+const (
+	NotifyVIPEmail       = "vip_email"
+	NotifyMeetingSoon    = "meeting_soon"
+	NotifyBookingCreated = "booking_created"
+	NotifyWebhookFailure = "webhook_failure"
+)
+
+// NotifyCategory describes a notification category.
+type NotifyCategory struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// NotifyCategories lists the notification categories in display order.
+var NotifyCategories = []NotifyCategory{
+	{NotifyVIPEmail, "New email from a VIP sender"},
+	{NotifyMeetingSoon, "Meeting starting soon"},
+	{NotifyBookingCreated, "Scheduler booking created"},
+	{NotifyWebhookFailure, "Webhook failing or a delivery failed"},
+}
+
+// Notification channels.
+const (
+	NotifyDesktop = "desktop"
+	NotifySlack   = "slack"
+	NotifyEmail   = "email"
+)
+
+// NotifyChannels lists the notification channels.
+var NotifyChannels = []string{NotifyDesktop, NotifySlack, NotifyEmail}
+
+// DefaultMeetingLead is how long before a meeting starts the meeting_soon
+// notification is sent when notify.meeting_lead is unset.
+const DefaultMeetingLead = 10 * time.Minute
+
+// NotifyConfig holds notification settings kept in config under notify.
+// The Slack webhook URL is a credential and lives in the secret store.
+type NotifyConfig struct {
+	// Routes maps a category to the channels it is sent to. Once notify is
+	// configured, a category without an entry goes to the desktop; an empty
+	// list turns it off.
+	Routes      map[string][]string `yaml:"routes,omitempty"`
+	EmailTo     string              `yaml:"email_to,omitempty"`     // recipient for the email channel
+	VIPs        []string            `yaml:"vips,omitempty"`         // emails, or "@domain"
+	MeetingLead string              `yaml:"meeting_lead,omitempty"` // e.g. "10m"
+}
+
+// Notification is one message sent to the user.
+type Notification struct {
+	Category string    `json:"category"`
+	Title    string    `json:"title"`
+	Body     string    `json:"body,omitempty"`
+	URL      string    `json:"url,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// IsNotifyCategory reports whether name is a known category.
+func IsNotifyCategory(name string) bool {
+	return slices.ContainsFunc(NotifyCategories, func(c NotifyCategory) bool { return c.Name == name })
+}
+
+// ChannelsFor returns the channels category is sent to. Nothing is sent
+// until notifications are configured.
+func (c *NotifyConfig) ChannelsFor(category string) []string {
+	if c == nil {
+		return nil
+	}
+	if channels, ok := c.Routes[category]; ok {
+		return channels
+	}
+	return []string{NotifyDesktop}
+}
+
+// IsVIP reports whether email matches one of the VIP patterns.
+func (c *NotifyConfig) IsVIP(email string) bool {
+	if c == nil || email == "" {
+		return false
+	}
+	return slices.ContainsFunc(c.VIPs, func(p string) bool { return emailPatternMatches(p, email) })
+}
+
+// MeetingLeadTime returns how long before a meeting to notify. Invalid or
+// unset values fall back to DefaultMeetingLead.
+func (c *NotifyConfig) MeetingLeadTime() time.Duration {
+	if c != nil {
+		if d, ok := parsePositiveDuration(c.MeetingLead); ok {
+			return d
+		}
+	}
+	return DefaultMeetingLead
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyConfig_ChannelsFor(t *testing.T) {
+	var unset *NotifyConfig
+	assert.Nil(t, unset.ChannelsFor(NotifyVIPEmail), "nothing is sent until configured")
+
+	cfg := &NotifyConfig{Routes: map[string][]string{
+		NotifyVIPEmail:       {NotifySlack, NotifyEmail},
+		NotifyBookingCreated: {},
+	}}
+	assert.Equal(t, []string{NotifySlack, NotifyEmail}, cfg.ChannelsFor(NotifyVIPEmail))
+	assert.Empty(t, cfg.ChannelsFor(NotifyBookingCreated), "empty route turns the category off")
+	assert.Equal(t, []string{NotifyDesktop}, cfg.ChannelsFor(NotifyMeetingSoon), "unrouted categories go to the desktop")
+}
+
+func TestNotifyConfig_IsVIP(t *testing.T) {
+	cfg := &NotifyConfig{VIPs: []string{"ceo@example.com", "@acme.com"}}
+	assert.True(t, cfg.IsVIP("CEO@example.com"))
+	assert.True(t, cfg.IsVIP("ana@acme.com"))
+	assert.False(t, cfg.IsVIP("ana@notacme.org"))
+	assert.False(t, cfg.IsVIP(""))
+
+	var unset *NotifyConfig
+	assert.False(t, unset.IsVIP("ceo@example.com"))
+}
+
+func TestNotifyConfig_MeetingLeadTime(t *testing.T) {
+	var unset *NotifyConfig
+	assert.Equal(t, DefaultMeetingLead, unset.MeetingLeadTime())
+	assert.Equal(t, 5*time.Minute, (&NotifyConfig{MeetingLead: "5m"}).MeetingLeadTime())
+	assert.Equal(t, DefaultMeetingLead, (&NotifyConfig{MeetingLead: "soon"}).MeetingLeadTime())
+}
+
+func TestIsNotifyCategory(t *testing.T) {
+	assert.True(t, IsNotifyCategory(NotifyWebhookFailure))
+	assert.False(t, IsNotifyCategory("desktop"))
+}
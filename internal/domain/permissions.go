@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// Scope names a Nylas permission area a command depends on. Each scope maps
+// to the provider-specific OAuth permissions a grant must have been issued
+// with for the command to succeed.
+type Scope string
+
+const (
+	ScopeEmailRead      Scope = "email.read"
+	ScopeEmailModify    Scope = "email.modify"
+	ScopeEmailSend      Scope = "email.send"
+	ScopeCalendarRead   Scope = "calendar.read"
+	ScopeCalendarWrite  Scope = "calendar"
+	ScopeContactsRead   Scope = "contacts.read"
+	ScopeContactsWrite  Scope = "contacts"
+	ScopeApplicationAPI Scope = "application"
+)
+
+// Capability names a local machine capability a command relies on.
+type Capability string
+
+const (
+	CapabilityGPG      Capability = "gpg"
+	CapabilityKeychain Capability = "keychain"
+	CapabilityBrowser  Capability = "browser"
+	CapabilityNetwork  Capability = "network-listener"
+	CapabilityAI       Capability = "ai-provider"
+)
+
+// CapabilityDescriptions explains what each local capability is used for.
+var CapabilityDescriptions = map[Capability]string{
+	CapabilityGPG:      "gpg binary on PATH (or gpg.backend native) with usable keys",
+	CapabilityKeychain: "OS keychain or encrypted file store for credentials",
+	CapabilityBrowser:  "default web browser for OAuth consent",
+	CapabilityNetwork:  "permission to bind a local port",
+	CapabilityAI:       "an AI provider set up with 'nylas ai config' (Ollama, Claude, OpenAI, ...)",
+}
+
+// ScopeDescriptions explains each scope in user-facing terms.
+var ScopeDescriptions = map[Scope]string{
+	ScopeEmailRead:      "Read messages, threads, folders, and attachments",
+	ScopeEmailModify:    "Change message flags, folders, and drafts",
+	ScopeEmailSend:      "Send messages on behalf of the grant",
+	ScopeCalendarRead:   "Read calendars, events, and free/busy",
+	ScopeCalendarWrite:  "Create, update, and delete events",
+	ScopeContactsRead:   "Read contacts and contact groups",
+	ScopeContactsWrite:  "Create, update, and delete contacts",
+	ScopeApplicationAPI: "Application-level API key access (no grant scopes)",
+}
+
+// ProviderScopes lists the provider OAuth permissions backing each scope.
+var ProviderScopes = map[Scope]map[Provider][]string{
+	ScopeEmailRead: {
+		ProviderGoogle:    {"https://www.googleapis.com/auth/gmail.readonly"},
+		ProviderMicrosoft: {"Mail.Read"},
+	},
+	ScopeEmailModify: {
+		ProviderGoogle:    {"https://www.googleapis.com/auth/gmail.modify"},
+		ProviderMicrosoft: {"Mail.ReadWrite"},
+	},
+	ScopeEmailSend: {
+		ProviderGoogle:    {"https://www.googleapis.com/auth/gmail.send"},
+		ProviderMicrosoft: {"Mail.Send"},
+	},
+	ScopeCalendarRead: {
+		ProviderGoogle:    {"https://www.googleapis.com/auth/calendar.readonly"},
+		ProviderMicrosoft: {"Calendars.Read"},
+	},
+	ScopeCalendarWrite: {
+		ProviderGoogle:    {"https://www.googleapis.com/auth/calendar"},
+		ProviderMicrosoft: {"Calendars.ReadWrite"},
+	},
+	ScopeContactsRead: {
+		ProviderGoogle:    {"https://www.googleapis.com/auth/contacts.readonly"},
+		ProviderMicrosoft: {"Contacts.Read"},
+	},
+	ScopeContactsWrite: {
+		ProviderGoogle:    {"https://www.googleapis.com/auth/contacts"},
+		ProviderMicrosoft: {"Contacts.ReadWrite"},
+	},
+}
+
+// scopeImplied lists the narrower scope each scope includes.
+var scopeImplied = map[Scope]Scope{
+	ScopeEmailModify:   ScopeEmailRead,
+	ScopeCalendarWrite: ScopeCalendarRead,
+	ScopeContactsWrite: ScopeContactsRead,
+}
+
+// ProviderPermissions returns the provider OAuth permissions backing s, or
+// nil when the provider has no OAuth scopes for it.
+func (s Scope) ProviderPermissions(p Provider) []string {
+	return ProviderScopes[s][p]
+}
+
+// GrantedBy reports whether a grant from provider p, issued with the
+// provider permissions in granted, has scope s, either directly or through
+// a broader scope. known is false when p has no OAuth permissions for s, as
+// with IMAP and other credential-based providers.
+func (s Scope) GrantedBy(p Provider, granted []string) (ok, known bool) {
+	if len(s.ProviderPermissions(p)) == 0 {
+		return false, false
+	}
+	for scope := s; scope != ""; {
+		for _, perm := range scope.ProviderPermissions(p) {
+			if slices.ContainsFunc(granted, func(g string) bool { return samePermission(g, perm) }) {
+				return true, true
+			}
+		}
+		scope = broaderScope(scope)
+	}
+	return false, true
+}
+
+// broaderScope returns the scope that includes s, if any.
+func broaderScope(s Scope) Scope {
+	for broader, narrower := range scopeImplied {
+		if narrower == s {
+			return broader
+		}
+	}
+	return ""
+}
+
+// samePermission compares a granted permission with a provider permission.
+// Microsoft grants may list permissions with the Graph resource prefix.
+func samePermission(granted, perm string) bool {
+	granted = strings.TrimPrefix(strings.TrimSpace(granted), "https://graph.microsoft.com/")
+	return strings.EqualFold(granted, perm)
+}
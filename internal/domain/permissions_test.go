@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeGrantedBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		scope     Scope
+		provider  Provider
+		granted   []string
+		wantOK    bool
+		wantKnown bool
+	}{
+		{"google direct", ScopeCalendarRead, ProviderGoogle, []string{"https://www.googleapis.com/auth/calendar.readonly"}, true, true},
+		{"google broader scope", ScopeCalendarRead, ProviderGoogle, []string{"https://www.googleapis.com/auth/calendar"}, true, true},
+		{"google missing", ScopeCalendarWrite, ProviderGoogle, []string{"https://www.googleapis.com/auth/calendar.readonly"}, false, true},
+		{"microsoft graph prefix", ScopeContactsRead, ProviderMicrosoft, []string{"https://graph.microsoft.com/Contacts.ReadWrite"}, true, true},
+		{"microsoft case", ScopeEmailSend, ProviderMicrosoft, []string{"mail.send"}, true, true},
+		{"imap unknown", ScopeEmailRead, ProviderIMAP, nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, known := tt.scope.GrantedBy(tt.provider, tt.granted)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantKnown, known)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuietHoursAllGrants is the EmailConfig.QuietHours key that applies to
+// every grant without its own entry.
+const QuietHoursAllGrants = "default"
+
+// QuietHours is a daily window during which `email send` holds messages,
+// evaluated in the recipients' time zone.
+type QuietHours struct {
+	Start    string `yaml:"start" json:"start"`                 // "20:00"
+	End      string `yaml:"end" json:"end"`                     // "07:00"; earlier than Start means overnight
+	Timezone string `yaml:"timezone,omitempty" json:"timezone"` // recipients' IANA zone; default: local
+	Weekends bool   `yaml:"weekends,omitempty" json:"weekends"` // also hold all of Saturday and Sunday
+}
+
+// QuietHoursFor returns the quiet hours for grantID, falling back to the
+// all-grants entry. It returns nil when none apply.
+func (e *EmailConfig) QuietHoursFor(grantID string) *QuietHours {
+	if e == nil {
+		return nil
+	}
+	if q, ok := e.QuietHours[grantID]; ok && q != nil {
+		return q
+	}
+	return e.QuietHours[QuietHoursAllGrants]
+}
+
+// Validate checks the clock times and time zone.
+func (q *QuietHours) Validate() error {
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return fmt.Errorf("%w: invalid quiet hours start %q (use HH:MM)", ErrInvalidInput, q.Start)
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return fmt.Errorf("%w: invalid quiet hours end %q (use HH:MM)", ErrInvalidInput, q.End)
+	}
+	if start == end {
+		return fmt.Errorf("%w: quiet hours start and end must differ", ErrInvalidInput)
+	}
+	if _, err := q.Location(); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidInput, q.Timezone)
+	}
+	return nil
+}
+
+// Location returns the time zone quiet hours are evaluated in.
+func (q *QuietHours) Location() (*time.Location, error) {
+	if q.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(q.Timezone)
+}
+
+// NextAllowed returns the first moment at or after t that is outside quiet
+// hours, in the quiet hours' time zone, and whether t itself was held.
+func (q *QuietHours) NextAllowed(t time.Time) (time.Time, bool, error) {
+	if err := q.Validate(); err != nil {
+		return time.Time{}, false, err
+	}
+	loc, _ := q.Location()
+	start, _ := parseClock(q.Start)
+	end, _ := parseClock(q.End)
+
+	cur := t.In(loc)
+	held := false
+	// Each step leaves one blocked period; a weekend plus an overnight
+	// window needs at most a few.
+	for i := 0; i < 8; i++ {
+		y, m, d := cur.Date()
+		clock := cur.Hour()*60 + cur.Minute()
+
+		switch {
+		case q.Weekends && isWeekend(cur.Weekday()):
+			cur = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case inWindow(clock, start, end):
+			endDay := d
+			if start > end && clock >= start {
+				endDay++ // overnight window ends tomorrow
+			}
+			cur = time.Date(y, m, endDay, end/60, end%60, 0, 0, loc)
+		default:
+			return cur, held, nil
+		}
+		held = true
+	}
+	return cur, held, nil
+}
+
+// String formats the window, e.g. "20:00-07:00 America/New_York".
+func (q *QuietHours) String() string {
+	tz := q.Timezone
+	if tz == "" {
+		tz = "local time"
+	}
+	s := fmt.Sprintf("%s-%s %s", q.Start, q.End, tz)
+	if q.Weekends {
+		s += ", weekends"
+	}
+	return s
+}
+
+// parseClock parses "HH:MM" into minutes after midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// inWindow reports whether clock falls in [start, end), wrapping midnight
+// when end is before start.
+func inWindow(clock, start, end int) bool {
+	if start < end {
+		return clock >= start && clock < end
+	}
+	return clock >= start || clock < end
+}
+
+func isWeekend(d time.Weekday) bool {
+	return d == time.Saturday || d == time.Sunday
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHours_NextAllowed(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, ny)
+	}
+
+	overnight := &QuietHours{Start: "20:00", End: "07:00", Timezone: "America/New_York"}
+	lunch := &QuietHours{Start: "12:00", End: "13:00", Timezone: "America/New_York"}
+	weekends := &QuietHours{Start: "20:00", End: "07:00", Timezone: "America/New_York", Weekends: true}
+
+	tests := []struct {
+		name     string
+		q        *QuietHours
+		t        time.Time
+		want     time.Time
+		wantHeld bool
+	}{
+		{"outside overnight window", overnight, at(3, 11, 10, 0), at(3, 11, 10, 0), false},
+		{"evening", overnight, at(3, 11, 21, 30), at(3, 12, 7, 0), true},
+		{"after midnight", overnight, at(3, 12, 2, 15), at(3, 12, 7, 0), true},
+		{"start is inclusive", overnight, at(3, 11, 20, 0), at(3, 12, 7, 0), true},
+		{"end is exclusive", overnight, at(3, 12, 7, 0), at(3, 12, 7, 0), false},
+		{"same-day window", lunch, at(3, 11, 12, 30), at(3, 11, 13, 0), true},
+		{"friday night waits for monday", weekends, at(3, 13, 22, 0), at(3, 16, 7, 0), true},
+		{"saturday noon", weekends, at(3, 14, 12, 0), at(3, 16, 7, 0), true},
+		{"across DST change", overnight, at(3, 7, 23, 0), at(3, 8, 7, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, held, err := tt.q.NextAllowed(tt.t)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+			assert.Equal(t, tt.wantHeld, held)
+		})
+	}
+}
+
+func TestQuietHours_EvaluatedInRecipientZone(t *testing.T) {
+	q := &QuietHours{Start: "20:00", End: "07:00", Timezone: "Asia/Tokyo"}
+
+	// 09:00 UTC is 18:00 in Tokyo: allowed.
+	_, held, err := q.NextAllowed(time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	// 12:00 UTC is 21:00 in Tokyo: held until 07:00 Tokyo (22:00 UTC).
+	got, held, err := q.NextAllowed(time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.True(t, held)
+	assert.True(t, got.Equal(time.Date(2026, 5, 4, 22, 0, 0, 0, time.UTC)), "got %s", got)
+}
+
+func TestQuietHours_Validate(t *testing.T) {
+	assert.NoError(t, (&QuietHours{Start: "20:00", End: "07:00"}).Validate())
+	assert.ErrorIs(t, (&QuietHours{Start: "8pm", End: "07:00"}).Validate(), ErrInvalidInput)
+	assert.ErrorIs(t, (&QuietHours{Start: "20:00", End: "25:00"}).Validate(), ErrInvalidInput)
+	assert.ErrorIs(t, (&QuietHours{Start: "20:00", End: "20:00"}).Validate(), ErrInvalidInput)
+	assert.ErrorIs(t, (&QuietHours{Start: "20:00", End: "07:00", Timezone: "Mars/Olympus"}).Validate(), ErrInvalidInput)
+}
+
+func TestEmailConfig_QuietHoursFor(t *testing.T) {
+	all := &QuietHours{Start: "20:00", End: "07:00"}
+	grant := &QuietHours{Start: "18:00", End: "09:00"}
+	cfg := &EmailConfig{QuietHours: map[string]*QuietHours{
+		QuietHoursAllGrants: all,
+		"grant-1":           grant,
+	}}
+
+	assert.Same(t, grant, cfg.QuietHoursFor("grant-1"))
+	assert.Same(t, all, cfg.QuietHoursFor("grant-2"))
+	assert.Nil(t, (&EmailConfig{}).QuietHoursFor("grant-1"))
+	assert.Nil(t, (*EmailConfig)(nil).QuietHoursFor("grant-1"))
+}
+
+func TestQuietHours_String(t *testing.T) {
+	assert.Equal(t, "20:00-07:00 local time", (&QuietHours{Start: "20:00", End: "07:00"}).String())
+	assert.Equal(t, "22:00-06:00 Europe/Berlin, weekends",
+		(&QuietHours{Start: "22:00", End: "06:00", Timezone: "Europe/Berlin", Weekends: true}).String())
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Recipient is an address in the local recipient index, which completes
+// --to, --cc and --bcc.
+type Recipient struct {
+	Email    string    `json:"email"`
+	Name     string    `json:"name,omitempty"`
+	Contact  bool      `json:"contact,omitempty"` // in the address book
+	Count    int       `json:"count"`             // times seen in recent mail or sent to
+	LastSeen time.Time `json:"last_seen,omitzero"`
+}
+
+// String returns the recipient as "Name <email>", or the bare address.
+func (r Recipient) String() string {
+	return Person{Name: r.Name, Email: r.Email}.String()
+}
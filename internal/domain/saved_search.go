@@ -0,0 +1,30 @@
+package domain
+
+import "regexp"
+
+// savedSearchNamePattern limits saved search names to what is easy to type.
+var savedSearchNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// SavedSearch is an `email search` kept in config under
+// email.saved_searches. After and Before hold either a date (YYYY-MM-DD) or
+// a duration such as "7d", measured back from the time the search runs.
+type SavedSearch struct {
+	Name          string `yaml:"-" json:"name,omitempty"`
+	Query         string `yaml:"query" json:"query"`
+	Native        bool   `yaml:"native,omitempty" json:"native,omitempty"` // Query uses the provider's search syntax
+	From          string `yaml:"from,omitempty" json:"from,omitempty"`
+	To            string `yaml:"to,omitempty" json:"to,omitempty"`
+	Subject       string `yaml:"subject,omitempty" json:"subject,omitempty"`
+	In            string `yaml:"in,omitempty" json:"in,omitempty"`
+	After         string `yaml:"after,omitempty" json:"after,omitempty"`
+	Before        string `yaml:"before,omitempty" json:"before,omitempty"`
+	HasAttachment *bool  `yaml:"has_attachment,omitempty" json:"has_attachment,omitempty"`
+	Unread        *bool  `yaml:"unread,omitempty" json:"unread,omitempty"`
+	Starred       *bool  `yaml:"starred,omitempty" json:"starred,omitempty"`
+	Limit         int    `yaml:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ValidSavedSearchName reports whether name can be used for a saved search.
+func ValidSavedSearchName(name string) bool {
+	return savedSearchNamePattern.MatchString(name)
+}
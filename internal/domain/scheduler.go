@@ -75,9 +75,18 @@ type EventBooking struct {
 	Conferencing    *ConferencingSettings `json:"conferencing,omitempty"`
 	DisableEmails   bool                  `json:"disable_emails,omitempty"`
 	ReminderMinutes []int                 `json:"reminder_minutes,omitempty"`
+	Reminders       []SchedulerReminder   `json:"reminders,omitempty"`
 	Metadata        map[string]string     `json:"metadata,omitempty"`
 }
 
+// SchedulerReminder represents a reminder sent before a booked event
+type SchedulerReminder struct {
+	Type               string `json:"type"` // "email", "webhook"
+	MinutesBeforeEvent int    `json:"minutes_before_event"`
+	Recipient          string `json:"recipient,omitempty"`     // "host", "guest", "all" (email only)
+	EmailSubject       string `json:"email_subject,omitempty"` // email only
+}
+
 // ConferencingSettings represents video conferencing settings
 type ConferencingSettings struct {
 	Provider   string               `json:"provider"` // "Google Meet", "Zoom", "Microsoft Teams"
@@ -213,3 +222,30 @@ type RescheduleBookingRequest struct {
 	StartTime int64 `json:"start_time"` // Unix timestamp for new start time
 	EndTime   int64 `json:"end_time"`   // Unix timestamp for new end time
 }
+
+// CreateBookingRequest is the Nylas v3 booking payload: the slot to book and
+// the guest booking it.
+type CreateBookingRequest struct {
+	StartTime        int64          `json:"start_time"` // Unix timestamp
+	EndTime          int64          `json:"end_time"`   // Unix timestamp
+	Guest            BookingGuest   `json:"guest"`
+	Timezone         string         `json:"timezone,omitempty"`
+	AdditionalFields map[string]any `json:"additional_fields,omitempty"`
+}
+
+// BookingGuest is the person a booking is made for.
+type BookingGuest struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email"`
+}
+
+// Validate checks the slot and guest before the request is sent.
+func (r *CreateBookingRequest) Validate() error {
+	if r.Guest.Email == "" {
+		return errors.New("guest email is required")
+	}
+	if r.StartTime <= 0 || r.EndTime <= r.StartTime {
+		return errors.New("end time must be after start time")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "strings"
+
+const (
+	secretRefPrefix = "${secret:"
+	secretRefSuffix = "}"
+)
+
+// SecretRef returns the config value that points at key in the secret store,
+// e.g. ${secret:ai.claude.api_key}. Config files hold the reference; the
+// secret itself stays in the OS keychain or the encrypted file store.
+func SecretRef(key string) string {
+	return secretRefPrefix + key + secretRefSuffix
+}
+
+// ParseSecretRef returns the secret store key a config value points at, and
+// false when the value is not a secret reference.
+func ParseSecretRef(value string) (string, bool) {
+	if !strings.HasPrefix(value, secretRefPrefix) || !strings.HasSuffix(value, secretRefSuffix) {
+		return "", false
+	}
+	key := value[len(secretRefPrefix) : len(value)-len(secretRefSuffix)]
+	return key, key != ""
+}
+
+// IsPlaintextSecret reports whether a config value holds a literal secret,
+// as opposed to being empty or a ${ENV_VAR} / ${secret:key} reference.
+func IsPlaintextSecret(value string) bool {
+	return value != "" && !(strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}"))
+}
+
+// SecretField is a config setting that may hold a secret.
+type SecretField struct {
+	Path  string  // Config path, e.g. ai.claude.api_key
+	Value *string // Points into the config, so callers can rewrite it
+}
+
+// SecretFields returns the AI settings that hold API keys. Providers missing
+// from the config are skipped.
+func (c *AIConfig) SecretFields() []SecretField {
+	if c == nil {
+		return nil
+	}
+	var fields []SecretField
+	if c.Claude != nil {
+		fields = append(fields, SecretField{Path: "ai.claude.api_key", Value: &c.Claude.APIKey})
+	}
+	if c.OpenAI != nil {
+		fields = append(fields, SecretField{Path: "ai.openai.api_key", Value: &c.OpenAI.APIKey})
+	}
+	if c.Groq != nil {
+		fields = append(fields, SecretField{Path: "ai.groq.api_key", Value: &c.Groq.APIKey})
+	}
+	if c.OpenRouter != nil {
+		fields = append(fields, SecretField{Path: "ai.openrouter.api_key", Value: &c.OpenRouter.APIKey})
+	}
+	if c.Translation != nil {
+		fields = append(fields, SecretField{Path: "ai.translation.api_key", Value: &c.Translation.APIKey})
+	}
+	return fields
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretRef(t *testing.T) {
+	ref := SecretRef("config.ai.claude.api_key")
+	assert.Equal(t, "${secret:config.ai.claude.api_key}", ref)
+
+	key, ok := ParseSecretRef(ref)
+	require.True(t, ok)
+	assert.Equal(t, "config.ai.claude.api_key", key)
+
+	for _, value := range []string{"", "sk-ant-123", "${ANTHROPIC_API_KEY}", "${secret:}"} {
+		_, ok := ParseSecretRef(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestIsPlaintextSecret(t *testing.T) {
+	assert.True(t, IsPlaintextSecret("sk-ant-123"))
+	assert.False(t, IsPlaintextSecret(""))
+	assert.False(t, IsPlaintextSecret("${ANTHROPIC_API_KEY}"))
+	assert.False(t, IsPlaintextSecret(SecretRef("x")))
+}
+
+func TestAIConfigSecretFields(t *testing.T) {
+	var nilCfg *AIConfig
+	assert.Empty(t, nilCfg.SecretFields())
+
+	cfg := &AIConfig{
+		Claude:      &ClaudeConfig{APIKey: "sk-ant-123"},
+		Translation: &TranslationConfig{APIKey: "lt-key"},
+	}
+	fields := cfg.SecretFields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "ai.claude.api_key", fields[0].Path)
+	assert.Equal(t, "ai.translation.api_key", fields[1].Path)
+
+	*fields[0].Value = SecretRef("k")
+	assert.Equal(t, "${secret:k}", cfg.Claude.APIKey)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultBestSendTime is the recipient-local time `email send
+// --send-at-best-time` targets when none is configured.
+const DefaultBestSendTime = "09:00"
+
+// NextSendTime returns the first weekday moment after t whose wall clock in
+// loc is clock ("HH:MM").
+func NextSendTime(t time.Time, loc *time.Location, clock string) (time.Time, error) {
+	minutes, err := parseClock(clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid send time %q (use HH:MM)", ErrInvalidInput, clock)
+	}
+
+	local := t.In(loc)
+	y, m, d := local.Date()
+	next := time.Date(y, m, d, minutes/60, minutes%60, 0, 0, loc)
+	for !next.After(t) || isWeekend(next.Weekday()) {
+		d++
+		next = time.Date(y, m, d, minutes/60, minutes%60, 0, 0, loc)
+	}
+	return next, nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextSendTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		// Wednesday 2026-03-11.
+		{"later today", time.Date(2026, 3, 11, 6, 0, 0, 0, berlin), time.Date(2026, 3, 11, 9, 0, 0, 0, berlin)},
+		{"already passed", time.Date(2026, 3, 11, 9, 0, 0, 0, berlin), time.Date(2026, 3, 12, 9, 0, 0, 0, berlin)},
+		{"friday evening skips the weekend", time.Date(2026, 3, 13, 18, 0, 0, 0, berlin), time.Date(2026, 3, 16, 9, 0, 0, 0, berlin)},
+		// 23:00 UTC on Wednesday is already Thursday 00:00 in Berlin.
+		{"evaluated in the recipient zone", time.Date(2026, 3, 11, 23, 0, 0, 0, time.UTC), time.Date(2026, 3, 12, 9, 0, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextSendTime(tt.now, berlin, "09:00")
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+
+	_, err = NextSendTime(time.Now(), berlin, "9am")
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ServiceStatus is a snapshot of the Nylas status page.
+type ServiceStatus struct {
+	Indicator   string            `json:"indicator"` // none, minor, major, critical
+	Description string            `json:"description"`
+	PageURL     string            `json:"page_url,omitempty"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Components  []StatusComponent `json:"components"`
+	Incidents   []StatusIncident  `json:"incidents,omitempty"`
+	Maintenance []StatusIncident  `json:"scheduled_maintenances,omitempty"`
+}
+
+// StatusComponent is one monitored part of the service, e.g. "Google" sync.
+type StatusComponent struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"` // operational, degraded_performance, partial_outage, major_outage, under_maintenance
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StatusIncident is an open incident or scheduled maintenance.
+type StatusIncident struct {
+	Name         string    `json:"name"`
+	Status       string    `json:"status"` // investigating, identified, monitoring, scheduled, in_progress
+	Impact       string    `json:"impact"` // none, minor, major, critical, maintenance
+	StartedAt    time.Time `json:"started_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Link         string    `json:"link,omitempty"`
+	Components   []string  `json:"components,omitempty"` // component IDs
+	LatestUpdate string    `json:"latest_update,omitempty"`
+}
+
+// IsOperational reports whether the component is fully operational.
+func (c StatusComponent) IsOperational() bool {
+	return c.Status == "" || c.Status == "operational"
+}
+
+// StatusLabel returns a human-readable component status.
+func (c StatusComponent) StatusLabel() string {
+	switch c.Status {
+	case "degraded_performance":
+		return "degraded"
+	case "partial_outage":
+		return "partial outage"
+	case "major_outage":
+		return "major outage"
+	case "under_maintenance":
+		return "maintenance"
+	case "", "operational":
+		return "operational"
+	default:
+		return strings.ReplaceAll(c.Status, "_", " ")
+	}
+}
+
+// Degraded returns the components that are not operational.
+func (s *ServiceStatus) Degraded() []StatusComponent {
+	var out []StatusComponent
+	for _, c := range s.Components {
+		if !c.IsOperational() {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// DegradedSince returns when the component's problem started: the earliest
+// open incident that lists it, or its last status change.
+func (s *ServiceStatus) DegradedSince(c StatusComponent) time.Time {
+	since := c.UpdatedAt
+	for _, inc := range s.Incidents {
+		for _, id := range inc.Components {
+			if id == c.ID && !inc.StartedAt.IsZero() && inc.StartedAt.Before(since) {
+				since = inc.StartedAt
+			}
+		}
+	}
+	return since
+}
+
+// OutageNotes returns one sentence per degraded component, e.g.
+// "Nylas reports degraded Google since 09:20 UTC". The date is included
+// when the problem started on an earlier day than now.
+func (s *ServiceStatus) OutageNotes(now time.Time) []string {
+	var notes []string
+	for _, c := range s.Degraded() {
+		note := fmt.Sprintf("Nylas reports %s %s", c.StatusLabel(), c.Name)
+		if since := s.DegradedSince(c).UTC(); !since.IsZero() {
+			note += " since " + since.Format("15:04 UTC")
+			if since.Format(time.DateOnly) != now.UTC().Format(time.DateOnly) {
+				note += " on " + since.Format("Jan 2")
+			}
+		}
+		notes = append(notes, note)
+	}
+	if len(notes) == 0 {
+		for _, inc := range s.Incidents {
+			notes = append(notes, fmt.Sprintf("Nylas reports an open incident: %s", inc.Name))
+		}
+	}
+	return notes
+}
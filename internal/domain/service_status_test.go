@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceStatus_OutageNotes(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		status ServiceStatus
+		want   []string
+	}{
+		{
+			name: "all operational",
+			status: ServiceStatus{Components: []StatusComponent{
+				{ID: "g", Name: "Google", Status: "operational"},
+			}},
+			want: nil,
+		},
+		{
+			name: "incident start wins over component update",
+			status: ServiceStatus{
+				Components: []StatusComponent{
+					{ID: "g", Name: "Google", Status: "degraded_performance", UpdatedAt: now.Add(-time.Hour)},
+				},
+				Incidents: []StatusIncident{
+					{Name: "Sync delays", Components: []string{"g"}, StartedAt: time.Date(2026, 10, 17, 9, 20, 0, 0, time.UTC)},
+				},
+			},
+			want: []string{"Nylas reports degraded Google since 09:20 UTC"},
+		},
+		{
+			name: "earlier day includes date",
+			status: ServiceStatus{Components: []StatusComponent{
+				{ID: "m", Name: "Microsoft", Status: "major_outage", UpdatedAt: time.Date(2026, 10, 16, 23, 5, 0, 0, time.UTC)},
+			}},
+			want: []string{"Nylas reports major outage Microsoft since 23:05 UTC on Oct 16"},
+		},
+		{
+			name: "incident without degraded component",
+			status: ServiceStatus{Incidents: []StatusIncident{
+				{Name: "Elevated API latency"},
+			}},
+			want: []string{"Nylas reports an open incident: Elevated API latency"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.status.OutageNotes(now))
+		})
+	}
+}
+
+func TestStatusComponent_StatusLabel(t *testing.T) {
+	assert.Equal(t, "operational", StatusComponent{}.StatusLabel())
+	assert.Equal(t, "partial outage", StatusComponent{Status: "partial_outage"}.StatusLabel())
+	assert.Equal(t, "maintenance", StatusComponent{Status: "under_maintenance"}.StatusLabel())
+}
@@ -0,0 +1,45 @@
+package domain
+
+// Translation backends.
+const (
+	// TranslationBackendLLM translates with the configured AI provider
+	// (Ollama for a local model, or a cloud provider).
+	TranslationBackendLLM = "llm"
+	// TranslationBackendLibreTranslate uses a LibreTranslate server, either
+	// self-hosted or a hosted API.
+	TranslationBackendLibreTranslate = "libretranslate"
+)
+
+// TranslationConfig configures message translation.
+type TranslationConfig struct {
+	Backend  string `yaml:"backend,omitempty"`  // llm (default) or libretranslate
+	Provider string `yaml:"provider,omitempty"` // AI provider for the llm backend (default: ai.default_provider)
+	URL      string `yaml:"url,omitempty"`      // LibreTranslate URL, e.g. http://localhost:5000
+	APIKey   string `yaml:"api_key,omitempty"`  // LibreTranslate API key; can use ${ENV_VAR}
+}
+
+// TranslationRequest is a request to translate plain text.
+type TranslationRequest struct {
+	Text           string `json:"text"`
+	TargetLanguage string `json:"target_language"`           // ISO 639-1 code
+	SourceLanguage string `json:"source_language,omitempty"` // ISO 639-1 code; empty to auto-detect
+}
+
+// TranslationResult is translated text with the detected source language.
+type TranslationResult struct {
+	Text           string `json:"text"`
+	SourceLanguage string `json:"source_language,omitempty"`
+	TargetLanguage string `json:"target_language"`
+	Backend        string `json:"backend"`
+}
+
+// MessageTranslation is a translated message subject and body.
+type MessageTranslation struct {
+	SourceLanguage string `json:"source_language,omitempty"`
+	TargetLanguage string `json:"target_language"`
+	Backend        string `json:"backend,omitempty"`
+	Subject        string `json:"subject,omitempty"`
+	Body           string `json:"body,omitempty"`
+	// Skipped is true when the message is already in the target language.
+	Skipped bool `json:"skipped,omitempty"`
+}
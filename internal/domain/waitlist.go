@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Waitlist entry states.
+const (
+	WaitlistWaiting = "waiting" // in line for a freed slot
+	WaitlistOffered = "offered" // emailed an offer that hasn't been accepted yet
+	WaitlistBooked  = "booked"  // accepted an offer and holds a booking
+)
+
+// WaitlistEntry is someone waiting for a slot on a scheduler configuration.
+// A zero Start means any slot on the configuration will do.
+type WaitlistEntry struct {
+	ID              string         `json:"id"`
+	ConfigurationID string         `json:"configuration_id"`
+	Email           string         `json:"email"`
+	Name            string         `json:"name,omitempty"`
+	Start           time.Time      `json:"start,omitzero"`
+	End             time.Time      `json:"end,omitzero"`
+	Status          string         `json:"status"`
+	Source          string         `json:"source,omitempty"` // "webhook" or "manual"
+	CreatedAt       time.Time      `json:"created_at"`
+	Offer           *WaitlistOffer `json:"offer,omitempty"`
+	BookingID       string         `json:"booking_id,omitempty"`
+	// Passed lists the start times of slots this entry was offered and let
+	// expire, so the same slot isn't offered to them again.
+	Passed []time.Time `json:"passed,omitempty"`
+}
+
+// WaitlistOffer is a freed slot offered to an entry.
+type WaitlistOffer struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	OfferedAt time.Time `json:"offered_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Wants reports whether the entry would take the slot: it's waiting, the
+// slot overlaps the one it asked for (or it asked for any slot), and it
+// hasn't already let this slot's offer expire.
+func (e *WaitlistEntry) Wants(configurationID string, start, end time.Time) bool {
+	if e.Status != WaitlistWaiting || e.ConfigurationID != configurationID {
+		return false
+	}
+	for _, p := range e.Passed {
+		if p.Equal(start) {
+			return false
+		}
+	}
+	if e.Start.IsZero() {
+		return true
+	}
+	return e.Start.Before(end) && start.Before(e.End)
+}
+
+// OfferExpired reports whether the entry holds an offer that lapsed at now.
+func (e *WaitlistEntry) OfferExpired(now time.Time) bool {
+	return e.Status == WaitlistOffered && e.Offer != nil && !now.Before(e.Offer.ExpiresAt)
+}
+
+// NextInLine returns the index of the earliest-queued entry that wants the
+// slot, or -1 if nobody does.
+func NextInLine(entries []WaitlistEntry, configurationID string, start, end time.Time) int {
+	order := make([]int, 0, len(entries))
+	for i := range entries {
+		if entries[i].Wants(configurationID, start, end) {
+			order = append(order, i)
+		}
+	}
+	if len(order) == 0 {
+		return -1
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return entries[order[a]].CreatedAt.Before(entries[order[b]].CreatedAt)
+	})
+	return order[0]
+}
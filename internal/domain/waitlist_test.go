@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitlistEntry_Wants(t *testing.T) {
+	slot := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	end := slot.Add(30 * time.Minute)
+
+	tests := []struct {
+		name  string
+		entry WaitlistEntry
+		want  bool
+	}{
+		{"any slot", WaitlistEntry{ConfigurationID: "cfg", Status: WaitlistWaiting}, true},
+		{"overlapping slot", WaitlistEntry{ConfigurationID: "cfg", Status: WaitlistWaiting, Start: slot.Add(15 * time.Minute), End: end.Add(time.Hour)}, true},
+		{"adjacent slot", WaitlistEntry{ConfigurationID: "cfg", Status: WaitlistWaiting, Start: end, End: end.Add(time.Hour)}, false},
+		{"other configuration", WaitlistEntry{ConfigurationID: "other", Status: WaitlistWaiting}, false},
+		{"already offered", WaitlistEntry{ConfigurationID: "cfg", Status: WaitlistOffered}, false},
+		{"passed on this slot", WaitlistEntry{ConfigurationID: "cfg", Status: WaitlistWaiting, Passed: []time.Time{slot}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.Wants("cfg", slot, end))
+		})
+	}
+}
+
+func TestNextInLine(t *testing.T) {
+	slot := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	base := slot.Add(-48 * time.Hour)
+	entries := []WaitlistEntry{
+		{ID: "late", ConfigurationID: "cfg", Status: WaitlistWaiting, CreatedAt: base.Add(2 * time.Hour)},
+		{ID: "booked", ConfigurationID: "cfg", Status: WaitlistBooked, CreatedAt: base},
+		{ID: "early", ConfigurationID: "cfg", Status: WaitlistWaiting, CreatedAt: base.Add(time.Hour)},
+	}
+
+	assert.Equal(t, 2, NextInLine(entries, "cfg", slot, slot.Add(time.Hour)))
+	assert.Equal(t, -1, NextInLine(entries, "other", slot, slot.Add(time.Hour)))
+}
+
+func TestWaitlistEntry_OfferExpired(t *testing.T) {
+	now := time.Now()
+	e := WaitlistEntry{Status: WaitlistOffered, Offer: &WaitlistOffer{ExpiresAt: now}}
+	assert.True(t, e.OfferExpired(now))
+	assert.False(t, e.OfferExpired(now.Add(-time.Second)))
+	e.Status = WaitlistBooked
+	assert.False(t, e.OfferExpired(now))
+}
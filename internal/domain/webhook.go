@@ -99,6 +99,13 @@ const (
 
 	// Notetaker triggers
 	TriggerNotetakerMedia = "notetaker.media"
+
+	// Scheduler booking triggers
+	TriggerBookingCreated     = "booking.created"
+	TriggerBookingPending     = "booking.pending"
+	TriggerBookingRescheduled = "booking.rescheduled"
+	TriggerBookingCancelled   = "booking.cancelled"
+	TriggerBookingReminder    = "booking.reminder"
 )
 
 // AllTriggerTypes returns all available trigger types.
@@ -139,6 +146,12 @@ func AllTriggerTypes() []string {
 		TriggerFolderDeleted,
 		// Notetaker
 		TriggerNotetakerMedia,
+		// Booking
+		TriggerBookingCreated,
+		TriggerBookingPending,
+		TriggerBookingRescheduled,
+		TriggerBookingCancelled,
+		TriggerBookingReminder,
 	}
 }
 
@@ -188,5 +201,12 @@ func TriggerTypeCategories() map[string][]string {
 		"notetaker": {
 			TriggerNotetakerMedia,
 		},
+		"booking": {
+			TriggerBookingCreated,
+			TriggerBookingPending,
+			TriggerBookingRescheduled,
+			TriggerBookingCancelled,
+			TriggerBookingReminder,
+		},
 	}
 }
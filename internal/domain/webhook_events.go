@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// WebhookEventRecord is a webhook delivery kept in the local event store.
+type WebhookEventRecord struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	GrantID    string            `json:"grant_id,omitempty"`
+	Source     string            `json:"source,omitempty"`
+	Verified   bool              `json:"verified"`
+	ReceivedAt time.Time         `json:"received_at"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Payload    json.RawMessage   `json:"payload"`
+}
+
+// WebhookEventQuery filters stored webhook events. Zero values match all.
+type WebhookEventQuery struct {
+	Triggers []string // Trigger types or "<prefix>.*" wildcards
+	GrantID  string
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+}
+
+// Matches reports whether r passes the query's filters.
+func (q *WebhookEventQuery) Matches(r *WebhookEventRecord) bool {
+	if !q.Since.IsZero() && r.ReceivedAt.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && r.ReceivedAt.After(q.Until) {
+		return false
+	}
+	if q.GrantID != "" && r.GrantID != q.GrantID {
+		return false
+	}
+	return MatchesTrigger(q.Triggers, r.Type)
+}
+
+// MatchesTrigger reports whether eventType is one of triggers, which may
+// use "<prefix>.*" wildcards. An empty list matches every type.
+func MatchesTrigger(triggers []string, eventType string) bool {
+	if len(triggers) == 0 {
+		return true
+	}
+	for _, trigger := range triggers {
+		if prefix, ok := strings.CutSuffix(trigger, ".*"); ok {
+			if strings.HasPrefix(eventType, prefix+".") {
+				return true
+			}
+		} else if trigger == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookTimeFields are converted from RFC 3339 to Unix seconds so objects
+// match the webhook wire format.
+var webhookTimeFields = []string{"date", "created_at", "updated_at"}
+
+// WebhookObject converts an API object to its webhook representation: Unix
+// timestamps, no zero times, and the "object" and "grant_id" fields set.
+// The object type is taken from trigger when the object has none.
+func WebhookObject(trigger, grantID string, object any) (map[string]any, error) {
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	for _, field := range webhookTimeFields {
+		s, ok := data[field].(string)
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil || t.IsZero() {
+			delete(data, field)
+			continue
+		}
+		data[field] = t.Unix()
+	}
+
+	if obj, _ := data["object"].(string); obj == "" {
+		data["object"], _, _ = strings.Cut(trigger, ".")
+	}
+	if id, _ := data["grant_id"].(string); id == "" {
+		data["grant_id"] = grantID
+	}
+	return data, nil
+}
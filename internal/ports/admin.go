@@ -80,6 +80,20 @@ type AdminClient interface {
 	// (POST /v3/workspaces/{id}/manual-assign).
 	AssignWorkspaceGrants(ctx context.Context, workspaceID string, req *domain.WorkspaceAssignRequest) (*domain.WorkspaceAssignResult, error)
 
+	// ================================
+	// API KEY OPERATIONS
+	// ================================
+
+	// ListAPIKeys retrieves the API keys of an application.
+	ListAPIKeys(ctx context.Context, appID string) ([]domain.APIKey, error)
+
+	// CreateAPIKey creates an API key. The result carries the key value,
+	// which the API never returns again.
+	CreateAPIKey(ctx context.Context, appID string, req *domain.CreateAPIKeyRequest) (*domain.CreatedAPIKey, error)
+
+	// RevokeAPIKey revokes an API key.
+	RevokeAPIKey(ctx context.Context, appID, keyID string) error
+
 	// ================================
 	// CREDENTIAL OPERATIONS
 	// ================================
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// AttachmentUploader stores a file and returns a link to it, so large
+// attachments can be sent as links instead of message parts.
+type AttachmentUploader interface {
+	// Upload stores content under filename and returns its download URL.
+	Upload(ctx context.Context, filename, contentType string, content []byte) (string, error)
+}
@@ -11,6 +11,10 @@ type AuthClient interface {
 	// BuildAuthURL builds an OAuth authorization URL for a provider.
 	BuildAuthURL(provider domain.Provider, redirectURI, state, codeChallenge string) string
 
+	// BuildAuthURLWithOptions builds an OAuth authorization URL that requests
+	// specific scopes or suggests the account to sign in with.
+	BuildAuthURLWithOptions(provider domain.Provider, redirectURI, state, codeChallenge string, opts domain.AuthURLOptions) string
+
 	// ExchangeCode exchanges an authorization code for a grant.
 	ExchangeCode(ctx context.Context, code, redirectURI, codeVerifier string) (*domain.Grant, error)
 
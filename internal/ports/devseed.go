@@ -0,0 +1,15 @@
+package ports
+
+import "github.com/nylas/cli/internal/domain"
+
+// SeedStore remembers the objects `dev seed` created.
+type SeedStore interface {
+	// List returns the items seeded into grantID.
+	List(grantID string) ([]domain.SeededItem, error)
+
+	// Save adds items, ignoring any already recorded.
+	Save(items ...domain.SeededItem) error
+
+	// Delete removes the items of grantID with the given IDs.
+	Delete(grantID string, ids ...string) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import "context"
+
+// EventSink forwards received webhook events to an external message queue.
+type EventSink interface {
+	// Name returns a display name for the sink, without credentials.
+	Name() string
+
+	// Publish delivers the event's original payload.
+	Publish(ctx context.Context, event *WebhookEvent) error
+
+	// Close releases any open connection.
+	Close() error
+}
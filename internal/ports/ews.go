@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// EWSChecker checks that an Exchange Web Services endpoint is reachable and,
+// where the server allows it, that credentials are accepted.
+type EWSChecker interface {
+	Check(ctx context.Context, opts domain.EWSCheckOptions) (*domain.EWSCheckResult, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "github.com/nylas/cli/internal/domain"
+
+// InvitationStore persists bulk hosted-auth invitations.
+type InvitationStore interface {
+	// List returns every invitation in the order they were first sent.
+	List() ([]domain.Invitation, error)
+
+	// Save adds invitations, replacing any existing invitation with the same
+	// email (case-insensitive).
+	Save(invitations ...domain.Invitation) error
+}
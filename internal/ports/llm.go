@@ -38,3 +38,12 @@ type LLMRouter interface {
 	// ListProviders returns available provider names
 	ListProviders() []string
 }
+
+// Translator translates text and detects its source language.
+type Translator interface {
+	// Translate translates req.Text into req.TargetLanguage
+	Translate(ctx context.Context, req *domain.TranslationRequest) (*domain.TranslationResult, error)
+
+	// Name returns the backend name
+	Name() string
+}
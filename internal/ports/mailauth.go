@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// MailAuthTester performs SASL authentication (bearer token or password)
+// against IMAP and SMTP servers and records the protocol exchange for debugging.
+type MailAuthTester interface {
+	// TestIMAP authenticates to an IMAP server and logs out.
+	TestIMAP(ctx context.Context, opts domain.MailAuthOptions) (*domain.MailAuthResult, error)
+
+	// TestSMTP authenticates to an SMTP server and quits.
+	TestSMTP(ctx context.Context, opts domain.MailAuthOptions) (*domain.MailAuthResult, error)
+}
+
+// MailboxWriter stores messages in an account over an authenticated IMAP
+// session. The Nylas API has no endpoint to insert existing messages, so
+// migrations write through IMAP.
+type MailboxWriter interface {
+	// Mailboxes lists the account's mailboxes.
+	Mailboxes(ctx context.Context) ([]domain.IMAPMailbox, error)
+
+	// CreateMailbox creates a mailbox. Creating one that exists is not an error.
+	CreateMailbox(ctx context.Context, name string) error
+
+	// Append stores a raw RFC 822 message in mailbox.
+	Append(ctx context.Context, mailbox string, msg domain.AppendMessage) error
+
+	// Close logs out and closes the connection.
+	Close() error
+}
+
+// MailConfigDiscoverer finds the IMAP and SMTP servers for an email address.
+type MailConfigDiscoverer interface {
+	// Discover looks up settings through autoconfig and autodiscover, then
+	// falls back to probing common host names.
+	Discover(ctx context.Context, email string) (*domain.MailServerConfig, error)
+}
@@ -29,4 +29,19 @@ type NotetakerClient interface {
 
 	// GetNotetakerMedia retrieves media data for a notetaker.
 	GetNotetakerMedia(ctx context.Context, grantID, notetakerID string) (*domain.MediaData, error)
+
+	// GetNotetakerTranscript downloads and parses a notetaker's transcript.
+	GetNotetakerTranscript(ctx context.Context, grantID, notetakerID string) (*domain.NotetakerTranscript, error)
+}
+
+// AutoNotetakerStore remembers the notetakers `notetaker sync` created.
+type AutoNotetakerStore interface {
+	// List returns the records for grantID.
+	List(grantID string) ([]domain.AutoNotetaker, error)
+
+	// Save adds records, replacing any with the same notetaker ID.
+	Save(records ...domain.AutoNotetaker) error
+
+	// Delete removes the records with the given notetaker IDs.
+	Delete(notetakerIDs ...string) error
 }
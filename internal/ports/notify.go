@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Notifier delivers notifications to the user.
+type Notifier interface {
+	// Notify sends n.
+	Notify(ctx context.Context, n domain.Notification) error
+}
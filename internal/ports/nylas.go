@@ -50,6 +50,15 @@ type OAuthServer interface {
 	GetRedirectURI() string
 }
 
+// OAuthClientValidator tests provider OAuth client credentials.
+type OAuthClientValidator interface {
+	// ValidateClient makes a token exchange with a dummy authorization code.
+	// The provider checks the client before the code, so an error wrapping
+	// domain.ErrOAuthClientRejected means the client ID, secret or redirect
+	// URI is wrong. Other errors mean the check couldn't be made.
+	ValidateClient(ctx context.Context, check domain.OAuthClientCheck) error
+}
+
 // Browser defines the interface for opening URLs in the browser.
 type Browser interface {
 	// Open opens a URL in the default browser.
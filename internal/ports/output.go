@@ -11,6 +11,9 @@ const (
 	FormatJSON  OutputFormat = "json"
 	FormatYAML  OutputFormat = "yaml"
 	FormatQuiet OutputFormat = "quiet"
+	FormatCSV   OutputFormat = "csv"
+	// FormatTemplate renders each item with a Go template (OutputOptions.Template).
+	FormatTemplate OutputFormat = "template"
 )
 
 // OutputWriter handles formatted output for CLI commands.
@@ -46,6 +49,9 @@ type OutputOptions struct {
 	// NoColor disables colored output
 	NoColor bool
 
+	// Template is the Go template text for FormatTemplate
+	Template string
+
 	// Writer is the destination for output
 	Writer io.Writer
 }
@@ -55,3 +61,10 @@ type QuietFielder interface {
 	// QuietField returns the field value to output in quiet mode (usually ID)
 	QuietField() string
 }
+
+// StreamWriter writes a list one item at a time, for listings too large to
+// buffer. Close ends the list.
+type StreamWriter interface {
+	WriteItem(item any) error
+	Close() error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "github.com/nylas/cli/internal/domain"
+
+// ResponseCache stores API responses for read-only endpoints, grouped by
+// resource (folders, calendars, contacts, grants).
+type ResponseCache interface {
+	// Get returns the cached response for key, or nil if there is none.
+	Get(resource, key string) (*domain.CachedResponse, error)
+
+	// Put stores a response under key.
+	Put(resource, key string, r *domain.CachedResponse) error
+
+	// Invalidate drops every cached response for resource.
+	Invalidate(resource string) error
+}
@@ -48,6 +48,9 @@ type SchedulerClient interface {
 	// the configuration ID (booking endpoints reject the application API key), so
 	// each takes the booking's configurationID.
 
+	// CreateBooking books a slot on a configuration for a guest.
+	CreateBooking(ctx context.Context, configurationID string, req *domain.CreateBookingRequest) (*domain.Booking, error)
+
 	// GetBooking retrieves a specific booking.
 	GetBooking(ctx context.Context, configurationID, bookingID string) (*domain.Booking, error)
 
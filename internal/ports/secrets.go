@@ -26,6 +26,10 @@ const (
 	KeyAPIKey       = "api_key"
 	KeyOrgID        = "org_id"
 
+	// KeyAPIKeyID is the ID of the stored API key, when the CLI created it,
+	// so `admin api-keys rotate` knows which key to revoke.
+	KeyAPIKeyID = "api_key_id"
+
 	// Dashboard auth keys
 	KeyDashboardUserToken    = "dashboard_user_token"
 	KeyDashboardOrgToken     = "dashboard_org_token"
@@ -34,4 +38,34 @@ const (
 	KeyDashboardDPoPKey      = "dashboard_dpop_key"
 	KeyDashboardAppID        = "dashboard_app_id"
 	KeyDashboardAppRegion    = "dashboard_app_region"
+
+	// KeySMTPSecret is the SMTP relay password, or OAuth access token for
+	// XOAUTH2.
+	KeySMTPSecret = "smtp_secret"
+
+	// KeyGrantTokenSigningKey signs the grant tokens issued by
+	// `auth token issue`.
+	KeyGrantTokenSigningKey = "grant_token_signing_key"
+
+	// KeyNotifySlackWebhook is the Slack incoming webhook URL notifications
+	// are posted to.
+	KeyNotifySlackWebhook = "notify_slack_webhook"
 )
+
+// AuditSinkSecretKey returns the secret store key holding the bearer token
+// for the named audit sink.
+func AuditSinkSecretKey(name string) string {
+	return "audit_sink." + name + ".token"
+}
+
+// EnvironmentSecretKey returns the secret store key holding key (e.g.
+// KeyAPIKey) for the named environment profile.
+func EnvironmentSecretKey(env, key string) string {
+	return "env." + env + "." + key
+}
+
+// ConfigSecretKey returns the secret store key holding a secret moved out of
+// config.yaml, identified by its config path (e.g. ai.claude.api_key).
+func ConfigSecretKey(path string) string {
+	return "config." + path
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+// MailTransport delivers RFC 5322 messages outside the Nylas API, e.g. via
+// an SMTP relay.
+type MailTransport interface {
+	// Send delivers raw to recipients with the given envelope sender.
+	Send(ctx context.Context, from string, recipients []string, raw []byte) error
+
+	// Verify connects and authenticates without sending anything.
+	Verify(ctx context.Context) error
+
+	// Name describes the transport, e.g. "smtp.example.com:587".
+	Name() string
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// StatusPage reads the public Nylas service status.
+type StatusPage interface {
+	// Summary returns the current component statuses and open incidents.
+	Summary(ctx context.Context) (*domain.ServiceStatus, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import "github.com/nylas/cli/internal/domain"
+
+// WaitlistStore persists scheduler waitlist entries.
+type WaitlistStore interface {
+	// List returns every entry in the order they were queued.
+	List() ([]domain.WaitlistEntry, error)
+
+	// Add queues an entry, assigning its ID, and returns it.
+	Add(entry domain.WaitlistEntry) (domain.WaitlistEntry, error)
+
+	// Update applies fn to all entries under the store lock and saves the
+	// result. fn may change entries in place but not add or remove them.
+	Update(fn func(entries []domain.WaitlistEntry) error) error
+
+	// Remove deletes an entry. Returns domain.ErrWaitlistEntryNotFound if it
+	// doesn't exist.
+	Remove(id string) error
+
+	// SigningKey returns the key used to sign offer accept links, creating
+	// it on first use.
+	SigningKey() ([]byte, error)
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// WebhookEventStore keeps received webhook events so past deliveries can be
+// inspected after the receiver has stopped.
+type WebhookEventStore interface {
+	// Append stores an event.
+	Append(record *domain.WebhookEventRecord) error
+
+	// Query returns matching events, newest first.
+	Query(ctx context.Context, query *domain.WebhookEventQuery) ([]domain.WebhookEventRecord, error)
+
+	// Get returns the most recent event with the given ID.
+	Get(ctx context.Context, id string) (*domain.WebhookEventRecord, error)
+
+	// Prune removes events received before cutoff and returns how many
+	// files were removed.
+	Prune(cutoff time.Time) (int, error)
+
+	// Clear removes all stored events.
+	Clear() error
+
+	// Path returns where events are stored.
+	Path() string
+}
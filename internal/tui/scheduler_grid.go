@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/nylas/cli/internal/domain"
+)
+
+// Grid geometry: seven days (0=Sunday, matching OpenHours.Days) by 48
+// half-hour slots.
+const (
+	gridDays        = 7
+	gridSlotMinutes = 30
+	gridSlots       = 24 * 60 / gridSlotMinutes
+)
+
+// AvailabilityGrid is an editable weekly view of scheduler open hours plus the
+// duration and buffer rules that apply to every booking.
+type AvailabilityGrid struct {
+	Cells           [gridDays][gridSlots]bool
+	DurationMinutes int
+	BufferBefore    int
+	BufferAfter     int
+
+	// The timezone and excluded dates of the entries on the grid, given to
+	// the entries of edited days.
+	Timezone string
+	ExDates  []string
+
+	// Kept holds the open hours the grid can't show exactly: times off the
+	// half-hour, empty or inverted windows, unknown days, and entries with
+	// another timezone or other excluded dates. They are written back
+	// unchanged.
+	Kept []domain.OpenHours
+
+	given   []domain.OpenHours
+	onGrid  []bool // Whether given[i] is drawn on the grid
+	initial [gridDays][gridSlots]bool
+}
+
+// NewAvailabilityGrid builds a grid from existing open hours and rules. The
+// first entry that fits the grid sets its timezone and excluded dates;
+// entries that don't fit go to Kept.
+func NewAvailabilityGrid(hours []domain.OpenHours, rules domain.AvailabilityRules) *AvailabilityGrid {
+	g := &AvailabilityGrid{DurationMinutes: rules.DurationMinutes}
+	if rules.Buffer != nil {
+		g.BufferBefore = rules.Buffer.Before
+		g.BufferAfter = rules.Buffer.After
+	}
+
+	g.given = hours
+	g.onGrid = make([]bool, len(hours))
+	seen := false
+	for i, oh := range hours {
+		first, last, ok := gridWindow(oh)
+		if ok && seen {
+			ok = oh.Timezone == g.Timezone && slices.Equal(oh.ExDates, g.ExDates)
+		}
+		if !ok {
+			g.Kept = append(g.Kept, oh)
+			continue
+		}
+		if !seen {
+			g.Timezone, g.ExDates, seen = oh.Timezone, oh.ExDates, true
+		}
+		g.onGrid[i] = true
+		for _, day := range oh.Days {
+			for slot := first; slot < last; slot++ {
+				g.Cells[day][slot] = true
+			}
+		}
+	}
+
+	g.initial = g.Cells
+	return g
+}
+
+// gridWindow returns the slots of oh, and false when the grid can't show it
+// exactly.
+func gridWindow(oh domain.OpenHours) (first, last int, ok bool) {
+	start, okStart := parseClock(oh.Start)
+	end, okEnd := parseClock(oh.End)
+	if end == 24*60-1 {
+		end = 24 * 60 // 23:59 is how the API spells end of day
+	}
+	if !okStart || !okEnd || end <= start || start%gridSlotMinutes != 0 || end%gridSlotMinutes != 0 {
+		return 0, 0, false
+	}
+	if len(oh.Days) == 0 || slices.ContainsFunc(oh.Days, func(day int) bool { return day < 0 || day >= gridDays }) {
+		return 0, 0, false
+	}
+	return start / gridSlotMinutes, end / gridSlotMinutes, true
+}
+
+// Toggle flips a single cell.
+func (g *AvailabilityGrid) Toggle(day, slot int) {
+	if day < 0 || day >= gridDays || slot < 0 || slot >= gridSlots {
+		return
+	}
+	g.Cells[day][slot] = !g.Cells[day][slot]
+}
+
+// AdjustDuration changes the meeting duration by delta minutes, never going
+// below 5 minutes. Durations aren't tied to the 30-minute grid slots.
+func (g *AvailabilityGrid) AdjustDuration(delta int) {
+	g.DurationMinutes = max(g.DurationMinutes+delta, 5)
+}
+
+// AdjustBuffers changes the before/after buffers, clamping at zero.
+func (g *AvailabilityGrid) AdjustBuffers(beforeDelta, afterDelta int) {
+	g.BufferBefore = max(g.BufferBefore+beforeDelta, 0)
+	g.BufferAfter = max(g.BufferAfter+afterDelta, 0)
+}
+
+// OpenHours converts the grid back to API open hours. Entries are returned
+// as given, in order, except that days the user edited are taken out of them
+// and rebuilt from the grid at the end. Kept entries are never changed.
+func (g *AvailabilityGrid) OpenHours() []domain.OpenHours {
+	var edited []int
+	for day := range gridDays {
+		if g.Cells[day] != g.initial[day] {
+			edited = append(edited, day)
+		}
+	}
+
+	hours := make([]domain.OpenHours, 0, len(g.given))
+	for i, oh := range g.given {
+		if g.onGrid[i] && len(edited) > 0 {
+			oh.Days = slices.DeleteFunc(slices.Clone(oh.Days), func(day int) bool {
+				return slices.Contains(edited, day)
+			})
+			if len(oh.Days) == 0 {
+				continue
+			}
+		}
+		hours = append(hours, oh)
+	}
+	return append(hours, g.dayHours(edited)...)
+}
+
+// dayHours converts the cells of days to open hours. Days that share the
+// same set of windows are grouped into a single entry, so a plain Mon-Fri
+// 9-5 edit becomes one OpenHours value.
+func (g *AvailabilityGrid) dayHours(days []int) []domain.OpenHours {
+	type window struct{ start, end int }
+
+	byWindow := make(map[window][]int)
+	for _, day := range days {
+		slot := 0
+		for slot < gridSlots {
+			if !g.Cells[day][slot] {
+				slot++
+				continue
+			}
+			start := slot
+			for slot < gridSlots && g.Cells[day][slot] {
+				slot++
+			}
+			w := window{start * gridSlotMinutes, slot * gridSlotMinutes}
+			byWindow[w] = append(byWindow[w], day)
+		}
+	}
+
+	windows := make([]window, 0, len(byWindow))
+	for w := range byWindow {
+		windows = append(windows, w)
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		di, dj := byWindow[windows[i]][0], byWindow[windows[j]][0]
+		if di != dj {
+			return di < dj
+		}
+		return windows[i].start < windows[j].start
+	})
+
+	hours := make([]domain.OpenHours, 0, len(windows))
+	for _, w := range windows {
+		hours = append(hours, domain.OpenHours{
+			Days:     slices.Clone(byWindow[w]),
+			Start:    formatClock(w.start),
+			End:      formatEndClock(w.end),
+			Timezone: g.Timezone,
+			ExDates:  g.ExDates,
+		})
+	}
+	return hours
+}
+
+// Rules applies the grid's duration and buffers on top of base, keeping any
+// other availability settings intact.
+func (g *AvailabilityGrid) Rules(base domain.AvailabilityRules) domain.AvailabilityRules {
+	base.DurationMinutes = g.DurationMinutes
+	if g.BufferBefore > 0 || g.BufferAfter > 0 {
+		base.Buffer = &domain.AvailabilityBuffer{Before: g.BufferBefore, After: g.BufferAfter}
+	} else {
+		base.Buffer = nil
+	}
+	return base
+}
+
+// parseClock parses "HH:MM" into minutes since midnight. "24:00" is accepted
+// as end of day.
+func parseClock(s string) (int, bool) {
+	var h, m int
+	if _, err := fmt.Sscanf(strings.TrimSpace(s), "%d:%d", &h, &m); err != nil {
+		return 0, false
+	}
+	if h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// formatEndClock renders a window end, using 23:59 for midnight since the API
+// expects end times within the same day.
+func formatEndClock(minutes int) string {
+	if minutes >= 24*60 {
+		return "23:59"
+	}
+	return formatClock(minutes)
+}
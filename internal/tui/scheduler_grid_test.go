@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/nylas/cli/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailabilityGrid_RoundTripsWeekdayHours(t *testing.T) {
+	hours := []domain.OpenHours{{
+		Days:     []int{1, 2, 3, 4, 5},
+		Start:    "09:00",
+		End:      "17:00",
+		Timezone: "America/New_York",
+		ExDates:  []string{"2025-12-25"},
+	}}
+
+	grid := NewAvailabilityGrid(hours, domain.AvailabilityRules{DurationMinutes: 30})
+
+	assert.Equal(t, hours, grid.OpenHours())
+}
+
+func TestAvailabilityGrid_SplitsDifferingDays(t *testing.T) {
+	grid := NewAvailabilityGrid([]domain.OpenHours{
+		{Days: []int{1, 2}, Start: "09:00", End: "12:00"},
+	}, domain.AvailabilityRules{})
+
+	// Close Tuesday 11:00-11:30 so Monday and Tuesday no longer match.
+	grid.Toggle(2, 22)
+
+	got := grid.OpenHours()
+	require.Len(t, got, 3)
+	assert.Equal(t, domain.OpenHours{Days: []int{1}, Start: "09:00", End: "12:00"}, got[0])
+	assert.Equal(t, domain.OpenHours{Days: []int{2}, Start: "09:00", End: "11:00"}, got[1])
+	assert.Equal(t, domain.OpenHours{Days: []int{2}, Start: "11:30", End: "12:00"}, got[2])
+}
+
+func TestAvailabilityGrid_EndOfDay(t *testing.T) {
+	grid := NewAvailabilityGrid([]domain.OpenHours{
+		{Days: []int{6}, Start: "22:00", End: "23:59"},
+	}, domain.AvailabilityRules{})
+
+	assert.True(t, grid.Cells[6][gridSlots-1])
+	grid.Toggle(6, 44) // Saturday 22:00-22:30
+	assert.Equal(t, []domain.OpenHours{{Days: []int{6}, Start: "22:30", End: "23:59"}}, grid.OpenHours())
+}
+
+// Saving without an edit must not rewrite anything, including entries the
+// grid can't show exactly.
+func TestAvailabilityGrid_UneditedSaveKeepsEveryEntry(t *testing.T) {
+	hours := []domain.OpenHours{
+		{Days: []int{1, 2, 3, 4, 5}, Start: "09:00", End: "12:00", Timezone: "America/New_York", ExDates: []string{"2025-12-25"}},
+		{Days: []int{1}, Start: "13:15", End: "17:45", Timezone: "America/New_York"}, // off the half-hour
+		{Days: []int{2}, Start: "14:00", End: "16:00", Timezone: "Europe/London"},    // another timezone
+		{Days: []int{3}, Start: "18:00", End: "10:00", Timezone: "America/New_York"}, // inverted
+		{Days: []int{4}, Start: "13:00", End: "17:00", Timezone: "America/New_York", // other excluded dates
+			ExDates: []string{"2025-11-27"}},
+	}
+
+	grid := NewAvailabilityGrid(hours, domain.AvailabilityRules{})
+
+	assert.Equal(t, "America/New_York", grid.Timezone)
+	assert.Equal(t, hours[1:], grid.Kept)
+	assert.False(t, grid.Cells[1][27], "kept entries are not drawn")
+	assert.Equal(t, hours, grid.OpenHours())
+}
+
+func TestAvailabilityGrid_EditRewritesOnlyEditedDays(t *testing.T) {
+	hours := []domain.OpenHours{
+		{Days: []int{1, 2, 3}, Start: "09:00", End: "12:00", Timezone: "America/New_York", ExDates: []string{"2025-12-25"}},
+		{Days: []int{2}, Start: "13:15", End: "17:45", Timezone: "America/New_York"},
+	}
+
+	grid := NewAvailabilityGrid(hours, domain.AvailabilityRules{})
+	grid.Toggle(2, 24) // Tuesday 12:00-12:30
+
+	assert.Equal(t, []domain.OpenHours{
+		{Days: []int{1, 3}, Start: "09:00", End: "12:00", Timezone: "America/New_York", ExDates: []string{"2025-12-25"}},
+		hours[1],
+		{Days: []int{2}, Start: "09:00", End: "12:30", Timezone: "America/New_York", ExDates: []string{"2025-12-25"}},
+	}, grid.OpenHours())
+	assert.Equal(t, []int{1, 2, 3}, hours[0].Days, "the given hours must not be mutated")
+
+	grid.Toggle(2, 24)
+	assert.Equal(t, hours, grid.OpenHours(), "toggling back is not an edit")
+}
+
+func TestAvailabilityGrid_RulesKeepOtherSettings(t *testing.T) {
+	base := domain.AvailabilityRules{DurationMinutes: 30, RoundTo: 15, Buffer: &domain.AvailabilityBuffer{Before: 5}}
+	grid := NewAvailabilityGrid(nil, base)
+
+	grid.AdjustBuffers(-10, 10)
+	grid.AdjustDuration(-100)
+
+	rules := grid.Rules(base)
+	assert.Equal(t, 5, rules.DurationMinutes, "duration is clamped to a minimum")
+	assert.Equal(t, 15, rules.RoundTo)
+	require.NotNil(t, rules.Buffer)
+	assert.Equal(t, domain.AvailabilityBuffer{Before: 0, After: 10}, *rules.Buffer)
+
+	grid.AdjustBuffers(0, -10)
+	assert.Nil(t, grid.Rules(base).Buffer)
+}
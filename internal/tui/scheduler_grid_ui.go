@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var gridDayNames = [gridDays]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// gridEditorHelp lists the editor keys. Brackets are escaped so tview doesn't
+// read them as color tags.
+var gridEditorHelp = "[::b]←↑→↓[::-] move  [::b]space[::-] toggle  [::b]+/-[::-] duration  " +
+	"[::b]" + tview.Escape("[ ]") + "[::-] buffer before  [::b]{ }[::-] buffer after  [::b]s[::-] save  [::b]q[::-] cancel"
+
+// gridEditor renders an AvailabilityGrid as a table and applies key edits.
+type gridEditor struct {
+	grid   *AvailabilityGrid
+	styles *Styles
+	table  *tview.Table
+	status *tview.TextView
+	saved  bool
+}
+
+// EditAvailabilityGrid opens a full-screen weekly grid editor. It returns true
+// when the user saved; the grid is edited in place either way, so callers
+// must discard it on cancel.
+func EditAvailabilityGrid(title string, grid *AvailabilityGrid) (bool, error) {
+	app := tview.NewApplication()
+	e := newGridEditor(grid, DefaultStyles())
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(e.table, 0, 1, true).
+		AddItem(e.status, 3+min(len(grid.Kept), 1), 0, false)
+	layout.SetBorder(true).SetTitle(fmt.Sprintf(" %s ", title))
+
+	e.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			app.Stop()
+			return nil
+		case tcell.KeyCtrlS:
+			e.saved = true
+			app.Stop()
+			return nil
+		}
+		if event.Key() == tcell.KeyRune {
+			switch event.Rune() {
+			case 's':
+				e.saved = true
+				app.Stop()
+				return nil
+			case 'q':
+				app.Stop()
+				return nil
+			}
+		}
+		return e.handleKey(event)
+	})
+
+	// Start on Monday 09:00, the most common first edit.
+	e.table.Select(9*60/gridSlotMinutes+1, 2)
+
+	if err := app.SetRoot(layout, true).EnableMouse(true).Run(); err != nil {
+		return false, err
+	}
+	return e.saved, nil
+}
+
+func newGridEditor(grid *AvailabilityGrid, styles *Styles) *gridEditor {
+	e := &gridEditor{
+		grid:   grid,
+		styles: styles,
+		table:  tview.NewTable().SetSelectable(true, true).SetFixed(1, 1),
+		status: tview.NewTextView().SetDynamicColors(true),
+	}
+
+	e.table.SetSelectedStyle(tcell.StyleDefault.Background(styles.TableSelectBg).Foreground(styles.TableSelectFg))
+	e.table.SetSelectionChangedFunc(func(row, col int) {
+		// Keep the cursor off the header row and time column.
+		if row == 0 || col == 0 {
+			e.table.Select(max(row, 1), max(col, 1))
+		}
+	})
+
+	e.render()
+	return e
+}
+
+// handleKey applies editing keys; navigation keys fall through to the table.
+func (e *gridEditor) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	row, col := e.table.GetSelection()
+
+	if event.Key() == tcell.KeyEnter {
+		e.grid.Toggle(col-1, row-1)
+		e.render()
+		return nil
+	}
+	if event.Key() != tcell.KeyRune {
+		return event
+	}
+
+	switch event.Rune() {
+	case ' ':
+		e.grid.Toggle(col-1, row-1)
+	case '+', '=':
+		e.grid.AdjustDuration(5)
+	case '-', '_':
+		e.grid.AdjustDuration(-5)
+	case '[':
+		e.grid.AdjustBuffers(-5, 0)
+	case ']':
+		e.grid.AdjustBuffers(5, 0)
+	case '{':
+		e.grid.AdjustBuffers(0, -5)
+	case '}':
+		e.grid.AdjustBuffers(0, 5)
+	default:
+		return event
+	}
+	e.render()
+	return nil
+}
+
+func (e *gridEditor) render() {
+	headerStyle := tcell.StyleDefault.Foreground(e.styles.TableHeaderFg).Attributes(tcell.AttrBold)
+
+	e.table.SetCell(0, 0, tview.NewTableCell("").SetSelectable(false))
+	for day, name := range gridDayNames {
+		e.table.SetCell(0, day+1, tview.NewTableCell(fmt.Sprintf(" %s ", name)).
+			SetStyle(headerStyle).SetAlign(tview.AlignCenter).SetSelectable(false))
+	}
+
+	for slot := range gridSlots {
+		e.table.SetCell(slot+1, 0, tview.NewTableCell(formatClock(slot*gridSlotMinutes)+" ").
+			SetStyle(headerStyle).SetSelectable(false))
+		for day := range gridDays {
+			cell := tview.NewTableCell("  ·  ").SetAlign(tview.AlignCenter)
+			if e.grid.Cells[day][slot] {
+				cell.SetText(" ███ ").SetTextColor(e.styles.SuccessColor)
+			} else {
+				cell.SetTextColor(e.styles.BorderColor)
+			}
+			e.table.SetCell(slot+1, day+1, cell)
+		}
+	}
+
+	tz := e.grid.Timezone
+	if tz == "" {
+		tz = "config default"
+	}
+	status := fmt.Sprintf(
+		" Duration: [::b]%d min[::-]   Buffer before: [::b]%d min[::-]   Buffer after: [::b]%d min[::-]   Timezone: %s\n %s",
+		e.grid.DurationMinutes, e.grid.BufferBefore, e.grid.BufferAfter, tz, gridEditorHelp,
+	)
+	if n := len(e.grid.Kept); n > 0 {
+		status += fmt.Sprintf("\n [%s]Not shown, kept unchanged: %d open-hours entries the grid can't draw exactly[-]",
+			e.styles.Hex(e.styles.WarnColor), n)
+	}
+	e.status.SetText(status)
+}